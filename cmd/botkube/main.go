@@ -6,7 +6,10 @@ import (
 	"log"
 	"net/http"
 	"os"
+	"reflect"
+	"sort"
 	"time"
+	_ "time/tzdata" // embed time zone database used by channel notification schedules, as the image doesn't contain it
 
 	"github.com/google/go-github/v44/github"
 	"github.com/gorilla/mux"
@@ -28,29 +31,37 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/manager/signals"
 
 	"github.com/kubeshop/botkube/internal/analytics"
+	"github.com/kubeshop/botkube/internal/configcheck"
 	"github.com/kubeshop/botkube/internal/lifecycle"
+	"github.com/kubeshop/botkube/internal/loadtest"
 	"github.com/kubeshop/botkube/internal/storage"
-	"github.com/kubeshop/botkube/pkg/action"
+	"github.com/kubeshop/botkube/pkg/approval"
 	"github.com/kubeshop/botkube/pkg/bot"
+	"github.com/kubeshop/botkube/pkg/bot/identity"
 	"github.com/kubeshop/botkube/pkg/bot/interactive"
 	"github.com/kubeshop/botkube/pkg/config"
 	"github.com/kubeshop/botkube/pkg/controller"
+	"github.com/kubeshop/botkube/pkg/customresources"
 	"github.com/kubeshop/botkube/pkg/execute"
+	cmdaudit "github.com/kubeshop/botkube/pkg/execute/audit"
 	"github.com/kubeshop/botkube/pkg/execute/kubectl"
+	execplugin "github.com/kubeshop/botkube/pkg/execute/plugin"
 	"github.com/kubeshop/botkube/pkg/filterengine"
 	"github.com/kubeshop/botkube/pkg/httpsrv"
+	"github.com/kubeshop/botkube/pkg/hub"
 	"github.com/kubeshop/botkube/pkg/notifier"
-	"github.com/kubeshop/botkube/pkg/recommendation"
 	"github.com/kubeshop/botkube/pkg/sink"
-	"github.com/kubeshop/botkube/pkg/sources"
+	"github.com/kubeshop/botkube/pkg/sources/alertmanager"
+	"github.com/kubeshop/botkube/pkg/sources/audit"
 )
 
 const (
-	componentLogFieldKey = "component"
-	botLogFieldKey       = "bot"
-	sinkLogFieldKey      = "sink"
-	commGroupFieldKey    = "commGroup"
-	printAPIKeyCharCount = 3
+	componentLogFieldKey   = "component"
+	botLogFieldKey         = "bot"
+	sinkLogFieldKey        = "sink"
+	commGroupFieldKey      = "commGroup"
+	slackWorkspaceFieldKey = "workspace"
+	printAPIKeyCharCount   = 3
 )
 
 func main() {
@@ -63,6 +74,20 @@ func main() {
 func run() error {
 	// Load configuration
 	config.RegisterFlags(pflag.CommandLine)
+	loadtest.RegisterFlags(pflag.CommandLine)
+	configcheck.RegisterFlags(pflag.CommandLine)
+	pflag.Parse()
+
+	if configcheck.Enabled(pflag.Args()) {
+		// nothing to clean up yet, so exit directly with the code describing the results
+		os.Exit(configcheck.Run(context.Background(), os.Stdout, config.FromEnvOrFlag))
+	}
+
+	loadTestSpec, loadTestEnabled, err := loadtest.SpecFromFlag()
+	if err != nil {
+		return fmt.Errorf("while parsing synthetic events flag: %w", err)
+	}
+
 	conf, confDetails, err := config.LoadWithDefaults(config.FromEnvOrFlag)
 	if err != nil {
 		return fmt.Errorf("while loading app configuration: %w", err)
@@ -119,14 +144,58 @@ func run() error {
 	}
 
 	// Prometheus metrics
-	metricsSrv := newMetricsServer(logger.WithField(componentLogFieldKey, "Metrics server"), conf.Settings.MetricsPort)
+	// Loopback bots are registered once they are created
+	loopbacks := bot.NewLoopbackRegistry()
+	metricsSrv := newMetricsServer(logger.WithField(componentLogFieldKey, "Metrics server"), conf.Settings.MetricsPort, loopbacks)
 	errGroup.Go(func() error {
 		defer analytics.ReportPanicIfOccurs(logger, reporter)
 		return metricsSrv.Serve(ctx)
 	})
 
+	// Merge the Botkube custom resources
+	if conf.Settings.CustomResources.Enabled {
+		if err := mergeCustomResources(ctx, logger, dynamicCli, conf); err != nil {
+			return reportFatalError("while merging custom resources", err)
+		}
+	}
+
 	// Set up the filter engine
 	filterEngine := filterengine.WithAllFilters(logger, dynamicCli, mapper, conf.Filters)
+	err = filterEngine.SetPipelines(filterengine.PipelinesForSources(conf.Sources))
+	if err != nil {
+		return reportFatalError("while setting filter pipelines", err)
+	}
+
+	if loadTestEnabled {
+		// Synthetic objects are sent through the controller event pipeline to the no-op platform only.
+		// Actions are disabled, so no commands are executed for the synthetic objects.
+		platform := loadtest.NewNoopPlatform()
+		loadTestConf := *conf
+		loadTestConf.Actions = nil
+		loadTestPipeline := &eventPipeline{
+			logger:       logger,
+			reporter:     reporter,
+			k8sCli:       k8sCli,
+			dynamicCli:   dynamicCli,
+			mapper:       mapper,
+			filterEngine: filterEngine,
+			notifiers:    []notifier.Notifier{platform},
+		}
+		ctrl, _, err := loadTestPipeline.newController(&loadTestConf)
+		if err != nil {
+			return reportFatalError("while creating load test controller", err)
+		}
+
+		runner := loadtest.NewRunner(
+			logger.WithField(componentLogFieldKey, "Load test"),
+			loadtest.NewGenerator(),
+			ctrl,
+			platform,
+		)
+		report := runner.Run(ctx, loadTestSpec)
+		logger.Info(report.String())
+		return nil
+	}
 
 	// Kubectl config merger
 	kcMerger := kubectl.NewMerger(conf.Executors)
@@ -147,8 +216,90 @@ func run() error {
 	cmdGuard := kubectl.NewCommandGuard(logger.WithField(componentLogFieldKey, "Command Guard"), discoveryCli)
 	commander := kubectl.NewCommander(logger.WithField(componentLogFieldKey, "Commander"), kcMerger, cmdGuard)
 
+	// Persisted state writes are coordinated with a Lease named after the system ConfigMap
+	stateLease := storage.NewLease(conf.Settings.SystemConfigMap.Namespace, conf.Settings.SystemConfigMap.Name, k8sCli)
+
+	// Botkube runtime state is stored in a dedicated object, the state from the system ConfigMap is migrated automatically
+	stateStore := storage.NewStateStore(conf.Settings.SystemConfigMap.Namespace, conf.Settings.SystemConfigMap.Name, k8sCli, stateLease)
+	if err := stateStore.Migrate(ctx); err != nil {
+		return reportFatalError("while migrating Botkube state", err)
+	}
+
+	localizer, err := interactive.NewLocalizer()
+	if err != nil {
+		return reportFatalError("while creating localizer", err)
+	}
+	if conf.Settings.Locales.CatalogsDir != "" {
+		if err := localizer.LoadCatalogsFromDir(conf.Settings.Locales.CatalogsDir); err != nil {
+			return reportFatalError("while loading custom message catalogs", err)
+		}
+	}
+
+	// The hub forwards commands with the `--cluster` flag to the connected clusters
+	var hubSrv *hub.Server
+	if conf.Settings.Hub.Mode == config.HubServerMode {
+		hubSrv = hub.NewServer(ctx, logger.WithField(componentLogFieldKey, "Hub"), conf.Settings.Hub)
+	}
+
+	// Executor plugins are downloaded and started in the background. Commands sent before a plugin starts are rejected.
+	execPluginManager := execplugin.NewManager(logger.WithField(componentLogFieldKey, "Executor Plugins"), conf.Settings.Plugins, conf.Executors)
+	if execPluginManager.Enabled() {
+		errGroup.Go(func() error {
+			defer analytics.ReportPanicIfOccurs(logger, reporter)
+			return execPluginManager.Start(ctx)
+		})
+	}
+
+	// Commands over the concurrency limits wait in the queue, which is also used to report its status
+	var cmdQueue *execute.CommandQueue
+	if conf.Settings.Middlewares.Queue.Enabled {
+		cmdQueue = execute.NewCommandQueue(conf.Settings.Middlewares.Queue)
+	}
+
+	// Scheduled commands are persisted in the startup state, so they survive restarts
+	var scheduleStore *execute.ScheduleStore
+	if conf.Settings.CommandSchedule.Enabled {
+		scheduleStore, err = execute.NewScheduleStore(logger.WithField(componentLogFieldKey, "Schedule Store"), conf.Settings.CommandSchedule, conf.Schedules)
+		if err != nil {
+			return reportFatalError("while creating schedule store", err)
+		}
+	}
+
+	var (
+		notifiers []notifier.Notifier
+		bots      = map[string]bot.Bot{}
+		// channelReloaders apply the reloaded channel bindings to the running bots
+		channelReloaders []func(cfg *config.Config)
+		// notifierReloaders recreate the sinks with the reloaded configuration
+		notifierReloaders []func(cfg *config.Config) error
+	)
+
+	// Sources and channel bindings are reloaded without restart, if enabled. Handlers are registered once all components are created.
+	sendMsgToAllFn := func(msg string) error {
+		return notifier.SendPlaintextMessage(ctx, notifiers, msg)
+	}
+	// Changed custom resources are applied in the same way. If the hot reload is disabled, they restart Botkube.
+	var (
+		reloader       *lifecycle.Reloader
+		hotReloader    *lifecycle.Reloader
+		configReloader execute.ConfigReloader
+	)
+	restartFn := lifecycle.NewDeploymentRestartFn(logger.WithField(componentLogFieldKey, "Lifecycle server"), k8sCli, conf.Settings.LifecycleServer.Deployment, conf.Settings.ClusterName, sendMsgToAllFn)
+	if conf.ConfigWatcher.HotReload || conf.Settings.CustomResources.Enabled {
+		reloader = lifecycle.NewReloader(
+			logger.WithField(componentLogFieldKey, "Config Reloader"),
+			conf,
+			loadConfigFn(ctx, logger, dynamicCli),
+			restartFn,
+		)
+	}
+	if conf.ConfigWatcher.HotReload {
+		hotReloader = reloader
+		configReloader = reloader
+	}
+
 	// Create executor factory
-	cfgManager := config.NewManager(logger.WithField(componentLogFieldKey, "Config manager"), conf.Settings.PersistentConfig, k8sCli)
+	cfgManager := config.NewManager(logger.WithField(componentLogFieldKey, "Config manager"), conf.Settings.PersistentConfig, k8sCli, stateLease)
 	executorFactory := execute.NewExecutorFactory(
 		execute.DefaultExecutorFactoryParams{
 			Log:               logger.WithField(componentLogFieldKey, "Executor"),
@@ -161,15 +312,25 @@ func run() error {
 			AnalyticsReporter: reporter,
 			NamespaceLister:   k8sCli.CoreV1().Namespaces(),
 			CommandGuard:      cmdGuard,
+			Localizer:         localizer,
+			RemoteClusters:    remoteClusters(hubSrv),
+			K8sCli:            k8sCli,
+			DynamicCli:        dynamicCli,
+			AuditRecorder:     auditRecorder(logger, conf.Settings.CommandAudit, k8sCli),
+			PluginManager:     execPluginManager,
+			CommandQueue:      cmdQueue,
+			ScheduleStore:     scheduleStore,
+			ConfigReloader:    configReloader,
 		},
 	)
 
-	router := sources.NewRouter(mapper, dynamicCli, logger.WithField(componentLogFieldKey, "Router"))
+	// Email lookups are registered once the bots are created
+	identityResolver := identity.NewResolver(logger.WithField(componentLogFieldKey, "Identity Resolver"), conf.Settings.Identity)
+	userGroupResolver := identity.NewUserGroupResolver(logger.WithField(componentLogFieldKey, "User Group Resolver"))
 
-	var (
-		notifiers []notifier.Notifier
-		bots      = map[string]bot.Bot{}
-	)
+	// All commands received by bots go through the configured middlewares
+	approvals := approval.NewRegistry(logger.WithField(componentLogFieldKey, "Action Approvals"))
+	botExecutorFactory := bot.NewMiddlewareExecutorFactory(executorFactory, botMiddlewares(logger, conf, identityResolver, userGroupResolver, cmdQueue)...)
 
 	// TODO: Current limitation: Communication platform config should be separate inside every group:
 	//    For example, if in both communication groups there's a Slack configuration pointing to the same workspace,
@@ -177,76 +338,261 @@ func run() error {
 	//	  and the second "Sorry, this channel is not authorized to execute kubectl command" error.
 	for commGroupName, commGroupCfg := range conf.Communications {
 		commGroupLogger := logger.WithField(commGroupFieldKey, commGroupName)
+		groupName := commGroupName
+		onChannelsReload := func(reloadFn func(cfg config.Communications)) {
+			channelReloaders = append(channelReloaders, func(cfg *config.Config) {
+				reloadFn(cfg.Communications[groupName])
+			})
+		}
 
-		router.AddCommunicationsBindings(commGroupCfg)
-
-		scheduleBot := func(in bot.Bot) {
+		scheduleBotWithKey := func(key string, in bot.Bot) {
 			notifiers = append(notifiers, in)
-			bots[fmt.Sprintf("%s-%s", commGroupName, in.IntegrationName())] = in
+			bots[key] = in
 			errGroup.Go(func() error {
 				defer analytics.ReportPanicIfOccurs(commGroupLogger, reporter)
 				return in.Start(ctx)
 			})
 		}
+		// runSink runs the background work of a given sink, if any. The returned function stops it.
+		runSink := func(in sink.Sink) context.CancelFunc {
+			runner, ok := in.(sinkRunner)
+			if !ok {
+				return func() {}
+			}
+			runCtx, cancel := context.WithCancel(ctx)
+			errGroup.Go(func() error {
+				defer analytics.ReportPanicIfOccurs(commGroupLogger, reporter)
+				return runner.Run(runCtx)
+			})
+			return cancel
+		}
+		scheduleBot := func(in bot.Bot) {
+			scheduleBotWithKey(fmt.Sprintf("%s-%s", commGroupName, in.IntegrationName()), in)
+		}
+		scheduleSink := func(in sink.Sink) error {
+			if !conf.Settings.SinkRetry.Enabled {
+				notifiers = append(notifiers, in)
+				return nil
+			}
+
+			retryLogger := commGroupLogger.WithField(sinkLogFieldKey, fmt.Sprintf("%s retry queue", in.IntegrationName()))
+			retrying, err := sink.NewRetrying(retryLogger, fmt.Sprintf("%s-%s", commGroupName, in.IntegrationName()), in, conf.Settings.SinkRetry)
+			if err != nil {
+				return err
+			}
+			notifiers = append(notifiers, retrying)
+			errGroup.Go(func() error {
+				defer analytics.ReportPanicIfOccurs(commGroupLogger, reporter)
+				return retrying.Run(ctx)
+			})
+			return nil
+		}
 
 		// Run bots
 		if commGroupCfg.Slack.Enabled {
-			sb, err := bot.NewSlack(commGroupLogger.WithField(botLogFieldKey, "Slack"), commGroupName, commGroupCfg.Slack, executorFactory, reporter)
+			sb, err := bot.NewSlack(commGroupLogger.WithField(botLogFieldKey, "Slack"), commGroupName, commGroupCfg.Slack, botExecutorFactory, reporter)
 			if err != nil {
 				return reportFatalError("while creating Slack bot", err)
 			}
+			identityResolver.RegisterEmailLookup(sb.IntegrationName(), sb)
+			userGroupResolver.RegisterUserGroupLookup(sb.IntegrationName(), sb)
 			scheduleBot(sb)
+			onChannelsReload(func(cfg config.Communications) { sb.ReloadChannels(cfg.Slack) })
 		}
 
 		if commGroupCfg.SocketSlack.Enabled {
-			sb, err := bot.NewSocketSlack(commGroupLogger.WithField(botLogFieldKey, "SocketSlack"), commGroupName, commGroupCfg.SocketSlack, executorFactory, commander, reporter)
-			if err != nil {
-				return reportFatalError("while creating SocketSlack bot", err)
+			socketSlackCfg := commGroupCfg.SocketSlack
+			if socketSlackCfg.HasDefaultWorkspace() {
+				sb, err := bot.NewSocketSlack(commGroupLogger.WithField(botLogFieldKey, "SocketSlack"), commGroupName, socketSlackCfg, conf.Settings.ClusterName, botExecutorFactory, commander, reporter, approvals)
+				if err != nil {
+					return reportFatalError("while creating SocketSlack bot", err)
+				}
+				identityResolver.RegisterEmailLookup(sb.IntegrationName(), sb)
+				userGroupResolver.RegisterUserGroupLookup(sb.IntegrationName(), sb)
+				approvals.RegisterPoster(sb)
+				scheduleBot(sb)
+				// channels of the other workspaces are configured per workspace, so changing them requires restart
+				onChannelsReload(func(cfg config.Communications) { sb.ReloadChannels(cfg.SocketSlack) })
+			}
+
+			// each workspace has its own socket connection, while the executor factory and config manager are shared
+			for _, workspace := range socketSlackCfg.Workspaces {
+				if workspace.IsServedByOrgApp() {
+					continue // handled by the default connection
+				}
+				workspaceLogger := commGroupLogger.WithFields(logrus.Fields{botLogFieldKey: "SocketSlack", slackWorkspaceFieldKey: workspace.Name})
+				sb, err := bot.NewSocketSlack(workspaceLogger, commGroupName, socketSlackCfg.ForWorkspace(workspace), conf.Settings.ClusterName, botExecutorFactory, commander, reporter, approvals)
+				if err != nil {
+					return reportFatalError(fmt.Sprintf("while creating SocketSlack bot for workspace %q", workspace.Name), err)
+				}
+				identityResolver.RegisterEmailLookup(sb.IntegrationName(), sb)
+				userGroupResolver.RegisterUserGroupLookup(sb.IntegrationName(), sb)
+				approvals.RegisterPoster(sb)
+				scheduleBotWithKey(fmt.Sprintf("%s-%s-%s", commGroupName, sb.IntegrationName(), workspace.Name), sb)
 			}
-			scheduleBot(sb)
 		}
 
 		if commGroupCfg.Mattermost.Enabled {
-			mb, err := bot.NewMattermost(commGroupLogger.WithField(botLogFieldKey, "Mattermost"), commGroupName, commGroupCfg.Mattermost, executorFactory, reporter)
+			mb, err := bot.NewMattermost(commGroupLogger.WithField(botLogFieldKey, "Mattermost"), commGroupName, commGroupCfg.Mattermost, botExecutorFactory, reporter)
 			if err != nil {
 				return reportFatalError("while creating Mattermost bot", err)
 			}
 			scheduleBot(mb)
+			onChannelsReload(func(cfg config.Communications) { mb.ReloadChannels(cfg.Mattermost) })
 		}
 
 		if commGroupCfg.Teams.Enabled {
-			tb, err := bot.NewTeams(commGroupLogger.WithField(botLogFieldKey, "MS Teams"), commGroupName, commGroupCfg.Teams, conf.Settings.ClusterName, executorFactory, reporter)
+			tb, err := bot.NewTeams(commGroupLogger.WithField(botLogFieldKey, "MS Teams"), commGroupName, commGroupCfg.Teams, conf.Settings.ClusterName, botExecutorFactory, commander, reporter)
 			if err != nil {
 				return reportFatalError("while creating Teams bot", err)
 			}
 			scheduleBot(tb)
+			onChannelsReload(func(cfg config.Communications) { tb.ReloadChannels(cfg.Teams) })
 		}
 
 		if commGroupCfg.Discord.Enabled {
-			db, err := bot.NewDiscord(commGroupLogger.WithField(botLogFieldKey, "Discord"), commGroupName, commGroupCfg.Discord, executorFactory, reporter)
+			db, err := bot.NewDiscord(commGroupLogger.WithField(botLogFieldKey, "Discord"), commGroupName, commGroupCfg.Discord, botExecutorFactory, reporter)
 			if err != nil {
 				return reportFatalError("while creating Discord bot", err)
 			}
 			scheduleBot(db)
+			onChannelsReload(func(cfg config.Communications) { db.ReloadChannels(cfg.Discord) })
 		}
 
-		// Run sinks
-		if commGroupCfg.Elasticsearch.Enabled {
-			es, err := sink.NewElasticsearch(commGroupLogger.WithField(sinkLogFieldKey, "Elasticsearch"), commGroupCfg.Elasticsearch, reporter)
+		if commGroupCfg.RocketChat.Enabled {
+			rb, err := bot.NewRocketChat(ctx, commGroupLogger.WithField(botLogFieldKey, "Rocket.Chat"), commGroupName, commGroupCfg.RocketChat, botExecutorFactory, reporter)
+			if err != nil {
+				return reportFatalError("while creating Rocket.Chat bot", err)
+			}
+			scheduleBot(rb)
+			onChannelsReload(func(cfg config.Communications) { rb.ReloadChannels(cfg.RocketChat) })
+		}
+
+		if commGroupCfg.GoogleChat.Enabled {
+			gb, err := bot.NewGoogleChat(ctx, commGroupLogger.WithField(botLogFieldKey, "Google Chat"), commGroupName, commGroupCfg.GoogleChat, botExecutorFactory, reporter)
+			if err != nil {
+				return reportFatalError("while creating Google Chat bot", err)
+			}
+			scheduleBot(gb)
+			onChannelsReload(func(cfg config.Communications) { gb.ReloadChannels(cfg.GoogleChat) })
+		}
+
+		if commGroupCfg.Webex.Enabled {
+			wb, err := bot.NewWebex(ctx, commGroupLogger.WithField(botLogFieldKey, "Webex"), commGroupName, commGroupCfg.Webex, botExecutorFactory, reporter)
+			if err != nil {
+				return reportFatalError("while creating Webex bot", err)
+			}
+			scheduleBot(wb)
+			onChannelsReload(func(cfg config.Communications) { wb.ReloadChannels(cfg.Webex) })
+		}
+
+		if commGroupCfg.Matrix.Enabled {
+			mxb, err := bot.NewMatrix(ctx, commGroupLogger.WithField(botLogFieldKey, "Matrix"), commGroupName, commGroupCfg.Matrix, botExecutorFactory, reporter)
+			if err != nil {
+				return reportFatalError("while creating Matrix bot", err)
+			}
+			scheduleBot(mxb)
+			onChannelsReload(func(cfg config.Communications) { mxb.ReloadChannels(cfg.Matrix) })
+		}
+
+		if commGroupCfg.Loopback.Enabled {
+			lb, err := bot.NewLoopback(commGroupLogger.WithField(botLogFieldKey, "Loopback"), commGroupCfg.Loopback, reporter)
 			if err != nil {
-				return reportFatalError("while creating Elasticsearch sink", err)
+				return reportFatalError("while creating Loopback bot", err)
 			}
-			notifiers = append(notifiers, es)
+			scheduleBot(lb)
+			loopbacks.Register(commGroupName, lb)
+			onChannelsReload(func(cfg config.Communications) { lb.ReloadChannels(cfg.Loopback) })
 		}
 
-		if commGroupCfg.Webhook.Enabled {
-			wh, err := sink.NewWebhook(commGroupLogger.WithField(sinkLogFieldKey, "Webhook"), commGroupCfg.Webhook, reporter)
+		// Run sinks
+		for _, factory := range sinkFactories {
+			if !factory.enabled(commGroupCfg) {
+				continue
+			}
+
+			factory := factory
+			sinkLogger := commGroupLogger.WithField(sinkLogFieldKey, factory.displayName)
+			created, err := factory.create(ctx, sinkLogger, commGroupCfg, reporter)
 			if err != nil {
-				return reportFatalError("while creating Webhook sink", err)
+				return reportFatalError(fmt.Sprintf("while creating %s sink", factory.displayName), err)
+			}
+
+			reloadable := sink.NewReloadable(created)
+			if err := scheduleSink(reloadable); err != nil {
+				return reportFatalError(fmt.Sprintf("while creating %s sink retry queue", factory.displayName), err)
 			}
+			stopFn := runSink(created)
+
+			// the changed sink is recreated, while its retry queue keeps the pending events
+			currentCfg := factory.cfg(commGroupCfg)
+			notifierReloaders = append(notifierReloaders, func(cfg *config.Config) error {
+				reloadedCfg := factory.cfg(cfg.Communications[groupName])
+				if reflect.DeepEqual(currentCfg, reloadedCfg) {
+					return nil
+				}
+
+				reloaded, err := factory.create(ctx, sinkLogger, cfg.Communications[groupName], reporter)
+				if err != nil {
+					return fmt.Errorf("while creating %s sink: %w", factory.displayName, err)
+				}
+				reloadable.Swap(reloaded)
+				stopFn()
+				stopFn = runSink(reloaded)
+				currentCfg = reloadedCfg
+				return nil
+			})
+		}
+	}
 
-			notifiers = append(notifiers, wh)
+	if scheduleStore != nil {
+		var runners []bot.ScheduledCommandRunner
+		for _, b := range bots {
+			if runner, ok := b.(bot.ScheduledCommandRunner); ok {
+				runners = append(runners, runner)
+			}
 		}
+		scheduledCmds := bot.NewScheduledCommands(logger.WithField(componentLogFieldKey, "Scheduled Commands"), scheduleStore, runners)
+		errGroup.Go(func() error {
+			defer analytics.ReportPanicIfOccurs(logger, reporter)
+			return scheduledCmds.Start(ctx)
+		})
+	}
+
+	// Multi-cluster setup
+	switch conf.Settings.Hub.Mode {
+	case config.HubServerMode:
+		dispatcher := notifier.NewDispatcher(logger, reporter, notifiers, conf.Settings.NotifierTimeout)
+		srv := httpsrv.New(
+			logger.WithField(componentLogFieldKey, "Hub server"),
+			fmt.Sprintf(":%d", conf.Settings.Hub.Port),
+			hubSrv.Handler(dispatcher),
+		)
+		errGroup.Go(func() error {
+			defer analytics.ReportPanicIfOccurs(logger, reporter)
+			return srv.Serve(ctx)
+		})
+	case config.HubAgentMode:
+		agent := hub.NewAgent(logger.WithField(componentLogFieldKey, "Hub agent"), conf.Settings.Hub, conf.Settings.ClusterName, botExecutorFactory)
+		notifiers = append(notifiers, agent)
+		errGroup.Go(func() error {
+			defer analytics.ReportPanicIfOccurs(logger, reporter)
+			return agent.Start(ctx)
+		})
+	}
+
+	// Restart to use the rotated secrets
+	if confDetails.Secrets != nil && conf.Settings.Secrets.RotationCheckInterval > 0 {
+		errGroup.Go(func() error {
+			defer analytics.ReportPanicIfOccurs(logger, reporter)
+			return config.WatchSecretsRotation(
+				ctx,
+				logger.WithField(componentLogFieldKey, "Secrets Rotation Watcher"),
+				confDetails.Secrets,
+				conf.Settings.Secrets.RotationCheckInterval,
+				restartFn,
+			)
+		})
 	}
 
 	// Lifecycle server
@@ -256,9 +602,8 @@ func run() error {
 			k8sCli,
 			conf.Settings.LifecycleServer,
 			conf.Settings.ClusterName,
-			func(msg string) error {
-				return notifier.SendPlaintextMessage(ctx, notifiers, msg)
-			},
+			sendMsgToAllFn,
+			hotReloader,
 		)
 		errGroup.Go(func() error {
 			defer analytics.ReportPanicIfOccurs(logger, reporter)
@@ -283,7 +628,7 @@ func run() error {
 	}
 
 	// Send help message
-	helpDB := storage.NewForHelp(conf.Settings.SystemConfigMap.Namespace, conf.Settings.SystemConfigMap.Name, k8sCli)
+	helpDB := storage.NewForHelp(stateStore)
 	err = sendHelp(ctx, helpDB, conf.Settings.ClusterName, bots)
 	if err != nil {
 		return fmt.Errorf("while sending initial help message: %w", err)
@@ -305,30 +650,41 @@ func run() error {
 		})
 	}
 
-	recommFactory := recommendation.NewFactory(logger.WithField(componentLogFieldKey, "Recommendations"), dynamicCli)
-
-	actionProvider := action.NewProvider(logger.WithField(componentLogFieldKey, "Action Provider"), conf.Actions, executorFactory)
-	router.AddEnabledActionBindings(conf.Actions)
-
-	// Create and start controller
-	ctrl := controller.New(
-		logger.WithField(componentLogFieldKey, "Controller"),
-		conf,
-		notifiers,
-		recommFactory,
-		filterEngine,
-		dynamicCli,
-		mapper,
-		conf.Settings.InformersResyncPeriod,
-		router.BuildTable(conf),
-		actionProvider,
-		reporter,
-	)
-
-	err = ctrl.Start(ctx)
-	if err != nil {
-		return reportFatalError("while starting controller", err)
+	pipeline := &eventPipeline{
+		logger:          logger,
+		reporter:        reporter,
+		k8sCli:          k8sCli,
+		dynamicCli:      dynamicCli,
+		mapper:          mapper,
+		filterEngine:    filterEngine,
+		notifiers:       notifiers,
+		executorFactory: executorFactory,
+		approvals:       approvals,
+		restartCh:       make(chan *config.Config),
+	}
+	if hotReloader != nil {
+		registerReloadHandlers(hotReloader, pipeline, filterEngine, executorFactory, channelReloaders, notifierReloaders)
+	}
+	if conf.Settings.CustomResources.Enabled {
+		crWatcher := customresources.NewWatcher(
+			logger.WithField(componentLogFieldKey, "Custom Resources Watcher"),
+			dynamicCli,
+			mapper,
+			conf.Settings.InformersResyncPeriod,
+			reloadOnChangeFn(logger, reloader),
+		)
+		errGroup.Go(func() error {
+			defer analytics.ReportPanicIfOccurs(logger, reporter)
+			return crWatcher.Start(ctx)
+		})
 	}
+	errGroup.Go(func() error {
+		defer analytics.ReportPanicIfOccurs(logger, reporter)
+		if err := pipeline.Run(ctx, conf); err != nil {
+			return fmt.Errorf("while running event pipeline: %w", err)
+		}
+		return nil
+	})
 
 	err = errGroup.Wait()
 	if err != nil {
@@ -338,6 +694,83 @@ func run() error {
 	return nil
 }
 
+// loadConfigFn returns a function which loads the configuration from the same sources as on startup.
+func loadConfigFn(ctx context.Context, logger logrus.FieldLogger, dynamicCli dynamic.Interface) lifecycle.LoadConfigFn {
+	return func() (*config.Config, error) {
+		conf, confDetails, err := config.LoadWithDefaults(config.FromEnvOrFlag)
+		if err != nil {
+			return nil, err
+		}
+		if confDetails.ValidateWarnings != nil {
+			logger.Warnf("Configuration validation warnings: %v", confDetails.ValidateWarnings.Error())
+		}
+		if conf.Settings.CustomResources.Enabled {
+			if err := mergeCustomResources(ctx, logger, dynamicCli, conf); err != nil {
+				return nil, err
+			}
+		}
+		return conf, nil
+	}
+}
+
+// mergeCustomResources merges the Botkube custom resources into a given configuration.
+// The invalid resources are skipped, so they don't affect the other ones.
+func mergeCustomResources(ctx context.Context, logger logrus.FieldLogger, dynamicCli dynamic.Interface, conf *config.Config) error {
+	resources, err := customresources.Load(ctx, dynamicCli)
+	if err != nil {
+		return fmt.Errorf("while loading custom resources: %w", err)
+	}
+	if err := customresources.Merge(conf, resources); err != nil {
+		logger.Warnf("Skipped invalid custom resources: %s", err.Error())
+	}
+	return nil
+}
+
+// reloadOnChangeFn returns a function which reloads the configuration when the custom resources changed.
+func reloadOnChangeFn(logger logrus.FieldLogger, reloader *lifecycle.Reloader) customresources.OnChangeFn {
+	return func(ctx context.Context) {
+		diff, err := reloader.Reload(ctx)
+		if err != nil {
+			logger.Errorf("while reloading configuration with changed custom resources: %s", err.Error())
+			return
+		}
+		if !diff.IsEmpty() {
+			logger.WithField("components", diff.Components).Info("Applied changed custom resources.")
+		}
+	}
+}
+
+// registerReloadHandlers registers the handlers which apply the reloaded sources, channel bindings and sinks.
+// The filter pipelines, executors and bot channels are updated before the event pipeline is restarted with the new bindings.
+// The sinks are recreated in place, so the event pipeline keeps using them.
+func registerReloadHandlers(reloader *lifecycle.Reloader, pipeline *eventPipeline, filterEngine filterengine.FilterEngine, executorFactory *execute.DefaultExecutorFactory, channelReloaders []func(cfg *config.Config), notifierReloaders []func(cfg *config.Config) error) {
+	reloader.RegisterHandler(func(_ context.Context, cfg *config.Config) error {
+		if err := filterEngine.SetPipelines(filterengine.PipelinesForSources(cfg.Sources)); err != nil {
+			return fmt.Errorf("while setting filter pipelines: %w", err)
+		}
+		return nil
+	}, config.SourcesReloadComponent)
+	reloader.RegisterHandler(func(_ context.Context, cfg *config.Config) error {
+		for _, reloadFn := range channelReloaders {
+			reloadFn(cfg)
+		}
+		return nil
+	}, config.ChannelsReloadComponent)
+	reloader.RegisterHandler(func(_ context.Context, cfg *config.Config) error {
+		for _, reloadFn := range notifierReloaders {
+			if err := reloadFn(cfg); err != nil {
+				return err
+			}
+		}
+		return nil
+	}, config.NotifiersReloadComponent)
+	reloader.RegisterHandler(func(_ context.Context, cfg *config.Config) error {
+		executorFactory.ReloadConfig(*cfg)
+		return nil
+	}, config.SourcesReloadComponent, config.ChannelsReloadComponent, config.NotifiersReloadComponent)
+	reloader.RegisterHandler(pipeline.Restart, config.SourcesReloadComponent, config.ChannelsReloadComponent)
+}
+
 func newLogger(logLevelStr string, logDisableColors bool) *logrus.Logger {
 	logger := logrus.New()
 	// Output to stdout instead of the default stderr
@@ -355,13 +788,89 @@ func newLogger(logLevelStr string, logDisableColors bool) *logrus.Logger {
 	return logger
 }
 
-func newMetricsServer(log logrus.FieldLogger, metricsPort string) *httpsrv.Server {
+func newMetricsServer(log logrus.FieldLogger, metricsPort string, loopbacks *bot.LoopbackRegistry) *httpsrv.Server {
 	addr := fmt.Sprintf(":%s", metricsPort)
 	router := mux.NewRouter()
 	router.Handle("/metrics", promhttp.Handler())
+	router.Handle(bot.LoopbackMessagesPath, loopbacks).Methods(http.MethodGet)
+	return httpsrv.New(log, addr, router)
+}
+
+func newSourceServer(log logrus.FieldLogger, cfg config.SourceServer, alertmanagerReceiver *alertmanager.Receiver, auditReceiver *audit.Receiver) *httpsrv.Server {
+	addr := fmt.Sprintf(":%d", cfg.Port)
+	router := mux.NewRouter()
+	router.Handle(alertmanager.Path, sourceServerAuth(cfg, alertmanagerReceiver))
+	router.Handle(audit.Path, sourceServerAuth(cfg, auditReceiver))
 	return httpsrv.New(log, addr, router)
 }
 
+// sourceServerAuth requires the bearer token in the source server requests, if it's specified.
+func sourceServerAuth(cfg config.SourceServer, next http.Handler) http.Handler {
+	if cfg.BearerToken == "" {
+		return next
+	}
+	return httpsrv.BearerAuth(cfg.BearerToken, next)
+}
+
+// remoteClusters returns the hub server as execute.RemoteClusters, so the executor doesn't get a nil pointer in an interface.
+func remoteClusters(hubSrv *hub.Server) execute.RemoteClusters {
+	if hubSrv == nil {
+		return nil
+	}
+	return hubSrv
+}
+
+func auditRecorder(logger logrus.FieldLogger, cfg config.CommandAudit, k8sCli kubernetes.Interface) execute.AuditRecorder {
+	if !cfg.Enabled {
+		return nil
+	}
+	return cmdaudit.NewRecorder(logger.WithField(componentLogFieldKey, "Command Audit"), cfg, k8sCli)
+}
+
+// isRBACEnabled returns true if any executor restricts the commands with RBAC rules.
+func isRBACEnabled(executors map[string]config.Executors) bool {
+	for _, executor := range executors {
+		if executor.RBAC.Enabled {
+			return true
+		}
+	}
+	return false
+}
+
+// sortedKeys returns the sorted source names.
+func sortedKeys(sources map[string]config.Sources) []string {
+	names := make([]string, 0, len(sources))
+	for name := range sources {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func botMiddlewares(logger logrus.FieldLogger, conf *config.Config, identityResolver *identity.Resolver, userGroupResolver *identity.UserGroupResolver, cmdQueue *execute.CommandQueue) []bot.Middleware {
+	var middlewares []bot.Middleware
+	// identity and user groups are resolved first, so the other middlewares can use them
+	if conf.Settings.Identity.Enabled {
+		middlewares = append(middlewares, bot.NewIdentityMiddleware(logger.WithField(componentLogFieldKey, "Identity"), identityResolver))
+	}
+	if isRBACEnabled(conf.Executors) {
+		middlewares = append(middlewares, bot.NewUserGroupsMiddleware(logger.WithField(componentLogFieldKey, "User Groups"), userGroupResolver))
+	}
+
+	cfg := conf.Settings.Middlewares
+	if cfg.Audit.Enabled {
+		middlewares = append(middlewares, bot.NewAuditMiddleware(logger.WithField(componentLogFieldKey, "Audit")))
+	}
+	if cfg.RateLimit.Enabled {
+		middlewares = append(middlewares, bot.NewRateLimitMiddleware(logger.WithField(componentLogFieldKey, "Rate Limiter"), cfg.RateLimit.MaxCommands, cfg.RateLimit.Interval))
+	}
+	// the queue is the last one, so the commands rejected by the other middlewares don't wait in it
+	if cmdQueue != nil {
+		middlewares = append(middlewares, bot.NewQueueMiddleware(logger.WithField(componentLogFieldKey, "Command Queue"), *conf, cmdQueue))
+	}
+	return middlewares
+}
+
 func newAnalyticsReporter(disableAnalytics bool, logger logrus.FieldLogger) (analytics.Reporter, error) {
 	if disableAnalytics {
 		logger.Info("Analytics disabled via configuration settings.")
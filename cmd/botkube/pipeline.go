@@ -0,0 +1,385 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+	"golang.org/x/sync/errgroup"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/kubeshop/botkube/internal/analytics"
+	"github.com/kubeshop/botkube/pkg/action"
+	"github.com/kubeshop/botkube/pkg/approval"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/containerlogs"
+	"github.com/kubeshop/botkube/pkg/controller"
+	"github.com/kubeshop/botkube/pkg/dedup"
+	"github.com/kubeshop/botkube/pkg/execute"
+	"github.com/kubeshop/botkube/pkg/filterengine"
+	"github.com/kubeshop/botkube/pkg/notifier"
+	"github.com/kubeshop/botkube/pkg/recommendation"
+	"github.com/kubeshop/botkube/pkg/redaction"
+	"github.com/kubeshop/botkube/pkg/sources"
+	"github.com/kubeshop/botkube/pkg/sources/alertmanager"
+	"github.com/kubeshop/botkube/pkg/sources/argocd"
+	"github.com/kubeshop/botkube/pkg/sources/audit"
+	"github.com/kubeshop/botkube/pkg/sources/certmanager"
+	"github.com/kubeshop/botkube/pkg/sources/flux"
+	"github.com/kubeshop/botkube/pkg/sources/helm"
+	"github.com/kubeshop/botkube/pkg/sources/jobs"
+	"github.com/kubeshop/botkube/pkg/sources/nodes"
+	"github.com/kubeshop/botkube/pkg/sources/plugin"
+	"github.com/kubeshop/botkube/pkg/sources/podcrashes"
+	"github.com/kubeshop/botkube/pkg/sources/pvcusage"
+	"github.com/kubeshop/botkube/pkg/sources/trivy"
+	"github.com/kubeshop/botkube/pkg/sources/velero"
+)
+
+// eventPipeline runs the components which deliver events to the notifiers: the controller, the event sources and
+// the source server. The pipeline is restarted to apply the reloaded sources and channel bindings, while the bots
+// and sinks keep running, so they don't need to reconnect.
+type eventPipeline struct {
+	logger          *logrus.Logger
+	reporter        analytics.Reporter
+	k8sCli          kubernetes.Interface
+	dynamicCli      dynamic.Interface
+	mapper          meta.RESTMapper
+	filterEngine    filterengine.FilterEngine
+	notifiers       []notifier.Notifier
+	executorFactory *execute.DefaultExecutorFactory
+	approvals       *approval.Registry
+
+	restartCh chan *config.Config
+
+	ctrlMutex sync.Mutex
+	ctrl      *controller.Controller
+}
+
+// Run runs the event pipeline until the context is canceled. The pipeline is restarted with a new configuration on Restart.
+func (p *eventPipeline) Run(ctx context.Context, conf *config.Config) error {
+	restarted := false
+	for {
+		runCtx, cancelFn := context.WithCancel(ctx)
+		errCh := make(chan error, 1)
+		go func(conf *config.Config, restarted bool) {
+			defer analytics.ReportPanicIfOccurs(p.logger, p.reporter)
+			errCh <- p.run(runCtx, conf, restarted)
+		}(conf, restarted)
+
+		select {
+		case err := <-errCh:
+			cancelFn()
+			return err
+		case conf = <-p.restartCh:
+			p.logger.Info("Restarting event pipeline...")
+			p.disableFinalMessage()
+			cancelFn()
+			if err := <-errCh; err != nil {
+				return err
+			}
+			restarted = true
+		}
+	}
+}
+
+// Restart stops the running pipeline and starts it again with a given configuration.
+func (p *eventPipeline) Restart(ctx context.Context, conf *config.Config) error {
+	select {
+	case p.restartCh <- conf:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (p *eventPipeline) run(ctx context.Context, conf *config.Config, restarted bool) error {
+	errGroup, ctx := errgroup.WithContext(ctx)
+
+	ctrl, router, err := p.newController(conf)
+	if err != nil {
+		return err
+	}
+
+	// Sources receiving events from external systems
+	alertmanagerReceiver := alertmanager.NewReceiver(
+		ctx,
+		p.logger.WithField(componentLogFieldKey, "Alertmanager Source"),
+		conf.Settings.ClusterName,
+		router.GetBoundSources(conf.Sources),
+		ctrl,
+	)
+	auditReceiver := audit.NewReceiver(
+		ctx,
+		p.logger.WithField(componentLogFieldKey, "Audit Source"),
+		conf.Settings.ClusterName,
+		router.GetBoundSources(conf.Sources),
+		ctrl,
+	)
+	if conf.Settings.SourceServer.Enabled {
+		sourceSrv := newSourceServer(p.logger.WithField(componentLogFieldKey, "Source server"), conf.Settings.SourceServer, alertmanagerReceiver, auditReceiver)
+		errGroup.Go(func() error {
+			defer analytics.ReportPanicIfOccurs(p.logger, p.reporter)
+			return sourceSrv.Serve(ctx)
+		})
+	} else {
+		if alertmanagerReceiver.Enabled() {
+			p.logger.Warn("Alertmanager source is enabled, but the source server is disabled. Alerts won't be received.")
+		}
+		if auditReceiver.Enabled() {
+			p.logger.Warn("Audit source is enabled, but the source server is disabled. Audit events won't be received.")
+		}
+	}
+
+	argoCDWatcher := argocd.NewWatcher(
+		p.logger.WithField(componentLogFieldKey, "Argo CD Source"),
+		conf.Settings.ClusterName,
+		p.dynamicCli,
+		p.mapper,
+		conf.Settings.InformersResyncPeriod,
+		router.GetBoundSources(conf.Sources),
+		ctrl,
+	)
+	if argoCDWatcher.Enabled() {
+		errGroup.Go(func() error {
+			defer analytics.ReportPanicIfOccurs(p.logger, p.reporter)
+			return argoCDWatcher.Start(ctx)
+		})
+	}
+
+	helmWatcher := helm.NewWatcher(
+		p.logger.WithField(componentLogFieldKey, "Helm Source"),
+		conf.Settings.ClusterName,
+		p.dynamicCli,
+		conf.Settings.InformersResyncPeriod,
+		router.GetBoundSources(conf.Sources),
+		ctrl,
+	)
+	if helmWatcher.Enabled() {
+		errGroup.Go(func() error {
+			defer analytics.ReportPanicIfOccurs(p.logger, p.reporter)
+			return helmWatcher.Start(ctx)
+		})
+	}
+
+	certManagerWatcher := certmanager.NewWatcher(
+		p.logger.WithField(componentLogFieldKey, "cert-manager Source"),
+		conf.Settings.ClusterName,
+		p.dynamicCli,
+		p.mapper,
+		conf.Settings.InformersResyncPeriod,
+		router.GetBoundSources(conf.Sources),
+		ctrl,
+	)
+	if certManagerWatcher.Enabled() {
+		errGroup.Go(func() error {
+			defer analytics.ReportPanicIfOccurs(p.logger, p.reporter)
+			return certManagerWatcher.Start(ctx)
+		})
+	}
+
+	jobsWatcher := jobs.NewWatcher(
+		p.logger.WithField(componentLogFieldKey, "Jobs Source"),
+		conf.Settings.ClusterName,
+		p.k8sCli,
+		conf.Settings.InformersResyncPeriod,
+		router.GetBoundSources(conf.Sources),
+		ctrl,
+	)
+	if jobsWatcher.Enabled() {
+		errGroup.Go(func() error {
+			defer analytics.ReportPanicIfOccurs(p.logger, p.reporter)
+			return jobsWatcher.Start(ctx)
+		})
+	}
+
+	nodesMonitor := nodes.NewMonitor(
+		p.logger.WithField(componentLogFieldKey, "Nodes Source"),
+		conf.Settings.ClusterName,
+		p.k8sCli,
+		router.GetBoundSources(conf.Sources),
+		ctrl,
+	)
+	if nodesMonitor.Enabled() {
+		errGroup.Go(func() error {
+			defer analytics.ReportPanicIfOccurs(p.logger, p.reporter)
+			return nodesMonitor.Start(ctx)
+		})
+	}
+
+	podCrashesWatcher := podcrashes.NewWatcher(
+		p.logger.WithField(componentLogFieldKey, "Pod Crashes Source"),
+		conf.Settings.ClusterName,
+		p.k8sCli,
+		conf.Settings.InformersResyncPeriod,
+		router.GetBoundSources(conf.Sources),
+		ctrl,
+	)
+	if podCrashesWatcher.Enabled() {
+		errGroup.Go(func() error {
+			defer analytics.ReportPanicIfOccurs(p.logger, p.reporter)
+			return podCrashesWatcher.Start(ctx)
+		})
+	}
+
+	veleroWatcher := velero.NewWatcher(
+		p.logger.WithField(componentLogFieldKey, "Velero Source"),
+		conf.Settings.ClusterName,
+		p.dynamicCli,
+		p.mapper,
+		conf.Settings.InformersResyncPeriod,
+		router.GetBoundSources(conf.Sources),
+		ctrl,
+	)
+	if veleroWatcher.Enabled() {
+		errGroup.Go(func() error {
+			defer analytics.ReportPanicIfOccurs(p.logger, p.reporter)
+			return veleroWatcher.Start(ctx)
+		})
+	}
+
+	fluxWatcher := flux.NewWatcher(
+		p.logger.WithField(componentLogFieldKey, "Flux Source"),
+		conf.Settings.ClusterName,
+		p.dynamicCli,
+		p.mapper,
+		conf.Settings.InformersResyncPeriod,
+		router.GetBoundSources(conf.Sources),
+		ctrl,
+	)
+	if fluxWatcher.Enabled() {
+		errGroup.Go(func() error {
+			defer analytics.ReportPanicIfOccurs(p.logger, p.reporter)
+			return fluxWatcher.Start(ctx)
+		})
+	}
+
+	pvcUsageMonitor := pvcusage.NewMonitor(
+		p.logger.WithField(componentLogFieldKey, "PVC Usage Source"),
+		conf.Settings.ClusterName,
+		p.k8sCli,
+		router.GetBoundSources(conf.Sources),
+		ctrl,
+	)
+	if pvcUsageMonitor.Enabled() {
+		errGroup.Go(func() error {
+			defer analytics.ReportPanicIfOccurs(p.logger, p.reporter)
+			return pvcUsageMonitor.Start(ctx)
+		})
+	}
+
+	trivyWatcher := trivy.NewWatcher(
+		p.logger.WithField(componentLogFieldKey, "Trivy Source"),
+		conf.Settings.ClusterName,
+		p.dynamicCli,
+		p.mapper,
+		conf.Settings.InformersResyncPeriod,
+		router.GetBoundSources(conf.Sources),
+		ctrl,
+	)
+	if trivyWatcher.Enabled() {
+		errGroup.Go(func() error {
+			defer analytics.ReportPanicIfOccurs(p.logger, p.reporter)
+			return trivyWatcher.Start(ctx)
+		})
+	}
+
+	pluginManager, err := plugin.NewManager(
+		p.logger.WithField(componentLogFieldKey, "Source Plugins"),
+		conf.Settings.ClusterName,
+		conf.Settings.Plugins,
+		router.GetBoundSources(conf.Sources),
+		ctrl,
+	)
+	if err != nil {
+		return fmt.Errorf("while creating source plugins manager: %w", err)
+	}
+	if pluginManager.Enabled() {
+		errGroup.Go(func() error {
+			defer analytics.ReportPanicIfOccurs(p.logger, p.reporter)
+			return pluginManager.Start(ctx)
+		})
+	}
+
+	if restarted {
+		ctrl.DisableWelcomeMessage()
+	}
+	p.setController(ctrl)
+	errGroup.Go(func() error {
+		defer analytics.ReportPanicIfOccurs(p.logger, p.reporter)
+		if err := ctrl.Start(ctx); err != nil {
+			return fmt.Errorf("while starting controller: %w", err)
+		}
+		return nil
+	})
+
+	return errGroup.Wait()
+}
+
+func (p *eventPipeline) setController(ctrl *controller.Controller) {
+	p.ctrlMutex.Lock()
+	defer p.ctrlMutex.Unlock()
+	p.ctrl = ctrl
+}
+
+// disableFinalMessage disables the final message of the running controller, as the pipeline is restarted, not stopped.
+func (p *eventPipeline) disableFinalMessage() {
+	p.ctrlMutex.Lock()
+	defer p.ctrlMutex.Unlock()
+	if p.ctrl != nil {
+		p.ctrl.DisableFinalMessage()
+	}
+}
+
+// newController returns the controller which routes, filters and sends the events with a given configuration,
+// together with the router used to find the source bindings.
+func (p *eventPipeline) newController(conf *config.Config) (*controller.Controller, *sources.Router, error) {
+	router := sources.NewRouter(p.mapper, p.dynamicCli, p.logger.WithField(componentLogFieldKey, "Router"))
+	for _, commGroupCfg := range conf.Communications {
+		router.AddCommunicationsBindings(commGroupCfg)
+	}
+	if conf.Settings.Hub.Mode == config.HubAgentMode {
+		// all sources are forwarded to the hub, which routes them to its channels
+		router.AddBindings(config.BotBindings{Sources: sortedKeys(conf.Sources)})
+	}
+
+	recommFactory := recommendation.NewFactory(p.logger.WithField(componentLogFieldKey, "Recommendations"), p.dynamicCli)
+	err := recommFactory.Register(recommendation.DeploymentPodDisruptionBudgetProvider{})
+	if err != nil {
+		return nil, nil, fmt.Errorf("while registering recommendation providers: %w", err)
+	}
+
+	actionProvider := action.NewProvider(p.logger.WithField(componentLogFieldKey, "Action Provider"), conf.Actions, p.executorFactory, p.approvals)
+	router.AddEnabledActionBindings(conf.Actions)
+
+	redactor, err := redaction.New(conf.Settings.Redaction)
+	if err != nil {
+		return nil, nil, fmt.Errorf("while creating event redactor: %w", err)
+	}
+
+	// Create controller
+	ctrl, err := controller.New(
+		p.logger.WithField(componentLogFieldKey, "Controller"),
+		conf,
+		p.notifiers,
+		recommFactory,
+		p.filterEngine,
+		p.dynamicCli,
+		p.mapper,
+		conf.Settings.InformersResyncPeriod,
+		router.BuildTable(conf),
+		actionProvider,
+		redactor,
+		dedup.New(conf.Settings.Deduplication),
+		containerlogs.NewFetcher(p.k8sCli),
+		p.reporter,
+	)
+	if err != nil {
+		return nil, nil, fmt.Errorf("while creating controller: %w", err)
+	}
+
+	return ctrl, router, nil
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/sink"
+)
+
+// sinkFactory creates a sink from the communication group configuration.
+type sinkFactory struct {
+	displayName string
+	// cfg returns the sink configuration. It's compared on reload to recreate only the changed sinks.
+	cfg     func(in config.Communications) any
+	enabled func(in config.Communications) bool
+	create  func(ctx context.Context, log logrus.FieldLogger, in config.Communications, reporter sink.AnalyticsReporter) (sink.Sink, error)
+}
+
+// sinkRunner is implemented by the sinks which run in the background, e.g. to flush the buffered events.
+type sinkRunner interface {
+	Run(ctx context.Context) error
+}
+
+var sinkFactories = []sinkFactory{
+	{
+		displayName: "Elasticsearch",
+		cfg:         func(in config.Communications) any { return in.Elasticsearch },
+		enabled:     func(in config.Communications) bool { return in.Elasticsearch.Enabled },
+		create: func(ctx context.Context, log logrus.FieldLogger, in config.Communications, reporter sink.AnalyticsReporter) (sink.Sink, error) {
+			return sink.NewElasticsearch(ctx, log, in.Elasticsearch, reporter)
+		},
+	},
+	{
+		displayName: "Webhook",
+		cfg:         func(in config.Communications) any { return in.Webhook },
+		enabled:     func(in config.Communications) bool { return in.Webhook.Enabled },
+		create: func(_ context.Context, log logrus.FieldLogger, in config.Communications, reporter sink.AnalyticsReporter) (sink.Sink, error) {
+			return sink.NewWebhook(log, in.Webhook, reporter)
+		},
+	},
+	{
+		displayName: "PagerDuty",
+		cfg:         func(in config.Communications) any { return in.PagerDuty },
+		enabled:     func(in config.Communications) bool { return in.PagerDuty.Enabled },
+		create: func(_ context.Context, log logrus.FieldLogger, in config.Communications, reporter sink.AnalyticsReporter) (sink.Sink, error) {
+			return sink.NewPagerDuty(log, in.PagerDuty, reporter)
+		},
+	},
+	{
+		displayName: "Opsgenie",
+		cfg:         func(in config.Communications) any { return in.Opsgenie },
+		enabled:     func(in config.Communications) bool { return in.Opsgenie.Enabled },
+		create: func(_ context.Context, log logrus.FieldLogger, in config.Communications, reporter sink.AnalyticsReporter) (sink.Sink, error) {
+			return sink.NewOpsgenie(log, in.Opsgenie, reporter)
+		},
+	},
+	{
+		displayName: "Kafka",
+		cfg:         func(in config.Communications) any { return in.Kafka },
+		enabled:     func(in config.Communications) bool { return in.Kafka.Enabled },
+		create: func(_ context.Context, log logrus.FieldLogger, in config.Communications, reporter sink.AnalyticsReporter) (sink.Sink, error) {
+			return sink.NewKafka(log, in.Kafka, reporter)
+		},
+	},
+	{
+		displayName: "AWS",
+		cfg:         func(in config.Communications) any { return in.AWS },
+		enabled:     func(in config.Communications) bool { return in.AWS.Enabled },
+		create: func(_ context.Context, log logrus.FieldLogger, in config.Communications, reporter sink.AnalyticsReporter) (sink.Sink, error) {
+			return sink.NewAWS(log, in.AWS, reporter)
+		},
+	},
+	{
+		displayName: "Azure Event Hub",
+		cfg:         func(in config.Communications) any { return in.AzureEventHub },
+		enabled:     func(in config.Communications) bool { return in.AzureEventHub.Enabled },
+		create: func(_ context.Context, log logrus.FieldLogger, in config.Communications, reporter sink.AnalyticsReporter) (sink.Sink, error) {
+			return sink.NewAzureEventHub(log, in.AzureEventHub, reporter)
+		},
+	},
+	{
+		displayName: "Loki",
+		cfg:         func(in config.Communications) any { return in.Loki },
+		enabled:     func(in config.Communications) bool { return in.Loki.Enabled },
+		create: func(_ context.Context, log logrus.FieldLogger, in config.Communications, reporter sink.AnalyticsReporter) (sink.Sink, error) {
+			return sink.NewLoki(log, in.Loki, reporter)
+		},
+	},
+	{
+		displayName: "Datadog",
+		cfg:         func(in config.Communications) any { return in.Datadog },
+		enabled:     func(in config.Communications) bool { return in.Datadog.Enabled },
+		create: func(_ context.Context, log logrus.FieldLogger, in config.Communications, reporter sink.AnalyticsReporter) (sink.Sink, error) {
+			return sink.NewDatadog(log, in.Datadog, reporter)
+		},
+	},
+	{
+		displayName: "Jira",
+		cfg:         func(in config.Communications) any { return in.Jira },
+		enabled:     func(in config.Communications) bool { return in.Jira.Enabled },
+		create: func(_ context.Context, log logrus.FieldLogger, in config.Communications, reporter sink.AnalyticsReporter) (sink.Sink, error) {
+			return sink.NewJira(log, in.Jira, reporter)
+		},
+	},
+	{
+		displayName: "ServiceNow",
+		cfg:         func(in config.Communications) any { return in.ServiceNow },
+		enabled:     func(in config.Communications) bool { return in.ServiceNow.Enabled },
+		create: func(_ context.Context, log logrus.FieldLogger, in config.Communications, reporter sink.AnalyticsReporter) (sink.Sink, error) {
+			return sink.NewServiceNow(log, in.ServiceNow, reporter)
+		},
+	},
+}
@@ -32,8 +32,46 @@ communications:
               - 'kubectl-read-only'
             sources:
               - 'k8s-events'
+        # Direct messages with the bot are configured by the Slack user ID. Requires the `message.im` bot event.
+        # 'dm-alias':
+        #   name: 'SLACK_USER_ID'
+        #   bindings:
+        #     executors:
+        #       - 'kubectl-read-only'
       botToken: "" # SLACK_BOT_TOKEN
       appToken: "" # SLACK_APP_TOKEN
+      reactions:
+        enabled: false                          # If true, the bot acknowledges commands with reactions. Requires the `reactions:write` scope.
+        disableRunningMessage: false            # If true, the "Running…" message is not posted for long-running commands.
+      gracefulShutdown:
+        drainTimeout: 10s                       # Time given to in-flight commands to finish when Botkube shuts down.
+        sendShutdownMessage: false              # If true, sends a "Botkube shutting down" message to all configured channels.
+      # Additional Slack workspaces. Channel aliases must be unique across all workspaces.
+      # For Enterprise Grid, set `teamID` without tokens to serve the workspace with the org-wide app.
+      # workspaces:
+      #   - name: 'other-workspace'
+      #     teamID: ""                         # Enterprise Grid workspace ID, e.g. T0123456789.
+      #     botToken: "" # SLACK_BOT_TOKEN
+      #     appToken: "" # SLACK_APP_TOKEN
+      #     channels:
+      #       'other-alias':
+      #         name: 'SLACK_CHANNEL'
+      #         bindings:
+      #           executors:
+      #             - 'kubectl-read-only'
+      #           sources:
+      #             - 'k8s-events'
+      # Binds channels whose topic contains the `botkube:<profile>` marker. Requires the `channels:read` and `groups:read` scopes.
+      channelDiscovery:
+        enabled: false
+        interval: 5m                            # Time between listing the channels.
+        profiles: {}
+        #  'read-only':
+        #    bindings:
+        #      executors:
+        #        - 'kubectl-read-only'
+        #      sources:
+        #        - 'k8s-events'
       notification:
         type: short                             # Change notification type short/long you want to receive. Type is optional and default is short.
 
@@ -57,6 +95,91 @@ communications:
               - k8s-events
       notification:
         type: short                             # Change notification type short/long you want to receive. Type is optional and default is short.
+      interactivity:
+        enabled: false                          # If true, sends interactive messages with buttons, selects and dialogs
+        callbackURL: ''                         # Botkube URL reachable from the Mattermost server, e.g. http://botkube.botkube:3979
+        port: 3979
+
+    # Settings for Rocket.Chat
+    rocketChat:
+      enabled: false
+      url: 'ROCKETCHAT_SERVER_URL'              # URL where Rocket.Chat is running. e.g https://chat.example.com
+      userID: 'ROCKETCHAT_USER_ID'              # User ID of the Personal Access token generated by Botkube user
+      token: 'ROCKETCHAT_TOKEN'                 # Personal Access token generated by Botkube user
+      botName: 'Botkube'                        # Bot name
+      channels:
+        'alias':
+          name: 'ROCKETCHAT_CHANNEL'            # Rocket.Chat Channel for receiving Botkube alerts:
+          notification:
+            # -- If true, the notifications are not sent to the channel. They can be enabled with `@Botkube` command anytime.
+            disabled: false
+          bindings:
+            executors:
+              - kubectl-read-only
+            sources:
+              - k8s-events
+      notification:
+        type: short                             # Change notification type short/long you want to receive. Type is optional and default is short.
+
+    # Settings for Google Chat
+    googleChat:
+      enabled: false
+      botName: 'Botkube'                        # Chat app name
+      projectNumber: 'GOOGLE_CLOUD_PROJECT_NUMBER' # Google Cloud project number of the Chat app, used to verify incoming requests
+      credentials: 'SERVICE_ACCOUNT_KEY_JSON'   # Service account key in JSON format used to call the Google Chat API
+      port: 3980
+      channels:
+        'alias':
+          name: 'spaces/GOOGLE_CHAT_SPACE'      # Google Chat space resource name for receiving Botkube alerts
+          notification:
+            # -- If true, the notifications are not sent to the space. They can be enabled with `@Botkube` command anytime.
+            disabled: false
+          bindings:
+            executors:
+              - kubectl-read-only
+            sources:
+              - k8s-events
+      notification:
+        type: short                             # Change notification type short/long you want to receive. Type is optional and default is short.
+
+    # Settings for Webex
+    webex:
+      enabled: false
+      botName: 'Botkube'                        # Bot name
+      token: 'WEBEX_BOT_TOKEN'                  # Webex bot access token
+      webhookURL: ''                            # Public URL of the Botkube service. If set, the webhook is registered on startup
+      webhookSecret: 'WEBEX_WEBHOOK_SECRET'     # Secret used to verify requests sent by Webex
+      port: 3981
+      channels:
+        'alias':
+          id: 'WEBEX_ROOM_ID'                   # Webex room ID for receiving Botkube alerts
+          notification:
+            # -- If true, the notifications are not sent to the room. They can be enabled with `@Botkube` command anytime.
+            disabled: false
+          bindings:
+            executors:
+              - kubectl-read-only
+            sources:
+              - k8s-events
+      notification:
+        type: short                             # Change notification type short/long you want to receive. Type is optional and default is short.
+
+    # Settings for Matrix. Only unencrypted rooms are supported.
+    matrix:
+      enabled: false
+      botName: 'Botkube'                        # Bot name used in mentions
+      homeserverURL: 'https://matrix.org'       # Matrix homeserver URL
+      accessToken: 'MATRIX_ACCESS_TOKEN'        # Access token of the Botkube Matrix user
+      channels:
+        'alias':
+          name: 'MATRIX_ROOM_ALIAS'             # Matrix room alias, e.g. '#ops:matrix.org'
+          bindings:
+            executors:
+              - kubectl-read-only
+            sources:
+              - k8s-events
+      notification:
+        type: short                             # Change notification type short/long you want to receive. Type is optional and default is short.
 
     # Settings for MS Teams
     teams:
@@ -86,6 +209,8 @@ communications:
               - k8s-events
       notification:
         type: short                             # Change notification type short/long you want to receive. Type is optional and default is short.
+      slashCommands:
+        enabled: false                          # If true, registers the `/botkube` slash command. Requires the `applications.commands` scope.
 
 
     # Settings for ELS
@@ -105,6 +230,12 @@ communications:
           name: botkube
           type: botkube-event
           shards: 1
+          dataStream: false                     # if true, events are written to the `name` data stream instead of daily indices. Requires Elasticsearch 7.9+
+          ilmPolicy: ''                         # name of the existing ILM policy applied to the created indices
+          template:
+            create: false                       # if true, the index template is created or updated on startup. Required for data streams, if not created manually
+            name: ''                            # defaults to the index name
+            priority: 200                       # must be higher than priorities of other templates matching the index name
           bindings:
             sources:
               - "k8s-events"
@@ -113,7 +244,123 @@ communications:
     webhook:
       enabled: false
       url: 'WEBHOOK_URL'                        # e.g https://example.com:80
+      template: ''                              # Go template of the request body, the event is available as .Event. The default JSON payload is sent if empty
+      headers: {}                               # e.g. Authorization: 'Bearer TOKEN'
+      signing:
+        secret: ''                              # HMAC-SHA256 key, requests are not signed if empty
+        header: 'X-Botkube-Signature-256'       # the header value is sha256=<hex-encoded signature>
       bindings:
         # -- Notification sources configuration for the webhook.
         sources:
           - k8s-events
+    # Settings for PagerDuty
+    # Error and critical events trigger incidents, which are resolved once an info event is received for the same object.
+    pagerDuty:
+      enabled: false
+      url: 'https://events.pagerduty.com/v2/enqueue'  # use https://events.eu.pagerduty.com/v2/enqueue for the EU region
+      routingKeys:                              # integration keys of PagerDuty services by source binding names
+        k8s-events: 'PAGERDUTY_ROUTING_KEY'
+    # Settings for Opsgenie
+    # Alerts priority is based on the event level. Alerts of the same object are deduplicated while they are open.
+    opsgenie:
+      enabled: false
+      url: 'https://api.opsgenie.com'           # use https://api.eu.opsgenie.com for the EU region
+      apiKey: 'OPSGENIE_API_KEY'
+      tags: []                                  # added together with cluster, namespace, kind and resource tags
+      bindings:
+        sources:
+          - k8s-events
+    # Settings for Kafka
+    # Events are published via the Confluent REST Proxy, keyed by the cluster and namespace.
+    kafka:
+      enabled: false
+      url: 'KAFKA_REST_PROXY_URL'               # e.g. http://kafka-rest-proxy:8082
+      topic: 'botkube-events'
+      format: json                              # json or avro
+      valueSchemaID: 0                          # Avro schema ID, the built-in schema is used if not specified
+      username: ''
+      password: ''
+      bindings:
+        sources:
+          - k8s-events
+    # Settings for AWS SNS and SQS
+    # Credentials of IAM Role for Service Account are used if configured.
+    aws:
+      enabled: false
+      region: 'AWS_REGION'
+      roleArn: ''                               # assumed if IAM Role for Service Account is not configured
+      sns:
+        topicARN: ''                            # e.g. arn:aws:sns:eu-central-1:123456789012:botkube
+      sqs:
+        queueURL: ''                            # e.g. https://sqs.eu-central-1.amazonaws.com/123456789012/botkube
+      bindings:
+        sources:
+          - k8s-events
+    # Settings for Azure Event Hub
+    # Events are sent in batches, e.g. to ingest them into Microsoft Sentinel.
+    azureEventHub:
+      enabled: false
+      connectionString: ''                      # if empty, the managed identity is used
+      namespace: ''                             # e.g. botkube.servicebus.windows.net, required for the managed identity
+      eventHub: ''                              # not required if the connection string contains the EntityPath
+      managedIdentity:
+        enabled: false
+        clientID: ''                            # user-assigned identity client ID, the system-assigned one is used if empty
+      batch:
+        maxEvents: 100
+        flushInterval: 10s
+      bindings:
+        sources:
+          - k8s-events
+    # Settings for Grafana Loki
+    # Events are pushed as JSON log lines with the cluster, namespace and level stream labels.
+    loki:
+      enabled: false
+      url: 'LOKI_URL'                           # e.g. http://loki-gateway.monitoring.svc
+      tenantID: ''                              # X-Scope-OrgID header, required for multi-tenant Loki
+      username: ''
+      password: ''
+      labels:                                   # added to all streams
+        job: botkube
+      bindings:
+        sources:
+          - k8s-events
+    # Settings for Datadog
+    datadog:
+      enabled: false
+      site: 'datadoghq.com'                     # e.g. datadoghq.eu
+      apiKey: 'DATADOG_API_KEY'
+      tags: []                                  # added together with the tags generated from the event
+      metrics:
+        enabled: false                          # if true, the botkube.event.count metric is emitted for each event
+      bindings:
+        sources:
+          - k8s-events
+    # Settings for Jira. Issues are opened for error and critical events only.
+    jira:
+      enabled: false
+      url: 'JIRA_URL'                           # e.g. https://example.atlassian.net
+      username: ''                              # account email for Jira Cloud, if empty apiToken is used as a personal access token
+      apiToken: 'JIRA_API_TOKEN'
+      project: 'OPS'                            # used when no route matches the event
+      issueType: 'Bug'
+      labels: []
+      routes:                                   # the first matching route wins
+        - query: 'namespace = prod AND reason in (BackOff, OOMKilled)'
+          project: 'PROD'
+          priority: 'High'
+      bindings:
+        sources:
+          - k8s-err-events
+    # Settings for ServiceNow. Incidents are created for error and critical events only.
+    serviceNow:
+      enabled: false
+      url: 'SERVICENOW_URL'                     # e.g. https://example.service-now.com
+      username: 'SERVICENOW_USERNAME'
+      password: 'SERVICENOW_PASSWORD'
+      table: 'incident'
+      fields:                                   # Go templates overriding the default incident fields
+        assignment_group: 'Kubernetes'
+      bindings:
+        sources:
+          - k8s-err-events
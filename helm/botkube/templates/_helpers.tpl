@@ -50,6 +50,30 @@ Create the name of the service account to use
 {{- .Values.ssl.existingSecretName | default (printf "%s-certificate-secret" (include "botkube.fullname" .)) -}}
 {{- end -}}
 
+{{- define "botkube.communication.mattermostInteractivity.enabled" -}}
+{{- range $key, $val := .Values.communications -}}
+{{- if dig "mattermost" "interactivity" "enabled" false $val -}}
+  {{- true -}}
+{{- end -}}
+{{- end -}}
+{{- end -}}
+
+{{- define "botkube.communication.googleChat.enabled" -}}
+{{- range $key, $val := .Values.communications -}}
+{{- if dig "googleChat" "enabled" false $val -}}
+  {{- true -}}
+{{- end -}}
+{{- end -}}
+{{- end -}}
+
+{{- define "botkube.communication.webex.enabled" -}}
+{{- range $key, $val := .Values.communications -}}
+{{- if dig "webex" "enabled" false $val -}}
+  {{- true -}}
+{{- end -}}
+{{- end -}}
+{{- end -}}
+
 {{- define "botkube.communication.team.enabled" -}}
 {{- range $key, $val := .Values.communications -}}
 {{- if $val.teams.enabled -}}
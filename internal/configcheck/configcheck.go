@@ -0,0 +1,191 @@
+package configcheck
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/slack-go/slack"
+	"github.com/spf13/pflag"
+
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/sink"
+)
+
+// Exit codes returned by the config check.
+const (
+	// ExitCodeValid means that the configuration is valid.
+	ExitCodeValid = 0
+	// ExitCodeInvalid means that the configuration cannot be loaded, has critical validation errors, or the live checks failed.
+	ExitCodeInvalid = 1
+	// ExitCodeWarnings means that the configuration is valid, but it has validation warnings.
+	ExitCodeWarnings = 2
+)
+
+const (
+	subcommandName   = "check-config"
+	liveCheckTimeout = 30 * time.Second
+)
+
+var (
+	checkConfigFlag bool
+	liveChecksFlag  bool
+)
+
+// RegisterFlags registers config check related flags.
+func RegisterFlags(flags *pflag.FlagSet) {
+	flags.BoolVar(&checkConfigFlag, subcommandName, false, "Load and validate the configuration, print the results and exit. The same as the 'check-config' subcommand.")
+	flags.BoolVar(&liveChecksFlag, "live-checks", false, "Used with the config check. Check also the connection to the communication platforms, e.g. Slack tokens and Elasticsearch server.")
+}
+
+// Enabled returns true if the config check is requested with the '--check-config' flag or the 'check-config' subcommand.
+// It must be called after the flags are parsed.
+func Enabled(args []string) bool {
+	return checkConfigFlag || (len(args) > 0 && args[0] == subcommandName)
+}
+
+// Run loads and validates the configuration, and prints the results to a given writer.
+// It returns the exit code describing the results.
+func Run(ctx context.Context, out io.Writer, getCfgPaths config.PathsGetter) int {
+	cfg, details, err := config.LoadWithDefaults(getCfgPaths)
+
+	printIssues(out, "Warnings", details.ValidateWarnings)
+	if err != nil {
+		if details.ValidateCriticals != nil {
+			printIssues(out, "Criticals", details.ValidateCriticals)
+		} else {
+			fmt.Fprintf(out, "Configuration cannot be loaded: %s\n", err.Error())
+		}
+		return ExitCodeInvalid
+	}
+
+	if liveChecksFlag && !runLiveChecks(ctx, out, cfg) {
+		return ExitCodeInvalid
+	}
+
+	if details.ValidateWarnings != nil {
+		fmt.Fprintln(out, "Configuration is valid, but it has warnings.")
+		return ExitCodeWarnings
+	}
+	fmt.Fprintln(out, "Configuration is valid.")
+	return ExitCodeValid
+}
+
+func printIssues(out io.Writer, header string, issues error) {
+	if issues == nil {
+		return
+	}
+
+	fmt.Fprintf(out, "%s:\n", header)
+	var multiErr *multierror.Error
+	if !errors.As(issues, &multiErr) {
+		fmt.Fprintf(out, "  - %s\n", issues.Error())
+		return
+	}
+	for _, issue := range multiErr.Errors {
+		fmt.Fprintf(out, "  - %s\n", issue.Error())
+	}
+}
+
+// liveCheck checks the connection to a given external service.
+type liveCheck struct {
+	name  string
+	check func(ctx context.Context) error
+}
+
+// runLiveChecks runs the live checks of the enabled communication platforms. It returns false if any check failed.
+func runLiveChecks(ctx context.Context, out io.Writer, cfg *config.Config, slackOpts ...slack.Option) bool {
+	ctx, cancel := context.WithTimeout(ctx, liveCheckTimeout)
+	defer cancel()
+
+	passed := true
+	for _, c := range liveChecks(cfg, slackOpts...) {
+		if err := c.check(ctx); err != nil {
+			fmt.Fprintf(out, "[FAILED] %s: %s\n", c.name, err.Error())
+			passed = false
+			continue
+		}
+		fmt.Fprintf(out, "[OK] %s\n", c.name)
+	}
+	return passed
+}
+
+func liveChecks(cfg *config.Config, slackOpts ...slack.Option) []liveCheck {
+	var out []liveCheck
+	for _, groupName := range sortedGroupNames(cfg.Communications) {
+		group := cfg.Communications[groupName]
+
+		if group.Slack.Enabled {
+			out = append(out, slackAuthCheck(fmt.Sprintf("%s: Slack token", groupName), group.Slack.Token, slackOpts...))
+		}
+
+		if group.SocketSlack.Enabled {
+			socketSlack := group.SocketSlack
+			if socketSlack.HasDefaultWorkspace() {
+				name := fmt.Sprintf("%s: Socket Slack", groupName)
+				out = append(out, socketSlackChecks(name, socketSlack.BotToken, socketSlack.AppToken, slackOpts...)...)
+			}
+			for _, workspace := range socketSlack.Workspaces {
+				if workspace.IsServedByOrgApp() {
+					continue
+				}
+				name := fmt.Sprintf("%s: Socket Slack workspace %q", groupName, workspace.Name)
+				out = append(out, socketSlackChecks(name, workspace.BotToken, workspace.AppToken, slackOpts...)...)
+			}
+		}
+
+		if group.Elasticsearch.Enabled {
+			es := group.Elasticsearch
+			out = append(out, liveCheck{
+				name: fmt.Sprintf("%s: Elasticsearch server %q", groupName, es.Server),
+				check: func(ctx context.Context) error {
+					cli, err := sink.NewElasticsearchClient(es)
+					if err != nil {
+						return err
+					}
+					_, _, err = cli.Ping(es.Server).Do(ctx)
+					return err
+				},
+			})
+		}
+	}
+	return out
+}
+
+func slackAuthCheck(name, token string, opts ...slack.Option) liveCheck {
+	return liveCheck{
+		name: name,
+		check: func(ctx context.Context) error {
+			_, err := slack.New(token, opts...).AuthTestContext(ctx)
+			return err
+		},
+	}
+}
+
+// socketSlackChecks checks the bot token, and the app token by requesting the Socket Mode connection URL, without connecting to it.
+func socketSlackChecks(name, botToken, appToken string, opts ...slack.Option) []liveCheck {
+	return []liveCheck{
+		slackAuthCheck(name+" bot token", botToken, opts...),
+		{
+			name: name + " app token",
+			check: func(ctx context.Context) error {
+				opts := append([]slack.Option{slack.OptionAppLevelToken(appToken)}, opts...)
+				_, _, err := slack.New(botToken, opts...).StartSocketModeContext(ctx)
+				return err
+			},
+		},
+	}
+}
+
+func sortedGroupNames(in map[string]config.Communications) []string {
+	out := make([]string, 0, len(in))
+	for name := range in {
+		out = append(out, name)
+	}
+	sort.Strings(out)
+	return out
+}
@@ -0,0 +1,108 @@
+package configcheck
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+func TestRun(t *testing.T) {
+	// given
+	tests := []struct {
+		name string
+
+		configFile  string
+		expExitCode int
+		expOut      string
+	}{
+		{
+			name:        "Should report valid configuration",
+			configFile:  "valid.yaml",
+			expExitCode: ExitCodeValid,
+			expOut:      "Configuration is valid.\n",
+		},
+		{
+			name:        "Should report warnings",
+			configFile:  "warnings.yaml",
+			expExitCode: ExitCodeWarnings,
+			expOut: "Warnings:\n" +
+				"  - Key: 'Config.Executors[kubectl-read-only].Kubectl.Namespaces.Include' Include matches both all and exact namespaces\n" +
+				"Configuration is valid, but it has warnings.\n",
+		},
+		{
+			name:        "Should report criticals",
+			configFile:  "criticals.yaml",
+			expExitCode: ExitCodeInvalid,
+			expOut: "Criticals:\n" +
+				"  - Key: 'Config.Communications[default-group].SocketSlack.Channels[alias].Bindings.k8s-events' 'k8s-events' binding not defined in Config.Sources\n" +
+				"  - Key: 'Config.Communications[default-group].SocketSlack.AppToken' AppToken is a required field\n" +
+				"  - Key: 'Config.Communications[default-group].SocketSlack.AppToken' AppToken must have the xapp- prefix. Learn more at https://botkube.io/docs/installation/socketslack/#generate-and-obtain-app-level-token\n",
+		},
+		{
+			name:        "Should report not loaded configuration",
+			configFile:  "not-existing.yaml",
+			expExitCode: ExitCodeInvalid,
+			expOut:      "Configuration cannot be loaded: open testdata/not-existing.yaml: no such file or directory\n",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var out bytes.Buffer
+
+			// when
+			exitCode := Run(context.Background(), &out, func() []string {
+				return []string{filepath.Join("testdata", tc.configFile)}
+			})
+
+			// then
+			assert.Equal(t, tc.expExitCode, exitCode)
+			assert.Equal(t, tc.expOut, out.String())
+		})
+	}
+}
+
+func TestRunLiveChecks(t *testing.T) {
+	// given
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/auth.test":
+			_, _ = w.Write([]byte(`{"ok": true, "user_id": "U1"}`))
+		case "/apps.connections.open":
+			_, _ = w.Write([]byte(`{"ok": false, "error": "invalid_auth"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	cfg := &config.Config{
+		Communications: map[string]config.Communications{
+			"default-group": {
+				SocketSlack: config.SocketSlack{
+					Enabled:  true,
+					BotToken: "xoxb-token",
+					AppToken: "xapp-token",
+				},
+			},
+		},
+	}
+	var out bytes.Buffer
+
+	// when
+	passed := runLiveChecks(context.Background(), &out, cfg, slack.OptionAPIURL(srv.URL+"/"))
+
+	// then
+	require.False(t, passed)
+	assert.Equal(t, "[OK] default-group: Socket Slack bot token\n"+
+		"[FAILED] default-group: Socket Slack app token: invalid_auth\n", out.String())
+}
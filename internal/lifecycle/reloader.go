@@ -0,0 +1,125 @@
+package lifecycle
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+// LoadConfigFn defines a function which loads the current configuration.
+type LoadConfigFn func() (*config.Config, error)
+
+// RestartFn defines a function which restarts Botkube to apply the changes which cannot be reloaded.
+type RestartFn func(ctx context.Context) error
+
+// ReloadHandler defines a function which applies a reloaded configuration.
+type ReloadHandler func(ctx context.Context, cfg *config.Config) error
+
+// Reloader applies the configuration changes without restarting Botkube, if possible.
+type Reloader struct {
+	log       logrus.FieldLogger
+	loadFn    LoadConfigFn
+	restartFn RestartFn
+	handlers  []componentsHandler
+
+	mu      sync.Mutex
+	current *config.Config
+}
+
+// NewReloader returns a new Reloader instance.
+func NewReloader(log logrus.FieldLogger, current *config.Config, loadFn LoadConfigFn, restartFn RestartFn) *Reloader {
+	return &Reloader{
+		log:       log,
+		loadFn:    loadFn,
+		restartFn: restartFn,
+		current:   current,
+	}
+}
+
+type componentsHandler struct {
+	components []config.ReloadComponent
+	handler    ReloadHandler
+}
+
+// RegisterHandler registers a handler which applies the changes of given components.
+// Handlers are executed in the registration order, once per reload, even if multiple of their components changed.
+func (r *Reloader) RegisterHandler(handler ReloadHandler, components ...config.ReloadComponent) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.handlers = append(r.handlers, componentsHandler{components: components, handler: handler})
+}
+
+// Reload loads the configuration and applies its changes. If some of them cannot be applied without restart,
+// Botkube is restarted. If the configuration is invalid, Botkube keeps running with the current one.
+// It returns the applied changes.
+func (r *Reloader) Reload(ctx context.Context) (config.Diff, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	reloaded, err := r.loadFn()
+	if err != nil {
+		return config.Diff{}, fmt.Errorf("while loading configuration: %w", err)
+	}
+
+	diff, err := config.DiffConfigs(r.current, reloaded)
+	if err != nil {
+		return config.Diff{}, fmt.Errorf("while comparing configuration: %w", err)
+	}
+
+	if diff.IsEmpty() {
+		r.log.Info("Configuration has not changed. Skipping reload...")
+		return diff, nil
+	}
+
+	// The components without handlers, e.g. when the hot reload is disabled, can be applied only by restart.
+	for _, component := range diff.Components {
+		if !r.handles(component) {
+			diff.RestartRequiredPaths = append(diff.RestartRequiredPaths, string(component))
+		}
+	}
+
+	if diff.RequiresRestart() {
+		r.log.WithField("paths", diff.RestartRequiredPaths).Info("Configuration changes require restart.")
+		if err := r.restartFn(ctx); err != nil {
+			return config.Diff{}, err
+		}
+		return diff, nil
+	}
+
+	r.log.WithField("components", diff.Components).Info("Reloading configuration...")
+	for _, h := range r.handlers {
+		if !h.changedIn(diff) {
+			continue
+		}
+		if err := h.handler(ctx, reloaded); err != nil {
+			return config.Diff{}, fmt.Errorf("while reloading %v: %w", h.components, err)
+		}
+	}
+
+	r.current = reloaded
+	return diff, nil
+}
+
+func (r *Reloader) handles(component config.ReloadComponent) bool {
+	for _, h := range r.handlers {
+		for _, handled := range h.components {
+			if handled == component {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (h componentsHandler) changedIn(diff config.Diff) bool {
+	for _, component := range h.components {
+		if diff.Has(component) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,130 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	logtest "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+func TestReloader_Reload(t *testing.T) {
+	// given
+	tests := []struct {
+		name string
+
+		reloaded          *config.Config
+		handledComponents []config.ReloadComponent
+		expDiff           config.Diff
+		expHandlerRuns    int
+		expRestarted      bool
+	}{
+		{
+			name:              "Should skip unchanged configuration",
+			reloaded:          fixReloaderConfig("dev", "Kubernetes"),
+			handledComponents: []config.ReloadComponent{config.SourcesReloadComponent, config.ChannelsReloadComponent},
+		},
+		{
+			name:              "Should reload sources once",
+			reloaded:          fixReloaderConfig("dev", "Kubernetes events"),
+			handledComponents: []config.ReloadComponent{config.SourcesReloadComponent, config.ChannelsReloadComponent},
+			expDiff: config.Diff{
+				Components: []config.ReloadComponent{config.SourcesReloadComponent},
+			},
+			expHandlerRuns: 1,
+		},
+		{
+			name:              "Should restart on changed components without handler",
+			reloaded:          fixReloaderConfig("dev", "Kubernetes events"),
+			handledComponents: []config.ReloadComponent{config.ChannelsReloadComponent},
+			expDiff: config.Diff{
+				Components:           []config.ReloadComponent{config.SourcesReloadComponent},
+				RestartRequiredPaths: []string{"sources"},
+			},
+			expRestarted: true,
+		},
+		{
+			name:              "Should restart on changed settings",
+			reloaded:          fixReloaderConfig("prod", "Kubernetes events"),
+			handledComponents: []config.ReloadComponent{config.SourcesReloadComponent, config.ChannelsReloadComponent},
+			expDiff: config.Diff{
+				Components:           []config.ReloadComponent{config.SourcesReloadComponent},
+				RestartRequiredPaths: []string{"settings.clusterName"},
+			},
+			expRestarted: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			logger, _ := logtest.NewNullLogger()
+			restarted := false
+			reloader := NewReloader(logger, fixReloaderConfig("dev", "Kubernetes"), func() (*config.Config, error) {
+				return tc.reloaded, nil
+			}, func(context.Context) error {
+				restarted = true
+				return nil
+			})
+
+			handlerRuns := 0
+			reloader.RegisterHandler(func(_ context.Context, cfg *config.Config) error {
+				handlerRuns++
+				assert.Equal(t, tc.reloaded, cfg)
+				return nil
+			}, tc.handledComponents...)
+
+			// when
+			diff, err := reloader.Reload(context.Background())
+
+			// then
+			require.NoError(t, err)
+			assert.Equal(t, tc.expDiff, diff)
+			assert.Equal(t, tc.expHandlerRuns, handlerRuns)
+			assert.Equal(t, tc.expRestarted, restarted)
+		})
+	}
+}
+
+func TestReloader_ReloadKeepsConfigOnError(t *testing.T) {
+	// given
+	logger, _ := logtest.NewNullLogger()
+	loadErr := errors.New("invalid configuration")
+	reloaded := fixReloaderConfig("dev", "Kubernetes events")
+
+	calls := 0
+	reloader := NewReloader(logger, fixReloaderConfig("dev", "Kubernetes"), func() (*config.Config, error) {
+		calls++
+		if calls == 1 {
+			return nil, loadErr
+		}
+		return reloaded, nil
+	}, nil)
+	reloader.RegisterHandler(func(context.Context, *config.Config) error {
+		return nil
+	}, config.SourcesReloadComponent)
+
+	// when
+	_, err := reloader.Reload(context.Background())
+
+	// then
+	assert.ErrorIs(t, err, loadErr)
+
+	// when
+	diff, err := reloader.Reload(context.Background())
+
+	// then
+	require.NoError(t, err)
+	assert.True(t, diff.Has(config.SourcesReloadComponent))
+}
+
+func fixReloaderConfig(clusterName, sourceDisplayName string) *config.Config {
+	return &config.Config{
+		Sources: map[string]config.Sources{
+			"k8s-events": {DisplayName: sourceDisplayName},
+		},
+		Settings: config.Settings{ClusterName: clusterName},
+	}
+}
@@ -1,8 +1,10 @@
 package lifecycle
 
 import (
+	"context"
 	"fmt"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/gorilla/mux"
@@ -18,22 +20,28 @@ import (
 const (
 	k8sDeploymentRestartPatchFmt = `{"spec":{"template":{"metadata":{"annotations":{"kubectl.kubernetes.io/restartedAt":"%s"}}}}}`
 	reloadMsgFmt                 = ":arrows_counterclockwise: Configuration reload requested for cluster '%s'. Hold on a sec..."
+	hotReloadMsgFmt              = ":arrows_counterclockwise: Configuration reloaded for cluster '%s'. Updated components: %s."
 )
 
 // SendMessageFn defines a function which sends a given message.
 type SendMessageFn func(msg string) error
 
 // NewServer creates a new httpsrv.Server that exposes lifecycle methods as HTTP endpoints.
-func NewServer(log logrus.FieldLogger, k8sCli kubernetes.Interface, cfg config.LifecycleServer, clusterName string, sendMsgFn SendMessageFn) *httpsrv.Server {
+// If the reloader is not nil, the configuration changes are applied without restart, if possible.
+func NewServer(log logrus.FieldLogger, k8sCli kubernetes.Interface, cfg config.LifecycleServer, clusterName string, sendMsgFn SendMessageFn, reloader *Reloader) *httpsrv.Server {
 	addr := fmt.Sprintf(":%d", cfg.Port)
 	router := mux.NewRouter()
 	reloadHandler := newReloadHandler(log, k8sCli, cfg.Deployment, clusterName, sendMsgFn)
+	if reloader != nil {
+		reloadHandler = newHotReloadHandler(log, reloader, clusterName, sendMsgFn)
+	}
 	router.HandleFunc("/reload", reloadHandler)
 	return httpsrv.New(log, addr, router)
 }
 
-func newReloadHandler(log logrus.FieldLogger, k8sCli kubernetes.Interface, deploy config.K8sResourceRef, clusterName string, sendMsgFn SendMessageFn) http.HandlerFunc {
-	return func(writer http.ResponseWriter, request *http.Request) {
+// NewDeploymentRestartFn returns a function which restarts the Botkube Deployment, after sending the last message.
+func NewDeploymentRestartFn(log logrus.FieldLogger, k8sCli kubernetes.Interface, deploy config.K8sResourceRef, clusterName string, sendMsgFn SendMessageFn) RestartFn {
+	return func(ctx context.Context) error {
 		log.Info("Reload requested. Sending last message before exit...")
 		err := sendMsgFn(fmt.Sprintf(reloadMsgFmt, clusterName))
 		if err != nil {
@@ -46,7 +54,6 @@ func newReloadHandler(log logrus.FieldLogger, k8sCli kubernetes.Interface, deplo
 		log.Infof(`Reloading te the deployment "%s/%s"...`, deploy.Namespace, deploy.Name)
 		// This is what `kubectl rollout restart` does.
 		restartData := fmt.Sprintf(k8sDeploymentRestartPatchFmt, time.Now().String())
-		ctx := request.Context()
 		_, err = k8sCli.AppsV1().Deployments(deploy.Namespace).Patch(
 			ctx,
 			deploy.Name,
@@ -55,15 +62,66 @@ func newReloadHandler(log logrus.FieldLogger, k8sCli kubernetes.Interface, deplo
 			metav1.PatchOptions{FieldManager: "kubectl-rollout"},
 		)
 		if err != nil {
-			errMsg := fmt.Sprintf("while restarting the Deployment: %s", err.Error())
+			return fmt.Errorf("while restarting the Deployment: %w", err)
+		}
+		return nil
+	}
+}
+
+func newReloadHandler(log logrus.FieldLogger, k8sCli kubernetes.Interface, deploy config.K8sResourceRef, clusterName string, sendMsgFn SendMessageFn) http.HandlerFunc {
+	restartFn := NewDeploymentRestartFn(log, k8sCli, deploy, clusterName, sendMsgFn)
+	return func(writer http.ResponseWriter, request *http.Request) {
+		err := restartFn(request.Context())
+		if err != nil {
+			log.Error(err.Error())
+			http.Error(writer, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		writer.WriteHeader(http.StatusOK)
+		_, err = writer.Write([]byte(fmt.Sprintf(`Deployment "%s/%s" restarted successfully.`, deploy.Namespace, deploy.Name)))
+		if err != nil {
+			log.Errorf("while writing success response: %s", err.Error())
+		}
+	}
+}
+
+func newHotReloadHandler(log logrus.FieldLogger, reloader *Reloader, clusterName string, sendMsgFn SendMessageFn) http.HandlerFunc {
+	return func(writer http.ResponseWriter, request *http.Request) {
+		diff, err := reloader.Reload(request.Context())
+		if err != nil {
+			errMsg := fmt.Sprintf("while reloading configuration: %s", err.Error())
 			log.Error(errMsg)
 			http.Error(writer, errMsg, http.StatusInternalServerError)
+			return
+		}
+
+		var response string
+		switch {
+		case diff.IsEmpty():
+			response = "Configuration has not changed."
+		case diff.RequiresRestart():
+			response = "Configuration changes require restart. Deployment restarted successfully."
+		default:
+			response = fmt.Sprintf("Configuration reloaded successfully. Updated components: %s.", componentsString(diff.Components))
+			err := sendMsgFn(fmt.Sprintf(hotReloadMsgFmt, clusterName, componentsString(diff.Components)))
+			if err != nil {
+				log.Errorf("while sending reload message: %s", err.Error())
+			}
 		}
 
 		writer.WriteHeader(http.StatusOK)
-		_, err = writer.Write([]byte(fmt.Sprintf(`Deployment "%s/%s" restarted successfully.`, deploy.Namespace, deploy.Name)))
+		_, err = writer.Write([]byte(response))
 		if err != nil {
 			log.Errorf("while writing success response: %s", err.Error())
 		}
 	}
 }
+
+func componentsString(components []config.ReloadComponent) string {
+	out := make([]string, 0, len(components))
+	for _, component := range components {
+		out = append(out, string(component))
+	}
+	return strings.Join(out, ", ")
+}
@@ -0,0 +1,97 @@
+package loadtest
+
+import (
+	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+const (
+	syntheticNamespace = "botkube-load-test"
+	syntheticNameFmt   = "synthetic-%d"
+	syntheticReason    = "SyntheticFailure"
+	syntheticMessage   = "Synthetic error generated by the Botkube load test"
+)
+
+type syntheticResource struct {
+	resource   string
+	apiVersion string
+	kind       string
+}
+
+var (
+	syntheticResources = []syntheticResource{
+		{resource: "v1/pods", apiVersion: "v1", kind: "Pod"},
+		{resource: "apps/v1/deployments", apiVersion: "apps/v1", kind: "Deployment"},
+		{resource: "v1/services", apiVersion: "v1", kind: "Service"},
+	}
+	syntheticEventTypes = []config.EventType{
+		config.CreateEvent,
+		config.UpdateEvent,
+		config.DeleteEvent,
+		config.ErrorEvent,
+	}
+)
+
+// SyntheticObject is a Kubernetes object injected into the event pipeline as if it was received by the informer of a given resource.
+type SyntheticObject struct {
+	// Name is the name of the synthetic object. It's also the name of the event sent to the platform.
+	Name      string
+	Object    *unstructured.Unstructured
+	Resource  string
+	EventType config.EventType
+}
+
+// Generator generates synthetic Kubernetes objects.
+type Generator struct{}
+
+// NewGenerator returns a new Generator instance.
+func NewGenerator() *Generator {
+	return &Generator{}
+}
+
+// Generate returns the n-th synthetic object. Resources and event types are rotated, so all of them are covered.
+// Error events are generated as Kubernetes Warning events involving the synthetic object, in the same way as they are
+// received from the cluster.
+func (g *Generator) Generate(n int) SyntheticObject {
+	res := syntheticResources[n%len(syntheticResources)]
+	eventType := syntheticEventTypes[n%len(syntheticEventTypes)]
+	name := fmt.Sprintf(syntheticNameFmt, n)
+	now := time.Now()
+
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion(res.apiVersion)
+	obj.SetKind(res.kind)
+	obj.SetName(name)
+	obj.SetNamespace(syntheticNamespace)
+	obj.SetCreationTimestamp(metav1.NewTime(now))
+
+	if eventType != config.ErrorEvent {
+		return SyntheticObject{Name: name, Object: obj, Resource: res.resource, EventType: eventType}
+	}
+
+	event := &unstructured.Unstructured{Object: map[string]interface{}{
+		"involvedObject": map[string]interface{}{
+			"apiVersion": res.apiVersion,
+			"kind":       res.kind,
+			"name":       name,
+			"namespace":  syntheticNamespace,
+		},
+		"type":          "Warning",
+		"reason":        syntheticReason,
+		"message":       syntheticMessage,
+		"count":         int64(1),
+		"lastTimestamp": now.UTC().Format(time.RFC3339),
+	}}
+	event.SetAPIVersion("v1")
+	event.SetKind("Event")
+	event.SetName(fmt.Sprintf("%s.%x", name, now.UnixNano()))
+	event.SetNamespace(syntheticNamespace)
+	event.SetCreationTimestamp(metav1.NewTime(now))
+
+	return SyntheticObject{Name: name, Object: event, Resource: res.resource, EventType: eventType}
+}
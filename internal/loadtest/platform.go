@@ -0,0 +1,83 @@
+package loadtest
+
+import (
+	"context"
+	"sync"
+
+	"github.com/kubeshop/botkube/pkg/bot/interactive"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/events"
+	"github.com/kubeshop/botkube/pkg/format"
+	"github.com/kubeshop/botkube/pkg/notifier"
+)
+
+// NoopCommPlatformIntegration defines the no-op platform used during load tests.
+const NoopCommPlatformIntegration config.CommPlatformIntegration = "loadTestNoop"
+
+var _ notifier.Notifier = &NoopPlatform{}
+
+// NoopPlatform implements the notifier.Notifier interface. It renders the messages, but doesn't send them anywhere.
+type NoopPlatform struct {
+	mu sync.Mutex
+	// pending holds the channels closed once the awaited events are received, indexed by the event name.
+	pending map[string]chan struct{}
+}
+
+// NewNoopPlatform returns a new NoopPlatform instance.
+func NewNoopPlatform() *NoopPlatform {
+	return &NoopPlatform{
+		pending: map[string]chan struct{}{},
+	}
+}
+
+// SendEvent renders the event message and drops it.
+func (p *NoopPlatform) SendEvent(_ context.Context, event events.Event, _ []string) error {
+	_ = format.ShortMessage(event)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if received, found := p.pending[event.Name]; found {
+		close(received)
+		delete(p.pending, event.Name)
+	}
+	return nil
+}
+
+// await returns a channel which is closed once the event with a given name is received.
+func (p *NoopPlatform) await(name string) <-chan struct{} {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	received := make(chan struct{})
+	p.pending[name] = received
+	return received
+}
+
+// forget stops awaiting the event with a given name.
+func (p *NoopPlatform) forget(name string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.pending, name)
+}
+
+// SendMessageToAll renders the message and drops it.
+func (*NoopPlatform) SendMessageToAll(_ context.Context, msg interactive.Message) error {
+	_ = interactive.RenderMessage(interactive.DefaultMDFormatter(), msg)
+	return nil
+}
+
+// SendGenericMessage renders the message and drops it.
+func (*NoopPlatform) SendGenericMessage(_ context.Context, genericMsg interactive.GenericMessage, _ []string) error {
+	_ = interactive.RenderMessage(interactive.DefaultMDFormatter(), genericMsg.ForBot(""))
+	return nil
+}
+
+// IntegrationName describes the integration name.
+func (*NoopPlatform) IntegrationName() config.CommPlatformIntegration {
+	return NoopCommPlatformIntegration
+}
+
+// Type describes the integration type.
+func (*NoopPlatform) Type() config.IntegrationType {
+	return config.SinkIntegrationType
+}
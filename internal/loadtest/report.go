@@ -0,0 +1,106 @@
+package loadtest
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Report holds the load test results.
+type Report struct {
+	Elapsed    time.Duration
+	Sent       int
+	Skipped    int
+	Failed     int
+	Throughput float64
+
+	LatencyP50 time.Duration
+	LatencyP90 time.Duration
+	LatencyP95 time.Duration
+	LatencyP99 time.Duration
+	LatencyMax time.Duration
+}
+
+// String returns a human-readable report.
+func (r Report) String() string {
+	var out strings.Builder
+	fmt.Fprintf(&out, "Load test finished in %s\n", r.Elapsed.Round(time.Millisecond))
+	fmt.Fprintf(&out, "Events sent: %d, skipped by routing, filters or deduplication: %d, failed: %d\n", r.Sent, r.Skipped, r.Failed)
+	fmt.Fprintf(&out, "Throughput: %.2f events/s\n", r.Throughput)
+	fmt.Fprintf(&out, "Latency: p50=%s p90=%s p95=%s p99=%s max=%s", r.LatencyP50, r.LatencyP90, r.LatencyP95, r.LatencyP99, r.LatencyMax)
+	return out.String()
+}
+
+// collector gathers load test results. It's safe for concurrent use.
+type collector struct {
+	mu        sync.Mutex
+	latencies []time.Duration
+	skipped   int
+	failed    int
+}
+
+func newCollector() *collector {
+	return &collector{}
+}
+
+func (c *collector) Sent(latency time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.latencies = append(c.latencies, latency)
+}
+
+func (c *collector) Skipped() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.skipped++
+}
+
+func (c *collector) Failed() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.failed++
+}
+
+func (c *collector) Report(elapsed time.Duration) Report {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	latencies := make([]time.Duration, len(c.latencies))
+	copy(latencies, c.latencies)
+	sort.Slice(latencies, func(i, j int) bool {
+		return latencies[i] < latencies[j]
+	})
+
+	report := Report{
+		Elapsed:    elapsed,
+		Sent:       len(latencies),
+		Skipped:    c.skipped,
+		Failed:     c.failed,
+		LatencyP50: percentile(latencies, 50),
+		LatencyP90: percentile(latencies, 90),
+		LatencyP95: percentile(latencies, 95),
+		LatencyP99: percentile(latencies, 99),
+		LatencyMax: percentile(latencies, 100),
+	}
+	if elapsed > 0 {
+		report.Throughput = float64(report.Sent) / elapsed.Seconds()
+	}
+
+	return report
+}
+
+// percentile returns the nearest-rank percentile of already sorted latencies.
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
@@ -0,0 +1,141 @@
+package loadtest
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+const (
+	// minTickPeriod is the smallest period between generating subsequent batches of events.
+	minTickPeriod = time.Millisecond
+	// deliveryTimeout limits the time between dispatching the event and receiving it by the platform.
+	deliveryTimeout = time.Minute
+)
+
+// EventHandler processes Kubernetes objects in the same way as the ones received by the informers.
+type EventHandler interface {
+	// HandleObject returns true if the event about a given object was dispatched to the notifiers.
+	HandleObject(ctx context.Context, obj *unstructured.Unstructured, resource string, eventType config.EventType) (bool, error)
+}
+
+// Runner injects synthetic objects into the event pipeline, which routes, filters, renders and sends them to the no-op platform.
+type Runner struct {
+	log       logrus.FieldLogger
+	generator *Generator
+	handler   EventHandler
+	platform  *NoopPlatform
+}
+
+type job struct {
+	n           int
+	scheduledAt time.Time
+}
+
+// NewRunner returns a new Runner instance. The handler needs to send the events to a given platform.
+func NewRunner(log logrus.FieldLogger, generator *Generator, handler EventHandler, platform *NoopPlatform) *Runner {
+	return &Runner{
+		log:       log,
+		generator: generator,
+		handler:   handler,
+		platform:  platform,
+	}
+}
+
+// Run generates events according to a given specification and returns the report once all of them are processed.
+// Events are processed by a fixed number of workers. If all of them are busy, the generation waits, so the delay
+// is included in the reported latency.
+func (r *Runner) Run(ctx context.Context, spec Spec) Report {
+	workers := spec.Workers
+	if workers <= 0 {
+		workers = DefaultWorkers
+	}
+	r.log.Infof("Starting load test with %d events per %s for %s using %d workers...", spec.Rate, spec.Interval, spec.Duration, workers)
+
+	// events generated right before the deadline are still processed, so only the generation is bound to the duration
+	genCtx, cancel := context.WithTimeout(ctx, spec.Duration)
+	defer cancel()
+
+	collector := newCollector()
+	jobs := make(chan job, workers)
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range jobs {
+				r.process(ctx, j, collector)
+			}
+		}()
+	}
+
+	tickPeriod := spec.Interval / time.Duration(spec.Rate)
+	if tickPeriod < minTickPeriod {
+		tickPeriod = minTickPeriod
+	}
+	ticker := time.NewTicker(tickPeriod)
+	defer ticker.Stop()
+
+	var (
+		generated int
+		start     = time.Now()
+	)
+
+loop:
+	for {
+		select {
+		case <-genCtx.Done():
+			break loop
+		case now := <-ticker.C:
+			// calculate the number of due events, so the rate is kept even if the ticker period is rounded
+			due := int(float64(now.Sub(start)) / float64(spec.Interval) * float64(spec.Rate))
+			for ; generated < due; generated++ {
+				select {
+				case jobs <- job{n: generated, scheduledAt: now}:
+				case <-genCtx.Done():
+					break loop
+				}
+			}
+		}
+	}
+
+	close(jobs)
+	wg.Wait()
+
+	return collector.Report(time.Since(start))
+}
+
+func (r *Runner) process(ctx context.Context, j job, collector *collector) {
+	obj := r.generator.Generate(j.n)
+
+	received := r.platform.await(obj.Name)
+	dispatched, err := r.handler.HandleObject(ctx, obj.Object, obj.Resource, obj.EventType)
+	if err != nil {
+		r.platform.forget(obj.Name)
+		r.log.Errorf("while handling synthetic object: %s", err.Error())
+		collector.Failed()
+		return
+	}
+	if !dispatched {
+		r.platform.forget(obj.Name)
+		collector.Skipped()
+		return
+	}
+
+	select {
+	case <-received:
+		collector.Sent(time.Since(j.scheduledAt))
+	case <-time.After(deliveryTimeout):
+		r.platform.forget(obj.Name)
+		r.log.Errorf("Event %q wasn't received by the platform within %s", obj.Name, deliveryTimeout)
+		collector.Failed()
+	case <-ctx.Done():
+		r.platform.forget(obj.Name)
+		collector.Failed()
+	}
+}
@@ -0,0 +1,75 @@
+package loadtest_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	logtest "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/kubeshop/botkube/internal/loadtest"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/events"
+)
+
+func TestRunnerRun(t *testing.T) {
+	// given
+	logger, _ := logtest.NewNullLogger()
+	platform := loadtest.NewNoopPlatform()
+	handler := &fakeEventHandler{platform: platform, skipType: config.DeleteEvent}
+
+	runner := loadtest.NewRunner(logger, loadtest.NewGenerator(), handler, platform)
+	spec := loadtest.Spec{Rate: 100, Interval: time.Second, Duration: 300 * time.Millisecond, Workers: 2}
+
+	// when
+	report := runner.Run(context.Background(), spec)
+
+	// then
+	assert.NotZero(t, report.Sent)
+	assert.NotZero(t, report.Skipped)
+	assert.Zero(t, report.Failed)
+	assert.LessOrEqual(t, report.Sent+report.Skipped, 30)
+	assert.LessOrEqual(t, report.LatencyP50, report.LatencyP99)
+	assert.LessOrEqual(t, report.LatencyP99, report.LatencyMax)
+	assert.LessOrEqual(t, handler.maxInFlight, 2)
+}
+
+// fakeEventHandler sends the events to the platform asynchronously, in the same way as the controller does.
+type fakeEventHandler struct {
+	platform *loadtest.NoopPlatform
+	skipType config.EventType
+
+	mu          sync.Mutex
+	inFlight    int
+	maxInFlight int
+}
+
+func (f *fakeEventHandler) HandleObject(ctx context.Context, obj *unstructured.Unstructured, resource string, eventType config.EventType) (bool, error) {
+	f.mu.Lock()
+	f.inFlight++
+	if f.inFlight > f.maxInFlight {
+		f.maxInFlight = f.inFlight
+	}
+	f.mu.Unlock()
+	defer func() {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		f.inFlight--
+	}()
+
+	if eventType == f.skipType {
+		return false, nil
+	}
+
+	name := obj.GetName()
+	if eventType == config.ErrorEvent {
+		name, _, _ = unstructured.NestedString(obj.Object, "involvedObject", "name")
+	}
+	go func() {
+		_ = f.platform.SendEvent(ctx, events.Event{Name: name, Resource: resource, Type: eventType}, nil)
+	}()
+	return true, nil
+}
@@ -0,0 +1,133 @@
+package loadtest
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/pflag"
+)
+
+const (
+	rateSpecKey     = "rate"
+	durationSpecKey = "duration"
+	workersSpecKey  = "workers"
+	specKeyValueSep = "="
+	rateUnitSep     = "/"
+)
+
+// DefaultWorkers is the number of workers processing the events, if not specified.
+const DefaultWorkers = 32
+
+var syntheticEventsFlag string
+
+// Spec describes the synthetic events load test.
+type Spec struct {
+	// Rate is the number of events generated per Interval.
+	Rate int
+	// Interval is the time unit of the Rate.
+	Interval time.Duration
+	// Duration is the total duration of the load test.
+	Duration time.Duration
+	// Workers is the number of events processed concurrently. DefaultWorkers is used if not specified.
+	Workers int
+}
+
+// RegisterFlags registers load test related flags.
+func RegisterFlags(flags *pflag.FlagSet) {
+	flags.StringVar(&syntheticEventsFlag, "synthetic-events", "", `Run Botkube in load test mode with synthetic events, e.g. "rate=100/s duration=5m workers=32".`)
+}
+
+// SpecFromFlag returns the load test specification parsed from the '--synthetic-events' flag.
+// The second returned value is false if the flag is not set.
+func SpecFromFlag() (Spec, bool, error) {
+	if strings.TrimSpace(syntheticEventsFlag) == "" {
+		return Spec{}, false, nil
+	}
+
+	spec, err := ParseSpec(syntheticEventsFlag)
+	if err != nil {
+		return Spec{}, false, err
+	}
+
+	return spec, true, nil
+}
+
+// ParseSpec parses the load test specification in the "rate=100/s duration=5m workers=32" format. Workers are optional.
+// Key-value pairs can be separated either with spaces or commas.
+func ParseSpec(in string) (Spec, error) {
+	spec := Spec{}
+
+	fields := strings.FieldsFunc(in, func(r rune) bool {
+		return r == ' ' || r == ','
+	})
+	for _, field := range fields {
+		key, value, found := strings.Cut(field, specKeyValueSep)
+		if !found {
+			return Spec{}, fmt.Errorf("invalid entry %q: expected key=value format", field)
+		}
+
+		switch key {
+		case rateSpecKey:
+			rate, interval, err := parseRate(value)
+			if err != nil {
+				return Spec{}, fmt.Errorf("while parsing %q: %w", rateSpecKey, err)
+			}
+			spec.Rate, spec.Interval = rate, interval
+		case durationSpecKey:
+			duration, err := time.ParseDuration(value)
+			if err != nil {
+				return Spec{}, fmt.Errorf("while parsing %q: %w", durationSpecKey, err)
+			}
+			spec.Duration = duration
+		case workersSpecKey:
+			workers, err := strconv.Atoi(value)
+			if err != nil {
+				return Spec{}, fmt.Errorf("while parsing %q: %w", workersSpecKey, err)
+			}
+			spec.Workers = workers
+		default:
+			return Spec{}, fmt.Errorf("unknown key %q", key)
+		}
+	}
+
+	if spec.Rate <= 0 {
+		return Spec{}, fmt.Errorf("%q must be set to a positive value", rateSpecKey)
+	}
+	if spec.Duration <= 0 {
+		return Spec{}, fmt.Errorf("%q must be set to a positive value", durationSpecKey)
+	}
+	if spec.Workers < 0 {
+		return Spec{}, fmt.Errorf("%q must not be negative", workersSpecKey)
+	}
+
+	return spec, nil
+}
+
+// parseRate parses rate in the "100/s" format. If the unit is omitted, events per second are assumed.
+func parseRate(in string) (int, time.Duration, error) {
+	rawCount, rawUnit, found := strings.Cut(in, rateUnitSep)
+	if !found {
+		rawUnit = "s"
+	}
+
+	count, err := strconv.Atoi(rawCount)
+	if err != nil {
+		return 0, 0, fmt.Errorf("while parsing events count: %w", err)
+	}
+
+	var interval time.Duration
+	switch rawUnit {
+	case "s":
+		interval = time.Second
+	case "m":
+		interval = time.Minute
+	case "h":
+		interval = time.Hour
+	default:
+		return 0, 0, fmt.Errorf("unsupported unit %q: use one of s, m, h", rawUnit)
+	}
+
+	return count, interval, nil
+}
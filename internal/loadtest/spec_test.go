@@ -0,0 +1,93 @@
+package loadtest_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/internal/loadtest"
+)
+
+func TestParseSpec(t *testing.T) {
+	tests := []struct {
+		name     string
+		in       string
+		expected loadtest.Spec
+	}{
+		{
+			name:     "Space separated",
+			in:       "rate=100/s duration=5m",
+			expected: loadtest.Spec{Rate: 100, Interval: time.Second, Duration: 5 * time.Minute},
+		},
+		{
+			name:     "Comma separated",
+			in:       "duration=1h,rate=60/m",
+			expected: loadtest.Spec{Rate: 60, Interval: time.Minute, Duration: time.Hour},
+		},
+		{
+			name:     "Rate without unit",
+			in:       "rate=10 duration=30s",
+			expected: loadtest.Spec{Rate: 10, Interval: time.Second, Duration: 30 * time.Second},
+		},
+		{
+			name:     "With workers",
+			in:       "rate=1000/s duration=1m workers=8",
+			expected: loadtest.Spec{Rate: 1000, Interval: time.Second, Duration: time.Minute, Workers: 8},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// when
+			actual, err := loadtest.ParseSpec(tc.in)
+
+			// then
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
+func TestParseSpecErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		in     string
+		errMsg string
+	}{
+		{
+			name:   "Missing rate",
+			in:     "duration=5m",
+			errMsg: `"rate" must be set to a positive value`,
+		},
+		{
+			name:   "Missing duration",
+			in:     "rate=100/s",
+			errMsg: `"duration" must be set to a positive value`,
+		},
+		{
+			name:   "Unknown unit",
+			in:     "rate=100/d duration=5m",
+			errMsg: `while parsing "rate": unsupported unit "d": use one of s, m, h`,
+		},
+		{
+			name:   "Unknown key",
+			in:     "rate=100/s duration=5m burst=10",
+			errMsg: `unknown key "burst"`,
+		},
+		{
+			name:   "Invalid entry",
+			in:     "rate",
+			errMsg: `invalid entry "rate": expected key=value format`,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// when
+			_, err := loadtest.ParseSpec(tc.in)
+
+			// then
+			assert.EqualError(t, err, tc.errMsg)
+		})
+	}
+}
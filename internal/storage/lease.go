@@ -0,0 +1,167 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/google/uuid"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/utils/pointer"
+)
+
+const (
+	defaultLeaseDuration     = 15 * time.Second
+	defaultLeaseRetryPeriod  = 500 * time.Millisecond
+	defaultLeaseWaitTimeout  = 30 * time.Second
+	leaseReleaseTimeout      = 5 * time.Second
+	leaseHolderIdentityFmt   = "%s_%s"
+	leaseHolderFallbackIdent = "botkube"
+)
+
+// Lease provides functionality to coordinate concurrent writes to the Botkube state
+// using the Kubernetes Lease resource.
+//
+// The Lease is acquired with optimistic concurrency: every update is sent with the
+// resource version that was read before, so only a single writer can win.
+type Lease struct {
+	name      string
+	namespace string
+	identity  string
+
+	leaseDuration time.Duration
+	retryPeriod   time.Duration
+	waitTimeout   time.Duration
+
+	k8sCli kubernetes.Interface
+}
+
+// NewLease returns a new Lease instance.
+func NewLease(ns, name string, k8sCli kubernetes.Interface) *Lease {
+	hostname, err := os.Hostname()
+	if err != nil || hostname == "" {
+		hostname = leaseHolderFallbackIdent
+	}
+
+	return &Lease{
+		name:          name,
+		namespace:     ns,
+		identity:      fmt.Sprintf(leaseHolderIdentityFmt, hostname, uuid.New().String()),
+		leaseDuration: defaultLeaseDuration,
+		retryPeriod:   defaultLeaseRetryPeriod,
+		waitTimeout:   defaultLeaseWaitTimeout,
+		k8sCli:        k8sCli,
+	}
+}
+
+// WithLock acquires the Lease, executes a given function and releases the Lease afterwards.
+func (l *Lease) WithLock(ctx context.Context, fn func(ctx context.Context) error) error {
+	if err := l.acquire(ctx); err != nil {
+		return fmt.Errorf("while acquiring the %q Lease: %w", l.name, err)
+	}
+	defer l.release()
+
+	return fn(ctx)
+}
+
+func (l *Lease) acquire(ctx context.Context) error {
+	waitCtx, cancel := context.WithTimeout(ctx, l.waitTimeout)
+	defer cancel()
+
+	return wait.PollImmediateUntilWithContext(waitCtx, l.retryPeriod, func(ctx context.Context) (bool, error) {
+		return l.tryAcquire(ctx)
+	})
+}
+
+func (l *Lease) tryAcquire(ctx context.Context) (bool, error) {
+	now := metav1.NewMicroTime(time.Now())
+
+	leaseCli := l.k8sCli.CoordinationV1().Leases(l.namespace)
+	lease, err := leaseCli.Get(ctx, l.name, metav1.GetOptions{})
+	switch {
+	case err == nil:
+	case apierrors.IsNotFound(err):
+		_, err := leaseCli.Create(ctx, &coordinationv1.Lease{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      l.name,
+				Namespace: l.namespace,
+			},
+			Spec: l.leaseSpec(now),
+		}, metav1.CreateOptions{})
+		if apierrors.IsAlreadyExists(err) {
+			return false, nil // someone was faster, try again
+		}
+		if err != nil {
+			return false, fmt.Errorf("while creating Lease: %w", err)
+		}
+		return true, nil
+	default:
+		return false, fmt.Errorf("while getting Lease: %w", err)
+	}
+
+	if l.isHeldByOther(lease.Spec, now) {
+		return false, nil
+	}
+
+	// the resource version is preserved, so the update fails if somebody else modified the Lease in the meantime
+	toUpdate := lease.DeepCopy()
+	toUpdate.Spec = l.leaseSpec(now)
+	_, err = leaseCli.Update(ctx, toUpdate, metav1.UpdateOptions{})
+	if apierrors.IsConflict(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("while updating Lease: %w", err)
+	}
+
+	return true, nil
+}
+
+// release releases the Lease. It's a best-effort operation, as the Lease expires anyway.
+func (l *Lease) release() {
+	ctx, cancel := context.WithTimeout(context.Background(), leaseReleaseTimeout)
+	defer cancel()
+
+	leaseCli := l.k8sCli.CoordinationV1().Leases(l.namespace)
+	lease, err := leaseCli.Get(ctx, l.name, metav1.GetOptions{})
+	if err != nil {
+		return
+	}
+
+	if lease.Spec.HolderIdentity == nil || *lease.Spec.HolderIdentity != l.identity {
+		return
+	}
+
+	toUpdate := lease.DeepCopy()
+	toUpdate.Spec.HolderIdentity = nil
+	toUpdate.Spec.AcquireTime = nil
+	toUpdate.Spec.RenewTime = nil
+	_, _ = leaseCli.Update(ctx, toUpdate, metav1.UpdateOptions{})
+}
+
+func (l *Lease) isHeldByOther(spec coordinationv1.LeaseSpec, now metav1.MicroTime) bool {
+	if spec.HolderIdentity == nil || *spec.HolderIdentity == "" || *spec.HolderIdentity == l.identity {
+		return false
+	}
+
+	if spec.RenewTime == nil || spec.LeaseDurationSeconds == nil {
+		return false
+	}
+
+	expiresAt := spec.RenewTime.Add(time.Duration(*spec.LeaseDurationSeconds) * time.Second)
+	return now.Time.Before(expiresAt)
+}
+
+func (l *Lease) leaseSpec(now metav1.MicroTime) coordinationv1.LeaseSpec {
+	return coordinationv1.LeaseSpec{
+		HolderIdentity:       pointer.String(l.identity),
+		LeaseDurationSeconds: pointer.Int32(int32(l.leaseDuration.Seconds())),
+		AcquireTime:          &now,
+		RenewTime:            &now,
+	}
+}
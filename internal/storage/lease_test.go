@@ -0,0 +1,250 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+	"k8s.io/utils/pointer"
+)
+
+const (
+	testLeaseName      = "botkube-system"
+	testLeaseNamespace = "botkube"
+	testLeaseIdentity  = "botkube-0_me"
+)
+
+func TestLeaseTryAcquire(t *testing.T) {
+	// given
+	now := time.Now()
+	leasesResource := schema.GroupResource{Group: "coordination.k8s.io", Resource: "leases"}
+
+	tests := []struct {
+		name string
+
+		existing  *coordinationv1.Lease
+		reactorFn k8stesting.ReactionFunc
+
+		expAcquired bool
+		expErrMsg   string
+		expHolder   string
+	}{
+		{
+			name:        "Should create Lease if it doesn't exist",
+			expAcquired: true,
+			expHolder:   testLeaseIdentity,
+		},
+		{
+			name:        "Should acquire released Lease",
+			existing:    fixLease(nil, now),
+			expAcquired: true,
+			expHolder:   testLeaseIdentity,
+		},
+		{
+			name:        "Should renew Lease held by itself",
+			existing:    fixLease(pointer.String(testLeaseIdentity), now),
+			expAcquired: true,
+			expHolder:   testLeaseIdentity,
+		},
+		{
+			name:        "Should take over expired Lease",
+			existing:    fixLease(pointer.String("botkube-1_other"), now.Add(-time.Minute)),
+			expAcquired: true,
+			expHolder:   testLeaseIdentity,
+		},
+		{
+			name:        "Should not acquire Lease held by other instance",
+			existing:    fixLease(pointer.String("botkube-1_other"), now),
+			expAcquired: false,
+			expHolder:   "botkube-1_other",
+		},
+		{
+			name:     "Should not acquire Lease on update conflict",
+			existing: fixLease(nil, now),
+			reactorFn: func(action k8stesting.Action) (bool, runtime.Object, error) {
+				if action.GetVerb() != "update" {
+					return false, nil, nil
+				}
+				return true, nil, apierrors.NewConflict(leasesResource, testLeaseName, errors.New("modified"))
+			},
+			expAcquired: false,
+		},
+		{
+			name: "Should not acquire Lease created by other instance in the meantime",
+			reactorFn: func(action k8stesting.Action) (bool, runtime.Object, error) {
+				if action.GetVerb() != "create" {
+					return false, nil, nil
+				}
+				return true, nil, apierrors.NewAlreadyExists(leasesResource, testLeaseName)
+			},
+			expAcquired: false,
+		},
+		{
+			name: "Should return other errors",
+			reactorFn: func(action k8stesting.Action) (bool, runtime.Object, error) {
+				if action.GetVerb() != "get" {
+					return false, nil, nil
+				}
+				return true, nil, apierrors.NewForbidden(leasesResource, testLeaseName, errors.New("no access"))
+			},
+			expErrMsg: `while getting Lease: leases.coordination.k8s.io "botkube-system" is forbidden: no access`,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			k8sCli := fake.NewSimpleClientset()
+			if tc.existing != nil {
+				k8sCli = fake.NewSimpleClientset(tc.existing)
+			}
+			if tc.reactorFn != nil {
+				k8sCli.PrependReactor("*", "leases", tc.reactorFn)
+			}
+			lease := NewLease(testLeaseNamespace, testLeaseName, k8sCli)
+			lease.identity = testLeaseIdentity
+
+			// when
+			acquired, err := lease.tryAcquire(context.Background())
+
+			// then
+			if tc.expErrMsg != "" {
+				assert.EqualError(t, err, tc.expErrMsg)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expAcquired, acquired)
+			if tc.expHolder == "" {
+				return
+			}
+
+			got, err := k8sCli.CoordinationV1().Leases(testLeaseNamespace).Get(context.Background(), testLeaseName, metav1.GetOptions{})
+			require.NoError(t, err)
+			require.NotNil(t, got.Spec.HolderIdentity)
+			assert.Equal(t, tc.expHolder, *got.Spec.HolderIdentity)
+		})
+	}
+}
+
+func TestLeaseIsHeldByOther(t *testing.T) {
+	// given
+	now := metav1.NewMicroTime(time.Now())
+	lease := &Lease{identity: testLeaseIdentity}
+
+	tests := []struct {
+		name string
+
+		spec coordinationv1.LeaseSpec
+
+		expHeld bool
+	}{
+		{
+			name:    "Released Lease",
+			spec:    coordinationv1.LeaseSpec{},
+			expHeld: false,
+		},
+		{
+			name:    "Lease with empty holder",
+			spec:    fixLease(pointer.String(""), now.Time).Spec,
+			expHeld: false,
+		},
+		{
+			name:    "Lease held by itself",
+			spec:    fixLease(pointer.String(testLeaseIdentity), now.Time).Spec,
+			expHeld: false,
+		},
+		{
+			name:    "Lease held by other instance",
+			spec:    fixLease(pointer.String("botkube-1_other"), now.Add(-5*time.Second)).Spec,
+			expHeld: true,
+		},
+		{
+			name:    "Expired Lease held by other instance",
+			spec:    fixLease(pointer.String("botkube-1_other"), now.Add(-16*time.Second)).Spec,
+			expHeld: false,
+		},
+		{
+			name: "Lease without renew time",
+			spec: coordinationv1.LeaseSpec{
+				HolderIdentity:       pointer.String("botkube-1_other"),
+				LeaseDurationSeconds: pointer.Int32(15),
+			},
+			expHeld: false,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// when
+			held := lease.isHeldByOther(tc.spec, now)
+
+			// then
+			assert.Equal(t, tc.expHeld, held)
+		})
+	}
+}
+
+func TestLeaseWithLockReleasesLease(t *testing.T) {
+	// given
+	k8sCli := fake.NewSimpleClientset()
+	lease := NewLease(testLeaseNamespace, testLeaseName, k8sCli)
+
+	// when
+	var heldInFn bool
+	err := lease.WithLock(context.Background(), func(ctx context.Context) error {
+		got, err := k8sCli.CoordinationV1().Leases(testLeaseNamespace).Get(ctx, testLeaseName, metav1.GetOptions{})
+		require.NoError(t, err)
+		heldInFn = got.Spec.HolderIdentity != nil && *got.Spec.HolderIdentity == lease.identity
+		return nil
+	})
+
+	// then
+	require.NoError(t, err)
+	assert.True(t, heldInFn)
+
+	got, err := k8sCli.CoordinationV1().Leases(testLeaseNamespace).Get(context.Background(), testLeaseName, metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Nil(t, got.Spec.HolderIdentity)
+}
+
+func TestLeaseWithLockTimeout(t *testing.T) {
+	// given
+	k8sCli := fake.NewSimpleClientset(fixLease(pointer.String("botkube-1_other"), time.Now()))
+	lease := NewLease(testLeaseNamespace, testLeaseName, k8sCli)
+	lease.retryPeriod = 10 * time.Millisecond
+	lease.waitTimeout = 50 * time.Millisecond
+
+	// when
+	called := false
+	err := lease.WithLock(context.Background(), func(context.Context) error {
+		called = true
+		return nil
+	})
+
+	// then
+	assert.EqualError(t, err, `while acquiring the "botkube-system" Lease: timed out waiting for the condition`)
+	assert.False(t, called)
+}
+
+func fixLease(holder *string, renewTime time.Time) *coordinationv1.Lease {
+	renew := metav1.NewMicroTime(renewTime)
+	return &coordinationv1.Lease{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      testLeaseName,
+			Namespace: testLeaseNamespace,
+		},
+		Spec: coordinationv1.LeaseSpec{
+			HolderIdentity:       holder,
+			LeaseDurationSeconds: pointer.Int32(15),
+			AcquireTime:          &renew,
+			RenewTime:            &renew,
+		},
+	}
+}
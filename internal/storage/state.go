@@ -0,0 +1,223 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/util/retry"
+)
+
+const (
+	stateObjectNameFmt = "%s-state"
+	stateDataKey       = "state.json"
+	stateVersion       = 1
+
+	stateManagedByLabelKey   = "app.kubernetes.io/managed-by"
+	stateManagedByLabelValue = "botkube"
+)
+
+// State holds the Botkube runtime state which is not a part of the Botkube configuration.
+type State struct {
+	// Version is the version of the state layout. It's used to migrate the state once its layout changes.
+	Version int `json:"version"`
+	// SentHelp holds the keys of the bots which already sent the help message.
+	SentHelp HelpEntries `json:"sentHelp,omitempty"`
+}
+
+// StateStore persists the State in a dedicated ConfigMap owned by Botkube.
+// Unlike the system ConfigMap, the state object is not managed by Helm, so chart upgrades don't modify it.
+//
+// Modifications are done under the Lease and sent with the resource version which was read before,
+// so concurrent writers don't overwrite each other's changes.
+type StateStore struct {
+	namespace       string
+	name            string
+	systemConfigMap string
+
+	k8sCli kubernetes.Interface
+	lease  *Lease
+}
+
+// NewStateStore returns a new StateStore instance. The state object is named after the system ConfigMap.
+func NewStateStore(ns, systemConfigMapName string, k8sCli kubernetes.Interface, lease *Lease) *StateStore {
+	return &StateStore{
+		namespace:       ns,
+		name:            fmt.Sprintf(stateObjectNameFmt, systemConfigMapName),
+		systemConfigMap: systemConfigMapName,
+		k8sCli:          k8sCli,
+		lease:           lease,
+	}
+}
+
+// Get returns the current state. If the state object doesn't exist yet, the state stored in the system ConfigMap
+// by the previous Botkube versions is returned.
+func (s *StateStore) Get(ctx context.Context) (State, error) {
+	state, _, err := s.get(ctx)
+	return state, err
+}
+
+// Modify reads the current state, applies a given mutation and writes it back.
+// The state object is created if it doesn't exist yet.
+func (s *StateStore) Modify(ctx context.Context, mutateFn func(state *State) error) error {
+	return s.lease.WithLock(ctx, func(ctx context.Context) error {
+		return s.modify(ctx, mutateFn)
+	})
+}
+
+// Migrate moves the state stored in the system ConfigMap by the previous Botkube versions to the state object.
+// It's a no-op if there is nothing to migrate.
+func (s *StateStore) Migrate(ctx context.Context) error {
+	return s.lease.WithLock(ctx, func(ctx context.Context) error {
+		// the legacy state is read only if the state object doesn't exist yet
+		if err := s.modify(ctx, func(*State) error { return nil }); err != nil {
+			return fmt.Errorf("while creating the state object: %w", err)
+		}
+
+		return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+			return s.removeLegacyState(ctx)
+		})
+	})
+}
+
+func (s *StateStore) modify(ctx context.Context, mutateFn func(state *State) error) error {
+	// creating the state object fails if somebody else created it in the meantime, so it's retried as well
+	isConflict := func(err error) bool {
+		return apierrors.IsConflict(err) || apierrors.IsAlreadyExists(err)
+	}
+
+	return retry.OnError(retry.DefaultRetry, isConflict, func() error {
+		state, cm, err := s.get(ctx)
+		if err != nil {
+			return err
+		}
+
+		if err := mutateFn(&state); err != nil {
+			return err
+		}
+
+		return s.save(ctx, cm, state)
+	})
+}
+
+// get returns the current state with the state object. The object is nil if it doesn't exist yet.
+func (s *StateStore) get(ctx context.Context) (State, *corev1.ConfigMap, error) {
+	cm, err := s.k8sCli.CoreV1().ConfigMaps(s.namespace).Get(ctx, s.name, metav1.GetOptions{})
+	switch {
+	case err == nil:
+	case apierrors.IsNotFound(err):
+		state, err := s.getLegacyState(ctx)
+		return state, nil, err
+	default:
+		return State{}, nil, fmt.Errorf("while getting the state object: %w", err)
+	}
+
+	state := State{Version: stateVersion}
+	data, found := cm.Data[stateDataKey]
+	if !found {
+		return state, cm, nil
+	}
+
+	if err := json.Unmarshal([]byte(data), &state); err != nil {
+		return State{}, nil, fmt.Errorf("while unmarshaling the state: %w", err)
+	}
+	if state.Version > stateVersion {
+		return State{}, nil, fmt.Errorf("unsupported state version %d, the latest supported one is %d", state.Version, stateVersion)
+	}
+
+	state.Version = stateVersion
+	return state, cm, nil
+}
+
+// save creates or updates the state object. The resource version of a given object is preserved,
+// so the update fails with conflict if the object was modified in the meantime.
+func (s *StateStore) save(ctx context.Context, cm *corev1.ConfigMap, state State) error {
+	raw, err := json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("while marshaling the state: %w", err)
+	}
+
+	if cm == nil {
+		_, err := s.k8sCli.CoreV1().ConfigMaps(s.namespace).Create(ctx, &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      s.name,
+				Namespace: s.namespace,
+				Labels: map[string]string{
+					stateManagedByLabelKey: stateManagedByLabelValue,
+				},
+			},
+			Data: map[string]string{
+				stateDataKey: string(raw),
+			},
+		}, metav1.CreateOptions{})
+		if err != nil {
+			// wrapped with %w, so the already exists error is still detected by the retry
+			return fmt.Errorf("while creating the state object: %w", err)
+		}
+		return nil
+	}
+
+	toUpdate := cm.DeepCopy()
+	if toUpdate.Data == nil {
+		toUpdate.Data = map[string]string{}
+	}
+	toUpdate.Data[stateDataKey] = string(raw)
+	_, err = s.k8sCli.CoreV1().ConfigMaps(s.namespace).Update(ctx, toUpdate, metav1.UpdateOptions{})
+	if err != nil {
+		// wrapped with %w, so the conflict error is still detected by the retry
+		return fmt.Errorf("while updating the state object: %w", err)
+	}
+	return nil
+}
+
+// getLegacyState returns the state stored in the system ConfigMap by the previous Botkube versions.
+func (s *StateStore) getLegacyState(ctx context.Context) (State, error) {
+	state := State{Version: stateVersion}
+
+	cm, err := s.k8sCli.CoreV1().ConfigMaps(s.namespace).Get(ctx, s.systemConfigMap, metav1.GetOptions{})
+	switch {
+	case err == nil:
+	case apierrors.IsNotFound(err):
+		return state, nil
+	default:
+		return State{}, fmt.Errorf("while getting the system ConfigMap: %w", err)
+	}
+
+	data, found := cm.Data[legacyHelpKey]
+	if !found {
+		return state, nil
+	}
+
+	if err := json.Unmarshal([]byte(data), &state.SentHelp); err != nil {
+		return State{}, fmt.Errorf("while unmarshaling the help data from the system ConfigMap: %w", err)
+	}
+	return state, nil
+}
+
+// removeLegacyState removes the migrated state from the system ConfigMap.
+func (s *StateStore) removeLegacyState(ctx context.Context) error {
+	cm, err := s.k8sCli.CoreV1().ConfigMaps(s.namespace).Get(ctx, s.systemConfigMap, metav1.GetOptions{})
+	switch {
+	case err == nil:
+	case apierrors.IsNotFound(err):
+		return nil
+	default:
+		return fmt.Errorf("while getting the system ConfigMap: %w", err)
+	}
+
+	if _, found := cm.Data[legacyHelpKey]; !found {
+		return nil
+	}
+
+	toUpdate := cm.DeepCopy()
+	delete(toUpdate.Data, legacyHelpKey)
+	_, err = s.k8sCli.CoreV1().ConfigMaps(s.namespace).Update(ctx, toUpdate, metav1.UpdateOptions{})
+	if err != nil {
+		return fmt.Errorf("while removing the migrated state from the system ConfigMap: %w", err)
+	}
+	return nil
+}
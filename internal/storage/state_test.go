@@ -0,0 +1,185 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+const testStateObjectName = "botkube-system-state"
+
+func TestStateStoreMigrate(t *testing.T) {
+	// given
+	tests := []struct {
+		name string
+
+		existing []runtime.Object
+
+		expState        string
+		expSystemCMData map[string]string
+	}{
+		{
+			name: "Should migrate help entries from system ConfigMap",
+			existing: []runtime.Object{
+				fixSystemConfigMap(map[string]string{legacyHelpKey: `{"slack":true}`, "other": "value"}),
+			},
+			expState:        `{"version":1,"sentHelp":{"slack":true}}`,
+			expSystemCMData: map[string]string{"other": "value"},
+		},
+		{
+			name: "Should create empty state if there is nothing to migrate",
+			existing: []runtime.Object{
+				fixSystemConfigMap(nil),
+			},
+			expState: `{"version":1}`,
+		},
+		{
+			name:     "Should create empty state without system ConfigMap",
+			expState: `{"version":1}`,
+		},
+		{
+			name: "Should keep already migrated state and remove leftovers",
+			existing: []runtime.Object{
+				fixSystemConfigMap(map[string]string{legacyHelpKey: `{"slack":true}`}),
+				fixStateObject(`{"version":1,"sentHelp":{"discord":true}}`),
+			},
+			expState:        `{"version":1,"sentHelp":{"discord":true}}`,
+			expSystemCMData: map[string]string{},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			k8sCli := fake.NewSimpleClientset(tc.existing...)
+			store := NewStateStore(testLeaseNamespace, testLeaseName, k8sCli, NewLease(testLeaseNamespace, testLeaseName, k8sCli))
+
+			// when
+			err := store.Migrate(context.Background())
+
+			// then
+			require.NoError(t, err)
+
+			stateObj, err := k8sCli.CoreV1().ConfigMaps(testLeaseNamespace).Get(context.Background(), testStateObjectName, metav1.GetOptions{})
+			require.NoError(t, err)
+			assert.JSONEq(t, tc.expState, stateObj.Data[stateDataKey])
+			assert.Equal(t, stateManagedByLabelValue, stateObj.Labels[stateManagedByLabelKey])
+
+			systemCM, err := k8sCli.CoreV1().ConfigMaps(testLeaseNamespace).Get(context.Background(), testLeaseName, metav1.GetOptions{})
+			if apierrors.IsNotFound(err) {
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expSystemCMData, systemCM.Data)
+		})
+	}
+}
+
+func TestStateStoreGet(t *testing.T) {
+	// given
+	tests := []struct {
+		name string
+
+		existing []runtime.Object
+
+		expState  State
+		expErrMsg string
+	}{
+		{
+			name: "Should return state from state object",
+			existing: []runtime.Object{
+				fixStateObject(`{"version":1,"sentHelp":{"discord":true}}`),
+			},
+			expState: State{Version: 1, SentHelp: HelpEntries{"discord": true}},
+		},
+		{
+			name: "Should return legacy state if state object doesn't exist",
+			existing: []runtime.Object{
+				fixSystemConfigMap(map[string]string{legacyHelpKey: `{"slack":true}`}),
+			},
+			expState: State{Version: 1, SentHelp: HelpEntries{"slack": true}},
+		},
+		{
+			name: "Should reject newer state version",
+			existing: []runtime.Object{
+				fixStateObject(`{"version":2}`),
+			},
+			expErrMsg: "unsupported state version 2, the latest supported one is 1",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			k8sCli := fake.NewSimpleClientset(tc.existing...)
+			store := NewStateStore(testLeaseNamespace, testLeaseName, k8sCli, NewLease(testLeaseNamespace, testLeaseName, k8sCli))
+
+			// when
+			state, err := store.Get(context.Background())
+
+			// then
+			if tc.expErrMsg != "" {
+				assert.EqualError(t, err, tc.expErrMsg)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expState, state)
+		})
+	}
+}
+
+func TestHelpMarkHelpAsSentRetriesOnConflict(t *testing.T) {
+	// given
+	k8sCli := fake.NewSimpleClientset(fixStateObject(`{"version":1,"sentHelp":{"discord":true}}`))
+	conflicts := 1
+	k8sCli.PrependReactor("update", "configmaps", func(k8stesting.Action) (bool, runtime.Object, error) {
+		if conflicts == 0 {
+			return false, nil, nil
+		}
+		conflicts--
+		return true, nil, apierrors.NewConflict(schema.GroupResource{Resource: "configmaps"}, testStateObjectName, errors.New("modified"))
+	})
+	help := NewForHelp(NewStateStore(testLeaseNamespace, testLeaseName, k8sCli, NewLease(testLeaseNamespace, testLeaseName, k8sCli)))
+
+	// when
+	err := help.MarkHelpAsSent(context.Background(), []string{"slack"})
+
+	// then
+	require.NoError(t, err)
+	assert.Zero(t, conflicts)
+
+	entries, err := help.GetSentHelpDetails(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, HelpEntries{"discord": true, "slack": true}, entries)
+}
+
+func fixSystemConfigMap(data map[string]string) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      testLeaseName,
+			Namespace: testLeaseNamespace,
+		},
+		Data: data,
+	}
+}
+
+func fixStateObject(state string) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      testStateObjectName,
+			Namespace: testLeaseNamespace,
+			Labels: map[string]string{
+				stateManagedByLabelKey: stateManagedByLabelValue,
+			},
+		},
+		Data: map[string]string{
+			stateDataKey: state,
+		},
+	}
+}
@@ -7,15 +7,19 @@ import (
 	"fmt"
 	"html/template"
 	"strings"
+	"sync"
+	"time"
 
 	sprig "github.com/go-task/slim-sprig"
 	"github.com/sirupsen/logrus"
 
+	"github.com/kubeshop/botkube/pkg/approval"
 	"github.com/kubeshop/botkube/pkg/bot/interactive"
 	"github.com/kubeshop/botkube/pkg/config"
 	"github.com/kubeshop/botkube/pkg/events"
 	"github.com/kubeshop/botkube/pkg/execute"
 	"github.com/kubeshop/botkube/pkg/execute/command"
+	"github.com/kubeshop/botkube/pkg/expression"
 	"github.com/kubeshop/botkube/pkg/multierror"
 	"github.com/kubeshop/botkube/pkg/sliceutil"
 )
@@ -33,23 +37,43 @@ type ExecutorFactory interface {
 	NewDefault(cfg execute.NewDefaultInput) execute.Executor
 }
 
+// ApprovalRequester requests approvals of the action commands.
+type ApprovalRequester interface {
+	Request(ctx context.Context, action events.Action, cfg config.ActionApproval) (approval.Decision, error)
+}
+
 // Provider provides automations for events.
 type Provider struct {
 	log             logrus.FieldLogger
 	cfg             config.Actions
 	executorFactory ExecutorFactory
+	approvals       ApprovalRequester
+	// conditions holds the compiled action conditions, indexed by the action name.
+	conditions map[string]*expression.Program
+
+	mu sync.Mutex
+	// lastExecutions holds the last execution time of the actions with cooldown, indexed by the action name.
+	lastExecutions map[string]time.Time
 }
 
 // NewProvider returns new instance of Provider.
-func NewProvider(log logrus.FieldLogger, cfg config.Actions, executorFactory ExecutorFactory) *Provider {
-	return &Provider{log: log, cfg: cfg, executorFactory: executorFactory}
+func NewProvider(log logrus.FieldLogger, cfg config.Actions, executorFactory ExecutorFactory, approvals ApprovalRequester) *Provider {
+	return &Provider{
+		log:             log,
+		cfg:             cfg,
+		executorFactory: executorFactory,
+		approvals:       approvals,
+		conditions:      compileConditions(log, cfg),
+		lastExecutions:  map[string]time.Time{},
+	}
 }
 
 // RenderedActionsForEvent finds and processes actions for given event.
 func (p *Provider) RenderedActionsForEvent(event events.Event, sourceBindings []string) ([]events.Action, error) {
 	var actions []events.Action
 	errs := multierror.New()
-	for _, action := range p.cfg {
+	var vars map[string]interface{}
+	for name, action := range p.cfg {
 		if !action.Enabled {
 			continue
 		}
@@ -58,6 +82,15 @@ func (p *Provider) RenderedActionsForEvent(event events.Event, sourceBindings []
 			continue
 		}
 
+		if action.Condition != "" {
+			if vars == nil {
+				vars = events.ExpressionVars(event)
+			}
+			if !p.matchesCondition(name, vars) {
+				continue
+			}
+		}
+
 		p.log.Debugf("Rendering Action %q (command: %q)...", action.DisplayName, action.Command)
 		renderingData := renderingData{
 			Event: event,
@@ -71,18 +104,56 @@ func (p *Provider) RenderedActionsForEvent(event events.Event, sourceBindings []
 		p.log.Debugf("Rendered command: %q", renderedCmd)
 
 		actions = append(actions, events.Action{
+			Name:             name,
 			DisplayName:      action.DisplayName,
 			Command:          fmt.Sprintf("%s %s", universalBotNamePlaceholder, renderedCmd),
 			ExecutorBindings: action.Bindings.Executors,
+			DryRun:           action.DryRun,
 		})
 	}
 
 	return actions, errs.ErrorOrNil()
 }
 
-// ExecuteEventAction executes action for given event.
+// AllowExecution returns false if the action was executed within its cooldown. Otherwise, it starts a new cooldown period.
+func (p *Provider) AllowExecution(action events.Action) bool {
+	cooldown := p.cfg[action.Name].Cooldown
+	if cooldown <= 0 {
+		return true
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := time.Now()
+	if last, found := p.lastExecutions[action.Name]; found && now.Sub(last) < cooldown {
+		return false
+	}
+	p.lastExecutions[action.Name] = now
+	return true
+}
+
+// ExecuteEventAction executes action for given event. In the dry-run mode, the command is only described.
+// If the action requires approval, it blocks until the command is approved, rejected or the request times out.
 // WARNING: The result interactive.Message contains BotNamePlaceholder, which should be replaced before sending the message.
 func (p *Provider) ExecuteEventAction(ctx context.Context, action events.Action) interactive.GenericMessage {
+	if action.DryRun {
+		return &genericMessage{response: dryRunMessage(action)}
+	}
+
+	user := fmt.Sprintf("Automation %q", action.DisplayName)
+	if approvalCfg := p.cfg[action.Name].Approval; approvalCfg.Enabled {
+		decision, err := p.requestApproval(ctx, action, approvalCfg)
+		if err != nil {
+			p.log.Errorf("while requesting approval of Action %q: %s", action.DisplayName, err.Error())
+			return &genericMessage{response: notApprovedMessage(action, err.Error())}
+		}
+		if !decision.Approved {
+			return &genericMessage{response: notApprovedMessage(action, fmt.Sprintf("rejected by %s", decision.User))}
+		}
+		user = fmt.Sprintf("%s approved by %s", user, decision.User)
+	}
+
 	e := p.executorFactory.NewDefault(execute.NewDefaultInput{
 		Conversation: execute.Conversation{
 			IsAuthenticated:  true,
@@ -94,14 +165,84 @@ func (p *Provider) ExecuteEventAction(ctx context.Context, action events.Action)
 		CommGroupName:   unknownValue,
 		Platform:        unknownValue,
 		NotifierHandler: &universalNotifierHandler{},
-		Message:         strings.TrimSpace(strings.TrimPrefix(action.Command, universalBotNamePlaceholder)),
-		User:            fmt.Sprintf("Automation %q", action.DisplayName),
+		Message:         trimBotName(action.Command),
+		User:            user,
 	})
 	response := e.Execute(ctx)
 
 	return &genericMessage{response: response}
 }
 
+func (p *Provider) requestApproval(ctx context.Context, action events.Action, cfg config.ActionApproval) (approval.Decision, error) {
+	if p.approvals == nil {
+		return approval.Decision{}, errors.New("approvals are not supported")
+	}
+
+	// the request is posted as-is, so the bot name placeholder is removed
+	action.Command = trimBotName(action.Command)
+	return p.approvals.Request(ctx, action, cfg)
+}
+
+func (p *Provider) matchesCondition(name string, vars map[string]interface{}) bool {
+	program := p.conditions[name]
+	if program == nil {
+		// the condition failed to compile
+		return false
+	}
+
+	matches, err := program.EvalBool(vars)
+	if err != nil {
+		p.log.Debugf("while evaluating condition %q of Action %q: %s", program.String(), name, err.Error())
+		return false
+	}
+	return matches
+}
+
+// compileConditions returns the compiled action conditions, indexed by the action name.
+// Actions with invalid conditions never run, so a typo doesn't make them run for all events.
+func compileConditions(log logrus.FieldLogger, cfg config.Actions) map[string]*expression.Program {
+	out := map[string]*expression.Program{}
+	for name, action := range cfg {
+		program, err := action.CompileCondition()
+		if err != nil {
+			log.Errorf("while compiling condition of Action %q: %s", name, err.Error())
+			continue
+		}
+		if program != nil {
+			out[name] = program
+		}
+	}
+	return out
+}
+
+func dryRunMessage(action events.Action) interactive.Message {
+	return interactive.Message{
+		Base: interactive.Base{
+			Header:      fmt.Sprintf("Action %q (dry run)", action.DisplayName),
+			Description: "The following command would be executed:",
+			Body: interactive.Body{
+				CodeBlock: trimBotName(action.Command),
+			},
+		},
+	}
+}
+
+func notApprovedMessage(action events.Action, reason string) interactive.Message {
+	return interactive.Message{
+		Base: interactive.Base{
+			Header:      fmt.Sprintf("Action %q was not executed", action.DisplayName),
+			Description: fmt.Sprintf("The command was not approved: %s.", reason),
+			Body: interactive.Body{
+				CodeBlock: trimBotName(action.Command),
+			},
+		},
+	}
+}
+
+func trimBotName(cmd string) string {
+	return strings.TrimSpace(strings.TrimPrefix(cmd, universalBotNamePlaceholder))
+}
+
 type renderingData struct {
 	Event events.Event
 }
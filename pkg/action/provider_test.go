@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/MakeNowJust/heredoc"
 	logtest "github.com/sirupsen/logrus/hooks/test"
@@ -11,6 +12,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/kubeshop/botkube/pkg/action"
+	"github.com/kubeshop/botkube/pkg/approval"
 	"github.com/kubeshop/botkube/pkg/bot/interactive"
 	"github.com/kubeshop/botkube/pkg/config"
 	"github.com/kubeshop/botkube/pkg/events"
@@ -35,6 +37,7 @@ func TestProvider_RenderedActionsForEvent(t *testing.T) {
 			Event:          fixEvent("name"),
 			ExpectedResult: []events.Action{
 				{
+					Name:             "success",
 					Command:          "{{BotName}} kubectl get po name",
 					ExecutorBindings: []string{"executor-binding1", "executor-binding2"},
 					DisplayName:      "Success",
@@ -55,6 +58,7 @@ func TestProvider_RenderedActionsForEvent(t *testing.T) {
 			Event:          fixEvent("name"),
 			ExpectedResult: []events.Action{
 				{
+					Name:             "success",
 					Command:          "{{BotName}} kubectl get po name",
 					ExecutorBindings: []string{"executor-binding1", "executor-binding2"},
 					DisplayName:      "Success",
@@ -64,12 +68,41 @@ func TestProvider_RenderedActionsForEvent(t *testing.T) {
 				1 error occurred:
 					* while rendering command "kubectl get po {{ .SomethingElse }}" for Action "Invalid Command": template: action-cmd:1:18: executing "action-cmd" at <.SomethingElse>: can't evaluate field SomethingElse in type action.renderingData`),
 		},
+		{
+			Name:           "Condition matching event",
+			Config:         fixActionsConfig(),
+			SourceBindings: []string{"conditional"},
+			Event:          fixEventWithReason("name", "BackOff"),
+			ExpectedResult: []events.Action{
+				{
+					Name:             "conditional",
+					Command:          "{{BotName}} kubectl delete po name",
+					ExecutorBindings: []string{"executor-binding1", "executor-binding2"},
+					DisplayName:      "Conditional",
+					DryRun:           true,
+				},
+			},
+		},
+		{
+			Name:           "Condition not matching event",
+			Config:         fixActionsConfig(),
+			SourceBindings: []string{"conditional"},
+			Event:          fixEventWithReason("name", "Unhealthy"),
+			ExpectedResult: nil,
+		},
+		{
+			Name:           "Invalid condition never matches",
+			Config:         fixActionsConfig(),
+			SourceBindings: []string{"invalid-condition"},
+			Event:          fixEventWithReason("name", "BackOff"),
+			ExpectedResult: nil,
+		},
 	}
 
 	for _, tc := range testCases {
 		t.Run(tc.Name, func(t *testing.T) {
 			log, _ := logtest.NewNullLogger()
-			provider := action.NewProvider(log, tc.Config, nil)
+			provider := action.NewProvider(log, tc.Config, nil, nil)
 
 			// when
 			result, err := provider.RenderedActionsForEvent(tc.Event, tc.SourceBindings)
@@ -112,7 +145,7 @@ func TestProvider_ExecuteEventAction(t *testing.T) {
 	}
 	log, _ := logtest.NewNullLogger()
 	execFactory := &fakeFactory{t: t, expectedInput: expectedExecutorInput}
-	provider := action.NewProvider(log, config.Actions{}, execFactory)
+	provider := action.NewProvider(log, config.Actions{}, execFactory, nil)
 
 	// when
 	res := provider.ExecuteEventAction(context.Background(), eventAction)
@@ -123,6 +156,94 @@ func TestProvider_ExecuteEventAction(t *testing.T) {
 	assert.Equal(t, fixInteractiveMessage(botName), msg)
 }
 
+func TestProvider_ExecuteEventActionDryRun(t *testing.T) {
+	// given
+	eventAction := events.Action{
+		Command:          "{{BotName}} kubectl delete po foo",
+		ExecutorBindings: []string{"executor-binding1"},
+		DisplayName:      "Restart",
+		DryRun:           true,
+	}
+	log, _ := logtest.NewNullLogger()
+	provider := action.NewProvider(log, config.Actions{}, nil, nil)
+
+	// when
+	res := provider.ExecuteEventAction(context.Background(), eventAction)
+
+	msg := res.ForBot("my-bot")
+
+	// then
+	assert.Equal(t, interactive.Message{
+		Base: interactive.Base{
+			Header:      `Action "Restart" (dry run)`,
+			Description: "The following command would be executed:",
+			Body: interactive.Body{
+				CodeBlock: "kubectl delete po foo",
+			},
+		},
+	}, msg)
+}
+
+func TestProvider_ExecuteEventActionRejected(t *testing.T) {
+	// given
+	eventAction := events.Action{
+		Name:             "restart",
+		Command:          "{{BotName}} kubectl delete po foo",
+		ExecutorBindings: []string{"executor-binding1"},
+		DisplayName:      "Restart",
+	}
+	approvalCfg := config.ActionApproval{Enabled: true, Channel: "ops", Approvers: []string{"U01"}}
+	log, _ := logtest.NewNullLogger()
+	approvals := &fakeApprovalRequester{decision: approval.Decision{Approved: false, User: "U01"}}
+	provider := action.NewProvider(log, config.Actions{
+		"restart": {Enabled: true, Command: "kubectl delete po {{ .Event.Name }}", Approval: approvalCfg},
+	}, nil, approvals)
+
+	// when
+	res := provider.ExecuteEventAction(context.Background(), eventAction)
+
+	msg := res.ForBot("my-bot")
+
+	// then
+	assert.Equal(t, interactive.Message{
+		Base: interactive.Base{
+			Header:      `Action "Restart" was not executed`,
+			Description: "The command was not approved: rejected by U01.",
+			Body: interactive.Body{
+				CodeBlock: "kubectl delete po foo",
+			},
+		},
+	}, msg)
+	assert.Equal(t, "kubectl delete po foo", approvals.action.Command)
+	assert.Equal(t, approvalCfg, approvals.cfg)
+}
+
+func TestProvider_AllowExecution(t *testing.T) {
+	// given
+	log, _ := logtest.NewNullLogger()
+	provider := action.NewProvider(log, config.Actions{
+		"with-cooldown": {
+			Enabled:  true,
+			Command:  "kubectl get po",
+			Cooldown: time.Hour,
+		},
+		"without-cooldown": {
+			Enabled: true,
+			Command: "kubectl get po",
+		},
+	}, nil, nil)
+
+	// when
+	first := provider.AllowExecution(events.Action{Name: "with-cooldown"})
+	second := provider.AllowExecution(events.Action{Name: "with-cooldown"})
+
+	// then
+	assert.True(t, first)
+	assert.False(t, second)
+	assert.True(t, provider.AllowExecution(events.Action{Name: "without-cooldown"}))
+	assert.True(t, provider.AllowExecution(events.Action{Name: "without-cooldown"}))
+}
+
 func fixActionsConfig() config.Actions {
 	executorBindings := []string{"executor-binding1", "executor-binding2"}
 	sampleCommand := "kubectl get po {{ .Event.Name }}"
@@ -163,6 +284,27 @@ func fixActionsConfig() config.Actions {
 				Executors: executorBindings,
 			},
 		},
+		"conditional": {
+			Enabled:     true,
+			DisplayName: "Conditional",
+			Command:     "kubectl delete po {{ .Event.Name }}",
+			Condition:   `event.reason == "BackOff"`,
+			DryRun:      true,
+			Bindings: config.ActionBindings{
+				Sources:   []string{"conditional"},
+				Executors: executorBindings,
+			},
+		},
+		"invalid-condition": {
+			Enabled:     true,
+			DisplayName: "Invalid Condition",
+			Command:     sampleCommand,
+			Condition:   `event.reason ==`,
+			Bindings: config.ActionBindings{
+				Sources:   []string{"invalid-condition"},
+				Executors: executorBindings,
+			},
+		},
 	}
 }
 
@@ -172,6 +314,13 @@ func fixEvent(name string) events.Event {
 	}
 }
 
+func fixEventWithReason(name, reason string) events.Event {
+	return events.Event{
+		Name:   name,
+		Reason: reason,
+	}
+}
+
 type fakeFactory struct {
 	t             *testing.T
 	expectedInput execute.NewDefaultInput
@@ -205,3 +354,15 @@ func fixInteractiveMessage(botName string) interactive.Message {
 		},
 	}
 }
+
+type fakeApprovalRequester struct {
+	decision approval.Decision
+	action   events.Action
+	cfg      config.ActionApproval
+}
+
+func (f *fakeApprovalRequester) Request(_ context.Context, action events.Action, cfg config.ActionApproval) (approval.Decision, error) {
+	f.action = action
+	f.cfg = cfg
+	return f.decision, nil
+}
@@ -0,0 +1,223 @@
+// Package approval provides the approval workflow for the automated actions.
+// The proposed command is posted with Approve and Reject buttons to a designated channel,
+// and it's executed only after one of the allowed approvers approves it.
+package approval
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"k8s.io/utils/strings/slices"
+
+	"github.com/kubeshop/botkube/pkg/bot/interactive"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/events"
+)
+
+const (
+	// commandPrefix prefixes the approval button commands. Such commands are handled by the bots directly, and are never executed.
+	commandPrefix  = "action-approval"
+	approveCommand = "approve"
+	rejectCommand  = "reject"
+
+	defaultTimeout = 15 * time.Minute
+)
+
+var (
+	// ErrRequestNotFound is returned when the approval request doesn't exist, e.g. because it has already timed out.
+	ErrRequestNotFound = errors.New("approval request not found or already resolved")
+	// ErrNotApprover is returned when the user is not allowed to approve the action.
+	ErrNotApprover = errors.New("you are not allowed to approve this action")
+)
+
+// Poster posts the approval requests to the communication platform channels.
+type Poster interface {
+	// PostApprovalRequest posts a given message to a given channel. It returns false if the channel is not configured for the poster.
+	PostApprovalRequest(ctx context.Context, channelName string, msg interactive.Message) (bool, error)
+}
+
+// Decision describes the approver's decision.
+type Decision struct {
+	Approved bool
+	// User is the ID of the user who made the decision.
+	User string
+}
+
+// Registry tracks the actions waiting for approval.
+type Registry struct {
+	log logrus.FieldLogger
+
+	mu      sync.Mutex
+	posters []Poster
+	pending map[string]*pendingRequest
+}
+
+type pendingRequest struct {
+	approvers []string
+	decision  chan Decision
+}
+
+// NewRegistry returns a new Registry instance.
+func NewRegistry(log logrus.FieldLogger) *Registry {
+	return &Registry{
+		log:     log,
+		pending: map[string]*pendingRequest{},
+	}
+}
+
+// RegisterPoster registers a poster for the approval requests. The posters are called in order until one of them has a given channel configured.
+func (r *Registry) RegisterPoster(poster Poster) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.posters = append(r.posters, poster)
+}
+
+// Request posts an approval request for a given action and waits until it's approved, rejected or timed out.
+// The action command must not contain the bot name placeholder, as the request is posted as-is.
+func (r *Registry) Request(ctx context.Context, action events.Action, cfg config.ActionApproval) (Decision, error) {
+	timeout := cfg.Timeout
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+
+	id := uuid.New().String()
+	req := &pendingRequest{
+		approvers: cfg.Approvers,
+		decision:  make(chan Decision, 1),
+	}
+
+	r.mu.Lock()
+	r.pending[id] = req
+	posters := r.posters
+	r.mu.Unlock()
+
+	defer r.remove(id)
+
+	if err := r.post(ctx, posters, cfg.Channel, requestMessage(id, action, timeout)); err != nil {
+		return Decision{}, err
+	}
+	r.log.Infof("Waiting for approval of Action %q in channel %q...", action.DisplayName, cfg.Channel)
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	select {
+	case decision := <-req.decision:
+		return decision, nil
+	case <-ctx.Done():
+		return Decision{}, fmt.Errorf("approval request timed out after %s", timeout)
+	}
+}
+
+// Decide records the decision of a given user for a given approval request command.
+func (r *Registry) Decide(cmd, user string) (Decision, error) {
+	id, approved, ok := ParseCommand(cmd)
+	if !ok {
+		return Decision{}, fmt.Errorf("invalid approval command %q", cmd)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	req, found := r.pending[id]
+	if !found {
+		return Decision{}, ErrRequestNotFound
+	}
+	if !slices.Contains(req.approvers, user) {
+		return Decision{}, ErrNotApprover
+	}
+
+	decision := Decision{Approved: approved, User: user}
+	req.decision <- decision
+	delete(r.pending, id)
+	return decision, nil
+}
+
+// IsCommand returns true if a given command is an approval button command.
+func IsCommand(cmd string) bool {
+	_, _, ok := ParseCommand(cmd)
+	return ok
+}
+
+// ParseCommand parses the approval button command, such as `action-approval approve <id>`.
+func ParseCommand(cmd string) (id string, approved bool, ok bool) {
+	fields := strings.Fields(cmd)
+	if len(fields) != 3 || fields[0] != commandPrefix {
+		return "", false, false
+	}
+
+	switch fields[1] {
+	case approveCommand:
+		return fields[2], true, true
+	case rejectCommand:
+		return fields[2], false, true
+	}
+	return "", false, false
+}
+
+// DecisionMessage returns the message which replaces the approval request once the decision is made.
+func DecisionMessage(decision Decision, userMention string) interactive.Message {
+	verb := "rejected"
+	if decision.Approved {
+		verb = "approved"
+	}
+	return interactive.Message{
+		Base: interactive.Base{
+			Description: fmt.Sprintf("Action %s by %s.", verb, userMention),
+		},
+		ReplaceOriginal: true,
+	}
+}
+
+func (r *Registry) post(ctx context.Context, posters []Poster, channel string, msg interactive.Message) error {
+	for _, poster := range posters {
+		posted, err := poster.PostApprovalRequest(ctx, channel, msg)
+		if err != nil {
+			return fmt.Errorf("while posting approval request to channel %q: %w", channel, err)
+		}
+		if posted {
+			return nil
+		}
+	}
+	return fmt.Errorf("channel %q for approval requests is not configured", channel)
+}
+
+func (r *Registry) remove(id string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.pending, id)
+}
+
+func requestMessage(id string, action events.Action, timeout time.Duration) interactive.Message {
+	return interactive.Message{
+		Base: interactive.Base{
+			Header:      fmt.Sprintf("Action %q requires approval", action.DisplayName),
+			Description: fmt.Sprintf("The following command will be executed once approved. The request expires in %s.", timeout),
+			Body: interactive.Body{
+				CodeBlock: action.Command,
+			},
+		},
+		Sections: []interactive.Section{
+			{
+				Buttons: interactive.Buttons{
+					{
+						Name:    "Approve",
+						Command: fmt.Sprintf("%s %s %s", commandPrefix, approveCommand, id),
+						Style:   interactive.ButtonStylePrimary,
+					},
+					{
+						Name:    "Reject",
+						Command: fmt.Sprintf("%s %s %s", commandPrefix, rejectCommand, id),
+						Style:   interactive.ButtonStyleDanger,
+					},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,165 @@
+package approval
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	logtest "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/pkg/bot/interactive"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/events"
+)
+
+func TestRegistryRequest(t *testing.T) {
+	tests := []struct {
+		name         string
+		user         string
+		button       int
+		expDecision  Decision
+		expDecideErr error
+	}{
+		{
+			name:        "Approved",
+			user:        "U01",
+			button:      0,
+			expDecision: Decision{Approved: true, User: "U01"},
+		},
+		{
+			name:        "Rejected",
+			user:        "U02",
+			button:      1,
+			expDecision: Decision{Approved: false, User: "U02"},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// given
+			poster := &fakePoster{channel: "ops", posted: make(chan interactive.Message, 1)}
+			registry := fixRegistry(poster)
+
+			var (
+				decision Decision
+				err      error
+				wg       sync.WaitGroup
+			)
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				decision, err = registry.Request(context.Background(), fixAction(), fixApprovalConfig(time.Minute))
+			}()
+			msg := <-poster.posted
+
+			// when
+			_, decideErr := registry.Decide(msg.Sections[0].Buttons[tc.button].Command, tc.user)
+			wg.Wait()
+
+			// then
+			require.NoError(t, decideErr)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expDecision, decision)
+			assert.Equal(t, `Action "Restart Pod" requires approval`, msg.Header)
+			assert.Equal(t, "kubectl delete pod api-7d9f", msg.Body.CodeBlock)
+		})
+	}
+}
+
+func TestRegistryDecideNotApprover(t *testing.T) {
+	// given
+	poster := &fakePoster{channel: "ops", posted: make(chan interactive.Message, 1)}
+	registry := fixRegistry(poster)
+
+	errs := make(chan error, 1)
+	go func() {
+		_, err := registry.Request(context.Background(), fixAction(), fixApprovalConfig(100*time.Millisecond))
+		errs <- err
+	}()
+	msg := <-poster.posted
+
+	// when
+	_, err := registry.Decide(msg.Sections[0].Buttons[0].Command, "U03")
+
+	// then
+	assert.ErrorIs(t, err, ErrNotApprover)
+	assert.EqualError(t, <-errs, "approval request timed out after 100ms")
+
+	_, err = registry.Decide(msg.Sections[0].Buttons[0].Command, "U01")
+	assert.ErrorIs(t, err, ErrRequestNotFound)
+}
+
+func TestRegistryRequestUnknownChannel(t *testing.T) {
+	// given
+	registry := fixRegistry(&fakePoster{channel: "general"})
+
+	// when
+	_, err := registry.Request(context.Background(), fixAction(), fixApprovalConfig(time.Minute))
+
+	// then
+	assert.EqualError(t, err, `channel "ops" for approval requests is not configured`)
+}
+
+func TestParseCommand(t *testing.T) {
+	tests := []struct {
+		cmd         string
+		expID       string
+		expApproved bool
+		expOK       bool
+	}{
+		{cmd: "action-approval approve 123", expID: "123", expApproved: true, expOK: true},
+		{cmd: "action-approval reject 123", expID: "123", expApproved: false, expOK: true},
+		{cmd: "action-approval delete 123", expOK: false},
+		{cmd: "kubectl get pods", expOK: false},
+	}
+	for _, tc := range tests {
+		t.Run(tc.cmd, func(t *testing.T) {
+			// when
+			id, approved, ok := ParseCommand(tc.cmd)
+
+			// then
+			assert.Equal(t, tc.expID, id)
+			assert.Equal(t, tc.expApproved, approved)
+			assert.Equal(t, tc.expOK, ok)
+		})
+	}
+}
+
+func fixRegistry(poster Poster) *Registry {
+	logger, _ := logtest.NewNullLogger()
+	registry := NewRegistry(logger)
+	registry.RegisterPoster(poster)
+	return registry
+}
+
+func fixAction() events.Action {
+	return events.Action{
+		Name:        "restart-pod",
+		DisplayName: "Restart Pod",
+		Command:     "kubectl delete pod api-7d9f",
+	}
+}
+
+func fixApprovalConfig(timeout time.Duration) config.ActionApproval {
+	return config.ActionApproval{
+		Enabled:   true,
+		Channel:   "ops",
+		Approvers: []string{"U01", "U02"},
+		Timeout:   timeout,
+	}
+}
+
+type fakePoster struct {
+	channel string
+	posted  chan interactive.Message
+}
+
+func (f *fakePoster) PostApprovalRequest(_ context.Context, channelName string, msg interactive.Message) (bool, error) {
+	if channelName != f.channel {
+		return false, nil
+	}
+	f.posted <- msg
+	return true, nil
+}
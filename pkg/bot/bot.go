@@ -2,8 +2,15 @@ package bot
 
 import (
 	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"k8s.io/utils/strings/slices"
 
 	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/digest"
+	"github.com/kubeshop/botkube/pkg/events"
 	"github.com/kubeshop/botkube/pkg/execute"
 	"github.com/kubeshop/botkube/pkg/notifier"
 )
@@ -15,6 +22,32 @@ type Bot interface {
 	notifier.Notifier
 }
 
+// Bots which support snoozing notifications in a given channel.
+var (
+	_ execute.NotificationSnoozer = &Slack{}
+	_ execute.NotificationSnoozer = &SocketSlack{}
+	_ execute.NotificationSnoozer = &Mattermost{}
+	_ execute.NotificationSnoozer = &Discord{}
+	_ execute.NotificationSnoozer = &RocketChat{}
+	_ execute.NotificationSnoozer = &GoogleChat{}
+	_ execute.NotificationSnoozer = &Webex{}
+	_ execute.NotificationSnoozer = &Matrix{}
+	_ execute.NotificationSnoozer = &Loopback{}
+)
+
+// Bots which support muting notifications about given resources or namespaces in a given channel.
+var (
+	_ execute.NotificationMuter = &Slack{}
+	_ execute.NotificationMuter = &SocketSlack{}
+	_ execute.NotificationMuter = &Mattermost{}
+	_ execute.NotificationMuter = &Discord{}
+	_ execute.NotificationMuter = &RocketChat{}
+	_ execute.NotificationMuter = &GoogleChat{}
+	_ execute.NotificationMuter = &Webex{}
+	_ execute.NotificationMuter = &Matrix{}
+	_ execute.NotificationMuter = &Loopback{}
+)
+
 // ExecutorFactory facilitates creation of execute.Executor instances.
 type ExecutorFactory interface {
 	NewDefault(cfg execute.NewDefaultInput) execute.Executor
@@ -50,3 +83,159 @@ type channelConfigByName struct {
 	alias  string
 	notify bool
 }
+
+// notificationsEnabled returns true if the notifications are enabled and not snoozed.
+func (c channelConfigByID) notificationsEnabled() bool {
+	return c.notify && !c.Notification.IsSnoozed(time.Now())
+}
+
+// notificationsEnabled returns true if the notifications are enabled and not snoozed.
+func (c channelConfigByName) notificationsEnabled() bool {
+	return c.notify && !c.Notification.IsSnoozed(time.Now())
+}
+
+// matchesRoute returns true if a given route channel refers to this channel by its ID or alias.
+func (c channelConfigByID) matchesRoute(channel string) bool {
+	return channel == c.Identifier() || channel == c.alias
+}
+
+// matchesRoute returns true if a given route channel refers to this channel by its name or alias.
+// The `#` prefix of the channel name is optional.
+func (c channelConfigByName) matchesRoute(channel string) bool {
+	channel = strings.TrimPrefix(channel, "#")
+	return channel == strings.TrimPrefix(c.Identifier(), "#") || channel == c.alias
+}
+
+// eventNotificationType returns the notification type used for a given event.
+// Events with a user-defined body are always rendered in the short format, as the body replaces the event details.
+func eventNotificationType(notification config.Notification, event events.Event) config.NotificationType {
+	if event.Body != "" {
+		return config.ShortNotification
+	}
+	return notification.Type
+}
+
+type routableChannel interface {
+	matchesRoute(channel string) bool
+	notificationsEnabled() bool
+}
+
+// channelsToNotifyForEvent returns channels for a given event, taking into account the event routes for its level.
+// Source bindings with a route are sent to the routed channels, and the remaining ones to the channels returned by boundChannelsFn.
+func channelsToNotifyForEvent[T routableChannel](event events.Event, sourceBindings []string, channels map[string]T, boundChannelsFn func(sourceBindings []string) []string) []string {
+	if len(event.Routes) == 0 {
+		return boundChannelsFn(sourceBindings)
+	}
+
+	var (
+		unrouted []string
+		routes   []string
+	)
+	for _, source := range sourceBindings {
+		routed, found := event.Routes[source]
+		if !found {
+			unrouted = append(unrouted, source)
+			continue
+		}
+		routes = append(routes, routed...)
+	}
+
+	out := boundChannelsFn(unrouted)
+
+	var routedChannels []string
+	for id, cfg := range channels {
+		if !cfg.notificationsEnabled() || slices.Contains(out, id) {
+			continue
+		}
+		for _, route := range routes {
+			if cfg.matchesRoute(route) {
+				routedChannels = append(routedChannels, id)
+				break
+			}
+		}
+	}
+	sort.Strings(routedChannels)
+
+	return append(out, routedChannels...)
+}
+
+// channelDigestByName returns a function which gets the digest configuration for a given channel.
+func channelDigestByName(getChannels func() map[string]channelConfigByName) digest.ConfigFn {
+	return func(channel string) digest.ChannelConfig {
+		cfg := getChannels()[channel]
+		return digest.ChannelConfig{
+			Digest:   cfg.Notification.Digest,
+			Schedule: cfg.NotificationSchedule,
+		}
+	}
+}
+
+// channelDigestByID returns a function which gets the digest configuration for a given channel.
+func channelDigestByID(getChannels func() map[string]channelConfigByID) digest.ConfigFn {
+	return func(channel string) digest.ChannelConfig {
+		cfg := getChannels()[channel]
+		return digest.ChannelConfig{
+			Digest:   cfg.Notification.Digest,
+			Schedule: cfg.NotificationSchedule,
+		}
+	}
+}
+
+// reloadedChannelsByName returns a copy of the current channels with the configuration reloaded from a given one.
+// Channels are matched by alias and name, as changing the channel name requires joining it. Channels missing in the
+// configuration, e.g. the ones discovered at runtime, are left intact.
+func reloadedChannelsByName(current map[string]channelConfigByName, channelsCfg config.IdentifiableMap[config.ChannelBindingsByName]) map[string]channelConfigByName {
+	out := make(map[string]channelConfigByName, len(current))
+	for key, channel := range current {
+		cfg, found := channelsCfg[channel.alias]
+		if found && key == cfg.Identifier() {
+			channel.notify = reloadedNotify(channel.notify, channel.Notification, cfg.Notification)
+			cfg.Notification.SnoozedUntil = channel.Notification.SnoozedUntil
+			channel.ChannelBindingsByName = cfg
+		}
+		out[key] = channel
+	}
+	return out
+}
+
+// reloadedChannelsByID returns a copy of the current channels with the configuration reloaded from a given one.
+// Channels are matched by alias. If the reloaded configuration doesn't specify the channel ID, e.g. because
+// it is resolved from the channel name, the current one is used.
+func reloadedChannelsByID(current map[string]channelConfigByID, channelsCfg config.IdentifiableMap[config.ChannelBindingsByID]) map[string]channelConfigByID {
+	out := make(map[string]channelConfigByID, len(current))
+	for key, channel := range current {
+		cfg, found := channelsCfg[channel.alias]
+		if found && (cfg.ID == "" || cfg.ID == channel.ID) {
+			cfg.ID = channel.ID
+			channel.notify = reloadedNotify(channel.notify, channel.Notification, cfg.Notification)
+			cfg.Notification.SnoozedUntil = channel.Notification.SnoozedUntil
+			channel.ChannelBindingsByID = cfg
+		}
+		out[key] = channel
+	}
+	return out
+}
+
+// channelBindingsByIDFrom converts channels configured by name to the ones configured by ID, without resolving the IDs.
+func channelBindingsByIDFrom(channelsCfg config.IdentifiableMap[config.ChannelBindingsByName]) config.IdentifiableMap[config.ChannelBindingsByID] {
+	out := make(config.IdentifiableMap[config.ChannelBindingsByID], len(channelsCfg))
+	for alias, cfg := range channelsCfg {
+		out[alias] = config.ChannelBindingsByID{
+			Notification:         cfg.Notification,
+			Bindings:             cfg.Bindings,
+			Commands:             cfg.Commands,
+			Locale:               cfg.Locale,
+			NotificationSchedule: cfg.NotificationSchedule,
+		}
+	}
+	return out
+}
+
+// reloadedNotify returns whether the notifications are enabled after reload. The state changed at runtime with
+// the `notifier` commands is preserved, unless the reloaded configuration changes it explicitly.
+func reloadedNotify(currentNotify bool, currentCfg, reloadedCfg config.ChannelNotification) bool {
+	if currentCfg.Disabled == reloadedCfg.Disabled {
+		return currentNotify
+	}
+	return !reloadedCfg.Disabled
+}
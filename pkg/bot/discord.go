@@ -7,16 +7,19 @@ import (
 	"regexp"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/bwmarrin/discordgo"
 	"github.com/sirupsen/logrus"
 
 	"github.com/kubeshop/botkube/pkg/bot/interactive"
 	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/digest"
 	"github.com/kubeshop/botkube/pkg/events"
 	"github.com/kubeshop/botkube/pkg/execute"
 	"github.com/kubeshop/botkube/pkg/execute/command"
 	"github.com/kubeshop/botkube/pkg/multierror"
+	"github.com/kubeshop/botkube/pkg/mute"
 	"github.com/kubeshop/botkube/pkg/sliceutil"
 )
 
@@ -60,6 +63,9 @@ type Discord struct {
 	botMentionRegex *regexp.Regexp
 	commGroupName   string
 	mdFormatter     interactive.MDFormatter
+	slashCommands   bool
+	digest          *digest.Scheduler
+	mutes           *mute.Registry
 }
 
 // discordMessage contains message details to execute command and send back the result.
@@ -92,6 +98,9 @@ func NewDiscord(log logrus.FieldLogger, commGroupName string, cfg config.Discord
 		channels:        channelsCfg,
 		botMentionRegex: botMentionRegex,
 		mdFormatter:     interactive.DefaultMDFormatter(),
+		slashCommands:   cfg.SlashCommands.Enabled,
+		digest:          digest.NewScheduler(log),
+		mutes:           mute.NewRegistry(),
 	}, nil
 }
 
@@ -109,12 +118,26 @@ func (b *Discord) Start(ctx context.Context) error {
 		}
 	})
 
+	if b.slashCommands {
+		b.api.AddHandler(func(s *discordgo.Session, i *discordgo.InteractionCreate) {
+			if err := b.handleInteraction(ctx, i.Interaction); err != nil {
+				b.log.Errorf("Interaction handling error: %s", err.Error())
+			}
+		})
+	}
+
 	// Open a websocket connection to Discord and begin listening.
 	err := b.api.Open()
 	if err != nil {
 		return fmt.Errorf("while opening connection: %w", err)
 	}
 
+	if b.slashCommands {
+		if err := b.registerSlashCommands(); err != nil {
+			return fmt.Errorf("while registering slash commands: %w", err)
+		}
+	}
+
 	err = b.reporter.ReportBotEnabled(b.IntegrationName())
 	if err != nil {
 		return fmt.Errorf("while reporting analytics: %w", err)
@@ -122,6 +145,8 @@ func (b *Discord) Start(ctx context.Context) error {
 
 	b.log.Info("Botkube connected to Discord!")
 
+	go b.digest.Run(ctx, b.sendDigest)
+
 	<-ctx.Done()
 	b.log.Info("Shutdown requested. Finishing...")
 	err = b.api.Close()
@@ -140,7 +165,8 @@ func (b *Discord) SendEvent(_ context.Context, event events.Event, eventSources
 	msgToSend := b.formatMessage(event)
 
 	errs := multierror.New()
-	for _, channelID := range b.getChannelsToNotify(eventSources) {
+	channels := b.digest.Filter(event, b.mutes.Filter(event, channelsToNotifyForEvent(event, eventSources, b.getChannels(), b.getChannelsToNotify)), channelDigestByID(b.getChannels))
+	for _, channelID := range channels {
 		msg := msgToSend // copy as the struct is modified when using Discord API client
 		if _, err := b.api.ChannelMessageSendComplex(channelID, &msg); err != nil {
 			errs = multierror.Append(errs, fmt.Errorf("while sending Discord message to channel %q: %w", channelID, err))
@@ -203,11 +229,18 @@ func (b *Discord) Type() config.IntegrationType {
 }
 
 // TODO: Support custom routing via annotations for Discord as well
+func (b *Discord) sendDigest(_ context.Context, channelID string, msg interactive.Message) error {
+	if _, err := b.api.ChannelMessageSend(channelID, interactive.RenderMessage(b.mdFormatter, msg)); err != nil {
+		return fmt.Errorf("while sending Discord message to channel %q: %w", channelID, err)
+	}
+	return nil
+}
+
 func (b *Discord) getChannelsToNotify(sourceBindings []string) []string {
 	var out []string
 	for _, cfg := range b.getChannels() {
 		switch {
-		case !cfg.notify:
+		case !cfg.notificationsEnabled():
 			b.log.Infof("Skipping notification for channel %q as notifications are disabled.", cfg.Identifier())
 		default:
 			if sliceutil.Intersect(sourceBindings, cfg.Bindings.Sources) {
@@ -225,7 +258,7 @@ func (b *Discord) NotificationsEnabled(channelID string) bool {
 		return false
 	}
 
-	return channel.notify
+	return channel.notificationsEnabled()
 }
 
 // SetNotificationsEnabled sets a new notification status for a given channel ID.
@@ -241,12 +274,58 @@ func (b *Discord) SetNotificationsEnabled(channelID string, enabled bool) error
 	}
 
 	channel.notify = enabled
+	channel.Notification.SnoozedUntil = time.Time{}
+	channels[channelID] = channel
+	b.setChannels(channels)
+
+	return nil
+}
+
+// SnoozeNotifications disables notifications for a given channel until a given time.
+func (b *Discord) SnoozeNotifications(channelID string, until time.Time) error {
+	// avoid race conditions with using the setter concurrently, as we set whole map
+	b.notifyMutex.Lock()
+	defer b.notifyMutex.Unlock()
+
+	channels := b.getChannels()
+	channel, exists := channels[channelID]
+	if !exists {
+		return execute.ErrNotificationsNotConfigured
+	}
+
+	channel.notify = true
+	channel.Notification.SnoozedUntil = until
 	channels[channelID] = channel
 	b.setChannels(channels)
 
 	return nil
 }
 
+// NotificationsSnoozedUntil returns the snooze end time for a given channel.
+func (b *Discord) NotificationsSnoozedUntil(channelID string) time.Time {
+	return b.getChannels()[channelID].Notification.SnoozedUntil
+}
+
+// MuteNotifications suppresses notifications matching a given rule for a given channel.
+func (b *Discord) MuteNotifications(channelID string, rule mute.Rule) error {
+	if _, exists := b.getChannels()[channelID]; !exists {
+		return execute.ErrNotificationsNotConfigured
+	}
+
+	b.mutes.Add(channelID, rule)
+	return nil
+}
+
+// UnmuteNotifications removes the mute rule for the same target as a given rule for a given channel.
+func (b *Discord) UnmuteNotifications(channelID string, rule mute.Rule) bool {
+	return b.mutes.Remove(channelID, rule)
+}
+
+// NotificationMutes returns active mute rules for a given channel.
+func (b *Discord) NotificationMutes(channelID string) []mute.Rule {
+	return b.mutes.List(channelID)
+}
+
 // HandleMessage handles the incoming messages.
 func (b *Discord) handleMessage(ctx context.Context, dm discordMessage) error {
 	// Handle message only if starts with mention
@@ -258,7 +337,22 @@ func (b *Discord) handleMessage(ctx context.Context, dm discordMessage) error {
 
 	b.log.Debugf("Discord incoming Request: %s", req)
 
-	channel, isAuthChannel := b.getChannels()[dm.Event.ChannelID]
+	response := b.executeCommand(ctx, dm.Event.ChannelID, dm.Event.Author.ID, req, command.TypedOrigin)
+	err := b.send(dm.Event.ChannelID, response)
+	if err != nil {
+		return fmt.Errorf("while sending message: %w", err)
+	}
+
+	return nil
+}
+
+// executeCommand executes a given command in the context of a given channel.
+func (b *Discord) executeCommand(ctx context.Context, channelID, userID, req string, cmdOrigin command.Origin) interactive.Message {
+	channel, isAuthChannel := b.getChannels()[channelID]
+	if !execute.IsCommandPermitted(channel.Commands, req) {
+		b.log.Debugf("Command %q is not permitted in channel %q", req, channel.Identifier())
+		return execute.CommandNotPermittedMessage(req)
+	}
 
 	e := b.executorFactory.NewDefault(execute.NewDefaultInput{
 		CommGroupName:   b.commGroupName,
@@ -268,20 +362,33 @@ func (b *Discord) handleMessage(ctx context.Context, dm discordMessage) error {
 			Alias:            channel.alias,
 			ID:               channel.Identifier(),
 			ExecutorBindings: channel.Bindings.Executors,
+			Commands:         channel.Commands,
+			Locale:           channel.Locale,
 			IsAuthenticated:  isAuthChannel,
-			CommandOrigin:    command.TypedOrigin,
+			CommandOrigin:    cmdOrigin,
 		},
 		Message: req,
-		User:    fmt.Sprintf("<@%s>", dm.Event.Author.ID),
+		User:    fmt.Sprintf("<@%s>", userID),
+		UserID:  userID,
 	})
 
-	response := e.Execute(ctx)
-	err := b.send(dm.Event.ChannelID, response)
-	if err != nil {
-		return fmt.Errorf("while sending message: %w", err)
+	return e.Execute(ctx)
+}
+
+// RunScheduledCommand executes a given scheduled command and posts its output in the command channel.
+func (b *Discord) RunScheduledCommand(ctx context.Context, cmd execute.ScheduledCommand) (bool, error) {
+	if cmd.CommGroup != b.commGroupName || cmd.Platform != b.IntegrationName() {
+		return false, nil
+	}
+	if _, found := b.getChannels()[cmd.ChannelID]; !found {
+		return false, nil
 	}
 
-	return nil
+	response := b.executeCommand(ctx, cmd.ChannelID, cmd.UserID, cmd.Command, command.ScheduleOrigin)
+	if err := b.send(cmd.ChannelID, response); err != nil {
+		return true, fmt.Errorf("while sending message: %w", err)
+	}
+	return true, nil
 }
 
 func (b *Discord) send(channelID string, resp interactive.Message) error {
@@ -337,6 +444,13 @@ func (b *Discord) setChannels(channels map[string]channelConfigByID) {
 	b.channels = channels
 }
 
+// ReloadChannels reloads the bindings and notification settings of the channels, without reconnecting the bot.
+func (b *Discord) ReloadChannels(cfg config.Discord) {
+	b.channelsMutex.Lock()
+	defer b.channelsMutex.Unlock()
+	b.channels = reloadedChannelsByID(b.channels, cfg.Channels)
+}
+
 func (b *Discord) findAndTrimBotMention(msg string) (string, bool) {
 	if !b.botMentionRegex.MatchString(msg) {
 		return "", false
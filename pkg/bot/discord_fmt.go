@@ -13,7 +13,7 @@ import (
 func (b *Discord) formatMessage(event events.Event) discordgo.MessageSend {
 	var messageEmbed discordgo.MessageEmbed
 
-	switch b.notification.Type {
+	switch eventNotificationType(b.notification, event) {
 	case config.LongNotification:
 		// generate Long notification message
 		messageEmbed = b.longNotification(event)
@@ -57,11 +57,13 @@ func (b *Discord) longNotification(event events.Event) discordgo.MessageEmbed {
 	}
 
 	messageEmbed.Fields = b.appendIfNotEmpty(messageEmbed.Fields, event.Namespace, "Namespace", true)
+	messageEmbed.Fields = b.appendIfNotEmpty(messageEmbed.Fields, event.OwnerString(), "Owner", true)
 	messageEmbed.Fields = b.appendIfNotEmpty(messageEmbed.Fields, event.Reason, "Reason", true)
 	messageEmbed.Fields = b.appendIfNotEmpty(messageEmbed.Fields, formatx.JoinMessages(event.Messages), "Message", false)
 	messageEmbed.Fields = b.appendIfNotEmpty(messageEmbed.Fields, event.Action, "Action", true)
 	messageEmbed.Fields = b.appendIfNotEmpty(messageEmbed.Fields, formatx.JoinMessages(event.Recommendations), "Recommendations", false)
 	messageEmbed.Fields = b.appendIfNotEmpty(messageEmbed.Fields, formatx.JoinMessages(event.Warnings), "Warnings", false)
+	messageEmbed.Fields = b.appendIfNotEmpty(messageEmbed.Fields, formatx.EventLogs(event), "Logs", false)
 	messageEmbed.Fields = b.appendIfNotEmpty(messageEmbed.Fields, event.Cluster, "Cluster", false)
 
 	return messageEmbed
@@ -0,0 +1,148 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+
+	"github.com/kubeshop/botkube/pkg/bot/interactive"
+	"github.com/kubeshop/botkube/pkg/execute/command"
+)
+
+const (
+	discordSlashCommandName       = "botkube"
+	discordSlashCommandOptionName = "command"
+)
+
+// discordSlashCommand describes the `/botkube <command>` slash command.
+var discordSlashCommand = &discordgo.ApplicationCommand{
+	Name:        discordSlashCommandName,
+	Description: "Execute Botkube command",
+	Options: []*discordgo.ApplicationCommandOption{
+		{
+			Type:        discordgo.ApplicationCommandOptionString,
+			Name:        discordSlashCommandOptionName,
+			Description: "Command to execute, e.g. kubectl get pods",
+			Required:    true,
+		},
+	},
+}
+
+// registerSlashCommands registers the slash command in all guilds of the configured channels.
+// Guild commands are available immediately, while global ones may take up to one hour to propagate.
+func (b *Discord) registerSlashCommands() error {
+	registered := map[string]struct{}{}
+	for channelID := range b.getChannels() {
+		channel, err := b.api.Channel(channelID)
+		if err != nil {
+			return fmt.Errorf("while getting channel %q: %w", channelID, err)
+		}
+
+		if _, found := registered[channel.GuildID]; found {
+			continue
+		}
+
+		if _, err := b.api.ApplicationCommandCreate(b.botID, channel.GuildID, discordSlashCommand); err != nil {
+			return fmt.Errorf("while creating slash command for guild %q: %w", channel.GuildID, err)
+		}
+		registered[channel.GuildID] = struct{}{}
+		b.log.Debugf("Slash command registered for guild %q", channel.GuildID)
+	}
+
+	return nil
+}
+
+// handleInteraction handles the slash command interactions.
+func (b *Discord) handleInteraction(ctx context.Context, i *discordgo.Interaction) error {
+	req, found := discordSlashCommandRequest(i)
+	if !found {
+		return nil
+	}
+
+	b.log.Debugf("Discord incoming slash command request: %s", req)
+
+	// Interaction must be acknowledged within 3 seconds, so we defer the response and edit it once the command is executed.
+	err := b.api.InteractionRespond(i, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
+	})
+	if err != nil {
+		return fmt.Errorf("while acknowledging interaction: %w", err)
+	}
+
+	response := b.executeCommand(ctx, i.ChannelID, discordInteractionUserID(i), req, command.TypedOrigin)
+	if err := b.sendInteractionResponse(i, response); err != nil {
+		return fmt.Errorf("while sending interaction response: %w", err)
+	}
+
+	return nil
+}
+
+func (b *Discord) sendInteractionResponse(i *discordgo.Interaction, resp interactive.Message) error {
+	b.log.Debugf("Discord Response: %s", resp)
+
+	markdown := interactive.RenderMessage(b.mdFormatter, resp)
+	if len(markdown) == 0 {
+		b.log.Debug("Deleting deferred response as the command response is empty")
+		if err := b.api.InteractionResponseDelete(i); err != nil {
+			return fmt.Errorf("while deleting deferred response: %w", err)
+		}
+		return nil
+	}
+
+	edit := &discordgo.WebhookEdit{
+		Content: markdown,
+	}
+
+	// Upload message as a file if too long
+	if len(markdown) >= discordMaxMessageSize {
+		edit = &discordgo.WebhookEdit{
+			Content: resp.Description,
+			Files: []*discordgo.File{
+				{
+					Name:   "Response.txt",
+					Reader: strings.NewReader(interactive.MessageToPlaintext(resp, interactive.NewlineFormatter)),
+				},
+			},
+		}
+	}
+
+	if _, err := b.api.InteractionResponseEdit(i, edit); err != nil {
+		return fmt.Errorf("while editing deferred response: %w", err)
+	}
+	return nil
+}
+
+// discordSlashCommandRequest returns the command passed to the Botkube slash command.
+func discordSlashCommandRequest(i *discordgo.Interaction) (string, bool) {
+	if i == nil || i.Type != discordgo.InteractionApplicationCommand {
+		return "", false
+	}
+
+	data, ok := i.Data.(discordgo.ApplicationCommandInteractionData)
+	if !ok || data.Name != discordSlashCommandName {
+		return "", false
+	}
+
+	for _, opt := range data.Options {
+		if opt.Name != discordSlashCommandOptionName || opt.Type != discordgo.ApplicationCommandOptionString {
+			continue
+		}
+		return opt.StringValue(), true
+	}
+
+	return "", false
+}
+
+// discordInteractionUserID returns ID of the user who triggered the interaction.
+// Member is set only when the interaction is invoked in a guild, User only when invoked in a DM.
+func discordInteractionUserID(i *discordgo.Interaction) string {
+	if i.Member != nil && i.Member.User != nil {
+		return i.Member.User.ID
+	}
+	if i.User != nil {
+		return i.User.ID
+	}
+	return ""
+}
@@ -3,6 +3,7 @@ package bot
 import (
 	"testing"
 
+	"github.com/bwmarrin/discordgo"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -55,3 +56,67 @@ func TestDiscord_FindAndTrimBotMention(t *testing.T) {
 		})
 	}
 }
+
+func TestDiscordSlashCommandRequest(t *testing.T) {
+	// given
+	testCases := []struct {
+		Name            string
+		Interaction     *discordgo.Interaction
+		ExpectedRequest string
+		ExpectedFound   bool
+	}{
+		{
+			Name: "Botkube slash command",
+			Interaction: &discordgo.Interaction{
+				Type: discordgo.InteractionApplicationCommand,
+				Data: discordgo.ApplicationCommandInteractionData{
+					Name: "botkube",
+					Options: []*discordgo.ApplicationCommandInteractionDataOption{
+						{Name: "command", Type: discordgo.ApplicationCommandOptionString, Value: "kubectl get pods"},
+					},
+				},
+			},
+			ExpectedRequest: "kubectl get pods",
+			ExpectedFound:   true,
+		},
+		{
+			Name: "Different slash command",
+			Interaction: &discordgo.Interaction{
+				Type: discordgo.InteractionApplicationCommand,
+				Data: discordgo.ApplicationCommandInteractionData{Name: "other"},
+			},
+		},
+		{
+			Name: "Different interaction type",
+			Interaction: &discordgo.Interaction{
+				Type: discordgo.InteractionMessageComponent,
+				Data: discordgo.MessageComponentInteractionData{CustomID: "botkube"},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			// when
+			req, found := discordSlashCommandRequest(tc.Interaction)
+
+			// then
+			assert.Equal(t, tc.ExpectedFound, found)
+			assert.Equal(t, tc.ExpectedRequest, req)
+		})
+	}
+}
+
+func TestDiscordInteractionUserID(t *testing.T) {
+	// given
+	guildInteraction := &discordgo.Interaction{Member: &discordgo.Member{User: &discordgo.User{ID: "member"}}}
+	dmInteraction := &discordgo.Interaction{User: &discordgo.User{ID: "user"}}
+
+	// when
+	guildUserID := discordInteractionUserID(guildInteraction)
+	dmUserID := discordInteractionUserID(dmInteraction)
+
+	// then
+	assert.Equal(t, "member", guildUserID)
+	assert.Equal(t, "user", dmUserID)
+}
@@ -0,0 +1,497 @@
+package bot
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+
+	"github.com/kubeshop/botkube/pkg/bot/interactive"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/digest"
+	"github.com/kubeshop/botkube/pkg/events"
+	"github.com/kubeshop/botkube/pkg/execute"
+	"github.com/kubeshop/botkube/pkg/execute/command"
+	"github.com/kubeshop/botkube/pkg/httpsrv"
+	"github.com/kubeshop/botkube/pkg/multierror"
+	"github.com/kubeshop/botkube/pkg/mute"
+	"github.com/kubeshop/botkube/pkg/sliceutil"
+)
+
+var _ Bot = &GoogleChat{}
+
+const (
+	googleChatDefaultPort = "3980"
+
+	// googleChatMaxMessageSize max size before a message is truncated. Google Chat limits the message text to 4096 characters.
+	googleChatMaxMessageSize = 4000
+	googleChatLongRespNotice = "Response is too long. Sending last few lines."
+
+	googleChatBotMentionRegexFmt = "^@(?i)%s"
+
+	googleChatMessageEvent      = "MESSAGE"
+	googleChatAddedToSpaceEvent = "ADDED_TO_SPACE"
+	googleChatCardClickedEvent  = "CARD_CLICKED"
+
+	googleChatNewMessageResponse = "NEW_MESSAGE"
+)
+
+// googleChatEvent is an interaction event sent by Google Chat to the Chat app endpoint.
+// See: https://developers.google.com/chat/api/reference/rest/v1/Event
+type googleChatEvent struct {
+	Type    string                      `json:"type"`
+	Space   googleChatSpace             `json:"space"`
+	User    googleChatUser              `json:"user"`
+	Message *googleChatEventMessage     `json:"message,omitempty"`
+	Common  googleChatCommonEventObject `json:"common"`
+}
+
+type googleChatEventMessage struct {
+	Name string `json:"name"`
+	Text string `json:"text"`
+	// ArgumentText is the message text without the Chat app mention.
+	ArgumentText string            `json:"argumentText"`
+	Thread       *googleChatThread `json:"thread,omitempty"`
+}
+
+// GoogleChat listens for user's message, execute commands and sends back the response.
+type GoogleChat struct {
+	log             logrus.FieldLogger
+	executorFactory ExecutorFactory
+	reporter        AnalyticsReporter
+	notification    config.Notification
+	client          *googleChatClient
+	verifier        *googleChatTokenVerifier
+	renderer        *GoogleChatRenderer
+	botName         string
+	commGroupName   string
+	port            string
+	messagePath     string
+	channelsMutex   sync.RWMutex
+	channels        map[string]channelConfigByID
+	notifyMutex     sync.Mutex
+	botMentionRegex *regexp.Regexp
+	mdFormatter     interactive.MDFormatter
+	digest          *digest.Scheduler
+	mutes           *mute.Registry
+}
+
+// NewGoogleChat creates a new GoogleChat instance.
+func NewGoogleChat(ctx context.Context, log logrus.FieldLogger, commGroupName string, cfg config.GoogleChat, executorFactory ExecutorFactory, reporter AnalyticsReporter) (*GoogleChat, error) {
+	botMentionRegex, err := googleChatBotMentionRegex(cfg.BotName)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := newGoogleChatClient(ctx, cfg.Credentials)
+	if err != nil {
+		return nil, fmt.Errorf("while creating Google Chat client: %w", err)
+	}
+
+	port := cfg.Port
+	if port == "" {
+		port = googleChatDefaultPort
+	}
+	msgPath := cfg.MessagePath
+	if msgPath == "" {
+		msgPath = "/"
+	}
+
+	return &GoogleChat{
+		log:             log,
+		executorFactory: executorFactory,
+		reporter:        reporter,
+		notification:    cfg.Notification,
+		client:          client,
+		verifier:        newGoogleChatTokenVerifier(cfg.ProjectNumber),
+		renderer:        NewGoogleChatRenderer(),
+		botName:         cfg.BotName,
+		commGroupName:   commGroupName,
+		port:            port,
+		messagePath:     msgPath,
+		channels:        googleChatChannelsCfgFrom(cfg.Channels),
+		botMentionRegex: botMentionRegex,
+		mdFormatter:     interactive.NewMDFormatter(interactive.NewlineFormatter, mdHeaderFormatter),
+		digest:          digest.NewScheduler(log),
+		mutes:           mute.NewRegistry(),
+	}, nil
+}
+
+// Start checks access to the configured spaces and starts the server which handles Google Chat events.
+func (b *GoogleChat) Start(ctx context.Context) error {
+	b.log.Info("Starting bot")
+
+	for spaceName := range b.getChannels() {
+		if _, err := b.client.GetSpace(ctx, spaceName); err != nil {
+			return fmt.Errorf("while getting space %q: %w", spaceName, err)
+		}
+	}
+
+	err := b.reporter.ReportBotEnabled(b.IntegrationName())
+	if err != nil {
+		return fmt.Errorf("while reporting analytics: %w", err)
+	}
+
+	go b.digest.Run(ctx, b.send)
+
+	addr := fmt.Sprintf(":%s", b.port)
+	srv := httpsrv.New(b.log, addr, b.router())
+	if err := srv.Serve(ctx); err != nil {
+		return fmt.Errorf("while running Google Chat server: %w", err)
+	}
+
+	return nil
+}
+
+func (b *GoogleChat) router() http.Handler {
+	router := mux.NewRouter()
+	router.PathPrefix(b.messagePath).HandlerFunc(b.handleEvent).Methods(http.MethodPost)
+	return router
+}
+
+// IntegrationName describes the notifier integration name.
+func (b *GoogleChat) IntegrationName() config.CommPlatformIntegration {
+	return config.GoogleChatCommPlatformIntegration
+}
+
+// Type describes the notifier type.
+func (b *GoogleChat) Type() config.IntegrationType {
+	return config.BotIntegrationType
+}
+
+// NotificationsEnabled returns current notification status for a given space name.
+func (b *GoogleChat) NotificationsEnabled(spaceName string) bool {
+	channel, exists := b.getChannels()[spaceName]
+	if !exists {
+		return false
+	}
+
+	return channel.notificationsEnabled()
+}
+
+// SetNotificationsEnabled sets a new notification status for a given space name.
+func (b *GoogleChat) SetNotificationsEnabled(spaceName string, enabled bool) error {
+	// avoid race conditions with using the setter concurrently, as we set whole map
+	b.notifyMutex.Lock()
+	defer b.notifyMutex.Unlock()
+
+	channels := b.getChannels()
+	channel, exists := channels[spaceName]
+	if !exists {
+		return execute.ErrNotificationsNotConfigured
+	}
+
+	channel.notify = enabled
+	channel.Notification.SnoozedUntil = time.Time{}
+	channels[spaceName] = channel
+	b.setChannels(channels)
+
+	return nil
+}
+
+// SnoozeNotifications disables notifications for a given channel until a given time.
+func (b *GoogleChat) SnoozeNotifications(spaceName string, until time.Time) error {
+	// avoid race conditions with using the setter concurrently, as we set whole map
+	b.notifyMutex.Lock()
+	defer b.notifyMutex.Unlock()
+
+	channels := b.getChannels()
+	channel, exists := channels[spaceName]
+	if !exists {
+		return execute.ErrNotificationsNotConfigured
+	}
+
+	channel.notify = true
+	channel.Notification.SnoozedUntil = until
+	channels[spaceName] = channel
+	b.setChannels(channels)
+
+	return nil
+}
+
+// NotificationsSnoozedUntil returns the snooze end time for a given channel.
+func (b *GoogleChat) NotificationsSnoozedUntil(spaceName string) time.Time {
+	return b.getChannels()[spaceName].Notification.SnoozedUntil
+}
+
+// MuteNotifications suppresses notifications matching a given rule for a given channel.
+func (b *GoogleChat) MuteNotifications(spaceName string, rule mute.Rule) error {
+	if _, exists := b.getChannels()[spaceName]; !exists {
+		return execute.ErrNotificationsNotConfigured
+	}
+
+	b.mutes.Add(spaceName, rule)
+	return nil
+}
+
+// UnmuteNotifications removes the mute rule for the same target as a given rule for a given channel.
+func (b *GoogleChat) UnmuteNotifications(spaceName string, rule mute.Rule) bool {
+	return b.mutes.Remove(spaceName, rule)
+}
+
+// NotificationMutes returns active mute rules for a given channel.
+func (b *GoogleChat) NotificationMutes(spaceName string) []mute.Rule {
+	return b.mutes.List(spaceName)
+}
+
+// SendEvent sends event notification to Google Chat.
+func (b *GoogleChat) SendEvent(ctx context.Context, event events.Event, eventSources []string) error {
+	b.log.Debugf("Sending to Google Chat: %+v", event)
+	msg := b.formatMessage(event)
+
+	errs := multierror.New()
+	for _, spaceName := range b.getChannelsToNotifyForEvent(event, eventSources) {
+		if err := b.client.CreateMessage(ctx, spaceName, msg); err != nil {
+			errs = multierror.Append(errs, fmt.Errorf("while posting message to space %q: %w", spaceName, err))
+			continue
+		}
+
+		b.log.Debugf("Event successfully sent to space %q", spaceName)
+	}
+
+	return errs.ErrorOrNil()
+}
+
+// SendGenericMessage sends message to selected Google Chat spaces.
+func (b *GoogleChat) SendGenericMessage(ctx context.Context, genericMsg interactive.GenericMessage, sourceBindings []string) error {
+	msg := genericMsg.ForBot(b.BotName())
+
+	errs := multierror.New()
+	for _, spaceName := range b.getChannelsToNotify(sourceBindings) {
+		b.log.Debugf("Sending message to space %q: %+v", spaceName, msg)
+		if err := b.send(ctx, spaceName, msg); err != nil {
+			errs = multierror.Append(errs, fmt.Errorf("while sending Google Chat message to space %q: %w", spaceName, err))
+			continue
+		}
+		b.log.Debugf("Message successfully sent to space %q", spaceName)
+	}
+
+	return errs.ErrorOrNil()
+}
+
+// SendMessageToAll sends message to all Google Chat spaces.
+func (b *GoogleChat) SendMessageToAll(ctx context.Context, msg interactive.Message) error {
+	errs := multierror.New()
+	for _, channel := range b.getChannels() {
+		spaceName := channel.ID
+		b.log.Debugf("Sending message to space %q: %+v", spaceName, msg)
+		if err := b.send(ctx, spaceName, msg); err != nil {
+			errs = multierror.Append(errs, fmt.Errorf("while sending Google Chat message to space %q: %w", spaceName, err))
+			continue
+		}
+		b.log.Debugf("Message successfully sent to space %q", spaceName)
+	}
+
+	return errs.ErrorOrNil()
+}
+
+// BotName returns the Bot name.
+func (b *GoogleChat) BotName() string {
+	return fmt.Sprintf("@%s", b.botName)
+}
+
+// handleEvent handles the Google Chat interaction events. The command response is returned synchronously.
+func (b *GoogleChat) handleEvent(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	if err := b.verifier.Verify(ctx, r); err != nil {
+		b.log.Debugf("Rejecting Google Chat request: %s", err.Error())
+		http.Error(w, "invalid bearer token", http.StatusUnauthorized)
+		return
+	}
+
+	var event googleChatEvent
+	if err := json.NewDecoder(r.Body).Decode(&event); err != nil {
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	resp := b.processEvent(ctx, event)
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		b.log.Errorf("while writing Google Chat response: %s", err.Error())
+	}
+}
+
+func (b *GoogleChat) processEvent(ctx context.Context, event googleChatEvent) googleChatMessage {
+	switch event.Type {
+	case googleChatMessageEvent, googleChatAddedToSpaceEvent:
+		// ADDED_TO_SPACE contains a message only if the Chat app was added by mentioning it
+		if event.Message == nil {
+			return googleChatMessage{}
+		}
+
+		req := strings.TrimSpace(event.Message.ArgumentText)
+		b.log.Debugf("Google Chat incoming Request: %s", req)
+
+		response := b.executeCommand(ctx, event.Space.Name, event.User, req, command.TypedOrigin)
+		return b.renderResponse(response)
+	case googleChatCardClickedEvent:
+		if event.Common.InvokedFunction != googleChatCommandFunction {
+			return googleChatMessage{}
+		}
+
+		cmd, cmdOrigin := resolveGoogleChatCommand(event.Common)
+		req, found := b.findAndTrimBotMention(cmd)
+		if !found {
+			req = cmd
+		}
+		b.log.Debugf("Google Chat incoming interactive Request: %s", req)
+
+		response := b.executeCommand(ctx, event.Space.Name, event.User, req, cmdOrigin)
+		msg := b.renderResponse(response)
+		msg.ActionResponse = &googleChatActionResponse{Type: googleChatNewMessageResponse}
+		return msg
+	default:
+		b.log.Debugf("Ignoring Google Chat %q event", event.Type)
+		return googleChatMessage{}
+	}
+}
+
+// executeCommand executes a given command in the context of a given space.
+func (b *GoogleChat) executeCommand(ctx context.Context, spaceName string, user googleChatUser, req string, cmdOrigin command.Origin) interactive.Message {
+	channel, isAuthChannel := b.getChannels()[spaceName]
+	if !execute.IsCommandPermitted(channel.Commands, req) {
+		b.log.Debugf("Command %q is not permitted in space %q", req, spaceName)
+		return execute.CommandNotPermittedMessage(req)
+	}
+
+	e := b.executorFactory.NewDefault(execute.NewDefaultInput{
+		CommGroupName:   b.commGroupName,
+		Platform:        b.IntegrationName(),
+		NotifierHandler: b,
+		Conversation: execute.Conversation{
+			Alias:            channel.alias,
+			ID:               spaceName,
+			ExecutorBindings: channel.Bindings.Executors,
+			Commands:         channel.Commands,
+			Locale:           channel.Locale,
+			IsAuthenticated:  isAuthChannel,
+			CommandOrigin:    cmdOrigin,
+		},
+		Message: req,
+		User:    fmt.Sprintf("<%s>", user.Name),
+		UserID:  user.Name,
+	})
+	return e.Execute(ctx)
+}
+
+func (b *GoogleChat) send(ctx context.Context, spaceName string, resp interactive.Message) error {
+	msg := b.renderResponse(resp)
+	if msg.Text == "" && len(msg.CardsV2) == 0 {
+		return errors.New("while reading Google Chat response: empty response")
+	}
+
+	if err := b.client.CreateMessage(ctx, spaceName, msg); err != nil {
+		return fmt.Errorf("while posting message: %w", err)
+	}
+	return nil
+}
+
+func (b *GoogleChat) renderResponse(resp interactive.Message) googleChatMessage {
+	b.log.Debugf("Google Chat Response: %s", resp)
+
+	// Google Chat doesn't allow the Chat apps to upload files, so we send only the last part of a long response
+	if len(interactive.RenderMessage(b.mdFormatter, resp)) >= googleChatMaxMessageSize {
+		resp = interactive.Message{
+			Base: interactive.Base{
+				Description: googleChatLongRespNotice,
+				Body: interactive.Body{
+					CodeBlock: tailOutput(interactive.MessageToPlaintext(resp, interactive.NewlineFormatter), googleChatMaxMessageSize-len(googleChatLongRespNotice)-100),
+				},
+			},
+		}
+	}
+
+	return b.renderer.RenderMessage(resp)
+}
+
+func (b *GoogleChat) getChannelsToNotifyForEvent(event events.Event, sourceBindings []string) []string {
+	// support custom event routing
+	if event.Channel != "" {
+		return []string{event.Channel}
+	}
+
+	return b.digest.Filter(event, b.mutes.Filter(event, channelsToNotifyForEvent(event, sourceBindings, b.getChannels(), b.getChannelsToNotify)), channelDigestByID(b.getChannels))
+}
+
+func (b *GoogleChat) getChannelsToNotify(sourceBindings []string) []string {
+	var out []string
+	for _, cfg := range b.getChannels() {
+		switch {
+		case !cfg.notificationsEnabled():
+			b.log.Infof("Skipping notification for space %q as notifications are disabled.", cfg.Identifier())
+		default:
+			if sliceutil.Intersect(sourceBindings, cfg.Bindings.Sources) {
+				out = append(out, cfg.Identifier())
+			}
+		}
+	}
+	return out
+}
+
+func (b *GoogleChat) findAndTrimBotMention(msg string) (string, bool) {
+	if !b.botMentionRegex.MatchString(msg) {
+		return "", false
+	}
+
+	return b.botMentionRegex.ReplaceAllString(msg, ""), true
+}
+
+func (b *GoogleChat) getChannels() map[string]channelConfigByID {
+	b.channelsMutex.RLock()
+	defer b.channelsMutex.RUnlock()
+	return b.channels
+}
+
+func (b *GoogleChat) setChannels(channels map[string]channelConfigByID) {
+	b.channelsMutex.Lock()
+	defer b.channelsMutex.Unlock()
+	b.channels = channels
+}
+
+// ReloadChannels reloads the bindings and notification settings of the channels, without reconnecting the bot.
+func (b *GoogleChat) ReloadChannels(cfg config.GoogleChat) {
+	b.channelsMutex.Lock()
+	defer b.channelsMutex.Unlock()
+	b.channels = reloadedChannelsByID(b.channels, channelBindingsByIDFrom(cfg.Channels))
+}
+
+// googleChatChannelsCfgFrom returns the spaces configuration by space name.
+// Google Chat events contain the space resource name, so there is no need to resolve the space ID.
+func googleChatChannelsCfgFrom(channelsCfg config.IdentifiableMap[config.ChannelBindingsByName]) map[string]channelConfigByID {
+	res := make(map[string]channelConfigByID)
+	for channAlias, channCfg := range channelsCfg {
+		res[channCfg.Identifier()] = channelConfigByID{
+			ChannelBindingsByID: config.ChannelBindingsByID{
+				ID:                   channCfg.Identifier(),
+				Notification:         channCfg.Notification,
+				Bindings:             channCfg.Bindings,
+				Commands:             channCfg.Commands,
+				Locale:               channCfg.Locale,
+				NotificationSchedule: channCfg.NotificationSchedule,
+			},
+			alias:  channAlias,
+			notify: !channCfg.Notification.Disabled,
+		}
+	}
+
+	return res
+}
+
+func googleChatBotMentionRegex(botName string) (*regexp.Regexp, error) {
+	botMentionRegex, err := regexp.Compile(fmt.Sprintf(googleChatBotMentionRegexFmt, botName))
+	if err != nil {
+		return nil, fmt.Errorf("while compiling bot mention regex: %w", err)
+	}
+
+	return botMentionRegex, nil
+}
@@ -0,0 +1,256 @@
+package bot
+
+import (
+	"bytes"
+	"context"
+	"crypto/rsa"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	jwtv4 "github.com/golang-jwt/jwt/v4"
+	"golang.org/x/oauth2/jwt"
+)
+
+const (
+	googleChatAPIURL      = "https://chat.googleapis.com/v1/"
+	googleChatBotScope    = "https://www.googleapis.com/auth/chat.bot"
+	googleChatTokenURL    = "https://oauth2.googleapis.com/token"
+	googleChatHTTPTimeout = 30 * time.Second
+
+	// googleChatIssuer is the issuer of the bearer tokens attached to requests sent by Google Chat.
+	googleChatIssuer = "chat@system.gserviceaccount.com"
+	// googleChatCertsURL returns the public certificates used to sign the Google Chat bearer tokens.
+	googleChatCertsURL = "https://www.googleapis.com/service_accounts/v1/metadata/x509/chat@system.gserviceaccount.com"
+	googleChatCertsTTL = time.Hour
+)
+
+// googleChatServiceAccount holds the service account key fields required to call the Google Chat API.
+type googleChatServiceAccount struct {
+	ClientEmail  string `json:"client_email"`
+	PrivateKey   string `json:"private_key"`
+	PrivateKeyID string `json:"private_key_id"`
+	TokenURI     string `json:"token_uri"`
+}
+
+type googleChatSpace struct {
+	Name            string `json:"name"`
+	Type            string `json:"type,omitempty"`
+	DisplayName     string `json:"displayName,omitempty"`
+	SingleUserBotDM bool   `json:"singleUserBotDm,omitempty"`
+}
+
+type googleChatUser struct {
+	Name        string `json:"name"`
+	DisplayName string `json:"displayName,omitempty"`
+	Type        string `json:"type,omitempty"`
+}
+
+type googleChatThread struct {
+	Name string `json:"name"`
+}
+
+// googleChatClient is a minimal Google Chat REST API client authenticated as the Chat app.
+type googleChatClient struct {
+	apiURL  string
+	httpCli *http.Client
+}
+
+func newGoogleChatClient(ctx context.Context, credentials string) (*googleChatClient, error) {
+	var sa googleChatServiceAccount
+	if err := json.Unmarshal([]byte(credentials), &sa); err != nil {
+		return nil, fmt.Errorf("while unmarshaling service account credentials: %w", err)
+	}
+	if sa.ClientEmail == "" || sa.PrivateKey == "" {
+		return nil, errors.New("service account credentials must contain the client email and private key")
+	}
+
+	tokenURL := sa.TokenURI
+	if tokenURL == "" {
+		tokenURL = googleChatTokenURL
+	}
+
+	cfg := &jwt.Config{
+		Email:        sa.ClientEmail,
+		PrivateKey:   []byte(sa.PrivateKey),
+		PrivateKeyID: sa.PrivateKeyID,
+		Scopes:       []string{googleChatBotScope},
+		TokenURL:     tokenURL,
+	}
+
+	httpCli := cfg.Client(ctx)
+	httpCli.Timeout = googleChatHTTPTimeout
+
+	return &googleChatClient{
+		apiURL:  googleChatAPIURL,
+		httpCli: httpCli,
+	}, nil
+}
+
+// GetSpace returns a space for a given resource name, e.g. `spaces/AAAAxyz`.
+func (c *googleChatClient) GetSpace(ctx context.Context, name string) (googleChatSpace, error) {
+	var out googleChatSpace
+	if err := c.do(ctx, http.MethodGet, name, nil, &out); err != nil {
+		return googleChatSpace{}, err
+	}
+	return out, nil
+}
+
+// CreateMessage sends a message to a given space.
+func (c *googleChatClient) CreateMessage(ctx context.Context, space string, msg googleChatMessage) error {
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("while marshaling message: %w", err)
+	}
+
+	path := fmt.Sprintf("%s/messages", space)
+	if msg.Thread != nil {
+		path += "?messageReplyOption=REPLY_MESSAGE_FALLBACK_TO_NEW_THREAD"
+	}
+
+	return c.do(ctx, http.MethodPost, path, bytes.NewReader(body), nil)
+}
+
+func (c *googleChatClient) do(ctx context.Context, method, path string, body io.Reader, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, method, c.apiURL+path, body)
+	if err != nil {
+		return fmt.Errorf("while creating request: %w", err)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	res, err := c.httpCli.Do(req)
+	if err != nil {
+		return fmt.Errorf("while sending request: %w", err)
+	}
+	defer res.Body.Close()
+
+	raw, err := io.ReadAll(res.Body)
+	if err != nil {
+		return fmt.Errorf("while reading response body: %w", err)
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("got unexpected status code %d from %s %s: %s", res.StatusCode, method, path, string(raw))
+	}
+
+	if out == nil {
+		return nil
+	}
+
+	if err := json.Unmarshal(raw, out); err != nil {
+		return fmt.Errorf("while unmarshaling response: %w", err)
+	}
+	return nil
+}
+
+// googleChatTokenVerifier verifies the bearer tokens which Google Chat attaches to requests sent to the Chat app.
+// See: https://developers.google.com/chat/api/guides/message-formats#verify_app_authenticity
+type googleChatTokenVerifier struct {
+	audience  string
+	fetchKeys func(ctx context.Context) (map[string]*rsa.PublicKey, error)
+	now       func() time.Time
+
+	mu        sync.Mutex
+	keys      map[string]*rsa.PublicKey
+	expiresAt time.Time
+}
+
+// newGoogleChatTokenVerifier creates a new verifier. The audience is the Google Cloud project number of the Chat app.
+func newGoogleChatTokenVerifier(audience string) *googleChatTokenVerifier {
+	httpCli := &http.Client{Timeout: googleChatHTTPTimeout}
+	return &googleChatTokenVerifier{
+		audience: audience,
+		fetchKeys: func(ctx context.Context) (map[string]*rsa.PublicKey, error) {
+			return fetchGoogleChatPublicKeys(ctx, httpCli, googleChatCertsURL)
+		},
+		now: time.Now,
+	}
+}
+
+// Verify returns error if a given request doesn't contain a valid Google Chat bearer token.
+func (v *googleChatTokenVerifier) Verify(ctx context.Context, r *http.Request) error {
+	authHeader := r.Header.Get("Authorization")
+	rawToken := strings.TrimPrefix(authHeader, "Bearer ")
+	if rawToken == "" || rawToken == authHeader {
+		return errors.New("missing bearer token")
+	}
+
+	claims := &jwtv4.RegisteredClaims{}
+	parser := jwtv4.NewParser(jwtv4.WithValidMethods([]string{jwtv4.SigningMethodRS256.Alg()}))
+	_, err := parser.ParseWithClaims(rawToken, claims, func(token *jwtv4.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+		return v.publicKey(ctx, kid)
+	})
+	if err != nil {
+		return fmt.Errorf("while parsing bearer token: %w", err)
+	}
+
+	if !claims.VerifyIssuer(googleChatIssuer, true) {
+		return fmt.Errorf("invalid token issuer %q", claims.Issuer)
+	}
+	if !claims.VerifyAudience(v.audience, true) {
+		return fmt.Errorf("invalid token audience %q", claims.Audience)
+	}
+
+	return nil
+}
+
+func (v *googleChatTokenVerifier) publicKey(ctx context.Context, kid string) (*rsa.PublicKey, error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if v.keys == nil || v.now().After(v.expiresAt) {
+		keys, err := v.fetchKeys(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("while fetching Google Chat public keys: %w", err)
+		}
+		v.keys = keys
+		v.expiresAt = v.now().Add(googleChatCertsTTL)
+	}
+
+	key, found := v.keys[kid]
+	if !found {
+		return nil, fmt.Errorf("public key %q not found", kid)
+	}
+	return key, nil
+}
+
+func fetchGoogleChatPublicKeys(ctx context.Context, httpCli *http.Client, certsURL string) (map[string]*rsa.PublicKey, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, certsURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("while creating request: %w", err)
+	}
+
+	res, err := httpCli.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("while sending request: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("got unexpected status code %d", res.StatusCode)
+	}
+
+	var certs map[string]string
+	if err := json.NewDecoder(res.Body).Decode(&certs); err != nil {
+		return nil, fmt.Errorf("while decoding certificates: %w", err)
+	}
+
+	keys := make(map[string]*rsa.PublicKey, len(certs))
+	for kid, cert := range certs {
+		key, err := jwtv4.ParseRSAPublicKeyFromPEM([]byte(cert))
+		if err != nil {
+			return nil, fmt.Errorf("while parsing certificate %q: %w", kid, err)
+		}
+		keys[kid] = key
+	}
+
+	return keys, nil
+}
@@ -0,0 +1,92 @@
+package bot
+
+import (
+	"html"
+
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/events"
+	formatx "github.com/kubeshop/botkube/pkg/format"
+)
+
+// googleChatLevelColor holds text colors for the event level. Google Chat cards support only HTML colors.
+var googleChatLevelColor = map[config.Level]string{
+	config.Info:     "#2eb886",
+	config.Warn:     "#daa038",
+	config.Debug:    "#2eb886",
+	config.Error:    "#a30200",
+	config.Critical: "#a30200",
+}
+
+func (b *GoogleChat) formatMessage(event events.Event) googleChatMessage {
+	card := googleChatCard{
+		Header: &googleChatCardHeader{
+			Title: event.Title,
+		},
+	}
+	if event.Cluster != "" {
+		card.Header.Subtitle = event.Cluster
+	}
+
+	var widgets []googleChatWidget
+	switch eventNotificationType(b.notification, event) {
+	case config.LongNotification:
+		widgets = b.longNotification(event)
+	case config.ShortNotification:
+		fallthrough
+	default:
+		widgets = []googleChatWidget{
+			{TextParagraph: &googleChatTextParagraph{Text: googleChatHTML(formatx.ShortMessage(event))}},
+		}
+	}
+
+	if color, ok := googleChatLevelColor[event.Level]; ok {
+		widgets = append(widgets, googleChatWidget{
+			DecoratedText: &googleChatDecoratedText{
+				TopLabel: "Level",
+				Text:     `<font color="` + color + `">` + html.EscapeString(string(event.Level)) + `</font>`,
+			},
+		})
+	}
+
+	card.Sections = []googleChatCardSection{{Widgets: widgets}}
+	return googleChatMessage{
+		CardsV2: []googleChatCardWithID{
+			{CardID: googleChatCardID, Card: card},
+		},
+	}
+}
+
+func (b *GoogleChat) longNotification(event events.Event) []googleChatWidget {
+	widgets := []googleChatWidget{
+		b.field("Kind", event.Kind),
+		b.field("Name", event.Name),
+	}
+
+	widgets = b.appendIfNotEmpty(widgets, event.Namespace, "Namespace")
+	widgets = b.appendIfNotEmpty(widgets, event.OwnerString(), "Owner")
+	widgets = b.appendIfNotEmpty(widgets, event.Reason, "Reason")
+	widgets = b.appendIfNotEmpty(widgets, formatx.JoinMessages(event.Messages), "Message")
+	widgets = b.appendIfNotEmpty(widgets, event.Action, "Action")
+	widgets = b.appendIfNotEmpty(widgets, formatx.JoinMessages(event.Recommendations), "Recommendations")
+	widgets = b.appendIfNotEmpty(widgets, formatx.JoinMessages(event.Warnings), "Warnings")
+	widgets = b.appendIfNotEmpty(widgets, formatx.EventLogs(event), "Logs")
+
+	return widgets
+}
+
+func (b *GoogleChat) appendIfNotEmpty(widgets []googleChatWidget, in string, title string) []googleChatWidget {
+	if in == "" {
+		return widgets
+	}
+	return append(widgets, b.field(title, in))
+}
+
+func (b *GoogleChat) field(title, value string) googleChatWidget {
+	return googleChatWidget{
+		DecoratedText: &googleChatDecoratedText{
+			TopLabel: title,
+			Text:     googleChatHTML(value),
+			WrapText: true,
+		},
+	}
+}
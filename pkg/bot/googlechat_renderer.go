@@ -0,0 +1,408 @@
+package bot
+
+import (
+	"fmt"
+	"html"
+	"strings"
+
+	"github.com/kubeshop/botkube/pkg/bot/interactive"
+	"github.com/kubeshop/botkube/pkg/execute/command"
+)
+
+const (
+	googleChatCardID = "botkube"
+	// googleChatCommandFunction is the name of the card action function. It's sent back in the CARD_CLICKED event.
+	googleChatCommandFunction = "botkubeCommand"
+
+	googleChatParamCommand = "command"
+	googleChatParamKind    = "kind"
+	googleChatParamInput   = "input"
+
+	googleChatApplyLabel  = "Apply"
+	googleChatSubmitLabel = "Submit"
+)
+
+// googleChatActionKind defines how the card action command is resolved.
+type googleChatActionKind string
+
+const (
+	googleChatButtonAction      googleChatActionKind = "button"
+	googleChatSelectAction      googleChatActionKind = "select"
+	googleChatMultiSelectAction googleChatActionKind = "multiSelect"
+	googleChatInputAction       googleChatActionKind = "input"
+)
+
+// googleChatButtonColor holds button colors for a given button style.
+var googleChatButtonColor = map[interactive.ButtonStyle]*googleChatColor{
+	interactive.ButtonStylePrimary: {Red: 0.1, Green: 0.45, Blue: 0.91, Alpha: 1},
+	interactive.ButtonStyleDanger:  {Red: 0.85, Green: 0.19, Blue: 0.15, Alpha: 1},
+}
+
+type googleChatMessage struct {
+	Text           string                    `json:"text,omitempty"`
+	CardsV2        []googleChatCardWithID    `json:"cardsV2,omitempty"`
+	Thread         *googleChatThread         `json:"thread,omitempty"`
+	ActionResponse *googleChatActionResponse `json:"actionResponse,omitempty"`
+}
+
+type googleChatActionResponse struct {
+	Type string `json:"type"`
+}
+
+type googleChatCardWithID struct {
+	CardID string         `json:"cardId"`
+	Card   googleChatCard `json:"card"`
+}
+
+type googleChatCard struct {
+	Header   *googleChatCardHeader   `json:"header,omitempty"`
+	Sections []googleChatCardSection `json:"sections,omitempty"`
+}
+
+type googleChatCardHeader struct {
+	Title    string `json:"title"`
+	Subtitle string `json:"subtitle,omitempty"`
+}
+
+type googleChatCardSection struct {
+	Header  string             `json:"header,omitempty"`
+	Widgets []googleChatWidget `json:"widgets"`
+}
+
+type googleChatWidget struct {
+	TextParagraph  *googleChatTextParagraph  `json:"textParagraph,omitempty"`
+	DecoratedText  *googleChatDecoratedText  `json:"decoratedText,omitempty"`
+	ButtonList     *googleChatButtonList     `json:"buttonList,omitempty"`
+	SelectionInput *googleChatSelectionInput `json:"selectionInput,omitempty"`
+	TextInput      *googleChatTextInput      `json:"textInput,omitempty"`
+}
+
+type googleChatTextParagraph struct {
+	Text string `json:"text"`
+}
+
+type googleChatDecoratedText struct {
+	TopLabel string `json:"topLabel,omitempty"`
+	Text     string `json:"text"`
+	WrapText bool   `json:"wrapText"`
+}
+
+type googleChatButtonList struct {
+	Buttons []googleChatButton `json:"buttons"`
+}
+
+type googleChatButton struct {
+	Text    string            `json:"text"`
+	OnClick googleChatOnClick `json:"onClick"`
+	Color   *googleChatColor  `json:"color,omitempty"`
+}
+
+type googleChatColor struct {
+	Red   float64 `json:"red"`
+	Green float64 `json:"green"`
+	Blue  float64 `json:"blue"`
+	Alpha float64 `json:"alpha"`
+}
+
+type googleChatOnClick struct {
+	Action   *googleChatAction   `json:"action,omitempty"`
+	OpenLink *googleChatOpenLink `json:"openLink,omitempty"`
+}
+
+type googleChatOpenLink struct {
+	URL string `json:"url"`
+}
+
+type googleChatAction struct {
+	Function   string                      `json:"function"`
+	Parameters []googleChatActionParameter `json:"parameters,omitempty"`
+}
+
+type googleChatActionParameter struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+type googleChatSelectionInput struct {
+	Name           string                    `json:"name"`
+	Label          string                    `json:"label,omitempty"`
+	Type           string                    `json:"type"`
+	Items          []googleChatSelectionItem `json:"items"`
+	OnChangeAction *googleChatAction         `json:"onChangeAction,omitempty"`
+}
+
+type googleChatSelectionItem struct {
+	Text     string `json:"text"`
+	Value    string `json:"value"`
+	Selected bool   `json:"selected"`
+}
+
+type googleChatTextInput struct {
+	Name     string `json:"name"`
+	Label    string `json:"label,omitempty"`
+	HintText string `json:"hintText,omitempty"`
+}
+
+// googleChatCommonEventObject holds the card action details sent in the CARD_CLICKED event.
+type googleChatCommonEventObject struct {
+	InvokedFunction string                         `json:"invokedFunction"`
+	Parameters      map[string]string              `json:"parameters"`
+	FormInputs      map[string]googleChatFormInput `json:"formInputs"`
+}
+
+type googleChatFormInput struct {
+	StringInputs struct {
+		Value []string `json:"value"`
+	} `json:"stringInputs"`
+}
+
+// GoogleChatRenderer provides functionality to render interactive messages as Google Chat cards.
+type GoogleChatRenderer struct {
+	mdFormatter interactive.MDFormatter
+}
+
+// NewGoogleChatRenderer returns new GoogleChatRenderer instance.
+func NewGoogleChatRenderer() *GoogleChatRenderer {
+	return &GoogleChatRenderer{
+		mdFormatter: interactive.NewMDFormatter(interactive.NewlineFormatter, mdHeaderFormatter),
+	}
+}
+
+// RenderMessage returns a Google Chat message for a given interactive message.
+// The message base is rendered as text, while sections and inputs are rendered as a card.
+func (r *GoogleChatRenderer) RenderMessage(msg interactive.Message) googleChatMessage {
+	if !msg.HasSections() && !msg.HasInputs() {
+		return googleChatMessage{
+			Text: interactive.RenderMessage(r.mdFormatter, msg),
+		}
+	}
+
+	var card googleChatCard
+	for idx, section := range msg.Sections {
+		card.Sections = append(card.Sections, r.renderSection(idx, section))
+	}
+
+	if len(msg.PlaintextInputs) > 0 {
+		card.Sections = append(card.Sections, googleChatCardSection{
+			Widgets: r.renderInputs(len(msg.Sections), msg.PlaintextInputs),
+		})
+	}
+
+	return googleChatMessage{
+		Text: interactive.RenderMessage(r.mdFormatter, interactive.Message{Base: msg.Base}),
+		CardsV2: []googleChatCardWithID{
+			{CardID: googleChatCardID, Card: card},
+		},
+	}
+}
+
+func (r *GoogleChatRenderer) renderSection(idx int, section interactive.Section) googleChatCardSection {
+	out := googleChatCardSection{
+		Header: html.EscapeString(section.Header),
+	}
+
+	if text := r.sectionText(section); text != "" {
+		out.Widgets = append(out.Widgets, googleChatWidget{
+			TextParagraph: &googleChatTextParagraph{Text: text},
+		})
+	}
+
+	for _, field := range section.TextFields {
+		out.Widgets = append(out.Widgets, googleChatWidget{
+			DecoratedText: &googleChatDecoratedText{Text: googleChatHTML(field.Text), WrapText: true},
+		})
+	}
+
+	for itemIdx, item := range section.Selects.Items {
+		// external selects are not supported, as Google Chat cards don't allow to load options dynamically
+		if item.Type == interactive.ExternalSelect {
+			continue
+		}
+
+		inputName := googleChatInputName(googleChatSelectAction, idx, itemIdx)
+		selection := &googleChatSelectionInput{
+			Name:           inputName,
+			Label:          item.Name,
+			Type:           "DROPDOWN",
+			OnChangeAction: googleChatCommandAction(item.Command, googleChatSelectAction, inputName),
+		}
+		for _, group := range item.OptionGroups {
+			for _, opt := range group.Options {
+				selection.Items = append(selection.Items, googleChatSelectionItem{
+					Text:     opt.Name,
+					Value:    opt.Value,
+					Selected: item.InitialOption != nil && item.InitialOption.Value == opt.Value,
+				})
+			}
+		}
+		out.Widgets = append(out.Widgets, googleChatWidget{SelectionInput: selection})
+	}
+
+	if ms := section.MultiSelect; ms.AreOptionsDefined() {
+		inputName := googleChatInputName(googleChatMultiSelectAction, idx, 0)
+		selection := &googleChatSelectionInput{
+			Name:  inputName,
+			Label: ms.Name,
+			Type:  "CHECK_BOX",
+		}
+		for _, opt := range ms.Options {
+			selection.Items = append(selection.Items, googleChatSelectionItem{
+				Text:     opt.Name,
+				Value:    opt.Value,
+				Selected: isOptionSelected(ms.InitialOptions, opt.Value),
+			})
+		}
+		out.Widgets = append(out.Widgets,
+			googleChatWidget{SelectionInput: selection},
+			googleChatWidget{ButtonList: &googleChatButtonList{Buttons: []googleChatButton{
+				{
+					Text:    googleChatApplyLabel,
+					OnClick: googleChatOnClick{Action: googleChatCommandAction(ms.Command, googleChatMultiSelectAction, inputName)},
+				},
+			}}},
+		)
+	}
+
+	out.Widgets = append(out.Widgets, r.renderInputs(idx, section.PlaintextInputs)...)
+
+	if buttons := r.renderButtons(section.Buttons); len(buttons) > 0 {
+		out.Widgets = append(out.Widgets, googleChatWidget{
+			ButtonList: &googleChatButtonList{Buttons: buttons},
+		})
+	}
+
+	var context []string
+	for _, item := range section.Context {
+		context = append(context, googleChatHTML(item.Text))
+	}
+	if len(context) > 0 {
+		out.Widgets = append(out.Widgets, googleChatWidget{
+			TextParagraph: &googleChatTextParagraph{Text: fmt.Sprintf("<font color=\"#80868b\">%s</font>", strings.Join(context, " "))},
+		})
+	}
+
+	return out
+}
+
+func (r *GoogleChatRenderer) sectionText(section interactive.Section) string {
+	var lines []string
+	if section.Description != "" {
+		lines = append(lines, googleChatHTML(section.Description))
+	}
+	if section.Body.Plaintext != "" {
+		lines = append(lines, googleChatHTML(section.Body.Plaintext))
+	}
+	if section.Body.CodeBlock != "" {
+		lines = append(lines, googleChatHTML(section.Body.CodeBlock))
+	}
+	if desc := section.MultiSelect.Description; section.MultiSelect.AreOptionsDefined() {
+		if desc.Plaintext != "" {
+			lines = append(lines, googleChatHTML(desc.Plaintext))
+		}
+		if desc.CodeBlock != "" {
+			lines = append(lines, googleChatHTML(desc.CodeBlock))
+		}
+	}
+
+	return strings.Join(lines, "<br>")
+}
+
+func (r *GoogleChatRenderer) renderButtons(in interactive.Buttons) []googleChatButton {
+	var out []googleChatButton
+	for _, btn := range in {
+		button := googleChatButton{
+			Text:  btn.Name,
+			Color: googleChatButtonColor[btn.Style],
+		}
+		switch {
+		case btn.URL != "":
+			button.OnClick.OpenLink = &googleChatOpenLink{URL: btn.URL}
+		case btn.Command != "":
+			button.OnClick.Action = googleChatCommandAction(btn.Command, googleChatButtonAction, "")
+		default:
+			continue
+		}
+		out = append(out, button)
+	}
+	return out
+}
+
+func (r *GoogleChatRenderer) renderInputs(sectionIdx int, inputs interactive.LabelInputs) []googleChatWidget {
+	var out []googleChatWidget
+	for idx, input := range inputs {
+		inputName := googleChatInputName(googleChatInputAction, sectionIdx, idx)
+		out = append(out,
+			googleChatWidget{TextInput: &googleChatTextInput{
+				Name:     inputName,
+				Label:    input.Text,
+				HintText: input.Placeholder,
+			}},
+			googleChatWidget{ButtonList: &googleChatButtonList{Buttons: []googleChatButton{
+				{
+					Text:    googleChatSubmitLabel,
+					OnClick: googleChatOnClick{Action: googleChatCommandAction(input.Command, googleChatInputAction, inputName)},
+				},
+			}}},
+		)
+	}
+	return out
+}
+
+// resolveGoogleChatCommand returns a command for a given card action.
+func resolveGoogleChatCommand(common googleChatCommonEventObject) (string, command.Origin) {
+	cmd := common.Parameters[googleChatParamCommand]
+	values := common.FormInputs[common.Parameters[googleChatParamInput]].StringInputs.Value
+
+	switch googleChatActionKind(common.Parameters[googleChatParamKind]) {
+	case googleChatSelectAction:
+		var selected string
+		if len(values) > 0 {
+			selected = values[0]
+		}
+		return fmt.Sprintf("%s %s", cmd, selected), command.SelectValueChangeOrigin
+	case googleChatMultiSelectAction:
+		return fmt.Sprintf("%s %s", cmd, strings.Join(values, ",")), command.MultiSelectValueChangeOrigin
+	case googleChatInputAction:
+		var value string
+		if len(values) > 0 {
+			value = strings.TrimSpace(values[0])
+		}
+		return fmt.Sprintf("%s%q", cmd, value), command.PlainTextInputOrigin
+	default:
+		return cmd, command.ButtonClickOrigin
+	}
+}
+
+func googleChatCommandAction(cmd string, kind googleChatActionKind, inputName string) *googleChatAction {
+	params := []googleChatActionParameter{
+		{Key: googleChatParamCommand, Value: cmd},
+		{Key: googleChatParamKind, Value: string(kind)},
+	}
+	if inputName != "" {
+		params = append(params, googleChatActionParameter{Key: googleChatParamInput, Value: inputName})
+	}
+
+	return &googleChatAction{
+		Function:   googleChatCommandFunction,
+		Parameters: params,
+	}
+}
+
+func googleChatInputName(kind googleChatActionKind, sectionIdx, itemIdx int) string {
+	return fmt.Sprintf("%s-%d-%d", kind, sectionIdx, itemIdx)
+}
+
+// googleChatHTML escapes a given text, as card text widgets support only a simple HTML formatting.
+// Card widgets don't support code blocks, so they are rendered as a regular text.
+func googleChatHTML(in string) string {
+	return strings.ReplaceAll(html.EscapeString(strings.TrimSpace(in)), "\n", "<br>")
+}
+
+func isOptionSelected(options []interactive.OptionItem, value string) bool {
+	for _, opt := range options {
+		if opt.Value == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,168 @@
+package bot
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/pkg/bot/interactive"
+	"github.com/kubeshop/botkube/pkg/execute/command"
+)
+
+func TestGoogleChatRenderer_RenderMessage(t *testing.T) {
+	// given
+	renderer := NewGoogleChatRenderer()
+	msg := interactive.Message{
+		Base: interactive.Base{
+			Header: "Header",
+		},
+		Sections: []interactive.Section{
+			{
+				Base: interactive.Base{
+					Header:      "Section",
+					Description: "Pods <all>",
+				},
+				Buttons: interactive.Buttons{
+					{Name: "Run", Command: "@Botkube get pods", Style: interactive.ButtonStylePrimary},
+					{Name: "Docs", URL: "https://docs.botkube.io"},
+				},
+				Selects: interactive.Selects{
+					Items: []interactive.Select{
+						{
+							Name:    "Verbs",
+							Command: "@Botkube kcc --verbs",
+							OptionGroups: []interactive.OptionGroup{
+								{Name: "Verbs", Options: []interactive.OptionItem{{Name: "get", Value: "get"}, {Name: "logs", Value: "logs"}}},
+							},
+							InitialOption: &interactive.OptionItem{Name: "logs", Value: "logs"},
+						},
+					},
+				},
+				MultiSelect: interactive.MultiSelect{
+					Name:           "Sources",
+					Command:        "@Botkube edit SourceBindings",
+					Options:        []interactive.OptionItem{{Name: "Errors", Value: "k8s-err-events"}, {Name: "Create", Value: "k8s-create-events"}},
+					InitialOptions: []interactive.OptionItem{{Name: "Create", Value: "k8s-create-events"}},
+				},
+			},
+		},
+	}
+
+	// when
+	out := renderer.RenderMessage(msg)
+
+	// then
+	assert.Equal(t, "*Header*\n", out.Text)
+	require.Len(t, out.CardsV2, 1)
+	require.Len(t, out.CardsV2[0].Card.Sections, 1)
+
+	section := out.CardsV2[0].Card.Sections[0]
+	assert.Equal(t, "Section", section.Header)
+	require.Len(t, section.Widgets, 5)
+
+	assert.Equal(t, "Pods &lt;all&gt;", section.Widgets[0].TextParagraph.Text)
+
+	dropdown := section.Widgets[1].SelectionInput
+	require.NotNil(t, dropdown)
+	assert.Equal(t, "DROPDOWN", dropdown.Type)
+	assert.Equal(t, []googleChatSelectionItem{{Text: "get", Value: "get"}, {Text: "logs", Value: "logs", Selected: true}}, dropdown.Items)
+	assert.Equal(t, googleChatCommandAction("@Botkube kcc --verbs", googleChatSelectAction, dropdown.Name), dropdown.OnChangeAction)
+
+	checkboxes := section.Widgets[2].SelectionInput
+	require.NotNil(t, checkboxes)
+	assert.Equal(t, "CHECK_BOX", checkboxes.Type)
+	assert.Equal(t, []googleChatSelectionItem{{Text: "Errors", Value: "k8s-err-events"}, {Text: "Create", Value: "k8s-create-events", Selected: true}}, checkboxes.Items)
+	assert.NotEqual(t, dropdown.Name, checkboxes.Name)
+
+	apply := section.Widgets[3].ButtonList.Buttons
+	require.Len(t, apply, 1)
+	assert.Equal(t, googleChatCommandAction("@Botkube edit SourceBindings", googleChatMultiSelectAction, checkboxes.Name), apply[0].OnClick.Action)
+
+	buttons := section.Widgets[4].ButtonList.Buttons
+	require.Len(t, buttons, 2)
+	assert.Equal(t, googleChatCommandAction("@Botkube get pods", googleChatButtonAction, ""), buttons[0].OnClick.Action)
+	assert.Equal(t, googleChatButtonColor[interactive.ButtonStylePrimary], buttons[0].Color)
+	assert.Equal(t, &googleChatOpenLink{URL: "https://docs.botkube.io"}, buttons[1].OnClick.OpenLink)
+}
+
+func TestGoogleChatRenderer_RenderPlainMessage(t *testing.T) {
+	// given
+	renderer := NewGoogleChatRenderer()
+	msg := interactive.Message{
+		Base: interactive.Base{
+			Description: "Pods",
+			Body:        interactive.Body{CodeBlock: "nginx"},
+		},
+	}
+
+	// when
+	out := renderer.RenderMessage(msg)
+
+	// then
+	assert.Equal(t, "Pods\n```\nnginx\n```\n", out.Text)
+	assert.Empty(t, out.CardsV2)
+}
+
+func TestResolveGoogleChatCommand(t *testing.T) {
+	// given
+	testCases := []struct {
+		Name           string
+		Kind           googleChatActionKind
+		Values         []string
+		ExpectedCmd    string
+		ExpectedOrigin command.Origin
+	}{
+		{
+			Name:           "Button",
+			Kind:           googleChatButtonAction,
+			ExpectedCmd:    "@Botkube kcc",
+			ExpectedOrigin: command.ButtonClickOrigin,
+		},
+		{
+			Name:           "Select",
+			Kind:           googleChatSelectAction,
+			Values:         []string{"get"},
+			ExpectedCmd:    "@Botkube kcc get",
+			ExpectedOrigin: command.SelectValueChangeOrigin,
+		},
+		{
+			Name:           "Multi select",
+			Kind:           googleChatMultiSelectAction,
+			Values:         []string{"k8s-err-events", "k8s-create-events"},
+			ExpectedCmd:    "@Botkube kcc k8s-err-events,k8s-create-events",
+			ExpectedOrigin: command.MultiSelectValueChangeOrigin,
+		},
+		{
+			Name:           "Input",
+			Kind:           googleChatInputAction,
+			Values:         []string{" nginx "},
+			ExpectedCmd:    `@Botkube kcc"nginx"`,
+			ExpectedOrigin: command.PlainTextInputOrigin,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			var input googleChatFormInput
+			input.StringInputs.Value = tc.Values
+
+			common := googleChatCommonEventObject{
+				InvokedFunction: googleChatCommandFunction,
+				Parameters: map[string]string{
+					googleChatParamCommand: "@Botkube kcc",
+					googleChatParamKind:    string(tc.Kind),
+					googleChatParamInput:   "input",
+				},
+				FormInputs: map[string]googleChatFormInput{"input": input},
+			}
+
+			// when
+			cmd, origin := resolveGoogleChatCommand(common)
+
+			// then
+			assert.Equal(t, tc.ExpectedCmd, cmd)
+			assert.Equal(t, tc.ExpectedOrigin, origin)
+		})
+	}
+}
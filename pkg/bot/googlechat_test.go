@@ -0,0 +1,199 @@
+package bot
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	jwtv4 "github.com/golang-jwt/jwt/v4"
+	logtest "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/pkg/bot/interactive"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/execute"
+)
+
+const googleChatTestProjectNumber = "123456789"
+
+func TestGoogleChat_HandleEvent(t *testing.T) {
+	// given
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	validClaims := jwtv4.RegisteredClaims{
+		Issuer:    googleChatIssuer,
+		Audience:  jwtv4.ClaimStrings{googleChatTestProjectNumber},
+		ExpiresAt: jwtv4.NewNumericDate(time.Now().Add(time.Hour)),
+	}
+	messageEvent := googleChatEvent{
+		Type:  googleChatMessageEvent,
+		Space: googleChatSpace{Name: "spaces/AAA"},
+		User:  googleChatUser{Name: "users/1"},
+		Message: &googleChatEventMessage{
+			Text:         "@Botkube get pods",
+			ArgumentText: " get pods",
+		},
+	}
+
+	testCases := []struct {
+		Name           string
+		Claims         jwtv4.RegisteredClaims
+		Event          googleChatEvent
+		ExpectedStatus int
+		ExpectedCmd    string
+		ExpectedText   string
+	}{
+		{
+			Name:           "Message",
+			Claims:         validClaims,
+			Event:          messageEvent,
+			ExpectedStatus: http.StatusOK,
+			ExpectedCmd:    "get pods",
+			ExpectedText:   "done\n",
+		},
+		{
+			Name:   "Card clicked",
+			Claims: validClaims,
+			Event: googleChatEvent{
+				Type:  googleChatCardClickedEvent,
+				Space: googleChatSpace{Name: "spaces/AAA"},
+				Common: googleChatCommonEventObject{
+					InvokedFunction: googleChatCommandFunction,
+					Parameters: map[string]string{
+						googleChatParamCommand: "@Botkube logs nginx",
+						googleChatParamKind:    string(googleChatButtonAction),
+					},
+				},
+			},
+			ExpectedStatus: http.StatusOK,
+			ExpectedCmd:    " logs nginx",
+			ExpectedText:   "done\n",
+		},
+		{
+			Name: "Invalid audience",
+			Claims: jwtv4.RegisteredClaims{
+				Issuer:    googleChatIssuer,
+				Audience:  jwtv4.ClaimStrings{"987654321"},
+				ExpiresAt: jwtv4.NewNumericDate(time.Now().Add(time.Hour)),
+			},
+			Event:          messageEvent,
+			ExpectedStatus: http.StatusUnauthorized,
+		},
+		{
+			Name: "Invalid issuer",
+			Claims: jwtv4.RegisteredClaims{
+				Issuer:    "attacker@example.com",
+				Audience:  jwtv4.ClaimStrings{googleChatTestProjectNumber},
+				ExpiresAt: jwtv4.NewNumericDate(time.Now().Add(time.Hour)),
+			},
+			Event:          messageEvent,
+			ExpectedStatus: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			factory := &fakeExecutorFactory{response: interactive.Message{Base: interactive.Base{Description: "done"}}}
+			bot := newTestGoogleChat(t, factory, &key.PublicKey)
+
+			token := jwtv4.NewWithClaims(jwtv4.SigningMethodRS256, tc.Claims)
+			token.Header["kid"] = "key-1"
+			rawToken, err := token.SignedString(key)
+			require.NoError(t, err)
+
+			body, err := json.Marshal(tc.Event)
+			require.NoError(t, err)
+
+			req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(body))
+			req.Header.Set("Authorization", "Bearer "+rawToken)
+			rec := httptest.NewRecorder()
+
+			// when
+			bot.router().ServeHTTP(rec, req)
+
+			// then
+			assert.Equal(t, tc.ExpectedStatus, rec.Code)
+			if tc.ExpectedStatus != http.StatusOK {
+				assert.Empty(t, factory.gotInputs)
+				return
+			}
+
+			require.Len(t, factory.gotInputs, 1)
+			assert.Equal(t, tc.ExpectedCmd, factory.gotInputs[0].Message)
+			assert.Equal(t, "spaces/AAA", factory.gotInputs[0].Conversation.ID)
+			assert.True(t, factory.gotInputs[0].Conversation.IsAuthenticated)
+
+			var resp googleChatMessage
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+			assert.Equal(t, tc.ExpectedText, resp.Text)
+		})
+	}
+}
+
+func TestGoogleChat_HandleEventWithoutToken(t *testing.T) {
+	// given
+	factory := &fakeExecutorFactory{}
+	bot := newTestGoogleChat(t, factory, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader([]byte(`{"type":"MESSAGE"}`)))
+	rec := httptest.NewRecorder()
+
+	// when
+	bot.router().ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.Empty(t, factory.gotInputs)
+}
+
+func newTestGoogleChat(t *testing.T, factory ExecutorFactory, publicKey *rsa.PublicKey) *GoogleChat {
+	t.Helper()
+
+	logger, _ := logtest.NewNullLogger()
+	botMentionRegex, err := googleChatBotMentionRegex("Botkube")
+	require.NoError(t, err)
+
+	verifier := newGoogleChatTokenVerifier(googleChatTestProjectNumber)
+	verifier.fetchKeys = func(context.Context) (map[string]*rsa.PublicKey, error) {
+		return map[string]*rsa.PublicKey{"key-1": publicKey}, nil
+	}
+
+	return &GoogleChat{
+		log:             logger,
+		executorFactory: factory,
+		verifier:        verifier,
+		renderer:        NewGoogleChatRenderer(),
+		messagePath:     "/",
+		channels: googleChatChannelsCfgFrom(config.IdentifiableMap[config.ChannelBindingsByName]{
+			"default": {Name: "spaces/AAA"},
+		}),
+		botMentionRegex: botMentionRegex,
+		mdFormatter:     interactive.NewMDFormatter(interactive.NewlineFormatter, mdHeaderFormatter),
+	}
+}
+
+type fakeExecutorFactory struct {
+	response  interactive.Message
+	gotInputs []execute.NewDefaultInput
+}
+
+func (f *fakeExecutorFactory) NewDefault(cfg execute.NewDefaultInput) execute.Executor {
+	f.gotInputs = append(f.gotInputs, cfg)
+	return &fakeExecutor{response: f.response}
+}
+
+type fakeExecutor struct {
+	response interactive.Message
+}
+
+func (f *fakeExecutor) Execute(context.Context) interactive.Message {
+	return f.response
+}
@@ -0,0 +1,143 @@
+package identity
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+// emailCacheTTL is the time for which the resolved emails are cached to avoid hitting the chat platform API rate limits.
+const emailCacheTTL = time.Hour
+
+// Identity describes a Kubernetes user.
+type Identity struct {
+	Username string
+	Groups   []string
+}
+
+// IsEmpty returns true if the identity is not set.
+func (i Identity) IsEmpty() bool {
+	return i.Username == ""
+}
+
+// EmailLookup provides functionality to get the email of a given chat platform user.
+type EmailLookup interface {
+	LookupEmail(ctx context.Context, userID string) (string, error)
+}
+
+type userKey struct {
+	platform config.CommPlatformIntegration
+	userID   string
+}
+
+type cachedEmail struct {
+	email     string
+	expiresAt time.Time
+}
+
+// Resolver maps chat platform users to Kubernetes identities.
+type Resolver struct {
+	log    logrus.FieldLogger
+	cfg    config.IdentityMapping
+	static map[userKey]Identity
+
+	mu      sync.RWMutex
+	lookups map[config.CommPlatformIntegration][]EmailLookup
+	emails  map[userKey]cachedEmail
+	nowFn   func() time.Time
+}
+
+// NewResolver returns a new Resolver instance.
+func NewResolver(log logrus.FieldLogger, cfg config.IdentityMapping) *Resolver {
+	static := make(map[userKey]Identity, len(cfg.Users))
+	for _, user := range cfg.Users {
+		static[userKey{platform: user.Platform, userID: user.UserID}] = Identity{
+			Username: user.Username,
+			Groups:   user.Groups,
+		}
+	}
+
+	return &Resolver{
+		log:     log,
+		cfg:     cfg,
+		static:  static,
+		lookups: map[config.CommPlatformIntegration][]EmailLookup{},
+		emails:  map[userKey]cachedEmail{},
+		nowFn:   time.Now,
+	}
+}
+
+// RegisterEmailLookup registers the email lookup for a given platform.
+// Multiple lookups can be registered for the same platform, e.g. one for each Slack workspace. They are called in order until the email is found.
+func (r *Resolver) RegisterEmailLookup(platform config.CommPlatformIntegration, lookup EmailLookup) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.lookups[platform] = append(r.lookups[platform], lookup)
+}
+
+// Resolve returns the Kubernetes identity of a given chat platform user.
+// It returns an empty identity if the user is not mapped.
+func (r *Resolver) Resolve(ctx context.Context, platform config.CommPlatformIntegration, userID string) (Identity, error) {
+	if userID == "" {
+		return Identity{}, nil
+	}
+
+	key := userKey{platform: platform, userID: userID}
+	if identity, found := r.static[key]; found {
+		return identity, nil
+	}
+
+	if !r.cfg.EmailLookup.Enabled {
+		return Identity{}, nil
+	}
+
+	email, err := r.lookupEmail(ctx, key)
+	if err != nil {
+		return Identity{}, err
+	}
+	if email == "" {
+		return Identity{}, nil
+	}
+
+	return Identity{
+		Username: r.cfg.EmailLookup.UsernamePrefix + email,
+		Groups:   r.cfg.EmailLookup.Groups,
+	}, nil
+}
+
+func (r *Resolver) lookupEmail(ctx context.Context, key userKey) (string, error) {
+	r.mu.RLock()
+	cached, found := r.emails[key]
+	lookups := r.lookups[key.platform]
+	r.mu.RUnlock()
+
+	if found && r.nowFn().Before(cached.expiresAt) {
+		return cached.email, nil
+	}
+
+	for _, lookup := range lookups {
+		email, err := lookup.LookupEmail(ctx, key.userID)
+		if err != nil {
+			r.log.Debugf("while looking up email for user %q: %s", key.userID, err.Error())
+			continue
+		}
+		if email == "" {
+			continue
+		}
+
+		r.mu.Lock()
+		r.emails[key] = cachedEmail{email: email, expiresAt: r.nowFn().Add(emailCacheTTL)}
+		r.mu.Unlock()
+		return email, nil
+	}
+
+	if len(lookups) == 0 {
+		return "", nil
+	}
+	return "", fmt.Errorf("cannot find email for user %q on %q platform", key.userID, key.platform)
+}
@@ -0,0 +1,147 @@
+package identity
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	logtest "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+func TestResolver_Resolve(t *testing.T) {
+	// given
+	staticUsers := []config.UserIdentityMapping{
+		{Platform: config.SocketSlackCommPlatformIntegration, UserID: "U01", Username: "alice", Groups: []string{"devs"}},
+	}
+	emails := fakeEmailLookup{"U01": "alice@example.com", "U02": "bob@example.com"}
+
+	tests := []struct {
+		name string
+
+		cfg         config.IdentityMapping
+		platform    config.CommPlatformIntegration
+		userID      string
+		expIdentity Identity
+		expErrMsg   string
+	}{
+		{
+			name:        "Should return static identity",
+			cfg:         config.IdentityMapping{Users: staticUsers, EmailLookup: config.EmailIdentityLookup{Enabled: true}},
+			platform:    config.SocketSlackCommPlatformIntegration,
+			userID:      "U01",
+			expIdentity: Identity{Username: "alice", Groups: []string{"devs"}},
+		},
+		{
+			name:     "Should not match static identity from other platform",
+			cfg:      config.IdentityMapping{Users: staticUsers},
+			platform: config.DiscordCommPlatformIntegration,
+			userID:   "U01",
+		},
+		{
+			name: "Should return identity based on email",
+			cfg: config.IdentityMapping{
+				Users: staticUsers,
+				EmailLookup: config.EmailIdentityLookup{
+					Enabled:        true,
+					UsernamePrefix: "oidc:",
+					Groups:         []string{"sso"},
+				},
+			},
+			platform:    config.SocketSlackCommPlatformIntegration,
+			userID:      "U02",
+			expIdentity: Identity{Username: "oidc:bob@example.com", Groups: []string{"sso"}},
+		},
+		{
+			name:     "Should return empty identity if email lookup is disabled",
+			cfg:      config.IdentityMapping{Users: staticUsers},
+			platform: config.SocketSlackCommPlatformIntegration,
+			userID:   "U02",
+		},
+		{
+			name:     "Should return empty identity if there is no email lookup for a given platform",
+			cfg:      config.IdentityMapping{EmailLookup: config.EmailIdentityLookup{Enabled: true}},
+			platform: config.DiscordCommPlatformIntegration,
+			userID:   "U02",
+		},
+		{
+			name:      "Should return error if email is not found",
+			cfg:       config.IdentityMapping{EmailLookup: config.EmailIdentityLookup{Enabled: true}},
+			platform:  config.SocketSlackCommPlatformIntegration,
+			userID:    "U03",
+			expErrMsg: `cannot find email for user "U03" on "socketSlack" platform`,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			logger, _ := logtest.NewNullLogger()
+			resolver := NewResolver(logger, tc.cfg)
+			resolver.RegisterEmailLookup(config.SocketSlackCommPlatformIntegration, emails)
+
+			// when
+			got, err := resolver.Resolve(context.Background(), tc.platform, tc.userID)
+
+			// then
+			if tc.expErrMsg != "" {
+				require.EqualError(t, err, tc.expErrMsg)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expIdentity, got)
+		})
+	}
+}
+
+func TestResolver_CachesEmails(t *testing.T) {
+	// given
+	logger, _ := logtest.NewNullLogger()
+	resolver := NewResolver(logger, config.IdentityMapping{EmailLookup: config.EmailIdentityLookup{Enabled: true}})
+
+	now := time.Now()
+	resolver.nowFn = func() time.Time { return now }
+
+	lookup := &countingEmailLookup{email: "alice@example.com"}
+	resolver.RegisterEmailLookup(config.SocketSlackCommPlatformIntegration, lookup)
+
+	// when
+	for i := 0; i < 3; i++ {
+		_, err := resolver.Resolve(context.Background(), config.SocketSlackCommPlatformIntegration, "U01")
+		require.NoError(t, err)
+	}
+
+	// then
+	assert.Equal(t, 1, lookup.calls)
+
+	// when
+	now = now.Add(emailCacheTTL + time.Second)
+	got, err := resolver.Resolve(context.Background(), config.SocketSlackCommPlatformIntegration, "U01")
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, "alice@example.com", got.Username)
+	assert.Equal(t, 2, lookup.calls)
+}
+
+type fakeEmailLookup map[string]string
+
+func (f fakeEmailLookup) LookupEmail(_ context.Context, userID string) (string, error) {
+	email, found := f[userID]
+	if !found {
+		return "", errors.New("user not found")
+	}
+	return email, nil
+}
+
+type countingEmailLookup struct {
+	email string
+	calls int
+}
+
+func (c *countingEmailLookup) LookupEmail(context.Context, string) (string, error) {
+	c.calls++
+	return c.email, nil
+}
@@ -0,0 +1,95 @@
+package identity
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+// userGroupsCacheTTL is the time for which the resolved user groups are cached. It's short, so the membership changes are applied quickly.
+const userGroupsCacheTTL = 5 * time.Minute
+
+// UserGroupLookup provides functionality to get the chat platform user groups of a given user.
+type UserGroupLookup interface {
+	LookupUserGroups(ctx context.Context, userID string) ([]string, error)
+}
+
+type cachedUserGroups struct {
+	groups    []string
+	expiresAt time.Time
+}
+
+// UserGroupResolver resolves the chat platform user groups, e.g. Slack user groups, of a given user.
+type UserGroupResolver struct {
+	log logrus.FieldLogger
+
+	mu      sync.RWMutex
+	lookups map[config.CommPlatformIntegration][]UserGroupLookup
+	groups  map[userKey]cachedUserGroups
+	nowFn   func() time.Time
+}
+
+// NewUserGroupResolver returns a new UserGroupResolver instance.
+func NewUserGroupResolver(log logrus.FieldLogger) *UserGroupResolver {
+	return &UserGroupResolver{
+		log:     log,
+		lookups: map[config.CommPlatformIntegration][]UserGroupLookup{},
+		groups:  map[userKey]cachedUserGroups{},
+		nowFn:   time.Now,
+	}
+}
+
+// RegisterUserGroupLookup registers the user group lookup for a given platform.
+// Multiple lookups can be registered for the same platform, e.g. one for each Slack workspace. The groups found by all lookups are returned.
+func (r *UserGroupResolver) RegisterUserGroupLookup(platform config.CommPlatformIntegration, lookup UserGroupLookup) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.lookups[platform] = append(r.lookups[platform], lookup)
+}
+
+// Resolve returns the user groups of a given chat platform user. It returns nil if the platform doesn't support user groups.
+func (r *UserGroupResolver) Resolve(ctx context.Context, platform config.CommPlatformIntegration, userID string) ([]string, error) {
+	if userID == "" {
+		return nil, nil
+	}
+
+	key := userKey{platform: platform, userID: userID}
+	r.mu.RLock()
+	cached, found := r.groups[key]
+	lookups := r.lookups[platform]
+	r.mu.RUnlock()
+
+	if found && r.nowFn().Before(cached.expiresAt) {
+		return cached.groups, nil
+	}
+
+	var (
+		out      []string
+		lastErr  error
+		resolved bool
+	)
+	for _, lookup := range lookups {
+		groups, err := lookup.LookupUserGroups(ctx, userID)
+		if err != nil {
+			r.log.Debugf("while looking up user groups for user %q: %s", userID, err.Error())
+			lastErr = err
+			continue
+		}
+		resolved = true
+		out = append(out, groups...)
+	}
+
+	if !resolved && lastErr != nil {
+		return nil, fmt.Errorf("cannot find user groups for user %q on %q platform: %w", userID, platform, lastErr)
+	}
+
+	r.mu.Lock()
+	r.groups[key] = cachedUserGroups{groups: out, expiresAt: r.nowFn().Add(userGroupsCacheTTL)}
+	r.mu.Unlock()
+	return out, nil
+}
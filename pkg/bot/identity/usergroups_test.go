@@ -0,0 +1,76 @@
+package identity
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	logtest "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+func TestUserGroupResolver_Resolve(t *testing.T) {
+	// given
+	logger, _ := logtest.NewNullLogger()
+	resolver := NewUserGroupResolver(logger)
+
+	now := time.Now()
+	resolver.nowFn = func() time.Time { return now }
+
+	workspaceA := &fakeUserGroupLookup{groups: map[string][]string{"U01": {"S01", "sre"}}}
+	workspaceB := &fakeUserGroupLookup{groups: map[string][]string{"U01": {"S02", "devs"}}}
+	resolver.RegisterUserGroupLookup(config.SocketSlackCommPlatformIntegration, workspaceA)
+	resolver.RegisterUserGroupLookup(config.SocketSlackCommPlatformIntegration, workspaceB)
+
+	// when
+	for i := 0; i < 3; i++ {
+		got, err := resolver.Resolve(context.Background(), config.SocketSlackCommPlatformIntegration, "U01")
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, []string{"S01", "sre", "S02", "devs"}, got)
+	}
+	assert.Equal(t, 1, workspaceA.calls)
+
+	// when
+	now = now.Add(userGroupsCacheTTL + time.Second)
+	_, err := resolver.Resolve(context.Background(), config.SocketSlackCommPlatformIntegration, "U01")
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, 2, workspaceA.calls)
+}
+
+func TestUserGroupResolver_ResolveErrors(t *testing.T) {
+	// given
+	logger, _ := logtest.NewNullLogger()
+	resolver := NewUserGroupResolver(logger)
+	resolver.RegisterUserGroupLookup(config.SocketSlackCommPlatformIntegration, &fakeUserGroupLookup{err: errors.New("missing_scope")})
+
+	// when
+	_, err := resolver.Resolve(context.Background(), config.SocketSlackCommPlatformIntegration, "U01")
+	groups, otherPlatformErr := resolver.Resolve(context.Background(), config.DiscordCommPlatformIntegration, "U01")
+
+	// then
+	assert.EqualError(t, err, `cannot find user groups for user "U01" on "socketSlack" platform: missing_scope`)
+	require.NoError(t, otherPlatformErr)
+	assert.Empty(t, groups)
+}
+
+type fakeUserGroupLookup struct {
+	groups map[string][]string
+	err    error
+	calls  int
+}
+
+func (f *fakeUserGroupLookup) LookupUserGroups(_ context.Context, userID string) ([]string, error) {
+	f.calls++
+	if f.err != nil {
+		return nil, f.err
+	}
+	return f.groups[userID], nil
+}
@@ -1,5 +1,7 @@
 package interactive
 
+import "strings"
+
 // EventCommandsSection defines a structure of commands for a given event.
 func EventCommandsSection(cmdPrefix string, optionItems []OptionItem) Section {
 	section := Section{
@@ -22,3 +24,15 @@ func EventCommandsSection(cmdPrefix string, optionItems []OptionItem) Section {
 
 	return section
 }
+
+// SuggestedCommandsSection defines a structure of commands suggested for a given event.
+func SuggestedCommandsSection(commands []string) Section {
+	return Section{
+		Base: Base{
+			Description: "Suggested commands:",
+			Body: Body{
+				CodeBlock: strings.Join(commands, "\n"),
+			},
+		},
+	}
+}
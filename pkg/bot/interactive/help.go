@@ -50,6 +50,32 @@ func (h *HelpMessage) Build() Message {
 	return msg
 }
 
+// BuildWelcome returns a short onboarding message for a user who interacts with Botkube for the first time.
+// The message is visible only for the given user.
+func (h *HelpMessage) BuildWelcome(user string) Message {
+	msg := Message{
+		OnlyVisibleForYou: true,
+		Base: Base{
+			Header:      "Welcome to Botkube!",
+			Description: fmt.Sprintf("Hi %s! Botkube is connected to %q cluster. Here are a few commands to get you started. This message is visible only to you.", user, h.clusterName),
+		},
+		Sections: []Section{
+			{
+				Base: Base{
+					Description: "Check the status of connected Kubernetes cluster(s) or see all available commands.",
+				},
+				Buttons: []Button{
+					h.btnBuilder.ForCommandWithDescCmd("Check status", "ping"),
+					h.btnBuilder.ForCommandWithDescCmd("Show help", "help", ButtonStylePrimary),
+				},
+			},
+		},
+	}
+	msg.Sections = append(msg.Sections, h.kubectlSections()...)
+
+	return msg
+}
+
 func (h *HelpMessage) cluster() []Section {
 	return []Section{
 		{
@@ -117,7 +143,7 @@ func (h *HelpMessage) notificationSections() []Section {
 			Base: Base{
 				Header: "Manage incoming notifications",
 				Body: Body{
-					CodeBlock: fmt.Sprintf("%s notifier [start|stop|status]\n", h.botName),
+					CodeBlock: fmt.Sprintf("%s notifier [start|stop|status|snooze <duration>]\n%s notifier [mute|unmute] [<kind>/<name>|ns <namespace>] [<duration>]\n%s notifier mutes\n", h.botName, h.botName, h.botName),
 				},
 			},
 			Buttons: []Button{
@@ -0,0 +1,150 @@
+package interactive
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultLocale is used when a given message is not translated to the requested locale.
+const DefaultLocale = "en"
+
+//go:embed locales/*.yaml
+var bundledLocales embed.FS
+
+// MessageKey identifies a localized message in a catalog.
+type MessageKey string
+
+// Keys of the messages available in message catalogs.
+const (
+	NotifierStartMsg           MessageKey = "notifier.start"
+	NotifierStopMsg            MessageKey = "notifier.stop"
+	NotifierStatusMsg          MessageKey = "notifier.status"
+	NotifierStatusEnabled      MessageKey = "notifier.status.enabled"
+	NotifierStatusDisabled     MessageKey = "notifier.status.disabled"
+	NotifierNotConfiguredMsg   MessageKey = "notifier.notConfigured"
+	NotifierSnoozeMsg          MessageKey = "notifier.snooze"
+	NotifierStatusSnoozed      MessageKey = "notifier.status.snoozed"
+	NotifierSnoozeInvalidMsg   MessageKey = "notifier.snooze.invalid"
+	NotifierSnoozeNotSupported MessageKey = "notifier.snooze.notSupported"
+	NotifierMuteMsg            MessageKey = "notifier.mute"
+	NotifierMuteUntilUnmuted   MessageKey = "notifier.mute.untilUnmuted"
+	NotifierMuteInvalidMsg     MessageKey = "notifier.mute.invalid"
+	NotifierMuteNotSupported   MessageKey = "notifier.mute.notSupported"
+	NotifierUnmuteMsg          MessageKey = "notifier.unmute"
+	NotifierUnmuteNotFoundMsg  MessageKey = "notifier.unmute.notFound"
+	NotifierMutesMsg           MessageKey = "notifier.mutes"
+	NotifierMutesEmptyMsg      MessageKey = "notifier.mutes.empty"
+	NotifierMutesItemExpiring  MessageKey = "notifier.mutes.item.expiring"
+	UnsupportedCommandMsg      MessageKey = "command.unsupported"
+	IncompleteCommandMsg       MessageKey = "command.incomplete"
+	InternalErrorMsg           MessageKey = "command.internalError"
+	EmptyResponseMsg           MessageKey = "command.emptyResponse"
+	CommandSuggestionMsg       MessageKey = "command.suggestion"
+)
+
+// Catalog holds translated messages for a single locale.
+type Catalog map[MessageKey]string
+
+// Localizer provides messages translated to a given locale.
+type Localizer struct {
+	catalogs map[string]Catalog
+}
+
+// NewLocalizer returns a new Localizer with the bundled message catalogs.
+func NewLocalizer() (*Localizer, error) {
+	l := &Localizer{catalogs: map[string]Catalog{}}
+	if err := l.loadCatalogs(bundledLocales, "locales"); err != nil {
+		return nil, fmt.Errorf("while loading bundled catalogs: %w", err)
+	}
+	return l, nil
+}
+
+// LoadCatalogsFromDir loads custom message catalogs from a given directory.
+// Each file is named after its locale, e.g. `fr.yaml`. Messages from custom catalogs override the bundled ones.
+func (l *Localizer) LoadCatalogsFromDir(dir string) error {
+	return l.loadCatalogs(os.DirFS(dir), ".")
+}
+
+// HasLocale returns true if there is a message catalog for a given locale.
+func (l *Localizer) HasLocale(locale string) bool {
+	_, found := l.catalogFor(locale)
+	return found
+}
+
+// Sprintf formats the message for a given locale. If the message is not translated, the DefaultLocale is used.
+func (l *Localizer) Sprintf(locale string, key MessageKey, args ...interface{}) string {
+	if catalog, found := l.catalogFor(locale); found {
+		if msg, ok := catalog[key]; ok {
+			return fmt.Sprintf(msg, args...)
+		}
+	}
+
+	if msg, ok := l.catalogs[DefaultLocale][key]; ok {
+		return fmt.Sprintf(msg, args...)
+	}
+
+	return string(key)
+}
+
+// catalogFor returns the catalog for a given locale. Regional locales, such as `de-AT`, fall back to the base language.
+func (l *Localizer) catalogFor(locale string) (Catalog, bool) {
+	locale = normalizeLocale(locale)
+	if locale == "" {
+		return nil, false
+	}
+
+	if catalog, ok := l.catalogs[locale]; ok {
+		return catalog, true
+	}
+
+	base, _, found := strings.Cut(locale, "-")
+	if !found {
+		return nil, false
+	}
+	catalog, ok := l.catalogs[base]
+	return catalog, ok
+}
+
+func (l *Localizer) loadCatalogs(fsys fs.FS, dir string) error {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return fmt.Errorf("while reading catalogs directory: %w", err)
+	}
+
+	for _, entry := range entries {
+		ext := path.Ext(entry.Name())
+		if entry.IsDir() || (ext != ".yaml" && ext != ".yml") {
+			continue
+		}
+
+		raw, err := fs.ReadFile(fsys, path.Join(dir, entry.Name()))
+		if err != nil {
+			return fmt.Errorf("while reading catalog %q: %w", entry.Name(), err)
+		}
+
+		var catalog Catalog
+		if err := yaml.Unmarshal(raw, &catalog); err != nil {
+			return fmt.Errorf("while unmarshaling catalog %q: %w", entry.Name(), err)
+		}
+
+		locale := normalizeLocale(strings.TrimSuffix(entry.Name(), ext))
+		if l.catalogs[locale] == nil {
+			l.catalogs[locale] = Catalog{}
+		}
+		for key, msg := range catalog {
+			l.catalogs[locale][key] = msg
+		}
+	}
+
+	return nil
+}
+
+func normalizeLocale(locale string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(locale), "_", "-"))
+}
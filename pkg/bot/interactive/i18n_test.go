@@ -0,0 +1,111 @@
+package interactive
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalizer_Sprintf(t *testing.T) {
+	// given
+	localizer, err := NewLocalizer()
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name     string
+		locale   string
+		key      MessageKey
+		args     []interface{}
+		expected string
+	}{
+		{
+			name:     "Default locale",
+			locale:   "",
+			key:      NotifierStartMsg,
+			args:     []interface{}{"dev"},
+			expected: "Brace yourselves, incoming notifications from cluster 'dev'.",
+		},
+		{
+			name:     "Bundled locale",
+			locale:   "de",
+			key:      NotifierStartMsg,
+			args:     []interface{}{"dev"},
+			expected: "Macht euch bereit, Benachrichtigungen vom Cluster 'dev' sind unterwegs.",
+		},
+		{
+			name:     "Regional locale falls back to base language",
+			locale:   "de_AT",
+			key:      NotifierStatusDisabled,
+			expected: "deaktiviert",
+		},
+		{
+			name:     "Unknown locale falls back to default locale",
+			locale:   "xx",
+			key:      UnsupportedCommandMsg,
+			expected: "Command not supported. Please use 'help' to see supported commands.",
+		},
+		{
+			name:     "Unknown message key",
+			locale:   "de",
+			key:      "unknown.key",
+			expected: "unknown.key",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// when
+			out := localizer.Sprintf(tc.locale, tc.key, tc.args...)
+
+			// then
+			assert.Equal(t, tc.expected, out)
+		})
+	}
+}
+
+func TestLocalizer_LoadCatalogsFromDir(t *testing.T) {
+	// given
+	dir := t.TempDir()
+	writeCatalog(t, dir, "pl.yaml", `notifier.stop: "Jasne! Nie będę tu wysyłać powiadomień z klastra '%s'."`)
+	writeCatalog(t, dir, "de.yml", `notifier.status.enabled: "eingeschaltet"`)
+	writeCatalog(t, dir, "README.md", "not a catalog")
+
+	localizer, err := NewLocalizer()
+	require.NoError(t, err)
+
+	// when
+	err = localizer.LoadCatalogsFromDir(dir)
+
+	// then
+	require.NoError(t, err)
+	assert.True(t, localizer.HasLocale("pl"))
+	assert.False(t, localizer.HasLocale("md"))
+	assert.Equal(t, "Jasne! Nie będę tu wysyłać powiadomień z klastra 'dev'.", localizer.Sprintf("pl", NotifierStopMsg, "dev"))
+	assert.Equal(t, "Command not supported. Please use 'help' to see supported commands.", localizer.Sprintf("pl", UnsupportedCommandMsg))
+	assert.Equal(t, "eingeschaltet", localizer.Sprintf("de", NotifierStatusEnabled))
+	assert.Equal(t, "deaktiviert", localizer.Sprintf("de", NotifierStatusDisabled))
+}
+
+func TestLocalizer_LoadCatalogsFromDirInvalidCatalog(t *testing.T) {
+	// given
+	dir := t.TempDir()
+	writeCatalog(t, dir, "fr.yaml", "- not\n- a\n- map")
+
+	localizer, err := NewLocalizer()
+	require.NoError(t, err)
+
+	// when
+	err = localizer.LoadCatalogsFromDir(dir)
+
+	// then
+	assert.ErrorContains(t, err, `while unmarshaling catalog "fr.yaml"`)
+}
+
+func writeCatalog(t *testing.T, dir, name, content string) {
+	t.Helper()
+	err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600)
+	require.NoError(t, err)
+}
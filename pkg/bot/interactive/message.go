@@ -47,6 +47,16 @@ type Message struct {
 	PlaintextInputs   LabelInputs
 	OnlyVisibleForYou bool
 	ReplaceOriginal   bool
+	// Attachment is uploaded as a file next to the message, if the platform supports it.
+	Attachment *Attachment
+}
+
+// Attachment holds a file attached to the message, such as the full command output.
+type Attachment struct {
+	FileName string
+	Content  string
+	// Data holds the binary content, such as an archive. If set, it's used instead of Content.
+	Data []byte
 }
 
 // HasSections returns true if message has interactive sections.
@@ -0,0 +1,377 @@
+package bot
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+
+	"github.com/kubeshop/botkube/pkg/bot/interactive"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/digest"
+	"github.com/kubeshop/botkube/pkg/events"
+	"github.com/kubeshop/botkube/pkg/execute"
+	"github.com/kubeshop/botkube/pkg/format"
+	"github.com/kubeshop/botkube/pkg/mute"
+	"github.com/kubeshop/botkube/pkg/sliceutil"
+)
+
+var _ Bot = &Loopback{}
+
+const (
+	loopbackOutputFilePerm = 0o644
+
+	// LoopbackMessagesPath is the path of the endpoint which returns the messages recorded by the Loopback bot
+	// of a given communication group.
+	LoopbackMessagesPath = "/loopback/{group}/messages"
+	loopbackGroupPathVar = "group"
+)
+
+// LoopbackMessage describes a message recorded by the Loopback bot.
+type LoopbackMessage struct {
+	Timestamp time.Time     `json:"timestamp"`
+	Channel   string        `json:"channel"`
+	Sources   []string      `json:"sources,omitempty"`
+	Text      string        `json:"text"`
+	Event     *events.Event `json:"event,omitempty"`
+}
+
+// Loopback records the messages instead of sending them to a communication platform.
+// It allows users to validate their sources, filters and bindings configuration.
+type Loopback struct {
+	log           logrus.FieldLogger
+	reporter      AnalyticsReporter
+	channelsMutex sync.RWMutex
+	channels      map[string]channelConfigByName
+	notifyMutex   sync.Mutex
+	mdFormatter   interactive.MDFormatter
+	digest        *digest.Scheduler
+	mutes         *mute.Registry
+
+	recordMutex sync.Mutex
+	recorded    []LoopbackMessage
+	output      *os.File
+}
+
+// NewLoopback creates a new Loopback instance.
+func NewLoopback(log logrus.FieldLogger, cfg config.Loopback, reporter AnalyticsReporter) (*Loopback, error) {
+	var output *os.File
+	if cfg.OutputPath != "" {
+		file, err := os.OpenFile(cfg.OutputPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, loopbackOutputFilePerm)
+		if err != nil {
+			return nil, fmt.Errorf("while opening output file: %w", err)
+		}
+		output = file
+	}
+
+	return &Loopback{
+		log:         log,
+		reporter:    reporter,
+		channels:    slackChannelsConfigFrom(cfg.Channels),
+		mdFormatter: interactive.DefaultMDFormatter(),
+		digest:      digest.NewScheduler(log),
+		mutes:       mute.NewRegistry(),
+		output:      output,
+	}, nil
+}
+
+// Start waits until the context is canceled and closes the output file.
+func (b *Loopback) Start(ctx context.Context) error {
+	b.log.Info("Starting bot")
+
+	err := b.reporter.ReportBotEnabled(b.IntegrationName())
+	if err != nil {
+		return fmt.Errorf("while reporting analytics: %w", err)
+	}
+
+	go b.digest.Run(ctx, b.recordDigest)
+
+	<-ctx.Done()
+	b.log.Info("Shutdown requested. Finishing...")
+
+	if b.output == nil {
+		return nil
+	}
+
+	b.recordMutex.Lock()
+	defer b.recordMutex.Unlock()
+	if err := b.output.Close(); err != nil {
+		return fmt.Errorf("while closing output file: %w", err)
+	}
+
+	return nil
+}
+
+// SendEvent records event notification for all channels bound to a given sources.
+func (b *Loopback) SendEvent(_ context.Context, event events.Event, eventSources []string) error {
+	b.log.Debugf("Recording event: %+v", event)
+
+	text := format.ShortMessage(event)
+	for _, channel := range b.getChannelsToNotify(event, eventSources) {
+		ev := event
+		err := b.record(LoopbackMessage{
+			Channel: channel,
+			Sources: eventSources,
+			Text:    text,
+			Event:   &ev,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// SendMessageToAll records a message for all channels.
+func (b *Loopback) SendMessageToAll(_ context.Context, msg interactive.Message) error {
+	text := interactive.RenderMessage(b.mdFormatter, msg)
+	for _, channel := range b.getChannels() {
+		err := b.record(LoopbackMessage{
+			Channel: channel.Identifier(),
+			Text:    text,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// SendGenericMessage records a message for all channels bound to a given sources.
+func (b *Loopback) SendGenericMessage(_ context.Context, genericMsg interactive.GenericMessage, sourceBindings []string) error {
+	text := interactive.RenderMessage(b.mdFormatter, genericMsg.ForBot(b.BotName()))
+	for _, channel := range b.getBoundChannels(sourceBindings) {
+		err := b.record(LoopbackMessage{
+			Channel: channel,
+			Sources: sourceBindings,
+			Text:    text,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Messages returns all messages recorded so far.
+func (b *Loopback) Messages() []LoopbackMessage {
+	b.recordMutex.Lock()
+	defer b.recordMutex.Unlock()
+
+	out := make([]LoopbackMessage, len(b.recorded))
+	copy(out, b.recorded)
+	return out
+}
+
+// LoopbackRegistry exposes the messages recorded by the Loopback bots over HTTP.
+type LoopbackRegistry struct {
+	mu   sync.RWMutex
+	bots map[string]*Loopback
+}
+
+// NewLoopbackRegistry returns a new LoopbackRegistry instance.
+func NewLoopbackRegistry() *LoopbackRegistry {
+	return &LoopbackRegistry{
+		bots: map[string]*Loopback{},
+	}
+}
+
+// Register exposes the messages of a given Loopback bot from a given communication group.
+func (r *LoopbackRegistry) Register(commGroupName string, bot *Loopback) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.bots[commGroupName] = bot
+}
+
+// ServeHTTP returns the messages recorded by the Loopback bot of the communication group from the LoopbackMessagesPath.
+func (r *LoopbackRegistry) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	group := mux.Vars(req)[loopbackGroupPathVar]
+
+	r.mu.RLock()
+	bot, found := r.bots[group]
+	r.mu.RUnlock()
+	if !found {
+		http.Error(w, fmt.Sprintf("Loopback bot is not enabled in the %q communication group", group), http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(bot.Messages()); err != nil {
+		bot.log.Errorf("while writing recorded messages: %s", err.Error())
+	}
+}
+
+// IntegrationName describes the integration name.
+func (b *Loopback) IntegrationName() config.CommPlatformIntegration {
+	return config.LoopbackCommPlatformIntegration
+}
+
+// Type describes the integration type.
+func (b *Loopback) Type() config.IntegrationType {
+	return config.BotIntegrationType
+}
+
+// BotName returns the Bot name.
+func (b *Loopback) BotName() string {
+	return "@Botkube"
+}
+
+// NotificationsEnabled returns current notification status for a given channel name.
+func (b *Loopback) NotificationsEnabled(channelName string) bool {
+	channel, exists := b.getChannels()[channelName]
+	if !exists {
+		return false
+	}
+
+	return channel.notificationsEnabled()
+}
+
+// SetNotificationsEnabled sets a new notification status for a given channel name.
+func (b *Loopback) SetNotificationsEnabled(channelName string, enabled bool) error {
+	// avoid race conditions with using the setter concurrently, as we set whole map
+	b.notifyMutex.Lock()
+	defer b.notifyMutex.Unlock()
+
+	channels := b.getChannels()
+	channel, exists := channels[channelName]
+	if !exists {
+		return execute.ErrNotificationsNotConfigured
+	}
+
+	channel.notify = enabled
+	channel.Notification.SnoozedUntil = time.Time{}
+	channels[channelName] = channel
+	b.setChannels(channels)
+
+	return nil
+}
+
+// SnoozeNotifications disables notifications for a given channel until a given time.
+func (b *Loopback) SnoozeNotifications(channelName string, until time.Time) error {
+	// avoid race conditions with using the setter concurrently, as we set whole map
+	b.notifyMutex.Lock()
+	defer b.notifyMutex.Unlock()
+
+	channels := b.getChannels()
+	channel, exists := channels[channelName]
+	if !exists {
+		return execute.ErrNotificationsNotConfigured
+	}
+
+	channel.notify = true
+	channel.Notification.SnoozedUntil = until
+	channels[channelName] = channel
+	b.setChannels(channels)
+
+	return nil
+}
+
+// NotificationsSnoozedUntil returns the snooze end time for a given channel.
+func (b *Loopback) NotificationsSnoozedUntil(channelName string) time.Time {
+	return b.getChannels()[channelName].Notification.SnoozedUntil
+}
+
+// MuteNotifications suppresses notifications matching a given rule for a given channel.
+func (b *Loopback) MuteNotifications(channelName string, rule mute.Rule) error {
+	if _, exists := b.getChannels()[channelName]; !exists {
+		return execute.ErrNotificationsNotConfigured
+	}
+
+	b.mutes.Add(channelName, rule)
+	return nil
+}
+
+// UnmuteNotifications removes the mute rule for the same target as a given rule for a given channel.
+func (b *Loopback) UnmuteNotifications(channelName string, rule mute.Rule) bool {
+	return b.mutes.Remove(channelName, rule)
+}
+
+// NotificationMutes returns active mute rules for a given channel.
+func (b *Loopback) NotificationMutes(channelName string) []mute.Rule {
+	return b.mutes.List(channelName)
+}
+
+func (b *Loopback) record(msg LoopbackMessage) error {
+	msg.Timestamp = time.Now()
+
+	b.recordMutex.Lock()
+	defer b.recordMutex.Unlock()
+
+	b.recorded = append(b.recorded, msg)
+
+	if b.output == nil {
+		b.log.WithField("channel", msg.Channel).Infof("Recorded message:\n%s", msg.Text)
+		return nil
+	}
+
+	raw, err := json.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("while marshaling message: %w", err)
+	}
+
+	if _, err := b.output.Write(append(raw, '\n')); err != nil {
+		return fmt.Errorf("while writing message to output file: %w", err)
+	}
+
+	return nil
+}
+
+func (b *Loopback) getChannelsToNotify(event events.Event, sourceBindings []string) []string {
+	// support custom event routing
+	if event.Channel != "" {
+		return []string{event.Channel}
+	}
+
+	out := channelsToNotifyForEvent(event, sourceBindings, b.getChannels(), b.getBoundChannels)
+	return b.digest.Filter(event, b.mutes.Filter(event, out), channelDigestByName(b.getChannels))
+}
+
+func (b *Loopback) getBoundChannels(sourceBindings []string) []string {
+	var out []string
+	for _, cfg := range b.getChannels() {
+		switch {
+		case !cfg.notificationsEnabled():
+			b.log.Infof("Skipping notification for channel %q as notifications are disabled.", cfg.Identifier())
+		default:
+			if sliceutil.Intersect(sourceBindings, cfg.Bindings.Sources) {
+				out = append(out, cfg.Identifier())
+			}
+		}
+	}
+	return out
+}
+
+func (b *Loopback) recordDigest(_ context.Context, channel string, msg interactive.Message) error {
+	return b.record(LoopbackMessage{
+		Channel: channel,
+		Text:    interactive.RenderMessage(b.mdFormatter, msg),
+	})
+}
+
+func (b *Loopback) getChannels() map[string]channelConfigByName {
+	b.channelsMutex.RLock()
+	defer b.channelsMutex.RUnlock()
+	return b.channels
+}
+
+func (b *Loopback) setChannels(channels map[string]channelConfigByName) {
+	b.channelsMutex.Lock()
+	defer b.channelsMutex.Unlock()
+	b.channels = channels
+}
+
+// ReloadChannels reloads the bindings and notification settings of the channels, without reconnecting the bot.
+func (b *Loopback) ReloadChannels(cfg config.Loopback) {
+	b.channelsMutex.Lock()
+	defer b.channelsMutex.Unlock()
+	b.channels = reloadedChannelsByName(b.channels, cfg.Channels)
+}
@@ -0,0 +1,404 @@
+package bot
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	logtest "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kubeshop/botkube/internal/analytics"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/events"
+	"github.com/kubeshop/botkube/pkg/execute"
+	"github.com/kubeshop/botkube/pkg/mute"
+)
+
+func TestLoopback_SendEvent(t *testing.T) {
+	// given
+	logger, _ := logtest.NewNullLogger()
+	outputPath := filepath.Join(t.TempDir(), "messages.jsonl")
+	cfg := config.Loopback{
+		Enabled:    true,
+		OutputPath: outputPath,
+		Channels: config.IdentifiableMap[config.ChannelBindingsByName]{
+			"errors": {
+				Name:     "errors",
+				Bindings: config.BotBindings{Sources: []string{"k8s-err-events"}},
+			},
+			"all": {
+				Name:     "all",
+				Bindings: config.BotBindings{Sources: []string{"k8s-err-events", "k8s-create-events"}},
+			},
+			"muted": {
+				Name:         "muted",
+				Notification: config.ChannelNotification{Disabled: true},
+				Bindings:     config.BotBindings{Sources: []string{"k8s-create-events"}},
+			},
+		},
+	}
+	b, err := NewLoopback(logger, cfg, analytics.NewNoopReporter())
+	require.NoError(t, err)
+
+	event := events.Event{
+		Name:      "nginx",
+		Namespace: "default",
+		Type:      config.CreateEvent,
+		Resource:  "v1/pods",
+		Title:     "v1/pods created",
+	}
+
+	// when
+	err = b.SendEvent(context.Background(), event, []string{"k8s-create-events"})
+
+	// then
+	require.NoError(t, err)
+
+	recorded := b.Messages()
+	require.Len(t, recorded, 1)
+	assert.Equal(t, "all", recorded[0].Channel)
+	assert.Equal(t, []string{"k8s-create-events"}, recorded[0].Sources)
+	require.NotNil(t, recorded[0].Event)
+	assert.Equal(t, "nginx", recorded[0].Event.Name)
+
+	file, err := os.Open(outputPath)
+	require.NoError(t, err)
+	defer file.Close()
+
+	var written []LoopbackMessage
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var msg LoopbackMessage
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &msg))
+		written = append(written, msg)
+	}
+	require.NoError(t, scanner.Err())
+	require.Len(t, written, 1)
+	assert.Equal(t, recorded[0].Channel, written[0].Channel)
+	assert.Equal(t, recorded[0].Text, written[0].Text)
+}
+
+func TestLoopback_SendEventDigest(t *testing.T) {
+	// given
+	logger, _ := logtest.NewNullLogger()
+	cfg := config.Loopback{
+		Enabled: true,
+		Channels: config.IdentifiableMap[config.ChannelBindingsByName]{
+			"all": {
+				Name:     "all",
+				Bindings: config.BotBindings{Sources: []string{"k8s-events"}},
+			},
+			"digest": {
+				Name: "digest",
+				Notification: config.ChannelNotification{
+					Digest: config.ChannelDigest{Enabled: true},
+				},
+				Bindings: config.BotBindings{Sources: []string{"k8s-events"}},
+			},
+		},
+	}
+	b, err := NewLoopback(logger, cfg, analytics.NewNoopReporter())
+	require.NoError(t, err)
+
+	infoEvent := events.Event{Name: "nginx", Namespace: "default", Type: config.CreateEvent, Level: config.Info}
+	errEvent := events.Event{Name: "nginx", Namespace: "default", Type: config.ErrorEvent, Level: config.Error}
+
+	// when
+	err = b.SendEvent(context.Background(), infoEvent, []string{"k8s-events"})
+	require.NoError(t, err)
+	err = b.SendEvent(context.Background(), errEvent, []string{"k8s-events"})
+	require.NoError(t, err)
+
+	// then
+	var got []string
+	for _, msg := range b.Messages() {
+		got = append(got, fmt.Sprintf("%s/%s", msg.Channel, msg.Event.Level))
+	}
+	assert.ElementsMatch(t, []string{"all/info", "all/error", "digest/error"}, got)
+}
+
+func TestLoopback_SnoozeNotifications(t *testing.T) {
+	// given
+	logger, _ := logtest.NewNullLogger()
+	cfg := config.Loopback{
+		Enabled: true,
+		Channels: config.IdentifiableMap[config.ChannelBindingsByName]{
+			"snoozed": {
+				Name:     "snoozed",
+				Bindings: config.BotBindings{Sources: []string{"k8s-events"}},
+			},
+			"persisted": {
+				Name: "persisted",
+				Notification: config.ChannelNotification{
+					SnoozedUntil: time.Now().Add(time.Hour),
+				},
+				Bindings: config.BotBindings{Sources: []string{"k8s-events"}},
+			},
+			"expired": {
+				Name: "expired",
+				Notification: config.ChannelNotification{
+					SnoozedUntil: time.Now().Add(-time.Minute),
+				},
+				Bindings: config.BotBindings{Sources: []string{"k8s-events"}},
+			},
+		},
+	}
+	b, err := NewLoopback(logger, cfg, analytics.NewNoopReporter())
+	require.NoError(t, err)
+
+	// when
+	err = b.SnoozeNotifications("snoozed", time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	err = b.SendEvent(context.Background(), events.Event{Name: "nginx", Type: config.CreateEvent}, []string{"k8s-events"})
+	require.NoError(t, err)
+
+	// then
+	recorded := b.Messages()
+	require.Len(t, recorded, 1)
+	assert.Equal(t, "expired", recorded[0].Channel)
+	assert.False(t, b.NotificationsEnabled("snoozed"))
+	assert.False(t, b.NotificationsEnabled("persisted"))
+	assert.True(t, b.NotificationsEnabled("expired"))
+
+	// when
+	err = b.SetNotificationsEnabled("snoozed", true)
+	require.NoError(t, err)
+
+	// then
+	assert.True(t, b.NotificationsEnabled("snoozed"))
+	assert.True(t, b.NotificationsSnoozedUntil("snoozed").IsZero())
+}
+
+func TestLoopback_ReloadChannels(t *testing.T) {
+	// given
+	logger, _ := logtest.NewNullLogger()
+	cfg := config.Loopback{
+		Enabled: true,
+		Channels: config.IdentifiableMap[config.ChannelBindingsByName]{
+			"prod": {
+				Name:     "prod",
+				Bindings: config.BotBindings{Sources: []string{"k8s-err-events"}},
+			},
+			"dev": {
+				Name:     "dev",
+				Bindings: config.BotBindings{Sources: []string{"k8s-err-events"}},
+			},
+		},
+	}
+	b, err := NewLoopback(logger, cfg, analytics.NewNoopReporter())
+	require.NoError(t, err)
+	err = b.SnoozeNotifications("dev", time.Now().Add(time.Hour))
+	require.NoError(t, err)
+
+	reloadedCfg := config.Loopback{
+		Enabled: true,
+		Channels: config.IdentifiableMap[config.ChannelBindingsByName]{
+			"prod": {
+				Name:     "prod",
+				Bindings: config.BotBindings{Sources: []string{"k8s-create-events"}},
+			},
+			"dev": {
+				Name:     "dev",
+				Bindings: config.BotBindings{Sources: []string{"k8s-create-events"}},
+			},
+		},
+	}
+
+	// when
+	b.ReloadChannels(reloadedCfg)
+	err = b.SendEvent(context.Background(), events.Event{Name: "nginx", Type: config.CreateEvent}, []string{"k8s-create-events"})
+	require.NoError(t, err)
+
+	// then
+	recorded := b.Messages()
+	require.Len(t, recorded, 1)
+	assert.Equal(t, "prod", recorded[0].Channel)
+	assert.False(t, b.NotificationsEnabled("dev"))
+}
+
+func TestLoopback_MuteNotifications(t *testing.T) {
+	// given
+	logger, _ := logtest.NewNullLogger()
+	cfg := config.Loopback{
+		Enabled: true,
+		Channels: config.IdentifiableMap[config.ChannelBindingsByName]{
+			"muted": {
+				Name:     "muted",
+				Bindings: config.BotBindings{Sources: []string{"k8s-events"}},
+			},
+			"other": {
+				Name:     "other",
+				Bindings: config.BotBindings{Sources: []string{"k8s-events"}},
+			},
+		},
+	}
+	b, err := NewLoopback(logger, cfg, analytics.NewNoopReporter())
+	require.NoError(t, err)
+
+	event := events.Event{
+		TypeMeta:  metaV1.TypeMeta{Kind: "Pod"},
+		Name:      "payments-api-7d9f-x2b4c",
+		Namespace: "prod",
+		Type:      config.CreateEvent,
+		Owner:     &events.Owner{Kind: "Deployment", Name: "payments-api"},
+	}
+
+	// when
+	err = b.MuteNotifications("muted", mute.Rule{Kind: "deployment", Name: "payments-api", Until: time.Now().Add(time.Hour)})
+	require.NoError(t, err)
+	err = b.MuteNotifications("not-configured", mute.Rule{Namespace: "prod"})
+	assert.ErrorIs(t, err, execute.ErrNotificationsNotConfigured)
+	err = b.SendEvent(context.Background(), event, []string{"k8s-events"})
+	require.NoError(t, err)
+
+	// then
+	recorded := b.Messages()
+	require.Len(t, recorded, 1)
+	assert.Equal(t, "other", recorded[0].Channel)
+	assert.Len(t, b.NotificationMutes("muted"), 1)
+
+	// when
+	unmuted := b.UnmuteNotifications("muted", mute.Rule{Kind: "Deployment", Name: "payments-api"})
+	err = b.SendEvent(context.Background(), event, []string{"k8s-events"})
+	require.NoError(t, err)
+
+	// then
+	assert.True(t, unmuted)
+	assert.Len(t, b.Messages(), 3)
+	assert.Empty(t, b.NotificationMutes("muted"))
+}
+
+func TestLoopback_SendEventRoutes(t *testing.T) {
+	// given
+	logger, _ := logtest.NewNullLogger()
+	cfg := config.Loopback{
+		Enabled: true,
+		Channels: config.IdentifiableMap[config.ChannelBindingsByName]{
+			"team": {
+				Name:     "team",
+				Bindings: config.BotBindings{Sources: []string{"k8s-events", "k8s-recommendations"}},
+			},
+			"incidents": {
+				Name: "prod-incidents",
+			},
+			"oncall": {
+				Name: "oncall",
+			},
+		},
+	}
+	b, err := NewLoopback(logger, cfg, analytics.NewNoopReporter())
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		routes map[string][]string
+
+		expectedChannels []string
+	}{
+		{
+			name:             "No routes",
+			expectedChannels: []string{"team"},
+		},
+		{
+			name:             "Route by name",
+			routes:           map[string][]string{"k8s-events": {"#prod-incidents"}},
+			expectedChannels: []string{"team", "prod-incidents"},
+		},
+		{
+			name: "All sources routed by alias",
+			routes: map[string][]string{
+				"k8s-events":          {"incidents"},
+				"k8s-recommendations": {"oncall", "not-configured"},
+			},
+			expectedChannels: []string{"oncall", "prod-incidents"},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			event := events.Event{Name: "nginx", Level: config.Critical, Routes: tc.routes}
+
+			// when
+			channels := b.getChannelsToNotify(event, []string{"k8s-events", "k8s-recommendations"})
+
+			// then
+			assert.Equal(t, tc.expectedChannels, channels)
+		})
+	}
+}
+
+func TestLoopbackRegistry_ServeHTTP(t *testing.T) {
+	// given
+	logger, _ := logtest.NewNullLogger()
+	cfg := config.Loopback{
+		Enabled: true,
+		Channels: config.IdentifiableMap[config.ChannelBindingsByName]{
+			"all": {
+				Name:     "all",
+				Bindings: config.BotBindings{Sources: []string{"k8s-events"}},
+			},
+		},
+	}
+	b, err := NewLoopback(logger, cfg, analytics.NewNoopReporter())
+	require.NoError(t, err)
+	err = b.SendEvent(context.Background(), events.Event{Name: "nginx", Type: config.CreateEvent}, []string{"k8s-events"})
+	require.NoError(t, err)
+
+	registry := NewLoopbackRegistry()
+	registry.Register("default-group", b)
+	router := mux.NewRouter()
+	router.Handle(LoopbackMessagesPath, registry)
+
+	tests := []struct {
+		name string
+
+		path string
+
+		expCode     int
+		expChannels []string
+	}{
+		{
+			name:        "Should return recorded messages",
+			path:        "/loopback/default-group/messages",
+			expCode:     http.StatusOK,
+			expChannels: []string{"all"},
+		},
+		{
+			name:    "Should return not found for group without Loopback bot",
+			path:    "/loopback/other-group/messages",
+			expCode: http.StatusNotFound,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+
+			// when
+			router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tc.path, nil))
+
+			// then
+			require.Equal(t, tc.expCode, rec.Code)
+			if tc.expCode != http.StatusOK {
+				return
+			}
+
+			var got []LoopbackMessage
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &got))
+			var channels []string
+			for _, msg := range got {
+				channels = append(channels, msg.Channel)
+			}
+			assert.Equal(t, tc.expChannels, channels)
+		})
+	}
+}
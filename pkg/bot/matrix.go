@@ -0,0 +1,507 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/kubeshop/botkube/pkg/bot/interactive"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/digest"
+	"github.com/kubeshop/botkube/pkg/events"
+	"github.com/kubeshop/botkube/pkg/execute"
+	"github.com/kubeshop/botkube/pkg/execute/command"
+	"github.com/kubeshop/botkube/pkg/multierror"
+	"github.com/kubeshop/botkube/pkg/mute"
+	"github.com/kubeshop/botkube/pkg/sliceutil"
+)
+
+var _ Bot = &Matrix{}
+
+const (
+	// matrixMaxMessageSize max size before a message should be uploaded as a file.
+	// Matrix limits the whole event to 65535 bytes, and the message is sent both as Markdown and HTML.
+	matrixMaxMessageSize = 20000
+
+	// matrixBotMentionRegexFmt matches the bot name, optionally followed by the homeserver name, e.g. `@botkube:matrix.org`,
+	// and the colon added by clients, such as Element, after the mention pill.
+	matrixBotMentionRegexFmt = `^@?(?i)%s\b(:\S+)?:?`
+
+	// matrixSyncRetryInterval is the delay between sync attempts after a failure.
+	matrixSyncRetryInterval = 5 * time.Second
+)
+
+// Matrix listens for user's message, execute commands and sends back the response.
+type Matrix struct {
+	log             logrus.FieldLogger
+	executorFactory ExecutorFactory
+	reporter        AnalyticsReporter
+	notification    config.Notification
+	client          *matrixClient
+	renderer        *MatrixRenderer
+	botUserID       string
+	botName         string
+	commGroupName   string
+	channelsMutex   sync.RWMutex
+	channels        map[string]channelConfigByID
+	notifyMutex     sync.Mutex
+	botMentionRegex *regexp.Regexp
+	digest          *digest.Scheduler
+	mutes           *mute.Registry
+}
+
+// NewMatrix creates a new Matrix instance.
+func NewMatrix(ctx context.Context, log logrus.FieldLogger, commGroupName string, cfg config.Matrix, executorFactory ExecutorFactory, reporter AnalyticsReporter) (*Matrix, error) {
+	client := newMatrixClient(cfg.HomeserverURL, cfg.AccessToken)
+
+	whoAmI, err := client.WhoAmI(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("while getting Matrix bot details: %w", err)
+	}
+
+	botName := cfg.BotName
+	if botName == "" {
+		botName = matrixUserLocalpart(whoAmI.UserID)
+	}
+	botMentionRegex, err := matrixBotMentionRegex(botName)
+	if err != nil {
+		return nil, err
+	}
+
+	channels, err := matrixChannelsCfgFrom(ctx, client, cfg.Channels)
+	if err != nil {
+		return nil, fmt.Errorf("while producing channels configuration map by ID: %w", err)
+	}
+
+	return &Matrix{
+		log:             log,
+		executorFactory: executorFactory,
+		reporter:        reporter,
+		notification:    cfg.Notification,
+		client:          client,
+		renderer:        NewMatrixRenderer(),
+		botUserID:       whoAmI.UserID,
+		botName:         botName,
+		commGroupName:   commGroupName,
+		channels:        channels,
+		botMentionRegex: botMentionRegex,
+		digest:          digest.NewScheduler(log),
+		mutes:           mute.NewRegistry(),
+	}, nil
+}
+
+// Start polls the Matrix homeserver for new messages.
+func (b *Matrix) Start(ctx context.Context) error {
+	b.log.Info("Starting bot")
+
+	err := b.reporter.ReportBotEnabled(b.IntegrationName())
+	if err != nil {
+		return fmt.Errorf("while reporting analytics: %w", err)
+	}
+
+	b.log.Info("Botkube connected to Matrix!")
+
+	go b.digest.Run(ctx, func(ctx context.Context, roomID string, msg interactive.Message) error {
+		return b.send(ctx, roomID, nil, msg)
+	})
+
+	var since string
+	for {
+		res, err := b.client.Sync(ctx, since)
+		if err != nil {
+			if ctx.Err() == nil {
+				b.log.Errorf("while syncing Matrix events: %s. Retrying...", err.Error())
+			}
+
+			select {
+			case <-ctx.Done():
+				b.log.Info("Shutdown requested. Finishing...")
+				return nil
+			case <-time.After(matrixSyncRetryInterval):
+				continue
+			}
+		}
+
+		// the initial sync returns the current state only, so there are no new messages to handle
+		if since != "" {
+			b.handleSync(ctx, res)
+		}
+		since = res.NextBatch
+
+		select {
+		case <-ctx.Done():
+			b.log.Info("Shutdown requested. Finishing...")
+			return nil
+		default:
+		}
+	}
+}
+
+// IntegrationName describes the notifier integration name.
+func (b *Matrix) IntegrationName() config.CommPlatformIntegration {
+	return config.MatrixCommPlatformIntegration
+}
+
+// Type describes the notifier type.
+func (b *Matrix) Type() config.IntegrationType {
+	return config.BotIntegrationType
+}
+
+// NotificationsEnabled returns current notification status for a given room ID.
+func (b *Matrix) NotificationsEnabled(roomID string) bool {
+	channel, exists := b.getChannels()[roomID]
+	if !exists {
+		return false
+	}
+
+	return channel.notificationsEnabled()
+}
+
+// SetNotificationsEnabled sets a new notification status for a given room ID.
+func (b *Matrix) SetNotificationsEnabled(roomID string, enabled bool) error {
+	// avoid race conditions with using the setter concurrently, as we set whole map
+	b.notifyMutex.Lock()
+	defer b.notifyMutex.Unlock()
+
+	channels := b.getChannels()
+	channel, exists := channels[roomID]
+	if !exists {
+		return execute.ErrNotificationsNotConfigured
+	}
+
+	channel.notify = enabled
+	channel.Notification.SnoozedUntil = time.Time{}
+	channels[roomID] = channel
+	b.setChannels(channels)
+
+	return nil
+}
+
+// SnoozeNotifications disables notifications for a given channel until a given time.
+func (b *Matrix) SnoozeNotifications(roomID string, until time.Time) error {
+	// avoid race conditions with using the setter concurrently, as we set whole map
+	b.notifyMutex.Lock()
+	defer b.notifyMutex.Unlock()
+
+	channels := b.getChannels()
+	channel, exists := channels[roomID]
+	if !exists {
+		return execute.ErrNotificationsNotConfigured
+	}
+
+	channel.notify = true
+	channel.Notification.SnoozedUntil = until
+	channels[roomID] = channel
+	b.setChannels(channels)
+
+	return nil
+}
+
+// NotificationsSnoozedUntil returns the snooze end time for a given channel.
+func (b *Matrix) NotificationsSnoozedUntil(roomID string) time.Time {
+	return b.getChannels()[roomID].Notification.SnoozedUntil
+}
+
+// MuteNotifications suppresses notifications matching a given rule for a given channel.
+func (b *Matrix) MuteNotifications(roomID string, rule mute.Rule) error {
+	if _, exists := b.getChannels()[roomID]; !exists {
+		return execute.ErrNotificationsNotConfigured
+	}
+
+	b.mutes.Add(roomID, rule)
+	return nil
+}
+
+// UnmuteNotifications removes the mute rule for the same target as a given rule for a given channel.
+func (b *Matrix) UnmuteNotifications(roomID string, rule mute.Rule) bool {
+	return b.mutes.Remove(roomID, rule)
+}
+
+// NotificationMutes returns active mute rules for a given channel.
+func (b *Matrix) NotificationMutes(roomID string) []mute.Rule {
+	return b.mutes.List(roomID)
+}
+
+// BotName returns the Bot name.
+func (b *Matrix) BotName() string {
+	return fmt.Sprintf("@%s", b.botName)
+}
+
+// SendEvent sends event notification to Matrix.
+func (b *Matrix) SendEvent(ctx context.Context, event events.Event, eventSources []string) error {
+	b.log.Debugf("Sending to Matrix: %+v", event)
+	content := b.renderer.RenderMarkdown(b.formatMessage(event))
+
+	errs := multierror.New()
+	for _, roomID := range b.getChannelsToNotifyForEvent(event, eventSources) {
+		if err := b.client.SendMessage(ctx, roomID, content); err != nil {
+			errs = multierror.Append(errs, fmt.Errorf("while sending message to room %q: %w", roomID, err))
+			continue
+		}
+
+		b.log.Debugf("Event successfully sent to room %q", roomID)
+	}
+
+	return errs.ErrorOrNil()
+}
+
+// SendGenericMessage sends message to selected Matrix rooms.
+func (b *Matrix) SendGenericMessage(ctx context.Context, genericMsg interactive.GenericMessage, sourceBindings []string) error {
+	msg := genericMsg.ForBot(b.BotName())
+
+	errs := multierror.New()
+	for _, roomID := range b.getChannelsToNotify(sourceBindings) {
+		b.log.Debugf("Sending message to room %q: %+v", roomID, msg)
+		if err := b.send(ctx, roomID, nil, msg); err != nil {
+			errs = multierror.Append(errs, fmt.Errorf("while sending Matrix message to room %q: %w", roomID, err))
+			continue
+		}
+		b.log.Debugf("Message successfully sent to room %q", roomID)
+	}
+
+	return errs.ErrorOrNil()
+}
+
+// SendMessageToAll sends message to all Matrix rooms.
+func (b *Matrix) SendMessageToAll(ctx context.Context, msg interactive.Message) error {
+	errs := multierror.New()
+	for _, channel := range b.getChannels() {
+		roomID := channel.ID
+		b.log.Debugf("Sending message to room %q: %+v", roomID, msg)
+		if err := b.send(ctx, roomID, nil, msg); err != nil {
+			errs = multierror.Append(errs, fmt.Errorf("while sending Matrix message to room %q: %w", roomID, err))
+			continue
+		}
+		b.log.Debugf("Message successfully sent to room %q", roomID)
+	}
+
+	return errs.ErrorOrNil()
+}
+
+func (b *Matrix) handleSync(ctx context.Context, res matrixSyncResponse) {
+	for roomID, room := range res.Rooms.Join {
+		for _, event := range room.Timeline.Events {
+			if err := b.handleEvent(ctx, roomID, event); err != nil {
+				b.log.Errorf("Message handling error: %s", err.Error())
+			}
+		}
+	}
+}
+
+func (b *Matrix) handleEvent(ctx context.Context, roomID string, event matrixEvent) error {
+	// Skip messages posted by Botkube, notices sent by other bots, and edits
+	if event.Type != matrixRoomMessageEvent || event.Sender == b.botUserID || event.Content.MsgType != matrixTextMsgType {
+		return nil
+	}
+	if event.Content.RelatesTo != nil && event.Content.RelatesTo.RelType == matrixReplaceRelType {
+		return nil
+	}
+
+	// Handle message only if starts with mention
+	req, found := b.findAndTrimBotMention(event.Content.Body)
+	if !found {
+		b.log.Debugf("Ignoring message as it doesn't contain %q mention", b.botName)
+		return nil
+	}
+	b.log.Debugf("Matrix incoming Request: %s", req)
+
+	// reply in the thread in which the command was sent
+	relatesTo := matrixThreadReplyTo(event)
+
+	channel, isAuthChannel := b.getChannels()[roomID]
+	if !execute.IsCommandPermitted(channel.Commands, req) {
+		b.log.Debugf("Command %q is not permitted in room %q", req, roomID)
+		if err := b.send(ctx, roomID, relatesTo, execute.CommandNotPermittedMessage(req)); err != nil {
+			return fmt.Errorf("while sending message: %w", err)
+		}
+		return nil
+	}
+
+	e := b.executorFactory.NewDefault(execute.NewDefaultInput{
+		CommGroupName:   b.commGroupName,
+		Platform:        b.IntegrationName(),
+		NotifierHandler: b,
+		Conversation: execute.Conversation{
+			Alias:            channel.alias,
+			ID:               roomID,
+			ExecutorBindings: channel.Bindings.Executors,
+			Commands:         channel.Commands,
+			Locale:           channel.Locale,
+			IsAuthenticated:  isAuthChannel,
+			CommandOrigin:    command.TypedOrigin,
+		},
+		Message: req,
+		User:    event.Sender,
+		UserID:  event.Sender,
+	})
+	response := e.Execute(ctx)
+
+	if err := b.send(ctx, roomID, relatesTo, response); err != nil {
+		return fmt.Errorf("while sending message: %w", err)
+	}
+
+	return nil
+}
+
+func (b *Matrix) send(ctx context.Context, roomID string, relatesTo *matrixRelatesTo, resp interactive.Message) error {
+	b.log.Debugf("Matrix Response: %s", resp)
+
+	content := b.renderer.RenderMessage(resp)
+	if len(content.Body) == 0 {
+		return errors.New("while reading Matrix response: empty response")
+	}
+
+	// Upload message as a file if too long
+	if len(content.Body)+len(content.FormattedBody) >= matrixMaxMessageSize {
+		return b.sendAsFile(ctx, roomID, relatesTo, resp)
+	}
+
+	content.RelatesTo = relatesTo
+	if err := b.client.SendMessage(ctx, roomID, content); err != nil {
+		return fmt.Errorf("while sending message: %w", err)
+	}
+	return nil
+}
+
+func (b *Matrix) sendAsFile(ctx context.Context, roomID string, relatesTo *matrixRelatesTo, resp interactive.Message) error {
+	if resp.Description != "" {
+		description := b.renderer.RenderMarkdown(resp.Description)
+		description.RelatesTo = relatesTo
+		if err := b.client.SendMessage(ctx, roomID, description); err != nil {
+			return fmt.Errorf("while sending message: %w", err)
+		}
+	}
+
+	plaintext := interactive.MessageToPlaintext(resp, interactive.NewlineFormatter)
+	contentURI, err := b.client.UploadFile(ctx, responseFileName, []byte(plaintext))
+	if err != nil {
+		return fmt.Errorf("while uploading file: %w", err)
+	}
+
+	err = b.client.SendMessage(ctx, roomID, matrixMessageContent{
+		MsgType:   matrixFileMsgType,
+		Body:      responseFileName,
+		URL:       contentURI,
+		RelatesTo: relatesTo,
+	})
+	if err != nil {
+		return fmt.Errorf("while sending file message: %w", err)
+	}
+	return nil
+}
+
+func (b *Matrix) getChannelsToNotifyForEvent(event events.Event, sourceBindings []string) []string {
+	// support custom event routing
+	if event.Channel != "" {
+		return []string{event.Channel}
+	}
+
+	return b.digest.Filter(event, b.mutes.Filter(event, channelsToNotifyForEvent(event, sourceBindings, b.getChannels(), b.getChannelsToNotify)), channelDigestByID(b.getChannels))
+}
+
+func (b *Matrix) getChannelsToNotify(sourceBindings []string) []string {
+	var out []string
+	for _, cfg := range b.getChannels() {
+		switch {
+		case !cfg.notificationsEnabled():
+			b.log.Infof("Skipping notification for room %q as notifications are disabled.", cfg.Identifier())
+		default:
+			if sliceutil.Intersect(sourceBindings, cfg.Bindings.Sources) {
+				out = append(out, cfg.Identifier())
+			}
+		}
+	}
+	return out
+}
+
+func (b *Matrix) findAndTrimBotMention(msg string) (string, bool) {
+	if !b.botMentionRegex.MatchString(msg) {
+		return "", false
+	}
+
+	return strings.TrimSpace(b.botMentionRegex.ReplaceAllString(msg, "")), true
+}
+
+func (b *Matrix) getChannels() map[string]channelConfigByID {
+	b.channelsMutex.RLock()
+	defer b.channelsMutex.RUnlock()
+	return b.channels
+}
+
+func (b *Matrix) setChannels(channels map[string]channelConfigByID) {
+	b.channelsMutex.Lock()
+	defer b.channelsMutex.Unlock()
+	b.channels = channels
+}
+
+// ReloadChannels reloads the bindings and notification settings of the channels, without reconnecting the bot.
+// Channels are matched by alias, so the channel IDs don't need to be resolved again.
+func (b *Matrix) ReloadChannels(cfg config.Matrix) {
+	b.channelsMutex.Lock()
+	defer b.channelsMutex.Unlock()
+	b.channels = reloadedChannelsByID(b.channels, channelBindingsByIDFrom(cfg.Channels))
+}
+
+// matrixThreadReplyTo returns the relation which replies to a given event in its thread.
+// If the event isn't a part of a thread, it starts a new one.
+func matrixThreadReplyTo(event matrixEvent) *matrixRelatesTo {
+	threadRootID := event.EventID
+	if rel := event.Content.RelatesTo; rel != nil && rel.RelType == matrixThreadRelType && rel.EventID != "" {
+		threadRootID = rel.EventID
+	}
+
+	return &matrixRelatesTo{
+		RelType:       matrixThreadRelType,
+		EventID:       threadRootID,
+		IsFallingBack: true,
+		InReplyTo:     &matrixInReplyToInfo{EventID: event.EventID},
+	}
+}
+
+// matrixChannelsCfgFrom joins the configured rooms and returns their configuration by room ID.
+// The rooms are configured by aliases, which are resolved to the room IDs while joining.
+func matrixChannelsCfgFrom(ctx context.Context, client *matrixClient, channelsCfg config.IdentifiableMap[config.ChannelBindingsByName]) (map[string]channelConfigByID, error) {
+	res := make(map[string]channelConfigByID)
+	for channAlias, channCfg := range channelsCfg {
+		roomID, err := client.JoinRoom(ctx, channCfg.Identifier())
+		if err != nil {
+			return nil, fmt.Errorf("while joining room %q: %w", channCfg.Name, err)
+		}
+
+		res[roomID] = channelConfigByID{
+			ChannelBindingsByID: config.ChannelBindingsByID{
+				ID:                   roomID,
+				Notification:         channCfg.Notification,
+				Bindings:             channCfg.Bindings,
+				Commands:             channCfg.Commands,
+				Locale:               channCfg.Locale,
+				NotificationSchedule: channCfg.NotificationSchedule,
+			},
+			alias:  channAlias,
+			notify: !channCfg.Notification.Disabled,
+		}
+	}
+
+	return res, nil
+}
+
+// matrixUserLocalpart returns the localpart of a given Matrix user ID, e.g. `botkube` for `@botkube:matrix.org`.
+func matrixUserLocalpart(userID string) string {
+	localpart, _, _ := strings.Cut(strings.TrimPrefix(userID, "@"), ":")
+	return localpart
+}
+
+func matrixBotMentionRegex(botName string) (*regexp.Regexp, error) {
+	botMentionRegex, err := regexp.Compile(fmt.Sprintf(matrixBotMentionRegexFmt, regexp.QuoteMeta(botName)))
+	if err != nil {
+		return nil, fmt.Errorf("while compiling bot mention regex: %w", err)
+	}
+
+	return botMentionRegex, nil
+}
@@ -0,0 +1,210 @@
+package bot
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	matrixClientAPIPath = "/_matrix/client/v3"
+	matrixMediaAPIPath  = "/_matrix/media/v3"
+
+	// matrixSyncTimeout is the time for which the homeserver holds the sync request if there are no new events.
+	matrixSyncTimeout = 30 * time.Second
+	// matrixHTTPTimeout must be greater than matrixSyncTimeout, as sync requests are long-polled.
+	matrixHTTPTimeout = matrixSyncTimeout + 30*time.Second
+
+	matrixRoomMessageEvent = "m.room.message"
+	matrixTextMsgType      = "m.text"
+	matrixNoticeMsgType    = "m.notice"
+	matrixFileMsgType      = "m.file"
+	matrixHTMLFormat       = "org.matrix.custom.html"
+	matrixThreadRelType    = "m.thread"
+	matrixReplaceRelType   = "m.replace"
+)
+
+// matrixClient is a minimal Matrix Client-Server API client. It supports only unencrypted rooms.
+// See https://spec.matrix.org/latest/client-server-api/
+type matrixClient struct {
+	homeserverURL string
+	accessToken   string
+	httpCli       *http.Client
+	txnIDPrefix   string
+	txnCounter    uint64
+}
+
+type matrixWhoAmI struct {
+	UserID string `json:"user_id"`
+}
+
+type matrixRelatesTo struct {
+	RelType       string               `json:"rel_type,omitempty"`
+	EventID       string               `json:"event_id,omitempty"`
+	IsFallingBack bool                 `json:"is_falling_back,omitempty"`
+	InReplyTo     *matrixInReplyToInfo `json:"m.in_reply_to,omitempty"`
+}
+
+type matrixInReplyToInfo struct {
+	EventID string `json:"event_id"`
+}
+
+// matrixMessageContent is the content of the `m.room.message` event.
+type matrixMessageContent struct {
+	MsgType       string           `json:"msgtype"`
+	Body          string           `json:"body"`
+	Format        string           `json:"format,omitempty"`
+	FormattedBody string           `json:"formatted_body,omitempty"`
+	URL           string           `json:"url,omitempty"`
+	RelatesTo     *matrixRelatesTo `json:"m.relates_to,omitempty"`
+}
+
+// matrixEvent is a room event received from the sync endpoint.
+type matrixEvent struct {
+	Type    string               `json:"type"`
+	EventID string               `json:"event_id"`
+	Sender  string               `json:"sender"`
+	Content matrixMessageContent `json:"content"`
+}
+
+type matrixSyncResponse struct {
+	NextBatch string `json:"next_batch"`
+	Rooms     struct {
+		Join map[string]struct {
+			Timeline struct {
+				Events []matrixEvent `json:"events"`
+			} `json:"timeline"`
+		} `json:"join"`
+	} `json:"rooms"`
+}
+
+type matrixErrorResponse struct {
+	ErrCode string `json:"errcode"`
+	Error   string `json:"error"`
+}
+
+func newMatrixClient(homeserverURL, accessToken string) *matrixClient {
+	return &matrixClient{
+		homeserverURL: strings.TrimSuffix(homeserverURL, "/"),
+		accessToken:   accessToken,
+		httpCli:       &http.Client{Timeout: matrixHTTPTimeout},
+		txnIDPrefix:   strconv.FormatInt(time.Now().UnixNano(), 36),
+	}
+}
+
+// WhoAmI returns the Matrix user ID of the access token owner.
+func (c *matrixClient) WhoAmI(ctx context.Context) (matrixWhoAmI, error) {
+	var out matrixWhoAmI
+	if err := c.doJSON(ctx, http.MethodGet, matrixClientAPIPath+"/account/whoami", nil, &out); err != nil {
+		return matrixWhoAmI{}, err
+	}
+	return out, nil
+}
+
+// JoinRoom joins a room with a given ID or alias and returns the room ID. It's a no-op if the bot is already a member.
+func (c *matrixClient) JoinRoom(ctx context.Context, roomIDOrAlias string) (string, error) {
+	var out struct {
+		RoomID string `json:"room_id"`
+	}
+	if err := c.doJSON(ctx, http.MethodPost, matrixClientAPIPath+"/join/"+url.PathEscape(roomIDOrAlias), struct{}{}, &out); err != nil {
+		return "", err
+	}
+	return out.RoomID, nil
+}
+
+// Sync returns events which happened since a given batch token. If the token is empty, it returns the latest state only.
+func (c *matrixClient) Sync(ctx context.Context, since string) (matrixSyncResponse, error) {
+	query := url.Values{}
+	if since == "" {
+		// skip the history, as the commands sent before Botkube started shouldn't be executed
+		query.Set("filter", `{"room":{"timeline":{"limit":0}}}`)
+	} else {
+		query.Set("since", since)
+		query.Set("timeout", strconv.FormatInt(matrixSyncTimeout.Milliseconds(), 10))
+	}
+
+	var out matrixSyncResponse
+	if err := c.doJSON(ctx, http.MethodGet, matrixClientAPIPath+"/sync?"+query.Encode(), nil, &out); err != nil {
+		return matrixSyncResponse{}, err
+	}
+	return out, nil
+}
+
+// SendMessage sends a message to a given room.
+func (c *matrixClient) SendMessage(ctx context.Context, roomID string, content matrixMessageContent) error {
+	path := fmt.Sprintf("%s/rooms/%s/send/%s/%s", matrixClientAPIPath, url.PathEscape(roomID), matrixRoomMessageEvent, c.nextTxnID())
+	return c.doJSON(ctx, http.MethodPut, path, content, nil)
+}
+
+// UploadFile uploads a given file to the homeserver and returns its content URI.
+func (c *matrixClient) UploadFile(ctx context.Context, fileName string, content []byte) (string, error) {
+	var out struct {
+		ContentURI string `json:"content_uri"`
+	}
+	path := matrixMediaAPIPath + "/upload?filename=" + url.QueryEscape(fileName)
+	if err := c.do(ctx, http.MethodPost, path, bytes.NewReader(content), "text/plain", &out); err != nil {
+		return "", err
+	}
+	return out.ContentURI, nil
+}
+
+// nextTxnID returns a unique transaction ID, which is used by the homeserver to deduplicate retried requests.
+func (c *matrixClient) nextTxnID() string {
+	return fmt.Sprintf("botkube-%s-%d", c.txnIDPrefix, atomic.AddUint64(&c.txnCounter, 1))
+}
+
+func (c *matrixClient) doJSON(ctx context.Context, method, path string, in, out interface{}) error {
+	if in == nil {
+		return c.do(ctx, method, path, nil, "", out)
+	}
+
+	raw, err := json.Marshal(in)
+	if err != nil {
+		return fmt.Errorf("while marshaling request body: %w", err)
+	}
+	return c.do(ctx, method, path, bytes.NewReader(raw), "application/json", out)
+}
+
+func (c *matrixClient) do(ctx context.Context, method, path string, body io.Reader, contentType string, out interface{}) (err error) {
+	req, err := http.NewRequestWithContext(ctx, method, c.homeserverURL+path, body)
+	if err != nil {
+		return fmt.Errorf("while creating request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+c.accessToken)
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	res, err := c.httpCli.Do(req)
+	if err != nil {
+		return fmt.Errorf("while sending request: %w", err)
+	}
+	defer func() {
+		if closeErr := res.Body.Close(); closeErr != nil && err == nil {
+			err = fmt.Errorf("while closing response body: %w", closeErr)
+		}
+	}()
+
+	if res.StatusCode < http.StatusOK || res.StatusCode >= http.StatusMultipleChoices {
+		var errResp matrixErrorResponse
+		_ = json.NewDecoder(res.Body).Decode(&errResp)
+		return fmt.Errorf("got unexpected response with status %d: %s (%s)", res.StatusCode, errResp.Error, errResp.ErrCode)
+	}
+
+	if out == nil {
+		return nil
+	}
+
+	if err := json.NewDecoder(res.Body).Decode(out); err != nil {
+		return fmt.Errorf("while decoding response: %w", err)
+	}
+	return nil
+}
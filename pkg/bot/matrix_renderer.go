@@ -0,0 +1,54 @@
+package bot
+
+import (
+	"strings"
+
+	"github.com/russross/blackfriday"
+
+	"github.com/kubeshop/botkube/pkg/bot/interactive"
+)
+
+const (
+	// matrixHTMLFlags skips raw HTML from the messages, as it could break the message layout.
+	matrixHTMLFlags = blackfriday.HTML_USE_XHTML | blackfriday.HTML_SKIP_HTML | blackfriday.HTML_SKIP_STYLE | blackfriday.HTML_SAFELINK
+
+	matrixMarkdownExtensions = blackfriday.EXTENSION_NO_INTRA_EMPHASIS |
+		blackfriday.EXTENSION_FENCED_CODE |
+		blackfriday.EXTENSION_AUTOLINK |
+		blackfriday.EXTENSION_STRIKETHROUGH |
+		blackfriday.EXTENSION_HARD_LINE_BREAK |
+		blackfriday.EXTENSION_NO_EMPTY_LINE_BEFORE_BLOCK
+)
+
+// MatrixRenderer provides functionality to render interactive messages as Matrix messages.
+// Matrix messages don't support interactive elements, so they are rendered as Markdown
+// with the commands which can be copied and sent to the bot. The HTML version of the message
+// is used by clients, such as Element, to display the formatted message.
+type MatrixRenderer struct {
+	mdFormatter interactive.MDFormatter
+}
+
+// NewMatrixRenderer returns new MatrixRenderer instance.
+func NewMatrixRenderer() *MatrixRenderer {
+	return &MatrixRenderer{
+		mdFormatter: interactive.DefaultMDFormatter(),
+	}
+}
+
+// RenderMessage returns interactive message as Matrix message content.
+func (r *MatrixRenderer) RenderMessage(msg interactive.Message) matrixMessageContent {
+	return r.RenderMarkdown(interactive.RenderMessage(r.mdFormatter, msg))
+}
+
+// RenderMarkdown returns Matrix message content with a given Markdown and its HTML version.
+func (r *MatrixRenderer) RenderMarkdown(markdown string) matrixMessageContent {
+	markdown = strings.TrimSpace(markdown)
+	html := blackfriday.Markdown([]byte(markdown), blackfriday.HtmlRenderer(matrixHTMLFlags, "", ""), matrixMarkdownExtensions)
+
+	return matrixMessageContent{
+		MsgType:       matrixNoticeMsgType,
+		Body:          markdown,
+		Format:        matrixHTMLFormat,
+		FormattedBody: strings.TrimSpace(string(html)),
+	}
+}
@@ -0,0 +1,178 @@
+package bot
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	logtest "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/pkg/bot/interactive"
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+func TestMatrix_HandleEvent(t *testing.T) {
+	// given
+	testCases := []struct {
+		Name              string
+		Event             matrixEvent
+		ExpectedCmd       string
+		ExpectedThreadID  string
+		ExpectedNoMessage bool
+	}{
+		{
+			Name:             "Message with display name mention",
+			Event:            fixMatrixTextEvent("$event", "@user:matrix.org", "Botkube: get pods", nil),
+			ExpectedCmd:      "get pods",
+			ExpectedThreadID: "$event",
+		},
+		{
+			Name:             "Message with user ID mention",
+			Event:            fixMatrixTextEvent("$event", "@user:matrix.org", "@botkube:matrix.org get pods", nil),
+			ExpectedCmd:      "get pods",
+			ExpectedThreadID: "$event",
+		},
+		{
+			Name:             "Message in thread",
+			Event:            fixMatrixTextEvent("$event", "@user:matrix.org", "Botkube get pods", &matrixRelatesTo{RelType: matrixThreadRelType, EventID: "$root"}),
+			ExpectedCmd:      "get pods",
+			ExpectedThreadID: "$root",
+		},
+		{
+			Name:              "Message without mention",
+			Event:             fixMatrixTextEvent("$event", "@user:matrix.org", "get pods", nil),
+			ExpectedNoMessage: true,
+		},
+		{
+			Name:              "Message sent by bot",
+			Event:             fixMatrixTextEvent("$event", "@botkube:matrix.org", "Botkube get pods", nil),
+			ExpectedNoMessage: true,
+		},
+		{
+			Name:              "Edited message",
+			Event:             fixMatrixTextEvent("$event", "@user:matrix.org", "Botkube get pods", &matrixRelatesTo{RelType: matrixReplaceRelType, EventID: "$other"}),
+			ExpectedNoMessage: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			var gotMessages []matrixMessageContent
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+				assert.Equal(t, http.MethodPut, r.Method)
+				assert.True(t, strings.HasPrefix(r.URL.Path, "/_matrix/client/v3/rooms/!room:matrix.org/send/m.room.message/"))
+
+				var content matrixMessageContent
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&content))
+				gotMessages = append(gotMessages, content)
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(`{"event_id": "$reply"}`))
+			}))
+			defer srv.Close()
+
+			factory := &fakeExecutorFactory{response: interactive.Message{Base: interactive.Base{Description: "done"}}}
+			bot := newTestMatrix(t, factory, srv.URL)
+
+			// when
+			err := bot.handleEvent(context.Background(), "!room:matrix.org", tc.Event)
+
+			// then
+			require.NoError(t, err)
+			if tc.ExpectedNoMessage {
+				assert.Empty(t, factory.gotInputs)
+				assert.Empty(t, gotMessages)
+				return
+			}
+
+			require.Len(t, factory.gotInputs, 1)
+			assert.Equal(t, tc.ExpectedCmd, factory.gotInputs[0].Message)
+			assert.Equal(t, "!room:matrix.org", factory.gotInputs[0].Conversation.ID)
+			assert.Equal(t, "default", factory.gotInputs[0].Conversation.Alias)
+			assert.Equal(t, "@user:matrix.org", factory.gotInputs[0].User)
+			assert.True(t, factory.gotInputs[0].Conversation.IsAuthenticated)
+
+			require.Len(t, gotMessages, 1)
+			assert.Equal(t, matrixMessageContent{
+				MsgType:       matrixNoticeMsgType,
+				Body:          "done",
+				Format:        matrixHTMLFormat,
+				FormattedBody: "<p>done</p>",
+				RelatesTo: &matrixRelatesTo{
+					RelType:       matrixThreadRelType,
+					EventID:       tc.ExpectedThreadID,
+					IsFallingBack: true,
+					InReplyTo:     &matrixInReplyToInfo{EventID: "$event"},
+				},
+			}, gotMessages[0])
+		})
+	}
+}
+
+func TestMatrixRenderer_RenderMessage(t *testing.T) {
+	// given
+	renderer := NewMatrixRenderer()
+	msg := interactive.Message{
+		Base: interactive.Base{
+			Header:      "Header",
+			Description: "Description",
+			Body:        interactive.Body{CodeBlock: "pod-1 <none>"},
+		},
+	}
+
+	// when
+	out := renderer.RenderMessage(msg)
+
+	// then
+	assert.Equal(t, matrixNoticeMsgType, out.MsgType)
+	assert.Equal(t, matrixHTMLFormat, out.Format)
+	assert.Equal(t, "**Header**\nDescription\n```\npod-1 <none>\n```", out.Body)
+	assert.Equal(t, "<p><strong>Header</strong><br />\nDescription</p>\n\n<pre><code>pod-1 &lt;none&gt;\n</code></pre>", out.FormattedBody)
+}
+
+func TestMatrixUserLocalpart(t *testing.T) {
+	assert.Equal(t, "botkube", matrixUserLocalpart("@botkube:matrix.org"))
+	assert.Equal(t, "botkube", matrixUserLocalpart("botkube"))
+}
+
+func newTestMatrix(t *testing.T, factory ExecutorFactory, homeserverURL string) *Matrix {
+	t.Helper()
+
+	logger, _ := logtest.NewNullLogger()
+	botMentionRegex, err := matrixBotMentionRegex("botkube")
+	require.NoError(t, err)
+
+	return &Matrix{
+		log:             logger,
+		executorFactory: factory,
+		client:          newMatrixClient(homeserverURL, "token"),
+		renderer:        NewMatrixRenderer(),
+		botUserID:       "@botkube:matrix.org",
+		botName:         "botkube",
+		channels: map[string]channelConfigByID{
+			"!room:matrix.org": {
+				ChannelBindingsByID: config.ChannelBindingsByID{ID: "!room:matrix.org"},
+				alias:               "default",
+			},
+		},
+		botMentionRegex: botMentionRegex,
+	}
+}
+
+func fixMatrixTextEvent(id, sender, body string, relatesTo *matrixRelatesTo) matrixEvent {
+	return matrixEvent{
+		Type:    matrixRoomMessageEvent,
+		EventID: id,
+		Sender:  sender,
+		Content: matrixMessageContent{
+			MsgType:   matrixTextMsgType,
+			Body:      body,
+			RelatesTo: relatesTo,
+		},
+	}
+}
@@ -2,6 +2,9 @@ package bot
 
 import (
 	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -9,16 +12,20 @@ import (
 	"regexp"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/mattermost/mattermost-server/v6/model"
 	"github.com/sirupsen/logrus"
+	"golang.org/x/sync/errgroup"
 
 	"github.com/kubeshop/botkube/pkg/bot/interactive"
 	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/digest"
 	"github.com/kubeshop/botkube/pkg/events"
 	"github.com/kubeshop/botkube/pkg/execute"
 	"github.com/kubeshop/botkube/pkg/execute/command"
 	"github.com/kubeshop/botkube/pkg/multierror"
+	"github.com/kubeshop/botkube/pkg/mute"
 	"github.com/kubeshop/botkube/pkg/sliceutil"
 )
 
@@ -38,6 +45,9 @@ const (
 
 	httpsScheme                  = "https"
 	mattermostBotMentionRegexFmt = "^@(?i)%s"
+
+	// mattermostInteractivityTokenSalt is used to derive the interactivity token from the bot token.
+	mattermostInteractivityTokenSalt = "botkube-mattermost-interactivity"
 )
 
 // TODO:
@@ -62,6 +72,18 @@ type Mattermost struct {
 	notifyMutex     sync.Mutex
 	botMentionRegex *regexp.Regexp
 	mdFormatter     interactive.MDFormatter
+	digest          *digest.Scheduler
+	mutes           *mute.Registry
+
+	// renderer is set only if interactivity is enabled
+	renderer           *MattermostRenderer
+	interactivityPort  string
+	interactivityToken string
+
+	// interactiveCmds tracks the commands triggered by the interactive messages, so Start waits for them
+	interactiveCmdsMutex   sync.Mutex
+	interactiveCmdsStopped bool
+	interactiveCmds        sync.WaitGroup
 }
 
 // mattermostMessage contains message details to execute command and send back the result
@@ -110,6 +132,15 @@ func NewMattermost(log logrus.FieldLogger, commGroupName string, cfg config.Matt
 		return nil, fmt.Errorf("while producing channels configuration map by ID: %w", err)
 	}
 
+	var (
+		renderer           *MattermostRenderer
+		interactivityToken string
+	)
+	if cfg.Interactivity.Enabled {
+		interactivityToken = mattermostInteractivityToken(cfg.Token)
+		renderer = NewMattermostRenderer(cfg.Interactivity.CallbackURL, interactivityToken)
+	}
+
 	return &Mattermost{
 		log:             log,
 		executorFactory: executorFactory,
@@ -124,6 +155,12 @@ func NewMattermost(log logrus.FieldLogger, commGroupName string, cfg config.Matt
 		channels:        channelsByIDCfg,
 		botMentionRegex: botMentionRegex,
 		mdFormatter:     interactive.DefaultMDFormatter(),
+		digest:          digest.NewScheduler(log),
+		mutes:           mute.NewRegistry(),
+
+		renderer:           renderer,
+		interactivityPort:  cfg.Interactivity.Port,
+		interactivityToken: interactivityToken,
 	}, nil
 }
 
@@ -142,9 +179,30 @@ func (b *Mattermost) Start(ctx context.Context) error {
 		return fmt.Errorf("while reporting analytics: %w", err)
 	}
 
-	// It is observed that Mattermost server closes connections unexpectedly after some time.
-	// For now, we are adding retry logic to reconnect to the server
-	// https://github.com/kubeshop/botkube/issues/201
+	// the bot stops if the interactivity server fails, e.g. because the port is already in use
+	errGroup, ctx := errgroup.WithContext(ctx)
+	if b.renderer != nil {
+		errGroup.Go(func() error {
+			return b.serveInteractivity(ctx)
+		})
+	}
+
+	go b.digest.Run(ctx, b.sendDigest)
+
+	errGroup.Go(func() error {
+		return b.listenWithReconnect(ctx)
+	})
+
+	err = errGroup.Wait()
+	b.waitForInteractiveCommands()
+	return err
+}
+
+// listenWithReconnect listens for messages until the context is canceled.
+// It is observed that Mattermost server closes connections unexpectedly after some time.
+// For now, we are adding retry logic to reconnect to the server
+// https://github.com/kubeshop/botkube/issues/201
+func (b *Mattermost) listenWithReconnect(ctx context.Context) error {
 	b.log.Info("Botkube connected to Mattermost!")
 	for {
 		select {
@@ -179,7 +237,7 @@ func (b *Mattermost) NotificationsEnabled(channelID string) bool {
 		return false
 	}
 
-	return channel.notify
+	return channel.notificationsEnabled()
 }
 
 // SetNotificationsEnabled sets a new notification status for a given channel ID.
@@ -195,12 +253,58 @@ func (b *Mattermost) SetNotificationsEnabled(channelID string, enabled bool) err
 	}
 
 	channel.notify = enabled
+	channel.Notification.SnoozedUntil = time.Time{}
 	channels[channelID] = channel
 	b.setChannels(channels)
 
 	return nil
 }
 
+// SnoozeNotifications disables notifications for a given channel until a given time.
+func (b *Mattermost) SnoozeNotifications(channelID string, until time.Time) error {
+	// avoid race conditions with using the setter concurrently, as we set whole map
+	b.notifyMutex.Lock()
+	defer b.notifyMutex.Unlock()
+
+	channels := b.getChannels()
+	channel, exists := channels[channelID]
+	if !exists {
+		return execute.ErrNotificationsNotConfigured
+	}
+
+	channel.notify = true
+	channel.Notification.SnoozedUntil = until
+	channels[channelID] = channel
+	b.setChannels(channels)
+
+	return nil
+}
+
+// NotificationsSnoozedUntil returns the snooze end time for a given channel.
+func (b *Mattermost) NotificationsSnoozedUntil(channelID string) time.Time {
+	return b.getChannels()[channelID].Notification.SnoozedUntil
+}
+
+// MuteNotifications suppresses notifications matching a given rule for a given channel.
+func (b *Mattermost) MuteNotifications(channelID string, rule mute.Rule) error {
+	if _, exists := b.getChannels()[channelID]; !exists {
+		return execute.ErrNotificationsNotConfigured
+	}
+
+	b.mutes.Add(channelID, rule)
+	return nil
+}
+
+// UnmuteNotifications removes the mute rule for the same target as a given rule for a given channel.
+func (b *Mattermost) UnmuteNotifications(channelID string, rule mute.Rule) bool {
+	return b.mutes.Remove(channelID, rule)
+}
+
+// NotificationMutes returns active mute rules for a given channel.
+func (b *Mattermost) NotificationMutes(channelID string) []mute.Rule {
+	return b.mutes.List(channelID)
+}
+
 // Check incoming message and take action
 func (b *Mattermost) handleMessage(ctx context.Context, mm *mattermostMessage) error {
 	post, err := postFromEvent(mm.Event)
@@ -218,8 +322,24 @@ func (b *Mattermost) handleMessage(ctx context.Context, mm *mattermostMessage) e
 	b.log.Debugf("Mattermost incoming Request: %s", req)
 
 	channelID := mm.Event.GetBroadcast().ChannelId
-	channel, exists := b.getChannels()[channelID]
-	mm.IsAuthChannel = exists
+	_, mm.IsAuthChannel = b.getChannels()[channelID]
+
+	response := b.executeCommand(ctx, channelID, post.UserId, req, command.TypedOrigin)
+	err = b.send(channelID, response)
+	if err != nil {
+		return fmt.Errorf("while sending message: %w", err)
+	}
+
+	return nil
+}
+
+// executeCommand executes a given command in the context of a given channel.
+func (b *Mattermost) executeCommand(ctx context.Context, channelID, userID, req string, cmdOrigin command.Origin) interactive.Message {
+	channel, isAuthChannel := b.getChannels()[channelID]
+	if !execute.IsCommandPermitted(channel.Commands, req) {
+		b.log.Debugf("Command %q is not permitted in channel %q", req, channel.Identifier())
+		return execute.CommandNotPermittedMessage(req)
+	}
 
 	e := b.executorFactory.NewDefault(execute.NewDefaultInput{
 		CommGroupName:   b.commGroupName,
@@ -229,18 +349,31 @@ func (b *Mattermost) handleMessage(ctx context.Context, mm *mattermostMessage) e
 			Alias:            channel.alias,
 			ID:               channel.Identifier(),
 			ExecutorBindings: channel.Bindings.Executors,
-			IsAuthenticated:  mm.IsAuthChannel,
-			CommandOrigin:    command.TypedOrigin,
+			Commands:         channel.Commands,
+			Locale:           channel.Locale,
+			IsAuthenticated:  isAuthChannel,
+			CommandOrigin:    cmdOrigin,
 		},
 		Message: req,
+		UserID:  userID,
 	})
-	response := e.Execute(ctx)
-	err = b.send(channelID, response)
-	if err != nil {
-		return fmt.Errorf("while sending message: %w", err)
+	return e.Execute(ctx)
+}
+
+// RunScheduledCommand executes a given scheduled command and posts its output in the command channel.
+func (b *Mattermost) RunScheduledCommand(ctx context.Context, cmd execute.ScheduledCommand) (bool, error) {
+	if cmd.CommGroup != b.commGroupName || cmd.Platform != b.IntegrationName() {
+		return false, nil
+	}
+	if _, found := b.getChannels()[cmd.ChannelID]; !found {
+		return false, nil
 	}
 
-	return nil
+	response := b.executeCommand(ctx, cmd.ChannelID, cmd.UserID, cmd.Command, command.ScheduleOrigin)
+	if err := b.send(cmd.ChannelID, response); err != nil {
+		return true, fmt.Errorf("while sending message: %w", err)
+	}
+	return true, nil
 }
 
 // Send messages to Mattermost
@@ -276,6 +409,17 @@ func (b *Mattermost) send(channelID string, resp interactive.Message) error {
 		return nil
 	}
 
+	if b.renderer != nil && (resp.HasSections() || resp.HasInputs()) {
+		post, err := b.renderer.RenderPost(channelID, resp)
+		if err != nil {
+			return fmt.Errorf("while rendering interactive message: %w", err)
+		}
+		if _, _, err := b.apiClient.CreatePost(post); err != nil {
+			return fmt.Errorf("while sending interactive message: %w", err)
+		}
+		return nil
+	}
+
 	post := &model.Post{}
 	post.ChannelId = channelID
 	post.Message = markdown
@@ -400,14 +544,25 @@ func (b *Mattermost) getChannelsToNotifyForEvent(event events.Event, sourceBindi
 		return []string{event.Channel}
 	}
 
-	return b.getChannelsToNotify(sourceBindings)
+	return b.digest.Filter(event, b.mutes.Filter(event, channelsToNotifyForEvent(event, sourceBindings, b.getChannels(), b.getChannelsToNotify)), channelDigestByID(b.getChannels))
+}
+
+func (b *Mattermost) sendDigest(_ context.Context, channelID string, msg interactive.Message) error {
+	post := &model.Post{
+		ChannelId: channelID,
+		Message:   interactive.RenderMessage(b.mdFormatter, msg),
+	}
+	if _, _, err := b.apiClient.CreatePost(post); err != nil {
+		return fmt.Errorf("while posting message to channel %q: %w", channelID, err)
+	}
+	return nil
 }
 
 func (b *Mattermost) getChannelsToNotify(eventSources []string) []string {
 	var out []string
 	for _, cfg := range b.getChannels() {
 		switch {
-		case !cfg.notify:
+		case !cfg.notificationsEnabled():
 			b.log.Infof("Skipping notification for channel %q as notifications are disabled.", cfg.Identifier())
 		default:
 			if sliceutil.Intersect(eventSources, cfg.Bindings.Sources) {
@@ -481,6 +636,14 @@ func (b *Mattermost) setChannels(channels map[string]channelConfigByID) {
 	b.channels = channels
 }
 
+// ReloadChannels reloads the bindings and notification settings of the channels, without reconnecting the bot.
+// Channels are matched by alias, so the channel IDs don't need to be resolved again.
+func (b *Mattermost) ReloadChannels(cfg config.Mattermost) {
+	b.channelsMutex.Lock()
+	defer b.channelsMutex.Unlock()
+	b.channels = reloadedChannelsByID(b.channels, channelBindingsByIDFrom(cfg.Channels))
+}
+
 func mattermostChannelsCfgFrom(client *model.Client4, teamID string, channelsCfg config.IdentifiableMap[config.ChannelBindingsByName]) (map[string]channelConfigByID, error) {
 	res := make(map[string]channelConfigByID)
 	for channAlias, channCfg := range channelsCfg {
@@ -491,8 +654,12 @@ func mattermostChannelsCfgFrom(client *model.Client4, teamID string, channelsCfg
 
 		res[fetchedChannel.Id] = channelConfigByID{
 			ChannelBindingsByID: config.ChannelBindingsByID{
-				ID:       fetchedChannel.Id,
-				Bindings: channCfg.Bindings,
+				ID:                   fetchedChannel.Id,
+				Notification:         channCfg.Notification,
+				Bindings:             channCfg.Bindings,
+				Commands:             channCfg.Commands,
+				Locale:               channCfg.Locale,
+				NotificationSchedule: channCfg.NotificationSchedule,
 			},
 			alias:  channAlias,
 			notify: !channCfg.Notification.Disabled,
@@ -518,3 +685,11 @@ func postFromEvent(event *model.WebSocketEvent) (*model.Post, error) {
 	}
 	return post, nil
 }
+
+// mattermostInteractivityToken derives the token which authenticates the interactive message callbacks from the bot token.
+// The token doesn't change on restart, so the messages sent before stay interactive.
+func mattermostInteractivityToken(botToken string) string {
+	mac := hmac.New(sha256.New, []byte(botToken))
+	mac.Write([]byte(mattermostInteractivityTokenSalt))
+	return hex.EncodeToString(mac.Sum(nil))
+}
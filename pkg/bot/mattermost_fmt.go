@@ -13,7 +13,7 @@ import (
 
 func (b *Mattermost) formatAttachments(event events.Event) []*model.SlackAttachment {
 	var fields []*model.SlackAttachmentField
-	switch b.notification.Type {
+	switch eventNotificationType(b.notification, event) {
 	case config.LongNotification:
 		fields = b.longNotification(event)
 	case config.ShortNotification:
@@ -49,11 +49,13 @@ func (b *Mattermost) longNotification(event events.Event) []*model.SlackAttachme
 	}
 
 	fields = b.appendIfNotEmpty(fields, event.Namespace, "Namespace", true)
+	fields = b.appendIfNotEmpty(fields, event.OwnerString(), "Owner", true)
 	fields = b.appendIfNotEmpty(fields, event.Reason, "Reason", true)
 	fields = b.appendIfNotEmpty(fields, formatx.JoinMessages(event.Messages), "Message", false)
 	fields = b.appendIfNotEmpty(fields, event.Action, "Action", true)
 	fields = b.appendIfNotEmpty(fields, formatx.JoinMessages(event.Recommendations), "Recommendations", false)
 	fields = b.appendIfNotEmpty(fields, formatx.JoinMessages(event.Warnings), "Warnings", false)
+	fields = b.appendIfNotEmpty(fields, formatx.EventLogs(event), "Logs", false)
 	fields = b.appendIfNotEmpty(fields, event.Cluster, "Cluster", false)
 
 	return fields
@@ -0,0 +1,187 @@
+package bot
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/mattermost/mattermost-server/v6/model"
+
+	"github.com/kubeshop/botkube/pkg/bot/interactive"
+	"github.com/kubeshop/botkube/pkg/execute/command"
+	"github.com/kubeshop/botkube/pkg/httpsrv"
+)
+
+// serveInteractivity starts the HTTP server which handles the message actions and dialog submissions.
+func (b *Mattermost) serveInteractivity(ctx context.Context) error {
+	addr := fmt.Sprintf(":%s", b.interactivityPort)
+	srv := httpsrv.New(b.log, addr, b.interactivityRouter(ctx))
+	if err := srv.Serve(ctx); err != nil {
+		return fmt.Errorf("while running Mattermost interactivity server: %w", err)
+	}
+
+	return nil
+}
+
+func (b *Mattermost) interactivityRouter(ctx context.Context) http.Handler {
+	router := mux.NewRouter()
+	router.HandleFunc(mattermostActionsPath, func(w http.ResponseWriter, r *http.Request) {
+		b.handleAction(ctx, w, r)
+	}).Methods(http.MethodPost)
+	router.HandleFunc(mattermostDialogsPath, func(w http.ResponseWriter, r *http.Request) {
+		b.handleDialogSubmission(ctx, w, r)
+	}).Methods(http.MethodPost)
+	return router
+}
+
+// handleAction handles the button clicks and select value changes.
+func (b *Mattermost) handleAction(ctx context.Context, w http.ResponseWriter, r *http.Request) {
+	var req model.PostActionIntegrationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	token, _ := req.Context[mattermostCtxTokenKey].(string)
+	if !b.isValidInteractivityToken(token) {
+		http.Error(w, "invalid token", http.StatusUnauthorized)
+		return
+	}
+
+	if rawSpec, ok := req.Context[mattermostCtxDialogKey].(string); ok {
+		// dialog needs to be opened immediately, as the trigger ID expires after a few seconds
+		dialog, err := b.renderer.DialogFromContext(rawSpec)
+		if err == nil {
+			err = b.openDialog(req.TriggerId, dialog)
+		}
+		if err != nil {
+			b.log.Errorf("while opening Mattermost dialog: %s", err.Error())
+		}
+		writeMattermostJSON(w, model.PostActionIntegrationResponse{})
+		return
+	}
+
+	cmd, _ := req.Context[mattermostCtxCommandKey].(string)
+	cmdOrigin := command.ButtonClickOrigin
+	if selected, ok := req.Context[mattermostCtxSelectedOptionKey].(string); ok {
+		cmd = fmt.Sprintf("%s %s", cmd, selected)
+		cmdOrigin = command.SelectValueChangeOrigin
+	}
+
+	writeMattermostJSON(w, model.PostActionIntegrationResponse{})
+	b.runInteractiveCommand(func() {
+		b.handleInteractiveCommand(ctx, req.ChannelId, req.UserId, req.TriggerId, cmd, cmdOrigin)
+	})
+}
+
+// handleDialogSubmission handles the interactive dialog submissions.
+func (b *Mattermost) handleDialogSubmission(ctx context.Context, w http.ResponseWriter, r *http.Request) {
+	var req model.SubmitDialogRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	var state mattermostDialogState
+	if err := json.Unmarshal([]byte(req.State), &state); err != nil {
+		http.Error(w, "invalid dialog state", http.StatusBadRequest)
+		return
+	}
+
+	if !b.isValidInteractivityToken(state.Token) {
+		http.Error(w, "invalid token", http.StatusUnauthorized)
+		return
+	}
+
+	writeMattermostJSON(w, model.SubmitDialogResponse{})
+	if req.Cancelled {
+		return
+	}
+
+	cmdOrigin := command.PlainTextInputOrigin
+	if state.Spec.Kind == mattermostMultiSelectDialog {
+		cmdOrigin = command.MultiSelectValueChangeOrigin
+	}
+
+	cmd := resolveMattermostDialogCommand(state.Spec, req.Submission)
+	b.runInteractiveCommand(func() {
+		b.handleInteractiveCommand(ctx, req.ChannelId, req.UserId, "", cmd, cmdOrigin)
+	})
+}
+
+// runInteractiveCommand runs a given command handler in the background, as the callback has to be answered immediately.
+// The handlers are not started once the bot is stopped.
+func (b *Mattermost) runInteractiveCommand(handleFn func()) {
+	b.interactiveCmdsMutex.Lock()
+	defer b.interactiveCmdsMutex.Unlock()
+	if b.interactiveCmdsStopped {
+		b.log.Debug("Ignoring interactive command, as the bot is stopped")
+		return
+	}
+
+	b.interactiveCmds.Add(1)
+	go func() {
+		defer b.interactiveCmds.Done()
+		handleFn()
+	}()
+}
+
+// waitForInteractiveCommands waits until the running command handlers finish.
+func (b *Mattermost) waitForInteractiveCommands() {
+	b.interactiveCmdsMutex.Lock()
+	b.interactiveCmdsStopped = true
+	b.interactiveCmdsMutex.Unlock()
+
+	b.interactiveCmds.Wait()
+}
+
+func (b *Mattermost) handleInteractiveCommand(ctx context.Context, channelID, userID, triggerID, cmd string, cmdOrigin command.Origin) {
+	req, found := b.findAndTrimBotMention(cmd)
+	if !found {
+		req = cmd
+	}
+	b.log.Debugf("Mattermost incoming interactive Request: %s", req)
+
+	response := b.executeCommand(ctx, channelID, userID, req, cmdOrigin)
+	if response.Type == interactive.Popup && triggerID != "" {
+		dialog, ok, err := b.renderer.RenderDialog(response)
+		if err != nil {
+			b.log.Errorf("while rendering Mattermost dialog: %s", err.Error())
+			return
+		}
+		if ok {
+			if err := b.openDialog(triggerID, dialog); err != nil {
+				b.log.Errorf("while opening Mattermost dialog: %s", err.Error())
+			}
+			return
+		}
+	}
+
+	if err := b.send(channelID, response); err != nil {
+		b.log.Errorf("while sending message: %s", err.Error())
+	}
+}
+
+func (b *Mattermost) openDialog(triggerID string, dialog model.Dialog) error {
+	_, err := b.apiClient.OpenInteractiveDialog(model.OpenDialogRequest{
+		TriggerId: triggerID,
+		URL:       b.renderer.DialogsURL(),
+		Dialog:    dialog,
+	})
+	if err != nil {
+		return fmt.Errorf("while opening interactive dialog: %w", err)
+	}
+	return nil
+}
+
+func (b *Mattermost) isValidInteractivityToken(token string) bool {
+	return token != "" && subtle.ConstantTimeCompare([]byte(token), []byte(b.interactivityToken)) == 1
+}
+
+func writeMattermostJSON(w http.ResponseWriter, resp interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(resp)
+}
@@ -0,0 +1,379 @@
+package bot
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+	"k8s.io/utils/strings/slices"
+
+	"github.com/kubeshop/botkube/pkg/bot/interactive"
+	formatx "github.com/kubeshop/botkube/pkg/format"
+)
+
+const (
+	mattermostActionsPath = "/mattermost/actions"
+	mattermostDialogsPath = "/mattermost/dialogs"
+
+	mattermostCtxTokenKey          = "token"
+	mattermostCtxCommandKey        = "command"
+	mattermostCtxDialogKey         = "dialog"
+	mattermostCtxSelectedOptionKey = "selected_option"
+
+	mattermostDialogCallbackID  = "botkube"
+	mattermostDialogSubmitLabel = "Apply"
+)
+
+// mattermostDialogKind defines the kind of the interactive dialog.
+type mattermostDialogKind string
+
+const (
+	mattermostMultiSelectDialog mattermostDialogKind = "multiSelect"
+	mattermostInputDialog       mattermostDialogKind = "input"
+)
+
+// mattermostDialogSpec describes an interactive dialog opened when user clicks a given button.
+// Mattermost attachments don't support multi-selects and inputs, so they are rendered as dialogs.
+type mattermostDialogSpec struct {
+	Kind        mattermostDialogKind     `json:"kind"`
+	Title       string                   `json:"title"`
+	Command     string                   `json:"command"`
+	Label       string                   `json:"label,omitempty"`
+	Placeholder string                   `json:"placeholder,omitempty"`
+	Options     []interactive.OptionItem `json:"options,omitempty"`
+	Initial     []string                 `json:"initial,omitempty"`
+}
+
+// mattermostDialogState is passed to the interactive dialog and sent back on dialog submission.
+type mattermostDialogState struct {
+	Token string               `json:"token"`
+	Spec  mattermostDialogSpec `json:"spec"`
+}
+
+// MattermostRenderer provides functionality to render interactive messages as Mattermost posts with message actions.
+type MattermostRenderer struct {
+	callbackURL string
+	token       string
+	mdFormatter interactive.MDFormatter
+}
+
+// NewMattermostRenderer returns new MattermostRenderer instance.
+// The token is attached to all actions to verify the callback requests.
+func NewMattermostRenderer(callbackURL, token string) *MattermostRenderer {
+	return &MattermostRenderer{
+		callbackURL: strings.TrimSuffix(callbackURL, "/"),
+		token:       token,
+		mdFormatter: interactive.DefaultMDFormatter(),
+	}
+}
+
+// RenderPost returns a Mattermost post for a given interactive message.
+func (r *MattermostRenderer) RenderPost(channelID string, msg interactive.Message) (*model.Post, error) {
+	var attachments []*model.SlackAttachment
+	for _, section := range msg.Sections {
+		attachment, err := r.renderSection(section)
+		if err != nil {
+			return nil, err
+		}
+		attachments = append(attachments, attachment)
+	}
+
+	if len(msg.PlaintextInputs) > 0 {
+		actions, err := r.renderInputs(msg.PlaintextInputs)
+		if err != nil {
+			return nil, err
+		}
+		attachments = append(attachments, &model.SlackAttachment{Actions: actions})
+	}
+
+	post := &model.Post{
+		ChannelId: channelID,
+		Message:   interactive.RenderMessage(r.mdFormatter, interactive.Message{Base: msg.Base}),
+	}
+	model.ParseSlackAttachment(post, attachments)
+	return post, nil
+}
+
+// RenderDialog returns a Mattermost dialog for a given popup message.
+// It returns false if the message doesn't contain any element which can be rendered as a dialog.
+func (r *MattermostRenderer) RenderDialog(msg interactive.Message) (model.Dialog, bool, error) {
+	for _, section := range msg.Sections {
+		if section.MultiSelect.AreOptionsDefined() {
+			dialog, err := r.dialogFor(r.multiSelectDialogSpec(section.MultiSelect, msg.Header))
+			return dialog, true, err
+		}
+		if len(section.PlaintextInputs) > 0 {
+			dialog, err := r.dialogFor(r.inputDialogSpec(section.PlaintextInputs[0], msg.Header))
+			return dialog, true, err
+		}
+	}
+
+	if len(msg.PlaintextInputs) > 0 {
+		dialog, err := r.dialogFor(r.inputDialogSpec(msg.PlaintextInputs[0], msg.Header))
+		return dialog, true, err
+	}
+
+	return model.Dialog{}, false, nil
+}
+
+// DialogFromContext returns a Mattermost dialog for a given action context.
+func (r *MattermostRenderer) DialogFromContext(rawSpec string) (model.Dialog, error) {
+	var spec mattermostDialogSpec
+	if err := json.Unmarshal([]byte(rawSpec), &spec); err != nil {
+		return model.Dialog{}, fmt.Errorf("while unmarshaling dialog spec: %w", err)
+	}
+
+	return r.dialogFor(spec)
+}
+
+// ActionsURL returns URL for the message action callbacks.
+func (r *MattermostRenderer) ActionsURL() string {
+	return r.callbackURL + mattermostActionsPath
+}
+
+// DialogsURL returns URL for the dialog submission callbacks.
+func (r *MattermostRenderer) DialogsURL() string {
+	return r.callbackURL + mattermostDialogsPath
+}
+
+func (r *MattermostRenderer) renderSection(section interactive.Section) (*model.SlackAttachment, error) {
+	attachment := &model.SlackAttachment{
+		Title: section.Header,
+		Text:  r.sectionText(section),
+	}
+
+	for _, field := range section.TextFields {
+		attachment.Fields = append(attachment.Fields, &model.SlackAttachmentField{
+			Value: field.Text,
+			Short: true,
+		})
+	}
+
+	var footer []string
+	for _, item := range section.Context {
+		footer = append(footer, item.Text)
+	}
+	attachment.Footer = strings.Join(footer, " ")
+
+	for _, btn := range section.Buttons {
+		if btn.Command == "" {
+			continue // URL buttons are rendered as links in the section text
+		}
+		attachment.Actions = append(attachment.Actions, &model.PostAction{
+			Type:        model.PostActionTypeButton,
+			Name:        btn.Name,
+			Style:       mattermostButtonStyle(btn.Style),
+			Integration: r.integration(map[string]interface{}{mattermostCtxCommandKey: btn.Command}),
+		})
+	}
+
+	for _, item := range section.Selects.Items {
+		// external selects are not supported, as Mattermost doesn't allow to load options dynamically
+		if item.Type == interactive.ExternalSelect {
+			continue
+		}
+
+		action := &model.PostAction{
+			Type:        model.PostActionTypeSelect,
+			Name:        item.Name,
+			Integration: r.integration(map[string]interface{}{mattermostCtxCommandKey: item.Command}),
+		}
+		for _, group := range item.OptionGroups {
+			for _, opt := range group.Options {
+				action.Options = append(action.Options, &model.PostActionOptions{Text: opt.Name, Value: opt.Value})
+			}
+		}
+		if item.InitialOption != nil {
+			action.DefaultOption = item.InitialOption.Value
+		}
+		attachment.Actions = append(attachment.Actions, action)
+	}
+
+	if section.MultiSelect.AreOptionsDefined() {
+		action, err := r.dialogButton(section.MultiSelect.Name, r.multiSelectDialogSpec(section.MultiSelect, section.Header))
+		if err != nil {
+			return nil, err
+		}
+		attachment.Actions = append(attachment.Actions, action)
+	}
+
+	inputs, err := r.renderInputs(section.PlaintextInputs)
+	if err != nil {
+		return nil, err
+	}
+	attachment.Actions = append(attachment.Actions, inputs...)
+
+	return attachment, nil
+}
+
+func (r *MattermostRenderer) sectionText(section interactive.Section) string {
+	var out strings.Builder
+	addLine := func(in string) {
+		out.WriteString(interactive.NewlineFormatter(in))
+	}
+
+	if section.Description != "" {
+		addLine(section.Description)
+	}
+	if section.Body.Plaintext != "" {
+		addLine(section.Body.Plaintext)
+	}
+	if section.Body.CodeBlock != "" {
+		addLine(formatx.CodeBlock(section.Body.CodeBlock))
+	}
+	if ms := section.MultiSelect; ms.AreOptionsDefined() {
+		if ms.Description.Plaintext != "" {
+			addLine(ms.Description.Plaintext)
+		}
+		if ms.Description.CodeBlock != "" {
+			addLine(formatx.CodeBlock(ms.Description.CodeBlock))
+		}
+	}
+	for _, btn := range section.Buttons {
+		if btn.URL == "" {
+			continue
+		}
+		addLine(fmt.Sprintf("[%s](%s)", btn.Name, btn.URL))
+	}
+
+	return strings.TrimSpace(out.String())
+}
+
+func (r *MattermostRenderer) renderInputs(inputs interactive.LabelInputs) ([]*model.PostAction, error) {
+	var out []*model.PostAction
+	for _, input := range inputs {
+		action, err := r.dialogButton(input.Text, r.inputDialogSpec(input, ""))
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, action)
+	}
+	return out, nil
+}
+
+func (r *MattermostRenderer) dialogButton(name string, spec mattermostDialogSpec) (*model.PostAction, error) {
+	rawSpec, err := json.Marshal(spec)
+	if err != nil {
+		return nil, fmt.Errorf("while marshaling dialog spec: %w", err)
+	}
+
+	return &model.PostAction{
+		Type:        model.PostActionTypeButton,
+		Name:        name,
+		Integration: r.integration(map[string]interface{}{mattermostCtxDialogKey: string(rawSpec)}),
+	}, nil
+}
+
+func (r *MattermostRenderer) integration(ctx map[string]interface{}) *model.PostActionIntegration {
+	ctx[mattermostCtxTokenKey] = r.token
+	return &model.PostActionIntegration{
+		URL:     r.ActionsURL(),
+		Context: ctx,
+	}
+}
+
+func (r *MattermostRenderer) multiSelectDialogSpec(ms interactive.MultiSelect, title string) mattermostDialogSpec {
+	if title == "" {
+		title = ms.Name
+	}
+
+	var initial []string
+	for _, opt := range ms.InitialOptions {
+		initial = append(initial, opt.Value)
+	}
+
+	return mattermostDialogSpec{
+		Kind:    mattermostMultiSelectDialog,
+		Title:   title,
+		Command: ms.Command,
+		Label:   ms.Description.Plaintext,
+		Options: ms.Options,
+		Initial: initial,
+	}
+}
+
+func (r *MattermostRenderer) inputDialogSpec(input interactive.LabelInput, title string) mattermostDialogSpec {
+	if title == "" {
+		title = input.Text
+	}
+
+	return mattermostDialogSpec{
+		Kind:        mattermostInputDialog,
+		Title:       title,
+		Command:     input.Command,
+		Label:       input.Text,
+		Placeholder: input.Placeholder,
+	}
+}
+
+func (r *MattermostRenderer) dialogFor(spec mattermostDialogSpec) (model.Dialog, error) {
+	state, err := json.Marshal(mattermostDialogState{Token: r.token, Spec: spec})
+	if err != nil {
+		return model.Dialog{}, fmt.Errorf("while marshaling dialog state: %w", err)
+	}
+
+	dialog := model.Dialog{
+		CallbackId:  mattermostDialogCallbackID,
+		Title:       spec.Title,
+		SubmitLabel: mattermostDialogSubmitLabel,
+		State:       string(state),
+	}
+
+	switch spec.Kind {
+	case mattermostMultiSelectDialog:
+		dialog.IntroductionText = spec.Label
+		for idx, opt := range spec.Options {
+			dialog.Elements = append(dialog.Elements, model.DialogElement{
+				DisplayName: opt.Name,
+				Name:        mattermostDialogOptionName(idx),
+				Type:        "bool",
+				Default:     fmt.Sprintf("%t", slices.Contains(spec.Initial, opt.Value)),
+				Optional:    true,
+			})
+		}
+	case mattermostInputDialog:
+		dialog.Elements = append(dialog.Elements, model.DialogElement{
+			DisplayName: spec.Label,
+			Name:        mattermostDialogOptionName(0),
+			Type:        "text",
+			Placeholder: spec.Placeholder,
+		})
+	}
+
+	return dialog, nil
+}
+
+// resolveMattermostDialogCommand returns a command for a given dialog submission.
+func resolveMattermostDialogCommand(spec mattermostDialogSpec, submission map[string]interface{}) string {
+	switch spec.Kind {
+	case mattermostMultiSelectDialog:
+		var selected []string
+		for idx, opt := range spec.Options {
+			if checked, ok := submission[mattermostDialogOptionName(idx)].(bool); ok && checked {
+				selected = append(selected, opt.Value)
+			}
+		}
+		return fmt.Sprintf("%s %s", spec.Command, strings.Join(selected, ","))
+	case mattermostInputDialog:
+		value, _ := submission[mattermostDialogOptionName(0)].(string)
+		return fmt.Sprintf("%s%q", spec.Command, strings.TrimSpace(value))
+	}
+
+	return ""
+}
+
+func mattermostDialogOptionName(idx int) string {
+	return fmt.Sprintf("option-%d", idx)
+}
+
+func mattermostButtonStyle(style interactive.ButtonStyle) string {
+	switch style {
+	case interactive.ButtonStylePrimary:
+		return "primary"
+	case interactive.ButtonStyleDanger:
+		return "danger"
+	default:
+		return "default"
+	}
+}
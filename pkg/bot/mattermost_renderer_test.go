@@ -0,0 +1,206 @@
+package bot
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+	logtest "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/pkg/bot/interactive"
+)
+
+func TestMattermostRenderer_RenderPost(t *testing.T) {
+	// given
+	renderer := NewMattermostRenderer("http://botkube:3979/", "secret")
+	msg := interactive.Message{
+		Base: interactive.Base{
+			Header: "Header",
+		},
+		Sections: []interactive.Section{
+			{
+				Base: interactive.Base{
+					Header:      "Section",
+					Description: "Description",
+				},
+				Buttons: interactive.Buttons{
+					{Name: "Run", Command: "@Botkube get pods", Style: interactive.ButtonStylePrimary},
+					{Name: "Docs", URL: "https://docs.botkube.io"},
+				},
+				Selects: interactive.Selects{
+					Items: []interactive.Select{
+						{
+							Name:    "Verbs",
+							Command: "@Botkube kcc --verbs",
+							OptionGroups: []interactive.OptionGroup{
+								{Name: "Verbs", Options: []interactive.OptionItem{{Name: "get", Value: "get"}}},
+							},
+							InitialOption: &interactive.OptionItem{Name: "get", Value: "get"},
+						},
+					},
+				},
+				MultiSelect: interactive.MultiSelect{
+					Name:    "Sources",
+					Command: "@Botkube edit SourceBindings",
+					Options: []interactive.OptionItem{{Name: "Errors", Value: "k8s-err-events"}},
+				},
+			},
+		},
+	}
+
+	// when
+	post, err := renderer.RenderPost("channel-id", msg)
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, "channel-id", post.ChannelId)
+	assert.Equal(t, "**Header**\n", post.Message)
+
+	attachments := post.Attachments()
+	require.Len(t, attachments, 1)
+	assert.Equal(t, "Section", attachments[0].Title)
+	assert.Equal(t, "Description\n[Docs](https://docs.botkube.io)", attachments[0].Text)
+
+	actions := attachments[0].Actions
+	require.Len(t, actions, 3)
+
+	assert.Equal(t, model.PostActionTypeButton, actions[0].Type)
+	assert.Equal(t, "primary", actions[0].Style)
+	assert.Equal(t, "http://botkube:3979/mattermost/actions", actions[0].Integration.URL)
+	assert.Equal(t, map[string]interface{}{"command": "@Botkube get pods", "token": "secret"}, actions[0].Integration.Context)
+
+	assert.Equal(t, model.PostActionTypeSelect, actions[1].Type)
+	assert.Equal(t, "get", actions[1].DefaultOption)
+	assert.Equal(t, []*model.PostActionOptions{{Text: "get", Value: "get"}}, actions[1].Options)
+
+	assert.Equal(t, model.PostActionTypeButton, actions[2].Type)
+	assert.Contains(t, actions[2].Integration.Context, mattermostCtxDialogKey)
+}
+
+func TestMattermostRenderer_MultiSelectDialog(t *testing.T) {
+	// given
+	renderer := NewMattermostRenderer("http://botkube:3979", "secret")
+	msg := interactive.Message{
+		Type: interactive.Popup,
+		Base: interactive.Base{Header: "Adjust notifications"},
+		Sections: []interactive.Section{
+			{
+				MultiSelect: interactive.MultiSelect{
+					Name:    "Adjust notifications",
+					Command: "@Botkube edit SourceBindings",
+					Options: []interactive.OptionItem{
+						{Name: "Errors", Value: "k8s-err-events"},
+						{Name: "Create", Value: "k8s-create-events"},
+						{Name: "Delete", Value: "k8s-delete-events"},
+					},
+					InitialOptions: []interactive.OptionItem{{Name: "Errors", Value: "k8s-err-events"}},
+				},
+			},
+		},
+	}
+
+	// when
+	dialog, found, err := renderer.RenderDialog(msg)
+
+	// then
+	require.NoError(t, err)
+	require.True(t, found)
+	assert.Equal(t, "Adjust notifications", dialog.Title)
+	require.Len(t, dialog.Elements, 3)
+	assert.Equal(t, "true", dialog.Elements[0].Default)
+	assert.Equal(t, "false", dialog.Elements[1].Default)
+
+	// when
+	var state mattermostDialogState
+	require.NoError(t, json.Unmarshal([]byte(dialog.State), &state))
+	cmd := resolveMattermostDialogCommand(state.Spec, map[string]interface{}{
+		dialog.Elements[0].Name: true,
+		dialog.Elements[1].Name: false,
+		dialog.Elements[2].Name: true,
+	})
+
+	// then
+	assert.Equal(t, "secret", state.Token)
+	assert.Equal(t, "@Botkube edit SourceBindings k8s-err-events,k8s-delete-events", cmd)
+}
+
+func TestResolveMattermostDialogCommand_Input(t *testing.T) {
+	// given
+	spec := mattermostDialogSpec{
+		Kind:    mattermostInputDialog,
+		Command: "@Botkube kcc --filter-query ",
+	}
+
+	// when
+	cmd := resolveMattermostDialogCommand(spec, map[string]interface{}{
+		mattermostDialogOptionName(0): " nginx ",
+	})
+
+	// then
+	assert.Equal(t, `@Botkube kcc --filter-query "nginx"`, cmd)
+}
+
+func TestMattermost_HandleActionWithInvalidToken(t *testing.T) {
+	// given
+	logger, _ := logtest.NewNullLogger()
+	bot := &Mattermost{
+		log:                logger,
+		renderer:           NewMattermostRenderer("http://botkube:3979", "secret"),
+		interactivityToken: "secret",
+	}
+	router := bot.interactivityRouter(context.Background())
+
+	body, err := json.Marshal(model.PostActionIntegrationRequest{
+		ChannelId: "channel-id",
+		Context: map[string]interface{}{
+			mattermostCtxTokenKey:   "forged",
+			mattermostCtxCommandKey: "@Botkube get pods",
+		},
+	})
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodPost, mattermostActionsPath, bytes.NewReader(body))
+	rec := httptest.NewRecorder()
+
+	// when
+	router.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
+
+func TestMattermostInteractivityToken(t *testing.T) {
+	// when
+	token := mattermostInteractivityToken("bot-token")
+
+	// then
+	assert.Equal(t, token, mattermostInteractivityToken("bot-token"))
+	assert.NotEqual(t, token, mattermostInteractivityToken("other-bot-token"))
+	assert.NotContains(t, token, "bot-token")
+}
+
+func TestMattermost_RunInteractiveCommand(t *testing.T) {
+	// given
+	logger, _ := logtest.NewNullLogger()
+	bot := &Mattermost{log: logger}
+
+	var handled []string
+	bot.runInteractiveCommand(func() {
+		handled = append(handled, "before stop")
+	})
+
+	// when
+	bot.waitForInteractiveCommands()
+	bot.runInteractiveCommand(func() {
+		handled = append(handled, "after stop")
+	})
+
+	// then
+	assert.Equal(t, []string{"before stop"}, handled)
+}
@@ -0,0 +1,92 @@
+package bot
+
+import (
+	"context"
+
+	"github.com/kubeshop/botkube/pkg/bot/interactive"
+	"github.com/kubeshop/botkube/pkg/execute"
+)
+
+// MessageHandler handles a command received by a bot and returns the response.
+type MessageHandler func(ctx context.Context, in execute.NewDefaultInput) interactive.Message
+
+// Middleware wraps a MessageHandler to add a cross-cutting functionality, such as rate limiting or audit logging.
+// It may return a response without calling the next handler to stop the command execution.
+type Middleware func(next MessageHandler) MessageHandler
+
+// chunkHandlerCtxKey is the context key of the handler of the streamed output chunks.
+type chunkHandlerCtxKey struct{}
+
+// outputChunkHandler returns the handler of the streamed output chunks. It's nil if the command output is not streamed.
+// Middlewares can use it to show the command progress before the command is executed.
+func outputChunkHandler(ctx context.Context) execute.OutputChunkHandler {
+	handleChunk, _ := ctx.Value(chunkHandlerCtxKey{}).(execute.OutputChunkHandler)
+	return handleChunk
+}
+
+// ChainMiddlewares returns a MessageHandler which passes the incoming messages through all middlewares before calling a given handler.
+// The first middleware is the outermost one.
+func ChainMiddlewares(handler MessageHandler, middlewares ...Middleware) MessageHandler {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		handler = middlewares[i](handler)
+	}
+	return handler
+}
+
+var _ ExecutorFactory = &MiddlewareExecutorFactory{}
+
+// MiddlewareExecutorFactory decorates ExecutorFactory to apply middlewares to all commands received by bots.
+// As all bots create executors with the factory, middlewares don't need to be wired in each platform integration.
+type MiddlewareExecutorFactory struct {
+	factory     ExecutorFactory
+	middlewares []Middleware
+}
+
+// NewMiddlewareExecutorFactory returns a new MiddlewareExecutorFactory instance.
+func NewMiddlewareExecutorFactory(factory ExecutorFactory, middlewares ...Middleware) *MiddlewareExecutorFactory {
+	return &MiddlewareExecutorFactory{
+		factory:     factory,
+		middlewares: middlewares,
+	}
+}
+
+// NewDefault returns an executor which runs the middlewares before the actual executor is created.
+func (f *MiddlewareExecutorFactory) NewDefault(cfg execute.NewDefaultInput) execute.Executor {
+	return &middlewareExecutor{
+		factory:     f.factory,
+		middlewares: f.middlewares,
+		input:       cfg,
+	}
+}
+
+// middlewareExecutor creates the actual executor only if all middlewares pass the message,
+// so the middlewares can also modify the executor input.
+type middlewareExecutor struct {
+	factory     ExecutorFactory
+	middlewares []Middleware
+	input       execute.NewDefaultInput
+}
+
+// Execute executes the command after passing it through all middlewares.
+func (e *middlewareExecutor) Execute(ctx context.Context) interactive.Message {
+	handler := ChainMiddlewares(func(ctx context.Context, in execute.NewDefaultInput) interactive.Message {
+		return e.factory.NewDefault(in).Execute(ctx)
+	}, e.middlewares...)
+
+	return handler(ctx, e.input)
+}
+
+// ExecuteStream executes the command after passing it through all middlewares.
+// The output is streamed only if the actual executor supports it.
+func (e *middlewareExecutor) ExecuteStream(ctx context.Context, handleChunk execute.OutputChunkHandler) interactive.Message {
+	handler := ChainMiddlewares(func(ctx context.Context, in execute.NewDefaultInput) interactive.Message {
+		executor := e.factory.NewDefault(in)
+		streamExecutor, ok := executor.(execute.StreamingExecutor)
+		if !ok {
+			return executor.Execute(ctx)
+		}
+		return streamExecutor.ExecuteStream(ctx, handleChunk)
+	}, e.middlewares...)
+
+	return handler(context.WithValue(ctx, chunkHandlerCtxKey{}, handleChunk), e.input)
+}
@@ -0,0 +1,36 @@
+package bot
+
+import (
+	"context"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/kubeshop/botkube/pkg/bot/interactive"
+	"github.com/kubeshop/botkube/pkg/execute"
+)
+
+// NewAuditMiddleware returns a middleware which logs all commands received by bots together with the caller details.
+func NewAuditMiddleware(log logrus.FieldLogger) Middleware {
+	return func(next MessageHandler) MessageHandler {
+		return func(ctx context.Context, in execute.NewDefaultInput) interactive.Message {
+			start := time.Now()
+			resp := next(ctx, in)
+
+			log.WithFields(logrus.Fields{
+				"commGroup":       in.CommGroupName,
+				"platform":        in.Platform,
+				"conversationID":  in.Conversation.ID,
+				"channelAlias":    in.Conversation.Alias,
+				"isAuthenticated": in.Conversation.IsAuthenticated,
+				"origin":          in.Conversation.CommandOrigin,
+				"user":            in.User,
+				"k8sUsername":     in.Identity.Username,
+				"command":         in.Message,
+				"duration":        time.Since(start).String(),
+			}).Info("Command executed")
+
+			return resp
+		}
+	}
+}
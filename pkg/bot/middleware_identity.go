@@ -0,0 +1,30 @@
+package bot
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/kubeshop/botkube/pkg/bot/identity"
+	"github.com/kubeshop/botkube/pkg/bot/interactive"
+	"github.com/kubeshop/botkube/pkg/execute"
+)
+
+// NewIdentityMiddleware returns a middleware which resolves the Kubernetes identity of the user who sent the command.
+// If the identity cannot be resolved, the command is executed without it.
+func NewIdentityMiddleware(log logrus.FieldLogger, resolver *identity.Resolver) Middleware {
+	return func(next MessageHandler) MessageHandler {
+		return func(ctx context.Context, in execute.NewDefaultInput) interactive.Message {
+			userIdentity, err := resolver.Resolve(ctx, in.Platform, in.UserID)
+			if err != nil {
+				log.WithFields(logrus.Fields{
+					"platform": in.Platform,
+					"userID":   in.UserID,
+				}).Warnf("while resolving Kubernetes identity: %s", err.Error())
+			}
+
+			in.Identity = userIdentity
+			return next(ctx, in)
+		}
+	}
+}
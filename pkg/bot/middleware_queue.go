@@ -0,0 +1,74 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/kubeshop/botkube/pkg/bot/interactive"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/execute"
+	"github.com/kubeshop/botkube/pkg/utils"
+)
+
+const (
+	queueFullMsgFmt    = "Sorry, too many commands are waiting on cluster '%s'. Please try again later."
+	queueTimeoutMsgFmt = "Sorry, the command waited in the queue for longer than %s on cluster '%s'. Please try again later."
+	queuedMsgFmt       = "Waiting in the queue at position %d…\n"
+
+	queueStatusCommand = "queue"
+)
+
+// NewQueueMiddleware returns a middleware which limits the number of commands executed concurrently with a given queue.
+// If the command output is streamed, the user is notified about the queue position while the command waits.
+// The `queue` command and the commands targeting other clusters are not queued, as they are not executed on this cluster.
+func NewQueueMiddleware(log logrus.FieldLogger, cfg config.Config, queue *execute.CommandQueue) Middleware {
+	clusterName := cfg.Settings.ClusterName
+	timeout := cfg.Settings.Middlewares.Queue.Timeout
+
+	return func(next MessageHandler) MessageHandler {
+		return func(ctx context.Context, in execute.NewDefaultInput) interactive.Message {
+			args := strings.Fields(in.Message)
+			if len(args) == 0 || args[0] == queueStatusCommand {
+				return next(ctx, in)
+			}
+			if inClusterName := utils.GetClusterNameFromKubectlCmd(in.Message); inClusterName != "" && inClusterName != clusterName {
+				return next(ctx, in)
+			}
+
+			channel := execute.CommandQueueChannel(in.CommGroupName, in.Platform, in.Conversation.ID)
+			release, err := queue.Acquire(ctx, channel, in.User, in.Message, func(position int) {
+				log.WithField("channel", channel).Debugf("Command %q is waiting in the queue at position %d", in.Message, position)
+				if handleChunk := outputChunkHandler(ctx); handleChunk != nil {
+					handleChunk(fmt.Sprintf(queuedMsgFmt, position))
+				}
+			})
+			switch {
+			case err == nil:
+			case errors.Is(err, execute.ErrCommandQueueFull):
+				log.WithField("channel", channel).Infof("Rejecting command %q as the queue is full", in.Message)
+				return queueFailureMessage(fmt.Sprintf(queueFullMsgFmt, clusterName))
+			case errors.Is(err, execute.ErrCommandQueueTimeout):
+				log.WithField("channel", channel).Infof("Rejecting command %q as it waited in the queue too long", in.Message)
+				return queueFailureMessage(fmt.Sprintf(queueTimeoutMsgFmt, timeout, clusterName))
+			default:
+				log.WithField("channel", channel).Debugf("Command %q was cancelled while waiting in the queue: %s", in.Message, err.Error())
+				return interactive.Message{}
+			}
+			defer release()
+
+			return next(ctx, in)
+		}
+	}
+}
+
+func queueFailureMessage(msg string) interactive.Message {
+	return interactive.Message{
+		Base: interactive.Base{
+			Description: msg,
+		},
+	}
+}
@@ -0,0 +1,97 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/kubeshop/botkube/pkg/bot/interactive"
+	"github.com/kubeshop/botkube/pkg/execute"
+)
+
+const rateLimitExceededMsgFmt = "Rate limit exceeded. You can execute up to %d commands per %s. Please try again later."
+
+type rateLimitWindow struct {
+	start time.Time
+	count int
+}
+
+// rateLimiter limits the number of commands within a fixed time window.
+type rateLimiter struct {
+	mu          sync.Mutex
+	maxCommands int
+	interval    time.Duration
+	now         func() time.Time
+	windows     map[string]rateLimitWindow
+}
+
+// NewRateLimitMiddleware returns a middleware which limits the number of commands a given user can execute within a given interval.
+// If a bot doesn't provide the user details, the limit applies to the whole conversation.
+func NewRateLimitMiddleware(log logrus.FieldLogger, maxCommands int, interval time.Duration) Middleware {
+	limiter := &rateLimiter{
+		maxCommands: maxCommands,
+		interval:    interval,
+		now:         time.Now,
+		windows:     map[string]rateLimitWindow{},
+	}
+
+	return limiter.middleware(log)
+}
+
+func (r *rateLimiter) middleware(log logrus.FieldLogger) Middleware {
+	return func(next MessageHandler) MessageHandler {
+		return func(ctx context.Context, in execute.NewDefaultInput) interactive.Message {
+			key := rateLimitKey(in)
+			if !r.Allow(key) {
+				log.WithField("key", key).Infof("Rejecting command %q as the rate limit is exceeded", in.Message)
+				return interactive.Message{
+					Base: interactive.Base{
+						Description: fmt.Sprintf(rateLimitExceededMsgFmt, r.maxCommands, r.interval),
+					},
+				}
+			}
+
+			return next(ctx, in)
+		}
+	}
+}
+
+// Allow returns true if a given key didn't exceed the limit in the current window.
+func (r *rateLimiter) Allow(key string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := r.now()
+	r.pruneExpired(now)
+
+	window, found := r.windows[key]
+	if !found {
+		window = rateLimitWindow{start: now}
+	}
+
+	if window.count >= r.maxCommands {
+		return false
+	}
+
+	window.count++
+	r.windows[key] = window
+	return true
+}
+
+func (r *rateLimiter) pruneExpired(now time.Time) {
+	for key, window := range r.windows {
+		if now.Sub(window.start) >= r.interval {
+			delete(r.windows, key)
+		}
+	}
+}
+
+func rateLimitKey(in execute.NewDefaultInput) string {
+	if in.User != "" {
+		return fmt.Sprintf("%s/%s/user/%s", in.CommGroupName, in.Platform, in.User)
+	}
+	return fmt.Sprintf("%s/%s/conversation/%s", in.CommGroupName, in.Platform, in.Conversation.ID)
+}
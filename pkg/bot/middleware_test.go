@@ -0,0 +1,178 @@
+package bot
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	logtest "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/pkg/bot/identity"
+	"github.com/kubeshop/botkube/pkg/bot/interactive"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/execute"
+)
+
+func TestMiddlewareExecutorFactory(t *testing.T) {
+	// given
+	var calls []string
+	recordingMiddleware := func(name string) Middleware {
+		return func(next MessageHandler) MessageHandler {
+			return func(ctx context.Context, in execute.NewDefaultInput) interactive.Message {
+				calls = append(calls, name)
+				in.Message = in.Message + " " + name
+				return next(ctx, in)
+			}
+		}
+	}
+
+	inner := &fakeExecutorFactory{response: interactive.Message{Base: interactive.Base{Description: "done"}}}
+	factory := NewMiddlewareExecutorFactory(inner, recordingMiddleware("first"), recordingMiddleware("second"))
+
+	// when
+	resp := factory.NewDefault(execute.NewDefaultInput{Message: "get pods"}).Execute(context.Background())
+
+	// then
+	assert.Equal(t, "done", resp.Description)
+	assert.Equal(t, []string{"first", "second"}, calls)
+	require.Len(t, inner.gotInputs, 1)
+	assert.Equal(t, "get pods first second", inner.gotInputs[0].Message)
+}
+
+func TestMiddlewareExecutorFactory_StopsExecution(t *testing.T) {
+	// given
+	rejectMiddleware := func(next MessageHandler) MessageHandler {
+		return func(ctx context.Context, in execute.NewDefaultInput) interactive.Message {
+			return interactive.Message{Base: interactive.Base{Description: "rejected"}}
+		}
+	}
+
+	inner := &fakeExecutorFactory{}
+	factory := NewMiddlewareExecutorFactory(inner, rejectMiddleware)
+
+	// when
+	resp := factory.NewDefault(execute.NewDefaultInput{Message: "get pods"}).Execute(context.Background())
+
+	// then
+	assert.Equal(t, "rejected", resp.Description)
+	assert.Empty(t, inner.gotInputs)
+}
+
+func TestRateLimiter(t *testing.T) {
+	// given
+	now := time.Date(2022, 10, 1, 12, 0, 0, 0, time.UTC)
+	limiter := &rateLimiter{
+		maxCommands: 2,
+		interval:    time.Minute,
+		now:         func() time.Time { return now },
+		windows:     map[string]rateLimitWindow{},
+	}
+	logger, _ := logtest.NewNullLogger()
+	inner := &fakeExecutorFactory{response: interactive.Message{Base: interactive.Base{Description: "done"}}}
+	factory := NewMiddlewareExecutorFactory(inner, limiter.middleware(logger))
+
+	execFor := func(user string) string {
+		return factory.NewDefault(execute.NewDefaultInput{
+			Platform:     config.SocketSlackCommPlatformIntegration,
+			Conversation: execute.Conversation{ID: "C01"},
+			User:         user,
+			Message:      "get pods",
+		}).Execute(context.Background()).Description
+	}
+	expectedRejection := "Rate limit exceeded. You can execute up to 2 commands per 1m0s. Please try again later."
+
+	// when
+	assert.Equal(t, "done", execFor("alice"))
+	assert.Equal(t, "done", execFor("alice"))
+	assert.Equal(t, expectedRejection, execFor("alice"))
+	assert.Equal(t, "done", execFor("bob"))
+
+	now = now.Add(time.Minute)
+
+	// then
+	assert.Equal(t, "done", execFor("alice"))
+	assert.Len(t, inner.gotInputs, 4)
+}
+
+func TestIdentityMiddleware(t *testing.T) {
+	// given
+	logger, _ := logtest.NewNullLogger()
+	resolver := identity.NewResolver(logger, config.IdentityMapping{
+		Enabled: true,
+		Users: []config.UserIdentityMapping{
+			{Platform: config.SocketSlackCommPlatformIntegration, UserID: "U01", Username: "alice", Groups: []string{"devs"}},
+		},
+	})
+
+	inner := &fakeExecutorFactory{response: interactive.Message{Base: interactive.Base{Description: "done"}}}
+	factory := NewMiddlewareExecutorFactory(inner, NewIdentityMiddleware(logger, resolver))
+
+	// when
+	factory.NewDefault(execute.NewDefaultInput{Platform: config.SocketSlackCommPlatformIntegration, UserID: "U01"}).Execute(context.Background())
+	factory.NewDefault(execute.NewDefaultInput{Platform: config.SocketSlackCommPlatformIntegration, UserID: "U02"}).Execute(context.Background())
+
+	// then
+	require.Len(t, inner.gotInputs, 2)
+	assert.Equal(t, identity.Identity{Username: "alice", Groups: []string{"devs"}}, inner.gotInputs[0].Identity)
+	assert.True(t, inner.gotInputs[1].Identity.IsEmpty())
+}
+
+func TestQueueMiddleware(t *testing.T) {
+	// given
+	cfg := config.Config{Settings: config.Settings{
+		ClusterName: "dev",
+		Middlewares: config.BotMiddlewares{Queue: config.QueueMiddleware{
+			Enabled:       true,
+			MaxConcurrent: 1,
+			MaxQueued:     1,
+			Timeout:       time.Minute,
+		}},
+	}}
+	logger, _ := logtest.NewNullLogger()
+	middleware := NewQueueMiddleware(logger, cfg, execute.NewCommandQueue(cfg.Settings.Middlewares.Queue))
+
+	unblock := make(chan struct{})
+	started := make(chan string, 10)
+	handler := ChainMiddlewares(func(_ context.Context, in execute.NewDefaultInput) interactive.Message {
+		started <- in.Message
+		if in.Message == "get pods" {
+			<-unblock
+		}
+		return interactive.Message{Base: interactive.Base{Description: "done: " + in.Message}}
+	}, middleware)
+
+	execFor := func(ctx context.Context, msg string) interactive.Message {
+		return handler(ctx, execute.NewDefaultInput{CommGroupName: "default", Conversation: execute.Conversation{ID: "C01"}, Message: msg})
+	}
+
+	// when
+	first := make(chan interactive.Message, 1)
+	go func() {
+		first <- execFor(context.Background(), "get pods")
+	}()
+	assert.Equal(t, "get pods", <-started)
+
+	chunks := make(chan string, 1)
+	streamCtx := context.WithValue(context.Background(), chunkHandlerCtxKey{}, execute.OutputChunkHandler(func(chunk string) {
+		chunks <- chunk
+	}))
+	second := make(chan interactive.Message, 1)
+	go func() {
+		second <- execFor(streamCtx, "get deploy")
+	}()
+
+	// then
+	assert.Equal(t, "Waiting in the queue at position 1…\n", <-chunks)
+	assert.Equal(t, "Sorry, too many commands are waiting on cluster 'dev'. Please try again later.", execFor(context.Background(), "get svc").Description)
+	assert.Equal(t, "done: queue", execFor(context.Background(), "queue").Description, "queue status must not wait in the queue")
+	assert.Equal(t, "done: get svc --cluster-name prod", execFor(context.Background(), "get svc --cluster-name prod").Description, "commands for other clusters must not wait in the queue")
+
+	// when
+	close(unblock)
+
+	// then
+	assert.Equal(t, "done: get pods", (<-first).Description)
+	assert.Equal(t, "done: get deploy", (<-second).Description)
+}
@@ -0,0 +1,30 @@
+package bot
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/kubeshop/botkube/pkg/bot/identity"
+	"github.com/kubeshop/botkube/pkg/bot/interactive"
+	"github.com/kubeshop/botkube/pkg/execute"
+)
+
+// NewUserGroupsMiddleware returns a middleware which resolves the chat platform user groups of the user who sent the command.
+// The groups are used to match the executor RBAC rules. If they cannot be resolved, the command is executed without them.
+func NewUserGroupsMiddleware(log logrus.FieldLogger, resolver *identity.UserGroupResolver) Middleware {
+	return func(next MessageHandler) MessageHandler {
+		return func(ctx context.Context, in execute.NewDefaultInput) interactive.Message {
+			groups, err := resolver.Resolve(ctx, in.Platform, in.UserID)
+			if err != nil {
+				log.WithFields(logrus.Fields{
+					"platform": in.Platform,
+					"userID":   in.UserID,
+				}).Warnf("while resolving user groups: %s", err.Error())
+			}
+
+			in.UserGroups = groups
+			return next(ctx, in)
+		}
+	}
+}
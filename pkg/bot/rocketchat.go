@@ -0,0 +1,456 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/kubeshop/botkube/pkg/bot/interactive"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/digest"
+	"github.com/kubeshop/botkube/pkg/events"
+	"github.com/kubeshop/botkube/pkg/execute"
+	"github.com/kubeshop/botkube/pkg/execute/command"
+	"github.com/kubeshop/botkube/pkg/multierror"
+	"github.com/kubeshop/botkube/pkg/mute"
+	"github.com/kubeshop/botkube/pkg/sliceutil"
+)
+
+var _ Bot = &RocketChat{}
+
+const (
+	// rocketChatMaxMessageSize max size before a message should be uploaded as a file.
+	rocketChatMaxMessageSize = 5000
+
+	rocketChatBotMentionRegexFmt = "^@(?i)%s"
+
+	// rocketChatReconnectInterval is the delay between reconnection attempts to the Realtime API.
+	rocketChatReconnectInterval = 5 * time.Second
+)
+
+// RocketChat listens for user's message, execute commands and sends back the response.
+type RocketChat struct {
+	log             logrus.FieldLogger
+	executorFactory ExecutorFactory
+	reporter        AnalyticsReporter
+	notification    config.Notification
+	client          *rocketChatClient
+	botName         string
+	botUserID       string
+	commGroupName   string
+	channelsMutex   sync.RWMutex
+	channels        map[string]channelConfigByID
+	notifyMutex     sync.Mutex
+	botMentionRegex *regexp.Regexp
+	mdFormatter     interactive.MDFormatter
+	digest          *digest.Scheduler
+	mutes           *mute.Registry
+}
+
+// NewRocketChat creates a new RocketChat instance.
+func NewRocketChat(ctx context.Context, log logrus.FieldLogger, commGroupName string, cfg config.RocketChat, executorFactory ExecutorFactory, reporter AnalyticsReporter) (*RocketChat, error) {
+	botMentionRegex, err := rocketChatBotMentionRegex(cfg.BotName)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := newRocketChatClient(cfg.URL, cfg.UserID, cfg.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	channels, err := rocketChatChannelsCfgFrom(ctx, client, cfg.Channels)
+	if err != nil {
+		return nil, fmt.Errorf("while producing channels configuration map by ID: %w", err)
+	}
+
+	return &RocketChat{
+		log:             log,
+		executorFactory: executorFactory,
+		reporter:        reporter,
+		notification:    cfg.Notification,
+		client:          client,
+		botName:         cfg.BotName,
+		botUserID:       cfg.UserID,
+		commGroupName:   commGroupName,
+		channels:        channels,
+		botMentionRegex: botMentionRegex,
+		mdFormatter:     interactive.DefaultMDFormatter(),
+		digest:          digest.NewScheduler(log),
+		mutes:           mute.NewRegistry(),
+	}, nil
+}
+
+// Start establishes the Rocket.Chat Realtime API connection and listens for messages.
+func (b *RocketChat) Start(ctx context.Context) error {
+	b.log.Info("Starting bot")
+
+	if _, err := b.client.Me(ctx); err != nil {
+		return fmt.Errorf("while checking Rocket.Chat credentials: %w", err)
+	}
+
+	err := b.reporter.ReportBotEnabled(b.IntegrationName())
+	if err != nil {
+		return fmt.Errorf("while reporting analytics: %w", err)
+	}
+
+	b.log.Info("Botkube connected to Rocket.Chat!")
+
+	go b.digest.Run(ctx, func(ctx context.Context, roomID string, msg interactive.Message) error {
+		return b.send(ctx, roomID, "", msg)
+	})
+
+	// The Realtime API connection may be closed by the server or proxies, so we reconnect until the context is canceled.
+	for {
+		err := b.listen(ctx)
+		if err != nil {
+			b.log.Errorf("while listening for Rocket.Chat messages: %s. Reconnecting...", err.Error())
+		}
+
+		select {
+		case <-ctx.Done():
+			b.log.Info("Shutdown requested. Finishing...")
+			return nil
+		case <-time.After(rocketChatReconnectInterval):
+		}
+	}
+}
+
+func (b *RocketChat) listen(ctx context.Context) error {
+	rt, err := b.client.DialRealtime(ctx)
+	if err != nil {
+		return fmt.Errorf("while connecting to Realtime API: %w", err)
+	}
+	defer func() {
+		if err := rt.Close(); err != nil {
+			b.log.Debugf("while closing Realtime API connection: %s", err.Error())
+		}
+	}()
+
+	for roomID := range b.getChannels() {
+		if err := rt.SubscribeRoomMessages(roomID); err != nil {
+			return fmt.Errorf("while subscribing to room %q: %w", roomID, err)
+		}
+	}
+
+	return rt.Listen(ctx, func(msg rocketChatMessage) {
+		if err := b.handleMessage(ctx, msg); err != nil {
+			b.log.Errorf("Message handling error: %s", err.Error())
+		}
+	})
+}
+
+// IntegrationName describes the notifier integration name.
+func (b *RocketChat) IntegrationName() config.CommPlatformIntegration {
+	return config.RocketChatCommPlatformIntegration
+}
+
+// Type describes the notifier type.
+func (b *RocketChat) Type() config.IntegrationType {
+	return config.BotIntegrationType
+}
+
+// NotificationsEnabled returns current notification status for a given channel ID.
+func (b *RocketChat) NotificationsEnabled(channelID string) bool {
+	channel, exists := b.getChannels()[channelID]
+	if !exists {
+		return false
+	}
+
+	return channel.notificationsEnabled()
+}
+
+// SetNotificationsEnabled sets a new notification status for a given channel ID.
+func (b *RocketChat) SetNotificationsEnabled(channelID string, enabled bool) error {
+	// avoid race conditions with using the setter concurrently, as we set whole map
+	b.notifyMutex.Lock()
+	defer b.notifyMutex.Unlock()
+
+	channels := b.getChannels()
+	channel, exists := channels[channelID]
+	if !exists {
+		return execute.ErrNotificationsNotConfigured
+	}
+
+	channel.notify = enabled
+	channel.Notification.SnoozedUntil = time.Time{}
+	channels[channelID] = channel
+	b.setChannels(channels)
+
+	return nil
+}
+
+// SnoozeNotifications disables notifications for a given channel until a given time.
+func (b *RocketChat) SnoozeNotifications(channelID string, until time.Time) error {
+	// avoid race conditions with using the setter concurrently, as we set whole map
+	b.notifyMutex.Lock()
+	defer b.notifyMutex.Unlock()
+
+	channels := b.getChannels()
+	channel, exists := channels[channelID]
+	if !exists {
+		return execute.ErrNotificationsNotConfigured
+	}
+
+	channel.notify = true
+	channel.Notification.SnoozedUntil = until
+	channels[channelID] = channel
+	b.setChannels(channels)
+
+	return nil
+}
+
+// NotificationsSnoozedUntil returns the snooze end time for a given channel.
+func (b *RocketChat) NotificationsSnoozedUntil(channelID string) time.Time {
+	return b.getChannels()[channelID].Notification.SnoozedUntil
+}
+
+// MuteNotifications suppresses notifications matching a given rule for a given channel.
+func (b *RocketChat) MuteNotifications(channelID string, rule mute.Rule) error {
+	if _, exists := b.getChannels()[channelID]; !exists {
+		return execute.ErrNotificationsNotConfigured
+	}
+
+	b.mutes.Add(channelID, rule)
+	return nil
+}
+
+// UnmuteNotifications removes the mute rule for the same target as a given rule for a given channel.
+func (b *RocketChat) UnmuteNotifications(channelID string, rule mute.Rule) bool {
+	return b.mutes.Remove(channelID, rule)
+}
+
+// NotificationMutes returns active mute rules for a given channel.
+func (b *RocketChat) NotificationMutes(channelID string) []mute.Rule {
+	return b.mutes.List(channelID)
+}
+
+// BotName returns the Bot name.
+func (b *RocketChat) BotName() string {
+	return fmt.Sprintf("@%s", b.botName)
+}
+
+// SendEvent sends event notification to Rocket.Chat.
+func (b *RocketChat) SendEvent(ctx context.Context, event events.Event, eventSources []string) error {
+	b.log.Debugf("Sending to Rocket.Chat: %+v", event)
+	attachment := b.formatAttachment(event)
+
+	errs := multierror.New()
+	for _, roomID := range b.getChannelsToNotifyForEvent(event, eventSources) {
+		err := b.client.PostMessage(ctx, rocketChatPostMessage{
+			RoomID:      roomID,
+			Attachments: []rocketChatAttachment{attachment},
+		})
+		if err != nil {
+			errs = multierror.Append(errs, fmt.Errorf("while posting message to channel %q: %w", roomID, err))
+			continue
+		}
+
+		b.log.Debugf("Event successfully sent to channel %q", roomID)
+	}
+
+	return errs.ErrorOrNil()
+}
+
+// SendGenericMessage sends message to selected Rocket.Chat channels.
+func (b *RocketChat) SendGenericMessage(ctx context.Context, genericMsg interactive.GenericMessage, sourceBindings []string) error {
+	msg := genericMsg.ForBot(b.BotName())
+
+	errs := multierror.New()
+	for _, roomID := range b.getChannelsToNotify(sourceBindings) {
+		b.log.Debugf("Sending message to channel %q: %+v", roomID, msg)
+		err := b.send(ctx, roomID, "", msg)
+		if err != nil {
+			errs = multierror.Append(errs, fmt.Errorf("while sending Rocket.Chat message to channel %q: %w", roomID, err))
+			continue
+		}
+		b.log.Debugf("Message successfully sent to channel %q", roomID)
+	}
+
+	return errs.ErrorOrNil()
+}
+
+// SendMessageToAll sends message to all Rocket.Chat channels.
+func (b *RocketChat) SendMessageToAll(ctx context.Context, msg interactive.Message) error {
+	errs := multierror.New()
+	for _, channel := range b.getChannels() {
+		roomID := channel.ID
+		b.log.Debugf("Sending message to channel %q: %+v", roomID, msg)
+		if err := b.send(ctx, roomID, "", msg); err != nil {
+			errs = multierror.Append(errs, fmt.Errorf("while sending Rocket.Chat message to channel %q: %w", roomID, err))
+			continue
+		}
+		b.log.Debugf("Message successfully sent to channel %q", roomID)
+	}
+
+	return errs.ErrorOrNil()
+}
+
+func (b *RocketChat) handleMessage(ctx context.Context, msg rocketChatMessage) error {
+	// Skip messages posted by Botkube, system messages and edits
+	if msg.User.ID == b.botUserID || msg.Type != "" || len(msg.EditedAt) > 0 {
+		return nil
+	}
+
+	// Handle message only if starts with mention
+	req, found := b.findAndTrimBotMention(msg.Msg)
+	if !found {
+		b.log.Debugf("Ignoring message as it doesn't contain %q mention", b.botName)
+		return nil
+	}
+	b.log.Debugf("Rocket.Chat incoming Request: %s", req)
+
+	channel, isAuthChannel := b.getChannels()[msg.RoomID]
+	if !execute.IsCommandPermitted(channel.Commands, req) {
+		b.log.Debugf("Command %q is not permitted in channel %q", req, channel.Identifier())
+		if err := b.send(ctx, msg.RoomID, msg.ThreadID, execute.CommandNotPermittedMessage(req)); err != nil {
+			return fmt.Errorf("while sending message: %w", err)
+		}
+		return nil
+	}
+
+	e := b.executorFactory.NewDefault(execute.NewDefaultInput{
+		CommGroupName:   b.commGroupName,
+		Platform:        b.IntegrationName(),
+		NotifierHandler: b,
+		Conversation: execute.Conversation{
+			Alias:            channel.alias,
+			ID:               channel.Identifier(),
+			ExecutorBindings: channel.Bindings.Executors,
+			Commands:         channel.Commands,
+			Locale:           channel.Locale,
+			IsAuthenticated:  isAuthChannel,
+			CommandOrigin:    command.TypedOrigin,
+		},
+		Message: req,
+		User:    fmt.Sprintf("@%s", msg.User.Username),
+		UserID:  msg.User.ID,
+	})
+	response := e.Execute(ctx)
+
+	// reply in thread if the command was sent in a thread
+	if err := b.send(ctx, msg.RoomID, msg.ThreadID, response); err != nil {
+		return fmt.Errorf("while sending message: %w", err)
+	}
+
+	return nil
+}
+
+func (b *RocketChat) send(ctx context.Context, roomID, threadID string, resp interactive.Message) error {
+	b.log.Debugf("Rocket.Chat Response: %s", resp)
+
+	markdown := interactive.RenderMessage(b.mdFormatter, resp)
+	if len(markdown) == 0 {
+		return errors.New("while reading Rocket.Chat response: empty response")
+	}
+
+	// Upload message as a file if too long
+	if len(markdown) >= rocketChatMaxMessageSize {
+		content := []byte(interactive.MessageToPlaintext(resp, interactive.NewlineFormatter))
+		if err := b.client.UploadFile(ctx, roomID, threadID, responseFileName, resp.Description, content); err != nil {
+			return fmt.Errorf("while uploading file: %w", err)
+		}
+		return nil
+	}
+
+	err := b.client.PostMessage(ctx, rocketChatPostMessage{
+		RoomID:   roomID,
+		ThreadID: threadID,
+		Text:     markdown,
+	})
+	if err != nil {
+		return fmt.Errorf("while posting message: %w", err)
+	}
+	return nil
+}
+
+func (b *RocketChat) getChannelsToNotifyForEvent(event events.Event, sourceBindings []string) []string {
+	// support custom event routing
+	if event.Channel != "" {
+		return []string{event.Channel}
+	}
+
+	return b.digest.Filter(event, b.mutes.Filter(event, channelsToNotifyForEvent(event, sourceBindings, b.getChannels(), b.getChannelsToNotify)), channelDigestByID(b.getChannels))
+}
+
+func (b *RocketChat) getChannelsToNotify(sourceBindings []string) []string {
+	var out []string
+	for _, cfg := range b.getChannels() {
+		switch {
+		case !cfg.notificationsEnabled():
+			b.log.Infof("Skipping notification for channel %q as notifications are disabled.", cfg.Identifier())
+		default:
+			if sliceutil.Intersect(sourceBindings, cfg.Bindings.Sources) {
+				out = append(out, cfg.Identifier())
+			}
+		}
+	}
+	return out
+}
+
+func (b *RocketChat) findAndTrimBotMention(msg string) (string, bool) {
+	if !b.botMentionRegex.MatchString(msg) {
+		return "", false
+	}
+
+	return b.botMentionRegex.ReplaceAllString(msg, ""), true
+}
+
+func (b *RocketChat) getChannels() map[string]channelConfigByID {
+	b.channelsMutex.RLock()
+	defer b.channelsMutex.RUnlock()
+	return b.channels
+}
+
+func (b *RocketChat) setChannels(channels map[string]channelConfigByID) {
+	b.channelsMutex.Lock()
+	defer b.channelsMutex.Unlock()
+	b.channels = channels
+}
+
+// ReloadChannels reloads the bindings and notification settings of the channels, without reconnecting the bot.
+// Channels are matched by alias, so the channel IDs don't need to be resolved again.
+func (b *RocketChat) ReloadChannels(cfg config.RocketChat) {
+	b.channelsMutex.Lock()
+	defer b.channelsMutex.Unlock()
+	b.channels = reloadedChannelsByID(b.channels, channelBindingsByIDFrom(cfg.Channels))
+}
+
+func rocketChatChannelsCfgFrom(ctx context.Context, client *rocketChatClient, channelsCfg config.IdentifiableMap[config.ChannelBindingsByName]) (map[string]channelConfigByID, error) {
+	res := make(map[string]channelConfigByID)
+	for channAlias, channCfg := range channelsCfg {
+		room, err := client.RoomByName(ctx, channCfg.Identifier())
+		if err != nil {
+			return nil, fmt.Errorf("while getting room by name %q: %w", channCfg.Name, err)
+		}
+
+		res[room.ID] = channelConfigByID{
+			ChannelBindingsByID: config.ChannelBindingsByID{
+				ID:                   room.ID,
+				Notification:         channCfg.Notification,
+				Bindings:             channCfg.Bindings,
+				Commands:             channCfg.Commands,
+				Locale:               channCfg.Locale,
+				NotificationSchedule: channCfg.NotificationSchedule,
+			},
+			alias:  channAlias,
+			notify: !channCfg.Notification.Disabled,
+		}
+	}
+
+	return res, nil
+}
+
+func rocketChatBotMentionRegex(botName string) (*regexp.Regexp, error) {
+	botMentionRegex, err := regexp.Compile(fmt.Sprintf(rocketChatBotMentionRegexFmt, botName))
+	if err != nil {
+		return nil, fmt.Errorf("while compiling bot mention regex: %w", err)
+	}
+
+	return botMentionRegex, nil
+}
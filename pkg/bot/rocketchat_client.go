@@ -0,0 +1,382 @@
+package bot
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// TODO: Replace with the official Rocket.Chat Go SDK once it supports Personal Access Tokens and is actively maintained.
+
+const (
+	rocketChatHTTPTimeout       = 30 * time.Second
+	rocketChatUserIDHeader      = "X-User-Id"
+	rocketChatAuthTokenHeader   = "X-Auth-Token"
+	rocketChatWebSocketPath     = "/websocket"
+	rocketChatRoomMessagesTopic = "stream-room-messages"
+)
+
+// rocketChatClient is a minimal Rocket.Chat client. It uses the REST API to send messages
+// and the Realtime API (DDP over WebSocket) to receive them.
+type rocketChatClient struct {
+	serverURL    *url.URL
+	webSocketURL string
+	userID       string
+	token        string
+	httpCli      *http.Client
+}
+
+type rocketChatUser struct {
+	ID       string `json:"_id"`
+	Username string `json:"username"`
+}
+
+type rocketChatRoom struct {
+	ID   string `json:"_id"`
+	Name string `json:"name"`
+}
+
+// rocketChatMessage is a message received via the Realtime API.
+type rocketChatMessage struct {
+	ID       string          `json:"_id"`
+	RoomID   string          `json:"rid"`
+	Msg      string          `json:"msg"`
+	ThreadID string          `json:"tmid,omitempty"`
+	Type     string          `json:"t,omitempty"`
+	EditedAt json.RawMessage `json:"editedAt,omitempty"`
+	User     rocketChatUser  `json:"u"`
+}
+
+type rocketChatAttachmentField struct {
+	Short bool   `json:"short"`
+	Title string `json:"title"`
+	Value string `json:"value"`
+}
+
+type rocketChatAttachment struct {
+	Title  string                      `json:"title,omitempty"`
+	Text   string                      `json:"text,omitempty"`
+	Color  string                      `json:"color,omitempty"`
+	Fields []rocketChatAttachmentField `json:"fields,omitempty"`
+	TS     *time.Time                  `json:"ts,omitempty"`
+}
+
+type rocketChatPostMessage struct {
+	RoomID      string                 `json:"roomId"`
+	Text        string                 `json:"text,omitempty"`
+	ThreadID    string                 `json:"tmid,omitempty"`
+	Attachments []rocketChatAttachment `json:"attachments,omitempty"`
+}
+
+type rocketChatAPIResponse struct {
+	Success bool   `json:"success"`
+	Error   string `json:"error"`
+}
+
+func newRocketChatClient(serverURL, userID, token string) (*rocketChatClient, error) {
+	parsedURL, err := url.Parse(serverURL)
+	if err != nil {
+		return nil, fmt.Errorf("while parsing Rocket.Chat URL %q: %w", serverURL, err)
+	}
+
+	wsURL := *parsedURL
+	wsURL.Scheme = "ws"
+	if parsedURL.Scheme == httpsScheme {
+		wsURL.Scheme = "wss"
+	}
+	wsURL.Path += rocketChatWebSocketPath
+
+	return &rocketChatClient{
+		serverURL:    parsedURL,
+		webSocketURL: wsURL.String(),
+		userID:       userID,
+		token:        token,
+		httpCli:      &http.Client{Timeout: rocketChatHTTPTimeout},
+	}, nil
+}
+
+// Me returns the authenticated user.
+func (c *rocketChatClient) Me(ctx context.Context) (rocketChatUser, error) {
+	var out struct {
+		rocketChatAPIResponse
+		rocketChatUser
+	}
+	if err := c.do(ctx, http.MethodGet, "/api/v1/me", nil, "", &out); err != nil {
+		return rocketChatUser{}, err
+	}
+	return out.rocketChatUser, nil
+}
+
+// RoomByName returns a room for a given name.
+func (c *rocketChatClient) RoomByName(ctx context.Context, name string) (rocketChatRoom, error) {
+	var out struct {
+		rocketChatAPIResponse
+		Room rocketChatRoom `json:"room"`
+	}
+	path := fmt.Sprintf("/api/v1/rooms.info?roomName=%s", url.QueryEscape(name))
+	if err := c.do(ctx, http.MethodGet, path, nil, "", &out); err != nil {
+		return rocketChatRoom{}, err
+	}
+	return out.Room, nil
+}
+
+// PostMessage sends a message to a given room.
+func (c *rocketChatClient) PostMessage(ctx context.Context, msg rocketChatPostMessage) error {
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("while marshaling message: %w", err)
+	}
+
+	var out rocketChatAPIResponse
+	return c.do(ctx, http.MethodPost, "/api/v1/chat.postMessage", bytes.NewReader(body), "application/json", &out)
+}
+
+// UploadFile uploads a file with a given description to a given room.
+func (c *rocketChatClient) UploadFile(ctx context.Context, roomID, threadID, fileName, description string, content []byte) error {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+
+	part, err := writer.CreateFormFile("file", fileName)
+	if err != nil {
+		return fmt.Errorf("while creating form file: %w", err)
+	}
+	if _, err := part.Write(content); err != nil {
+		return fmt.Errorf("while writing form file: %w", err)
+	}
+	if err := writer.WriteField("msg", description); err != nil {
+		return fmt.Errorf("while writing form field: %w", err)
+	}
+	if threadID != "" {
+		if err := writer.WriteField("tmid", threadID); err != nil {
+			return fmt.Errorf("while writing form field: %w", err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		return fmt.Errorf("while closing form writer: %w", err)
+	}
+
+	var out rocketChatAPIResponse
+	path := fmt.Sprintf("/api/v1/rooms.upload/%s", url.PathEscape(roomID))
+	return c.do(ctx, http.MethodPost, path, &body, writer.FormDataContentType(), &out)
+}
+
+func (c *rocketChatClient) do(ctx context.Context, method, path string, body io.Reader, contentType string, out interface{}) (err error) {
+	req, err := http.NewRequestWithContext(ctx, method, c.serverURL.String()+path, body)
+	if err != nil {
+		return fmt.Errorf("while creating request: %w", err)
+	}
+	req.Header.Set(rocketChatUserIDHeader, c.userID)
+	req.Header.Set(rocketChatAuthTokenHeader, c.token)
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	res, err := c.httpCli.Do(req)
+	if err != nil {
+		return fmt.Errorf("while sending request: %w", err)
+	}
+	defer func() {
+		if closeErr := res.Body.Close(); closeErr != nil && err == nil {
+			err = fmt.Errorf("while closing response body: %w", closeErr)
+		}
+	}()
+
+	raw, err := io.ReadAll(res.Body)
+	if err != nil {
+		return fmt.Errorf("while reading response body: %w", err)
+	}
+
+	var apiResp rocketChatAPIResponse
+	if err := json.Unmarshal(raw, &apiResp); err != nil {
+		return fmt.Errorf("while decoding response with status %d: %w", res.StatusCode, err)
+	}
+	if res.StatusCode != http.StatusOK || !apiResp.Success {
+		return fmt.Errorf("got unexpected response with status %d: %s", res.StatusCode, apiResp.Error)
+	}
+
+	if err := json.Unmarshal(raw, out); err != nil {
+		return fmt.Errorf("while decoding response: %w", err)
+	}
+	return nil
+}
+
+// rocketChatRealtime is a connection to the Rocket.Chat Realtime API.
+// See https://developer.rocket.chat/reference/api/realtime-api
+type rocketChatRealtime struct {
+	conn    *websocket.Conn
+	writeMu sync.Mutex
+	lastID  int
+}
+
+// ddpMessage describes the subset of DDP frames used by Rocket.Chat Realtime API.
+type ddpMessage struct {
+	Msg        string          `json:"msg"`
+	ID         string          `json:"id,omitempty"`
+	Method     string          `json:"method,omitempty"`
+	Name       string          `json:"name,omitempty"`
+	Version    string          `json:"version,omitempty"`
+	Support    []string        `json:"support,omitempty"`
+	Params     []interface{}   `json:"params,omitempty"`
+	Collection string          `json:"collection,omitempty"`
+	Fields     *ddpFields      `json:"fields,omitempty"`
+	Error      json.RawMessage `json:"error,omitempty"`
+}
+
+type ddpFields struct {
+	EventName string            `json:"eventName"`
+	Args      []json.RawMessage `json:"args"`
+}
+
+// DialRealtime connects and logs in to the Rocket.Chat Realtime API.
+func (c *rocketChatClient) DialRealtime(ctx context.Context) (*rocketChatRealtime, error) {
+	conn, _, err := websocket.DefaultDialer.DialContext(ctx, c.webSocketURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("while dialing %q: %w", c.webSocketURL, err)
+	}
+
+	rt := &rocketChatRealtime{conn: conn}
+	if err := rt.handshake(c.token); err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+
+	return rt, nil
+}
+
+func (r *rocketChatRealtime) handshake(token string) error {
+	err := r.send(ddpMessage{Msg: "connect", Version: "1", Support: []string{"1"}})
+	if err != nil {
+		return fmt.Errorf("while connecting: %w", err)
+	}
+	if err := r.waitFor(func(msg ddpMessage) (bool, error) {
+		return msg.Msg == "connected", nil
+	}); err != nil {
+		return fmt.Errorf("while waiting for connection: %w", err)
+	}
+
+	loginID := r.nextID()
+	err = r.send(ddpMessage{
+		Msg:    "method",
+		ID:     loginID,
+		Method: "login",
+		Params: []interface{}{map[string]string{"resume": token}},
+	})
+	if err != nil {
+		return fmt.Errorf("while logging in: %w", err)
+	}
+
+	return r.waitFor(func(msg ddpMessage) (bool, error) {
+		if msg.Msg != "result" || msg.ID != loginID {
+			return false, nil
+		}
+		if len(msg.Error) > 0 {
+			return false, fmt.Errorf("while logging in: %s", string(msg.Error))
+		}
+		return true, nil
+	})
+}
+
+// SubscribeRoomMessages subscribes to new messages in a given room.
+func (r *rocketChatRealtime) SubscribeRoomMessages(roomID string) error {
+	return r.send(ddpMessage{
+		Msg:    "sub",
+		ID:     r.nextID(),
+		Name:   rocketChatRoomMessagesTopic,
+		Params: []interface{}{roomID, false},
+	})
+}
+
+// Listen reads incoming frames until the connection is closed or the context is canceled.
+func (r *rocketChatRealtime) Listen(ctx context.Context, handleFn func(msg rocketChatMessage)) error {
+	go func() {
+		<-ctx.Done()
+		_ = r.conn.Close()
+	}()
+
+	for {
+		var frame ddpMessage
+		if err := r.conn.ReadJSON(&frame); err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return fmt.Errorf("while reading frame: %w", err)
+		}
+
+		switch frame.Msg {
+		case "ping":
+			if err := r.send(ddpMessage{Msg: "pong"}); err != nil {
+				return fmt.Errorf("while responding to ping: %w", err)
+			}
+		case "nosub":
+			return fmt.Errorf("subscription %q was rejected: %s", frame.ID, string(frame.Error))
+		case "changed":
+			if frame.Collection != rocketChatRoomMessagesTopic || frame.Fields == nil {
+				continue
+			}
+			for _, arg := range frame.Fields.Args {
+				var msg rocketChatMessage
+				if err := json.Unmarshal(arg, &msg); err != nil {
+					continue
+				}
+				handleFn(msg)
+			}
+		}
+	}
+}
+
+// Close closes the underlying connection.
+func (r *rocketChatRealtime) Close() error {
+	err := r.conn.Close()
+	if errors.Is(err, net.ErrClosed) {
+		return nil
+	}
+	return err
+}
+
+func (r *rocketChatRealtime) waitFor(matchFn func(msg ddpMessage) (bool, error)) error {
+	for {
+		var frame ddpMessage
+		if err := r.conn.ReadJSON(&frame); err != nil {
+			return fmt.Errorf("while reading frame: %w", err)
+		}
+		if frame.Msg == "ping" {
+			if err := r.send(ddpMessage{Msg: "pong"}); err != nil {
+				return fmt.Errorf("while responding to ping: %w", err)
+			}
+			continue
+		}
+
+		matched, err := matchFn(frame)
+		if err != nil {
+			return err
+		}
+		if matched {
+			return nil
+		}
+	}
+}
+
+func (r *rocketChatRealtime) send(msg ddpMessage) error {
+	r.writeMu.Lock()
+	defer r.writeMu.Unlock()
+	return r.conn.WriteJSON(msg)
+}
+
+func (r *rocketChatRealtime) nextID() string {
+	r.writeMu.Lock()
+	defer r.writeMu.Unlock()
+	r.lastID++
+	return strconv.Itoa(r.lastID)
+}
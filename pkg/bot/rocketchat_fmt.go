@@ -0,0 +1,77 @@
+package bot
+
+import (
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/events"
+	formatx "github.com/kubeshop/botkube/pkg/format"
+)
+
+// rocketChatAttachmentColor holds attachment colors. Rocket.Chat supports only CSS colors.
+var rocketChatAttachmentColor = map[config.Level]string{
+	config.Info:     "#2eb886",
+	config.Warn:     "#daa038",
+	config.Debug:    "#2eb886",
+	config.Error:    "#a30200",
+	config.Critical: "#a30200",
+}
+
+func (b *RocketChat) formatAttachment(event events.Event) rocketChatAttachment {
+	attachment := rocketChatAttachment{
+		Title: event.Title,
+		Color: rocketChatAttachmentColor[event.Level],
+	}
+
+	switch eventNotificationType(b.notification, event) {
+	case config.LongNotification:
+		attachment.Fields = b.longNotification(event)
+	case config.ShortNotification:
+		fallthrough
+	default:
+		attachment.Text = formatx.ShortMessage(event)
+	}
+
+	if !event.TimeStamp.IsZero() {
+		ts := event.TimeStamp
+		attachment.TS = &ts
+	}
+
+	return attachment
+}
+
+func (b *RocketChat) longNotification(event events.Event) []rocketChatAttachmentField {
+	fields := []rocketChatAttachmentField{
+		{
+			Title: "Kind",
+			Value: event.Kind,
+			Short: true,
+		},
+		{
+			Title: "Name",
+			Value: event.Name,
+			Short: true,
+		},
+	}
+
+	fields = b.appendIfNotEmpty(fields, event.Namespace, "Namespace", true)
+	fields = b.appendIfNotEmpty(fields, event.OwnerString(), "Owner", true)
+	fields = b.appendIfNotEmpty(fields, event.Reason, "Reason", true)
+	fields = b.appendIfNotEmpty(fields, formatx.JoinMessages(event.Messages), "Message", false)
+	fields = b.appendIfNotEmpty(fields, event.Action, "Action", true)
+	fields = b.appendIfNotEmpty(fields, formatx.JoinMessages(event.Recommendations), "Recommendations", false)
+	fields = b.appendIfNotEmpty(fields, formatx.JoinMessages(event.Warnings), "Warnings", false)
+	fields = b.appendIfNotEmpty(fields, formatx.EventLogs(event), "Logs", false)
+	fields = b.appendIfNotEmpty(fields, event.Cluster, "Cluster", false)
+
+	return fields
+}
+
+func (b *RocketChat) appendIfNotEmpty(fields []rocketChatAttachmentField, in string, title string, short bool) []rocketChatAttachmentField {
+	if in == "" {
+		return fields
+	}
+	return append(fields, rocketChatAttachmentField{
+		Title: title,
+		Value: in,
+		Short: short,
+	})
+}
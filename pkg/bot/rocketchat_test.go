@@ -0,0 +1,109 @@
+package bot
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRocketChat_FindAndTrimBotMention(t *testing.T) {
+	/// given
+	botName := "Botkube"
+	testCases := []struct {
+		Name               string
+		Input              string
+		ExpectedTrimmedMsg string
+		ExpectedFound      bool
+	}{
+		{
+			Name:               "Mention",
+			Input:              "@Botkube get pods",
+			ExpectedFound:      true,
+			ExpectedTrimmedMsg: " get pods",
+		},
+		{
+			Name:               "Lowercase",
+			Input:              "@botkube get pods",
+			ExpectedFound:      true,
+			ExpectedTrimmedMsg: " get pods",
+		},
+		{
+			Name:          "Not at the beginning",
+			Input:         "Not at the beginning @Botkube get pods",
+			ExpectedFound: false,
+		},
+		{
+			Name:          "Different mention",
+			Input:         "@bootkube get pods",
+			ExpectedFound: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			botMentionRegex, err := rocketChatBotMentionRegex(botName)
+			require.NoError(t, err)
+			b := &RocketChat{botMentionRegex: botMentionRegex}
+
+			// when
+			actualTrimmedMsg, actualFound := b.findAndTrimBotMention(tc.Input)
+
+			// then
+			assert.Equal(t, tc.ExpectedFound, actualFound)
+			assert.Equal(t, tc.ExpectedTrimmedMsg, actualTrimmedMsg)
+		})
+	}
+}
+
+func TestRocketChatClient_PostMessage(t *testing.T) {
+	// given
+	var gotMsg rocketChatPostMessage
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/chat.postMessage", r.URL.Path)
+		assert.Equal(t, "user-id", r.Header.Get(rocketChatUserIDHeader))
+		assert.Equal(t, "token", r.Header.Get(rocketChatAuthTokenHeader))
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&gotMsg))
+
+		_, _ = w.Write([]byte(`{"success": true}`))
+	}))
+	defer srv.Close()
+
+	cli, err := newRocketChatClient(srv.URL, "user-id", "token")
+	require.NoError(t, err)
+
+	msg := rocketChatPostMessage{
+		RoomID:   "room-id",
+		ThreadID: "thread-id",
+		Text:     "hello",
+	}
+
+	// when
+	err = cli.PostMessage(context.Background(), msg)
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, msg, gotMsg)
+}
+
+func TestRocketChatClient_ErrorResponse(t *testing.T) {
+	// given
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"success": false, "error": "room not found"}`))
+	}))
+	defer srv.Close()
+
+	cli, err := newRocketChatClient(srv.URL, "user-id", "token")
+	require.NoError(t, err)
+
+	// when
+	_, err = cli.RoomByName(context.Background(), "general")
+
+	// then
+	assert.EqualError(t, err, "got unexpected response with status 400: room not found")
+}
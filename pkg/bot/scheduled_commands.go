@@ -0,0 +1,95 @@
+package bot
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/kubeshop/botkube/pkg/execute"
+)
+
+// scheduledCommandsCheckInterval is the interval of checking for due scheduled commands. Cron expressions have a minute precision.
+const scheduledCommandsCheckInterval = 10 * time.Second
+
+// ScheduledCommandRunner executes scheduled commands and posts their output in its channels.
+type ScheduledCommandRunner interface {
+	// RunScheduledCommand executes a given command on behalf of the user who scheduled it, and posts the output in the command channel.
+	// It returns false if the command channel is not handled by this bot.
+	RunScheduledCommand(ctx context.Context, cmd execute.ScheduledCommand) (bool, error)
+}
+
+// Bots which support scheduled commands.
+var (
+	_ ScheduledCommandRunner = &SocketSlack{}
+	_ ScheduledCommandRunner = &Mattermost{}
+	_ ScheduledCommandRunner = &Discord{}
+)
+
+// ScheduledCommands runs the due scheduled commands with the bots which handle their channels.
+type ScheduledCommands struct {
+	log     logrus.FieldLogger
+	store   *execute.ScheduleStore
+	runners []ScheduledCommandRunner
+	nowFn   func() time.Time
+}
+
+// NewScheduledCommands returns a new ScheduledCommands instance.
+func NewScheduledCommands(log logrus.FieldLogger, store *execute.ScheduleStore, runners []ScheduledCommandRunner) *ScheduledCommands {
+	return &ScheduledCommands{
+		log:     log,
+		store:   store,
+		runners: runners,
+		nowFn:   time.Now,
+	}
+}
+
+// Start checks periodically for the due scheduled commands and runs them, until a given context is cancelled.
+// The commands are run concurrently, so a long-running command doesn't delay the other ones.
+func (s *ScheduledCommands) Start(ctx context.Context) error {
+	s.log.Info("Starting scheduled commands...")
+	ticker := time.NewTicker(scheduledCommandsCheckInterval)
+	defer ticker.Stop()
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
+	for {
+		select {
+		case <-ctx.Done():
+			s.log.Info("Shutdown requested. Finishing...")
+			return nil
+		case <-ticker.C:
+			for _, cmd := range s.store.Due(s.nowFn()) {
+				cmd := cmd
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					s.run(ctx, cmd)
+				}()
+			}
+		}
+	}
+}
+
+func (s *ScheduledCommands) run(ctx context.Context, cmd execute.ScheduledCommand) {
+	log := s.log.WithFields(logrus.Fields{
+		"id":        cmd.ID,
+		"commGroup": cmd.CommGroup,
+		"platform":  cmd.Platform,
+		"channel":   cmd.ChannelID,
+	})
+	log.Debugf("Running scheduled command %q...", cmd.Command)
+
+	for _, runner := range s.runners {
+		handled, err := runner.RunScheduledCommand(ctx, cmd)
+		if err != nil {
+			log.Errorf("while running scheduled command: %s", err.Error())
+		}
+		if handled {
+			return
+		}
+	}
+	log.Warn("Skipping scheduled command, as its channel is not configured for any bot.")
+}
@@ -0,0 +1,100 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	logtest "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/execute"
+)
+
+func TestScheduledCommandsRun(t *testing.T) {
+	// given
+	cmd := execute.ScheduledCommand{
+		ID: "a1b2c3d4",
+		Schedule: config.Schedule{
+			Cron:      "0 9 * * *",
+			Command:   "kubectl get pods",
+			CommGroup: "default",
+			Platform:  config.DiscordCommPlatformIntegration,
+			ChannelID: "123",
+		},
+	}
+
+	tests := []struct {
+		name string
+
+		runners []*fakeScheduledCommandRunner
+
+		expCalls []int
+		expLogs  []string
+	}{
+		{
+			name: "Should stop at the first runner which handles the command",
+			runners: []*fakeScheduledCommandRunner{
+				{},
+				{handles: true},
+				{handles: true},
+			},
+			expCalls: []int{1, 1, 0},
+		},
+		{
+			name: "Should log runner error",
+			runners: []*fakeScheduledCommandRunner{
+				{handles: true, err: errors.New("fake error")},
+			},
+			expCalls: []int{1},
+			expLogs:  []string{"while running scheduled command: fake error"},
+		},
+		{
+			name: "Should log command which is not handled",
+			runners: []*fakeScheduledCommandRunner{
+				{},
+			},
+			expCalls: []int{1},
+			expLogs:  []string{"Skipping scheduled command, as its channel is not configured for any bot."},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			logger, hook := logtest.NewNullLogger()
+			var runners []ScheduledCommandRunner
+			for _, runner := range tc.runners {
+				runners = append(runners, runner)
+			}
+			scheduler := NewScheduledCommands(logger, nil, runners)
+
+			// when
+			scheduler.run(context.Background(), cmd)
+
+			// then
+			for idx, runner := range tc.runners {
+				assert.Len(t, runner.cmds, tc.expCalls[idx], "runner %d", idx)
+				for _, got := range runner.cmds {
+					assert.Equal(t, cmd, got)
+				}
+			}
+
+			var logs []string
+			for _, entry := range hook.AllEntries() {
+				logs = append(logs, entry.Message)
+			}
+			assert.Equal(t, tc.expLogs, logs)
+		})
+	}
+}
+
+type fakeScheduledCommandRunner struct {
+	handles bool
+	err     error
+	cmds    []execute.ScheduledCommand
+}
+
+func (f *fakeScheduledCommandRunner) RunScheduledCommand(_ context.Context, cmd execute.ScheduledCommand) (bool, error) {
+	f.cmds = append(f.cmds, cmd)
+	return f.handles, f.err
+}
@@ -0,0 +1,38 @@
+package bot
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	// shutdownMsgFmt is the message sent to all configured channels when the bot shuts down.
+	shutdownMsgFmt = "Botkube on cluster '%s' is shutting down."
+	// shutdownMsgTimeout is the time given to send the shutdown message, as the bot context is already cancelled at that point.
+	shutdownMsgTimeout = 5 * time.Second
+)
+
+// drainingContext returns a context for executing commands, detached from a given parent context.
+// Once the parent context is done, the in-flight commands have the drain timeout to finish
+// before the returned context is cancelled too.
+func drainingContext(parent context.Context, drainTimeout time.Duration) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		select {
+		case <-ctx.Done():
+			return
+		case <-parent.Done():
+		}
+
+		timer := time.NewTimer(drainTimeout)
+		defer timer.Stop()
+
+		select {
+		case <-ctx.Done():
+		case <-timer.C:
+			cancel()
+		}
+	}()
+
+	return ctx, cancel
+}
@@ -0,0 +1,56 @@
+package bot
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDrainingContext(t *testing.T) {
+	t.Run("Not cancelled until the drain timeout elapses", func(t *testing.T) {
+		// given
+		parent, cancelParent := context.WithCancel(context.Background())
+		ctx, cancel := drainingContext(parent, 50*time.Millisecond)
+		defer cancel()
+
+		// when
+		cancelParent()
+
+		// then
+		assert.NoError(t, ctx.Err())
+		assert.Eventually(t, func() bool {
+			return ctx.Err() != nil
+		}, time.Second, 5*time.Millisecond)
+	})
+
+	t.Run("Not cancelled while parent is active", func(t *testing.T) {
+		// given
+		parent, cancelParent := context.WithCancel(context.Background())
+		defer cancelParent()
+
+		// when
+		ctx, cancel := drainingContext(parent, time.Millisecond)
+		defer cancel()
+
+		// then
+		time.Sleep(20 * time.Millisecond)
+		assert.NoError(t, ctx.Err())
+	})
+
+	t.Run("Cancelled immediately without drain timeout", func(t *testing.T) {
+		// given
+		parent, cancelParent := context.WithCancel(context.Background())
+		ctx, cancel := drainingContext(parent, 0)
+		defer cancel()
+
+		// when
+		cancelParent()
+
+		// then
+		assert.Eventually(t, func() bool {
+			return ctx.Err() != nil
+		}, time.Second, time.Millisecond)
+	})
+}
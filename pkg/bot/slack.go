@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"regexp"
 	"sync"
+	"time"
 
 	"github.com/sirupsen/logrus"
 	"github.com/slack-go/slack"
@@ -13,10 +14,13 @@ import (
 	"github.com/kubeshop/botkube/internal/analytics"
 	"github.com/kubeshop/botkube/pkg/bot/interactive"
 	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/correlation"
+	"github.com/kubeshop/botkube/pkg/digest"
 	"github.com/kubeshop/botkube/pkg/events"
 	"github.com/kubeshop/botkube/pkg/execute"
 	"github.com/kubeshop/botkube/pkg/execute/command"
 	"github.com/kubeshop/botkube/pkg/multierror"
+	"github.com/kubeshop/botkube/pkg/mute"
 	"github.com/kubeshop/botkube/pkg/sliceutil"
 )
 
@@ -59,6 +63,9 @@ type Slack struct {
 	commGroupName   string
 	renderer        *SlackRenderer
 	mdFormatter     interactive.MDFormatter
+	digest          *digest.Scheduler
+	mutes           *mute.Registry
+	eventThreads    *correlation.Store
 }
 
 // slackMessage contains message details to execute command and send back the result
@@ -102,6 +109,9 @@ func NewSlack(log logrus.FieldLogger, commGroupName string, cfg config.Slack, ex
 		renderer:        NewSlackRenderer(cfg.Notification),
 		botMentionRegex: botMentionRegex,
 		mdFormatter:     mdFormatter,
+		digest:          digest.NewScheduler(log),
+		mutes:           mute.NewRegistry(),
+		eventThreads:    eventThreadsStore(cfg.EventThreads),
 	}, nil
 }
 
@@ -115,6 +125,11 @@ func (b *Slack) Start(ctx context.Context) error {
 		rtm.ManageConnection()
 	}()
 
+	go func() {
+		defer analytics.ReportPanicIfOccurs(b.log, b.reporter)
+		b.digest.Run(ctx, b.sendDigest)
+	}()
+
 	for {
 		select {
 		case <-ctx.Done():
@@ -194,7 +209,7 @@ func (b *Slack) NotificationsEnabled(channelName string) bool {
 		return false
 	}
 
-	return channel.notify
+	return channel.notificationsEnabled()
 }
 
 // SetNotificationsEnabled sets a new notification status for a given channel name.
@@ -210,12 +225,58 @@ func (b *Slack) SetNotificationsEnabled(channelName string, enabled bool) error
 	}
 
 	channel.notify = enabled
+	channel.Notification.SnoozedUntil = time.Time{}
+	channels[channelName] = channel
+	b.setChannels(channels)
+
+	return nil
+}
+
+// SnoozeNotifications disables notifications for a given channel until a given time.
+func (b *Slack) SnoozeNotifications(channelName string, until time.Time) error {
+	// avoid race conditions with using the setter concurrently, as we set whole map
+	b.notifyMutex.Lock()
+	defer b.notifyMutex.Unlock()
+
+	channels := b.getChannels()
+	channel, exists := channels[channelName]
+	if !exists {
+		return execute.ErrNotificationsNotConfigured
+	}
+
+	channel.notify = true
+	channel.Notification.SnoozedUntil = until
 	channels[channelName] = channel
 	b.setChannels(channels)
 
 	return nil
 }
 
+// NotificationsSnoozedUntil returns the snooze end time for a given channel.
+func (b *Slack) NotificationsSnoozedUntil(channelName string) time.Time {
+	return b.getChannels()[channelName].Notification.SnoozedUntil
+}
+
+// MuteNotifications suppresses notifications matching a given rule for a given channel.
+func (b *Slack) MuteNotifications(channelName string, rule mute.Rule) error {
+	if _, exists := b.getChannels()[channelName]; !exists {
+		return execute.ErrNotificationsNotConfigured
+	}
+
+	b.mutes.Add(channelName, rule)
+	return nil
+}
+
+// UnmuteNotifications removes the mute rule for the same target as a given rule for a given channel.
+func (b *Slack) UnmuteNotifications(channelName string, rule mute.Rule) bool {
+	return b.mutes.Remove(channelName, rule)
+}
+
+// NotificationMutes returns active mute rules for a given channel.
+func (b *Slack) NotificationMutes(channelName string) []mute.Rule {
+	return b.mutes.List(channelName)
+}
+
 func (b *Slack) handleMessage(ctx context.Context, msg slackMessage) error {
 	// Handle message only if starts with mention
 	request, found := b.findAndTrimBotMention(msg.Text)
@@ -236,6 +297,13 @@ func (b *Slack) handleMessage(ctx context.Context, msg slackMessage) error {
 	}
 
 	channel, isAuthChannel := b.getChannels()[info.Name]
+	if !execute.IsCommandPermitted(channel.Commands, request) {
+		b.log.Debugf("Command %q is not permitted in channel %q", request, channel.Identifier())
+		if err := b.send(msg, execute.CommandNotPermittedMessage(request), false); err != nil {
+			return fmt.Errorf("while sending message: %w", err)
+		}
+		return nil
+	}
 
 	e := b.executorFactory.NewDefault(execute.NewDefaultInput{
 		CommGroupName:   b.commGroupName,
@@ -245,11 +313,14 @@ func (b *Slack) handleMessage(ctx context.Context, msg slackMessage) error {
 			Alias:            channel.alias,
 			ID:               channel.Identifier(),
 			ExecutorBindings: channel.Bindings.Executors,
+			Commands:         channel.Commands,
+			Locale:           channel.Locale,
 			IsAuthenticated:  isAuthChannel,
 			CommandOrigin:    command.TypedOrigin,
 		},
 		Message: request,
 		User:    fmt.Sprintf("<@%s>", msg.User),
+		UserID:  msg.User,
 	})
 	response := e.Execute(ctx)
 	err = b.send(msg, response, response.OnlyVisibleForYou)
@@ -290,9 +361,19 @@ func (b *Slack) send(msg slackMessage, resp interactive.Message, onlyVisibleToUs
 			return fmt.Errorf("while posting Slack message visible only to user: %w", err)
 		}
 	} else {
-		if _, _, err := b.client.PostMessage(msg.Channel, options...); err != nil {
+		channelID, timestamp, err := b.client.PostMessage(msg.Channel, options...)
+		if err != nil {
 			return fmt.Errorf("while posting Slack message: %w", err)
 		}
+		if resp.Attachment != nil {
+			threadTS := msg.ThreadTimeStamp
+			if threadTS == "" {
+				threadTS = timestamp
+			}
+			if err := uploadAttachmentToSlack(b.client, channelID, threadTS, resp.Attachment); err != nil {
+				return err
+			}
+		}
 	}
 
 	return nil
@@ -301,35 +382,78 @@ func (b *Slack) send(msg slackMessage, resp interactive.Message, onlyVisibleToUs
 // SendEvent sends event notification to slack
 func (b *Slack) SendEvent(ctx context.Context, event events.Event, eventSources []string) error {
 	b.log.Debugf("Sending to Slack: %+v", event)
+	event, longLogs := detachLongEventLogs(event)
 	attachment := b.renderer.RenderLegacyEventMessage(event)
 
 	errs := multierror.New()
 	for _, channelName := range b.getChannelsToNotifyForEvent(event, eventSources) {
-		channelID, timestamp, err := b.client.PostMessageContext(ctx, channelName, slack.MsgOptionAttachments(attachment), slack.MsgOptionAsUser(true))
+		options := []slack.MsgOption{
+			slack.MsgOptionAttachments(attachment),
+			slack.MsgOptionAsUser(true),
+		}
+		threadTS, inThread := b.eventThread(channelName, event)
+		if inThread {
+			options = append(options, slack.MsgOptionTS(threadTS))
+		}
+
+		channelID, timestamp, err := b.client.PostMessageContext(ctx, channelName, options...)
 		if err != nil {
 			errs = multierror.Append(errs, fmt.Errorf("while posting message to channel %q: %w", channelName, err))
 			continue
 		}
 
+		if b.eventThreads != nil {
+			if !inThread {
+				threadTS = timestamp
+			}
+			b.eventThreads.Set(channelName, event, threadTS)
+		}
+
+		if longLogs != nil {
+			logsThreadTS := timestamp
+			if inThread {
+				logsThreadTS = threadTS
+			}
+			if err := uploadEventLogsToSlack(ctx, b.client, channelID, logsThreadTS, longLogs); err != nil {
+				errs = multierror.Append(errs, fmt.Errorf("while sending logs to channel %q: %w", channelName, err))
+			}
+		}
+
 		b.log.Debugf("Event successfully sent to channel %q (ID: %q) at %b", channelName, channelID, timestamp)
 	}
 
 	return errs.ErrorOrNil()
 }
 
+// eventThread returns the timestamp of the message which started a thread for events related to a given one.
+func (b *Slack) eventThread(channelName string, event events.Event) (string, bool) {
+	if b.eventThreads == nil {
+		return "", false
+	}
+	return b.eventThreads.Get(channelName, event)
+}
+
 func (b *Slack) getChannelsToNotifyForEvent(event events.Event, sourceBindings []string) []string {
 	// support custom event routing
 	if event.Channel != "" {
 		return []string{event.Channel}
 	}
 
-	return b.getChannelsToNotify(sourceBindings)
+	return b.digest.Filter(event, b.mutes.Filter(event, channelsToNotifyForEvent(event, sourceBindings, b.getChannels(), b.getChannelsToNotify)), channelDigestByName(b.getChannels))
+}
+
+func (b *Slack) sendDigest(ctx context.Context, channelName string, msg interactive.Message) error {
+	message := interactive.RenderMessage(b.mdFormatter, msg)
+	if _, _, err := b.client.PostMessageContext(ctx, channelName, slack.MsgOptionText(message, false), slack.MsgOptionAsUser(true)); err != nil {
+		return fmt.Errorf("while posting message to channel %q: %w", channelName, err)
+	}
+	return nil
 }
 
 func (b *Slack) getChannelsToNotify(sourceBindings []string) []string {
 	var out []string
 	for _, cfg := range b.getChannels() {
-		if !cfg.notify {
+		if !cfg.notificationsEnabled() {
 			b.log.Infof("Skipping notification for channel %q as notifications are disabled.", cfg.Identifier())
 			continue
 		}
@@ -401,6 +525,13 @@ func (b *Slack) setChannels(channels map[string]channelConfigByName) {
 	b.channels = channels
 }
 
+// ReloadChannels reloads the bindings and notification settings of the channels, without reconnecting the bot.
+func (b *Slack) ReloadChannels(cfg config.Slack) {
+	b.channelsMutex.Lock()
+	defer b.channelsMutex.Unlock()
+	b.channels = reloadedChannelsByName(b.channels, cfg.Channels)
+}
+
 func (b *Slack) findAndTrimBotMention(msg string) (string, bool) {
 	if !b.botMentionRegex.MatchString(msg) {
 		return "", false
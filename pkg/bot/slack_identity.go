@@ -0,0 +1,65 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/slack-go/slack"
+
+	"github.com/kubeshop/botkube/pkg/bot/identity"
+)
+
+var (
+	_ identity.EmailLookup = &Slack{}
+	_ identity.EmailLookup = &SocketSlack{}
+
+	_ identity.UserGroupLookup = &Slack{}
+	_ identity.UserGroupLookup = &SocketSlack{}
+)
+
+// LookupEmail returns the email of a given Slack user. It requires the `users:read.email` scope.
+func (b *Slack) LookupEmail(ctx context.Context, userID string) (string, error) {
+	return slackUserEmail(ctx, b.client, userID)
+}
+
+// LookupEmail returns the email of a given Slack user. It requires the `users:read.email` scope.
+func (b *SocketSlack) LookupEmail(ctx context.Context, userID string) (string, error) {
+	return slackUserEmail(ctx, b.client, userID)
+}
+
+func slackUserEmail(ctx context.Context, client *slack.Client, userID string) (string, error) {
+	user, err := client.GetUserInfoContext(ctx, userID)
+	if err != nil {
+		return "", fmt.Errorf("while getting user info: %w", err)
+	}
+	return user.Profile.Email, nil
+}
+
+// LookupUserGroups returns the IDs and handles of the Slack user groups of a given user. It requires the `usergroups:read` scope.
+func (b *Slack) LookupUserGroups(ctx context.Context, userID string) ([]string, error) {
+	return slackUserGroups(ctx, b.client, userID)
+}
+
+// LookupUserGroups returns the IDs and handles of the Slack user groups of a given user. It requires the `usergroups:read` scope.
+func (b *SocketSlack) LookupUserGroups(ctx context.Context, userID string) ([]string, error) {
+	return slackUserGroups(ctx, b.client, userID)
+}
+
+func slackUserGroups(ctx context.Context, client *slack.Client, userID string) ([]string, error) {
+	groups, err := client.GetUserGroupsContext(ctx, slack.GetUserGroupsOptionIncludeUsers(true))
+	if err != nil {
+		return nil, fmt.Errorf("while getting user groups: %w", err)
+	}
+
+	var out []string
+	for _, group := range groups {
+		for _, member := range group.Users {
+			if member != userID {
+				continue
+			}
+			out = append(out, group.ID, group.Handle)
+			break
+		}
+	}
+	return out, nil
+}
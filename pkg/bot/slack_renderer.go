@@ -42,7 +42,7 @@ func NewSlackRenderer(notificationType config.Notification) *SlackRenderer {
 func (b *SlackRenderer) RenderLegacyEventMessage(event events.Event) slack.Attachment {
 	var attachment slack.Attachment
 
-	switch b.notification.Type {
+	switch eventNotificationType(b.notification, event) {
 	case config.LongNotification:
 		attachment = b.legacyLongNotification(event)
 	case config.ShortNotification:
@@ -64,7 +64,7 @@ func (b *SlackRenderer) RenderLegacyEventMessage(event events.Event) slack.Attac
 func (b *SlackRenderer) RenderEventMessage(event events.Event, additionalSections ...interactive.Section) interactive.Message {
 	var sections []interactive.Section
 
-	switch b.notification.Type {
+	switch eventNotificationType(b.notification, event) {
 	case config.LongNotification:
 		sections = append(sections, b.longNotificationSection(event))
 	case config.ShortNotification:
@@ -409,6 +409,7 @@ func (b *SlackRenderer) longNotificationSection(event events.Event) interactive.
 		{Text: fmt.Sprintf("*Name:* %s", event.Name)},
 	}
 	section.TextFields = b.appendTextFieldIfNotEmpty(section.TextFields, "Namespace", event.Namespace)
+	section.TextFields = b.appendTextFieldIfNotEmpty(section.TextFields, "Owner", event.OwnerString())
 	section.TextFields = b.appendTextFieldIfNotEmpty(section.TextFields, "Reason", event.Reason)
 	section.TextFields = b.appendTextFieldIfNotEmpty(section.TextFields, "Action", event.Action)
 	section.TextFields = b.appendTextFieldIfNotEmpty(section.TextFields, "Cluster", event.Cluster)
@@ -431,6 +432,11 @@ func (b *SlackRenderer) appendTextFieldIfNotEmpty(fields []interactive.TextField
 func (b *SlackRenderer) shortNotificationSection(event events.Event) interactive.Section {
 	section := b.baseNotificationSection(event)
 
+	if event.Body != "" {
+		section.Base.Description = event.Body
+		return section
+	}
+
 	header := formatx.ShortNotificationHeader(event)
 	attachments := formatx.BulletPointEventAttachments(event)
 	prefix := ""
@@ -487,11 +493,13 @@ func (b *SlackRenderer) legacyLongNotification(event events.Event) slack.Attachm
 	}
 
 	attachment.Fields = b.appendIfNotEmpty(attachment.Fields, event.Namespace, "Namespace", true)
+	attachment.Fields = b.appendIfNotEmpty(attachment.Fields, event.OwnerString(), "Owner", true)
 	attachment.Fields = b.appendIfNotEmpty(attachment.Fields, event.Reason, "Reason", true)
 	attachment.Fields = b.appendIfNotEmpty(attachment.Fields, formatx.JoinMessages(event.Messages), "Message", false)
 	attachment.Fields = b.appendIfNotEmpty(attachment.Fields, event.Action, "Action", true)
 	attachment.Fields = b.appendIfNotEmpty(attachment.Fields, formatx.JoinMessages(event.Recommendations), "Recommendations", false)
 	attachment.Fields = b.appendIfNotEmpty(attachment.Fields, formatx.JoinMessages(event.Warnings), "Warnings", false)
+	attachment.Fields = b.appendIfNotEmpty(attachment.Fields, formatx.EventLogs(event), "Logs", false)
 	attachment.Fields = b.appendIfNotEmpty(attachment.Fields, event.Cluster, "Cluster", false)
 
 	return attachment
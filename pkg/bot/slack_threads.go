@@ -0,0 +1,116 @@
+package bot
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+)
+
+const (
+	// slackDefaultActiveThreadTTL defines how long a thread stays active since the last command sent by a user.
+	slackDefaultActiveThreadTTL = time.Hour
+	// slackDefaultActiveThreadPrefix marks the follow-up commands in active threads.
+	slackDefaultActiveThreadPrefix = "!"
+)
+
+type slackThreadKey struct {
+	channel  string
+	threadTS string
+}
+
+type slackActiveThread struct {
+	user         string
+	lastActivity time.Time
+}
+
+// slackActiveThreads tracks Slack threads in which a user ran a command with the bot mention.
+// Once the thread is active, subsequent messages sent by the same user can be treated as commands,
+// even if they don't start with the bot mention.
+type slackActiveThreads struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	now     func() time.Time
+	threads map[slackThreadKey]slackActiveThread
+}
+
+func newSlackActiveThreads(ttl time.Duration) *slackActiveThreads {
+	return &slackActiveThreads{
+		ttl:     ttl,
+		now:     time.Now,
+		threads: map[slackThreadKey]slackActiveThread{},
+	}
+}
+
+// Activate marks a given thread as active for a given user.
+func (t *slackActiveThreads) Activate(channel, threadTS, user string) {
+	if channel == "" || threadTS == "" || user == "" {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := t.now()
+	t.pruneExpired(now)
+	t.threads[slackThreadKey{channel: channel, threadTS: threadTS}] = slackActiveThread{
+		user:         user,
+		lastActivity: now,
+	}
+}
+
+// IsActiveFor returns true if a given thread was activated by a given user and didn't expire yet.
+// It refreshes the thread expiration time.
+func (t *slackActiveThreads) IsActiveFor(channel, threadTS, user string) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	key := slackThreadKey{channel: channel, threadTS: threadTS}
+	thread, found := t.threads[key]
+	if !found {
+		return false
+	}
+
+	now := t.now()
+	if t.isExpired(thread, now) {
+		delete(t.threads, key)
+		return false
+	}
+
+	if thread.user != user {
+		return false
+	}
+
+	thread.lastActivity = now
+	t.threads[key] = thread
+	return true
+}
+
+func (t *slackActiveThreads) pruneExpired(now time.Time) {
+	for key, thread := range t.threads {
+		if t.isExpired(thread, now) {
+			delete(t.threads, key)
+		}
+	}
+}
+
+func (t *slackActiveThreads) isExpired(thread slackActiveThread, now time.Time) bool {
+	return now.Sub(thread.lastActivity) > t.ttl
+}
+
+// slackMessageParentUserID returns the ID of the user who posted the message which started the thread of a given message event.
+// The field is not exposed by the Slack client events, so it's read from the raw payload.
+func slackMessageParentUserID(payload json.RawMessage) string {
+	if len(payload) == 0 {
+		return ""
+	}
+
+	var out struct {
+		Event struct {
+			ParentUserID string `json:"parent_user_id"`
+		} `json:"event"`
+	}
+	if err := json.Unmarshal(payload, &out); err != nil {
+		return ""
+	}
+	return out.Event.ParentUserID
+}
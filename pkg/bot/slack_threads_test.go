@@ -0,0 +1,256 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	logtest "github.com/sirupsen/logrus/hooks/test"
+	"github.com/slack-go/slack"
+	"github.com/slack-go/slack/slackevents"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/pkg/bot/interactive"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/execute/command"
+)
+
+func TestSlackActiveThreads(t *testing.T) {
+	// given
+	now := time.Date(2022, 10, 1, 12, 0, 0, 0, time.UTC)
+	threads := newSlackActiveThreads(time.Hour)
+	threads.now = func() time.Time { return now }
+
+	// when
+	threads.Activate("C01", "1665.001", "U01")
+
+	// then
+	assert.True(t, threads.IsActiveFor("C01", "1665.001", "U01"))
+	assert.False(t, threads.IsActiveFor("C01", "1665.001", "U02"), "different user")
+	assert.False(t, threads.IsActiveFor("C01", "1665.002", "U01"), "different thread")
+	assert.False(t, threads.IsActiveFor("C02", "1665.001", "U01"), "different channel")
+
+	// when activity refreshes the thread expiration
+	now = now.Add(50 * time.Minute)
+	assert.True(t, threads.IsActiveFor("C01", "1665.001", "U01"))
+	now = now.Add(50 * time.Minute)
+
+	// then
+	assert.True(t, threads.IsActiveFor("C01", "1665.001", "U01"))
+
+	// when the thread expires
+	now = now.Add(2 * time.Hour)
+
+	// then
+	assert.False(t, threads.IsActiveFor("C01", "1665.001", "U01"))
+	assert.Empty(t, threads.threads)
+}
+
+func TestSlackActiveThreads_IgnoreMessagesOutsideThread(t *testing.T) {
+	// given
+	threads := newSlackActiveThreads(time.Hour)
+
+	// when
+	threads.Activate("C01", "", "U01")
+
+	// then
+	assert.Empty(t, threads.threads)
+}
+
+func TestSocketSlack_ActiveThreadMessage(t *testing.T) {
+	// given
+	botMentionRegex, err := slackBotMentionRegex("B01")
+	require.NoError(t, err)
+
+	bot := &SocketSlack{
+		botID:              "B01",
+		botMentionRegex:    botMentionRegex,
+		activeThreads:      newSlackActiveThreads(time.Hour),
+		activeThreadPrefix: "!",
+	}
+	bot.activeThreads.Activate("C01", "1665.001", "U01")
+
+	testCases := []struct {
+		Name          string
+		Event         slackevents.MessageEvent
+		ParentUserID  string
+		ExpectedFound bool
+		ExpectedText  string
+	}{
+		{
+			Name:          "Message with prefix from the same user in active thread",
+			Event:         slackevents.MessageEvent{Text: "!get pods", Channel: "C01", ThreadTimeStamp: "1665.001", User: "U01"},
+			ParentUserID:  "U01",
+			ExpectedFound: true,
+			ExpectedText:  "get pods",
+		},
+		{
+			Name:          "Reply in thread started by the bot",
+			Event:         slackevents.MessageEvent{Text: "get pods", Channel: "C01", ThreadTimeStamp: "1665.001", User: "U01"},
+			ParentUserID:  "B01",
+			ExpectedFound: true,
+			ExpectedText:  "get pods",
+		},
+		{
+			Name:         "Message without prefix in thread started by a user",
+			Event:        slackevents.MessageEvent{Text: "thanks, it works now", Channel: "C01", ThreadTimeStamp: "1665.001", User: "U01"},
+			ParentUserID: "U01",
+		},
+		{
+			Name:         "Prefix only",
+			Event:        slackevents.MessageEvent{Text: "! ", Channel: "C01", ThreadTimeStamp: "1665.001", User: "U01"},
+			ParentUserID: "U01",
+		},
+		{
+			Name:         "Message from a different user",
+			Event:        slackevents.MessageEvent{Text: "!get pods", Channel: "C01", ThreadTimeStamp: "1665.001", User: "U02"},
+			ParentUserID: "B01",
+		},
+		{
+			Name:         "Message in inactive thread",
+			Event:        slackevents.MessageEvent{Text: "!get pods", Channel: "C01", ThreadTimeStamp: "1665.002", User: "U01"},
+			ParentUserID: "B01",
+		},
+		{
+			Name:  "Message with bot mention",
+			Event: slackevents.MessageEvent{Text: "<@B01> get pods", Channel: "C01", ThreadTimeStamp: "1665.001", User: "U01"},
+		},
+		{
+			Name:  "Message outside thread",
+			Event: slackevents.MessageEvent{Text: "!get pods", Channel: "C01", User: "U01"},
+		},
+		{
+			Name:  "Edited message",
+			Event: slackevents.MessageEvent{Text: "!get pods", Channel: "C01", ThreadTimeStamp: "1665.001", User: "U01", SubType: "message_changed"},
+		},
+		{
+			Name:  "Bot message",
+			Event: slackevents.MessageEvent{Text: "!get pods", Channel: "C01", ThreadTimeStamp: "1665.001", User: "U01", BotID: "B02"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			ev := tc.Event
+
+			// when
+			msg, found := bot.activeThreadMessage(&ev, tc.ParentUserID)
+
+			// then
+			require.Equal(t, tc.ExpectedFound, found)
+			if !tc.ExpectedFound {
+				return
+			}
+			assert.Equal(t, socketSlackMessage{
+				Text:               tc.ExpectedText,
+				Channel:            ev.Channel,
+				ThreadTimeStamp:    ev.ThreadTimeStamp,
+				User:               ev.User,
+				CommandOrigin:      command.TypedOrigin,
+				WithinActiveThread: true,
+			}, msg)
+		})
+	}
+}
+
+func TestSocketSlack_HandleMessageActivatesThread(t *testing.T) {
+	// given
+	testCases := []struct {
+		Name            string
+		Text            string
+		ChannelName     string
+		ExpectActivated bool
+	}{
+		{
+			Name:            "Command with mention in configured channel",
+			Text:            "<@B01> kubectl get pods",
+			ChannelName:     "general",
+			ExpectActivated: true,
+		},
+		{
+			Name:        "Mention without command",
+			Text:        "<@B01> ",
+			ChannelName: "general",
+		},
+		{
+			Name:        "Command which is not permitted",
+			Text:        "<@B01> kubectl delete pod pod-1",
+			ChannelName: "general",
+		},
+		{
+			Name:        "Command in channel which is not configured",
+			Text:        "<@B01> kubectl get pods",
+			ChannelName: "random",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				if r.URL.Path == "/conversations.info" {
+					_, _ = fmt.Fprintf(w, `{"ok": true, "channel": {"id": "C01", "name": %q}}`, tc.ChannelName)
+					return
+				}
+				_, _ = w.Write([]byte(`{"ok": true}`))
+			}))
+			defer srv.Close()
+
+			botMentionRegex, err := slackBotMentionRegex("B01")
+			require.NoError(t, err)
+			logger, _ := logtest.NewNullLogger()
+			seenUsers := newSlackSeenUsers()
+			seenUsers.MarkSeen("C01", "U01")
+			bot := &SocketSlack{
+				log:             logger,
+				botID:           "B01",
+				botMentionRegex: botMentionRegex,
+				client:          slack.New("token", slack.OptionAPIURL(srv.URL+"/")),
+				renderer:        NewSlackRenderer(config.Notification{}),
+				mdFormatter:     interactive.DefaultMDFormatter(),
+				executorFactory: &fakeExecutorFactory{response: interactive.Message{Base: interactive.Base{Description: "done"}}},
+				seenUsers:       seenUsers,
+				activeThreads:   newSlackActiveThreads(time.Hour),
+				channels: map[string]channelConfigByName{
+					"general": {
+						ChannelBindingsByName: config.ChannelBindingsByName{
+							Name:     "general",
+							Commands: config.ChannelCommands{Blocked: []string{"kubectl delete"}},
+						},
+					},
+				},
+			}
+			msg := socketSlackMessage{
+				Text:            tc.Text,
+				Channel:         "C01",
+				TimeStamp:       "1665.002",
+				ThreadTimeStamp: "1665.001",
+				User:            "U01",
+				CommandOrigin:   command.TypedOrigin,
+			}
+
+			// when
+			err = bot.handleMessage(context.Background(), msg)
+
+			// then
+			require.NoError(t, err)
+			assert.Equal(t, tc.ExpectActivated, bot.activeThreads.IsActiveFor("C01", "1665.001", "U01"))
+		})
+	}
+}
+
+func TestSlackMessageParentUserID(t *testing.T) {
+	// given
+	payload := []byte(`{"event": {"type": "message", "thread_ts": "1665.001", "parent_user_id": "B01"}}`)
+
+	// when
+	got := slackMessageParentUserID(payload)
+
+	// then
+	assert.Equal(t, "B01", got)
+	assert.Empty(t, slackMessageParentUserID(nil))
+}
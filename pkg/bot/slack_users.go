@@ -0,0 +1,39 @@
+package bot
+
+import "sync"
+
+type slackChannelUserKey struct {
+	channel string
+	user    string
+}
+
+// slackSeenUsers tracks users who already interacted with Botkube in a given channel.
+// The state is kept in memory, so users are greeted again after Botkube restart.
+type slackSeenUsers struct {
+	mu    sync.Mutex
+	users map[slackChannelUserKey]struct{}
+}
+
+func newSlackSeenUsers() *slackSeenUsers {
+	return &slackSeenUsers{
+		users: map[slackChannelUserKey]struct{}{},
+	}
+}
+
+// MarkSeen marks a given user as seen in a given channel. It returns true if the user wasn't seen before.
+func (s *slackSeenUsers) MarkSeen(channel, user string) bool {
+	if channel == "" || user == "" {
+		return false
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := slackChannelUserKey{channel: channel, user: user}
+	if _, found := s.users[key]; found {
+		return false
+	}
+
+	s.users[key] = struct{}{}
+	return true
+}
@@ -1,13 +1,26 @@
 package bot
 
 import (
+	"bytes"
+	"context"
 	"fmt"
 	"regexp"
+	"time"
 
+	"github.com/slack-go/slack"
+
+	"github.com/kubeshop/botkube/pkg/bot/interactive"
 	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/correlation"
+	"github.com/kubeshop/botkube/pkg/events"
 )
 
-const slackBotMentionPrefixFmt = "^<@%s>"
+const (
+	slackBotMentionPrefixFmt = "^<@%s>"
+	// slackMaxInlineLogsLength is the maximum length of container logs rendered in the event message.
+	// Slack limits the text of a single block to 3000 characters, so longer logs are uploaded as a file.
+	slackMaxInlineLogsLength = 2500
+)
 
 func slackChannelsConfigFrom(channelsCfg config.IdentifiableMap[config.ChannelBindingsByName]) map[string]channelConfigByName {
 	channels := make(map[string]channelConfigByName)
@@ -30,3 +43,77 @@ func slackBotMentionRegex(botID string) (*regexp.Regexp, error) {
 
 	return botMentionRegex, nil
 }
+
+// eventThreadsStore returns the store of the related events threads, or nil if posting events in threads is disabled.
+func eventThreadsStore(cfg config.SlackEventThreads) *correlation.Store {
+	if !cfg.Enabled {
+		return nil
+	}
+	return correlation.NewStore(cfg.TTL)
+}
+
+// detachLongEventLogs removes the container logs from a given event, if they are too long to be rendered in the message.
+func detachLongEventLogs(event events.Event) (events.Event, *events.ContainerLogs) {
+	if event.Logs == nil || len(event.Logs.Content) <= slackMaxInlineLogsLength {
+		return event, nil
+	}
+
+	logs := event.Logs
+	event.Logs = nil
+	return event, logs
+}
+
+// uploadEventLogsToSlack uploads the container logs as a file in a given thread.
+func uploadEventLogsToSlack(ctx context.Context, client *slack.Client, channel, threadTS string, logs *events.ContainerLogs) error {
+	fileName := fmt.Sprintf("%s.log", logs.Container)
+	params := slack.FileUploadParameters{
+		Filename:        fileName,
+		Title:           fileName,
+		InitialComment:  logs.Description(),
+		Content:         logs.Content,
+		Channels:        []string{channel},
+		ThreadTimestamp: threadTS,
+	}
+
+	if _, err := client.UploadFileContext(ctx, params); err != nil {
+		return fmt.Errorf("while uploading container logs: %w", err)
+	}
+	return nil
+}
+
+// uploadAttachmentToSlack uploads the message attachment as a file in a given thread.
+func uploadAttachmentToSlack(client *slack.Client, channel, threadTS string, attachment *interactive.Attachment) error {
+	params := slack.FileUploadParameters{
+		Filename:        attachment.FileName,
+		Title:           attachment.FileName,
+		Content:         attachment.Content,
+		Channels:        []string{channel},
+		ThreadTimestamp: threadTS,
+	}
+	// the binary content is uploaded as a multipart form file, as the `content` parameter supports only text
+	if attachment.Data != nil {
+		params.Content = ""
+		params.Reader = bytes.NewReader(attachment.Data)
+	}
+
+	if _, err := client.UploadFile(params); err != nil {
+		return fmt.Errorf("while uploading message attachment: %w", err)
+	}
+	return nil
+}
+
+// activeThreadsTTL returns the time after which an inactive thread requires the bot mention again.
+func activeThreadsTTL(cfg config.SlackActiveThreads) time.Duration {
+	if cfg.TTL <= 0 {
+		return slackDefaultActiveThreadTTL
+	}
+	return cfg.TTL
+}
+
+// activeThreadsPrefix returns the prefix of the follow-up commands in active threads.
+func activeThreadsPrefix(cfg config.SlackActiveThreads) string {
+	if cfg.Prefix == "" {
+		return slackDefaultActiveThreadPrefix
+	}
+	return cfg.Prefix
+}
@@ -7,6 +7,7 @@ import (
 	"regexp"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
@@ -17,11 +18,14 @@ import (
 	"github.com/kubeshop/botkube/internal/analytics"
 	"github.com/kubeshop/botkube/pkg/bot/interactive"
 	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/correlation"
+	"github.com/kubeshop/botkube/pkg/digest"
 	"github.com/kubeshop/botkube/pkg/events"
 	"github.com/kubeshop/botkube/pkg/execute"
 	"github.com/kubeshop/botkube/pkg/execute/command"
 	"github.com/kubeshop/botkube/pkg/execute/kubectl"
 	"github.com/kubeshop/botkube/pkg/multierror"
+	"github.com/kubeshop/botkube/pkg/mute"
 	"github.com/kubeshop/botkube/pkg/sliceutil"
 	"github.com/kubeshop/botkube/pkg/utils"
 )
@@ -48,16 +52,38 @@ type SocketSlack struct {
 	client           *slack.Client
 	channelsMutex    sync.RWMutex
 	channels         map[string]channelConfigByName
+	staticChannels   map[string]struct{}
+	// orgChannelIDs holds IDs of the channels configured for the Enterprise Grid workspaces, by their keys.
+	orgChannelIDs    map[string]string
+	channelDiscovery config.SlackChannelDiscovery
 	notifyMutex      sync.Mutex
 	botMentionRegex  *regexp.Regexp
 	commGroupName    string
 	renderer         *SlackRenderer
 	mdFormatter      interactive.MDFormatter
+	activeThreads    *slackActiveThreads
+	// activeThreadPrefix marks the follow-up commands in active threads.
+	activeThreadPrefix string
+	seenUsers          *slackSeenUsers
+	workflows          *slackWorkflowsClient
+	streamOpts         slackStreamOptions
+	reactions          config.SlackReactions
+	gracefulShutdown   config.BotGracefulShutdown
+	cmdCancellation    config.SlackCommandCancellation
+	runningCmds        *slackRunningCommands
+	pagedOutputs       *slackPagedOutputs
+	clusterName        string
+	digest             *digest.Scheduler
+	mutes              *mute.Registry
+	eventThreads       *correlation.Store
+	approvals          ActionApprovals
 }
 
 type socketSlackMessage struct {
-	Text            string
-	Channel         string
+	Text    string
+	Channel string
+	// TimeStamp is the timestamp of the message which triggered the command. It's empty for interactive callbacks.
+	TimeStamp       string
 	ThreadTimeStamp string
 	User            string
 	TriggerID       string
@@ -65,6 +91,19 @@ type socketSlackMessage struct {
 	State           *slack.BlockActionStates
 	ResponseURL     string
 	BlockID         string
+	// TeamID is the ID of the workspace where the message was sent. It's used to find the channel configuration in Enterprise Grid.
+	TeamID string
+	// ViewID and ViewHash are set if the message was triggered from an active modal.
+	ViewID   string
+	ViewHash string
+	// WithinActiveThread is true if the message was sent in a thread in which the conversation was already started.
+	// In such case, the bot mention is not required.
+	WithinActiveThread bool
+	// IsDirectMessage is true if the message was sent in a direct message conversation with the bot.
+	// In such case, the bot mention is optional.
+	IsDirectMessage bool
+	// Files holds the files attached to the message. The content of the attached file is passed to the executed command as its standard input.
+	Files []slackevents.File
 }
 
 // socketSlackAnalyticsReporter defines a reporter that collects analytics data.
@@ -74,7 +113,7 @@ type socketSlackAnalyticsReporter interface {
 }
 
 // NewSocketSlack creates a new SocketSlack instance.
-func NewSocketSlack(log logrus.FieldLogger, commGroupName string, cfg config.SocketSlack, executorFactory ExecutorFactory, eventCmdProvider EventCommandProvider, reporter socketSlackAnalyticsReporter) (*SocketSlack, error) {
+func NewSocketSlack(log logrus.FieldLogger, commGroupName string, cfg config.SocketSlack, clusterName string, executorFactory ExecutorFactory, eventCmdProvider EventCommandProvider, reporter socketSlackAnalyticsReporter, approvals ActionApprovals) (*SocketSlack, error) {
 	client := slack.New(cfg.BotToken, slack.OptionAppLevelToken(cfg.AppToken))
 
 	authResp, err := client.AuthTest()
@@ -89,23 +128,55 @@ func NewSocketSlack(log logrus.FieldLogger, commGroupName string, cfg config.Soc
 	}
 
 	channels := slackChannelsConfigFrom(cfg.Channels)
+	orgWorkspaces := cfg.OrgWorkspaces()
+	for key, channel := range slackOrgChannelsConfigFrom(orgWorkspaces) {
+		channels[key] = channel
+	}
+
+	orgChannelIDs, err := resolveSlackOrgChannelIDs(context.Background(), client, orgWorkspaces)
 	if err != nil {
-		return nil, fmt.Errorf("while producing channels configuration map by ID: %w", err)
+		return nil, fmt.Errorf("while resolving Enterprise Grid channels: %w", err)
+	}
+
+	staticChannels := make(map[string]struct{}, len(channels))
+	for name := range channels {
+		staticChannels[name] = struct{}{}
 	}
 
 	mdFormatter := interactive.NewMDFormatter(interactive.NewlineFormatter, mdHeaderFormatter)
 	return &SocketSlack{
-		log:              log,
-		executorFactory:  executorFactory,
-		reporter:         reporter,
-		botID:            botID,
-		client:           client,
-		channels:         channels,
-		commGroupName:    commGroupName,
-		eventCmdProvider: eventCmdProvider,
-		renderer:         NewSlackRenderer(cfg.Notification),
-		botMentionRegex:  botMentionRegex,
-		mdFormatter:      mdFormatter,
+		log:                log,
+		executorFactory:    executorFactory,
+		reporter:           reporter,
+		botID:              botID,
+		client:             client,
+		channels:           channels,
+		staticChannels:     staticChannels,
+		orgChannelIDs:      orgChannelIDs,
+		channelDiscovery:   cfg.ChannelDiscovery,
+		commGroupName:      commGroupName,
+		eventCmdProvider:   eventCmdProvider,
+		renderer:           NewSlackRenderer(cfg.Notification),
+		botMentionRegex:    botMentionRegex,
+		mdFormatter:        mdFormatter,
+		activeThreads:      newSlackActiveThreads(activeThreadsTTL(cfg.ActiveThreads)),
+		activeThreadPrefix: activeThreadsPrefix(cfg.ActiveThreads),
+		seenUsers:          newSlackSeenUsers(),
+		workflows:          newSlackWorkflowsClient(cfg.BotToken),
+		clusterName:        clusterName,
+		reactions:          cfg.Reactions,
+		gracefulShutdown:   cfg.GracefulShutdown,
+		streamOpts: slackStreamOptions{
+			runningMsgDelay: slackRunningMsgDelay,
+			updateInterval:  slackStreamUpdateInterval,
+		},
+		cmdCancellation: cfg.CommandCancellation,
+		runningCmds:     newSlackRunningCommands(),
+		pagedOutputs:    newSlackPagedOutputs(cfg.OutputPagination),
+		digest:          digest.NewScheduler(log),
+		mutes:           mute.NewRegistry(),
+		eventThreads:    eventThreadsStore(cfg.EventThreads),
+		approvals:       approvals,
 	}, nil
 }
 
@@ -126,12 +197,35 @@ func (b *SocketSlack) Start(ctx context.Context) error {
 		}
 	}()
 
+	if b.channelDiscovery.Enabled {
+		go func() {
+			defer analytics.ReportPanicIfOccurs(b.log, b.reporter)
+			b.runChannelDiscovery(ctx)
+		}()
+	}
+
+	go func() {
+		defer analytics.ReportPanicIfOccurs(b.log, b.reporter)
+		b.digest.Run(ctx, b.sendDigest)
+	}()
+
+	// Commands are executed with a separate context, so they can finish once the shutdown is requested.
+	// As the events are processed sequentially, there are no in-flight commands once the loop below returns.
+	cmdCtx, cancelCmds := drainingContext(ctx, b.gracefulShutdown.DrainTimeout)
+	defer cancelCmds()
+
+	events := b.forwardEvents(ctx, websocketClient)
+
 	for {
 		select {
 		case <-ctx.Done():
 			b.log.Info("Shutdown requested. Finishing...")
+			b.sendShutdownMessage()
 			return nil
-		case event := <-websocketClient.Events:
+		case event := <-events:
+			if ctx.Err() != nil {
+				continue // shutdown requested, don't start new commands
+			}
 			switch event.Type {
 			case socketmode.EventTypeConnecting:
 				b.log.Info("Botkube is connecting to Slack...")
@@ -156,13 +250,43 @@ func (b *SocketSlack) Start(ctx context.Context) error {
 						msg := socketSlackMessage{
 							Text:            ev.Text,
 							Channel:         ev.Channel,
+							TimeStamp:       ev.TimeStamp,
 							ThreadTimeStamp: ev.ThreadTimeStamp,
 							User:            ev.User,
 							CommandOrigin:   command.TypedOrigin,
+							Files:           slackAppMentionFiles(event.Request.Payload),
+							TeamID:          eventsAPIEvent.TeamID,
+						}
+						if err := b.handleMessage(cmdCtx, msg); err != nil {
+							b.log.Errorf("Message handling error: %s", err.Error())
+						}
+					case *slackevents.MessageEvent:
+						if msg, ok := b.directMessage(ev); ok {
+							msg.TeamID = eventsAPIEvent.TeamID
+							b.log.Debugf("Got direct message %s", utils.StructDumper().Sdump(innerEvent))
+							if err := b.handleMessage(cmdCtx, msg); err != nil {
+								b.log.Errorf("Message handling error: %s", err.Error())
+							}
+							continue
+						}
+
+						msg, ok := b.activeThreadMessage(ev, slackMessageParentUserID(event.Request.Payload))
+						if !ok {
+							continue
 						}
-						if err := b.handleMessage(ctx, msg); err != nil {
+						msg.TeamID = eventsAPIEvent.TeamID
+						b.log.Debugf("Got message in active thread %s", utils.StructDumper().Sdump(innerEvent))
+						if err := b.handleMessage(cmdCtx, msg); err != nil {
 							b.log.Errorf("Message handling error: %s", err.Error())
 						}
+					case *slackevents.WorkflowStepExecuteEvent:
+						if ev.CallbackID != slackWorkflowStepCallbackID {
+							continue
+						}
+						b.log.Debugf("Got workflow step execution %s", utils.StructDumper().Sdump(innerEvent))
+						if err := b.executeWorkflowStep(cmdCtx, ev); err != nil {
+							b.log.Errorf("Workflow step execution error: %s", err.Error())
+						}
 					}
 				}
 			case socketmode.EventTypeInteractive:
@@ -192,17 +316,22 @@ func (b *SocketSlack) Start(ctx context.Context) error {
 						continue // skip the url actions
 					}
 
+					cmd, cmdOrigin := resolveBlockActionCommand(*act)
+
 					channelID := callback.Channel.ID
+					var viewID, viewHash string
 					if channelID == "" && callback.View.ID != "" {
-						// TODO: add support when we will need to handle button clicks from active modal.
-						//
-						// The request is coming from active modal, currently we don't support that.
-						// We process that only when the modal is submitted (see slack.InteractionTypeViewSubmission action type).
-						b.log.Debug("Ignoring callback as its source is an active modal")
-						continue
+						// The request is coming from active modal.
+						// Multi-select values are form fields, so we process them only when the modal is submitted
+						// (see slack.InteractionTypeViewSubmission action type).
+						if cmdOrigin == command.MultiSelectValueChangeOrigin {
+							b.log.Debug("Ignoring multi-select callback as its source is an active modal")
+							continue
+						}
+						channelID = callback.View.PrivateMetadata
+						viewID, viewHash = callback.View.ID, callback.View.Hash
 					}
 
-					cmd, cmdOrigin := resolveBlockActionCommand(*act)
 					// Use thread's TS if interactive call triggered within thread.
 					threadTs := callback.MessageTs
 					if callback.Message.Msg.ThreadTimestamp != "" {
@@ -218,11 +347,33 @@ func (b *SocketSlack) Start(ctx context.Context) error {
 						State:           callback.BlockActionState,
 						ResponseURL:     callback.ResponseURL,
 						BlockID:         act.BlockID,
+						TeamID:          callback.Team.ID,
+						ViewID:          viewID,
+						ViewHash:        viewHash,
+					}
+					if cmdOrigin == command.ButtonClickOrigin && b.isApprovalDecision(cmd) {
+						if err := b.handleApprovalDecision(msg); err != nil {
+							b.log.Errorf("Approval handling error: %s", err.Error())
+						}
+						continue
 					}
-					if err := b.handleMessage(ctx, msg); err != nil {
+					if err := b.handleMessage(cmdCtx, msg); err != nil {
 						b.log.Errorf("Message handling error: %s", err.Error())
 					}
+				case slack.InteractionTypeWorkflowStepEdit:
+					if callback.CallbackID != slackWorkflowStepCallbackID {
+						continue
+					}
+					if err := b.openWorkflowStepConfiguration(callback); err != nil {
+						b.log.Errorf("Workflow step edit error: %s", err.Error())
+					}
 				case slack.InteractionTypeViewSubmission: // this event is received when modal is submitted
+					if callback.View.Type == slack.VTWorkflowStep {
+						if err := b.saveWorkflowStepConfiguration(callback); err != nil {
+							b.log.Errorf("Workflow step configuration error: %s", err.Error())
+						}
+						continue
+					}
 
 					// the map key is the ID of the input block, for us, it's autogenerated
 					for _, item := range callback.View.State.Values {
@@ -235,9 +386,10 @@ func (b *SocketSlack) Start(ctx context.Context) error {
 								Channel:       callback.View.PrivateMetadata,
 								User:          callback.User.ID,
 								CommandOrigin: cmdOrigin,
+								TeamID:        callback.Team.ID,
 							}
 
-							if err := b.handleMessage(ctx, msg); err != nil {
+							if err := b.handleMessage(cmdCtx, msg); err != nil {
 								b.log.Errorf("Message handling error: %s", err.Error())
 							}
 						}
@@ -274,7 +426,7 @@ func (b *SocketSlack) NotificationsEnabled(channelName string) bool {
 		return false
 	}
 
-	return channel.notify
+	return channel.notificationsEnabled()
 }
 
 // SetNotificationsEnabled sets a new notification status for a given channel name.
@@ -290,22 +442,128 @@ func (b *SocketSlack) SetNotificationsEnabled(channelName string, enabled bool)
 	}
 
 	channel.notify = enabled
+	channel.Notification.SnoozedUntil = time.Time{}
 	channels[channelName] = channel
 	b.setChannels(channels)
 
 	return nil
 }
 
+// SnoozeNotifications disables notifications for a given channel until a given time.
+func (b *SocketSlack) SnoozeNotifications(channelName string, until time.Time) error {
+	// avoid race conditions with using the setter concurrently, as we set whole map
+	b.notifyMutex.Lock()
+	defer b.notifyMutex.Unlock()
+
+	channels := b.getChannels()
+	channel, exists := channels[channelName]
+	if !exists {
+		return execute.ErrNotificationsNotConfigured
+	}
+
+	channel.notify = true
+	channel.Notification.SnoozedUntil = until
+	channels[channelName] = channel
+	b.setChannels(channels)
+
+	return nil
+}
+
+// NotificationsSnoozedUntil returns the snooze end time for a given channel.
+func (b *SocketSlack) NotificationsSnoozedUntil(channelName string) time.Time {
+	return b.getChannels()[channelName].Notification.SnoozedUntil
+}
+
+// MuteNotifications suppresses notifications matching a given rule for a given channel.
+func (b *SocketSlack) MuteNotifications(channelName string, rule mute.Rule) error {
+	if _, exists := b.getChannels()[channelName]; !exists {
+		return execute.ErrNotificationsNotConfigured
+	}
+
+	b.mutes.Add(channelName, rule)
+	return nil
+}
+
+// UnmuteNotifications removes the mute rule for the same target as a given rule for a given channel.
+func (b *SocketSlack) UnmuteNotifications(channelName string, rule mute.Rule) bool {
+	return b.mutes.Remove(channelName, rule)
+}
+
+// NotificationMutes returns active mute rules for a given channel.
+func (b *SocketSlack) NotificationMutes(channelName string) []mute.Rule {
+	return b.mutes.List(channelName)
+}
+
+// activeThreadMessage returns a message to handle if a given message event was sent in an active thread by the user
+// who ran a command there. The message must start with the active thread prefix, unless the thread starts with a bot message.
+func (b *SocketSlack) activeThreadMessage(ev *slackevents.MessageEvent, parentUserID string) (socketSlackMessage, bool) {
+	// skip edits, bot messages, and other message subtypes
+	if !isSlackUserMessageSubType(ev.SubType) || ev.BotID != "" || ev.User == "" || ev.User == b.botID {
+		return socketSlackMessage{}, false
+	}
+
+	// messages with the bot mention are already handled as AppMentionEvent
+	if ev.ThreadTimeStamp == "" || b.botMentionRegex.MatchString(ev.Text) {
+		return socketSlackMessage{}, false
+	}
+
+	text := strings.TrimSpace(ev.Text)
+	switch {
+	case b.activeThreadPrefix != "" && strings.HasPrefix(text, b.activeThreadPrefix):
+		text = strings.TrimSpace(strings.TrimPrefix(text, b.activeThreadPrefix))
+	case parentUserID != "" && parentUserID == b.botID:
+	default:
+		return socketSlackMessage{}, false
+	}
+
+	if text == "" || !b.activeThreads.IsActiveFor(ev.Channel, ev.ThreadTimeStamp, ev.User) {
+		return socketSlackMessage{}, false
+	}
+
+	return socketSlackMessage{
+		Text:               text,
+		Channel:            ev.Channel,
+		TimeStamp:          ev.TimeStamp,
+		ThreadTimeStamp:    ev.ThreadTimeStamp,
+		User:               ev.User,
+		CommandOrigin:      command.TypedOrigin,
+		WithinActiveThread: true,
+		Files:              ev.Files,
+	}, true
+}
+
 func (b *SocketSlack) handleMessage(ctx context.Context, event socketSlackMessage) error {
-	// Handle message only if starts with mention
-	request, found := b.findAndTrimBotMention(event.Text)
-	if !found {
-		b.log.Debugf("Ignoring message as it doesn't contain %q mention", b.botID)
-		return nil
+	request := event.Text
+	mentioned := false
+	switch {
+	case event.WithinActiveThread:
+	case event.IsDirectMessage:
+		if trimmed, found := b.findAndTrimBotMention(event.Text); found {
+			request = trimmed
+		}
+	default:
+		// Handle message only if starts with mention
+		trimmed, found := b.findAndTrimBotMention(event.Text)
+		if !found {
+			b.log.Debugf("Ignoring message as it doesn't contain %q mention", b.botID)
+			return nil
+		}
+		request = trimmed
+		mentioned = true
 	}
 
 	b.log.Debugf("Slack incoming Request: %s", request)
 
+	b.ackCommandReceived(event)
+	succeeded := false
+	defer func() {
+		b.ackCommandFinished(event, succeeded)
+	}()
+
+	if event.CommandOrigin == command.TypedOrigin {
+		b.welcomeUserIfFirstInteraction(event, request)
+	}
+
 	// Unfortunately we need to do a call for channel name based on ID every time a message arrives.
 	// I wanted to query for channel IDs based on names and prepare a map in the `slackChannelsConfigFrom`,
 	// but unfortunately Botkube would need another scope (get all conversations).
@@ -315,7 +573,26 @@ func (b *SocketSlack) handleMessage(ctx context.Context, event socketSlackMessag
 		return fmt.Errorf("while getting conversation info: %w", err)
 	}
 
-	channel, isAuthChannel := b.getChannels()[info.Name]
+	channelKey, channel, isAuthChannel := b.findChannel(event.TeamID, slackConversationName(info))
+	if !execute.IsCommandPermitted(channel.Commands, request) {
+		b.log.Debugf("Command %q is not permitted in channel %q", request, channel.Identifier())
+		if err := b.send(event, execute.CommandNotPermittedMessage(request)); err != nil {
+			return fmt.Errorf("while sending message: %w", err)
+		}
+		return nil
+	}
+
+	stdin, err := b.downloadAttachment(ctx, event.Files)
+	if err != nil {
+		var attachmentErr *slackAttachmentError
+		if !errors.As(err, &attachmentErr) {
+			return fmt.Errorf("while downloading attachment: %w", err)
+		}
+		if err := b.send(event, attachmentErrorMessage(attachmentErr)); err != nil {
+			return fmt.Errorf("while sending message: %w", err)
+		}
+		return nil
+	}
 
 	e := b.executorFactory.NewDefault(execute.NewDefaultInput{
 		CommGroupName:   b.commGroupName,
@@ -323,24 +600,98 @@ func (b *SocketSlack) handleMessage(ctx context.Context, event socketSlackMessag
 		NotifierHandler: b,
 		Conversation: execute.Conversation{
 			Alias:            channel.alias,
-			ID:               channel.Identifier(),
+			ID:               channelKey,
 			ExecutorBindings: channel.Bindings.Executors,
+			Commands:         channel.Commands,
+			Locale:           channel.Locale,
 			IsAuthenticated:  isAuthChannel,
 			CommandOrigin:    event.CommandOrigin,
 			State:            event.State,
 		},
 		Message: request,
 		User:    fmt.Sprintf("<@%s>", event.User),
+		UserID:  event.User,
+		Stdin:   stdin,
 	})
-	response := e.Execute(ctx)
-	err = b.send(event, response)
+	err = b.executeAndSend(ctx, e, event, request, isAuthChannel)
 	if err != nil {
 		return fmt.Errorf("while sending message: %w", err)
 	}
 
+	// only a command run with the mention in a configured channel starts a conversation in the thread
+	if mentioned && isAuthChannel && strings.TrimSpace(request) != "" {
+		b.activeThreads.Activate(event.Channel, event.ThreadTimeStamp, event.User)
+	}
+
+	succeeded = isAuthChannel
 	return nil
 }
 
+// RunScheduledCommand executes a given scheduled command and posts its output in the command channel.
+func (b *SocketSlack) RunScheduledCommand(ctx context.Context, cmd execute.ScheduledCommand) (bool, error) {
+	if cmd.CommGroup != b.commGroupName || cmd.Platform != b.IntegrationName() {
+		return false, nil
+	}
+	// in Enterprise Grid, each workspace connection handles only its own channels
+	channel, found := b.getChannels()[cmd.ChannelID]
+	if !found {
+		return false, nil
+	}
+
+	event := socketSlackMessage{
+		Channel:       b.postTarget(cmd.ChannelID),
+		User:          cmd.UserID,
+		CommandOrigin: command.ScheduleOrigin,
+	}
+	if !execute.IsCommandPermitted(channel.Commands, cmd.Command) {
+		b.log.Debugf("Scheduled command %q is not permitted in channel %q", cmd.Command, channel.Identifier())
+		if err := b.send(event, execute.CommandNotPermittedMessage(cmd.Command)); err != nil {
+			return true, fmt.Errorf("while sending message: %w", err)
+		}
+		return true, nil
+	}
+
+	e := b.executorFactory.NewDefault(execute.NewDefaultInput{
+		CommGroupName:   b.commGroupName,
+		Platform:        b.IntegrationName(),
+		NotifierHandler: b,
+		Conversation: execute.Conversation{
+			Alias:            channel.alias,
+			ID:               cmd.ChannelID,
+			ExecutorBindings: channel.Bindings.Executors,
+			Commands:         channel.Commands,
+			Locale:           channel.Locale,
+			IsAuthenticated:  true,
+			CommandOrigin:    command.ScheduleOrigin,
+		},
+		Message: cmd.Command,
+		User:    cmd.User,
+		UserID:  cmd.UserID,
+	})
+	if err := b.send(event, e.Execute(ctx)); err != nil {
+		return true, fmt.Errorf("while sending message: %w", err)
+	}
+	return true, nil
+}
+
+// welcomeUserIfFirstInteraction sends the onboarding message to a user who mentions Botkube in a given channel for the first time.
+// The message is visible only for the user.
+func (b *SocketSlack) welcomeUserIfFirstInteraction(event socketSlackMessage, request string) {
+	if !b.seenUsers.MarkSeen(event.Channel, event.User) {
+		return
+	}
+
+	// the user asked for help explicitly, so the onboarding message would be redundant
+	if fields := strings.Fields(request); len(fields) > 0 && strings.EqualFold(fields[0], "help") {
+		return
+	}
+
+	msg := interactive.NewHelpMessage(b.IntegrationName(), b.clusterName, b.BotName()).BuildWelcome(fmt.Sprintf("<@%s>", event.User))
+	if err := b.send(event, msg); err != nil {
+		b.log.Errorf("while sending welcome message: %s", err.Error())
+	}
+}
+
 func (b *SocketSlack) send(event socketSlackMessage, resp interactive.Message) error {
 	b.log.Debugf("Slack Response: %s", resp)
 
@@ -354,24 +705,41 @@ func (b *SocketSlack) send(event socketSlackMessage, resp interactive.Message) e
 	var file *slack.File
 	var err error
 	if len(markdown) >= slackMaxMessageSize {
+		if sent, err := b.sendPaginated(event, resp); sent || err != nil {
+			return err
+		}
+
 		file, err = uploadFileToSlack(event.Channel, resp, b.client, event.ThreadTimeStamp)
 		if err != nil {
 			return err
 		}
 		resp = interactive.Message{
 			PlaintextInputs: resp.PlaintextInputs,
+			Attachment:      resp.Attachment,
 		}
 	}
 
-	// we can open modal only if we have a TriggerID (it's available when user clicks a button)
-	if resp.Type == interactive.Popup && event.TriggerID != "" {
-		modalView := b.renderer.RenderModal(resp)
-		modalView.PrivateMetadata = event.Channel
-		_, err := b.client.OpenView(event.TriggerID, modalView)
-		if err != nil {
-			return fmt.Errorf("while opening modal: %w", err)
+	if resp.Type == interactive.Popup {
+		switch {
+		// the message was triggered from an active modal, so we replace its content
+		case event.ViewID != "":
+			modalView := b.renderer.RenderModal(resp)
+			modalView.PrivateMetadata = event.Channel
+			_, err := b.client.UpdateView(modalView, "", event.ViewHash, event.ViewID)
+			if err != nil {
+				return fmt.Errorf("while updating modal: %w", err)
+			}
+			return nil
+		// we can open modal only if we have a TriggerID (it's available when user clicks a button)
+		case event.TriggerID != "":
+			modalView := b.renderer.RenderModal(resp)
+			modalView.PrivateMetadata = event.Channel
+			_, err := b.client.OpenView(event.TriggerID, modalView)
+			if err != nil {
+				return fmt.Errorf("while opening modal: %w", err)
+			}
+			return nil
 		}
-		return nil
 	}
 
 	options := []slack.MsgOption{
@@ -391,9 +759,19 @@ func (b *SocketSlack) send(event socketSlackMessage, resp interactive.Message) e
 			return fmt.Errorf("while posting Slack message visible only to user: %w", err)
 		}
 	} else {
-		if _, _, err := b.client.PostMessage(event.Channel, options...); err != nil {
+		channelID, timestamp, err := b.client.PostMessage(event.Channel, options...)
+		if err != nil {
 			return fmt.Errorf("while posting Slack message: %w", err)
 		}
+		if resp.Attachment != nil {
+			threadTS := event.ThreadTimeStamp
+			if threadTS == "" {
+				threadTS = timestamp
+			}
+			if err := uploadAttachmentToSlack(b.client, channelID, threadTS, resp.Attachment); err != nil {
+				return err
+			}
+		}
 	}
 
 	return nil
@@ -403,6 +781,8 @@ func (b *SocketSlack) send(event socketSlackMessage, resp interactive.Message) e
 func (b *SocketSlack) SendEvent(ctx context.Context, event events.Event, eventSources []string) error {
 	b.log.Debugf("Sending to Slack: %+v", event)
 
+	event, longLogs := detachLongEventLogs(event)
+
 	errs := multierror.New()
 	for _, channelName := range b.getChannelsToNotifyForEvent(event, eventSources) {
 		additionalSection := b.getInteractiveEventSectionIfShould(event, channelName)
@@ -411,18 +791,45 @@ func (b *SocketSlack) SendEvent(ctx context.Context, event events.Event, eventSo
 		if additionalSection != nil {
 			additionalSections = append(additionalSections, *additionalSection)
 		}
+		if len(event.SuggestedCommands) > 0 {
+			additionalSections = append(additionalSections, interactive.SuggestedCommandsSection(event.SuggestedCommands))
+		}
+		if len(event.Buttons) > 0 {
+			additionalSections = append(additionalSections, b.eventButtonsSection(event.Buttons))
+		}
 		msg := b.renderer.RenderEventMessage(event, additionalSections...)
 
 		options := []slack.MsgOption{
 			b.renderer.RenderInteractiveMessage(msg),
 		}
+		threadTS, inThread := b.eventThread(channelName, event)
+		if inThread {
+			options = append(options, slack.MsgOptionTS(threadTS))
+		}
 
-		channelID, timestamp, err := b.client.PostMessageContext(ctx, channelName, options...)
+		channelID, timestamp, err := b.client.PostMessageContext(ctx, b.postTarget(channelName), options...)
 		if err != nil {
 			errs = multierror.Append(errs, fmt.Errorf("while posting message to channel %q: %w", channelName, err))
 			continue
 		}
 
+		if b.eventThreads != nil {
+			if !inThread {
+				threadTS = timestamp
+			}
+			b.eventThreads.Set(channelName, event, threadTS)
+		}
+
+		if longLogs != nil {
+			logsThreadTS := timestamp
+			if inThread {
+				logsThreadTS = threadTS
+			}
+			if err := uploadEventLogsToSlack(ctx, b.client, channelID, logsThreadTS, longLogs); err != nil {
+				errs = multierror.Append(errs, fmt.Errorf("while sending logs to channel %q: %w", channelName, err))
+			}
+		}
+
 		b.log.Debugf("Event successfully sent to channel %q (ID: %q) at %b", channelName, channelID, timestamp)
 	}
 
@@ -457,19 +864,45 @@ func (b *SocketSlack) getInteractiveEventSectionIfShould(event events.Event, cha
 	return &section
 }
 
+func (b *SocketSlack) eventButtonsSection(buttons []events.Button) interactive.Section {
+	btnBuilder := interactive.ButtonBuilder{BotName: b.BotName()}
+
+	var section interactive.Section
+	for _, btn := range buttons {
+		section.Buttons = append(section.Buttons, btnBuilder.ForCommandWithoutDesc(btn.Name, btn.Command))
+	}
+	return section
+}
+
+// eventThread returns the timestamp of the message which started a thread for events related to a given one.
+func (b *SocketSlack) eventThread(channelName string, event events.Event) (string, bool) {
+	if b.eventThreads == nil {
+		return "", false
+	}
+	return b.eventThreads.Get(channelName, event)
+}
+
 func (b *SocketSlack) getChannelsToNotifyForEvent(event events.Event, sourceBindings []string) []string {
 	// support custom event routing
 	if event.Channel != "" {
 		return []string{event.Channel}
 	}
 
-	return b.getChannelsToNotify(sourceBindings)
+	return b.digest.Filter(event, b.mutes.Filter(event, channelsToNotifyForEvent(event, sourceBindings, b.getChannels(), b.getChannelsToNotify)), channelDigestByName(b.getChannels))
+}
+
+func (b *SocketSlack) sendDigest(ctx context.Context, channelName string, msg interactive.Message) error {
+	if _, _, err := b.client.PostMessageContext(ctx, b.postTarget(channelName), b.renderer.RenderInteractiveMessage(msg)); err != nil {
+		return fmt.Errorf("while posting message to channel %q: %w", channelName, err)
+	}
+	return nil
 }
 
+// getChannelsToNotify returns keys of the channels to notify. Use postTarget to get the channel to which the message should be posted.
 func (b *SocketSlack) getChannelsToNotify(sourceBindings []string) []string {
 	var out []string
-	for _, cfg := range b.getChannels() {
-		if !cfg.notify {
+	for key, cfg := range b.getChannels() {
+		if !cfg.notificationsEnabled() {
 			b.log.Infof("Skipping notification for channel %q as notifications are disabled.", cfg.Identifier())
 			continue
 		}
@@ -478,7 +911,7 @@ func (b *SocketSlack) getChannelsToNotify(sourceBindings []string) []string {
 			continue
 		}
 
-		out = append(out, cfg.Identifier())
+		out = append(out, key)
 	}
 	return out
 }
@@ -492,7 +925,7 @@ func (b *SocketSlack) SendGenericMessage(_ context.Context, genericMsg interacti
 		b.log.Debugf("Sending message to channel %q: %+v", channelName, msg)
 
 		msgMetadata := socketSlackMessage{
-			Channel:         channelName,
+			Channel:         b.postTarget(channelName),
 			ThreadTimeStamp: "",
 			BlockID:         uuid.New().String(),
 			CommandOrigin:   command.AutomationOrigin,
@@ -511,13 +944,13 @@ func (b *SocketSlack) SendGenericMessage(_ context.Context, genericMsg interacti
 // SendMessageToAll sends message with interactive sections to all Slack channels.
 func (b *SocketSlack) SendMessageToAll(ctx context.Context, msg interactive.Message) error {
 	errs := multierror.New()
-	for _, channel := range b.getChannels() {
+	for key, channel := range b.getChannels() {
 		channelName := channel.Name
 		b.log.Debugf("Sending message to channel %q (alias: %q): %+v", channelName, channel.alias, msg)
 
 		message := b.renderer.RenderInteractiveMessage(msg)
 
-		channelID, timestamp, err := b.client.PostMessageContext(ctx, channelName, message)
+		channelID, timestamp, err := b.client.PostMessageContext(ctx, b.postTarget(key), message)
 		if err != nil {
 			errs = multierror.Append(errs, fmt.Errorf("while sending Slack message to channel %q (alias: %q): %w", channelName, channel.alias, err))
 			continue
@@ -545,6 +978,14 @@ func (b *SocketSlack) setChannels(channels map[string]channelConfigByName) {
 	b.channels = channels
 }
 
+// ReloadChannels reloads the bindings and notification settings of the channels, without reconnecting the bot.
+// Channels of the Enterprise Grid workspaces are not reloaded, as they are configured per workspace.
+func (b *SocketSlack) ReloadChannels(cfg config.SocketSlack) {
+	b.channelsMutex.Lock()
+	defer b.channelsMutex.Unlock()
+	b.channels = reloadedChannelsByName(b.channels, cfg.Channels)
+}
+
 func (b *SocketSlack) findAndTrimBotMention(msg string) (string, bool) {
 	if !b.botMentionRegex.MatchString(msg) {
 		return "", false
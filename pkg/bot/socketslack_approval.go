@@ -0,0 +1,49 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kubeshop/botkube/pkg/approval"
+	"github.com/kubeshop/botkube/pkg/bot/interactive"
+)
+
+// ActionApprovals records the decisions for the automated actions waiting for approval.
+type ActionApprovals interface {
+	Decide(cmd, user string) (approval.Decision, error)
+}
+
+// PostApprovalRequest posts the approval request to a given channel, if the channel is configured for the bot.
+func (b *SocketSlack) PostApprovalRequest(ctx context.Context, channelName string, msg interactive.Message) (bool, error) {
+	if _, found := b.getChannels()[channelName]; !found {
+		return false, nil
+	}
+
+	_, _, err := b.client.PostMessageContext(ctx, b.postTarget(channelName), b.renderer.RenderInteractiveMessage(msg))
+	if err != nil {
+		return true, fmt.Errorf("while posting Slack message: %w", err)
+	}
+	return true, nil
+}
+
+// isApprovalDecision returns true if a given button command approves or rejects an action.
+func (b *SocketSlack) isApprovalDecision(cmd string) bool {
+	return b.approvals != nil && approval.IsCommand(cmd)
+}
+
+// handleApprovalDecision records the approver's decision and replaces the approval request with it.
+// Users who are not allowed to approve the action get a message visible only for them.
+func (b *SocketSlack) handleApprovalDecision(event socketSlackMessage) error {
+	decision, err := b.approvals.Decide(event.Text, event.User)
+	if err != nil {
+		return b.send(event, interactive.Message{
+			Base: interactive.Base{
+				Description: fmt.Sprintf("Cannot resolve the approval request: %s.", err.Error()),
+			},
+			OnlyVisibleForYou: true,
+		})
+	}
+
+	b.log.Infof("User %q resolved the action approval request (approved: %t)", event.User, decision.Approved)
+	return b.send(event, approval.DecisionMessage(decision, fmt.Sprintf("<@%s>", event.User)))
+}
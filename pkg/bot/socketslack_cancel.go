@@ -0,0 +1,204 @@
+package bot
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/slack-go/slack"
+	"github.com/slack-go/slack/socketmode"
+
+	"github.com/kubeshop/botkube/internal/analytics"
+	"github.com/kubeshop/botkube/pkg/bot/interactive"
+	"github.com/kubeshop/botkube/pkg/execute/command"
+)
+
+const (
+	// slackCancelCommandPrefix prefixes the Cancel button commands. Such commands are handled by the bot directly, as soon as they arrive,
+	// and are never executed.
+	slackCancelCommandPrefix  = "command-cancellation"
+	slackCancelledMsgFmt      = "`%s` was cancelled by %s."
+	slackStoppedMsgFmt        = "`%s` was stopped by %s."
+	slackCancelBtnName        = "Cancel"
+	slackStopBtnName          = "Stop"
+	slackCancelOtherUserFmt   = "Sorry, only %s can cancel the `%s` command."
+	slackRunningCommandIDSize = 8
+)
+
+// slackRunningCommand is a command which can be cancelled with the Cancel button.
+type slackRunningCommand struct {
+	request string
+	userID  string
+	cancel  context.CancelFunc
+	// cancelledBy is the ID of the user who cancelled the command. It's empty if the command wasn't cancelled.
+	cancelledBy string
+}
+
+// slackRunningCommands tracks the commands which can be cancelled. The ID of a given command is embedded in its Cancel button.
+type slackRunningCommands struct {
+	mu       sync.Mutex
+	commands map[string]*slackRunningCommand
+}
+
+func newSlackRunningCommands() *slackRunningCommands {
+	return &slackRunningCommands{commands: map[string]*slackRunningCommand{}}
+}
+
+// add returns a context which is cancelled once the command is cancelled with a given ID. The returned function must be called once the command finishes.
+func (c *slackRunningCommands) add(ctx context.Context, request, userID string) (context.Context, string, func(), error) {
+	raw := make([]byte, slackRunningCommandIDSize)
+	if _, err := rand.Read(raw); err != nil {
+		return nil, "", nil, fmt.Errorf("while generating command ID: %w", err)
+	}
+	id := hex.EncodeToString(raw)
+
+	ctx, cancel := context.WithCancel(ctx)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.commands[id] = &slackRunningCommand{
+		request: strings.TrimSpace(request),
+		userID:  userID,
+		cancel:  cancel,
+	}
+
+	return ctx, id, func() {
+		cancel()
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		delete(c.commands, id)
+	}, nil
+}
+
+// cancelBy cancels a given command on behalf of a given user. If the command was sent by a different user, it's not cancelled
+// and the returned message explains why. It returns false if the command is unknown, e.g. it has already finished.
+func (c *slackRunningCommands) cancelBy(id, userID string) (string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	cmd, found := c.commands[id]
+	if !found {
+		return "", false
+	}
+	if cmd.userID != userID {
+		return fmt.Sprintf(slackCancelOtherUserFmt, fmt.Sprintf("<@%s>", cmd.userID), cmd.request), true
+	}
+
+	cmd.cancelledBy = userID
+	cmd.cancel()
+	return "", true
+}
+
+// cancelledBy returns the ID of the user who cancelled a given command, or an empty string if it wasn't cancelled.
+func (c *slackRunningCommands) cancelledBy(id string) string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	cmd, found := c.commands[id]
+	if !found {
+		return ""
+	}
+	return cmd.cancelledBy
+}
+
+// forwardEvents forwards the Socket Mode events to the returned channel, apart from the Cancel and page button clicks, which are handled immediately.
+// The other events are processed sequentially, so the click would wait until the command that is supposed to be cancelled finishes.
+func (b *SocketSlack) forwardEvents(ctx context.Context, client *socketmode.Client) <-chan socketmode.Event {
+	out := make(chan socketmode.Event)
+	go func() {
+		defer analytics.ReportPanicIfOccurs(b.log, b.reporter)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case event := <-client.Events:
+				if callback, cmd, ok := slackCancelClick(event); ok {
+					client.Ack(*event.Request)
+					b.handleCancelClick(callback, cmd)
+					continue
+				}
+				if callback, id, cmd, ok := slackPageClick(event); ok && b.pagedOutputs != nil {
+					client.Ack(*event.Request)
+					b.handlePageClick(callback, id, cmd)
+					continue
+				}
+
+				select {
+				case out <- event:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+	return out
+}
+
+// handleCancelClick cancels the command with the ID from a given Cancel button command.
+// Clicks of other users get a message visible only for them, while the clicks of the buttons sent by other Botkube instances are ignored.
+func (b *SocketSlack) handleCancelClick(callback slack.InteractionCallback, cmd string) {
+	id := strings.TrimSpace(strings.TrimPrefix(cmd, slackCancelCommandPrefix))
+	msg, found := b.runningCmds.cancelBy(id, callback.User.ID)
+	if !found {
+		b.log.Debugf("Ignoring cancellation of unknown command %q", id)
+		return
+	}
+	if msg == "" {
+		b.log.Infof("User %q cancelled the command %q", callback.User.ID, id)
+		return
+	}
+
+	err := b.send(socketSlackMessage{
+		Channel:       callback.Channel.ID,
+		User:          callback.User.ID,
+		CommandOrigin: command.ButtonClickOrigin,
+	}, interactive.Message{
+		Base: interactive.Base{
+			Description: msg,
+		},
+		OnlyVisibleForYou: true,
+	})
+	if err != nil {
+		b.log.Errorf("while sending cancellation message: %s", err.Error())
+	}
+}
+
+// slackCancelClick returns the Cancel button command if a given event is a click on such button.
+func slackCancelClick(event socketmode.Event) (slack.InteractionCallback, string, bool) {
+	if event.Type != socketmode.EventTypeInteractive || event.Request == nil {
+		return slack.InteractionCallback{}, "", false
+	}
+	callback, ok := event.Data.(slack.InteractionCallback)
+	if !ok || callback.Type != slack.InteractionTypeBlockActions || len(callback.ActionCallback.BlockActions) != 1 {
+		return slack.InteractionCallback{}, "", false
+	}
+
+	act := callback.ActionCallback.BlockActions[0]
+	if act == nil || act.Type != "button" || !strings.HasPrefix(act.Value, slackCancelCommandPrefix+" ") {
+		return slack.InteractionCallback{}, "", false
+	}
+	return callback, act.Value, true
+}
+
+// cancelButton returns the button which cancels the command with a given ID.
+func cancelButton(name, id string) interactive.Button {
+	return interactive.Button{
+		Name:    name,
+		Command: fmt.Sprintf("%s %s", slackCancelCommandPrefix, id),
+		Style:   interactive.ButtonStyleDanger,
+	}
+}
+
+// cancelledMessage returns the response of a command cancelled by a given user, with the output received so far.
+func cancelledMessage(msgFmt, request, userID, output string) interactive.Message {
+	return interactive.Message{
+		Base: interactive.Base{
+			Description: fmt.Sprintf(msgFmt, strings.TrimSpace(request), fmt.Sprintf("<@%s>", userID)),
+			Body: interactive.Body{
+				CodeBlock: tailOutput(output, slackStreamMaxOutputSize),
+			},
+		},
+	}
+}
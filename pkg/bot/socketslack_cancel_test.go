@@ -0,0 +1,246 @@
+package bot
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"sync"
+	"testing"
+	"time"
+
+	logtest "github.com/sirupsen/logrus/hooks/test"
+	"github.com/slack-go/slack"
+	"github.com/slack-go/slack/socketmode"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/pkg/bot/interactive"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/execute"
+)
+
+func TestSlackRunningCommandsCancelBy(t *testing.T) {
+	// given
+	cmds := newSlackRunningCommands()
+	ctx, id, done, err := cmds.add(context.Background(), " logs -f nginx ", "U01")
+	require.NoError(t, err)
+
+	// when
+	msg, found := cmds.cancelBy(id, "U02")
+
+	// then
+	assert.True(t, found)
+	assert.Equal(t, "Sorry, only <@U01> can cancel the `logs -f nginx` command.", msg)
+	assert.NoError(t, ctx.Err())
+	assert.Empty(t, cmds.cancelledBy(id))
+
+	// when
+	msg, found = cmds.cancelBy(id, "U01")
+
+	// then
+	assert.True(t, found)
+	assert.Empty(t, msg)
+	assert.ErrorIs(t, ctx.Err(), context.Canceled)
+	assert.Equal(t, "U01", cmds.cancelledBy(id))
+
+	// when
+	done()
+	_, found = cmds.cancelBy(id, "U01")
+
+	// then
+	assert.False(t, found)
+	assert.Empty(t, cmds.cancelledBy(id))
+}
+
+func TestSlackCancelClick(t *testing.T) {
+	// given
+	newEvent := func(actions ...*slack.BlockAction) socketmode.Event {
+		return socketmode.Event{
+			Type: socketmode.EventTypeInteractive,
+			Data: slack.InteractionCallback{
+				Type:           slack.InteractionTypeBlockActions,
+				ActionCallback: slack.ActionCallbacks{BlockActions: actions},
+			},
+			Request: &socketmode.Request{EnvelopeID: "1"},
+		}
+	}
+
+	tests := []struct {
+		name string
+
+		event socketmode.Event
+
+		expCmd string
+		expOK  bool
+	}{
+		{
+			name:   "Cancel button",
+			event:  newEvent(&slack.BlockAction{Type: "button", Value: "command-cancellation a1b2"}),
+			expCmd: "command-cancellation a1b2",
+			expOK:  true,
+		},
+		{
+			name:  "Other button",
+			event: newEvent(&slack.BlockAction{Type: "button", Value: "kubectl get pods"}),
+		},
+		{
+			name:  "Select",
+			event: newEvent(&slack.BlockAction{Type: "static_select", Value: "command-cancellation a1b2"}),
+		},
+		{
+			name:  "Events API event",
+			event: socketmode.Event{Type: socketmode.EventTypeEventsAPI, Request: &socketmode.Request{}},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// when
+			_, cmd, ok := slackCancelClick(tc.event)
+
+			// then
+			assert.Equal(t, tc.expOK, ok)
+			assert.Equal(t, tc.expCmd, cmd)
+		})
+	}
+}
+
+func TestSocketSlack_ExecuteAndSendCancelled(t *testing.T) {
+	// given
+	var (
+		mu          sync.Mutex
+		gotPaths    []string
+		gotContents []string
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		mu.Lock()
+		defer mu.Unlock()
+		gotPaths = append(gotPaths, r.URL.Path)
+		gotContents = append(gotContents, r.PostForm.Get("text")+r.PostForm.Get("blocks"))
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"ok": true, "channel": "C01", "ts": "1665.001"}`))
+	}))
+	defer srv.Close()
+
+	logger, _ := logtest.NewNullLogger()
+	bot := &SocketSlack{
+		log:         logger,
+		client:      slack.New("token", slack.OptionAPIURL(srv.URL+"/")),
+		renderer:    NewSlackRenderer(config.Notification{}),
+		mdFormatter: interactive.DefaultMDFormatter(),
+		streamOpts: slackStreamOptions{
+			runningMsgDelay: time.Minute,
+			updateInterval:  time.Minute,
+		},
+		cmdCancellation: config.SlackCommandCancellation{Enabled: true, After: 10 * time.Millisecond},
+		runningCmds:     newSlackRunningCommands(),
+	}
+	msg := socketSlackMessage{Channel: "C01", User: "U01"}
+
+	errs := make(chan error, 1)
+	go func() {
+		errs <- bot.executeAndSend(context.Background(), &fakeBlockingExecutor{}, msg, "logs -f nginx", true)
+	}()
+
+	cancelCmd := regexp.MustCompile(`command-cancellation [0-9a-f]+`)
+	var id string
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		if len(gotContents) == 0 {
+			return false
+		}
+		id = cancelCmd.FindString(gotContents[0])
+		return id != ""
+	}, time.Second, 5*time.Millisecond, "Running message with Cancel button was not posted")
+
+	// when
+	bot.handleCancelClick(slack.InteractionCallback{User: slack.User{ID: "U01"}}, id)
+
+	// then
+	require.NoError(t, <-errs)
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, []string{"/chat.postMessage", "/chat.update"}, gotPaths)
+	assert.Contains(t, gotContents[1], "was cancelled by <@U01>")
+	assert.NotContains(t, gotContents[1], "command-cancellation")
+}
+
+func TestSocketSlack_ExecuteAndSendWatchStopped(t *testing.T) {
+	// given
+	var (
+		mu          sync.Mutex
+		gotPaths    []string
+		gotContents []string
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		mu.Lock()
+		defer mu.Unlock()
+		gotPaths = append(gotPaths, r.URL.Path)
+		gotContents = append(gotContents, r.PostForm.Get("text")+r.PostForm.Get("blocks"))
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"ok": true, "channel": "C01", "ts": "1665.001"}`))
+	}))
+	defer srv.Close()
+
+	logger, _ := logtest.NewNullLogger()
+	bot := &SocketSlack{
+		log:         logger,
+		client:      slack.New("token", slack.OptionAPIURL(srv.URL+"/")),
+		renderer:    NewSlackRenderer(config.Notification{}),
+		mdFormatter: interactive.DefaultMDFormatter(),
+		streamOpts: slackStreamOptions{
+			runningMsgDelay: time.Minute,
+			updateInterval:  time.Minute,
+		},
+		runningCmds: newSlackRunningCommands(),
+	}
+	msg := socketSlackMessage{Channel: "C01", User: "U01"}
+
+	errs := make(chan error, 1)
+	go func() {
+		errs <- bot.executeAndSend(context.Background(), &fakeBlockingExecutor{watch: true}, msg, "kubectl get pods -w", true)
+	}()
+
+	stopCmd := regexp.MustCompile(`command-cancellation [0-9a-f]+`)
+	var id string
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		if len(gotContents) == 0 {
+			return false
+		}
+		id = stopCmd.FindString(gotContents[0])
+		return id != ""
+	}, time.Second, 5*time.Millisecond, "Running message with Stop button was not posted")
+
+	// when
+	bot.handleCancelClick(slack.InteractionCallback{User: slack.User{ID: "U01"}}, id)
+
+	// then
+	require.NoError(t, <-errs)
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, []string{"/chat.postMessage", "/chat.update"}, gotPaths)
+	assert.Contains(t, gotContents[0], `"text":"Stop"`)
+	assert.Contains(t, gotContents[1], "was stopped by <@U01>")
+}
+
+// fakeBlockingExecutor blocks until its context is cancelled. If watch is set, it reports that it watches resources.
+type fakeBlockingExecutor struct {
+	watch bool
+}
+
+func (f *fakeBlockingExecutor) Execute(ctx context.Context) interactive.Message {
+	return f.ExecuteStream(ctx, func(string) {})
+}
+
+func (f *fakeBlockingExecutor) ExecuteStream(ctx context.Context, _ execute.OutputChunkHandler) interactive.Message {
+	if f.watch {
+		execute.NotifyWatchStarted(ctx)
+	}
+	<-ctx.Done()
+	return interactive.Message{Base: interactive.Base{Description: "context canceled"}}
+}
@@ -0,0 +1,143 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/slack-go/slack"
+
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+const (
+	slackDefaultChannelDiscoveryInterval = 5 * time.Minute
+	slackConversationsPageLimit          = 200
+)
+
+// slackChannelDiscoveryMarkerRegex matches the `botkube:<profile>` marker in the channel topic.
+var slackChannelDiscoveryMarkerRegex = regexp.MustCompile(`(?i)\bbotkube:([\w-]+)`)
+
+// runChannelDiscovery periodically binds channels the bot is a member of, based on the marker in their topic.
+func (b *SocketSlack) runChannelDiscovery(ctx context.Context) {
+	interval := b.channelDiscovery.Interval
+	if interval <= 0 {
+		interval = slackDefaultChannelDiscoveryInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := b.discoverChannels(ctx); err != nil {
+			b.log.Errorf("while discovering channels: %s", err.Error())
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// discoverChannels lists channels the bot is a member of and updates the discovered channels.
+// Statically configured channels take precedence over the discovered ones.
+func (b *SocketSlack) discoverChannels(ctx context.Context) error {
+	discovered := map[string]channelConfigByName{}
+
+	params := &slack.GetConversationsForUserParameters{
+		Types:           []string{"public_channel", "private_channel"},
+		Limit:           slackConversationsPageLimit,
+		ExcludeArchived: true,
+	}
+	for {
+		conversations, nextCursor, err := b.client.GetConversationsForUserContext(ctx, params)
+		if err != nil {
+			return fmt.Errorf("while listing conversations: %w", err)
+		}
+
+		for _, conversation := range conversations {
+			channel, ok := b.discoveredChannel(conversation)
+			if !ok {
+				continue
+			}
+			discovered[channel.Identifier()] = channel
+		}
+
+		if nextCursor == "" {
+			break
+		}
+		params.Cursor = nextCursor
+	}
+
+	b.updateDiscoveredChannels(discovered)
+	return nil
+}
+
+// discoveredChannel returns the configuration for a given conversation if its topic contains the marker with a known profile.
+func (b *SocketSlack) discoveredChannel(conversation slack.Channel) (channelConfigByName, bool) {
+	matches := slackChannelDiscoveryMarkerRegex.FindStringSubmatch(conversation.Topic.Value)
+	if len(matches) < 2 {
+		return channelConfigByName{}, false
+	}
+
+	profileName := matches[1]
+	profile, found := b.channelDiscovery.Profiles[profileName]
+	if !found {
+		b.log.Warnf("Skipping channel %q as its topic refers to unknown profile %q", conversation.Name, profileName)
+		return channelConfigByName{}, false
+	}
+
+	return channelConfigByName{
+		ChannelBindingsByName: config.ChannelBindingsByName{
+			Name:         conversation.Name,
+			Notification: profile.Notification,
+			Bindings:     profile.Bindings,
+			Commands:     profile.Commands,
+			Locale:       profile.Locale,
+		},
+		alias:  fmt.Sprintf("discovered-%s", conversation.Name),
+		notify: !profile.Notification.Disabled,
+	}, true
+}
+
+// updateDiscoveredChannels replaces the previously discovered channels with a given ones.
+// The notification status and snooze of already bound channels are preserved.
+func (b *SocketSlack) updateDiscoveredChannels(discovered map[string]channelConfigByName) {
+	// avoid race conditions with SetNotificationsEnabled, as we set whole map
+	b.notifyMutex.Lock()
+	defer b.notifyMutex.Unlock()
+
+	current := b.getChannels()
+	channels := make(map[string]channelConfigByName, len(b.staticChannels)+len(discovered))
+	for name, channel := range current {
+		if _, isStatic := b.staticChannels[name]; isStatic {
+			channels[name] = channel
+		}
+	}
+
+	for name, channel := range discovered {
+		if _, isStatic := b.staticChannels[name]; isStatic {
+			continue
+		}
+
+		existing, wasBound := current[name]
+		if !wasBound {
+			b.log.Infof("Binding discovered channel %q", name)
+		} else {
+			channel.notify = existing.notify
+			channel.Notification.SnoozedUntil = existing.Notification.SnoozedUntil
+		}
+		channels[name] = channel
+	}
+
+	for name := range current {
+		if _, isBound := channels[name]; !isBound {
+			b.log.Infof("Unbinding channel %q as its topic doesn't contain the marker anymore", name)
+		}
+	}
+
+	b.setChannels(channels)
+}
@@ -0,0 +1,104 @@
+package bot
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	logtest "github.com/sirupsen/logrus/hooks/test"
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+func TestSocketSlack_DiscoverChannels(t *testing.T) {
+	// given
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/users.conversations", r.URL.Path)
+		require.NoError(t, r.ParseForm())
+		assert.Equal(t, "public_channel,private_channel", r.PostForm.Get("types"))
+
+		w.Header().Set("Content-Type", "application/json")
+		if r.PostForm.Get("cursor") == "" {
+			_, _ = w.Write([]byte(`{"ok": true, "channels": [
+				{"id": "C01", "name": "team-a", "topic": {"value": "Alerts for team A botkube:read-only"}},
+				{"id": "C02", "name": "random", "topic": {"value": "Off-topic"}}
+			], "response_metadata": {"next_cursor": "page-2"}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"ok": true, "channels": [
+			{"id": "C03", "name": "team-b", "topic": {"value": "BOTKUBE:admin"}},
+			{"id": "C04", "name": "team-c", "topic": {"value": "botkube:unknown"}},
+			{"id": "C05", "name": "static", "topic": {"value": "botkube:admin"}}
+		]}`))
+	}))
+	defer srv.Close()
+
+	readOnly := config.ChannelBindingsProfile{
+		Bindings: config.BotBindings{Executors: []string{"kubectl-read-only"}, Sources: []string{"k8s-err-events"}},
+	}
+	admin := config.ChannelBindingsProfile{
+		Bindings:     config.BotBindings{Executors: []string{"kubectl-admin"}},
+		Notification: config.ChannelNotification{Disabled: true},
+		Locale:       "de",
+	}
+
+	logger, _ := logtest.NewNullLogger()
+	bot := &SocketSlack{
+		log:    logger,
+		client: slack.New("token", slack.OptionAPIURL(srv.URL+"/")),
+		channelDiscovery: config.SlackChannelDiscovery{
+			Enabled:  true,
+			Profiles: map[string]config.ChannelBindingsProfile{"read-only": readOnly, "admin": admin},
+		},
+		staticChannels: map[string]struct{}{"static": {}},
+		channels: map[string]channelConfigByName{
+			"static": {
+				ChannelBindingsByName: config.ChannelBindingsByName{Name: "static"},
+				alias:                 "default",
+				notify:                true,
+			},
+			"team-a": {
+				ChannelBindingsByName: config.ChannelBindingsByName{Name: "team-a", Bindings: readOnly.Bindings},
+				alias:                 "discovered-team-a",
+				notify:                false, // notifications were disabled by user
+			},
+			"removed": {
+				ChannelBindingsByName: config.ChannelBindingsByName{Name: "removed"},
+				alias:                 "discovered-removed",
+				notify:                true,
+			},
+		},
+	}
+
+	// when
+	err := bot.discoverChannels(context.Background())
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, map[string]channelConfigByName{
+		"static": {
+			ChannelBindingsByName: config.ChannelBindingsByName{Name: "static"},
+			alias:                 "default",
+			notify:                true,
+		},
+		"team-a": {
+			ChannelBindingsByName: config.ChannelBindingsByName{Name: "team-a", Bindings: readOnly.Bindings},
+			alias:                 "discovered-team-a",
+			notify:                false,
+		},
+		"team-b": {
+			ChannelBindingsByName: config.ChannelBindingsByName{
+				Name:         "team-b",
+				Bindings:     admin.Bindings,
+				Notification: admin.Notification,
+				Locale:       "de",
+			},
+			alias:  "discovered-team-b",
+			notify: false,
+		},
+	}, bot.getChannels())
+}
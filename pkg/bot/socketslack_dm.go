@@ -0,0 +1,43 @@
+package bot
+
+import (
+	"github.com/slack-go/slack"
+	"github.com/slack-go/slack/slackevents"
+
+	"github.com/kubeshop/botkube/pkg/execute/command"
+)
+
+// slackIMChannelType is the channel type of the direct message conversations between a user and the bot.
+const slackIMChannelType = "im"
+
+// directMessage returns a message to handle if a given message event was sent by a user in a direct message conversation with the bot.
+func (b *SocketSlack) directMessage(ev *slackevents.MessageEvent) (socketSlackMessage, bool) {
+	if ev.ChannelType != slackIMChannelType {
+		return socketSlackMessage{}, false
+	}
+
+	// skip edits, bot messages, and other message subtypes
+	if !isSlackUserMessageSubType(ev.SubType) || ev.BotID != "" || ev.User == "" || ev.User == b.botID {
+		return socketSlackMessage{}, false
+	}
+
+	return socketSlackMessage{
+		Text:            ev.Text,
+		Channel:         ev.Channel,
+		TimeStamp:       ev.TimeStamp,
+		ThreadTimeStamp: ev.ThreadTimeStamp,
+		User:            ev.User,
+		CommandOrigin:   command.TypedOrigin,
+		IsDirectMessage: true,
+		Files:           ev.Files,
+	}, true
+}
+
+// slackConversationName returns the name under which a given conversation is configured in Botkube.
+// Direct message conversations are configured by the Slack ID of the user.
+func slackConversationName(info *slack.Channel) string {
+	if info.IsIM {
+		return info.User
+	}
+	return info.Name
+}
@@ -0,0 +1,106 @@
+package bot
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/slack-go/slack/slackevents"
+
+	"github.com/kubeshop/botkube/pkg/bot/interactive"
+)
+
+const (
+	// slackFileShareSubType is the subtype of the messages with attached files.
+	slackFileShareSubType = "file_share"
+	// slackMaxAttachmentSize limits the size of the attached file which is passed to the executed command.
+	slackMaxAttachmentSize = 1 << 20 // 1 MiB
+
+	slackTooManyAttachmentsMsg       = "Only a single file can be attached to the command."
+	slackAttachmentTooLargeMsgFmt    = "The attached file %q is too large. The maximum size is %d KiB."
+	slackAttachmentUnsupportedMsgFmt = "The attached file %q is not supported. Please attach a text file, such as a YAML snippet."
+)
+
+// slackAttachmentError is returned if the attached file cannot be passed to the executed command.
+// Its message is suitable to be printed to the end user.
+type slackAttachmentError struct {
+	msg string
+}
+
+func (e *slackAttachmentError) Error() string {
+	return e.msg
+}
+
+// isSlackUserMessageSubType returns true if a given message subtype describes a message sent by a user.
+// Edits, bot messages, and other message subtypes are skipped.
+func isSlackUserMessageSubType(subType string) bool {
+	return subType == "" || subType == slackFileShareSubType
+}
+
+// slackAppMentionFiles returns files attached to the app mention event.
+// The files are not exposed by the slackevents.AppMentionEvent, so they are extracted from the raw Events API payload.
+func slackAppMentionFiles(payload json.RawMessage) []slackevents.File {
+	if len(payload) == 0 {
+		return nil
+	}
+
+	var out struct {
+		Event struct {
+			Files []slackevents.File `json:"files"`
+		} `json:"event"`
+	}
+	if err := json.Unmarshal(payload, &out); err != nil {
+		return nil
+	}
+	return out.Event.Files
+}
+
+// downloadAttachment returns the content of the file attached to the message. It returns nil if there are no files.
+func (b *SocketSlack) downloadAttachment(ctx context.Context, files []slackevents.File) ([]byte, error) {
+	switch len(files) {
+	case 0:
+		return nil, nil
+	case 1:
+	default:
+		return nil, &slackAttachmentError{msg: slackTooManyAttachmentsMsg}
+	}
+
+	file := files[0]
+	if file.Size > slackMaxAttachmentSize {
+		return nil, &slackAttachmentError{msg: fmt.Sprintf(slackAttachmentTooLargeMsgFmt, file.Name, slackMaxAttachmentSize/1024)}
+	}
+	if !isSlackTextFile(file) {
+		return nil, &slackAttachmentError{msg: fmt.Sprintf(slackAttachmentUnsupportedMsgFmt, file.Name)}
+	}
+
+	var buff bytes.Buffer
+	if err := b.client.GetFileContext(ctx, file.URLPrivateDownload, &buff); err != nil {
+		return nil, fmt.Errorf("while downloading file %q: %w", file.ID, err)
+	}
+	if buff.Len() > slackMaxAttachmentSize {
+		return nil, &slackAttachmentError{msg: fmt.Sprintf(slackAttachmentTooLargeMsgFmt, file.Name, slackMaxAttachmentSize/1024)}
+	}
+
+	return buff.Bytes(), nil
+}
+
+// isSlackTextFile returns true if a given file is a text file, e.g. a snippet or an uploaded YAML file.
+func isSlackTextFile(file slackevents.File) bool {
+	switch {
+	case strings.HasPrefix(file.Mimetype, "text/"):
+		return true
+	case file.Mimetype == "application/json", file.Mimetype == "application/x-yaml", file.Mimetype == "application/yaml":
+		return true
+	}
+	return false
+}
+
+func attachmentErrorMessage(err *slackAttachmentError) interactive.Message {
+	return interactive.Message{
+		Base: interactive.Base{
+			Description: err.Error(),
+		},
+	}
+}
@@ -0,0 +1,140 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	logtest "github.com/sirupsen/logrus/hooks/test"
+	"github.com/slack-go/slack"
+	"github.com/slack-go/slack/slackevents"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/pkg/bot/interactive"
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+func TestSocketSlack_HandleMessageWithAttachment(t *testing.T) {
+	// given
+	const manifest = "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: foo\n"
+
+	testCases := []struct {
+		Name             string
+		Files            []slackevents.File
+		ExpectedStdin    string
+		ExpectedResponse string
+	}{
+		{
+			Name:          "YAML snippet",
+			Files:         []slackevents.File{{ID: "F01", Name: "cm.yaml", Mimetype: "text/plain", Size: len(manifest)}},
+			ExpectedStdin: manifest,
+		},
+		{
+			Name: "Multiple files",
+			Files: []slackevents.File{
+				{ID: "F01", Name: "cm.yaml", Mimetype: "text/plain"},
+				{ID: "F02", Name: "secret.yaml", Mimetype: "text/plain"},
+			},
+			ExpectedResponse: slackTooManyAttachmentsMsg,
+		},
+		{
+			Name:             "Too large file",
+			Files:            []slackevents.File{{ID: "F01", Name: "cm.yaml", Mimetype: "text/plain", Size: slackMaxAttachmentSize + 1}},
+			ExpectedResponse: "is too large. The maximum size is 1024 KiB.",
+		},
+		{
+			Name:             "Binary file",
+			Files:            []slackevents.File{{ID: "F01", Name: "image.png", Mimetype: "image/png", Size: 10}},
+			ExpectedResponse: "is not supported. Please attach a text file, such as a YAML snippet.",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			var gotPostedMessages []string
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.Path {
+				case "/files/F01":
+					assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+					_, _ = w.Write([]byte(manifest))
+					return
+				case "/conversations.info":
+					w.Header().Set("Content-Type", "application/json")
+					_, _ = w.Write([]byte(`{"ok": true, "channel": {"id": "C01", "name": "botkube"}}`))
+					return
+				case "/chat.postMessage":
+					require.NoError(t, r.ParseForm())
+					gotPostedMessages = append(gotPostedMessages, fmt.Sprint(r.PostForm))
+				}
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(`{"ok": true}`))
+			}))
+			defer srv.Close()
+
+			for idx := range tc.Files {
+				tc.Files[idx].URLPrivateDownload = srv.URL + "/files/" + tc.Files[idx].ID
+			}
+
+			logger, _ := logtest.NewNullLogger()
+			botMentionRegex, err := slackBotMentionRegex("B01")
+			require.NoError(t, err)
+			seenUsers := newSlackSeenUsers()
+			seenUsers.MarkSeen("C01", "U01")
+			factory := &fakeExecutorFactory{response: interactive.Message{Base: interactive.Base{Description: "done"}}}
+			bot := &SocketSlack{
+				log:             logger,
+				botID:           "B01",
+				client:          slack.New("token", slack.OptionAPIURL(srv.URL+"/")),
+				renderer:        NewSlackRenderer(config.Notification{}),
+				mdFormatter:     interactive.DefaultMDFormatter(),
+				executorFactory: factory,
+				botMentionRegex: botMentionRegex,
+				seenUsers:       seenUsers,
+				channels: map[string]channelConfigByName{
+					"botkube": {
+						ChannelBindingsByName: config.ChannelBindingsByName{Name: "botkube"},
+						alias:                 "default",
+					},
+				},
+			}
+
+			// when
+			err = bot.handleMessage(context.Background(), socketSlackMessage{
+				Text:    "<@B01> apply -f -",
+				Channel: "C01",
+				User:    "U01",
+				Files:   tc.Files,
+			})
+
+			// then
+			require.NoError(t, err)
+			if tc.ExpectedResponse != "" {
+				assert.Empty(t, factory.gotInputs)
+				require.Len(t, gotPostedMessages, 1)
+				assert.True(t, strings.Contains(gotPostedMessages[0], tc.ExpectedResponse), gotPostedMessages[0])
+				return
+			}
+
+			require.Len(t, factory.gotInputs, 1)
+			assert.Equal(t, tc.ExpectedStdin, string(factory.gotInputs[0].Stdin))
+		})
+	}
+}
+
+func TestSlackAppMentionFiles(t *testing.T) {
+	// given
+	payload := []byte(`{"type": "event_callback", "event": {"type": "app_mention", "text": "<@B01> apply -f -", "files": [{"id": "F01", "name": "cm.yaml", "mimetype": "text/plain", "url_private_download": "https://files.slack.com/F01"}]}}`)
+
+	// when
+	files := slackAppMentionFiles(payload)
+
+	// then
+	require.Len(t, files, 1)
+	assert.Equal(t, "F01", files[0].ID)
+	assert.Equal(t, "https://files.slack.com/F01", files[0].URLPrivateDownload)
+	assert.Empty(t, slackAppMentionFiles(nil))
+}
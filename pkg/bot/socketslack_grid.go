@@ -0,0 +1,82 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/slack-go/slack"
+
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+// slackTeamChannelKey returns the key under which a given channel of the Enterprise Grid workspace is stored.
+// Channel names are unique only within a workspace, so the key is prefixed with the workspace ID.
+func slackTeamChannelKey(teamID, channelName string) string {
+	return fmt.Sprintf("%s/%s", teamID, channelName)
+}
+
+// slackOrgChannelsConfigFrom returns channels of the Enterprise Grid workspaces served by the org-wide app.
+func slackOrgChannelsConfigFrom(workspaces []config.SocketSlackWorkspace) map[string]channelConfigByName {
+	channels := make(map[string]channelConfigByName)
+	for _, workspace := range workspaces {
+		for key, channel := range slackChannelsConfigFrom(workspace.Channels) {
+			channels[slackTeamChannelKey(workspace.TeamID, key)] = channel
+		}
+	}
+	return channels
+}
+
+// resolveSlackOrgChannelIDs returns IDs of the channels configured for the Enterprise Grid workspaces.
+// The org-wide app cannot post messages by channel name, as the names are not unique within the organization.
+func resolveSlackOrgChannelIDs(ctx context.Context, client *slack.Client, workspaces []config.SocketSlackWorkspace) (map[string]string, error) {
+	out := map[string]string{}
+	for _, workspace := range workspaces {
+		params := &slack.GetConversationsParameters{
+			TeamID:          workspace.TeamID,
+			Types:           []string{"public_channel", "private_channel"},
+			Limit:           slackConversationsPageLimit,
+			ExcludeArchived: true,
+		}
+		for {
+			conversations, nextCursor, err := client.GetConversationsContext(ctx, params)
+			if err != nil {
+				return nil, fmt.Errorf("while listing channels for workspace %q: %w", workspace.Name, err)
+			}
+			for _, conversation := range conversations {
+				out[slackTeamChannelKey(workspace.TeamID, conversation.Name)] = conversation.ID
+			}
+			if nextCursor == "" {
+				break
+			}
+			params.Cursor = nextCursor
+		}
+	}
+	return out, nil
+}
+
+// findChannel returns the configuration of a given channel together with the key under which it's stored.
+// The key is empty if the channel is not configured.
+// Channels configured for the Enterprise Grid workspace where the message was sent take precedence over the top-level ones.
+func (b *SocketSlack) findChannel(teamID, channelName string) (string, channelConfigByName, bool) {
+	channels := b.getChannels()
+	if teamID != "" {
+		key := slackTeamChannelKey(teamID, channelName)
+		if channel, found := channels[key]; found {
+			return key, channel, true
+		}
+	}
+
+	channel, found := channels[channelName]
+	if !found {
+		return "", channelConfigByName{}, false
+	}
+	return channelName, channel, true
+}
+
+// postTarget returns the channel to which the messages for a given channel key are posted.
+func (b *SocketSlack) postTarget(channelKey string) string {
+	if id, found := b.orgChannelIDs[channelKey]; found {
+		return id
+	}
+	return channelKey
+}
@@ -0,0 +1,137 @@
+package bot
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	logtest "github.com/sirupsen/logrus/hooks/test"
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/pkg/bot/interactive"
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+func TestSocketSlack_HandleMessageInOrgWorkspace(t *testing.T) {
+	// given
+	testCases := []struct {
+		Name              string
+		TeamID            string
+		ExpectedChannelID string
+		ExpectedBindings  []string
+	}{
+		{
+			Name:              "Message from workspace with own channel configuration",
+			TeamID:            "T02",
+			ExpectedChannelID: "T02/alerts",
+			ExpectedBindings:  []string{"kubectl-team-b"},
+		},
+		{
+			Name:              "Message from workspace without own channel configuration",
+			TeamID:            "T01",
+			ExpectedChannelID: "alerts",
+			ExpectedBindings:  []string{"kubectl-read-only"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				if r.URL.Path == "/conversations.info" {
+					_, _ = w.Write([]byte(`{"ok": true, "channel": {"id": "C01", "name": "alerts"}}`))
+					return
+				}
+				_, _ = w.Write([]byte(`{"ok": true}`))
+			}))
+			defer srv.Close()
+
+			logger, _ := logtest.NewNullLogger()
+			botMentionRegex, err := slackBotMentionRegex("B01")
+			require.NoError(t, err)
+			seenUsers := newSlackSeenUsers()
+			seenUsers.MarkSeen("C01", "U01")
+
+			channels := slackChannelsConfigFrom(config.IdentifiableMap[config.ChannelBindingsByName]{
+				"default": {Name: "alerts", Bindings: config.BotBindings{Executors: []string{"kubectl-read-only"}}},
+			})
+			orgChannels := slackOrgChannelsConfigFrom([]config.SocketSlackWorkspace{
+				{
+					Name:   "team-b",
+					TeamID: "T02",
+					Channels: config.IdentifiableMap[config.ChannelBindingsByName]{
+						"team-b": {Name: "alerts", Bindings: config.BotBindings{Executors: []string{"kubectl-team-b"}}},
+					},
+				},
+			})
+			for key, channel := range orgChannels {
+				channels[key] = channel
+			}
+
+			factory := &fakeExecutorFactory{response: interactive.Message{Base: interactive.Base{Description: "done"}}}
+			bot := &SocketSlack{
+				log:             logger,
+				botID:           "B01",
+				client:          slack.New("token", slack.OptionAPIURL(srv.URL+"/")),
+				renderer:        NewSlackRenderer(config.Notification{}),
+				mdFormatter:     interactive.DefaultMDFormatter(),
+				executorFactory: factory,
+				botMentionRegex: botMentionRegex,
+				seenUsers:       seenUsers,
+				channels:        channels,
+			}
+
+			// when
+			err = bot.handleMessage(context.Background(), socketSlackMessage{
+				Text:    "<@B01> get pods",
+				Channel: "C01",
+				User:    "U01",
+				TeamID:  tc.TeamID,
+			})
+
+			// then
+			require.NoError(t, err)
+			require.Len(t, factory.gotInputs, 1)
+			assert.Equal(t, tc.ExpectedChannelID, factory.gotInputs[0].Conversation.ID)
+			assert.Equal(t, tc.ExpectedBindings, factory.gotInputs[0].Conversation.ExecutorBindings)
+			assert.True(t, factory.gotInputs[0].Conversation.IsAuthenticated)
+		})
+	}
+}
+
+func TestResolveSlackOrgChannelIDs(t *testing.T) {
+	// given
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/conversations.list", r.URL.Path)
+		require.NoError(t, r.ParseForm())
+
+		w.Header().Set("Content-Type", "application/json")
+		switch r.PostForm.Get("team_id") {
+		case "T01":
+			_, _ = w.Write([]byte(`{"ok": true, "channels": [{"id": "C01", "name": "alerts"}]}`))
+		case "T02":
+			_, _ = w.Write([]byte(`{"ok": true, "channels": [{"id": "C02", "name": "alerts"}]}`))
+		default:
+			t.Errorf("unexpected team ID %q", r.PostForm.Get("team_id"))
+		}
+	}))
+	defer srv.Close()
+
+	workspaces := []config.SocketSlackWorkspace{
+		{Name: "team-a", TeamID: "T01"},
+		{Name: "team-b", TeamID: "T02"},
+	}
+
+	// when
+	ids, err := resolveSlackOrgChannelIDs(context.Background(), slack.New("token", slack.OptionAPIURL(srv.URL+"/")), workspaces)
+
+	// then
+	require.NoError(t, err)
+	bot := &SocketSlack{orgChannelIDs: ids}
+	assert.Equal(t, "C01", bot.postTarget("T01/alerts"))
+	assert.Equal(t, "C02", bot.postTarget("T02/alerts"))
+	assert.Equal(t, "alerts", bot.postTarget("alerts"))
+}
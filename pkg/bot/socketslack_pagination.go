@@ -0,0 +1,286 @@
+package bot
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/slack-go/slack"
+	"github.com/slack-go/slack/socketmode"
+
+	"github.com/kubeshop/botkube/pkg/bot/interactive"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/execute/command"
+)
+
+const (
+	// slackPageCommandPrefix prefixes the Next and Previous button commands. Such commands are handled by the bot directly, as soon as they arrive,
+	// and are never executed. The paginated output is identified by the ID of the buttons block.
+	slackPageCommandPrefix = "output-page"
+	// slackPageMaxSize leaves some space for the message header and the code block formatting.
+	slackPageMaxSize     = slackMaxMessageSize - 500
+	slackPageInfoMsgFmt  = "Page %d of %d"
+	slackPageExpiredMsg  = "Sorry, this output is no longer available. Please run the command again."
+	slackPageNextBtnName = "Next ›"
+	slackPagePrevBtnName = "‹ Prev"
+	slackDefaultPagesTTL = time.Hour
+	slackDefaultMaxPages = 20
+)
+
+// slackPagedOutput is a command response split into pages.
+type slackPagedOutput struct {
+	// msg is the response without its code block, which is replaced with a given page.
+	msg       interactive.Message
+	pages     []string
+	createdAt time.Time
+}
+
+// slackPagedOutputs stores the paginated responses, so the Next and Previous buttons can replace the message with a given page.
+// Responses are stored by the ID of the buttons block and expire after the configured TTL.
+type slackPagedOutputs struct {
+	mu       sync.Mutex
+	ttl      time.Duration
+	maxPages int
+	now      func() time.Time
+	outputs  map[string]slackPagedOutput
+}
+
+// newSlackPagedOutputs returns the paginated responses store if the pagination is enabled. Otherwise, it returns nil.
+func newSlackPagedOutputs(cfg config.SlackOutputPagination) *slackPagedOutputs {
+	if !cfg.Enabled {
+		return nil
+	}
+
+	ttl := cfg.TTL
+	if ttl <= 0 {
+		ttl = slackDefaultPagesTTL
+	}
+	maxPages := cfg.MaxPages
+	if maxPages <= 0 {
+		maxPages = slackDefaultMaxPages
+	}
+	return &slackPagedOutputs{
+		ttl:      ttl,
+		maxPages: maxPages,
+		now:      time.Now,
+		outputs:  map[string]slackPagedOutput{},
+	}
+}
+
+// add splits the code block of a given response into pages and stores them. It returns the stored response with its ID.
+// It returns false if the response cannot be paginated, e.g. it has interactive sections or too many pages. Such response should be uploaded as a file.
+func (s *slackPagedOutputs) add(msg interactive.Message) (string, slackPagedOutput, bool) {
+	if msg.Type == interactive.Popup || msg.OnlyVisibleForYou || msg.HasSections() || msg.Body.CodeBlock == "" {
+		return "", slackPagedOutput{}, false
+	}
+
+	pages := splitIntoPages(msg.Body.CodeBlock, slackPageMaxSize)
+	if len(pages) < 2 || len(pages) > s.maxPages {
+		return "", slackPagedOutput{}, false
+	}
+	msg.Body.CodeBlock = ""
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	s.pruneExpired(now)
+
+	id := uuid.New().String()
+	out := slackPagedOutput{
+		msg:       msg,
+		pages:     pages,
+		createdAt: now,
+	}
+	s.outputs[id] = out
+	return id, out, true
+}
+
+// get returns the stored response with a given ID. The first returned bool is false if the response is unknown,
+// e.g. it was sent by another Botkube instance. The second one is true if the response has expired.
+func (s *slackPagedOutputs) get(id string) (slackPagedOutput, bool, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	out, found := s.outputs[id]
+	if !found {
+		return slackPagedOutput{}, false, false
+	}
+	if s.isExpired(out, s.now()) {
+		delete(s.outputs, id)
+		return slackPagedOutput{}, true, true
+	}
+	return out, true, false
+}
+
+func (s *slackPagedOutputs) pruneExpired(now time.Time) {
+	for id, out := range s.outputs {
+		if s.isExpired(out, now) {
+			delete(s.outputs, id)
+		}
+	}
+}
+
+func (s *slackPagedOutputs) isExpired(out slackPagedOutput, now time.Time) bool {
+	return now.Sub(out.createdAt) > s.ttl
+}
+
+// sendPaginated posts the first page of a given response. It returns false if the response cannot be paginated.
+func (b *SocketSlack) sendPaginated(event socketSlackMessage, resp interactive.Message) (bool, error) {
+	if b.pagedOutputs == nil {
+		return false, nil
+	}
+	id, out, ok := b.pagedOutputs.add(resp)
+	if !ok {
+		return false, nil
+	}
+
+	options := []slack.MsgOption{
+		b.renderPage(id, out, 0),
+	}
+	if ts := b.getThreadOptionIfNeeded(event, nil); ts != nil {
+		options = append(options, ts)
+	}
+	if resp.ReplaceOriginal && event.ResponseURL != "" {
+		options = append(options, slack.MsgOptionReplaceOriginal(event.ResponseURL))
+	}
+
+	channelID, timestamp, err := b.client.PostMessage(event.Channel, options...)
+	if err != nil {
+		return true, fmt.Errorf("while posting Slack message: %w", err)
+	}
+	if resp.Attachment != nil {
+		threadTS := event.ThreadTimeStamp
+		if threadTS == "" {
+			threadTS = timestamp
+		}
+		if err := uploadAttachmentToSlack(b.client, channelID, threadTS, resp.Attachment); err != nil {
+			return true, err
+		}
+	}
+	return true, nil
+}
+
+// handlePageClick replaces the paginated message with the page from a given Next or Previous button command.
+func (b *SocketSlack) handlePageClick(callback slack.InteractionCallback, id, cmd string) {
+	out, found, expired := b.pagedOutputs.get(id)
+	if !found {
+		b.log.Debugf("Ignoring page of unknown output %q", id)
+		return
+	}
+
+	if expired {
+		err := b.send(socketSlackMessage{
+			Channel:       callback.Channel.ID,
+			User:          callback.User.ID,
+			CommandOrigin: command.ButtonClickOrigin,
+		}, interactive.Message{
+			Base: interactive.Base{
+				Description: slackPageExpiredMsg,
+			},
+			OnlyVisibleForYou: true,
+		})
+		if err != nil {
+			b.log.Errorf("while sending expired output message: %s", err.Error())
+		}
+		return
+	}
+
+	page, err := strconv.Atoi(strings.TrimSpace(strings.TrimPrefix(cmd, slackPageCommandPrefix)))
+	if err != nil || page < 0 || page >= len(out.pages) {
+		b.log.Debugf("Ignoring invalid page command %q", cmd)
+		return
+	}
+
+	if _, _, _, err := b.client.UpdateMessage(callback.Channel.ID, callback.Container.MessageTs, b.renderPage(id, out, page)); err != nil {
+		b.log.Errorf("while updating Slack message with output page: %s", err.Error())
+	}
+}
+
+// renderPage renders a given page of the paginated response, with the Next and Previous buttons in the block identified by a given ID.
+func (b *SocketSlack) renderPage(id string, out slackPagedOutput, page int) slack.MsgOption {
+	msg := out.msg
+	msg.Body.CodeBlock = out.pages[page]
+	inputs := msg.PlaintextInputs
+	msg.PlaintextInputs = nil
+
+	blocks := b.renderer.RenderAsSlackBlocks(msg)
+	blocks = append(blocks, b.renderer.renderContext([]interactive.ContextItem{
+		{Text: fmt.Sprintf(slackPageInfoMsgFmt, page+1, len(out.pages))},
+	})...)
+
+	var btns []slack.BlockElement
+	if page > 0 {
+		btns = append(btns, b.renderer.renderButton(pageButton(slackPagePrevBtnName, page-1)))
+	}
+	if page < len(out.pages)-1 {
+		btns = append(btns, b.renderer.renderButton(pageButton(slackPageNextBtnName, page+1)))
+	}
+	blocks = append(blocks, slack.NewActionBlock(id, btns...))
+
+	for _, input := range inputs {
+		blocks = append(blocks, b.renderer.renderInput(input))
+	}
+	return slack.MsgOptionBlocks(blocks...)
+}
+
+// slackPageClick returns the ID of the paginated output and the Next or Previous button command if a given event is a click on such button.
+func slackPageClick(event socketmode.Event) (slack.InteractionCallback, string, string, bool) {
+	if event.Type != socketmode.EventTypeInteractive || event.Request == nil {
+		return slack.InteractionCallback{}, "", "", false
+	}
+	callback, ok := event.Data.(slack.InteractionCallback)
+	if !ok || callback.Type != slack.InteractionTypeBlockActions || len(callback.ActionCallback.BlockActions) != 1 {
+		return slack.InteractionCallback{}, "", "", false
+	}
+
+	act := callback.ActionCallback.BlockActions[0]
+	if act == nil || act.Type != "button" || !strings.HasPrefix(act.Value, slackPageCommandPrefix+" ") {
+		return slack.InteractionCallback{}, "", "", false
+	}
+	return callback, act.BlockID, act.Value, true
+}
+
+// pageButton returns the button which shows a given page.
+func pageButton(name string, page int) interactive.Button {
+	return interactive.Button{
+		Name:    name,
+		Command: fmt.Sprintf("%s %d", slackPageCommandPrefix, page),
+	}
+}
+
+// splitIntoPages splits a given output into pages which don't exceed a given size. Pages are split at line boundaries,
+// unless a single line exceeds the size.
+func splitIntoPages(output string, maxSize int) []string {
+	var (
+		pages []string
+		page  strings.Builder
+	)
+	for _, line := range strings.SplitAfter(output, "\n") {
+		for len(line) > maxSize {
+			if page.Len() > 0 {
+				pages = append(pages, page.String())
+				page.Reset()
+			}
+			cut := maxSize
+			for cut > 0 && !utf8.RuneStart(line[cut]) {
+				cut--
+			}
+			pages = append(pages, line[:cut])
+			line = line[cut:]
+		}
+		if page.Len()+len(line) > maxSize {
+			pages = append(pages, page.String())
+			page.Reset()
+		}
+		page.WriteString(line)
+	}
+	if page.Len() > 0 {
+		pages = append(pages, page.String())
+	}
+	return pages
+}
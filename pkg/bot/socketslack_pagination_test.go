@@ -0,0 +1,277 @@
+package bot
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	logtest "github.com/sirupsen/logrus/hooks/test"
+	"github.com/slack-go/slack"
+	"github.com/slack-go/slack/socketmode"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/pkg/bot/interactive"
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+func TestSplitIntoPages(t *testing.T) {
+	tests := []struct {
+		name string
+
+		output  string
+		maxSize int
+
+		expPages []string
+	}{
+		{
+			name:     "Should keep short output",
+			output:   "NAME\nnginx\n",
+			maxSize:  20,
+			expPages: []string{"NAME\nnginx\n"},
+		},
+		{
+			name:     "Should split at line boundaries",
+			output:   "line 1\nline 2\nline 3\n",
+			maxSize:  14,
+			expPages: []string{"line 1\nline 2\n", "line 3\n"},
+		},
+		{
+			name:     "Should split too long line",
+			output:   "short\n0123456789\nend",
+			maxSize:  8,
+			expPages: []string{"short\n", "01234567", "89\nend"},
+		},
+		{
+			name:     "Should not split multi-byte characters",
+			output:   "ąęćż",
+			maxSize:  3,
+			expPages: []string{"ą", "ę", "ć", "ż"},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// when
+			pages := splitIntoPages(tc.output, tc.maxSize)
+
+			// then
+			assert.Equal(t, tc.expPages, pages)
+		})
+	}
+}
+
+func TestSlackPagedOutputsAdd(t *testing.T) {
+	// given
+	longOutput := strings.Repeat(strings.Repeat("x", 99)+"\n", 60)
+
+	tests := []struct {
+		name string
+
+		msg interactive.Message
+
+		expPages int
+		expOK    bool
+	}{
+		{
+			name:     "Should paginate long code block",
+			msg:      fixPagedMessage(longOutput),
+			expPages: 3,
+			expOK:    true,
+		},
+		{
+			name: "Should not paginate short code block",
+			msg:  fixPagedMessage("NAME\nnginx\n"),
+		},
+		{
+			name: "Should not paginate too many pages",
+			msg:  fixPagedMessage(strings.Repeat(longOutput, 10)),
+		},
+		{
+			name: "Should not paginate message with sections",
+			msg: interactive.Message{
+				Base:     interactive.Base{Body: interactive.Body{CodeBlock: longOutput}},
+				Sections: []interactive.Section{{Buttons: interactive.Buttons{{Name: "Run", Command: "get pods"}}}},
+			},
+		},
+		{
+			name: "Should not paginate ephemeral message",
+			msg: interactive.Message{
+				Base:              interactive.Base{Body: interactive.Body{CodeBlock: longOutput}},
+				OnlyVisibleForYou: true,
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			store := newSlackPagedOutputs(config.SlackOutputPagination{Enabled: true, MaxPages: 5})
+
+			// when
+			id, out, ok := store.add(tc.msg)
+
+			// then
+			assert.Equal(t, tc.expOK, ok)
+			assert.Len(t, out.pages, tc.expPages)
+			if !tc.expOK {
+				return
+			}
+			assert.Empty(t, out.msg.Body.CodeBlock)
+			assert.Equal(t, longOutput, strings.Join(out.pages, ""))
+			got, found, expired := store.get(id)
+			assert.True(t, found)
+			assert.False(t, expired)
+			assert.Equal(t, out, got)
+		})
+	}
+}
+
+func TestSlackPagedOutputsExpiration(t *testing.T) {
+	// given
+	now := time.Date(2022, 10, 3, 8, 0, 0, 0, time.UTC)
+	store := newSlackPagedOutputs(config.SlackOutputPagination{Enabled: true, TTL: time.Minute})
+	store.now = func() time.Time { return now }
+
+	id, _, ok := store.add(fixPagedMessage(strings.Repeat("line\n", 1000)))
+	require.True(t, ok)
+
+	// when
+	now = now.Add(2 * time.Minute)
+	_, found, expired := store.get(id)
+
+	// then
+	assert.True(t, found)
+	assert.True(t, expired)
+
+	// when
+	_, found, _ = store.get(id)
+
+	// then
+	assert.False(t, found, "expired output must be removed")
+}
+
+func TestSlackPageClick(t *testing.T) {
+	// given
+	newEvent := func(actions ...*slack.BlockAction) socketmode.Event {
+		return socketmode.Event{
+			Type: socketmode.EventTypeInteractive,
+			Data: slack.InteractionCallback{
+				Type:           slack.InteractionTypeBlockActions,
+				ActionCallback: slack.ActionCallbacks{BlockActions: actions},
+			},
+			Request: &socketmode.Request{EnvelopeID: "1"},
+		}
+	}
+
+	tests := []struct {
+		name string
+
+		event socketmode.Event
+
+		expID  string
+		expCmd string
+		expOK  bool
+	}{
+		{
+			name:   "Page button",
+			event:  newEvent(&slack.BlockAction{Type: "button", BlockID: "b1", Value: "output-page 1"}),
+			expID:  "b1",
+			expCmd: "output-page 1",
+			expOK:  true,
+		},
+		{
+			name:  "Other button",
+			event: newEvent(&slack.BlockAction{Type: "button", BlockID: "b1", Value: "kubectl get pods"}),
+		},
+		{
+			name:  "Cancel button",
+			event: newEvent(&slack.BlockAction{Type: "button", Value: "command-cancellation a1b2"}),
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// when
+			_, id, cmd, ok := slackPageClick(tc.event)
+
+			// then
+			assert.Equal(t, tc.expOK, ok)
+			assert.Equal(t, tc.expID, id)
+			assert.Equal(t, tc.expCmd, cmd)
+		})
+	}
+}
+
+func TestSocketSlack_SendPaginated(t *testing.T) {
+	// given
+	var (
+		mu        sync.Mutex
+		gotPaths  []string
+		gotBlocks []string
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		mu.Lock()
+		defer mu.Unlock()
+		gotPaths = append(gotPaths, r.URL.Path)
+		gotBlocks = append(gotBlocks, r.PostForm.Get("blocks"))
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"ok": true, "channel": "C01", "ts": "1665.001"}`))
+	}))
+	defer srv.Close()
+
+	logger, _ := logtest.NewNullLogger()
+	bot := &SocketSlack{
+		log:          logger,
+		client:       slack.New("token", slack.OptionAPIURL(srv.URL+"/")),
+		renderer:     NewSlackRenderer(config.Notification{}),
+		mdFormatter:  interactive.DefaultMDFormatter(),
+		pagedOutputs: newSlackPagedOutputs(config.SlackOutputPagination{Enabled: true}),
+	}
+	output := strings.Repeat("a", 2000) + "\n" + strings.Repeat("b", 2000) + "\n"
+
+	// when
+	err := bot.send(socketSlackMessage{Channel: "C01", User: "U01"}, fixPagedMessage(output))
+
+	// then
+	require.NoError(t, err)
+	require.Len(t, bot.pagedOutputs.outputs, 1)
+	var id string
+	for key := range bot.pagedOutputs.outputs {
+		id = key
+	}
+
+	// when
+	bot.handlePageClick(slack.InteractionCallback{
+		Channel:   slack.Channel{GroupConversation: slack.GroupConversation{Conversation: slack.Conversation{ID: "C01"}}},
+		Container: slack.Container{MessageTs: "1665.001"},
+	}, id, "output-page 1")
+
+	// then
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, []string{"/chat.postMessage", "/chat.update"}, gotPaths)
+
+	assert.Contains(t, gotBlocks[0], strings.Repeat("a", 2000))
+	assert.NotContains(t, gotBlocks[0], strings.Repeat("b", 2000))
+	assert.Contains(t, gotBlocks[0], "Page 1 of 2")
+	assert.Contains(t, gotBlocks[0], `"value":"output-page 1"`)
+	assert.Contains(t, gotBlocks[0], `"block_id":"`+id+`"`)
+
+	assert.Contains(t, gotBlocks[1], strings.Repeat("b", 2000))
+	assert.NotContains(t, gotBlocks[1], strings.Repeat("a", 2000))
+	assert.Contains(t, gotBlocks[1], "Page 2 of 2")
+	assert.Contains(t, gotBlocks[1], `"value":"output-page 0"`)
+	assert.NotContains(t, gotBlocks[1], `"value":"output-page 2"`)
+}
+
+func fixPagedMessage(output string) interactive.Message {
+	return interactive.Message{
+		Base: interactive.Base{
+			Description: "`kubectl get pods` on `dev`",
+			Body: interactive.Body{
+				CodeBlock: output,
+			},
+		},
+	}
+}
@@ -0,0 +1,43 @@
+package bot
+
+import (
+	"github.com/slack-go/slack"
+)
+
+const (
+	slackReactionReceived  = "hourglass_flowing_sand"
+	slackReactionSucceeded = "white_check_mark"
+	slackReactionFailed    = "x"
+)
+
+// ackCommandReceived reacts to the message which triggered a given command.
+// Only typed messages can be acknowledged, as interactive callbacks don't have their own message.
+func (b *SocketSlack) ackCommandReceived(event socketSlackMessage) {
+	if !b.reactions.Enabled || event.TimeStamp == "" {
+		return
+	}
+
+	if err := b.client.AddReaction(slackReactionReceived, slack.NewRefToMessage(event.Channel, event.TimeStamp)); err != nil {
+		b.log.Errorf("while adding %q reaction: %s", slackReactionReceived, err.Error())
+	}
+}
+
+// ackCommandFinished replaces the reaction added by ackCommandReceived with the one describing the command result.
+func (b *SocketSlack) ackCommandFinished(event socketSlackMessage, succeeded bool) {
+	if !b.reactions.Enabled || event.TimeStamp == "" {
+		return
+	}
+
+	msgRef := slack.NewRefToMessage(event.Channel, event.TimeStamp)
+	if err := b.client.RemoveReaction(slackReactionReceived, msgRef); err != nil {
+		b.log.Errorf("while removing %q reaction: %s", slackReactionReceived, err.Error())
+	}
+
+	reaction := slackReactionSucceeded
+	if !succeeded {
+		reaction = slackReactionFailed
+	}
+	if err := b.client.AddReaction(reaction, msgRef); err != nil {
+		b.log.Errorf("while adding %q reaction: %s", reaction, err.Error())
+	}
+}
@@ -0,0 +1,28 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kubeshop/botkube/pkg/bot/interactive"
+)
+
+// sendShutdownMessage notifies all configured channels that the bot is shutting down.
+func (b *SocketSlack) sendShutdownMessage() {
+	if !b.gracefulShutdown.SendShutdownMessage {
+		return
+	}
+
+	// the bot context is already cancelled, so the message is sent with a separate one
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownMsgTimeout)
+	defer cancel()
+
+	msg := interactive.Message{
+		Base: interactive.Base{
+			Description: fmt.Sprintf(shutdownMsgFmt, b.clusterName),
+		},
+	}
+	if err := b.SendMessageToAll(ctx, msg); err != nil {
+		b.log.Errorf("while sending shutdown message: %s", err.Error())
+	}
+}
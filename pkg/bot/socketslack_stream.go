@@ -0,0 +1,232 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/slack-go/slack"
+
+	"github.com/kubeshop/botkube/pkg/bot/interactive"
+	"github.com/kubeshop/botkube/pkg/execute"
+)
+
+const (
+	// slackRunningMsgDelay defines how long Botkube waits for the command response before it posts the "Running…" message.
+	slackRunningMsgDelay = 2 * time.Second
+	// slackStreamUpdateInterval defines how often the "Running…" message is updated with the command output.
+	// Slack allows roughly one message update per second per channel.
+	slackStreamUpdateInterval = time.Second
+	slackStreamChunksBuffer   = 100
+	// slackStreamMaxOutputSize leaves some space for the message header.
+	slackStreamMaxOutputSize = slackMaxMessageSize - 500
+	slackRunningMsgFmt       = "Running `%s`…"
+)
+
+// slackStreamOptions holds configuration for long-running command responses.
+type slackStreamOptions struct {
+	runningMsgDelay time.Duration
+	updateInterval  time.Duration
+}
+
+// executeAndSend executes a given command and sends the response back.
+// If the command takes long, it posts the "Running…" message and updates it in place as the output arrives,
+// unless the message is disabled in favor of reactions.
+// If the command streams its output before the "Running…" message is posted, the output and the final response
+// are sent to the thread of the command message, not to clutter the channel. Too long responses are uploaded there as files.
+// If command cancellation is enabled, the "Running…" message gets the Cancel button once the command exceeds the configured duration.
+// Commands which watch resources, such as `kubectl get pods --watch`, get the Stop button as soon as the watch starts.
+func (b *SocketSlack) executeAndSend(ctx context.Context, e execute.Executor, event socketSlackMessage, request string, isAuthChannel bool) error {
+	streamExecutor, ok := e.(execute.StreamingExecutor)
+	if !ok || !isAuthChannel || b.reactions.DisableRunningMessage {
+		return b.send(event, e.Execute(ctx))
+	}
+
+	ctx, cmdID, done, err := b.runningCmds.add(ctx, request, event.User)
+	if err != nil {
+		return err
+	}
+	defer done()
+
+	watchStarted := make(chan struct{}, 1)
+	ctx = execute.WithWatchStartedHandler(ctx, func() {
+		select {
+		case watchStarted <- struct{}{}:
+		default:
+		}
+	})
+
+	var cancelTimer <-chan time.Time
+	if b.cmdCancellation.Enabled {
+		timer := time.NewTimer(b.cmdCancellation.After)
+		defer timer.Stop()
+		cancelTimer = timer.C
+	}
+
+	chunks := make(chan string, slackStreamChunksBuffer)
+	result := make(chan interactive.Message, 1)
+	go func() {
+		result <- streamExecutor.ExecuteStream(ctx, func(chunk string) {
+			chunks <- chunk
+		})
+	}()
+
+	runningTimer := time.NewTimer(b.streamOpts.runningMsgDelay)
+	defer runningTimer.Stop()
+	updateTicker := time.NewTicker(b.streamOpts.updateInterval)
+	defer updateTicker.Stop()
+
+	threadEvent := event
+	if threadEvent.ThreadTimeStamp == "" {
+		threadEvent.ThreadTimeStamp = event.TimeStamp
+	}
+
+	var (
+		output       strings.Builder
+		msgTimestamp string
+		hasNewOutput bool
+		// cancelBtn is the Cancel or Stop button. It's set once the command can be cancelled.
+		cancelBtn *interactive.Button
+		watching  bool
+		// respEvent describes where the "Running…" message and the final response are posted.
+		respEvent = event
+	)
+	for {
+		select {
+		case <-runningTimer.C:
+			if msgTimestamp == "" {
+				msgTimestamp = b.postRunningMessage(respEvent, request, output.String(), cancelBtn)
+			}
+		case <-cancelTimer:
+			if cancelBtn != nil {
+				continue
+			}
+			btn := cancelButton(slackCancelBtnName, cmdID)
+			cancelBtn = &btn
+			msgTimestamp = b.showRunningMessage(respEvent, msgTimestamp, request, output.String(), cancelBtn)
+			hasNewOutput = false
+		case <-watchStarted:
+			watching = true
+			btn := cancelButton(slackStopBtnName, cmdID)
+			cancelBtn = &btn
+			msgTimestamp = b.showRunningMessage(respEvent, msgTimestamp, request, output.String(), cancelBtn)
+			hasNewOutput = false
+		case chunk := <-chunks:
+			output.WriteString(chunk)
+			hasNewOutput = true
+			if msgTimestamp == "" {
+				respEvent = threadEvent
+				msgTimestamp = b.postRunningMessage(respEvent, request, output.String(), cancelBtn)
+				hasNewOutput = false
+			}
+		case <-updateTicker.C:
+			if msgTimestamp == "" || !hasNewOutput {
+				continue
+			}
+			b.updateRunningMessage(respEvent, msgTimestamp, request, output.String(), cancelBtn)
+			hasNewOutput = false
+		case resp := <-result:
+			if userID := b.runningCmds.cancelledBy(cmdID); userID != "" {
+				msgFmt := slackCancelledMsgFmt
+				if watching {
+					msgFmt = slackStoppedMsgFmt
+				}
+				resp = cancelledMessage(msgFmt, request, userID, output.String())
+			}
+			return b.finishStream(respEvent, msgTimestamp, resp)
+		}
+	}
+}
+
+// showRunningMessage posts the "Running…" message, or updates it if it's already posted. It returns the message timestamp.
+func (b *SocketSlack) showRunningMessage(event socketSlackMessage, msgTimestamp, request, output string, cancelBtn *interactive.Button) string {
+	if msgTimestamp == "" {
+		return b.postRunningMessage(event, request, output, cancelBtn)
+	}
+	b.updateRunningMessage(event, msgTimestamp, request, output, cancelBtn)
+	return msgTimestamp
+}
+
+func (b *SocketSlack) postRunningMessage(event socketSlackMessage, request, output string, cancelBtn *interactive.Button) string {
+	options := []slack.MsgOption{
+		b.renderer.RenderInteractiveMessage(b.runningMessage(request, output, cancelBtn)),
+	}
+	if ts := b.getThreadOptionIfNeeded(event, nil); ts != nil {
+		options = append(options, ts)
+	}
+
+	_, timestamp, err := b.client.PostMessage(event.Channel, options...)
+	if err != nil {
+		b.log.Errorf("while posting Slack message about running command: %s", err.Error())
+		return ""
+	}
+
+	return timestamp
+}
+
+func (b *SocketSlack) updateRunningMessage(event socketSlackMessage, msgTimestamp, request, output string, cancelBtn *interactive.Button) {
+	msg := b.runningMessage(request, output, cancelBtn)
+	if _, _, _, err := b.client.UpdateMessage(event.Channel, msgTimestamp, b.renderer.RenderInteractiveMessage(msg)); err != nil {
+		b.log.Errorf("while updating Slack message with command output: %s", err.Error())
+	}
+}
+
+func (b *SocketSlack) finishStream(event socketSlackMessage, msgTimestamp string, resp interactive.Message) error {
+	if msgTimestamp == "" {
+		return b.send(event, resp)
+	}
+
+	if b.canUpdateInPlace(resp) {
+		if _, _, _, err := b.client.UpdateMessage(event.Channel, msgTimestamp, b.renderer.RenderInteractiveMessage(resp)); err != nil {
+			return fmt.Errorf("while updating Slack message: %w", err)
+		}
+		return nil
+	}
+
+	// The response cannot be rendered in place of the "Running…" message, so we replace it with a regular response.
+	if _, _, err := b.client.DeleteMessage(event.Channel, msgTimestamp); err != nil {
+		return fmt.Errorf("while deleting Slack message: %w", err)
+	}
+	return b.send(event, resp)
+}
+
+func (b *SocketSlack) canUpdateInPlace(resp interactive.Message) bool {
+	if resp.Type == interactive.Popup || resp.OnlyVisibleForYou || resp.ReplaceOriginal || resp.Attachment != nil {
+		return false
+	}
+
+	markdown := interactive.RenderMessage(b.mdFormatter, resp)
+	return len(markdown) > 0 && len(markdown) < slackMaxMessageSize
+}
+
+// runningMessage returns the "Running…" message. If the Cancel button is given, the message has it.
+func (b *SocketSlack) runningMessage(request, output string, cancelBtn *interactive.Button) interactive.Message {
+	msg := interactive.Message{
+		Base: interactive.Base{
+			Description: fmt.Sprintf(slackRunningMsgFmt, strings.TrimSpace(request)),
+			Body: interactive.Body{
+				CodeBlock: tailOutput(output, slackStreamMaxOutputSize),
+			},
+		},
+	}
+	if cancelBtn != nil {
+		msg.Sections = []interactive.Section{
+			{Buttons: interactive.Buttons{*cancelBtn}},
+		}
+	}
+	return msg
+}
+
+// tailOutput returns the last lines of the output that fit into a given size.
+func tailOutput(output string, maxSize int) string {
+	if len(output) <= maxSize {
+		return output
+	}
+
+	tail := output[len(output)-maxSize:]
+	if idx := strings.IndexByte(tail, '\n'); idx != -1 {
+		tail = tail[idx+1:]
+	}
+	return strings.ToValidUTF8(tail, "")
+}
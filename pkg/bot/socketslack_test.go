@@ -0,0 +1,822 @@
+package bot
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	logtest "github.com/sirupsen/logrus/hooks/test"
+	"github.com/slack-go/slack"
+	"github.com/slack-go/slack/slackevents"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kubeshop/botkube/pkg/bot/interactive"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/digest"
+	"github.com/kubeshop/botkube/pkg/events"
+	"github.com/kubeshop/botkube/pkg/execute"
+	"github.com/kubeshop/botkube/pkg/execute/command"
+	"github.com/kubeshop/botkube/pkg/execute/kubectl"
+	"github.com/kubeshop/botkube/pkg/mute"
+)
+
+func TestSocketSlack_SendPopupFromActiveModal(t *testing.T) {
+	// given
+	var gotPaths []string
+	var gotReq struct {
+		View   slack.ModalViewRequest `json:"view"`
+		Hash   string                 `json:"hash"`
+		ViewID string                 `json:"view_id"`
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPaths = append(gotPaths, r.URL.Path)
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&gotReq))
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"ok": true}`))
+	}))
+	defer srv.Close()
+
+	logger, _ := logtest.NewNullLogger()
+	bot := &SocketSlack{
+		log:         logger,
+		client:      slack.New("token", slack.OptionAPIURL(srv.URL+"/")),
+		renderer:    NewSlackRenderer(config.Notification{}),
+		mdFormatter: interactive.DefaultMDFormatter(),
+	}
+
+	msg := socketSlackMessage{
+		Channel:   "C01",
+		User:      "U01",
+		TriggerID: "trigger-id",
+		ViewID:    "V01",
+		ViewHash:  "hash",
+	}
+	resp := interactive.Message{
+		Type: interactive.Popup,
+		Base: interactive.Base{
+			Header:      "Popup",
+			Description: "Updated content",
+		},
+	}
+
+	// when
+	err := bot.send(msg, resp)
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, []string{"/views.update"}, gotPaths)
+	assert.Equal(t, "V01", gotReq.ViewID)
+	assert.Equal(t, "hash", gotReq.Hash)
+	assert.Equal(t, "C01", gotReq.View.PrivateMetadata)
+}
+
+func TestSocketSlack_SendWithAttachment(t *testing.T) {
+	// given
+	var (
+		gotPaths    []string
+		gotThreadTS string
+		gotContent  string
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		gotPaths = append(gotPaths, r.URL.Path)
+		if r.URL.Path == "/files.upload" {
+			gotThreadTS = r.PostForm.Get("thread_ts")
+			gotContent = r.PostForm.Get("content")
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"ok": true, "channel": "C01", "ts": "1665.001"}`))
+	}))
+	defer srv.Close()
+
+	logger, _ := logtest.NewNullLogger()
+	bot := &SocketSlack{
+		log:         logger,
+		client:      slack.New("token", slack.OptionAPIURL(srv.URL+"/")),
+		renderer:    NewSlackRenderer(config.Notification{}),
+		mdFormatter: interactive.DefaultMDFormatter(),
+	}
+	resp := interactive.Message{
+		Base: interactive.Base{
+			Body: interactive.Body{CodeBlock: "NAME\nnginx-…"},
+		},
+		Attachment: &interactive.Attachment{
+			FileName: "output.txt",
+			Content:  "NAME              AGE\nnginx-1234567890  5m\n",
+		},
+	}
+
+	// when
+	err := bot.send(socketSlackMessage{Channel: "C01", User: "U01"}, resp)
+
+	// then
+	require.NoError(t, err)
+	require.NotEmpty(t, gotPaths)
+	assert.Equal(t, "/chat.postMessage", gotPaths[0])
+	assert.Contains(t, gotPaths, "/files.upload")
+	assert.Equal(t, "1665.001", gotThreadTS)
+	assert.Equal(t, resp.Attachment.Content, gotContent)
+}
+
+type fakeStreamingExecutor struct {
+	chunks   []string
+	delay    time.Duration
+	response interactive.Message
+}
+
+func (f *fakeStreamingExecutor) Execute(ctx context.Context) interactive.Message {
+	return f.ExecuteStream(ctx, func(string) {})
+}
+
+func (f *fakeStreamingExecutor) ExecuteStream(_ context.Context, handleChunk execute.OutputChunkHandler) interactive.Message {
+	for _, chunk := range f.chunks {
+		time.Sleep(f.delay)
+		handleChunk(chunk)
+	}
+	time.Sleep(f.delay)
+	return f.response
+}
+
+func TestSocketSlack_ExecuteAndSend(t *testing.T) {
+	// given
+	response := interactive.Message{
+		Base: interactive.Base{
+			Body: interactive.Body{CodeBlock: "line 1\nline 2\nline 3"},
+		},
+	}
+	testCases := []struct {
+		Name          string
+		Executor      *fakeStreamingExecutor
+		ExpectedPaths []string
+	}{
+		{
+			Name:          "Fast command",
+			Executor:      &fakeStreamingExecutor{response: response},
+			ExpectedPaths: []string{"/chat.postMessage"},
+		},
+		{
+			Name: "Long-running command with output chunks",
+			Executor: &fakeStreamingExecutor{
+				chunks:   []string{"line 1\n", "line 2\n", "line 3\n"},
+				delay:    50 * time.Millisecond,
+				response: response,
+			},
+			ExpectedPaths: []string{"/chat.postMessage", "/chat.update", "/chat.update", "/chat.update"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			var (
+				mu       sync.Mutex
+				gotPaths []string
+			)
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				mu.Lock()
+				defer mu.Unlock()
+				gotPaths = append(gotPaths, r.URL.Path)
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(`{"ok": true, "channel": "C01", "ts": "1665.001"}`))
+			}))
+			defer srv.Close()
+
+			logger, _ := logtest.NewNullLogger()
+			bot := &SocketSlack{
+				log:         logger,
+				client:      slack.New("token", slack.OptionAPIURL(srv.URL+"/")),
+				renderer:    NewSlackRenderer(config.Notification{}),
+				mdFormatter: interactive.DefaultMDFormatter(),
+				streamOpts: slackStreamOptions{
+					runningMsgDelay: time.Second,
+					updateInterval:  10 * time.Millisecond,
+				},
+				runningCmds: newSlackRunningCommands(),
+			}
+			msg := socketSlackMessage{Channel: "C01", User: "U01"}
+
+			// when
+			err := bot.executeAndSend(context.Background(), tc.Executor, msg, "logs -f nginx", true)
+
+			// then
+			require.NoError(t, err)
+			mu.Lock()
+			defer mu.Unlock()
+			assert.Equal(t, tc.ExpectedPaths, gotPaths)
+		})
+	}
+}
+
+func TestSocketSlack_ExecuteAndSendStreamsToThread(t *testing.T) {
+	// given
+	var (
+		mu          sync.Mutex
+		gotPaths    []string
+		gotThreadTS []string
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		mu.Lock()
+		defer mu.Unlock()
+		gotPaths = append(gotPaths, r.URL.Path)
+		gotThreadTS = append(gotThreadTS, r.PostForm.Get("thread_ts"))
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"ok": true, "channel": "C01", "ts": "1665.001"}`))
+	}))
+	defer srv.Close()
+
+	logger, _ := logtest.NewNullLogger()
+	bot := &SocketSlack{
+		log:         logger,
+		client:      slack.New("token", slack.OptionAPIURL(srv.URL+"/")),
+		renderer:    NewSlackRenderer(config.Notification{}),
+		mdFormatter: interactive.DefaultMDFormatter(),
+		streamOpts: slackStreamOptions{
+			runningMsgDelay: time.Second,
+			updateInterval:  time.Second,
+		},
+		runningCmds: newSlackRunningCommands(),
+	}
+	executor := &fakeStreamingExecutor{
+		chunks: []string{"line 1\n"},
+		response: interactive.Message{
+			Base: interactive.Base{
+				Body: interactive.Body{CodeBlock: "line 1\n"},
+			},
+		},
+	}
+	msg := socketSlackMessage{Channel: "C01", User: "U01", TimeStamp: "1665.000"}
+
+	// when
+	err := bot.executeAndSend(context.Background(), executor, msg, "logs nginx", true)
+
+	// then
+	require.NoError(t, err)
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, []string{"/chat.postMessage", "/chat.update"}, gotPaths)
+	assert.Equal(t, "1665.000", gotThreadTS[0])
+}
+
+func TestTailOutput(t *testing.T) {
+	// given
+	output := "first line\nsecond line\nthird line\n"
+
+	// when
+	out := tailOutput(output, 20)
+
+	// then
+	assert.Equal(t, "third line\n", out)
+}
+
+func TestSocketSlack_WelcomeUserIfFirstInteraction(t *testing.T) {
+	// given
+	var (
+		mu        sync.Mutex
+		gotPaths  []string
+		gotUsers  []string
+		gotBlocks []string
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		mu.Lock()
+		gotPaths = append(gotPaths, r.URL.Path)
+		gotUsers = append(gotUsers, r.PostForm.Get("user"))
+		gotBlocks = append(gotBlocks, r.PostForm.Get("blocks"))
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"ok": true}`))
+	}))
+	defer srv.Close()
+
+	logger, _ := logtest.NewNullLogger()
+	bot := &SocketSlack{
+		log:         logger,
+		client:      slack.New("token", slack.OptionAPIURL(srv.URL+"/")),
+		renderer:    NewSlackRenderer(config.Notification{}),
+		mdFormatter: interactive.DefaultMDFormatter(),
+		seenUsers:   newSlackSeenUsers(),
+		botID:       "B01",
+		clusterName: "dev",
+	}
+
+	// when
+	bot.welcomeUserIfFirstInteraction(socketSlackMessage{Channel: "C01", User: "U01"}, "get pods")
+	bot.welcomeUserIfFirstInteraction(socketSlackMessage{Channel: "C01", User: "U01"}, "get pods")
+	bot.welcomeUserIfFirstInteraction(socketSlackMessage{Channel: "C01", User: "U02"}, "help")
+	bot.welcomeUserIfFirstInteraction(socketSlackMessage{Channel: "C01", User: "U02"}, "get pods")
+
+	// then
+	assert.Equal(t, []string{"/chat.postEphemeral"}, gotPaths)
+	assert.Equal(t, []string{"U01"}, gotUsers)
+	assert.Contains(t, gotBlocks[0], "Welcome to Botkube!")
+}
+
+func TestSocketSlack_ExecuteWorkflowStep(t *testing.T) {
+	// given
+	testCases := []struct {
+		Name             string
+		Command          string
+		ExpectedPath     string
+		ExpectedOutputs  map[string]string
+		ExpectedErrorMsg string
+	}{
+		{
+			Name:            "Completed",
+			Command:         "kubectl get pods",
+			ExpectedPath:    "/workflows.stepCompleted",
+			ExpectedOutputs: map[string]string{"output": "pod-1\n"},
+		},
+		{
+			Name:             "Not permitted",
+			Command:          "kubectl delete pod pod-1",
+			ExpectedPath:     "/workflows.stepFailed",
+			ExpectedErrorMsg: `command "kubectl delete pod pod-1" is not permitted in channel "general"`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			var (
+				gotPath string
+				gotReq  struct {
+					ExecuteID string            `json:"workflow_step_execute_id"`
+					Outputs   map[string]string `json:"outputs"`
+					Error     struct {
+						Message string `json:"message"`
+					} `json:"error"`
+				}
+			)
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				if r.URL.Path == "/conversations.info" {
+					_, _ = w.Write([]byte(`{"ok": true, "channel": {"id": "C01", "name": "general"}}`))
+					return
+				}
+				gotPath = r.URL.Path
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&gotReq))
+				_, _ = w.Write([]byte(`{"ok": true}`))
+			}))
+			defer srv.Close()
+
+			logger, _ := logtest.NewNullLogger()
+			factory := &fakeExecutorFactory{response: interactive.Message{Base: interactive.Base{Body: interactive.Body{CodeBlock: "pod-1"}}}}
+			workflows := newSlackWorkflowsClient("token")
+			workflows.apiURL = srv.URL + "/"
+			bot := &SocketSlack{
+				log:             logger,
+				client:          slack.New("token", slack.OptionAPIURL(srv.URL+"/")),
+				workflows:       workflows,
+				executorFactory: factory,
+				channels: map[string]channelConfigByName{
+					"general": {
+						ChannelBindingsByName: config.ChannelBindingsByName{
+							Name:     "general",
+							Commands: config.ChannelCommands{Blocked: []string{"kubectl delete"}},
+						},
+					},
+				},
+			}
+			ev := &slackevents.WorkflowStepExecuteEvent{
+				CallbackID: slackWorkflowStepCallbackID,
+				WorkflowStep: slackevents.EventWorkflowStep{
+					WorkflowStepExecuteID: "exec-id",
+					Inputs: &slack.WorkflowStepInputs{
+						slackWorkflowCommandInput: {Value: tc.Command},
+						slackWorkflowChannelInput: {Value: "C01"},
+					},
+				},
+			}
+
+			// when
+			err := bot.executeWorkflowStep(context.Background(), ev)
+
+			// then
+			require.NoError(t, err)
+			assert.Equal(t, tc.ExpectedPath, gotPath)
+			assert.Equal(t, "exec-id", gotReq.ExecuteID)
+			assert.Equal(t, tc.ExpectedOutputs, gotReq.Outputs)
+			assert.Equal(t, tc.ExpectedErrorMsg, gotReq.Error.Message)
+		})
+	}
+}
+
+func TestSocketSlack_HandleMessageWithReactions(t *testing.T) {
+	// given
+	testCases := []struct {
+		Name              string
+		Command           string
+		ExpectedReactions []string
+	}{
+		{
+			Name:    "Succeeded",
+			Command: "kubectl get pods",
+			ExpectedReactions: []string{
+				"/reactions.add hourglass_flowing_sand",
+				"/reactions.remove hourglass_flowing_sand",
+				"/reactions.add white_check_mark",
+			},
+		},
+		{
+			Name:    "Not permitted",
+			Command: "kubectl delete pod pod-1",
+			ExpectedReactions: []string{
+				"/reactions.add hourglass_flowing_sand",
+				"/reactions.remove hourglass_flowing_sand",
+				"/reactions.add x",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			var gotReactions []string
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				require.NoError(t, r.ParseForm())
+				w.Header().Set("Content-Type", "application/json")
+				switch r.URL.Path {
+				case "/conversations.info":
+					_, _ = w.Write([]byte(`{"ok": true, "channel": {"id": "C01", "name": "general"}}`))
+					return
+				case "/reactions.add", "/reactions.remove":
+					assert.Equal(t, "C01", r.PostForm.Get("channel"))
+					assert.Equal(t, "1663245600.000100", r.PostForm.Get("timestamp"))
+					gotReactions = append(gotReactions, r.URL.Path+" "+r.PostForm.Get("name"))
+				}
+				_, _ = w.Write([]byte(`{"ok": true}`))
+			}))
+			defer srv.Close()
+
+			logger, _ := logtest.NewNullLogger()
+			seenUsers := newSlackSeenUsers()
+			seenUsers.MarkSeen("C01", "U01")
+			bot := &SocketSlack{
+				log:             logger,
+				client:          slack.New("token", slack.OptionAPIURL(srv.URL+"/")),
+				renderer:        NewSlackRenderer(config.Notification{}),
+				mdFormatter:     interactive.DefaultMDFormatter(),
+				executorFactory: &fakeExecutorFactory{response: interactive.Message{Base: interactive.Base{Description: "done"}}},
+				seenUsers:       seenUsers,
+				reactions:       config.SlackReactions{Enabled: true},
+				channels: map[string]channelConfigByName{
+					"general": {
+						ChannelBindingsByName: config.ChannelBindingsByName{
+							Name:     "general",
+							Commands: config.ChannelCommands{Blocked: []string{"kubectl delete"}},
+						},
+					},
+				},
+			}
+			msg := socketSlackMessage{
+				Text:               tc.Command,
+				Channel:            "C01",
+				TimeStamp:          "1663245600.000100",
+				User:               "U01",
+				CommandOrigin:      command.TypedOrigin,
+				WithinActiveThread: true,
+			}
+
+			// when
+			err := bot.handleMessage(context.Background(), msg)
+
+			// then
+			require.NoError(t, err)
+			assert.Equal(t, tc.ExpectedReactions, gotReactions)
+		})
+	}
+}
+
+func TestSocketSlack_HandleDirectMessage(t *testing.T) {
+	// given
+	testCases := []struct {
+		Name              string
+		Text              string
+		IMUser            string
+		ExpectedCmd       string
+		ExpectedAuthed    bool
+		ExpectedChannelID string
+	}{
+		{
+			Name:              "Configured user without mention",
+			Text:              "kubectl get pods",
+			IMUser:            "U01",
+			ExpectedCmd:       "kubectl get pods",
+			ExpectedAuthed:    true,
+			ExpectedChannelID: "U01",
+		},
+		{
+			Name:              "Configured user with mention",
+			Text:              "<@B01> kubectl get pods",
+			IMUser:            "U01",
+			ExpectedCmd:       " kubectl get pods", // the executor trims the command
+			ExpectedAuthed:    true,
+			ExpectedChannelID: "U01",
+		},
+		{
+			Name:           "Not configured user",
+			Text:           "kubectl get pods",
+			IMUser:         "U02",
+			ExpectedCmd:    "kubectl get pods",
+			ExpectedAuthed: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				if r.URL.Path == "/conversations.info" {
+					_, _ = w.Write([]byte(`{"ok": true, "channel": {"id": "D01", "is_im": true, "user": "` + tc.IMUser + `"}}`))
+					return
+				}
+				_, _ = w.Write([]byte(`{"ok": true}`))
+			}))
+			defer srv.Close()
+
+			logger, _ := logtest.NewNullLogger()
+			botMentionRegex, err := slackBotMentionRegex("B01")
+			require.NoError(t, err)
+			seenUsers := newSlackSeenUsers()
+			seenUsers.MarkSeen("D01", tc.IMUser)
+			factory := &fakeExecutorFactory{response: interactive.Message{Base: interactive.Base{Description: "done"}}}
+			bot := &SocketSlack{
+				log:             logger,
+				botID:           "B01",
+				client:          slack.New("token", slack.OptionAPIURL(srv.URL+"/")),
+				renderer:        NewSlackRenderer(config.Notification{}),
+				mdFormatter:     interactive.DefaultMDFormatter(),
+				executorFactory: factory,
+				botMentionRegex: botMentionRegex,
+				seenUsers:       seenUsers,
+				channels: map[string]channelConfigByName{
+					"U01": {
+						ChannelBindingsByName: config.ChannelBindingsByName{Name: "U01"},
+						alias:                 "dm",
+					},
+				},
+			}
+			msg, ok := bot.directMessage(&slackevents.MessageEvent{
+				Text:        tc.Text,
+				Channel:     "D01",
+				ChannelType: slackIMChannelType,
+				User:        tc.IMUser,
+			})
+			require.True(t, ok)
+
+			// when
+			err = bot.handleMessage(context.Background(), msg)
+
+			// then
+			require.NoError(t, err)
+			require.Len(t, factory.gotInputs, 1)
+			assert.Equal(t, tc.ExpectedCmd, factory.gotInputs[0].Message)
+			assert.Equal(t, tc.ExpectedAuthed, factory.gotInputs[0].Conversation.IsAuthenticated)
+			assert.Equal(t, tc.ExpectedChannelID, factory.gotInputs[0].Conversation.ID)
+		})
+	}
+}
+
+func TestSocketSlack_DirectMessage(t *testing.T) {
+	// given
+	bot := &SocketSlack{botID: "B01"}
+
+	testCases := []struct {
+		Name       string
+		Event      slackevents.MessageEvent
+		ExpectedOK bool
+	}{
+		{
+			Name:       "Direct message",
+			Event:      slackevents.MessageEvent{ChannelType: slackIMChannelType, User: "U01", Text: "ping"},
+			ExpectedOK: true,
+		},
+		{
+			Name:  "Channel message",
+			Event: slackevents.MessageEvent{ChannelType: "channel", User: "U01", Text: "ping"},
+		},
+		{
+			Name:       "Direct message with attached file",
+			Event:      slackevents.MessageEvent{ChannelType: slackIMChannelType, SubType: slackFileShareSubType, User: "U01", Text: "apply -f -"},
+			ExpectedOK: true,
+		},
+		{
+			Name:  "Edited message",
+			Event: slackevents.MessageEvent{ChannelType: slackIMChannelType, SubType: "message_changed", User: "U01"},
+		},
+		{
+			Name:  "Message sent by bot",
+			Event: slackevents.MessageEvent{ChannelType: slackIMChannelType, User: "B01", Text: "pong"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			// when
+			msg, ok := bot.directMessage(&tc.Event)
+
+			// then
+			assert.Equal(t, tc.ExpectedOK, ok)
+			assert.Equal(t, tc.ExpectedOK, msg.IsDirectMessage)
+		})
+	}
+}
+
+func TestSocketSlack_SendShutdownMessage(t *testing.T) {
+	// given
+	testCases := []struct {
+		Name             string
+		Enabled          bool
+		ExpectedChannels []string
+	}{
+		{
+			Name:             "Enabled",
+			Enabled:          true,
+			ExpectedChannels: []string{"general"},
+		},
+		{
+			Name:    "Disabled",
+			Enabled: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			var gotChannels []string
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				require.NoError(t, r.ParseForm())
+				assert.Equal(t, "/chat.postMessage", r.URL.Path)
+				assert.Contains(t, fmt.Sprint(r.PostForm), "Botkube on cluster 'dev' is shutting down.")
+				gotChannels = append(gotChannels, r.PostForm.Get("channel"))
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(`{"ok": true}`))
+			}))
+			defer srv.Close()
+
+			logger, _ := logtest.NewNullLogger()
+			bot := &SocketSlack{
+				log:              logger,
+				client:           slack.New("token", slack.OptionAPIURL(srv.URL+"/")),
+				renderer:         NewSlackRenderer(config.Notification{}),
+				clusterName:      "dev",
+				gracefulShutdown: config.BotGracefulShutdown{SendShutdownMessage: tc.Enabled},
+				channels: map[string]channelConfigByName{
+					"general": {
+						ChannelBindingsByName: config.ChannelBindingsByName{Name: "general"},
+					},
+				},
+			}
+
+			// when
+			bot.sendShutdownMessage()
+
+			// then
+			assert.Equal(t, tc.ExpectedChannels, gotChannels)
+		})
+	}
+}
+
+type fakeEventCommandProvider struct{}
+
+func (fakeEventCommandProvider) GetCommandsForEvent(events.Event, []string) ([]kubectl.Command, error) {
+	return nil, nil
+}
+
+func TestSocketSlack_SendEventInThread(t *testing.T) {
+	// given
+	var (
+		mu          sync.Mutex
+		gotThreadTS []string
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		mu.Lock()
+		gotThreadTS = append(gotThreadTS, r.PostForm.Get("thread_ts"))
+		ts := fmt.Sprintf("1665.00%d", len(gotThreadTS))
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"ok": true, "channel": "C01", "ts": %q}`, ts)
+	}))
+	defer srv.Close()
+
+	logger, _ := logtest.NewNullLogger()
+	bot := &SocketSlack{
+		log:              logger,
+		client:           slack.New("token", slack.OptionAPIURL(srv.URL+"/")),
+		renderer:         NewSlackRenderer(config.Notification{}),
+		mdFormatter:      interactive.DefaultMDFormatter(),
+		eventCmdProvider: fakeEventCommandProvider{},
+		channels: map[string]channelConfigByName{
+			"alerts": {
+				ChannelBindingsByName: config.ChannelBindingsByName{
+					Name:     "alerts",
+					Bindings: config.BotBindings{Sources: []string{"k8s-err-events"}},
+				},
+				notify: true,
+			},
+		},
+		digest:       digest.NewScheduler(logger),
+		mutes:        mute.NewRegistry(),
+		eventThreads: eventThreadsStore(config.SlackEventThreads{Enabled: true}),
+	}
+
+	owner := &events.Owner{Kind: "Deployment", Name: "payments-api"}
+	failures := []events.Event{
+		{TypeMeta: metaV1.TypeMeta{Kind: "Pod"}, Name: "payments-api-1", Namespace: "prod", Type: config.ErrorEvent, Level: config.Error, Owner: owner},
+		{TypeMeta: metaV1.TypeMeta{Kind: "Pod"}, Name: "payments-api-2", Namespace: "prod", Type: config.ErrorEvent, Level: config.Error, Owner: owner},
+		{TypeMeta: metaV1.TypeMeta{Kind: "Pod"}, Name: "orders-api-1", Namespace: "prod", Type: config.ErrorEvent, Level: config.Error},
+		{TypeMeta: metaV1.TypeMeta{Kind: "Pod"}, Name: "payments-api-3", Namespace: "prod", Type: config.ErrorEvent, Level: config.Error, Owner: owner},
+	}
+
+	// when
+	for _, event := range failures {
+		err := bot.SendEvent(context.Background(), event, []string{"k8s-err-events"})
+		require.NoError(t, err)
+	}
+
+	// then
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, []string{"", "1665.001", "", "1665.001"}, gotThreadTS)
+}
+
+func TestSocketSlack_SendEventWithLongLogs(t *testing.T) {
+	// given
+	type upload struct {
+		Channels string
+		ThreadTS string
+		Content  string
+	}
+	var (
+		mu         sync.Mutex
+		gotUploads []upload
+		gotMsgs    []string
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		mu.Lock()
+		switch r.URL.Path {
+		case "/chat.postMessage":
+			gotMsgs = append(gotMsgs, r.PostForm.Get("blocks"))
+		case "/files.upload":
+			gotUploads = append(gotUploads, upload{
+				Channels: r.PostForm.Get("channels"),
+				ThreadTS: r.PostForm.Get("thread_ts"),
+				Content:  r.PostForm.Get("content"),
+			})
+		}
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprint(w, `{"ok": true, "channel": "C01", "ts": "1665.001", "file": {"id": "F01"}}`)
+	}))
+	defer srv.Close()
+
+	logger, _ := logtest.NewNullLogger()
+	bot := &SocketSlack{
+		log:              logger,
+		client:           slack.New("token", slack.OptionAPIURL(srv.URL+"/")),
+		renderer:         NewSlackRenderer(config.Notification{}),
+		mdFormatter:      interactive.DefaultMDFormatter(),
+		eventCmdProvider: fakeEventCommandProvider{},
+		channels: map[string]channelConfigByName{
+			"alerts": {
+				ChannelBindingsByName: config.ChannelBindingsByName{
+					Name:     "alerts",
+					Bindings: config.BotBindings{Sources: []string{"k8s-err-events"}},
+				},
+				notify: true,
+			},
+		},
+		digest: digest.NewScheduler(logger),
+		mutes:  mute.NewRegistry(),
+	}
+
+	longLogs := strings.Repeat("panic: connection refused\n", 100)
+	event := events.Event{
+		TypeMeta:  metaV1.TypeMeta{Kind: "Pod"},
+		Name:      "payments-api-1",
+		Namespace: "prod",
+		Type:      config.ErrorEvent,
+		Level:     config.Error,
+		Logs:      &events.ContainerLogs{Container: "app", Content: longLogs},
+	}
+
+	// when
+	err := bot.SendEvent(context.Background(), event, []string{"k8s-err-events"})
+
+	// then
+	require.NoError(t, err)
+	mu.Lock()
+	defer mu.Unlock()
+	require.Len(t, gotMsgs, 1)
+	assert.NotContains(t, gotMsgs[0], "connection refused")
+	assert.Equal(t, []upload{
+		{Channels: "C01", ThreadTS: "1665.001", Content: longLogs},
+	}, gotUploads)
+}
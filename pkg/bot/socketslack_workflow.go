@@ -0,0 +1,223 @@
+package bot
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/slack-go/slack"
+	"github.com/slack-go/slack/slackevents"
+
+	"github.com/kubeshop/botkube/pkg/bot/interactive"
+	"github.com/kubeshop/botkube/pkg/execute"
+	"github.com/kubeshop/botkube/pkg/execute/command"
+)
+
+// Slack Workflow Builder step configuration. The step must be added to the Slack app with the `botkube_command` callback ID.
+// See: https://api.slack.com/workflows/steps
+const (
+	slackWorkflowStepCallbackID = "botkube_command"
+
+	slackWorkflowCommandInput = "command"
+	slackWorkflowChannelInput = "channel"
+	slackWorkflowOutputName   = "output"
+
+	slackWorkflowHTTPTimeout = 30 * time.Second
+)
+
+// slackWorkflowsClient calls the Slack Workflow Builder methods which are not supported by the slack-go library.
+type slackWorkflowsClient struct {
+	apiURL  string
+	token   string
+	httpCli *http.Client
+}
+
+func newSlackWorkflowsClient(token string) *slackWorkflowsClient {
+	return &slackWorkflowsClient{
+		apiURL:  slack.APIURL,
+		token:   token,
+		httpCli: &http.Client{Timeout: slackWorkflowHTTPTimeout},
+	}
+}
+
+// StepCompleted marks a given workflow step execution as completed.
+func (c *slackWorkflowsClient) StepCompleted(ctx context.Context, executeID string, outputs map[string]string) error {
+	return c.post(ctx, "workflows.stepCompleted", map[string]interface{}{
+		"workflow_step_execute_id": executeID,
+		"outputs":                  outputs,
+	})
+}
+
+// StepFailed marks a given workflow step execution as failed.
+func (c *slackWorkflowsClient) StepFailed(ctx context.Context, executeID, message string) error {
+	return c.post(ctx, "workflows.stepFailed", map[string]interface{}{
+		"workflow_step_execute_id": executeID,
+		"error": map[string]string{
+			"message": message,
+		},
+	})
+}
+
+func (c *slackWorkflowsClient) post(ctx context.Context, method string, body interface{}) error {
+	raw, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("while marshaling request body: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.apiURL+method, bytes.NewReader(raw))
+	if err != nil {
+		return fmt.Errorf("while creating request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	req.Header.Set("Authorization", "Bearer "+c.token)
+
+	res, err := c.httpCli.Do(req)
+	if err != nil {
+		return fmt.Errorf("while calling %s: %w", method, err)
+	}
+	defer res.Body.Close()
+
+	var out slack.SlackResponse
+	if err := json.NewDecoder(res.Body).Decode(&out); err != nil {
+		return fmt.Errorf("while decoding %s response: %w", method, err)
+	}
+	if !out.Ok {
+		return fmt.Errorf("while calling %s: %w", method, out.Err())
+	}
+
+	return nil
+}
+
+// openWorkflowStepConfiguration opens the modal in which user configures the Botkube step in Workflow Builder.
+func (b *SocketSlack) openWorkflowStepConfiguration(callback slack.InteractionCallback) error {
+	var cmd, channel string
+	if inputs := callback.WorkflowStep.Inputs; inputs != nil {
+		cmd = (*inputs)[slackWorkflowCommandInput].Value
+		channel = (*inputs)[slackWorkflowChannelInput].Value
+	}
+
+	commandInput := slack.NewPlainTextInputBlockElement(
+		slack.NewTextBlockObject(slack.PlainTextType, "kubectl get pods", false, false),
+		slackWorkflowCommandInput,
+	)
+	commandInput.InitialValue = cmd
+
+	channelSelect := slack.NewOptionsSelectBlockElement(
+		slack.OptTypeConversations,
+		slack.NewTextBlockObject(slack.PlainTextType, "Select a channel", false, false),
+		slackWorkflowChannelInput,
+	)
+	channelSelect.InitialConversation = channel
+
+	blocks := slack.Blocks{
+		BlockSet: []slack.Block{
+			slack.NewInputBlock(
+				slackWorkflowCommandInput,
+				slack.NewTextBlockObject(slack.PlainTextType, "Botkube command", false, false),
+				slack.NewTextBlockObject(slack.PlainTextType, "Command is executed without the bot mention. You can use workflow variables.", false, false),
+				commandInput,
+			),
+			slack.NewInputBlock(
+				slackWorkflowChannelInput,
+				slack.NewTextBlockObject(slack.PlainTextType, "Channel", false, false),
+				slack.NewTextBlockObject(slack.PlainTextType, "Command is executed with the executor bindings of this channel.", false, false),
+				channelSelect,
+			),
+		},
+	}
+
+	modal := slack.NewConfigurationModalRequest(blocks, "", "")
+	if _, err := b.client.OpenView(callback.TriggerID, modal.ModalViewRequest); err != nil {
+		return fmt.Errorf("while opening workflow step configuration: %w", err)
+	}
+	return nil
+}
+
+// saveWorkflowStepConfiguration saves the Botkube step configuration submitted in Workflow Builder.
+func (b *SocketSlack) saveWorkflowStepConfiguration(callback slack.InteractionCallback) error {
+	values := callback.View.State.Values
+	cmd := strings.TrimSpace(values[slackWorkflowCommandInput][slackWorkflowCommandInput].Value)
+	channel := values[slackWorkflowChannelInput][slackWorkflowChannelInput].SelectedConversation
+
+	inputs := slack.WorkflowStepInputs{
+		slackWorkflowCommandInput: {Value: cmd},
+		slackWorkflowChannelInput: {Value: channel},
+	}
+	outputs := []slack.WorkflowStepOutput{
+		{Name: slackWorkflowOutputName, Type: "text", Label: "Botkube command output"},
+	}
+
+	if err := b.client.SaveWorkflowStepConfiguration(callback.WorkflowStep.WorkflowStepEditID, &inputs, &outputs); err != nil {
+		return fmt.Errorf("while saving workflow step configuration: %w", err)
+	}
+	return nil
+}
+
+// executeWorkflowStep executes the configured command and passes its output to the next workflow steps.
+func (b *SocketSlack) executeWorkflowStep(ctx context.Context, ev *slackevents.WorkflowStepExecuteEvent) error {
+	executeID := ev.WorkflowStep.WorkflowStepExecuteID
+
+	output, err := b.runWorkflowStepCommand(ctx, ev.WorkflowStep.Inputs)
+	if err != nil {
+		if failErr := b.workflows.StepFailed(ctx, executeID, err.Error()); failErr != nil {
+			return fmt.Errorf("while reporting workflow step failure %q: %w", err.Error(), failErr)
+		}
+		return nil
+	}
+
+	return b.workflows.StepCompleted(ctx, executeID, map[string]string{
+		slackWorkflowOutputName: output,
+	})
+}
+
+func (b *SocketSlack) runWorkflowStepCommand(ctx context.Context, inputs *slack.WorkflowStepInputs) (string, error) {
+	if inputs == nil {
+		return "", errors.New("step is not configured")
+	}
+
+	request := strings.TrimSpace((*inputs)[slackWorkflowCommandInput].Value)
+	channelID := (*inputs)[slackWorkflowChannelInput].Value
+	if request == "" || channelID == "" {
+		return "", errors.New("step requires both command and channel")
+	}
+
+	b.log.Debugf("Slack incoming workflow step Request: %s", request)
+
+	info, err := b.client.GetConversationInfoContext(ctx, channelID, false)
+	if err != nil {
+		return "", fmt.Errorf("while getting conversation info: %w", err)
+	}
+
+	// workflows are not triggered by a specific user, so we run commands only in the context of configured channels
+	channel, isAuthChannel := b.getChannels()[info.Name]
+	if !isAuthChannel {
+		return "", fmt.Errorf("channel %q is not configured in Botkube", info.Name)
+	}
+	if !execute.IsCommandPermitted(channel.Commands, request) {
+		return "", fmt.Errorf("command %q is not permitted in channel %q", request, info.Name)
+	}
+
+	e := b.executorFactory.NewDefault(execute.NewDefaultInput{
+		CommGroupName:   b.commGroupName,
+		Platform:        b.IntegrationName(),
+		NotifierHandler: b,
+		Conversation: execute.Conversation{
+			Alias:            channel.alias,
+			ID:               channel.Identifier(),
+			ExecutorBindings: channel.Bindings.Executors,
+			Commands:         channel.Commands,
+			Locale:           channel.Locale,
+			IsAuthenticated:  isAuthChannel,
+			CommandOrigin:    command.AutomationOrigin,
+		},
+		Message: request,
+	})
+	resp := e.Execute(ctx)
+
+	return tailOutput(interactive.MessageToPlaintext(resp, interactive.NewlineFormatter), slackMaxMessageSize), nil
+}
@@ -21,6 +21,7 @@ import (
 	"github.com/kubeshop/botkube/pkg/events"
 	"github.com/kubeshop/botkube/pkg/execute"
 	"github.com/kubeshop/botkube/pkg/execute/command"
+	"github.com/kubeshop/botkube/pkg/execute/kubectl"
 	"github.com/kubeshop/botkube/pkg/httpsrv"
 	"github.com/kubeshop/botkube/pkg/multierror"
 	"github.com/kubeshop/botkube/pkg/sliceutil"
@@ -47,6 +48,9 @@ const (
 
 	// teamsMaxMessageSize max size before a message should be uploaded as a file.
 	teamsMaxMessageSize = 15700
+
+	// teamsActionCommandKey is the Adaptive Card `Action.Submit` data key which holds the command to execute.
+	teamsActionCommandKey = "botkubeCommand"
 )
 
 var _ Bot = &Teams{}
@@ -63,11 +67,13 @@ type conversation struct {
 
 // Teams listens for user's message, execute commands and sends back the response.
 type Teams struct {
-	log             logrus.FieldLogger
-	executorFactory ExecutorFactory
-	reporter        AnalyticsReporter
+	log              logrus.FieldLogger
+	executorFactory  ExecutorFactory
+	eventCmdProvider EventCommandProvider
+	reporter         AnalyticsReporter
 	// TODO: Be consistent with other communicators when Teams supports multiple channels
 	//channels map[string][ChannelBindingsByName]
+	bindingsMutex      sync.RWMutex
 	bindings           config.BotBindings
 	conversationsMutex sync.RWMutex
 	commGroupName      string
@@ -92,7 +98,7 @@ type consentContext struct {
 }
 
 // NewTeams creates a new Teams instance.
-func NewTeams(log logrus.FieldLogger, commGroupName string, cfg config.Teams, clusterName string, executorFactory ExecutorFactory, reporter AnalyticsReporter) (*Teams, error) {
+func NewTeams(log logrus.FieldLogger, commGroupName string, cfg config.Teams, clusterName string, executorFactory ExecutorFactory, eventCmdProvider EventCommandProvider, reporter AnalyticsReporter) (*Teams, error) {
 	botMentionRegex, err := teamsBotMentionRegex(cfg.BotName)
 	if err != nil {
 		return nil, err
@@ -111,22 +117,23 @@ func NewTeams(log logrus.FieldLogger, commGroupName string, cfg config.Teams, cl
 	shortFormatter := interactive.NewMDFormatter(shortLineFormatter, interactive.MdHeaderFormatter)
 
 	return &Teams{
-		log:             log,
-		executorFactory: executorFactory,
-		reporter:        reporter,
-		botName:         cfg.BotName,
-		ClusterName:     clusterName,
-		AppID:           cfg.AppID,
-		AppPassword:     cfg.AppPassword,
-		Notification:    cfg.Notification,
-		bindings:        cfg.Bindings,
-		commGroupName:   commGroupName,
-		MessagePath:     msgPath,
-		Port:            port,
-		conversations:   make(map[string]conversation),
-		botMentionRegex: botMentionRegex,
-		longFormatter:   longFormatter,
-		shortFormatter:  shortFormatter,
+		log:              log,
+		executorFactory:  executorFactory,
+		eventCmdProvider: eventCmdProvider,
+		reporter:         reporter,
+		botName:          cfg.BotName,
+		ClusterName:      clusterName,
+		AppID:            cfg.AppID,
+		AppPassword:      cfg.AppPassword,
+		Notification:     cfg.Notification,
+		bindings:         cfg.Bindings,
+		commGroupName:    commGroupName,
+		MessagePath:      msgPath,
+		Port:             port,
+		conversations:    make(map[string]conversation),
+		botMentionRegex:  botMentionRegex,
+		longFormatter:    longFormatter,
+		shortFormatter:   shortFormatter,
 	}, nil
 }
 
@@ -184,16 +191,18 @@ func (b *Teams) processActivity(w http.ResponseWriter, req *http.Request) {
 			n, resp := b.processMessage(ctx, turn.Activity)
 			if n >= teamsMaxMessageSize {
 				if turn.Activity.Conversation.ConversationType == convTypePersonal {
+					// the command is resolved again, as it's not in the activity text for `Action.Submit` callbacks
+					cmd, _ := b.resolveActivityCommand(turn.Activity)
 					// send file upload request
 					attachments := []schema.Attachment{
 						{
 							ContentType: contentTypeFile,
 							Name:        responseFileName,
 							Content: map[string]interface{}{
-								"description": turn.Activity.Text,
+								"description": cmd,
 								"sizeInBytes": len(resp),
 								"acceptContext": map[string]interface{}{
-									"command": activity.Text,
+									"command": cmd,
 								},
 							},
 						},
@@ -278,7 +287,7 @@ func (b *Teams) processActivity(w http.ResponseWriter, req *http.Request) {
 }
 
 func (b *Teams) processMessage(ctx context.Context, activity schema.Activity) (int, string) {
-	trimmedMsg := b.trimBotMention(activity.Text)
+	trimmedMsg, cmdOrigin := b.resolveActivityCommand(activity)
 
 	// Multicluster is not supported for Teams
 
@@ -296,14 +305,25 @@ func (b *Teams) processMessage(ctx context.Context, activity schema.Activity) (i
 			Alias:            "",
 			IsAuthenticated:  true,
 			ID:               ref.ChannelID,
-			ExecutorBindings: b.bindings.Executors,
-			CommandOrigin:    command.TypedOrigin,
+			ExecutorBindings: b.getBindings().Executors,
+			CommandOrigin:    cmdOrigin,
 		},
 		Message: trimmedMsg,
+		UserID:  activity.From.ID,
 	})
 	return b.convertInteractiveMessage(e.Execute(ctx), false)
 }
 
+// resolveActivityCommand returns the command to execute for a given activity.
+// The Adaptive Card `Action.Submit` callbacks don't have text, but the command is passed in the activity value.
+func (b *Teams) resolveActivityCommand(activity schema.Activity) (string, command.Origin) {
+	if cmd, ok := activity.Value[teamsActionCommandKey].(string); ok && cmd != "" {
+		return cmd, command.ButtonClickOrigin
+	}
+
+	return b.trimBotMention(activity.Text), command.TypedOrigin
+}
+
 func (b *Teams) convertInteractiveMessage(in interactive.Message, forceMarkdown bool) (int, string) {
 	var out string
 
@@ -358,11 +378,13 @@ func (b *Teams) putRequest(u string, data []byte) (err error) {
 func (b *Teams) SendEvent(ctx context.Context, event events.Event, eventSources []string) error {
 	b.log.Debugf("Sending to Teams: %+v", event)
 	card := b.formatMessage(event, b.Notification)
+	card = b.withEventCommandActions(card, b.getEventCommands(event))
 
-	if !sliceutil.Intersect(eventSources, b.bindings.Sources) {
+	bindings := b.getBindings()
+	if !sliceutil.Intersect(eventSources, bindings.Sources) {
 		b.log.Debugf(
 			"Event was not sent as bot source bindings: %+v do not overlap with the event's sources: %+v",
-			b.bindings.Sources,
+			bindings.Sources,
 			eventSources,
 		)
 		return nil
@@ -494,15 +516,32 @@ func (b *Teams) sendProactiveMessage(ctx context.Context, convRef schema.Convers
 	return err
 }
 
+// getEventCommands returns commands which can be executed for a given event.
+// Teams doesn't support multiple channels yet, so the executor bindings are the same for all conversations.
+func (b *Teams) getEventCommands(event events.Event) []kubectl.Command {
+	if b.eventCmdProvider == nil {
+		return nil
+	}
+
+	commands, err := b.eventCmdProvider.GetCommandsForEvent(event, b.getBindings().Executors)
+	if err != nil {
+		b.log.Errorf("while getting commands for event: %s", err.Error())
+		return nil
+	}
+
+	return commands
+}
+
 func (b *Teams) getConversationRefsToNotify(sourceBindings []string) []schema.ConversationReference {
 	var convRefsToNotify []schema.ConversationReference
+	bindings := b.getBindings()
 	for _, convConfig := range b.getConversations() {
 		if !convConfig.notify {
 			b.log.Infof("Skipping notification for channel %q as notifications are disabled.", convConfig.ref.ChannelID)
 			continue
 		}
 
-		if !sliceutil.Intersect(sourceBindings, b.bindings.Sources) {
+		if !sliceutil.Intersect(sourceBindings, bindings.Sources) {
 			continue
 		}
 
@@ -511,6 +550,19 @@ func (b *Teams) getConversationRefsToNotify(sourceBindings []string) []schema.Co
 	return convRefsToNotify
 }
 
+// ReloadChannels reloads the bindings, without restarting the bot.
+func (b *Teams) ReloadChannels(cfg config.Teams) {
+	b.bindingsMutex.Lock()
+	defer b.bindingsMutex.Unlock()
+	b.bindings = cfg.Bindings
+}
+
+func (b *Teams) getBindings() config.BotBindings {
+	b.bindingsMutex.RLock()
+	defer b.bindingsMutex.RUnlock()
+	return b.bindings
+}
+
 func (b *Teams) getConversations() map[string]conversation {
 	b.conversationsMutex.RLock()
 	defer b.conversationsMutex.RUnlock()
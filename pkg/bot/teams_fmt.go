@@ -1,14 +1,20 @@
 package bot
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/kubeshop/botkube/pkg/config"
 	"github.com/kubeshop/botkube/pkg/events"
+	"github.com/kubeshop/botkube/pkg/execute/kubectl"
 	"github.com/kubeshop/botkube/pkg/format"
 	formatx "github.com/kubeshop/botkube/pkg/format"
 )
 
+// teamsMaxCardActions is the maximum number of actions rendered on a single Adaptive Card.
+// See https://learn.microsoft.com/en-us/microsoftteams/platform/task-modules-and-cards/cards/cards-reference#adaptive-card
+const teamsMaxCardActions = 6
+
 var themeColor = map[config.Level]string{
 	config.Info:     "good",
 	config.Warn:     "warning",
@@ -21,7 +27,7 @@ var themeColor = map[config.Level]string{
 type fact map[string]interface{}
 
 func (b *Teams) formatMessage(event events.Event, notification config.Notification) map[string]interface{} {
-	switch notification.Type {
+	switch eventNotificationType(notification, event) {
 	case config.LongNotification:
 		return b.longNotification(event)
 
@@ -75,11 +81,13 @@ func (b *Teams) longNotification(event events.Event) map[string]interface{} {
 	}
 
 	sectionFacts = b.appendIfNotEmpty(sectionFacts, event.Namespace, "Namespace")
+	sectionFacts = b.appendIfNotEmpty(sectionFacts, event.OwnerString(), "Owner")
 	sectionFacts = b.appendIfNotEmpty(sectionFacts, event.Reason, "Reason")
 	sectionFacts = b.appendIfNotEmpty(sectionFacts, formatx.JoinMessages(event.Messages), "Message")
 	sectionFacts = b.appendIfNotEmpty(sectionFacts, event.Action, "Action")
 	sectionFacts = b.appendIfNotEmpty(sectionFacts, formatx.JoinMessages(event.Recommendations), "Recommendations")
 	sectionFacts = b.appendIfNotEmpty(sectionFacts, formatx.JoinMessages(event.Warnings), "Warnings")
+	sectionFacts = b.appendIfNotEmpty(sectionFacts, formatx.EventLogs(event), "Logs")
 	sectionFacts = b.appendIfNotEmpty(sectionFacts, event.Cluster, "Cluster")
 
 	card["body"] = []map[string]interface{}{
@@ -106,3 +114,28 @@ func (b *Teams) appendIfNotEmpty(fields []fact, in string, title string) []fact
 		"value": in,
 	})
 }
+
+// withEventCommandActions adds `Action.Submit` buttons to a given Adaptive Card, so the suggested commands can be run with a single click.
+func (b *Teams) withEventCommandActions(card map[string]interface{}, commands []kubectl.Command) map[string]interface{} {
+	if len(commands) == 0 {
+		return card
+	}
+
+	if len(commands) > teamsMaxCardActions {
+		commands = commands[:teamsMaxCardActions]
+	}
+
+	var actions []map[string]interface{}
+	for _, cmd := range commands {
+		actions = append(actions, map[string]interface{}{
+			"type":  "Action.Submit",
+			"title": cmd.Name,
+			"data": map[string]interface{}{
+				teamsActionCommandKey: fmt.Sprintf("kubectl %s", cmd.Cmd),
+			},
+		})
+	}
+
+	card["actions"] = actions
+	return card
+}
@@ -3,8 +3,12 @@ package bot
 import (
 	"testing"
 
+	"github.com/infracloudio/msbotbuilder-go/schema"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/pkg/execute/command"
+	"github.com/kubeshop/botkube/pkg/execute/kubectl"
 )
 
 func TestTeams_TrimBotMention(t *testing.T) {
@@ -47,3 +51,74 @@ func TestTeams_TrimBotMention(t *testing.T) {
 		})
 	}
 }
+
+func TestTeams_ResolveActivityCommand(t *testing.T) {
+	// given
+	botMentionRegex, err := teamsBotMentionRegex("Botkube")
+	require.NoError(t, err)
+	b := &Teams{botMentionRegex: botMentionRegex}
+
+	testCases := []struct {
+		Name           string
+		Input          schema.Activity
+		ExpectedCmd    string
+		ExpectedOrigin command.Origin
+	}{
+		{
+			Name:           "Typed message",
+			Input:          schema.Activity{Text: "<at>Botkube</at> get pods"},
+			ExpectedCmd:    " get pods",
+			ExpectedOrigin: command.TypedOrigin,
+		},
+		{
+			Name: "Action.Submit callback",
+			Input: schema.Activity{Value: map[string]interface{}{
+				teamsActionCommandKey: "kubectl describe pods nginx --namespace default",
+			}},
+			ExpectedCmd:    "kubectl describe pods nginx --namespace default",
+			ExpectedOrigin: command.ButtonClickOrigin,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			// when
+			actualCmd, actualOrigin := b.resolveActivityCommand(tc.Input)
+
+			// then
+			assert.Equal(t, tc.ExpectedCmd, actualCmd)
+			assert.Equal(t, tc.ExpectedOrigin, actualOrigin)
+		})
+	}
+}
+
+func TestTeams_WithEventCommandActions(t *testing.T) {
+	// given
+	b := &Teams{}
+	commands := []kubectl.Command{
+		{Name: "describe", Cmd: "describe pods nginx --namespace default"},
+		{Name: "get", Cmd: "get pods nginx --namespace default"},
+	}
+	expActions := []map[string]interface{}{
+		{
+			"type":  "Action.Submit",
+			"title": "describe",
+			"data": map[string]interface{}{
+				teamsActionCommandKey: "kubectl describe pods nginx --namespace default",
+			},
+		},
+		{
+			"type":  "Action.Submit",
+			"title": "get",
+			"data": map[string]interface{}{
+				teamsActionCommandKey: "kubectl get pods nginx --namespace default",
+			},
+		},
+	}
+
+	// when
+	card := b.withEventCommandActions(map[string]interface{}{}, commands)
+
+	// then
+	assert.Equal(t, expActions, card["actions"])
+}
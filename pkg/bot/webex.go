@@ -0,0 +1,549 @@
+package bot
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha1" // #nosec G505 -- Webex signs webhook requests with HMAC-SHA1
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+
+	"github.com/kubeshop/botkube/pkg/bot/interactive"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/digest"
+	"github.com/kubeshop/botkube/pkg/events"
+	"github.com/kubeshop/botkube/pkg/execute"
+	"github.com/kubeshop/botkube/pkg/execute/command"
+	"github.com/kubeshop/botkube/pkg/httpsrv"
+	"github.com/kubeshop/botkube/pkg/multierror"
+	"github.com/kubeshop/botkube/pkg/mute"
+	"github.com/kubeshop/botkube/pkg/sliceutil"
+)
+
+var _ Bot = &Webex{}
+
+const (
+	webexDefaultPort = "3981"
+
+	// webexMaxMessageSize max size before a message should be uploaded as a file. Webex limits the message to 7439 bytes.
+	webexMaxMessageSize = 7000
+	// webexMaxRequestSize limits the size of the webhook request body.
+	webexMaxRequestSize = 1 << 20
+
+	webexBotMentionRegexFmt = `^@?(?i)%s\b`
+	webexSignatureHeader    = "X-Spark-Signature"
+
+	webexMessagesResource = "messages"
+	webexCreatedEvent     = "created"
+	webexWebhookNameFmt   = "botkube-%s"
+)
+
+// webexWebhookEvent is a notification sent by Webex to the registered webhook.
+// See: https://developer.webex.com/docs/api/guides/webhooks
+type webexWebhookEvent struct {
+	Resource string `json:"resource"`
+	Event    string `json:"event"`
+	Data     struct {
+		ID       string `json:"id"`
+		RoomID   string `json:"roomId"`
+		PersonID string `json:"personId"`
+	} `json:"data"`
+}
+
+// Webex listens for user's message, execute commands and sends back the response.
+type Webex struct {
+	log             logrus.FieldLogger
+	executorFactory ExecutorFactory
+	reporter        AnalyticsReporter
+	notification    config.Notification
+	client          *webexClient
+	renderer        *WebexRenderer
+	botID           string
+	botName         string
+	commGroupName   string
+	port            string
+	messagePath     string
+	webhookURL      string
+	webhookSecret   string
+	channelsMutex   sync.RWMutex
+	channels        map[string]channelConfigByID
+	notifyMutex     sync.Mutex
+	botMentionRegex *regexp.Regexp
+	digest          *digest.Scheduler
+	mutes           *mute.Registry
+}
+
+// NewWebex creates a new Webex instance.
+func NewWebex(ctx context.Context, log logrus.FieldLogger, commGroupName string, cfg config.Webex, executorFactory ExecutorFactory, reporter AnalyticsReporter) (*Webex, error) {
+	client := newWebexClient(cfg.Token)
+
+	me, err := client.Me(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("while getting Webex bot details: %w", err)
+	}
+
+	botName := cfg.BotName
+	if botName == "" {
+		botName = me.DisplayName
+	}
+	botMentionRegex, err := webexBotMentionRegex(botName)
+	if err != nil {
+		return nil, err
+	}
+
+	port := cfg.Port
+	if port == "" {
+		port = webexDefaultPort
+	}
+	msgPath := cfg.MessagePath
+	if msgPath == "" {
+		msgPath = "/"
+	}
+
+	return &Webex{
+		log:             log,
+		executorFactory: executorFactory,
+		reporter:        reporter,
+		notification:    cfg.Notification,
+		client:          client,
+		renderer:        NewWebexRenderer(),
+		botID:           me.ID,
+		botName:         botName,
+		commGroupName:   commGroupName,
+		port:            port,
+		messagePath:     msgPath,
+		webhookURL:      cfg.WebhookURL,
+		webhookSecret:   cfg.WebhookSecret,
+		channels:        webexChannelsCfgFrom(cfg.Channels),
+		botMentionRegex: botMentionRegex,
+		digest:          digest.NewScheduler(log),
+		mutes:           mute.NewRegistry(),
+	}, nil
+}
+
+// Start checks access to the configured rooms, registers the webhook and starts the server which handles Webex notifications.
+func (b *Webex) Start(ctx context.Context) error {
+	b.log.Info("Starting bot")
+
+	for roomID := range b.getChannels() {
+		if _, err := b.client.GetRoom(ctx, roomID); err != nil {
+			return fmt.Errorf("while getting room %q: %w", roomID, err)
+		}
+	}
+
+	if b.webhookURL != "" {
+		if err := b.registerWebhook(ctx); err != nil {
+			return fmt.Errorf("while registering Webex webhook: %w", err)
+		}
+	}
+
+	err := b.reporter.ReportBotEnabled(b.IntegrationName())
+	if err != nil {
+		return fmt.Errorf("while reporting analytics: %w", err)
+	}
+
+	go b.digest.Run(ctx, func(ctx context.Context, roomID string, msg interactive.Message) error {
+		return b.send(ctx, roomID, "", msg)
+	})
+
+	addr := fmt.Sprintf(":%s", b.port)
+	srv := httpsrv.New(b.log, addr, b.router())
+	if err := srv.Serve(ctx); err != nil {
+		return fmt.Errorf("while running Webex server: %w", err)
+	}
+
+	return nil
+}
+
+// registerWebhook replaces the webhook registered by previous Botkube runs, as the target URL or secret might have changed.
+func (b *Webex) registerWebhook(ctx context.Context) error {
+	name := fmt.Sprintf(webexWebhookNameFmt, b.commGroupName)
+
+	webhooks, err := b.client.ListWebhooks(ctx)
+	if err != nil {
+		return fmt.Errorf("while listing webhooks: %w", err)
+	}
+	for _, webhook := range webhooks {
+		if webhook.Name != name {
+			continue
+		}
+		if err := b.client.DeleteWebhook(ctx, webhook.ID); err != nil {
+			return fmt.Errorf("while deleting webhook %q: %w", webhook.ID, err)
+		}
+	}
+
+	return b.client.CreateWebhook(ctx, webexWebhook{
+		Name:      name,
+		TargetURL: b.webhookURL,
+		Resource:  webexMessagesResource,
+		Event:     webexCreatedEvent,
+		Secret:    b.webhookSecret,
+	})
+}
+
+func (b *Webex) router() http.Handler {
+	router := mux.NewRouter()
+	router.PathPrefix(b.messagePath).HandlerFunc(b.handleWebhook).Methods(http.MethodPost)
+	return router
+}
+
+// IntegrationName describes the notifier integration name.
+func (b *Webex) IntegrationName() config.CommPlatformIntegration {
+	return config.WebexCommPlatformIntegration
+}
+
+// Type describes the notifier type.
+func (b *Webex) Type() config.IntegrationType {
+	return config.BotIntegrationType
+}
+
+// NotificationsEnabled returns current notification status for a given room ID.
+func (b *Webex) NotificationsEnabled(roomID string) bool {
+	channel, exists := b.getChannels()[roomID]
+	if !exists {
+		return false
+	}
+
+	return channel.notificationsEnabled()
+}
+
+// SetNotificationsEnabled sets a new notification status for a given room ID.
+func (b *Webex) SetNotificationsEnabled(roomID string, enabled bool) error {
+	// avoid race conditions with using the setter concurrently, as we set whole map
+	b.notifyMutex.Lock()
+	defer b.notifyMutex.Unlock()
+
+	channels := b.getChannels()
+	channel, exists := channels[roomID]
+	if !exists {
+		return execute.ErrNotificationsNotConfigured
+	}
+
+	channel.notify = enabled
+	channel.Notification.SnoozedUntil = time.Time{}
+	channels[roomID] = channel
+	b.setChannels(channels)
+
+	return nil
+}
+
+// SnoozeNotifications disables notifications for a given channel until a given time.
+func (b *Webex) SnoozeNotifications(roomID string, until time.Time) error {
+	// avoid race conditions with using the setter concurrently, as we set whole map
+	b.notifyMutex.Lock()
+	defer b.notifyMutex.Unlock()
+
+	channels := b.getChannels()
+	channel, exists := channels[roomID]
+	if !exists {
+		return execute.ErrNotificationsNotConfigured
+	}
+
+	channel.notify = true
+	channel.Notification.SnoozedUntil = until
+	channels[roomID] = channel
+	b.setChannels(channels)
+
+	return nil
+}
+
+// NotificationsSnoozedUntil returns the snooze end time for a given channel.
+func (b *Webex) NotificationsSnoozedUntil(roomID string) time.Time {
+	return b.getChannels()[roomID].Notification.SnoozedUntil
+}
+
+// MuteNotifications suppresses notifications matching a given rule for a given channel.
+func (b *Webex) MuteNotifications(roomID string, rule mute.Rule) error {
+	if _, exists := b.getChannels()[roomID]; !exists {
+		return execute.ErrNotificationsNotConfigured
+	}
+
+	b.mutes.Add(roomID, rule)
+	return nil
+}
+
+// UnmuteNotifications removes the mute rule for the same target as a given rule for a given channel.
+func (b *Webex) UnmuteNotifications(roomID string, rule mute.Rule) bool {
+	return b.mutes.Remove(roomID, rule)
+}
+
+// NotificationMutes returns active mute rules for a given channel.
+func (b *Webex) NotificationMutes(roomID string) []mute.Rule {
+	return b.mutes.List(roomID)
+}
+
+// SendEvent sends event notification to Webex.
+func (b *Webex) SendEvent(ctx context.Context, event events.Event, eventSources []string) error {
+	b.log.Debugf("Sending to Webex: %+v", event)
+	markdown := b.formatMessage(event)
+
+	errs := multierror.New()
+	for _, roomID := range b.getChannelsToNotifyForEvent(event, eventSources) {
+		if err := b.client.CreateMessage(ctx, webexCreateMessage{RoomID: roomID, Markdown: markdown}); err != nil {
+			errs = multierror.Append(errs, fmt.Errorf("while posting message to room %q: %w", roomID, err))
+			continue
+		}
+
+		b.log.Debugf("Event successfully sent to room %q", roomID)
+	}
+
+	return errs.ErrorOrNil()
+}
+
+// SendGenericMessage sends message to selected Webex rooms.
+func (b *Webex) SendGenericMessage(ctx context.Context, genericMsg interactive.GenericMessage, sourceBindings []string) error {
+	msg := genericMsg.ForBot(b.BotName())
+
+	errs := multierror.New()
+	for _, roomID := range b.getChannelsToNotify(sourceBindings) {
+		b.log.Debugf("Sending message to room %q: %+v", roomID, msg)
+		if err := b.send(ctx, roomID, "", msg); err != nil {
+			errs = multierror.Append(errs, fmt.Errorf("while sending Webex message to room %q: %w", roomID, err))
+			continue
+		}
+		b.log.Debugf("Message successfully sent to room %q", roomID)
+	}
+
+	return errs.ErrorOrNil()
+}
+
+// SendMessageToAll sends message to all Webex rooms.
+func (b *Webex) SendMessageToAll(ctx context.Context, msg interactive.Message) error {
+	errs := multierror.New()
+	for _, channel := range b.getChannels() {
+		roomID := channel.ID
+		b.log.Debugf("Sending message to room %q: %+v", roomID, msg)
+		if err := b.send(ctx, roomID, "", msg); err != nil {
+			errs = multierror.Append(errs, fmt.Errorf("while sending Webex message to room %q: %w", roomID, err))
+			continue
+		}
+		b.log.Debugf("Message successfully sent to room %q", roomID)
+	}
+
+	return errs.ErrorOrNil()
+}
+
+// BotName returns the Bot name.
+func (b *Webex) BotName() string {
+	return fmt.Sprintf("@%s", b.botName)
+}
+
+// handleWebhook handles the Webex webhook notifications about new messages.
+func (b *Webex) handleWebhook(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(io.LimitReader(r.Body, webexMaxRequestSize))
+	if err != nil {
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if !b.isSignatureValid(body, r.Header.Get(webexSignatureHeader)) {
+		b.log.Debug("Rejecting Webex request with invalid signature")
+		http.Error(w, "invalid signature", http.StatusUnauthorized)
+		return
+	}
+
+	var event webexWebhookEvent
+	if err := json.Unmarshal(body, &event); err != nil {
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if err := b.processEvent(r.Context(), event); err != nil {
+		b.log.Errorf("Message handling error: %s", err.Error())
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// isSignatureValid checks the HMAC-SHA1 signature of the request body calculated with the webhook secret.
+func (b *Webex) isSignatureValid(body []byte, signature string) bool {
+	if b.webhookSecret == "" || signature == "" {
+		return false
+	}
+
+	gotMAC, err := hex.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+
+	mac := hmac.New(sha1.New, []byte(b.webhookSecret))
+	mac.Write(body)
+	return hmac.Equal(gotMAC, mac.Sum(nil))
+}
+
+func (b *Webex) processEvent(ctx context.Context, event webexWebhookEvent) error {
+	if event.Resource != webexMessagesResource || event.Event != webexCreatedEvent {
+		b.log.Debugf("Ignoring Webex %s:%s event", event.Resource, event.Event)
+		return nil
+	}
+
+	// skip messages sent by the bot itself
+	if event.Data.PersonID == b.botID {
+		return nil
+	}
+
+	msg, err := b.client.GetMessage(ctx, event.Data.ID)
+	if err != nil {
+		return fmt.Errorf("while getting message: %w", err)
+	}
+
+	// Webex sends notifications from group rooms only if the bot is mentioned, so the mention is optional here
+	req := strings.TrimSpace(msg.Text)
+	if trimmed, found := b.findAndTrimBotMention(req); found {
+		req = trimmed
+	}
+	b.log.Debugf("Webex incoming Request: %s", req)
+
+	response := b.executeCommand(ctx, msg, req)
+
+	// reply in the thread in which the command was sent
+	parentID := msg.ParentID
+	if parentID == "" {
+		parentID = msg.ID
+	}
+	if err := b.send(ctx, msg.RoomID, parentID, response); err != nil {
+		return fmt.Errorf("while sending message: %w", err)
+	}
+
+	return nil
+}
+
+// executeCommand executes a given command in the context of a given room.
+func (b *Webex) executeCommand(ctx context.Context, msg webexMessage, req string) interactive.Message {
+	channel, isAuthChannel := b.getChannels()[msg.RoomID]
+	if !execute.IsCommandPermitted(channel.Commands, req) {
+		b.log.Debugf("Command %q is not permitted in room %q", req, msg.RoomID)
+		return execute.CommandNotPermittedMessage(req)
+	}
+
+	e := b.executorFactory.NewDefault(execute.NewDefaultInput{
+		CommGroupName:   b.commGroupName,
+		Platform:        b.IntegrationName(),
+		NotifierHandler: b,
+		Conversation: execute.Conversation{
+			Alias:            channel.alias,
+			ID:               msg.RoomID,
+			ExecutorBindings: channel.Bindings.Executors,
+			Commands:         channel.Commands,
+			Locale:           channel.Locale,
+			IsAuthenticated:  isAuthChannel,
+			CommandOrigin:    command.TypedOrigin,
+		},
+		Message: req,
+		User:    msg.PersonEmail,
+		UserID:  msg.PersonID,
+	})
+	return e.Execute(ctx)
+}
+
+func (b *Webex) send(ctx context.Context, roomID, parentID string, resp interactive.Message) error {
+	b.log.Debugf("Webex Response: %s", resp)
+
+	markdown := b.renderer.RenderMessage(resp)
+	if len(markdown) == 0 {
+		return errors.New("while reading Webex response: empty response")
+	}
+
+	msg := webexCreateMessage{
+		RoomID:   roomID,
+		ParentID: parentID,
+		Markdown: markdown,
+	}
+
+	// Upload message as a file if too long
+	if len(markdown) >= webexMaxMessageSize {
+		msg.Markdown = resp.Description
+		content := []byte(interactive.MessageToPlaintext(resp, interactive.NewlineFormatter))
+		if err := b.client.UploadFile(ctx, msg, responseFileName, content); err != nil {
+			return fmt.Errorf("while uploading file: %w", err)
+		}
+		return nil
+	}
+
+	if err := b.client.CreateMessage(ctx, msg); err != nil {
+		return fmt.Errorf("while posting message: %w", err)
+	}
+	return nil
+}
+
+func (b *Webex) getChannelsToNotifyForEvent(event events.Event, sourceBindings []string) []string {
+	// support custom event routing
+	if event.Channel != "" {
+		return []string{event.Channel}
+	}
+
+	return b.digest.Filter(event, b.mutes.Filter(event, channelsToNotifyForEvent(event, sourceBindings, b.getChannels(), b.getChannelsToNotify)), channelDigestByID(b.getChannels))
+}
+
+func (b *Webex) getChannelsToNotify(sourceBindings []string) []string {
+	var out []string
+	for _, cfg := range b.getChannels() {
+		switch {
+		case !cfg.notificationsEnabled():
+			b.log.Infof("Skipping notification for room %q as notifications are disabled.", cfg.Identifier())
+		default:
+			if sliceutil.Intersect(sourceBindings, cfg.Bindings.Sources) {
+				out = append(out, cfg.Identifier())
+			}
+		}
+	}
+	return out
+}
+
+func (b *Webex) findAndTrimBotMention(msg string) (string, bool) {
+	if !b.botMentionRegex.MatchString(msg) {
+		return "", false
+	}
+
+	return strings.TrimSpace(b.botMentionRegex.ReplaceAllString(msg, "")), true
+}
+
+func (b *Webex) getChannels() map[string]channelConfigByID {
+	b.channelsMutex.RLock()
+	defer b.channelsMutex.RUnlock()
+	return b.channels
+}
+
+func (b *Webex) setChannels(channels map[string]channelConfigByID) {
+	b.channelsMutex.Lock()
+	defer b.channelsMutex.Unlock()
+	b.channels = channels
+}
+
+// ReloadChannels reloads the bindings and notification settings of the channels, without reconnecting the bot.
+func (b *Webex) ReloadChannels(cfg config.Webex) {
+	b.channelsMutex.Lock()
+	defer b.channelsMutex.Unlock()
+	b.channels = reloadedChannelsByID(b.channels, cfg.Channels)
+}
+
+func webexChannelsCfgFrom(channelsCfg config.IdentifiableMap[config.ChannelBindingsByID]) map[string]channelConfigByID {
+	res := make(map[string]channelConfigByID)
+	for channAlias, channCfg := range channelsCfg {
+		res[channCfg.Identifier()] = channelConfigByID{
+			ChannelBindingsByID: channCfg,
+			alias:               channAlias,
+			notify:              !channCfg.Notification.Disabled,
+		}
+	}
+
+	return res
+}
+
+func webexBotMentionRegex(botName string) (*regexp.Regexp, error) {
+	botMentionRegex, err := regexp.Compile(fmt.Sprintf(webexBotMentionRegexFmt, regexp.QuoteMeta(botName)))
+	if err != nil {
+		return nil, fmt.Errorf("while compiling bot mention regex: %w", err)
+	}
+
+	return botMentionRegex, nil
+}
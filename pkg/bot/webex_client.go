@@ -0,0 +1,211 @@
+package bot
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+const (
+	webexAPIURL      = "https://webexapis.com/v1"
+	webexHTTPTimeout = 30 * time.Second
+)
+
+// webexClient is a minimal Webex REST API client.
+// See https://developer.webex.com/docs/api/getting-started
+type webexClient struct {
+	apiURL  string
+	token   string
+	httpCli *http.Client
+}
+
+type webexPerson struct {
+	ID          string `json:"id"`
+	DisplayName string `json:"displayName"`
+}
+
+type webexRoom struct {
+	ID    string `json:"id"`
+	Title string `json:"title"`
+}
+
+// webexMessage is a message received from Webex.
+type webexMessage struct {
+	ID          string `json:"id"`
+	RoomID      string `json:"roomId"`
+	RoomType    string `json:"roomType"`
+	Text        string `json:"text"`
+	PersonID    string `json:"personId"`
+	PersonEmail string `json:"personEmail"`
+	ParentID    string `json:"parentId,omitempty"`
+}
+
+// webexCreateMessage is a message sent to a Webex room.
+type webexCreateMessage struct {
+	RoomID   string `json:"roomId"`
+	ParentID string `json:"parentId,omitempty"`
+	Markdown string `json:"markdown"`
+}
+
+type webexWebhook struct {
+	ID        string `json:"id,omitempty"`
+	Name      string `json:"name"`
+	TargetURL string `json:"targetUrl"`
+	Resource  string `json:"resource"`
+	Event     string `json:"event"`
+	Secret    string `json:"secret,omitempty"`
+}
+
+type webexErrorResponse struct {
+	Message    string `json:"message"`
+	TrackingID string `json:"trackingId"`
+}
+
+func newWebexClient(token string) *webexClient {
+	return &webexClient{
+		apiURL:  webexAPIURL,
+		token:   token,
+		httpCli: &http.Client{Timeout: webexHTTPTimeout},
+	}
+}
+
+// Me returns the authenticated bot.
+func (c *webexClient) Me(ctx context.Context) (webexPerson, error) {
+	var out webexPerson
+	if err := c.doJSON(ctx, http.MethodGet, "/people/me", nil, &out); err != nil {
+		return webexPerson{}, err
+	}
+	return out, nil
+}
+
+// GetRoom returns a room with a given ID.
+func (c *webexClient) GetRoom(ctx context.Context, roomID string) (webexRoom, error) {
+	var out webexRoom
+	if err := c.doJSON(ctx, http.MethodGet, "/rooms/"+url.PathEscape(roomID), nil, &out); err != nil {
+		return webexRoom{}, err
+	}
+	return out, nil
+}
+
+// GetMessage returns a message with a given ID.
+// Webhook notifications don't contain the message text, so it needs to be fetched separately.
+func (c *webexClient) GetMessage(ctx context.Context, messageID string) (webexMessage, error) {
+	var out webexMessage
+	if err := c.doJSON(ctx, http.MethodGet, "/messages/"+url.PathEscape(messageID), nil, &out); err != nil {
+		return webexMessage{}, err
+	}
+	return out, nil
+}
+
+// CreateMessage sends a message to a given room.
+func (c *webexClient) CreateMessage(ctx context.Context, msg webexCreateMessage) error {
+	return c.doJSON(ctx, http.MethodPost, "/messages", msg, nil)
+}
+
+// UploadFile sends a message with a given file attached to a given room.
+func (c *webexClient) UploadFile(ctx context.Context, msg webexCreateMessage, fileName string, content []byte) error {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+
+	fields := map[string]string{
+		"roomId":   msg.RoomID,
+		"parentId": msg.ParentID,
+		"markdown": msg.Markdown,
+	}
+	for name, value := range fields {
+		if value == "" {
+			continue
+		}
+		if err := writer.WriteField(name, value); err != nil {
+			return fmt.Errorf("while writing form field: %w", err)
+		}
+	}
+
+	part, err := writer.CreateFormFile("files", fileName)
+	if err != nil {
+		return fmt.Errorf("while creating form file: %w", err)
+	}
+	if _, err := part.Write(content); err != nil {
+		return fmt.Errorf("while writing form file: %w", err)
+	}
+	if err := writer.Close(); err != nil {
+		return fmt.Errorf("while closing form writer: %w", err)
+	}
+
+	return c.do(ctx, http.MethodPost, "/messages", &body, writer.FormDataContentType(), nil)
+}
+
+// ListWebhooks returns all webhooks registered by the bot.
+func (c *webexClient) ListWebhooks(ctx context.Context) ([]webexWebhook, error) {
+	var out struct {
+		Items []webexWebhook `json:"items"`
+	}
+	if err := c.doJSON(ctx, http.MethodGet, "/webhooks", nil, &out); err != nil {
+		return nil, err
+	}
+	return out.Items, nil
+}
+
+// CreateWebhook registers a given webhook.
+func (c *webexClient) CreateWebhook(ctx context.Context, webhook webexWebhook) error {
+	return c.doJSON(ctx, http.MethodPost, "/webhooks", webhook, nil)
+}
+
+// DeleteWebhook deletes a webhook with a given ID.
+func (c *webexClient) DeleteWebhook(ctx context.Context, webhookID string) error {
+	return c.doJSON(ctx, http.MethodDelete, "/webhooks/"+url.PathEscape(webhookID), nil, nil)
+}
+
+func (c *webexClient) doJSON(ctx context.Context, method, path string, in, out interface{}) error {
+	if in == nil {
+		return c.do(ctx, method, path, nil, "", out)
+	}
+
+	raw, err := json.Marshal(in)
+	if err != nil {
+		return fmt.Errorf("while marshaling request body: %w", err)
+	}
+	return c.do(ctx, method, path, bytes.NewReader(raw), "application/json", out)
+}
+
+func (c *webexClient) do(ctx context.Context, method, path string, body io.Reader, contentType string, out interface{}) (err error) {
+	req, err := http.NewRequestWithContext(ctx, method, c.apiURL+path, body)
+	if err != nil {
+		return fmt.Errorf("while creating request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	res, err := c.httpCli.Do(req)
+	if err != nil {
+		return fmt.Errorf("while sending request: %w", err)
+	}
+	defer func() {
+		if closeErr := res.Body.Close(); closeErr != nil && err == nil {
+			err = fmt.Errorf("while closing response body: %w", closeErr)
+		}
+	}()
+
+	if res.StatusCode < http.StatusOK || res.StatusCode >= http.StatusMultipleChoices {
+		var errResp webexErrorResponse
+		_ = json.NewDecoder(res.Body).Decode(&errResp)
+		return fmt.Errorf("got unexpected response with status %d: %s (tracking ID: %s)", res.StatusCode, errResp.Message, errResp.TrackingID)
+	}
+
+	if out == nil || res.StatusCode == http.StatusNoContent {
+		return nil
+	}
+
+	if err := json.NewDecoder(res.Body).Decode(out); err != nil {
+		return fmt.Errorf("while decoding response: %w", err)
+	}
+	return nil
+}
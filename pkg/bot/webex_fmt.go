@@ -0,0 +1,67 @@
+package bot
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/events"
+	formatx "github.com/kubeshop/botkube/pkg/format"
+)
+
+// webexLevelEmoji holds emojis for the event level. Webex markdown doesn't support text colors.
+var webexLevelEmoji = map[config.Level]string{
+	config.Info:     "🟢",
+	config.Warn:     "🟠",
+	config.Debug:    "🟢",
+	config.Error:    "🔴",
+	config.Critical: "🔴",
+}
+
+func (b *Webex) formatMessage(event events.Event) string {
+	title := fmt.Sprintf("**%s**", event.Title)
+	if emoji, ok := webexLevelEmoji[event.Level]; ok {
+		title = fmt.Sprintf("%s %s", emoji, title)
+	}
+
+	switch eventNotificationType(b.notification, event) {
+	case config.LongNotification:
+		return title + "\n" + b.longNotification(event)
+	case config.ShortNotification:
+		fallthrough
+	default:
+		return title + "\n" + formatx.ShortMessage(event)
+	}
+}
+
+func (b *Webex) longNotification(event events.Event) string {
+	var out strings.Builder
+	writeField := func(title, value string) {
+		if value == "" {
+			return
+		}
+		out.WriteString(fmt.Sprintf("**%s:** %s\n", title, value))
+	}
+	writeList := func(title string, values []string) {
+		if len(values) == 0 {
+			return
+		}
+		out.WriteString(fmt.Sprintf("**%s:**\n", title))
+		for _, value := range values {
+			out.WriteString(fmt.Sprintf("- %s\n", value))
+		}
+	}
+
+	writeField("Kind", event.Kind)
+	writeField("Name", event.Name)
+	writeField("Namespace", event.Namespace)
+	writeField("Owner", event.OwnerString())
+	writeField("Reason", event.Reason)
+	writeList("Message", event.Messages)
+	writeField("Action", event.Action)
+	writeList("Recommendations", event.Recommendations)
+	writeList("Warnings", event.Warnings)
+	writeField("Cluster", event.Cluster)
+
+	return out.String()
+}
@@ -0,0 +1,107 @@
+package bot
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kubeshop/botkube/pkg/bot/interactive"
+	formatx "github.com/kubeshop/botkube/pkg/format"
+)
+
+// WebexRenderer provides functionality to render interactive messages as Webex markdown.
+// Webex markdown messages don't support interactive elements, so they are downgraded:
+// URL buttons are rendered as links, and the other elements as commands which can be copied and sent to the bot.
+type WebexRenderer struct{}
+
+// NewWebexRenderer returns new WebexRenderer instance.
+func NewWebexRenderer() *WebexRenderer {
+	return &WebexRenderer{}
+}
+
+// RenderMessage returns interactive message as Webex markdown.
+func (r *WebexRenderer) RenderMessage(msg interactive.Message) string {
+	var out strings.Builder
+	addLine := func(in string) {
+		out.WriteString(in)
+		out.WriteString("\n")
+	}
+
+	r.renderBase(addLine, msg.Base)
+
+	for _, section := range msg.Sections {
+		addLine("") // padding between sections
+
+		r.renderBase(addLine, section.Base)
+
+		for _, field := range section.TextFields {
+			addLine(field.Text)
+		}
+
+		if section.MultiSelect.AreOptionsDefined() {
+			ms := section.MultiSelect
+			if ms.Description.Plaintext != "" {
+				addLine(ms.Description.Plaintext)
+			}
+			if ms.Description.CodeBlock != "" {
+				addLine(formatx.AdaptiveCodeBlock(ms.Description.CodeBlock))
+			}
+			addLine(fmt.Sprintf("**%s** (send the command with space-separated options):", ms.Name))
+			for _, opt := range ms.Options {
+				addLine(fmt.Sprintf("- %s: `%s`", opt.Name, opt.Value))
+			}
+			addLine(formatx.AdaptiveCodeBlock(ms.Command))
+		}
+
+		for _, item := range section.Selects.Items {
+			addLine(fmt.Sprintf("**%s**:", item.Name))
+			for _, group := range item.OptionGroups {
+				for _, opt := range group.Options {
+					addLine(fmt.Sprintf("- %s: %s", opt.Name, formatx.AdaptiveCodeBlock(fmt.Sprintf("%s %s", item.Command, opt.Value))))
+				}
+			}
+		}
+
+		r.renderInputs(addLine, section.PlaintextInputs)
+
+		for _, btn := range section.Buttons {
+			addLine(r.renderButton(btn))
+		}
+
+		for _, item := range section.Context {
+			addLine(fmt.Sprintf("_%s_", item.Text))
+		}
+	}
+
+	r.renderInputs(addLine, msg.PlaintextInputs)
+
+	return strings.TrimSuffix(out.String(), "\n")
+}
+
+func (r *WebexRenderer) renderBase(addLine func(string), base interactive.Base) {
+	if base.Header != "" {
+		addLine(fmt.Sprintf("**%s**", base.Header))
+	}
+	if base.Description != "" {
+		addLine(base.Description)
+	}
+	if base.Body.Plaintext != "" {
+		addLine(base.Body.Plaintext)
+	}
+	if base.Body.CodeBlock != "" {
+		addLine(formatx.CodeBlock(base.Body.CodeBlock))
+	}
+}
+
+func (r *WebexRenderer) renderInputs(addLine func(string), inputs interactive.LabelInputs) {
+	for _, input := range inputs {
+		addLine(fmt.Sprintf("**%s**: `%s <%s>`", input.Text, input.Command, input.Placeholder))
+	}
+}
+
+func (r *WebexRenderer) renderButton(btn interactive.Button) string {
+	if btn.URL != "" {
+		return fmt.Sprintf("- [%s](%s)", btn.Name, btn.URL)
+	}
+
+	return fmt.Sprintf("- %s: `%s`", btn.Name, btn.Command)
+}
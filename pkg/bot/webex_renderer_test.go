@@ -0,0 +1,67 @@
+package bot
+
+import (
+	"testing"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kubeshop/botkube/pkg/bot/interactive"
+)
+
+func TestWebexRenderer_RenderMessage(t *testing.T) {
+	// given
+	renderer := NewWebexRenderer()
+	msg := interactive.Message{
+		Base: interactive.Base{
+			Header:      "Header",
+			Description: "Description",
+		},
+		Sections: []interactive.Section{
+			{
+				Base: interactive.Base{
+					Header: "Section",
+					Body:   interactive.Body{CodeBlock: "pod-1"},
+				},
+				Buttons: interactive.Buttons{
+					{Name: "Run", Command: "@Botkube get pods"},
+					{Name: "Docs", URL: "https://docs.botkube.io"},
+				},
+				Selects: interactive.Selects{
+					Items: []interactive.Select{
+						{
+							Name:    "Verbs",
+							Command: "@Botkube kcc --verbs",
+							OptionGroups: []interactive.OptionGroup{
+								{Name: "Verbs", Options: []interactive.OptionItem{{Name: "get", Value: "get"}}},
+							},
+						},
+					},
+				},
+			},
+		},
+		PlaintextInputs: interactive.LabelInputs{
+			{Command: "@Botkube kc", Text: "Filter output", Placeholder: "filter"},
+		},
+	}
+
+	expected := heredoc.Doc(`
+		**Header**
+		Description
+
+		**Section**
+		` + "```" + `
+		pod-1
+		` + "```" + `
+		**Verbs**:
+		- get: ` + "`@Botkube kcc --verbs get`" + `
+		- Run: ` + "`@Botkube get pods`" + `
+		- [Docs](https://docs.botkube.io)
+		**Filter output**: ` + "`@Botkube kc <filter>`")
+
+	// when
+	out := renderer.RenderMessage(msg)
+
+	// then
+	assert.Equal(t, expected, out)
+}
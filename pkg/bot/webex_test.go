@@ -0,0 +1,154 @@
+package bot
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha1" // #nosec G505 -- Webex signs webhook requests with HMAC-SHA1
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	logtest "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/pkg/bot/interactive"
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+const webexTestSecret = "secret"
+
+func TestWebex_HandleWebhook(t *testing.T) {
+	// given
+	testCases := []struct {
+		Name             string
+		PersonID         string
+		Text             string
+		InvalidSignature bool
+		ExpectedStatus   int
+		ExpectedCmd      string
+	}{
+		{
+			Name:           "Message with mention",
+			PersonID:       "user-id",
+			Text:           "Botkube get pods",
+			ExpectedStatus: http.StatusOK,
+			ExpectedCmd:    "get pods",
+		},
+		{
+			Name:           "Direct message",
+			PersonID:       "user-id",
+			Text:           "get pods",
+			ExpectedStatus: http.StatusOK,
+			ExpectedCmd:    "get pods",
+		},
+		{
+			Name:           "Message sent by bot",
+			PersonID:       "bot-id",
+			Text:           "done",
+			ExpectedStatus: http.StatusOK,
+		},
+		{
+			Name:             "Invalid signature",
+			PersonID:         "user-id",
+			Text:             "Botkube get pods",
+			InvalidSignature: true,
+			ExpectedStatus:   http.StatusUnauthorized,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			var gotMessages []webexCreateMessage
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+				w.Header().Set("Content-Type", "application/json")
+				switch {
+				case r.Method == http.MethodGet && r.URL.Path == "/messages/msg-id":
+					require.NoError(t, json.NewEncoder(w).Encode(webexMessage{
+						ID:          "msg-id",
+						RoomID:      "room-id",
+						Text:        tc.Text,
+						PersonID:    tc.PersonID,
+						PersonEmail: "user@example.com",
+					}))
+				case r.Method == http.MethodPost && r.URL.Path == "/messages":
+					var msg webexCreateMessage
+					require.NoError(t, json.NewDecoder(r.Body).Decode(&msg))
+					gotMessages = append(gotMessages, msg)
+					_, _ = w.Write([]byte(`{}`))
+				default:
+					t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+				}
+			}))
+			defer srv.Close()
+
+			factory := &fakeExecutorFactory{response: interactive.Message{Base: interactive.Base{Description: "done"}}}
+			bot := newTestWebex(t, factory, srv.URL)
+
+			body := []byte(`{"resource": "messages", "event": "created", "data": {"id": "msg-id", "roomId": "room-id", "personId": "` + tc.PersonID + `"}}`)
+			req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(body))
+			signature := webexSignature(body)
+			if tc.InvalidSignature {
+				signature = webexSignature([]byte("other"))
+			}
+			req.Header.Set(webexSignatureHeader, signature)
+			rec := httptest.NewRecorder()
+
+			// when
+			bot.router().ServeHTTP(rec, req)
+
+			// then
+			assert.Equal(t, tc.ExpectedStatus, rec.Code)
+			if tc.ExpectedCmd == "" {
+				assert.Empty(t, factory.gotInputs)
+				assert.Empty(t, gotMessages)
+				return
+			}
+
+			require.Len(t, factory.gotInputs, 1)
+			assert.Equal(t, tc.ExpectedCmd, factory.gotInputs[0].Message)
+			assert.Equal(t, "room-id", factory.gotInputs[0].Conversation.ID)
+			assert.Equal(t, "user@example.com", factory.gotInputs[0].User)
+			assert.True(t, factory.gotInputs[0].Conversation.IsAuthenticated)
+			assert.Equal(t, "de", factory.gotInputs[0].Conversation.Locale)
+
+			require.Len(t, gotMessages, 1)
+			assert.Equal(t, webexCreateMessage{RoomID: "room-id", ParentID: "msg-id", Markdown: "done"}, gotMessages[0])
+		})
+	}
+}
+
+func newTestWebex(t *testing.T, factory ExecutorFactory, apiURL string) *Webex {
+	t.Helper()
+
+	logger, _ := logtest.NewNullLogger()
+	botMentionRegex, err := webexBotMentionRegex("Botkube")
+	require.NoError(t, err)
+
+	client := newWebexClient("token")
+	client.apiURL = apiURL
+
+	return &Webex{
+		log:             logger,
+		executorFactory: factory,
+		client:          client,
+		renderer:        NewWebexRenderer(),
+		botID:           "bot-id",
+		botName:         "Botkube",
+		messagePath:     "/",
+		webhookSecret:   webexTestSecret,
+		channels: webexChannelsCfgFrom(config.IdentifiableMap[config.ChannelBindingsByID]{
+			"default": {ID: "room-id", Locale: "de"},
+		}),
+		botMentionRegex: botMentionRegex,
+	}
+}
+
+func webexSignature(body []byte) string {
+	mac := hmac.New(sha1.New, []byte(webexTestSecret))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -6,17 +6,23 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/knadh/koanf"
 	koanfyaml "github.com/knadh/koanf/parsers/yaml"
+	"github.com/knadh/koanf/providers/confmap"
 	"github.com/knadh/koanf/providers/env"
 	"github.com/knadh/koanf/providers/file"
 	"github.com/knadh/koanf/providers/rawbytes"
+	"github.com/mitchellh/mapstructure"
 	"github.com/spf13/pflag"
 	"golang.org/x/text/cases"
 	"golang.org/x/text/language"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/kubeshop/botkube/pkg/expression"
 )
 
 //go:embed default.yaml
@@ -94,11 +100,56 @@ const (
 	// DiscordCommPlatformIntegration defines Discord integration.
 	DiscordCommPlatformIntegration CommPlatformIntegration = "discord"
 
+	// RocketChatCommPlatformIntegration defines Rocket.Chat integration.
+	RocketChatCommPlatformIntegration CommPlatformIntegration = "rocketChat"
+
+	// GoogleChatCommPlatformIntegration defines Google Chat integration.
+	GoogleChatCommPlatformIntegration CommPlatformIntegration = "googleChat"
+
+	// WebexCommPlatformIntegration defines Webex integration.
+	WebexCommPlatformIntegration CommPlatformIntegration = "webex"
+
+	// MatrixCommPlatformIntegration defines Matrix integration.
+	MatrixCommPlatformIntegration CommPlatformIntegration = "matrix"
+
+	// LoopbackCommPlatformIntegration defines an integration which records messages instead of sending them.
+	LoopbackCommPlatformIntegration CommPlatformIntegration = "loopback"
+
 	//ElasticsearchCommPlatformIntegration defines Elasticsearch integration.
 	ElasticsearchCommPlatformIntegration CommPlatformIntegration = "elasticsearch"
 
 	// WebhookCommPlatformIntegration defines an outgoing webhook integration.
 	WebhookCommPlatformIntegration CommPlatformIntegration = "webhook"
+
+	// PagerDutyCommPlatformIntegration defines PagerDuty integration.
+	PagerDutyCommPlatformIntegration CommPlatformIntegration = "pagerDuty"
+
+	// OpsgenieCommPlatformIntegration defines Opsgenie integration.
+	OpsgenieCommPlatformIntegration CommPlatformIntegration = "opsgenie"
+
+	// KafkaCommPlatformIntegration defines Kafka integration.
+	KafkaCommPlatformIntegration CommPlatformIntegration = "kafka"
+
+	// AWSCommPlatformIntegration defines AWS SNS and SQS integration.
+	AWSCommPlatformIntegration CommPlatformIntegration = "aws"
+
+	// AzureEventHubCommPlatformIntegration defines Azure Event Hub integration.
+	AzureEventHubCommPlatformIntegration CommPlatformIntegration = "azureEventHub"
+
+	// LokiCommPlatformIntegration defines Grafana Loki integration.
+	LokiCommPlatformIntegration CommPlatformIntegration = "loki"
+
+	// DatadogCommPlatformIntegration defines Datadog integration.
+	DatadogCommPlatformIntegration CommPlatformIntegration = "datadog"
+
+	// JiraCommPlatformIntegration defines Jira integration.
+	JiraCommPlatformIntegration CommPlatformIntegration = "jira"
+
+	// ServiceNowCommPlatformIntegration defines ServiceNow integration.
+	ServiceNowCommPlatformIntegration CommPlatformIntegration = "serviceNow"
+
+	// HubCommPlatformIntegration defines an integration which forwards events to the Botkube hub.
+	HubCommPlatformIntegration CommPlatformIntegration = "hub"
 )
 
 // IntegrationType describes the type of integration with a communication platform.
@@ -129,17 +180,44 @@ type Config struct {
 	Executors      map[string]Executors      `yaml:"executors" validate:"dive"`
 	Communications map[string]Communications `yaml:"communications"  validate:"required,min=1,dive"`
 	Filters        Filters                   `yaml:"filters"`
+	Aliases        Aliases                   `yaml:"aliases"`
+	Schedules      Schedules                 `yaml:"schedules"`
 
 	Analytics     Analytics  `yaml:"analytics"`
 	Settings      Settings   `yaml:"settings"`
 	ConfigWatcher CfgWatcher `yaml:"configWatcher"`
 }
 
+// Aliases contains command aliases. The key is the alias name, and the value is the command which it expands to.
+// The command may reference the alias arguments with the `{{.arg1}}` placeholders. An empty command disables a given alias.
+type Aliases map[string]string
+
+// Schedules contains commands executed periodically. The key is the schedule ID. Schedules are managed with the `schedule` command.
+type Schedules map[string]Schedule
+
+// Schedule is a command executed periodically in a given channel, on behalf of the user who scheduled it.
+type Schedule struct {
+	// Cron is the cron expression, e.g. `0 9 * * 1-5`.
+	Cron      string                  `yaml:"cron"`
+	Command   string                  `yaml:"command"`
+	CommGroup string                  `yaml:"commGroup"`
+	Platform  CommPlatformIntegration `yaml:"platform"`
+	// ChannelID is the ID of the conversation where the command output is posted.
+	ChannelID string `yaml:"channelID"`
+	User      string `yaml:"user"`
+	UserID    string `yaml:"userID"`
+}
+
 // ChannelBindingsByName contains configuration bindings per channel.
 type ChannelBindingsByName struct {
 	Name         string              `yaml:"name"`
 	Notification ChannelNotification `yaml:"notification"` // TODO: rename to `notifications` later
 	Bindings     BotBindings         `yaml:"bindings"`
+	Commands     ChannelCommands     `yaml:"commands"`
+	Locale       string              `yaml:"locale,omitempty"`
+	// NotificationSchedule defines the working hours of the channel. Outside them, non-critical events are held
+	// and delivered as a digest when the next working hours window starts.
+	NotificationSchedule NotificationSchedule `yaml:"notificationSchedule,omitempty"`
 }
 
 // Identifier returns ChannelBindingsByID identifier.
@@ -152,6 +230,11 @@ type ChannelBindingsByID struct {
 	ID           string              `yaml:"id"`
 	Notification ChannelNotification `yaml:"notification"` // TODO: rename to `notifications` later
 	Bindings     BotBindings         `yaml:"bindings"`
+	Commands     ChannelCommands     `yaml:"commands"`
+	Locale       string              `yaml:"locale,omitempty"`
+	// NotificationSchedule defines the working hours of the channel. Outside them, non-critical events are held
+	// and delivered as a digest when the next working hours window starts.
+	NotificationSchedule NotificationSchedule `yaml:"notificationSchedule,omitempty"`
 }
 
 // Identifier returns ChannelBindingsByID identifier.
@@ -165,6 +248,15 @@ type BotBindings struct {
 	Executors []string `yaml:"executors"`
 }
 
+// ChannelCommands contains per-channel command restrictions.
+// Each entry is a command prefix, e.g. `kubectl delete`. Kubectl aliases (`kc`, `k`) are treated the same as `kubectl`.
+type ChannelCommands struct {
+	// Allowed lists commands that can be executed in a given channel. If empty, all commands are allowed.
+	Allowed []string `yaml:"allowed"`
+	// Blocked lists commands that cannot be executed in a given channel. It takes precedence over Allowed.
+	Blocked []string `yaml:"blocked"`
+}
+
 // SinkBindings contains configuration for possible Sink bindings.
 type SinkBindings struct {
 	Sources []string `yaml:"sources"`
@@ -179,6 +271,34 @@ type Action struct {
 	DisplayName string         `yaml:"displayName"`
 	Command     string         `yaml:"command" validate:"required_if=Enabled true"`
 	Bindings    ActionBindings `yaml:"bindings"`
+	// Condition is a CEL expression which must evaluate to true for the action to run, e.g. `event.reason == "BackOff"`.
+	// It can reference the `event` and its raw Kubernetes `object`. If empty, the action runs for all events of the bound sources.
+	Condition string `yaml:"condition,omitempty"`
+	// DryRun posts the rendered command to the bound channels without executing it.
+	DryRun bool `yaml:"dryRun"`
+	// Cooldown is the minimal time between two executions of the action. If 0, the action runs for every matching event.
+	Cooldown time.Duration `yaml:"cooldown"`
+	// Approval requires the command to be approved before it's executed.
+	Approval ActionApproval `yaml:"approval"`
+}
+
+// ActionApproval contains configuration for approving the action commands before they are executed.
+type ActionApproval struct {
+	Enabled bool `yaml:"enabled"`
+	// Channel is the name of the Socket Slack channel where the approval requests are posted.
+	Channel string `yaml:"channel" validate:"required_if=Enabled true"`
+	// Approvers lists the IDs of the Slack users allowed to approve the command.
+	Approvers []string `yaml:"approvers" validate:"required_if=Enabled true"`
+	// Timeout is the time after which the request expires and the command is not executed. Defaults to 15m.
+	Timeout time.Duration `yaml:"timeout"`
+}
+
+// CompileCondition returns the compiled action condition, or nil if the condition is not set.
+func (a Action) CompileCondition() (*expression.Program, error) {
+	if a.Condition == "" {
+		return nil, nil
+	}
+	return expression.Compile(a.Condition, "event", "object")
 }
 
 // ActionBindings contains configuration for action bindings.
@@ -189,8 +309,202 @@ type ActionBindings struct {
 
 // Sources contains configuration for Botkube app sources.
 type Sources struct {
-	DisplayName string           `yaml:"displayName"`
-	Kubernetes  KubernetesSource `yaml:"kubernetes"`
+	DisplayName  string             `yaml:"displayName"`
+	Kubernetes   KubernetesSource   `yaml:"kubernetes"`
+	Alertmanager AlertmanagerSource `yaml:"alertmanager"`
+	Audit        AuditSource        `yaml:"audit"`
+	ArgoCD       ArgoCDSource       `yaml:"argocd"`
+	Helm         HelmSource         `yaml:"helm"`
+	CertManager  CertManagerSource  `yaml:"certManager"`
+	Jobs         JobsSource         `yaml:"jobs"`
+	Nodes        NodesSource        `yaml:"nodes"`
+	PodCrashes   PodCrashesSource   `yaml:"podCrashes"`
+	Velero       VeleroSource       `yaml:"velero"`
+	Flux         FluxSource         `yaml:"flux"`
+	PVCUsage     PVCUsageSource     `yaml:"pvcUsage"`
+	Trivy        TrivySource        `yaml:"trivy"`
+	// Plugins holds configuration of the source plugins, indexed by the plugin name.
+	Plugins map[string]SourcePlugin `yaml:"plugins"`
+	// Route sends events of given levels to dedicated channels, instead of the channels bound to this source.
+	Route SourceRoute `yaml:"route,omitempty"`
+	// Filters lists the filters run for events of this source, in a given order.
+	// If not set, the filters enabled under the top-level `filters` property are used.
+	Filters []string `yaml:"filters,omitempty"`
+}
+
+// SourceRoute contains channels for events of given levels. Channels are matched by name, ID or alias, across all communication platforms.
+// Levels without configured channels are sent to the channels bound to the source.
+type SourceRoute struct {
+	Critical []string `yaml:"critical,omitempty"`
+	Error    []string `yaml:"error,omitempty"`
+	Warn     []string `yaml:"warn,omitempty"`
+	Info     []string `yaml:"info,omitempty"`
+	Debug    []string `yaml:"debug,omitempty"`
+}
+
+// ChannelsForLevel returns the channels configured for a given event level.
+func (r SourceRoute) ChannelsForLevel(level Level) []string {
+	switch level {
+	case Critical:
+		return r.Critical
+	case Error:
+		return r.Error
+	case Warn:
+		return r.Warn
+	case Info:
+		return r.Info
+	case Debug:
+		return r.Debug
+	}
+	return nil
+}
+
+// SourcePlugin contains configuration for a source plugin.
+type SourcePlugin struct {
+	Enabled bool `yaml:"enabled"`
+	// Config is passed to the plugin as JSON.
+	Config map[string]interface{} `yaml:"config"`
+}
+
+// TrivySource contains configuration for Trivy Operator vulnerability reports.
+type TrivySource struct {
+	Enabled bool `yaml:"enabled"`
+	// Namespaces limits the reports to the ones in given namespaces. If not configured, all namespaces are watched.
+	Namespaces Namespaces `yaml:"namespaces"`
+	// MinSeverity defines the lowest severity of vulnerabilities, which trigger the event. Defaults to HIGH.
+	MinSeverity string `yaml:"minSeverity" validate:"omitempty,oneof=CRITICAL HIGH MEDIUM LOW UNKNOWN"`
+	// TopVulnerabilities defines the number of the most severe vulnerabilities listed in the event. Defaults to 5.
+	TopVulnerabilities int `yaml:"topVulnerabilities"`
+}
+
+// PVCUsageSource contains configuration for the PersistentVolumeClaim usage checks, based on the kubelet volume stats.
+type PVCUsageSource struct {
+	Enabled bool `yaml:"enabled"`
+	// Namespaces limits the PersistentVolumeClaims to the ones in given namespaces. If not configured, all namespaces are watched.
+	Namespaces Namespaces `yaml:"namespaces"`
+	// Interval defines how often the volume stats are polled. If source bindings use different intervals, the shortest one is used.
+	Interval time.Duration `yaml:"interval"`
+	// Thresholds define the usage percentages, which are reported once crossed. Defaults to 80 and 90.
+	Thresholds []int `yaml:"thresholds"`
+}
+
+// FluxSource contains configuration for Flux Kustomization and HelmRelease reconciliation events.
+type FluxSource struct {
+	Enabled bool `yaml:"enabled"`
+	// Namespaces limits the Flux resources to the ones in given namespaces. If not configured, all namespaces are watched.
+	Namespaces Namespaces `yaml:"namespaces"`
+}
+
+// VeleroSource contains configuration for Velero Backup and Restore outcome events.
+type VeleroSource struct {
+	Enabled bool `yaml:"enabled"`
+}
+
+// PodCrashesSource contains configuration for the OOMKilled and crash looping containers detection.
+type PodCrashesSource struct {
+	Enabled bool `yaml:"enabled"`
+	// Namespaces limits the Pods to the ones in given namespaces. If not configured, all namespaces are watched.
+	Namespaces Namespaces `yaml:"namespaces"`
+	// MinRestarts defines the number of container restarts after which the event is sent. Defaults to 3.
+	MinRestarts int `yaml:"minRestarts"`
+	// LogLines defines the number of the last log lines of the crashed container attached to the event. Defaults to 20.
+	LogLines int `yaml:"logLines"`
+}
+
+// NodesSource contains configuration for the periodic node conditions and capacity checks.
+type NodesSource struct {
+	Enabled bool `yaml:"enabled"`
+	// Interval defines how often the nodes are inspected. If source bindings use different intervals, the shortest one is used.
+	Interval time.Duration `yaml:"interval"`
+	// Conditions lists the node conditions, which are reported when true. Defaults to MemoryPressure, DiskPressure, and PIDPressure.
+	Conditions []string `yaml:"conditions"`
+	// Thresholds define the percentage of node allocatable resources, which is reported when requested by Pods.
+	Thresholds NodeThresholds `yaml:"thresholds"`
+}
+
+// NodeThresholds defines the percentage of node allocatable resources requested by Pods. Zero disables a given threshold.
+type NodeThresholds struct {
+	CPURequests    int `yaml:"cpuRequests"`
+	MemoryRequests int `yaml:"memoryRequests"`
+	Pods           int `yaml:"pods"`
+}
+
+// JobsSource contains configuration for Job outcome and CronJob missed schedule events.
+type JobsSource struct {
+	Enabled bool `yaml:"enabled"`
+	// Namespaces limits the Jobs and CronJobs to the ones in given namespaces. If not configured, all namespaces are watched.
+	Namespaces Namespaces `yaml:"namespaces"`
+	// NotifyOnCompletion enables events for successfully completed Jobs. Failed Jobs are always reported.
+	NotifyOnCompletion bool `yaml:"notifyOnCompletion"`
+}
+
+// CertManagerSource contains configuration for cert-manager Certificate expiry and renewal failure events.
+type CertManagerSource struct {
+	Enabled bool `yaml:"enabled"`
+	// Namespaces limits the Certificates to the ones in given namespaces. If not configured, all namespaces are watched.
+	Namespaces Namespaces `yaml:"namespaces"`
+	// ExpiryWarningDays defines how many days before the expiry a warning is sent. Defaults to 14 days.
+	ExpiryWarningDays int `yaml:"expiryWarningDays"`
+}
+
+// HelmSource contains configuration for Helm release lifecycle events.
+type HelmSource struct {
+	Enabled bool `yaml:"enabled"`
+	// Namespaces limits the releases to the ones installed in given namespaces. If not configured, all namespaces are watched.
+	Namespaces Namespaces `yaml:"namespaces"`
+}
+
+// ArgoCDSource contains configuration for Argo CD Application sync and health status changes.
+type ArgoCDSource struct {
+	Enabled bool `yaml:"enabled"`
+}
+
+// AlertmanagerSource contains configuration for Prometheus Alertmanager alerts, received by the source server.
+type AlertmanagerSource struct {
+	Enabled bool `yaml:"enabled"`
+	// Receivers limits the alerts to the ones sent by given Alertmanager receivers. If empty, alerts from all receivers are accepted.
+	Receivers []string `yaml:"receivers"`
+}
+
+// AuditSource contains configuration for Kubernetes audit events, received by the source server.
+type AuditSource struct {
+	Enabled bool `yaml:"enabled"`
+	// Rules define the audit events which are reported. The first matching rule is used.
+	Rules []AuditRule `yaml:"rules" validate:"dive"`
+}
+
+// AuditRule defines the audit events which are reported. Empty criteria match all audit events.
+type AuditRule struct {
+	Name string `yaml:"name" validate:"required"`
+	// Description is attached to the event.
+	Description string `yaml:"description"`
+	// Level of the event. Defaults to warn.
+	Level Level `yaml:"level"`
+	// Verbs of the API requests, such as `get`, `create`, or `delete`.
+	Verbs []string `yaml:"verbs"`
+	// Resources of the API requests, optionally with subresource, such as `secrets` or `pods/exec`.
+	Resources  []string   `yaml:"resources"`
+	Namespaces Namespaces `yaml:"namespaces"`
+	Users      AuditUsers `yaml:"users"`
+}
+
+// AuditUsers provides an option to include and exclude given user names, for example service accounts.
+type AuditUsers struct {
+	// Include contains a list of user names. It can also contain a regex expressions.
+	Include []string `yaml:"include"`
+	// Exclude contains a list of user names to be ignored even if allowed by Include. It can also contain a regex expressions.
+	Exclude []string `yaml:"exclude,omitempty"`
+}
+
+// IsConfigured checks whether the users have any Include/Exclude configuration.
+func (u AuditUsers) IsConfigured() bool {
+	return len(u.Include) > 0 || len(u.Exclude) > 0
+}
+
+// IsAllowed checks if a given user name is allowed based on the config. The same rules as for Namespaces apply.
+func (u AuditUsers) IsAllowed(username string) bool {
+	ns := Namespaces{Include: u.Include, Exclude: u.Exclude}
+	return ns.IsAllowed(username)
 }
 
 // KubernetesSource contains configuration for Kubernetes sources.
@@ -199,6 +513,38 @@ type KubernetesSource struct {
 	Event           KubernetesEvent `yaml:"event"`
 	Resources       []Resource      `yaml:"resources" validate:"dive"`
 	Namespaces      Namespaces      `yaml:"namespaces"`
+	// ContainerLogs attaches the last logs of the failing container to the Pod error events.
+	ContainerLogs ContainerLogs `yaml:"containerLogs"`
+	// Expressions lists the CEL expressions which must all evaluate to true for the event to be sent,
+	// e.g. `event.reason == "Unhealthy" && event.count > 3`. They can reference the `event` and its raw Kubernetes `object`.
+	Expressions []string `yaml:"expressions,omitempty"`
+	// Template is used for all resources of the source, unless the resource defines its own template.
+	Template EventTemplate `yaml:"template,omitempty"`
+}
+
+// CompileExpressions returns the compiled event expressions.
+func (r KubernetesSource) CompileExpressions() ([]*expression.Program, error) {
+	var out []*expression.Program
+	for idx, expr := range r.Expressions {
+		program, err := expression.Compile(expr, "event", "object")
+		if err != nil {
+			return nil, fmt.Errorf("expressions[%d]: %w", idx, err)
+		}
+		out = append(out, program)
+	}
+	return out, nil
+}
+
+// ContainerLogs contains configuration for attaching the container logs to the Pod error events.
+type ContainerLogs struct {
+	Enabled bool `yaml:"enabled"`
+	// Lines defines the number of the last log lines to fetch. Defaults to 20.
+	Lines int `yaml:"lines"`
+	// MaxBytes limits the size of the attached logs. Older lines are dropped to fit the limit. Defaults to 8192.
+	MaxBytes int `yaml:"maxBytes"`
+	// Redaction lists the patterns masked in the logs, in addition to the global redaction rules.
+	// The JSONPath rules don't apply to the logs.
+	Redaction []RedactionRule `yaml:"redaction" validate:"dive"`
 }
 
 // KubernetesEvent contains configuration for Kubernetes events.
@@ -241,6 +587,8 @@ func (r *KubernetesSource) IsAllowed(resourceType, namespace string, eventType E
 type Recommendations struct {
 	Ingress IngressRecommendations `yaml:"ingress"`
 	Pod     PodRecommendations     `yaml:"pod"`
+	// Providers enables the registered recommendation providers, indexed by the provider name.
+	Providers map[string]bool `yaml:"providers,omitempty"`
 }
 
 // PodRecommendations contains configuration for pods recommendations.
@@ -264,6 +612,20 @@ type IngressRecommendations struct {
 // Executors contains executors configuration parameters.
 type Executors struct {
 	Kubectl Kubectl `yaml:"kubectl"`
+	Helm    Helm    `yaml:"helm"`
+	Top     Top     `yaml:"top"`
+	Diag    Diag    `yaml:"diag"`
+	Events  Events  `yaml:"events"`
+	RBAC    RBAC    `yaml:"rbac"`
+	// Plugins holds configuration of the executor plugins, indexed by the plugin name. The plugin name is also the command name.
+	Plugins map[string]ExecutorPlugin `yaml:"plugins"`
+}
+
+// ExecutorPlugin contains configuration for an executor plugin.
+type ExecutorPlugin struct {
+	Enabled bool `yaml:"enabled"`
+	// Config is passed to the plugin as JSON together with each command.
+	Config map[string]interface{} `yaml:"config"`
 }
 
 // Filters contains configuration for built-in filters.
@@ -306,6 +668,31 @@ type Resource struct {
 	Namespaces    Namespaces      `yaml:"namespaces"`
 	Event         KubernetesEvent `yaml:"event"`
 	UpdateSetting UpdateSetting   `yaml:"updateSetting"`
+	// LabelSelector limits the watched objects to the ones with matching labels, e.g. "app=ingress".
+	LabelSelector string `yaml:"labelSelector,omitempty"`
+	// FieldSelector limits the watched objects to the ones with matching fields, e.g. "status.phase!=Running".
+	FieldSelector string `yaml:"fieldSelector,omitempty"`
+	// Template overrides the default event title and message, which is useful for custom resources.
+	Template EventTemplate `yaml:"template"`
+}
+
+// EventTemplate contains user-defined Go templates of the event title, message and notification body.
+type EventTemplate struct {
+	// Title is a Go template of the event title.
+	Title string `yaml:"title"`
+	// Message is a Go template of the event message.
+	Message string `yaml:"message"`
+	// Body is a Go template of the whole notification text. It replaces the default short and long layouts.
+	// If it renders to an empty string, the default layout is used.
+	Body string `yaml:"body,omitempty"`
+	// Fields maps field names to JSONPath expressions evaluated against the resource.
+	// The extracted values are available in the templates as `.Fields.<name>`.
+	Fields map[string]string `yaml:"fields"`
+}
+
+// IsConfigured returns true if any of the templates is set.
+func (t EventTemplate) IsConfigured() bool {
+	return t.Title != "" || t.Message != "" || t.Body != ""
 }
 
 // KubernetesResourceEventTypes contains events to watch for a resource.
@@ -333,8 +720,10 @@ func (e *KubernetesResourceEventTypes) Contains(eventType EventType) bool {
 
 // UpdateSetting struct defines updateEvent fields specification
 type UpdateSetting struct {
-	Fields      []string `yaml:"fields"`
-	IncludeDiff bool     `yaml:"includeDiff"`
+	// Fields are JSONPath expressions, such as `spec.replicas`. If set, update events are sent only when any of the fields changes.
+	Fields []string `yaml:"fields"`
+	// IncludeDiff adds the old and new values of the changed fields to the event message.
+	IncludeDiff bool `yaml:"includeDiff"`
 }
 
 // Namespaces provides an option to include and exclude given Namespaces.
@@ -347,12 +736,39 @@ type Namespaces struct {
 	// Exclude contains a list of Namespaces to be ignored even if allowed by Include.
 	// It can also contain a regex expressions:
 	//  - "test-.*" - to specif all Namespaces with `test-` prefix.
+	// It requires Include or LabelSelector to be set, and it cannot contain the same entries as Include.
 	Exclude []string `yaml:"exclude,omitempty"`
+
+	// LabelSelector allows Namespaces with matching labels, in addition to the ones allowed by Include.
+	// It uses the Kubernetes label selector syntax, e.g. "team=payments,env!=dev".
+	// Namespace labels are resolved only by the Kubernetes source.
+	LabelSelector string `yaml:"labelSelector,omitempty"`
 }
 
-// IsConfigured checks whether the Namespace has any Include/Exclude configuration.
+// IsConfigured checks whether the Namespace has any Include/Exclude/LabelSelector configuration.
 func (n *Namespaces) IsConfigured() bool {
-	return len(n.Include) > 0 || len(n.Exclude) > 0
+	return len(n.Include) > 0 || len(n.Exclude) > 0 || n.LabelSelector != ""
+}
+
+// IsAllowedWithLabels checks if a given Namespace is allowed based on the config.
+// Apart from the Include list, the Namespace is allowed if its labels match the LabelSelector.
+func (n *Namespaces) IsAllowedWithLabels(givenNs string, nsLabels map[string]string) bool {
+	if n == nil || givenNs == "" {
+		return false
+	}
+
+	if n.IsAllowed(givenNs) {
+		return true
+	}
+	if n.LabelSelector == "" || n.isExcluded(givenNs) {
+		return false
+	}
+
+	selector, err := labels.Parse(n.LabelSelector)
+	if err != nil {
+		return false
+	}
+	return selector.Matches(labels.Set(nsLabels))
 }
 
 // IsAllowed checks if a given Namespace is allowed based on the config.
@@ -362,22 +778,8 @@ func (n *Namespaces) IsAllowed(givenNs string) bool {
 	}
 
 	// 1. Check if excluded
-	if len(n.Exclude) > 0 {
-		for _, excludeNamespace := range n.Exclude {
-			if strings.TrimSpace(excludeNamespace) == "" {
-				continue
-			}
-			// exact match
-			if excludeNamespace == givenNs {
-				return false
-			}
-
-			// regexp
-			matched, err := regexp.MatchString(excludeNamespace, givenNs)
-			if err == nil && matched {
-				return false
-			}
-		}
+	if n.isExcluded(givenNs) {
+		return false
 	}
 
 	// 2. Check if included, if matched, return true
@@ -404,6 +806,25 @@ func (n *Namespaces) IsAllowed(givenNs string) bool {
 	return false
 }
 
+func (n *Namespaces) isExcluded(givenNs string) bool {
+	for _, excludeNamespace := range n.Exclude {
+		if strings.TrimSpace(excludeNamespace) == "" {
+			continue
+		}
+		// exact match
+		if excludeNamespace == givenNs {
+			return true
+		}
+
+		// regexp
+		matched, err := regexp.MatchString(excludeNamespace, givenNs)
+		if err == nil && matched {
+			return true
+		}
+	}
+	return false
+}
+
 // Notification holds notification configuration.
 type Notification struct {
 	Type NotificationType
@@ -412,6 +833,22 @@ type Notification struct {
 // ChannelNotification contains notification configuration for a given platform.
 type ChannelNotification struct {
 	Disabled bool `yaml:"disabled"`
+	// SnoozedUntil is the time until which the notifications are temporarily disabled.
+	SnoozedUntil time.Time     `yaml:"snoozedUntil,omitempty"`
+	Digest       ChannelDigest `yaml:"digest,omitempty"`
+}
+
+// IsSnoozed returns true if the notifications are snoozed at a given time.
+func (n ChannelNotification) IsSnoozed(now time.Time) bool {
+	return now.Before(n.SnoozedUntil)
+}
+
+// ChannelDigest contains configuration for batching non-critical events into periodic summary messages.
+// Events with the error and critical levels are always sent immediately.
+type ChannelDigest struct {
+	Enabled bool `yaml:"enabled"`
+	// Interval is the time between the digest messages. Defaults to 15m.
+	Interval time.Duration `yaml:"interval"`
 }
 
 // Communications contains communication platforms that are supported.
@@ -420,9 +857,23 @@ type Communications struct {
 	SocketSlack   SocketSlack   `yaml:"socketSlack"`
 	Mattermost    Mattermost    `yaml:"mattermost"`
 	Discord       Discord       `yaml:"discord"`
+	RocketChat    RocketChat    `yaml:"rocketChat"`
+	GoogleChat    GoogleChat    `yaml:"googleChat"`
+	Webex         Webex         `yaml:"webex"`
+	Matrix        Matrix        `yaml:"matrix"`
 	Teams         Teams         `yaml:"teams"`
+	Loopback      Loopback      `yaml:"loopback"`
 	Webhook       Webhook       `yaml:"webhook"`
 	Elasticsearch Elasticsearch `yaml:"elasticsearch"`
+	PagerDuty     PagerDuty     `yaml:"pagerDuty"`
+	Opsgenie      Opsgenie      `yaml:"opsgenie"`
+	Kafka         Kafka         `yaml:"kafka"`
+	AWS           AWS           `yaml:"aws"`
+	AzureEventHub AzureEventHub `yaml:"azureEventHub"`
+	Loki          Loki          `yaml:"loki"`
+	Datadog       Datadog       `yaml:"datadog"`
+	Jira          Jira          `yaml:"jira"`
+	ServiceNow    ServiceNow    `yaml:"serviceNow"`
 }
 
 // Slack configuration to authentication and send notifications
@@ -431,15 +882,160 @@ type Slack struct {
 	Channels     IdentifiableMap[ChannelBindingsByName] `yaml:"channels"  validate:"required_if=Enabled true,dive,omitempty,min=1"`
 	Notification Notification                           `yaml:"notification,omitempty"`
 	Token        string                                 `yaml:"token,omitempty"`
+	// EventThreads holds the configuration of posting related events as thread replies.
+	EventThreads SlackEventThreads `yaml:"eventThreads,omitempty"`
 }
 
 // SocketSlack configuration to authentication and send notifications
 type SocketSlack struct {
 	Enabled      bool                                   `yaml:"enabled"`
-	Channels     IdentifiableMap[ChannelBindingsByName] `yaml:"channels"  validate:"required_if=Enabled true,dive,omitempty,min=1"`
+	Channels     IdentifiableMap[ChannelBindingsByName] `yaml:"channels"  validate:"dive,omitempty,min=1"`
 	Notification Notification                           `yaml:"notification,omitempty"`
 	BotToken     string                                 `yaml:"botToken,omitempty"`
 	AppToken     string                                 `yaml:"appToken,omitempty"`
+	Reactions    SlackReactions                         `yaml:"reactions"`
+	// GracefulShutdown holds the configuration of finishing in-flight commands when the bot shuts down.
+	GracefulShutdown BotGracefulShutdown `yaml:"gracefulShutdown"`
+	// CommandCancellation holds the configuration of cancelling long-running commands with a button.
+	CommandCancellation SlackCommandCancellation `yaml:"commandCancellation"`
+	// OutputPagination holds the configuration of splitting too long command responses into pages.
+	OutputPagination SlackOutputPagination `yaml:"outputPagination"`
+	// Workspaces holds additional Slack workspaces. The top-level tokens and channels define the default workspace.
+	// Channel aliases must be unique across all workspaces, as the channels state is persisted by alias.
+	Workspaces []SocketSlackWorkspace `yaml:"workspaces,omitempty" validate:"dive"`
+	// ChannelDiscovery holds the configuration of binding channels based on a marker in their topic.
+	ChannelDiscovery SlackChannelDiscovery `yaml:"channelDiscovery"`
+	// EventThreads holds the configuration of posting related events as thread replies.
+	EventThreads SlackEventThreads `yaml:"eventThreads,omitempty"`
+	// ActiveThreads holds the configuration of handling follow-up commands in threads without the bot mention.
+	ActiveThreads SlackActiveThreads `yaml:"activeThreads"`
+}
+
+// SlackActiveThreads contains configuration for handling follow-up commands in threads without the bot mention.
+// A thread is activated once a user runs a command with the bot mention in the thread, in a channel configured for Botkube.
+// Then, the follow-up messages of that user are handled as commands if they start with the prefix, or if the thread starts with a Botkube message.
+type SlackActiveThreads struct {
+	// TTL is the time since the last command in the thread, after which the thread is deactivated. Defaults to 1h.
+	TTL time.Duration `yaml:"ttl"`
+	// Prefix marks the follow-up commands, e.g. `!get pods`. Defaults to `!`.
+	Prefix string `yaml:"prefix"`
+}
+
+// SlackEventThreads contains configuration for posting related events, such as repeated failures of a given Deployment,
+// as replies in the thread of the first event message. Events are related if they have the same type and concern
+// the same object, or objects with the same top-level owner.
+type SlackEventThreads struct {
+	Enabled bool `yaml:"enabled"`
+	// TTL is the time since the last related event, after which a new event starts a new thread. Defaults to 1h.
+	TTL time.Duration `yaml:"ttl"`
+}
+
+// SlackChannelDiscovery contains configuration for discovering channels the bot is a member of.
+// A channel is bound if its topic contains the `botkube:<profile>` marker, where `<profile>` is the name of a configured profile.
+type SlackChannelDiscovery struct {
+	Enabled bool `yaml:"enabled"`
+	// Interval is the time between listing the channels. Channels without the marker are unbound on the next run.
+	Interval time.Duration `yaml:"interval"`
+	// Profiles holds the configuration applied to the discovered channels. The property name is the profile name used in the marker.
+	Profiles map[string]ChannelBindingsProfile `yaml:"profiles" validate:"required_if=Enabled true"`
+}
+
+// ChannelBindingsProfile contains configuration applied to all channels bound with a given profile.
+type ChannelBindingsProfile struct {
+	Notification ChannelNotification `yaml:"notification"`
+	Bindings     BotBindings         `yaml:"bindings"`
+	Commands     ChannelCommands     `yaml:"commands"`
+	Locale       string              `yaml:"locale,omitempty"`
+}
+
+// SocketSlackWorkspace contains configuration for a single Slack workspace.
+type SocketSlackWorkspace struct {
+	Name string `yaml:"name" validate:"required"`
+	// TeamID is the ID of the workspace in the Slack Enterprise Grid organization.
+	// If set, and the workspace doesn't define own tokens, its channels are served by the org-wide app configured with the top-level tokens.
+	TeamID   string                                 `yaml:"teamID,omitempty"`
+	Channels IdentifiableMap[ChannelBindingsByName] `yaml:"channels"  validate:"required,dive,omitempty,min=1"`
+	BotToken string                                 `yaml:"botToken,omitempty"`
+	AppToken string                                 `yaml:"appToken,omitempty"`
+}
+
+// IsServedByOrgApp returns true if the workspace is a part of the Enterprise Grid organization served by the org-wide app.
+func (w SocketSlackWorkspace) IsServedByOrgApp() bool {
+	return w.TeamID != "" && w.BotToken == "" && w.AppToken == ""
+}
+
+// HasDefaultWorkspace returns true if the top-level tokens or channels are configured,
+// or there are no additional workspaces defined.
+func (s SocketSlack) HasDefaultWorkspace() bool {
+	return s.BotToken != "" || s.AppToken != "" || len(s.Channels) > 0 || len(s.Workspaces) == 0
+}
+
+// OrgWorkspaces returns the Enterprise Grid workspaces served by the org-wide app configured with the top-level tokens.
+func (s SocketSlack) OrgWorkspaces() []SocketSlackWorkspace {
+	var out []SocketSlackWorkspace
+	for _, workspace := range s.Workspaces {
+		if workspace.IsServedByOrgApp() {
+			out = append(out, workspace)
+		}
+	}
+	return out
+}
+
+// ForWorkspace returns the configuration with tokens and channels of a given workspace.
+func (s SocketSlack) ForWorkspace(workspace SocketSlackWorkspace) SocketSlack {
+	out := s
+	out.Channels = workspace.Channels
+	out.BotToken = workspace.BotToken
+	out.AppToken = workspace.AppToken
+	out.Workspaces = nil
+	return out
+}
+
+// AllChannels returns channels from all workspaces.
+func (s SocketSlack) AllChannels() IdentifiableMap[ChannelBindingsByName] {
+	out := IdentifiableMap[ChannelBindingsByName]{}
+	for alias, channel := range s.Channels {
+		out[alias] = channel
+	}
+	for _, workspace := range s.Workspaces {
+		for alias, channel := range workspace.Channels {
+			out[alias] = channel
+		}
+	}
+	return out
+}
+
+// SlackReactions contains configuration for acknowledging commands with reactions on the message which triggered them.
+type SlackReactions struct {
+	Enabled bool `yaml:"enabled"`
+	// DisableRunningMessage skips the "Running…" message posted for long-running commands.
+	DisableRunningMessage bool `yaml:"disableRunningMessage"`
+}
+
+// SlackCommandCancellation contains configuration for the Cancel button added to the "Running…" message of long-running commands.
+// Clicking the button cancels the command. Only the user who sent the command can cancel it.
+type SlackCommandCancellation struct {
+	Enabled bool `yaml:"enabled"`
+	// After is the command duration after which the Cancel button is shown.
+	After time.Duration `yaml:"after" validate:"required_if=Enabled true"`
+}
+
+// SlackOutputPagination contains configuration for splitting too long command responses into pages with the Next and Previous buttons,
+// instead of uploading them as files. The message is updated in place once a button is clicked.
+type SlackOutputPagination struct {
+	Enabled bool `yaml:"enabled"`
+	// TTL is the time for which the pages are stored. Once it passes, the buttons stop working. Defaults to 1h.
+	TTL time.Duration `yaml:"ttl" validate:"gte=0"`
+	// MaxPages is the maximum number of pages. Longer responses are uploaded as files. Defaults to 20.
+	MaxPages int `yaml:"maxPages" validate:"gte=0"`
+}
+
+// BotGracefulShutdown contains configuration for finishing in-flight commands when the bot shuts down.
+type BotGracefulShutdown struct {
+	// DrainTimeout is the time given to in-flight commands to finish and post their responses. If zero, the commands are cancelled immediately.
+	DrainTimeout time.Duration `yaml:"drainTimeout"`
+	// SendShutdownMessage sends a final message to all configured channels once the bot is shut down.
+	SendShutdownMessage bool `yaml:"sendShutdownMessage"`
 }
 
 // Elasticsearch config auth settings
@@ -466,17 +1062,98 @@ type ELSIndex struct {
 	Type     string `yaml:"type"`
 	Shards   int    `yaml:"shards"`
 	Replicas int    `yaml:"replicas"`
+	// DataStream enables writing events to the data stream with the index name, instead of the daily indices.
+	// The data stream requires a matching index template, see Template.
+	DataStream bool `yaml:"dataStream"`
+	// ILMPolicy is the name of the index lifecycle management policy applied to the created indices.
+	ILMPolicy string           `yaml:"ilmPolicy"`
+	Template  ELSIndexTemplate `yaml:"template"`
 
 	Bindings SinkBindings `yaml:"bindings"`
 }
 
+// ELSIndexTemplate settings for ELS index template
+type ELSIndexTemplate struct {
+	// Create enables creating or updating the index template on startup.
+	Create bool `yaml:"create"`
+	// Name is the index template name. Defaults to the index name.
+	Name string `yaml:"name"`
+	// Priority must be higher than the priorities of other templates which match the index name.
+	Priority int `yaml:"priority"`
+}
+
 // Mattermost configuration to authentication and send notifications
 type Mattermost struct {
-	Enabled      bool                                   `yaml:"enabled"`
-	BotName      string                                 `yaml:"botName"`
-	URL          string                                 `yaml:"url"`
+	Enabled       bool                                   `yaml:"enabled"`
+	BotName       string                                 `yaml:"botName"`
+	URL           string                                 `yaml:"url"`
+	Token         string                                 `yaml:"token"`
+	Team          string                                 `yaml:"team"`
+	Channels      IdentifiableMap[ChannelBindingsByName] `yaml:"channels"  validate:"required_if=Enabled true,dive,omitempty,min=1"`
+	Notification  Notification                           `yaml:"notification,omitempty"`
+	Interactivity MattermostInteractivity                `yaml:"interactivity"`
+}
+
+// MattermostInteractivity holds configuration for Mattermost interactive message actions and dialogs.
+type MattermostInteractivity struct {
+	Enabled bool `yaml:"enabled"`
+	// CallbackURL is the Botkube URL reachable from the Mattermost server, e.g. http://botkube.botkube:3979.
+	CallbackURL string `yaml:"callbackURL" validate:"required_if=Enabled true"`
+	Port        string `yaml:"port"`
+}
+
+// RocketChat configuration for authentication and send notifications
+type RocketChat struct {
+	Enabled bool   `yaml:"enabled"`
+	BotName string `yaml:"botName"`
+	URL     string `yaml:"url"`
+	// UserID and Token are the Personal Access Token credentials of the Botkube user.
+	UserID       string                                 `yaml:"userID"`
 	Token        string                                 `yaml:"token"`
-	Team         string                                 `yaml:"team"`
+	Channels     IdentifiableMap[ChannelBindingsByName] `yaml:"channels"  validate:"required_if=Enabled true,dive,omitempty,min=1"`
+	Notification Notification                           `yaml:"notification,omitempty"`
+}
+
+// GoogleChat configuration for authentication and send notifications
+type GoogleChat struct {
+	Enabled bool   `yaml:"enabled"`
+	BotName string `yaml:"botName"`
+	// ProjectNumber is the Google Cloud project number of the Chat app. It's used to verify requests sent by Google Chat.
+	ProjectNumber string `yaml:"projectNumber" validate:"required_if=Enabled true"`
+	// Credentials is the service account key in JSON format used to call the Google Chat API.
+	Credentials string `yaml:"credentials" validate:"required_if=Enabled true"`
+	Port        string `yaml:"port"`
+	MessagePath string `yaml:"messagePath,omitempty"`
+	// Channels holds the Google Chat spaces configuration. The name is a space resource name, e.g. `spaces/AAAAxyz`.
+	Channels     IdentifiableMap[ChannelBindingsByName] `yaml:"channels"  validate:"required_if=Enabled true,dive,omitempty,min=1"`
+	Notification Notification                           `yaml:"notification,omitempty"`
+}
+
+// Webex configuration for authentication and send notifications
+type Webex struct {
+	Enabled bool   `yaml:"enabled"`
+	BotName string `yaml:"botName"`
+	Token   string `yaml:"token" validate:"required_if=Enabled true"`
+	// WebhookURL is the public URL of the Botkube Webex endpoint. If set, Botkube registers the webhook on startup.
+	WebhookURL string `yaml:"webhookURL"`
+	// WebhookSecret is used to verify the signature of requests sent by Webex.
+	WebhookSecret string `yaml:"webhookSecret" validate:"required_if=Enabled true"`
+	Port          string `yaml:"port"`
+	MessagePath   string `yaml:"messagePath,omitempty"`
+	// Channels holds the Webex rooms configuration by room ID.
+	Channels     IdentifiableMap[ChannelBindingsByID] `yaml:"channels"  validate:"required_if=Enabled true,dive,omitempty,min=1"`
+	Notification Notification                         `yaml:"notification,omitempty"`
+}
+
+// Matrix configuration for authentication and send notifications
+type Matrix struct {
+	Enabled bool   `yaml:"enabled"`
+	BotName string `yaml:"botName"`
+	// HomeserverURL is the base URL of the Matrix homeserver, e.g. https://matrix.org.
+	HomeserverURL string `yaml:"homeserverURL" validate:"required_if=Enabled true"`
+	// AccessToken is the access token of the Botkube Matrix user.
+	AccessToken string `yaml:"accessToken" validate:"required_if=Enabled true"`
+	// Channels holds the Matrix rooms configuration. The name is a room alias, e.g. `#ops:matrix.org`.
 	Channels     IdentifiableMap[ChannelBindingsByName] `yaml:"channels"  validate:"required_if=Enabled true,dive,omitempty,min=1"`
 	Notification Notification                           `yaml:"notification,omitempty"`
 }
@@ -497,20 +1174,237 @@ type Teams struct {
 
 // Discord configuration for authentication and send notifications
 type Discord struct {
-	Enabled      bool                                 `yaml:"enabled"`
-	Token        string                               `yaml:"token"`
-	BotID        string                               `yaml:"botID"`
-	Channels     IdentifiableMap[ChannelBindingsByID] `yaml:"channels"  validate:"required_if=Enabled true,dive,omitempty,min=1"`
-	Notification Notification                         `yaml:"notification,omitempty"`
+	Enabled       bool                                 `yaml:"enabled"`
+	Token         string                               `yaml:"token"`
+	BotID         string                               `yaml:"botID"`
+	Channels      IdentifiableMap[ChannelBindingsByID] `yaml:"channels"  validate:"required_if=Enabled true,dive,omitempty,min=1"`
+	Notification  Notification                         `yaml:"notification,omitempty"`
+	SlashCommands DiscordSlashCommands                 `yaml:"slashCommands"`
+}
+
+// DiscordSlashCommands holds configuration for Discord native slash commands.
+type DiscordSlashCommands struct {
+	// Enabled registers the `/botkube` slash command in all guilds of the configured channels.
+	Enabled bool `yaml:"enabled"`
+}
+
+// Loopback configuration to record notifications instead of sending them to a communication platform.
+// It's useful to validate sources, filters and bindings before using a real communication platform.
+type Loopback struct {
+	Enabled  bool                                   `yaml:"enabled"`
+	Channels IdentifiableMap[ChannelBindingsByName] `yaml:"channels"  validate:"required_if=Enabled true,dive,omitempty,min=1"`
+	// OutputPath is a path to a file where the messages are appended in the JSON Lines format.
+	// If not set, the messages are logged.
+	OutputPath string `yaml:"outputPath"`
 }
 
 // Webhook configuration to send notifications
 type Webhook struct {
-	Enabled  bool         `yaml:"enabled"`
-	URL      string       `yaml:"url"`
+	Enabled bool   `yaml:"enabled"`
+	URL     string `yaml:"url"`
+	// Template is the Go template used to render the request body. The event is available as `.Event`.
+	// If not specified, the default JSON payload is sent.
+	Template string `yaml:"template"`
+	// Headers are added to each request. They can override the default `Content-Type: application/json` header.
+	Headers  map[string]string `yaml:"headers"`
+	Signing  WebhookSigning    `yaml:"signing"`
+	Bindings SinkBindings      `yaml:"bindings" validate:"required_if=Enabled true"`
+}
+
+// WebhookSigning configuration for HMAC-SHA256 signing of the webhook requests
+type WebhookSigning struct {
+	// Secret is the HMAC key. If not specified, requests are not signed.
+	Secret string `yaml:"secret"`
+	// Header is the name of the header with the request body signature. Defaults to `X-Botkube-Signature-256`.
+	Header string `yaml:"header"`
+}
+
+// PagerDuty configuration to trigger and resolve incidents
+type PagerDuty struct {
+	Enabled bool `yaml:"enabled"`
+	// URL is the PagerDuty Events API v2 endpoint. Change it to use the EU service region.
+	URL string `yaml:"url"`
+	// RoutingKeys maps source bindings to the integration keys of PagerDuty services.
+	RoutingKeys map[string]string `yaml:"routingKeys" validate:"required_if=Enabled true"`
+}
+
+// Bindings returns the source bindings for which the incidents are created.
+func (p PagerDuty) Bindings() SinkBindings {
+	sources := make([]string, 0, len(p.RoutingKeys))
+	for source := range p.RoutingKeys {
+		sources = append(sources, source)
+	}
+	sort.Strings(sources)
+	return SinkBindings{Sources: sources}
+}
+
+// Opsgenie configuration to create alerts
+type Opsgenie struct {
+	Enabled bool `yaml:"enabled"`
+	// URL is the Opsgenie API endpoint. Change it to use the EU service region.
+	URL    string `yaml:"url"`
+	APIKey string `yaml:"apiKey" validate:"required_if=Enabled true"`
+	// Tags are added to all created alerts, together with the ones generated from the event.
+	Tags     []string     `yaml:"tags"`
 	Bindings SinkBindings `yaml:"bindings" validate:"required_if=Enabled true"`
 }
 
+// KafkaFormat defines the format of the records published to Kafka.
+type KafkaFormat string
+
+const (
+	// KafkaJSONFormat publishes records as JSON.
+	KafkaJSONFormat KafkaFormat = "json"
+	// KafkaAvroFormat publishes records as Avro. The schema is registered in the Schema Registry by the REST Proxy.
+	KafkaAvroFormat KafkaFormat = "avro"
+)
+
+// Kafka configuration to publish events to a Kafka topic via the Confluent REST Proxy
+type Kafka struct {
+	Enabled bool `yaml:"enabled"`
+	// URL is the Kafka REST Proxy endpoint, e.g. http://kafka-rest-proxy:8082.
+	URL   string `yaml:"url" validate:"required_if=Enabled true"`
+	Topic string `yaml:"topic" validate:"required_if=Enabled true"`
+	// Format is the format of the published records. Defaults to KafkaJSONFormat.
+	Format KafkaFormat `yaml:"format" validate:"omitempty,oneof=json avro"`
+	// ValueSchemaID is the ID of the Avro schema registered in the Schema Registry.
+	// If not specified, the built-in event schema is sent with the records.
+	ValueSchemaID int          `yaml:"valueSchemaID"`
+	Username      string       `yaml:"username"`
+	Password      string       `yaml:"password"`
+	Bindings      SinkBindings `yaml:"bindings" validate:"required_if=Enabled true"`
+}
+
+// AWS configuration to publish events to AWS SNS topic and/or SQS queue
+type AWS struct {
+	Enabled bool   `yaml:"enabled"`
+	Region  string `yaml:"region" validate:"required_if=Enabled true"`
+	// RoleArn is the IAM role assumed to publish events. It's not used if IAM Role for Service Account is configured.
+	RoleArn  string       `yaml:"roleArn"`
+	SNS      AWSSNS       `yaml:"sns"`
+	SQS      AWSSQS       `yaml:"sqs"`
+	Bindings SinkBindings `yaml:"bindings" validate:"required_if=Enabled true"`
+}
+
+// AWSSNS configuration for AWS SNS topic
+type AWSSNS struct {
+	TopicARN string `yaml:"topicARN"`
+}
+
+// AWSSQS configuration for AWS SQS queue
+type AWSSQS struct {
+	QueueURL string `yaml:"queueURL"`
+}
+
+// AzureEventHub configuration to send events to Azure Event Hub, e.g. to ingest them into Microsoft Sentinel
+type AzureEventHub struct {
+	Enabled bool `yaml:"enabled"`
+	// ConnectionString is the connection string of the Event Hub shared access policy. If not specified, ManagedIdentity is used.
+	ConnectionString string `yaml:"connectionString"`
+	// Namespace is the fully qualified Event Hubs namespace, e.g. botkube.servicebus.windows.net. Not used with ConnectionString.
+	Namespace string `yaml:"namespace"`
+	// EventHub is the Event Hub name. Not required if the ConnectionString contains the EntityPath.
+	EventHub        string               `yaml:"eventHub"`
+	ManagedIdentity AzureManagedIdentity `yaml:"managedIdentity"`
+	Batch           AzureEventHubBatch   `yaml:"batch"`
+	Bindings        SinkBindings         `yaml:"bindings" validate:"required_if=Enabled true"`
+}
+
+// AzureManagedIdentity configuration to authenticate with Azure managed identity
+type AzureManagedIdentity struct {
+	Enabled bool `yaml:"enabled"`
+	// ClientID is the client ID of the user-assigned managed identity. If not specified, the system-assigned identity is used.
+	ClientID string `yaml:"clientID"`
+}
+
+// AzureEventHubBatch configuration of the events batching
+type AzureEventHubBatch struct {
+	// MaxEvents is the number of buffered events which triggers the flush.
+	MaxEvents int `yaml:"maxEvents"`
+	// FlushInterval is the maximum time for which the events are buffered.
+	FlushInterval time.Duration `yaml:"flushInterval"`
+}
+
+// Loki configuration to push events as log lines to Grafana Loki
+type Loki struct {
+	Enabled bool `yaml:"enabled"`
+	// URL is the Loki base URL, e.g. http://loki-gateway.monitoring.svc.
+	URL string `yaml:"url" validate:"required_if=Enabled true"`
+	// TenantID is sent in the X-Scope-OrgID header. Required if Loki runs in the multi-tenant mode.
+	TenantID string `yaml:"tenantID"`
+	Username string `yaml:"username"`
+	Password string `yaml:"password"`
+	// Labels are added to all streams, together with the cluster, namespace and level labels.
+	Labels   map[string]string `yaml:"labels"`
+	Bindings SinkBindings      `yaml:"bindings" validate:"required_if=Enabled true"`
+}
+
+// Datadog configuration to post events and metrics to Datadog
+type Datadog struct {
+	Enabled bool `yaml:"enabled"`
+	// Site is the Datadog site, e.g. datadoghq.eu. Defaults to datadoghq.com.
+	Site   string `yaml:"site"`
+	APIKey string `yaml:"apiKey" validate:"required_if=Enabled true"`
+	// Tags are added to all events and metrics, together with the ones generated from the event.
+	Tags     []string       `yaml:"tags"`
+	Metrics  DatadogMetrics `yaml:"metrics"`
+	Bindings SinkBindings   `yaml:"bindings" validate:"required_if=Enabled true"`
+}
+
+// DatadogMetrics configuration of the metrics emitted for events
+type DatadogMetrics struct {
+	// Enabled emits the `botkube.event.count` metric for each event.
+	Enabled bool `yaml:"enabled"`
+}
+
+// Jira configuration to open Jira issues for error events
+type Jira struct {
+	Enabled bool `yaml:"enabled"`
+	// URL is the Jira base URL, e.g. https://example.atlassian.net.
+	URL string `yaml:"url" validate:"required_if=Enabled true"`
+	// Username is the account email for Jira Cloud. If empty, the APIToken is used as a personal access token.
+	Username string `yaml:"username"`
+	APIToken string `yaml:"apiToken" validate:"required_if=Enabled true"`
+	// Project is the key of the project used when no route matches the event.
+	Project string `yaml:"project"`
+	// IssueType is the type of the created issues. Defaults to Bug.
+	IssueType string `yaml:"issueType"`
+	// Labels are added to all created issues.
+	Labels []string `yaml:"labels"`
+	// Routes are evaluated in order. The first route matching the event decides where the issue is created.
+	Routes   []JiraRoute  `yaml:"routes" validate:"dive"`
+	Bindings SinkBindings `yaml:"bindings" validate:"required_if=Enabled true"`
+}
+
+// JiraRoute routes the matching events to a given Jira project
+type JiraRoute struct {
+	// Query is a JQL-style expression matched against the event, e.g. `namespace = prod AND reason in (BackOff, OOMKilled)`.
+	Query string `yaml:"query" validate:"required"`
+	// Project overrides the Jira.Project for the matching events.
+	Project string `yaml:"project"`
+	// IssueType overrides the Jira.IssueType for the matching events.
+	IssueType string `yaml:"issueType"`
+	// Priority is the name of the issue priority, e.g. High.
+	Priority string `yaml:"priority"`
+	// Labels are added to the issues created for the matching events.
+	Labels []string `yaml:"labels"`
+}
+
+// ServiceNow configuration to create incidents with the ServiceNow Table API
+type ServiceNow struct {
+	Enabled bool `yaml:"enabled"`
+	// URL is the ServiceNow instance URL, e.g. https://example.service-now.com.
+	URL      string `yaml:"url" validate:"required_if=Enabled true"`
+	Username string `yaml:"username" validate:"required_if=Enabled true"`
+	Password string `yaml:"password" validate:"required_if=Enabled true"`
+	// Table is the name of the table where the records are created. Defaults to incident.
+	Table string `yaml:"table"`
+	// Fields maps the record fields to Go templates, which are rendered with the event available as `.Event`.
+	// They override the default fields. An empty template disables a given default field.
+	Fields   map[string]string `yaml:"fields"`
+	Bindings SinkBindings      `yaml:"bindings" validate:"required_if=Enabled true"`
+}
+
 // Kubectl configuration for executing commands inside cluster
 type Kubectl struct {
 	Namespaces       Namespaces `yaml:"namespaces,omitempty"`
@@ -518,6 +1412,94 @@ type Kubectl struct {
 	Commands         Commands   `yaml:"commands,omitempty"`
 	DefaultNamespace string     `yaml:"defaultNamespace,omitempty"`
 	RestrictAccess   *bool      `yaml:"restrictAccess,omitempty"`
+	// Exec restricts the `kubectl exec` command. It's used only if the `exec` verb is allowed.
+	Exec KubectlExec `yaml:"exec,omitempty"`
+	// Streaming configures the safety limits of the commands which stream their output, such as `kubectl logs` and `kubectl exec`.
+	Streaming KubectlStreaming `yaml:"streaming,omitempty"`
+	// CommandTimeout is the maximum duration of the commands which don't stream their output. It overrides the `settings.commandTimeout` default.
+	CommandTimeout time.Duration `yaml:"commandTimeout,omitempty" validate:"gte=0"`
+}
+
+// KubectlExec configuration for the restricted `kubectl exec` command.
+type KubectlExec struct {
+	// AllowedCommands lists the commands which can be executed in the containers, e.g. `ls` or `env`.
+	// If empty, no command can be executed.
+	AllowedCommands []string `yaml:"allowedCommands,omitempty"`
+}
+
+// KubectlStreaming configures the safety limits of the commands which stream their output.
+type KubectlStreaming struct {
+	// MaxOutputSize is the maximum size of the command output in bytes. Once it's exceeded, the command is stopped. Defaults to 1 MiB.
+	MaxOutputSize int `yaml:"maxOutputSize,omitempty" validate:"gte=0"`
+	// Timeout is the maximum duration of the command. Once it's exceeded, the command is stopped. Defaults to 30s.
+	Timeout time.Duration `yaml:"timeout,omitempty" validate:"gte=0"`
+	// DefaultTailLines is the number of the recent log lines printed if neither `--tail` nor `--since` flag is specified. Defaults to 100.
+	DefaultTailLines int `yaml:"defaultTailLines,omitempty" validate:"gte=0"`
+	// WatchTimeout is the maximum duration of the `kubectl get --watch` command. Once it's exceeded, the watch is stopped. Defaults to 5m.
+	WatchTimeout time.Duration `yaml:"watchTimeout,omitempty" validate:"gte=0"`
+}
+
+// Helm configuration for executing Helm commands inside the cluster.
+type Helm struct {
+	Enabled    bool       `yaml:"enabled"`
+	Namespaces Namespaces `yaml:"namespaces,omitempty"`
+	// Verbs lists the allowed Helm commands. Supported ones are `list`, `status`, `history`, `rollback` and `upgrade`.
+	// The `upgrade` command is always executed with the `--dry-run` flag.
+	Verbs            []string `yaml:"verbs" validate:"dive,oneof=list status history rollback upgrade"`
+	DefaultNamespace string   `yaml:"defaultNamespace,omitempty"`
+}
+
+// Top configuration for the resource usage and capacity reports. The usage is fetched from the metrics-server.
+type Top struct {
+	Enabled bool `yaml:"enabled"`
+	// Namespaces restricts the Namespaces for which the Pods usage is reported.
+	Namespaces Namespaces `yaml:"namespaces,omitempty"`
+	// NodePoolLabel is the node label which groups the nodes into pools in the capacity report.
+	// If not set, the well-known labels of the managed Kubernetes services are used.
+	NodePoolLabel string `yaml:"nodePoolLabel,omitempty"`
+}
+
+// Diag configuration for the diagnostics bundles of workloads. A bundle collects the describe output, recent events,
+// logs tails and resource metrics of a given workload into a single archive, which is uploaded as a file.
+type Diag struct {
+	Enabled bool `yaml:"enabled"`
+	// Namespaces restricts the Namespaces of the workloads for which the bundle can be collected.
+	Namespaces Namespaces `yaml:"namespaces,omitempty"`
+	// LogTailLines is the number of the recent log lines collected per container. Defaults to 200.
+	LogTailLines int64 `yaml:"logTailLines,omitempty" validate:"gte=0"`
+	// MaxPods is the maximum number of the workload Pods included in the bundle. Defaults to 10.
+	MaxPods int `yaml:"maxPods,omitempty" validate:"gte=0"`
+}
+
+// Events configuration for the summary of the recent Kubernetes events, grouped by the involved object.
+type Events struct {
+	Enabled bool `yaml:"enabled"`
+	// Namespaces restricts the Namespaces for which the events can be queried.
+	Namespaces Namespaces `yaml:"namespaces,omitempty"`
+	// DefaultSince is the time range of the reported events if the `--since` flag is not specified. Defaults to 1h.
+	DefaultSince time.Duration `yaml:"defaultSince,omitempty" validate:"gte=0"`
+}
+
+// RBAC restricts the commands handled by a given executor binding to the users and groups listed in the rules.
+// Executor bindings without RBAC can be used by all members of the bound channels.
+type RBAC struct {
+	Enabled bool       `yaml:"enabled"`
+	Rules   []RBACRule `yaml:"rules" validate:"required_if=Enabled true,dive"`
+}
+
+// RBACRule allows given users and groups to execute commands matching the verbs, resources and Namespaces.
+// Empty verbs, resources and Namespaces match all commands. Otherwise, commands with unknown resource or without Namespace,
+// e.g. `kubectl apply -f -`, Helm commands or `top nodes`, are not matched. Resources of kubectl commands such as `logs` or `exec` are not checked.
+type RBACRule struct {
+	Name string `yaml:"name"`
+	// Users holds the chat platform user IDs, e.g. `U01234ABCD` for Slack.
+	Users []string `yaml:"users"`
+	// Groups holds the Slack user group IDs or handles, and the Kubernetes groups assigned with the identity mapping.
+	Groups []string `yaml:"groups"`
+	// Verbs holds the kubectl and Helm verbs, `top` or `diag`.
+	Verbs      []string   `yaml:"verbs"`
+	Resources  []string   `yaml:"resources"`
+	Namespaces Namespaces `yaml:"namespaces"`
 }
 
 // Commands allowed in bot
@@ -531,6 +1513,9 @@ type CfgWatcher struct {
 	Enabled            bool          `yaml:"enabled"`
 	InitialSyncTimeout time.Duration `yaml:"initialSyncTimeout"`
 	TmpDir             string        `yaml:"tmpDir"`
+	// HotReload enables applying the changed sources and channel bindings without restarting Botkube.
+	// Other changes still restart the Botkube Pod.
+	HotReload bool `yaml:"hotReload"`
 }
 
 // Settings contains Botkube's related configuration.
@@ -541,12 +1526,31 @@ type Settings struct {
 	PersistentConfig PersistentConfig `yaml:"persistentConfig"`
 	MetricsPort      string           `yaml:"metricsPort"`
 	LifecycleServer  LifecycleServer  `yaml:"lifecycleServer"`
-	Log              struct {
+	SourceServer     SourceServer     `yaml:"sourceServer"`
+	Plugins          PluginsSettings  `yaml:"plugins"`
+	Middlewares      BotMiddlewares   `yaml:"middlewares"`
+	Locales          LocalesSettings  `yaml:"locales"`
+	Identity         IdentityMapping  `yaml:"identity"`
+	SinkRetry        SinkRetry        `yaml:"sinkRetry"`
+	Redaction        Redaction        `yaml:"redaction"`
+	CommandAudit     CommandAudit     `yaml:"commandAudit"`
+	// CommandConfirmation requires confirming destructive commands before they are executed.
+	CommandConfirmation CommandConfirmation `yaml:"commandConfirmation"`
+	CommandSchedule     CommandSchedule     `yaml:"commandSchedule"`
+	Deduplication       Deduplication       `yaml:"deduplication"`
+	Hub                 Hub                 `yaml:"hub"`
+	CustomResources     CustomResources     `yaml:"customResources"`
+	Secrets             SecretsSettings     `yaml:"secrets"`
+	Log                 struct {
 		Level         string `yaml:"level"`
 		DisableColors bool   `yaml:"disableColors"`
 	} `yaml:"log"`
 	InformersResyncPeriod time.Duration `yaml:"informersResyncPeriod"`
-	Kubeconfig            string        `yaml:"kubeconfig"`
+	// NotifierTimeout limits the time for sending a single event to a given notifier. Zero means no limit.
+	NotifierTimeout time.Duration `yaml:"notifierTimeout"`
+	// CommandTimeout is the default maximum duration of a single kubectl command which doesn't stream its output. Zero means no limit.
+	CommandTimeout time.Duration `yaml:"commandTimeout"`
+	Kubeconfig     string        `yaml:"kubeconfig"`
 }
 
 // LifecycleServer contains configuration for the server with app lifecycle methods.
@@ -556,6 +1560,261 @@ type LifecycleServer struct {
 	Deployment K8sResourceRef `yaml:"deployment"`
 }
 
+// CustomResources contains configuration for defining sources, executors, actions and channel bindings
+// with namespaced Botkube custom resources.
+type CustomResources struct {
+	Enabled bool `yaml:"enabled"`
+}
+
+// SourceServer contains configuration for the server which receives events from external sources, such as Alertmanager.
+type SourceServer struct {
+	Enabled bool `yaml:"enabled"`
+	Port    int  `yaml:"port"`
+	// BearerToken is required in the Authorization header of all requests, if specified.
+	BearerToken string `yaml:"bearerToken"`
+}
+
+// HubMode defines the role of a Botkube instance in the multi-cluster setup.
+type HubMode string
+
+const (
+	// HubServerMode is used by the instance which receives events from the agents and sends them to the communication platforms.
+	HubServerMode HubMode = "hub"
+	// HubAgentMode is used by the instances which forward their events to the hub and execute commands sent by it.
+	HubAgentMode HubMode = "agent"
+)
+
+// Hub contains configuration for aggregating events from multiple clusters in a single Botkube instance.
+type Hub struct {
+	// Mode is either `hub` or `agent`. Empty mode disables the multi-cluster setup.
+	Mode HubMode `yaml:"mode" validate:"omitempty,oneof=hub agent"`
+	// Port is the port of the server which receives events from the agents. Used in the hub mode.
+	Port int `yaml:"port" validate:"required_if=Mode hub"`
+	// URL is the address of the hub server, such as `http://botkube-hub.botkube:2117`. Used in the agent mode.
+	URL string `yaml:"url" validate:"required_if=Mode agent"`
+	// BearerToken authenticates the agent in the hub. It needs to be the same as the token of the agent cluster configured
+	// in the hub. Required in the agent mode.
+	BearerToken string `yaml:"bearerToken"`
+	// Agents holds the agents which can connect to the hub, indexed by their cluster name. Each agent can send events
+	// and poll commands only for its own cluster. Required in the hub mode.
+	Agents map[string]HubAgent `yaml:"agents"`
+	// ExecutorBindings are used for the commands which the hub forwards to the agent. Only the executors which are also bound
+	// to the hub channel that sent the command are used. Used in the agent mode.
+	ExecutorBindings []string `yaml:"executorBindings"`
+	// CommandTimeout limits the time for executing a command on the agent cluster. Used in the hub mode.
+	CommandTimeout time.Duration `yaml:"commandTimeout"`
+}
+
+// HubAgent contains configuration of the agent which connects to the hub.
+type HubAgent struct {
+	// BearerToken authenticates the agent. It needs to be unique for each agent.
+	BearerToken string `yaml:"bearerToken"`
+}
+
+// PluginsSettings contains configuration for the plugins discovery.
+type PluginsSettings struct {
+	// Directory contains the source and executor plugin binaries, named `botkube-source-<plugin name>` and `botkube-executor-<plugin name>`.
+	Directory string `yaml:"directory"`
+	// Repositories holds the plugin repositories, indexed by the repository name.
+	// Executor plugins which are not found in the directory are downloaded from them.
+	Repositories map[string]PluginsRepository `yaml:"repositories" validate:"dive"`
+}
+
+// PluginsRepository contains configuration for a plugin repository.
+type PluginsRepository struct {
+	// URL is the address of the repository index, which lists the plugin binaries with their SHA-256 checksums.
+	URL string `yaml:"url" validate:"required"`
+}
+
+// SinkRetry contains configuration for retrying events which sinks failed to send.
+type SinkRetry struct {
+	Enabled bool `yaml:"enabled"`
+	// MaxAttempts is the maximum number of send attempts for a given event. Zero means no limit.
+	MaxAttempts    int           `yaml:"maxAttempts"`
+	InitialBackoff time.Duration `yaml:"initialBackoff"`
+	MaxBackoff     time.Duration `yaml:"maxBackoff"`
+	// QueueSize is the maximum number of queued events per sink. Once it's exceeded, the oldest events are dropped.
+	QueueSize   int                  `yaml:"queueSize"`
+	Persistence SinkRetryPersistence `yaml:"persistence"`
+}
+
+// SinkRetryPersistence contains configuration for buffering the queued events on disk, so they survive restarts.
+type SinkRetryPersistence struct {
+	Enabled bool `yaml:"enabled"`
+	// Dir is a writable directory where the queued events are stored.
+	Dir string `yaml:"dir" validate:"required_if=Enabled true"`
+	// MaxSizeBytes limits the disk space used per sink. Once it's exceeded, the oldest events are dropped.
+	MaxSizeBytes int64 `yaml:"maxSizeBytes"`
+}
+
+// Redaction contains configuration for masking sensitive data in events before they are sent to bots and sinks.
+type Redaction struct {
+	Enabled bool `yaml:"enabled"`
+	// Mask replaces the redacted values. Defaults to `***`.
+	Mask  string          `yaml:"mask"`
+	Rules []RedactionRule `yaml:"rules" validate:"dive"`
+}
+
+// Deduplication contains configuration for suppressing repeated events.
+// Events are repeated if they have the same cluster, namespace, kind, name, type, reason and source bindings.
+type Deduplication struct {
+	Enabled bool `yaml:"enabled"`
+	// Window is the time in which the repeated events are suppressed and summarized in a single event. Defaults to 10m.
+	Window time.Duration `yaml:"window"`
+}
+
+// RedactionRule defines sensitive data to mask. Exactly one of the Pattern and JSONPath must be set.
+type RedactionRule struct {
+	// Pattern is a regular expression matched against the event messages and all string values of the Kubernetes object.
+	// If it contains capture groups, only the groups are masked.
+	Pattern string `yaml:"pattern"`
+	// JSONPath selects values of the Kubernetes object to mask, e.g. `$..env[*].value`.
+	JSONPath string `yaml:"jsonPath"`
+}
+
+// LocalesSettings contains configuration for localized bot responses.
+type LocalesSettings struct {
+	// CatalogsDir is a directory with custom message catalogs. Each file is named after its locale, e.g. `fr.yaml`.
+	CatalogsDir string `yaml:"catalogsDir"`
+}
+
+// IdentityMapping contains configuration for mapping chat platform users to Kubernetes identities.
+type IdentityMapping struct {
+	Enabled bool `yaml:"enabled"`
+	// Users holds static mapping of chat platform user IDs to Kubernetes identities. It takes precedence over the email lookup.
+	Users []UserIdentityMapping `yaml:"users" validate:"dive"`
+	// EmailLookup maps users to Kubernetes usernames based on their email, which is resolved with the chat platform API.
+	EmailLookup EmailIdentityLookup `yaml:"emailLookup"`
+	// Impersonation configures running kubectl commands as the mapped Kubernetes identity.
+	Impersonation IdentityImpersonation `yaml:"impersonation"`
+}
+
+// IdentityImpersonation contains configuration for running kubectl commands with the `--as` and `--as-group` flags.
+// The Botkube service account must be allowed to impersonate the mapped users and groups.
+type IdentityImpersonation struct {
+	Enabled bool `yaml:"enabled"`
+	// RequireIdentity rejects kubectl commands of users without the Kubernetes identity, instead of running them as the Botkube service account.
+	RequireIdentity bool `yaml:"requireIdentity"`
+}
+
+// UserIdentityMapping maps a given chat platform user to a Kubernetes identity.
+type UserIdentityMapping struct {
+	Platform CommPlatformIntegration `yaml:"platform" validate:"required"`
+	UserID   string                  `yaml:"userID" validate:"required"`
+	Username string                  `yaml:"username" validate:"required"`
+	Groups   []string                `yaml:"groups"`
+}
+
+// EmailIdentityLookup contains configuration for mapping users to Kubernetes usernames based on their email.
+// It's useful if the Kubernetes cluster uses the same SSO provider as the chat platform.
+type EmailIdentityLookup struct {
+	Enabled bool `yaml:"enabled"`
+	// UsernamePrefix is prepended to the email, the same as the `--oidc-username-prefix` Kubernetes API server flag.
+	UsernamePrefix string `yaml:"usernamePrefix"`
+	// Groups are assigned to all users mapped by email.
+	Groups []string `yaml:"groups"`
+}
+
+// BotMiddlewares contains configuration for the built-in middlewares applied to all commands received by bots.
+type BotMiddlewares struct {
+	RateLimit RateLimitMiddleware `yaml:"rateLimit"`
+	Audit     AuditMiddleware     `yaml:"audit"`
+	Queue     QueueMiddleware     `yaml:"queue"`
+}
+
+// RateLimitMiddleware contains configuration for limiting the number of commands executed by a given user.
+type RateLimitMiddleware struct {
+	Enabled     bool          `yaml:"enabled"`
+	MaxCommands int           `yaml:"maxCommands" validate:"required_if=Enabled true,omitempty,min=1"`
+	Interval    time.Duration `yaml:"interval" validate:"required_if=Enabled true"`
+}
+
+// AuditMiddleware contains configuration for logging all commands received by bots.
+type AuditMiddleware struct {
+	Enabled bool `yaml:"enabled"`
+}
+
+// QueueMiddleware contains configuration for limiting the number of commands executed concurrently.
+// Commands over the limits wait in the queue and they are executed in the order they were received.
+type QueueMiddleware struct {
+	Enabled bool `yaml:"enabled"`
+	// MaxConcurrent is the maximum number of commands executed concurrently from all channels.
+	MaxConcurrent int `yaml:"maxConcurrent" validate:"required_if=Enabled true,omitempty,min=1"`
+	// MaxConcurrentPerChannel is the maximum number of commands executed concurrently from a single channel.
+	// If not set, only the MaxConcurrent limit applies.
+	MaxConcurrentPerChannel int `yaml:"maxConcurrentPerChannel" validate:"omitempty,min=1"`
+	// MaxQueued is the maximum number of waiting commands. Commands received when the queue is full are rejected.
+	MaxQueued int `yaml:"maxQueued" validate:"required_if=Enabled true,omitempty,min=1"`
+	// Timeout is the maximum time a command waits in the queue.
+	Timeout time.Duration `yaml:"timeout" validate:"required_if=Enabled true"`
+}
+
+// CommandAudit contains configuration for recording all commands executed by bots.
+// The entries are sent to all enabled stores. The `audit list` command reads them from the ConfigMap or file store.
+type CommandAudit struct {
+	Enabled bool `yaml:"enabled"`
+	// ListLimit is the maximum number of entries returned by the `audit list` command.
+	ListLimit int `yaml:"listLimit"`
+	// SaveTimeout limits the time for saving a single entry in a given store. If not set, it defaults to 5s.
+	SaveTimeout   time.Duration           `yaml:"saveTimeout"`
+	ConfigMap     AuditConfigMapStore     `yaml:"configMap"`
+	File          AuditFileStore          `yaml:"file"`
+	Webhook       AuditWebhookStore       `yaml:"webhook"`
+	Elasticsearch AuditElasticsearchStore `yaml:"elasticsearch"`
+}
+
+// CommandConfirmation contains configuration for confirming destructive commands with interactive buttons before they are executed.
+// Only the user who sent the command can confirm it.
+type CommandConfirmation struct {
+	Enabled bool `yaml:"enabled"`
+	// Commands holds the command verbs which require confirmation, e.g. `delete` or `helm uninstall`. An entry can also list flags,
+	// e.g. `scale --replicas=0`, and then the confirmation is required only if the command has all of them.
+	// The `--replicas=0` and `--replicas 0` forms are equivalent, and a flag listed without a value matches any value.
+	Commands []string `yaml:"commands"`
+	// Timeout is the time in which the command has to be confirmed.
+	Timeout time.Duration `yaml:"timeout" validate:"required_if=Enabled true"`
+}
+
+// CommandSchedule contains configuration for the commands executed periodically, added with the `schedule` command.
+type CommandSchedule struct {
+	Enabled bool `yaml:"enabled"`
+	// MaxPerChannel is the maximum number of scheduled commands in a single channel.
+	MaxPerChannel int `yaml:"maxPerChannel" validate:"required_if=Enabled true,omitempty,min=1"`
+	// Timezone is the IANA time zone name used for the cron expressions, e.g. `Europe/Warsaw`. If not set, UTC is used.
+	Timezone string `yaml:"timezone"`
+}
+
+// AuditConfigMapStore contains configuration for storing the most recent audit entries in a ConfigMap.
+type AuditConfigMapStore struct {
+	Enabled   bool   `yaml:"enabled"`
+	Name      string `yaml:"name" validate:"required_if=Enabled true"`
+	Namespace string `yaml:"namespace" validate:"required_if=Enabled true"`
+	// MaxEntries is the number of the most recent entries kept in the ConfigMap.
+	MaxEntries int `yaml:"maxEntries"`
+}
+
+// AuditFileStore contains configuration for appending audit entries to a file, e.g. on a mounted persistent volume.
+type AuditFileStore struct {
+	Enabled bool   `yaml:"enabled"`
+	Path    string `yaml:"path" validate:"required_if=Enabled true"`
+}
+
+// AuditWebhookStore contains configuration for sending audit entries to an external webhook.
+type AuditWebhookStore struct {
+	Enabled bool              `yaml:"enabled"`
+	URL     string            `yaml:"url" validate:"required_if=Enabled true"`
+	Headers map[string]string `yaml:"headers"`
+}
+
+// AuditElasticsearchStore contains configuration for indexing audit entries in Elasticsearch.
+type AuditElasticsearchStore struct {
+	Enabled  bool   `yaml:"enabled"`
+	Server   string `yaml:"server" validate:"required_if=Enabled true"`
+	Username string `yaml:"username"`
+	Password string `yaml:"password"`
+	Index    string `yaml:"index" validate:"required_if=Enabled true"`
+}
+
 // PersistentConfig contains configuration for persistent storage.
 type PersistentConfig struct {
 	Startup PartialPersistentConfig `yaml:"startup"`
@@ -584,10 +1843,27 @@ type PathsGetter func() []string
 // LoadWithDefaultsDetails holds the LoadWithDefaults function details.
 type LoadWithDefaultsDetails struct {
 	ValidateWarnings error
+	// ValidateCriticals is set together with the returned error, if the configuration has critical validation errors.
+	ValidateCriticals error
+	// Secrets holds the values resolved from the `valueFrom` references. It's nil if there are no references.
+	Secrets *ResolvedSecrets
 }
 
-// LoadWithDefaults loads new configuration from files and environment variables.
+// LoadWithDefaults loads new configuration from files and environment variables. The sources take precedence in the following order:
+//  1. Environment variables with the `BOTKUBE_` prefix, e.g. `BOTKUBE_COMMUNICATIONS__DEFAULT-GROUP__SOCKETSLACK__BOTTOKEN`
+//     or `BOTKUBE_COMMUNICATIONS_DEFAULT-GROUP_SOCKET__SLACK_BOT__TOKEN` sets the `communications.default-group.socketSlack.botToken` property.
+//     See configEnvNameNormalizer for details.
+//  2. Configuration files, in a given order. The files with the `_` name prefix, such as the persisted state, are loaded last.
+//  3. Default configuration.
+//
+// The `${NAME}` and `${NAME:-default}` references in the values of the configuration files are replaced with the environment variables.
+// The special files, such as the persisted state, and the default configuration are not interpolated.
+// The `valueFrom` references are replaced with the resolved values.
 func LoadWithDefaults(getCfgPaths PathsGetter) (*Config, LoadWithDefaultsDetails, error) {
+	return loadWithDefaults(getCfgPaths, newSecretResolver)
+}
+
+func loadWithDefaults(getCfgPaths PathsGetter, newSecretResolverFn func(k *koanf.Koanf) (SecretResolver, error)) (*Config, LoadWithDefaultsDetails, error) {
 	configPaths := getCfgPaths()
 	k := koanf.New(configDelimiter)
 
@@ -599,40 +1875,110 @@ func LoadWithDefaults(getCfgPaths PathsGetter) (*Config, LoadWithDefaultsDetails
 	// merge with user conf files
 	configPaths = sortCfgFiles(configPaths)
 	for _, path := range configPaths {
-		if err := k.Load(file.Provider(filepath.Clean(path)), koanfyaml.Parser()); err != nil {
+		if err := loadConfigFile(k, filepath.Clean(path)); err != nil {
 			return nil, LoadWithDefaultsDetails{}, err
 		}
 	}
 
 	// LoadWithDefaults environment variables and merge into the loaded config.
+	// They override the values from files, e.g. `BOTKUBE_SETTINGS_CLUSTER__NAME` or `BOTKUBE_SETTINGS__CLUSTERNAME` sets the `settings.clusterName` property.
 	err := k.Load(env.Provider(
 		configEnvVariablePrefix,
 		configDelimiter,
-		normalizeConfigEnvName,
+		configEnvNameNormalizer(k.Raw()),
 	), nil)
 	if err != nil {
 		return nil, LoadWithDefaultsDetails{}, err
 	}
 
+	secrets, err := resolveSecrets(k, newSecretResolverFn)
+	if err != nil {
+		return nil, LoadWithDefaultsDetails{}, fmt.Errorf("while resolving secrets: %w", err)
+	}
+
 	var cfg Config
-	err = k.UnmarshalWithConf("", &cfg, koanf.UnmarshalConf{Tag: "yaml"})
+	err = k.UnmarshalWithConf("", &cfg, koanf.UnmarshalConf{
+		Tag: "yaml",
+		DecoderConfig: &mapstructure.DecoderConfig{
+			DecodeHook: mapstructure.ComposeDecodeHookFunc(
+				mapstructure.StringToTimeDurationHookFunc(),
+				// used for the persisted notification snooze time
+				mapstructure.StringToTimeHookFunc(time.RFC3339),
+			),
+			Result:           &cfg,
+			WeaklyTypedInput: true,
+		},
+	})
 	if err != nil {
 		return nil, LoadWithDefaultsDetails{}, err
 	}
+	moveSocketSlackWorkspacesState(k, &cfg)
 
 	result, err := ValidateStruct(cfg)
 	if err != nil {
 		return nil, LoadWithDefaultsDetails{}, fmt.Errorf("while validating loaded configuration: %w", err)
 	}
 	if err := result.Criticals.ErrorOrNil(); err != nil {
-		return nil, LoadWithDefaultsDetails{}, fmt.Errorf("found critical validation errors: %w", err)
+		return nil, LoadWithDefaultsDetails{
+			ValidateWarnings:  result.Warnings.ErrorOrNil(),
+			ValidateCriticals: err,
+		}, fmt.Errorf("found critical validation errors: %w", err)
 	}
 
 	return &cfg, LoadWithDefaultsDetails{
 		ValidateWarnings: result.Warnings.ErrorOrNil(),
+		Secrets:          secrets,
 	}, nil
 }
 
+// loadConfigFile merges a given configuration file. The environment variables referenced in the file values are interpolated,
+// unless it's a special file, such as the persisted state, which holds values written by Botkube.
+func loadConfigFile(k *koanf.Koanf, path string) error {
+	if strings.HasPrefix(filepath.Base(path), specialConfigFileNamePrefix) {
+		return k.Load(file.Provider(path), koanfyaml.Parser())
+	}
+
+	fileK := koanf.New(configDelimiter)
+	if err := fileK.Load(file.Provider(path), koanfyaml.Parser()); err != nil {
+		return err
+	}
+	raw := fileK.Raw()
+	if err := interpolateEnv(raw, os.LookupEnv); err != nil {
+		return fmt.Errorf("while interpolating environment variables in %q: %w", path, err)
+	}
+	return k.Load(confmap.Provider(raw, ""), nil)
+}
+
+// moveSocketSlackWorkspacesState moves the persisted state of channels to the additional Slack workspaces.
+// The state is persisted by channel alias under the top-level `socketSlack.channels` property,
+// so for workspace channels it's loaded as a top-level channel without a name.
+func moveSocketSlackWorkspacesState(k *koanf.Koanf, cfg *Config) {
+	for groupName, group := range cfg.Communications {
+		for alias, persisted := range group.SocketSlack.Channels {
+			if persisted.Name != "" {
+				continue
+			}
+
+			channelPath := strings.Join([]string{"communications", groupName, "socketSlack", "channels", alias}, configDelimiter)
+			for _, workspace := range group.SocketSlack.Workspaces {
+				channel, found := workspace.Channels[alias]
+				if !found {
+					continue
+				}
+
+				if k.Exists(strings.Join([]string{channelPath, "notification", "disabled"}, configDelimiter)) {
+					channel.Notification = persisted.Notification
+				}
+				if k.Exists(strings.Join([]string{channelPath, "bindings", "sources"}, configDelimiter)) {
+					channel.Bindings.Sources = persisted.Bindings.Sources
+				}
+				workspace.Channels[alias] = channel
+				delete(group.SocketSlack.Channels, alias)
+			}
+		}
+	}
+}
+
 // FromEnvOrFlag resolves and returns paths for config files.
 // It reads them the 'BOTKUBE_CONFIG_PATHS' env variable. If not found, then it uses '--config' flag.
 func FromEnvOrFlag() []string {
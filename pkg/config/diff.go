@@ -0,0 +1,182 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+	"k8s.io/utils/strings/slices"
+)
+
+// ReloadComponent defines a part of the configuration which can be reloaded without restarting Botkube.
+type ReloadComponent string
+
+const (
+	// SourcesReloadComponent describes the sources configuration. Reloading it restarts the event sources.
+	SourcesReloadComponent ReloadComponent = "sources"
+	// ChannelsReloadComponent describes the bindings and notification settings of the bot channels.
+	// Reloading it updates the channels of the running bots, without reconnecting them.
+	ChannelsReloadComponent ReloadComponent = "channels"
+	// NotifiersReloadComponent describes the sinks configuration. Reloading it recreates the sinks,
+	// while their retry queues keep the pending events.
+	NotifiersReloadComponent ReloadComponent = "notifiers"
+)
+
+// channelPlatforms holds the keys of the communication platforms which configure the channels under the `channels` property.
+var channelPlatforms = []string{
+	string(SlackCommPlatformIntegration),
+	string(SocketSlackCommPlatformIntegration),
+	string(MattermostCommPlatformIntegration),
+	string(DiscordCommPlatformIntegration),
+	string(RocketChatCommPlatformIntegration),
+	string(GoogleChatCommPlatformIntegration),
+	string(WebexCommPlatformIntegration),
+	string(MatrixCommPlatformIntegration),
+	string(LoopbackCommPlatformIntegration),
+}
+
+// sinkPlatforms holds the keys of the sinks.
+var sinkPlatforms = []string{
+	string(ElasticsearchCommPlatformIntegration),
+	string(WebhookCommPlatformIntegration),
+	string(PagerDutyCommPlatformIntegration),
+	string(OpsgenieCommPlatformIntegration),
+	string(KafkaCommPlatformIntegration),
+	string(AWSCommPlatformIntegration),
+	string(AzureEventHubCommPlatformIntegration),
+	string(LokiCommPlatformIntegration),
+	string(DatadogCommPlatformIntegration),
+	string(JiraCommPlatformIntegration),
+	string(ServiceNowCommPlatformIntegration),
+}
+
+// channelIdentityProperties holds the channel properties which identify the channel. Changing them requires rejoining the channel.
+var channelIdentityProperties = []string{"name", "id"}
+
+// Diff describes the changes between two configurations.
+type Diff struct {
+	// Components holds the changed components which can be reloaded without restarting Botkube.
+	Components []ReloadComponent
+	// RestartRequiredPaths holds the changed properties which can be applied only by restarting Botkube, e.g. `settings.clusterName`.
+	RestartRequiredPaths []string
+}
+
+// IsEmpty returns true if there are no changes.
+func (d Diff) IsEmpty() bool {
+	return len(d.Components) == 0 && len(d.RestartRequiredPaths) == 0
+}
+
+// RequiresRestart returns true if some changes can be applied only by restarting Botkube.
+func (d Diff) RequiresRestart() bool {
+	return len(d.RestartRequiredPaths) > 0
+}
+
+// Has returns true if a given component has changed.
+func (d Diff) Has(component ReloadComponent) bool {
+	for _, c := range d.Components {
+		if c == component {
+			return true
+		}
+	}
+	return false
+}
+
+// DiffConfigs returns the changes between given configurations.
+func DiffConfigs(old, new *Config) (Diff, error) {
+	oldMap, err := toGenericMap(old)
+	if err != nil {
+		return Diff{}, fmt.Errorf("while converting old configuration: %w", err)
+	}
+	newMap, err := toGenericMap(new)
+	if err != nil {
+		return Diff{}, fmt.Errorf("while converting new configuration: %w", err)
+	}
+
+	var out Diff
+	for _, path := range changedPaths(nil, oldMap, newMap) {
+		component, reloadable := reloadComponentForPath(path)
+		if !reloadable {
+			out.RestartRequiredPaths = append(out.RestartRequiredPaths, strings.Join(path, "."))
+			continue
+		}
+		if !out.Has(component) {
+			out.Components = append(out.Components, component)
+		}
+	}
+
+	sort.Slice(out.Components, func(i, j int) bool {
+		return out.Components[i] < out.Components[j]
+	})
+	return out, nil
+}
+
+// reloadComponentForPath returns the component which can reload a given changed property.
+// It returns false if the property can be applied only by restarting Botkube.
+func reloadComponentForPath(path []string) (ReloadComponent, bool) {
+	switch {
+	case len(path) >= 2 && path[0] == "sources":
+		return SourcesReloadComponent, true
+	case len(path) < 4 || path[0] != "communications":
+		return "", false
+	// communications.<group>.teams.bindings
+	case path[2] == string(TeamsCommPlatformIntegration) && path[3] == "bindings":
+		return ChannelsReloadComponent, true
+	// communications.<group>.<platform>.channels.<alias>.<property>
+	// Added or removed channels, and the ones with changed name or ID, have to be joined, so they require a restart.
+	case len(path) >= 6 && slices.Contains(channelPlatforms, path[2]) && path[3] == "channels" && !slices.Contains(channelIdentityProperties, path[5]):
+		return ChannelsReloadComponent, true
+	// communications.<group>.<sink>.<property>
+	// Enabled or disabled sinks change the list of notifiers, so they require a restart.
+	case slices.Contains(sinkPlatforms, path[2]) && path[3] != "enabled":
+		return NotifiersReloadComponent, true
+	}
+	return "", false
+}
+
+// changedPaths returns paths of the properties which differ in given values.
+// Maps are compared property by property, while other values, including lists, are compared as a whole.
+func changedPaths(prefix []string, old, new interface{}) [][]string {
+	oldMap, oldIsMap := old.(map[string]interface{})
+	newMap, newIsMap := new.(map[string]interface{})
+	if !oldIsMap || !newIsMap {
+		if reflect.DeepEqual(old, new) {
+			return nil
+		}
+		return [][]string{prefix}
+	}
+
+	keys := map[string]struct{}{}
+	for key := range oldMap {
+		keys[key] = struct{}{}
+	}
+	for key := range newMap {
+		keys[key] = struct{}{}
+	}
+	sortedKeys := make([]string, 0, len(keys))
+	for key := range keys {
+		sortedKeys = append(sortedKeys, key)
+	}
+	sort.Strings(sortedKeys)
+
+	var out [][]string
+	for _, key := range sortedKeys {
+		path := append(append([]string{}, prefix...), key)
+		out = append(out, changedPaths(path, oldMap[key], newMap[key])...)
+	}
+	return out
+}
+
+func toGenericMap(cfg *Config) (map[string]interface{}, error) {
+	raw, err := yaml.Marshal(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	out := map[string]interface{}{}
+	if err := yaml.Unmarshal(raw, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
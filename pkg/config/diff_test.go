@@ -0,0 +1,153 @@
+package config_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+func TestDiffConfigs(t *testing.T) {
+	// given
+	tests := []struct {
+		name string
+
+		modifyFn   func(cfg *config.Config)
+		expDiff    config.Diff
+		expEmpty   bool
+		expRestart bool
+	}{
+		{
+			name:     "Should return empty diff",
+			modifyFn: func(cfg *config.Config) {},
+			expEmpty: true,
+		},
+		{
+			name: "Should detect changed sources",
+			modifyFn: func(cfg *config.Config) {
+				cfg.Sources["k8s-events"] = config.Sources{DisplayName: "Kubernetes events"}
+			},
+			expDiff: config.Diff{
+				Components: []config.ReloadComponent{config.SourcesReloadComponent},
+			},
+		},
+		{
+			name: "Should detect changed channel bindings and Teams bindings",
+			modifyFn: func(cfg *config.Config) {
+				group := cfg.Communications["default-group"]
+				channel := group.SocketSlack.Channels["alerts"]
+				channel.Bindings.Sources = []string{"k8s-events", "k8s-err-events"}
+				group.SocketSlack.Channels["alerts"] = channel
+				group.Teams.Bindings.Executors = []string{"kubectl-read-only"}
+				cfg.Communications["default-group"] = group
+			},
+			expDiff: config.Diff{
+				Components: []config.ReloadComponent{config.ChannelsReloadComponent},
+			},
+		},
+		{
+			name: "Should require restart for renamed channel",
+			modifyFn: func(cfg *config.Config) {
+				group := cfg.Communications["default-group"]
+				channel := group.SocketSlack.Channels["alerts"]
+				channel.Name = "prod-alerts"
+				group.SocketSlack.Channels["alerts"] = channel
+				cfg.Communications["default-group"] = group
+			},
+			expDiff: config.Diff{
+				RestartRequiredPaths: []string{"communications.default-group.socketSlack.channels.alerts.name"},
+			},
+			expRestart: true,
+		},
+		{
+			name: "Should require restart for added channel and changed settings",
+			modifyFn: func(cfg *config.Config) {
+				group := cfg.Communications["default-group"]
+				group.SocketSlack.Channels["dev"] = config.ChannelBindingsByName{Name: "dev"}
+				cfg.Communications["default-group"] = group
+				cfg.Sources["k8s-events"] = config.Sources{DisplayName: "Kubernetes events"}
+				cfg.Settings.ClusterName = "prod"
+			},
+			expDiff: config.Diff{
+				Components: []config.ReloadComponent{config.SourcesReloadComponent},
+				RestartRequiredPaths: []string{
+					"communications.default-group.socketSlack.channels.dev",
+					"settings.clusterName",
+				},
+			},
+			expRestart: true,
+		},
+		{
+			name: "Should detect changed sink settings",
+			modifyFn: func(cfg *config.Config) {
+				group := cfg.Communications["default-group"]
+				group.Webhook.URL = "http://webhook.example.com/events"
+				cfg.Communications["default-group"] = group
+			},
+			expDiff: config.Diff{
+				Components: []config.ReloadComponent{config.NotifiersReloadComponent},
+			},
+		},
+		{
+			name: "Should require restart for disabled sink",
+			modifyFn: func(cfg *config.Config) {
+				group := cfg.Communications["default-group"]
+				group.Webhook.Enabled = false
+				cfg.Communications["default-group"] = group
+			},
+			expDiff: config.Diff{
+				RestartRequiredPaths: []string{"communications.default-group.webhook.enabled"},
+			},
+			expRestart: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			old := fixDiffConfig()
+			updated := fixDiffConfig()
+			tc.modifyFn(updated)
+
+			// when
+			diff, err := config.DiffConfigs(old, updated)
+
+			// then
+			require.NoError(t, err)
+			assert.Equal(t, tc.expDiff, diff)
+			assert.Equal(t, tc.expEmpty, diff.IsEmpty())
+			assert.Equal(t, tc.expRestart, diff.RequiresRestart())
+		})
+	}
+}
+
+func fixDiffConfig() *config.Config {
+	return &config.Config{
+		Sources: map[string]config.Sources{
+			"k8s-events": {DisplayName: "Kubernetes"},
+		},
+		Communications: map[string]config.Communications{
+			"default-group": {
+				SocketSlack: config.SocketSlack{
+					Enabled: true,
+					Channels: config.IdentifiableMap[config.ChannelBindingsByName]{
+						"alerts": {
+							Name:     "alerts",
+							Bindings: config.BotBindings{Sources: []string{"k8s-events"}},
+						},
+					},
+				},
+				Teams: config.Teams{
+					Enabled:  true,
+					Bindings: config.BotBindings{Executors: []string{"kubectl-all"}},
+				},
+				Webhook: config.Webhook{
+					Enabled:  true,
+					URL:      "http://webhook.example.com",
+					Bindings: config.SinkBindings{Sources: []string{"k8s-events"}},
+				},
+			},
+		},
+		Settings: config.Settings{ClusterName: "dev"},
+	}
+}
@@ -0,0 +1,113 @@
+package config
+
+import (
+	"reflect"
+	"strings"
+)
+
+// envPathDelimiter separates the properties in the `BOTKUBE_COMMUNICATIONS__DEFAULT-GROUP__SOCKETSLACK__BOTTOKEN` form
+// of the environment variables.
+const envPathDelimiter = "__"
+
+// configEnvNameNormalizer returns a function which converts the name of a `BOTKUBE_` environment variable to a configuration property path.
+// Two forms are supported:
+//   - `BOTKUBE_COMMUNICATIONS__DEFAULT-GROUP__SOCKETSLACK__BOTTOKEN`, where the `__` separates properties, which are matched case-insensitively.
+//     The names of the map keys, such as communication groups, are taken from the loaded configuration, or lowercased if they are not set yet.
+//   - `BOTKUBE_COMMUNICATIONS_DEFAULT-GROUP_SOCKET__SLACK_BOT__TOKEN`, where the `_` separates properties, and the `__` separates words of a camelCase property.
+//
+// The first form is used only if each property is known, otherwise the name is converted with the second one.
+func configEnvNameNormalizer(loaded map[string]interface{}) func(string) string {
+	return func(name string) string {
+		trimmed := strings.TrimPrefix(name, configEnvVariablePrefix)
+		if path, ok := resolveEnvPath(strings.Split(trimmed, envPathDelimiter), loaded); ok {
+			return path
+		}
+		return normalizeConfigEnvName(name)
+	}
+}
+
+// resolveEnvPath matches the given properties against the loaded configuration and the Config struct.
+func resolveEnvPath(segments []string, loaded map[string]interface{}) (string, bool) {
+	if len(segments) < 2 {
+		return "", false
+	}
+
+	var (
+		path             = make([]string, 0, len(segments))
+		node interface{} = loaded
+		typ              = reflect.TypeOf(Config{})
+	)
+	for _, segment := range segments {
+		if segment == "" || strings.Contains(segment, "_") {
+			// it's the form with the `_` delimiter
+			return "", false
+		}
+
+		key, found := mapKeyFold(node, segment)
+		for typ != nil && typ.Kind() == reflect.Pointer {
+			typ = typ.Elem()
+		}
+
+		var next reflect.Type
+		switch {
+		case typ == nil || typ.Kind() == reflect.Interface:
+			// free-form values, such as plugin configuration
+			if !found {
+				key, found = strings.ToLower(segment), true
+			}
+		case typ.Kind() == reflect.Struct:
+			tag, fieldType, ok := structFieldFold(typ, segment)
+			if !ok {
+				return "", false
+			}
+			key, found, next = tag, true, fieldType
+		case typ.Kind() == reflect.Map:
+			if !found {
+				key, found = strings.ToLower(segment), true
+			}
+			next = typ.Elem()
+		}
+		if !found {
+			return "", false
+		}
+
+		path = append(path, key)
+		if m, ok := node.(map[string]interface{}); ok {
+			node = m[key]
+		} else {
+			node = nil
+		}
+		typ = next
+	}
+
+	return strings.Join(path, configDelimiter), true
+}
+
+// mapKeyFold returns the key of a given map which is equal to the name under Unicode case-folding.
+func mapKeyFold(node interface{}, name string) (string, bool) {
+	m, ok := node.(map[string]interface{})
+	if !ok {
+		return "", false
+	}
+	for key := range m {
+		if strings.EqualFold(key, name) {
+			return key, true
+		}
+	}
+	return "", false
+}
+
+// structFieldFold returns the YAML name and the type of the struct field which YAML name is equal to the name under Unicode case-folding.
+func structFieldFold(typ reflect.Type, name string) (string, reflect.Type, bool) {
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		tag, _, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+		if tag == "" || tag == "-" {
+			continue
+		}
+		if strings.EqualFold(tag, name) {
+			return tag, field.Type, true
+		}
+	}
+	return "", nil, false
+}
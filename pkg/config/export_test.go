@@ -1,9 +1,21 @@
 package config
 
+import "github.com/knadh/koanf"
+
 func NormalizeConfigEnvName(name string) string {
 	return normalizeConfigEnvName(name)
 }
 
+func ConfigEnvNameNormalizer(loaded map[string]interface{}) func(string) string {
+	return configEnvNameNormalizer(loaded)
+}
+
 func SortCfgFiles(paths []string) []string {
 	return sortCfgFiles(paths)
 }
+
+func LoadWithDefaultsAndSecretResolver(getCfgPaths PathsGetter, resolver SecretResolver) (*Config, LoadWithDefaultsDetails, error) {
+	return loadWithDefaults(getCfgPaths, func(*koanf.Koanf) (SecretResolver, error) {
+		return resolver, nil
+	})
+}
@@ -0,0 +1,78 @@
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// envReferenceRegex matches the `${NAME}` and `${NAME:-default}` references, and the `$${...}` escaped ones.
+var envReferenceRegex = regexp.MustCompile(`\$?\$\{([A-Za-z_][A-Za-z0-9_]*)(:-([^}]*))?\}`)
+
+// LookupEnvFn defines a function which returns the value of a given environment variable.
+type LookupEnvFn func(name string) (string, bool)
+
+// interpolateEnv replaces the environment variable references in all string values of a given configuration tree:
+//   - `${NAME}` is replaced with the value of the NAME variable. It's an error if the variable is not set.
+//   - `${NAME:-default}` is replaced with the value of the NAME variable, or with `default` if the variable is not set or empty.
+//   - `$${NAME}` is replaced with the literal `${NAME}`.
+//
+// The map keys are not interpolated.
+func interpolateEnv(in map[string]interface{}, lookupEnv LookupEnvFn) error {
+	missing := map[string]struct{}{}
+	for key, val := range in {
+		in[key] = interpolateValue(val, lookupEnv, missing)
+	}
+
+	if len(missing) == 0 {
+		return nil
+	}
+	names := make([]string, 0, len(missing))
+	for name := range missing {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return fmt.Errorf("environment variables referenced in the configuration are not set: %s", strings.Join(names, ", "))
+}
+
+func interpolateValue(val interface{}, lookupEnv LookupEnvFn, missing map[string]struct{}) interface{} {
+	switch v := val.(type) {
+	case map[string]interface{}:
+		for key, nested := range v {
+			v[key] = interpolateValue(nested, lookupEnv, missing)
+		}
+		return v
+	case []interface{}:
+		for i, item := range v {
+			v[i] = interpolateValue(item, lookupEnv, missing)
+		}
+		return v
+	case string:
+		return interpolateString(v, lookupEnv, missing)
+	default:
+		return val
+	}
+}
+
+func interpolateString(in string, lookupEnv LookupEnvFn, missing map[string]struct{}) string {
+	return envReferenceRegex.ReplaceAllStringFunc(in, func(ref string) string {
+		if strings.HasPrefix(ref, "$$") {
+			return ref[1:]
+		}
+
+		groups := envReferenceRegex.FindStringSubmatch(ref)
+		name, hasDefault, defaultValue := groups[1], groups[2] != "", groups[3]
+
+		value, found := lookupEnv(name)
+		switch {
+		case found && (value != "" || !hasDefault):
+			return value
+		case hasDefault:
+			return defaultValue
+		}
+
+		missing[name] = struct{}{}
+		return ref
+	})
+}
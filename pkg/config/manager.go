@@ -3,6 +3,7 @@ package config
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/sirupsen/logrus"
 	"k8s.io/client-go/kubernetes"
@@ -14,27 +15,51 @@ type PersistenceManager struct {
 	log    logrus.FieldLogger
 	cfg    PersistentConfig
 	k8sCli kubernetes.Interface
+	locker StateLocker
+}
+
+// StateLocker guards concurrent modifications of the persisted state.
+type StateLocker interface {
+	WithLock(ctx context.Context, fn func(ctx context.Context) error) error
 }
 
 // ErrUnsupportedPlatform is an error returned when a platform is not supported.
 var ErrUnsupportedPlatform = errors.New("unsupported platform to persist data")
 
 // NewManager creates a new PersistenceManager instance.
-func NewManager(log logrus.FieldLogger, cfg PersistentConfig, k8sCli kubernetes.Interface) *PersistenceManager {
+func NewManager(log logrus.FieldLogger, cfg PersistentConfig, k8sCli kubernetes.Interface, locker StateLocker) *PersistenceManager {
 	return &PersistenceManager{
 		log:    log,
 		cfg:    cfg,
 		k8sCli: k8sCli,
+		locker: locker,
 	}
 }
 
 // PersistSourceBindings persists source bindings configuration for a given channel in a given platform.
 func (m *PersistenceManager) PersistSourceBindings(ctx context.Context, commGroupName string, platform CommPlatformIntegration, channelAlias string, sourceBindings []string) error {
+	return m.modifyChannelBindings(ctx, commGroupName, platform, channelAlias, func(bindings *ChannelRuntimeBindings) {
+		bindings.Sources = sourceBindings
+	})
+}
+
+// PersistExecutorBindings persists executor bindings configuration for a given channel in a given platform.
+func (m *PersistenceManager) PersistExecutorBindings(ctx context.Context, commGroupName string, platform CommPlatformIntegration, channelAlias string, executorBindings []string) error {
+	return m.modifyChannelBindings(ctx, commGroupName, platform, channelAlias, func(bindings *ChannelRuntimeBindings) {
+		bindings.Executors = executorBindings
+	})
+}
+
+func (m *PersistenceManager) modifyChannelBindings(ctx context.Context, commGroupName string, platform CommPlatformIntegration, channelAlias string, mutateFn func(bindings *ChannelRuntimeBindings)) error {
 	supportedPlatforms := []string{
 		string(SlackCommPlatformIntegration),
 		string(SocketSlackCommPlatformIntegration),
 		string(DiscordCommPlatformIntegration),
 		string(MattermostCommPlatformIntegration),
+		string(RocketChatCommPlatformIntegration),
+		string(GoogleChatCommPlatformIntegration),
+		string(WebexCommPlatformIntegration),
+		string(MatrixCommPlatformIntegration),
 		string(TeamsCommPlatformIntegration),
 	}
 
@@ -42,140 +67,144 @@ func (m *PersistenceManager) PersistSourceBindings(ctx context.Context, commGrou
 		return ErrUnsupportedPlatform
 	}
 
-	configMapStorage := configMapStorage[RuntimeState]{k8sCli: m.k8sCli, cfg: m.cfg.Runtime}
-
-	state, cm, err := configMapStorage.Get(ctx)
-	if err != nil {
-		return err
-	}
-
-	if state.Communications == nil {
-		state.Communications = make(map[string]CommunicationsRuntimeState)
-	}
-	commGroup, exists := state.Communications[commGroupName]
-	if !exists {
-		commGroup = make(CommunicationsRuntimeState)
-		state.Communications[commGroupName] = commGroup
-	}
-
-	platformCfg, exists := commGroup[platform]
-	if !exists {
-		platformCfg = BotRuntimeState{}
-		state.Communications[commGroupName][platform] = platformCfg
-	}
-
-	if platform == TeamsCommPlatformIntegration {
-		if platformCfg.MSTeamsOnlyRuntimeState == nil {
-			platformCfg.MSTeamsOnlyRuntimeState = &ChannelRuntimeState{}
+	cmStorage := m.runtimeStorage()
+	return cmStorage.Modify(ctx, func(state *RuntimeState) error {
+		if state.Communications == nil {
+			state.Communications = make(map[string]CommunicationsRuntimeState)
 		}
-
-		platformCfg.MSTeamsOnlyRuntimeState.Bindings.Sources = sourceBindings
-		state.Communications[commGroupName][platform] = platformCfg
-
-		err = configMapStorage.Update(ctx, cm, state)
-		if err != nil {
-			return err
+		commGroup, exists := state.Communications[commGroupName]
+		if !exists {
+			commGroup = make(CommunicationsRuntimeState)
+			state.Communications[commGroupName] = commGroup
 		}
 
-		return nil
-	}
+		platformCfg := commGroup[platform]
 
-	if platformCfg.Channels == nil {
-		platformCfg.Channels = make(map[string]ChannelRuntimeState)
-		state.Communications[commGroupName][platform] = platformCfg
-	}
+		if platform == TeamsCommPlatformIntegration {
+			if platformCfg.MSTeamsOnlyRuntimeState == nil {
+				platformCfg.MSTeamsOnlyRuntimeState = &ChannelRuntimeState{}
+			}
 
-	channel, exists := platformCfg.Channels[channelAlias]
-	if !exists {
-		channel = ChannelRuntimeState{}
-	}
+			mutateFn(&platformCfg.MSTeamsOnlyRuntimeState.Bindings)
+			commGroup[platform] = platformCfg
+			return nil
+		}
 
-	channel.Bindings.Sources = sourceBindings
-	state.Communications[commGroupName][platform].Channels[channelAlias] = channel
+		if platformCfg.Channels == nil {
+			platformCfg.Channels = make(map[string]ChannelRuntimeState)
+		}
 
-	err = configMapStorage.Update(ctx, cm, state)
-	if err != nil {
-		return err
-	}
+		channel := platformCfg.Channels[channelAlias]
+		mutateFn(&channel.Bindings)
+		platformCfg.Channels[channelAlias] = channel
+		commGroup[platform] = platformCfg
 
-	return nil
+		return nil
+	})
 }
 
-// PersistNotificationsEnabled persists notifications state for a given channel.
+// PersistNotificationsEnabled persists notifications state for a given channel. It also ends the notifications snooze, if any.
 // While this method updates the Botkube ConfigMap, it doesn't reload Botkube itself.
 func (m *PersistenceManager) PersistNotificationsEnabled(ctx context.Context, commGroupName string, platform CommPlatformIntegration, channelAlias string, enabled bool) error {
+	return m.modifyChannelNotification(ctx, commGroupName, platform, channelAlias, func(notification *NotificationStartupState) {
+		notification.Disabled = !enabled
+		notification.SnoozedUntil = time.Time{}
+	})
+}
+
+// PersistNotificationsSnoozed persists notifications snooze for a given channel. Once the snooze ends, the notifications are enabled.
+// While this method updates the Botkube ConfigMap, it doesn't reload Botkube itself.
+func (m *PersistenceManager) PersistNotificationsSnoozed(ctx context.Context, commGroupName string, platform CommPlatformIntegration, channelAlias string, until time.Time) error {
+	return m.modifyChannelNotification(ctx, commGroupName, platform, channelAlias, func(notification *NotificationStartupState) {
+		notification.Disabled = false
+		notification.SnoozedUntil = until.UTC().Truncate(time.Second)
+	})
+}
+
+func (m *PersistenceManager) modifyChannelNotification(ctx context.Context, commGroupName string, platform CommPlatformIntegration, channelAlias string, mutateFn func(notification *NotificationStartupState)) error {
 	supportedPlatforms := []string{
 		string(SlackCommPlatformIntegration),
 		string(SocketSlackCommPlatformIntegration),
 		string(DiscordCommPlatformIntegration),
 		string(MattermostCommPlatformIntegration),
+		string(RocketChatCommPlatformIntegration),
+		string(GoogleChatCommPlatformIntegration),
+		string(WebexCommPlatformIntegration),
+		string(MatrixCommPlatformIntegration),
 	}
 
 	if !slices.Contains(supportedPlatforms, string(platform)) {
 		return ErrUnsupportedPlatform
 	}
 
-	cmStorage := configMapStorage[StartupState]{k8sCli: m.k8sCli, cfg: m.cfg.Startup}
-	state, cm, err := cmStorage.Get(ctx)
-	if err != nil {
-		return err
-	}
-
-	if state.Communications == nil {
-		state.Communications = make(map[string]CommunicationsStartupState)
-	}
-	commGroup, exists := state.Communications[commGroupName]
-	if !exists {
-		commGroup = make(CommunicationsStartupState)
-		state.Communications[commGroupName] = commGroup
-	}
-
-	platformCfg, exists := commGroup[platform]
-	if !exists {
-		platformCfg = BotStartupState{}
-		state.Communications[commGroupName][platform] = platformCfg
-	}
-
-	if platformCfg.Channels == nil {
-		platformCfg.Channels = make(map[string]ChannelStartupState)
-		state.Communications[commGroupName][platform] = platformCfg
-	}
-
-	channel, exists := platformCfg.Channels[channelAlias]
-	if !exists {
-		channel = ChannelStartupState{}
-	}
+	cmStorage := m.startupStorage()
+	return cmStorage.Modify(ctx, func(state *StartupState) error {
+		if state.Communications == nil {
+			state.Communications = make(map[string]CommunicationsStartupState)
+		}
+		commGroup, exists := state.Communications[commGroupName]
+		if !exists {
+			commGroup = make(CommunicationsStartupState)
+			state.Communications[commGroupName] = commGroup
+		}
 
-	channel.Notification.Disabled = !enabled
-	state.Communications[commGroupName][platform].Channels[channelAlias] = channel
+		platformCfg := commGroup[platform]
+		if platformCfg.Channels == nil {
+			platformCfg.Channels = make(map[string]ChannelStartupState)
+		}
 
-	err = cmStorage.Update(ctx, cm, state)
-	if err != nil {
-		return err
-	}
+		channel := platformCfg.Channels[channelAlias]
+		mutateFn(&channel.Notification)
+		platformCfg.Channels[channelAlias] = channel
+		commGroup[platform] = platformCfg
 
-	return nil
+		return nil
+	})
 }
 
 // PersistFilterEnabled persists status for a given filter.
 // While this method updates the Botkube ConfigMap, it doesn't reload Botkube itself.
 func (m *PersistenceManager) PersistFilterEnabled(ctx context.Context, name string, enabled bool) error {
-	cmStorage := configMapStorage[StartupState]{k8sCli: m.k8sCli, cfg: m.cfg.Startup}
+	cmStorage := m.startupStorage()
+	return cmStorage.Modify(ctx, func(state *StartupState) error {
+		return state.Filters.Kubernetes.SetEnabled(name, enabled)
+	})
+}
 
-	state, cm, err := cmStorage.Get(ctx)
-	if err != nil {
-		return err
-	}
+// PersistAlias persists a given command alias. An empty command disables the alias, also if it's defined in the Botkube configuration.
+// While this method updates the Botkube ConfigMap, it doesn't reload Botkube itself.
+func (m *PersistenceManager) PersistAlias(ctx context.Context, name, command string) error {
+	cmStorage := m.startupStorage()
+	return cmStorage.Modify(ctx, func(state *StartupState) error {
+		if state.Aliases == nil {
+			state.Aliases = make(Aliases)
+		}
+		state.Aliases[name] = command
+		return nil
+	})
+}
 
-	err = state.Filters.Kubernetes.SetEnabled(name, enabled)
-	if err != nil {
-		return err
-	}
+// PersistSchedule persists a given scheduled command. A nil schedule removes it.
+// While this method updates the Botkube ConfigMap, it doesn't reload Botkube itself.
+func (m *PersistenceManager) PersistSchedule(ctx context.Context, id string, schedule *Schedule) error {
+	cmStorage := m.startupStorage()
+	return cmStorage.Modify(ctx, func(state *StartupState) error {
+		if schedule == nil {
+			delete(state.Schedules, id)
+			return nil
+		}
+		if state.Schedules == nil {
+			state.Schedules = make(Schedules)
+		}
+		state.Schedules[id] = *schedule
+		return nil
+	})
+}
 
-	err = cmStorage.Update(ctx, cm, state)
-	if err != nil {
-		return err
-	}
+func (m *PersistenceManager) runtimeStorage() *configMapStorage[RuntimeState] {
+	return &configMapStorage[RuntimeState]{k8sCli: m.k8sCli, cfg: m.cfg.Runtime, locker: m.locker}
+}
 
-	return nil
+func (m *PersistenceManager) startupStorage() *configMapStorage[StartupState] {
+	return &configMapStorage[StartupState]{k8sCli: m.k8sCli, cfg: m.cfg.Startup, locker: m.locker}
 }
@@ -3,6 +3,7 @@ package config_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/MakeNowJust/heredoc"
 	logtest "github.com/sirupsen/logrus/hooks/test"
@@ -12,6 +13,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes/fake"
 
+	"github.com/kubeshop/botkube/internal/storage"
 	"github.com/kubeshop/botkube/pkg/config"
 )
 
@@ -69,6 +71,36 @@ func TestPersistenceManager_PersistSourceBindings(t *testing.T) {
 				},
 			},
 		},
+		{
+			Name:                "No state file in ConfigMap",
+			InputPlatform:       config.DiscordCommPlatformIntegration,
+			InputChannel:        "foo",
+			InputSourceBindings: []string{"first"},
+			InputCfgMap: &v1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      cfg.ConfigMap.Name,
+					Namespace: cfg.ConfigMap.Namespace,
+				},
+			},
+			Expected: &v1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      cfg.ConfigMap.Name,
+					Namespace: cfg.ConfigMap.Namespace,
+				},
+				Data: map[string]string{
+					cfg.FileName: heredoc.Doc(`
+                      communications:
+                        default-group:
+                          discord:
+                            channels:
+                              foo:
+                                bindings:
+                                  sources:
+                                    - first
+					`),
+				},
+			},
+		},
 		{
 			Name:                "Empty state files - MS Teams",
 			InputPlatform:       config.TeamsCommPlatformIntegration,
@@ -250,7 +282,7 @@ func TestPersistenceManager_PersistSourceBindings(t *testing.T) {
 		t.Run(testCase.Name, func(t *testing.T) {
 			logger, _ := logtest.NewNullLogger()
 			k8sCli := fake.NewSimpleClientset(testCase.InputCfgMap)
-			manager := config.NewManager(logger, config.PersistentConfig{Runtime: cfg}, k8sCli)
+			manager := config.NewManager(logger, config.PersistentConfig{Runtime: cfg}, k8sCli, storage.NewLease(cfg.ConfigMap.Namespace, "botkube-system", k8sCli))
 
 			// when
 			err := manager.PersistSourceBindings(context.Background(), commGroupName, testCase.InputPlatform, testCase.InputChannel, testCase.InputSourceBindings)
@@ -271,6 +303,144 @@ func TestPersistenceManager_PersistSourceBindings(t *testing.T) {
 	}
 }
 
+func TestPersistenceManager_PersistExecutorBindings(t *testing.T) {
+	// given
+	commGroupName := "default-group"
+	cfg := config.PartialPersistentConfig{
+		ConfigMap: config.K8sResourceRef{
+			Name:      "foo",
+			Namespace: "ns",
+		},
+		FileName: "_runtime_state.yaml",
+	}
+
+	testCases := []struct {
+		Name                  string
+		InputCfgMap           *v1.ConfigMap
+		InputPlatform         config.CommPlatformIntegration
+		InputChannel          string
+		InputExecutorBindings []string
+		ExpectedErrMessage    string
+		Expected              *v1.ConfigMap
+	}{
+		{
+			Name:                  "Existing state files",
+			InputChannel:          "general",
+			InputPlatform:         config.SlackCommPlatformIntegration,
+			InputExecutorBindings: []string{"kubectl-read-only", "helm"},
+			InputCfgMap: &v1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      cfg.ConfigMap.Name,
+					Namespace: cfg.ConfigMap.Namespace,
+				},
+				Data: map[string]string{
+					cfg.FileName: heredoc.Doc(`
+                      communications:
+                        default-group:
+                          slack:
+                            channels:
+                              general:
+                                bindings:
+                                  sources:
+                                    - old
+                                  executors:
+                                    - older
+					`),
+				},
+			},
+			Expected: &v1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      cfg.ConfigMap.Name,
+					Namespace: cfg.ConfigMap.Namespace,
+				},
+				Data: map[string]string{
+					cfg.FileName: heredoc.Doc(`
+                      communications:
+                        default-group:
+                          slack:
+                            channels:
+                              general:
+                                bindings:
+                                  sources:
+                                    - old
+                                  executors:
+                                    - kubectl-read-only
+                                    - helm
+					`),
+				},
+			},
+		},
+		{
+			Name:                  "Empty state files - MS Teams",
+			InputPlatform:         config.TeamsCommPlatformIntegration,
+			InputChannel:          "foo",
+			InputExecutorBindings: []string{"kubectl-read-only"},
+			InputCfgMap: &v1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      cfg.ConfigMap.Name,
+					Namespace: cfg.ConfigMap.Namespace,
+				},
+				Data: map[string]string{
+					cfg.FileName: "",
+				},
+			},
+			Expected: &v1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      cfg.ConfigMap.Name,
+					Namespace: cfg.ConfigMap.Namespace,
+				},
+				Data: map[string]string{
+					cfg.FileName: heredoc.Doc(`
+                      communications:
+                        default-group:
+                          teams:
+                            bindings:
+                              executors:
+                                - kubectl-read-only
+					`),
+				},
+			},
+		},
+		{
+			Name:                  "Unsupported platform",
+			InputPlatform:         config.WebhookCommPlatformIntegration,
+			InputChannel:          "foo",
+			InputExecutorBindings: []string{"kubectl-read-only"},
+			InputCfgMap: &v1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      cfg.ConfigMap.Name,
+					Namespace: cfg.ConfigMap.Namespace,
+				},
+			},
+			ExpectedErrMessage: `unsupported platform to persist data`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			logger, _ := logtest.NewNullLogger()
+			k8sCli := fake.NewSimpleClientset(testCase.InputCfgMap)
+			manager := config.NewManager(logger, config.PersistentConfig{Runtime: cfg}, k8sCli, storage.NewLease(cfg.ConfigMap.Namespace, "botkube-system", k8sCli))
+
+			// when
+			err := manager.PersistExecutorBindings(context.Background(), commGroupName, testCase.InputPlatform, testCase.InputChannel, testCase.InputExecutorBindings)
+
+			// then
+			if testCase.ExpectedErrMessage != "" {
+				require.Error(t, err)
+				assert.EqualError(t, err, testCase.ExpectedErrMessage)
+				return
+			}
+
+			require.NoError(t, err)
+
+			cfgMap, err := k8sCli.CoreV1().ConfigMaps(cfg.ConfigMap.Namespace).Get(context.Background(), cfg.ConfigMap.Name, metav1.GetOptions{})
+			require.NoError(t, err)
+			assert.Equal(t, testCase.Expected, cfgMap)
+		})
+	}
+}
+
 func TestPersistenceManager_PersistNotificationsEnabled(t *testing.T) {
 	// given
 	commGroupName := "default-group"
@@ -404,7 +574,7 @@ func TestPersistenceManager_PersistNotificationsEnabled(t *testing.T) {
 		t.Run(testCase.Name, func(t *testing.T) {
 			logger, _ := logtest.NewNullLogger()
 			k8sCli := fake.NewSimpleClientset(testCase.InputCfgMap)
-			manager := config.NewManager(logger, config.PersistentConfig{Startup: cfg}, k8sCli)
+			manager := config.NewManager(logger, config.PersistentConfig{Startup: cfg}, k8sCli, storage.NewLease(cfg.ConfigMap.Namespace, "botkube-system", k8sCli))
 
 			// when
 			err := manager.PersistNotificationsEnabled(context.Background(), commGroupName, testCase.InputPlatform, testCase.InputChannel, testCase.InputEnabled)
@@ -425,6 +595,63 @@ func TestPersistenceManager_PersistNotificationsEnabled(t *testing.T) {
 	}
 }
 
+func TestPersistenceManager_PersistNotificationsSnoozed(t *testing.T) {
+	// given
+	cfg := config.PartialPersistentConfig{
+		ConfigMap: config.K8sResourceRef{
+			Name:      "foo",
+			Namespace: "ns",
+		},
+		FileName: "__startup_state.yaml",
+	}
+	cfgMap := &v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cfg.ConfigMap.Name,
+			Namespace: cfg.ConfigMap.Namespace,
+		},
+		Data: map[string]string{
+			cfg.FileName: heredoc.Doc(`
+				communications:
+				  default-group:
+				    slack:
+				      channels:
+				        general:
+				          notification:
+				            disabled: true
+			`),
+		},
+	}
+	until := time.Date(2022, 10, 10, 14, 30, 0, 0, time.FixedZone("CEST", 2*60*60))
+
+	logger, _ := logtest.NewNullLogger()
+	k8sCli := fake.NewSimpleClientset(cfgMap)
+	manager := config.NewManager(logger, config.PersistentConfig{Startup: cfg}, k8sCli, nil)
+
+	// when
+	err := manager.PersistNotificationsSnoozed(context.Background(), "default-group", config.SlackCommPlatformIntegration, "general", until)
+	require.NoError(t, err)
+	snoozed, err := k8sCli.CoreV1().ConfigMaps(cfg.ConfigMap.Namespace).Get(context.Background(), cfg.ConfigMap.Name, metav1.GetOptions{})
+	require.NoError(t, err)
+
+	err = manager.PersistNotificationsEnabled(context.Background(), "default-group", config.SlackCommPlatformIntegration, "general", true)
+	require.NoError(t, err)
+	enabled, err := k8sCli.CoreV1().ConfigMaps(cfg.ConfigMap.Namespace).Get(context.Background(), cfg.ConfigMap.Name, metav1.GetOptions{})
+	require.NoError(t, err)
+
+	// then
+	assert.Equal(t, heredoc.Doc(`
+		communications:
+		  default-group:
+		    slack:
+		      channels:
+		        general:
+		          notification:
+		            disabled: false
+		            snoozedUntil: 2022-10-10T12:30:00Z
+	`), snoozed.Data[cfg.FileName])
+	assert.NotContains(t, enabled.Data[cfg.FileName], "snoozedUntil")
+}
+
 func TestPersistenceManager_PersistFilterEnabled(t *testing.T) {
 	// given
 	cfg := config.PartialPersistentConfig{
@@ -543,7 +770,7 @@ func TestPersistenceManager_PersistFilterEnabled(t *testing.T) {
 		t.Run(testCase.Name, func(t *testing.T) {
 			logger, _ := logtest.NewNullLogger()
 			k8sCli := fake.NewSimpleClientset(testCase.InputCfgMap)
-			manager := config.NewManager(logger, config.PersistentConfig{Startup: cfg}, k8sCli)
+			manager := config.NewManager(logger, config.PersistentConfig{Startup: cfg}, k8sCli, storage.NewLease(cfg.ConfigMap.Namespace, "botkube-system", k8sCli))
 
 			// when
 			err := manager.PersistFilterEnabled(context.Background(), testCase.InputName, testCase.InputEnabled)
@@ -563,3 +790,120 @@ func TestPersistenceManager_PersistFilterEnabled(t *testing.T) {
 		})
 	}
 }
+
+func TestPersistenceManager_PersistAlias(t *testing.T) {
+	// given
+	cfg := config.PartialPersistentConfig{
+		ConfigMap: config.K8sResourceRef{
+			Name:      "foo",
+			Namespace: "ns",
+		},
+		FileName: "__startup_state.yaml",
+	}
+	inputCfgMap := &v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cfg.ConfigMap.Name,
+			Namespace: cfg.ConfigMap.Namespace,
+		},
+		Data: map[string]string{
+			cfg.FileName: heredoc.Doc(`
+              filters:
+                kubernetes:
+                  objectAnnotationChecker: true
+                  nodeEventsChecker: true
+              aliases:
+                kgp: kubectl get pods
+			`),
+		},
+	}
+
+	logger, _ := logtest.NewNullLogger()
+	k8sCli := fake.NewSimpleClientset(inputCfgMap)
+	manager := config.NewManager(logger, config.PersistentConfig{Startup: cfg}, k8sCli, nil)
+
+	// when
+	err := manager.PersistAlias(context.Background(), "gp", "kubectl get pods -n {{.arg1}}")
+	require.NoError(t, err)
+	err = manager.PersistAlias(context.Background(), "kgp", "")
+	require.NoError(t, err)
+
+	// then
+	cfgMap, err := k8sCli.CoreV1().ConfigMaps(cfg.ConfigMap.Namespace).Get(context.Background(), cfg.ConfigMap.Name, metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, heredoc.Doc(`
+      filters:
+        kubernetes:
+          objectAnnotationChecker: true
+          nodeEventsChecker: true
+      aliases:
+        gp: kubectl get pods -n {{.arg1}}
+        kgp: ""
+	`), cfgMap.Data[cfg.FileName])
+}
+
+func TestPersistenceManager_PersistSchedule(t *testing.T) {
+	// given
+	cfg := config.PartialPersistentConfig{
+		ConfigMap: config.K8sResourceRef{
+			Name:      "foo",
+			Namespace: "ns",
+		},
+		FileName: "__startup_state.yaml",
+	}
+	inputCfgMap := &v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cfg.ConfigMap.Name,
+			Namespace: cfg.ConfigMap.Namespace,
+		},
+		Data: map[string]string{
+			cfg.FileName: heredoc.Doc(`
+              aliases:
+                kgp: kubectl get pods
+              schedules:
+                a1b2c3d4:
+                  cron: '@daily'
+                  command: kubectl get nodes
+                  commGroup: default-group
+                  platform: socketSlack
+                  channelID: C123
+                  user: Joe
+                  userID: U123
+			`),
+		},
+	}
+
+	logger, _ := logtest.NewNullLogger()
+	k8sCli := fake.NewSimpleClientset(inputCfgMap)
+	manager := config.NewManager(logger, config.PersistentConfig{Startup: cfg}, k8sCli, nil)
+
+	// when
+	err := manager.PersistSchedule(context.Background(), "e5f6a7b8", &config.Schedule{
+		Cron:      "0 9 * * 1-5",
+		Command:   "kubectl get pods -n prod",
+		CommGroup: "default-group",
+		Platform:  config.SocketSlackCommPlatformIntegration,
+		ChannelID: "C123",
+		User:      "Ann",
+		UserID:    "U456",
+	})
+	require.NoError(t, err)
+	err = manager.PersistSchedule(context.Background(), "a1b2c3d4", nil)
+	require.NoError(t, err)
+
+	// then
+	cfgMap, err := k8sCli.CoreV1().ConfigMaps(cfg.ConfigMap.Namespace).Get(context.Background(), cfg.ConfigMap.Name, metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, heredoc.Doc(`
+      aliases:
+        kgp: kubectl get pods
+      schedules:
+        e5f6a7b8:
+          cron: 0 9 * * 1-5
+          command: kubectl get pods -n prod
+          commGroup: default-group
+          platform: socketSlack
+          channelID: C123
+          user: Ann
+          userID: U456
+	`), cfgMap.Data[cfg.FileName])
+}
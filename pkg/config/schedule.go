@@ -0,0 +1,193 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+const minutesPerDay = 24 * 60
+
+var weekdays = map[string]time.Weekday{
+	"sun": time.Sunday, "sunday": time.Sunday,
+	"mon": time.Monday, "monday": time.Monday,
+	"tue": time.Tuesday, "tuesday": time.Tuesday,
+	"wed": time.Wednesday, "wednesday": time.Wednesday,
+	"thu": time.Thursday, "thursday": time.Thursday,
+	"fri": time.Friday, "friday": time.Friday,
+	"sat": time.Saturday, "saturday": time.Saturday,
+}
+
+// NotificationSchedule contains the time windows in which all notifications are sent to a given channel.
+type NotificationSchedule struct {
+	// Timezone is the IANA time zone name, such as `Europe/Berlin`. Defaults to UTC.
+	Timezone string `yaml:"timezone,omitempty"`
+	// Windows lists the working hours. If empty, the notifications are always sent.
+	Windows []NotificationWindow `yaml:"windows,omitempty"`
+}
+
+// NotificationWindow contains the time range on given week days.
+type NotificationWindow struct {
+	// Days lists the week days, such as `mon` or `monday`. If empty, the window applies to every day.
+	Days []string `yaml:"days,omitempty"`
+	// From is the window start time in the `HH:MM` format.
+	From string `yaml:"from"`
+	// To is the window end time in the `HH:MM` format. Defaults to midnight.
+	// If it is not after From, the window ends on the next day.
+	To string `yaml:"to,omitempty"`
+}
+
+// IsConfigured returns true if the schedule has at least one window.
+func (s NotificationSchedule) IsConfigured() bool {
+	return len(s.Windows) > 0
+}
+
+// Validate returns error if the schedule is invalid.
+func (s NotificationSchedule) Validate() error {
+	if _, err := s.location(); err != nil {
+		return err
+	}
+	for idx, window := range s.Windows {
+		if _, err := window.parse(); err != nil {
+			return fmt.Errorf("windows[%d]: %w", idx, err)
+		}
+	}
+	return nil
+}
+
+// IsActive returns true if a given time is within one of the schedule windows.
+// Not configured or invalid schedule is always active.
+func (s NotificationSchedule) IsActive(t time.Time) bool {
+	windows, loc, ok := s.parse()
+	if !ok {
+		return true
+	}
+
+	t = t.In(loc)
+	minute := t.Hour()*60 + t.Minute()
+	for _, w := range windows {
+		if w.contains(t.Weekday(), minute) {
+			return true
+		}
+	}
+	return false
+}
+
+// NextStart returns the start of the next schedule window after a given time.
+// It returns a given time, if the schedule is active at that time.
+func (s NotificationSchedule) NextStart(t time.Time) time.Time {
+	if s.IsActive(t) {
+		return t
+	}
+
+	windows, loc, _ := s.parse()
+	t = t.In(loc)
+
+	var next time.Time
+	for day := 0; day <= 7; day++ {
+		date := t.AddDate(0, 0, day)
+		for _, w := range windows {
+			if !w.days[date.Weekday()] {
+				continue
+			}
+			start := time.Date(date.Year(), date.Month(), date.Day(), w.from/60, w.from%60, 0, 0, loc)
+			if start.After(t) && (next.IsZero() || start.Before(next)) {
+				next = start
+			}
+		}
+		if !next.IsZero() {
+			return next
+		}
+	}
+	return t
+}
+
+func (s NotificationSchedule) location() (*time.Location, error) {
+	if s.Timezone == "" {
+		return time.UTC, nil
+	}
+	loc, err := time.LoadLocation(s.Timezone)
+	if err != nil {
+		return nil, fmt.Errorf("invalid timezone %q: %w", s.Timezone, err)
+	}
+	return loc, nil
+}
+
+func (s NotificationSchedule) parse() ([]scheduleWindow, *time.Location, bool) {
+	if !s.IsConfigured() {
+		return nil, nil, false
+	}
+
+	loc, err := s.location()
+	if err != nil {
+		return nil, nil, false
+	}
+
+	windows := make([]scheduleWindow, 0, len(s.Windows))
+	for _, window := range s.Windows {
+		parsed, err := window.parse()
+		if err != nil {
+			return nil, nil, false
+		}
+		windows = append(windows, parsed)
+	}
+	return windows, loc, true
+}
+
+type scheduleWindow struct {
+	days map[time.Weekday]bool
+	// from and to are minutes since midnight.
+	from, to int
+}
+
+// contains returns true if a given minute of a given week day is within the window.
+func (w scheduleWindow) contains(day time.Weekday, minute int) bool {
+	if w.from < w.to {
+		return w.days[day] && minute >= w.from && minute < w.to
+	}
+
+	// the window ends on the next day
+	previousDay := (day + 6) % 7
+	return (w.days[day] && minute >= w.from) || (w.days[previousDay] && minute < w.to)
+}
+
+func (w NotificationWindow) parse() (scheduleWindow, error) {
+	out := scheduleWindow{days: map[time.Weekday]bool{}}
+
+	if len(w.Days) == 0 {
+		for _, day := range weekdays {
+			out.days[day] = true
+		}
+	}
+	for _, name := range w.Days {
+		day, found := weekdays[strings.ToLower(name)]
+		if !found {
+			return scheduleWindow{}, fmt.Errorf("invalid week day %q", name)
+		}
+		out.days[day] = true
+	}
+
+	var err error
+	out.from, err = parseClock(w.From)
+	if err != nil {
+		return scheduleWindow{}, fmt.Errorf("invalid start time: %w", err)
+	}
+
+	out.to = minutesPerDay
+	if w.To != "" {
+		out.to, err = parseClock(w.To)
+		if err != nil {
+			return scheduleWindow{}, fmt.Errorf("invalid end time: %w", err)
+		}
+	}
+	return out, nil
+}
+
+// parseClock returns minutes since midnight for a given `HH:MM` time.
+func parseClock(in string) (int, error) {
+	t, err := time.Parse("15:04", in)
+	if err != nil {
+		return 0, fmt.Errorf("expected HH:MM format, got %q", in)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
@@ -0,0 +1,137 @@
+package config_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+func TestNotificationSchedule(t *testing.T) {
+	// given
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+
+	workingHours := config.NotificationSchedule{
+		Timezone: "Europe/Berlin",
+		Windows: []config.NotificationWindow{
+			{Days: []string{"mon", "tue", "wed", "thu", "Friday"}, From: "09:00", To: "17:00"},
+		},
+	}
+	nightShift := config.NotificationSchedule{
+		Windows: []config.NotificationWindow{
+			{Days: []string{"fri"}, From: "22:00", To: "06:00"},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		schedule config.NotificationSchedule
+		time     time.Time
+
+		expectedActive    bool
+		expectedNextStart time.Time
+	}{
+		{
+			name:              "Not configured",
+			time:              time.Date(2022, 10, 8, 3, 0, 0, 0, time.UTC),
+			expectedActive:    true,
+			expectedNextStart: time.Date(2022, 10, 8, 3, 0, 0, 0, time.UTC),
+		},
+		{
+			name:              "Working hours",
+			schedule:          workingHours,
+			time:              time.Date(2022, 10, 10, 10, 30, 0, 0, berlin),
+			expectedActive:    true,
+			expectedNextStart: time.Date(2022, 10, 10, 10, 30, 0, 0, berlin),
+		},
+		{
+			name:              "Before working hours",
+			schedule:          workingHours,
+			time:              time.Date(2022, 10, 10, 6, 30, 0, 0, time.UTC),
+			expectedNextStart: time.Date(2022, 10, 10, 9, 0, 0, 0, berlin),
+		},
+		{
+			name:              "Friday evening",
+			schedule:          workingHours,
+			time:              time.Date(2022, 10, 14, 17, 0, 0, 0, berlin),
+			expectedNextStart: time.Date(2022, 10, 17, 9, 0, 0, 0, berlin),
+		},
+		{
+			name:              "Overnight window on the next day",
+			schedule:          nightShift,
+			time:              time.Date(2022, 10, 15, 5, 59, 0, 0, time.UTC),
+			expectedActive:    true,
+			expectedNextStart: time.Date(2022, 10, 15, 5, 59, 0, 0, time.UTC),
+		},
+		{
+			name:              "After overnight window",
+			schedule:          nightShift,
+			time:              time.Date(2022, 10, 15, 6, 0, 0, 0, time.UTC),
+			expectedNextStart: time.Date(2022, 10, 21, 22, 0, 0, 0, time.UTC),
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// when
+			active := tc.schedule.IsActive(tc.time)
+			nextStart := tc.schedule.NextStart(tc.time)
+
+			// then
+			assert.Equal(t, tc.expectedActive, active)
+			assert.True(t, tc.expectedNextStart.Equal(nextStart), "expected %s, got %s", tc.expectedNextStart, nextStart)
+		})
+	}
+}
+
+func TestNotificationScheduleValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		schedule config.NotificationSchedule
+
+		expErrMsg string
+	}{
+		{
+			name: "Valid",
+			schedule: config.NotificationSchedule{
+				Timezone: "America/New_York",
+				Windows:  []config.NotificationWindow{{Days: []string{"sat"}, From: "10:00"}},
+			},
+		},
+		{
+			name:      "Invalid timezone",
+			schedule:  config.NotificationSchedule{Timezone: "Mars/Olympus_Mons"},
+			expErrMsg: `invalid timezone "Mars/Olympus_Mons": unknown time zone Mars/Olympus_Mons`,
+		},
+		{
+			name: "Invalid day",
+			schedule: config.NotificationSchedule{
+				Windows: []config.NotificationWindow{{Days: []string{"someday"}, From: "10:00"}},
+			},
+			expErrMsg: `windows[0]: invalid week day "someday"`,
+		},
+		{
+			name: "Invalid end time",
+			schedule: config.NotificationSchedule{
+				Windows: []config.NotificationWindow{{From: "10:00", To: "25:00"}},
+			},
+			expErrMsg: `windows[0]: invalid end time: expected HH:MM format, got "25:00"`,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// when
+			err := tc.schedule.Validate()
+
+			// then
+			if tc.expErrMsg != "" {
+				assert.EqualError(t, err, tc.expErrMsg)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
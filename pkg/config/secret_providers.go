@@ -0,0 +1,305 @@
+package config
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials/stscreds"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/secretsmanager"
+	"github.com/aws/aws-sdk-go/service/secretsmanager/secretsmanageriface"
+	"github.com/knadh/koanf"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+const (
+	defaultVaultKubernetesAuthMountPath = "kubernetes"
+	defaultServiceAccountTokenPath      = "/var/run/secrets/kubernetes.io/serviceaccount/token"
+	vaultRequestTimeout                 = 10 * time.Second
+)
+
+// secretResolver resolves the references to Kubernetes Secrets, Vault and AWS Secrets Manager.
+// The clients are created on first use, so only the referenced providers have to be configured.
+type secretResolver struct {
+	kubeconfig       string
+	defaultNamespace string
+	cfg              SecretsSettings
+
+	mu     sync.Mutex
+	k8sCli kubernetes.Interface
+	vault  *vaultClient
+	awsCli secretsmanageriface.SecretsManagerAPI
+}
+
+// newSecretResolver returns a resolver configured with the `settings` of the loaded configuration.
+func newSecretResolver(k *koanf.Koanf) (SecretResolver, error) {
+	var cfg SecretsSettings
+	err := k.UnmarshalWithConf("settings.secrets", &cfg, koanf.UnmarshalConf{Tag: "yaml"})
+	if err != nil {
+		return nil, fmt.Errorf("while loading secrets settings: %w", err)
+	}
+
+	return &secretResolver{
+		kubeconfig:       k.String("settings.kubeconfig"),
+		defaultNamespace: k.String("settings.systemConfigMap.namespace"),
+		cfg:              cfg,
+	}, nil
+}
+
+// Resolve returns the referenced value.
+func (r *secretResolver) Resolve(ctx context.Context, ref ValueFrom) (string, error) {
+	switch {
+	case ref.SecretKeyRef != nil:
+		return r.resolveSecretKeyRef(ctx, *ref.SecretKeyRef)
+	case ref.Vault != nil:
+		return r.resolveVault(ctx, *ref.Vault)
+	case ref.AWSSecretsManager != nil:
+		return r.resolveAWSSecretsManager(ctx, *ref.AWSSecretsManager)
+	}
+	return "", fmt.Errorf("reference doesn't have any source")
+}
+
+func (r *secretResolver) resolveSecretKeyRef(ctx context.Context, ref SecretKeySelector) (string, error) {
+	cli, err := r.getK8sCli()
+	if err != nil {
+		return "", err
+	}
+
+	ns := ref.Namespace
+	if ns == "" {
+		ns = r.defaultNamespace
+	}
+	secret, err := cli.CoreV1().Secrets(ns).Get(ctx, ref.Name, metav1.GetOptions{})
+	if err != nil {
+		return "", fmt.Errorf("while getting Secret %q: %w", fmt.Sprintf("%s/%s", ns, ref.Name), err)
+	}
+	value, found := secret.Data[ref.Key]
+	if !found {
+		return "", fmt.Errorf("key %q not found in Secret %q", ref.Key, fmt.Sprintf("%s/%s", ns, ref.Name))
+	}
+	return string(value), nil
+}
+
+func (r *secretResolver) resolveVault(ctx context.Context, ref VaultSecretRef) (string, error) {
+	r.mu.Lock()
+	if r.vault == nil {
+		r.vault = newVaultClient(r.cfg.Vault, &http.Client{Timeout: vaultRequestTimeout})
+	}
+	vault := r.vault
+	r.mu.Unlock()
+
+	data, err := vault.Read(ctx, ref.Path)
+	if err != nil {
+		return "", fmt.Errorf("while reading Vault secret %q: %w", ref.Path, err)
+	}
+	value, found := data[ref.Key]
+	if !found {
+		return "", fmt.Errorf("key %q not found in Vault secret %q", ref.Key, ref.Path)
+	}
+	str, ok := value.(string)
+	if !ok {
+		return "", fmt.Errorf("key %q of Vault secret %q is not a string", ref.Key, ref.Path)
+	}
+	return str, nil
+}
+
+func (r *secretResolver) resolveAWSSecretsManager(ctx context.Context, ref AWSSecretsManagerRef) (string, error) {
+	cli, err := r.getAWSCli()
+	if err != nil {
+		return "", err
+	}
+
+	out, err := cli.GetSecretValueWithContext(ctx, &secretsmanager.GetSecretValueInput{SecretId: aws.String(ref.SecretID)})
+	if err != nil {
+		return "", fmt.Errorf("while getting AWS secret %q: %w", ref.SecretID, err)
+	}
+	secret := aws.StringValue(out.SecretString)
+	if ref.Key == "" {
+		return secret, nil
+	}
+
+	var data map[string]interface{}
+	if err := json.Unmarshal([]byte(secret), &data); err != nil {
+		return "", fmt.Errorf("while decoding AWS secret %q as JSON: %w", ref.SecretID, err)
+	}
+	value, found := data[ref.Key]
+	if !found {
+		return "", fmt.Errorf("key %q not found in AWS secret %q", ref.Key, ref.SecretID)
+	}
+	return fmt.Sprint(value), nil
+}
+
+func (r *secretResolver) getK8sCli() (kubernetes.Interface, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.k8sCli != nil {
+		return r.k8sCli, nil
+	}
+
+	kubeConfig, err := clientcmd.BuildConfigFromFlags("", r.kubeconfig)
+	if err != nil {
+		return nil, fmt.Errorf("while loading k8s config: %w", err)
+	}
+	r.k8sCli, err = kubernetes.NewForConfig(kubeConfig)
+	if err != nil {
+		return nil, fmt.Errorf("while creating K8s clientset: %w", err)
+	}
+	return r.k8sCli, nil
+}
+
+func (r *secretResolver) getAWSCli() (secretsmanageriface.SecretsManagerAPI, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.awsCli != nil {
+		return r.awsCli, nil
+	}
+
+	sess, err := session.NewSession(&aws.Config{Region: aws.String(r.cfg.AWSSecretsManager.Region)})
+	if err != nil {
+		return nil, fmt.Errorf("while creating AWS session: %w", err)
+	}
+	awsCfg := &aws.Config{}
+	if r.cfg.AWSSecretsManager.RoleArn != "" {
+		awsCfg.Credentials = stscreds.NewCredentials(sess, r.cfg.AWSSecretsManager.RoleArn)
+	}
+	r.awsCli = secretsmanager.New(sess, awsCfg)
+	return r.awsCli, nil
+}
+
+// vaultClient reads secrets with the Vault HTTP API.
+type vaultClient struct {
+	cfg     VaultSettings
+	httpCli *http.Client
+
+	mu    sync.Mutex
+	token string
+}
+
+func newVaultClient(cfg VaultSettings, httpCli *http.Client) *vaultClient {
+	return &vaultClient{
+		cfg:     cfg,
+		httpCli: httpCli,
+		token:   cfg.Token,
+	}
+}
+
+// Read returns the data of a given secret. The KV version 2 data is unwrapped from its metadata.
+// If the token obtained with the Kubernetes auth method expired, it logs in again.
+func (c *vaultClient) Read(ctx context.Context, path string) (map[string]interface{}, error) {
+	token, err := c.getToken(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp struct {
+		Data map[string]interface{} `json:"data"`
+	}
+	status, err := c.do(ctx, http.MethodGet, "/v1/"+strings.TrimPrefix(path, "/"), token, nil, &resp)
+	if status == http.StatusForbidden && c.cfg.Token == "" {
+		token, err = c.getToken(ctx, true)
+		if err != nil {
+			return nil, err
+		}
+		status, err = c.do(ctx, http.MethodGet, "/v1/"+strings.TrimPrefix(path, "/"), token, nil, &resp)
+	}
+	if err != nil {
+		return nil, err
+	}
+	switch status {
+	case http.StatusForbidden:
+		return nil, fmt.Errorf("permission denied")
+	case http.StatusNotFound:
+		return nil, fmt.Errorf("secret not found")
+	}
+
+	if nested, ok := resp.Data["data"].(map[string]interface{}); ok {
+		if _, isKVv2 := resp.Data["metadata"]; isKVv2 {
+			return nested, nil
+		}
+	}
+	return resp.Data, nil
+}
+
+func (c *vaultClient) getToken(ctx context.Context, renew bool) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.token != "" && !renew {
+		return c.token, nil
+	}
+
+	tokenPath := c.cfg.KubernetesAuth.TokenPath
+	if tokenPath == "" {
+		tokenPath = defaultServiceAccountTokenPath
+	}
+	jwt, err := os.ReadFile(tokenPath)
+	if err != nil {
+		return "", fmt.Errorf("while reading Service Account token: %w", err)
+	}
+
+	mountPath := c.cfg.KubernetesAuth.MountPath
+	if mountPath == "" {
+		mountPath = defaultVaultKubernetesAuthMountPath
+	}
+	body, err := json.Marshal(map[string]string{
+		"role": c.cfg.KubernetesAuth.Role,
+		"jwt":  strings.TrimSpace(string(jwt)),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	var resp struct {
+		Auth struct {
+			ClientToken string `json:"client_token"`
+		} `json:"auth"`
+	}
+	_, err = c.do(ctx, http.MethodPost, fmt.Sprintf("/v1/auth/%s/login", strings.Trim(mountPath, "/")), "", body, &resp)
+	if err != nil {
+		return "", fmt.Errorf("while logging in to Vault: %w", err)
+	}
+	if resp.Auth.ClientToken == "" {
+		return "", fmt.Errorf("while logging in to Vault: empty client token")
+	}
+
+	c.token = resp.Auth.ClientToken
+	return c.token, nil
+}
+
+// do sends a request to Vault and decodes the successful response. The forbidden and not found statuses are returned without error.
+func (c *vaultClient) do(ctx context.Context, method, path, token string, body []byte, out interface{}) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, method, strings.TrimSuffix(c.cfg.Address, "/")+path, bytes.NewReader(body))
+	if err != nil {
+		return 0, fmt.Errorf("while creating request: %w", err)
+	}
+	if token != "" {
+		req.Header.Set("X-Vault-Token", token)
+	}
+
+	res, err := c.httpCli.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("while sending request: %w", err)
+	}
+	defer res.Body.Close()
+
+	switch {
+	case res.StatusCode == http.StatusForbidden, res.StatusCode == http.StatusNotFound:
+		return res.StatusCode, nil
+	case res.StatusCode >= http.StatusBadRequest:
+		return res.StatusCode, fmt.Errorf("unexpected status code %d", res.StatusCode)
+	}
+
+	if err := json.NewDecoder(res.Body).Decode(out); err != nil {
+		return res.StatusCode, fmt.Errorf("while decoding response: %w", err)
+	}
+	return res.StatusCode, nil
+}
@@ -0,0 +1,247 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/knadh/koanf"
+	"github.com/knadh/koanf/providers/confmap"
+	"github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	valueFromKey         = "valueFrom"
+	secretResolveTimeout = 30 * time.Second
+)
+
+// ValueFrom references a value stored outside the configuration, such as a token. It can be used in place of any string property:
+//
+//	botToken:
+//	  valueFrom:
+//	    secretKeyRef:
+//	      name: botkube-slack
+//	      key: botToken
+//
+// The values are resolved when the configuration is loaded. Exactly one source must be set.
+type ValueFrom struct {
+	SecretKeyRef      *SecretKeySelector    `yaml:"secretKeyRef"`
+	Vault             *VaultSecretRef       `yaml:"vault"`
+	AWSSecretsManager *AWSSecretsManagerRef `yaml:"awsSecretsManager"`
+}
+
+// SecretKeySelector selects a key of a Kubernetes Secret.
+type SecretKeySelector struct {
+	// Namespace of the Secret. If empty, the Namespace of the `settings.systemConfigMap` is used.
+	Namespace string `yaml:"namespace"`
+	Name      string `yaml:"name"`
+	Key       string `yaml:"key"`
+}
+
+// VaultSecretRef selects a key of a HashiCorp Vault secret.
+type VaultSecretRef struct {
+	// Path of the secret, e.g. `secret/data/botkube` for the KV version 2 engine mounted under `secret`.
+	Path string `yaml:"path"`
+	Key  string `yaml:"key"`
+}
+
+// AWSSecretsManagerRef selects an AWS Secrets Manager secret.
+type AWSSecretsManagerRef struct {
+	// SecretID is the name or ARN of the secret.
+	SecretID string `yaml:"secretID"`
+	// Key selects a property of the secret stored as a JSON object. If empty, the whole secret string is used.
+	Key string `yaml:"key"`
+}
+
+// SecretsSettings contains configuration of the external secret providers used by the `valueFrom` references.
+type SecretsSettings struct {
+	// RotationCheckInterval is the interval of checking if the referenced secrets changed. If they did, Botkube is restarted. Zero disables the check.
+	RotationCheckInterval time.Duration     `yaml:"rotationCheckInterval"`
+	Vault                 VaultSettings     `yaml:"vault"`
+	AWSSecretsManager     AWSSecretsManager `yaml:"awsSecretsManager"`
+}
+
+// VaultSettings contains configuration of the HashiCorp Vault client.
+type VaultSettings struct {
+	// Address of the Vault server, e.g. `https://vault.example.com:8200`.
+	Address string `yaml:"address"`
+	// Token is a static Vault token. If empty, Botkube logs in with its Service Account token using the Kubernetes auth method.
+	Token          string              `yaml:"token"`
+	KubernetesAuth VaultKubernetesAuth `yaml:"kubernetesAuth"`
+}
+
+// VaultKubernetesAuth contains configuration of the Vault Kubernetes auth method.
+type VaultKubernetesAuth struct {
+	Role string `yaml:"role"`
+	// MountPath of the auth method, e.g. `kubernetes`.
+	MountPath string `yaml:"mountPath"`
+	// TokenPath is the path of the Service Account token file.
+	TokenPath string `yaml:"tokenPath"`
+}
+
+// AWSSecretsManager contains configuration of the AWS Secrets Manager client.
+type AWSSecretsManager struct {
+	Region string `yaml:"region"`
+	// RoleArn is the IAM role assumed to read the secrets. It's not used if IAM Role for Service Account is configured.
+	RoleArn string `yaml:"roleArn"`
+}
+
+// SecretResolver resolves the values referenced in the configuration.
+type SecretResolver interface {
+	Resolve(ctx context.Context, ref ValueFrom) (string, error)
+}
+
+// ResolvedSecrets holds the values resolved from the `valueFrom` references, indexed by their property paths.
+type ResolvedSecrets struct {
+	resolver SecretResolver
+	refs     map[string]ValueFrom
+	values   map[string]string
+}
+
+// Rotated resolves the referenced values again and returns the paths of the properties which values changed.
+func (s *ResolvedSecrets) Rotated(ctx context.Context) ([]string, error) {
+	current, err := resolveValueFromRefs(ctx, s.resolver, s.refs)
+	if err != nil {
+		return nil, err
+	}
+
+	var out []string
+	for path, value := range current {
+		if s.values[path] != value {
+			out = append(out, path)
+		}
+	}
+	sort.Strings(out)
+	return out, nil
+}
+
+// WatchSecretsRotation checks periodically if the referenced secrets changed, and calls onRotate if they did.
+// It blocks until the context is cancelled.
+func WatchSecretsRotation(ctx context.Context, log logrus.FieldLogger, secrets *ResolvedSecrets, interval time.Duration, onRotate func(ctx context.Context) error) error {
+	log.Infof("Checking secrets rotation every %s...", interval)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+
+		rotated, err := secrets.Rotated(ctx)
+		if err != nil {
+			log.Errorf("while checking secrets rotation: %s", err.Error())
+			continue
+		}
+		if len(rotated) == 0 {
+			continue
+		}
+
+		log.WithField("paths", rotated).Info("Secrets rotated.")
+		if err := onRotate(ctx); err != nil {
+			log.Errorf("while applying rotated secrets: %s", err.Error())
+		}
+	}
+}
+
+// resolveSecrets replaces the `valueFrom` references in the loaded configuration with the resolved values.
+// It returns nil if the configuration doesn't contain any reference.
+func resolveSecrets(k *koanf.Koanf, newResolverFn func(k *koanf.Koanf) (SecretResolver, error)) (*ResolvedSecrets, error) {
+	refs := map[string]ValueFrom{}
+	if err := findValueFromRefs(nil, k.Raw(), refs); err != nil {
+		return nil, err
+	}
+	if len(refs) == 0 {
+		return nil, nil
+	}
+
+	resolver, err := newResolverFn(k)
+	if err != nil {
+		return nil, fmt.Errorf("while creating secret resolver: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), secretResolveTimeout)
+	defer cancel()
+	values, err := resolveValueFromRefs(ctx, resolver, refs)
+	if err != nil {
+		return nil, err
+	}
+
+	resolved := make(map[string]interface{}, len(values))
+	for path, value := range values {
+		resolved[path] = value
+	}
+	if err := k.Load(confmap.Provider(resolved, configDelimiter), nil); err != nil {
+		return nil, fmt.Errorf("while loading resolved secrets: %w", err)
+	}
+
+	return &ResolvedSecrets{
+		resolver: resolver,
+		refs:     refs,
+		values:   values,
+	}, nil
+}
+
+func resolveValueFromRefs(ctx context.Context, resolver SecretResolver, refs map[string]ValueFrom) (map[string]string, error) {
+	out := make(map[string]string, len(refs))
+	for path, ref := range refs {
+		value, err := resolver.Resolve(ctx, ref)
+		if err != nil {
+			return nil, fmt.Errorf("while resolving %q: %w", path, err)
+		}
+		out[path] = value
+	}
+	return out, nil
+}
+
+// findValueFromRefs collects the properties which contain only the `valueFrom` reference.
+func findValueFromRefs(path []string, in map[string]interface{}, out map[string]ValueFrom) error {
+	for key, val := range in {
+		nested, ok := val.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		propPath := append(append([]string{}, path...), key)
+
+		rawRef, isRef := nested[valueFromKey]
+		if !isRef || len(nested) != 1 {
+			if err := findValueFromRefs(propPath, nested, out); err != nil {
+				return err
+			}
+			continue
+		}
+
+		ref, err := decodeValueFrom(rawRef)
+		if err != nil {
+			return fmt.Errorf("while decoding %q reference: %w", strings.Join(propPath, configDelimiter), err)
+		}
+		out[strings.Join(propPath, configDelimiter)] = ref
+	}
+	return nil
+}
+
+func decodeValueFrom(in interface{}) (ValueFrom, error) {
+	raw, err := yaml.Marshal(in)
+	if err != nil {
+		return ValueFrom{}, err
+	}
+	var ref ValueFrom
+	if err := yaml.Unmarshal(raw, &ref); err != nil {
+		return ValueFrom{}, err
+	}
+
+	set := 0
+	for _, isSet := range []bool{ref.SecretKeyRef != nil, ref.Vault != nil, ref.AWSSecretsManager != nil} {
+		if isSet {
+			set++
+		}
+	}
+	if set != 1 {
+		return ValueFrom{}, fmt.Errorf("exactly one of secretKeyRef, vault and awsSecretsManager must be set")
+	}
+	return ref, nil
+}
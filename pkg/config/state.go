@@ -4,11 +4,13 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"gopkg.in/yaml.v3"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/util/retry"
 )
 
 // RuntimeState represents the runtime state.
@@ -38,14 +40,18 @@ type ChannelRuntimeState struct {
 }
 
 // ChannelRuntimeBindings represents the bindings for a channel.
+// Empty bindings are omitted, so they don't override the ones from the Botkube configuration.
 type ChannelRuntimeBindings struct {
-	Sources []string `yaml:"sources"`
+	Sources   []string `yaml:"sources,omitempty"`
+	Executors []string `yaml:"executors,omitempty"`
 }
 
 // StartupState represents the startup state.
 type StartupState struct {
 	Communications map[string]CommunicationsStartupState `yaml:"communications,omitempty"`
 	Filters        Filters                               `yaml:"filters,omitempty"`
+	Aliases        Aliases                               `yaml:"aliases,omitempty"`
+	Schedules      Schedules                             `yaml:"schedules,omitempty"`
 }
 
 // MarshalToMap marshals the startup state to a string map.
@@ -68,7 +74,8 @@ type ChannelStartupState struct {
 
 // NotificationStartupState represents the startup state for a notification.
 type NotificationStartupState struct {
-	Disabled bool `yaml:"disabled"`
+	Disabled     bool      `yaml:"disabled"`
+	SnoozedUntil time.Time `yaml:"snoozedUntil,omitempty"`
 }
 
 func marshalToMap(in interface{}, propertyName string) (map[string]string, error) {
@@ -101,8 +108,37 @@ type marshalableState interface {
 type configMapStorage[T marshalableState] struct {
 	k8sCli kubernetes.Interface
 	cfg    PartialPersistentConfig
+	locker StateLocker
+}
+
+// Modify reads the current state, applies a given mutation and writes it back.
+// The whole operation is guarded by the StateLocker and retried on conflicts, as the ConfigMap
+// can be modified by other Botkube instances or by Helm chart upgrades in the meantime.
+func (s *configMapStorage[T]) Modify(ctx context.Context, mutateFn func(state *T) error) error {
+	modifyFn := func(ctx context.Context) error {
+		return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+			state, cm, err := s.Get(ctx)
+			if err != nil {
+				return err
+			}
+
+			if err := mutateFn(&state); err != nil {
+				return err
+			}
+
+			return s.Update(ctx, cm, state)
+		})
+	}
+
+	if s.locker == nil {
+		return modifyFn(ctx)
+	}
+
+	return s.locker.WithLock(ctx, modifyFn)
 }
 
+// Get returns the state with the ConfigMap it's stored in. If the ConfigMap doesn't contain the state file yet,
+// an empty state is returned together with the ConfigMap, so the state can be written to it.
 func (s *configMapStorage[T]) Get(ctx context.Context) (T, *v1.ConfigMap, error) {
 	var emptyState T
 	cm, err := s.k8sCli.CoreV1().ConfigMaps(s.cfg.ConfigMap.Namespace).Get(ctx, s.cfg.ConfigMap.Name, metav1.GetOptions{})
@@ -113,7 +149,7 @@ func (s *configMapStorage[T]) Get(ctx context.Context) (T, *v1.ConfigMap, error)
 	var state T
 	runtimeStateStr, exists := cm.Data[s.cfg.FileName]
 	if !exists {
-		return emptyState, nil, nil
+		return emptyState, cm, nil
 	}
 
 	err = yaml.Unmarshal([]byte(runtimeStateStr), &state)
@@ -134,7 +170,8 @@ func (s *configMapStorage[T]) Update(ctx context.Context, originalCM *v1.ConfigM
 	cmToUpdate.Data = data
 	_, err = s.k8sCli.CoreV1().ConfigMaps(cmToUpdate.Namespace).Update(ctx, cmToUpdate, metav1.UpdateOptions{})
 	if err != nil {
-		return fmt.Errorf("while updating the ConfigMap with help details: %w", err)
+		// wrapped with %w, so the conflict error is still detected by retry.RetryOnConflict
+		return fmt.Errorf("while updating the ConfigMap with state: %w", err)
 	}
 
 	return nil
@@ -2,6 +2,8 @@ package config
 
 import (
 	"fmt"
+	"regexp"
+	"sort"
 	"strings"
 
 	"github.com/go-playground/locales/en"
@@ -9,19 +11,35 @@ import (
 	"github.com/go-playground/validator/v10"
 	en_translations "github.com/go-playground/validator/v10/translations/en"
 	"github.com/hashicorp/go-multierror"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/utils/strings/slices"
 
 	multierrx "github.com/kubeshop/botkube/pkg/multierror"
 )
 
 const (
-	nsIncludeTag      = "ns-include-regex"
-	invalidBindingTag = "invalid_binding"
-	appTokenPrefix    = "xapp-"
-	botTokenPrefix    = "xoxb-"
+	nsIncludeTag              = "ns-include-regex"
+	nsIncludeExcludeTag       = "ns-include-exclude"
+	nsExcludeOnlyTag          = "ns-exclude-only"
+	invalidBindingTag         = "invalid_binding"
+	duplicatedChannelAliasTag = "duplicated_channel_alias"
+	invalidRedactionRuleTag   = "invalid_redaction_rule"
+	invalidSelectorTag        = "invalid_selector"
+	invalidScheduleTag        = "invalid_schedule"
+	invalidExpressionTag      = "invalid_expression"
+	duplicatedChannelTag      = "duplicated_channel"
+	channelAliasCollisionTag  = "channel_alias_collision"
+	emptySourceBindingTag     = "empty_source_binding"
+	appTokenPrefix            = "xapp-"
+	botTokenPrefix            = "xoxb-"
 )
 
 var warnsOnlyTags = map[string]struct{}{
-	nsIncludeTag: {},
+	nsIncludeTag:             {},
+	duplicatedChannelTag:     {},
+	channelAliasCollisionTag: {},
+	emptySourceBindingTag:    {},
 }
 
 // ValidateResult holds the validation results.
@@ -48,9 +66,21 @@ func ValidateStruct(in any) (ValidateResult, error) {
 	if err := registerBindingsValidator(validate, trans); err != nil {
 		return ValidateResult{}, err
 	}
+	if err := registerChannelsValidator(validate, trans); err != nil {
+		return ValidateResult{}, err
+	}
 
 	validate.RegisterStructValidation(slackStructTokenValidator, Slack{})
 	validate.RegisterStructValidation(socketSlackStructTokenValidator, SocketSlack{})
+	validate.RegisterStructValidation(awsStructValidator, AWS{})
+	validate.RegisterStructValidation(azureEventHubStructValidator, AzureEventHub{})
+	validate.RegisterStructValidation(jiraStructValidator, Jira{})
+	validate.RegisterStructValidation(redactionRuleStructValidator, RedactionRule{})
+	validate.RegisterStructValidation(resourceStructValidator, Resource{})
+	validate.RegisterStructValidation(notificationScheduleStructValidator, NotificationSchedule{})
+	validate.RegisterStructValidation(kubernetesSourceStructValidator, KubernetesSource{})
+	validate.RegisterStructValidation(actionStructValidator, Action{})
+	validate.RegisterStructValidation(hubStructValidator, Hub{})
 
 	err := validate.Struct(in)
 	if err == nil {
@@ -88,6 +118,41 @@ func registerCustomTranslations(validate *validator.Validate, trans ut.Translato
 		return err
 	}
 
+	duplicatedAlias := func(ut ut.Translator) error {
+		return ut.Add(duplicatedChannelAliasTag, "{0} alias is already used in another Slack workspace", false)
+	}
+	if err := validate.RegisterTranslation(duplicatedChannelAliasTag, trans, duplicatedAlias, translateFunc); err != nil {
+		return err
+	}
+
+	invalidRedactionRule := func(ut ut.Translator) error {
+		return ut.Add(invalidRedactionRuleTag, "{0} {1}", false)
+	}
+	if err := validate.RegisterTranslation(invalidRedactionRuleTag, trans, invalidRedactionRule, translateFunc); err != nil {
+		return err
+	}
+
+	invalidSelector := func(ut ut.Translator) error {
+		return ut.Add(invalidSelectorTag, "{0} {1}", false)
+	}
+	if err := validate.RegisterTranslation(invalidSelectorTag, trans, invalidSelector, translateFunc); err != nil {
+		return err
+	}
+
+	invalidSchedule := func(ut ut.Translator) error {
+		return ut.Add(invalidScheduleTag, "{0} {1}", false)
+	}
+	if err := validate.RegisterTranslation(invalidScheduleTag, trans, invalidSchedule, translateFunc); err != nil {
+		return err
+	}
+
+	invalidExpression := func(ut ut.Translator) error {
+		return ut.Add(invalidExpressionTag, "{0} {1}", false)
+	}
+	if err := validate.RegisterTranslation(invalidExpressionTag, trans, invalidExpression, translateFunc); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -99,8 +164,21 @@ func registerNamespaceValidator(validate *validator.Validate, trans ut.Translato
 	registerFn := func(ut ut.Translator) error {
 		return ut.Add(nsIncludeTag, "{0} matches both all and exact namespaces", false)
 	}
+	if err := validate.RegisterTranslation(nsIncludeTag, trans, registerFn, translateFunc); err != nil {
+		return err
+	}
 
-	return validate.RegisterTranslation(nsIncludeTag, trans, registerFn, translateFunc)
+	includeExcludeFn := func(ut ut.Translator) error {
+		return ut.Add(nsIncludeExcludeTag, "{0} contains namespaces which are also included: {1}", false)
+	}
+	if err := validate.RegisterTranslation(nsIncludeExcludeTag, trans, includeExcludeFn, translateFunc); err != nil {
+		return err
+	}
+
+	excludeOnlyFn := func(ut ut.Translator) error {
+		return ut.Add(nsExcludeOnlyTag, "{0} is set, but Include is empty, so no namespace is allowed", false)
+	}
+	return validate.RegisterTranslation(nsExcludeOnlyTag, trans, excludeOnlyFn, translateFunc)
 }
 
 func registerBindingsValidator(validate *validator.Validate, trans ut.Translator) error {
@@ -111,8 +189,30 @@ func registerBindingsValidator(validate *validator.Validate, trans ut.Translator
 	registerFn := func(ut ut.Translator) error {
 		return ut.Add(invalidBindingTag, "'{0}' binding not defined in {1}", false)
 	}
+	if err := validate.RegisterTranslation(invalidBindingTag, trans, registerFn, translateFunc); err != nil {
+		return err
+	}
 
-	return validate.RegisterTranslation(invalidBindingTag, trans, registerFn, translateFunc)
+	emptySourceFn := func(ut ut.Translator) error {
+		return ut.Add(emptySourceBindingTag, "'{0}' source doesn't have any resources configured", false)
+	}
+	return validate.RegisterTranslation(emptySourceBindingTag, trans, emptySourceFn, translateFunc)
+}
+
+func registerChannelsValidator(validate *validator.Validate, trans ut.Translator) error {
+	validate.RegisterStructValidation(communicationsStructValidator, Communications{})
+
+	duplicatedChannelFn := func(ut ut.Translator) error {
+		return ut.Add(duplicatedChannelTag, "{0} binds the same channel as {1}", false)
+	}
+	if err := validate.RegisterTranslation(duplicatedChannelTag, trans, duplicatedChannelFn, translateFunc); err != nil {
+		return err
+	}
+
+	aliasCollisionFn := func(ut ut.Translator) error {
+		return ut.Add(channelAliasCollisionTag, "{0} alias is the same as the name of the channel bound under {1}", false)
+	}
+	return validate.RegisterTranslation(channelAliasCollisionTag, trans, aliasCollisionFn, translateFunc)
 }
 
 func slackStructTokenValidator(sl validator.StructLevel) {
@@ -140,22 +240,193 @@ func socketSlackStructTokenValidator(sl validator.StructLevel) {
 		return
 	}
 
-	if slack.AppToken == "" {
-		sl.ReportError(slack.AppToken, "AppToken", "AppToken", "required", "")
+	if slack.HasDefaultWorkspace() {
+		if len(slack.Channels) == 0 {
+			sl.ReportError(slack.Channels, "Channels", "Channels", "required", "")
+		}
+		validateSocketSlackTokens(sl, "", slack.BotToken, slack.AppToken)
+	}
+
+	aliases := map[string]struct{}{}
+	for alias := range slack.Channels {
+		aliases[alias] = struct{}{}
+	}
+	for idx, workspace := range slack.Workspaces {
+		fieldPrefix := fmt.Sprintf("Workspaces[%d].", idx)
+		validateSocketSlackTokens(sl, fieldPrefix, workspace.BotToken, workspace.AppToken)
+
+		for alias := range workspace.Channels {
+			if _, exists := aliases[alias]; exists {
+				field := fmt.Sprintf("%sChannels[%s]", fieldPrefix, alias)
+				sl.ReportError(workspace.Channels, field, field, duplicatedChannelAliasTag, "")
+			}
+			aliases[alias] = struct{}{}
+		}
+	}
+}
+
+func validateSocketSlackTokens(sl validator.StructLevel, fieldPrefix, botToken, appToken string) {
+	appTokenField, botTokenField := fieldPrefix+"AppToken", fieldPrefix+"BotToken"
+	if appToken == "" {
+		sl.ReportError(appToken, appTokenField, appTokenField, "required", "")
 	}
 
-	if slack.BotToken == "" {
-		sl.ReportError(slack.BotToken, "BotToken", "BotToken", "required", "")
+	if botToken == "" {
+		sl.ReportError(botToken, botTokenField, botTokenField, "required", "")
 	}
 
-	if !strings.HasPrefix(slack.BotToken, botTokenPrefix) {
+	if !strings.HasPrefix(botToken, botTokenPrefix) {
 		msg := fmt.Sprintf("must have the %s prefix. Learn more at https://botkube.io/docs/installation/socketslack/#obtain-bot-token", botTokenPrefix)
-		sl.ReportError(slack.BotToken, "BotToken", "BotToken", "invalid_slack_token", msg)
+		sl.ReportError(botToken, botTokenField, botTokenField, "invalid_slack_token", msg)
 	}
 
-	if !strings.HasPrefix(slack.AppToken, appTokenPrefix) {
+	if !strings.HasPrefix(appToken, appTokenPrefix) {
 		msg := fmt.Sprintf("must have the %s prefix. Learn more at https://botkube.io/docs/installation/socketslack/#generate-and-obtain-app-level-token", appTokenPrefix)
-		sl.ReportError(slack.AppToken, "AppToken", "AppToken", "invalid_slack_token", msg)
+		sl.ReportError(appToken, appTokenField, appTokenField, "invalid_slack_token", msg)
+	}
+}
+
+func awsStructValidator(sl validator.StructLevel) {
+	aws, ok := sl.Current().Interface().(AWS)
+	if !ok || !aws.Enabled {
+		return
+	}
+
+	// at least one destination is required
+	if aws.SNS.TopicARN == "" && aws.SQS.QueueURL == "" {
+		sl.ReportError(aws.SNS.TopicARN, "SNS.TopicARN", "SNS.TopicARN", "required", "")
+		sl.ReportError(aws.SQS.QueueURL, "SQS.QueueURL", "SQS.QueueURL", "required", "")
+	}
+}
+
+func azureEventHubStructValidator(sl validator.StructLevel) {
+	hub, ok := sl.Current().Interface().(AzureEventHub)
+	if !ok || !hub.Enabled || hub.ConnectionString != "" {
+		return
+	}
+
+	if !hub.ManagedIdentity.Enabled {
+		sl.ReportError(hub.ConnectionString, "ConnectionString", "ConnectionString", "required", "")
+		return
+	}
+	if hub.Namespace == "" {
+		sl.ReportError(hub.Namespace, "Namespace", "Namespace", "required", "")
+	}
+	if hub.EventHub == "" {
+		sl.ReportError(hub.EventHub, "EventHub", "EventHub", "required", "")
+	}
+}
+
+func hubStructValidator(sl validator.StructLevel) {
+	hub, ok := sl.Current().Interface().(Hub)
+	if !ok {
+		return
+	}
+
+	switch hub.Mode {
+	case HubAgentMode:
+		if hub.BearerToken == "" {
+			sl.ReportError(hub.BearerToken, "BearerToken", "BearerToken", "required", "")
+		}
+	case HubServerMode:
+		if len(hub.Agents) == 0 {
+			sl.ReportError(hub.Agents, "Agents", "Agents", "required", "")
+			return
+		}
+		for name, agent := range hub.Agents {
+			if agent.BearerToken == "" {
+				sl.ReportError(agent.BearerToken, "BearerToken", fmt.Sprintf("Agents[%s].BearerToken", name), "required", "")
+			}
+		}
+	}
+}
+
+func jiraStructValidator(sl validator.StructLevel) {
+	jira, ok := sl.Current().Interface().(Jira)
+	if !ok || !jira.Enabled || jira.Project != "" {
+		return
+	}
+
+	// without the default project, each route must specify its own one
+	if len(jira.Routes) == 0 {
+		sl.ReportError(jira.Project, "Project", "Project", "required", "")
+		return
+	}
+	for idx, route := range jira.Routes {
+		if route.Project != "" {
+			continue
+		}
+		field := fmt.Sprintf("Routes[%d].Project", idx)
+		sl.ReportError(route.Project, field, field, "required", "")
+	}
+}
+
+func redactionRuleStructValidator(sl validator.StructLevel) {
+	rule, ok := sl.Current().Interface().(RedactionRule)
+	if !ok {
+		return
+	}
+
+	if (rule.Pattern == "") == (rule.JSONPath == "") {
+		sl.ReportError(rule.Pattern, "Pattern", "Pattern", invalidRedactionRuleTag, "requires exactly one of pattern and jsonPath to be set")
+		return
+	}
+	if rule.Pattern == "" {
+		return
+	}
+	if _, err := regexp.Compile(rule.Pattern); err != nil {
+		sl.ReportError(rule.Pattern, "Pattern", "Pattern", invalidRedactionRuleTag, fmt.Sprintf("is not a valid regular expression: %s", err))
+	}
+}
+
+func resourceStructValidator(sl validator.StructLevel) {
+	res, ok := sl.Current().Interface().(Resource)
+	if !ok {
+		return
+	}
+
+	if res.LabelSelector != "" {
+		if _, err := labels.Parse(res.LabelSelector); err != nil {
+			sl.ReportError(res.LabelSelector, "LabelSelector", "LabelSelector", invalidSelectorTag, fmt.Sprintf("is not a valid label selector: %s", err))
+		}
+	}
+	if res.FieldSelector != "" {
+		if _, err := fields.ParseSelector(res.FieldSelector); err != nil {
+			sl.ReportError(res.FieldSelector, "FieldSelector", "FieldSelector", invalidSelectorTag, fmt.Sprintf("is not a valid field selector: %s", err))
+		}
+	}
+}
+
+func notificationScheduleStructValidator(sl validator.StructLevel) {
+	schedule, ok := sl.Current().Interface().(NotificationSchedule)
+	if !ok {
+		return
+	}
+
+	if err := schedule.Validate(); err != nil {
+		sl.ReportError(schedule.Windows, "Windows", "Windows", invalidScheduleTag, fmt.Sprintf("is not a valid schedule: %s", err))
+	}
+}
+
+func kubernetesSourceStructValidator(sl validator.StructLevel) {
+	source, ok := sl.Current().Interface().(KubernetesSource)
+	if !ok {
+		return
+	}
+
+	if _, err := source.CompileExpressions(); err != nil {
+		sl.ReportError(source.Expressions, "Expressions", "Expressions", invalidExpressionTag, fmt.Sprintf("contains invalid expression: %s", err))
+	}
+}
+
+func actionStructValidator(sl validator.StructLevel) {
+	action, ok := sl.Current().Interface().(Action)
+	if !ok {
+		return
+	}
+
+	if _, err := action.CompileCondition(); err != nil {
+		sl.ReportError(action.Condition, "Condition", "Condition", invalidExpressionTag, fmt.Sprintf("is not a valid expression: %s", err))
 	}
 }
 
@@ -165,6 +436,14 @@ func namespacesStructValidator(sl validator.StructLevel) {
 		return
 	}
 
+	if ns.LabelSelector != "" {
+		if _, err := labels.Parse(ns.LabelSelector); err != nil {
+			sl.ReportError(ns.LabelSelector, "LabelSelector", "LabelSelector", invalidSelectorTag, fmt.Sprintf("is not a valid label selector: %s", err))
+		}
+	}
+
+	validateNamespacesExclude(sl, ns)
+
 	if len(ns.Include) < 2 {
 		return
 	}
@@ -183,6 +462,45 @@ func namespacesStructValidator(sl validator.StructLevel) {
 	}
 }
 
+// validateNamespacesExclude reports the namespaces, or regular expressions, which are both included and excluded,
+// and the excluded namespaces without any namespace allowed by Include or LabelSelector.
+func validateNamespacesExclude(sl validator.StructLevel, ns Namespaces) {
+	exclude := nonEmptyTrimmed(ns.Exclude)
+	if len(exclude) == 0 {
+		return
+	}
+
+	include := nonEmptyTrimmed(ns.Include)
+	if len(include) == 0 {
+		if ns.LabelSelector == "" {
+			sl.ReportError(ns.Exclude, "Exclude", "Exclude", nsExcludeOnlyTag, "")
+		}
+		return
+	}
+
+	var conflicts []string
+	for _, name := range exclude {
+		if slices.Contains(include, name) && !slices.Contains(conflicts, name) {
+			conflicts = append(conflicts, name)
+		}
+	}
+	if len(conflicts) > 0 {
+		sl.ReportError(ns.Exclude, "Exclude", "Exclude", nsIncludeExcludeTag, strings.Join(conflicts, ", "))
+	}
+}
+
+func nonEmptyTrimmed(in []string) []string {
+	var out []string
+	for _, item := range in {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		out = append(out, item)
+	}
+	return out
+}
+
 func botBindingsStructValidator(sl validator.StructLevel) {
 	bindings, ok := sl.Current().Interface().(BotBindings)
 	if !ok {
@@ -194,6 +512,7 @@ func botBindingsStructValidator(sl validator.StructLevel) {
 	}
 	validateSourceBindings(sl, conf.Sources, bindings.Sources)
 	validateExecutorBindings(sl, conf.Executors, bindings.Executors)
+	validateSourceBindingsNotEmpty(sl, conf.Sources, bindings.Sources)
 }
 
 func actionBindingsStructValidator(sl validator.StructLevel) {
@@ -229,6 +548,142 @@ func validateSourceBindings(sl validator.StructLevel, sources map[string]Sources
 	}
 }
 
+// validateSourceBindingsNotEmpty reports the bound sources, which don't produce any events, as they don't have any resources or sub-sources enabled.
+func validateSourceBindingsNotEmpty(sl validator.StructLevel, sources map[string]Sources, bindings []string) {
+	for _, name := range bindings {
+		source, ok := sources[name]
+		if !ok || hasConfiguredResources(source) {
+			continue
+		}
+		sl.ReportError(bindings, name, name, emptySourceBindingTag, "")
+	}
+}
+
+func hasConfiguredResources(source Sources) bool {
+	if len(source.Kubernetes.Resources) > 0 {
+		return true
+	}
+
+	enabled := []bool{
+		source.Alertmanager.Enabled,
+		source.Audit.Enabled,
+		source.ArgoCD.Enabled,
+		source.Helm.Enabled,
+		source.CertManager.Enabled,
+		source.Jobs.Enabled,
+		source.Nodes.Enabled,
+		source.PodCrashes.Enabled,
+		source.Velero.Enabled,
+		source.Flux.Enabled,
+		source.PVCUsage.Enabled,
+		source.Trivy.Enabled,
+	}
+	for _, plugin := range source.Plugins {
+		enabled = append(enabled, plugin.Enabled)
+	}
+	for _, isEnabled := range enabled {
+		if isEnabled {
+			return true
+		}
+	}
+	return false
+}
+
+// boundChannels holds the channel identifiers of a given platform, indexed by alias.
+type boundChannels struct {
+	field    string
+	channels map[string]string
+}
+
+func newBoundChannels[T Identifiable](field string, in IdentifiableMap[T]) boundChannels {
+	out := boundChannels{field: field, channels: map[string]string{}}
+	for alias, channel := range in {
+		out.channels[alias] = channel.Identifier()
+	}
+	return out
+}
+
+func (b boundChannels) fieldFor(alias string) string {
+	return fmt.Sprintf("%s[%s]", b.field, alias)
+}
+
+// aliasForIdentifier returns the first alias, in alphabetical order, of a channel with a given identifier.
+func (b boundChannels) aliasForIdentifier(id string) (string, bool) {
+	for _, alias := range b.sortedAliases() {
+		if b.channels[alias] == id {
+			return alias, true
+		}
+	}
+	return "", false
+}
+
+func (b boundChannels) sortedAliases() []string {
+	out := make([]string, 0, len(b.channels))
+	for alias := range b.channels {
+		out = append(out, alias)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// communicationsStructValidator reports the same channel bound twice within a given platform, and the aliases which are the same as
+// the name of other channel in a given communication group. Source routes match channels by name, ID or alias, so such alias is ambiguous.
+func communicationsStructValidator(sl validator.StructLevel) {
+	comm, ok := sl.Current().Interface().(Communications)
+	if !ok {
+		return
+	}
+
+	var all []boundChannels
+	appendIfEnabled := func(enabled bool, channels boundChannels) {
+		if enabled {
+			all = append(all, channels)
+		}
+	}
+	appendIfEnabled(comm.Slack.Enabled, newBoundChannels("Slack.Channels", comm.Slack.Channels))
+	appendIfEnabled(comm.SocketSlack.Enabled, newBoundChannels("SocketSlack.Channels", comm.SocketSlack.Channels))
+	for idx, workspace := range comm.SocketSlack.Workspaces {
+		appendIfEnabled(comm.SocketSlack.Enabled, newBoundChannels(fmt.Sprintf("SocketSlack.Workspaces[%d].Channels", idx), workspace.Channels))
+	}
+	appendIfEnabled(comm.Mattermost.Enabled, newBoundChannels("Mattermost.Channels", comm.Mattermost.Channels))
+	appendIfEnabled(comm.RocketChat.Enabled, newBoundChannels("RocketChat.Channels", comm.RocketChat.Channels))
+	appendIfEnabled(comm.GoogleChat.Enabled, newBoundChannels("GoogleChat.Channels", comm.GoogleChat.Channels))
+	appendIfEnabled(comm.Webex.Enabled, newBoundChannels("Webex.Channels", comm.Webex.Channels))
+	appendIfEnabled(comm.Matrix.Enabled, newBoundChannels("Matrix.Channels", comm.Matrix.Channels))
+	appendIfEnabled(comm.Discord.Enabled, newBoundChannels("Discord.Channels", comm.Discord.Channels))
+	appendIfEnabled(comm.Loopback.Enabled, newBoundChannels("Loopback.Channels", comm.Loopback.Channels))
+
+	for _, platform := range all {
+		firstAliases := map[string]string{}
+		for _, alias := range platform.sortedAliases() {
+			id := platform.channels[alias]
+			if id == "" {
+				continue
+			}
+			if firstAlias, exists := firstAliases[id]; exists {
+				field := platform.fieldFor(alias)
+				sl.ReportError(platform.channels, field, field, duplicatedChannelTag, platform.fieldFor(firstAlias))
+				continue
+			}
+			firstAliases[id] = alias
+		}
+	}
+
+	for _, platform := range all {
+		for _, alias := range platform.sortedAliases() {
+			for _, other := range all {
+				otherAlias, found := other.aliasForIdentifier(alias)
+				if !found || (other.field == platform.field && platform.channels[alias] == alias) {
+					continue
+				}
+				field := platform.fieldFor(alias)
+				sl.ReportError(platform.channels, field, field, channelAliasCollisionTag, other.fieldFor(otherAlias))
+				break
+			}
+		}
+	}
+}
+
 func validateExecutorBindings(sl validator.StructLevel, executors map[string]Executors, bindings []string) {
 	for _, executor := range bindings {
 		if _, ok := executors[executor]; !ok {
@@ -0,0 +1,123 @@
+package containerlogs
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	coreV1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/events"
+	"github.com/kubeshop/botkube/pkg/redaction"
+)
+
+const (
+	// DefaultLines is used when the number of log lines is not configured.
+	DefaultLines = 20
+	// DefaultMaxBytes is used when the logs size limit is not configured.
+	DefaultMaxBytes = 8192
+)
+
+// Fetcher gets the last logs of the failing Pod containers.
+type Fetcher struct {
+	k8sCli kubernetes.Interface
+}
+
+// NewFetcher returns a new Fetcher instance.
+func NewFetcher(k8sCli kubernetes.Interface) *Fetcher {
+	return &Fetcher{k8sCli: k8sCli}
+}
+
+// ForEvent returns the last logs of the failing container of a Pod from a given event.
+// It returns nil, if the Pod doesn't have a failing container which produced any logs.
+func (f *Fetcher) ForEvent(ctx context.Context, event events.Event, cfg config.ContainerLogs) (*events.ContainerLogs, error) {
+	redactor, err := redaction.New(config.Redaction{Enabled: true, Rules: cfg.Redaction})
+	if err != nil {
+		return nil, fmt.Errorf("while creating logs redactor: %w", err)
+	}
+
+	pod, err := f.k8sCli.CoreV1().Pods(event.Namespace).Get(ctx, event.Name, metaV1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("while getting Pod %s/%s: %w", event.Namespace, event.Name, err)
+	}
+
+	container, previous, found := failingContainer(pod)
+	if !found {
+		return nil, nil
+	}
+
+	lines := int64(cfg.Lines)
+	if lines <= 0 {
+		lines = DefaultLines
+	}
+	raw, err := f.k8sCli.CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, &coreV1.PodLogOptions{
+		Container: container,
+		Previous:  previous,
+		TailLines: &lines,
+	}).DoRaw(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("while getting logs of container %q: %w", container, err)
+	}
+
+	content := strings.TrimSpace(string(raw))
+	if content == "" {
+		return nil, nil
+	}
+
+	maxBytes := cfg.MaxBytes
+	if maxBytes <= 0 {
+		maxBytes = DefaultMaxBytes
+	}
+	content, truncated := truncate(redactor.RedactString(content), maxBytes)
+
+	return &events.ContainerLogs{
+		Container: container,
+		Previous:  previous,
+		Content:   content,
+		Truncated: truncated,
+	}, nil
+}
+
+// failingContainer returns the name of the first failing container of a given Pod,
+// and whether its logs should be taken from the previous, terminated instance.
+func failingContainer(pod *coreV1.Pod) (string, bool, bool) {
+	var statuses []coreV1.ContainerStatus
+	statuses = append(statuses, pod.Status.InitContainerStatuses...)
+	statuses = append(statuses, pod.Status.ContainerStatuses...)
+	for _, status := range statuses {
+		if terminated := status.State.Terminated; terminated != nil {
+			if terminated.ExitCode != 0 {
+				return status.Name, false, true
+			}
+			continue
+		}
+
+		lastTerminated := status.LastTerminationState.Terminated
+		if !status.Ready && lastTerminated != nil && lastTerminated.ExitCode != 0 {
+			return status.Name, true, true
+		}
+	}
+	return "", false, false
+}
+
+// truncate drops the oldest lines to fit a given size limit. A single line exceeding the limit is cut from the beginning.
+func truncate(in string, maxBytes int) (string, bool) {
+	if len(in) <= maxBytes {
+		return in, false
+	}
+
+	start := len(in) - maxBytes
+	for start < len(in) && !utf8.RuneStart(in[start]) {
+		start++
+	}
+
+	out := in[start:]
+	if idx := strings.IndexByte(out, '\n'); idx != -1 && idx < len(out)-1 {
+		out = out[idx+1:]
+	}
+	return out, true
+}
@@ -0,0 +1,135 @@
+package containerlogs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	coreV1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/events"
+)
+
+func TestFetcherForEvent(t *testing.T) {
+	tests := []struct {
+		name     string
+		statuses []coreV1.ContainerStatus
+		cfg      config.ContainerLogs
+
+		expected *events.ContainerLogs
+	}{
+		{
+			name: "Crash looping container",
+			statuses: []coreV1.ContainerStatus{
+				fixStatus("sidecar", true, coreV1.ContainerState{Running: &coreV1.ContainerStateRunning{}}, coreV1.ContainerState{}),
+				fixStatus("app", false,
+					coreV1.ContainerState{Waiting: &coreV1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}},
+					coreV1.ContainerState{Terminated: &coreV1.ContainerStateTerminated{ExitCode: 1}},
+				),
+			},
+			// logs returned by the fake client are "fake logs"
+			expected: &events.ContainerLogs{Container: "app", Previous: true, Content: "fake logs"},
+		},
+		{
+			name: "Terminated container",
+			statuses: []coreV1.ContainerStatus{
+				fixStatus("app", false, coreV1.ContainerState{Terminated: &coreV1.ContainerStateTerminated{ExitCode: 2}}, coreV1.ContainerState{}),
+			},
+			cfg: config.ContainerLogs{
+				MaxBytes:  4,
+				Redaction: []config.RedactionRule{{Pattern: "fake"}},
+			},
+			expected: &events.ContainerLogs{Container: "app", Content: "logs", Truncated: true},
+		},
+		{
+			name: "Completed container",
+			statuses: []coreV1.ContainerStatus{
+				fixStatus("app", false, coreV1.ContainerState{Terminated: &coreV1.ContainerStateTerminated{ExitCode: 0}}, coreV1.ContainerState{}),
+			},
+		},
+		{
+			name: "Image pull failure",
+			statuses: []coreV1.ContainerStatus{
+				fixStatus("app", false, coreV1.ContainerState{Waiting: &coreV1.ContainerStateWaiting{Reason: "ImagePullBackOff"}}, coreV1.ContainerState{}),
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// given
+			pod := &coreV1.Pod{
+				ObjectMeta: metaV1.ObjectMeta{Name: "api-7d9f-x2b4c", Namespace: "prod"},
+				Status:     coreV1.PodStatus{ContainerStatuses: tc.statuses},
+			}
+			fetcher := NewFetcher(fake.NewSimpleClientset(pod))
+			event := events.Event{
+				TypeMeta:  metaV1.TypeMeta{Kind: "Pod"},
+				Name:      pod.Name,
+				Namespace: pod.Namespace,
+				Type:      config.ErrorEvent,
+			}
+
+			// when
+			logs, err := fetcher.ForEvent(context.Background(), event, tc.cfg)
+
+			// then
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, logs)
+		})
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	tests := []struct {
+		name     string
+		in       string
+		maxBytes int
+
+		expected          string
+		expectedTruncated bool
+	}{
+		{
+			name:     "Within limit",
+			in:       "first\nsecond",
+			maxBytes: 12,
+			expected: "first\nsecond",
+		},
+		{
+			name:              "Drops partial line",
+			in:                "first\nsecond\nthird",
+			maxBytes:          10,
+			expected:          "third",
+			expectedTruncated: true,
+		},
+		{
+			name:              "Long line",
+			in:                "zażółć",
+			maxBytes:          4,
+			expected:          "łć",
+			expectedTruncated: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// when
+			out, truncated := truncate(tc.in, tc.maxBytes)
+
+			// then
+			assert.Equal(t, tc.expected, out)
+			assert.Equal(t, tc.expectedTruncated, truncated)
+		})
+	}
+}
+
+func fixStatus(name string, ready bool, state, lastState coreV1.ContainerState) coreV1.ContainerStatus {
+	return coreV1.ContainerStatus{
+		Name:                 name,
+		Ready:                ready,
+		State:                state,
+		LastTerminationState: lastState,
+	}
+}
@@ -3,11 +3,15 @@ package controller
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/sirupsen/logrus"
 	"k8s.io/apimachinery/pkg/api/meta"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/dynamic/dynamicinformer"
@@ -16,10 +20,12 @@ import (
 	"github.com/kubeshop/botkube/internal/analytics"
 	"github.com/kubeshop/botkube/pkg/bot/interactive"
 	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/dedup"
 	"github.com/kubeshop/botkube/pkg/events"
+	"github.com/kubeshop/botkube/pkg/expression"
 	"github.com/kubeshop/botkube/pkg/filterengine"
-	"github.com/kubeshop/botkube/pkg/multierror"
 	"github.com/kubeshop/botkube/pkg/notifier"
+	"github.com/kubeshop/botkube/pkg/owner"
 	"github.com/kubeshop/botkube/pkg/recommendation"
 	"github.com/kubeshop/botkube/pkg/sources"
 	"github.com/kubeshop/botkube/pkg/utils"
@@ -56,6 +62,23 @@ type RecommendationFactory interface {
 type ActionProvider interface {
 	RenderedActionsForEvent(event events.Event, sourceBindings []string) ([]events.Action, error)
 	ExecuteEventAction(ctx context.Context, action events.Action) interactive.GenericMessage
+	AllowExecution(action events.Action) bool
+}
+
+// EventRedactor defines a redactor that masks sensitive data in events.
+type EventRedactor interface {
+	RedactEvent(event events.Event) events.Event
+}
+
+// ContainerLogsFetcher defines a fetcher that gets the logs of the failing Pod containers.
+type ContainerLogsFetcher interface {
+	ForEvent(ctx context.Context, event events.Event, cfg config.ContainerLogs) (*events.ContainerLogs, error)
+}
+
+// EventDeduplicator defines a deduplicator that suppresses repeated events.
+type EventDeduplicator interface {
+	Allow(event events.Event, sources []string) bool
+	Run(ctx context.Context, send dedup.SendFn)
 }
 
 // Controller watches Kubernetes resources and send events to notifiers.
@@ -65,16 +88,28 @@ type Controller struct {
 	startTime             time.Time
 	conf                  *config.Config
 	notifiers             []notifier.Notifier
+	dispatcher            *notifier.Dispatcher
 	recommFactory         RecommendationFactory
 	filterEngine          filterengine.FilterEngine
 	informersResyncPeriod time.Duration
 	sourcesRouter         *sources.Router
 	actionProvider        ActionProvider
+	redactor              EventRedactor
+	deduplicator          EventDeduplicator
+	logsFetcher           ContainerLogsFetcher
+	ownerResolver         *owner.Resolver
+	// expressions holds the compiled event expressions, indexed by the source binding name.
+	expressions map[string][]*expression.Program
+	// welcomeMessageDisabled and finalMessageDisabled are set when the controller is restarted on configuration reload.
+	welcomeMessageDisabled bool
+	finalMessageDisabled   atomic.Bool
 
 	dynamicCli dynamic.Interface
 
 	mapper                     meta.RESTMapper
 	dynamicKubeInformerFactory dynamicinformer.DynamicSharedInformerFactory
+	// filteredInformerFactories hold informer factories for resources watched with label or field selectors.
+	filteredInformerFactories map[metaV1.ListOptions]dynamicinformer.DynamicSharedInformerFactory
 }
 
 // New create a new Controller instance.
@@ -88,12 +123,21 @@ func New(log logrus.FieldLogger,
 	informersResyncPeriod time.Duration,
 	router *sources.Router,
 	actionProvider ActionProvider,
+	redactor EventRedactor,
+	deduplicator EventDeduplicator,
+	logsFetcher ContainerLogsFetcher,
 	reporter AnalyticsReporter,
-) *Controller {
+) (*Controller, error) {
+	expressions, err := compileExpressions(conf.Sources)
+	if err != nil {
+		return nil, err
+	}
+
 	return &Controller{
 		log:                   log,
 		conf:                  conf,
 		notifiers:             notifiers,
+		dispatcher:            notifier.NewDispatcher(log, reporter, notifiers, conf.Settings.NotifierTimeout),
 		recommFactory:         recommFactory,
 		filterEngine:          filterEngine,
 		dynamicCli:            dynamicCli,
@@ -101,27 +145,36 @@ func New(log logrus.FieldLogger,
 		informersResyncPeriod: informersResyncPeriod,
 		sourcesRouter:         router,
 		actionProvider:        actionProvider,
+		redactor:              redactor,
+		deduplicator:          deduplicator,
+		logsFetcher:           logsFetcher,
 		reporter:              reporter,
-	}
+		ownerResolver:         owner.NewResolver(dynamicCli, mapper),
+		expressions:           expressions,
+	}, nil
+}
+
+// DisableWelcomeMessage disables the message sent on start. It must be called before Start.
+func (c *Controller) DisableWelcomeMessage() {
+	c.welcomeMessageDisabled = true
+}
+
+// DisableFinalMessage disables the message sent on stop. It can be called while the controller is running.
+func (c *Controller) DisableFinalMessage() {
+	c.finalMessageDisabled.Store(true)
 }
 
 // Start creates new informer controllers to watch k8s resources
 func (c *Controller) Start(ctx context.Context) error {
 	c.log.Info("Starting controller...")
 	c.dynamicKubeInformerFactory = dynamicinformer.NewDynamicSharedInformerFactory(c.dynamicCli, c.informersResyncPeriod)
+	c.filteredInformerFactories = map[metaV1.ListOptions]dynamicinformer.DynamicSharedInformerFactory{}
 
 	err := c.sourcesRouter.RegisterInformers([]config.EventType{
 		config.CreateEvent,
 		config.UpdateEvent,
 		config.DeleteEvent,
-	}, func(resource string) (cache.SharedIndexInformer, error) {
-		gvr, err := c.parseResourceArg(resource)
-		if err != nil {
-			c.log.Infof("Unable to parse resource: %s to register with informer\n", resource)
-			return nil, err
-		}
-		return c.dynamicKubeInformerFactory.ForResource(gvr).Informer(), nil
-	})
+	}, c.informerForResource)
 	if err != nil {
 		c.log.WithFields(logrus.Fields{
 			"events": []config.EventType{
@@ -134,17 +187,7 @@ func (c *Controller) Start(ctx context.Context) error {
 		return err
 	}
 
-	err = c.sourcesRouter.MapWithEventsInformer(
-		config.ErrorEvent,
-		config.WarningEvent,
-		func(resource string) (cache.SharedIndexInformer, error) {
-			gvr, err := c.parseResourceArg(resource)
-			if err != nil {
-				c.log.Infof("Unable to parse resource: %s to register with informer\n", resource)
-				return nil, err
-			}
-			return c.dynamicKubeInformerFactory.ForResource(gvr).Informer(), nil
-		})
+	err = c.sourcesRouter.MapWithEventsInformer(config.ErrorEvent, config.WarningEvent, c.informerForResource)
 	if err != nil {
 		c.log.WithFields(logrus.Fields{
 			"srcEvent": config.ErrorEvent,
@@ -154,6 +197,14 @@ func (c *Controller) Start(ctx context.Context) error {
 		return err
 	}
 
+	err = c.sourcesRouter.RegisterNamespaceInformer(c.informerForResource)
+	if err != nil {
+		c.log.WithFields(logrus.Fields{
+			"error": err.Error(),
+		}).Errorf("Could not register namespace informer.")
+		return err
+	}
+
 	c.sourcesRouter.HandleEvent(
 		ctx,
 		config.CreateEvent,
@@ -208,19 +259,34 @@ func (c *Controller) Start(ctx context.Context) error {
 			}
 		})
 
-	c.log.Info("Sending welcome message...")
-	err = notifier.SendPlaintextMessage(ctx, c.notifiers, fmt.Sprintf(controllerStartMsg, c.conf.Settings.ClusterName))
-	if err != nil {
-		return fmt.Errorf("while sending first message: %w", err)
+	go func() {
+		defer analytics.ReportPanicIfOccurs(c.log, c.reporter)
+		c.deduplicator.Run(ctx, c.dispatchEvent)
+	}()
+
+	if !c.welcomeMessageDisabled {
+		c.log.Info("Sending welcome message...")
+		err = notifier.SendPlaintextMessage(ctx, c.notifiers, fmt.Sprintf(controllerStartMsg, c.conf.Settings.ClusterName))
+		if err != nil {
+			return fmt.Errorf("while sending first message: %w", err)
+		}
 	}
 
 	c.startTime = time.Now()
 
 	stopCh := ctx.Done()
 	c.dynamicKubeInformerFactory.Start(stopCh)
+	for _, factory := range c.filteredInformerFactories {
+		factory.Start(stopCh)
+	}
 
 	<-stopCh
 
+	if c.finalMessageDisabled.Load() {
+		c.log.Info("Shutdown requested.")
+		return nil
+	}
+
 	c.log.Info("Shutdown requested. Sending final message...")
 	finalMsgCtx, cancelFn := context.WithTimeout(context.Background(), finalMessageTimeout)
 	defer cancelFn()
@@ -232,12 +298,13 @@ func (c *Controller) Start(ctx context.Context) error {
 	return nil
 }
 
-func (c *Controller) handleEvent(ctx context.Context, obj interface{}, resource string, eventType config.EventType, sources []string, updateDiffs []string) {
+// handleEvent processes a given object event and returns true if it was dispatched to the notifiers.
+func (c *Controller) handleEvent(ctx context.Context, obj interface{}, resource string, eventType config.EventType, sources []string, updateDiffs []string) bool {
 	// Filter namespaces
 	objectMeta, err := utils.GetObjectMetaData(ctx, c.dynamicCli, c.mapper, obj)
 	if err != nil {
 		c.log.Errorf("while getting object metadata: %s", err.Error())
-		return
+		return false
 	}
 
 	c.log.Debugf("Processing %s to %s/%v in %s namespace", eventType, resource, objectMeta.Name, objectMeta.Namespace)
@@ -246,13 +313,26 @@ func (c *Controller) handleEvent(ctx context.Context, obj interface{}, resource
 	event, err := events.New(objectMeta, obj, eventType, resource, c.conf.Settings.ClusterName)
 	if err != nil {
 		c.log.Errorf("while creating new event: %s", err.Error())
-		return
+		return false
 	}
 
 	// Skip older events
 	if !event.TimeStamp.IsZero() && event.TimeStamp.Before(c.startTime) {
 		c.log.Debug("Skipping older events")
-		return
+		return false
+	}
+
+	event.Owner, err = c.ownerResolver.TopLevelOwnerForEvent(ctx, event)
+	if err != nil {
+		c.log.Errorf("while resolving owner of %s/%s: %s", resource, event.Name, err.Error())
+		// continue processing event without the owner
+	}
+
+	if tpl, found := c.eventTemplate(resource, sources); found {
+		if err := event.RenderTemplate(tpl); err != nil {
+			c.log.Errorf("while rendering %s event template: %s", resource, err.Error())
+			// continue processing event with the default title and messages
+		}
 	}
 
 	event.Actions, err = c.actionProvider.RenderedActionsForEvent(event, sources)
@@ -276,15 +356,21 @@ func (c *Controller) handleEvent(ctx context.Context, obj interface{}, resource
 	}
 
 	// Filter events
-	event = c.filterEngine.Run(ctx, event)
+	event = c.filterEngine.Run(ctx, event, sources)
 	if event.Skip {
 		c.log.Debugf("Skipping event: %#v", event)
-		return
+		return false
 	}
 
 	if len(event.Kind) <= 0 {
 		c.log.Warn("sendEvent received event with Kind nil. Hence skipping.")
-		return
+		return false
+	}
+
+	sources = c.sourcesMatchingExpressions(event, sources)
+	if len(sources) == 0 {
+		c.log.Debugf("Skipping event as it doesn't match expressions of any source: %#v", event)
+		return false
 	}
 
 	recRunner, recCfg := c.recommFactory.NewForSources(c.conf.Sources, sources)
@@ -295,46 +381,216 @@ func (c *Controller) handleEvent(ctx context.Context, obj interface{}, resource
 
 	if recommendation.ShouldIgnoreEvent(recCfg, c.conf.Sources, sources, event) {
 		c.log.Debugf("Skipping event as it is related to recommendation informers and doesn't have any recommendations: %#v", event)
+		return false
+	}
+
+	return c.sendEvent(ctx, event, sources)
+}
+
+// eventTemplate returns the event template configured for a given resource.
+// Resource templates take precedence over the source ones. If multiple source bindings configure it,
+// the first one in alphabetical order is used.
+func (c *Controller) eventTemplate(resource string, sources []string) (config.EventTemplate, bool) {
+	names := make([]string, len(sources))
+	copy(names, sources)
+	sort.Strings(names)
+
+	for _, name := range names {
+		for _, res := range c.conf.Sources[name].Kubernetes.Resources {
+			if res.Type == resource && res.Template.IsConfigured() {
+				return res.Template, true
+			}
+		}
+	}
+	for _, name := range names {
+		tpl := c.conf.Sources[name].Kubernetes.Template
+		if tpl.IsConfigured() {
+			return tpl, true
+		}
+	}
+	return config.EventTemplate{}, false
+}
+
+// renderEventBody renders the user-defined notification body for a given event.
+func (c *Controller) renderEventBody(event *events.Event, sources []string) {
+	tpl, found := c.eventTemplate(event.Resource, sources)
+	if !found {
 		return
 	}
 
+	if err := event.RenderBody(tpl); err != nil {
+		c.log.Errorf("while rendering %s event body template: %s", event.Resource, err.Error())
+		// continue processing event with the default layout
+	}
+}
+
+// containerLogsConfig returns the container logs configuration for a given event.
+// If multiple source bindings enable it, the first one in alphabetical order is used.
+func (c *Controller) containerLogsConfig(event events.Event, sources []string) (config.ContainerLogs, bool) {
+	if event.Type != config.ErrorEvent || event.Kind != "Pod" || event.Logs != nil {
+		return config.ContainerLogs{}, false
+	}
+
+	names := make([]string, len(sources))
+	copy(names, sources)
+	sort.Strings(names)
+
+	for _, name := range names {
+		cfg := c.conf.Sources[name].Kubernetes.ContainerLogs
+		if cfg.Enabled {
+			return cfg, true
+		}
+	}
+	return config.ContainerLogs{}, false
+}
+
+// attachContainerLogs attaches the last logs of the failing container to the Pod error events.
+func (c *Controller) attachContainerLogs(ctx context.Context, event *events.Event, sources []string) {
+	cfg, enabled := c.containerLogsConfig(*event, sources)
+	if !enabled {
+		return
+	}
+
+	logs, err := c.logsFetcher.ForEvent(ctx, *event, cfg)
+	if err != nil {
+		c.log.Errorf("while getting container logs of %s/%s: %s", event.Namespace, event.Name, err.Error())
+		// continue processing event without the logs
+		return
+	}
+	event.Logs = logs
+}
+
+// levelRoutes returns the channels routed for a given event level, indexed by the source binding name.
+func (c *Controller) levelRoutes(level config.Level, sources []string) map[string][]string {
+	out := map[string][]string{}
+	for _, name := range sources {
+		channels := c.conf.Sources[name].Route.ChannelsForLevel(level)
+		if len(channels) == 0 {
+			continue
+		}
+		out[name] = channels
+	}
+
+	if len(out) == 0 {
+		return nil
+	}
+	return out
+}
+
+// HandleObject processes a given object as if it was received by the informer of a given resource, and returns true
+// if the event was dispatched to the notifiers. It's used to inject objects which are not watched, such as the load test
+// synthetic objects, into the same routing, filtering and sending path.
+func (c *Controller) HandleObject(ctx context.Context, obj *unstructured.Unstructured, resource string, eventType config.EventType) (bool, error) {
+	sources, err := c.sourcesRouter.SourcesForObject(ctx, resource, eventType, obj)
+	if err != nil {
+		return false, fmt.Errorf("while getting sources for %s: %w", resource, err)
+	}
+	if len(sources) == 0 {
+		return false, nil
+	}
+
+	return c.handleEvent(ctx, obj, resource, eventType, sources, nil), nil
+}
+
+// HandleExternalEvent sends an event received from a source outside the Kubernetes cluster, such as Alertmanager.
+// Such events are routed to given source bindings directly, without the Kubernetes-specific filtering.
+func (c *Controller) HandleExternalEvent(ctx context.Context, event events.Event, sources []string) {
+	var err error
+	event.Actions, err = c.actionProvider.RenderedActionsForEvent(event, sources)
+	if err != nil {
+		c.log.Errorf("while getting rendered actions for event: %s", err.Error())
+		// continue processing event
+	}
+
+	c.sendEvent(ctx, event, sources)
+}
+
+// sendEvent dispatches a given event, unless it's a repeated one. It returns true if the event was dispatched.
+func (c *Controller) sendEvent(ctx context.Context, event events.Event, sources []string) bool {
+	if !c.deduplicator.Allow(event, sources) {
+		c.log.Debugf("Skipping repeated event, it will be included in the summary: %#v", event)
+		return false
+	}
+
+	c.dispatchEvent(ctx, event, sources)
+	return true
+}
+
+// dispatchEvent sends an event to notifiers and executes its actions.
+func (c *Controller) dispatchEvent(ctx context.Context, event events.Event, sources []string) {
+	event.Routes = c.levelRoutes(event.Level, sources)
+	c.attachContainerLogs(ctx, &event, sources)
+
+	// Mask sensitive data before the event leaves the cluster
+	event = c.redactor.RedactEvent(event)
+	// Render the body from the redacted event, so the template doesn't reveal the masked values
+	c.renderEventBody(&event, sources)
+
 	// Send event over notifiers
 	anonymousEvent := analytics.AnonymizedEventDetailsFrom(event)
-	for _, n := range c.notifiers {
-		go func(n notifier.Notifier) {
-			defer analytics.ReportPanicIfOccurs(c.log, c.reporter)
+	go func() {
+		defer analytics.ReportPanicIfOccurs(c.log, c.reporter)
 
-			err := n.SendEvent(ctx, event, sources)
-			if err != nil {
-				reportErr := c.reporter.ReportHandledEventError(n.Type(), n.IntegrationName(), anonymousEvent, err)
-				if reportErr != nil {
-					err = multierror.Append(err, fmt.Errorf("while reporting analytics: %w", reportErr))
+		err := c.dispatcher.SendEvent(ctx, event, sources, func(n notifier.Notifier, err error) {
+			if err == nil {
+				if reportErr := c.reporter.ReportHandledEventSuccess(n.Type(), n.IntegrationName(), anonymousEvent); reportErr != nil {
+					c.log.Errorf("while reporting analytics: %s", reportErr.Error())
 				}
-
-				c.log.Errorf("while sending event: %s", err.Error())
+				return
 			}
 
-			reportErr := c.reporter.ReportHandledEventSuccess(n.Type(), n.IntegrationName(), anonymousEvent)
-			if reportErr != nil {
-				c.log.Errorf("while reporting analytics: %w", err)
+			if reportErr := c.reporter.ReportHandledEventError(n.Type(), n.IntegrationName(), anonymousEvent, err); reportErr != nil {
+				c.log.Errorf("while reporting analytics: %s", reportErr.Error())
 			}
-		}(n)
-	}
+		})
+		if err != nil {
+			c.log.Errorf("while sending event: %s", err.Error())
+		}
+	}()
 
 	// execute actions
 	for _, action := range event.Actions {
-		c.log.Infof("Executing action %q (command: %q)...", action.DisplayName, action.Command)
-		genericMsg := c.actionProvider.ExecuteEventAction(ctx, action)
-		for _, n := range c.notifiers {
-			go func(n notifier.Notifier) {
-				defer analytics.ReportPanicIfOccurs(c.log, c.reporter)
-				err := n.SendGenericMessage(ctx, genericMsg, sources)
-				if err != nil {
-					c.log.Errorf("while sending event: %s", err.Error())
-				}
-			}(n)
+		if !c.actionProvider.AllowExecution(action) {
+			c.log.Debugf("Skipping action %q, as it is in cooldown", action.DisplayName)
+			continue
 		}
+
+		c.log.Infof("Executing action %q (command: %q)...", action.DisplayName, action.Command)
+		// actions are executed asynchronously, as they can wait for approval
+		go func(action events.Action) {
+			defer analytics.ReportPanicIfOccurs(c.log, c.reporter)
+
+			genericMsg := c.actionProvider.ExecuteEventAction(ctx, action)
+			err := c.dispatcher.SendGenericMessage(ctx, genericMsg, sources)
+			if err != nil {
+				c.log.Errorf("while sending action result: %s", err.Error())
+			}
+		}(action)
+	}
+}
+
+// informerForResource returns a shared informer for a given resource. Objects are filtered with the list options selectors.
+func (c *Controller) informerForResource(resource string, opts metaV1.ListOptions) (cache.SharedIndexInformer, error) {
+	gvr, err := c.parseResourceArg(resource)
+	if err != nil {
+		c.log.Infof("Unable to parse resource: %s to register with informer\n", resource)
+		return nil, err
+	}
+
+	if opts.LabelSelector == "" && opts.FieldSelector == "" {
+		return c.dynamicKubeInformerFactory.ForResource(gvr).Informer(), nil
+	}
+
+	key := metaV1.ListOptions{LabelSelector: opts.LabelSelector, FieldSelector: opts.FieldSelector}
+	factory, found := c.filteredInformerFactories[key]
+	if !found {
+		factory = dynamicinformer.NewFilteredDynamicSharedInformerFactory(c.dynamicCli, c.informersResyncPeriod, metaV1.NamespaceAll, func(options *metaV1.ListOptions) {
+			options.LabelSelector = key.LabelSelector
+			options.FieldSelector = key.FieldSelector
+		})
+		c.filteredInformerFactories[key] = factory
 	}
+	return factory.ForResource(gvr).Informer(), nil
 }
 
 func (c *Controller) parseResourceArg(arg string) (schema.GroupVersionResource, error) {
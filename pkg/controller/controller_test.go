@@ -3,9 +3,15 @@ package controller
 import (
 	"testing"
 
+	logtest "github.com/sirupsen/logrus/hooks/test"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/events"
 )
 
 // TODO: Refactor these tests as a part of https://github.com/kubeshop/botkube/issues/589
@@ -71,3 +77,158 @@ func TestController_strToGVR(t *testing.T) {
 		})
 	}
 }
+
+func TestController_levelRoutes(t *testing.T) {
+	// given
+	c := Controller{
+		conf: &config.Config{
+			Sources: map[string]config.Sources{
+				"k8s-events": {
+					Route: config.SourceRoute{
+						Critical: []string{"#prod-incidents"},
+						Error:    []string{"#prod-incidents", "#oncall"},
+					},
+				},
+				"k8s-recommendations": {},
+			},
+		},
+	}
+	sources := []string{"k8s-events", "k8s-recommendations"}
+
+	// when
+	critical := c.levelRoutes(config.Critical, sources)
+	errRoutes := c.levelRoutes(config.Error, sources)
+	info := c.levelRoutes(config.Info, sources)
+
+	// then
+	assert.Equal(t, map[string][]string{"k8s-events": {"#prod-incidents"}}, critical)
+	assert.Equal(t, map[string][]string{"k8s-events": {"#prod-incidents", "#oncall"}}, errRoutes)
+	assert.Nil(t, info)
+}
+
+func TestController_eventTemplate(t *testing.T) {
+	// given
+	podTemplate := config.EventTemplate{Body: `{{ .Event.Name }} is {{ .Event.Type }}d`}
+	sourceTemplate := config.EventTemplate{Body: `{{ .Event.Kind }} {{ .Event.Name }}`}
+	c := Controller{
+		conf: &config.Config{
+			Sources: map[string]config.Sources{
+				"k8s-all-events": {
+					Kubernetes: config.KubernetesSource{
+						Template: sourceTemplate,
+					},
+				},
+				"k8s-pod-events": {
+					Kubernetes: config.KubernetesSource{
+						Resources: []config.Resource{{Type: "v1/pods", Template: podTemplate}},
+					},
+				},
+				"k8s-recommendations": {},
+			},
+		},
+	}
+
+	// when
+	podTpl, podFound := c.eventTemplate("v1/pods", []string{"k8s-all-events", "k8s-pod-events"})
+	svcTpl, svcFound := c.eventTemplate("v1/services", []string{"k8s-all-events", "k8s-pod-events"})
+	_, notFound := c.eventTemplate("v1/pods", []string{"k8s-recommendations"})
+
+	// then
+	assert.True(t, podFound)
+	assert.Equal(t, podTemplate, podTpl)
+	assert.True(t, svcFound)
+	assert.Equal(t, sourceTemplate, svcTpl)
+	assert.False(t, notFound)
+}
+
+func TestController_containerLogsConfig(t *testing.T) {
+	// given
+	c := Controller{
+		conf: &config.Config{
+			Sources: map[string]config.Sources{
+				"k8s-events": {
+					Kubernetes: config.KubernetesSource{
+						ContainerLogs: config.ContainerLogs{Enabled: true, Lines: 50},
+					},
+				},
+				"k8s-err-events": {
+					Kubernetes: config.KubernetesSource{
+						ContainerLogs: config.ContainerLogs{Enabled: true, Lines: 10},
+					},
+				},
+				"k8s-recommendations": {},
+			},
+		},
+	}
+	podErr := events.Event{TypeMeta: metaV1.TypeMeta{Kind: "Pod"}, Type: config.ErrorEvent}
+	deploymentErr := events.Event{TypeMeta: metaV1.TypeMeta{Kind: "Deployment"}, Type: config.ErrorEvent}
+	podCreate := events.Event{TypeMeta: metaV1.TypeMeta{Kind: "Pod"}, Type: config.CreateEvent}
+
+	// when
+	cfg, enabled := c.containerLogsConfig(podErr, []string{"k8s-recommendations", "k8s-events", "k8s-err-events"})
+	_, disabledForSource := c.containerLogsConfig(podErr, []string{"k8s-recommendations"})
+	_, disabledForKind := c.containerLogsConfig(deploymentErr, []string{"k8s-events"})
+	_, disabledForType := c.containerLogsConfig(podCreate, []string{"k8s-events"})
+
+	// then
+	assert.True(t, enabled)
+	assert.Equal(t, 10, cfg.Lines)
+	assert.False(t, disabledForSource)
+	assert.False(t, disabledForKind)
+	assert.False(t, disabledForType)
+}
+
+func TestController_sourcesMatchingExpressions(t *testing.T) {
+	// given
+	logger, _ := logtest.NewNullLogger()
+	sources := map[string]config.Sources{
+		"k8s-err-events": {
+			Kubernetes: config.KubernetesSource{
+				Expressions: []string{`event.reason == "Unhealthy" && event.count > 3`},
+			},
+		},
+		"k8s-labeled-events": {
+			Kubernetes: config.KubernetesSource{
+				Expressions: []string{`object.metadata.labels.team == "payments"`},
+			},
+		},
+		"k8s-all-events": {},
+	}
+	expressions, err := compileExpressions(sources)
+	require.NoError(t, err)
+	c := Controller{
+		log:         logger,
+		expressions: expressions,
+	}
+	event := events.Event{
+		TypeMeta: metaV1.TypeMeta{Kind: "Pod"},
+		Reason:   "Unhealthy",
+		Count:    5,
+		Object: &unstructured.Unstructured{Object: map[string]interface{}{
+			"metadata": map[string]interface{}{"name": "payments-api-7d9f-x2b4c"},
+		}},
+	}
+
+	// when
+	matching := c.sourcesMatchingExpressions(event, []string{"k8s-all-events", "k8s-err-events", "k8s-labeled-events"})
+
+	// then
+	assert.Equal(t, []string{"k8s-all-events", "k8s-err-events"}, matching)
+}
+
+func TestCompileExpressionsRejectsInvalidExpression(t *testing.T) {
+	// given
+	sources := map[string]config.Sources{
+		"k8s-invalid-events": {
+			Kubernetes: config.KubernetesSource{
+				Expressions: []string{`evnt.count > 3`},
+			},
+		},
+	}
+
+	// when
+	_, err := compileExpressions(sources)
+
+	// then
+	assert.EqualError(t, err, `while compiling expressions of source "k8s-invalid-events": expressions[0]: undeclared reference to 'evnt' (in container '') at position 0`)
+}
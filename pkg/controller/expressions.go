@@ -0,0 +1,69 @@
+package controller
+
+import (
+	"fmt"
+
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/events"
+	"github.com/kubeshop/botkube/pkg/expression"
+)
+
+// compileExpressions returns the compiled event expressions, indexed by the source binding name.
+// The expressions are checked during the config validation, so an error means the configuration wasn't validated.
+func compileExpressions(sources map[string]config.Sources) (map[string][]*expression.Program, error) {
+	out := map[string][]*expression.Program{}
+	for name, source := range sources {
+		if len(source.Kubernetes.Expressions) == 0 {
+			continue
+		}
+
+		programs, err := source.Kubernetes.CompileExpressions()
+		if err != nil {
+			return nil, fmt.Errorf("while compiling expressions of source %q: %w", name, err)
+		}
+		out[name] = programs
+	}
+	return out, nil
+}
+
+// sourcesMatchingExpressions returns the source bindings whose expressions all evaluate to true for a given event.
+// An expression which fails to evaluate, for example because of a missing object field, is treated as false.
+func (c *Controller) sourcesMatchingExpressions(event events.Event, sources []string) []string {
+	if len(c.expressions) == 0 {
+		return sources
+	}
+
+	var (
+		out  []string
+		vars map[string]interface{}
+	)
+	for _, name := range sources {
+		programs, found := c.expressions[name]
+		if !found {
+			out = append(out, name)
+			continue
+		}
+
+		if vars == nil {
+			vars = events.ExpressionVars(event)
+		}
+		if c.matchesAll(programs, vars, name) {
+			out = append(out, name)
+		}
+	}
+	return out
+}
+
+func (c *Controller) matchesAll(programs []*expression.Program, vars map[string]interface{}, source string) bool {
+	for _, program := range programs {
+		matches, err := program.EvalBool(vars)
+		if err != nil {
+			c.log.Debugf("while evaluating expression %q of source %q: %s", program.String(), source, err.Error())
+			return false
+		}
+		if !matches {
+			return false
+		}
+	}
+	return true
+}
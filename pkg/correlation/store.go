@@ -0,0 +1,90 @@
+package correlation
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kubeshop/botkube/pkg/events"
+)
+
+// DefaultTTL is used when the correlation TTL is not configured.
+const DefaultTTL = time.Hour
+
+// Store keeps the IDs of messages which started a thread for related events, indexed by channel and event fingerprint.
+// A thread expires when there are no related events within the TTL.
+type Store struct {
+	ttl time.Duration
+	now func() time.Time
+
+	mu      sync.Mutex
+	threads map[string]thread
+}
+
+type thread struct {
+	messageID string
+	expiresAt time.Time
+}
+
+// NewStore returns a new Store instance. If a given TTL is not positive, DefaultTTL is used.
+func NewStore(ttl time.Duration) *Store {
+	if ttl <= 0 {
+		ttl = DefaultTTL
+	}
+	return &Store{
+		ttl:     ttl,
+		now:     time.Now,
+		threads: map[string]thread{},
+	}
+}
+
+// Fingerprint identifies related events. Events are related if they have the same type and concern the same object,
+// or the objects with the same top-level owner, such as Pods of a given Deployment.
+func Fingerprint(event events.Event) string {
+	kind, name := event.Kind, event.Name
+	if event.Owner != nil {
+		kind, name = event.Owner.Kind, event.Owner.Name
+	}
+
+	return strings.Join([]string{
+		event.Cluster,
+		event.Namespace,
+		kind,
+		name,
+		string(event.Type),
+	}, "/")
+}
+
+// Get returns the ID of the message which started a thread for events related to a given one.
+func (s *Store) Get(channel string, event events.Event) (string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	item, found := s.threads[key(channel, event)]
+	if !found || !s.now().Before(item.expiresAt) {
+		return "", false
+	}
+	return item.messageID, true
+}
+
+// Set stores the ID of the message which started a thread for events related to a given one, and extends its TTL.
+func (s *Store) Set(channel string, event events.Event, messageID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	for k, item := range s.threads {
+		if !now.Before(item.expiresAt) {
+			delete(s.threads, k)
+		}
+	}
+
+	s.threads[key(channel, event)] = thread{
+		messageID: messageID,
+		expiresAt: now.Add(s.ttl),
+	}
+}
+
+func key(channel string, event events.Event) string {
+	return channel + "/" + Fingerprint(event)
+}
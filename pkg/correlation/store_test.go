@@ -0,0 +1,70 @@
+package correlation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/events"
+)
+
+func TestFingerprint(t *testing.T) {
+	// given
+	owner := &events.Owner{Kind: "Deployment", Name: "payments-api"}
+	deployment := fixEvent("Deployment", "payments-api", config.ErrorEvent, nil)
+	firstPod := fixEvent("Pod", "payments-api-7d9f-x2b4c", config.ErrorEvent, owner)
+	secondPod := fixEvent("Pod", "payments-api-7d9f-k8s2a", config.ErrorEvent, owner)
+	createdPod := fixEvent("Pod", "payments-api-7d9f-k8s2a", config.CreateEvent, owner)
+
+	// then
+	assert.Equal(t, Fingerprint(deployment), Fingerprint(firstPod))
+	assert.Equal(t, Fingerprint(firstPod), Fingerprint(secondPod))
+	assert.NotEqual(t, Fingerprint(secondPod), Fingerprint(createdPod))
+}
+
+func TestStore(t *testing.T) {
+	// given
+	now := time.Date(2022, 10, 10, 12, 0, 0, 0, time.UTC)
+	store := NewStore(time.Hour)
+	store.now = func() time.Time { return now }
+
+	event := fixEvent("Deployment", "payments-api", config.ErrorEvent, nil)
+
+	// when
+	_, foundBefore := store.Get("alerts", event)
+	store.Set("alerts", event, "1665403200.000100")
+	messageID, found := store.Get("alerts", event)
+	_, foundInOtherChannel := store.Get("dev", event)
+
+	// then
+	assert.False(t, foundBefore)
+	assert.True(t, found)
+	assert.Equal(t, "1665403200.000100", messageID)
+	assert.False(t, foundInOtherChannel)
+
+	// when
+	now = now.Add(50 * time.Minute)
+	store.Set("alerts", event, messageID)
+	now = now.Add(50 * time.Minute)
+	_, foundAfterExtend := store.Get("alerts", event)
+	now = now.Add(10 * time.Minute)
+	_, foundAfterTTL := store.Get("alerts", event)
+
+	// then
+	assert.True(t, foundAfterExtend)
+	assert.False(t, foundAfterTTL)
+}
+
+func fixEvent(kind, name string, eventType config.EventType, owner *events.Owner) events.Event {
+	return events.Event{
+		TypeMeta:  metaV1.TypeMeta{Kind: kind},
+		Name:      name,
+		Namespace: "prod",
+		Cluster:   "dev",
+		Type:      eventType,
+		Owner:     owner,
+	}
+}
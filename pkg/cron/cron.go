@@ -0,0 +1,207 @@
+package cron
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxSearchYears limits the search for the next activation time of expressions which never match, e.g. `0 0 30 2 *`.
+const maxSearchYears = 5
+
+type field struct {
+	name     string
+	min, max int
+	names    map[string]int
+}
+
+var (
+	minuteField = field{name: "minute", min: 0, max: 59}
+	hourField   = field{name: "hour", min: 0, max: 23}
+	domField    = field{name: "day of month", min: 1, max: 31}
+	monthField  = field{name: "month", min: 1, max: 12, names: map[string]int{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6, "jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}}
+	// Sunday is both 0 and 7.
+	dowField = field{name: "day of week", min: 0, max: 7, names: map[string]int{
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	}}
+)
+
+var macros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// Schedule is a parsed cron expression.
+type Schedule struct {
+	minute, hour, dom, month, dow uint64
+	// domStar and dowStar are true if the day of month or day of week is not restricted.
+	// If both are restricted, the schedule matches a day which matches any of them, the same as in crontab.
+	domStar, dowStar bool
+}
+
+// Parse parses a standard cron expression with five fields: minute, hour, day of month, month and day of week.
+// Fields support lists, ranges, steps, and month and day of week names, e.g. `*/15 9-17 * * MON-FRI`.
+// The `@yearly`, `@monthly`, `@weekly`, `@daily` and `@hourly` macros are supported as well.
+func Parse(expr string) (*Schedule, error) {
+	expr = strings.TrimSpace(expr)
+	if macro, found := macros[strings.ToLower(expr)]; found {
+		expr = macro
+	}
+
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("expected 5 fields, got %d", len(fields))
+	}
+
+	var (
+		out  Schedule
+		errs []error
+	)
+	parseInto := func(dst *uint64, expr string, f field) {
+		bits, err := parseField(expr, f)
+		if err != nil {
+			errs = append(errs, err)
+			return
+		}
+		*dst = bits
+	}
+	parseInto(&out.minute, fields[0], minuteField)
+	parseInto(&out.hour, fields[1], hourField)
+	parseInto(&out.dom, fields[2], domField)
+	parseInto(&out.month, fields[3], monthField)
+	parseInto(&out.dow, fields[4], dowField)
+	if len(errs) > 0 {
+		return nil, errs[0]
+	}
+
+	if out.dow&(1<<7) != 0 {
+		out.dow |= 1 << 0
+	}
+	out.domStar = fields[2] == "*" || fields[2] == "?"
+	out.dowStar = fields[4] == "*" || fields[4] == "?"
+	return &out, nil
+}
+
+// Next returns the first activation time after a given time, in the time location. It returns zero time if there is no activation
+// within the next few years.
+func (s *Schedule) Next(after time.Time) time.Time {
+	t := after.Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(maxSearchYears, 0, 0)
+
+	for t.Before(limit) {
+		if !has(s.month, int(t.Month())) {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if !s.matchesDay(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if !has(s.hour, t.Hour()) {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+			continue
+		}
+		if !has(s.minute, t.Minute()) {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+func (s *Schedule) matchesDay(t time.Time) bool {
+	domMatch := has(s.dom, t.Day())
+	dowMatch := has(s.dow, int(t.Weekday()))
+	if s.domStar || s.dowStar {
+		return domMatch && dowMatch
+	}
+	return domMatch || dowMatch
+}
+
+func parseField(expr string, f field) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(expr, ",") {
+		partBits, err := parseRange(part, f)
+		if err != nil {
+			return 0, fmt.Errorf("invalid %s %q: %w", f.name, part, err)
+		}
+		bits |= partBits
+	}
+	return bits, nil
+}
+
+// parseRange parses a single list item: `*`, a value, a range `a-b`, optionally with a step, e.g. `*/5` or `1-10/2`.
+func parseRange(expr string, f field) (uint64, error) {
+	rangeExpr, stepExpr, hasStep := strings.Cut(expr, "/")
+
+	step := 1
+	if hasStep {
+		var err error
+		step, err = strconv.Atoi(stepExpr)
+		if err != nil || step <= 0 {
+			return 0, fmt.Errorf("step must be a positive number")
+		}
+	}
+
+	var start, end int
+	switch {
+	case rangeExpr == "*" || rangeExpr == "?":
+		start, end = f.min, f.max
+	case strings.Contains(rangeExpr, "-"):
+		startExpr, endExpr, _ := strings.Cut(rangeExpr, "-")
+		var err error
+		if start, err = parseValue(startExpr, f); err != nil {
+			return 0, err
+		}
+		if end, err = parseValue(endExpr, f); err != nil {
+			return 0, err
+		}
+		if start > end {
+			return 0, fmt.Errorf("range start must not be greater than its end")
+		}
+	default:
+		value, err := parseValue(rangeExpr, f)
+		if err != nil {
+			return 0, err
+		}
+		start, end = value, value
+		if hasStep {
+			// `5/10` means from 5 to the maximum value, every 10
+			end = f.max
+		}
+	}
+
+	var bits uint64
+	for value := start; value <= end; value += step {
+		bits |= 1 << uint(value)
+	}
+	return bits, nil
+}
+
+func parseValue(expr string, f field) (int, error) {
+	if value, found := f.names[strings.ToLower(expr)]; found {
+		return value, nil
+	}
+
+	value, err := strconv.Atoi(expr)
+	if err != nil {
+		return 0, fmt.Errorf("%q is not a number", expr)
+	}
+	if value < f.min || value > f.max {
+		return 0, fmt.Errorf("value must be between %d and %d", f.min, f.max)
+	}
+	return value, nil
+}
+
+func has(bits uint64, value int) bool {
+	return bits&(1<<uint(value)) != 0
+}
@@ -0,0 +1,120 @@
+package cron
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScheduleNext(t *testing.T) {
+	// given
+	// Monday
+	now := time.Date(2022, 10, 3, 9, 30, 15, 0, time.UTC)
+	tests := []struct {
+		name string
+
+		expr    string
+		expNext time.Time
+	}{
+		{
+			name:    "Every minute",
+			expr:    "* * * * *",
+			expNext: time.Date(2022, 10, 3, 9, 31, 0, 0, time.UTC),
+		},
+		{
+			name:    "Every 15 minutes",
+			expr:    "*/15 * * * *",
+			expNext: time.Date(2022, 10, 3, 9, 45, 0, 0, time.UTC),
+		},
+		{
+			name:    "Weekdays at 9 AM",
+			expr:    "0 9 * * 1-5",
+			expNext: time.Date(2022, 10, 4, 9, 0, 0, 0, time.UTC),
+		},
+		{
+			name:    "Weekend with names",
+			expr:    "0 9 * * SAT,SUN",
+			expNext: time.Date(2022, 10, 8, 9, 0, 0, 0, time.UTC),
+		},
+		{
+			name:    "Sunday as 7",
+			expr:    "0 0 * * 7",
+			expNext: time.Date(2022, 10, 9, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:    "Day of month or day of week",
+			expr:    "0 12 15 * FRI",
+			expNext: time.Date(2022, 10, 7, 12, 0, 0, 0, time.UTC),
+		},
+		{
+			name:    "Month with name and step from value",
+			expr:    "30 6 1 jan/6 *",
+			expNext: time.Date(2023, 1, 1, 6, 30, 0, 0, time.UTC),
+		},
+		{
+			name:    "Leap day",
+			expr:    "0 0 29 2 *",
+			expNext: time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:    "Macro",
+			expr:    "@daily",
+			expNext: time.Date(2022, 10, 4, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "Never",
+			expr: "0 0 30 2 *",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			schedule, err := Parse(tc.expr)
+			require.NoError(t, err)
+
+			// when
+			next := schedule.Next(now)
+
+			// then
+			assert.Equal(t, tc.expNext, next)
+		})
+	}
+}
+
+func TestScheduleNextInLocation(t *testing.T) {
+	// given
+	loc := time.FixedZone("UTC+2", 2*60*60)
+	schedule, err := Parse("0 9 * * *")
+	require.NoError(t, err)
+
+	// when
+	next := schedule.Next(time.Date(2022, 10, 3, 8, 0, 0, 0, time.UTC).In(loc))
+
+	// then
+	assert.Equal(t, time.Date(2022, 10, 4, 7, 0, 0, 0, time.UTC), next.UTC())
+}
+
+func TestParseErrors(t *testing.T) {
+	// given
+	tests := []struct {
+		expr   string
+		expErr string
+	}{
+		{expr: "* * * *", expErr: "expected 5 fields, got 4"},
+		{expr: "60 * * * *", expErr: `invalid minute "60": value must be between 0 and 59`},
+		{expr: "* * * foo *", expErr: `invalid month "foo": "foo" is not a number`},
+		{expr: "*/0 * * * *", expErr: `invalid minute "*/0": step must be a positive number`},
+		{expr: "* 10-2 * * *", expErr: `invalid hour "10-2": range start must not be greater than its end`},
+		{expr: "* * 0 * *", expErr: `invalid day of month "0": value must be between 1 and 31`},
+	}
+	for _, tc := range tests {
+		t.Run(tc.expr, func(t *testing.T) {
+			// when
+			_, err := Parse(tc.expr)
+
+			// then
+			assert.EqualError(t, err, tc.expErr)
+		})
+	}
+}
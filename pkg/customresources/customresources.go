@@ -0,0 +1,147 @@
+package customresources
+
+import (
+	"context"
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+const (
+	group   = "botkube.io"
+	version = "v1alpha1"
+)
+
+var (
+	// SourceGVR describes the BotkubeSource custom resource.
+	SourceGVR = schema.GroupVersionResource{Group: group, Version: version, Resource: "botkubesources"}
+	// ExecutorGVR describes the BotkubeExecutor custom resource.
+	ExecutorGVR = schema.GroupVersionResource{Group: group, Version: version, Resource: "botkubeexecutors"}
+	// ActionGVR describes the BotkubeAction custom resource.
+	ActionGVR = schema.GroupVersionResource{Group: group, Version: version, Resource: "botkubeactions"}
+	// ChannelBindingGVR describes the BotkubeChannelBinding custom resource.
+	ChannelBindingGVR = schema.GroupVersionResource{Group: group, Version: version, Resource: "botkubechannelbindings"}
+)
+
+// GVRs contains all Botkube custom resources.
+var GVRs = []schema.GroupVersionResource{SourceGVR, ExecutorGVR, ActionGVR, ChannelBindingGVR}
+
+// Resource is a single Botkube custom resource with a decoded spec.
+type Resource[T any] struct {
+	Namespace string
+	Name      string
+	Spec      T
+}
+
+// Key returns the name under which the resource is merged into the configuration.
+func (r Resource[T]) Key() string {
+	return Key(r.Namespace, r.Name)
+}
+
+// Key returns the name of the custom resource from a given Namespace in the configuration.
+func Key(namespace, name string) string {
+	return fmt.Sprintf("%s/%s", namespace, name)
+}
+
+// ChannelBindingSpec binds the sources and executors to an existing channel.
+type ChannelBindingSpec struct {
+	// CommunicationGroup is the name of the communication group, e.g. `default-group`.
+	CommunicationGroup string `yaml:"communicationGroup"`
+	// Platform is the communication platform, e.g. `slack`.
+	Platform config.CommPlatformIntegration `yaml:"platform"`
+	// Channel is the alias of the channel under the platform `channels` property. It's not used for MS Teams.
+	Channel string `yaml:"channel"`
+	// Sources contains the names of the BotkubeSources from the same Namespace.
+	Sources []string `yaml:"sources"`
+	// Executors contains the names of the BotkubeExecutors from the same Namespace.
+	Executors []string `yaml:"executors"`
+}
+
+// Resources contains all Botkube custom resources.
+type Resources struct {
+	Sources         []Resource[config.Sources]
+	Executors       []Resource[config.Executors]
+	Actions         []Resource[config.Action]
+	ChannelBindings []Resource[ChannelBindingSpec]
+
+	// Invalid contains the errors of the resources which cannot be decoded. Such resources are skipped.
+	Invalid []error
+}
+
+// Load lists the Botkube custom resources from all Namespaces.
+// The kinds which are not installed in the cluster and the resources which cannot be decoded are skipped.
+func Load(ctx context.Context, dynamicCli dynamic.Interface) (Resources, error) {
+	var (
+		out Resources
+		err error
+	)
+
+	out.Sources, err = list[config.Sources](ctx, dynamicCli, SourceGVR, &out.Invalid)
+	if err != nil {
+		return Resources{}, err
+	}
+	out.Executors, err = list[config.Executors](ctx, dynamicCli, ExecutorGVR, &out.Invalid)
+	if err != nil {
+		return Resources{}, err
+	}
+	out.Actions, err = list[config.Action](ctx, dynamicCli, ActionGVR, &out.Invalid)
+	if err != nil {
+		return Resources{}, err
+	}
+	out.ChannelBindings, err = list[ChannelBindingSpec](ctx, dynamicCli, ChannelBindingGVR, &out.Invalid)
+	if err != nil {
+		return Resources{}, err
+	}
+
+	return out, nil
+}
+
+func list[T any](ctx context.Context, dynamicCli dynamic.Interface, gvr schema.GroupVersionResource, invalid *[]error) ([]Resource[T], error) {
+	items, err := dynamicCli.Resource(gvr).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("while listing %s: %w", gvr.Resource, err)
+	}
+
+	var out []Resource[T]
+	for i := range items.Items {
+		res, err := decode[T](&items.Items[i])
+		if err != nil {
+			*invalid = append(*invalid, fmt.Errorf("while decoding %s %s: %w", gvr.Resource, res.Key(), err))
+			continue
+		}
+		out = append(out, res)
+	}
+	return out, nil
+}
+
+// decode converts the spec of a given object to the configuration type. It uses YAML to follow the configuration file format.
+func decode[T any](obj *unstructured.Unstructured) (Resource[T], error) {
+	res := Resource[T]{
+		Namespace: obj.GetNamespace(),
+		Name:      obj.GetName(),
+	}
+
+	spec, found, err := unstructured.NestedFieldNoCopy(obj.Object, "spec")
+	if err != nil || !found {
+		return res, err
+	}
+
+	raw, err := yaml.Marshal(spec)
+	if err != nil {
+		return res, fmt.Errorf("while marshaling spec: %w", err)
+	}
+	if err := yaml.Unmarshal(raw, &res.Spec); err != nil {
+		return res, fmt.Errorf("while unmarshaling spec: %w", err)
+	}
+	return res, nil
+}
@@ -0,0 +1,257 @@
+package customresources
+
+import (
+	"fmt"
+	"reflect"
+
+	"k8s.io/utils/strings/slices"
+
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/multierror"
+)
+
+// Merge merges the custom resources into a given configuration. The resources are added under the `<namespace>/<name>` keys.
+//
+// The sources and executors are limited to the Namespace of the resource, and all resources can reference only
+// the resources from their own Namespace. Channel bindings extend the bindings of the existing channels.
+// The resources which don't meet these rules or don't pass the configuration validation are skipped
+// and reported in the returned error.
+func Merge(cfg *config.Config, res Resources) error {
+	issues := multierror.New()
+	issues = multierror.Append(issues, res.Invalid...)
+
+	if cfg.Sources == nil {
+		cfg.Sources = map[string]config.Sources{}
+	}
+	sources := map[string]struct{}{}
+	for _, src := range res.Sources {
+		if err := mergeSource(cfg, src); err != nil {
+			issues = multierror.Append(issues, fmt.Errorf("while merging BotkubeSource %s: %w", src.Key(), err))
+			continue
+		}
+		sources[src.Key()] = struct{}{}
+	}
+
+	if cfg.Executors == nil {
+		cfg.Executors = map[string]config.Executors{}
+	}
+	executors := map[string]struct{}{}
+	for _, exec := range res.Executors {
+		if err := mergeExecutor(cfg, exec); err != nil {
+			issues = multierror.Append(issues, fmt.Errorf("while merging BotkubeExecutor %s: %w", exec.Key(), err))
+			continue
+		}
+		executors[exec.Key()] = struct{}{}
+	}
+
+	if cfg.Actions == nil {
+		cfg.Actions = config.Actions{}
+	}
+	for _, act := range res.Actions {
+		if err := mergeAction(cfg, act, sources, executors); err != nil {
+			issues = multierror.Append(issues, fmt.Errorf("while merging BotkubeAction %s: %w", act.Key(), err))
+		}
+	}
+
+	for _, binding := range res.ChannelBindings {
+		if err := mergeChannelBinding(cfg, binding, sources, executors); err != nil {
+			issues = multierror.Append(issues, fmt.Errorf("while merging BotkubeChannelBinding %s: %w", binding.Key(), err))
+		}
+	}
+
+	return issues.ErrorOrNil()
+}
+
+func mergeSource(cfg *config.Config, src Resource[config.Sources]) error {
+	if _, exists := cfg.Sources[src.Key()]; exists {
+		return fmt.Errorf("source %q already exists", src.Key())
+	}
+
+	spec := config.Sources{
+		DisplayName: src.Spec.DisplayName,
+		Kubernetes:  src.Spec.Kubernetes,
+	}
+	if !reflect.DeepEqual(spec, src.Spec) {
+		return fmt.Errorf("only the kubernetes source is supported")
+	}
+
+	namespaces := namespaceOnly(src.Namespace)
+	spec.Kubernetes.Namespaces = namespaces
+	resources := make([]config.Resource, 0, len(spec.Kubernetes.Resources))
+	for _, r := range spec.Kubernetes.Resources {
+		r.Namespaces = namespaces
+		resources = append(resources, r)
+	}
+	spec.Kubernetes.Resources = resources
+
+	if err := validateSpec(spec); err != nil {
+		return err
+	}
+
+	cfg.Sources[src.Key()] = spec
+	return nil
+}
+
+func mergeExecutor(cfg *config.Config, exec Resource[config.Executors]) error {
+	if _, exists := cfg.Executors[exec.Key()]; exists {
+		return fmt.Errorf("executor %q already exists", exec.Key())
+	}
+
+	spec := config.Executors{
+		Kubectl: exec.Spec.Kubectl,
+	}
+	if !reflect.DeepEqual(spec, exec.Spec) {
+		return fmt.Errorf("only the kubectl executor is supported")
+	}
+
+	spec.Kubectl.Namespaces = namespaceOnly(exec.Namespace)
+	spec.Kubectl.DefaultNamespace = exec.Namespace
+
+	if err := validateSpec(spec); err != nil {
+		return err
+	}
+
+	cfg.Executors[exec.Key()] = spec
+	return nil
+}
+
+func mergeAction(cfg *config.Config, act Resource[config.Action], sources, executors map[string]struct{}) error {
+	if _, exists := cfg.Actions[act.Key()]; exists {
+		return fmt.Errorf("action %q already exists", act.Key())
+	}
+
+	spec := act.Spec
+	var err error
+	spec.Bindings.Sources, err = resolve(act.Namespace, spec.Bindings.Sources, sources, "source")
+	if err != nil {
+		return err
+	}
+	spec.Bindings.Executors, err = resolve(act.Namespace, spec.Bindings.Executors, executors, "executor")
+	if err != nil {
+		return err
+	}
+
+	if err := validateSpec(spec); err != nil {
+		return err
+	}
+
+	cfg.Actions[act.Key()] = spec
+	return nil
+}
+
+func mergeChannelBinding(cfg *config.Config, binding Resource[ChannelBindingSpec], sources, executors map[string]struct{}) error {
+	spec := binding.Spec
+	srcNames, err := resolve(binding.Namespace, spec.Sources, sources, "source")
+	if err != nil {
+		return err
+	}
+	execNames, err := resolve(binding.Namespace, spec.Executors, executors, "executor")
+	if err != nil {
+		return err
+	}
+
+	group, exists := cfg.Communications[spec.CommunicationGroup]
+	if !exists {
+		return fmt.Errorf("communication group %q doesn't exist", spec.CommunicationGroup)
+	}
+
+	bindingsFn := func(in config.BotBindings) config.BotBindings {
+		return config.BotBindings{
+			Sources:   union(in.Sources, srcNames),
+			Executors: union(in.Executors, execNames),
+		}
+	}
+
+	switch spec.Platform {
+	case config.SlackCommPlatformIntegration:
+		err = bindChannelByName(group.Slack.Channels, spec.Channel, bindingsFn)
+	case config.SocketSlackCommPlatformIntegration:
+		err = bindChannelByName(group.SocketSlack.Channels, spec.Channel, bindingsFn)
+	case config.MattermostCommPlatformIntegration:
+		err = bindChannelByName(group.Mattermost.Channels, spec.Channel, bindingsFn)
+	case config.RocketChatCommPlatformIntegration:
+		err = bindChannelByName(group.RocketChat.Channels, spec.Channel, bindingsFn)
+	case config.GoogleChatCommPlatformIntegration:
+		err = bindChannelByName(group.GoogleChat.Channels, spec.Channel, bindingsFn)
+	case config.MatrixCommPlatformIntegration:
+		err = bindChannelByName(group.Matrix.Channels, spec.Channel, bindingsFn)
+	case config.LoopbackCommPlatformIntegration:
+		err = bindChannelByName(group.Loopback.Channels, spec.Channel, bindingsFn)
+	case config.DiscordCommPlatformIntegration:
+		err = bindChannelByID(group.Discord.Channels, spec.Channel, bindingsFn)
+	case config.WebexCommPlatformIntegration:
+		err = bindChannelByID(group.Webex.Channels, spec.Channel, bindingsFn)
+	case config.TeamsCommPlatformIntegration:
+		group.Teams.Bindings = bindingsFn(group.Teams.Bindings)
+	default:
+		err = fmt.Errorf("platform %q doesn't support channel bindings", spec.Platform)
+	}
+	if err != nil {
+		return err
+	}
+
+	cfg.Communications[spec.CommunicationGroup] = group
+	return nil
+}
+
+func bindChannelByName(channels config.IdentifiableMap[config.ChannelBindingsByName], alias string, bindingsFn func(config.BotBindings) config.BotBindings) error {
+	channel, exists := channels[alias]
+	if !exists {
+		return fmt.Errorf("channel %q doesn't exist", alias)
+	}
+	channel.Bindings = bindingsFn(channel.Bindings)
+	channels[alias] = channel
+	return nil
+}
+
+func bindChannelByID(channels config.IdentifiableMap[config.ChannelBindingsByID], alias string, bindingsFn func(config.BotBindings) config.BotBindings) error {
+	channel, exists := channels[alias]
+	if !exists {
+		return fmt.Errorf("channel %q doesn't exist", alias)
+	}
+	channel.Bindings = bindingsFn(channel.Bindings)
+	channels[alias] = channel
+	return nil
+}
+
+// validateSpec runs the configuration validation for a given spec. Only the critical issues are reported.
+func validateSpec(spec any) error {
+	result, err := config.ValidateStruct(spec)
+	if err != nil {
+		return fmt.Errorf("while validating: %w", err)
+	}
+	if err := result.Criticals.ErrorOrNil(); err != nil {
+		return fmt.Errorf("found critical validation errors: %w", err)
+	}
+	return nil
+}
+
+// resolve returns the configuration keys of the resources with given names from a given Namespace.
+func resolve(namespace string, names []string, merged map[string]struct{}, kind string) ([]string, error) {
+	var out []string
+	for _, name := range names {
+		key := Key(namespace, name)
+		if _, exists := merged[key]; !exists {
+			return nil, fmt.Errorf("%s %q doesn't exist in the %q Namespace", kind, name, namespace)
+		}
+		out = append(out, key)
+	}
+	return out, nil
+}
+
+func union(current, added []string) []string {
+	out := append([]string{}, current...)
+	for _, item := range added {
+		if slices.Contains(out, item) {
+			continue
+		}
+		out = append(out, item)
+	}
+	return out
+}
+
+// namespaceOnly returns the Namespaces which include only a given Namespace. The Include entries are regular expressions,
+// so the name is anchored to not match other Namespaces with the same prefix.
+func namespaceOnly(namespace string) config.Namespaces {
+	return config.Namespaces{Include: []string{fmt.Sprintf("^%s$", namespace)}}
+}
@@ -0,0 +1,213 @@
+package customresources
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+func TestMerge(t *testing.T) {
+	// given
+	resources := Resources{
+		Sources: []Resource[config.Sources]{
+			{
+				Namespace: "team-a",
+				Name:      "pods",
+				Spec: config.Sources{
+					DisplayName: "Team A pods",
+					Kubernetes: config.KubernetesSource{
+						Namespaces: config.Namespaces{Include: []string{".*"}},
+						Resources:  []config.Resource{{Type: "v1/pods"}},
+					},
+				},
+			},
+			{
+				Namespace: "team-a",
+				Name:      "alerts",
+				Spec: config.Sources{
+					Alertmanager: config.AlertmanagerSource{Enabled: true},
+				},
+			},
+		},
+		Executors: []Resource[config.Executors]{
+			{
+				Namespace: "team-a",
+				Name:      "kubectl",
+				Spec: config.Executors{
+					Kubectl: config.Kubectl{
+						Enabled:    true,
+						Namespaces: config.Namespaces{Include: []string{".*"}},
+						Commands:   config.Commands{Verbs: []string{"get"}, Resources: []string{"pods"}},
+					},
+				},
+			},
+		},
+		Actions: []Resource[config.Action]{
+			{
+				Namespace: "team-a",
+				Name:      "describe",
+				Spec: config.Action{
+					Enabled:  true,
+					Command:  "kubectl describe {{ .Event.TypeMeta.Kind | lower }} {{ .Event.Name }}",
+					Bindings: config.ActionBindings{Sources: []string{"pods"}, Executors: []string{"kubectl"}},
+				},
+			},
+			{
+				Namespace: "team-b",
+				Name:      "describe",
+				Spec: config.Action{
+					Enabled:  true,
+					Command:  "kubectl describe pods",
+					Bindings: config.ActionBindings{Sources: []string{"pods"}},
+				},
+			},
+		},
+		ChannelBindings: []Resource[ChannelBindingSpec]{
+			{
+				Namespace: "team-a",
+				Name:      "alerts",
+				Spec: ChannelBindingSpec{
+					CommunicationGroup: "default-group",
+					Platform:           config.SlackCommPlatformIntegration,
+					Channel:            "alerts",
+					Sources:            []string{"pods"},
+					Executors:          []string{"kubectl"},
+				},
+			},
+			{
+				Namespace: "team-a",
+				Name:      "discord",
+				Spec: ChannelBindingSpec{
+					CommunicationGroup: "default-group",
+					Platform:           config.DiscordCommPlatformIntegration,
+					Channel:            "missing",
+					Sources:            []string{"pods"},
+				},
+			},
+		},
+		Invalid: []error{errors.New("while decoding botkubesources team-c/broken: invalid spec")},
+	}
+
+	cfg := &config.Config{
+		Communications: map[string]config.Communications{
+			"default-group": {
+				Slack: config.Slack{
+					Channels: config.IdentifiableMap[config.ChannelBindingsByName]{
+						"alerts": {
+							Name:     "alerts",
+							Bindings: config.BotBindings{Sources: []string{"k8s-events"}},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	// when
+	err := Merge(cfg, resources)
+
+	// then
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "4 errors occurred")
+	assert.Contains(t, err.Error(), "while decoding botkubesources team-c/broken: invalid spec")
+	assert.Contains(t, err.Error(), "while merging BotkubeSource team-a/alerts: only the kubernetes source is supported")
+	assert.Contains(t, err.Error(), `while merging BotkubeAction team-b/describe: source "pods" doesn't exist in the "team-b" Namespace`)
+	assert.Contains(t, err.Error(), `while merging BotkubeChannelBinding team-a/discord: channel "missing" doesn't exist`)
+
+	teamANamespace := config.Namespaces{Include: []string{"^team-a$"}}
+	assert.Equal(t, map[string]config.Sources{
+		"team-a/pods": {
+			DisplayName: "Team A pods",
+			Kubernetes: config.KubernetesSource{
+				Namespaces: teamANamespace,
+				Resources:  []config.Resource{{Type: "v1/pods", Namespaces: teamANamespace}},
+			},
+		},
+	}, cfg.Sources)
+	assert.Equal(t, map[string]config.Executors{
+		"team-a/kubectl": {
+			Kubectl: config.Kubectl{
+				Enabled:          true,
+				Namespaces:       teamANamespace,
+				DefaultNamespace: "team-a",
+				Commands:         config.Commands{Verbs: []string{"get"}, Resources: []string{"pods"}},
+			},
+		},
+	}, cfg.Executors)
+	assert.Equal(t, config.Actions{
+		"team-a/describe": {
+			Enabled:  true,
+			Command:  "kubectl describe {{ .Event.TypeMeta.Kind | lower }} {{ .Event.Name }}",
+			Bindings: config.ActionBindings{Sources: []string{"team-a/pods"}, Executors: []string{"team-a/kubectl"}},
+		},
+	}, cfg.Actions)
+	assert.Equal(t, config.BotBindings{
+		Sources:   []string{"k8s-events", "team-a/pods"},
+		Executors: []string{"team-a/kubectl"},
+	}, cfg.Communications["default-group"].Slack.Channels["alerts"].Bindings)
+}
+
+func TestMergeRejectsExistingNames(t *testing.T) {
+	// given
+	cfg := &config.Config{
+		Sources: map[string]config.Sources{
+			"team-a/pods": {DisplayName: "Admin source"},
+		},
+	}
+	resources := Resources{
+		Sources: []Resource[config.Sources]{
+			{Namespace: "team-a", Name: "pods", Spec: config.Sources{DisplayName: "Team A pods"}},
+		},
+	}
+
+	// when
+	err := Merge(cfg, resources)
+
+	// then
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `source "team-a/pods" already exists`)
+	assert.Equal(t, "Admin source", cfg.Sources["team-a/pods"].DisplayName)
+}
+
+func TestMergeSkipsResourcesFailingValidation(t *testing.T) {
+	// given
+	cfg := &config.Config{}
+	resources := Resources{
+		Sources: []Resource[config.Sources]{
+			{
+				Namespace: "team-a",
+				Name:      "pods",
+				Spec: config.Sources{
+					Kubernetes: config.KubernetesSource{
+						Resources:   []config.Resource{{Type: "v1/pods"}},
+						Expressions: []string{`evnt.count > 3`},
+					},
+				},
+			},
+		},
+		Actions: []Resource[config.Action]{
+			{
+				Namespace: "team-a",
+				Name:      "describe",
+				Spec:      config.Action{Enabled: true},
+			},
+		},
+	}
+
+	// when
+	err := Merge(cfg, resources)
+
+	// then
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "2 errors occurred")
+	assert.Contains(t, err.Error(), "while merging BotkubeSource team-a/pods: found critical validation errors")
+	assert.Contains(t, err.Error(), "Expressions contains invalid expression")
+	assert.Contains(t, err.Error(), "while merging BotkubeAction team-a/describe: found critical validation errors")
+	assert.Contains(t, err.Error(), "Command is a required field")
+	assert.Empty(t, cfg.Sources)
+	assert.Empty(t, cfg.Actions)
+}
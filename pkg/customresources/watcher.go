@@ -0,0 +1,119 @@
+package customresources
+
+import (
+	"context"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	"k8s.io/client-go/tools/cache"
+)
+
+const defaultDebounce = 5 * time.Second
+
+// OnChangeFn defines a function called when the custom resources changed.
+type OnChangeFn func(ctx context.Context)
+
+// Watcher watches the Botkube custom resources and notifies about their changes.
+type Watcher struct {
+	log          logrus.FieldLogger
+	dynamicCli   dynamic.Interface
+	mapper       meta.RESTMapper
+	resyncPeriod time.Duration
+	debounce     time.Duration
+	onChange     OnChangeFn
+}
+
+// NewWatcher returns a new Watcher instance.
+func NewWatcher(log logrus.FieldLogger, dynamicCli dynamic.Interface, mapper meta.RESTMapper, resyncPeriod time.Duration, onChange OnChangeFn) *Watcher {
+	return &Watcher{
+		log:          log,
+		dynamicCli:   dynamicCli,
+		mapper:       mapper,
+		resyncPeriod: resyncPeriod,
+		debounce:     defaultDebounce,
+		onChange:     onChange,
+	}
+}
+
+// Start starts watching the custom resources. It blocks until the context is cancelled.
+// Changes made in a short period of time are notified once.
+func (w *Watcher) Start(ctx context.Context) error {
+	changed := make(chan struct{}, 1)
+	notify := func() {
+		select {
+		case changed <- struct{}{}:
+		default:
+		}
+	}
+
+	factory := dynamicinformer.NewDynamicSharedInformerFactory(w.dynamicCli, w.resyncPeriod)
+	watched := 0
+	for _, gvr := range GVRs {
+		if _, err := w.mapper.ResourcesFor(gvr); err != nil {
+			w.log.Warnf("Custom resource %q is not available in the cluster, skipping watching it: %s", gvr.Resource, err.Error())
+			continue
+		}
+
+		informer := factory.ForResource(gvr).Informer()
+		informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+			AddFunc: func(obj interface{}) {
+				notify()
+			},
+			UpdateFunc: func(oldObj, newObj interface{}) {
+				if specChanged(oldObj, newObj) {
+					notify()
+				}
+			},
+			DeleteFunc: func(obj interface{}) {
+				notify()
+			},
+		})
+		watched++
+	}
+	if watched == 0 {
+		return nil
+	}
+
+	w.log.Info("Starting Botkube custom resources watcher...")
+	// The initial list triggers a reload too. It doesn't change anything, unless the resources changed after they were loaded on startup.
+	factory.Start(ctx.Done())
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-changed:
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(w.debounce):
+		}
+		// drop the changes made during debouncing, they are applied together
+		select {
+		case <-changed:
+		default:
+		}
+
+		w.log.Info("Botkube custom resources changed.")
+		w.onChange(ctx)
+	}
+}
+
+// specChanged returns false for the updates which don't change the spec, such as status or metadata updates, and periodic resyncs.
+func specChanged(oldObj, newObj interface{}) bool {
+	oldRes, ok := oldObj.(*unstructured.Unstructured)
+	if !ok {
+		return true
+	}
+	newRes, ok := newObj.(*unstructured.Unstructured)
+	if !ok {
+		return true
+	}
+	return oldRes.GetGeneration() != newRes.GetGeneration()
+}
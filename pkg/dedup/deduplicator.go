@@ -0,0 +1,162 @@
+package dedup
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/events"
+	"github.com/kubeshop/botkube/pkg/format"
+)
+
+const (
+	// DefaultWindow is used when the deduplication window is not configured.
+	DefaultWindow = 10 * time.Minute
+
+	minFlushInterval = time.Second
+)
+
+// SendFn sends a given event to the source bindings.
+type SendFn func(ctx context.Context, event events.Event, sources []string)
+
+// Deduplicator suppresses events repeated within the deduplication window.
+// The first event with a given fingerprint is sent immediately, and the repeated ones are summarized in a single event
+// sent once the window ends.
+type Deduplicator struct {
+	enabled bool
+	window  time.Duration
+	now     func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]*entry
+}
+
+type entry struct {
+	firstSeen time.Time
+	// suppressed is the number of events suppressed since the first one was sent.
+	suppressed int
+	last       events.Event
+	sources    []string
+}
+
+// New returns a new Deduplicator instance. If deduplication is disabled, the returned Deduplicator allows all events.
+func New(cfg config.Deduplication) *Deduplicator {
+	window := cfg.Window
+	if window <= 0 {
+		window = DefaultWindow
+	}
+
+	return &Deduplicator{
+		enabled: cfg.Enabled,
+		window:  window,
+		now:     time.Now,
+		entries: map[string]*entry{},
+	}
+}
+
+// Allow returns true if a given event should be sent. Otherwise, the event is counted to the summary
+// sent once the deduplication window ends.
+func (d *Deduplicator) Allow(event events.Event, sources []string) bool {
+	if !d.enabled {
+		return true
+	}
+
+	key := fingerprint(event, sources)
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	item, found := d.entries[key]
+	if !found {
+		d.entries[key] = &entry{firstSeen: d.now()}
+		return true
+	}
+
+	item.suppressed++
+	item.last = event
+	item.sources = sources
+	return false
+}
+
+// Run periodically sends summaries of the suppressed events, once their deduplication window ends.
+// It blocks until the context is cancelled.
+func (d *Deduplicator) Run(ctx context.Context, send SendFn) {
+	if !d.enabled {
+		return
+	}
+
+	interval := d.window / 10
+	if interval < minFlushInterval {
+		interval = minFlushInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			for _, item := range d.flushExpired() {
+				send(ctx, d.summaryEvent(item), item.sources)
+			}
+		}
+	}
+}
+
+// flushExpired removes entries with finished deduplication window and returns the ones with suppressed events.
+func (d *Deduplicator) flushExpired() []*entry {
+	now := d.now()
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	keys := make([]string, 0, len(d.entries))
+	for key, item := range d.entries {
+		if now.Sub(item.firstSeen) >= d.window {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	var out []*entry
+	for _, key := range keys {
+		item := d.entries[key]
+		delete(d.entries, key)
+		if item.suppressed > 0 {
+			out = append(out, item)
+		}
+	}
+	return out
+}
+
+// summaryEvent returns the last suppressed event with a message about the number of occurrences.
+func (d *Deduplicator) summaryEvent(item *entry) events.Event {
+	event := item.last
+	summary := fmt.Sprintf("Seen %d times in the last %s", item.suppressed+1, format.Duration(d.window))
+	event.Messages = append([]string{summary}, event.Messages...)
+	// actions were already executed for the first event
+	event.Actions = nil
+	return event
+}
+
+// fingerprint identifies repeated events. Events sent to different source bindings are deduplicated separately.
+func fingerprint(event events.Event, sources []string) string {
+	sorted := make([]string, len(sources))
+	copy(sorted, sources)
+	sort.Strings(sorted)
+
+	return strings.Join([]string{
+		event.Cluster,
+		event.Namespace,
+		event.Kind,
+		event.Name,
+		string(event.Type),
+		event.Reason,
+		strings.Join(sorted, ","),
+	}, "/")
+}
@@ -0,0 +1,93 @@
+package dedup
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/events"
+)
+
+func TestDeduplicator(t *testing.T) {
+	// given
+	now := time.Date(2022, 10, 10, 10, 0, 0, 0, time.UTC)
+	deduplicator := New(config.Deduplication{Enabled: true})
+	deduplicator.now = func() time.Time { return now }
+
+	backOff := fixEvent("api-7d9f", "BackOff", "Back-off restarting failed container")
+	sources := []string{"k8s-err-events", "k8s-all-events"}
+
+	// when
+	first := deduplicator.Allow(backOff, sources)
+	second := deduplicator.Allow(backOff, []string{"k8s-all-events", "k8s-err-events"})
+	otherPod := deduplicator.Allow(fixEvent("api-5c2a", "BackOff", "Back-off restarting failed container"), sources)
+	otherSources := deduplicator.Allow(backOff, []string{"k8s-err-events"})
+	now = now.Add(5 * time.Minute)
+	backOff.Messages = []string{"Back-off restarting failed container, again"}
+	third := deduplicator.Allow(backOff, sources)
+
+	// then
+	assert.True(t, first)
+	assert.False(t, second)
+	assert.True(t, otherPod)
+	assert.True(t, otherSources)
+	assert.False(t, third)
+
+	// when
+	expired := deduplicator.flushExpired()
+
+	// then
+	assert.Empty(t, expired)
+
+	// when
+	now = now.Add(5 * time.Minute)
+	expired = deduplicator.flushExpired()
+
+	// then
+	require.Len(t, expired, 1)
+	summary := deduplicator.summaryEvent(expired[0])
+	assert.Equal(t, "api-7d9f", summary.Name)
+	assert.Equal(t, []string{
+		"Seen 3 times in the last 10m",
+		"Back-off restarting failed container, again",
+	}, summary.Messages)
+	assert.Empty(t, summary.Actions)
+	assert.Equal(t, sources, expired[0].sources)
+
+	// when
+	allowed := deduplicator.Allow(backOff, sources)
+
+	// then
+	assert.True(t, allowed, "event must be sent again after the window ends")
+}
+
+func TestDeduplicatorDisabled(t *testing.T) {
+	// given
+	deduplicator := New(config.Deduplication{Enabled: false})
+	event := fixEvent("api-7d9f", "BackOff", "Back-off restarting failed container")
+
+	// when
+	first := deduplicator.Allow(event, []string{"k8s-err-events"})
+	second := deduplicator.Allow(event, []string{"k8s-err-events"})
+
+	// then
+	assert.True(t, first)
+	assert.True(t, second)
+}
+
+func fixEvent(name, reason, message string) events.Event {
+	return events.Event{
+		TypeMeta:  metaV1.TypeMeta{Kind: "Pod", APIVersion: "v1"},
+		Name:      name,
+		Namespace: "default",
+		Cluster:   "dev",
+		Type:      config.WarningEvent,
+		Reason:    reason,
+		Messages:  []string{message},
+		Actions:   []events.Action{{Command: "@Botkube kubectl describe pod"}},
+	}
+}
@@ -0,0 +1,239 @@
+package digest
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/kubeshop/botkube/pkg/bot/interactive"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/events"
+	"github.com/kubeshop/botkube/pkg/format"
+)
+
+const (
+	// DefaultInterval is used when the digest interval is not configured.
+	DefaultInterval = 15 * time.Minute
+
+	checkInterval = 5 * time.Second
+)
+
+// SendFn sends a given digest message to a given channel.
+type SendFn func(ctx context.Context, channel string, msg interactive.Message) error
+
+// ConfigFn returns the digest configuration for a given channel.
+type ConfigFn func(channel string) ChannelConfig
+
+// ChannelConfig holds the channel settings which affect when the events are sent.
+type ChannelConfig struct {
+	Digest   config.ChannelDigest
+	Schedule config.NotificationSchedule
+}
+
+// Scheduler buffers non-critical events for channels with digest mode enabled, and periodically sends them
+// as a single summary message per channel. It also holds non-critical events for channels outside their
+// notification schedule, and sends them as a digest when the next schedule window starts.
+type Scheduler struct {
+	log logrus.FieldLogger
+	now func() time.Time
+
+	mu      sync.Mutex
+	buffers map[string]*buffer
+}
+
+type buffer struct {
+	since   time.Time
+	flushAt time.Time
+	events  []events.Event
+}
+
+// NewScheduler returns a new Scheduler instance.
+func NewScheduler(log logrus.FieldLogger) *Scheduler {
+	return &Scheduler{
+		log:     log,
+		now:     time.Now,
+		buffers: map[string]*buffer{},
+	}
+}
+
+// IsCritical returns true if a given event should be always sent immediately.
+func IsCritical(event events.Event) bool {
+	return event.Level == config.Error || event.Level == config.Critical
+}
+
+// Filter returns channels which should be notified about a given event immediately.
+// For channels with digest mode enabled or outside their notification schedule, non-critical events are buffered
+// and sent later in the digest message.
+func (s *Scheduler) Filter(event events.Event, channels []string, cfgFn ConfigFn) []string {
+	if IsCritical(event) {
+		return channels
+	}
+
+	now := s.now()
+	var out []string
+	for _, channel := range channels {
+		cfg := cfgFn(channel)
+		if !cfg.Schedule.IsActive(now) {
+			s.hold(channel, event, cfg.Schedule.NextStart(now))
+			continue
+		}
+
+		if !cfg.Digest.Enabled {
+			out = append(out, channel)
+			continue
+		}
+
+		interval := cfg.Digest.Interval
+		if interval <= 0 {
+			interval = DefaultInterval
+		}
+		s.add(channel, event, now.Add(interval))
+	}
+	return out
+}
+
+// Run periodically sends the digest messages for the buffered events. It blocks until the context is cancelled.
+func (s *Scheduler) Run(ctx context.Context, send SendFn) {
+	ticker := time.NewTicker(checkInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			for _, channel := range s.dueChannels() {
+				msg, ok := s.flush(channel)
+				if !ok {
+					continue
+				}
+				if err := send(ctx, channel, msg); err != nil {
+					s.log.Errorf("while sending digest to channel %q: %s", channel, err.Error())
+				}
+			}
+		}
+	}
+}
+
+// add buffers a given event. If the channel has no buffered events yet, the digest is sent at a given time.
+func (s *Scheduler) add(channel string, event events.Event, flushAt time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	buf := s.bufferFor(channel, flushAt)
+	buf.events = append(buf.events, event)
+}
+
+// hold buffers a given event, and postpones the digest at least until a given time.
+func (s *Scheduler) hold(channel string, event events.Event, until time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	buf := s.bufferFor(channel, until)
+	if until.After(buf.flushAt) {
+		buf.flushAt = until
+	}
+	buf.events = append(buf.events, event)
+}
+
+// bufferFor returns the buffer for a given channel, creating it if needed. It must be called with the lock held.
+func (s *Scheduler) bufferFor(channel string, flushAt time.Time) *buffer {
+	buf, found := s.buffers[channel]
+	if !found {
+		buf = &buffer{since: s.now(), flushAt: flushAt}
+		s.buffers[channel] = buf
+	}
+	return buf
+}
+
+// dueChannels returns sorted channels which digest should be sent.
+func (s *Scheduler) dueChannels() []string {
+	now := s.now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var out []string
+	for channel, buf := range s.buffers {
+		if !now.Before(buf.flushAt) {
+			out = append(out, channel)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+// flush removes the buffered events for a given channel and returns the digest message.
+func (s *Scheduler) flush(channel string) (interactive.Message, bool) {
+	s.mu.Lock()
+	buf, found := s.buffers[channel]
+	delete(s.buffers, channel)
+	s.mu.Unlock()
+
+	if !found || len(buf.events) == 0 {
+		return interactive.Message{}, false
+	}
+	return digestMessage(buf.events, buf.flushAt.Sub(buf.since)), true
+}
+
+// digestMessage returns a summary of given events, grouped by namespace and kind.
+func digestMessage(evts []events.Event, period time.Duration) interactive.Message {
+	groups := map[string][]events.Event{}
+	for _, event := range evts {
+		key := event.Kind
+		if event.Namespace != "" {
+			key = fmt.Sprintf("%s in %s namespace", event.Kind, event.Namespace)
+		}
+		groups[key] = append(groups[key], event)
+	}
+
+	keys := make([]string, 0, len(groups))
+	for key := range groups {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	msg := interactive.Message{
+		Base: interactive.Base{
+			Header: fmt.Sprintf("Digest of %d events from the last %s", len(evts), format.Duration(period)),
+		},
+	}
+	if cluster := evts[0].Cluster; cluster != "" {
+		msg.Description = fmt.Sprintf("Cluster: %s", cluster)
+	}
+
+	for _, key := range keys {
+		var lines strings.Builder
+		for _, event := range groups[key] {
+			lines.WriteString(fmt.Sprintf("• %s\n", eventSummary(event)))
+		}
+		msg.Sections = append(msg.Sections, interactive.Section{
+			Base: interactive.Base{
+				Header: fmt.Sprintf("%s (%d)", key, len(groups[key])),
+				Body: interactive.Body{
+					Plaintext: strings.TrimSuffix(lines.String(), "\n"),
+				},
+			},
+		})
+	}
+	return msg
+}
+
+// eventSummary returns a short description of a given event, for example "*nginx* has been created".
+func eventSummary(event events.Event) string {
+	out := fmt.Sprintf("*%s*", event.Name)
+	switch event.Type {
+	case config.CreateEvent, config.DeleteEvent, config.UpdateEvent:
+		return fmt.Sprintf("%s has been %sd", out, event.Type)
+	}
+
+	if event.Reason != "" {
+		return fmt.Sprintf("%s: %s", out, event.Reason)
+	}
+	return out
+}
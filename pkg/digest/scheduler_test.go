@@ -0,0 +1,192 @@
+package digest
+
+import (
+	"testing"
+	"time"
+
+	logtest "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kubeshop/botkube/pkg/bot/interactive"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/events"
+)
+
+func TestSchedulerFilter(t *testing.T) {
+	cfgFn := func(channel string) ChannelConfig {
+		return ChannelConfig{Digest: config.ChannelDigest{Enabled: channel == "digest"}}
+	}
+
+	tests := []struct {
+		name  string
+		event events.Event
+
+		expectedChannels []string
+		expectedBuffered int
+	}{
+		{
+			name:             "Info event",
+			event:            fixEvent("Pod", "default", "nginx", config.CreateEvent, config.Info),
+			expectedChannels: []string{"all"},
+			expectedBuffered: 1,
+		},
+		{
+			name:             "Warning event",
+			event:            fixEvent("Pod", "default", "nginx", config.WarningEvent, config.Warn),
+			expectedChannels: []string{"all"},
+			expectedBuffered: 1,
+		},
+		{
+			name:             "Error event",
+			event:            fixEvent("Pod", "default", "nginx", config.ErrorEvent, config.Error),
+			expectedChannels: []string{"all", "digest"},
+		},
+		{
+			name:             "Critical event",
+			event:            fixEvent("Node", "", "worker-1", config.ErrorEvent, config.Critical),
+			expectedChannels: []string{"all", "digest"},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// given
+			logger, _ := logtest.NewNullLogger()
+			scheduler := NewScheduler(logger)
+
+			// when
+			channels := scheduler.Filter(tc.event, []string{"all", "digest"}, cfgFn)
+
+			// then
+			assert.Equal(t, tc.expectedChannels, channels)
+			var buffered int
+			if buf, ok := scheduler.buffers["digest"]; ok {
+				buffered = len(buf.events)
+			}
+			assert.Equal(t, tc.expectedBuffered, buffered)
+		})
+	}
+}
+
+func TestSchedulerFlush(t *testing.T) {
+	// given
+	logger, _ := logtest.NewNullLogger()
+	scheduler := NewScheduler(logger)
+	now := time.Date(2022, 10, 10, 12, 0, 0, 0, time.UTC)
+	scheduler.now = func() time.Time { return now }
+
+	cfgFn := func(channel string) ChannelConfig {
+		if channel == "hourly" {
+			return ChannelConfig{Digest: config.ChannelDigest{Enabled: true, Interval: time.Hour}}
+		}
+		return ChannelConfig{Digest: config.ChannelDigest{Enabled: true}}
+	}
+	for _, event := range []events.Event{
+		fixEvent("Pod", "default", "nginx", config.CreateEvent, config.Info),
+		fixEvent("Pod", "prod", "api", config.WarningEvent, config.Warn),
+		fixEvent("Pod", "default", "redis", config.DeleteEvent, config.Info),
+		fixEvent("Node", "", "worker-1", config.UpdateEvent, config.Info),
+	} {
+		scheduler.Filter(event, []string{"default", "hourly"}, cfgFn)
+	}
+
+	// when
+	now = now.Add(10 * time.Minute)
+	beforeInterval := scheduler.dueChannels()
+	now = now.Add(5 * time.Minute)
+	afterInterval := scheduler.dueChannels()
+	msg, ok := scheduler.flush("default")
+
+	// then
+	assert.Empty(t, beforeInterval)
+	assert.Equal(t, []string{"default"}, afterInterval)
+
+	require.True(t, ok)
+	assert.Equal(t, interactive.Message{
+		Base: interactive.Base{
+			Header:      "Digest of 4 events from the last 15m",
+			Description: "Cluster: dev",
+		},
+		Sections: []interactive.Section{
+			{
+				Base: interactive.Base{
+					Header: "Node (1)",
+					Body:   interactive.Body{Plaintext: "• *worker-1* has been updated"},
+				},
+			},
+			{
+				Base: interactive.Base{
+					Header: "Pod in default namespace (2)",
+					Body:   interactive.Body{Plaintext: "• *nginx* has been created\n• *redis* has been deleted"},
+				},
+			},
+			{
+				Base: interactive.Base{
+					Header: "Pod in prod namespace (1)",
+					Body:   interactive.Body{Plaintext: "• *api*: BackOff"},
+				},
+			},
+		},
+	}, msg)
+
+	_, ok = scheduler.flush("default")
+	assert.False(t, ok)
+	assert.Contains(t, scheduler.buffers, "hourly")
+}
+
+func TestSchedulerHoldOutsideSchedule(t *testing.T) {
+	// given
+	logger, _ := logtest.NewNullLogger()
+	scheduler := NewScheduler(logger)
+	now := time.Date(2022, 10, 14, 18, 0, 0, 0, time.UTC) // Friday
+	scheduler.now = func() time.Time { return now }
+
+	cfgFn := func(channel string) ChannelConfig {
+		return ChannelConfig{
+			Digest: config.ChannelDigest{Enabled: channel == "digest"},
+			Schedule: config.NotificationSchedule{
+				Windows: []config.NotificationWindow{
+					{Days: []string{"mon", "tue", "wed", "thu", "fri"}, From: "09:00", To: "17:00"},
+				},
+			},
+		}
+	}
+	channels := []string{"team", "digest"}
+
+	// when
+	critical := scheduler.Filter(fixEvent("Node", "", "worker-1", config.ErrorEvent, config.Critical), channels, cfgFn)
+	info := scheduler.Filter(fixEvent("Pod", "default", "nginx", config.CreateEvent, config.Info), channels, cfgFn)
+
+	// then
+	assert.Equal(t, channels, critical)
+	assert.Empty(t, info)
+
+	// when
+	now = time.Date(2022, 10, 17, 8, 59, 0, 0, time.UTC)
+	beforeStart := scheduler.dueChannels()
+	now = time.Date(2022, 10, 17, 9, 0, 0, 0, time.UTC)
+	afterStart := scheduler.dueChannels()
+	msg, ok := scheduler.flush("team")
+
+	// then
+	assert.Empty(t, beforeStart)
+	assert.Equal(t, []string{"digest", "team"}, afterStart)
+	require.True(t, ok)
+	assert.Equal(t, "Digest of 1 events from the last 63h", msg.Header)
+}
+
+func fixEvent(kind, namespace, name string, eventType config.EventType, level config.Level) events.Event {
+	event := events.Event{
+		TypeMeta:  metaV1.TypeMeta{Kind: kind},
+		Name:      name,
+		Namespace: namespace,
+		Cluster:   "dev",
+		Type:      eventType,
+		Level:     level,
+	}
+	if eventType == config.WarningEvent {
+		event.Reason = "BackOff"
+	}
+	return event
+}
@@ -34,18 +34,78 @@ type Event struct {
 	Skip      bool `json:",omitempty"`
 	Resource  string
 	Object    interface{} `json:"-"`
+	// Owner is the top-level controller of the object, for example a Deployment managing a Pod through a ReplicaSet.
+	Owner *Owner `json:",omitempty"`
+	// Routes holds the channels configured for the event level, indexed by the source binding name.
+	// For these source bindings, the event is sent to the routed channels instead of the bound ones.
+	Routes map[string][]string `json:"-"`
+	// Logs holds the last logs of the failing container, attached to the Pod error events.
+	Logs *ContainerLogs `json:",omitempty"`
+	// Body is the notification text rendered from the user-defined template. If set, it replaces the default layout.
+	Body string `json:",omitempty"`
 
 	Recommendations []string
 	Warnings        []string
 	Actions         []Action
+	// SuggestedCommands are displayed in the interactive section of the message, to help with resolving the event.
+	SuggestedCommands []string
+	// Buttons are displayed in the interactive section of the message, to run commands related to the event.
+	Buttons []Button
+}
+
+// Button describes a command which can be run with a single click from the event message.
+type Button struct {
+	Name string
+	// Command is the command to be executed, without the bot name prefix.
+	Command string
+}
+
+// Owner describes the top-level controller of the event object.
+type Owner struct {
+	APIVersion string
+	Kind       string
+	Name       string
+}
+
+// String returns the owner in the `kind/name` format.
+func (o Owner) String() string {
+	return fmt.Sprintf("%s/%s", o.Kind, o.Name)
+}
+
+// ContainerLogs holds the last log lines of a given container.
+type ContainerLogs struct {
+	Container string
+	// Previous is set if the logs come from the previous, terminated instance of the container.
+	Previous bool
+	Content  string
+	// Truncated is set if the older lines were dropped to fit the size limit.
+	Truncated bool
+}
+
+// Description returns a short description of the logs origin.
+func (l ContainerLogs) Description() string {
+	instance := "container"
+	if l.Previous {
+		instance = "previous container instance"
+	}
+
+	out := fmt.Sprintf("Last logs of the %q %s", l.Container, instance)
+	if l.Truncated {
+		out += " (truncated)"
+	}
+	return out
 }
 
 // Action describes an automated action for a given event.
 type Action struct {
+	// Name is the action name from the configuration.
+	Name string
 	// Command is the command to be executed, with the bot.CrossPlatformBotName prefix.
 	Command          string
 	ExecutorBindings []string
 	DisplayName      string
+	// DryRun means that the command is only posted to the channels, and not executed.
+	DryRun bool
 }
 
 // HasRecommendationsOrWarnings returns true if event has recommendations or warnings.
@@ -53,6 +113,14 @@ func (e *Event) HasRecommendationsOrWarnings() bool {
 	return len(e.Recommendations) > 0 || len(e.Warnings) > 0
 }
 
+// OwnerString returns the top-level owner in the `kind/name` format, or an empty string if the owner is not resolved.
+func (e *Event) OwnerString() string {
+	if e.Owner == nil {
+		return ""
+	}
+	return e.Owner.String()
+}
+
 // LevelMap is a map of event type to Level
 var LevelMap = map[config.EventType]config.Level{
 	config.CreateEvent:  config.Info,
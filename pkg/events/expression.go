@@ -0,0 +1,56 @@
+package events
+
+import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// ExpressionVars returns the variables available in the event expressions, such as source expressions and action conditions.
+func ExpressionVars(event Event) map[string]interface{} {
+	var owner interface{}
+	if event.Owner != nil {
+		owner = map[string]interface{}{
+			"apiVersion": event.Owner.APIVersion,
+			"kind":       event.Owner.Kind,
+			"name":       event.Owner.Name,
+		}
+	}
+
+	return map[string]interface{}{
+		"event": map[string]interface{}{
+			"apiVersion": event.APIVersion,
+			"kind":       event.Kind,
+			"name":       event.Name,
+			"namespace":  event.Namespace,
+			"resource":   event.Resource,
+			"type":       string(event.Type),
+			"reason":     event.Reason,
+			"error":      event.Error,
+			"level":      string(event.Level),
+			"title":      event.Title,
+			"messages":   event.Messages,
+			"count":      event.Count,
+			"action":     event.Action,
+			"cluster":    event.Cluster,
+			"owner":      owner,
+		},
+		"object": objectVar(event.Object),
+	}
+}
+
+func objectVar(obj interface{}) interface{} {
+	switch val := obj.(type) {
+	case nil:
+		return nil
+	case *unstructured.Unstructured:
+		return val.Object
+	case map[string]interface{}:
+		return val
+	}
+
+	out, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return nil
+	}
+	return out
+}
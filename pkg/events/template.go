@@ -0,0 +1,125 @@
+package events
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/template"
+
+	sprig "github.com/go-task/slim-sprig"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/utils"
+)
+
+// TemplateData is the data available in the user-defined event templates.
+type TemplateData struct {
+	// Event is the event with the default title and messages. In the body template, it also contains
+	// the recommendations, warnings and container logs.
+	Event Event
+	// Fields holds the values extracted with the template JSONPath expressions.
+	Fields map[string]string
+	// Object is the resource which triggered the event.
+	Object map[string]interface{}
+}
+
+// RenderTemplate overrides the event title and messages with a given user-defined template.
+// The event is not modified if the template cannot be rendered.
+func (e *Event) RenderTemplate(tpl config.EventTemplate) error {
+	data, err := e.templateData(tpl)
+	if err != nil {
+		return err
+	}
+
+	title, err := renderTemplate("title", tpl.Title, data)
+	if err != nil {
+		return err
+	}
+	message, err := renderTemplate("message", tpl.Message, data)
+	if err != nil {
+		return err
+	}
+
+	if title != "" {
+		e.Title = title
+	}
+	if message != "" {
+		e.Messages = []string{message}
+	}
+	return nil
+}
+
+// RenderBody sets the event notification body with a given user-defined template.
+// As the body replaces the whole notification, it should be rendered once the event is complete.
+func (e *Event) RenderBody(tpl config.EventTemplate) error {
+	if tpl.Body == "" {
+		return nil
+	}
+
+	data, err := e.templateData(tpl)
+	if err != nil {
+		return err
+	}
+
+	body, err := renderTemplate("body", tpl.Body, data)
+	if err != nil {
+		return err
+	}
+	e.Body = body
+	return nil
+}
+
+func (e *Event) templateData(tpl config.EventTemplate) (TemplateData, error) {
+	obj, err := toUnstructuredMap(e.Object)
+	if err != nil {
+		return TemplateData{}, err
+	}
+
+	data := TemplateData{
+		Event:  *e,
+		Fields: make(map[string]string, len(tpl.Fields)),
+		Object: obj,
+	}
+	for name, path := range tpl.Fields {
+		value, err := utils.ParseJsonpathAllowMissingKeys(obj, path)
+		if err != nil {
+			return TemplateData{}, fmt.Errorf("while extracting field %q with JSONPath %q: %w", name, path, err)
+		}
+		data.Fields[name] = value
+	}
+	return data, nil
+}
+
+func renderTemplate(name, raw string, data TemplateData) (string, error) {
+	if raw == "" {
+		return "", nil
+	}
+
+	tpl, err := template.New(name).Funcs(sprig.FuncMap()).Parse(raw)
+	if err != nil {
+		return "", fmt.Errorf("while parsing %s template: %w", name, err)
+	}
+
+	var out bytes.Buffer
+	if err := tpl.Execute(&out, data); err != nil {
+		return "", fmt.Errorf("while rendering %s template: %w", name, err)
+	}
+	return strings.TrimSpace(out.String()), nil
+}
+
+func toUnstructuredMap(obj interface{}) (map[string]interface{}, error) {
+	switch o := obj.(type) {
+	case nil:
+		return map[string]interface{}{}, nil
+	case *unstructured.Unstructured:
+		return o.Object, nil
+	default:
+		out, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+		if err != nil {
+			return nil, fmt.Errorf("while converting object %T into unstructured: %w", obj, err)
+		}
+		return out, nil
+	}
+}
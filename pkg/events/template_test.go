@@ -0,0 +1,158 @@
+package events
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+func TestEventRenderTemplate(t *testing.T) {
+	tests := []struct {
+		name     string
+		template config.EventTemplate
+
+		expectedTitle    string
+		expectedMessages []string
+		expectedErrMsg   string
+	}{
+		{
+			name: "Title and message with fields",
+			template: config.EventTemplate{
+				Title:   `Backup {{ .Event.Name }} is {{ .Fields.phase | lower }}`,
+				Message: `Schedule: {{ .Object.spec.schedule }}, errors: {{ .Fields.errors }}`,
+				Fields: map[string]string{
+					"phase":  "status.phase",
+					"errors": "{.status.errors}",
+				},
+			},
+			expectedTitle:    "Backup daily-20221010 is failed",
+			expectedMessages: []string{"Schedule: daily, errors: 2"},
+		},
+		{
+			name: "Only title",
+			template: config.EventTemplate{
+				Title: `{{ .Event.Kind }} {{ .Event.Name }} {{ .Event.Type }}d`,
+			},
+			expectedTitle:    "Backup daily-20221010 updated",
+			expectedMessages: []string{"default message"},
+		},
+		{
+			name: "Missing field",
+			template: config.EventTemplate{
+				Message: `Reason: {{ .Fields.reason }}`,
+				Fields:  map[string]string{"reason": "status.failureReason"},
+			},
+			expectedTitle:    "velero.io/v1/backups updated",
+			expectedMessages: []string{"Reason: <none>"},
+		},
+		{
+			name: "Invalid template",
+			template: config.EventTemplate{
+				Title:   `Backup {{ .Event.Name }}`,
+				Message: `{{ .Event.Name`,
+			},
+			expectedTitle:    "velero.io/v1/backups updated",
+			expectedMessages: []string{"default message"},
+			expectedErrMsg:   `while parsing message template: template: message:1: unclosed action`,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// given
+			event := fixBackupEvent(t)
+
+			// when
+			err := event.RenderTemplate(tc.template)
+
+			// then
+			if tc.expectedErrMsg != "" {
+				require.EqualError(t, err, tc.expectedErrMsg)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tc.expectedTitle, event.Title)
+			assert.Equal(t, tc.expectedMessages, event.Messages)
+		})
+	}
+}
+
+func TestEventRenderBody(t *testing.T) {
+	tests := []struct {
+		name     string
+		template config.EventTemplate
+
+		expectedBody   string
+		expectedErrMsg string
+	}{
+		{
+			name: "Compact body for routine events",
+			template: config.EventTemplate{
+				Body: `{{ if eq .Event.Type "error" }}{{ else }}{{ .Event.Kind }} {{ .Event.Namespace }}/{{ .Event.Name }} {{ .Event.Type }}d ({{ .Fields.phase }}){{ end }}`,
+				Fields: map[string]string{
+					"phase": "status.phase",
+				},
+			},
+			expectedBody: "Backup velero/daily-20221010 updated (Failed)",
+		},
+		{
+			name: "Body rendered to empty string",
+			template: config.EventTemplate{
+				Body: `{{ if eq .Event.Type "update" }}{{ end }}`,
+			},
+			expectedBody: "",
+		},
+		{
+			name: "No body",
+			template: config.EventTemplate{
+				Title: `{{ .Event.Name }}`,
+			},
+			expectedBody: "",
+		},
+		{
+			name: "Invalid template",
+			template: config.EventTemplate{
+				Body: `{{ .Event.Name | unknown }}`,
+			},
+			expectedErrMsg: `while parsing body template: template: body:1: function "unknown" not defined`,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// given
+			event := fixBackupEvent(t)
+
+			// when
+			err := event.RenderBody(tc.template)
+
+			// then
+			if tc.expectedErrMsg != "" {
+				require.EqualError(t, err, tc.expectedErrMsg)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tc.expectedBody, event.Body)
+			assert.Equal(t, "velero.io/v1/backups updated", event.Title)
+		})
+	}
+}
+
+func fixBackupEvent(t *testing.T) Event {
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{
+		"spec":   map[string]interface{}{"schedule": "daily"},
+		"status": map[string]interface{}{"phase": "Failed", "errors": int64(2)},
+	}}
+	obj.SetAPIVersion("velero.io/v1")
+	obj.SetKind("Backup")
+	obj.SetName("daily-20221010")
+	obj.SetNamespace("velero")
+
+	event, err := New(metaV1.ObjectMeta{Name: obj.GetName(), Namespace: obj.GetNamespace()}, obj, config.UpdateEvent, "velero.io/v1/backups", "dev")
+	require.NoError(t, err)
+	event.Messages = []string{"default message"}
+	return event
+}
@@ -0,0 +1,269 @@
+package execute
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"text/template"
+
+	"github.com/sirupsen/logrus"
+	"k8s.io/utils/strings/slices"
+
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+const (
+	aliasAdded              = "I have added '%s' alias on '%s' cluster. It expands to `%s`."
+	aliasRemoved            = "Done. I removed '%s' alias on '%s' cluster."
+	aliasNoAliases          = "There are no aliases defined on cluster '%s'."
+	aliasNotFoundMsgFmt     = "Sorry, the '%s' alias is not defined on cluster '%s'. Use 'alias list' to see defined aliases."
+	aliasReservedMsgFmt     = "Sorry, the '%s' name is reserved for a Botkube command, so it cannot be used as an alias."
+	aliasInvalidNameMsgFmt  = "Sorry, the '%s' alias name is invalid. It can contain only letters, digits, '-' and '_' characters."
+	aliasInvalidCmdMsgFmt   = "Sorry, the '%s' alias command is invalid: %s"
+	aliasMissingArgsMsgFmt  = "Sorry, the '%s' alias requires more arguments, as it expands to `%s`."
+	aliasAddUsageMsg        = "Please specify the alias name and command, for example: alias add gp kubectl get pods -n {{.arg1}}"
+	aliasRemoveUsageMsg     = "Please specify the alias name, for example: alias remove gp"
+	aliasTemplateActionMark = "{{"
+)
+
+// aliasReservedNames holds the names of the Botkube commands and kubectl aliases, which cannot be overridden by aliases.
+var aliasReservedNames = append([]string{
+	"help", "ping", "version", "filters", "commands", "notifier", "edit", "feedback", "audit", "alias", confirmCommandName, cancelCommandName, "helm", topCommandName, diagCommandName, eventsCommandName, queueCommandName, scheduleCommandName, configCommandName,
+}, kubectlAlias...)
+
+var aliasNameRegex = regexp.MustCompile(`^[a-zA-Z0-9_-]+$`)
+
+// aliasAction for options in alias commands
+type aliasAction string
+
+// Alias command options
+const (
+	aliasList   aliasAction = "list"
+	aliasAdd    aliasAction = "add"
+	aliasRemove aliasAction = "remove"
+)
+
+// Alias is a command alias.
+type Alias struct {
+	Name    string
+	Command string
+}
+
+// AliasStore holds the command aliases. The aliases can be modified at runtime, so a single store is shared by all executors.
+type AliasStore struct {
+	mu      sync.RWMutex
+	aliases map[string]string
+}
+
+// NewAliasStore returns a new AliasStore instance with a given aliases. The aliases with reserved names and the disabled ones are skipped.
+func NewAliasStore(log logrus.FieldLogger, aliases config.Aliases) *AliasStore {
+	store := &AliasStore{aliases: map[string]string{}}
+	for name, cmd := range aliases {
+		if cmd == "" {
+			continue
+		}
+		if slices.Contains(aliasReservedNames, name) {
+			log.Warnf("Skipping %q alias, as the name is reserved for a Botkube command.", name)
+			continue
+		}
+		store.aliases[name] = cmd
+	}
+	return store
+}
+
+// Get returns the command of a given alias.
+func (s *AliasStore) Get(name string) (string, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	cmd, found := s.aliases[name]
+	return cmd, found
+}
+
+// Set adds or updates a given alias.
+func (s *AliasStore) Set(name, cmd string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.aliases[name] = cmd
+}
+
+// Remove removes a given alias.
+func (s *AliasStore) Remove(name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.aliases, name)
+}
+
+// List returns all aliases sorted by name.
+func (s *AliasStore) List() []Alias {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var out []Alias
+	for name, cmd := range s.aliases {
+		out = append(out, Alias{Name: name, Command: cmd})
+	}
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].Name < out[j].Name
+	})
+	return out
+}
+
+// Expand expands the alias used as the first word of a given command. Other commands are returned unchanged.
+// The positional arguments are available in the alias command as `{{.arg1}}`, `{{.arg2}}` etc., and all of them as `{{.args}}`.
+// The flags, e.g. `--filter=foo`, are always appended to the expanded command. Apart from the cluster flags, their values must be
+// passed after the `=` sign, as otherwise they are treated as positional arguments. If the alias command has no placeholders,
+// all arguments are appended to it. Aliases are not expanded recursively.
+func (s *AliasStore) Expand(rawCmd string) (string, error) {
+	fields := strings.Fields(rawCmd)
+	if len(fields) == 0 {
+		return rawCmd, nil
+	}
+
+	name, args := fields[0], fields[1:]
+	cmd, found := s.Get(name)
+	if !found {
+		return rawCmd, nil
+	}
+
+	if !strings.Contains(cmd, aliasTemplateActionMark) {
+		return strings.Join(append([]string{cmd}, args...), " "), nil
+	}
+
+	var positional, flags []string
+	for idx := 0; idx < len(args); idx++ {
+		arg := args[idx]
+		if !strings.HasPrefix(arg, "-") {
+			positional = append(positional, arg)
+			continue
+		}
+		flags = append(flags, arg)
+		// the cluster flags are the only ones whose value is known to be passed as a separate argument
+		if isClusterFlag(arg) && !strings.Contains(arg, "=") && idx+1 < len(args) {
+			idx++
+			flags = append(flags, args[idx])
+		}
+	}
+
+	data := map[string]string{
+		"args": strings.Join(positional, " "),
+	}
+	for idx, arg := range positional {
+		data[fmt.Sprintf("arg%d", idx+1)] = arg
+	}
+
+	tpl, err := parseAliasCommand(name, cmd)
+	if err != nil {
+		return "", NewExecutionCommandError(aliasInvalidCmdMsgFmt, name, err.Error())
+	}
+
+	var out strings.Builder
+	if err := tpl.Execute(&out, data); err != nil {
+		return "", NewExecutionCommandError(aliasMissingArgsMsgFmt, name, cmd)
+	}
+
+	return strings.Join(append([]string{out.String()}, flags...), " "), nil
+}
+
+func parseAliasCommand(name, cmd string) (*template.Template, error) {
+	return template.New(name).Option("missingkey=error").Parse(cmd)
+}
+
+// runAliasCommand lists, adds or removes command aliases. The added and removed aliases are persisted, so they survive restarts.
+func (e *DefaultExecutor) runAliasCommand(ctx context.Context, args []string, clusterName string) (string, error) {
+	if len(args) < 2 {
+		return "", errInvalidCommand
+	}
+
+	var cmdVerb = args[1]
+	defer func() {
+		cmdToReport := fmt.Sprintf("%s %s", args[0], cmdVerb)
+		e.reportCommand(cmdToReport, false)
+	}()
+
+	switch aliasAction(cmdVerb) {
+	case aliasList:
+		return e.makeAliasesList(clusterName), nil
+
+	case aliasAdd:
+		if len(args) < 4 {
+			return "", NewExecutionCommandError(aliasAddUsageMsg)
+		}
+		name := args[2]
+		cmd := unquoteAliasCommand(strings.Join(args[3:], " "))
+		if err := validateAlias(name, cmd); err != nil {
+			return "", err
+		}
+
+		e.log.Debugf("Adding %q alias...", name)
+		if err := e.cfgManager.PersistAlias(ctx, name, cmd); err != nil {
+			return "", fmt.Errorf("while persisting %q alias: %w", name, err)
+		}
+		e.aliases.Set(name, cmd)
+
+		return fmt.Sprintf(aliasAdded, name, clusterName, cmd), nil
+
+	case aliasRemove:
+		if len(args) < 3 {
+			return "", NewExecutionCommandError(aliasRemoveUsageMsg)
+		}
+		name := args[2]
+		if _, found := e.aliases.Get(name); !found {
+			return "", NewExecutionCommandError(aliasNotFoundMsgFmt, name, clusterName)
+		}
+
+		e.log.Debugf("Removing %q alias...", name)
+		if err := e.cfgManager.PersistAlias(ctx, name, ""); err != nil {
+			return "", fmt.Errorf("while persisting removal of %q alias: %w", name, err)
+		}
+		e.aliases.Remove(name)
+
+		return fmt.Sprintf(aliasRemoved, name, clusterName), nil
+	}
+
+	cmdVerb = anonymizedInvalidVerb // prevent passing any personal information
+	return "", errUnsupportedCommand
+}
+
+func (e *DefaultExecutor) makeAliasesList(clusterName string) string {
+	aliases := e.aliases.List()
+	if len(aliases) == 0 {
+		return fmt.Sprintf(aliasNoAliases, clusterName)
+	}
+
+	buf := new(bytes.Buffer)
+	w := tabwriter.NewWriter(buf, 5, 0, 2, ' ', 0)
+
+	fmt.Fprintln(w, "ALIAS\tCOMMAND")
+	for _, alias := range aliases {
+		fmt.Fprintf(w, "%s\t%s\n", alias.Name, alias.Command)
+	}
+
+	w.Flush()
+	return buf.String()
+}
+
+func validateAlias(name, cmd string) error {
+	if slices.Contains(aliasReservedNames, name) {
+		return NewExecutionCommandError(aliasReservedMsgFmt, name)
+	}
+	if !aliasNameRegex.MatchString(name) {
+		return NewExecutionCommandError(aliasInvalidNameMsgFmt, name)
+	}
+	if _, err := parseAliasCommand(name, cmd); err != nil {
+		return NewExecutionCommandError(aliasInvalidCmdMsgFmt, name, err.Error())
+	}
+	return nil
+}
+
+// unquoteAliasCommand removes the quotes surrounding the whole alias command, e.g. `"kubectl get pods"`.
+func unquoteAliasCommand(cmd string) string {
+	if len(cmd) >= 2 && cmd[0] == '"' && cmd[len(cmd)-1] == '"' {
+		return cmd[1 : len(cmd)-1]
+	}
+	return cmd
+}
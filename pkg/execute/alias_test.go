@@ -0,0 +1,219 @@
+package execute
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	logtest "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+func TestAliasStoreExpand(t *testing.T) {
+	// given
+	logger, _ := logtest.NewNullLogger()
+	store := NewAliasStore(logger, config.Aliases{
+		"gp":       "kubectl get pods -n {{.arg1}}",
+		"kgp":      "kubectl get pods",
+		"desc":     "kubectl describe {{.arg1}} {{.arg2}}",
+		"all":      "kubectl get {{.args}}",
+		"disabled": "",
+		"help":     "kubectl get pods",
+	})
+
+	tests := []struct {
+		name string
+
+		command string
+
+		expCommand  string
+		expErrorMsg string
+	}{
+		{
+			name:       "Should expand alias with placeholders",
+			command:    "gp team-a",
+			expCommand: "kubectl get pods -n team-a",
+		},
+		{
+			name:       "Should append arguments to alias without placeholders",
+			command:    "kgp -n team-a -o wide",
+			expCommand: "kubectl get pods -n team-a -o wide",
+		},
+		{
+			name:       "Should append flags to expanded alias",
+			command:    "desc pod nginx --filter=Image --cluster-name dev",
+			expCommand: "kubectl describe pod nginx --filter=Image --cluster-name dev",
+		},
+		{
+			name:       "Should expand all arguments",
+			command:    "all pods nginx",
+			expCommand: "kubectl get pods nginx",
+		},
+		{
+			name:       "Should not change other commands",
+			command:    "get pods",
+			expCommand: "get pods",
+		},
+		{
+			name:       "Should skip disabled alias",
+			command:    "disabled pods",
+			expCommand: "disabled pods",
+		},
+		{
+			name:       "Should skip alias with reserved name",
+			command:    "help",
+			expCommand: "help",
+		},
+		{
+			name:        "Should return error on missing arguments",
+			command:     "desc pod",
+			expErrorMsg: "Sorry, the 'desc' alias requires more arguments, as it expands to `kubectl describe {{.arg1}} {{.arg2}}`.",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// when
+			got, err := store.Expand(tc.command)
+
+			// then
+			if tc.expErrorMsg != "" {
+				require.Error(t, err)
+				assert.True(t, IsExecutionCommandError(err))
+				assert.EqualError(t, err, tc.expErrorMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expCommand, got)
+		})
+	}
+}
+
+func TestDefaultExecutorRunAliasCommand(t *testing.T) {
+	// given
+	logger, _ := logtest.NewNullLogger()
+	persistence := &fakeAliasPersistenceManager{}
+	executor := &DefaultExecutor{
+		log:               logger,
+		analyticsReporter: &fakeAnalyticsReporter{},
+		cfgManager:        persistence,
+		aliases:           NewAliasStore(logger, config.Aliases{"kgp": "kubectl get pods"}),
+	}
+
+	// when
+	out, err := executor.runAliasCommand(context.Background(), []string{"alias", "add", "gp", `"kubectl`, "get", "pods", "-n", `{{.arg1}}"`}, "dev")
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, "I have added 'gp' alias on 'dev' cluster. It expands to `kubectl get pods -n {{.arg1}}`.", out)
+	assert.Equal(t, map[string]string{"gp": "kubectl get pods -n {{.arg1}}"}, persistence.aliases)
+
+	// when
+	out, err = executor.runAliasCommand(context.Background(), []string{"alias", "list"}, "dev")
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, "ALIAS  COMMAND\n"+
+		"gp     kubectl get pods -n {{.arg1}}\n"+
+		"kgp    kubectl get pods\n", out)
+
+	// when
+	out, err = executor.runAliasCommand(context.Background(), []string{"alias", "remove", "kgp"}, "dev")
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, "Done. I removed 'kgp' alias on 'dev' cluster.", out)
+	assert.Equal(t, map[string]string{"gp": "kubectl get pods -n {{.arg1}}", "kgp": ""}, persistence.aliases)
+	_, found := executor.aliases.Get("kgp")
+	assert.False(t, found)
+}
+
+func TestDefaultExecutorRunAliasCommandErrors(t *testing.T) {
+	// given
+	tests := []struct {
+		name string
+
+		args           []string
+		persistenceErr error
+
+		expErrorMsg       string
+		expExecutionError bool
+	}{
+		{
+			name:              "Should reject reserved name",
+			args:              []string{"alias", "add", "kubectl", "get", "pods"},
+			expErrorMsg:       "Sorry, the 'kubectl' name is reserved for a Botkube command, so it cannot be used as an alias.",
+			expExecutionError: true,
+		},
+		{
+			name:              "Should reject invalid name",
+			args:              []string{"alias", "add", "get.pods", "kubectl", "get", "pods"},
+			expErrorMsg:       "Sorry, the 'get.pods' alias name is invalid. It can contain only letters, digits, '-' and '_' characters.",
+			expExecutionError: true,
+		},
+		{
+			name:              "Should reject invalid command",
+			args:              []string{"alias", "add", "gp", "kubectl", "get", "pods", "-n", "{{.arg1"},
+			expErrorMsg:       "Sorry, the 'gp' alias command is invalid: template: gp:1: unclosed action",
+			expExecutionError: true,
+		},
+		{
+			name:              "Should reject missing command",
+			args:              []string{"alias", "add", "gp"},
+			expErrorMsg:       aliasAddUsageMsg,
+			expExecutionError: true,
+		},
+		{
+			name:              "Should reject removing unknown alias",
+			args:              []string{"alias", "remove", "gp"},
+			expErrorMsg:       "Sorry, the 'gp' alias is not defined on cluster 'dev'. Use 'alias list' to see defined aliases.",
+			expExecutionError: true,
+		},
+		{
+			name:           "Should return persistence error",
+			args:           []string{"alias", "add", "gp", "kubectl", "get", "pods"},
+			persistenceErr: errors.New("configmaps \"botkube-startup-config\" not found"),
+			expErrorMsg:    "while persisting \"gp\" alias: configmaps \"botkube-startup-config\" not found",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			logger, _ := logtest.NewNullLogger()
+			executor := &DefaultExecutor{
+				log:               logger,
+				analyticsReporter: &fakeAnalyticsReporter{},
+				cfgManager:        &fakeAliasPersistenceManager{err: tc.persistenceErr},
+				aliases:           NewAliasStore(logger, nil),
+			}
+
+			// when
+			_, err := executor.runAliasCommand(context.Background(), tc.args, "dev")
+
+			// then
+			require.Error(t, err)
+			assert.Equal(t, tc.expExecutionError, IsExecutionCommandError(err))
+			assert.EqualError(t, err, tc.expErrorMsg)
+			assert.Empty(t, executor.aliases.List())
+		})
+	}
+}
+
+type fakeAliasPersistenceManager struct {
+	fakeCfgPersistenceManager
+	aliases map[string]string
+	err     error
+}
+
+func (f *fakeAliasPersistenceManager) PersistAlias(_ context.Context, name, command string) error {
+	if f.err != nil {
+		return f.err
+	}
+	if f.aliases == nil {
+		f.aliases = map[string]string{}
+	}
+	f.aliases[name] = command
+	return nil
+}
@@ -0,0 +1,175 @@
+package audit
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/multierror"
+)
+
+const (
+	defaultListLimit   = 10
+	defaultSaveTimeout = 5 * time.Second
+	// recentEntriesLimit is the number of entries kept in memory, if none of the stores can list entries.
+	recentEntriesLimit = 100
+)
+
+// Status describes the result of an executed command.
+type Status string
+
+const (
+	// StatusSucceeded means that the command was executed successfully.
+	StatusSucceeded Status = "Succeeded"
+	// StatusFailed means that the command failed, e.g. kubectl exited with a non-zero code or the command was forbidden.
+	StatusFailed Status = "Failed"
+	// StatusError means that Botkube couldn't execute the command due to an internal error.
+	StatusError Status = "Error"
+)
+
+// Entry describes a single command executed by a bot.
+type Entry struct {
+	Time      time.Time `json:"time"`
+	Cluster   string    `json:"cluster"`
+	CommGroup string    `json:"commGroup"`
+	Platform  string    `json:"platform"`
+	Channel   string    `json:"channel"`
+	User      string    `json:"user"`
+	UserID    string    `json:"userID,omitempty"`
+	Command   string    `json:"command"`
+	Status    Status    `json:"status"`
+	// OutputSHA256 is the hex-encoded SHA-256 hash of the command response. It allows verifying the response without storing it.
+	OutputSHA256 string `json:"outputSHA256"`
+}
+
+// HashOutput returns the hex-encoded SHA-256 hash of a given output.
+func HashOutput(out []byte) string {
+	sum := sha256.Sum256(out)
+	return hex.EncodeToString(sum[:])
+}
+
+// Store persists the audit entries.
+type Store interface {
+	Save(ctx context.Context, entry Entry) error
+}
+
+// Lister returns the most recent audit entries, starting from the newest one.
+type Lister interface {
+	List(ctx context.Context, limit int) ([]Entry, error)
+}
+
+// Recorder records the audit entries in all configured stores.
+type Recorder struct {
+	log         logrus.FieldLogger
+	stores      []Store
+	listLimit   int
+	saveTimeout time.Duration
+
+	mu     sync.RWMutex
+	recent []Entry
+}
+
+// NewRecorder returns a new Recorder instance with stores enabled in a given configuration.
+func NewRecorder(log logrus.FieldLogger, cfg config.CommandAudit, k8sCli kubernetes.Interface) *Recorder {
+	var stores []Store
+	if cfg.ConfigMap.Enabled {
+		stores = append(stores, NewConfigMapStore(k8sCli, cfg.ConfigMap))
+	}
+	if cfg.File.Enabled {
+		stores = append(stores, NewFileStore(cfg.File))
+	}
+	if cfg.Webhook.Enabled {
+		stores = append(stores, NewWebhookStore(cfg.Webhook))
+	}
+	if cfg.Elasticsearch.Enabled {
+		stores = append(stores, NewElasticsearchStore(cfg.Elasticsearch))
+	}
+
+	return newRecorder(log, cfg.ListLimit, cfg.SaveTimeout, stores...)
+}
+
+func newRecorder(log logrus.FieldLogger, listLimit int, saveTimeout time.Duration, stores ...Store) *Recorder {
+	if listLimit <= 0 {
+		listLimit = defaultListLimit
+	}
+	if saveTimeout <= 0 {
+		saveTimeout = defaultSaveTimeout
+	}
+	return &Recorder{
+		log:         log,
+		stores:      stores,
+		listLimit:   listLimit,
+		saveTimeout: saveTimeout,
+	}
+}
+
+// Record saves a given entry in all stores. Each save is bounded by the save timeout.
+// Errors are logged, as they shouldn't prevent returning the command response.
+func (r *Recorder) Record(ctx context.Context, entry Entry) {
+	r.mu.Lock()
+	r.recent = append(r.recent, entry)
+	if len(r.recent) > recentEntriesLimit {
+		r.recent = r.recent[len(r.recent)-recentEntriesLimit:]
+	}
+	r.mu.Unlock()
+
+	errs := multierror.New()
+	for _, store := range r.stores {
+		if err := r.save(ctx, store, entry); err != nil {
+			errs = multierror.Append(errs, err)
+		}
+	}
+	if err := errs.ErrorOrNil(); err != nil {
+		r.log.WithField("command", entry.Command).Errorf("while recording audit entry: %s", err.Error())
+	}
+}
+
+func (r *Recorder) save(ctx context.Context, store Store, entry Entry) error {
+	ctx, cancel := context.WithTimeout(ctx, r.saveTimeout)
+	defer cancel()
+
+	err := store.Save(ctx, entry)
+	if err != nil && ctx.Err() == context.DeadlineExceeded {
+		return fmt.Errorf("timed out after %s: %w", r.saveTimeout, err)
+	}
+	return err
+}
+
+// List returns the most recent entries, starting from the newest one. The entries are read from the first store
+// which can list them. If there is no such store, the entries recorded since the Botkube start are returned.
+func (r *Recorder) List(ctx context.Context) ([]Entry, error) {
+	for _, store := range r.stores {
+		lister, ok := store.(Lister)
+		if !ok {
+			continue
+		}
+		entries, err := lister.List(ctx, r.listLimit)
+		if err != nil {
+			return nil, fmt.Errorf("while listing audit entries: %w", err)
+		}
+		return entries, nil
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return newestFirst(r.recent, r.listLimit), nil
+}
+
+// newestFirst returns up to limit last entries in the reversed order.
+func newestFirst(entries []Entry, limit int) []Entry {
+	if len(entries) > limit {
+		entries = entries[len(entries)-limit:]
+	}
+	out := make([]Entry, 0, len(entries))
+	for i := len(entries) - 1; i >= 0; i-- {
+		out = append(out, entries[i])
+	}
+	return out
+}
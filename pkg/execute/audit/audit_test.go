@@ -0,0 +1,207 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	logtest "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+type listerStore interface {
+	Store
+	Lister
+}
+
+func TestListerStores(t *testing.T) {
+	tests := []struct {
+		name     string
+		newStore func(t *testing.T) listerStore
+	}{
+		{
+			name: "ConfigMap",
+			newStore: func(t *testing.T) listerStore {
+				return NewConfigMapStore(fake.NewSimpleClientset(), config.AuditConfigMapStore{
+					Name:       "audit",
+					Namespace:  "botkube",
+					MaxEntries: 3,
+				})
+			},
+		},
+		{
+			name: "File",
+			newStore: func(t *testing.T) listerStore {
+				return NewFileStore(config.AuditFileStore{Path: filepath.Join(t.TempDir(), "audit", "commands.jsonl")})
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// given
+			ctx := context.Background()
+			store := tc.newStore(t)
+
+			empty, err := store.List(ctx, 2)
+			require.NoError(t, err)
+			assert.Empty(t, empty)
+
+			for _, cmd := range []string{"get po", "get deploy", "logs nginx", "describe po nginx"} {
+				require.NoError(t, store.Save(ctx, fixEntry(cmd)))
+			}
+
+			// when
+			got, err := store.List(ctx, 2)
+
+			// then
+			require.NoError(t, err)
+			assert.Equal(t, []Entry{fixEntry("describe po nginx"), fixEntry("logs nginx")}, got)
+		})
+	}
+}
+
+func TestHTTPStores(t *testing.T) {
+	tests := []struct {
+		name     string
+		newStore func(url string) *HTTPStore
+
+		expPath   string
+		expHeader string
+		expAuth   bool
+	}{
+		{
+			name: "Webhook",
+			newStore: func(url string) *HTTPStore {
+				return NewWebhookStore(config.AuditWebhookStore{
+					URL:     url + "/audit",
+					Headers: map[string]string{"X-Token": "secret"},
+				})
+			},
+			expPath:   "/audit",
+			expHeader: "secret",
+		},
+		{
+			name: "Elasticsearch",
+			newStore: func(url string) *HTTPStore {
+				return NewElasticsearchStore(config.AuditElasticsearchStore{
+					Server:   url + "/",
+					Username: "elastic",
+					Password: "pass",
+					Index:    "botkube-audit",
+				})
+			},
+			expPath: "/botkube-audit/_doc",
+			expAuth: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// given
+			var (
+				gotPath   string
+				gotHeader string
+				gotAuth   bool
+				gotEntry  Entry
+			)
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gotPath = r.URL.Path
+				gotHeader = r.Header.Get("X-Token")
+				user, pass, ok := r.BasicAuth()
+				gotAuth = ok && user == "elastic" && pass == "pass"
+				assert.NoError(t, json.NewDecoder(r.Body).Decode(&gotEntry))
+				w.WriteHeader(http.StatusCreated)
+			}))
+			defer srv.Close()
+
+			// when
+			err := tc.newStore(srv.URL).Save(context.Background(), fixEntry("get po"))
+
+			// then
+			require.NoError(t, err)
+			assert.Equal(t, tc.expPath, gotPath)
+			assert.Equal(t, tc.expHeader, gotHeader)
+			assert.Equal(t, tc.expAuth, gotAuth)
+			assert.Equal(t, fixEntry("get po"), gotEntry)
+		})
+	}
+}
+
+func TestHTTPStoreError(t *testing.T) {
+	// given
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte("invalid token"))
+	}))
+	defer srv.Close()
+
+	// when
+	err := NewWebhookStore(config.AuditWebhookStore{URL: srv.URL}).Save(context.Background(), fixEntry("get po"))
+
+	// then
+	assert.EqualError(t, err, "while sending audit entry: unexpected status code 401: invalid token")
+}
+
+func TestRecorderListWithoutLister(t *testing.T) {
+	// given
+	logger, _ := logtest.NewNullLogger()
+	recorder := newRecorder(logger, 2, 0)
+	for _, cmd := range []string{"get po", "get deploy", "logs nginx"} {
+		recorder.Record(context.Background(), fixEntry(cmd))
+	}
+
+	// when
+	got, err := recorder.List(context.Background())
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, []Entry{fixEntry("logs nginx"), fixEntry("get deploy")}, got)
+}
+
+func TestRecorderRecordSaveTimeout(t *testing.T) {
+	// given
+	logger, hook := logtest.NewNullLogger()
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer srv.Close()
+	defer close(release)
+
+	fileStore := NewFileStore(config.AuditFileStore{Path: filepath.Join(t.TempDir(), "commands.jsonl")})
+	recorder := newRecorder(logger, 2, 20*time.Millisecond, NewWebhookStore(config.AuditWebhookStore{URL: srv.URL}), fileStore)
+
+	// when
+	start := time.Now()
+	recorder.Record(context.Background(), fixEntry("get po"))
+
+	// then
+	assert.Less(t, time.Since(start), time.Second)
+	require.Len(t, hook.AllEntries(), 1)
+	assert.Contains(t, hook.LastEntry().Message, "timed out after 20ms")
+
+	got, err := fileStore.List(context.Background(), 2)
+	require.NoError(t, err)
+	assert.Equal(t, []Entry{fixEntry("get po")}, got, "other stores should still save the entry")
+}
+
+func fixEntry(cmd string) Entry {
+	return Entry{
+		Time:         time.Date(2022, 10, 10, 12, 0, 0, 0, time.UTC),
+		Cluster:      "dev",
+		CommGroup:    "default-group",
+		Platform:     "socketslack",
+		Channel:      "botkube",
+		User:         "Joe",
+		Command:      cmd,
+		Status:       StatusSucceeded,
+		OutputSHA256: HashOutput([]byte(cmd)),
+	}
+}
@@ -0,0 +1,126 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/util/retry"
+
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+const (
+	defaultConfigMapMaxEntries = 100
+	configMapEntriesKey        = "entries.json"
+)
+
+var _ Lister = &ConfigMapStore{}
+
+// ConfigMapStore keeps the most recent audit entries in a ConfigMap. The older entries are dropped.
+type ConfigMapStore struct {
+	k8sCli     kubernetes.Interface
+	name       string
+	namespace  string
+	maxEntries int
+
+	mu sync.Mutex
+}
+
+// NewConfigMapStore returns a new ConfigMapStore instance.
+func NewConfigMapStore(k8sCli kubernetes.Interface, cfg config.AuditConfigMapStore) *ConfigMapStore {
+	maxEntries := cfg.MaxEntries
+	if maxEntries <= 0 {
+		maxEntries = defaultConfigMapMaxEntries
+	}
+	return &ConfigMapStore{
+		k8sCli:     k8sCli,
+		name:       cfg.Name,
+		namespace:  cfg.Namespace,
+		maxEntries: maxEntries,
+	}
+}
+
+// Save appends a given entry to the ConfigMap. The ConfigMap is created if it doesn't exist.
+func (s *ConfigMapStore) Save(ctx context.Context, entry Entry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		cm, entries, err := s.get(ctx)
+		if err != nil {
+			return err
+		}
+
+		entries = append(entries, entry)
+		if len(entries) > s.maxEntries {
+			entries = entries[len(entries)-s.maxEntries:]
+		}
+
+		raw, err := json.Marshal(entries)
+		if err != nil {
+			return fmt.Errorf("while marshalling audit entries: %w", err)
+		}
+
+		if cm == nil {
+			cm = &v1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: s.name, Namespace: s.namespace},
+				Data:       map[string]string{configMapEntriesKey: string(raw)},
+			}
+			_, err = s.k8sCli.CoreV1().ConfigMaps(s.namespace).Create(ctx, cm, metav1.CreateOptions{})
+			if err != nil {
+				return fmt.Errorf("while creating the audit ConfigMap: %w", err)
+			}
+			return nil
+		}
+
+		cm = cm.DeepCopy()
+		if cm.Data == nil {
+			cm.Data = map[string]string{}
+		}
+		cm.Data[configMapEntriesKey] = string(raw)
+		_, err = s.k8sCli.CoreV1().ConfigMaps(s.namespace).Update(ctx, cm, metav1.UpdateOptions{})
+		if err != nil {
+			// wrapped with %w, so the conflict error is still detected by retry.RetryOnConflict
+			return fmt.Errorf("while updating the audit ConfigMap: %w", err)
+		}
+		return nil
+	})
+}
+
+// List returns the most recent entries from the ConfigMap, starting from the newest one.
+func (s *ConfigMapStore) List(ctx context.Context, limit int) ([]Entry, error) {
+	_, entries, err := s.get(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return newestFirst(entries, limit), nil
+}
+
+// get returns the ConfigMap with stored entries. The ConfigMap is nil if it doesn't exist yet.
+func (s *ConfigMapStore) get(ctx context.Context) (*v1.ConfigMap, []Entry, error) {
+	cm, err := s.k8sCli.CoreV1().ConfigMaps(s.namespace).Get(ctx, s.name, metav1.GetOptions{})
+	switch {
+	case err == nil:
+	case apierrors.IsNotFound(err):
+		return nil, nil, nil
+	default:
+		return nil, nil, fmt.Errorf("while getting the audit ConfigMap: %w", err)
+	}
+
+	raw, exists := cm.Data[configMapEntriesKey]
+	if !exists {
+		return cm, nil, nil
+	}
+
+	var entries []Entry
+	if err := json.Unmarshal([]byte(raw), &entries); err != nil {
+		return nil, nil, fmt.Errorf("while unmarshalling %q: %w", configMapEntriesKey, err)
+	}
+	return cm, entries, nil
+}
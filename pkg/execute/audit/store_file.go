@@ -0,0 +1,90 @@
+package audit
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+var _ Lister = &FileStore{}
+
+// FileStore appends audit entries to a file as JSON lines. It's meant to be used with a mounted persistent volume.
+type FileStore struct {
+	path string
+
+	mu sync.Mutex
+}
+
+// NewFileStore returns a new FileStore instance.
+func NewFileStore(cfg config.AuditFileStore) *FileStore {
+	return &FileStore{path: cfg.Path}
+}
+
+// Save appends a given entry to the file. The file and its parent directories are created if they don't exist.
+func (s *FileStore) Save(_ context.Context, entry Entry) error {
+	raw, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("while marshalling audit entry: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o750); err != nil {
+		return fmt.Errorf("while creating audit log directory: %w", err)
+	}
+
+	file, err := os.OpenFile(s.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o640)
+	if err != nil {
+		return fmt.Errorf("while opening audit log file: %w", err)
+	}
+	defer file.Close()
+
+	if _, err := file.Write(append(raw, '\n')); err != nil {
+		return fmt.Errorf("while writing audit entry: %w", err)
+	}
+	return nil
+}
+
+// List returns the most recent entries from the file, starting from the newest one.
+func (s *FileStore) List(_ context.Context, limit int) ([]Entry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	file, err := os.Open(s.path)
+	switch {
+	case err == nil:
+	case errors.Is(err, fs.ErrNotExist):
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("while opening audit log file: %w", err)
+	}
+	defer file.Close()
+
+	var entries []Entry
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var entry Entry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return nil, fmt.Errorf("while unmarshalling audit entry: %w", err)
+		}
+		entries = append(entries, entry)
+		// keep only the entries which can be returned
+		if len(entries) > 2*limit {
+			entries = entries[len(entries)-limit:]
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("while reading audit log file: %w", err)
+	}
+
+	return newestFirst(entries, limit), nil
+}
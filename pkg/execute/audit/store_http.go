@@ -0,0 +1,76 @@
+package audit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+const defaultHTTPCliTimeout = 30 * time.Second
+
+// HTTPStore sends audit entries as JSON documents to an external HTTP endpoint.
+type HTTPStore struct {
+	httpCli  *http.Client
+	url      string
+	headers  map[string]string
+	username string
+	password string
+}
+
+// NewWebhookStore returns a new HTTPStore instance which sends entries to a given webhook.
+func NewWebhookStore(cfg config.AuditWebhookStore) *HTTPStore {
+	return &HTTPStore{
+		httpCli: &http.Client{Timeout: defaultHTTPCliTimeout},
+		url:     cfg.URL,
+		headers: cfg.Headers,
+	}
+}
+
+// NewElasticsearchStore returns a new HTTPStore instance which indexes entries in a given Elasticsearch index.
+func NewElasticsearchStore(cfg config.AuditElasticsearchStore) *HTTPStore {
+	return &HTTPStore{
+		httpCli:  &http.Client{Timeout: defaultHTTPCliTimeout},
+		url:      fmt.Sprintf("%s/%s/_doc", strings.TrimSuffix(cfg.Server, "/"), cfg.Index),
+		username: cfg.Username,
+		password: cfg.Password,
+	}
+}
+
+// Save sends a given entry to the endpoint.
+func (s *HTTPStore) Save(ctx context.Context, entry Entry) error {
+	raw, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("while marshalling audit entry: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(raw))
+	if err != nil {
+		return fmt.Errorf("while creating request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range s.headers {
+		req.Header.Set(key, value)
+	}
+	if s.username != "" {
+		req.SetBasicAuth(s.username, s.password)
+	}
+
+	resp, err := s.httpCli.Do(req)
+	if err != nil {
+		return fmt.Errorf("while sending audit entry: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("while sending audit entry: unexpected status code %d: %s", resp.StatusCode, string(body))
+	}
+	return nil
+}
@@ -0,0 +1,236 @@
+package execute
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/utils/strings/slices"
+
+	"github.com/kubeshop/botkube/pkg/bot/interactive"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/execute/kubectl"
+)
+
+const (
+	commandNotPermittedMsgFmt = "Command %q is not permitted in this channel."
+	normalizedKubectlAlias    = "kubectl"
+)
+
+// kubectlCommandAliases holds all kubectl aliases supported by the kubectl executor.
+var kubectlCommandAliases = []string{"kubectl", "kc", "k"}
+
+// kubectlValueFlags holds kubectl flags which take a value as a separate argument, e.g. `-n prod`.
+// Other flags are treated as boolean ones, so they don't consume the following argument.
+var kubectlValueFlags = []string{
+	"-n", "--namespace", "--context", "--cluster", "--cluster-name", "--user", "-s", "--server", "--kubeconfig",
+	"--token", "--as", "--as-group", "--as-uid", "--cache-dir", "--certificate-authority", "--client-certificate",
+	"--client-key", "--tls-server-name", "--request-timeout", "--profile", "--profile-output", "-v", "--v",
+	"--vmodule", "--log-file", "--log-file-max-size", "--password", "--username",
+	"-o", "--output", "-l", "--selector", "--field-selector", "-c", "--container", "-f", "--filename",
+	"-k", "--kustomize", "-L", "--label-columns", "--sort-by", "--template", "--since", "--since-time", "--tail",
+	"--grace-period", "--timeout", "-p", "--patch", "--type", "--image", "--replicas", "--for", "--chunk-size",
+	"--subresource", "--field-manager", "--raw",
+}
+
+// builtinResourceNames maps the singular names and short names of the built-in Kubernetes resources to their plural names.
+// It's used to normalize resources when the resource names discovered from the cluster are not available.
+var builtinResourceNames = map[string]string{
+	"pod": "pods", "po": "pods",
+	"service": "services", "svc": "services",
+	"deployment": "deployments", "deploy": "deployments",
+	"replicaset": "replicasets", "rs": "replicasets",
+	"statefulset": "statefulsets", "sts": "statefulsets",
+	"daemonset": "daemonsets", "ds": "daemonsets",
+	"replicationcontroller": "replicationcontrollers", "rc": "replicationcontrollers",
+	"job":     "jobs",
+	"cronjob": "cronjobs", "cj": "cronjobs",
+	"namespace": "namespaces", "ns": "namespaces",
+	"node": "nodes", "no": "nodes",
+	"configmap": "configmaps", "cm": "configmaps",
+	"secret":         "secrets",
+	"serviceaccount": "serviceaccounts", "sa": "serviceaccounts",
+	"persistentvolume": "persistentvolumes", "pv": "persistentvolumes",
+	"persistentvolumeclaim": "persistentvolumeclaims", "pvc": "persistentvolumeclaims",
+	"storageclass": "storageclasses", "sc": "storageclasses",
+	"ingress": "ingresses", "ing": "ingresses",
+	"networkpolicy": "networkpolicies", "netpol": "networkpolicies",
+	"endpoint": "endpoints", "ep": "endpoints",
+	"event": "events", "ev": "events",
+	"horizontalpodautoscaler": "horizontalpodautoscalers", "hpa": "horizontalpodautoscalers",
+	"poddisruptionbudget": "poddisruptionbudgets", "pdb": "poddisruptionbudgets",
+	"limitrange": "limitranges", "limits": "limitranges",
+	"resourcequota": "resourcequotas", "quota": "resourcequotas",
+	"role":                     "roles",
+	"rolebinding":              "rolebindings",
+	"clusterrole":              "clusterroles",
+	"clusterrolebinding":       "clusterrolebindings",
+	"customresourcedefinition": "customresourcedefinitions", "crd": "customresourcedefinitions", "crds": "customresourcedefinitions",
+}
+
+// isCommandPermitted returns true if a given command can be executed in the current channel.
+// The confirm and cancel commands are always permitted, as the confirmed command is checked again before it's executed.
+func (e *DefaultExecutor) isCommandPermitted(cmd string) bool {
+	args := strings.Fields(cmd)
+	if len(args) > 0 && (args[0] == confirmCommandName || args[0] == cancelCommandName) {
+		return true
+	}
+
+	var resourceVariants kubectl.ResourceVariantsFunc
+	if e.kubectlExecutor != nil && e.kubectlExecutor.kcChecker != nil {
+		resourceVariants = e.kubectlExecutor.kcChecker.ResourceVariants
+	}
+	return isCommandPermitted(e.conversation.Commands, cmd, resourceVariants)
+}
+
+// IsCommandPermitted returns true if a given command can be executed according to the channel command restrictions.
+// The kubectl resources are normalized with the built-in Kubernetes resource names, so `pod`, `pods` and `po` are equivalent.
+func IsCommandPermitted(cfg config.ChannelCommands, cmd string) bool {
+	return isCommandPermitted(cfg, cmd, nil)
+}
+
+// isCommandPermitted checks a given command against the channel command restrictions. If resourceVariants is set,
+// the kubectl resources are additionally normalized with the resource names discovered from the cluster.
+func isCommandPermitted(cfg config.ChannelCommands, cmd string, resourceVariants kubectl.ResourceVariantsFunc) bool {
+	for _, blocked := range cfg.Blocked {
+		if commandMatches(blocked, cmd, resourceVariants) {
+			return false
+		}
+	}
+
+	if len(cfg.Allowed) == 0 {
+		return true
+	}
+
+	for _, allowed := range cfg.Allowed {
+		if commandMatches(allowed, cmd, resourceVariants) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// CommandNotPermittedMessage returns a message that informs user that a given command is not permitted.
+func CommandNotPermittedMessage(cmd string) interactive.Message {
+	return interactive.Message{
+		Base: interactive.Base{
+			Description: fmt.Sprintf(commandNotPermittedMsgFmt, strings.TrimSpace(cmd)),
+		},
+	}
+}
+
+// commandMatches returns true if a given command starts with a given pattern.
+// Kubectl aliases are normalized, so the `kubectl delete` pattern matches `k delete pod`, `kc delete pod`,
+// and `delete pod`, as kubectl commands can be also executed without the alias.
+// Kubectl patterns are matched against the kubectl verb and resource, so flags placed anywhere in the command,
+// such as `kubectl -n prod delete pod`, don't affect the result. The resource is compared in its plural form,
+// so the `kubectl delete pod` pattern matches also `kubectl delete pods` and `kubectl delete po`.
+func commandMatches(pattern, cmd string, resourceVariants kubectl.ResourceVariantsFunc) bool {
+	patternArgs := normalizeCommandArgs(pattern)
+	if len(patternArgs) == 0 {
+		return false
+	}
+
+	cmdArgs := normalizeCommandArgs(cmd)
+	if hasArgsPrefix(cmdArgs, patternArgs) {
+		return true
+	}
+
+	isKubectlPattern := patternArgs[0] == normalizedKubectlAlias && len(patternArgs) > 1
+	if !isKubectlPattern {
+		return false
+	}
+
+	if len(cmdArgs) > 0 && cmdArgs[0] == normalizedKubectlAlias {
+		cmdArgs = cmdArgs[1:]
+	}
+	return hasArgsPrefix(
+		normalizeKubectlResource(kubectlPositionalArgs(cmdArgs), resourceVariants),
+		normalizeKubectlResource(kubectlPositionalArgs(patternArgs[1:]), resourceVariants),
+	)
+}
+
+// normalizeKubectlResource replaces the resource in kubectl positional arguments with its plural name.
+func normalizeKubectlResource(args []string, resourceVariants kubectl.ResourceVariantsFunc) []string {
+	if len(args) < 2 {
+		return args
+	}
+
+	args[1] = canonicalResourceName(args[1], resourceVariants)
+	return args
+}
+
+// canonicalResourceName returns the plural name of a given resource. Unknown resources are returned in lower case.
+func canonicalResourceName(resource string, resourceVariants kubectl.ResourceVariantsFunc) string {
+	resource = strings.ToLower(resource)
+	if plural, found := builtinResourceNames[resource]; found {
+		return plural
+	}
+
+	if resourceVariants == nil {
+		return resource
+	}
+
+	// The resource variants contain the plural name for a given kind or short name, other entries are empty or
+	// equal to the input resource.
+	for _, variant := range resourceVariants(resource) {
+		variant = strings.ToLower(variant)
+		if variant != "" && variant != resource {
+			return variant
+		}
+	}
+	return resource
+}
+
+// kubectlPositionalArgs returns kubectl arguments without flags and their values.
+// The `TYPE/NAME` resource notation is split into separate arguments, so `delete pod/nginx` is returned as `delete pod nginx`.
+func kubectlPositionalArgs(args []string) []string {
+	var out []string
+	for idx := 0; idx < len(args); idx++ {
+		arg := args[idx]
+		if arg == "--" {
+			break
+		}
+
+		if strings.HasPrefix(arg, "-") && len(arg) > 1 {
+			if !strings.Contains(arg, "=") && slices.Contains(kubectlValueFlags, arg) {
+				idx++ // skip flag value
+			}
+			continue
+		}
+
+		if len(out) == 1 {
+			resource, name, found := strings.Cut(arg, "/")
+			if found && resource != "" {
+				out = append(out, resource, name)
+				continue
+			}
+		}
+		out = append(out, arg)
+	}
+
+	return out
+}
+
+func hasArgsPrefix(args, prefix []string) bool {
+	if len(args) < len(prefix) {
+		return false
+	}
+
+	for idx, arg := range prefix {
+		if !strings.EqualFold(arg, args[idx]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func normalizeCommandArgs(cmd string) []string {
+	args := strings.Fields(cmd)
+	if len(args) > 0 && slices.Contains(kubectlCommandAliases, args[0]) {
+		args[0] = normalizedKubectlAlias
+	}
+
+	return args
+}
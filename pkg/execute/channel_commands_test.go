@@ -0,0 +1,223 @@
+package execute
+
+import (
+	"context"
+	"testing"
+
+	logtest "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/execute/audit"
+)
+
+func TestIsCommandPermitted(t *testing.T) {
+	// given
+	testCases := []struct {
+		Name              string
+		Config            config.ChannelCommands
+		Command           string
+		ExpectedPermitted bool
+	}{
+		{
+			Name:              "No restrictions",
+			Config:            config.ChannelCommands{},
+			Command:           "kubectl delete pod nginx",
+			ExpectedPermitted: true,
+		},
+		{
+			Name:              "Blocked command",
+			Config:            config.ChannelCommands{Blocked: []string{"kubectl delete"}},
+			Command:           " kubectl delete pod nginx",
+			ExpectedPermitted: false,
+		},
+		{
+			Name:              "Blocked command with kubectl alias",
+			Config:            config.ChannelCommands{Blocked: []string{"kubectl delete"}},
+			Command:           "kc delete pod nginx",
+			ExpectedPermitted: false,
+		},
+		{
+			Name:              "Blocked command without kubectl alias",
+			Config:            config.ChannelCommands{Blocked: []string{"kubectl delete"}},
+			Command:           "delete pod nginx",
+			ExpectedPermitted: false,
+		},
+		{
+			Name:              "Blocked command with flags before verb",
+			Config:            config.ChannelCommands{Blocked: []string{"kubectl delete"}},
+			Command:           "kubectl -n prod delete pod nginx",
+			ExpectedPermitted: false,
+		},
+		{
+			Name:              "Blocked command with flags without kubectl alias",
+			Config:            config.ChannelCommands{Blocked: []string{"kubectl delete pod"}},
+			Command:           "--context=prod -n prod delete --now pod nginx",
+			ExpectedPermitted: false,
+		},
+		{
+			Name:              "Blocked resource in type/name notation",
+			Config:            config.ChannelCommands{Blocked: []string{"kubectl delete pod"}},
+			Command:           "kc delete pod/nginx -n prod",
+			ExpectedPermitted: false,
+		},
+		{
+			Name:              "Allowed command with flags before verb",
+			Config:            config.ChannelCommands{Allowed: []string{"kubectl get"}},
+			Command:           "kubectl --namespace prod get pods",
+			ExpectedPermitted: true,
+		},
+		{
+			Name:              "Flag value doesn't match allowed verb",
+			Config:            config.ChannelCommands{Allowed: []string{"kubectl get"}},
+			Command:           "kubectl -n get delete pod nginx",
+			ExpectedPermitted: false,
+		},
+		{
+			Name:              "Command not matching blocked prefix",
+			Config:            config.ChannelCommands{Blocked: []string{"kubectl delete"}},
+			Command:           "kubectl get pods",
+			ExpectedPermitted: true,
+		},
+		{
+			Name:              "Allowed command",
+			Config:            config.ChannelCommands{Allowed: []string{"kubectl get", "ping"}},
+			Command:           "ping",
+			ExpectedPermitted: true,
+		},
+		{
+			Name:              "Command not on allowed list",
+			Config:            config.ChannelCommands{Allowed: []string{"kubectl get", "ping"}},
+			Command:           "kubectl delete pod nginx",
+			ExpectedPermitted: false,
+		},
+		{
+			Name: "Blocked takes precedence over allowed",
+			Config: config.ChannelCommands{
+				Allowed: []string{"kubectl"},
+				Blocked: []string{"kubectl delete"},
+			},
+			Command:           "k delete pod nginx",
+			ExpectedPermitted: false,
+		},
+		{
+			Name:              "Blocked singular resource with plural name in command",
+			Config:            config.ChannelCommands{Blocked: []string{"kubectl delete pod"}},
+			Command:           "kubectl delete pods nginx",
+			ExpectedPermitted: false,
+		},
+		{
+			Name:              "Blocked singular resource with short name in command",
+			Config:            config.ChannelCommands{Blocked: []string{"kubectl delete pod"}},
+			Command:           "kc delete po nginx",
+			ExpectedPermitted: false,
+		},
+		{
+			Name:              "Blocked short name resource with singular name in type/name notation",
+			Config:            config.ChannelCommands{Blocked: []string{"kubectl delete deploy"}},
+			Command:           "kubectl delete Deployment/nginx",
+			ExpectedPermitted: false,
+		},
+		{
+			Name:              "Blocked resource with flags in flag=value form",
+			Config:            config.ChannelCommands{Blocked: []string{"kubectl delete pod"}},
+			Command:           "kubectl --namespace=prod delete --grace-period=0 pods nginx",
+			ExpectedPermitted: false,
+		},
+		{
+			Name:              "Allowed plural resource with short name and flag=value form in command",
+			Config:            config.ChannelCommands{Allowed: []string{"kubectl get pods"}},
+			Command:           "kubectl get -n=prod po",
+			ExpectedPermitted: true,
+		},
+		{
+			Name:              "Blocked resource doesn't match other resource",
+			Config:            config.ChannelCommands{Blocked: []string{"kubectl delete pod"}},
+			Command:           "kubectl delete svc nginx",
+			ExpectedPermitted: true,
+		},
+		{
+			Name:              "Pattern longer than command",
+			Config:            config.ChannelCommands{Blocked: []string{"edit SourceBindings"}},
+			Command:           "edit",
+			ExpectedPermitted: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			// when
+			permitted := IsCommandPermitted(tc.Config, tc.Command)
+
+			// then
+			assert.Equal(t, tc.ExpectedPermitted, permitted)
+		})
+	}
+}
+
+func TestIsCommandPermittedWithDiscoveredResourceVariants(t *testing.T) {
+	// given
+	resourceVariants := func(resource string) []string {
+		switch resource {
+		case "certificate", "cert":
+			return []string{resource, "certificates"}
+		}
+		return []string{resource}
+	}
+	cfg := config.ChannelCommands{Blocked: []string{"kubectl delete certificate"}}
+
+	testCases := []struct {
+		Name              string
+		Command           string
+		ExpectedPermitted bool
+	}{
+		{
+			Name:              "Plural name",
+			Command:           "kubectl delete certificates cert-1",
+			ExpectedPermitted: false,
+		},
+		{
+			Name:              "Short name",
+			Command:           "kubectl delete cert cert-1 --wait=false",
+			ExpectedPermitted: false,
+		},
+		{
+			Name:              "Other resource",
+			Command:           "kubectl delete issuers issuer-1",
+			ExpectedPermitted: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			// when
+			permitted := isCommandPermitted(cfg, tc.Command, resourceVariants)
+
+			// then
+			assert.Equal(t, tc.ExpectedPermitted, permitted)
+		})
+	}
+}
+
+func TestDefaultExecutorChecksExpandedAlias(t *testing.T) {
+	// given
+	logger, _ := logtest.NewNullLogger()
+	executor := &DefaultExecutor{
+		log:               logger,
+		analyticsReporter: &fakeAnalyticsReporter{},
+		notifierHandler:   &fakeNotifierHandler{},
+		aliases:           NewAliasStore(logger, config.Aliases{"x": "kubectl -n prod delete pod nginx"}),
+		conversation: Conversation{
+			ID:       "C123",
+			Commands: config.ChannelCommands{Blocked: []string{"kubectl delete"}},
+		},
+		message: "x",
+	}
+
+	// when
+	msg := executor.execute(context.Background())
+
+	// then
+	assert.Equal(t, `Command "kubectl -n prod delete pod nginx" is not permitted in this channel.`, msg.Description)
+	assert.Equal(t, audit.StatusFailed, executor.auditStatus)
+}
@@ -24,4 +24,7 @@ const (
 
 	// AutomationOrigin is the value for Origin when the command was triggered by an automation.
 	AutomationOrigin Origin = "automation"
+
+	// ScheduleOrigin is the value for Origin when the command was triggered by a schedule.
+	ScheduleOrigin Origin = "schedule"
 )
@@ -0,0 +1,80 @@
+package execute
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+const (
+	configCommandName = "config"
+
+	configReloadDisabledMsgFmt = "Configuration hot reload is disabled on cluster '%s'. Please enable it with the `configWatcher.hotReload` property."
+	configReloadFailedMsgFmt   = "Sorry, the configuration cannot be reloaded on cluster '%s': %s"
+	configNotChangedMsgFmt     = "Configuration on cluster '%s' has not changed."
+	configReloadedMsgFmt       = "Configuration reloaded on cluster '%s'. Updated components: %s."
+	configRestartMsgFmt        = "The following configuration changes on cluster '%s' require restart:\n%s\nRestarting Botkube. Hold on a sec..."
+)
+
+// configAction for options in config commands
+type configAction string
+
+// Config command options
+const (
+	configReload configAction = "reload"
+)
+
+// ConfigReloader reloads the configuration, without restarting Botkube if possible.
+type ConfigReloader interface {
+	// Reload applies the configuration changes. If some of them require restart, Botkube is restarted.
+	Reload(ctx context.Context) (config.Diff, error)
+}
+
+// runConfigCommand reloads the configuration.
+func (e *DefaultExecutor) runConfigCommand(ctx context.Context, args []string, clusterName string) (string, error) {
+	if len(args) < 2 {
+		return "", errInvalidCommand
+	}
+
+	var cmdVerb = args[1]
+	defer func() {
+		cmdToReport := fmt.Sprintf("%s %s", args[0], cmdVerb)
+		e.reportCommand(cmdToReport, false)
+	}()
+
+	switch configAction(cmdVerb) {
+	case configReload:
+		if e.configReloader == nil {
+			return "", NewExecutionCommandError(configReloadDisabledMsgFmt, clusterName)
+		}
+
+		e.log.Info("Reloading configuration...")
+		diff, err := e.configReloader.Reload(ctx)
+		if err != nil {
+			e.log.Errorf("while reloading configuration: %s", err.Error())
+			return "", NewExecutionCommandError(configReloadFailedMsgFmt, clusterName, err.Error())
+		}
+
+		switch {
+		case diff.IsEmpty():
+			return fmt.Sprintf(configNotChangedMsgFmt, clusterName), nil
+		case diff.RequiresRestart():
+			var paths strings.Builder
+			for _, path := range diff.RestartRequiredPaths {
+				paths.WriteString(fmt.Sprintf("  - %s\n", path))
+			}
+			return fmt.Sprintf(configRestartMsgFmt, clusterName, paths.String()), nil
+		}
+
+		components := make([]string, 0, len(diff.Components))
+		for _, component := range diff.Components {
+			components = append(components, string(component))
+		}
+		return fmt.Sprintf(configReloadedMsgFmt, clusterName, strings.Join(components, ", ")), nil
+	}
+
+	cmdVerb = anonymizedInvalidVerb // prevent passing any personal information
+	return "", errUnsupportedCommand
+}
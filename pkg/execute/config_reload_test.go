@@ -0,0 +1,114 @@
+package execute
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	logtest "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+func TestDefaultExecutorRunConfigCommand(t *testing.T) {
+	// given
+	tests := []struct {
+		name string
+
+		diff   config.Diff
+		expOut string
+	}{
+		{
+			name:   "Should report unchanged configuration",
+			expOut: "Configuration on cluster 'dev' has not changed.",
+		},
+		{
+			name: "Should report reloaded components",
+			diff: config.Diff{
+				Components: []config.ReloadComponent{config.ChannelsReloadComponent, config.SourcesReloadComponent},
+			},
+			expOut: "Configuration reloaded on cluster 'dev'. Updated components: channels, sources.",
+		},
+		{
+			name: "Should report restart",
+			diff: config.Diff{
+				Components:           []config.ReloadComponent{config.SourcesReloadComponent},
+				RestartRequiredPaths: []string{"settings.clusterName", "communications.default-group.slack.channels.dev"},
+			},
+			expOut: "The following configuration changes on cluster 'dev' require restart:\n" +
+				"  - settings.clusterName\n" +
+				"  - communications.default-group.slack.channels.dev\n" +
+				"\n" +
+				"Restarting Botkube. Hold on a sec...",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			logger, _ := logtest.NewNullLogger()
+			executor := &DefaultExecutor{
+				log:               logger,
+				analyticsReporter: &fakeAnalyticsReporter{},
+				configReloader:    &fakeConfigReloader{diff: tc.diff},
+			}
+
+			// when
+			out, err := executor.runConfigCommand(context.Background(), []string{"config", "reload"}, "dev")
+
+			// then
+			require.NoError(t, err)
+			assert.Equal(t, tc.expOut, out)
+		})
+	}
+}
+
+func TestDefaultExecutorRunConfigCommandErrors(t *testing.T) {
+	// given
+	tests := []struct {
+		name string
+
+		args     []string
+		reloader ConfigReloader
+		expErr   string
+	}{
+		{
+			name:   "Should report disabled hot reload",
+			args:   []string{"config", "reload"},
+			expErr: "Configuration hot reload is disabled on cluster 'dev'. Please enable it with the `configWatcher.hotReload` property.",
+		},
+		{
+			name:     "Should report invalid configuration",
+			args:     []string{"config", "reload"},
+			reloader: &fakeConfigReloader{err: errors.New("while loading configuration: found critical validation errors")},
+			expErr:   "Sorry, the configuration cannot be reloaded on cluster 'dev': while loading configuration: found critical validation errors",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			logger, _ := logtest.NewNullLogger()
+			executor := &DefaultExecutor{
+				log:               logger,
+				analyticsReporter: &fakeAnalyticsReporter{},
+				configReloader:    tc.reloader,
+			}
+
+			// when
+			_, err := executor.runConfigCommand(context.Background(), tc.args, "dev")
+
+			// then
+			require.Error(t, err)
+			assert.True(t, IsExecutionCommandError(err))
+			assert.EqualError(t, err, tc.expErr)
+		})
+	}
+}
+
+type fakeConfigReloader struct {
+	diff config.Diff
+	err  error
+}
+
+func (f *fakeConfigReloader) Reload(context.Context) (config.Diff, error) {
+	return f.diff, f.err
+}
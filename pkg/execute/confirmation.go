@@ -0,0 +1,256 @@
+package execute
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"k8s.io/utils/strings/slices"
+
+	"github.com/kubeshop/botkube/pkg/bot/interactive"
+	"github.com/kubeshop/botkube/pkg/execute/audit"
+	"github.com/kubeshop/botkube/pkg/execute/command"
+)
+
+const (
+	confirmCommandName = "confirm"
+	cancelCommandName  = "cancel"
+
+	confirmationRequiredMsgFmt  = "The `%s` command requires confirmation. Please confirm it within %s."
+	confirmationCancelled       = "Cancelled. I won't execute the `%s` command on '%s' cluster."
+	confirmationNotFoundMsgFmt  = "Sorry, the command confirmation on cluster '%s' has expired or was already handled. Please send the command again."
+	confirmationOtherUserMsgFmt = "Sorry, only %s can confirm or cancel the `%s` command."
+	confirmationIDBytes         = 8
+)
+
+// pendingConfirmation holds a command waiting for confirmation.
+type pendingConfirmation struct {
+	message        string
+	userID         string
+	user           string
+	conversationID string
+	expiresAt      time.Time
+}
+
+// ConfirmationStore holds commands waiting for confirmation. A single store is shared by all executors,
+// as the command is confirmed with a separate message.
+type ConfirmationStore struct {
+	mu      sync.Mutex
+	pending map[string]pendingConfirmation
+	nowFn   func() time.Time
+}
+
+// NewConfirmationStore returns a new ConfirmationStore instance.
+func NewConfirmationStore() *ConfirmationStore {
+	return &ConfirmationStore{
+		pending: map[string]pendingConfirmation{},
+		nowFn:   time.Now,
+	}
+}
+
+// add stores a given command until the timeout passes and returns its confirmation ID. The expired commands are removed.
+func (s *ConfirmationStore) add(in pendingConfirmation, timeout time.Duration) (string, error) {
+	raw := make([]byte, confirmationIDBytes)
+	if _, err := rand.Read(raw); err != nil {
+		return "", fmt.Errorf("while generating confirmation ID: %w", err)
+	}
+	id := hex.EncodeToString(raw)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.nowFn()
+	for key, item := range s.pending {
+		if now.After(item.expiresAt) {
+			delete(s.pending, key)
+		}
+	}
+
+	in.expiresAt = now.Add(timeout)
+	s.pending[id] = in
+	return id, nil
+}
+
+// get returns a given command, if it hasn't expired yet.
+func (s *ConfirmationStore) get(id string) (pendingConfirmation, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	item, found := s.pending[id]
+	if !found || s.nowFn().After(item.expiresAt) {
+		return pendingConfirmation{}, false
+	}
+	return item, true
+}
+
+func (s *ConfirmationStore) remove(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.pending, id)
+}
+
+// requiresConfirmation returns true if a given command matches any of the configured commands.
+// The commands triggered by automations and the already confirmed ones are executed without confirmation.
+func (e *DefaultExecutor) requiresConfirmation(cmd string) bool {
+	cfg := e.cfg.Settings.CommandConfirmation
+	if !cfg.Enabled || e.confirmed || e.conversation.CommandOrigin == command.AutomationOrigin {
+		return false
+	}
+
+	args := strings.Fields(cmd)
+	if len(args) >= 2 && slices.Contains(kubectlAlias, args[0]) {
+		args = args[1:]
+	}
+	if len(args) == 0 {
+		return false
+	}
+
+	args = normalizeFlagValues(args)
+	for _, entry := range cfg.Commands {
+		words := normalizeFlagValues(strings.Fields(entry))
+		if len(words) == 0 || words[0] != args[0] {
+			continue
+		}
+		if containsAll(args[1:], words[1:]) {
+			return true
+		}
+	}
+	return false
+}
+
+// respondWithConfirmation returns a message asking for confirmation of the current command.
+func (e *DefaultExecutor) respondWithConfirmation(rawCmd, botName string) interactive.Message {
+	msg, err := e.askForConfirmation(rawCmd, botName)
+	if err != nil {
+		e.log.Errorf("while asking for command confirmation: %s", err.Error())
+		e.auditStatus = audit.StatusError
+		return interactive.Message{}
+	}
+	return msg
+}
+
+// askForConfirmation stores the current command and returns a message with the Confirm and Cancel buttons.
+func (e *DefaultExecutor) askForConfirmation(rawCmd, botName string) (interactive.Message, error) {
+	cfg := e.cfg.Settings.CommandConfirmation
+	id, err := e.confirmations.add(pendingConfirmation{
+		message:        e.message,
+		userID:         e.userID,
+		user:           e.user,
+		conversationID: e.conversation.ID,
+	}, cfg.Timeout)
+	if err != nil {
+		return interactive.Message{}, err
+	}
+
+	cmd := strings.TrimSpace(rawCmd)
+	clusterFlag := fmt.Sprintf("%s %s", ClusterFlag, e.cfg.Settings.ClusterName)
+	btnBuilder := interactive.ButtonBuilder{BotName: botName}
+	return interactive.Message{
+		Base: interactive.Base{
+			Description: e.header(rawCmd),
+		},
+		Sections: []interactive.Section{
+			{
+				Base: interactive.Base{
+					Body: interactive.Body{
+						Plaintext: fmt.Sprintf(confirmationRequiredMsgFmt, cmd, cfg.Timeout),
+					},
+				},
+				Buttons: interactive.Buttons{
+					btnBuilder.ForCommandWithoutDesc("Confirm", fmt.Sprintf("%s %s %s", confirmCommandName, id, clusterFlag), interactive.ButtonStyleDanger),
+					btnBuilder.ForCommandWithoutDesc("Cancel", fmt.Sprintf("%s %s %s", cancelCommandName, id, clusterFlag)),
+				},
+			},
+		},
+	}, nil
+}
+
+// resolveConfirmation returns the command waiting for confirmation with a given ID.
+// It returns an ExecutionCommandError if the confirmation expired, or if it's resolved by a different user or in a different channel.
+func (e *DefaultExecutor) resolveConfirmation(args []string) (pendingConfirmation, error) {
+	if len(args) < 2 {
+		return pendingConfirmation{}, errInvalidCommand
+	}
+
+	id := args[1]
+	item, found := e.confirmations.get(id)
+	if !found || item.conversationID != e.conversation.ID {
+		return pendingConfirmation{}, NewExecutionCommandError(confirmationNotFoundMsgFmt, e.cfg.Settings.ClusterName)
+	}
+
+	// not all platforms provide the user ID, so the user name is compared instead
+	sameUser := item.userID == e.userID
+	if item.userID == "" {
+		sameUser = item.user == e.user
+	}
+	if !sameUser {
+		return pendingConfirmation{}, NewExecutionCommandError(confirmationOtherUserMsgFmt, item.user, strings.TrimSpace(item.message))
+	}
+
+	e.confirmations.remove(id)
+	return item, nil
+}
+
+// confirmCommand marks the command waiting for confirmation as confirmed, so it's executed instead of the current message.
+func (e *DefaultExecutor) confirmCommand(args []string) error {
+	e.reportCommand(confirmCommandName, false)
+	item, err := e.resolveConfirmation(args)
+	if err != nil {
+		return err
+	}
+
+	e.message = item.message
+	e.confirmed = true
+	return nil
+}
+
+// runCancelCommand cancels the command waiting for confirmation.
+func (e *DefaultExecutor) runCancelCommand(args []string) (string, error) {
+	e.reportCommand(cancelCommandName, false)
+	item, err := e.resolveConfirmation(args)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf(confirmationCancelled, strings.TrimSpace(item.message), e.cfg.Settings.ClusterName), nil
+}
+
+// normalizeFlagValues joins the known value flags with their values, so `--replicas 0` and `--replicas=0` are equal.
+func normalizeFlagValues(args []string) []string {
+	out := make([]string, 0, len(args))
+	for idx := 0; idx < len(args); idx++ {
+		arg := args[idx]
+		if idx+1 < len(args) && slices.Contains(kubectlValueFlags, arg) {
+			idx++
+			arg = fmt.Sprintf("%s=%s", arg, args[idx])
+		}
+		out = append(out, arg)
+	}
+	return out
+}
+
+// containsAll returns true if all expected args are present. An expected flag without a value, such as `--force`,
+// matches the flag with any value as well, e.g. `--force=true`.
+func containsAll(in []string, expected []string) bool {
+	for _, item := range expected {
+		if !slices.Contains(in, item) && !containsFlag(in, item) {
+			return false
+		}
+	}
+	return true
+}
+
+func containsFlag(in []string, flag string) bool {
+	if !strings.HasPrefix(flag, "-") || strings.Contains(flag, "=") {
+		return false
+	}
+	for _, arg := range in {
+		if strings.HasPrefix(arg, flag+"=") {
+			return true
+		}
+	}
+	return false
+}
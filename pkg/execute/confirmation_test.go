@@ -0,0 +1,215 @@
+package execute
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	logtest "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/pkg/bot/interactive"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/execute/command"
+)
+
+func TestDefaultExecutorRequiresConfirmation(t *testing.T) {
+	// given
+	tests := []struct {
+		name string
+
+		command   string
+		origin    command.Origin
+		confirmed bool
+
+		expRequired bool
+	}{
+		{
+			name:        "Should require confirmation for verb",
+			command:     "delete pod nginx",
+			expRequired: true,
+		},
+		{
+			name:        "Should require confirmation for verb with kubectl prefix",
+			command:     "kc drain node-1",
+			expRequired: true,
+		},
+		{
+			name:        "Should require confirmation for command with all flags",
+			command:     "kubectl scale deploy/nginx --replicas=0 -n team-a",
+			expRequired: true,
+		},
+		{
+			name:        "Should require confirmation for flag with value as separate argument",
+			command:     "kubectl scale deploy/nginx --replicas 0 -n team-a",
+			expRequired: true,
+		},
+		{
+			name:        "Should require confirmation for flag with value when configured with value as separate argument",
+			command:     "kubectl taint nodes node-1 key=value:NoSchedule --namespace=kube-system",
+			expRequired: true,
+		},
+		{
+			name:        "Should require confirmation for flag with value as separate argument when configured the same way",
+			command:     "kubectl taint nodes node-1 key=value:NoSchedule --namespace kube-system",
+			expRequired: true,
+		},
+		{
+			name:        "Should require confirmation for flag with value when configured without value",
+			command:     "kubectl cordon node-1 --dry-run=none",
+			expRequired: true,
+		},
+		{
+			name:        "Should require confirmation for Helm command",
+			command:     "helm uninstall nginx -n team-a",
+			expRequired: true,
+		},
+		{
+			name:    "Should not require confirmation for command without flags",
+			command: "kubectl scale deploy/nginx --replicas=2",
+		},
+		{
+			name:    "Should not require confirmation for flag with other value",
+			command: "kubectl taint nodes node-1 key=value:NoSchedule --namespace default",
+		},
+		{
+			name:    "Should not require confirmation for flag with other value as separate argument",
+			command: "kubectl scale deploy/nginx --replicas 2",
+		},
+		{
+			name:    "Should not require confirmation for other verb",
+			command: "get pods",
+		},
+		{
+			name:    "Should not require confirmation for automation",
+			command: "delete pod nginx",
+			origin:  command.AutomationOrigin,
+		},
+		{
+			name:      "Should not require confirmation for confirmed command",
+			command:   "delete pod nginx",
+			confirmed: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			executor := fixConfirmationExecutor()
+			executor.conversation.CommandOrigin = tc.origin
+			executor.confirmed = tc.confirmed
+
+			// when
+			got := executor.requiresConfirmation(tc.command)
+
+			// then
+			assert.Equal(t, tc.expRequired, got)
+		})
+	}
+}
+
+func TestDefaultExecutorConfirmCommand(t *testing.T) {
+	// given
+	executor := fixConfirmationExecutor()
+
+	// when
+	msg := executor.respondWithConfirmation("delete pod nginx", "@Botkube")
+
+	// then
+	require.Len(t, msg.Sections, 1)
+	assert.Equal(t, "`delete pod nginx` on `dev`", msg.Description)
+	assert.Equal(t, "The `delete pod nginx` command requires confirmation. Please confirm it within 2m0s.", msg.Sections[0].Body.Plaintext)
+	require.Len(t, msg.Sections[0].Buttons, 2)
+	confirmBtn, cancelBtn := msg.Sections[0].Buttons[0], msg.Sections[0].Buttons[1]
+	assert.Equal(t, interactive.ButtonStyleDanger, confirmBtn.Style)
+	assert.Regexp(t, `^@Botkube confirm [0-9a-f]{16} --cluster-name dev$`, confirmBtn.Command)
+	assert.Regexp(t, `^@Botkube cancel [0-9a-f]{16} --cluster-name dev$`, cancelBtn.Command)
+
+	id := confirmArgs(confirmBtn.Command)[1]
+
+	// when
+	otherUser := fixConfirmationExecutor()
+	otherUser.confirmations = executor.confirmations
+	otherUser.userID, otherUser.user = "U-OTHER", "Ann"
+	err := otherUser.confirmCommand(confirmArgs(confirmBtn.Command))
+
+	// then
+	require.Error(t, err)
+	assert.EqualError(t, err, "Sorry, only Joe can confirm or cancel the `delete pod nginx` command.")
+
+	// when
+	confirming := fixConfirmationExecutor()
+	confirming.confirmations = executor.confirmations
+	confirming.message = "confirm " + id
+	err = confirming.confirmCommand(confirmArgs(confirmBtn.Command))
+
+	// then
+	require.NoError(t, err)
+	assert.True(t, confirming.confirmed)
+	assert.Equal(t, "delete pod nginx", confirming.message)
+
+	// when
+	_, err = confirming.runCancelCommand(confirmArgs(cancelBtn.Command))
+
+	// then
+	require.Error(t, err)
+	assert.EqualError(t, err, "Sorry, the command confirmation on cluster 'dev' has expired or was already handled. Please send the command again.")
+}
+
+func TestDefaultExecutorCancelCommand(t *testing.T) {
+	// given
+	executor := fixConfirmationExecutor()
+	now := time.Now()
+	executor.confirmations.nowFn = func() time.Time { return now }
+	msg := executor.respondWithConfirmation("delete pod nginx", "@Botkube")
+	require.Len(t, msg.Sections, 1)
+	cancelArgs := confirmArgs(msg.Sections[0].Buttons[1].Command)
+
+	// when
+	out, err := executor.runCancelCommand(cancelArgs)
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, "Cancelled. I won't execute the `delete pod nginx` command on 'dev' cluster.", out)
+
+	// given
+	msg = executor.respondWithConfirmation("delete pod nginx", "@Botkube")
+	require.Len(t, msg.Sections, 1)
+	now = now.Add(3 * time.Minute)
+
+	// when
+	_, err = executor.runCancelCommand(confirmArgs(msg.Sections[0].Buttons[1].Command))
+
+	// then
+	require.Error(t, err)
+	assert.True(t, IsExecutionCommandError(err))
+	assert.EqualError(t, err, "Sorry, the command confirmation on cluster 'dev' has expired or was already handled. Please send the command again.")
+}
+
+func fixConfirmationExecutor() *DefaultExecutor {
+	logger, _ := logtest.NewNullLogger()
+	return &DefaultExecutor{
+		log: logger,
+		cfg: config.Config{
+			Settings: config.Settings{
+				ClusterName: "dev",
+				CommandConfirmation: config.CommandConfirmation{
+					Enabled:  true,
+					Commands: []string{"delete", "drain", "scale --replicas=0", "helm uninstall", "cordon --dry-run", "taint --namespace kube-system"},
+					Timeout:  2 * time.Minute,
+				},
+			},
+		},
+		analyticsReporter: &fakeAnalyticsReporter{},
+		confirmations:     NewConfirmationStore(),
+		conversation:      Conversation{Alias: "botkube", ID: "C123", CommandOrigin: command.TypedOrigin},
+		user:              "Joe",
+		userID:            "U123",
+		message:           "delete pod nginx",
+	}
+}
+
+// confirmArgs returns the command args of a given button command, without the bot name.
+func confirmArgs(cmd string) []string {
+	args := strings.Fields(cmd)
+	return args[1:]
+}
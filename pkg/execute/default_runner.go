@@ -1,6 +1,8 @@
 package execute
 
 import (
+	"context"
+	"io"
 	"os/exec"
 	"strings"
 
@@ -23,6 +25,24 @@ type CommandSeparateOutputRunner interface {
 	RunSeparateOutput(command string, args []string) (string, string, error)
 }
 
+// CommandCombinedOutputWithStdinRunner provides functionality to run arbitrary commands with a given standard input.
+type CommandCombinedOutputWithStdinRunner interface {
+	RunCombinedOutputWithStdin(command string, args []string, stdin io.Reader) (string, error)
+}
+
+// CommandCombinedOutputStreamRunner provides functionality to run arbitrary commands and stream their output.
+type CommandCombinedOutputStreamRunner interface {
+	// RunCombinedOutputStream runs a given command and calls the handler each time its standard output or standard error is written.
+	// The handler is called sequentially. The command is killed once the context is done.
+	RunCombinedOutputStream(ctx context.Context, command string, args []string, handleChunk OutputChunkHandler) error
+}
+
+// CommandCombinedOutputContextRunner provides functionality to run arbitrary commands, which are killed once a given context is done.
+type CommandCombinedOutputContextRunner interface {
+	// RunCombinedOutputContext runs a given command with a given standard input, which may be nil, and returns its combined output.
+	RunCombinedOutputContext(ctx context.Context, command string, args []string, stdin io.Reader) (string, error)
+}
+
 // OSCommand provides syntax sugar for working with exec.Command
 type OSCommand struct{}
 
@@ -50,6 +70,47 @@ func (*OSCommand) RunCombinedOutput(command string, args []string) (string, erro
 	return string(out), err
 }
 
+// RunCombinedOutputWithStdin runs a given command with a given standard input and returns its combined standard output and standard error.
+func (*OSCommand) RunCombinedOutputWithStdin(command string, args []string, stdin io.Reader) (string, error) {
+	// #nosec G204
+	cmd := exec.Command(command, args...)
+	cmd.Stdin = stdin
+	out, err := cmd.CombinedOutput()
+	return string(out), err
+}
+
+// RunCombinedOutputContext runs a given command with a given standard input and returns its combined standard output and standard error.
+// The command is killed once the context is done.
+func (*OSCommand) RunCombinedOutputContext(ctx context.Context, command string, args []string, stdin io.Reader) (string, error) {
+	// #nosec G204
+	cmd := exec.CommandContext(ctx, command, args...)
+	cmd.Stdin = stdin
+	out, err := cmd.CombinedOutput()
+	return string(out), err
+}
+
+// RunCombinedOutputStream runs a given command and streams its combined standard output and standard error to a given handler.
+func (*OSCommand) RunCombinedOutputStream(ctx context.Context, command string, args []string, handleChunk OutputChunkHandler) error {
+	// #nosec G204
+	cmd := exec.CommandContext(ctx, command, args...)
+	// the same writer is used for both outputs, so it's never called concurrently
+	out := &chunkWriter{handleChunk: handleChunk}
+	cmd.Stdout = out
+	cmd.Stderr = out
+	return cmd.Run()
+}
+
+// chunkWriter passes all written data to a given handler.
+type chunkWriter struct {
+	handleChunk OutputChunkHandler
+}
+
+// Write calls the handler with a given data.
+func (w *chunkWriter) Write(p []byte) (int, error) {
+	w.handleChunk(string(p))
+	return len(p), nil
+}
+
 type (
 	executorFunc    func() (interactive.Message, error)
 	executorsRunner map[string]executorFunc
@@ -0,0 +1,507 @@
+package execute
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mattn/go-shellwords"
+	"github.com/sirupsen/logrus"
+	appsV1 "k8s.io/api/apps/v1"
+	coreV1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/kubeshop/botkube/pkg/bot/interactive"
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+const (
+	diagCommandName               = "diag"
+	diagDefaultLogTailLines int64 = 200
+	diagDefaultMaxPods            = 10
+	diagArchiveTimeFormat         = "20060102-150405"
+
+	diagUsageMsg                  = "Please specify the workload, e.g. 'diag <namespace>/<name>' or 'diag <namespace>/<kind>/<name>'."
+	diagUnsupportedKindMsgFmt     = "Sorry, the '%s' kind is not supported. Please use deployment, statefulset, daemonset or pod."
+	diagNotAllowedNsMsgFmt        = "Sorry, the diagnostics bundle cannot be collected for the '%s' Namespace on cluster '%s'."
+	diagNotFoundMsgFmt            = "Sorry, the '%s/%s' workload was not found on cluster '%s'."
+	diagUnsupportedPlatformMsgFmt = "Sorry, diagnostics bundles cannot be uploaded to %s. Please use Slack."
+	diagSummaryMsgFmt             = "Collected the diagnostics bundle of the '%s/%s' %s: %d Pods, %d events, %d log files."
+	diagMetricsNotAvailableMsg    = "Resource metrics are not available. Please make sure that the metrics-server is installed.\n"
+	diagNoEventsMsg               = "No events found.\n"
+)
+
+// Workload kinds supported by the diagnostics bundles.
+const (
+	diagDeploymentKind  = "Deployment"
+	diagStatefulSetKind = "StatefulSet"
+	diagDaemonSetKind   = "DaemonSet"
+	diagPodKind         = "Pod"
+)
+
+// diagKindAliases maps the workload kinds, their plural forms and short names to the kinds.
+var diagKindAliases = map[string]string{
+	"deployment":   diagDeploymentKind,
+	"deployments":  diagDeploymentKind,
+	"deploy":       diagDeploymentKind,
+	"statefulset":  diagStatefulSetKind,
+	"statefulsets": diagStatefulSetKind,
+	"sts":          diagStatefulSetKind,
+	"daemonset":    diagDaemonSetKind,
+	"daemonsets":   diagDaemonSetKind,
+	"ds":           diagDaemonSetKind,
+	"pod":          diagPodKind,
+	"pods":         diagPodKind,
+	"po":           diagPodKind,
+}
+
+// diagKindResources maps the workload kinds to their resource names, used by kubectl and RBAC rules.
+var diagKindResources = map[string]string{
+	diagDeploymentKind:  "deployments",
+	diagStatefulSetKind: "statefulsets",
+	diagDaemonSetKind:   "daemonsets",
+	diagPodKind:         "pods",
+}
+
+// diagKindsLookupOrder is the order in which the kinds are checked if the workload kind is not specified.
+var diagKindsLookupOrder = []string{diagDeploymentKind, diagStatefulSetKind, diagDaemonSetKind, diagPodKind}
+
+// diagTarget describes the workload for which the bundle is collected.
+type diagTarget struct {
+	Namespace string
+	// Kind is empty if it's not specified in the command.
+	Kind string
+	Name string
+}
+
+// diagWorkload is a resolved workload with its Pods.
+type diagWorkload struct {
+	diagTarget
+	Pods []coreV1.Pod
+}
+
+// diagFile is a single file of the diagnostics bundle.
+type diagFile struct {
+	Name    string
+	Content string
+}
+
+// Diag collects the diagnostics bundles of workloads.
+type Diag struct {
+	log        logrus.FieldLogger
+	cfg        config.Config
+	k8sCli     kubernetes.Interface
+	dynamicCli dynamic.Interface
+	cmdRunner  CommandCombinedOutputRunner
+	nowFn      func() time.Time
+}
+
+// NewDiag creates a new instance of Diag.
+func NewDiag(log logrus.FieldLogger, cfg config.Config, k8sCli kubernetes.Interface, dynamicCli dynamic.Interface, cmdRunner CommandCombinedOutputRunner) *Diag {
+	return &Diag{
+		log:        log,
+		cfg:        cfg,
+		k8sCli:     k8sCli,
+		dynamicCli: dynamicCli,
+		cmdRunner:  cmdRunner,
+		nowFn:      time.Now,
+	}
+}
+
+// CanHandle returns true if it's a diag command and at least one Diag executor is enabled for given bindings.
+func (e *Diag) CanHandle(bindings []string, args []string) bool {
+	if len(args) == 0 || args[0] != diagCommandName || e.k8sCli == nil || e.dynamicCli == nil {
+		return false
+	}
+
+	for _, name := range bindings {
+		if e.cfg.Executors[name].Diag.Enabled {
+			return true
+		}
+	}
+	return false
+}
+
+// Execute collects the diagnostics bundle of a given workload. It returns the bundle summary and the archive to upload.
+//
+// This method should be called ONLY if:
+// - we are a target cluster,
+// - and Diag.CanHandle returned true.
+func (e *Diag) Execute(ctx context.Context, bindings []string, command string) (string, *interactive.Attachment, error) {
+	log := e.log.WithField("command", command)
+	log.Debugf("Handling command...")
+
+	target, err := parseDiagCommand(command)
+	if err != nil {
+		return "", nil, err
+	}
+
+	clusterName := e.cfg.Settings.ClusterName
+	if !e.isNamespaceAllowed(bindings, target.Namespace) {
+		return "", nil, NewExecutionCommandError(diagNotAllowedNsMsgFmt, target.Namespace, clusterName)
+	}
+
+	workload, err := e.findWorkload(ctx, target, e.maxPods(bindings))
+	if err != nil {
+		return "", nil, err
+	}
+
+	events, err := e.workloadEvents(ctx, workload)
+	if err != nil {
+		return "", nil, err
+	}
+
+	files := []diagFile{
+		{Name: "describe.txt", Content: e.describe(workload)},
+		{Name: "events.txt", Content: renderDiagEvents(events)},
+		{Name: "metrics.txt", Content: e.metrics(ctx, workload)},
+	}
+	logs := e.logs(ctx, workload, e.logTailLines(bindings))
+	files = append(files, logs...)
+
+	now := e.nowFn()
+	baseName := fmt.Sprintf("diag-%s-%s-%s", workload.Namespace, workload.Name, now.UTC().Format(diagArchiveTimeFormat))
+	archive, err := archiveDiagFiles(baseName, now, files)
+	if err != nil {
+		return "", nil, err
+	}
+
+	summary := fmt.Sprintf(diagSummaryMsgFmt, workload.Namespace, workload.Name, workload.Kind, len(workload.Pods), len(events), len(logs))
+	return summary, &interactive.Attachment{
+		FileName: baseName + ".tar.gz",
+		Data:     archive,
+	}, nil
+}
+
+// parseDiagCommand returns the workload from a given `diag <namespace>/[<kind>/]<name>` command.
+func parseDiagCommand(command string) (diagTarget, error) {
+	args, err := shellwords.Parse(strings.TrimSpace(command))
+	if err != nil {
+		return diagTarget{}, fmt.Errorf("while parsing the command message into args: %w", err)
+	}
+	args = removeClusterFlags(args[1:])
+	if len(args) != 1 {
+		return diagTarget{}, NewExecutionCommandError(diagUsageMsg)
+	}
+
+	parts := strings.Split(args[0], "/")
+	for _, part := range parts {
+		if part == "" {
+			return diagTarget{}, NewExecutionCommandError(diagUsageMsg)
+		}
+	}
+
+	switch len(parts) {
+	case 2:
+		return diagTarget{Namespace: parts[0], Name: parts[1]}, nil
+	case 3:
+		kind, found := diagKindAliases[strings.ToLower(parts[1])]
+		if !found {
+			return diagTarget{}, NewExecutionCommandError(diagUnsupportedKindMsgFmt, parts[1])
+		}
+		return diagTarget{Namespace: parts[0], Kind: kind, Name: parts[2]}, nil
+	}
+	return diagTarget{}, NewExecutionCommandError(diagUsageMsg)
+}
+
+// findWorkload returns the workload with its Pods. If the kind is not specified, the first workload found with a given name is returned.
+func (e *Diag) findWorkload(ctx context.Context, target diagTarget, maxPods int) (diagWorkload, error) {
+	kinds := diagKindsLookupOrder
+	if target.Kind != "" {
+		kinds = []string{target.Kind}
+	}
+
+	for _, kind := range kinds {
+		pods, err := e.workloadPods(ctx, kind, target.Namespace, target.Name)
+		switch {
+		case apierrors.IsNotFound(err):
+			continue
+		case err != nil:
+			return diagWorkload{}, err
+		}
+
+		sort.Slice(pods, func(i, j int) bool {
+			return pods[i].Name < pods[j].Name
+		})
+		if len(pods) > maxPods {
+			pods = pods[:maxPods]
+		}
+
+		workload := diagWorkload{diagTarget: target, Pods: pods}
+		workload.Kind = kind
+		return workload, nil
+	}
+
+	return diagWorkload{}, NewExecutionCommandError(diagNotFoundMsgFmt, target.Namespace, target.Name, e.cfg.Settings.ClusterName)
+}
+
+// workloadPods returns the Pods of a given workload. The returned error is the NotFound API error if the workload doesn't exist.
+func (e *Diag) workloadPods(ctx context.Context, kind, namespace, name string) ([]coreV1.Pod, error) {
+	var (
+		selector *metaV1.LabelSelector
+		err      error
+	)
+	switch kind {
+	case diagPodKind:
+		pod, err := e.k8sCli.CoreV1().Pods(namespace).Get(ctx, name, metaV1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("while getting Pod: %w", err)
+		}
+		return []coreV1.Pod{*pod}, nil
+	case diagDeploymentKind:
+		var deploy *appsV1.Deployment
+		if deploy, err = e.k8sCli.AppsV1().Deployments(namespace).Get(ctx, name, metaV1.GetOptions{}); err == nil {
+			selector = deploy.Spec.Selector
+		}
+	case diagStatefulSetKind:
+		var sts *appsV1.StatefulSet
+		if sts, err = e.k8sCli.AppsV1().StatefulSets(namespace).Get(ctx, name, metaV1.GetOptions{}); err == nil {
+			selector = sts.Spec.Selector
+		}
+	case diagDaemonSetKind:
+		var ds *appsV1.DaemonSet
+		if ds, err = e.k8sCli.AppsV1().DaemonSets(namespace).Get(ctx, name, metaV1.GetOptions{}); err == nil {
+			selector = ds.Spec.Selector
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("while getting %s: %w", kind, err)
+	}
+
+	podSelector, err := metaV1.LabelSelectorAsSelector(selector)
+	if err != nil {
+		return nil, fmt.Errorf("while converting %s selector: %w", kind, err)
+	}
+	podList, err := e.k8sCli.CoreV1().Pods(namespace).List(ctx, metaV1.ListOptions{LabelSelector: podSelector.String()})
+	if err != nil {
+		return nil, fmt.Errorf("while listing Pods: %w", err)
+	}
+	return podList.Items, nil
+}
+
+// describe returns the `kubectl describe` output of the workload and its Pods. Errors are included in the output,
+// so the rest of the bundle is still collected.
+func (e *Diag) describe(workload diagWorkload) string {
+	commands := [][]string{
+		{"describe", diagKindResources[workload.Kind], workload.Name, "-n", workload.Namespace},
+	}
+	if workload.Kind != diagPodKind {
+		for _, pod := range workload.Pods {
+			commands = append(commands, []string{"describe", "pods", pod.Name, "-n", workload.Namespace})
+		}
+	}
+
+	var out strings.Builder
+	for _, args := range commands {
+		res, err := e.cmdRunner.RunCombinedOutput(kubectlBinary, args)
+		fmt.Fprintf(&out, "$ kubectl %s\n%s", strings.Join(args, " "), res)
+		if err != nil {
+			fmt.Fprintf(&out, "%s\n", err.Error())
+		}
+		out.WriteString("\n")
+	}
+	return out.String()
+}
+
+// workloadEvents returns the events of the workload and its Pods, sorted by their last occurrence.
+func (e *Diag) workloadEvents(ctx context.Context, workload diagWorkload) ([]coreV1.Event, error) {
+	// the field selectors are not used, as a single query can't match both the workload and its Pods
+	list, err := e.k8sCli.CoreV1().Events(workload.Namespace).List(ctx, metaV1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("while listing events: %w", err)
+	}
+
+	pods := map[string]struct{}{}
+	for _, pod := range workload.Pods {
+		pods[pod.Name] = struct{}{}
+	}
+
+	var out []coreV1.Event
+	for _, event := range list.Items {
+		obj := event.InvolvedObject
+		_, isPod := pods[obj.Name]
+		if (obj.Kind == workload.Kind && obj.Name == workload.Name) || (obj.Kind == diagPodKind && isPod) {
+			out = append(out, event)
+		}
+	}
+
+	sort.SliceStable(out, func(i, j int) bool {
+		return diagEventTime(out[i]).Before(diagEventTime(out[j]))
+	})
+	return out, nil
+}
+
+// metrics returns the resource usage of the workload Pods. If the metrics are not available, the output explains it.
+func (e *Diag) metrics(ctx context.Context, workload diagWorkload) string {
+	list, err := e.dynamicCli.Resource(podMetricsGVR).Namespace(workload.Namespace).List(ctx, metaV1.ListOptions{})
+	if err != nil {
+		e.log.Debugf("while listing Pod metrics: %s", err.Error())
+		return diagMetricsNotAvailableMsg
+	}
+
+	usage := map[string]coreV1.ResourceList{}
+	for _, item := range list.Items {
+		var m resourceMetrics
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(item.Object, &m); err != nil {
+			e.log.Debugf("while converting %s metrics: %s", item.GetName(), err.Error())
+			continue
+		}
+		usage[m.Metadata.Name] = m.usage()
+	}
+
+	rows := [][]string{{"NAME", "CPU(cores)", "MEMORY(bytes)"}}
+	for _, pod := range workload.Pods {
+		podUsage, found := usage[pod.Name]
+		if !found {
+			rows = append(rows, []string{pod.Name, "-", "-"})
+			continue
+		}
+		rows = append(rows, []string{pod.Name, formatCPU(*podUsage.Cpu()), formatMemory(*podUsage.Memory())})
+	}
+	return renderTable(rows)
+}
+
+// logs returns the logs tail of all containers of the workload Pods. The previous logs are collected for the restarted containers.
+func (e *Diag) logs(ctx context.Context, workload diagWorkload, tailLines int64) []diagFile {
+	var out []diagFile
+	for _, pod := range workload.Pods {
+		restarts := map[string]int32{}
+		for _, status := range pod.Status.ContainerStatuses {
+			restarts[status.Name] = status.RestartCount
+		}
+
+		for _, container := range pod.Spec.Containers {
+			name := fmt.Sprintf("logs/%s/%s.log", pod.Name, container.Name)
+			out = append(out, diagFile{Name: name, Content: e.containerLogs(ctx, pod, container.Name, tailLines, false)})
+
+			if restarts[container.Name] > 0 {
+				name := fmt.Sprintf("logs/%s/%s.previous.log", pod.Name, container.Name)
+				out = append(out, diagFile{Name: name, Content: e.containerLogs(ctx, pod, container.Name, tailLines, true)})
+			}
+		}
+	}
+	return out
+}
+
+func (e *Diag) containerLogs(ctx context.Context, pod coreV1.Pod, container string, tailLines int64, previous bool) string {
+	res, err := e.k8sCli.CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, &coreV1.PodLogOptions{
+		Container: container,
+		TailLines: &tailLines,
+		Previous:  previous,
+	}).DoRaw(ctx)
+	if err != nil {
+		return fmt.Sprintf("Cannot get logs: %s\n", err.Error())
+	}
+	return string(res)
+}
+
+func (e *Diag) isNamespaceAllowed(bindings []string, namespace string) bool {
+	for _, name := range bindings {
+		diag := e.cfg.Executors[name].Diag
+		if diag.Enabled && diag.Namespaces.IsAllowed(namespace) {
+			return true
+		}
+	}
+	return false
+}
+
+// logTailLines returns the highest number of log lines set by the enabled Diag executors, or the default one.
+func (e *Diag) logTailLines(bindings []string) int64 {
+	var out int64
+	for _, name := range bindings {
+		diag := e.cfg.Executors[name].Diag
+		if diag.Enabled && diag.LogTailLines > out {
+			out = diag.LogTailLines
+		}
+	}
+	if out == 0 {
+		return diagDefaultLogTailLines
+	}
+	return out
+}
+
+// maxPods returns the highest number of Pods set by the enabled Diag executors, or the default one.
+func (e *Diag) maxPods(bindings []string) int {
+	out := 0
+	for _, name := range bindings {
+		diag := e.cfg.Executors[name].Diag
+		if diag.Enabled && diag.MaxPods > out {
+			out = diag.MaxPods
+		}
+	}
+	if out == 0 {
+		return diagDefaultMaxPods
+	}
+	return out
+}
+
+func renderDiagEvents(events []coreV1.Event) string {
+	if len(events) == 0 {
+		return diagNoEventsMsg
+	}
+
+	rows := [][]string{{"LAST SEEN", "TYPE", "REASON", "OBJECT", "COUNT", "MESSAGE"}}
+	for _, event := range events {
+		obj := event.InvolvedObject
+		rows = append(rows, []string{
+			diagEventTime(event).UTC().Format(time.RFC3339),
+			event.Type,
+			event.Reason,
+			fmt.Sprintf("%s/%s", strings.ToLower(obj.Kind), obj.Name),
+			fmt.Sprint(event.Count),
+			strings.TrimSpace(event.Message),
+		})
+	}
+	return renderTable(rows)
+}
+
+// diagEventTime returns the time of the last event occurrence.
+func diagEventTime(event coreV1.Event) time.Time {
+	switch {
+	case !event.LastTimestamp.IsZero():
+		return event.LastTimestamp.Time
+	case !event.EventTime.IsZero():
+		return event.EventTime.Time
+	}
+	return event.CreationTimestamp.Time
+}
+
+// archiveDiagFiles returns the gzipped tar archive with a given files placed in a directory with a given name.
+func archiveDiagFiles(dir string, modTime time.Time, files []diagFile) ([]byte, error) {
+	var buff bytes.Buffer
+	gz := gzip.NewWriter(&buff)
+	tw := tar.NewWriter(gz)
+
+	for _, file := range files {
+		hdr := &tar.Header{
+			Name:    fmt.Sprintf("%s/%s", dir, file.Name),
+			Mode:    0o644,
+			Size:    int64(len(file.Content)),
+			ModTime: modTime,
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return nil, fmt.Errorf("while writing %s header: %w", file.Name, err)
+		}
+		if _, err := io.WriteString(tw, file.Content); err != nil {
+			return nil, fmt.Errorf("while writing %s: %w", file.Name, err)
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return nil, fmt.Errorf("while closing archive: %w", err)
+	}
+	if err := gz.Close(); err != nil {
+		return nil, fmt.Errorf("while closing gzip writer: %w", err)
+	}
+	return buff.Bytes(), nil
+}
@@ -0,0 +1,235 @@
+package execute
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	logtest "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsV1 "k8s.io/api/apps/v1"
+	coreV1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+func TestDiagExecute(t *testing.T) {
+	// given
+	runner := &fakeDiagRunner{}
+	executor := fixDiagExecutor(t, runner)
+
+	// when
+	out, attachment, err := executor.Execute(context.Background(), fixBindingsNames, "diag prod/nginx")
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, "Collected the diagnostics bundle of the 'prod/nginx' Deployment: 2 Pods, 2 events, 3 log files.", out)
+	require.NotNil(t, attachment)
+	assert.Equal(t, "diag-prod-nginx-20221003-081500.tar.gz", attachment.FileName)
+	assert.Equal(t, [][]string{
+		{"describe", "deployments", "nginx", "-n", "prod"},
+		{"describe", "pods", "nginx-a", "-n", "prod"},
+		{"describe", "pods", "nginx-b", "-n", "prod"},
+	}, runner.gotArgs)
+
+	files := untarDiagArchive(t, attachment.Data)
+	assert.Equal(t, []string{
+		"diag-prod-nginx-20221003-081500/describe.txt",
+		"diag-prod-nginx-20221003-081500/events.txt",
+		"diag-prod-nginx-20221003-081500/metrics.txt",
+		"diag-prod-nginx-20221003-081500/logs/nginx-a/app.log",
+		"diag-prod-nginx-20221003-081500/logs/nginx-b/app.log",
+		"diag-prod-nginx-20221003-081500/logs/nginx-b/app.previous.log",
+	}, files.names)
+	assert.Equal(t, "LAST SEEN             TYPE     REASON             OBJECT            COUNT  MESSAGE\n"+
+		"2022-10-03T08:00:00Z  Normal   ScalingReplicaSet  deployment/nginx  1      Scaled up replica set nginx-7d to 2\n"+
+		"2022-10-03T08:10:00Z  Warning  BackOff            pod/nginx-b       4      Back-off restarting failed container\n",
+		files.contents["diag-prod-nginx-20221003-081500/events.txt"])
+	assert.Equal(t, "NAME     CPU(cores)  MEMORY(bytes)\n"+
+		"nginx-a  100m        64Mi\n"+
+		"nginx-b  -           -\n",
+		files.contents["diag-prod-nginx-20221003-081500/metrics.txt"])
+	assert.Contains(t, files.contents["diag-prod-nginx-20221003-081500/describe.txt"], "$ kubectl describe deployments nginx -n prod\nfake describe output\n")
+}
+
+func TestDiagExecuteErrors(t *testing.T) {
+	// given
+	tests := []struct {
+		name string
+
+		command string
+		expErr  string
+	}{
+		{
+			name:    "Should require workload",
+			command: "diag",
+			expErr:  "Please specify the workload, e.g. 'diag <namespace>/<name>' or 'diag <namespace>/<kind>/<name>'.",
+		},
+		{
+			name:    "Should require Namespace",
+			command: "diag nginx",
+			expErr:  "Please specify the workload, e.g. 'diag <namespace>/<name>' or 'diag <namespace>/<kind>/<name>'.",
+		},
+		{
+			name:    "Should forbid unsupported kind",
+			command: "diag prod/cronjob/backup",
+			expErr:  "Sorry, the 'cronjob' kind is not supported. Please use deployment, statefulset, daemonset or pod.",
+		},
+		{
+			name:    "Should forbid not allowed Namespace",
+			command: "diag kube-system/dns",
+			expErr:  "Sorry, the diagnostics bundle cannot be collected for the 'kube-system' Namespace on cluster 'test'.",
+		},
+		{
+			name:    "Should report not found workload",
+			command: "diag prod/redis",
+			expErr:  "Sorry, the 'prod/redis' workload was not found on cluster 'test'.",
+		},
+		{
+			name:    "Should report workload of other kind",
+			command: "diag prod/sts/nginx",
+			expErr:  "Sorry, the 'prod/nginx' workload was not found on cluster 'test'.",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			executor := fixDiagExecutor(t, &fakeDiagRunner{})
+
+			// when
+			_, attachment, err := executor.Execute(context.Background(), fixBindingsNames, tc.command)
+
+			// then
+			require.Error(t, err)
+			assert.True(t, IsExecutionCommandError(err))
+			assert.EqualError(t, err, tc.expErr)
+			assert.Nil(t, attachment)
+		})
+	}
+}
+
+func TestDiagExecutePod(t *testing.T) {
+	// given
+	runner := &fakeDiagRunner{}
+	executor := fixDiagExecutor(t, runner)
+
+	// when
+	out, attachment, err := executor.Execute(context.Background(), fixBindingsNames, "diag prod/po/nginx-a --cluster-name=test")
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, "Collected the diagnostics bundle of the 'prod/nginx-a' Pod: 1 Pods, 0 events, 1 log files.", out)
+	require.NotNil(t, attachment)
+	assert.Equal(t, [][]string{{"describe", "pods", "nginx-a", "-n", "prod"}}, runner.gotArgs)
+
+	files := untarDiagArchive(t, attachment.Data)
+	assert.Equal(t, "No events found.\n", files.contents["diag-prod-nginx-a-20221003-081500/events.txt"])
+}
+
+func fixDiagExecutor(t *testing.T, runner *fakeDiagRunner) *Diag {
+	t.Helper()
+
+	cfg := config.Config{
+		Settings: config.Settings{ClusterName: "test"},
+		Executors: map[string]config.Executors{
+			"default": {
+				Diag: config.Diag{Enabled: true, Namespaces: config.Namespaces{Include: []string{"prod"}}},
+			},
+		},
+	}
+
+	selector := map[string]string{"app": "nginx"}
+	k8sCli := fake.NewSimpleClientset(
+		&appsV1.Deployment{
+			ObjectMeta: metaV1.ObjectMeta{Name: "nginx", Namespace: "prod"},
+			Spec:       appsV1.DeploymentSpec{Selector: &metaV1.LabelSelector{MatchLabels: selector}},
+		},
+		fixDiagPod("nginx-b", selector, 3),
+		fixDiagPod("nginx-a", selector, 0),
+		fixDiagPod("redis-0", map[string]string{"app": "redis"}, 0),
+		fixDiagEvent("scaled", "Deployment", "nginx", "Normal", "ScalingReplicaSet", "Scaled up replica set nginx-7d to 2", 1, time.Date(2022, 10, 3, 8, 0, 0, 0, time.UTC)),
+		fixDiagEvent("backoff", "Pod", "nginx-b", "Warning", "BackOff", "Back-off restarting failed container", 4, time.Date(2022, 10, 3, 8, 10, 0, 0, time.UTC)),
+		fixDiagEvent("other", "Pod", "redis-0", "Normal", "Pulled", "Container image already present", 1, time.Date(2022, 10, 3, 8, 5, 0, 0, time.UTC)),
+	)
+	dynamicCli := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		podMetricsGVR: "PodMetricsList",
+	})
+	obj := fixPodMetrics("nginx-a", "prod", "100m", "64Mi")
+	require.NoError(t, dynamicCli.Tracker().Create(podMetricsGVR, obj, obj.GetNamespace()))
+
+	logger, _ := logtest.NewNullLogger()
+	executor := NewDiag(logger, cfg, k8sCli, dynamicCli, runner)
+	executor.nowFn = func() time.Time {
+		return time.Date(2022, 10, 3, 8, 15, 0, 0, time.UTC)
+	}
+	return executor
+}
+
+func fixDiagPod(name string, labels map[string]string, restarts int32) *coreV1.Pod {
+	return &coreV1.Pod{
+		ObjectMeta: metaV1.ObjectMeta{Name: name, Namespace: "prod", Labels: labels},
+		Spec: coreV1.PodSpec{
+			Containers: []coreV1.Container{{Name: "app"}},
+		},
+		Status: coreV1.PodStatus{
+			ContainerStatuses: []coreV1.ContainerStatus{{Name: "app", RestartCount: restarts}},
+		},
+	}
+}
+
+func fixDiagEvent(name, kind, objName, eventType, reason, msg string, count int32, lastSeen time.Time) *coreV1.Event {
+	return &coreV1.Event{
+		ObjectMeta:     metaV1.ObjectMeta{Name: name, Namespace: "prod"},
+		InvolvedObject: coreV1.ObjectReference{Kind: kind, Name: objName, Namespace: "prod"},
+		Type:           eventType,
+		Reason:         reason,
+		Message:        msg,
+		Count:          count,
+		LastTimestamp:  metaV1.NewTime(lastSeen),
+	}
+}
+
+type diagArchiveFiles struct {
+	names    []string
+	contents map[string]string
+}
+
+func untarDiagArchive(t *testing.T, data []byte) diagArchiveFiles {
+	t.Helper()
+
+	gz, err := gzip.NewReader(bytes.NewReader(data))
+	require.NoError(t, err)
+	tr := tar.NewReader(gz)
+
+	out := diagArchiveFiles{contents: map[string]string{}}
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return out
+		}
+		require.NoError(t, err)
+
+		content, err := io.ReadAll(tr)
+		require.NoError(t, err)
+		out.names = append(out.names, hdr.Name)
+		out.contents[hdr.Name] = string(content)
+	}
+}
+
+type fakeDiagRunner struct {
+	gotArgs [][]string
+}
+
+func (r *fakeDiagRunner) RunCombinedOutput(_ string, args []string) (string, error) {
+	r.gotArgs = append(r.gotArgs, args)
+	return "fake describe output\n", nil
+}
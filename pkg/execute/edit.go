@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"sort"
 	"strings"
+	"sync"
 	"unicode"
 
 	"github.com/dustin/go-humanize/english"
@@ -20,6 +21,10 @@ const (
 	editedSourcesMsgFmt              = ":white_check_mark: %s adjusted the Botkube notifications settings to %s messages for this channel. Expect Botkube reload in a few seconds..."
 	editedSourcesMsgWithoutReloadFmt = ":white_check_mark: %s adjusted the Botkube notifications settings to %s messages.\nAs the Config Watcher is disabled, you need to restart Botkube manually to apply the changes."
 	unknownSourcesMsgFmt             = ":exclamation: The %s %s not found in configuration. To learn how to add custom source, visit https://botkube.io/docs/configuration/source."
+
+	editedExecutorsMsgFmt              = ":white_check_mark: %s adjusted the Botkube executors settings to %s for this channel. Expect Botkube reload in a few seconds..."
+	editedExecutorsMsgWithoutReloadFmt = ":white_check_mark: %s adjusted the Botkube executors settings to %s.\nAs the Config Watcher is disabled, you need to restart Botkube manually to apply the changes."
+	unknownExecutorsMsgFmt             = ":exclamation: The %s %s not found in configuration. To learn how to add custom executor, visit https://botkube.io/docs/configuration/executor."
 )
 
 // EditResource defines the name of editable resource
@@ -28,6 +33,8 @@ type EditResource string
 const (
 	// SourceBindings define name of source binding resource
 	SourceBindings EditResource = "SourceBindings"
+	// ExecutorBindings define name of executor binding resource
+	ExecutorBindings EditResource = "ExecutorBindings"
 )
 
 // Key returns normalized edit resource name.
@@ -35,9 +42,10 @@ func (e EditResource) Key() string {
 	return strings.ToLower(string(e))
 }
 
-// BindingsStorage provides functionality to persist source binding for a given channel.
+// BindingsStorage provides functionality to persist source and executor bindings for a given channel.
 type BindingsStorage interface {
 	PersistSourceBindings(ctx context.Context, commGroupName string, platform config.CommPlatformIntegration, channelAlias string, sourceBindings []string) error
+	PersistExecutorBindings(ctx context.Context, commGroupName string, platform config.CommPlatformIntegration, channelAlias string, executorBindings []string) error
 }
 
 // EditExecutor provides functionality to run all Botkube edit related commands.
@@ -45,12 +53,26 @@ type EditExecutor struct {
 	log               logrus.FieldLogger
 	analyticsReporter AnalyticsReporter
 	cfgManager        BindingsStorage
-	sources           map[string]string
-	cfg               config.Config
+
+	mu        sync.RWMutex
+	sources   map[string]string
+	executors map[string]string
+	cfg       config.Config
 }
 
 // NewEditExecutor returns a new EditExecutor instance.
 func NewEditExecutor(log logrus.FieldLogger, analyticsReporter AnalyticsReporter, cfgManager BindingsStorage, cfg config.Config) *EditExecutor {
+	e := &EditExecutor{
+		log:               log,
+		analyticsReporter: analyticsReporter,
+		cfgManager:        cfgManager,
+	}
+	e.ReloadConfig(cfg)
+	return e
+}
+
+// ReloadConfig applies the reloaded configuration, so the edit commands offer the current sources and bindings.
+func (e *EditExecutor) ReloadConfig(cfg config.Config) {
 	normalizedSource := map[string]string{}
 	for key, item := range cfg.Sources {
 		displayName := item.DisplayName
@@ -60,13 +82,17 @@ func NewEditExecutor(log logrus.FieldLogger, analyticsReporter AnalyticsReporter
 		normalizedSource[key] = displayName
 	}
 
-	return &EditExecutor{
-		log:               log,
-		analyticsReporter: analyticsReporter,
-		cfgManager:        cfgManager,
-		sources:           normalizedSource,
-		cfg:               cfg,
+	// executors don't have display names
+	executors := map[string]string{}
+	for key := range cfg.Executors {
+		executors[key] = key
 	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.sources = normalizedSource
+	e.executors = executors
+	e.cfg = cfg
 }
 
 // Do executes a given edit command based on args.
@@ -77,6 +103,9 @@ func (e *EditExecutor) Do(args []string, commGroupName string, platform config.C
 		return empty, errInvalidCommand
 	}
 
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
 	var (
 		cmdName = args[0]
 		cmdVerb = args[1]
@@ -95,6 +124,9 @@ func (e *EditExecutor) Do(args []string, commGroupName string, platform config.C
 		SourceBindings.Key(): func() (interactive.Message, error) {
 			return e.editSourceBindingHandler(cmdArgs, commGroupName, platform, conversation, userID, botName)
 		},
+		ExecutorBindings.Key(): func() (interactive.Message, error) {
+			return e.editExecutorBindingHandler(cmdArgs, commGroupName, platform, conversation, userID, botName)
+		},
 	}
 
 	msg, err := cmds.SelectAndRun(cmdVerb)
@@ -110,13 +142,13 @@ func (e *EditExecutor) Do(args []string, commGroupName string, platform config.C
 func (e *EditExecutor) editSourceBindingHandler(cmdArgs []string, commGroupName string, platform config.CommPlatformIntegration, conversation Conversation, userID, botName string) (interactive.Message, error) {
 	var empty interactive.Message
 
-	sourceBindings, err := e.normalizeSourceItems(cmdArgs)
+	sourceBindings, err := e.normalizeItems(cmdArgs)
 	if err != nil {
 		return empty, fmt.Errorf("while normalizing source args: %w", err)
 	}
 
 	if len(sourceBindings) == 0 {
-		selectedOptions := e.currentlySelectedOptions(commGroupName, platform, conversation.ID)
+		selectedOptions := e.mapToOptions(e.currentBindings(commGroupName, platform, conversation.ID).Sources, e.sources)
 		return interactive.Message{
 			Type: interactive.Popup,
 			Base: interactive.Base{
@@ -131,7 +163,7 @@ func (e *EditExecutor) editSourceBindingHandler(cmdArgs []string, commGroupName
 							Plaintext: "Select notification sources.",
 						},
 						Command:        fmt.Sprintf("%s %s", botName, "edit SourceBindings"),
-						Options:        e.allOptions(),
+						Options:        e.allOptions(e.sources),
 						InitialOptions: selectedOptions,
 					},
 				},
@@ -139,9 +171,9 @@ func (e *EditExecutor) editSourceBindingHandler(cmdArgs []string, commGroupName
 		}, nil
 	}
 
-	unknown := e.getUnknownInputSourceBindings(sourceBindings)
+	unknown := e.getUnknownItems(sourceBindings, e.sources)
 	if len(unknown) > 0 {
-		return e.generateUnknownMessage(unknown), nil
+		return e.generateUnknownMessage(unknownSourcesMsgFmt, "source was", "sources were", unknown), nil
 	}
 
 	err = e.cfgManager.PersistSourceBindings(context.Background(), commGroupName, platform, conversation.Alias, sourceBindings)
@@ -149,7 +181,7 @@ func (e *EditExecutor) editSourceBindingHandler(cmdArgs []string, commGroupName
 		return empty, fmt.Errorf("while persisting source bindings configuration: %w", err)
 	}
 
-	names := e.mapToDisplayNames(sourceBindings)
+	names := e.mapToDisplayNames(sourceBindings, e.sources)
 	names = e.quoteEachItem(names)
 	sourceList := english.OxfordWordSeries(names, "and")
 	if userID == "" {
@@ -171,68 +203,129 @@ func (e *EditExecutor) getEditedSourceBindingsMsg(userID, sourceList string) str
 	return fmt.Sprintf(editedSourcesMsgFmt, userID, sourceList)
 }
 
-func (e *EditExecutor) generateUnknownMessage(unknown []string) interactive.Message {
+func (e *EditExecutor) editExecutorBindingHandler(cmdArgs []string, commGroupName string, platform config.CommPlatformIntegration, conversation Conversation, userID, botName string) (interactive.Message, error) {
+	var empty interactive.Message
+
+	executorBindings, err := e.normalizeItems(cmdArgs)
+	if err != nil {
+		return empty, fmt.Errorf("while normalizing executor args: %w", err)
+	}
+
+	if len(executorBindings) == 0 {
+		selectedOptions := e.mapToOptions(e.currentBindings(commGroupName, platform, conversation.ID).Executors, e.executors)
+		return interactive.Message{
+			Type: interactive.Popup,
+			Base: interactive.Base{
+				Header: "Adjust executors",
+			},
+			OnlyVisibleForYou: true,
+			Sections: []interactive.Section{
+				{
+					MultiSelect: interactive.MultiSelect{
+						Name: "Adjust executors",
+						Description: interactive.Body{
+							Plaintext: "Select executors allowed in this channel.",
+						},
+						Command:        fmt.Sprintf("%s %s", botName, "edit ExecutorBindings"),
+						Options:        e.allOptions(e.executors),
+						InitialOptions: selectedOptions,
+					},
+				},
+			},
+		}, nil
+	}
+
+	unknown := e.getUnknownItems(executorBindings, e.executors)
+	if len(unknown) > 0 {
+		return e.generateUnknownMessage(unknownExecutorsMsgFmt, "executor was", "executors were", unknown), nil
+	}
+
+	err = e.cfgManager.PersistExecutorBindings(context.Background(), commGroupName, platform, conversation.Alias, executorBindings)
+	if err != nil {
+		return empty, fmt.Errorf("while persisting executor bindings configuration: %w", err)
+	}
+
+	names := e.quoteEachItem(e.mapToDisplayNames(executorBindings, e.executors))
+	executorList := english.OxfordWordSeries(names, "and")
+	if userID == "" {
+		userID = "Anonymous"
+	}
+
+	msgFmt := editedExecutorsMsgFmt
+	if !e.cfg.ConfigWatcher.Enabled {
+		msgFmt = editedExecutorsMsgWithoutReloadFmt
+	}
+	return interactive.Message{
+		Base: interactive.Base{
+			Description: fmt.Sprintf(msgFmt, userID, executorList),
+		},
+	}, nil
+}
+
+func (e *EditExecutor) generateUnknownMessage(msgFmt, singular, plural string, unknown []string) interactive.Message {
 	list := english.OxfordWordSeries(e.quoteEachItem(unknown), "and")
-	word := english.PluralWord(len(unknown), "source was", "sources were")
+	word := english.PluralWord(len(unknown), singular, plural)
 	return interactive.Message{
 		Base: interactive.Base{
-			Description: fmt.Sprintf(unknownSourcesMsgFmt, list, word),
+			Description: fmt.Sprintf(msgFmt, list, word),
 		},
 	}
 }
 
-func (e *EditExecutor) currentlySelectedOptions(commGroupName string, platform config.CommPlatformIntegration, conversationID string) []interactive.OptionItem {
+// currentBindings returns the bindings of a given conversation.
+func (e *EditExecutor) currentBindings(commGroupName string, platform config.CommPlatformIntegration, conversationID string) config.BotBindings {
+	commGroup := e.cfg.Communications[commGroupName]
 	switch platform {
 	case config.SlackCommPlatformIntegration:
-		channels := e.cfg.Communications[commGroupName].Slack.Channels
-		for _, channel := range channels {
-			if channel.Identifier() != conversationID {
-				continue
-			}
-			return e.mapToOptions(channel.Bindings.Sources)
+		if channel, found := commGroup.Slack.Channels.GetByIdentifier(conversationID); found {
+			return channel.Bindings
 		}
 	case config.SocketSlackCommPlatformIntegration:
-		channels := e.cfg.Communications[commGroupName].SocketSlack.Channels
-		for _, channel := range channels {
-			if channel.Identifier() != conversationID {
-				continue
-			}
-			return e.mapToOptions(channel.Bindings.Sources)
+		if channel, found := commGroup.SocketSlack.AllChannels().GetByIdentifier(conversationID); found {
+			return channel.Bindings
 		}
 	case config.MattermostCommPlatformIntegration:
-		channels := e.cfg.Communications[commGroupName].Mattermost.Channels
-		for _, channel := range channels {
-			if channel.Identifier() != conversationID {
-				continue
-			}
-			return e.mapToOptions(channel.Bindings.Sources)
+		if channel, found := commGroup.Mattermost.Channels.GetByIdentifier(conversationID); found {
+			return channel.Bindings
 		}
 	case config.DiscordCommPlatformIntegration:
-		channels := e.cfg.Communications[commGroupName].Mattermost.Channels
-		for _, channel := range channels {
-			if channel.Identifier() != conversationID {
-				continue
-			}
-			return e.mapToOptions(channel.Bindings.Sources)
+		if channel, found := commGroup.Discord.Channels.GetByIdentifier(conversationID); found {
+			return channel.Bindings
+		}
+	case config.RocketChatCommPlatformIntegration:
+		if channel, found := commGroup.RocketChat.Channels.GetByIdentifier(conversationID); found {
+			return channel.Bindings
+		}
+	case config.GoogleChatCommPlatformIntegration:
+		if channel, found := commGroup.GoogleChat.Channels.GetByIdentifier(conversationID); found {
+			return channel.Bindings
+		}
+	case config.WebexCommPlatformIntegration:
+		if channel, found := commGroup.Webex.Channels.GetByIdentifier(conversationID); found {
+			return channel.Bindings
+		}
+	case config.MatrixCommPlatformIntegration:
+		if channel, found := commGroup.Matrix.Channels.GetByIdentifier(conversationID); found {
+			return channel.Bindings
 		}
 	case config.TeamsCommPlatformIntegration:
-		return e.mapToOptions(e.cfg.Communications[commGroupName].Teams.Bindings.Sources)
+		return commGroup.Teams.Bindings
 	}
-	return nil
+	return config.BotBindings{}
 }
 
-func (e *EditExecutor) mapToDisplayNames(in []string) []string {
+func (*EditExecutor) mapToDisplayNames(in []string, items map[string]string) []string {
 	var out []string
 	for _, key := range in {
-		out = append(out, e.sources[key])
+		out = append(out, items[key])
 	}
 	return out
 }
 
-func (e *EditExecutor) mapToOptions(in []string) []interactive.OptionItem {
+func (*EditExecutor) mapToOptions(in []string, items map[string]string) []interactive.OptionItem {
 	var options []interactive.OptionItem
 	for _, key := range in {
-		displayName, found := e.sources[key]
+		displayName, found := items[key]
 		if !found {
 			continue
 		}
@@ -244,9 +337,9 @@ func (e *EditExecutor) mapToOptions(in []string) []interactive.OptionItem {
 	return options
 }
 
-func (e *EditExecutor) allOptions() []interactive.OptionItem {
+func (*EditExecutor) allOptions(items map[string]string) []interactive.OptionItem {
 	var options []interactive.OptionItem
-	for key, displayName := range e.sources {
+	for key, displayName := range items {
 		options = append(options, interactive.OptionItem{
 			Name:  displayName,
 			Value: key,
@@ -259,7 +352,7 @@ func (e *EditExecutor) allOptions() []interactive.OptionItem {
 	return options
 }
 
-func (*EditExecutor) normalizeSourceItems(args []string) ([]string, error) {
+func (*EditExecutor) normalizeItems(args []string) ([]string, error) {
 	var out []string
 	for _, item := range args {
 		// Case: "foo,baz,bar"
@@ -294,10 +387,10 @@ func (*EditExecutor) normalizeSourceItems(args []string) ([]string, error) {
 	return out, nil
 }
 
-func (e *EditExecutor) getUnknownInputSourceBindings(sources []string) []string {
+func (*EditExecutor) getUnknownItems(in []string, items map[string]string) []string {
 	var out []string
-	for _, item := range sources {
-		_, found := e.sources[item]
+	for _, item := range in {
+		_, found := items[item]
 		if found {
 			continue
 		}
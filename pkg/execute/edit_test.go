@@ -280,6 +280,52 @@ func TestSourceBindingsMultiSelectMessage(t *testing.T) {
 	assert.Equal(t, expMsg, gotMsg)
 }
 
+func TestSourceBindingsMultiSelectMessageAfterReload(t *testing.T) {
+	// given
+	log, _ := logtest.NewNullLogger()
+
+	args := strings.Fields(strings.TrimSpace(`edit SourceBindings`))
+	executor := NewEditExecutor(log, &fakeAnalyticsReporter{}, nil, config.Config{
+		Sources: map[string]config.Sources{
+			"bar": {DisplayName: "BAR"},
+		},
+	})
+
+	// when
+	executor.ReloadConfig(config.Config{
+		Sources: map[string]config.Sources{
+			"bar": {DisplayName: "BAR"},
+			"foo": {DisplayName: "FOO"},
+		},
+		Communications: map[string]config.Communications{
+			groupName: {
+				Slack: config.Slack{
+					Channels: config.IdentifiableMap[config.ChannelBindingsByName]{
+						conversation.ID: config.ChannelBindingsByName{
+							Name: conversation.ID,
+							Bindings: config.BotBindings{
+								Sources: []string{"foo"},
+							},
+						},
+					},
+				},
+			},
+		},
+	})
+	gotMsg, err := executor.Do(args, groupName, platform, conversation, userID, botName)
+
+	// then
+	require.NoError(t, err)
+	require.Len(t, gotMsg.Sections, 1)
+	assert.Equal(t, []interactive.OptionItem{
+		{Name: "BAR", Value: "bar"},
+		{Name: "FOO", Value: "foo"},
+	}, gotMsg.Sections[0].MultiSelect.Options)
+	assert.Equal(t, []interactive.OptionItem{
+		{Name: "FOO", Value: "foo"},
+	}, gotMsg.Sections[0].MultiSelect.InitialOptions)
+}
+
 func TestSourceBindingsMultiSelectMessageWithIncorrectBindingConfig(t *testing.T) {
 	// given
 	log, _ := logtest.NewNullLogger()
@@ -339,11 +385,177 @@ func TestSourceBindingsMultiSelectMessageWithIncorrectBindingConfig(t *testing.T
 	assert.EqualValues(t, expMsg, gotMsg)
 }
 
+func TestExecutorBindingsHappyPath(t *testing.T) {
+	cfg := config.Config{
+		Executors: map[string]config.Executors{
+			"kubectl-read-only": {},
+			"kubectl-all":       {},
+			"helm":              {},
+		},
+		ConfigWatcher: config.CfgWatcher{
+			Enabled: true,
+		},
+	}
+	cfgWithCfgWatcherDisabled := config.Config{Executors: cfg.Executors}
+
+	tests := []struct {
+		name    string
+		command string
+		config  config.Config
+
+		message          string
+		executorBindings []string
+	}{
+		{
+			name:    "Should resolve quoted list which is separated by comma",
+			command: `edit ExecutorBindings "kubectl-read-only,helm"`,
+			config:  cfg,
+
+			message:          ":white_check_mark: Joe adjusted the Botkube executors settings to `kubectl-read-only` and `helm` for this channel. Expect Botkube reload in a few seconds...",
+			executorBindings: []string{"kubectl-read-only", "helm"},
+		},
+		{
+			name:    "Should resolve list which has mixed formatting for different items",
+			command: "edit executorbindings `kubectl-all`, helm",
+			config:  cfg,
+
+			message:          ":white_check_mark: Joe adjusted the Botkube executors settings to `kubectl-all` and `helm` for this channel. Expect Botkube reload in a few seconds...",
+			executorBindings: []string{"kubectl-all", "helm"},
+		},
+		{
+			name:    "Should mention manual app restart",
+			command: `edit ExecutorBindings kubectl-read-only`,
+			config:  cfgWithCfgWatcherDisabled,
+
+			message:          ":white_check_mark: Joe adjusted the Botkube executors settings to `kubectl-read-only`.\nAs the Config Watcher is disabled, you need to restart Botkube manually to apply the changes.",
+			executorBindings: []string{"kubectl-read-only"},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// given
+			log, _ := logtest.NewNullLogger()
+
+			fakeStorage := &fakeBindingsStorage{}
+			args := strings.Fields(strings.TrimSpace(tc.command))
+			executor := NewEditExecutor(log, &fakeAnalyticsReporter{}, fakeStorage, tc.config)
+
+			expMessage := interactive.Message{
+				Base: interactive.Base{
+					Description: tc.message,
+				},
+			}
+			// when
+			msg, err := executor.Do(args, groupName, platform, conversation, userID, botName)
+
+			// then
+			require.NoError(t, err)
+			assert.Equal(t, expMessage, msg)
+			assert.Equal(t, tc.executorBindings, fakeStorage.executorBindings)
+			assert.Nil(t, fakeStorage.sourceBindings)
+			assert.Equal(t, groupName, fakeStorage.commGroupName)
+			assert.Equal(t, platform, fakeStorage.platform)
+			assert.Equal(t, conversation.Alias, fakeStorage.channelAlias)
+		})
+	}
+}
+
+func TestExecutorBindingsUnknownExecutor(t *testing.T) {
+	// given
+	log, _ := logtest.NewNullLogger()
+
+	args := strings.Fields(`edit ExecutorBindings helm,something-else`)
+	cfg := config.Config{
+		Executors: map[string]config.Executors{
+			"helm": {},
+		},
+	}
+	expMsg := interactive.Message{
+		Base: interactive.Base{
+			Description: ":exclamation: The `something-else` executor was not found in configuration. To learn how to add custom executor, visit https://botkube.io/docs/configuration/executor.",
+		},
+	}
+
+	executor := NewEditExecutor(log, &fakeAnalyticsReporter{}, nil, cfg)
+
+	// when
+	gotMsg, err := executor.Do(args, groupName, platform, conversation, userID, botName)
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, expMsg, gotMsg)
+}
+
+func TestExecutorBindingsMultiSelectMessage(t *testing.T) {
+	// given
+	log, _ := logtest.NewNullLogger()
+
+	args := strings.Fields(`edit ExecutorBindings`)
+	cfg := config.Config{
+		Executors: map[string]config.Executors{
+			"kubectl-read-only": {},
+			"kubectl-all":       {},
+			"helm":              {},
+		},
+		Communications: map[string]config.Communications{
+			groupName: {
+				Discord: config.Discord{
+					Channels: config.IdentifiableMap[config.ChannelBindingsByID]{
+						"alias": config.ChannelBindingsByID{
+							ID: conversation.ID,
+							Bindings: config.BotBindings{
+								Executors: []string{"kubectl-read-only", "unknown"},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	expMsg := interactive.Message{
+		Type: interactive.Popup,
+		Base: interactive.Base{
+			Header: "Adjust executors",
+		},
+		OnlyVisibleForYou: true,
+		Sections: []interactive.Section{
+			{
+				MultiSelect: interactive.MultiSelect{
+					Name: "Adjust executors",
+					Description: interactive.Body{
+						Plaintext: "Select executors allowed in this channel.",
+					},
+					Command: "Botkube edit ExecutorBindings",
+					Options: []interactive.OptionItem{
+						{Name: "helm", Value: "helm"},
+						{Name: "kubectl-all", Value: "kubectl-all"},
+						{Name: "kubectl-read-only", Value: "kubectl-read-only"},
+					},
+					InitialOptions: []interactive.OptionItem{
+						{Name: "kubectl-read-only", Value: "kubectl-read-only"},
+					},
+				},
+			},
+		},
+	}
+
+	executor := NewEditExecutor(log, &fakeAnalyticsReporter{}, nil, cfg)
+
+	// when
+	gotMsg, err := executor.Do(args, groupName, config.DiscordCommPlatformIntegration, conversation, userID, botName)
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, expMsg, gotMsg)
+}
+
 type fakeBindingsStorage struct {
-	commGroupName  string
-	platform       config.CommPlatformIntegration
-	channelAlias   string
-	sourceBindings []string
+	commGroupName    string
+	platform         config.CommPlatformIntegration
+	channelAlias     string
+	sourceBindings   []string
+	executorBindings []string
 }
 
 func (f *fakeBindingsStorage) PersistSourceBindings(_ context.Context, commGroupName string, platform config.CommPlatformIntegration, channelAlias string, sourceBindings []string) error {
@@ -353,3 +565,11 @@ func (f *fakeBindingsStorage) PersistSourceBindings(_ context.Context, commGroup
 	f.sourceBindings = sourceBindings
 	return nil
 }
+
+func (f *fakeBindingsStorage) PersistExecutorBindings(_ context.Context, commGroupName string, platform config.CommPlatformIntegration, channelAlias string, executorBindings []string) error {
+	f.commGroupName = commGroupName
+	f.platform = platform
+	f.channelAlias = channelAlias
+	f.executorBindings = executorBindings
+	return nil
+}
@@ -0,0 +1,324 @@
+package execute
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mattn/go-shellwords"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/pflag"
+	coreV1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/duration"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+const (
+	eventsCommandName         = "events"
+	eventsDefaultNamespace    = "default"
+	eventsDefaultSince        = time.Hour
+	eventsWarningType         = "Warning"
+	eventsInvalidForMsg       = "Please specify the object as '<kind>/<name>', e.g. 'events --for deploy/nginx'."
+	eventsInvalidSinceMsgFmt  = "Sorry, '%s' is not a valid duration. Please specify it as e.g. '30m' or '2h'."
+	eventsNotAllowedNsMsgFmt  = "Sorry, the events cannot be queried for the '%s' Namespace on cluster '%s'."
+	eventsNotAllowedAllNsMsg  = "Sorry, the events cannot be queried for all Namespaces on cluster '%s'."
+	eventsNoEventsMsgFmt      = "No events found %s in the last %s."
+	eventsSummaryMsgFmt       = "%d events of %d objects %s in the last %s:"
+	eventsInNamespaceMsgFmt   = "in the '%s' Namespace"
+	eventsInAllNamespacesMsg  = "in all Namespaces"
+	eventsForObjectMsgFmt     = "for %s/%s"
+	eventsWarningsCountMsgFmt = " (%d warnings)"
+)
+
+// eventsKindAliases maps the short names and plural forms of the common kinds to the kinds.
+// Other kinds must be specified by their names, e.g. `HorizontalPodAutoscaler`.
+var eventsKindAliases = map[string]string{
+	"deploy":       diagDeploymentKind,
+	"deployment":   diagDeploymentKind,
+	"deployments":  diagDeploymentKind,
+	"sts":          diagStatefulSetKind,
+	"statefulset":  diagStatefulSetKind,
+	"statefulsets": diagStatefulSetKind,
+	"ds":           diagDaemonSetKind,
+	"daemonset":    diagDaemonSetKind,
+	"daemonsets":   diagDaemonSetKind,
+	"po":           diagPodKind,
+	"pod":          diagPodKind,
+	"pods":         diagPodKind,
+	"rs":           "ReplicaSet",
+	"replicaset":   "ReplicaSet",
+	"replicasets":  "ReplicaSet",
+	"job":          "Job",
+	"jobs":         "Job",
+	"cj":           "CronJob",
+	"cronjob":      "CronJob",
+	"cronjobs":     "CronJob",
+	"svc":          "Service",
+	"service":      "Service",
+	"services":     "Service",
+	"no":           "Node",
+	"node":         "Node",
+	"nodes":        "Node",
+	"pvc":          "PersistentVolumeClaim",
+	"hpa":          "HorizontalPodAutoscaler",
+	"ing":          "Ingress",
+	"ingress":      "Ingress",
+}
+
+// eventsQuery describes the queried events.
+type eventsQuery struct {
+	Namespace     string
+	AllNamespaces bool
+	// ForKind and ForName are empty if the events are not filtered by the involved object.
+	ForKind string
+	ForName string
+	Since   time.Duration
+}
+
+// eventsGroup holds the events of a single involved object, sorted from the most recent one.
+type eventsGroup struct {
+	Object   coreV1.ObjectReference
+	Events   []coreV1.Event
+	Warnings int
+}
+
+// Events reports the recent Kubernetes events grouped by the involved object.
+type Events struct {
+	log    logrus.FieldLogger
+	cfg    config.Config
+	k8sCli kubernetes.Interface
+	nowFn  func() time.Time
+}
+
+// NewEvents creates a new instance of Events.
+func NewEvents(log logrus.FieldLogger, cfg config.Config, k8sCli kubernetes.Interface) *Events {
+	return &Events{
+		log:    log,
+		cfg:    cfg,
+		k8sCli: k8sCli,
+		nowFn:  time.Now,
+	}
+}
+
+// CanHandle returns true if it's an events command and at least one Events executor is enabled for given bindings.
+func (e *Events) CanHandle(bindings []string, args []string) bool {
+	if len(args) == 0 || args[0] != eventsCommandName || e.k8sCli == nil {
+		return false
+	}
+
+	for _, name := range bindings {
+		if e.cfg.Executors[name].Events.Enabled {
+			return true
+		}
+	}
+	return false
+}
+
+// Execute executes a given events command and renders the events summary.
+//
+// This method should be called ONLY if:
+// - we are a target cluster,
+// - and Events.CanHandle returned true.
+func (e *Events) Execute(ctx context.Context, bindings []string, command string) (string, error) {
+	log := e.log.WithField("command", command)
+	log.Debugf("Handling command...")
+
+	query, err := parseEventsCommand(command, e.defaultSince(bindings))
+	if err != nil {
+		return "", err
+	}
+
+	clusterName := e.cfg.Settings.ClusterName
+	namespace := query.Namespace
+	if query.AllNamespaces {
+		if !e.isNamespaceAllowed(bindings, config.AllNamespaceIndicator) {
+			return "", NewExecutionCommandError(eventsNotAllowedAllNsMsg, clusterName)
+		}
+		namespace = metaV1.NamespaceAll
+	} else if !e.isNamespaceAllowed(bindings, namespace) {
+		return "", NewExecutionCommandError(eventsNotAllowedNsMsgFmt, namespace, clusterName)
+	}
+
+	list, err := e.k8sCli.CoreV1().Events(namespace).List(ctx, metaV1.ListOptions{})
+	if err != nil {
+		return "", fmt.Errorf("while listing events: %w", err)
+	}
+
+	now := e.nowFn()
+	var events []coreV1.Event
+	for _, event := range list.Items {
+		if now.Sub(diagEventTime(event)) > query.Since {
+			continue
+		}
+		obj := event.InvolvedObject
+		if query.ForName != "" && (obj.Name != query.ForName || !strings.EqualFold(obj.Kind, query.ForKind)) {
+			continue
+		}
+		events = append(events, event)
+	}
+
+	scope := fmt.Sprintf(eventsInNamespaceMsgFmt, query.Namespace)
+	if query.AllNamespaces {
+		scope = eventsInAllNamespacesMsg
+	}
+	if query.ForName != "" {
+		scope = fmt.Sprintf(eventsForObjectMsgFmt, strings.ToLower(query.ForKind), query.ForName) + " " + scope
+	}
+	since := duration.HumanDuration(query.Since)
+	if len(events) == 0 {
+		return fmt.Sprintf(eventsNoEventsMsgFmt, scope, since), nil
+	}
+
+	groups := groupEvents(events)
+	out := []string{fmt.Sprintf(eventsSummaryMsgFmt, len(events), len(groups), scope, since)}
+	for _, group := range groups {
+		out = append(out, "", renderEventsGroup(group, query.AllNamespaces, now))
+	}
+	return strings.Join(out, "\n"), nil
+}
+
+func (e *Events) isNamespaceAllowed(bindings []string, namespace string) bool {
+	for _, name := range bindings {
+		events := e.cfg.Executors[name].Events
+		if events.Enabled && events.Namespaces.IsAllowed(namespace) {
+			return true
+		}
+	}
+	return false
+}
+
+// defaultSince returns the default time range of the last enabled Events executor which specifies it.
+func (e *Events) defaultSince(bindings []string) time.Duration {
+	out := eventsDefaultSince
+	for _, name := range bindings {
+		events := e.cfg.Executors[name].Events
+		if events.Enabled && events.DefaultSince > 0 {
+			out = events.DefaultSince
+		}
+	}
+	return out
+}
+
+// parseEventsCommand parses a given events command, e.g. `events -n prod --for deploy/nginx --since 2h`.
+func parseEventsCommand(command string, defaultSince time.Duration) (eventsQuery, error) {
+	args, err := shellwords.Parse(strings.TrimSpace(command))
+	if err != nil {
+		return eventsQuery{}, fmt.Errorf("while parsing the command message into args: %w", err)
+	}
+	args = removeClusterFlags(args[1:])
+
+	f := pflag.NewFlagSet("extract-events-flags", pflag.ContinueOnError)
+	// ignore unknown flags errors, e.g. `--types` etc.
+	f.ParseErrorsWhitelist.UnknownFlags = true
+
+	var (
+		out       eventsQuery
+		forObject string
+		since     string
+	)
+	f.StringVarP(&out.Namespace, "namespace", "n", "", "Kubernetes Namespace")
+	f.BoolVarP(&out.AllNamespaces, "all-namespaces", "A", false, "Kubernetes All Namespaces")
+	f.StringVar(&forObject, "for", "", "Involved object")
+	f.StringVar(&since, "since", "", "Time range")
+	if err := f.Parse(args); err != nil {
+		return eventsQuery{}, fmt.Errorf("while parsing flags: %w", err)
+	}
+
+	if out.Namespace == "" {
+		out.Namespace = eventsDefaultNamespace
+	}
+
+	out.Since = defaultSince
+	if since != "" {
+		out.Since, err = time.ParseDuration(since)
+		if err != nil || out.Since <= 0 {
+			return eventsQuery{}, NewExecutionCommandError(eventsInvalidSinceMsgFmt, since)
+		}
+	}
+
+	if forObject != "" {
+		kind, name, found := strings.Cut(forObject, "/")
+		if !found || kind == "" || name == "" {
+			return eventsQuery{}, NewExecutionCommandError(eventsInvalidForMsg)
+		}
+		out.ForKind = kind
+		if alias, found := eventsKindAliases[strings.ToLower(kind)]; found {
+			out.ForKind = alias
+		}
+		out.ForName = name
+	}
+	return out, nil
+}
+
+// groupEvents groups a given events by the involved object. The objects with warnings go first, then the ones with the most recent events.
+func groupEvents(events []coreV1.Event) []eventsGroup {
+	groups := map[coreV1.ObjectReference]*eventsGroup{}
+	for _, event := range events {
+		obj := coreV1.ObjectReference{
+			Kind:      event.InvolvedObject.Kind,
+			Namespace: event.InvolvedObject.Namespace,
+			Name:      event.InvolvedObject.Name,
+		}
+		group, found := groups[obj]
+		if !found {
+			group = &eventsGroup{Object: obj}
+			groups[obj] = group
+		}
+		group.Events = append(group.Events, event)
+		if event.Type == eventsWarningType {
+			group.Warnings++
+		}
+	}
+
+	out := make([]eventsGroup, 0, len(groups))
+	for _, group := range groups {
+		sort.SliceStable(group.Events, func(i, j int) bool {
+			return diagEventTime(group.Events[i]).After(diagEventTime(group.Events[j]))
+		})
+		out = append(out, *group)
+	}
+
+	sort.Slice(out, func(i, j int) bool {
+		if (out[i].Warnings > 0) != (out[j].Warnings > 0) {
+			return out[i].Warnings > 0
+		}
+		ti, tj := diagEventTime(out[i].Events[0]), diagEventTime(out[j].Events[0])
+		if !ti.Equal(tj) {
+			return ti.After(tj)
+		}
+		return eventsObjectName(out[i].Object, true) < eventsObjectName(out[j].Object, true)
+	})
+	return out
+}
+
+// renderEventsGroup renders the events of a single object, one per line, so the summary is readable on narrow screens.
+func renderEventsGroup(group eventsGroup, withNamespace bool, now time.Time) string {
+	header := eventsObjectName(group.Object, withNamespace)
+	if group.Warnings > 0 {
+		header += fmt.Sprintf(eventsWarningsCountMsgFmt, group.Warnings)
+	}
+
+	out := []string{header}
+	for _, event := range group.Events {
+		line := fmt.Sprintf("  %s ago  %s %s", duration.HumanDuration(now.Sub(diagEventTime(event))), event.Type, event.Reason)
+		if event.Count > 1 {
+			line += fmt.Sprintf(" (x%d)", event.Count)
+		}
+		line += ": " + strings.TrimSpace(event.Message)
+		out = append(out, line)
+	}
+	return strings.Join(out, "\n")
+}
+
+func eventsObjectName(obj coreV1.ObjectReference, withNamespace bool) string {
+	name := fmt.Sprintf("%s/%s", strings.ToLower(obj.Kind), obj.Name)
+	if withNamespace && obj.Namespace != "" {
+		return fmt.Sprintf("%s/%s", obj.Namespace, name)
+	}
+	return name
+}
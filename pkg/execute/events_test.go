@@ -0,0 +1,166 @@
+package execute
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	logtest "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	coreV1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+func TestEventsExecute(t *testing.T) {
+	// given
+	tests := []struct {
+		name string
+
+		command string
+		expOut  string
+	}{
+		{
+			name:    "Should group events of default time range by object",
+			command: "events -n prod",
+			expOut: "3 events of 2 objects in the 'prod' Namespace in the last 60m:\n" +
+				"\n" +
+				"pod/nginx-b (1 warnings)\n" +
+				"  45m ago  Warning BackOff (x4): Back-off restarting failed container\n" +
+				"\n" +
+				"deployment/nginx\n" +
+				"  5m ago  Normal ScalingReplicaSet: Scaled up replica set nginx-7d to 2\n" +
+				"  50m ago  Normal ScalingReplicaSet: Scaled up replica set nginx-7d to 1",
+		},
+		{
+			name:    "Should filter events by object",
+			command: "events -n prod --for deploy/nginx --since 10m --cluster-name test",
+			expOut: "1 events of 1 objects for deployment/nginx in the 'prod' Namespace in the last 10m:\n" +
+				"\n" +
+				"deployment/nginx\n" +
+				"  5m ago  Normal ScalingReplicaSet: Scaled up replica set nginx-7d to 2",
+		},
+		{
+			name:    "Should report events of all Namespaces",
+			command: "events -A --since 3h",
+			expOut: "5 events of 4 objects in all Namespaces in the last 3h:\n" +
+				"\n" +
+				"prod/pod/nginx-b (1 warnings)\n" +
+				"  45m ago  Warning BackOff (x4): Back-off restarting failed container\n" +
+				"\n" +
+				"prod/deployment/nginx\n" +
+				"  5m ago  Normal ScalingReplicaSet: Scaled up replica set nginx-7d to 2\n" +
+				"  50m ago  Normal ScalingReplicaSet: Scaled up replica set nginx-7d to 1\n" +
+				"\n" +
+				"default/pod/redis-0\n" +
+				"  30m ago  Normal Pulled: Container image already present\n" +
+				"\n" +
+				"prod/job/backup\n" +
+				"  120m ago  Normal Completed: Job completed",
+		},
+		{
+			name:    "Should report no events",
+			command: "events --for pod/redis-0 --since 5m",
+			expOut:  "No events found for pod/redis-0 in the 'default' Namespace in the last 5m.",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			executor := fixEventsExecutor(config.Namespaces{Include: []string{".*"}})
+
+			// when
+			out, err := executor.Execute(context.Background(), fixBindingsNames, tc.command)
+
+			// then
+			require.NoError(t, err)
+			assert.Equal(t, tc.expOut, out)
+		})
+	}
+}
+
+func TestEventsExecuteErrors(t *testing.T) {
+	// given
+	tests := []struct {
+		name string
+
+		command string
+		expErr  string
+	}{
+		{
+			name:    "Should forbid not allowed Namespace",
+			command: "events -n kube-system",
+			expErr:  "Sorry, the events cannot be queried for the 'kube-system' Namespace on cluster 'test'.",
+		},
+		{
+			name:    "Should forbid all Namespaces",
+			command: "events -A",
+			expErr:  "Sorry, the events cannot be queried for all Namespaces on cluster 'test'.",
+		},
+		{
+			name:    "Should require valid object",
+			command: "events -n prod --for nginx",
+			expErr:  "Please specify the object as '<kind>/<name>', e.g. 'events --for deploy/nginx'.",
+		},
+		{
+			name:    "Should require valid time range",
+			command: "events -n prod --since 1d",
+			expErr:  "Sorry, '1d' is not a valid duration. Please specify it as e.g. '30m' or '2h'.",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			executor := fixEventsExecutor(config.Namespaces{Include: []string{"prod"}})
+
+			// when
+			_, err := executor.Execute(context.Background(), fixBindingsNames, tc.command)
+
+			// then
+			require.Error(t, err)
+			assert.True(t, IsExecutionCommandError(err))
+			assert.EqualError(t, err, tc.expErr)
+		})
+	}
+}
+
+func fixEventsExecutor(namespaces config.Namespaces) *Events {
+	cfg := config.Config{
+		Settings: config.Settings{ClusterName: "test"},
+		Executors: map[string]config.Executors{
+			"default": {
+				Events: config.Events{Enabled: true, Namespaces: namespaces},
+			},
+		},
+	}
+
+	now := time.Date(2022, 10, 3, 9, 0, 0, 0, time.UTC)
+	k8sCli := fake.NewSimpleClientset(
+		fixEvent("prod", "scaled-1", "Deployment", "nginx", "Normal", "ScalingReplicaSet", "Scaled up replica set nginx-7d to 1", 1, now.Add(-50*time.Minute)),
+		fixEvent("prod", "scaled-2", "Deployment", "nginx", "Normal", "ScalingReplicaSet", "Scaled up replica set nginx-7d to 2", 1, now.Add(-5*time.Minute)),
+		fixEvent("prod", "backoff", "Pod", "nginx-b", "Warning", "BackOff", "Back-off restarting failed container", 4, now.Add(-45*time.Minute)),
+		fixEvent("prod", "completed", "Job", "backup", "Normal", "Completed", "Job completed", 1, now.Add(-2*time.Hour)),
+		fixEvent("default", "pulled", "Pod", "redis-0", "Normal", "Pulled", "Container image already present", 1, now.Add(-30*time.Minute)),
+		fixEvent("default", "old", "Pod", "redis-0", "Normal", "Started", "Started container", 1, now.Add(-24*time.Hour)),
+	)
+
+	logger, _ := logtest.NewNullLogger()
+	executor := NewEvents(logger, cfg, k8sCli)
+	executor.nowFn = func() time.Time {
+		return now
+	}
+	return executor
+}
+
+func fixEvent(namespace, name, kind, objName, eventType, reason, msg string, count int32, lastSeen time.Time) *coreV1.Event {
+	return &coreV1.Event{
+		ObjectMeta:     metaV1.ObjectMeta{Name: name, Namespace: namespace},
+		InvolvedObject: coreV1.ObjectReference{Kind: kind, Name: objName, Namespace: namespace},
+		Type:           eventType,
+		Reason:         reason,
+		Message:        msg,
+		Count:          count,
+		LastTimestamp:  metaV1.NewTime(lastSeen),
+	}
+}
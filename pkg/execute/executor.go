@@ -12,8 +12,10 @@ import (
 	"github.com/sirupsen/logrus"
 	"gopkg.in/yaml.v3"
 
+	"github.com/kubeshop/botkube/pkg/bot/identity"
 	"github.com/kubeshop/botkube/pkg/bot/interactive"
 	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/execute/audit"
 	"github.com/kubeshop/botkube/pkg/execute/command"
 	"github.com/kubeshop/botkube/pkg/execute/kubectl"
 	"github.com/kubeshop/botkube/pkg/filterengine"
@@ -26,15 +28,9 @@ var (
 )
 
 const (
-	unsupportedCmdMsg   = "Command not supported. Please use 'help' to see supported commands."
-	filterNameMissing   = "You forgot to pass filter name. Please pass one of the following valid filters:\n\n%s"
-	filterEnabled       = "I have enabled '%s' filter on '%s' cluster."
-	filterDisabled      = "Done. I won't run '%s' filter on '%s' cluster."
-	internalErrorMsgFmt = "Sorry, an internal error occurred while executing your command for the '%s' cluster :( See the logs for more details."
-	emptyResponseMsg    = ".... empty response _*<cricket sounds>*_ :cricket: :cricket: :cricket:"
-
-	// incompleteCmdMsg incomplete command response message
-	incompleteCmdMsg = "You missed to pass options for the command. Please use 'help' to see command options."
+	filterNameMissing = "You forgot to pass filter name. Please pass one of the following valid filters:\n\n%s"
+	filterEnabled     = "I have enabled '%s' filter on '%s' cluster."
+	filterDisabled    = "Done. I won't run '%s' filter on '%s' cluster."
 
 	anonymizedInvalidVerb = "{invalid verb}"
 
@@ -53,17 +49,40 @@ type DefaultExecutor struct {
 	analyticsReporter AnalyticsReporter
 	cmdRunner         CommandSeparateOutputRunner
 	kubectlExecutor   *Kubectl
+	helmExecutor      *Helm
+	topExecutor       *Top
+	diagExecutor      *Diag
+	eventsExecutor    *Events
+	pluginExecutor    *PluginExecutor
 	editExecutor      *EditExecutor
 	notifierExecutor  *NotifierExecutor
 	notifierHandler   NotifierHandler
 	message           string
+	stdin             []byte
 	platform          config.CommPlatformIntegration
 	conversation      Conversation
 	merger            *kubectl.Merger
 	cfgManager        ConfigPersistenceManager
 	commGroupName     string
 	user              string
+	userID            string
+	userGroups        []string
+	identity          identity.Identity
 	kubectlCmdBuilder *KubectlCmdBuilder
+	localizer         *interactive.Localizer
+	remoteClusters    RemoteClusters
+	auditRecorder     AuditRecorder
+	aliases           *AliasStore
+	confirmations     *ConfirmationStore
+	commandQueue      *CommandQueue
+	schedules         *ScheduleStore
+	configReloader    ConfigReloader
+	// confirmed is true if the executed command was confirmed by the user, so it's not confirmed again.
+	confirmed bool
+	// handleChunk handles the output chunks of the streamed kubectl commands. It's nil if the output is not streamed.
+	handleChunk OutputChunkHandler
+	// auditStatus is the status of the executed command recorded in the audit log. It's empty if the command succeeded.
+	auditStatus audit.Status
 }
 
 // NotifierAction creates custom type for notifier actions
@@ -74,6 +93,10 @@ const (
 	Start      NotifierAction = "start"
 	Stop       NotifierAction = "stop"
 	Status     NotifierAction = "status"
+	Snooze     NotifierAction = "snooze"
+	Mute       NotifierAction = "mute"
+	Unmute     NotifierAction = "unmute"
+	Mutes      NotifierAction = "mutes"
 	ShowConfig NotifierAction = "showconfig"
 )
 
@@ -91,6 +114,9 @@ const (
 	AbbrFollowFlag CommandFlags = "-f"
 	WatchFlag      CommandFlags = "--watch"
 	AbbrWatchFlag  CommandFlags = "-w"
+
+	// AbbrClusterFlag is an alias of ClusterFlag, used to target the clusters connected to the Botkube hub.
+	AbbrClusterFlag CommandFlags = "--cluster"
 )
 
 func (flag CommandFlags) String() string {
@@ -117,16 +143,32 @@ const (
 
 // Execute executes commands and returns output
 func (e *DefaultExecutor) Execute(ctx context.Context) interactive.Message {
+	msg := e.execute(ctx)
+	e.recordAudit(ctx, msg)
+	return msg
+}
+
+func (e *DefaultExecutor) execute(ctx context.Context) interactive.Message {
 	empty := interactive.Message{}
 	rawCmd := utils.RemoveAnyHyperlinks(e.message)
 	rawCmd = strings.NewReplacer(`“`, `"`, `”`, `"`, `‘`, `"`, `’`, `"`).Replace(rawCmd)
 	clusterName := e.cfg.Settings.ClusterName
-	inClusterName := utils.GetClusterNameFromKubectlCmd(rawCmd)
 	botName := e.notifierHandler.BotName()
 
+	rawCmd, err := e.aliases.Expand(rawCmd)
+	if err != nil {
+		return e.respondWithFailure(err.Error(), e.message, "", botName)
+	}
+	if !e.isCommandPermitted(rawCmd) {
+		e.log.Debugf("Command %q is not permitted in channel %q", rawCmd, e.conversation.ID)
+		e.auditStatus = audit.StatusFailed
+		return CommandNotPermittedMessage(rawCmd)
+	}
+	inClusterName := utils.GetClusterNameFromKubectlCmd(rawCmd)
+
 	execFilter, err := extractExecutorFilter(rawCmd)
 	if err != nil {
-		return e.respond(err.Error(), rawCmd, "", botName)
+		return e.respondWithFailure(err.Error(), rawCmd, "", botName)
 	}
 
 	args := strings.Fields(rawCmd)
@@ -134,7 +176,7 @@ func (e *DefaultExecutor) Execute(ctx context.Context) interactive.Message {
 		if e.conversation.IsAuthenticated {
 			return interactive.Message{
 				Base: interactive.Base{
-					Description: unsupportedCmdMsg,
+					Description: e.localizer.Sprintf(e.conversation.Locale, interactive.UnsupportedCommandMsg),
 				},
 			}
 		}
@@ -142,6 +184,10 @@ func (e *DefaultExecutor) Execute(ctx context.Context) interactive.Message {
 	}
 
 	if inClusterName != "" && inClusterName != clusterName {
+		if e.conversation.IsAuthenticated && e.remoteClusters != nil && e.remoteClusters.IsConnected(inClusterName) {
+			return e.executeOnRemoteCluster(ctx, inClusterName, rawCmd, botName)
+		}
+
 		e.log.WithFields(logrus.Fields{
 			"config-cluster-name":  clusterName,
 			"command-cluster-name": inClusterName,
@@ -149,18 +195,139 @@ func (e *DefaultExecutor) Execute(ctx context.Context) interactive.Message {
 		return empty // user specified different target cluster
 	}
 
+	switch args[0] {
+	case confirmCommandName:
+		err := e.confirmCommand(args)
+		switch {
+		case err == nil:
+			return e.execute(ctx)
+		case errors.Is(err, errInvalidCommand):
+			return e.respondWithFailure(e.localizer.Sprintf(e.conversation.Locale, interactive.IncompleteCommandMsg), rawCmd, execFilter.FilteredCommand(), botName)
+		default:
+			return e.respondWithFailure(err.Error(), rawCmd, execFilter.FilteredCommand(), botName)
+		}
+	case cancelCommandName:
+		out, err := e.runCancelCommand(args)
+		switch {
+		case err == nil:
+			return e.respond(out, rawCmd, execFilter.FilteredCommand(), botName)
+		case errors.Is(err, errInvalidCommand):
+			return e.respondWithFailure(e.localizer.Sprintf(e.conversation.Locale, interactive.IncompleteCommandMsg), rawCmd, execFilter.FilteredCommand(), botName)
+		default:
+			return e.respondWithFailure(err.Error(), rawCmd, execFilter.FilteredCommand(), botName)
+		}
+	}
+
+	if !e.identity.IsEmpty() {
+		e.log.WithFields(logrus.Fields{
+			"username": e.identity.Username,
+			"groups":   e.identity.Groups,
+		}).Debugf("Command sent by a user mapped to Kubernetes identity")
+	}
+
+	// the top executor takes precedence over the `kubectl top` command, as it's enabled explicitly
+	if e.conversation.IsAuthenticated && e.topExecutor.CanHandle(e.conversation.ExecutorBindings, args) {
+		e.reportCommand(e.topExecutor.GetCommandPrefix(args), execFilter.IsActive())
+		var out string
+		bindings, err := e.authorizedBindings(isTopEnabled, e.topExecutor.rbacCommand, execFilter.FilteredCommand())
+		if err == nil {
+			out, err = e.topExecutor.Execute(ctx, bindings, execFilter.FilteredCommand())
+		}
+		switch {
+		case err == nil:
+		case IsExecutionCommandError(err):
+			return e.respondWithFailure(err.Error(), rawCmd, execFilter.FilteredCommand(), botName)
+		default:
+			e.log.Errorf("while executing top: %s", err.Error())
+			e.auditStatus = audit.StatusError
+			return empty
+		}
+		return e.respond(execFilter.Apply(out), rawCmd, execFilter.FilteredCommand(), botName)
+	}
+
+	if e.conversation.IsAuthenticated && e.diagExecutor.CanHandle(e.conversation.ExecutorBindings, args) {
+		e.reportCommand(diagCommandName, execFilter.IsActive())
+		if e.platform != config.SlackCommPlatformIntegration && e.platform != config.SocketSlackCommPlatformIntegration {
+			return e.respondWithFailure(fmt.Sprintf(diagUnsupportedPlatformMsgFmt, e.platform), rawCmd, execFilter.FilteredCommand(), botName)
+		}
+
+		var (
+			out        string
+			attachment *interactive.Attachment
+		)
+		bindings, err := e.authorizedBindings(isDiagEnabled, e.diagExecutor.rbacCommand, execFilter.FilteredCommand())
+		if err == nil {
+			out, attachment, err = e.diagExecutor.Execute(ctx, bindings, execFilter.FilteredCommand())
+		}
+		switch {
+		case err == nil:
+		case IsExecutionCommandError(err):
+			return e.respondWithFailure(err.Error(), rawCmd, execFilter.FilteredCommand(), botName)
+		default:
+			e.log.Errorf("while executing diag: %s", err.Error())
+			e.auditStatus = audit.StatusError
+			return empty
+		}
+		msg := e.respond(execFilter.Apply(out), rawCmd, execFilter.FilteredCommand(), botName)
+		msg.Attachment = attachment
+		return msg
+	}
+
+	// the events executor takes precedence over the `kubectl events` command, as it's enabled explicitly
+	if e.conversation.IsAuthenticated && e.eventsExecutor.CanHandle(e.conversation.ExecutorBindings, args) {
+		e.reportCommand(eventsCommandName, execFilter.IsActive())
+		var out string
+		bindings, err := e.authorizedBindings(isEventsEnabled, e.eventsExecutor.rbacCommand, execFilter.FilteredCommand())
+		if err == nil {
+			out, err = e.eventsExecutor.Execute(ctx, bindings, execFilter.FilteredCommand())
+		}
+		switch {
+		case err == nil:
+		case IsExecutionCommandError(err):
+			return e.respondWithFailure(err.Error(), rawCmd, execFilter.FilteredCommand(), botName)
+		default:
+			e.log.Errorf("while executing events: %s", err.Error())
+			e.auditStatus = audit.StatusError
+			return empty
+		}
+		return e.respond(execFilter.Apply(out), rawCmd, execFilter.FilteredCommand(), botName)
+	}
+
 	if e.kubectlExecutor.CanHandle(e.conversation.ExecutorBindings, args) {
 		e.reportCommand(e.kubectlExecutor.GetCommandPrefix(args), execFilter.IsActive())
-		out, err := e.kubectlExecutor.Execute(e.conversation.ExecutorBindings, execFilter.FilteredCommand(), e.conversation.IsAuthenticated)
+		kcCmd, asTable := extractTableOutput(execFilter.FilteredCommand())
+		handleChunk := e.handleChunk
+		if execFilter.IsActive() || asTable { // the filter and table are applied on the whole output
+			handleChunk = nil
+		}
+		var out string
+		bindings, err := e.authorizedBindings(isKubectlEnabled, e.kubectlExecutor.rbacCommand, kcCmd)
+		if err == nil && e.requiresConfirmation(kcCmd) {
+			return e.respondWithConfirmation(rawCmd, botName)
+		}
+		if err == nil {
+			out, err = e.kubectlExecutor.ExecuteStream(ctx, bindings, kcCmd, e.conversation.IsAuthenticated, e.identity, e.stdin, handleChunk)
+		}
 		switch {
 		case err == nil:
 		case IsExecutionCommandError(err):
-			return e.respond(err.Error(), rawCmd, execFilter.FilteredCommand(), botName)
+			if suggestion := e.suggestCommand(args); suggestion != "" {
+				return e.respondWithSuggestion(err.Error(), suggestion, rawCmd, execFilter.FilteredCommand(), botName)
+			}
+			return e.respondWithFailure(err.Error(), rawCmd, execFilter.FilteredCommand(), botName)
 		default:
 			// TODO: Return error when the DefaultExecutor is refactored as a part of https://github.com/kubeshop/botkube/issues/589
 			e.log.Errorf("while executing kubectl: %s", err.Error())
+			e.auditStatus = audit.StatusError
 			return empty
 		}
+		if asTable {
+			table, full := newTableFormatter().Format(out)
+			if full != "" {
+				full = execFilter.Apply(full)
+			}
+			return withFullOutput(e.respond(execFilter.Apply(table), rawCmd, execFilter.FilteredCommand(), botName), full)
+		}
 		return e.respond(execFilter.Apply(out), rawCmd, execFilter.FilteredCommand(), botName)
 	}
 
@@ -169,12 +336,57 @@ func (e *DefaultExecutor) Execute(ctx context.Context) interactive.Message {
 		return empty
 	}
 
+	if e.helmExecutor.CanHandle(e.conversation.ExecutorBindings, args) {
+		e.reportCommand(e.helmExecutor.GetCommandPrefix(args), execFilter.IsActive())
+		var out string
+		bindings, err := e.authorizedBindings(isHelmEnabled, e.helmExecutor.rbacCommand, execFilter.FilteredCommand())
+		if err == nil && e.requiresConfirmation(execFilter.FilteredCommand()) {
+			return e.respondWithConfirmation(rawCmd, botName)
+		}
+		if err == nil {
+			out, err = e.helmExecutor.Execute(bindings, execFilter.FilteredCommand())
+		}
+		switch {
+		case err == nil:
+		case IsExecutionCommandError(err):
+			return e.respondWithFailure(err.Error(), rawCmd, execFilter.FilteredCommand(), botName)
+		default:
+			e.log.Errorf("while executing helm: %s", err.Error())
+			e.auditStatus = audit.StatusError
+			return empty
+		}
+		return e.respond(execFilter.Apply(out), rawCmd, execFilter.FilteredCommand(), botName)
+	}
+
+	if e.pluginExecutor.CanHandle(e.conversation.ExecutorBindings, args) {
+		e.reportCommand(e.pluginExecutor.GetCommandPrefix(args), execFilter.IsActive())
+		var out string
+		bindings, err := e.authorizedBindings(isPluginEnabled(args[0]), e.pluginExecutor.rbacCommand, execFilter.FilteredCommand())
+		if err == nil && e.requiresConfirmation(execFilter.FilteredCommand()) {
+			return e.respondWithConfirmation(rawCmd, botName)
+		}
+		if err == nil {
+			out, err = e.pluginExecutor.Execute(ctx, bindings, execFilter.FilteredCommand())
+		}
+		switch {
+		case err == nil:
+		case IsExecutionCommandError(err):
+			return e.respondWithFailure(err.Error(), rawCmd, execFilter.FilteredCommand(), botName)
+		default:
+			e.log.Errorf("while executing plugin: %s", err.Error())
+			e.auditStatus = audit.StatusError
+			return empty
+		}
+		return e.respond(execFilter.Apply(out), rawCmd, execFilter.FilteredCommand(), botName)
+	}
+
 	if e.kubectlCmdBuilder.CanHandle(args) {
 		e.reportCommand(e.kubectlCmdBuilder.GetCommandPrefix(args), false)
 		out, err := e.kubectlCmdBuilder.Do(ctx, args, e.platform, e.conversation.ExecutorBindings, e.conversation.State, botName, e.header(rawCmd))
 		if err != nil {
 			// TODO: Return error when the DefaultExecutor is refactored as a part of https://github.com/kubeshop/botkube/issues/589
 			e.log.Errorf("while executing kubectl: %s", err.Error())
+			e.auditStatus = audit.StatusError
 			return empty
 		}
 		return out
@@ -211,20 +423,44 @@ func (e *DefaultExecutor) Execute(ctx context.Context) interactive.Message {
 			e.reportCommand(args[0], false)
 			return interactive.Feedback(), nil
 		},
+		"audit": func() (interactive.Message, error) {
+			res, err := e.runAuditCommand(ctx, args)
+			return e.respond(execFilter.Apply(res), rawCmd, execFilter.FilteredCommand(), botName), err
+		},
+		"alias": func() (interactive.Message, error) {
+			res, err := e.runAliasCommand(ctx, args, clusterName)
+			return e.respond(res, rawCmd, execFilter.FilteredCommand(), botName), err
+		},
+		queueCommandName: func() (interactive.Message, error) {
+			res, err := e.runQueueCommand()
+			return e.respond(execFilter.Apply(res), rawCmd, execFilter.FilteredCommand(), botName), err
+		},
+		scheduleCommandName: func() (interactive.Message, error) {
+			res, err := e.runScheduleCommand(ctx, args, rawCmd, clusterName)
+			return e.respond(res, rawCmd, execFilter.FilteredCommand(), botName), err
+		},
+		configCommandName: func() (interactive.Message, error) {
+			res, err := e.runConfigCommand(ctx, args, clusterName)
+			return e.respond(res, rawCmd, execFilter.FilteredCommand(), botName), err
+		},
 	}
 
 	msg, err := cmds.SelectAndRun(args[0])
 	switch {
 	case err == nil:
 	case errors.Is(err, errInvalidCommand):
-		return e.respond(incompleteCmdMsg, rawCmd, execFilter.FilteredCommand(), botName)
+		return e.respondWithFailure(e.localizer.Sprintf(e.conversation.Locale, interactive.IncompleteCommandMsg), rawCmd, execFilter.FilteredCommand(), botName)
 	case errors.Is(err, errUnsupportedCommand):
-		return e.respond(unsupportedCmdMsg, rawCmd, execFilter.FilteredCommand(), botName)
+		if suggestion := e.suggestCommand(args); suggestion != "" {
+			return e.respondWithSuggestion(e.localizer.Sprintf(e.conversation.Locale, interactive.UnsupportedCommandMsg), suggestion, rawCmd, execFilter.FilteredCommand(), botName)
+		}
+		return e.respondWithFailure(e.localizer.Sprintf(e.conversation.Locale, interactive.UnsupportedCommandMsg), rawCmd, execFilter.FilteredCommand(), botName)
 	case IsExecutionCommandError(err):
-		return e.respond(err.Error(), rawCmd, execFilter.FilteredCommand(), botName)
+		return e.respondWithFailure(err.Error(), rawCmd, execFilter.FilteredCommand(), botName)
 	default:
 		e.log.Errorf("while executing command %q: %s", execFilter.FilteredCommand(), err.Error())
-		internalErrorMsg := fmt.Sprintf(internalErrorMsgFmt, clusterName)
+		e.auditStatus = audit.StatusError
+		internalErrorMsg := e.localizer.Sprintf(e.conversation.Locale, interactive.InternalErrorMsg, clusterName)
 		return e.respond(internalErrorMsg, rawCmd, execFilter.FilteredCommand(), botName)
 	}
 
@@ -237,7 +473,7 @@ func (e *DefaultExecutor) respond(msg string, rawCmd string, filteredCmd string,
 	}
 	if msg == "" {
 		msgBody = interactive.Body{
-			Plaintext: emptyResponseMsg,
+			Plaintext: e.localizer.Sprintf(e.conversation.Locale, interactive.EmptyResponseMsg),
 		}
 	}
 
@@ -254,6 +490,12 @@ func (e *DefaultExecutor) respond(msg string, rawCmd string, filteredCmd string,
 	return message
 }
 
+// respondWithFailure returns a response for a failed command and marks the command as failed in the audit log.
+func (e *DefaultExecutor) respondWithFailure(msg string, rawCmd string, filteredCmd string, botName string) interactive.Message {
+	e.auditStatus = audit.StatusFailed
+	return e.respond(msg, rawCmd, filteredCmd, botName)
+}
+
 func (e *DefaultExecutor) header(command string, overrideName ...string) string {
 	cmd := fmt.Sprintf("`%s`", strings.TrimSpace(command))
 	if len(overrideName) > 0 {
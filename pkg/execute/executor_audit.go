@@ -0,0 +1,102 @@
+package execute
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/kubeshop/botkube/pkg/bot/interactive"
+	"github.com/kubeshop/botkube/pkg/execute/audit"
+	"github.com/kubeshop/botkube/pkg/utils"
+)
+
+const (
+	auditListAction  = "list"
+	auditTimeFormat  = "2006-01-02 15:04:05"
+	auditDisabledMsg = "Command audit is disabled on cluster '%s'. Please enable it with the `settings.commandAudit.enabled` property."
+)
+
+// recordAudit records a given command response in the audit log. Commands ignored by this Botkube instance are not recorded.
+func (e *DefaultExecutor) recordAudit(ctx context.Context, msg interactive.Message) {
+	if e.auditRecorder == nil {
+		return
+	}
+	if e.auditStatus == "" && reflect.ValueOf(msg).IsZero() {
+		return
+	}
+
+	status := e.auditStatus
+	if status == "" {
+		status = audit.StatusSucceeded
+	}
+
+	out, err := json.Marshal(msg)
+	if err != nil {
+		e.log.Errorf("while marshalling response for audit log: %s", err.Error())
+	}
+
+	e.auditRecorder.Record(ctx, audit.Entry{
+		Time:         time.Now(),
+		Cluster:      e.cfg.Settings.ClusterName,
+		CommGroup:    e.commGroupName,
+		Platform:     string(e.platform),
+		Channel:      e.auditChannel(),
+		User:         e.user,
+		UserID:       e.userID,
+		Command:      strings.TrimSpace(utils.RemoveAnyHyperlinks(e.message)),
+		Status:       status,
+		OutputSHA256: audit.HashOutput(out),
+	})
+}
+
+// runAuditCommand lists the most recent commands recorded in the audit log.
+func (e *DefaultExecutor) runAuditCommand(ctx context.Context, args []string) (string, error) {
+	if len(args) < 2 {
+		return "", errInvalidCommand
+	}
+	var cmdVerb = args[1]
+	defer func() {
+		cmdToReport := fmt.Sprintf("%s %s", args[0], cmdVerb)
+		e.reportCommand(cmdToReport, false)
+	}()
+
+	if cmdVerb != auditListAction {
+		cmdVerb = anonymizedInvalidVerb // prevent passing any personal information
+		return "", errUnsupportedCommand
+	}
+
+	if e.auditRecorder == nil {
+		return "", NewExecutionCommandError(auditDisabledMsg, e.cfg.Settings.ClusterName)
+	}
+
+	entries, err := e.auditRecorder.List(ctx)
+	if err != nil {
+		return "", err
+	}
+	if len(entries) == 0 {
+		return "No commands recorded yet.", nil
+	}
+
+	rows := [][]string{{"TIME", "PLATFORM", "CHANNEL", "USER", "STATUS", "COMMAND"}}
+	for _, entry := range entries {
+		rows = append(rows, []string{
+			entry.Time.UTC().Format(auditTimeFormat),
+			entry.Platform,
+			entry.Channel,
+			entry.User,
+			string(entry.Status),
+			entry.Command,
+		})
+	}
+	return renderTable(rows), nil
+}
+
+func (e *DefaultExecutor) auditChannel() string {
+	if e.conversation.Alias != "" {
+		return e.conversation.Alias
+	}
+	return e.conversation.ID
+}
@@ -0,0 +1,150 @@
+package execute
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	logtest "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/pkg/bot/interactive"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/execute/audit"
+)
+
+func TestDefaultExecutorRecordAudit(t *testing.T) {
+	// given
+	tests := []struct {
+		name string
+
+		msg    interactive.Message
+		status audit.Status
+
+		expStatus audit.Status
+		expRecord bool
+	}{
+		{
+			name:      "Should record succeeded command",
+			msg:       interactive.Message{Base: interactive.Base{Description: "`get po` on `dev`"}},
+			expStatus: audit.StatusSucceeded,
+			expRecord: true,
+		},
+		{
+			name:      "Should record failed command",
+			msg:       interactive.Message{Base: interactive.Base{Description: "`get po` on `dev`"}},
+			status:    audit.StatusFailed,
+			expStatus: audit.StatusFailed,
+			expRecord: true,
+		},
+		{
+			name:      "Should record internal error without response",
+			status:    audit.StatusError,
+			expStatus: audit.StatusError,
+			expRecord: true,
+		},
+		{
+			name: "Should skip command ignored by this instance",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			recorder := &fakeAuditRecorder{}
+			executor := fixAuditExecutor(recorder)
+			executor.auditStatus = tc.status
+
+			// when
+			executor.recordAudit(context.Background(), tc.msg)
+
+			// then
+			if !tc.expRecord {
+				assert.Empty(t, recorder.entries)
+				return
+			}
+			require.Len(t, recorder.entries, 1)
+			got := recorder.entries[0]
+			assert.Equal(t, tc.expStatus, got.Status)
+			assert.Equal(t, "get po", got.Command)
+			assert.Equal(t, "botkube", got.Channel)
+			assert.Equal(t, "Joe", got.User)
+			assert.Equal(t, "U123", got.UserID)
+			assert.Len(t, got.OutputSHA256, 64)
+		})
+	}
+}
+
+func TestDefaultExecutorRunAuditCommand(t *testing.T) {
+	// given
+	recorder := &fakeAuditRecorder{
+		entries: []audit.Entry{
+			{
+				Time:     time.Date(2022, 10, 10, 12, 0, 0, 0, time.UTC),
+				Platform: "socketslack",
+				Channel:  "botkube",
+				User:     "Joe",
+				Command:  "get po",
+				Status:   audit.StatusSucceeded,
+			},
+			{
+				Time:     time.Date(2022, 10, 10, 11, 0, 0, 0, time.UTC),
+				Platform: "discord",
+				Channel:  "ops",
+				User:     "Ann",
+				Command:  "exec nginx -- sh",
+				Status:   audit.StatusFailed,
+			},
+		},
+	}
+	executor := fixAuditExecutor(recorder)
+
+	// when
+	out, err := executor.runAuditCommand(context.Background(), []string{"audit", "list"})
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, "TIME                 PLATFORM     CHANNEL  USER  STATUS     COMMAND\n"+
+		"2022-10-10 12:00:00  socketslack  botkube  Joe   Succeeded  get po\n"+
+		"2022-10-10 11:00:00  discord      ops      Ann   Failed     exec nginx -- sh\n", out)
+}
+
+func TestDefaultExecutorRunAuditCommandDisabled(t *testing.T) {
+	// given
+	executor := fixAuditExecutor(nil)
+	executor.auditRecorder = nil
+
+	// when
+	_, err := executor.runAuditCommand(context.Background(), []string{"audit", "list"})
+
+	// then
+	require.Error(t, err)
+	assert.True(t, IsExecutionCommandError(err))
+	assert.EqualError(t, err, "Command audit is disabled on cluster 'dev'. Please enable it with the `settings.commandAudit.enabled` property.")
+}
+
+func fixAuditExecutor(recorder *fakeAuditRecorder) *DefaultExecutor {
+	logger, _ := logtest.NewNullLogger()
+	return &DefaultExecutor{
+		log:               logger,
+		cfg:               config.Config{Settings: config.Settings{ClusterName: "dev"}},
+		analyticsReporter: &fakeAnalyticsReporter{},
+		auditRecorder:     recorder,
+		platform:          config.SocketSlackCommPlatformIntegration,
+		conversation:      Conversation{Alias: "botkube", ID: "C123"},
+		user:              "Joe",
+		userID:            "U123",
+		message:           " get po ",
+	}
+}
+
+type fakeAuditRecorder struct {
+	entries []audit.Entry
+}
+
+func (f *fakeAuditRecorder) Record(_ context.Context, entry audit.Entry) {
+	f.entries = append(f.entries, entry)
+}
+
+func (f *fakeAuditRecorder) List(context.Context) ([]audit.Entry, error) {
+	return f.entries, nil
+}
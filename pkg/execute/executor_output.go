@@ -0,0 +1,239 @@
+package execute
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"text/tabwriter"
+	"time"
+	"unicode/utf8"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/duration"
+	"k8s.io/apimachinery/pkg/util/json"
+
+	"github.com/kubeshop/botkube/pkg/bot/interactive"
+)
+
+const (
+	// tableMaxWidth is the maximum width of the compact table. Wider tables are wrapped by the communication platforms.
+	tableMaxWidth = 80
+	// tableMaxColumnWidth is the maximum width of a single table cell. Longer values are trimmed.
+	tableMaxColumnWidth = 40
+	tableTrimmedSuffix  = "…"
+	tableFullOutputFile = "output.txt"
+)
+
+// tableOutputFlagRegex matches the `-o table` and `--output table` flags. The `table` output is Botkube specific,
+// so kubectl is executed with the JSON output which is then rendered as a compact table.
+var tableOutputFlagRegex = regexp.MustCompile(`(^|\s)(-o|--output)(=|\s+)table(\s|$)`)
+
+// extractTableOutput returns a given command with the `table` output replaced with the `json` one.
+// It returns false if the table output is not requested.
+func extractTableOutput(cmd string) (string, bool) {
+	if !tableOutputFlagRegex.MatchString(cmd) {
+		return cmd, false
+	}
+	return tableOutputFlagRegex.ReplaceAllString(cmd, "${1}${2}${3}json${4}"), true
+}
+
+// tableFormatter renders the kubectl JSON output as a compact table, which fits the chat messages.
+type tableFormatter struct {
+	now            func() time.Time
+	maxWidth       int
+	maxColumnWidth int
+}
+
+func newTableFormatter() *tableFormatter {
+	return &tableFormatter{
+		now:            time.Now,
+		maxWidth:       tableMaxWidth,
+		maxColumnWidth: tableMaxColumnWidth,
+	}
+}
+
+// Format returns the compact table and the full one. The full table is empty if nothing was trimmed.
+// If the output is not a valid Kubernetes object or list, it's returned as is.
+func (f *tableFormatter) Format(out string) (string, string) {
+	objects, ok := parseKubernetesObjects(out)
+	if !ok {
+		return out, ""
+	}
+
+	rows := f.rows(objects)
+	full := renderTable(rows)
+	trimmedRows, trimmed := f.trim(rows)
+	if !trimmed {
+		return full, ""
+	}
+	return renderTable(trimmedRows), full
+}
+
+// rows returns the table rows including the header. The NAMESPACE and KIND columns are shown only if they differ between the objects,
+// and the STATUS column only if it's known for any object.
+func (f *tableFormatter) rows(objects []unstructured.Unstructured) [][]string {
+	var (
+		namespaces = map[string]struct{}{}
+		kinds      = map[string]struct{}{}
+		statuses   = make([]string, 0, len(objects))
+		hasStatus  bool
+	)
+	for _, obj := range objects {
+		namespaces[obj.GetNamespace()] = struct{}{}
+		kinds[obj.GetKind()] = struct{}{}
+		status := objectStatus(obj)
+		hasStatus = hasStatus || status != ""
+		statuses = append(statuses, status)
+	}
+	withNamespace, withKind := len(namespaces) > 1, len(kinds) > 1
+
+	header := []string{"NAME"}
+	if withNamespace {
+		header = append([]string{"NAMESPACE"}, header...)
+	}
+	if withKind {
+		header = append(header, "KIND")
+	}
+	if hasStatus {
+		header = append(header, "STATUS")
+	}
+	header = append(header, "AGE")
+
+	rows := [][]string{header}
+	for idx, obj := range objects {
+		row := []string{obj.GetName()}
+		if withNamespace {
+			row = append([]string{obj.GetNamespace()}, row...)
+		}
+		if withKind {
+			row = append(row, obj.GetKind())
+		}
+		if hasStatus {
+			row = append(row, statuses[idx])
+		}
+		row = append(row, f.age(obj))
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+func (f *tableFormatter) age(obj unstructured.Unstructured) string {
+	created := obj.GetCreationTimestamp()
+	if created.IsZero() {
+		return "<unknown>"
+	}
+	return duration.HumanDuration(f.now().Sub(created.Time))
+}
+
+// trim trims too long cells, and drops the last columns until the table fits the maximum width. The first column is always kept.
+func (f *tableFormatter) trim(rows [][]string) ([][]string, bool) {
+	var (
+		trimmed bool
+		out     = make([][]string, 0, len(rows))
+		widths  = make([]int, len(rows[0]))
+	)
+	for _, row := range rows {
+		trimmedRow := make([]string, 0, len(row))
+		for idx, cell := range row {
+			if utf8.RuneCountInString(cell) > f.maxColumnWidth {
+				cell = string([]rune(cell)[:f.maxColumnWidth-1]) + tableTrimmedSuffix
+				trimmed = true
+			}
+			if width := utf8.RuneCountInString(cell); width > widths[idx] {
+				widths[idx] = width
+			}
+			trimmedRow = append(trimmedRow, cell)
+		}
+		out = append(out, trimmedRow)
+	}
+
+	columns := len(widths)
+	for columns > 1 && tableWidth(widths[:columns]) > f.maxWidth {
+		columns--
+		trimmed = true
+	}
+	for idx := range out {
+		out[idx] = out[idx][:columns]
+	}
+	return out, trimmed
+}
+
+// tableWidth returns the width of the table with given column widths, including the padding between columns.
+func tableWidth(widths []int) int {
+	out := 2 * (len(widths) - 1)
+	for _, width := range widths {
+		out += width
+	}
+	return out
+}
+
+// objectStatus returns a short status of a given object, based on its phase, the Ready condition or the ready replicas.
+func objectStatus(obj unstructured.Unstructured) string {
+	if phase, found, _ := unstructured.NestedString(obj.Object, "status", "phase"); found && phase != "" {
+		return phase
+	}
+
+	if replicas, found, _ := unstructured.NestedInt64(obj.Object, "spec", "replicas"); found {
+		ready, _, _ := unstructured.NestedInt64(obj.Object, "status", "readyReplicas")
+		return fmt.Sprintf("%d/%d ready", ready, replicas)
+	}
+
+	conditions, _, _ := unstructured.NestedSlice(obj.Object, "status", "conditions")
+	for _, item := range conditions {
+		cond, ok := item.(map[string]interface{})
+		if !ok || cond["type"] != "Ready" {
+			continue
+		}
+		if cond["status"] == "True" {
+			return "Ready"
+		}
+		return "NotReady"
+	}
+	return ""
+}
+
+// parseKubernetesObjects parses a given JSON output into objects. The List items are returned separately.
+// The Kubernetes JSON package is used, as it decodes the integer numbers to int64.
+func parseKubernetesObjects(out string) ([]unstructured.Unstructured, bool) {
+	var raw map[string]interface{}
+	if err := json.Unmarshal([]byte(out), &raw); err != nil {
+		return nil, false
+	}
+
+	obj := unstructured.Unstructured{Object: raw}
+	if obj.GetKind() == "" {
+		return nil, false
+	}
+	if !obj.IsList() {
+		return []unstructured.Unstructured{obj}, true
+	}
+
+	list, err := obj.ToList()
+	if err != nil {
+		return nil, false
+	}
+	return list.Items, true
+}
+
+func renderTable(rows [][]string) string {
+	var buff strings.Builder
+	w := tabwriter.NewWriter(&buff, 0, 8, 2, ' ', 0)
+	for _, row := range rows {
+		fmt.Fprintln(w, strings.Join(row, "\t"))
+	}
+	// writing to strings.Builder never fails
+	_ = w.Flush()
+	return buff.String()
+}
+
+// withFullOutput attaches a given full output to the message as a file.
+func withFullOutput(msg interactive.Message, full string) interactive.Message {
+	if full == "" {
+		return msg
+	}
+	msg.Attachment = &interactive.Attachment{
+		FileName: tableFullOutputFile,
+		Content:  full,
+	}
+	return msg
+}
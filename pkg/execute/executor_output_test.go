@@ -0,0 +1,119 @@
+package execute
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExtractTableOutput(t *testing.T) {
+	tests := []struct {
+		name string
+
+		command    string
+		expCommand string
+		expTable   bool
+	}{
+		{
+			name:       "Should replace short flag",
+			command:    "kubectl get pods -o table -n default",
+			expCommand: "kubectl get pods -o json -n default",
+			expTable:   true,
+		},
+		{
+			name:       "Should replace long flag with value after equal sign",
+			command:    "kubectl get pods --output=table",
+			expCommand: "kubectl get pods --output=json",
+			expTable:   true,
+		},
+		{
+			name:       "Should ignore kubectl output formats",
+			command:    "kubectl get pods -o wide",
+			expCommand: "kubectl get pods -o wide",
+		},
+		{
+			name:       "Should ignore resource names",
+			command:    "kubectl get configmap table-config",
+			expCommand: "kubectl get configmap table-config",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// when
+			gotCommand, gotTable := extractTableOutput(tc.command)
+
+			// then
+			assert.Equal(t, tc.expCommand, gotCommand)
+			assert.Equal(t, tc.expTable, gotTable)
+		})
+	}
+}
+
+func TestTableFormatterFormat(t *testing.T) {
+	now := time.Date(2022, 10, 10, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name string
+
+		output         string
+		maxWidth       int
+		maxColumnWidth int
+		expTable       string
+		expFull        string
+	}{
+		{
+			name: "Should render list with namespaces and statuses",
+			output: `{"kind": "List", "apiVersion": "v1", "items": [
+				{"kind": "Pod", "apiVersion": "v1", "metadata": {"name": "nginx", "namespace": "default", "creationTimestamp": "2022-10-10T10:00:00Z"}, "status": {"phase": "Running"}},
+				{"kind": "Pod", "apiVersion": "v1", "metadata": {"name": "dns", "namespace": "kube-system", "creationTimestamp": "2022-10-08T12:00:00Z"}, "status": {"phase": "Pending"}}
+			]}`,
+			maxWidth:       80,
+			maxColumnWidth: 40,
+			expTable: "NAMESPACE    NAME   STATUS   AGE\n" +
+				"default      nginx  Running  120m\n" +
+				"kube-system  dns    Pending  2d\n",
+		},
+		{
+			name:           "Should render single object with ready replicas",
+			output:         `{"kind": "Deployment", "apiVersion": "apps/v1", "metadata": {"name": "nginx", "namespace": "default", "creationTimestamp": "2022-10-10T11:59:00Z"}, "spec": {"replicas": 3}, "status": {"readyReplicas": 2}}`,
+			maxWidth:       80,
+			maxColumnWidth: 40,
+			expTable: "NAME   STATUS     AGE\n" +
+				"nginx  2/3 ready  60s\n",
+		},
+		{
+			name:           "Should trim long cells and too wide columns",
+			output:         `{"kind": "Node", "apiVersion": "v1", "metadata": {"name": "gke-cluster-default-pool-1234567890", "creationTimestamp": "2022-10-10T11:00:00Z"}, "status": {"conditions": [{"type": "Ready", "status": "True"}]}}`,
+			maxWidth:       20,
+			maxColumnWidth: 15,
+			expTable: "NAME\n" +
+				"gke-cluster-de…\n",
+			expFull: "NAME                                 STATUS  AGE\n" +
+				"gke-cluster-default-pool-1234567890  Ready   60m\n",
+		},
+		{
+			name:           "Should return not JSON output as is",
+			output:         "error: the server doesn't have a resource type \"foo\"",
+			maxWidth:       80,
+			maxColumnWidth: 40,
+			expTable:       "error: the server doesn't have a resource type \"foo\"",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// given
+			formatter := &tableFormatter{
+				now:            func() time.Time { return now },
+				maxWidth:       tc.maxWidth,
+				maxColumnWidth: tc.maxColumnWidth,
+			}
+
+			// when
+			gotTable, gotFull := formatter.Format(tc.output)
+
+			// then
+			assert.Equal(t, tc.expTable, gotTable)
+			assert.Equal(t, tc.expFull, gotFull)
+		})
+	}
+}
@@ -2,12 +2,18 @@ package execute
 
 import (
 	"context"
+	"sync"
+	"time"
 
 	"github.com/sirupsen/logrus"
 	"github.com/slack-go/slack"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
 
+	"github.com/kubeshop/botkube/pkg/bot/identity"
 	"github.com/kubeshop/botkube/pkg/bot/interactive"
 	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/execute/audit"
 	"github.com/kubeshop/botkube/pkg/execute/command"
 	"github.com/kubeshop/botkube/pkg/execute/kubectl"
 	"github.com/kubeshop/botkube/pkg/filterengine"
@@ -17,15 +23,29 @@ import (
 type DefaultExecutorFactory struct {
 	log               logrus.FieldLogger
 	cmdRunner         CommandSeparateOutputRunner
+	cfgMutex          sync.RWMutex
 	cfg               config.Config
 	filterEngine      filterengine.FilterEngine
 	analyticsReporter AnalyticsReporter
 	notifierExecutor  *NotifierExecutor
 	kubectlExecutor   *Kubectl
+	helmExecutor      *Helm
+	topExecutor       *Top
+	diagExecutor      *Diag
+	eventsExecutor    *Events
+	pluginExecutor    *PluginExecutor
 	editExecutor      *EditExecutor
 	merger            *kubectl.Merger
 	cfgManager        ConfigPersistenceManager
 	kubectlCmdBuilder *KubectlCmdBuilder
+	localizer         *interactive.Localizer
+	remoteClusters    RemoteClusters
+	auditRecorder     AuditRecorder
+	aliases           *AliasStore
+	confirmations     *ConfirmationStore
+	commandQueue      *CommandQueue
+	schedules         *ScheduleStore
+	configReloader    ConfigReloader
 }
 
 // DefaultExecutorFactoryParams contains input parameters for DefaultExecutorFactory.
@@ -40,6 +60,22 @@ type DefaultExecutorFactoryParams struct {
 	AnalyticsReporter AnalyticsReporter
 	NamespaceLister   NamespaceLister
 	CommandGuard      CommandGuard
+	Localizer         *interactive.Localizer
+	// RemoteClusters executes commands targeting other clusters with the `--cluster` flag. It's optional.
+	RemoteClusters RemoteClusters
+	// K8sCli and DynamicCli are used to report the resource usage and capacity. If not set, the top commands are not handled.
+	K8sCli     kubernetes.Interface
+	DynamicCli dynamic.Interface
+	// AuditRecorder records all executed commands. It's optional.
+	AuditRecorder AuditRecorder
+	// PluginManager executes commands with the executor plugins. If not set, the plugin commands are not handled.
+	PluginManager PluginManager
+	// CommandQueue limits the number of commands executed concurrently. It's used only to report the queue status.
+	CommandQueue *CommandQueue
+	// ScheduleStore holds the scheduled commands. If not set, the commands cannot be scheduled.
+	ScheduleStore *ScheduleStore
+	// ConfigReloader reloads the configuration with the `config reload` command. If not set, the hot reload is disabled.
+	ConfigReloader ConfigReloader
 }
 
 // Executor is an interface for processes to execute commands
@@ -50,8 +86,12 @@ type Executor interface {
 // ConfigPersistenceManager manages persistence of the configuration.
 type ConfigPersistenceManager interface {
 	PersistSourceBindings(ctx context.Context, commGroupName string, platform config.CommPlatformIntegration, channelAlias string, sourceBindings []string) error
+	PersistExecutorBindings(ctx context.Context, commGroupName string, platform config.CommPlatformIntegration, channelAlias string, executorBindings []string) error
 	PersistNotificationsEnabled(ctx context.Context, commGroupName string, platform config.CommPlatformIntegration, channelAlias string, enabled bool) error
+	PersistNotificationsSnoozed(ctx context.Context, commGroupName string, platform config.CommPlatformIntegration, channelAlias string, until time.Time) error
 	PersistFilterEnabled(ctx context.Context, name string, enabled bool) error
+	PersistAlias(ctx context.Context, name, command string) error
+	PersistSchedule(ctx context.Context, id string, schedule *config.Schedule) error
 }
 
 // AnalyticsReporter defines a reporter that collects analytics data.
@@ -60,6 +100,12 @@ type AnalyticsReporter interface {
 	ReportCommand(platform config.CommPlatformIntegration, command string, origin command.Origin, withFilter bool) error
 }
 
+// AuditRecorder records executed commands and lists the most recent ones.
+type AuditRecorder interface {
+	Record(ctx context.Context, entry audit.Entry)
+	List(ctx context.Context) ([]audit.Entry, error)
+}
+
 // CommandGuard is an interface that allows to check if a given command is allowed to be executed.
 type CommandGuard interface {
 	GetAllowedResourcesForVerb(verb string, allConfiguredResources []string) ([]kubectl.Resource, error)
@@ -87,6 +133,7 @@ func NewExecutorFactory(params DefaultExecutorFactoryParams) *DefaultExecutorFac
 			params.Cfg,
 			params.CfgManager,
 			params.AnalyticsReporter,
+			params.Localizer,
 		),
 		kubectlCmdBuilder: NewKubectlCmdBuilder(
 			params.Log.WithField("component", "Kubectl Command Builder"),
@@ -104,6 +151,42 @@ func NewExecutorFactory(params DefaultExecutorFactoryParams) *DefaultExecutorFac
 		merger:          params.Merger,
 		cfgManager:      params.CfgManager,
 		kubectlExecutor: kcExecutor,
+		helmExecutor: NewHelm(
+			params.Log.WithField("component", "Helm Executor"),
+			params.Cfg,
+			params.CmdRunner,
+		),
+		topExecutor: NewTop(
+			params.Log.WithField("component", "Top Executor"),
+			params.Cfg,
+			params.K8sCli,
+			params.DynamicCli,
+		),
+		diagExecutor: NewDiag(
+			params.Log.WithField("component", "Diag Executor"),
+			params.Cfg,
+			params.K8sCli,
+			params.DynamicCli,
+			params.CmdRunner,
+		),
+		eventsExecutor: NewEvents(
+			params.Log.WithField("component", "Events Executor"),
+			params.Cfg,
+			params.K8sCli,
+		),
+		pluginExecutor: NewPluginExecutor(
+			params.Log.WithField("component", "Plugin Executor"),
+			params.Cfg,
+			params.PluginManager,
+		),
+		localizer:      params.Localizer,
+		remoteClusters: params.RemoteClusters,
+		auditRecorder:  params.AuditRecorder,
+		aliases:        NewAliasStore(params.Log.WithField("component", "Alias Store"), params.Cfg.Aliases),
+		confirmations:  NewConfirmationStore(),
+		commandQueue:   params.CommandQueue,
+		schedules:      params.ScheduleStore,
+		configReloader: params.ConfigReloader,
 	}
 }
 
@@ -115,6 +198,10 @@ type Conversation struct {
 	IsAuthenticated  bool
 	CommandOrigin    command.Origin
 	State            *slack.BlockActionStates
+	Locale           string
+	// Commands holds the channel command restrictions. Bots check the received command, and the executor checks it
+	// again after the command aliases are expanded.
+	Commands config.ChannelCommands
 }
 
 // NewDefaultInput an input for NewDefault
@@ -125,26 +212,67 @@ type NewDefaultInput struct {
 	Conversation    Conversation
 	Message         string
 	User            string
+	// UserID is the platform-specific ID of the user who sent the message. It's used to resolve the user Kubernetes identity.
+	UserID string
+	// Identity is the Kubernetes identity of the user who sent the message. It's empty if the user is not mapped.
+	Identity identity.Identity
+	// UserGroups holds the chat platform user groups of the user who sent the message, e.g. Slack user groups.
+	// They are resolved only if any executor enables RBAC.
+	UserGroups []string
+	// Stdin holds the payload attached to the message, such as a file snippet.
+	// It's passed as a standard input to the commands which read from it, e.g. `kubectl apply -f -`.
+	Stdin []byte
+}
+
+// ReloadConfig applies the reloaded sources, channel bindings and sinks to the executors created afterwards.
+// The executors settings are not reloaded, as changing them requires a restart.
+func (f *DefaultExecutorFactory) ReloadConfig(cfg config.Config) {
+	f.cfgMutex.Lock()
+	defer f.cfgMutex.Unlock()
+
+	f.cfg = cfg
+	f.editExecutor.ReloadConfig(cfg)
+	f.notifierExecutor.ReloadConfig(cfg)
 }
 
 // NewDefault creates new Default Executor.
 func (f *DefaultExecutorFactory) NewDefault(cfg NewDefaultInput) Executor {
+	f.cfgMutex.RLock()
+	defer f.cfgMutex.RUnlock()
+
 	return &DefaultExecutor{
 		log:               f.log,
 		cmdRunner:         f.cmdRunner,
 		cfg:               f.cfg,
 		analyticsReporter: f.analyticsReporter,
 		kubectlExecutor:   f.kubectlExecutor,
+		helmExecutor:      f.helmExecutor,
+		topExecutor:       f.topExecutor,
+		diagExecutor:      f.diagExecutor,
+		eventsExecutor:    f.eventsExecutor,
+		pluginExecutor:    f.pluginExecutor,
 		notifierExecutor:  f.notifierExecutor,
 		editExecutor:      f.editExecutor,
 		filterEngine:      f.filterEngine,
 		merger:            f.merger,
 		cfgManager:        f.cfgManager,
 		kubectlCmdBuilder: f.kubectlCmdBuilder,
+		localizer:         f.localizer,
+		remoteClusters:    f.remoteClusters,
+		auditRecorder:     f.auditRecorder,
+		aliases:           f.aliases,
+		confirmations:     f.confirmations,
+		commandQueue:      f.commandQueue,
+		schedules:         f.schedules,
+		configReloader:    f.configReloader,
 		user:              cfg.User,
+		userID:            cfg.UserID,
+		userGroups:        cfg.UserGroups,
+		identity:          cfg.Identity,
 		notifierHandler:   cfg.NotifierHandler,
 		conversation:      cfg.Conversation,
 		message:           cfg.Message,
+		stdin:             cfg.Stdin,
 		platform:          cfg.Platform,
 		commGroupName:     cfg.CommGroupName,
 	}
@@ -0,0 +1,284 @@
+package execute
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/mattn/go-shellwords"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/pflag"
+	"k8s.io/utils/strings/slices"
+
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+const (
+	helmNotAllowedVerbMsgFmt        = "Sorry, the helm '%s' command cannot be executed in the '%s' Namespace on cluster '%s'."
+	helmNotAllowedVerbInAllNsMsgFmt = "Sorry, the helm '%s' command cannot be executed for all Namespaces on cluster '%s'."
+	helmUnsupportedVerbMsgFmt       = "Sorry, the helm '%s' command is not supported. Supported commands: %s."
+	helmMissingVerbMsg              = "Please specify the helm command, e.g. 'helm list'."
+	helmDefaultNamespace            = "default"
+
+	helmCommandName = "helm"
+	helmDryRunFlag  = "--dry-run"
+)
+
+var helmBinary = "/usr/local/bin/helm"
+
+// helmSupportedVerbs holds the Helm commands which can be enabled in the Helm executor.
+var helmSupportedVerbs = []string{"list", "status", "history", "rollback", "upgrade"}
+
+// helmVerbAliases maps the Helm command aliases to the command names.
+var helmVerbAliases = map[string]string{
+	"ls":   "list",
+	"hist": "history",
+}
+
+// helmTableColumns holds the columns of the Helm commands rendered as tables, and their JSON output fields.
+var helmTableColumns = map[string][]helmTableColumn{
+	"list": {
+		{header: "NAME", field: "name"},
+		{header: "NAMESPACE", field: "namespace"},
+		{header: "REVISION", field: "revision"},
+		{header: "UPDATED", field: "updated"},
+		{header: "STATUS", field: "status"},
+		{header: "CHART", field: "chart"},
+		{header: "APP VERSION", field: "app_version"},
+	},
+	"history": {
+		{header: "REVISION", field: "revision"},
+		{header: "UPDATED", field: "updated"},
+		{header: "STATUS", field: "status"},
+		{header: "CHART", field: "chart"},
+		{header: "APP VERSION", field: "app_version"},
+		{header: "DESCRIPTION", field: "description"},
+	},
+}
+
+type helmTableColumn struct {
+	header string
+	field  string
+}
+
+// Helm executes Helm commands using local binary.
+type Helm struct {
+	log       logrus.FieldLogger
+	cfg       config.Config
+	cmdRunner CommandCombinedOutputRunner
+}
+
+// NewHelm creates a new instance of Helm.
+func NewHelm(log logrus.FieldLogger, cfg config.Config, fn CommandCombinedOutputRunner) *Helm {
+	return &Helm{
+		log:       log,
+		cfg:       cfg,
+		cmdRunner: fn,
+	}
+}
+
+// CanHandle returns true if it's a Helm command and at least one Helm executor is enabled for given bindings.
+func (e *Helm) CanHandle(bindings []string, args []string) bool {
+	if len(args) == 0 || args[0] != helmCommandName {
+		return false
+	}
+
+	for _, name := range bindings {
+		if e.cfg.Executors[name].Helm.Enabled {
+			return true
+		}
+	}
+	return false
+}
+
+// GetCommandPrefix gets the Helm command with its verb, e.g. `helm list`.
+func (e *Helm) GetCommandPrefix(args []string) string {
+	if len(args) < 2 {
+		return helmCommandName
+	}
+
+	verb := helmVerb(args[1])
+	if !slices.Contains(helmSupportedVerbs, verb) {
+		verb = anonymizedInvalidVerb
+	}
+	return fmt.Sprintf("%s %s", helmCommandName, verb)
+}
+
+// Execute executes a given Helm command.
+// The `list` and `history` commands are rendered as tables, unless the output format is specified explicitly.
+//
+// This method should be called ONLY if:
+// - we are a target cluster,
+// - and Helm.CanHandle returned true.
+func (e *Helm) Execute(bindings []string, command string) (string, error) {
+	log := e.log.WithField("command", command)
+	log.Debugf("Handling command...")
+
+	args, err := shellwords.Parse(strings.TrimSpace(command))
+	if err != nil {
+		return "", fmt.Errorf("while parsing the command message into args: %w", err)
+	}
+	args = removeClusterFlags(args[1:])
+	if len(args) == 0 {
+		return "", NewExecutionCommandError(helmMissingVerbMsg)
+	}
+
+	clusterName := e.cfg.Settings.ClusterName
+	verb := helmVerb(args[0])
+	if !slices.Contains(helmSupportedVerbs, verb) {
+		return "", NewExecutionCommandError(helmUnsupportedVerbMsgFmt, args[0], strings.Join(helmSupportedVerbs, ", "))
+	}
+	args[0] = verb
+
+	flags, err := parseHelmFlags(args)
+	if err != nil {
+		return "", fmt.Errorf("while parsing flags: %w", err)
+	}
+
+	executionNs := flags.namespace
+	if flags.allNamespaces {
+		executionNs = config.AllNamespaceIndicator
+	}
+	if executionNs == "" {
+		executionNs = e.findDefaultNamespace(bindings)
+		args = append(args, "--namespace", executionNs)
+	}
+
+	if !e.isVerbAllowedInNs(bindings, verb, executionNs) {
+		if executionNs == config.AllNamespaceIndicator {
+			return "", NewExecutionCommandError(helmNotAllowedVerbInAllNsMsgFmt, verb, clusterName)
+		}
+		return "", NewExecutionCommandError(helmNotAllowedVerbMsgFmt, verb, executionNs, clusterName)
+	}
+
+	// upgrades are only previewed, as the chart changes should go through the regular deployment process
+	if verb == "upgrade" && !flags.dryRun {
+		args = append(args, helmDryRunFlag)
+	}
+
+	columns, asTable := helmTableColumns[verb]
+	asTable = asTable && flags.output == ""
+	if asTable {
+		args = append(args, "--output", "json")
+	}
+
+	out, err := e.cmdRunner.RunCombinedOutput(helmBinary, args)
+	if err != nil {
+		return "", NewExecutionCommandError("%s%s", out, err.Error())
+	}
+	if !asTable {
+		return out, nil
+	}
+
+	table, err := renderHelmTable(columns, out)
+	if err != nil {
+		log.Debugf("Cannot render output as table: %s", err.Error())
+		return out, nil
+	}
+	return table, nil
+}
+
+func (e *Helm) isVerbAllowedInNs(bindings []string, verb, namespace string) bool {
+	for _, name := range bindings {
+		helm := e.cfg.Executors[name].Helm
+		if !helm.Enabled || !helm.Namespaces.IsAllowed(namespace) {
+			continue
+		}
+		if slices.Contains(helm.Verbs, verb) {
+			return true
+		}
+	}
+	return false
+}
+
+// findDefaultNamespace returns the default Namespace of the last enabled Helm executor which specifies it.
+func (e *Helm) findDefaultNamespace(bindings []string) string {
+	out := helmDefaultNamespace
+	for _, name := range bindings {
+		helm := e.cfg.Executors[name].Helm
+		if helm.Enabled && helm.DefaultNamespace != "" {
+			out = helm.DefaultNamespace
+		}
+	}
+	return out
+}
+
+type helmFlags struct {
+	namespace     string
+	allNamespaces bool
+	output        string
+	dryRun        bool
+}
+
+func parseHelmFlags(args []string) (helmFlags, error) {
+	f := pflag.NewFlagSet("extract-helm-flags", pflag.ContinueOnError)
+	// ignore unknown flags errors, e.g. `--max` etc.
+	f.ParseErrorsWhitelist.UnknownFlags = true
+
+	var out helmFlags
+	f.StringVarP(&out.namespace, "namespace", "n", "", "Kubernetes Namespace")
+	f.BoolVarP(&out.allNamespaces, "all-namespaces", "A", false, "Kubernetes All Namespaces")
+	f.StringVarP(&out.output, "output", "o", "", "Output format")
+	f.BoolVar(&out.dryRun, "dry-run", false, "Simulate an upgrade")
+	if err := f.Parse(args); err != nil {
+		return helmFlags{}, err
+	}
+	return out, nil
+}
+
+// removeClusterFlags removes the `--cluster-name` flag and its value, as it's Botkube specific.
+func removeClusterFlags(args []string) []string {
+	var out []string
+	for idx := 0; idx < len(args); idx++ {
+		arg := args[idx]
+		if !isClusterFlag(arg) {
+			out = append(out, arg)
+			continue
+		}
+		if arg == ClusterFlag.String() || arg == AbbrClusterFlag.String() {
+			idx++ // skip the flag value
+		}
+	}
+	return out
+}
+
+func helmVerb(verb string) string {
+	if name, found := helmVerbAliases[verb]; found {
+		return name
+	}
+	return verb
+}
+
+func renderHelmTable(columns []helmTableColumn, out string) (string, error) {
+	var rows []map[string]interface{}
+	if err := json.Unmarshal([]byte(out), &rows); err != nil {
+		return "", fmt.Errorf("while unmarshaling output: %w", err)
+	}
+
+	var buff strings.Builder
+	w := tabwriter.NewWriter(&buff, 0, 8, 2, ' ', 0)
+
+	var headers []string
+	for _, col := range columns {
+		headers = append(headers, col.header)
+	}
+	fmt.Fprintln(w, strings.Join(headers, "\t"))
+
+	for _, row := range rows {
+		var values []string
+		for _, col := range columns {
+			val, found := row[col.field]
+			if !found || val == nil {
+				val = ""
+			}
+			values = append(values, fmt.Sprint(val))
+		}
+		fmt.Fprintln(w, strings.Join(values, "\t"))
+	}
+
+	if err := w.Flush(); err != nil {
+		return "", fmt.Errorf("while flushing table: %w", err)
+	}
+	return buff.String(), nil
+}
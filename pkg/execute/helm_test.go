@@ -0,0 +1,176 @@
+package execute
+
+import (
+	"testing"
+
+	"github.com/MakeNowJust/heredoc"
+	logtest "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+func TestHelmExecute(t *testing.T) {
+	tests := []struct {
+		name string
+
+		command   string
+		runnerOut string
+
+		expArgs   []string
+		expOutput string
+	}{
+		{
+			name:      "Should render list as table in default Namespace",
+			command:   "helm ls --cluster-name test",
+			runnerOut: `[{"name":"payments","namespace":"team-a","revision":"3","updated":"2022-10-10 10:00:00","status":"deployed","chart":"payments-1.2.0","app_version":"1.2.0"}]`,
+			expArgs:   []string{"list", "--namespace", "team-a", "--output", "json"},
+			expOutput: heredoc.Doc(`
+				NAME      NAMESPACE  REVISION  UPDATED              STATUS    CHART           APP VERSION
+				payments  team-a     3         2022-10-10 10:00:00  deployed  payments-1.2.0  1.2.0
+			`),
+		},
+		{
+			name:      "Should render history as table",
+			command:   "helm history payments -n team-a",
+			runnerOut: `[{"revision":1,"updated":"2022-10-10","status":"superseded","chart":"payments-1.1.0","app_version":"1.1.0","description":"Install complete"}]`,
+			expArgs:   []string{"history", "payments", "-n", "team-a", "--output", "json"},
+			expOutput: heredoc.Doc(`
+				REVISION  UPDATED     STATUS      CHART           APP VERSION  DESCRIPTION
+				1         2022-10-10  superseded  payments-1.1.0  1.1.0        Install complete
+			`),
+		},
+		{
+			name:      "Should keep explicit output format",
+			command:   "helm list -o yaml",
+			runnerOut: "- name: payments\n",
+			expArgs:   []string{"list", "-o", "yaml", "--namespace", "team-a"},
+			expOutput: "- name: payments\n",
+		},
+		{
+			name:      "Should always run upgrade as dry run",
+			command:   "helm upgrade payments repo/payments --reuse-values",
+			runnerOut: "Release \"payments\" has been upgraded. Happy Helming!",
+			expArgs:   []string{"upgrade", "payments", "repo/payments", "--reuse-values", "--namespace", "team-a", "--dry-run"},
+			expOutput: "Release \"payments\" has been upgraded. Happy Helming!",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// given
+			logger, _ := logtest.NewNullLogger()
+			runner := &fakeHelmRunner{out: tc.runnerOut}
+			executor := NewHelm(logger, fixHelmConfig(), runner)
+
+			// when
+			out, err := executor.Execute([]string{"helm-read-only", "helm-team-a"}, tc.command)
+
+			// then
+			require.NoError(t, err)
+			assert.Equal(t, tc.expArgs, runner.args)
+			assert.Equal(t, tc.expOutput, out)
+		})
+	}
+}
+
+func TestHelmExecuteErrors(t *testing.T) {
+	tests := []struct {
+		name string
+
+		command  string
+		bindings []string
+
+		expErr string
+	}{
+		{
+			name:     "Should forbid verb not enabled in bindings",
+			command:  "helm rollback payments 2 -n team-a",
+			bindings: []string{"helm-read-only"},
+			expErr:   "Sorry, the helm 'rollback' command cannot be executed in the 'team-a' Namespace on cluster 'test'.",
+		},
+		{
+			name:     "Should forbid verb in not allowed Namespace",
+			command:  "helm rollback payments 2 -n team-b",
+			bindings: []string{"helm-read-only", "helm-team-a"},
+			expErr:   "Sorry, the helm 'rollback' command cannot be executed in the 'team-b' Namespace on cluster 'test'.",
+		},
+		{
+			name:     "Should forbid all Namespaces if not allowed",
+			command:  "helm list -A",
+			bindings: []string{"helm-team-a"},
+			expErr:   "Sorry, the helm 'list' command cannot be executed for all Namespaces on cluster 'test'.",
+		},
+		{
+			name:     "Should reject unsupported command",
+			command:  "helm uninstall payments",
+			bindings: []string{"helm-read-only", "helm-team-a"},
+			expErr:   "Sorry, the helm 'uninstall' command is not supported. Supported commands: list, status, history, rollback, upgrade.",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// given
+			logger, _ := logtest.NewNullLogger()
+			runner := &fakeHelmRunner{}
+			executor := NewHelm(logger, fixHelmConfig(), runner)
+
+			// when
+			_, err := executor.Execute(tc.bindings, tc.command)
+
+			// then
+			assert.EqualError(t, err, tc.expErr)
+			assert.Nil(t, runner.args)
+		})
+	}
+}
+
+func TestHelmCanHandle(t *testing.T) {
+	// given
+	logger, _ := logtest.NewNullLogger()
+	executor := NewHelm(logger, fixHelmConfig(), nil)
+
+	// then
+	assert.True(t, executor.CanHandle([]string{"helm-team-a"}, []string{"helm", "list"}))
+	assert.False(t, executor.CanHandle([]string{"helm-disabled"}, []string{"helm", "list"}))
+	assert.False(t, executor.CanHandle([]string{"helm-team-a"}, []string{"get", "pods"}))
+}
+
+func fixHelmConfig() config.Config {
+	return config.Config{
+		Settings: config.Settings{ClusterName: "test"},
+		Executors: map[string]config.Executors{
+			"helm-read-only": {
+				Helm: config.Helm{
+					Enabled:    true,
+					Namespaces: config.Namespaces{Include: []string{".*"}},
+					Verbs:      []string{"list", "status", "history"},
+				},
+			},
+			"helm-team-a": {
+				Helm: config.Helm{
+					Enabled:          true,
+					Namespaces:       config.Namespaces{Include: []string{"team-a"}},
+					Verbs:            []string{"list", "rollback", "upgrade"},
+					DefaultNamespace: "team-a",
+				},
+			},
+			"helm-disabled": {
+				Helm: config.Helm{
+					Enabled: false,
+					Verbs:   []string{"list"},
+				},
+			},
+		},
+	}
+}
+
+type fakeHelmRunner struct {
+	out  string
+	args []string
+}
+
+func (r *fakeHelmRunner) RunCombinedOutput(_ string, args []string) (string, error) {
+	r.args = args
+	return r.out, nil
+}
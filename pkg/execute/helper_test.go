@@ -3,6 +3,7 @@ package execute
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/kubeshop/botkube/pkg/config"
 	"github.com/kubeshop/botkube/pkg/execute/command"
@@ -25,6 +26,13 @@ func (f *fakeCfgPersistenceManager) PersistSourceBindings(ctx context.Context, c
 	return nil
 }
 
+func (f *fakeCfgPersistenceManager) PersistExecutorBindings(ctx context.Context, commGroupName string, platform config.CommPlatformIntegration, channelAlias string, executorBindings []string) error {
+	if f.expectedAlias != channelAlias {
+		return errors.New("different alias")
+	}
+	return nil
+}
+
 func (f *fakeCfgPersistenceManager) PersistNotificationsEnabled(ctx context.Context, commGroupName string, platform config.CommPlatformIntegration, channelAlias string, enabled bool) error {
 	if f.expectedAlias != channelAlias {
 		return errors.New("different alias")
@@ -32,6 +40,21 @@ func (f *fakeCfgPersistenceManager) PersistNotificationsEnabled(ctx context.Cont
 	return nil
 }
 
+func (f *fakeCfgPersistenceManager) PersistNotificationsSnoozed(ctx context.Context, commGroupName string, platform config.CommPlatformIntegration, channelAlias string, until time.Time) error {
+	if f.expectedAlias != channelAlias {
+		return errors.New("different alias")
+	}
+	return nil
+}
+
 func (f *fakeCfgPersistenceManager) PersistFilterEnabled(ctx context.Context, name string, enabled bool) error {
 	return nil
 }
+
+func (f *fakeCfgPersistenceManager) PersistAlias(ctx context.Context, name, command string) error {
+	return nil
+}
+
+func (f *fakeCfgPersistenceManager) PersistSchedule(ctx context.Context, id string, schedule *config.Schedule) error {
+	return nil
+}
@@ -1,8 +1,13 @@
 package execute
 
 import (
+	"bytes"
+	"context"
+	"errors"
 	"fmt"
+	"io"
 	"strings"
+	"time"
 	"unicode"
 
 	"github.com/gookit/color"
@@ -11,6 +16,7 @@ import (
 	"github.com/spf13/pflag"
 	"k8s.io/utils/strings/slices"
 
+	"github.com/kubeshop/botkube/pkg/bot/identity"
 	"github.com/kubeshop/botkube/pkg/config"
 	"github.com/kubeshop/botkube/pkg/execute/kubectl"
 	"github.com/kubeshop/botkube/pkg/utils"
@@ -23,6 +29,7 @@ const (
 	kubectlNotAllowedKindMsgFmt        = "Sorry, the kubectl command is not authorized to work with '%s' resources in the '%s' Namespace on cluster '%s'. Use 'commands list' to see allowed commands."
 	kubectlNotAllowedKinInAllNsMsgFmt  = "Sorry, the kubectl command is not authorized to work with '%s' resources for all Namespaces on cluster '%s'. Use 'commands list' to see allowed commands."
 	kubectlFlagAfterVerbMsg            = "Please specify the resource name after the verb, and all flags after the resource name. Format <verb> <resource> [flags]"
+	kubectlCommandTimeoutMsgFmt        = "Sorry, the command timed out after %s on cluster '%s'."
 	kubectlDefaultNamespace            = "default"
 )
 
@@ -130,6 +137,30 @@ func (e *Kubectl) getArgsWithoutAlias(msg string) ([]string, error) {
 // - we are a target cluster,
 // - and Kubectl.CanHandle returned true.
 func (e *Kubectl) Execute(bindings []string, command string, isAuthChannel bool) (string, error) {
+	return e.ExecuteWithStdin(bindings, command, isAuthChannel, nil)
+}
+
+// ExecuteWithStdin executes kubectl command based on a given args and passes a given payload as its standard input.
+// The payload is used only if the command reads the manifests from standard input, e.g. `kubectl apply -f -`.
+// In such case, all kinds defined in the manifests must be allowed in the execution Namespace.
+//
+// This method should be called ONLY if:
+// - we are a target cluster,
+// - and Kubectl.CanHandle returned true.
+func (e *Kubectl) ExecuteWithStdin(bindings []string, command string, isAuthChannel bool, stdin []byte) (string, error) {
+	return e.ExecuteStream(context.Background(), bindings, command, isAuthChannel, identity.Identity{}, stdin, nil)
+}
+
+// ExecuteStream executes kubectl command based on a given args, in the same way as ExecuteWithStdin.
+// The output of the `logs` and `exec` commands is passed to a given handler as it arrives. The handler may be nil.
+// Such commands are executed with the safety limits, and the `exec` command is allowed only for the configured container commands.
+// If the handler is given, the `get --watch` command streams the resource changes until the watch timeout. Otherwise, the watch flags are ignored.
+// If the impersonation is enabled, the command is executed as a given Kubernetes identity.
+//
+// This method should be called ONLY if:
+// - we are a target cluster,
+// - and Kubectl.CanHandle returned true.
+func (e *Kubectl) ExecuteStream(ctx context.Context, bindings []string, command string, isAuthChannel bool, as identity.Identity, stdin []byte, handleChunk OutputChunkHandler) (string, error) {
 	log := e.log.WithFields(logrus.Fields{
 		"isAuthChannel": isAuthChannel,
 		"command":       command,
@@ -171,8 +202,28 @@ func (e *Kubectl) Execute(bindings []string, command string, isAuthChannel bool)
 		return "", NewExecutionCommandError(kubectlNotAllowedVerbMsgFmt, verb, executionNs, clusterName)
 	}
 
+	withStdin := readsFromStdin(args)
+	if withStdin {
+		kind, err := e.findNotAllowedManifestKind(kcConfig, stdin)
+		if err != nil {
+			return "", err
+		}
+		if kind != "" {
+			if executionNs == config.AllNamespaceIndicator {
+				return "", NewExecutionCommandError(kubectlNotAllowedKinInAllNsMsgFmt, kind, clusterName)
+			}
+			return "", NewExecutionCommandError(kubectlNotAllowedKindMsgFmt, kind, executionNs, clusterName)
+		}
+	}
+
+	if verb == kubectlExecVerb {
+		if err := validateExecCommand(kcConfig, args, clusterName); err != nil {
+			return "", err
+		}
+	}
+
 	_, isResourceless := resourcelessCommands[verb]
-	if !isResourceless && resource != "" {
+	if !isResourceless && !withStdin && resource != "" {
 		if !e.validResourceName(resource) {
 			return "", NewExecutionCommandError(kubectlFlagAfterVerbMsg)
 		}
@@ -186,16 +237,83 @@ func (e *Kubectl) Execute(bindings []string, command string, isAuthChannel bool)
 		}
 	}
 
-	finalArgs := e.getFinalArgs(args)
-	out, err := e.cmdRunner.RunCombinedOutput(kubectlBinary, finalArgs)
+	impersonationArgs, err := e.impersonationArgs(args, as)
+	if err != nil {
+		return "", err
+	}
+
+	finalArgs := append(e.getFinalArgs(args), impersonationArgs...)
+	watchArgs := watchFlags(verb, args)
+	var out string
+	switch {
+	case isStreamingVerb(verb):
+		limits := streamingLimits(kcConfig.Streaming)
+		if verb == kubectlLogsVerb {
+			finalArgs = addDefaultTailFlag(finalArgs, limits.DefaultTailLines)
+		}
+		out, err = e.runStream(ctx, finalArgs, limits, handleChunk)
+	case len(watchArgs) > 0 && handleChunk != nil:
+		limits := streamingLimits(kcConfig.Streaming)
+		limits.Timeout = limits.WatchTimeout
+		NotifyWatchStarted(ctx)
+		out, err = e.runStream(ctx, append(finalArgs, watchArgs...), limits, handleChunk)
+	default:
+		timeout := e.commandTimeout(kcConfig)
+		out, err = e.run(ctx, finalArgs, withStdin, stdin, timeout)
+		if errors.Is(err, context.DeadlineExceeded) {
+			return "", NewExecutionCommandError(kubectlCommandTimeoutMsgFmt, timeout, clusterName)
+		}
+	}
 	out = color.ClearCode(out)
 	if err != nil {
+		if len(impersonationArgs) > 0 {
+			if impErr := e.impersonationError(out, as); impErr != nil {
+				return "", impErr
+			}
+		}
 		return "", NewExecutionCommandError("%s%s", out, err.Error())
 	}
 
 	return out, nil
 }
 
+// run runs a given kubectl command. If a given timeout is set and the command runner supports it, the command is killed once the timeout
+// is exceeded, and the context.DeadlineExceeded error is returned.
+func (e *Kubectl) run(ctx context.Context, args []string, withStdin bool, stdin []byte, timeout time.Duration) (string, error) {
+	if runner, ok := e.cmdRunner.(CommandCombinedOutputContextRunner); ok && timeout > 0 {
+		ctx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+
+		var in io.Reader
+		if withStdin {
+			in = bytes.NewReader(stdin)
+		}
+		out, err := runner.RunCombinedOutputContext(ctx, kubectlBinary, args, in)
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return out, ctx.Err()
+		}
+		return out, err
+	}
+
+	if !withStdin {
+		return e.cmdRunner.RunCombinedOutput(kubectlBinary, args)
+	}
+
+	runner, ok := e.cmdRunner.(CommandCombinedOutputWithStdinRunner)
+	if !ok {
+		return "", errors.New("command runner doesn't support standard input")
+	}
+	return runner.RunCombinedOutputWithStdin(kubectlBinary, args, bytes.NewReader(stdin))
+}
+
+// commandTimeout returns the timeout of the commands which don't stream their output. Zero means no limit.
+func (e *Kubectl) commandTimeout(kcConfig kubectl.EnabledKubectl) time.Duration {
+	if kcConfig.CommandTimeout > 0 {
+		return kcConfig.CommandTimeout
+	}
+	return e.cfg.Settings.CommandTimeout
+}
+
 // omitIfWeAreNotExplicitlyTargetCluster returns verboseMsg if there is explicit '--cluster-name' flag that matches this cluster.
 // It's useful if we want to be more verbose, but we also don't want to spam if we are not the target one.
 func (e *Kubectl) omitIfWeAreNotExplicitlyTargetCluster(log *logrus.Entry, cmd string, verboseMsg *ExecutionCommandError) error {
@@ -217,21 +335,23 @@ func (e *Kubectl) getFinalArgs(args []string) []string {
 	// Remove unnecessary flags
 	var finalArgs []string
 	isClusterNameArg := false
-	for _, arg := range args {
+	for idx, arg := range args {
 		if isClusterNameArg {
 			isClusterNameArg = false
 			continue
 		}
-		if arg == AbbrFollowFlag.String() || strings.HasPrefix(arg, FollowFlag.String()) {
+		// `-f` is also a shorthand for `--filename`, so we keep it if it's used to read from standard input
+		isStdinFilename := idx+1 < len(args) && args[idx+1] == kubectlStdinFilename
+		if (arg == AbbrFollowFlag.String() && !isStdinFilename) || strings.HasPrefix(arg, FollowFlag.String()) {
 			continue
 		}
 		if arg == AbbrWatchFlag.String() || strings.HasPrefix(arg, WatchFlag.String()) {
 			continue
 		}
 		// Remove --cluster-name flag and it's value
-		if strings.HasPrefix(arg, ClusterFlag.String()) {
+		if isClusterFlag(arg) {
 			// Check if flag value in current or next argument and compare with config.settings.clusterName
-			if arg == ClusterFlag.String() {
+			if arg == ClusterFlag.String() || arg == AbbrClusterFlag.String() {
 				isClusterNameArg = true
 			}
 			continue
@@ -242,6 +362,17 @@ func (e *Kubectl) getFinalArgs(args []string) []string {
 	return finalArgs
 }
 
+// isClusterFlag returns true if a given argument is the `--cluster-name` flag or its `--cluster` alias.
+// Other flags with the same prefix, such as `--clusterrole`, are not matched.
+func isClusterFlag(arg string) bool {
+	for _, flag := range []CommandFlags{ClusterFlag, AbbrClusterFlag} {
+		if arg == flag.String() || strings.HasPrefix(arg, flag.String()+"=") {
+			return true
+		}
+	}
+	return false
+}
+
 // getNamespaceFlag returns the namespace value extracted from a given args.
 // If `--namespace/-n` was not found, returns empty string.
 func (e *Kubectl) getNamespaceFlag(args []string) (string, error) {
@@ -38,6 +38,15 @@ func (c *Checker) IsResourceAllowedInNs(config EnabledKubectl, resource string)
 	return false
 }
 
+// ResourceVariants returns a given resource name together with its alternative namings, e.g. `po` and `pods`.
+func (c *Checker) ResourceVariants(resource string) []string {
+	out := []string{resource}
+	if c.resourceVariants == nil {
+		return out
+	}
+	return append(out, c.resourceVariants(resource)...)
+}
+
 // IsVerbAllowedInNs returns true if verb was found in a given config.
 func (c *Checker) IsVerbAllowedInNs(config EnabledKubectl, verb string) bool {
 	_, found := config.AllowedKubectlVerb[verb]
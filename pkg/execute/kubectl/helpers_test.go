@@ -54,7 +54,34 @@ executors:
         include: [ ".*" ]
       commands:
         verbs: [ "exec" ]
-        resources: [ ]`
+        resources: [ ]
+  'kubectl-streaming-a':
+    kubectl:
+      enabled: true
+      namespaces:
+        include: [ ".*" ]
+      commands:
+        verbs: [ "logs" ]
+      exec:
+        allowedCommands: [ "ls" ]
+      streaming:
+        maxOutputSize: 2048
+        timeout: 1m
+        watchTimeout: 2m
+      commandTimeout: 10s
+  'kubectl-streaming-b':
+    kubectl:
+      enabled: true
+      namespaces:
+        include: [ ".*" ]
+      commands:
+        verbs: [ "exec" ]
+      exec:
+        allowedCommands: [ "env" ]
+      streaming:
+        maxOutputSize: 1024
+        defaultTailLines: 10
+      commandTimeout: 20s`
 
 func fixExecutorsConfig(t *testing.T) map[string]config.Executors {
 	t.Helper()
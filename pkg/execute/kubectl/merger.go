@@ -1,6 +1,8 @@
 package kubectl
 
 import (
+	"time"
+
 	"github.com/kubeshop/botkube/pkg/config"
 )
 
@@ -13,6 +15,10 @@ type EnabledKubectl struct {
 
 	DefaultNamespace string
 	RestrictAccess   bool
+
+	AllowedExecCommands map[string]struct{}
+	Streaming           config.KubectlStreaming
+	CommandTimeout      time.Duration
 }
 
 // Merger provides functionality to merge multiple bindings
@@ -35,6 +41,9 @@ func NewMerger(executors map[string]config.Executors) *Merger {
 //   - kubectl.commands.resources - strategy append
 //   - kubectl.defaultNamespace   - strategy override (if not empty)
 //   - kubectl.restrictAccess     - strategy override (if not empty)
+//   - kubectl.exec.allowedCommands - strategy append
+//   - kubectl.streaming.*        - strategy override (if not empty)
+//   - kubectl.commandTimeout     - strategy override (if not empty)
 //
 // The order of merging is the same as the order of items specified in the includeBindings list.
 func (kc *Merger) MergeForNamespace(includeBindings []string, forNamespace string) EnabledKubectl {
@@ -104,6 +113,9 @@ func (kc *Merger) merge(collectedKubectls map[string]config.Kubectl, mapKeyOrder
 		allowedResources     = map[string]struct{}{}
 		allowedVerbs         = map[string]struct{}{}
 		allowedNSPerResource = map[string]config.Namespaces{}
+		allowedExecCommands  = map[string]struct{}{}
+		streaming            config.KubectlStreaming
+		commandTimeout       time.Duration
 	)
 	for _, name := range mapKeyOrder {
 		item, found := collectedKubectls[name]
@@ -133,6 +145,26 @@ func (kc *Merger) merge(collectedKubectls map[string]config.Kubectl, mapKeyOrder
 		if item.RestrictAccess != nil {
 			restrictAccess = *item.RestrictAccess
 		}
+
+		for _, cmd := range item.Exec.AllowedCommands {
+			allowedExecCommands[cmd] = struct{}{}
+		}
+
+		if item.Streaming.MaxOutputSize > 0 {
+			streaming.MaxOutputSize = item.Streaming.MaxOutputSize
+		}
+		if item.Streaming.Timeout > 0 {
+			streaming.Timeout = item.Streaming.Timeout
+		}
+		if item.Streaming.DefaultTailLines > 0 {
+			streaming.DefaultTailLines = item.Streaming.DefaultTailLines
+		}
+		if item.Streaming.WatchTimeout > 0 {
+			streaming.WatchTimeout = item.Streaming.WatchTimeout
+		}
+		if item.CommandTimeout > 0 {
+			commandTimeout = item.CommandTimeout
+		}
 	}
 
 	return EnabledKubectl{
@@ -141,6 +173,9 @@ func (kc *Merger) merge(collectedKubectls map[string]config.Kubectl, mapKeyOrder
 		AllowedNamespacesPerResource: allowedNSPerResource,
 		DefaultNamespace:             defaultNs,
 		RestrictAccess:               restrictAccess,
+		AllowedExecCommands:          allowedExecCommands,
+		Streaming:                    streaming,
+		CommandTimeout:               commandTimeout,
 	}
 }
 
@@ -2,6 +2,7 @@ package kubectl_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -43,8 +44,9 @@ func TestKubectlMerger(t *testing.T) {
 				AllowedKubectlResource: map[string]struct{}{
 					"deployments": {},
 				},
-				DefaultNamespace: "team-a",
-				RestrictAccess:   true,
+				DefaultNamespace:    "team-a",
+				RestrictAccess:      true,
+				AllowedExecCommands: map[string]struct{}{},
 			},
 		},
 		{
@@ -67,6 +69,7 @@ func TestKubectlMerger(t *testing.T) {
 				AllowedKubectlResource: map[string]struct{}{},
 				DefaultNamespace:       "foo",
 				RestrictAccess:         true,
+				AllowedExecCommands:    map[string]struct{}{},
 			},
 		},
 		{
@@ -90,8 +93,9 @@ func TestKubectlMerger(t *testing.T) {
 				AllowedKubectlResource: map[string]struct{}{
 					"deployments": {},
 				},
-				DefaultNamespace: "team-a",
-				RestrictAccess:   false,
+				DefaultNamespace:    "team-a",
+				RestrictAccess:      false,
+				AllowedExecCommands: map[string]struct{}{},
 			},
 		},
 		{
@@ -117,8 +121,9 @@ func TestKubectlMerger(t *testing.T) {
 				AllowedKubectlResource: map[string]struct{}{
 					"deployments": {},
 				},
-				DefaultNamespace: "team-a",
-				RestrictAccess:   true,
+				DefaultNamespace:    "team-a",
+				RestrictAccess:      true,
+				AllowedExecCommands: map[string]struct{}{},
 			},
 		},
 		{
@@ -144,8 +149,9 @@ func TestKubectlMerger(t *testing.T) {
 				AllowedKubectlResource: map[string]struct{}{
 					"deployments": {},
 				},
-				DefaultNamespace: "team-a",
-				RestrictAccess:   false,
+				DefaultNamespace:    "team-a",
+				RestrictAccess:      false,
+				AllowedExecCommands: map[string]struct{}{},
 			},
 		},
 		{
@@ -178,7 +184,35 @@ func TestKubectlMerger(t *testing.T) {
 					"deployments": {},
 					"pods":        {},
 				},
-				RestrictAccess: true,
+				RestrictAccess:      true,
+				AllowedExecCommands: map[string]struct{}{},
+			},
+		},
+		{
+			name: "Should collect exec commands and override streaming limits and command timeout",
+			givenBindings: []string{
+				"kubectl-streaming-a",
+				"kubectl-streaming-b",
+			},
+			givenNamespace: "team-a",
+			expectKubectlConfig: kubectl.EnabledKubectl{
+				AllowedNamespacesPerResource: map[string]config.Namespaces{},
+				AllowedKubectlVerb: map[string]struct{}{
+					"logs": {},
+					"exec": {},
+				},
+				AllowedKubectlResource: map[string]struct{}{},
+				AllowedExecCommands: map[string]struct{}{
+					"ls":  {},
+					"env": {},
+				},
+				Streaming: config.KubectlStreaming{
+					MaxOutputSize:    1024,
+					Timeout:          time.Minute,
+					DefaultTailLines: 10,
+					WatchTimeout:     2 * time.Minute,
+				},
+				CommandTimeout: 20 * time.Second,
 			},
 		},
 	}
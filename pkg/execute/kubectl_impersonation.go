@@ -0,0 +1,63 @@
+package execute
+
+import (
+	"strings"
+
+	"github.com/kubeshop/botkube/pkg/bot/identity"
+)
+
+const (
+	kubectlImpersonationForbiddenMsgFmt       = "Sorry, Botkube is not allowed to impersonate the '%s' user on cluster '%s'. Please ask your Botkube administrator to grant the `impersonate` permission to the Botkube service account."
+	kubectlImpersonationMissingIdentityMsgFmt = "Sorry, kubectl commands on cluster '%s' can be executed only by users mapped to a Kubernetes identity. Please ask your Botkube administrator to map your user."
+	kubectlImpersonationFlagsNotAllowedMsgFmt = "Sorry, the impersonation flags cannot be used on cluster '%s', as the commands are executed with your own Kubernetes identity."
+	kubectlImpersonationForbiddenOutputPart   = "cannot impersonate resource"
+)
+
+// kubectlImpersonationFlags holds the kubectl flags used for impersonation. They cannot be specified by users if the impersonation is enabled.
+var kubectlImpersonationFlags = []string{"--as", "--as-group", "--as-uid"}
+
+// impersonationArgs returns the kubectl impersonation flags for a given identity, or an ExecutionCommandError if the command cannot be executed.
+// No flags are returned if the impersonation is disabled, or if the identity is empty and it is not required.
+func (e *Kubectl) impersonationArgs(args []string, as identity.Identity) ([]string, error) {
+	cfg := e.cfg.Settings.Identity.Impersonation
+	if !cfg.Enabled {
+		return nil, nil
+	}
+
+	clusterName := e.cfg.Settings.ClusterName
+	if hasImpersonationFlag(args) {
+		return nil, NewExecutionCommandError(kubectlImpersonationFlagsNotAllowedMsgFmt, clusterName)
+	}
+
+	if as.IsEmpty() {
+		if cfg.RequireIdentity {
+			return nil, NewExecutionCommandError(kubectlImpersonationMissingIdentityMsgFmt, clusterName)
+		}
+		return nil, nil
+	}
+
+	out := []string{"--as=" + as.Username}
+	for _, group := range as.Groups {
+		out = append(out, "--as-group="+group)
+	}
+	return out, nil
+}
+
+// impersonationError returns a clear ExecutionCommandError if the kubectl command failed because the Botkube service account cannot impersonate a given identity.
+func (e *Kubectl) impersonationError(out string, as identity.Identity) error {
+	if as.IsEmpty() || !strings.Contains(out, kubectlImpersonationForbiddenOutputPart) {
+		return nil
+	}
+	return NewExecutionCommandError(kubectlImpersonationForbiddenMsgFmt, as.Username, e.cfg.Settings.ClusterName)
+}
+
+func hasImpersonationFlag(args []string) bool {
+	for _, arg := range args {
+		for _, flag := range kubectlImpersonationFlags {
+			if arg == flag || strings.HasPrefix(arg, flag+"=") {
+				return true
+			}
+		}
+	}
+	return false
+}
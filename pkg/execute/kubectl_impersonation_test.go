@@ -0,0 +1,121 @@
+package execute
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	logtest "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/pkg/bot/identity"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/execute/kubectl"
+)
+
+func TestKubectlExecuteWithImpersonation(t *testing.T) {
+	// given
+	logger, _ := logtest.NewNullLogger()
+	alice := identity.Identity{Username: "alice@example.com", Groups: []string{"developers", "sre"}}
+
+	tests := []struct {
+		name string
+
+		impersonation config.IdentityImpersonation
+		command       string
+		identity      identity.Identity
+		runnerOut     string
+		runnerErr     error
+
+		expArgs     []string
+		expErrorMsg string
+	}{
+		{
+			name:          "Should run command as mapped identity",
+			impersonation: config.IdentityImpersonation{Enabled: true},
+			command:       "get pods",
+			identity:      alice,
+			expArgs:       []string{"-n", "default", "get", "pods", "--as=alice@example.com", "--as-group=developers", "--as-group=sre"},
+		},
+		{
+			name:          "Should run command as service account if identity is not mapped",
+			impersonation: config.IdentityImpersonation{Enabled: true},
+			command:       "get pods",
+			expArgs:       []string{"-n", "default", "get", "pods"},
+		},
+		{
+			name:     "Should not impersonate if disabled",
+			command:  "get pods",
+			identity: alice,
+			expArgs:  []string{"-n", "default", "get", "pods"},
+		},
+		{
+			name:          "Should reject command if identity is required",
+			impersonation: config.IdentityImpersonation{Enabled: true, RequireIdentity: true},
+			command:       "get pods",
+			expErrorMsg:   "Sorry, kubectl commands on cluster 'test' can be executed only by users mapped to a Kubernetes identity. Please ask your Botkube administrator to map your user.",
+		},
+		{
+			name:          "Should reject impersonation flags specified by user",
+			impersonation: config.IdentityImpersonation{Enabled: true},
+			command:       "get pods --as=system:admin",
+			identity:      alice,
+			expErrorMsg:   "Sorry, the impersonation flags cannot be used on cluster 'test', as the commands are executed with your own Kubernetes identity.",
+		},
+		{
+			name:          "Should return clear error if impersonation is forbidden",
+			impersonation: config.IdentityImpersonation{Enabled: true},
+			command:       "get pods",
+			identity:      alice,
+			runnerOut:     `Error from server (Forbidden): users "alice@example.com" is forbidden: User "system:serviceaccount:botkube:botkube-sa" cannot impersonate resource "users" in API group "" at the cluster scope`,
+			runnerErr:     errors.New("exit status 1"),
+			expErrorMsg:   "Sorry, Botkube is not allowed to impersonate the 'alice@example.com' user on cluster 'test'. Please ask your Botkube administrator to grant the `impersonate` permission to the Botkube service account.",
+		},
+		{
+			name:          "Should return kubectl output if impersonated user is forbidden",
+			impersonation: config.IdentityImpersonation{Enabled: true},
+			command:       "get pods",
+			identity:      alice,
+			runnerOut:     `Error from server (Forbidden): pods is forbidden: User "alice@example.com" cannot list resource "pods" in API group "" in the namespace "default"`,
+			runnerErr:     errors.New("exit status 1"),
+			expErrorMsg:   `Error from server (Forbidden): pods is forbidden: User "alice@example.com" cannot list resource "pods" in API group "" in the namespace "default"exit status 1`,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := fixCfgWithKubectlExecutor(t, config.Kubectl{
+				Enabled: true,
+				Namespaces: config.Namespaces{
+					Include: []string{"default"},
+				},
+				Commands: config.Commands{
+					Verbs:     []string{"get"},
+					Resources: []string{"pods"},
+				},
+			})
+			cfg.Settings.Identity.Impersonation = tc.impersonation
+
+			var gotArgs []string
+			runner := cmdCombinedFunc(func(_ string, args []string) (string, error) {
+				gotArgs = args
+				return tc.runnerOut, tc.runnerErr
+			})
+			executor := NewKubectl(logger, cfg, kubectl.NewMerger(cfg.Executors), kubectl.NewChecker(nil), runner)
+
+			// when
+			_, err := executor.ExecuteStream(context.Background(), fixBindingsNames, tc.command, true, tc.identity, nil, nil)
+
+			// then
+			if tc.expErrorMsg != "" {
+				require.Error(t, err)
+				assert.True(t, IsExecutionCommandError(err))
+				assert.EqualError(t, err, tc.expErrorMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expArgs, gotArgs)
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package execute
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+
+	"github.com/kubeshop/botkube/pkg/execute/kubectl"
+)
+
+const (
+	// kubectlStdinFilename is the filename which instructs kubectl to read the manifests from standard input.
+	kubectlStdinFilename = "-"
+
+	kubectlStdinMissingMsg    = "Please attach a file with Kubernetes manifests to the message to use the standard input, e.g. `kubectl apply -f -`."
+	kubectlStdinNoManifestMsg = "The attached file doesn't contain any Kubernetes manifests."
+	kubectlStdinInvalidMsgFmt = "Cannot parse the attached file: %s"
+
+	manifestDecoderBufferSize = 4096
+)
+
+// manifestKinds holds the kind of the manifest together with the kinds of the list items, if it's a list.
+type manifestKinds struct {
+	Kind  string `json:"kind"`
+	Items []struct {
+		Kind string `json:"kind"`
+	} `json:"items"`
+}
+
+// readsFromStdin returns true if a given kubectl args instruct kubectl to read the manifests from standard input.
+func readsFromStdin(args []string) bool {
+	for idx, arg := range args {
+		switch arg {
+		case "-f", "--filename":
+			if idx+1 < len(args) && args[idx+1] == kubectlStdinFilename {
+				return true
+			}
+		case "-f=" + kubectlStdinFilename, "--filename=" + kubectlStdinFilename:
+			return true
+		}
+	}
+	return false
+}
+
+// getManifestKinds returns the kinds of all objects defined in a given YAML or JSON manifests.
+// Each object from the `List` kinds is returned separately.
+func getManifestKinds(manifests []byte) ([]string, error) {
+	decoder := utilyaml.NewYAMLOrJSONDecoder(bytes.NewReader(manifests), manifestDecoderBufferSize)
+
+	var kinds []string
+	for {
+		var obj manifestKinds
+		err := decoder.Decode(&obj)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if len(obj.Items) > 0 && strings.HasSuffix(obj.Kind, "List") {
+			for _, item := range obj.Items {
+				kinds = append(kinds, item.Kind)
+			}
+			continue
+		}
+		if obj.Kind == "" { // e.g. empty YAML document
+			continue
+		}
+		kinds = append(kinds, obj.Kind)
+	}
+
+	return kinds, nil
+}
+
+// findNotAllowedManifestKind returns the first kind from a given manifests which is not allowed in a given config.
+// It returns ExecutionCommandError if the manifests are missing or malformed.
+func (e *Kubectl) findNotAllowedManifestKind(kcConfig kubectl.EnabledKubectl, manifests []byte) (string, error) {
+	if len(manifests) == 0 {
+		return "", NewExecutionCommandError(kubectlStdinMissingMsg)
+	}
+
+	kinds, err := getManifestKinds(manifests)
+	if err != nil {
+		return "", NewExecutionCommandError(kubectlStdinInvalidMsgFmt, err.Error())
+	}
+	if len(kinds) == 0 {
+		return "", NewExecutionCommandError(kubectlStdinNoManifestMsg)
+	}
+
+	for _, kind := range kinds {
+		if !e.kcChecker.IsResourceAllowedInNs(kcConfig, kind) {
+			return kind, nil
+		}
+	}
+	return "", nil
+}
@@ -0,0 +1,166 @@
+package execute
+
+import (
+	"io"
+	"testing"
+
+	logtest "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/execute/kubectl"
+)
+
+const fixManifests = `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: foo
+---
+apiVersion: v1
+kind: List
+items:
+- apiVersion: v1
+  kind: ConfigMap
+  metadata:
+    name: bar
+`
+
+func TestKubectlExecuteWithStdin(t *testing.T) {
+	// given
+	logger, _ := logtest.NewNullLogger()
+	kubectlCfg := config.Kubectl{
+		Enabled: true,
+		Namespaces: config.Namespaces{
+			Include: []string{"default"},
+		},
+		Commands: config.Commands{
+			Verbs:     []string{"apply"},
+			Resources: []string{"ConfigMap"},
+		},
+	}
+
+	tests := []struct {
+		name string
+
+		command     string
+		stdin       string
+		expArgs     []string
+		expStdin    string
+		expErrorMsg string
+	}{
+		{
+			name: "Should pass manifests to kubectl",
+
+			command:  "apply -f -",
+			stdin:    fixManifests,
+			expArgs:  []string{"-n", "default", "apply", "-f", "-"},
+			expStdin: fixManifests,
+		},
+		{
+			name: "Should pass manifests to kubectl with the long flag",
+
+			command:  "kubectl apply --filename=-",
+			stdin:    fixManifests,
+			expArgs:  []string{"-n", "default", "apply", "--filename=-"},
+			expStdin: fixManifests,
+		},
+		{
+			name: "Should reject manifests with not allowed kinds",
+
+			command:     "apply -f -",
+			stdin:       "apiVersion: v1\nkind: Secret\nmetadata:\n  name: foo\n",
+			expErrorMsg: "Sorry, the kubectl command is not authorized to work with 'Secret' resources in the 'default' Namespace on cluster 'test'. Use 'commands list' to see allowed commands.",
+		},
+		{
+			name: "Should reject missing manifests",
+
+			command:     "apply -f -",
+			expErrorMsg: kubectlStdinMissingMsg,
+		},
+		{
+			name: "Should reject file without manifests",
+
+			command:     "apply -f -",
+			stdin:       "---\n",
+			expErrorMsg: kubectlStdinNoManifestMsg,
+		},
+		{
+			name: "Should reject malformed manifests",
+
+			command:     "apply -f -",
+			stdin:       "kind: [",
+			expErrorMsg: "Cannot parse the attached file: error converting YAML to JSON: yaml: line 1: did not find expected node content",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := fixCfgWithKubectlExecutor(t, kubectlCfg)
+			executor := NewKubectl(logger, cfg, kubectl.NewMerger(cfg.Executors), kubectl.NewChecker(nil), &fakeStdinRunner{})
+
+			// when
+			out, err := executor.ExecuteWithStdin(fixBindingsNames, tc.command, true, []byte(tc.stdin))
+
+			// then
+			runner := executor.cmdRunner.(*fakeStdinRunner)
+			if tc.expErrorMsg != "" {
+				require.Error(t, err)
+				assert.True(t, IsExecutionCommandError(err))
+				assert.EqualError(t, err, tc.expErrorMsg)
+				assert.Nil(t, runner.gotArgs)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, "kubectl executed", out)
+			assert.Equal(t, tc.expArgs, runner.gotArgs)
+			assert.Equal(t, tc.expStdin, runner.gotStdin)
+		})
+	}
+}
+
+func TestKubectlExecuteIgnoresStdinIfNotRequested(t *testing.T) {
+	// given
+	logger, _ := logtest.NewNullLogger()
+	cfg := fixCfgWithKubectlExecutor(t, config.Kubectl{
+		Enabled: true,
+		Namespaces: config.Namespaces{
+			Include: []string{"default"},
+		},
+		Commands: config.Commands{
+			Verbs:     []string{"logs"},
+			Resources: []string{"pods"},
+		},
+	})
+	runner := &fakeStdinRunner{}
+	executor := NewKubectl(logger, cfg, kubectl.NewMerger(cfg.Executors), kubectl.NewChecker(nil), runner)
+
+	// when
+	_, err := executor.ExecuteWithStdin(fixBindingsNames, "logs foo -f", true, []byte(fixManifests))
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, []string{"-n", "default", "logs", "foo", "--tail=100"}, runner.gotArgs)
+	assert.Empty(t, runner.gotStdin)
+}
+
+type fakeStdinRunner struct {
+	gotArgs  []string
+	gotStdin string
+}
+
+func (r *fakeStdinRunner) RunCombinedOutput(_ string, args []string) (string, error) {
+	r.gotArgs = args
+	return "kubectl executed", nil
+}
+
+func (r *fakeStdinRunner) RunCombinedOutputWithStdin(_ string, args []string, stdin io.Reader) (string, error) {
+	raw, err := io.ReadAll(stdin)
+	if err != nil {
+		return "", err
+	}
+	r.gotArgs = args
+	r.gotStdin = string(raw)
+	return "kubectl executed", nil
+}
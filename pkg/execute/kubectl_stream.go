@@ -0,0 +1,167 @@
+package execute
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gookit/color"
+	"github.com/spf13/pflag"
+	"k8s.io/utils/strings/slices"
+
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/execute/kubectl"
+)
+
+const (
+	kubectlExecMissingCommandMsg   = "Please specify the command to execute after `--`, e.g. `kubectl exec my-pod -- ls`."
+	kubectlExecInteractiveMsg      = "Sorry, interactive `kubectl exec` sessions are not supported. Please remove the `-i` and `-t` flags."
+	kubectlExecNotAllowedCmdMsgFmt = "Sorry, the '%s' command cannot be executed in containers on cluster '%s'."
+	kubectlStreamTruncatedMsgFmt   = "\n\n[Output truncated, as it exceeded %d bytes.]"
+	kubectlStreamTimeoutMsgFmt     = "\n\n[Command stopped, as it exceeded the %s timeout.]"
+	kubectlStreamDefaultMaxOutput  = 1 << 20 // 1 MiB
+	kubectlStreamDefaultTimeout    = 30 * time.Second
+	kubectlStreamDefaultTailLines  = 100
+	kubectlDefaultWatchTimeout     = 5 * time.Minute
+	kubectlExecCommandSeparator    = "--"
+	kubectlLogsVerb                = "logs"
+	kubectlExecVerb                = "exec"
+	kubectlGetVerb                 = "get"
+)
+
+// isStreamingVerb returns true if a given kubectl verb streams its output, so it's executed with the safety limits.
+func isStreamingVerb(verb string) bool {
+	return verb == kubectlLogsVerb || verb == kubectlExecVerb
+}
+
+// streamingLimits returns a given limits with the defaults for all missing values.
+func streamingLimits(in config.KubectlStreaming) config.KubectlStreaming {
+	if in.MaxOutputSize <= 0 {
+		in.MaxOutputSize = kubectlStreamDefaultMaxOutput
+	}
+	if in.Timeout <= 0 {
+		in.Timeout = kubectlStreamDefaultTimeout
+	}
+	if in.DefaultTailLines <= 0 {
+		in.DefaultTailLines = kubectlStreamDefaultTailLines
+	}
+	if in.WatchTimeout <= 0 {
+		in.WatchTimeout = kubectlDefaultWatchTimeout
+	}
+	return in
+}
+
+// watchFlags returns the `--watch`, `--watch-only` flags, or their `-w` shorthand, of a given `kubectl get` args.
+// It returns nil for other verbs, which don't support watching resources.
+func watchFlags(verb string, args []string) []string {
+	if verb != kubectlGetVerb {
+		return nil
+	}
+
+	var out []string
+	for _, arg := range args {
+		if arg == AbbrWatchFlag.String() || strings.HasPrefix(arg, WatchFlag.String()) {
+			out = append(out, arg)
+		}
+	}
+	return out
+}
+
+// validateExecCommand returns ExecutionCommandError if a given `kubectl exec` args start an interactive session,
+// or if the executed command is not allowed.
+func validateExecCommand(kcConfig kubectl.EnabledKubectl, args []string, clusterName string) error {
+	sepIdx := slices.Index(args, kubectlExecCommandSeparator)
+	if sepIdx == -1 || sepIdx == len(args)-1 {
+		return NewExecutionCommandError(kubectlExecMissingCommandMsg)
+	}
+
+	interactive, err := isInteractiveExec(args[:sepIdx])
+	if err != nil {
+		return fmt.Errorf("while parsing flags: %w", err)
+	}
+	if interactive {
+		return NewExecutionCommandError(kubectlExecInteractiveMsg)
+	}
+
+	cmd := args[sepIdx+1]
+	if _, found := kcConfig.AllowedExecCommands[cmd]; !found {
+		return NewExecutionCommandError(kubectlExecNotAllowedCmdMsgFmt, cmd, clusterName)
+	}
+	return nil
+}
+
+func isInteractiveExec(args []string) (bool, error) {
+	f := pflag.NewFlagSet("extract-exec-flags", pflag.ContinueOnError)
+	// ignore unknown flags errors, e.g. `--container` etc.
+	f.ParseErrorsWhitelist.UnknownFlags = true
+
+	var stdin, tty bool
+	f.BoolVarP(&stdin, "stdin", "i", false, "Pass stdin to the container")
+	f.BoolVarP(&tty, "tty", "t", false, "Stdin is a TTY")
+	if err := f.Parse(args); err != nil {
+		return false, err
+	}
+	return stdin || tty, nil
+}
+
+// addDefaultTailFlag limits the printed logs to the recent lines, unless the `--tail` or `--since` flag is already specified.
+func addDefaultTailFlag(args []string, lines int) []string {
+	for _, arg := range args {
+		if arg == kubectlExecCommandSeparator {
+			break
+		}
+		for _, flag := range []string{"--tail", "--since", "--since-time"} {
+			if arg == flag || strings.HasPrefix(arg, flag+"=") {
+				return args
+			}
+		}
+	}
+	return append(args, fmt.Sprintf("--tail=%d", lines))
+}
+
+// runStream runs a given kubectl command with the safety limits, and passes the output chunks to a given handler as they arrive.
+// Once the output size or the command duration exceeds the limits, the command is stopped and the notice is appended to its output.
+func (e *Kubectl) runStream(ctx context.Context, args []string, limits config.KubectlStreaming, handleChunk OutputChunkHandler) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, limits.Timeout)
+	defer cancel()
+
+	var (
+		out       strings.Builder
+		truncated bool
+	)
+	handle := func(chunk string) {
+		if truncated {
+			return
+		}
+		if remaining := limits.MaxOutputSize - out.Len(); len(chunk) > remaining {
+			chunk = strings.ToValidUTF8(chunk[:remaining], "")
+			truncated = true
+			cancel()
+		}
+		out.WriteString(chunk)
+		if handleChunk != nil && chunk != "" {
+			handleChunk(color.ClearCode(chunk))
+		}
+	}
+
+	var err error
+	if runner, ok := e.cmdRunner.(CommandCombinedOutputStreamRunner); ok {
+		err = runner.RunCombinedOutputStream(ctx, kubectlBinary, args, handle)
+	} else {
+		var res string
+		res, err = e.cmdRunner.RunCombinedOutput(kubectlBinary, args)
+		handle(res)
+	}
+
+	switch {
+	case truncated:
+		out.WriteString(fmt.Sprintf(kubectlStreamTruncatedMsgFmt, limits.MaxOutputSize))
+	case errors.Is(ctx.Err(), context.DeadlineExceeded):
+		out.WriteString(fmt.Sprintf(kubectlStreamTimeoutMsgFmt, limits.Timeout))
+	case err != nil:
+		return out.String(), err
+	}
+	return out.String(), nil
+}
@@ -0,0 +1,239 @@
+package execute
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	logtest "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/pkg/bot/identity"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/execute/kubectl"
+)
+
+func TestKubectlExecuteStream(t *testing.T) {
+	// given
+	tests := []struct {
+		name string
+
+		command   string
+		chunks    []string
+		streaming config.KubectlStreaming
+
+		expArgs   []string
+		expOut    string
+		expChunks []string
+	}{
+		{
+			name:      "Should stream logs with default tail",
+			command:   "logs nginx",
+			chunks:    []string{"line 1\n", "line 2\n"},
+			expArgs:   []string{"-n", "default", "logs", "nginx", "--tail=100"},
+			expOut:    "line 1\nline 2\n",
+			expChunks: []string{"line 1\n", "line 2\n"},
+		},
+		{
+			name:      "Should respect the since flag",
+			command:   "logs nginx --since=1h",
+			chunks:    []string{"line 1\n"},
+			expArgs:   []string{"-n", "default", "logs", "nginx", "--since=1h"},
+			expOut:    "line 1\n",
+			expChunks: []string{"line 1\n"},
+		},
+		{
+			name:      "Should stream allowed exec command",
+			command:   "exec nginx -c app -- ls /tmp",
+			chunks:    []string{"file.txt\n"},
+			expArgs:   []string{"-n", "default", "exec", "nginx", "-c", "app", "--", "ls", "/tmp"},
+			expOut:    "file.txt\n",
+			expChunks: []string{"file.txt\n"},
+		},
+		{
+			name:      "Should cut off output exceeding the limit",
+			command:   "logs nginx --tail=10",
+			chunks:    []string{"line 1\n", "line 2\n", "line 3\n"},
+			streaming: config.KubectlStreaming{MaxOutputSize: 10},
+			expArgs:   []string{"-n", "default", "logs", "nginx", "--tail=10"},
+			expOut:    "line 1\nlin\n\n[Output truncated, as it exceeded 10 bytes.]",
+			expChunks: []string{"line 1\n", "lin"},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			logger, _ := logtest.NewNullLogger()
+			cfg := fixCfgWithKubectlExecutor(t, fixStreamingKubectl(tc.streaming))
+			runner := &fakeStreamRunner{chunks: tc.chunks}
+			executor := NewKubectl(logger, cfg, kubectl.NewMerger(cfg.Executors), kubectl.NewChecker(nil), runner)
+
+			var gotChunks []string
+
+			// when
+			out, err := executor.ExecuteStream(context.Background(), fixBindingsNames, tc.command, true, identity.Identity{}, nil, func(chunk string) {
+				gotChunks = append(gotChunks, chunk)
+			})
+
+			// then
+			require.NoError(t, err)
+			assert.Equal(t, tc.expArgs, runner.gotArgs)
+			assert.Equal(t, tc.expOut, out)
+			assert.Equal(t, tc.expChunks, gotChunks)
+		})
+	}
+}
+
+func TestKubectlExecuteStreamTimeout(t *testing.T) {
+	// given
+	logger, _ := logtest.NewNullLogger()
+	cfg := fixCfgWithKubectlExecutor(t, fixStreamingKubectl(config.KubectlStreaming{Timeout: 10 * time.Millisecond}))
+	runner := &fakeStreamRunner{chunks: []string{"line 1\n"}, block: true}
+	executor := NewKubectl(logger, cfg, kubectl.NewMerger(cfg.Executors), kubectl.NewChecker(nil), runner)
+
+	// when
+	out, err := executor.ExecuteStream(context.Background(), fixBindingsNames, "logs nginx -f", true, identity.Identity{}, nil, nil)
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, "line 1\n\n\n[Command stopped, as it exceeded the 10ms timeout.]", out)
+}
+
+func TestKubectlExecuteStreamWatch(t *testing.T) {
+	// given
+	logger, _ := logtest.NewNullLogger()
+	cfg := fixCfgWithKubectlExecutor(t, fixStreamingKubectl(config.KubectlStreaming{WatchTimeout: 10 * time.Millisecond}))
+	runner := &fakeStreamRunner{chunks: []string{"NAME    READY\n", "nginx   1/1\n"}, block: true}
+	executor := NewKubectl(logger, cfg, kubectl.NewMerger(cfg.Executors), kubectl.NewChecker(nil), runner)
+
+	var (
+		gotChunks    []string
+		watchStarted bool
+	)
+	ctx := WithWatchStartedHandler(context.Background(), func() {
+		watchStarted = true
+	})
+
+	// when
+	out, err := executor.ExecuteStream(ctx, fixBindingsNames, "get pods -w", true, identity.Identity{}, nil, func(chunk string) {
+		gotChunks = append(gotChunks, chunk)
+	})
+
+	// then
+	require.NoError(t, err)
+	assert.True(t, watchStarted)
+	assert.Equal(t, []string{"-n", "default", "get", "pods", "-w"}, runner.gotArgs)
+	assert.Equal(t, "NAME    READY\nnginx   1/1\n\n\n[Command stopped, as it exceeded the 10ms timeout.]", out)
+	assert.Equal(t, []string{"NAME    READY\n", "nginx   1/1\n"}, gotChunks)
+}
+
+func TestKubectlExecuteStreamWatchWithoutHandler(t *testing.T) {
+	// given
+	logger, _ := logtest.NewNullLogger()
+	cfg := fixCfgWithKubectlExecutor(t, fixStreamingKubectl(config.KubectlStreaming{}))
+	runner := &fakeStreamRunner{chunks: []string{"NAME    READY\n"}, block: true}
+	executor := NewKubectl(logger, cfg, kubectl.NewMerger(cfg.Executors), kubectl.NewChecker(nil), runner)
+
+	watchStarted := false
+	ctx := WithWatchStartedHandler(context.Background(), func() {
+		watchStarted = true
+	})
+
+	// when
+	out, err := executor.ExecuteStream(ctx, fixBindingsNames, "get pods --watch", true, identity.Identity{}, nil, nil)
+
+	// then
+	require.NoError(t, err)
+	assert.False(t, watchStarted)
+	assert.Equal(t, []string{"-n", "default", "get", "pods"}, runner.gotArgs)
+	assert.Equal(t, "NAME    READY\n", out)
+}
+
+func TestKubectlExecuteStreamErrors(t *testing.T) {
+	// given
+	tests := []struct {
+		name string
+
+		command string
+		expErr  string
+	}{
+		{
+			name:    "Should forbid exec without command",
+			command: "exec nginx",
+			expErr:  "Please specify the command to execute after `--`, e.g. `kubectl exec my-pod -- ls`.",
+		},
+		{
+			name:    "Should forbid interactive exec",
+			command: "exec -it nginx -- ls",
+			expErr:  "Sorry, interactive `kubectl exec` sessions are not supported. Please remove the `-i` and `-t` flags.",
+		},
+		{
+			name:    "Should forbid not allowed exec command",
+			command: "exec nginx -- sh -c 'rm -rf /'",
+			expErr:  "Sorry, the 'sh' command cannot be executed in containers on cluster 'test'.",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			logger, _ := logtest.NewNullLogger()
+			cfg := fixCfgWithKubectlExecutor(t, fixStreamingKubectl(config.KubectlStreaming{}))
+			runner := &fakeStreamRunner{}
+			executor := NewKubectl(logger, cfg, kubectl.NewMerger(cfg.Executors), kubectl.NewChecker(nil), runner)
+
+			// when
+			_, err := executor.ExecuteStream(context.Background(), fixBindingsNames, tc.command, true, identity.Identity{}, nil, nil)
+
+			// then
+			require.Error(t, err)
+			assert.True(t, IsExecutionCommandError(err))
+			assert.EqualError(t, err, tc.expErr)
+			assert.Nil(t, runner.gotArgs)
+		})
+	}
+}
+
+func fixStreamingKubectl(streaming config.KubectlStreaming) config.Kubectl {
+	return config.Kubectl{
+		Enabled: true,
+		Namespaces: config.Namespaces{
+			Include: []string{"default"},
+		},
+		Commands: config.Commands{
+			Verbs:     []string{"logs", "exec", "get"},
+			Resources: []string{"pods"},
+		},
+		Exec: config.KubectlExec{
+			AllowedCommands: []string{"ls"},
+		},
+		Streaming: streaming,
+	}
+}
+
+type fakeStreamRunner struct {
+	chunks []string
+	// block blocks the runner until the context is done, as it's for the `--follow` flag.
+	block   bool
+	gotArgs []string
+}
+
+func (r *fakeStreamRunner) RunCombinedOutput(_ string, args []string) (string, error) {
+	r.gotArgs = args
+	return strings.Join(r.chunks, ""), nil
+}
+
+func (r *fakeStreamRunner) RunCombinedOutputStream(ctx context.Context, _ string, args []string, handleChunk OutputChunkHandler) error {
+	r.gotArgs = args
+	for _, chunk := range r.chunks {
+		if ctx.Err() != nil {
+			return errors.New("signal: killed")
+		}
+		handleChunk(chunk)
+	}
+	if r.block {
+		<-ctx.Done()
+		return errors.New("signal: killed")
+	}
+	return nil
+}
@@ -1,8 +1,12 @@
 package execute
 
 import (
+	"context"
+	"errors"
+	"io"
 	"strings"
 	"testing"
+	"time"
 
 	logtest "github.com/sirupsen/logrus/hooks/test"
 	"github.com/stretchr/testify/assert"
@@ -613,6 +617,80 @@ func TestKubectlGetArgsWithoutAlias(t *testing.T) {
 
 var fixBindingsNames = []string{"default"}
 
+func TestKubectlExecuteCommandTimeout(t *testing.T) {
+	// given
+	tests := []struct {
+		name string
+
+		settingsTimeout time.Duration
+		kubectlTimeout  time.Duration
+		runner          *fakeContextRunner
+
+		expOut string
+		expErr string
+	}{
+		{
+			name:            "Should stop command after default timeout",
+			settingsTimeout: 10 * time.Millisecond,
+			runner:          &fakeContextRunner{block: true},
+			expErr:          "Sorry, the command timed out after 10ms on cluster 'test'.",
+		},
+		{
+			name:            "Should prefer executor timeout",
+			settingsTimeout: time.Hour,
+			kubectlTimeout:  20 * time.Millisecond,
+			runner:          &fakeContextRunner{block: true},
+			expErr:          "Sorry, the command timed out after 20ms on cluster 'test'.",
+		},
+		{
+			name:            "Should return output of command finished on time",
+			settingsTimeout: time.Minute,
+			runner:          &fakeContextRunner{out: "NAME    READY\n"},
+			expOut:          "NAME    READY\n",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			logger, _ := logtest.NewNullLogger()
+			kcCfg := fixStreamingKubectl(config.KubectlStreaming{})
+			kcCfg.CommandTimeout = tc.kubectlTimeout
+			cfg := fixCfgWithKubectlExecutor(t, kcCfg)
+			cfg.Settings.CommandTimeout = tc.settingsTimeout
+			executor := NewKubectl(logger, cfg, kubectl.NewMerger(cfg.Executors), kubectl.NewChecker(nil), tc.runner)
+
+			// when
+			out, err := executor.Execute(fixBindingsNames, "get pods", true)
+
+			// then
+			if tc.expErr != "" {
+				require.Error(t, err)
+				assert.True(t, IsExecutionCommandError(err))
+				assert.EqualError(t, err, tc.expErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expOut, out)
+			assert.Equal(t, []string{"-n", "default", "get", "pods"}, tc.runner.gotArgs)
+		})
+	}
+}
+
+func TestKubectlExecuteWithoutCommandTimeout(t *testing.T) {
+	// given
+	logger, _ := logtest.NewNullLogger()
+	cfg := fixCfgWithKubectlExecutor(t, fixStreamingKubectl(config.KubectlStreaming{}))
+	runner := &fakeContextRunner{out: "NAME    READY\n"}
+	executor := NewKubectl(logger, cfg, kubectl.NewMerger(cfg.Executors), kubectl.NewChecker(nil), runner)
+
+	// when
+	out, err := executor.Execute(fixBindingsNames, "get pods", true)
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, "NAME    READY\n", out)
+	assert.False(t, runner.calledWithContext, "command without timeout must not be run with context")
+}
+
 func fixCfgWithKubectlExecutor(t *testing.T, executor config.Kubectl) config.Config {
 	t.Helper()
 
@@ -634,3 +712,27 @@ type cmdCombinedFunc func(command string, args []string) (string, error)
 func (f cmdCombinedFunc) RunCombinedOutput(command string, args []string) (string, error) {
 	return f(command, args)
 }
+
+// fakeContextRunner returns a given output. If block is set, it blocks until the context is done, as a hanging command.
+type fakeContextRunner struct {
+	out   string
+	block bool
+
+	gotArgs           []string
+	calledWithContext bool
+}
+
+func (r *fakeContextRunner) RunCombinedOutput(_ string, args []string) (string, error) {
+	r.gotArgs = args
+	return r.out, nil
+}
+
+func (r *fakeContextRunner) RunCombinedOutputContext(ctx context.Context, _ string, args []string, _ io.Reader) (string, error) {
+	r.gotArgs = args
+	r.calledWithContext = true
+	if r.block {
+		<-ctx.Done()
+		return "", errors.New("signal: killed")
+	}
+	return r.out, nil
+}
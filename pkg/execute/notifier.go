@@ -5,18 +5,19 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/sirupsen/logrus"
 	"gopkg.in/yaml.v3"
 
+	"github.com/kubeshop/botkube/pkg/bot/interactive"
 	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/format"
+	"github.com/kubeshop/botkube/pkg/mute"
 )
 
 const (
-	notifierStartMsgFmt                = "Brace yourselves, incoming notifications from cluster '%s'."
-	notifierStopMsgFmt                 = "Sure! I won't send you notifications from cluster '%s' here."
-	notifierStatusMsgFmt               = "Notifications from cluster '%s' are %s here."
-	notifierNotConfiguredMsgFmt        = "I'm not configured to send notifications here ('%s') from cluster '%s', so you cannot turn them on or off."
 	notifierPersistenceNotSupportedFmt = "Platform %q doesn't support persistence for notifications. When Botkube Pod restarts, default notification settings will be applied for this platform."
 )
 
@@ -31,6 +32,28 @@ type NotifierHandler interface {
 	BotName() string
 }
 
+// NotificationSnoozer handles snoozing notifications for a given communication platform.
+type NotificationSnoozer interface {
+	// SnoozeNotifications disables notifications for a given conversation ID until a given time.
+	// Once the snooze ends, the notifications are enabled again.
+	SnoozeNotifications(conversationID string, until time.Time) error
+
+	// NotificationsSnoozedUntil returns the snooze end time for a given conversation ID. It returns zero time if the notifications are not snoozed.
+	NotificationsSnoozedUntil(conversationID string) time.Time
+}
+
+// NotificationMuter handles muting notifications about given resources or namespaces for a given communication platform.
+type NotificationMuter interface {
+	// MuteNotifications suppresses notifications matching a given rule for a given conversation ID.
+	MuteNotifications(conversationID string, rule mute.Rule) error
+
+	// UnmuteNotifications removes the rule for the same target as a given rule. It returns false if there was no such rule.
+	UnmuteNotifications(conversationID string, rule mute.Rule) bool
+
+	// NotificationMutes returns active mute rules for a given conversation ID.
+	NotificationMutes(conversationID string) []mute.Rule
+}
+
 var (
 	// ErrNotificationsNotConfigured describes an error when user wants to toggle on/off the notifications for not configured channel.
 	ErrNotificationsNotConfigured = errors.New("notifications not configured for this channel")
@@ -41,24 +64,30 @@ type NotifierExecutor struct {
 	log               logrus.FieldLogger
 	analyticsReporter AnalyticsReporter
 	cfgManager        ConfigPersistenceManager
+	localizer         *interactive.Localizer
 
 	// Used for deprecated showControllerConfig function.
-	cfg config.Config
+	cfgMutex sync.RWMutex
+	cfg      config.Config
+
+	now func() time.Time
 }
 
 // NewNotifierExecutor creates a new instance of NotifierExecutor.
-func NewNotifierExecutor(log logrus.FieldLogger, cfg config.Config, cfgManager ConfigPersistenceManager, analyticsReporter AnalyticsReporter) *NotifierExecutor {
+func NewNotifierExecutor(log logrus.FieldLogger, cfg config.Config, cfgManager ConfigPersistenceManager, analyticsReporter AnalyticsReporter, localizer *interactive.Localizer) *NotifierExecutor {
 	return &NotifierExecutor{
 		log:               log,
 		cfg:               cfg,
 		cfgManager:        cfgManager,
 		analyticsReporter: analyticsReporter,
+		localizer:         localizer,
+		now:               time.Now,
 	}
 }
 
 // Do executes a given Notifier command based on args.
 func (e *NotifierExecutor) Do(ctx context.Context, args []string, commGroupName string, platform config.CommPlatformIntegration, conversation Conversation, clusterName string, handler NotifierHandler) (string, error) {
-	if len(args) != 2 {
+	if !isValidNotifierArgsCount(args) {
 		return "", errInvalidCommand
 	}
 
@@ -82,13 +111,13 @@ func (e *NotifierExecutor) Do(ctx context.Context, args []string, commGroupName
 		err := handler.SetNotificationsEnabled(conversation.ID, enabled)
 		if err != nil {
 			if errors.Is(err, ErrNotificationsNotConfigured) {
-				return fmt.Sprintf(notifierNotConfiguredMsgFmt, conversation.ID, clusterName), nil
+				return e.localizer.Sprintf(conversation.Locale, interactive.NotifierNotConfiguredMsg, conversation.ID, clusterName), nil
 			}
 
 			return "", fmt.Errorf("while setting notifications to %t: %w", enabled, err)
 		}
 
-		successMessage := fmt.Sprintf(notifierStartMsgFmt, clusterName)
+		successMessage := e.localizer.Sprintf(conversation.Locale, interactive.NotifierStartMsg, clusterName)
 		err = e.cfgManager.PersistNotificationsEnabled(ctx, commGroupName, platform, conversation.Alias, enabled)
 		if err != nil {
 			if err == config.ErrUnsupportedPlatform {
@@ -105,13 +134,13 @@ func (e *NotifierExecutor) Do(ctx context.Context, args []string, commGroupName
 		err := handler.SetNotificationsEnabled(conversation.ID, enabled)
 		if err != nil {
 			if errors.Is(err, ErrNotificationsNotConfigured) {
-				return fmt.Sprintf(notifierNotConfiguredMsgFmt, conversation.ID, clusterName), nil
+				return e.localizer.Sprintf(conversation.Locale, interactive.NotifierNotConfiguredMsg, conversation.ID, clusterName), nil
 			}
 
 			return "", fmt.Errorf("while setting notifications to %t: %w", enabled, err)
 		}
 
-		successMessage := fmt.Sprintf(notifierStopMsgFmt, clusterName)
+		successMessage := e.localizer.Sprintf(conversation.Locale, interactive.NotifierStopMsg, clusterName)
 		err = e.cfgManager.PersistNotificationsEnabled(ctx, commGroupName, platform, conversation.Alias, enabled)
 		if err != nil {
 			if err == config.ErrUnsupportedPlatform {
@@ -123,15 +152,115 @@ func (e *NotifierExecutor) Do(ctx context.Context, args []string, commGroupName
 		}
 
 		return successMessage, nil
+	case Snooze:
+		snoozer, ok := handler.(NotificationSnoozer)
+		if !ok {
+			return e.localizer.Sprintf(conversation.Locale, interactive.NotifierSnoozeNotSupported), nil
+		}
+
+		duration, err := time.ParseDuration(args[2])
+		if err != nil || duration <= 0 {
+			return e.localizer.Sprintf(conversation.Locale, interactive.NotifierSnoozeInvalidMsg, args[2]), nil
+		}
+
+		until := e.now().Add(duration)
+		err = snoozer.SnoozeNotifications(conversation.ID, until)
+		if err != nil {
+			if errors.Is(err, ErrNotificationsNotConfigured) {
+				return e.localizer.Sprintf(conversation.Locale, interactive.NotifierNotConfiguredMsg, conversation.ID, clusterName), nil
+			}
+
+			return "", fmt.Errorf("while snoozing notifications: %w", err)
+		}
+
+		successMessage := e.localizer.Sprintf(conversation.Locale, interactive.NotifierSnoozeMsg, clusterName, format.Duration(duration))
+		err = e.cfgManager.PersistNotificationsSnoozed(ctx, commGroupName, platform, conversation.Alias, until)
+		if err != nil {
+			if err == config.ErrUnsupportedPlatform {
+				e.log.Warnf(notifierPersistenceNotSupportedFmt, platform)
+				return successMessage, nil
+			}
+
+			return "", fmt.Errorf("while persisting configuration: %w", err)
+		}
+
+		return successMessage, nil
+	case Mute:
+		muter, ok := handler.(NotificationMuter)
+		if !ok {
+			return e.localizer.Sprintf(conversation.Locale, interactive.NotifierMuteNotSupported), nil
+		}
+
+		rule, duration, err := parseMuteRule(args[2:])
+		if err != nil {
+			return e.localizer.Sprintf(conversation.Locale, interactive.NotifierMuteInvalidMsg, strings.Join(args[2:], " ")), nil
+		}
+		if duration > 0 {
+			rule.Until = e.now().Add(duration)
+		}
+
+		err = muter.MuteNotifications(conversation.ID, rule)
+		if err != nil {
+			if errors.Is(err, ErrNotificationsNotConfigured) {
+				return e.localizer.Sprintf(conversation.Locale, interactive.NotifierNotConfiguredMsg, conversation.ID, clusterName), nil
+			}
+
+			return "", fmt.Errorf("while muting notifications: %w", err)
+		}
+
+		if duration == 0 {
+			return e.localizer.Sprintf(conversation.Locale, interactive.NotifierMuteUntilUnmuted, rule, clusterName), nil
+		}
+		return e.localizer.Sprintf(conversation.Locale, interactive.NotifierMuteMsg, rule, clusterName, format.Duration(duration)), nil
+	case Unmute:
+		muter, ok := handler.(NotificationMuter)
+		if !ok {
+			return e.localizer.Sprintf(conversation.Locale, interactive.NotifierMuteNotSupported), nil
+		}
+
+		rule, duration, err := parseMuteRule(args[2:])
+		if err != nil || duration != 0 {
+			return e.localizer.Sprintf(conversation.Locale, interactive.NotifierMuteInvalidMsg, strings.Join(args[2:], " ")), nil
+		}
+
+		if !muter.UnmuteNotifications(conversation.ID, rule) {
+			return e.localizer.Sprintf(conversation.Locale, interactive.NotifierUnmuteNotFoundMsg, rule, clusterName), nil
+		}
+		return e.localizer.Sprintf(conversation.Locale, interactive.NotifierUnmuteMsg, rule, clusterName), nil
+	case Mutes:
+		muter, ok := handler.(NotificationMuter)
+		if !ok {
+			return e.localizer.Sprintf(conversation.Locale, interactive.NotifierMuteNotSupported), nil
+		}
+
+		rules := muter.NotificationMutes(conversation.ID)
+		if len(rules) == 0 {
+			return e.localizer.Sprintf(conversation.Locale, interactive.NotifierMutesEmptyMsg, clusterName), nil
+		}
+
+		items := make([]string, 0, len(rules))
+		for _, rule := range rules {
+			item := rule.String()
+			if !rule.Until.IsZero() {
+				remaining := rule.Until.Sub(e.now()).Round(time.Second)
+				item = e.localizer.Sprintf(conversation.Locale, interactive.NotifierMutesItemExpiring, item, format.Duration(remaining))
+			}
+			items = append(items, fmt.Sprintf("• %s", item))
+		}
+		return e.localizer.Sprintf(conversation.Locale, interactive.NotifierMutesMsg, clusterName, strings.Join(items, "\n")), nil
 	case Status:
 		enabled := handler.NotificationsEnabled(conversation.ID)
 
-		enabledStr := "enabled"
+		enabledKey := interactive.NotifierStatusEnabled
 		if !enabled {
-			enabledStr = "disabled"
+			enabledKey = interactive.NotifierStatusDisabled
 		}
 
-		return fmt.Sprintf(notifierStatusMsgFmt, clusterName, enabledStr), nil
+		enabledStr := e.localizer.Sprintf(conversation.Locale, enabledKey)
+		if remaining := e.snoozeRemaining(handler, conversation.ID); remaining > 0 {
+			enabledStr = e.localizer.Sprintf(conversation.Locale, interactive.NotifierStatusSnoozed, format.Duration(remaining))
+		}
+		return e.localizer.Sprintf(conversation.Locale, interactive.NotifierStatusMsg, clusterName, enabledStr), nil
 	case ShowConfig:
 		out, err := e.showControllerConfig()
 		if err != nil {
@@ -146,26 +275,154 @@ func (e *NotifierExecutor) Do(ctx context.Context, args []string, commGroupName
 	return "", errUnsupportedCommand
 }
 
+// isValidNotifierArgsCount returns true if the number of arguments, including the `notifier` keyword and verb, is valid for a given verb.
+func isValidNotifierArgsCount(args []string) bool {
+	if len(args) < 2 {
+		return false
+	}
+
+	minArgs, maxArgs := 2, 2
+	switch NotifierAction(strings.ToLower(args[1])) {
+	case Snooze:
+		minArgs, maxArgs = 3, 3
+	case Mute:
+		minArgs, maxArgs = 3, 5
+	case Unmute:
+		minArgs, maxArgs = 3, 4
+	}
+	return len(args) >= minArgs && len(args) <= maxArgs
+}
+
+// parseMuteRule parses the mute target and optional duration, e.g. `deployment/payments-api 1h` or `ns staging`.
+// It returns zero duration if it wasn't specified.
+func parseMuteRule(args []string) (mute.Rule, time.Duration, error) {
+	if len(args) == 0 {
+		return mute.Rule{}, 0, errors.New("missing mute target")
+	}
+
+	var (
+		rule mute.Rule
+		rest []string
+	)
+	switch strings.ToLower(args[0]) {
+	case "ns", "namespace":
+		if len(args) < 2 {
+			return mute.Rule{}, 0, errors.New("missing namespace name")
+		}
+		rule = mute.Rule{Namespace: args[1]}
+		rest = args[2:]
+	default:
+		kind, name, found := strings.Cut(args[0], "/")
+		if !found || kind == "" || name == "" {
+			return mute.Rule{}, 0, fmt.Errorf("invalid resource %q, expected <kind>/<name>", args[0])
+		}
+		rule = mute.Rule{Kind: kind, Name: name}
+		rest = args[1:]
+	}
+
+	switch len(rest) {
+	case 0:
+		return rule, 0, nil
+	case 1:
+		duration, err := time.ParseDuration(rest[0])
+		if err != nil {
+			return mute.Rule{}, 0, fmt.Errorf("while parsing duration: %w", err)
+		}
+		if duration <= 0 {
+			return mute.Rule{}, 0, fmt.Errorf("duration %q must be positive", rest[0])
+		}
+		return rule, duration, nil
+	default:
+		return mute.Rule{}, 0, errors.New("too many arguments")
+	}
+}
+
+// snoozeRemaining returns the remaining snooze time, rounded to seconds. It returns zero if the notifications are not snoozed.
+func (e *NotifierExecutor) snoozeRemaining(handler NotifierHandler, conversationID string) time.Duration {
+	snoozer, ok := handler.(NotificationSnoozer)
+	if !ok {
+		return 0
+	}
+
+	until := snoozer.NotificationsSnoozedUntil(conversationID)
+	return until.Sub(e.now()).Round(time.Second)
+}
+
 const redactedSecretStr = "*** REDACTED ***"
 
+// ReloadConfig applies the reloaded configuration, so the current one is shown.
+func (e *NotifierExecutor) ReloadConfig(cfg config.Config) {
+	e.cfgMutex.Lock()
+	defer e.cfgMutex.Unlock()
+	e.cfg = cfg
+}
+
 // Deprecated: this function doesn't fit in the scope of notifier. It was moved from legacy reasons, but it will be removed in future.
 func (e *NotifierExecutor) showControllerConfig() (string, error) {
+	e.cfgMutex.RLock()
 	cfg := e.cfg
+	e.cfgMutex.RUnlock()
 
 	// hide sensitive info
 	// TODO: avoid printing sensitive data without need to resetting them manually (which is an error-prone approach)
+	// The communications are copied, as the map is shared with the configuration used by other components.
+	communications := make(map[string]config.Communications, len(cfg.Communications))
 	for key, old := range cfg.Communications {
 		old.Slack.Token = redactedSecretStr
 		old.SocketSlack.AppToken = redactedSecretStr
 		old.SocketSlack.BotToken = redactedSecretStr
+		workspaces := make([]config.SocketSlackWorkspace, 0, len(old.SocketSlack.Workspaces))
+		for _, workspace := range old.SocketSlack.Workspaces {
+			workspace.AppToken = redactedSecretStr
+			workspace.BotToken = redactedSecretStr
+			workspaces = append(workspaces, workspace)
+		}
+		old.SocketSlack.Workspaces = workspaces
 		old.Elasticsearch.Password = redactedSecretStr
 		old.Discord.Token = redactedSecretStr
 		old.Mattermost.Token = redactedSecretStr
+		old.RocketChat.Token = redactedSecretStr
+		old.GoogleChat.Credentials = redactedSecretStr
+		old.Webex.Token = redactedSecretStr
+		old.Webex.WebhookSecret = redactedSecretStr
+		old.Matrix.AccessToken = redactedSecretStr
 		old.Teams.AppPassword = redactedSecretStr
+		routingKeys := make(map[string]string, len(old.PagerDuty.RoutingKeys))
+		for source := range old.PagerDuty.RoutingKeys {
+			routingKeys[source] = redactedSecretStr
+		}
+		old.PagerDuty.RoutingKeys = routingKeys
+		old.Opsgenie.APIKey = redactedSecretStr
+		old.Kafka.Password = redactedSecretStr
+		old.AzureEventHub.ConnectionString = redactedSecretStr
+		old.Loki.Password = redactedSecretStr
+		old.Datadog.APIKey = redactedSecretStr
+		old.Jira.APIToken = redactedSecretStr
+		old.ServiceNow.Password = redactedSecretStr
+		old.Webhook.Signing.Secret = redactedSecretStr
+		webhookHeaders := make(map[string]string, len(old.Webhook.Headers))
+		for name := range old.Webhook.Headers {
+			webhookHeaders[name] = redactedSecretStr
+		}
+		old.Webhook.Headers = webhookHeaders
 
-		// maps are not addressable: https://stackoverflow.com/questions/42605337/cannot-assign-to-struct-field-in-a-map
-		cfg.Communications[key] = old
+		communications[key] = old
+	}
+	cfg.Communications = communications
+	cfg.Settings.SourceServer.BearerToken = redactedSecretStr
+	cfg.Settings.Hub.BearerToken = redactedSecretStr
+	hubAgents := make(map[string]config.HubAgent, len(cfg.Settings.Hub.Agents))
+	for cluster := range cfg.Settings.Hub.Agents {
+		hubAgents[cluster] = config.HubAgent{BearerToken: redactedSecretStr}
+	}
+	cfg.Settings.Hub.Agents = hubAgents
+	cfg.Settings.Secrets.Vault.Token = redactedSecretStr
+	cfg.Settings.CommandAudit.Elasticsearch.Password = redactedSecretStr
+	auditHeaders := make(map[string]string, len(cfg.Settings.CommandAudit.Webhook.Headers))
+	for name := range cfg.Settings.CommandAudit.Webhook.Headers {
+		auditHeaders[name] = redactedSecretStr
 	}
+	cfg.Settings.CommandAudit.Webhook.Headers = auditHeaders
 
 	b, err := yaml.Marshal(cfg)
 	if err != nil {
@@ -3,13 +3,16 @@ package execute
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/MakeNowJust/heredoc"
 	logtest "github.com/sirupsen/logrus/hooks/test"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/kubeshop/botkube/pkg/bot/interactive"
 	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/mute"
 )
 
 func TestNotifierExecutor_Do_Success(t *testing.T) {
@@ -25,6 +28,8 @@ func TestNotifierExecutor_Do_Success(t *testing.T) {
 			ClusterName: "foo",
 		},
 	}
+	localizer, err := interactive.NewLocalizer()
+	require.NoError(t, err)
 
 	testCases := []struct {
 		Name                 string
@@ -45,6 +50,16 @@ func TestNotifierExecutor_Do_Success(t *testing.T) {
 			ExpectedResult:      `Brace yourselves, incoming notifications from cluster 'cluster-name'.`,
 			ExpectedStatusAfter: `Notifications from cluster 'cluster-name' are enabled here.`,
 		},
+		{
+			Name:         "Start with channel locale",
+			InputArgs:    []string{"notifier", "start"},
+			Conversation: Conversation{Alias: channelAlias, ID: "conv-id", Locale: "de-AT"},
+			InputNotifierHandler: &fakeNotifierHandler{
+				conf: map[string]bool{"conv-id": false},
+			},
+			ExpectedResult:      `Macht euch bereit, Benachrichtigungen vom Cluster 'cluster-name' sind unterwegs.`,
+			ExpectedStatusAfter: `Benachrichtigungen vom Cluster 'cluster-name' sind hier aktiviert.`,
+		},
 		{
 			Name:         "Start for non-configured channel",
 			InputArgs:    []string{"notifier", "start"},
@@ -75,6 +90,56 @@ func TestNotifierExecutor_Do_Success(t *testing.T) {
 			ExpectedResult:      `I'm not configured to send notifications here ('non-existing') from cluster 'cluster-name', so you cannot turn them on or off.`,
 			ExpectedStatusAfter: `Notifications from cluster 'cluster-name' are disabled here.`,
 		},
+		{
+			Name:         "Snooze",
+			Conversation: Conversation{Alias: channelAlias, ID: "conv-id"},
+			InputArgs:    []string{"notifier", "snooze", "2h"},
+			InputNotifierHandler: &fakeSnoozingNotifierHandler{
+				fakeNotifierHandler: fakeNotifierHandler{conf: map[string]bool{"conv-id": false}},
+			},
+			ExpectedResult:      `Sure! I won't send you notifications from cluster 'cluster-name' here for 2h.`,
+			ExpectedStatusAfter: `Notifications from cluster 'cluster-name' are snoozed for 2h here.`,
+		},
+		{
+			Name:         "Snooze with channel locale",
+			Conversation: Conversation{Alias: channelAlias, ID: "conv-id", Locale: "de"},
+			InputArgs:    []string{"notifier", "snooze", "90m"},
+			InputNotifierHandler: &fakeSnoozingNotifierHandler{
+				fakeNotifierHandler: fakeNotifierHandler{conf: map[string]bool{"conv-id": true}},
+			},
+			ExpectedResult:      `Alles klar! Ich sende hier für 1h30m keine Benachrichtigungen vom Cluster 'cluster-name'.`,
+			ExpectedStatusAfter: `Benachrichtigungen vom Cluster 'cluster-name' sind hier für 1h30m pausiert.`,
+		},
+		{
+			Name:         "Snooze with invalid duration",
+			Conversation: Conversation{Alias: channelAlias, ID: "conv-id"},
+			InputArgs:    []string{"notifier", "snooze", "-5m"},
+			InputNotifierHandler: &fakeSnoozingNotifierHandler{
+				fakeNotifierHandler: fakeNotifierHandler{conf: map[string]bool{"conv-id": true}},
+			},
+			ExpectedResult:      `Invalid snooze duration '-5m'. Please use a positive duration, e.g. '30m' or '2h'.`,
+			ExpectedStatusAfter: `Notifications from cluster 'cluster-name' are enabled here.`,
+		},
+		{
+			Name:         "Snooze for non-configured channel",
+			Conversation: Conversation{Alias: channelAlias, ID: "non-existing"},
+			InputArgs:    []string{"notifier", "snooze", "2h"},
+			InputNotifierHandler: &fakeSnoozingNotifierHandler{
+				fakeNotifierHandler: fakeNotifierHandler{conf: map[string]bool{"conv-id": true}},
+			},
+			ExpectedResult:      `I'm not configured to send notifications here ('non-existing') from cluster 'cluster-name', so you cannot turn them on or off.`,
+			ExpectedStatusAfter: `Notifications from cluster 'cluster-name' are disabled here.`,
+		},
+		{
+			Name:         "Snooze not supported",
+			Conversation: Conversation{Alias: channelAlias, ID: "conv-id"},
+			InputArgs:    []string{"notifier", "snooze", "2h"},
+			InputNotifierHandler: &fakeNotifierHandler{
+				conf: map[string]bool{"conv-id": true},
+			},
+			ExpectedResult:      `Snoozing notifications is not supported for this platform.`,
+			ExpectedStatusAfter: `Notifications from cluster 'cluster-name' are enabled here.`,
+		},
 		{
 			Name:                 "Show config",
 			Conversation:         Conversation{Alias: channelAlias, ID: "conv-id"},
@@ -91,6 +156,8 @@ func TestNotifierExecutor_Do_Success(t *testing.T) {
 				    kubernetes:
 				        objectAnnotationChecker: false
 				        nodeEventsChecker: false
+				aliases: {}
+				schedules: {}
 				analytics:
 				    disable: false
 				settings:
@@ -109,15 +176,119 @@ func TestNotifierExecutor_Do_Success(t *testing.T) {
 				        enabled: false
 				        port: 0
 				        deployment: {}
+				    sourceServer:
+				        enabled: false
+				        port: 0
+				        bearerToken: '*** REDACTED ***'
+				    plugins:
+				        directory: ""
+				        repositories: {}
+				    middlewares:
+				        rateLimit:
+				            enabled: false
+				            maxCommands: 0
+				            interval: 0s
+				        audit:
+				            enabled: false
+				        queue:
+				            enabled: false
+				            maxConcurrent: 0
+				            maxConcurrentPerChannel: 0
+				            maxQueued: 0
+				            timeout: 0s
+				    locales:
+				        catalogsDir: ""
+				    identity:
+				        enabled: false
+				        users: []
+				        emailLookup:
+				            enabled: false
+				            usernamePrefix: ""
+				            groups: []
+				        impersonation:
+				            enabled: false
+				            requireIdentity: false
+				    sinkRetry:
+				        enabled: false
+				        maxAttempts: 0
+				        initialBackoff: 0s
+				        maxBackoff: 0s
+				        queueSize: 0
+				        persistence:
+				            enabled: false
+				            dir: ""
+				            maxSizeBytes: 0
+				    redaction:
+				        enabled: false
+				        mask: ""
+				        rules: []
+				    commandAudit:
+				        enabled: false
+				        listLimit: 0
+				        saveTimeout: 0s
+				        configMap:
+				            enabled: false
+				            name: ""
+				            namespace: ""
+				            maxEntries: 0
+				        file:
+				            enabled: false
+				            path: ""
+				        webhook:
+				            enabled: false
+				            url: ""
+				            headers: {}
+				        elasticsearch:
+				            enabled: false
+				            server: ""
+				            username: ""
+				            password: '*** REDACTED ***'
+				            index: ""
+				    commandConfirmation:
+				        enabled: false
+				        commands: []
+				        timeout: 0s
+				    commandSchedule:
+				        enabled: false
+				        maxPerChannel: 0
+				        timezone: ""
+				    deduplication:
+				        enabled: false
+				        window: 0s
+				    hub:
+				        mode: ""
+				        port: 0
+				        url: ""
+				        bearerToken: '*** REDACTED ***'
+				        agents: {}
+				        executorBindings: []
+				        commandTimeout: 0s
+				    customResources:
+				        enabled: false
+				    secrets:
+				        rotationCheckInterval: 0s
+				        vault:
+				            address: ""
+				            token: '*** REDACTED ***'
+				            kubernetesAuth:
+				                role: ""
+				                mountPath: ""
+				                tokenPath: ""
+				        awsSecretsManager:
+				            region: ""
+				            roleArn: ""
 				    log:
 				        level: ""
 				        disableColors: false
 				    informersResyncPeriod: 0s
+				    notifierTimeout: 0s
+				    commandTimeout: 0s
 				    kubeconfig: ""
 				configWatcher:
 				    enabled: false
 				    initialSyncTimeout: 0s
 				    tmpDir: ""
+				    hotReload: false
 			`),
 			ExpectedStatusAfter: `Notifications from cluster 'cluster-name' are disabled here.`,
 		},
@@ -131,6 +302,21 @@ func TestNotifierExecutor_Do_Success(t *testing.T) {
 			InputArgs:            []string{"notifier"},
 			ExpectedErrorMessage: "invalid command",
 		},
+		{
+			Name:                 "Snooze without duration",
+			InputArgs:            []string{"notifier", "snooze"},
+			ExpectedErrorMessage: "invalid command",
+		},
+		{
+			Name:                 "Mute without target",
+			InputArgs:            []string{"notifier", "mute"},
+			ExpectedErrorMessage: "invalid command",
+		},
+		{
+			Name:                 "Duration for other verb",
+			InputArgs:            []string{"notifier", "stop", "2h"},
+			ExpectedErrorMessage: "invalid command",
+		},
 		{
 			Name:                 "Invalid command 2",
 			InputArgs:            []string{"notifier", "stop", "stop", "stop", "please", "stop!!!!1111111oneoneone"},
@@ -140,7 +326,7 @@ func TestNotifierExecutor_Do_Success(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.Name, func(t *testing.T) {
-			e := NewNotifierExecutor(log, cfg, &fakeCfgPersistenceManager{expectedAlias: channelAlias}, &fakeAnalyticsReporter{})
+			e := NewNotifierExecutor(log, cfg, &fakeCfgPersistenceManager{expectedAlias: channelAlias}, &fakeAnalyticsReporter{}, localizer)
 
 			// execute command
 
@@ -171,6 +357,130 @@ func TestNotifierExecutor_Do_Success(t *testing.T) {
 	}
 }
 
+func TestNotifierExecutor_Do_Mute(t *testing.T) {
+	// given
+	log, _ := logtest.NewNullLogger()
+	platform := config.SlackCommPlatformIntegration
+	conversation := Conversation{Alias: "alias", ID: "conv-id"}
+	localizer, err := interactive.NewLocalizer()
+	require.NoError(t, err)
+
+	now := time.Date(2022, 10, 10, 12, 0, 0, 0, time.UTC)
+	e := NewNotifierExecutor(log, config.Config{}, &fakeCfgPersistenceManager{expectedAlias: conversation.Alias}, &fakeAnalyticsReporter{}, localizer)
+	e.now = func() time.Time { return now }
+
+	handler := &fakeMutingNotifierHandler{
+		fakeNotifierHandler: fakeNotifierHandler{conf: map[string]bool{"conv-id": true}},
+	}
+
+	steps := []struct {
+		Name      string
+		InputArgs []string
+		Conv      *Conversation
+
+		ExpectedResult string
+	}{
+		{
+			Name:           "No mutes",
+			InputArgs:      []string{"notifier", "mutes"},
+			ExpectedResult: `There are no muted notifications from cluster 'cluster-name' here.`,
+		},
+		{
+			Name:           "Mute resource",
+			InputArgs:      []string{"notifier", "mute", "deployment/payments-api", "1h"},
+			ExpectedResult: `Sure! I won't send you notifications about deployment/payments-api from cluster 'cluster-name' here for 1h.`,
+		},
+		{
+			Name:           "Mute namespace",
+			InputArgs:      []string{"notifier", "mute", "ns", "staging"},
+			ExpectedResult: `Sure! I won't send you notifications about namespace staging from cluster 'cluster-name' here until you unmute them.`,
+		},
+		{
+			Name:           "Mute namespace with channel locale",
+			InputArgs:      []string{"notifier", "mute", "namespace", "dev", "30m"},
+			Conv:           &Conversation{Alias: "alias", ID: "conv-id", Locale: "de"},
+			ExpectedResult: `Alles klar! Ich sende hier für 30m keine Benachrichtigungen zu namespace dev vom Cluster 'cluster-name'.`,
+		},
+		{
+			Name:      "List mutes",
+			InputArgs: []string{"notifier", "mutes"},
+			ExpectedResult: heredoc.Doc(`
+				Muted notifications from cluster 'cluster-name' here:
+				• deployment/payments-api (1h left)
+				• namespace staging
+				• namespace dev (30m left)`),
+		},
+		{
+			Name:           "Unmute namespace",
+			InputArgs:      []string{"notifier", "unmute", "ns", "staging"},
+			ExpectedResult: `Notifications about namespace staging from cluster 'cluster-name' are unmuted here.`,
+		},
+		{
+			Name:           "Unmute not muted resource",
+			InputArgs:      []string{"notifier", "unmute", "deployment/orders-api"},
+			ExpectedResult: `Notifications about deployment/orders-api from cluster 'cluster-name' are not muted here.`,
+		},
+		{
+			Name:           "Unmute with duration",
+			InputArgs:      []string{"notifier", "unmute", "deployment/payments-api", "1h"},
+			ExpectedResult: `Invalid mute target 'deployment/payments-api 1h'. Please use '<kind>/<name>' or 'ns <namespace>', optionally followed by a positive duration, e.g. 'deployment/payments-api 1h'.`,
+		},
+		{
+			Name:           "Mute invalid target",
+			InputArgs:      []string{"notifier", "mute", "payments-api", "1h"},
+			ExpectedResult: `Invalid mute target 'payments-api 1h'. Please use '<kind>/<name>' or 'ns <namespace>', optionally followed by a positive duration, e.g. 'deployment/payments-api 1h'.`,
+		},
+		{
+			Name:           "Mute with invalid duration",
+			InputArgs:      []string{"notifier", "mute", "ns", "staging", "-1h"},
+			ExpectedResult: `Invalid mute target 'ns staging -1h'. Please use '<kind>/<name>' or 'ns <namespace>', optionally followed by a positive duration, e.g. 'deployment/payments-api 1h'.`,
+		},
+		{
+			Name:           "Mute for non-configured channel",
+			InputArgs:      []string{"notifier", "mute", "ns", "staging"},
+			Conv:           &Conversation{Alias: "alias", ID: "non-existing"},
+			ExpectedResult: `I'm not configured to send notifications here ('non-existing') from cluster 'cluster-name', so you cannot turn them on or off.`,
+		},
+		{
+			Name:      "List mutes after changes",
+			InputArgs: []string{"notifier", "mutes"},
+			ExpectedResult: heredoc.Doc(`
+				Muted notifications from cluster 'cluster-name' here:
+				• deployment/payments-api (1h left)
+				• namespace dev (30m left)`),
+		},
+	}
+
+	for _, step := range steps {
+		conv := conversation
+		if step.Conv != nil {
+			conv = *step.Conv
+		}
+
+		// when
+		actual, err := e.Do(context.Background(), step.InputArgs, "comm-group", platform, conv, "cluster-name", handler)
+
+		// then
+		require.NoError(t, err, step.Name)
+		assert.Equal(t, step.ExpectedResult, actual, step.Name)
+	}
+}
+
+func TestNotifierExecutor_Do_MuteNotSupported(t *testing.T) {
+	// given
+	log, _ := logtest.NewNullLogger()
+	localizer, err := interactive.NewLocalizer()
+	require.NoError(t, err)
+	e := NewNotifierExecutor(log, config.Config{}, &fakeCfgPersistenceManager{}, &fakeAnalyticsReporter{}, localizer)
+
+	// when
+	actual, err := e.Do(context.Background(), []string{"notifier", "mute", "ns", "staging"}, "comm-group", config.SlackCommPlatformIntegration, Conversation{ID: "conv-id"}, "cluster-name", &fakeNotifierHandler{})
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, `Muting notifications is not supported for this platform.`, actual)
+}
+
 type fakeNotifierHandler struct {
 	conf map[string]bool
 }
@@ -197,3 +507,58 @@ func (f *fakeNotifierHandler) SetNotificationsEnabled(convID string, enabled boo
 func (f *fakeNotifierHandler) BotName() string {
 	return "fake"
 }
+
+type fakeSnoozingNotifierHandler struct {
+	fakeNotifierHandler
+	snoozedUntil map[string]time.Time
+}
+
+func (f *fakeSnoozingNotifierHandler) SnoozeNotifications(convID string, until time.Time) error {
+	_, exists := f.conf[convID]
+	if !exists {
+		return ErrNotificationsNotConfigured
+	}
+
+	if f.snoozedUntil == nil {
+		f.snoozedUntil = map[string]time.Time{}
+	}
+	f.conf[convID] = false
+	f.snoozedUntil[convID] = until
+	return nil
+}
+
+func (f *fakeSnoozingNotifierHandler) NotificationsSnoozedUntil(convID string) time.Time {
+	return f.snoozedUntil[convID]
+}
+
+type fakeMutingNotifierHandler struct {
+	fakeNotifierHandler
+	rules map[string][]mute.Rule
+}
+
+func (f *fakeMutingNotifierHandler) MuteNotifications(convID string, rule mute.Rule) error {
+	_, exists := f.conf[convID]
+	if !exists {
+		return ErrNotificationsNotConfigured
+	}
+
+	if f.rules == nil {
+		f.rules = map[string][]mute.Rule{}
+	}
+	f.rules[convID] = append(f.rules[convID], rule)
+	return nil
+}
+
+func (f *fakeMutingNotifierHandler) UnmuteNotifications(convID string, rule mute.Rule) bool {
+	for i, existing := range f.rules[convID] {
+		if existing.String() == rule.String() {
+			f.rules[convID] = append(f.rules[convID][:i], f.rules[convID][i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+func (f *fakeMutingNotifierHandler) NotificationMutes(convID string) []mute.Rule {
+	return f.rules[convID]
+}
@@ -0,0 +1,348 @@
+package plugin
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v3"
+
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+const (
+	// DefaultDirectory is used when the plugins directory is not configured.
+	DefaultDirectory = "/botkube/plugins"
+
+	// executorType is the type of the executor plugins in the repository index.
+	executorType = "executor"
+
+	defaultHTTPCliTimeout = 5 * time.Minute
+	defaultInitialBackoff = time.Second
+	defaultMaxBackoff     = time.Minute
+)
+
+// runner runs a plugin binary and executes commands with it.
+type runner interface {
+	Run(ctx context.Context) error
+	Execute(ctx context.Context, req Request) (Response, error)
+}
+
+// Manager installs the executor plugins enabled in executor bindings and supervises them as subprocesses.
+// Plugins which are not found in the plugins directory are downloaded from the configured repositories.
+// Plugins which stop are restarted with exponential backoff.
+type Manager struct {
+	log          logrus.FieldLogger
+	dir          string
+	repositories map[string]config.PluginsRepository
+	plugins      []string
+	httpCli      *http.Client
+
+	mu      sync.RWMutex
+	runners map[string]runner
+
+	newRunner      func(log logrus.FieldLogger, path string) runner
+	platform       IndexURLPlatform
+	initialBackoff time.Duration
+	maxBackoff     time.Duration
+}
+
+// NewManager returns a new Manager instance for the plugins enabled in executor bindings.
+func NewManager(log logrus.FieldLogger, cfg config.PluginsSettings, executors map[string]config.Executors) *Manager {
+	enabled := map[string]struct{}{}
+	for _, executor := range executors {
+		for name, plugin := range executor.Plugins {
+			if plugin.Enabled {
+				enabled[name] = struct{}{}
+			}
+		}
+	}
+
+	var plugins []string
+	for name := range enabled {
+		plugins = append(plugins, name)
+	}
+	sort.Strings(plugins)
+
+	dir := cfg.Directory
+	if dir == "" {
+		dir = DefaultDirectory
+	}
+
+	return &Manager{
+		log:          log,
+		dir:          dir,
+		repositories: cfg.Repositories,
+		plugins:      plugins,
+		httpCli:      &http.Client{Timeout: defaultHTTPCliTimeout},
+		runners:      map[string]runner{},
+		newRunner: func(log logrus.FieldLogger, path string) runner {
+			return NewProcess(log, path)
+		},
+		platform:       IndexURLPlatform{OS: runtime.GOOS, Architecture: runtime.GOARCH},
+		initialBackoff: defaultInitialBackoff,
+		maxBackoff:     defaultMaxBackoff,
+	}
+}
+
+// Enabled returns true if any executor binding has a plugin enabled.
+func (m *Manager) Enabled() bool {
+	return len(m.plugins) > 0
+}
+
+// Start installs the missing plugin binaries and runs the enabled plugins. It blocks until the context is cancelled.
+func (m *Manager) Start(ctx context.Context) error {
+	m.log.Infof("Starting executor plugins from %q...", m.dir)
+
+	paths, err := m.install(ctx)
+	if err != nil {
+		m.log.Errorf("Executor plugins are disabled: %s", err.Error())
+		return nil
+	}
+
+	var wg sync.WaitGroup
+	for _, name := range m.plugins {
+		path, found := paths[name]
+		if !found {
+			continue
+		}
+
+		log := m.log.WithField("plugin", name)
+		plugin := m.newRunner(log, path)
+		m.mu.Lock()
+		m.runners[name] = plugin
+		m.mu.Unlock()
+
+		wg.Add(1)
+		go func(plugin runner, log logrus.FieldLogger) {
+			defer wg.Done()
+			m.supervise(ctx, plugin, log)
+		}(plugin, log)
+	}
+
+	wg.Wait()
+	return nil
+}
+
+// Execute executes a given command with a given plugin. The returned error is not nil only if the plugin didn't respond.
+// It's ErrNotRunning if the plugin is not installed or it's being restarted.
+func (m *Manager) Execute(ctx context.Context, name string, cfg map[string]interface{}, command string, args []string) (Response, error) {
+	m.mu.RLock()
+	plugin, found := m.runners[name]
+	m.mu.RUnlock()
+	if !found {
+		return Response{}, ErrNotRunning
+	}
+
+	raw, err := json.Marshal(cfg)
+	if err != nil {
+		return Response{}, fmt.Errorf("while marshaling configuration of %q plugin: %w", name, err)
+	}
+	return plugin.Execute(ctx, Request{Command: command, Args: args, Config: raw})
+}
+
+// install returns paths of the enabled plugin binaries. The binaries not found in the plugins directory are downloaded.
+func (m *Manager) install(ctx context.Context) (map[string]string, error) {
+	paths, err := Discover(m.dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var missing []string
+	for _, name := range m.plugins {
+		if _, found := paths[name]; !found {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) == 0 {
+		return paths, nil
+	}
+
+	entries := m.fetchIndexEntries(ctx)
+	for _, name := range missing {
+		entry, found := entries[name]
+		if !found {
+			m.log.Errorf("Plugin %q not found in %q and in the configured repositories. Skipping...", name, m.dir)
+			continue
+		}
+
+		path, err := m.download(ctx, name, entry)
+		if err != nil {
+			m.log.Errorf("Cannot install %q plugin: %s. Skipping...", name, err.Error())
+			continue
+		}
+		m.log.Infof("Installed %q plugin %s.", name, entry.Version)
+		paths[name] = path
+	}
+	return paths, nil
+}
+
+// fetchIndexEntries returns the executor plugins listed in all repository indexes, indexed by the plugin name.
+// If a plugin is listed in multiple repositories, the entry from the first repository, sorted by name, is used.
+func (m *Manager) fetchIndexEntries(ctx context.Context) map[string]IndexEntry {
+	var repos []string
+	for name := range m.repositories {
+		repos = append(repos, name)
+	}
+	sort.Strings(repos)
+
+	out := map[string]IndexEntry{}
+	for _, repo := range repos {
+		index, err := m.fetchIndex(ctx, m.repositories[repo].URL)
+		if err != nil {
+			m.log.Errorf("Cannot fetch %q repository index: %s", repo, err.Error())
+			continue
+		}
+
+		for _, entry := range index.Entries {
+			if entry.Type != executorType {
+				continue
+			}
+			if _, exists := out[entry.Name]; exists {
+				continue
+			}
+			out[entry.Name] = entry
+		}
+	}
+	return out
+}
+
+func (m *Manager) fetchIndex(ctx context.Context, url string) (Index, error) {
+	body, err := m.get(ctx, url)
+	if err != nil {
+		return Index{}, err
+	}
+	defer body.Close()
+
+	var index Index
+	if err := yaml.NewDecoder(body).Decode(&index); err != nil {
+		return Index{}, fmt.Errorf("while decoding index: %w", err)
+	}
+	return index, nil
+}
+
+// download downloads the plugin binary for the current platform and verifies its checksum.
+// The binary is written to a temporary file first, so the plugins directory never contains a partially downloaded binary.
+func (m *Manager) download(ctx context.Context, name string, entry IndexEntry) (string, error) {
+	var binary *IndexURL
+	for idx := range entry.URLs {
+		if entry.URLs[idx].Platform == m.platform {
+			binary = &entry.URLs[idx]
+			break
+		}
+	}
+	if binary == nil {
+		return "", fmt.Errorf("binary for %s/%s platform not found", m.platform.OS, m.platform.Architecture)
+	}
+
+	if err := os.MkdirAll(m.dir, 0o750); err != nil {
+		return "", fmt.Errorf("while creating plugins directory: %w", err)
+	}
+
+	body, err := m.get(ctx, binary.URL)
+	if err != nil {
+		return "", err
+	}
+	defer body.Close()
+
+	tmp, err := os.CreateTemp(m.dir, fmt.Sprintf(".%s%s-*", BinaryPrefix, name))
+	if err != nil {
+		return "", fmt.Errorf("while creating temporary file: %w", err)
+	}
+	defer os.Remove(tmp.Name()) // no-op once the file is renamed
+
+	hash := sha256.New()
+	_, err = io.Copy(io.MultiWriter(tmp, hash), body)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return "", fmt.Errorf("while writing binary: %w", err)
+	}
+
+	if got := hex.EncodeToString(hash.Sum(nil)); !strings.EqualFold(got, binary.Checksum) {
+		return "", fmt.Errorf("checksum mismatch: expected %q, got %q", binary.Checksum, got)
+	}
+
+	// #nosec G302 -- plugin binaries need to be executable
+	if err := os.Chmod(tmp.Name(), 0o750); err != nil {
+		return "", fmt.Errorf("while making binary executable: %w", err)
+	}
+	path := filepath.Join(m.dir, BinaryPrefix+name)
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return "", fmt.Errorf("while moving binary to plugins directory: %w", err)
+	}
+	return path, nil
+}
+
+func (m *Manager) get(ctx context.Context, url string) (io.ReadCloser, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("while creating request: %w", err)
+	}
+
+	res, err := m.httpCli.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("while getting %q: %w", url, err)
+	}
+	if res.StatusCode != http.StatusOK {
+		res.Body.Close()
+		return nil, fmt.Errorf("while getting %q: unexpected status code %d", url, res.StatusCode)
+	}
+	return res.Body, nil
+}
+
+// supervise runs a given plugin until the context is cancelled.
+func (m *Manager) supervise(ctx context.Context, plugin runner, log logrus.FieldLogger) {
+	attempts := 0
+	for {
+		startedAt := time.Now()
+		err := plugin.Run(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+
+		if time.Since(startedAt) >= m.maxBackoff {
+			// the plugin was running long enough to start the backoff again
+			attempts = 0
+		}
+		attempts++
+
+		backoff := m.backoff(attempts)
+		if err != nil {
+			log.Errorf("Plugin stopped with error: %s. Restarting in %s...", err.Error(), backoff)
+		} else {
+			log.Warnf("Plugin stopped. Restarting in %s...", backoff)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+	}
+}
+
+// backoff returns the time to wait before a next restart, based on the number of restarts in a row.
+func (m *Manager) backoff(attempts int) time.Duration {
+	backoff := m.initialBackoff
+	for i := 1; i < attempts; i++ {
+		backoff *= 2
+		if backoff >= m.maxBackoff {
+			return m.maxBackoff
+		}
+	}
+	return backoff
+}
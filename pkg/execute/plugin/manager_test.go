@@ -0,0 +1,234 @@
+package plugin
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	logtest "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+const fixBinary = "#!/bin/sh\necho gh\n"
+
+var errFakeRun = errors.New("plugin crashed")
+
+func TestManagerStartDownloadsMissingPlugins(t *testing.T) {
+	// given
+	dir := t.TempDir()
+	writeBinary(t, dir, "botkube-executor-flux", "#!/bin/sh\n")
+
+	checksum := sha256.Sum256([]byte(fixBinary))
+	srv := fixRepositoryServer(t, hex.EncodeToString(checksum[:]))
+
+	logger, _ := logtest.NewNullLogger()
+	manager := NewManager(logger, config.PluginsSettings{
+		Directory: dir,
+		Repositories: map[string]config.PluginsRepository{
+			"botkube": {URL: srv.URL + "/index.yaml"},
+		},
+	}, map[string]config.Executors{
+		"gitops": {Plugins: map[string]config.ExecutorPlugin{
+			"gh":        {Enabled: true},
+			"flux":      {Enabled: true},
+			"terraform": {Enabled: true},
+		}},
+		"disabled": {Plugins: map[string]config.ExecutorPlugin{
+			"helmfile": {Enabled: false},
+		}},
+	})
+	manager.platform = IndexURLPlatform{OS: "linux", Architecture: "amd64"}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	started := map[string]*fakeRunner{}
+	var mu sync.Mutex
+	manager.newRunner = func(_ logrus.FieldLogger, path string) runner {
+		mu.Lock()
+		defer mu.Unlock()
+		r := &fakeRunner{}
+		started[path] = r
+		if len(started) == 2 {
+			cancel()
+		}
+		return r
+	}
+
+	// when
+	err := manager.Start(ctx)
+
+	// then
+	require.NoError(t, err)
+	assert.Len(t, started, 2, "terraform must be skipped, as it's not in the index")
+	assert.Contains(t, started, filepath.Join(dir, "botkube-executor-flux"))
+	assert.Contains(t, started, filepath.Join(dir, "botkube-executor-gh"))
+
+	raw, err := os.ReadFile(filepath.Join(dir, "botkube-executor-gh"))
+	require.NoError(t, err)
+	assert.Equal(t, fixBinary, string(raw))
+
+	paths, err := Discover(dir)
+	require.NoError(t, err)
+	assert.Len(t, paths, 2, "downloaded binary must be executable and no temporary files must be left")
+}
+
+func TestManagerStartChecksumMismatch(t *testing.T) {
+	// given
+	dir := t.TempDir()
+	srv := fixRepositoryServer(t, "0000")
+
+	logger, _ := logtest.NewNullLogger()
+	manager := NewManager(logger, config.PluginsSettings{
+		Directory: dir,
+		Repositories: map[string]config.PluginsRepository{
+			"botkube": {URL: srv.URL + "/index.yaml"},
+		},
+	}, map[string]config.Executors{
+		"gitops": {Plugins: map[string]config.ExecutorPlugin{"gh": {Enabled: true}}},
+	})
+	manager.platform = IndexURLPlatform{OS: "linux", Architecture: "amd64"}
+	manager.newRunner = func(_ logrus.FieldLogger, path string) runner {
+		t.Fatalf("plugin %q must not be started", path)
+		return nil
+	}
+
+	// when
+	err := manager.Start(context.Background())
+
+	// then
+	require.NoError(t, err)
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Empty(t, entries, "binary with invalid checksum must be removed")
+
+	_, err = manager.Execute(context.Background(), "gh", nil, "gh pr list", []string{"pr", "list"})
+	assert.ErrorIs(t, err, ErrNotRunning)
+}
+
+func TestManagerRestartsPlugin(t *testing.T) {
+	// given
+	dir := t.TempDir()
+	writeBinary(t, dir, "botkube-executor-gh", "#!/bin/sh\n")
+
+	logger, _ := logtest.NewNullLogger()
+	manager := NewManager(logger, config.PluginsSettings{Directory: dir}, map[string]config.Executors{
+		"gitops": {Plugins: map[string]config.ExecutorPlugin{
+			"gh": {Enabled: true, Config: map[string]interface{}{"repo": "kubeshop/botkube"}},
+		}},
+	})
+	manager.initialBackoff = time.Millisecond
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	plugin := &fakeRunner{cancelAfter: 3, cancel: cancel}
+	manager.newRunner = func(_ logrus.FieldLogger, _ string) runner {
+		return plugin
+	}
+
+	// when
+	err := manager.Start(ctx)
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, 3, plugin.runs)
+
+	// when
+	res, err := manager.Execute(context.Background(), "gh", map[string]interface{}{"repo": "kubeshop/botkube"}, "gh pr list", []string{"pr", "list"})
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, Response{Output: "executed"}, res)
+	assert.Equal(t, []Request{{Command: "gh pr list", Args: []string{"pr", "list"}, Config: []byte(`{"repo":"kubeshop/botkube"}`)}}, plugin.requests)
+}
+
+func TestManagerBackoff(t *testing.T) {
+	// given
+	manager := &Manager{initialBackoff: time.Second, maxBackoff: 5 * time.Second}
+
+	// when
+	var got []time.Duration
+	for attempts := 1; attempts <= 5; attempts++ {
+		got = append(got, manager.backoff(attempts))
+	}
+
+	// then
+	assert.Equal(t, []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second}, got)
+}
+
+func fixRepositoryServer(t *testing.T, checksum string) *httptest.Server {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	mux.HandleFunc("/index.yaml", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprintf(w, `entries:
+  - name: gh
+    type: source
+    urls:
+      - url: %[1]s/source-gh
+        checksum: %[2]s
+        platform: {os: linux, architecture: amd64}
+  - name: gh
+    type: executor
+    version: v1.0.0
+    urls:
+      - url: %[1]s/gh-darwin
+        checksum: %[2]s
+        platform: {os: darwin, architecture: arm64}
+      - url: %[1]s/gh-linux
+        checksum: %[2]s
+        platform: {os: linux, architecture: amd64}
+`, srv.URL, checksum)
+	})
+	mux.HandleFunc("/gh-linux", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(fixBinary))
+	})
+	return srv
+}
+
+// fakeRunner fails each run, and cancels the context after a given number of runs.
+type fakeRunner struct {
+	mu          sync.Mutex
+	cancel      context.CancelFunc
+	cancelAfter int
+	runs        int
+	requests    []Request
+}
+
+func (f *fakeRunner) Run(ctx context.Context) error {
+	f.mu.Lock()
+	f.runs++
+	last := f.runs == f.cancelAfter
+	f.mu.Unlock()
+
+	if f.cancel == nil {
+		<-ctx.Done()
+		return nil
+	}
+	if last {
+		f.cancel()
+	}
+	return errFakeRun
+}
+
+func (f *fakeRunner) Execute(_ context.Context, req Request) (Response, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.requests = append(f.requests, req)
+	return Response{Output: "executed"}, nil
+}
@@ -0,0 +1,54 @@
+package plugin
+
+import (
+	"encoding/json"
+	"errors"
+)
+
+// ErrNotRunning is returned when a command is sent to a plugin which is not running, e.g. because it's being restarted.
+var ErrNotRunning = errors.New("plugin is not running")
+
+// Request is a command sent to an executor plugin. It mirrors the ExecuteRequest message from pkg/pluginrpc/pb/plugin.proto.
+type Request struct {
+	// Command is the whole command sent by the user, including the plugin name.
+	Command string `json:"command"`
+	// Args holds the command arguments without the plugin name and the Botkube flags, such as `--cluster-name`.
+	Args []string `json:"args"`
+	// Config is the plugin configuration from the executor binding.
+	Config json.RawMessage `json:"config,omitempty"`
+}
+
+// Response is a command result returned by an executor plugin. It mirrors the ExecuteResponse message from pkg/pluginrpc/pb/plugin.proto.
+type Response struct {
+	Output string `json:"output"`
+	// Error is shown to the user if the command failed.
+	Error string `json:"error,omitempty"`
+}
+
+// Index is a plugin repository index.
+type Index struct {
+	Entries []IndexEntry `yaml:"entries"`
+}
+
+// IndexEntry describes a single plugin in the repository index.
+type IndexEntry struct {
+	Name        string     `yaml:"name"`
+	Type        string     `yaml:"type"`
+	Description string     `yaml:"description"`
+	Version     string     `yaml:"version"`
+	URLs        []IndexURL `yaml:"urls"`
+}
+
+// IndexURL describes the plugin binary for a given platform.
+type IndexURL struct {
+	URL string `yaml:"url"`
+	// Checksum is the SHA-256 checksum of the binary, encoded as hex.
+	Checksum string           `yaml:"checksum"`
+	Platform IndexURLPlatform `yaml:"platform"`
+}
+
+// IndexURLPlatform describes the platform for which the binary is built.
+type IndexURLPlatform struct {
+	OS           string `yaml:"os"`
+	Architecture string `yaml:"architecture"`
+}
@@ -0,0 +1,125 @@
+package plugin
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/kubeshop/botkube/pkg/pluginrpc"
+	"github.com/kubeshop/botkube/pkg/pluginrpc/pb"
+)
+
+// BinaryPrefix is the file name prefix of the executor plugin binaries. The rest of the file name is the plugin name.
+const BinaryPrefix = "botkube-executor-"
+
+// Process is an executor plugin running as an external binary. The binary serves the Executor gRPC service defined in
+// pkg/pluginrpc/pb/plugin.proto, see pluginrpc.ServeExecutor. Lines written to stderr are logged.
+type Process struct {
+	log  logrus.FieldLogger
+	path string
+
+	connMu sync.RWMutex
+	conn   *pluginrpc.Conn
+}
+
+// NewProcess returns a new Process instance for a given plugin binary.
+func NewProcess(log logrus.FieldLogger, path string) *Process {
+	return &Process{log: log, path: path}
+}
+
+// Run runs the plugin binary until it exits or the context is cancelled.
+func (p *Process) Run(ctx context.Context) error {
+	conn, err := pluginrpc.Start(p.log, p.path, pluginrpc.ExecutorPluginName)
+	if err != nil {
+		return err
+	}
+	defer conn.Kill()
+
+	p.setConn(conn)
+	defer p.setConn(nil)
+
+	select {
+	case <-ctx.Done():
+		return nil
+	case <-conn.Done():
+		return fmt.Errorf("plugin %q exited", p.path)
+	}
+}
+
+// Execute sends a given request to the running binary and waits for the response.
+// If the context is done before the response arrives, the call is cancelled.
+func (p *Process) Execute(ctx context.Context, req Request) (Response, error) {
+	conn := p.getConn()
+	if conn == nil {
+		return Response{}, ErrNotRunning
+	}
+
+	resp, err := conn.Executor().Execute(ctx, &pb.ExecuteRequest{
+		Command: req.Command,
+		Args:    req.Args,
+		Config:  req.Config,
+	})
+	switch {
+	case err == nil:
+	case ctx.Err() != nil:
+		return Response{}, ctx.Err()
+	case status.Code(err) == codes.Unavailable:
+		return Response{}, ErrNotRunning
+	default:
+		return Response{}, fmt.Errorf("while executing command: %w", err)
+	}
+
+	return Response{Output: resp.Output, Error: resp.Error}, nil
+}
+
+func (p *Process) setConn(conn *pluginrpc.Conn) {
+	p.connMu.Lock()
+	defer p.connMu.Unlock()
+	p.conn = conn
+}
+
+func (p *Process) getConn() *pluginrpc.Conn {
+	p.connMu.RLock()
+	defer p.connMu.RUnlock()
+	return p.conn
+}
+
+// Discover returns paths of the executor plugin binaries found in a given directory, indexed by the plugin name.
+// It returns an empty map if the directory doesn't exist.
+func Discover(dir string) (map[string]string, error) {
+	entries, err := os.ReadDir(dir)
+	switch {
+	case err == nil:
+	case os.IsNotExist(err):
+		return map[string]string{}, nil
+	default:
+		return nil, fmt.Errorf("while reading plugins directory: %w", err)
+	}
+
+	out := map[string]string{}
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasPrefix(name, BinaryPrefix) {
+			continue
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			return nil, fmt.Errorf("while getting details of %q: %w", name, err)
+		}
+		if info.Mode()&0o111 == 0 {
+			// not executable
+			continue
+		}
+
+		out[strings.TrimPrefix(name, BinaryPrefix)] = filepath.Join(dir, name)
+	}
+	return out, nil
+}
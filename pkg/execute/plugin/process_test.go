@@ -0,0 +1,228 @@
+package plugin
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	logtest "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/pkg/pluginrpc"
+	"github.com/kubeshop/botkube/pkg/pluginrpc/pb"
+)
+
+// testPluginEnv selects the fake plugin served by the test binary when it's started as a plugin.
+const testPluginEnv = "BOTKUBE_TEST_EXECUTOR_PLUGIN"
+
+func TestMain(m *testing.M) {
+	switch os.Getenv(testPluginEnv) {
+	case "":
+		os.Exit(m.Run())
+	case "echo":
+		pluginrpc.ServeExecutor(&echoExecutor{})
+	case "sleepy":
+		pluginrpc.ServeExecutor(&sleepyExecutor{})
+	}
+	os.Exit(0)
+}
+
+func TestProcessExecute(t *testing.T) {
+	// given
+	path := writePluginBinary(t, t.TempDir(), "botkube-executor-echo", "echo")
+	logger, _ := logtest.NewNullLogger()
+	process := NewProcess(logger, path)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	runErr := make(chan error, 1)
+	go func() {
+		runErr <- process.Run(ctx)
+	}()
+	waitForProcess(t, process)
+
+	// when
+	res, err := process.Execute(ctx, Request{Command: "gh pr list", Args: []string{"pr", "list"}, Config: []byte(`{"repo":"kubeshop/botkube"}`)})
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, Response{Output: `gh pr list [pr list] {"repo":"kubeshop/botkube"}`}, res)
+
+	// when
+	res, err = process.Execute(ctx, Request{Command: "gh fail", Args: []string{"fail"}})
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, Response{Error: "command failed"}, res)
+
+	cancel()
+	assert.NoError(t, <-runErr)
+}
+
+func TestProcessExecuteTimeout(t *testing.T) {
+	// given
+	path := writePluginBinary(t, t.TempDir(), "botkube-executor-sleepy", "sleepy")
+	logger, _ := logtest.NewNullLogger()
+	process := NewProcess(logger, path)
+
+	runCtx, stop := context.WithCancel(context.Background())
+	defer stop()
+	go func() {
+		_ = process.Run(runCtx)
+	}()
+	waitForProcess(t, process)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	// when
+	_, err := process.Execute(ctx, Request{Command: "sleepy", Args: []string{"10s"}})
+
+	// then
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	// when
+	res, err := process.Execute(context.Background(), Request{Command: "sleepy", Args: []string{"0s"}})
+
+	// then
+	require.NoError(t, err, "cancelled call must not affect the next one")
+	assert.Equal(t, Response{Output: "woke up"}, res)
+}
+
+func TestProcessRunPluginExited(t *testing.T) {
+	// given
+	path := writePluginBinary(t, t.TempDir(), "botkube-executor-echo", "echo")
+	logger, _ := logtest.NewNullLogger()
+	process := NewProcess(logger, path)
+
+	runErr := make(chan error, 1)
+	go func() {
+		runErr <- process.Run(context.Background())
+	}()
+	waitForProcess(t, process)
+
+	// when
+	_, _ = process.Execute(context.Background(), Request{Command: "exit"})
+
+	// then
+	select {
+	case err := <-runErr:
+		assert.EqualError(t, err, fmt.Sprintf("plugin %q exited", path))
+	case <-time.After(5 * time.Second):
+		t.Fatal("exit of the plugin was not detected")
+	}
+	_, err := process.Execute(context.Background(), Request{Command: "gh pr list"})
+	assert.ErrorIs(t, err, ErrNotRunning)
+}
+
+func TestProcessRunNotAPlugin(t *testing.T) {
+	// given
+	path := writeBinary(t, t.TempDir(), "botkube-executor-invalid", "#!/bin/sh\nexit 3\n")
+	logger, _ := logtest.NewNullLogger()
+
+	// when
+	err := NewProcess(logger, path).Run(context.Background())
+
+	// then
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `while starting "`+path+`"`)
+}
+
+func TestProcessExecuteNotRunning(t *testing.T) {
+	// given
+	logger, _ := logtest.NewNullLogger()
+	process := NewProcess(logger, "botkube-executor-missing")
+
+	// when
+	_, err := process.Execute(context.Background(), Request{Command: "missing"})
+
+	// then
+	assert.ErrorIs(t, err, ErrNotRunning)
+}
+
+func TestDiscover(t *testing.T) {
+	// given
+	dir := t.TempDir()
+	writeBinary(t, dir, "botkube-executor-gh", "#!/bin/sh\n")
+	writeBinary(t, dir, "botkube-source-velero", "#!/bin/sh\n")
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "botkube-executor-readme"), []byte("not executable"), 0o600))
+
+	// when
+	paths, err := Discover(dir)
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"gh": filepath.Join(dir, "botkube-executor-gh"),
+	}, paths)
+
+	// when
+	paths, err = Discover(filepath.Join(dir, "missing"))
+
+	// then
+	require.NoError(t, err)
+	assert.Empty(t, paths)
+}
+
+// waitForProcess waits until the process accepts requests.
+func waitForProcess(t *testing.T, process *Process) {
+	t.Helper()
+
+	require.Eventually(t, func() bool {
+		return process.getConn() != nil
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func writeBinary(t *testing.T, dir, name, content string) string {
+	t.Helper()
+
+	path := filepath.Join(dir, name)
+	// #nosec G306 -- test binaries need to be executable
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o700))
+	return path
+}
+
+// writePluginBinary writes a script which runs the test binary as a given fake plugin.
+func writePluginBinary(t *testing.T, dir, name, fake string) string {
+	t.Helper()
+
+	return writeBinary(t, dir, name, fmt.Sprintf("#!/bin/sh\n%s=%s exec %q\n", testPluginEnv, fake, os.Args[0]))
+}
+
+// echoExecutor returns the received request as the output, unless the command contains "fail" or is "exit".
+type echoExecutor struct {
+	pb.UnimplementedExecutorServer
+}
+
+func (*echoExecutor) Execute(_ context.Context, req *pb.ExecuteRequest) (*pb.ExecuteResponse, error) {
+	if req.Command == "exit" {
+		os.Exit(0)
+	}
+	if strings.Contains(req.Command, "fail") {
+		return &pb.ExecuteResponse{Error: "command failed"}, nil
+	}
+	return &pb.ExecuteResponse{Output: fmt.Sprintf("%s %v %s", req.Command, req.Args, req.Config)}, nil
+}
+
+// sleepyExecutor sleeps for the duration given as the first argument.
+type sleepyExecutor struct {
+	pb.UnimplementedExecutorServer
+}
+
+func (*sleepyExecutor) Execute(ctx context.Context, req *pb.ExecuteRequest) (*pb.ExecuteResponse, error) {
+	d, err := time.ParseDuration(req.Args[0])
+	if err != nil {
+		return nil, err
+	}
+	select {
+	case <-time.After(d):
+		return &pb.ExecuteResponse{Output: "woke up"}, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
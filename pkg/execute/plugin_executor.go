@@ -0,0 +1,116 @@
+package execute
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mattn/go-shellwords"
+	"github.com/sirupsen/logrus"
+
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/execute/plugin"
+)
+
+const (
+	pluginNotRunningMsgFmt = "Sorry, the '%s' plugin is not running on cluster '%s'. Please try again later or ask your Botkube administrator to check the Botkube logs."
+	pluginTimeoutMsgFmt    = "Sorry, the '%s' plugin didn't respond within %s on cluster '%s'."
+	pluginErrorMsgFmt      = "The '%s' plugin failed on cluster '%s': %s"
+
+	pluginExecuteTimeout = time.Minute
+)
+
+// PluginManager executes commands with the executor plugins.
+type PluginManager interface {
+	Execute(ctx context.Context, name string, cfg map[string]interface{}, command string, args []string) (plugin.Response, error)
+}
+
+// PluginExecutor executes commands with the executor plugins. The plugin name is the command name, e.g. `gh pr list`.
+type PluginExecutor struct {
+	log     logrus.FieldLogger
+	cfg     config.Config
+	manager PluginManager
+	timeout time.Duration
+}
+
+// NewPluginExecutor creates a new instance of PluginExecutor.
+func NewPluginExecutor(log logrus.FieldLogger, cfg config.Config, manager PluginManager) *PluginExecutor {
+	return &PluginExecutor{
+		log:     log,
+		cfg:     cfg,
+		manager: manager,
+		timeout: pluginExecuteTimeout,
+	}
+}
+
+// CanHandle returns true if the command name is a plugin enabled for given bindings.
+func (e *PluginExecutor) CanHandle(bindings []string, args []string) bool {
+	if len(args) == 0 || e.manager == nil {
+		return false
+	}
+
+	for _, name := range bindings {
+		if e.cfg.Executors[name].Plugins[args[0]].Enabled {
+			return true
+		}
+	}
+	return false
+}
+
+// GetCommandPrefix gets the plugin name. The plugin arguments are not reported, as their meaning is not known.
+func (e *PluginExecutor) GetCommandPrefix(args []string) string {
+	if len(args) == 0 {
+		return ""
+	}
+	return args[0]
+}
+
+// Execute executes a given command with the plugin. The plugin configuration is taken from the first binding
+// which enables the plugin.
+//
+// This method should be called ONLY if:
+// - we are a target cluster,
+// - and PluginExecutor.CanHandle returned true.
+func (e *PluginExecutor) Execute(ctx context.Context, bindings []string, command string) (string, error) {
+	log := e.log.WithField("command", command)
+	log.Debugf("Handling command...")
+
+	args, err := shellwords.Parse(strings.TrimSpace(command))
+	if err != nil {
+		return "", fmt.Errorf("while parsing the command message into args: %w", err)
+	}
+	if len(args) == 0 {
+		return "", errInvalidCommand
+	}
+	name := args[0]
+
+	var cfg map[string]interface{}
+	for _, binding := range bindings {
+		if p := e.cfg.Executors[binding].Plugins[name]; p.Enabled {
+			cfg = p.Config
+			break
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, e.timeout)
+	defer cancel()
+
+	clusterName := e.cfg.Settings.ClusterName
+	res, err := e.manager.Execute(ctx, name, cfg, strings.TrimSpace(command), removeClusterFlags(args[1:]))
+	switch {
+	case err == nil:
+	case errors.Is(err, plugin.ErrNotRunning):
+		return "", NewExecutionCommandError(pluginNotRunningMsgFmt, name, clusterName)
+	case errors.Is(err, context.DeadlineExceeded):
+		return "", NewExecutionCommandError(pluginTimeoutMsgFmt, name, e.timeout, clusterName)
+	default:
+		return "", fmt.Errorf("while executing %q plugin: %w", name, err)
+	}
+
+	if res.Error != "" {
+		return "", NewExecutionCommandError(pluginErrorMsgFmt, name, clusterName, res.Error)
+	}
+	return res.Output, nil
+}
@@ -0,0 +1,160 @@
+package execute
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	logtest "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/execute/plugin"
+)
+
+func TestPluginExecutorExecute(t *testing.T) {
+	// given
+	manager := &fakePluginManager{res: plugin.Response{Output: "PR #1 open"}}
+	executor := fixPluginExecutor(manager)
+
+	// when
+	out, err := executor.Execute(context.Background(), []string{"disabled", "gitops"}, "gh pr list --cluster-name dev --state 'all open'")
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, "PR #1 open", out)
+	assert.Equal(t, "gh", manager.name)
+	assert.Equal(t, map[string]interface{}{"repo": "kubeshop/botkube"}, manager.cfg)
+	assert.Equal(t, "gh pr list --cluster-name dev --state 'all open'", manager.command)
+	assert.Equal(t, []string{"pr", "list", "--state", "all open"}, manager.args)
+}
+
+func TestPluginExecutorExecuteErrors(t *testing.T) {
+	// given
+	tests := []struct {
+		name string
+
+		res plugin.Response
+		err error
+
+		expErr        string
+		expCommandErr bool
+	}{
+		{
+			name:          "Should return plugin error",
+			res:           plugin.Response{Error: "repository not found"},
+			expErr:        "The 'gh' plugin failed on cluster 'dev': repository not found",
+			expCommandErr: true,
+		},
+		{
+			name:          "Should return error if plugin is not running",
+			err:           plugin.ErrNotRunning,
+			expErr:        "Sorry, the 'gh' plugin is not running on cluster 'dev'. Please try again later or ask your Botkube administrator to check the Botkube logs.",
+			expCommandErr: true,
+		},
+		{
+			name:          "Should return error if plugin didn't respond in time",
+			err:           context.DeadlineExceeded,
+			expErr:        "Sorry, the 'gh' plugin didn't respond within 1m0s on cluster 'dev'.",
+			expCommandErr: true,
+		},
+		{
+			name:   "Should return internal error",
+			err:    errors.New("broken pipe"),
+			expErr: `while executing "gh" plugin: broken pipe`,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			executor := fixPluginExecutor(&fakePluginManager{res: tc.res, err: tc.err})
+
+			// when
+			_, err := executor.Execute(context.Background(), []string{"gitops"}, "gh pr list")
+
+			// then
+			require.Error(t, err)
+			assert.EqualError(t, err, tc.expErr)
+			assert.Equal(t, tc.expCommandErr, IsExecutionCommandError(err))
+		})
+	}
+}
+
+func TestPluginExecutorCanHandle(t *testing.T) {
+	// given
+	tests := []struct {
+		name string
+
+		bindings []string
+		args     []string
+		manager  PluginManager
+
+		expCanHandle bool
+	}{
+		{
+			name:         "Should handle enabled plugin",
+			bindings:     []string{"gitops"},
+			args:         []string{"gh", "pr", "list"},
+			manager:      &fakePluginManager{},
+			expCanHandle: true,
+		},
+		{
+			name:     "Should not handle plugin disabled in bindings",
+			bindings: []string{"disabled"},
+			args:     []string{"gh", "pr", "list"},
+			manager:  &fakePluginManager{},
+		},
+		{
+			name:     "Should not handle other command",
+			bindings: []string{"gitops"},
+			args:     []string{"kubectl", "get", "pods"},
+			manager:  &fakePluginManager{},
+		},
+		{
+			name:     "Should not handle plugin without manager",
+			bindings: []string{"gitops"},
+			args:     []string{"gh", "pr", "list"},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			executor := fixPluginExecutor(tc.manager)
+
+			// when
+			got := executor.CanHandle(tc.bindings, tc.args)
+
+			// then
+			assert.Equal(t, tc.expCanHandle, got)
+		})
+	}
+}
+
+func fixPluginExecutor(manager PluginManager) *PluginExecutor {
+	logger, _ := logtest.NewNullLogger()
+	return NewPluginExecutor(logger, config.Config{
+		Settings: config.Settings{ClusterName: "dev"},
+		Executors: map[string]config.Executors{
+			"gitops": {Plugins: map[string]config.ExecutorPlugin{
+				"gh": {Enabled: true, Config: map[string]interface{}{"repo": "kubeshop/botkube"}},
+			}},
+			"disabled": {Plugins: map[string]config.ExecutorPlugin{
+				"gh": {Enabled: false, Config: map[string]interface{}{"repo": "other"}},
+			}},
+		},
+	}, manager)
+}
+
+type fakePluginManager struct {
+	res plugin.Response
+	err error
+
+	name    string
+	cfg     map[string]interface{}
+	command string
+	args    []string
+}
+
+func (f *fakePluginManager) Execute(_ context.Context, name string, cfg map[string]interface{}, command string, args []string) (plugin.Response, error) {
+	f.name, f.cfg, f.command, f.args = name, cfg, command, args
+	return f.res, f.err
+}
@@ -0,0 +1,201 @@
+package execute
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+const (
+	queueCommandName = "queue"
+
+	queueDisabledMsgFmt = "Command queue is disabled on cluster '%s'. Please enable it with the `settings.middlewares.queue.enabled` property."
+	queueStatusMsgFmt   = "Running commands: %d/%d, waiting: %d/%d.\nThis channel: running %d%s, waiting %d."
+	queueTimeFormat     = "15:04:05"
+)
+
+var (
+	// ErrCommandQueueFull is returned when a command is received when the maximum number of commands is waiting in the queue.
+	ErrCommandQueueFull = errors.New("command queue is full")
+	// ErrCommandQueueTimeout is returned when a command waited in the queue longer than the configured timeout.
+	ErrCommandQueueTimeout = errors.New("command waited in the queue too long")
+)
+
+// queuedCommand is a command waiting in the queue.
+type queuedCommand struct {
+	channel  string
+	user     string
+	command  string
+	queuedAt time.Time
+	ready    chan struct{}
+	granted  bool
+}
+
+// CommandQueue limits the number of commands executed concurrently, from all channels and from a single channel.
+// Commands over the limits wait in the queue. They are executed in the order they were received, but a command waiting
+// for its channel limit doesn't block the commands from other channels.
+type CommandQueue struct {
+	cfg config.QueueMiddleware
+
+	mu           sync.Mutex
+	running      map[string]int
+	runningTotal int
+	waiting      []*queuedCommand
+	nowFn        func() time.Time
+}
+
+// NewCommandQueue returns a new CommandQueue instance.
+func NewCommandQueue(cfg config.QueueMiddleware) *CommandQueue {
+	return &CommandQueue{
+		cfg:     cfg,
+		running: map[string]int{},
+		nowFn:   time.Now,
+	}
+}
+
+// CommandQueueChannel returns the queue channel key of a given conversation.
+func CommandQueueChannel(commGroupName string, platform config.CommPlatformIntegration, conversationID string) string {
+	return fmt.Sprintf("%s/%s/%s", commGroupName, platform, conversationID)
+}
+
+// Acquire waits until a given command can be executed. If the command has to wait, onQueued is called with its position in the queue.
+// The returned function must be called once the command is executed, so the next commands can run.
+func (q *CommandQueue) Acquire(ctx context.Context, channel, user, command string, onQueued func(position int)) (func(), error) {
+	q.mu.Lock()
+	if len(q.waiting) >= q.cfg.MaxQueued {
+		q.mu.Unlock()
+		return nil, ErrCommandQueueFull
+	}
+
+	item := &queuedCommand{
+		channel:  channel,
+		user:     user,
+		command:  strings.TrimSpace(command),
+		queuedAt: q.nowFn(),
+		ready:    make(chan struct{}),
+	}
+	q.waiting = append(q.waiting, item)
+	q.dispatch()
+	position := q.position(item)
+	q.mu.Unlock()
+
+	if position > 0 && onQueued != nil {
+		onQueued(position)
+	}
+
+	timer := time.NewTimer(q.cfg.Timeout)
+	defer timer.Stop()
+
+	var err error
+	select {
+	case <-item.ready:
+		return q.releaseFunc(channel), nil
+	case <-timer.C:
+		err = ErrCommandQueueTimeout
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if item.granted {
+		// the command was dispatched in the meantime, so it runs anyway
+		return q.releaseFunc(channel), nil
+	}
+	q.remove(item)
+	return nil, err
+}
+
+// Status returns the queue status for a given channel.
+func (q *CommandQueue) Status(channel string) string {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	channelLimit := ""
+	if q.cfg.MaxConcurrentPerChannel > 0 {
+		channelLimit = fmt.Sprintf("/%d", q.cfg.MaxConcurrentPerChannel)
+	}
+
+	rows := [][]string{{"POSITION", "QUEUED AT", "USER", "COMMAND"}}
+	for idx, item := range q.waiting {
+		if item.channel != channel {
+			continue
+		}
+		rows = append(rows, []string{
+			fmt.Sprintf("%d", idx+1),
+			item.queuedAt.UTC().Format(queueTimeFormat),
+			item.user,
+			item.command,
+		})
+	}
+
+	out := fmt.Sprintf(queueStatusMsgFmt, q.runningTotal, q.cfg.MaxConcurrent, len(q.waiting), q.cfg.MaxQueued, q.running[channel], channelLimit, len(rows)-1)
+	if len(rows) > 1 {
+		out = fmt.Sprintf("%s\n\n%s", out, renderTable(rows))
+	}
+	return out
+}
+
+// dispatch starts the waiting commands in order, as long as the limits allow. It must be called with the mutex locked.
+func (q *CommandQueue) dispatch() {
+	for idx := 0; idx < len(q.waiting) && q.runningTotal < q.cfg.MaxConcurrent; {
+		item := q.waiting[idx]
+		if q.cfg.MaxConcurrentPerChannel > 0 && q.running[item.channel] >= q.cfg.MaxConcurrentPerChannel {
+			idx++
+			continue
+		}
+
+		q.waiting = append(q.waiting[:idx], q.waiting[idx+1:]...)
+		q.running[item.channel]++
+		q.runningTotal++
+		item.granted = true
+		close(item.ready)
+	}
+}
+
+func (q *CommandQueue) releaseFunc(channel string) func() {
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			q.mu.Lock()
+			defer q.mu.Unlock()
+
+			q.running[channel]--
+			if q.running[channel] <= 0 {
+				delete(q.running, channel)
+			}
+			q.runningTotal--
+			q.dispatch()
+		})
+	}
+}
+
+// position returns the 1-based position of a given command in the queue, or 0 if it's not waiting.
+func (q *CommandQueue) position(item *queuedCommand) int {
+	for idx, waiting := range q.waiting {
+		if waiting == item {
+			return idx + 1
+		}
+	}
+	return 0
+}
+
+func (q *CommandQueue) remove(item *queuedCommand) {
+	if idx := q.position(item); idx > 0 {
+		q.waiting = append(q.waiting[:idx-1], q.waiting[idx:]...)
+	}
+}
+
+// runQueueCommand returns the queue status for the current channel.
+func (e *DefaultExecutor) runQueueCommand() (string, error) {
+	e.reportCommand(queueCommandName, false)
+	if e.commandQueue == nil {
+		return "", NewExecutionCommandError(queueDisabledMsgFmt, e.cfg.Settings.ClusterName)
+	}
+	return e.commandQueue.Status(CommandQueueChannel(e.commGroupName, e.platform, e.conversation.ID)), nil
+}
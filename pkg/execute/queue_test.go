@@ -0,0 +1,149 @@
+package execute
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	logtest "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+func TestCommandQueueAcquire(t *testing.T) {
+	// given
+	queue := NewCommandQueue(config.QueueMiddleware{
+		Enabled:                 true,
+		MaxConcurrent:           2,
+		MaxConcurrentPerChannel: 1,
+		MaxQueued:               2,
+		Timeout:                 time.Minute,
+	})
+	ctx := context.Background()
+
+	// when
+	releaseA1, err := queue.Acquire(ctx, "a", "Joe", "get pods", failOnQueued(t))
+	require.NoError(t, err)
+	releaseB1, err := queue.Acquire(ctx, "b", "Ann", "get svc", failOnQueued(t))
+	require.NoError(t, err)
+
+	a2 := acquireAsync(ctx, queue, "a", "get deploy")
+	assert.Equal(t, 1, <-a2.positions)
+	b2 := acquireAsync(ctx, queue, "b", "get nodes")
+	assert.Equal(t, 2, <-b2.positions)
+
+	_, err = queue.Acquire(ctx, "c", "Tom", "get cm", failOnQueued(t))
+
+	// then
+	assert.ErrorIs(t, err, ErrCommandQueueFull)
+
+	// when
+	releaseB1()
+	releaseB1() // no-op
+
+	// then
+	releaseB2 := <-b2.release
+	assert.Empty(t, a2.release, "command waiting for its channel limit must not be started")
+
+	// when
+	releaseA1()
+
+	// then
+	releaseA2 := <-a2.release
+	releaseA2()
+	releaseB2()
+	assert.Equal(t, "Running commands: 0/2, waiting: 0/2.\nThis channel: running 0/1, waiting 0.", queue.Status("a"))
+}
+
+func TestCommandQueueAcquireTimeout(t *testing.T) {
+	// given
+	queue := NewCommandQueue(config.QueueMiddleware{
+		Enabled:       true,
+		MaxConcurrent: 1,
+		MaxQueued:     1,
+		Timeout:       10 * time.Millisecond,
+	})
+	release, err := queue.Acquire(context.Background(), "a", "Joe", "get pods", failOnQueued(t))
+	require.NoError(t, err)
+	defer release()
+
+	// when
+	_, err = queue.Acquire(context.Background(), "a", "Joe", "get svc", nil)
+
+	// then
+	assert.ErrorIs(t, err, ErrCommandQueueTimeout)
+	assert.Equal(t, "Running commands: 1/1, waiting: 0/1.\nThis channel: running 1, waiting 0.", queue.Status("a"), "timed out command must be removed")
+}
+
+func TestCommandQueueStatus(t *testing.T) {
+	// given
+	queue := NewCommandQueue(config.QueueMiddleware{
+		Enabled:                 true,
+		MaxConcurrent:           1,
+		MaxConcurrentPerChannel: 1,
+		MaxQueued:               5,
+		Timeout:                 time.Minute,
+	})
+	queue.nowFn = func() time.Time { return time.Date(2022, 10, 1, 12, 30, 0, 0, time.UTC) }
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	release, err := queue.Acquire(ctx, "a", "Joe", "get pods", failOnQueued(t))
+	require.NoError(t, err)
+	defer release()
+	<-acquireAsync(ctx, queue, "b", "get svc").positions
+	<-acquireAsync(ctx, queue, "a", "logs nginx").positions
+
+	// when
+	out := queue.Status("a")
+
+	// then
+	assert.Equal(t, "Running commands: 1/1, waiting: 2/5.\nThis channel: running 1/1, waiting 1.\n\n"+
+		"POSITION  QUEUED AT  USER  COMMAND\n"+
+		"2         12:30:00   Joe   logs nginx\n", out)
+}
+
+func TestDefaultExecutorRunQueueCommandDisabled(t *testing.T) {
+	// given
+	logger, _ := logtest.NewNullLogger()
+	executor := &DefaultExecutor{
+		log:               logger,
+		cfg:               config.Config{Settings: config.Settings{ClusterName: "dev"}},
+		analyticsReporter: &fakeAnalyticsReporter{},
+	}
+
+	// when
+	_, err := executor.runQueueCommand()
+
+	// then
+	require.Error(t, err)
+	assert.True(t, IsExecutionCommandError(err))
+	assert.EqualError(t, err, "Command queue is disabled on cluster 'dev'. Please enable it with the `settings.middlewares.queue.enabled` property.")
+}
+
+type asyncAcquire struct {
+	positions chan int
+	release   chan func()
+}
+
+// acquireAsync acquires the queue in the background. The queue position is sent once the command waits in the queue.
+func acquireAsync(ctx context.Context, queue *CommandQueue, channel, command string) asyncAcquire {
+	out := asyncAcquire{positions: make(chan int, 1), release: make(chan func(), 1)}
+	go func() {
+		release, err := queue.Acquire(ctx, channel, "Joe", command, func(position int) {
+			out.positions <- position
+		})
+		if err == nil {
+			out.release <- release
+		}
+	}()
+	return out
+}
+
+func failOnQueued(t *testing.T) func(int) {
+	return func(position int) {
+		t.Errorf("command must not be queued, got position %d", position)
+	}
+}
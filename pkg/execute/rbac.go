@@ -0,0 +1,323 @@
+package execute
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mattn/go-shellwords"
+	"k8s.io/utils/strings/slices"
+
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/sliceutil"
+)
+
+const (
+	rbacNoRulesMsgFmt    = "Sorry, you are not allowed to execute this command on cluster '%s', as none of the RBAC rules configured for this channel applies to you. Please ask your Botkube administrator for access."
+	rbacNotAllowedMsgFmt = "Sorry, your RBAC rules don't allow executing '%s'%s on cluster '%s'. You are allowed to execute:\n%s"
+	rbacAllValues        = "all"
+	rbacUnnamedRuleFmt   = "%s rule #%d"
+)
+
+// rbacCommand describes a command checked against the RBAC rules. The resources hold all alternative namings of the resource.
+// The resources and Namespace are empty if they are not known. Resourceless commands, e.g. `kubectl logs`, are checked without the resource.
+type rbacCommand struct {
+	verb         string
+	resources    []string
+	namespace    string
+	resourceless bool
+}
+
+// rbacCommandFunc describes a given command executed with given bindings.
+type rbacCommandFunc func(bindings []string, command string) (rbacCommand, error)
+
+// authorizedBindings returns the channel executor bindings which allow the caller to execute a given command.
+// The bindings without RBAC are always returned. If the caller is not allowed to execute the command with any binding,
+// an ExecutionCommandError describing the caller rules is returned.
+func (e *DefaultExecutor) authorizedBindings(isEnabled func(config.Executors) bool, describe rbacCommandFunc, command string) ([]string, error) {
+	bindings := e.conversation.ExecutorBindings
+	restricted := false
+	for _, name := range bindings {
+		executor := e.cfg.Executors[name]
+		if isEnabled(executor) && executor.RBAC.Enabled {
+			restricted = true
+			break
+		}
+	}
+	if !restricted {
+		return bindings, nil
+	}
+
+	cmd, err := describe(bindings, command)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		out         []string
+		callerRules []string
+	)
+	for _, name := range bindings {
+		executor := e.cfg.Executors[name]
+		if !isEnabled(executor) {
+			continue
+		}
+		if !executor.RBAC.Enabled {
+			out = append(out, name)
+			continue
+		}
+
+		allowed := false
+		for idx, rule := range executor.RBAC.Rules {
+			if !e.isRBACRuleForCaller(rule) {
+				continue
+			}
+			callerRules = append(callerRules, fmt.Sprintf("• %s: %s", rbacRuleName(name, rule, idx), describeRBACRule(rule)))
+			allowed = allowed || rbacRuleAllows(rule, cmd)
+		}
+		if allowed {
+			out = append(out, name)
+		}
+	}
+
+	clusterName := e.cfg.Settings.ClusterName
+	switch {
+	case len(out) > 0:
+		return out, nil
+	case len(callerRules) == 0:
+		return nil, NewExecutionCommandError(rbacNoRulesMsgFmt, clusterName)
+	default:
+		return nil, NewExecutionCommandError(rbacNotAllowedMsgFmt, cmd.name(), cmd.scope(), clusterName, strings.Join(callerRules, "\n"))
+	}
+}
+
+// isRBACRuleForCaller returns true if a given rule lists the caller user ID or one of the caller groups.
+// The groups are the chat platform user groups and the Kubernetes groups assigned with the identity mapping.
+func (e *DefaultExecutor) isRBACRuleForCaller(rule config.RBACRule) bool {
+	if e.userID != "" && slices.Contains(rule.Users, e.userID) {
+		return true
+	}
+
+	groups := append(append([]string{}, e.userGroups...), e.identity.Groups...)
+	return sliceutil.Intersect(rule.Groups, groups)
+}
+
+// rbacRuleAllows returns true if a given rule allows the command verb, resource and Namespace.
+func rbacRuleAllows(rule config.RBACRule, cmd rbacCommand) bool {
+	if len(rule.Verbs) > 0 && !slices.Contains(rule.Verbs, cmd.verb) {
+		return false
+	}
+
+	if len(rule.Resources) > 0 && !cmd.resourceless && !sliceutil.Intersect(rule.Resources, cmd.resources) {
+		return false
+	}
+
+	if rule.Namespaces.IsConfigured() && !rule.Namespaces.IsAllowed(cmd.namespace) {
+		return false
+	}
+	return true
+}
+
+func (c rbacCommand) name() string {
+	if len(c.resources) == 0 || c.resourceless {
+		return c.verb
+	}
+	return fmt.Sprintf("%s %s", c.verb, c.resources[0])
+}
+
+func (c rbacCommand) scope() string {
+	switch c.namespace {
+	case "":
+		return ""
+	case config.AllNamespaceIndicator:
+		return " in all Namespaces"
+	default:
+		return fmt.Sprintf(" in the '%s' Namespace", c.namespace)
+	}
+}
+
+// rbacRuleName returns the rule name. Unnamed rules are identified by the executor binding name and their position.
+func rbacRuleName(binding string, rule config.RBACRule, idx int) string {
+	if rule.Name != "" {
+		return rule.Name
+	}
+	return fmt.Sprintf(rbacUnnamedRuleFmt, binding, idx+1)
+}
+
+func describeRBACRule(rule config.RBACRule) string {
+	namespaces := rbacAllValues
+	if rule.Namespaces.IsConfigured() {
+		namespaces = strings.Join(rule.Namespaces.Include, ", ")
+		if len(rule.Namespaces.Exclude) > 0 {
+			namespaces = fmt.Sprintf("%s (except %s)", namespaces, strings.Join(rule.Namespaces.Exclude, ", "))
+		}
+	}
+	return fmt.Sprintf("verbs: %s; resources: %s; Namespaces: %s", joinOrAll(rule.Verbs), joinOrAll(rule.Resources), namespaces)
+}
+
+func joinOrAll(in []string) string {
+	if len(in) == 0 {
+		return rbacAllValues
+	}
+	return strings.Join(in, ", ")
+}
+
+func isKubectlEnabled(executor config.Executors) bool {
+	return executor.Kubectl.Enabled
+}
+
+func isHelmEnabled(executor config.Executors) bool {
+	return executor.Helm.Enabled
+}
+
+func isTopEnabled(executor config.Executors) bool {
+	return executor.Top.Enabled
+}
+
+func isDiagEnabled(executor config.Executors) bool {
+	return executor.Diag.Enabled
+}
+
+func isEventsEnabled(executor config.Executors) bool {
+	return executor.Events.Enabled
+}
+
+// isPluginEnabled returns a function which checks if a given plugin is enabled in an executor binding.
+func isPluginEnabled(name string) func(config.Executors) bool {
+	return func(executor config.Executors) bool {
+		return executor.Plugins[name].Enabled
+	}
+}
+
+// rbacCommand describes a given kubectl command. The resource of commands reading the manifests from standard input is not known.
+func (e *Kubectl) rbacCommand(bindings []string, command string) (rbacCommand, error) {
+	args, err := e.getArgsWithoutAlias(command)
+	if err != nil {
+		return rbacCommand{}, err
+	}
+	if len(args) == 0 {
+		return rbacCommand{}, nil
+	}
+
+	namespace, err := e.getCommandNamespace(args)
+	if err != nil {
+		return rbacCommand{}, fmt.Errorf("while extracting Namespace from command: %w", err)
+	}
+	if namespace == "" {
+		namespace = e.findDefaultNamespace(bindings)
+	}
+
+	verb := args[0]
+	if _, isResourceless := resourcelessCommands[verb]; isResourceless {
+		return rbacCommand{verb: verb, namespace: namespace, resourceless: true}, nil
+	}
+
+	out := rbacCommand{verb: verb, namespace: namespace}
+	if resource := e.getResourceName(args); resource != "" && e.validResourceName(resource) && !readsFromStdin(args) {
+		out.resources = e.kcChecker.ResourceVariants(resource)
+	}
+	return out, nil
+}
+
+// rbacCommand describes a given Helm command. Helm commands have no resource.
+func (e *Helm) rbacCommand(bindings []string, command string) (rbacCommand, error) {
+	args, err := shellwords.Parse(strings.TrimSpace(command))
+	if err != nil {
+		return rbacCommand{}, fmt.Errorf("while parsing the command message into args: %w", err)
+	}
+	args = removeClusterFlags(args[1:])
+	if len(args) == 0 {
+		return rbacCommand{}, NewExecutionCommandError(helmMissingVerbMsg)
+	}
+
+	flags, err := parseHelmFlags(args)
+	if err != nil {
+		return rbacCommand{}, fmt.Errorf("while parsing flags: %w", err)
+	}
+	namespace := flags.namespace
+	if flags.allNamespaces {
+		namespace = config.AllNamespaceIndicator
+	}
+	if namespace == "" {
+		namespace = e.findDefaultNamespace(bindings)
+	}
+
+	return rbacCommand{verb: helmVerb(args[0]), namespace: namespace}, nil
+}
+
+// rbacCommand describes a given top command. The report name is the resource, and only the Pods report has a Namespace.
+func (e *Top) rbacCommand(_ []string, command string) (rbacCommand, error) {
+	args, err := shellwords.Parse(strings.TrimSpace(command))
+	if err != nil {
+		return rbacCommand{}, fmt.Errorf("while parsing the command message into args: %w", err)
+	}
+	args = removeClusterFlags(args[1:])
+	if len(args) == 0 {
+		return rbacCommand{}, NewExecutionCommandError(topUsageMsg)
+	}
+
+	report, found := topCommandAliases[args[0]]
+	if !found {
+		return rbacCommand{}, NewExecutionCommandError(topUsageMsg)
+	}
+
+	out := rbacCommand{verb: topCommandName, resources: []string{report}}
+	if report != topPodsCmd {
+		return out, nil
+	}
+
+	namespace, allNamespaces, err := parseTopPodsFlags(args[1:])
+	if err != nil {
+		return rbacCommand{}, fmt.Errorf("while parsing flags: %w", err)
+	}
+	switch {
+	case allNamespaces:
+		out.namespace = config.AllNamespaceIndicator
+	case namespace == "":
+		out.namespace = topDefaultNamespace
+	default:
+		out.namespace = namespace
+	}
+	return out, nil
+}
+
+// rbacCommand describes a given diag command. The workload resource is known only if the kind is specified.
+func (e *Diag) rbacCommand(_ []string, command string) (rbacCommand, error) {
+	target, err := parseDiagCommand(command)
+	if err != nil {
+		return rbacCommand{}, err
+	}
+
+	out := rbacCommand{verb: diagCommandName, namespace: target.Namespace}
+	if target.Kind != "" {
+		out.resources = []string{diagKindResources[target.Kind]}
+	}
+	return out, nil
+}
+
+// rbacCommand describes a given events command. The involved object is not checked, as the events are the queried resource.
+func (e *Events) rbacCommand(bindings []string, command string) (rbacCommand, error) {
+	query, err := parseEventsCommand(command, e.defaultSince(bindings))
+	if err != nil {
+		return rbacCommand{}, err
+	}
+
+	out := rbacCommand{verb: eventsCommandName, resources: []string{"events", "ev"}, namespace: query.Namespace}
+	if query.AllNamespaces {
+		out.namespace = config.AllNamespaceIndicator
+	}
+	return out, nil
+}
+
+// rbacCommand describes a given plugin command. The first plugin argument is the verb, e.g. `pr` for `gh pr list`.
+// Plugin commands have no resource and Namespace.
+func (e *PluginExecutor) rbacCommand(_ []string, command string) (rbacCommand, error) {
+	args, err := shellwords.Parse(strings.TrimSpace(command))
+	if err != nil {
+		return rbacCommand{}, fmt.Errorf("while parsing the command message into args: %w", err)
+	}
+	args = removeClusterFlags(args[1:])
+	if len(args) == 0 {
+		return rbacCommand{resourceless: true}, nil
+	}
+	return rbacCommand{verb: args[0], resourceless: true}, nil
+}
@@ -0,0 +1,231 @@
+package execute
+
+import (
+	"testing"
+
+	logtest "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/pkg/bot/identity"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/execute/kubectl"
+)
+
+func TestDefaultExecutorAuthorizedBindings(t *testing.T) {
+	// given
+	tests := []struct {
+		name string
+
+		executor   string
+		command    string
+		bindings   []string
+		userID     string
+		userGroups []string
+		identity   identity.Identity
+
+		expBindings []string
+		expErr      string
+	}{
+		{
+			name:        "Should allow command matching user rule",
+			executor:    "kubectl",
+			command:     "get po -n team-a",
+			bindings:    []string{"kubectl-rbac"},
+			userID:      "U-DEV",
+			expBindings: []string{"kubectl-rbac"},
+		},
+		{
+			name:     "Should deny command not matching user rule",
+			executor: "kubectl",
+			command:  "kubectl delete pods nginx -n team-a",
+			bindings: []string{"kubectl-rbac"},
+			userID:   "U-DEV",
+			expErr: "Sorry, your RBAC rules don't allow executing 'delete pods' in the 'team-a' Namespace on cluster 'dev'. You are allowed to execute:\n" +
+				"• developers: verbs: get, logs; resources: pods; Namespaces: team-a",
+		},
+		{
+			name:     "Should deny command in other Namespace",
+			executor: "kubectl",
+			command:  "logs nginx -n team-b",
+			bindings: []string{"kubectl-rbac"},
+			userID:   "U-DEV",
+			expErr: "Sorry, your RBAC rules don't allow executing 'logs' in the 'team-b' Namespace on cluster 'dev'. You are allowed to execute:\n" +
+				"• developers: verbs: get, logs; resources: pods; Namespaces: team-a",
+		},
+		{
+			name:     "Should deny user without rules",
+			executor: "kubectl",
+			command:  "get pods",
+			bindings: []string{"kubectl-rbac"},
+			userID:   "U-OTHER",
+			expErr:   "Sorry, you are not allowed to execute this command on cluster 'dev', as none of the RBAC rules configured for this channel applies to you. Please ask your Botkube administrator for access.",
+		},
+		{
+			name:        "Should allow any command for group from identity mapping",
+			executor:    "kubectl",
+			command:     "delete pods nginx -n prod",
+			bindings:    []string{"kubectl-rbac"},
+			identity:    identity.Identity{Username: "alice", Groups: []string{"sre"}},
+			expBindings: []string{"kubectl-rbac"},
+		},
+		{
+			name:        "Should keep bindings without RBAC",
+			executor:    "kubectl",
+			command:     "get pods",
+			bindings:    []string{"kubectl-rbac", "kubectl-open"},
+			userID:      "U-OTHER",
+			expBindings: []string{"kubectl-open"},
+		},
+		{
+			name:        "Should allow Helm command for chat platform user group",
+			executor:    "helm",
+			command:     "helm ls -A",
+			bindings:    []string{"helm-rbac"},
+			userID:      "U-SRE",
+			userGroups:  []string{"S01", "sre"},
+			expBindings: []string{"helm-rbac"},
+		},
+		{
+			name:     "Should deny top report in other Namespace",
+			executor: "top",
+			command:  "top pods -n team-b",
+			bindings: []string{"top-rbac"},
+			userID:   "U-DEV",
+			expErr: "Sorry, your RBAC rules don't allow executing 'top pods' in the 'team-b' Namespace on cluster 'dev'. You are allowed to execute:\n" +
+				"• top-rbac rule #1: verbs: top; resources: pods; Namespaces: team-a",
+		},
+		{
+			name:        "Should return all bindings if RBAC is disabled",
+			executor:    "top",
+			command:     "top nodes",
+			bindings:    []string{"top-open"},
+			userID:      "U-OTHER",
+			expBindings: []string{"top-open"},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			executor := fixRBACExecutor()
+			executor.conversation.ExecutorBindings = tc.bindings
+			executor.userID = tc.userID
+			executor.userGroups = tc.userGroups
+			executor.identity = tc.identity
+
+			// when
+			var (
+				got []string
+				err error
+			)
+			switch tc.executor {
+			case "kubectl":
+				got, err = executor.authorizedBindings(isKubectlEnabled, executor.kubectlExecutor.rbacCommand, tc.command)
+			case "helm":
+				got, err = executor.authorizedBindings(isHelmEnabled, executor.helmExecutor.rbacCommand, tc.command)
+			case "top":
+				got, err = executor.authorizedBindings(isTopEnabled, executor.topExecutor.rbacCommand, tc.command)
+			}
+
+			// then
+			if tc.expErr != "" {
+				require.Error(t, err)
+				assert.True(t, IsExecutionCommandError(err))
+				assert.EqualError(t, err, tc.expErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expBindings, got)
+		})
+	}
+}
+
+func fixRBACExecutor() *DefaultExecutor {
+	allNamespaces := config.Namespaces{Include: []string{".*"}}
+	cfg := config.Config{
+		Settings: config.Settings{ClusterName: "dev"},
+		Executors: map[string]config.Executors{
+			"kubectl-rbac": {
+				Kubectl: config.Kubectl{
+					Enabled:    true,
+					Namespaces: allNamespaces,
+					Commands: config.Commands{
+						Verbs:     []string{"get", "logs", "delete"},
+						Resources: []string{"pods"},
+					},
+				},
+				RBAC: config.RBAC{
+					Enabled: true,
+					Rules: []config.RBACRule{
+						{
+							Name:       "developers",
+							Users:      []string{"U-DEV"},
+							Verbs:      []string{"get", "logs"},
+							Resources:  []string{"pods"},
+							Namespaces: config.Namespaces{Include: []string{"team-a"}},
+						},
+						{
+							Name:   "sre",
+							Groups: []string{"sre"},
+						},
+					},
+				},
+			},
+			"kubectl-open": {
+				Kubectl: config.Kubectl{
+					Enabled:    true,
+					Namespaces: allNamespaces,
+					Commands: config.Commands{
+						Verbs:     []string{"get"},
+						Resources: []string{"pods"},
+					},
+				},
+			},
+			"helm-rbac": {
+				Helm: config.Helm{
+					Enabled:    true,
+					Namespaces: allNamespaces,
+					Verbs:      []string{"list"},
+				},
+				RBAC: config.RBAC{
+					Enabled: true,
+					Rules: []config.RBACRule{
+						{Groups: []string{"sre"}, Verbs: []string{"list"}},
+					},
+				},
+			},
+			"top-rbac": {
+				Top: config.Top{Enabled: true, Namespaces: allNamespaces},
+				RBAC: config.RBAC{
+					Enabled: true,
+					Rules: []config.RBACRule{
+						{
+							Users:      []string{"U-DEV"},
+							Verbs:      []string{"top"},
+							Resources:  []string{"pods"},
+							Namespaces: config.Namespaces{Include: []string{"team-a"}},
+						},
+					},
+				},
+			},
+			"top-open": {
+				Top: config.Top{Enabled: true, Namespaces: allNamespaces},
+			},
+		},
+	}
+
+	logger, _ := logtest.NewNullLogger()
+	return &DefaultExecutor{
+		log:             logger,
+		cfg:             cfg,
+		kubectlExecutor: NewKubectl(logger, cfg, kubectl.NewMerger(cfg.Executors), kubectl.NewChecker(fixPodsResourceVariants), nil),
+		helmExecutor:    NewHelm(logger, cfg, nil),
+		topExecutor:     NewTop(logger, cfg, nil, nil),
+	}
+}
+
+func fixPodsResourceVariants(resource string) []string {
+	if resource == "po" {
+		return []string{"pods"}
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+package execute
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kubeshop/botkube/pkg/bot/interactive"
+)
+
+const remoteNoExecutorBindingsMsgFmt = "Sorry, this channel doesn't have any executor bindings, so it cannot execute commands on %q cluster."
+
+// RemoteCommand is a command forwarded to another cluster.
+type RemoteCommand struct {
+	Command string
+	User    string
+	// BotName is used in the commands rendered in the response, such as the filter input.
+	BotName string
+	Stdin   []byte
+	// ExecutorBindings holds the executor bindings of the channel which sent the command.
+	// The target cluster runs the command only with the executors bound to both the channel and the target cluster.
+	ExecutorBindings []string
+	// UserID and UserGroups are used by the target cluster to evaluate its executor RBAC rules.
+	UserID     string
+	UserGroups []string
+}
+
+// RemoteClusters executes commands on other clusters, such as the ones connected to the Botkube hub.
+type RemoteClusters interface {
+	// IsConnected returns true if a given cluster can execute commands.
+	IsConnected(cluster string) bool
+	// Execute executes a given command on a given cluster and returns its response.
+	Execute(ctx context.Context, cluster string, cmd RemoteCommand) (interactive.Message, error)
+}
+
+// executeOnRemoteCluster forwards a given command to another cluster. The command is authorized by the target cluster,
+// which gets the executor bindings of the current channel, so channels without executor bindings cannot forward commands.
+func (e *DefaultExecutor) executeOnRemoteCluster(ctx context.Context, cluster, rawCmd, botName string) interactive.Message {
+	if len(e.conversation.ExecutorBindings) == 0 {
+		return e.respondWithFailure(fmt.Sprintf(remoteNoExecutorBindingsMsgFmt, cluster), rawCmd, "", botName)
+	}
+
+	msg, err := e.remoteClusters.Execute(ctx, cluster, RemoteCommand{
+		Command:          rawCmd,
+		User:             e.user,
+		BotName:          botName,
+		Stdin:            e.stdin,
+		ExecutorBindings: e.conversation.ExecutorBindings,
+		UserID:           e.userID,
+		UserGroups:       e.userGroups,
+	})
+	if err != nil {
+		e.log.Errorf("while executing command on cluster %q: %s", cluster, err.Error())
+		return e.respond(fmt.Sprintf("Cannot execute command on %q cluster: %s", cluster, err.Error()), rawCmd, "", botName)
+	}
+	return msg
+}
@@ -0,0 +1,373 @@
+package execute
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/sirupsen/logrus"
+	"k8s.io/utils/strings/slices"
+
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/cron"
+)
+
+const (
+	scheduleCommandName = "schedule"
+
+	scheduleDisabledMsgFmt       = "Scheduled commands are disabled on cluster '%s'. Please enable them with the `settings.commandSchedule.enabled` property."
+	scheduleAdded                = "I have scheduled `%s` on '%s' cluster with ID '%s'. Next run: %s."
+	scheduleRemoved              = "Done. I removed the '%s' scheduled command on '%s' cluster."
+	scheduleNoSchedules          = "There are no scheduled commands in this channel on cluster '%s'."
+	scheduleNotFoundMsgFmt       = "Sorry, the '%s' scheduled command is not defined in this channel on cluster '%s'. Use 'schedule list' to see the scheduled commands."
+	scheduleInvalidCronMsgFmt    = "Sorry, the '%s' cron expression is invalid: %s"
+	scheduleNeverRunsMsgFmt      = "Sorry, the '%s' cron expression never matches."
+	scheduleNotSupportedMsgFmt   = "Sorry, scheduled commands are not supported on %s."
+	scheduleNotAllowedMsgFmt     = "Sorry, the `%s` command cannot be scheduled."
+	scheduleConfirmationMsgFmt   = "Sorry, the `%s` command requires confirmation, so it cannot be scheduled."
+	scheduleLimitMsgFmt          = "Sorry, there are already %d scheduled commands in this channel on cluster '%s'. Please remove one of them first."
+	scheduleAddUsageMsg          = `Please specify the cron expression and command, for example: schedule add "0 9 * * 1-5" kubectl get pods -n prod`
+	scheduleRemoveUsageMsg       = "Please specify the scheduled command ID, for example: schedule remove 1a2b3c4d"
+	scheduleUnterminatedQuoteMsg = "Sorry, the cron expression quote is not closed."
+	scheduleTimeFormat           = "2006-01-02 15:04 MST"
+	scheduleIDBytes              = 4
+	scheduleCronFields           = 5
+)
+
+// scheduleSupportedPlatforms holds the platforms on which the bots can post the scheduled command output on their own.
+var scheduleSupportedPlatforms = map[config.CommPlatformIntegration]struct{}{
+	config.SocketSlackCommPlatformIntegration: {},
+	config.MattermostCommPlatformIntegration:  {},
+	config.DiscordCommPlatformIntegration:     {},
+}
+
+// scheduleForbiddenCommands holds the commands which cannot be scheduled, as they make sense only as a direct response.
+var scheduleForbiddenCommands = []string{scheduleCommandName, confirmCommandName, cancelCommandName, "edit", "feedback", configCommandName}
+
+// scheduleAction for options in schedule commands
+type scheduleAction string
+
+// Schedule command options
+const (
+	scheduleList   scheduleAction = "list"
+	scheduleAdd    scheduleAction = "add"
+	scheduleRemove scheduleAction = "remove"
+)
+
+// ScheduledCommand is a command executed periodically.
+type ScheduledCommand struct {
+	ID string
+	config.Schedule
+	// Next is the time of the next execution.
+	Next time.Time
+}
+
+type scheduleEntry struct {
+	schedule config.Schedule
+	cron     *cron.Schedule
+	next     time.Time
+}
+
+// ScheduleStore holds the scheduled commands and tracks when they are due. A single store is shared by all executors
+// and the scheduler which runs the commands.
+type ScheduleStore struct {
+	location *time.Location
+
+	mu      sync.Mutex
+	entries map[string]*scheduleEntry
+	nowFn   func() time.Time
+}
+
+// NewScheduleStore returns a new ScheduleStore instance with given scheduled commands. The commands with invalid cron expressions are skipped.
+func NewScheduleStore(log logrus.FieldLogger, cfg config.CommandSchedule, schedules config.Schedules) (*ScheduleStore, error) {
+	location := time.UTC
+	if cfg.Timezone != "" {
+		var err error
+		location, err = time.LoadLocation(cfg.Timezone)
+		if err != nil {
+			return nil, fmt.Errorf("while loading %q time zone: %w", cfg.Timezone, err)
+		}
+	}
+
+	store := &ScheduleStore{
+		location: location,
+		entries:  map[string]*scheduleEntry{},
+		nowFn:    time.Now,
+	}
+	for id, schedule := range schedules {
+		if err := store.Add(id, schedule); err != nil {
+			log.Warnf("Skipping %q scheduled command: %s", id, err.Error())
+		}
+	}
+	return store, nil
+}
+
+// Add adds a given scheduled command. It returns an error if the cron expression is invalid.
+func (s *ScheduleStore) Add(id string, schedule config.Schedule) error {
+	parsed, err := cron.Parse(schedule.Cron)
+	if err != nil {
+		return fmt.Errorf("while parsing %q cron expression: %w", schedule.Cron, err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.entries[id] = &scheduleEntry{
+		schedule: schedule,
+		cron:     parsed,
+		next:     parsed.Next(s.nowFn().In(s.location)),
+	}
+	return nil
+}
+
+// Get returns a given scheduled command.
+func (s *ScheduleStore) Get(id string) (ScheduledCommand, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry, found := s.entries[id]
+	if !found {
+		return ScheduledCommand{}, false
+	}
+	return ScheduledCommand{ID: id, Schedule: entry.schedule, Next: entry.next}, true
+}
+
+// Remove removes a given scheduled command.
+func (s *ScheduleStore) Remove(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.entries, id)
+}
+
+// List returns the scheduled commands of a given channel, sorted by the next execution time.
+func (s *ScheduleStore) List(commGroupName string, platform config.CommPlatformIntegration, channelID string) []ScheduledCommand {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var out []ScheduledCommand
+	for id, entry := range s.entries {
+		if entry.schedule.CommGroup != commGroupName || entry.schedule.Platform != platform || entry.schedule.ChannelID != channelID {
+			continue
+		}
+		out = append(out, ScheduledCommand{ID: id, Schedule: entry.schedule, Next: entry.next})
+	}
+	sortScheduledCommands(out)
+	return out
+}
+
+// Due returns the scheduled commands which should be executed at a given time, and moves their next execution time forward.
+// If a command missed several executions, e.g. because Botkube was down, it's returned only once.
+func (s *ScheduleStore) Due(now time.Time) []ScheduledCommand {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var out []ScheduledCommand
+	for id, entry := range s.entries {
+		if entry.next.IsZero() || now.Before(entry.next) {
+			continue
+		}
+		out = append(out, ScheduledCommand{ID: id, Schedule: entry.schedule, Next: entry.next})
+		entry.next = entry.cron.Next(now.In(s.location))
+	}
+	sortScheduledCommands(out)
+	return out
+}
+
+func sortScheduledCommands(in []ScheduledCommand) {
+	sort.Slice(in, func(i, j int) bool {
+		if !in[i].Next.Equal(in[j].Next) {
+			return in[i].Next.Before(in[j].Next)
+		}
+		return in[i].ID < in[j].ID
+	})
+}
+
+// runScheduleCommand lists, adds or removes the scheduled commands of the current channel. The changes are persisted, so they survive restarts.
+// The raw command is used to preserve the quoted cron expression and the flags of the scheduled command.
+func (e *DefaultExecutor) runScheduleCommand(ctx context.Context, args []string, rawCmd, clusterName string) (string, error) {
+	if len(args) < 2 {
+		return "", errInvalidCommand
+	}
+
+	var cmdVerb = args[1]
+	defer func() {
+		cmdToReport := fmt.Sprintf("%s %s", args[0], cmdVerb)
+		e.reportCommand(cmdToReport, false)
+	}()
+
+	if e.schedules == nil {
+		return "", NewExecutionCommandError(scheduleDisabledMsgFmt, clusterName)
+	}
+
+	switch scheduleAction(cmdVerb) {
+	case scheduleList:
+		return e.makeSchedulesList(clusterName), nil
+
+	case scheduleAdd:
+		_, rest := cutWord(rawCmd)
+		_, rest = cutWord(rest)
+		cronExpr, cmd, err := parseScheduleArgs(rest)
+		if err != nil {
+			return "", err
+		}
+		return e.addSchedule(ctx, cronExpr, cmd, clusterName)
+
+	case scheduleRemove:
+		if len(args) < 3 {
+			return "", NewExecutionCommandError(scheduleRemoveUsageMsg)
+		}
+		id := args[2]
+		schedule, found := e.schedules.Get(id)
+		if !found || !e.isCurrentChannel(schedule.Schedule) {
+			return "", NewExecutionCommandError(scheduleNotFoundMsgFmt, id, clusterName)
+		}
+
+		e.log.Debugf("Removing %q scheduled command...", id)
+		if err := e.cfgManager.PersistSchedule(ctx, id, nil); err != nil {
+			return "", fmt.Errorf("while persisting removal of %q scheduled command: %w", id, err)
+		}
+		e.schedules.Remove(id)
+
+		return fmt.Sprintf(scheduleRemoved, id, clusterName), nil
+	}
+
+	cmdVerb = anonymizedInvalidVerb // prevent passing any personal information
+	return "", errUnsupportedCommand
+}
+
+func (e *DefaultExecutor) addSchedule(ctx context.Context, cronExpr, cmd, clusterName string) (string, error) {
+	if _, supported := scheduleSupportedPlatforms[e.platform]; !supported {
+		return "", NewExecutionCommandError(scheduleNotSupportedMsgFmt, e.platform)
+	}
+
+	parsed, err := cron.Parse(cronExpr)
+	if err != nil {
+		return "", NewExecutionCommandError(scheduleInvalidCronMsgFmt, cronExpr, err.Error())
+	}
+	if parsed.Next(time.Now()).IsZero() {
+		return "", NewExecutionCommandError(scheduleNeverRunsMsgFmt, cronExpr)
+	}
+
+	expanded, err := e.aliases.Expand(cmd)
+	if err != nil {
+		return "", err
+	}
+	if fields := strings.Fields(expanded); len(fields) > 0 && slices.Contains(scheduleForbiddenCommands, fields[0]) {
+		return "", NewExecutionCommandError(scheduleNotAllowedMsgFmt, fields[0])
+	}
+	if e.requiresConfirmation(expanded) {
+		return "", NewExecutionCommandError(scheduleConfirmationMsgFmt, cmd)
+	}
+
+	maxPerChannel := e.cfg.Settings.CommandSchedule.MaxPerChannel
+	if len(e.schedules.List(e.commGroupName, e.platform, e.conversation.ID)) >= maxPerChannel {
+		return "", NewExecutionCommandError(scheduleLimitMsgFmt, maxPerChannel, clusterName)
+	}
+
+	id, err := newScheduleID()
+	if err != nil {
+		return "", err
+	}
+	schedule := config.Schedule{
+		Cron:      cronExpr,
+		Command:   cmd,
+		CommGroup: e.commGroupName,
+		Platform:  e.platform,
+		ChannelID: e.conversation.ID,
+		User:      e.user,
+		UserID:    e.userID,
+	}
+
+	e.log.Debugf("Adding %q scheduled command...", id)
+	if err := e.cfgManager.PersistSchedule(ctx, id, &schedule); err != nil {
+		return "", fmt.Errorf("while persisting %q scheduled command: %w", id, err)
+	}
+	if err := e.schedules.Add(id, schedule); err != nil {
+		return "", fmt.Errorf("while adding %q scheduled command: %w", id, err)
+	}
+
+	added, _ := e.schedules.Get(id)
+	return fmt.Sprintf(scheduleAdded, cmd, clusterName, id, added.Next.Format(scheduleTimeFormat)), nil
+}
+
+func (e *DefaultExecutor) makeSchedulesList(clusterName string) string {
+	schedules := e.schedules.List(e.commGroupName, e.platform, e.conversation.ID)
+	if len(schedules) == 0 {
+		return fmt.Sprintf(scheduleNoSchedules, clusterName)
+	}
+
+	rows := [][]string{{"ID", "CRON", "NEXT RUN", "USER", "COMMAND"}}
+	for _, schedule := range schedules {
+		rows = append(rows, []string{
+			schedule.ID,
+			schedule.Cron,
+			schedule.Next.Format(scheduleTimeFormat),
+			schedule.User,
+			schedule.Command,
+		})
+	}
+	return renderTable(rows)
+}
+
+func (e *DefaultExecutor) isCurrentChannel(schedule config.Schedule) bool {
+	return schedule.CommGroup == e.commGroupName && schedule.Platform == e.platform && schedule.ChannelID == e.conversation.ID
+}
+
+// parseScheduleArgs splits the `schedule add` arguments into the cron expression and the command. The cron expression can be quoted,
+// e.g. `"0 9 * * 1-5" kubectl get pods`. Otherwise, it's either a macro, such as `@daily`, or its first five words.
+func parseScheduleArgs(in string) (string, string, error) {
+	in = strings.TrimSpace(in)
+	if in == "" {
+		return "", "", NewExecutionCommandError(scheduleAddUsageMsg)
+	}
+
+	var cronExpr, rest string
+	switch quote := in[0]; {
+	case quote == '"' || quote == '\'':
+		end := strings.IndexByte(in[1:], quote)
+		if end < 0 {
+			return "", "", NewExecutionCommandError(scheduleUnterminatedQuoteMsg)
+		}
+		cronExpr, rest = in[1:end+1], in[end+2:]
+	case quote == '@':
+		cronExpr, rest = cutWord(in)
+	default:
+		rest = in
+		var fields []string
+		for i := 0; i < scheduleCronFields; i++ {
+			var word string
+			word, rest = cutWord(rest)
+			fields = append(fields, word)
+		}
+		cronExpr = strings.Join(fields, " ")
+	}
+
+	cmd := unquoteAliasCommand(strings.TrimSpace(rest))
+	if cmd == "" {
+		return "", "", NewExecutionCommandError(scheduleAddUsageMsg)
+	}
+	return strings.TrimSpace(cronExpr), cmd, nil
+}
+
+// cutWord returns the first word of a given string, and the rest of it.
+func cutWord(in string) (string, string) {
+	in = strings.TrimLeftFunc(in, unicode.IsSpace)
+	idx := strings.IndexFunc(in, unicode.IsSpace)
+	if idx < 0 {
+		return in, ""
+	}
+	return in[:idx], in[idx:]
+}
+
+func newScheduleID() (string, error) {
+	raw := make([]byte, scheduleIDBytes)
+	if _, err := rand.Read(raw); err != nil {
+		return "", fmt.Errorf("while generating schedule ID: %w", err)
+	}
+	return hex.EncodeToString(raw), nil
+}
@@ -0,0 +1,300 @@
+package execute
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	logtest "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+func TestScheduleStoreDue(t *testing.T) {
+	// given
+	logger, _ := logtest.NewNullLogger()
+	store, err := NewScheduleStore(logger, config.CommandSchedule{Enabled: true, MaxPerChannel: 5}, nil)
+	require.NoError(t, err)
+	now := time.Date(2022, 10, 3, 8, 15, 0, 0, time.UTC)
+	store.nowFn = func() time.Time { return now }
+
+	require.NoError(t, store.Add("a1", config.Schedule{Cron: "*/10 * * * *", Command: "kubectl get pods"}))
+	require.NoError(t, store.Add("b2", config.Schedule{Cron: "0 9 * * *", Command: "kubectl get nodes"}))
+	assert.Error(t, store.Add("c3", config.Schedule{Cron: "invalid", Command: "kubectl get svc"}))
+
+	// when
+	due := store.Due(now.Add(time.Minute))
+
+	// then
+	assert.Empty(t, due)
+
+	// when
+	due = store.Due(now.Add(11 * time.Minute))
+
+	// then
+	require.Len(t, due, 1)
+	assert.Equal(t, "a1", due[0].ID)
+	assert.Equal(t, time.Date(2022, 10, 3, 8, 20, 0, 0, time.UTC), due[0].Next)
+	assert.Empty(t, store.Due(now.Add(11*time.Minute)), "command must not be returned again before its next execution")
+
+	// when
+	due = store.Due(now.Add(48 * time.Hour))
+
+	// then
+	require.Len(t, due, 2)
+	assert.Equal(t, "a1", due[0].ID, "missed executions must be returned once, in the order of their next execution time")
+	assert.Equal(t, "b2", due[1].ID)
+}
+
+func TestNewScheduleStoreInvalidTimezone(t *testing.T) {
+	// given
+	logger, _ := logtest.NewNullLogger()
+
+	// when
+	_, err := NewScheduleStore(logger, config.CommandSchedule{Timezone: "Mars/Olympus"}, nil)
+
+	// then
+	assert.EqualError(t, err, `while loading "Mars/Olympus" time zone: unknown time zone Mars/Olympus`)
+}
+
+func TestParseScheduleArgs(t *testing.T) {
+	// given
+	tests := []struct {
+		name string
+
+		in string
+
+		expCron     string
+		expCommand  string
+		expErrorMsg string
+	}{
+		{
+			name:       "Quoted cron expression",
+			in:         `"0 9 * * 1-5" kubectl get pods -n prod`,
+			expCron:    "0 9 * * 1-5",
+			expCommand: "kubectl get pods -n prod",
+		},
+		{
+			name:       "Unquoted cron expression",
+			in:         `*/5  * * * *   kubectl logs nginx --filter="error"`,
+			expCron:    "*/5 * * * *",
+			expCommand: `kubectl logs nginx --filter="error"`,
+		},
+		{
+			name:       "Macro",
+			in:         `@hourly "kubectl top pods"`,
+			expCron:    "@hourly",
+			expCommand: "kubectl top pods",
+		},
+		{
+			name:        "Missing command",
+			in:          `'0 9 * * *'`,
+			expErrorMsg: scheduleAddUsageMsg,
+		},
+		{
+			name:        "Unterminated quote",
+			in:          `"0 9 * * * kubectl get pods`,
+			expErrorMsg: "Sorry, the cron expression quote is not closed.",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// when
+			cronExpr, cmd, err := parseScheduleArgs(tc.in)
+
+			// then
+			if tc.expErrorMsg != "" {
+				require.Error(t, err)
+				assert.True(t, IsExecutionCommandError(err))
+				assert.EqualError(t, err, tc.expErrorMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expCron, cronExpr)
+			assert.Equal(t, tc.expCommand, cmd)
+		})
+	}
+}
+
+func TestDefaultExecutorRunScheduleCommand(t *testing.T) {
+	// given
+	logger, _ := logtest.NewNullLogger()
+	persistence := &fakeSchedulePersistenceManager{}
+	store, err := NewScheduleStore(logger, config.CommandSchedule{Enabled: true, MaxPerChannel: 1}, config.Schedules{
+		"ffff0000": {Cron: "@daily", Command: "kubectl get nodes", CommGroup: "default", Platform: config.SocketSlackCommPlatformIntegration, ChannelID: "other"},
+	})
+	require.NoError(t, err)
+	store.nowFn = func() time.Time { return time.Date(2022, 10, 3, 8, 15, 0, 0, time.UTC) }
+
+	executor := &DefaultExecutor{
+		log:               logger,
+		cfg:               config.Config{Settings: config.Settings{CommandSchedule: config.CommandSchedule{Enabled: true, MaxPerChannel: 1}}},
+		analyticsReporter: &fakeAnalyticsReporter{},
+		cfgManager:        persistence,
+		aliases:           NewAliasStore(logger, nil),
+		schedules:         store,
+		commGroupName:     "default",
+		platform:          config.SocketSlackCommPlatformIntegration,
+		conversation:      Conversation{ID: "alerts"},
+		user:              "<@U123>",
+		userID:            "U123",
+	}
+	rawCmd := `schedule add "0 9 * * 1-5" kubectl get pods -n prod`
+
+	// when
+	out, err := executor.runScheduleCommand(context.Background(), []string{"schedule", "add"}, rawCmd, "dev")
+
+	// then
+	require.NoError(t, err)
+	require.Len(t, persistence.schedules, 1)
+	var id string
+	for key, schedule := range persistence.schedules {
+		id = key
+		assert.Equal(t, config.Schedule{
+			Cron:      "0 9 * * 1-5",
+			Command:   "kubectl get pods -n prod",
+			CommGroup: "default",
+			Platform:  config.SocketSlackCommPlatformIntegration,
+			ChannelID: "alerts",
+			User:      "<@U123>",
+			UserID:    "U123",
+		}, *schedule)
+	}
+	assert.Equal(t, "I have scheduled `kubectl get pods -n prod` on 'dev' cluster with ID '"+id+"'. Next run: 2022-10-03 09:00 UTC.", out)
+
+	// when
+	_, err = executor.runScheduleCommand(context.Background(), []string{"schedule", "add"}, `schedule add @daily kubectl get svc`, "dev")
+
+	// then
+	assert.EqualError(t, err, "Sorry, there are already 1 scheduled commands in this channel on cluster 'dev'. Please remove one of them first.")
+
+	// when
+	out, err = executor.runScheduleCommand(context.Background(), []string{"schedule", "list"}, "schedule list", "dev")
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, "ID        CRON         NEXT RUN              USER     COMMAND\n"+
+		id+"  0 9 * * 1-5  2022-10-03 09:00 UTC  <@U123>  kubectl get pods -n prod\n", out)
+
+	// when
+	_, err = executor.runScheduleCommand(context.Background(), []string{"schedule", "remove", "ffff0000"}, "schedule remove ffff0000", "dev")
+
+	// then
+	assert.EqualError(t, err, "Sorry, the 'ffff0000' scheduled command is not defined in this channel on cluster 'dev'. Use 'schedule list' to see the scheduled commands.")
+
+	// when
+	out, err = executor.runScheduleCommand(context.Background(), []string{"schedule", "remove", id}, "schedule remove "+id, "dev")
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, "Done. I removed the '"+id+"' scheduled command on 'dev' cluster.", out)
+	assert.Empty(t, persistence.schedules)
+	assert.Empty(t, store.List("default", config.SocketSlackCommPlatformIntegration, "alerts"))
+}
+
+func TestDefaultExecutorRunScheduleCommandErrors(t *testing.T) {
+	// given
+	tests := []struct {
+		name string
+
+		rawCmd   string
+		platform config.CommPlatformIntegration
+
+		expErrorMsg string
+	}{
+		{
+			name:        "Invalid cron expression",
+			rawCmd:      `schedule add "0 25 * * *" kubectl get pods`,
+			platform:    config.SocketSlackCommPlatformIntegration,
+			expErrorMsg: `Sorry, the '0 25 * * *' cron expression is invalid: invalid hour "25": value must be between 0 and 23`,
+		},
+		{
+			name:        "Command requiring confirmation",
+			rawCmd:      `schedule add @daily kubectl delete pod nginx`,
+			platform:    config.SocketSlackCommPlatformIntegration,
+			expErrorMsg: "Sorry, the `kubectl delete pod nginx` command requires confirmation, so it cannot be scheduled.",
+		},
+		{
+			name:        "Nested schedule",
+			rawCmd:      `schedule add @daily schedule list`,
+			platform:    config.SocketSlackCommPlatformIntegration,
+			expErrorMsg: "Sorry, the `schedule` command cannot be scheduled.",
+		},
+		{
+			name:        "Unsupported platform",
+			rawCmd:      `schedule add @daily kubectl get pods`,
+			platform:    config.WebhookCommPlatformIntegration,
+			expErrorMsg: "Sorry, scheduled commands are not supported on webhook.",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			logger, _ := logtest.NewNullLogger()
+			store, err := NewScheduleStore(logger, config.CommandSchedule{Enabled: true, MaxPerChannel: 5}, nil)
+			require.NoError(t, err)
+			executor := &DefaultExecutor{
+				log: logger,
+				cfg: config.Config{Settings: config.Settings{
+					CommandSchedule: config.CommandSchedule{Enabled: true, MaxPerChannel: 5},
+					CommandConfirmation: config.CommandConfirmation{
+						Enabled:  true,
+						Commands: []string{"delete"},
+						Timeout:  time.Minute,
+					},
+				}},
+				analyticsReporter: &fakeAnalyticsReporter{},
+				cfgManager:        &fakeSchedulePersistenceManager{},
+				aliases:           NewAliasStore(logger, nil),
+				schedules:         store,
+				platform:          tc.platform,
+				conversation:      Conversation{ID: "alerts"},
+			}
+
+			// when
+			_, err = executor.runScheduleCommand(context.Background(), []string{"schedule", "add"}, tc.rawCmd, "dev")
+
+			// then
+			require.Error(t, err)
+			assert.True(t, IsExecutionCommandError(err))
+			assert.EqualError(t, err, tc.expErrorMsg)
+			assert.Empty(t, store.List("", tc.platform, "alerts"))
+		})
+	}
+}
+
+func TestDefaultExecutorRunScheduleCommandDisabled(t *testing.T) {
+	// given
+	logger, _ := logtest.NewNullLogger()
+	executor := &DefaultExecutor{
+		log:               logger,
+		analyticsReporter: &fakeAnalyticsReporter{},
+	}
+
+	// when
+	_, err := executor.runScheduleCommand(context.Background(), []string{"schedule", "list"}, "schedule list", "dev")
+
+	// then
+	require.Error(t, err)
+	assert.True(t, IsExecutionCommandError(err))
+	assert.EqualError(t, err, "Scheduled commands are disabled on cluster 'dev'. Please enable them with the `settings.commandSchedule.enabled` property.")
+}
+
+type fakeSchedulePersistenceManager struct {
+	fakeCfgPersistenceManager
+	schedules map[string]*config.Schedule
+}
+
+func (f *fakeSchedulePersistenceManager) PersistSchedule(_ context.Context, id string, schedule *config.Schedule) error {
+	if f.schedules == nil {
+		f.schedules = map[string]*config.Schedule{}
+	}
+	if schedule == nil {
+		delete(f.schedules, id)
+		return nil
+	}
+	f.schedules[id] = schedule
+	return nil
+}
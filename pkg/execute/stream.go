@@ -0,0 +1,45 @@
+package execute
+
+import (
+	"context"
+
+	"github.com/kubeshop/botkube/pkg/bot/interactive"
+)
+
+// watchStartedCtxKey is the context key of the handler called once a command starts watching resources.
+type watchStartedCtxKey struct{}
+
+// OutputChunkHandler handles an incremental output chunk emitted by a long-running command.
+type OutputChunkHandler func(chunk string)
+
+// StreamingExecutor is an Executor that can emit incremental output chunks while the command is still running.
+// It allows the bots to show the command progress, e.g. by updating a single message in place.
+type StreamingExecutor interface {
+	Executor
+
+	// ExecuteStream executes a command and calls the handler each time a new output chunk is available.
+	// The handler is called sequentially. The returned message is the final command response.
+	ExecuteStream(ctx context.Context, handleChunk OutputChunkHandler) interactive.Message
+}
+
+var _ StreamingExecutor = &DefaultExecutor{}
+
+// WithWatchStartedHandler returns a copy of a given context with a handler which is called once the command starts watching resources,
+// e.g. `kubectl get pods --watch`. Such command runs until it's stopped or times out, so the bot can offer a way to stop it.
+func WithWatchStartedHandler(ctx context.Context, handler func()) context.Context {
+	return context.WithValue(ctx, watchStartedCtxKey{}, handler)
+}
+
+// NotifyWatchStarted calls the handler set with WithWatchStartedHandler, if any. Executors call it before they start watching resources.
+func NotifyWatchStarted(ctx context.Context) {
+	if handler, ok := ctx.Value(watchStartedCtxKey{}).(func()); ok && handler != nil {
+		handler()
+	}
+}
+
+// ExecuteStream executes commands and returns the final output.
+// Currently, only the `kubectl logs`, `kubectl exec` and `kubectl get --watch` commands emit incremental output chunks.
+func (e *DefaultExecutor) ExecuteStream(ctx context.Context, handleChunk OutputChunkHandler) interactive.Message {
+	e.handleChunk = handleChunk
+	return e.Execute(ctx)
+}
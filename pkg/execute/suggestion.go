@@ -0,0 +1,202 @@
+package execute
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"k8s.io/utils/strings/slices"
+
+	"github.com/kubeshop/botkube/pkg/bot/interactive"
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+const (
+	suggestionRunBtnName = "Run"
+	// suggestionMinWordLen is the minimum length of a word which is corrected. Shorter words, such as `k` or `po`, are too ambiguous.
+	suggestionMinWordLen = 3
+	// suggestionLongWordLen is the length of a word for which two typos are allowed.
+	suggestionLongWordLen = 6
+)
+
+// suggestCommand returns a given command with the misspelled command name, kubectl verb and resource replaced with the closest known ones,
+// e.g. `kubectl get deployments` for `kubectl gte deploymnets`. It returns an empty string if there is nothing to correct.
+func (e *DefaultExecutor) suggestCommand(args []string) string {
+	args = removeClusterFlags(args)
+	if len(args) == 0 {
+		return ""
+	}
+
+	bindings := e.conversation.ExecutorBindings
+	verbs := sortedKeys(e.merger.MergeAllEnabledVerbs(bindings))
+	resources := sortedKeys(e.merger.MergeAllEnabled(bindings).AllowedKubectlResource)
+
+	out := append([]string{}, args...)
+	changed := false
+	correct := func(idx int, candidates []string) {
+		// the resource can be followed by its name, e.g. `deploy/nginx`
+		word, name, hasName := strings.Cut(out[idx], "/")
+		fixed, ok := closestWord(word, candidates)
+		if !ok {
+			return
+		}
+		if hasName {
+			fixed = fmt.Sprintf("%s/%s", fixed, name)
+		}
+		out[idx] = fixed
+		changed = true
+	}
+
+	correct(0, append(e.suggestedCommandNames(), verbs...))
+
+	verbIdx := -1
+	switch {
+	case slices.Contains(kubectlAlias, out[0]):
+		verbIdx = 1
+	case slices.Contains(verbs, out[0]):
+		verbIdx = 0
+	}
+	if verbIdx > 0 && verbIdx < len(out) {
+		correct(verbIdx, verbs)
+	}
+	if resIdx := verbIdx + 1; verbIdx >= 0 && resIdx < len(out) && !strings.HasPrefix(out[resIdx], "-") {
+		correct(resIdx, resources)
+	}
+
+	if !changed {
+		return ""
+	}
+	return strings.Join(out, " ")
+}
+
+// suggestedCommandNames returns the names of the commands which can be executed in the current conversation.
+func (e *DefaultExecutor) suggestedCommandNames() []string {
+	out := []string{"help", "ping", "version", "filters", "commands", "notifier", "edit", "feedback", "audit", "alias", queueCommandName, scheduleCommandName, configCommandName}
+	out = append(out, kubectlAlias...)
+
+	for name, isEnabled := range map[string]func(config.Executors) bool{
+		"helm":            isHelmEnabled,
+		topCommandName:    isTopEnabled,
+		diagCommandName:   isDiagEnabled,
+		eventsCommandName: isEventsEnabled,
+	} {
+		if e.isEnabledInBindings(isEnabled) {
+			out = append(out, name)
+		}
+	}
+
+	for _, binding := range e.conversation.ExecutorBindings {
+		for name, plugin := range e.cfg.Executors[binding].Plugins {
+			if plugin.Enabled {
+				out = append(out, name)
+			}
+		}
+	}
+
+	if e.aliases != nil {
+		for _, alias := range e.aliases.List() {
+			out = append(out, alias.Name)
+		}
+	}
+
+	sort.Strings(out)
+	return out
+}
+
+func (e *DefaultExecutor) isEnabledInBindings(isEnabled func(config.Executors) bool) bool {
+	for _, name := range e.conversation.ExecutorBindings {
+		if isEnabled(e.cfg.Executors[name]) {
+			return true
+		}
+	}
+	return false
+}
+
+// respondWithSuggestion returns a response for a failed command, which suggests a corrected command with a button to run it.
+func (e *DefaultExecutor) respondWithSuggestion(msg, suggestion, rawCmd, filteredCmd, botName string) interactive.Message {
+	out := e.respondWithFailure(msg, rawCmd, filteredCmd, botName)
+
+	btnBuilder := interactive.ButtonBuilder{BotName: botName}
+	cmd := fmt.Sprintf("%s %s %s", suggestion, ClusterFlag, e.cfg.Settings.ClusterName)
+	out.Sections = append(out.Sections, interactive.Section{
+		Base: interactive.Base{
+			Body: interactive.Body{
+				Plaintext: e.localizer.Sprintf(e.conversation.Locale, interactive.CommandSuggestionMsg, suggestion),
+			},
+		},
+		Buttons: interactive.Buttons{
+			btnBuilder.ForCommandWithoutDesc(suggestionRunBtnName, cmd, interactive.ButtonStylePrimary),
+		},
+	})
+	return out
+}
+
+// closestWord returns the candidate closest to a given misspelled word. It returns false if the word is one of the candidates,
+// or if there is no candidate close enough.
+func closestWord(word string, candidates []string) (string, bool) {
+	if len(word) < suggestionMinWordLen || slices.Contains(candidates, word) {
+		return "", false
+	}
+
+	maxDistance := 1
+	if len(word) >= suggestionLongWordLen {
+		maxDistance = 2
+	}
+
+	var (
+		best         string
+		bestDistance = maxDistance + 1
+	)
+	for _, candidate := range candidates {
+		if distance := editDistance(word, candidate); distance < bestDistance {
+			best, bestDistance = candidate, distance
+		}
+	}
+	return best, best != ""
+}
+
+// editDistance returns the Levenshtein distance of given words, where the transposition of two adjacent characters counts as a single edit.
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	// rows holds the last three rows of the distance matrix
+	rows := [3][]int{make([]int, len(rb)+1), make([]int, len(rb)+1), make([]int, len(rb)+1)}
+	for j := range rows[1] {
+		rows[1][j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		prevPrev, prev, cur := rows[0], rows[1], rows[2]
+		cur[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = minInt(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				cur[j] = minInt(cur[j], prevPrev[j-2]+1)
+			}
+		}
+		rows = [3][]int{prev, cur, prevPrev}
+	}
+	return rows[1][len(rb)]
+}
+
+func minInt(first int, rest ...int) int {
+	out := first
+	for _, v := range rest {
+		if v < out {
+			out = v
+		}
+	}
+	return out
+}
+
+func sortedKeys(in map[string]struct{}) []string {
+	out := make([]string, 0, len(in))
+	for key := range in {
+		out = append(out, key)
+	}
+	sort.Strings(out)
+	return out
+}
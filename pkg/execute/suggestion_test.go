@@ -0,0 +1,161 @@
+package execute
+
+import (
+	"testing"
+
+	logtest "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/pkg/bot/interactive"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/execute/audit"
+	"github.com/kubeshop/botkube/pkg/execute/kubectl"
+)
+
+func TestEditDistance(t *testing.T) {
+	tests := []struct {
+		a, b        string
+		expDistance int
+	}{
+		{a: "get", b: "get", expDistance: 0},
+		{a: "gte", b: "get", expDistance: 1},
+		{a: "gt", b: "get", expDistance: 1},
+		{a: "deploymnets", b: "deployments", expDistance: 1},
+		{a: "pdos", b: "pods", expDistance: 1},
+		{a: "describe", b: "get", expDistance: 7},
+		{a: "", b: "logs", expDistance: 4},
+	}
+	for _, tc := range tests {
+		t.Run(tc.a+" "+tc.b, func(t *testing.T) {
+			assert.Equal(t, tc.expDistance, editDistance(tc.a, tc.b))
+			assert.Equal(t, tc.expDistance, editDistance(tc.b, tc.a))
+		})
+	}
+}
+
+func TestDefaultExecutorSuggestCommand(t *testing.T) {
+	// given
+	tests := []struct {
+		name string
+
+		args          []string
+		expSuggestion string
+	}{
+		{
+			name:          "Should correct kubectl verb and resource",
+			args:          []string{"kubectl", "gte", "deploymnets"},
+			expSuggestion: "kubectl get deployments",
+		},
+		{
+			name:          "Should correct verb without kubectl alias",
+			args:          []string{"descrbie", "pods/nginx", "-n", "default"},
+			expSuggestion: "describe pods/nginx -n default",
+		},
+		{
+			name:          "Should correct resource with name",
+			args:          []string{"k", "get", "deploymnets/nginx"},
+			expSuggestion: "k get deployments/nginx",
+		},
+		{
+			name:          "Should correct command name",
+			args:          []string{"verison"},
+			expSuggestion: "version",
+		},
+		{
+			name:          "Should correct kubectl alias",
+			args:          []string{"kubeclt", "get", "pods"},
+			expSuggestion: "kubectl get pods",
+		},
+		{
+			name:          "Should correct user alias",
+			args:          []string{"kgpp"},
+			expSuggestion: "kgp",
+		},
+		{
+			name:          "Should skip cluster name",
+			args:          []string{"gte", "pods", "--cluster-name", "dev"},
+			expSuggestion: "get pods",
+		},
+		{
+			name: "Should not suggest for valid command",
+			args: []string{"kubectl", "get", "pods"},
+		},
+		{
+			name: "Should not suggest for short words",
+			args: []string{"kubectl", "gt", "po"},
+		},
+		{
+			name: "Should not suggest for words which are too different",
+			args: []string{"kubectl", "apply", "secrets"},
+		},
+		{
+			name: "Should not suggest disabled commands",
+			args: []string{"hlm", "list"},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			executor := fixSuggestionExecutor(t)
+
+			// when
+			suggestion := executor.suggestCommand(tc.args)
+
+			// then
+			assert.Equal(t, tc.expSuggestion, suggestion)
+		})
+	}
+}
+
+func TestDefaultExecutorRespondWithSuggestion(t *testing.T) {
+	// given
+	executor := fixSuggestionExecutor(t)
+
+	// when
+	msg := executor.respondWithSuggestion("Command not supported.", "kubectl get deployments", "kubectl gte deploymnets", "kubectl gte deploymnets", "@Botkube")
+
+	// then
+	assert.Equal(t, audit.StatusFailed, executor.auditStatus)
+	assert.Equal(t, "`kubectl gte deploymnets` on `dev`", msg.Description)
+	assert.Equal(t, "Command not supported.", msg.Body.CodeBlock)
+	require.Len(t, msg.Sections, 1)
+	assert.Equal(t, "Did you mean `kubectl get deployments`?", msg.Sections[0].Body.Plaintext)
+	assert.Equal(t, interactive.Buttons{
+		{
+			Name:    "Run",
+			Command: "@Botkube kubectl get deployments --cluster-name dev",
+			Style:   interactive.ButtonStylePrimary,
+		},
+	}, msg.Sections[0].Buttons)
+}
+
+func fixSuggestionExecutor(t *testing.T) *DefaultExecutor {
+	t.Helper()
+
+	executors := map[string]config.Executors{
+		"kubectl-read-only": {
+			Kubectl: config.Kubectl{
+				Enabled: true,
+				Commands: config.Commands{
+					Verbs:     []string{"get", "describe", "logs"},
+					Resources: []string{"pods", "deployments", "services"},
+				},
+			},
+		},
+		"helm": {
+			Helm: config.Helm{Enabled: false},
+		},
+	}
+
+	localizer, err := interactive.NewLocalizer()
+	require.NoError(t, err)
+	logger, _ := logtest.NewNullLogger()
+	return &DefaultExecutor{
+		log:          logger,
+		cfg:          config.Config{Settings: config.Settings{ClusterName: "dev"}, Executors: executors},
+		merger:       kubectl.NewMerger(executors),
+		localizer:    localizer,
+		aliases:      NewAliasStore(logger, config.Aliases{"kgp": "kubectl get pods"}),
+		conversation: Conversation{ExecutorBindings: []string{"kubectl-read-only", "helm"}},
+	}
+}
@@ -0,0 +1,411 @@
+package execute
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/mattn/go-shellwords"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/pflag"
+	coreV1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/sources/nodes"
+)
+
+const (
+	topCommandName      = "top"
+	topNodesCmd         = "nodes"
+	topPodsCmd          = "pods"
+	topCapacityCmd      = "capacity"
+	topDefaultNamespace = "default"
+	topNoNodePool       = "<none>"
+
+	topUsageMsg                  = "Please specify what to report, e.g. 'top nodes', 'top pods -n <namespace>' or 'top capacity'."
+	topNotAllowedNsMsgFmt        = "Sorry, the Pods usage cannot be reported for the '%s' Namespace on cluster '%s'."
+	topNotAllowedAllNsMsgFmt     = "Sorry, the Pods usage cannot be reported for all Namespaces on cluster '%s'."
+	topMetricsNotAvailableMsgFmt = "Sorry, the resource metrics are not available on cluster '%s'. Please make sure that the metrics-server is installed."
+	topNoMetricsMsg              = "No resource metrics found."
+	topActivePodsSelector        = "status.phase!=Succeeded,status.phase!=Failed"
+)
+
+// topMemoryUnit is the memory unit used in the reports, which is Mi.
+const topMemoryUnit float64 = 1 << 20
+
+var (
+	nodeMetricsGVR = schema.GroupVersionResource{Group: "metrics.k8s.io", Version: "v1beta1", Resource: "nodes"}
+	podMetricsGVR  = schema.GroupVersionResource{Group: "metrics.k8s.io", Version: "v1beta1", Resource: "pods"}
+)
+
+// topCommandAliases maps the report names and their aliases to the report names.
+var topCommandAliases = map[string]string{
+	"node":     topNodesCmd,
+	"no":       topNodesCmd,
+	"pod":      topPodsCmd,
+	"po":       topPodsCmd,
+	"capacity": topCapacityCmd,
+	"nodes":    topNodesCmd,
+	"pods":     topPodsCmd,
+}
+
+// defaultNodePoolLabels holds the node pool labels of the managed Kubernetes services.
+var defaultNodePoolLabels = []string{
+	"cloud.google.com/gke-nodepool",
+	"eks.amazonaws.com/nodegroup",
+	"kubernetes.azure.com/agentpool",
+	"agentpool",
+	"karpenter.sh/provisioner-name",
+}
+
+// resourceMetrics holds the resource usage reported by the metrics-server.
+type resourceMetrics struct {
+	Metadata   metaV1.ObjectMeta   `json:"metadata"`
+	Usage      coreV1.ResourceList `json:"usage"`
+	Containers []struct {
+		Usage coreV1.ResourceList `json:"usage"`
+	} `json:"containers"`
+}
+
+// usage returns the resource usage. For Pods, it's the sum of the containers usage.
+func (m resourceMetrics) usage() coreV1.ResourceList {
+	if len(m.Containers) == 0 {
+		return m.Usage
+	}
+
+	out := coreV1.ResourceList{}
+	for _, container := range m.Containers {
+		for name, quantity := range container.Usage {
+			sum := out[name]
+			sum.Add(quantity)
+			out[name] = sum
+		}
+	}
+	return out
+}
+
+// nodePoolCapacity holds the resources requested on the nodes from a given pool and the allocatable ones.
+type nodePoolCapacity struct {
+	Nodes                             int
+	CPURequests, CPUAllocatable       resource.Quantity
+	MemoryRequests, MemoryAllocatable resource.Quantity
+	PodsScheduled, PodsAllocatable    int64
+}
+
+// Top reports the resource usage of the nodes and Pods, and the cluster capacity.
+type Top struct {
+	log        logrus.FieldLogger
+	cfg        config.Config
+	k8sCli     kubernetes.Interface
+	dynamicCli dynamic.Interface
+}
+
+// NewTop creates a new instance of Top.
+func NewTop(log logrus.FieldLogger, cfg config.Config, k8sCli kubernetes.Interface, dynamicCli dynamic.Interface) *Top {
+	return &Top{
+		log:        log,
+		cfg:        cfg,
+		k8sCli:     k8sCli,
+		dynamicCli: dynamicCli,
+	}
+}
+
+// CanHandle returns true if it's a top command and at least one Top executor is enabled for given bindings.
+func (e *Top) CanHandle(bindings []string, args []string) bool {
+	if len(args) == 0 || args[0] != topCommandName || e.k8sCli == nil || e.dynamicCli == nil {
+		return false
+	}
+
+	for _, name := range bindings {
+		if e.cfg.Executors[name].Top.Enabled {
+			return true
+		}
+	}
+	return false
+}
+
+// GetCommandPrefix gets the top command with its report name, e.g. `top nodes`.
+func (e *Top) GetCommandPrefix(args []string) string {
+	if len(args) < 2 {
+		return topCommandName
+	}
+
+	report, found := topCommandAliases[args[1]]
+	if !found {
+		report = anonymizedInvalidVerb
+	}
+	return fmt.Sprintf("%s %s", topCommandName, report)
+}
+
+// Execute executes a given top command and renders the report as a table.
+//
+// This method should be called ONLY if:
+// - we are a target cluster,
+// - and Top.CanHandle returned true.
+func (e *Top) Execute(ctx context.Context, bindings []string, command string) (string, error) {
+	log := e.log.WithField("command", command)
+	log.Debugf("Handling command...")
+
+	args, err := shellwords.Parse(strings.TrimSpace(command))
+	if err != nil {
+		return "", fmt.Errorf("while parsing the command message into args: %w", err)
+	}
+	args = removeClusterFlags(args[1:])
+	if len(args) == 0 {
+		return "", NewExecutionCommandError(topUsageMsg)
+	}
+
+	switch topCommandAliases[args[0]] {
+	case topNodesCmd:
+		return e.topNodes(ctx)
+	case topPodsCmd:
+		return e.topPods(ctx, bindings, args[1:])
+	case topCapacityCmd:
+		return e.capacity(ctx, bindings)
+	}
+	return "", NewExecutionCommandError(topUsageMsg)
+}
+
+func (e *Top) topNodes(ctx context.Context) (string, error) {
+	metrics, err := e.listMetrics(ctx, nodeMetricsGVR, metaV1.NamespaceAll)
+	if err != nil {
+		return "", err
+	}
+	if len(metrics) == 0 {
+		return topNoMetricsMsg, nil
+	}
+
+	nodeList, err := e.k8sCli.CoreV1().Nodes().List(ctx, metaV1.ListOptions{})
+	if err != nil {
+		return "", fmt.Errorf("while listing nodes: %w", err)
+	}
+	allocatable := map[string]coreV1.ResourceList{}
+	for _, node := range nodeList.Items {
+		allocatable[node.Name] = node.Status.Allocatable
+	}
+
+	rows := [][]string{{"NAME", "CPU(cores)", "CPU%", "MEMORY(bytes)", "MEMORY%"}}
+	for _, m := range metrics {
+		usage := m.usage()
+		alloc := allocatable[m.Metadata.Name]
+		rows = append(rows, []string{
+			m.Metadata.Name,
+			formatCPU(*usage.Cpu()),
+			formatPercent(float64(usage.Cpu().MilliValue()), float64(alloc.Cpu().MilliValue())),
+			formatMemory(*usage.Memory()),
+			formatPercent(float64(usage.Memory().Value()), float64(alloc.Memory().Value())),
+		})
+	}
+	return renderTable(rows), nil
+}
+
+func (e *Top) topPods(ctx context.Context, bindings []string, args []string) (string, error) {
+	namespace, allNamespaces, err := parseTopPodsFlags(args)
+	if err != nil {
+		return "", fmt.Errorf("while parsing flags: %w", err)
+	}
+
+	clusterName := e.cfg.Settings.ClusterName
+	switch {
+	case allNamespaces:
+		if !e.isNamespaceAllowed(bindings, config.AllNamespaceIndicator) {
+			return "", NewExecutionCommandError(topNotAllowedAllNsMsgFmt, clusterName)
+		}
+		namespace = metaV1.NamespaceAll
+	case namespace == "":
+		namespace = topDefaultNamespace
+		fallthrough
+	default:
+		if !e.isNamespaceAllowed(bindings, namespace) {
+			return "", NewExecutionCommandError(topNotAllowedNsMsgFmt, namespace, clusterName)
+		}
+	}
+
+	metrics, err := e.listMetrics(ctx, podMetricsGVR, namespace)
+	if err != nil {
+		return "", err
+	}
+	if len(metrics) == 0 {
+		return topNoMetricsMsg, nil
+	}
+
+	header := []string{"NAME", "CPU(cores)", "MEMORY(bytes)"}
+	if allNamespaces {
+		header = append([]string{"NAMESPACE"}, header...)
+	}
+	rows := [][]string{header}
+	for _, m := range metrics {
+		usage := m.usage()
+		row := []string{m.Metadata.Name, formatCPU(*usage.Cpu()), formatMemory(*usage.Memory())}
+		if allNamespaces {
+			row = append([]string{m.Metadata.Namespace}, row...)
+		}
+		rows = append(rows, row)
+	}
+	return renderTable(rows), nil
+}
+
+// capacity summarizes the resources requested by the active Pods versus the allocatable resources per node pool.
+func (e *Top) capacity(ctx context.Context, bindings []string) (string, error) {
+	nodeList, err := e.k8sCli.CoreV1().Nodes().List(ctx, metaV1.ListOptions{})
+	if err != nil {
+		return "", fmt.Errorf("while listing nodes: %w", err)
+	}
+	podList, err := e.k8sCli.CoreV1().Pods(metaV1.NamespaceAll).List(ctx, metaV1.ListOptions{
+		FieldSelector: topActivePodsSelector,
+	})
+	if err != nil {
+		return "", fmt.Errorf("while listing Pods: %w", err)
+	}
+
+	poolLabels := e.findNodePoolLabels(bindings)
+	poolPerNode := map[string]string{}
+	pools := map[string]*nodePoolCapacity{}
+	for _, node := range nodeList.Items {
+		pool := nodePool(node, poolLabels)
+		poolPerNode[node.Name] = pool
+		if pools[pool] == nil {
+			pools[pool] = &nodePoolCapacity{}
+		}
+		capacity := pools[pool]
+		capacity.Nodes++
+		capacity.CPUAllocatable.Add(*node.Status.Allocatable.Cpu())
+		capacity.MemoryAllocatable.Add(*node.Status.Allocatable.Memory())
+		capacity.PodsAllocatable += node.Status.Allocatable.Pods().Value()
+	}
+
+	for _, pod := range podList.Items {
+		pool, found := poolPerNode[pod.Spec.NodeName]
+		if !found || pod.Status.Phase == coreV1.PodSucceeded || pod.Status.Phase == coreV1.PodFailed {
+			continue
+		}
+		capacity := pools[pool]
+		requests := nodes.PodRequests(pod)
+		capacity.CPURequests.Add(*requests.Cpu())
+		capacity.MemoryRequests.Add(*requests.Memory())
+		capacity.PodsScheduled++
+	}
+
+	poolNames := make([]string, 0, len(pools))
+	for name := range pools {
+		poolNames = append(poolNames, name)
+	}
+	sort.Strings(poolNames)
+
+	rows := [][]string{{"NODE POOL", "NODES", "CPU REQUESTS", "CPU ALLOCATABLE", "CPU%", "MEMORY REQUESTS", "MEMORY ALLOCATABLE", "MEMORY%", "PODS"}}
+	for _, name := range poolNames {
+		c := pools[name]
+		rows = append(rows, []string{
+			name,
+			fmt.Sprint(c.Nodes),
+			formatCPU(c.CPURequests),
+			formatCPU(c.CPUAllocatable),
+			formatPercent(float64(c.CPURequests.MilliValue()), float64(c.CPUAllocatable.MilliValue())),
+			formatMemory(c.MemoryRequests),
+			formatMemory(c.MemoryAllocatable),
+			formatPercent(float64(c.MemoryRequests.Value()), float64(c.MemoryAllocatable.Value())),
+			fmt.Sprintf("%d/%d", c.PodsScheduled, c.PodsAllocatable),
+		})
+	}
+	return renderTable(rows), nil
+}
+
+func (e *Top) listMetrics(ctx context.Context, gvr schema.GroupVersionResource, namespace string) ([]resourceMetrics, error) {
+	list, err := e.dynamicCli.Resource(gvr).Namespace(namespace).List(ctx, metaV1.ListOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) || apierrors.IsServiceUnavailable(err) {
+			return nil, NewExecutionCommandError(topMetricsNotAvailableMsgFmt, e.cfg.Settings.ClusterName)
+		}
+		return nil, fmt.Errorf("while listing %s: %w", gvr.String(), err)
+	}
+
+	out := make([]resourceMetrics, 0, len(list.Items))
+	for _, item := range list.Items {
+		var m resourceMetrics
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(item.Object, &m); err != nil {
+			return nil, fmt.Errorf("while converting %s metrics: %w", item.GetName(), err)
+		}
+		out = append(out, m)
+	}
+
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Metadata.Namespace != out[j].Metadata.Namespace {
+			return out[i].Metadata.Namespace < out[j].Metadata.Namespace
+		}
+		return out[i].Metadata.Name < out[j].Metadata.Name
+	})
+	return out, nil
+}
+
+func (e *Top) isNamespaceAllowed(bindings []string, namespace string) bool {
+	for _, name := range bindings {
+		top := e.cfg.Executors[name].Top
+		if top.Enabled && top.Namespaces.IsAllowed(namespace) {
+			return true
+		}
+	}
+	return false
+}
+
+// findNodePoolLabels returns the node pool label of the last enabled Top executor which specifies it.
+// If not found, it returns the well-known labels.
+func (e *Top) findNodePoolLabels(bindings []string) []string {
+	out := defaultNodePoolLabels
+	for _, name := range bindings {
+		top := e.cfg.Executors[name].Top
+		if top.Enabled && top.NodePoolLabel != "" {
+			out = []string{top.NodePoolLabel}
+		}
+	}
+	return out
+}
+
+// nodePool returns the node pool name based on the first of a given labels set for the node.
+func nodePool(node coreV1.Node, labels []string) string {
+	for _, label := range labels {
+		if pool := node.Labels[label]; pool != "" {
+			return pool
+		}
+	}
+	return topNoNodePool
+}
+
+func parseTopPodsFlags(args []string) (string, bool, error) {
+	f := pflag.NewFlagSet("extract-top-flags", pflag.ContinueOnError)
+	// ignore unknown flags errors, e.g. `--sort-by` etc.
+	f.ParseErrorsWhitelist.UnknownFlags = true
+
+	var (
+		namespace     string
+		allNamespaces bool
+	)
+	f.StringVarP(&namespace, "namespace", "n", "", "Kubernetes Namespace")
+	f.BoolVarP(&allNamespaces, "all-namespaces", "A", false, "Kubernetes All Namespaces")
+	if err := f.Parse(args); err != nil {
+		return "", false, err
+	}
+	return namespace, allNamespaces, nil
+}
+
+func formatCPU(q resource.Quantity) string {
+	return fmt.Sprintf("%dm", q.MilliValue())
+}
+
+func formatMemory(q resource.Quantity) string {
+	return fmt.Sprintf("%.0fMi", float64(q.Value())/topMemoryUnit)
+}
+
+func formatPercent(value, total float64) string {
+	if total <= 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%.0f%%", value/total*100)
+}
@@ -0,0 +1,240 @@
+package execute
+
+import (
+	"context"
+	"testing"
+
+	logtest "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	coreV1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+func TestTopExecute(t *testing.T) {
+	// given
+	tests := []struct {
+		name string
+
+		command string
+		expOut  string
+	}{
+		{
+			name:    "Should report nodes usage",
+			command: "top nodes",
+			expOut: "NAME    CPU(cores)  CPU%  MEMORY(bytes)  MEMORY%\n" +
+				"node-1  1000m       25%   2048Mi         25%\n" +
+				"node-2  3000m       75%   4096Mi         50%\n",
+		},
+		{
+			name:    "Should report Pods usage in default Namespace",
+			command: "top po",
+			expOut: "NAME   CPU(cores)  MEMORY(bytes)\n" +
+				"nginx  150m        96Mi\n",
+		},
+		{
+			name:    "Should report Pods usage in all Namespaces",
+			command: "top pods -A",
+			expOut: "NAMESPACE    NAME   CPU(cores)  MEMORY(bytes)\n" +
+				"default      nginx  150m        96Mi\n" +
+				"kube-system  dns    10m         20Mi\n",
+		},
+		{
+			name:    "Should report capacity per node pool",
+			command: "top capacity",
+			expOut: "NODE POOL  NODES  CPU REQUESTS  CPU ALLOCATABLE  CPU%  MEMORY REQUESTS  MEMORY ALLOCATABLE  MEMORY%  PODS\n" +
+				"<none>     1      0m            4000m            0%    0Mi              8192Mi              0%       0/110\n" +
+				"pool-a     1      1500m         4000m            38%   2048Mi           8192Mi              25%      2/110\n",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			executor := fixTopExecutor(t, config.Namespaces{Include: []string{".*"}})
+
+			// when
+			out, err := executor.Execute(context.Background(), fixBindingsNames, tc.command)
+
+			// then
+			require.NoError(t, err)
+			assert.Equal(t, tc.expOut, out)
+		})
+	}
+}
+
+func TestTopExecuteErrors(t *testing.T) {
+	// given
+	tests := []struct {
+		name string
+
+		command string
+		expErr  string
+	}{
+		{
+			name:    "Should return usage for unknown report",
+			command: "top services",
+			expErr:  "Please specify what to report, e.g. 'top nodes', 'top pods -n <namespace>' or 'top capacity'.",
+		},
+		{
+			name:    "Should forbid not allowed Namespace",
+			command: "top pods -n kube-system",
+			expErr:  "Sorry, the Pods usage cannot be reported for the 'kube-system' Namespace on cluster 'test'.",
+		},
+		{
+			name:    "Should forbid all Namespaces",
+			command: "top pods --all-namespaces",
+			expErr:  "Sorry, the Pods usage cannot be reported for all Namespaces on cluster 'test'.",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			executor := fixTopExecutor(t, config.Namespaces{Include: []string{"default"}})
+
+			// when
+			_, err := executor.Execute(context.Background(), fixBindingsNames, tc.command)
+
+			// then
+			require.Error(t, err)
+			assert.True(t, IsExecutionCommandError(err))
+			assert.EqualError(t, err, tc.expErr)
+		})
+	}
+}
+
+func TestTopCanHandle(t *testing.T) {
+	// given
+	executor := fixTopExecutor(t, config.Namespaces{Include: []string{".*"}})
+
+	// when
+	canHandleTop := executor.CanHandle(fixBindingsNames, []string{"top", "nodes"})
+	canHandleGet := executor.CanHandle(fixBindingsNames, []string{"get", "nodes"})
+	canHandleOtherBinding := executor.CanHandle([]string{"other"}, []string{"top", "nodes"})
+
+	// then
+	assert.True(t, canHandleTop)
+	assert.False(t, canHandleGet)
+	assert.False(t, canHandleOtherBinding)
+}
+
+func fixTopExecutor(t *testing.T, namespaces config.Namespaces) *Top {
+	t.Helper()
+
+	cfg := config.Config{
+		Settings: config.Settings{ClusterName: "test"},
+		Executors: map[string]config.Executors{
+			"default": {
+				Top: config.Top{Enabled: true, Namespaces: namespaces},
+			},
+		},
+	}
+
+	k8sCli := fake.NewSimpleClientset(
+		fixTopNode("node-1", map[string]string{"cloud.google.com/gke-nodepool": "pool-a"}),
+		fixTopNode("node-2", nil),
+		fixTopPod("nginx", "default", "node-1", coreV1.PodRunning, "1", "1Gi"),
+		fixTopPod("dns", "kube-system", "node-1", coreV1.PodRunning, "500m", "1Gi"),
+		fixTopPod("job", "default", "node-1", coreV1.PodSucceeded, "2", "2Gi"),
+	)
+	dynamicCli := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		nodeMetricsGVR: "NodeMetricsList",
+		podMetricsGVR:  "PodMetricsList",
+	})
+	// the metrics resource names can't be guessed from their kinds, so they are added directly
+	for _, obj := range []*unstructured.Unstructured{
+		fixNodeMetrics("node-2", "3", "4Gi"),
+		fixNodeMetrics("node-1", "1", "2Gi"),
+	} {
+		require.NoError(t, dynamicCli.Tracker().Create(nodeMetricsGVR, obj, ""))
+	}
+	for _, obj := range []*unstructured.Unstructured{
+		fixPodMetrics("nginx", "default", "100m", "64Mi", "50m", "32Mi"),
+		fixPodMetrics("dns", "kube-system", "10m", "20Mi"),
+	} {
+		require.NoError(t, dynamicCli.Tracker().Create(podMetricsGVR, obj, obj.GetNamespace()))
+	}
+
+	logger, _ := logtest.NewNullLogger()
+	return NewTop(logger, cfg, k8sCli, dynamicCli)
+}
+
+func fixTopNode(name string, labels map[string]string) *coreV1.Node {
+	return &coreV1.Node{
+		ObjectMeta: metaV1.ObjectMeta{Name: name, Labels: labels},
+		Status: coreV1.NodeStatus{
+			Allocatable: coreV1.ResourceList{
+				coreV1.ResourceCPU:    resource.MustParse("4"),
+				coreV1.ResourceMemory: resource.MustParse("8Gi"),
+				coreV1.ResourcePods:   resource.MustParse("110"),
+			},
+		},
+	}
+}
+
+func fixTopPod(name, namespace, nodeName string, phase coreV1.PodPhase, cpu, memory string) *coreV1.Pod {
+	return &coreV1.Pod{
+		ObjectMeta: metaV1.ObjectMeta{Name: name, Namespace: namespace},
+		Spec: coreV1.PodSpec{
+			NodeName: nodeName,
+			Containers: []coreV1.Container{
+				{
+					Name: "app",
+					Resources: coreV1.ResourceRequirements{
+						Requests: coreV1.ResourceList{
+							coreV1.ResourceCPU:    resource.MustParse(cpu),
+							coreV1.ResourceMemory: resource.MustParse(memory),
+						},
+					},
+				},
+			},
+		},
+		Status: coreV1.PodStatus{Phase: phase},
+	}
+}
+
+func fixNodeMetrics(name, cpu, memory string) *unstructured.Unstructured {
+	return &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "metrics.k8s.io/v1beta1",
+			"kind":       "NodeMetrics",
+			"metadata": map[string]interface{}{
+				"name": name,
+			},
+			"usage": map[string]interface{}{
+				"cpu":    cpu,
+				"memory": memory,
+			},
+		},
+	}
+}
+
+// fixPodMetrics returns the Pod metrics with containers usage given as the CPU and memory pairs.
+func fixPodMetrics(name, namespace string, usage ...string) *unstructured.Unstructured {
+	var containers []interface{}
+	for i := 0; i+1 < len(usage); i += 2 {
+		containers = append(containers, map[string]interface{}{
+			"usage": map[string]interface{}{
+				"cpu":    usage[i],
+				"memory": usage[i+1],
+			},
+		})
+	}
+
+	return &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "metrics.k8s.io/v1beta1",
+			"kind":       "PodMetrics",
+			"metadata": map[string]interface{}{
+				"name":      name,
+				"namespace": namespace,
+			},
+			"containers": containers,
+		},
+	}
+}
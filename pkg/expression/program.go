@@ -0,0 +1,82 @@
+// Package expression compiles and evaluates the Common Expression Language (CEL) expressions, see https://github.com/google/cel-spec.
+//
+// Besides the CEL standard definitions, the string extension functions, such as `lowerAscii`, are available.
+package expression
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/ext"
+)
+
+// Program is a compiled expression.
+type Program struct {
+	source  string
+	program cel.Program
+}
+
+// Compile parses and checks a given expression. It returns error if the expression references variables other than given ones.
+// All variables are dynamically typed, so type mismatches in their fields are reported during evaluation.
+func Compile(expr string, variables ...string) (*Program, error) {
+	opts := []cel.EnvOption{ext.Strings()}
+	for _, name := range variables {
+		opts = append(opts, cel.Variable(name, cel.DynType))
+	}
+
+	env, err := cel.NewEnv(opts...)
+	if err != nil {
+		return nil, fmt.Errorf("while creating environment: %w", err)
+	}
+
+	ast, issues := env.Compile(expr)
+	if issues != nil && issues.Err() != nil {
+		return nil, issuesError(issues)
+	}
+
+	// the regular expression constants are compiled here, so the invalid ones are reported before the evaluation
+	program, err := env.Program(ast, cel.EvalOptions(cel.OptOptimize))
+	if err != nil {
+		return nil, err
+	}
+
+	return &Program{source: expr, program: program}, nil
+}
+
+// String returns the expression source.
+func (p *Program) String() string {
+	return p.source
+}
+
+// Eval evaluates the expression with given variables.
+func (p *Program) Eval(vars map[string]interface{}) (interface{}, error) {
+	out, _, err := p.program.Eval(vars)
+	if err != nil {
+		return nil, err
+	}
+	return out.Value(), nil
+}
+
+// EvalBool evaluates the expression with given variables. It returns error if the result is not a bool.
+func (p *Program) EvalBool(vars map[string]interface{}) (bool, error) {
+	out, _, err := p.program.Eval(vars)
+	if err != nil {
+		return false, err
+	}
+
+	result, ok := out.Value().(bool)
+	if !ok {
+		return false, fmt.Errorf("expected bool, got %s", out.Type().TypeName())
+	}
+	return result, nil
+}
+
+// issuesError returns the compilation issues as a single line error, as it's reported together with the config validation errors.
+func issuesError(issues *cel.Issues) error {
+	var msgs []string
+	for _, item := range issues.Errors() {
+		msgs = append(msgs, fmt.Sprintf("%s at position %d", item.Message, item.Location.Column()))
+	}
+	return fmt.Errorf("%s", strings.Join(msgs, "; "))
+}
@@ -0,0 +1,119 @@
+package expression
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProgramEval(t *testing.T) {
+	// given
+	vars := map[string]interface{}{
+		"event": map[string]interface{}{
+			"reason":    "Unhealthy",
+			"count":     int32(5),
+			"namespace": "prod",
+			"messages":  []string{"Readiness probe failed: HTTP probe failed with statuscode: 503"},
+		},
+		"object": map[string]interface{}{
+			"metadata": map[string]interface{}{
+				"labels": map[string]interface{}{"app": "payments-api"},
+			},
+			"spec": map[string]interface{}{
+				"replicas": int64(3),
+			},
+		},
+	}
+
+	tests := []struct {
+		name string
+		expr string
+
+		expected interface{}
+	}{
+		{name: "Logical and", expr: `event.reason == "Unhealthy" && event.count > 3`, expected: true},
+		{name: "Logical or", expr: `event.reason == 'BackOff' || event.count >= 10`, expected: false},
+		{name: "Negation", expr: `!(event.namespace in ["kube-system", "kube-public"])`, expected: true},
+		{name: "Map index", expr: `object.metadata.labels["app"].startsWith("payments")`, expected: true},
+		{name: "List index", expr: `event.messages[0].contains("503")`, expected: true},
+		{name: "Regular expression", expr: `event.messages[0].matches("statuscode: 5\\d\\d")`, expected: true},
+		{name: "Size", expr: `size(event.messages) == 1 && event.reason.size() == 9`, expected: true},
+		{name: "Has", expr: `has(object.metadata.annotations)`, expected: false},
+		{name: "Error absorbed by logical operator", expr: `has(object.metadata.annotations) && object.metadata.annotations.team == "a"`, expected: false},
+		{name: "Map key", expr: `"app" in object.metadata.labels`, expected: true},
+		{name: "Arithmetic", expr: `object.spec.replicas * 2 - 1`, expected: int64(5)},
+		{name: "Mixed numbers", expr: `object.spec.replicas > 2.5`, expected: true},
+		{name: "Conditional", expr: `event.count > 3 ? "many" : "few"`, expected: "many"},
+		{name: "Non-ASCII string", expr: `"Zażółć".size() == 6 && "Zażółć".startsWith("Za")`, expected: true},
+		{name: "String functions", expr: `string(event.count) + "x" == "5x" && event.reason.lowerAscii() == "unhealthy"`, expected: true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			program, err := Compile(tc.expr, "event", "object")
+			require.NoError(t, err)
+
+			// when
+			actual, err := program.Eval(vars)
+
+			// then
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
+func TestProgramEvalErrors(t *testing.T) {
+	// given
+	vars := map[string]interface{}{
+		"event": map[string]interface{}{"reason": "Unhealthy", "count": int32(5)},
+	}
+
+	tests := []struct {
+		name string
+		expr string
+
+		expErrMsg string
+	}{
+		{name: "Missing key", expr: `event.action == "create"`, expErrMsg: "no such key: action"},
+		{name: "Type mismatch", expr: `event.reason > 3`, expErrMsg: "no such overload"},
+		{name: "Non-bool result", expr: `event.count`, expErrMsg: "expected bool, got int"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			program, err := Compile(tc.expr, "event")
+			require.NoError(t, err)
+
+			// when
+			_, err = program.EvalBool(vars)
+
+			// then
+			assert.EqualError(t, err, tc.expErrMsg)
+		})
+	}
+}
+
+func TestCompileErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		expr string
+
+		expErrMsg string
+	}{
+		{name: "Undeclared variable", expr: `evnt.reason == "Unhealthy" && obj.kind == "Pod"`, expErrMsg: "undeclared reference to 'evnt' (in container '') at position 0; undeclared reference to 'obj' (in container '') at position 30"},
+		{name: "Unknown function", expr: `size(event.reason, 1) == 3`, expErrMsg: "found no matching overload for 'size' applied to '(dyn, int)' at position 4"},
+		{name: "Unterminated string", expr: `event.reason == "Unhealthy`, expErrMsg: "Syntax error: token recognition error at: '\"Unhealthy' at position 16; Syntax error: mismatched input '<EOF>' expecting {'[', '{', '(', '.', '-', '!', 'true', 'false', 'null', NUM_FLOAT, NUM_INT, NUM_UINT, STRING, BYTES, IDENTIFIER} at position 26"},
+		{name: "Missing operand", expr: `event.count >`, expErrMsg: "Syntax error: mismatched input '<EOF>' expecting {'[', '{', '(', '.', '-', '!', 'true', 'false', 'null', NUM_FLOAT, NUM_INT, NUM_UINT, STRING, BYTES, IDENTIFIER} at position 13"},
+		{name: "Trailing token", expr: `event.count > 3 3`, expErrMsg: "Syntax error: extraneous input '3' expecting <EOF> at position 16"},
+		{name: "Invalid regular expression", expr: `event.reason.matches("(")`, expErrMsg: "error parsing regexp: missing closing ): `(`"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// when
+			_, err := Compile(tc.expr, "event")
+
+			// then
+			assert.EqualError(t, err, tc.expErrMsg)
+		})
+	}
+}
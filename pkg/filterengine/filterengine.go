@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"sort"
+	"strings"
+	"sync"
 
 	"github.com/sirupsen/logrus"
 
@@ -15,14 +17,19 @@ type DefaultFilterEngine struct {
 	log logrus.FieldLogger
 
 	filters map[string]RegisteredFilter
+	// pipelines holds the ordered filter names, indexed by the source binding name.
+	// They are replaced on configuration reload, while the events are processed.
+	pipelinesMutex sync.RWMutex
+	pipelines      map[string][]string
 }
 
 // FilterEngine has methods to register and run filters.
 type FilterEngine interface {
-	Run(context.Context, events.Event) events.Event
+	Run(ctx context.Context, event events.Event, sourceBindings []string) events.Event
 	Register(...RegisteredFilter)
 	RegisteredFilters() []RegisteredFilter
 	SetFilter(string, bool) error
+	SetPipelines(map[string][]string) error
 }
 
 // RegisteredFilter contains details about registered filter.
@@ -41,15 +48,17 @@ type Filter interface {
 // New creates new DefaultFilterEngine instance..
 func New(log logrus.FieldLogger) *DefaultFilterEngine {
 	return &DefaultFilterEngine{
-		log:     log,
-		filters: make(map[string]RegisteredFilter),
+		log:       log,
+		filters:   make(map[string]RegisteredFilter),
+		pipelines: make(map[string][]string),
 	}
 }
 
-// Run runs the registered filters always iterating over a slice of filters with sorted keys.
-func (f *DefaultFilterEngine) Run(ctx context.Context, event events.Event) events.Event {
+// Run runs the filter pipeline of given source bindings. If none of them configures a pipeline,
+// the enabled registered filters are run, always iterating over a slice of filters with sorted keys.
+func (f *DefaultFilterEngine) Run(ctx context.Context, event events.Event, sourceBindings []string) events.Event {
 	f.log.Debug("Running registered filters")
-	filters := f.RegisteredFilters()
+	filters := f.pipelineFor(sourceBindings)
 	f.log.Debugf("registered filters: %+v", filters)
 
 	for _, filter := range filters {
@@ -90,6 +99,70 @@ func (f *DefaultFilterEngine) RegisteredFilters() []RegisteredFilter {
 	return registeredFilters
 }
 
+// SetPipelines sets the ordered filter pipelines, indexed by the source binding name.
+// Filters are matched by their names case-insensitively. Events of sources with a pipeline are processed by the listed filters only,
+// regardless of whether the filters are enabled.
+func (f *DefaultFilterEngine) SetPipelines(pipelines map[string][]string) error {
+	out := make(map[string][]string)
+	for source, names := range pipelines {
+		if names == nil {
+			continue
+		}
+
+		// an empty pipeline disables filtering for a given source
+		out[source] = []string{}
+		for _, name := range names {
+			filter, found := f.lookup(name)
+			if !found {
+				return fmt.Errorf("couldn't find filter with name %q used by source %q", name, source)
+			}
+			out[source] = append(out[source], filter.Name())
+		}
+	}
+
+	f.pipelinesMutex.Lock()
+	defer f.pipelinesMutex.Unlock()
+	f.pipelines = out
+	return nil
+}
+
+// pipelineFor returns the filters to run for given source bindings.
+// If multiple source bindings configure a pipeline, the first one in alphabetical order is used.
+func (f *DefaultFilterEngine) pipelineFor(sourceBindings []string) []RegisteredFilter {
+	names := make([]string, len(sourceBindings))
+	copy(names, sourceBindings)
+	sort.Strings(names)
+
+	f.pipelinesMutex.RLock()
+	pipelines := f.pipelines
+	f.pipelinesMutex.RUnlock()
+
+	for _, source := range names {
+		pipeline, found := pipelines[source]
+		if !found {
+			continue
+		}
+
+		var out []RegisteredFilter
+		for _, name := range pipeline {
+			out = append(out, RegisteredFilter{Enabled: true, Filter: f.filters[name].Filter})
+		}
+		return out
+	}
+
+	return f.RegisteredFilters()
+}
+
+// lookup returns the registered filter with a given name, ignoring case.
+func (f *DefaultFilterEngine) lookup(name string) (RegisteredFilter, bool) {
+	for key, filter := range f.filters {
+		if strings.EqualFold(key, name) {
+			return filter, true
+		}
+	}
+	return RegisteredFilter{}, false
+}
+
 // SetFilter sets filter value in FilterMap to enable or disable filter.
 func (f *DefaultFilterEngine) SetFilter(name string, flag bool) error {
 	// Find filter struct name
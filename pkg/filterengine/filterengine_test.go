@@ -0,0 +1,98 @@
+package filterengine
+
+import (
+	"context"
+	"testing"
+
+	logtest "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/pkg/events"
+)
+
+func TestDefaultFilterEngine_Run(t *testing.T) {
+	// given
+	logger, _ := logtest.NewNullLogger()
+	engine := New(logger)
+	engine.Register(
+		RegisteredFilter{Enabled: true, Filter: fakeFilter{name: "ObjectAnnotationChecker"}},
+		RegisteredFilter{Enabled: false, Filter: fakeFilter{name: "NodeEventsChecker"}},
+		RegisteredFilter{Enabled: true, Filter: fakeFilter{name: "CustomFilter"}},
+	)
+	err := engine.SetPipelines(map[string][]string{
+		"k8s-err-events": {"nodeEventsChecker", "objectAnnotationChecker"},
+		"k8s-all-events": {"customFilter"},
+		"k8s-audit":      {},
+	})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name           string
+		sourceBindings []string
+
+		expectedFilters []string
+	}{
+		{
+			name:            "Default pipeline",
+			sourceBindings:  []string{"k8s-create-events"},
+			expectedFilters: []string{"CustomFilter", "ObjectAnnotationChecker"},
+		},
+		{
+			name:            "Source pipeline",
+			sourceBindings:  []string{"k8s-create-events", "k8s-err-events"},
+			expectedFilters: []string{"NodeEventsChecker", "ObjectAnnotationChecker"},
+		},
+		{
+			name:            "First source pipeline in alphabetical order",
+			sourceBindings:  []string{"k8s-err-events", "k8s-all-events"},
+			expectedFilters: []string{"CustomFilter"},
+		},
+		{
+			name:           "Empty source pipeline",
+			sourceBindings: []string{"k8s-audit"},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// when
+			event := engine.Run(context.Background(), events.Event{}, tc.sourceBindings)
+
+			// then
+			assert.Equal(t, tc.expectedFilters, event.Messages)
+		})
+	}
+}
+
+func TestDefaultFilterEngine_SetPipelinesUnknownFilter(t *testing.T) {
+	// given
+	logger, _ := logtest.NewNullLogger()
+	engine := New(logger)
+	engine.Register(RegisteredFilter{Enabled: true, Filter: fakeFilter{name: "NodeEventsChecker"}})
+
+	// when
+	err := engine.SetPipelines(map[string][]string{
+		"k8s-err-events": {"nodeEventsChecker", "myCustomFilter"},
+	})
+
+	// then
+	assert.EqualError(t, err, `couldn't find filter with name "myCustomFilter" used by source "k8s-err-events"`)
+}
+
+// fakeFilter records its name in the event messages.
+type fakeFilter struct {
+	name string
+}
+
+func (f fakeFilter) Run(_ context.Context, event *events.Event) error {
+	event.Messages = append(event.Messages, f.name)
+	return nil
+}
+
+func (f fakeFilter) Name() string {
+	return f.name
+}
+
+func (f fakeFilter) Describe() string {
+	return "Records its name in the event messages."
+}
@@ -15,6 +15,7 @@ const (
 )
 
 // WithAllFilters returns new DefaultFilterEngine instance with all filters registered.
+// Filter pipelines of given sources can be set only after all custom filters are registered, see SetPipelines.
 func WithAllFilters(logger *logrus.Logger, dynamicCli dynamic.Interface, mapper meta.RESTMapper, cfg config.Filters) *DefaultFilterEngine {
 	filterEngine := New(logger.WithField(componentLogFieldKey, "Filter Engine"))
 	filterEngine.Register([]RegisteredFilter{
@@ -30,3 +31,15 @@ func WithAllFilters(logger *logrus.Logger, dynamicCli dynamic.Interface, mapper
 
 	return filterEngine
 }
+
+// PipelinesForSources returns the filter pipelines configured for given sources, indexed by the source binding name.
+func PipelinesForSources(sources map[string]config.Sources) map[string][]string {
+	out := make(map[string][]string)
+	for name, source := range sources {
+		if source.Filters == nil {
+			continue
+		}
+		out[name] = source.Filters
+	}
+	return out
+}
@@ -0,0 +1,18 @@
+package format
+
+import (
+	"strings"
+	"time"
+)
+
+// Duration formats a given duration without the zero units, e.g. "10m" instead of "10m0s".
+func Duration(d time.Duration) string {
+	out := d.String()
+	if strings.HasSuffix(out, "m0s") {
+		out = strings.TrimSuffix(out, "0s")
+	}
+	if strings.HasSuffix(out, "h0m") {
+		out = strings.TrimSuffix(out, "0m")
+	}
+	return out
+}
@@ -0,0 +1,23 @@
+package format_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kubeshop/botkube/pkg/format"
+)
+
+func TestDuration(t *testing.T) {
+	tests := map[time.Duration]string{
+		10 * time.Minute:              "10m",
+		time.Hour:                     "1h",
+		90 * time.Minute:              "1h30m",
+		30 * time.Second:              "30s",
+		2*time.Minute + 5*time.Second: "2m5s",
+	}
+	for in, expected := range tests {
+		assert.Equal(t, expected, format.Duration(in))
+	}
+}
@@ -31,15 +31,25 @@ func joinMessages(msgs []string, msgPrefix string) string {
 	return strBuilder.String()
 }
 
-// BulletPointEventAttachments returns formatted lists of event messages, recommendations and warnings.
+// BulletPointEventAttachments returns formatted lists of event messages, recommendations and warnings, followed by the container logs.
 func BulletPointEventAttachments(event events.Event) string {
 	strBuilder := strings.Builder{}
 	writeStringIfNotEmpty(&strBuilder, "Messages", BulletPointListFromMessages(event.Messages))
 	writeStringIfNotEmpty(&strBuilder, "Recommendations", BulletPointListFromMessages(event.Recommendations))
 	writeStringIfNotEmpty(&strBuilder, "Warnings", BulletPointListFromMessages(event.Warnings))
+	writeStringIfNotEmpty(&strBuilder, "Logs", EventLogs(event))
 	return strBuilder.String()
 }
 
+// EventLogs returns the container logs attached to the event, formatted as a code block with a description.
+func EventLogs(event events.Event) string {
+	if event.Logs == nil || event.Logs.Content == "" {
+		return ""
+	}
+
+	return fmt.Sprintf("%s:\n%s\n", event.Logs.Description(), CodeBlock(event.Logs.Content))
+}
+
 func writeStringIfNotEmpty(strBuilder *strings.Builder, title, in string) {
 	if in == "" {
 		return
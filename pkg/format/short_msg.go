@@ -10,8 +10,12 @@ import (
 
 const bulletPointFmt = "- %s\n"
 
-// ShortMessage prepares message in short event format.
+// ShortMessage prepares message in short event format. If the event has a user-defined body, it is returned instead.
 func ShortMessage(event events.Event) string {
+	if event.Body != "" {
+		return event.Body
+	}
+
 	msg := ShortNotificationHeader(event)
 	msgAttachments := messageAttachments(event)
 
@@ -24,6 +28,9 @@ func ShortNotificationHeader(event events.Event) string {
 	if event.Namespace != "" {
 		resourceName = fmt.Sprintf("%s/%s", event.Namespace, event.Name)
 	}
+	if event.Owner != nil {
+		resourceName = fmt.Sprintf("%s* of %s *%s", resourceName, event.Owner.Kind, event.Owner.Name)
+	}
 
 	switch event.Type {
 	case config.CreateEvent, config.DeleteEvent, config.UpdateEvent:
@@ -83,6 +90,8 @@ func messageAttachments(event events.Event) string {
 		}
 	}
 
+	additionalMsgStrBuilder.WriteString(EventLogs(event))
+
 	if additionalMsgStrBuilder.Len() == 0 {
 		return ""
 	}
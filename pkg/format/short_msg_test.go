@@ -64,6 +64,24 @@ func TestShortMessage(t *testing.T) {
 			},
 			Expected: fmt.Sprintf("Pod *namespace/pod* has been created in *cluster-name* cluster\n%s", expectedAttachments),
 		},
+		{
+			Name: "Create event for resource with owner",
+			Input: events.Event{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       "Pod",
+					APIVersion: "v1",
+				},
+				Name:            "payments-api-7d9f-x2b4c",
+				Namespace:       "namespace",
+				Owner:           &events.Owner{APIVersion: "apps/v1", Kind: "Deployment", Name: "payments-api"},
+				Messages:        []string{"message 1", "message 2"},
+				Type:            config.CreateEvent,
+				Cluster:         "cluster-name",
+				Recommendations: []string{"recommendation 1", "recommendation 2"},
+				Warnings:        []string{"warning 1", "warning 2"},
+			},
+			Expected: fmt.Sprintf("Pod *namespace/payments-api-7d9f-x2b4c* of Deployment *payments-api* has been created in *cluster-name* cluster\n%s", expectedAttachments),
+		},
 		{
 			Name: "Error event for cluster resource",
 			Input: events.Event{
@@ -164,6 +182,22 @@ func TestShortMessage(t *testing.T) {
 			},
 			Expected: fmt.Sprintf("Info for Pod *namespace/pod* in *cluster-name* cluster\n%s", expectedAttachments),
 		},
+		{
+			Name: "Event with user-defined body",
+			Input: events.Event{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       "Pod",
+					APIVersion: "v1",
+				},
+				Name:      "pod",
+				Namespace: "namespace",
+				Messages:  []string{"message 1", "message 2"},
+				Type:      config.CreateEvent,
+				Cluster:   "cluster-name",
+				Body:      "namespace/pod created",
+			},
+			Expected: "namespace/pod created",
+		},
 	}
 
 	for _, tc := range testCases {
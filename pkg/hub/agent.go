@@ -0,0 +1,260 @@
+package hub
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"k8s.io/utils/strings/slices"
+
+	"github.com/kubeshop/botkube/pkg/bot/interactive"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/events"
+	"github.com/kubeshop/botkube/pkg/execute"
+	"github.com/kubeshop/botkube/pkg/execute/command"
+)
+
+const (
+	// agentConversationID identifies the hub in the executed commands, e.g. in the audit logs.
+	agentConversationID = "hub"
+	// defaultBotName is used if the hub doesn't send its bot name.
+	defaultBotName = "@Botkube"
+
+	reconnectInterval = 5 * time.Second
+	requestTimeout    = 30 * time.Second
+)
+
+// ExecutorFactory facilitates creation of execute.Executor instances.
+type ExecutorFactory interface {
+	NewDefault(cfg execute.NewDefaultInput) execute.Executor
+}
+
+// Agent forwards events to the hub and executes the commands sent by it.
+type Agent struct {
+	log             logrus.FieldLogger
+	cfg             config.Hub
+	clusterName     string
+	executorFactory ExecutorFactory
+	httpCli         *http.Client
+	pollCli         *http.Client
+}
+
+// NewAgent returns a new Agent instance.
+func NewAgent(log logrus.FieldLogger, cfg config.Hub, clusterName string, executorFactory ExecutorFactory) *Agent {
+	return &Agent{
+		log:             log,
+		cfg:             cfg,
+		clusterName:     clusterName,
+		executorFactory: executorFactory,
+		httpCli:         &http.Client{Timeout: requestTimeout},
+		// poll requests are held by the hub until a command is available
+		pollCli: &http.Client{Timeout: pollTimeout + requestTimeout},
+	}
+}
+
+// Start polls the hub for commands and executes them. It blocks until the context is cancelled.
+func (a *Agent) Start(ctx context.Context) error {
+	a.log.Infof("Connecting to the hub on %q...", a.cfg.URL)
+	for {
+		cmd, err := a.poll(ctx)
+		if ctx.Err() != nil {
+			return nil
+		}
+		if err != nil {
+			a.log.Errorf("while polling commands from the hub: %s", err.Error())
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-time.After(reconnectInterval):
+			}
+			continue
+		}
+		if cmd == nil {
+			continue
+		}
+
+		go a.handleCommand(ctx, *cmd)
+	}
+}
+
+// SendEvent forwards a given event to the hub.
+func (a *Agent) SendEvent(ctx context.Context, event events.Event, eventSources []string) error {
+	msg := EventMessage{
+		Cluster: a.clusterName,
+		Sources: eventSources,
+		Event:   event,
+	}
+
+	res, err := a.do(ctx, a.httpCli, http.MethodPost, EventsPath, msg)
+	if err != nil {
+		return fmt.Errorf("while sending event to the hub: %w", err)
+	}
+	defer res.Body.Close()
+
+	if err := checkResponse(res); err != nil {
+		return fmt.Errorf("while sending event to the hub: %w", err)
+	}
+	return nil
+}
+
+// SendMessageToAll is no-op. Botkube start and stop messages are sent only by the hub.
+func (a *Agent) SendMessageToAll(context.Context, interactive.Message) error {
+	return nil
+}
+
+// SendGenericMessage is no-op. Only events are forwarded to the hub.
+func (a *Agent) SendGenericMessage(context.Context, interactive.GenericMessage, []string) error {
+	return nil
+}
+
+// IntegrationName describes the integration name.
+func (a *Agent) IntegrationName() config.CommPlatformIntegration {
+	return config.HubCommPlatformIntegration
+}
+
+// Type describes the integration type.
+func (a *Agent) Type() config.IntegrationType {
+	return config.SinkIntegrationType
+}
+
+func (a *Agent) poll(ctx context.Context) (*CommandMessage, error) {
+	path := fmt.Sprintf("%s?%s=%s", CommandsPath, clusterQueryParam, url.QueryEscape(a.clusterName))
+	res, err := a.do(ctx, a.pollCli, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusNoContent {
+		return nil, nil
+	}
+	if err := checkResponse(res); err != nil {
+		return nil, err
+	}
+
+	var cmd CommandMessage
+	if err := json.NewDecoder(res.Body).Decode(&cmd); err != nil {
+		return nil, fmt.Errorf("while decoding command: %w", err)
+	}
+	return &cmd, nil
+}
+
+func (a *Agent) handleCommand(ctx context.Context, cmd CommandMessage) {
+	a.log.WithFields(logrus.Fields{
+		"id":   cmd.ID,
+		"user": cmd.User,
+	}).Debugf("Executing command %q sent by the hub", cmd.Command)
+
+	botName := cmd.BotName
+	if botName == "" {
+		botName = defaultBotName
+	}
+
+	executor := a.executorFactory.NewDefault(execute.NewDefaultInput{
+		CommGroupName:   agentConversationID,
+		Platform:        config.HubCommPlatformIntegration,
+		NotifierHandler: &agentNotifierHandler{botName: botName},
+		Conversation: execute.Conversation{
+			Alias:            agentConversationID,
+			ID:               agentConversationID,
+			ExecutorBindings: a.executorBindingsFor(cmd),
+			// the hub forwards only the commands sent from its configured channels
+			IsAuthenticated: true,
+			CommandOrigin:   command.TypedOrigin,
+		},
+		Message:    cmd.Command,
+		User:       cmd.User,
+		UserID:     cmd.UserID,
+		UserGroups: cmd.UserGroups,
+		Stdin:      cmd.Stdin,
+	})
+
+	result := ResultMessage{
+		ID:      cmd.ID,
+		Message: executor.Execute(ctx),
+	}
+
+	res, err := a.do(ctx, a.httpCli, http.MethodPost, ResultsPath, result)
+	if err != nil {
+		a.log.Errorf("while sending result of command %q to the hub: %s", cmd.ID, err.Error())
+		return
+	}
+	defer res.Body.Close()
+
+	if err := checkResponse(res); err != nil {
+		a.log.Errorf("while sending result of command %q to the hub: %s", cmd.ID, err.Error())
+	}
+}
+
+// executorBindingsFor returns the agent executor bindings which are also bound to the hub channel that sent a given command,
+// so the hub channels cannot run commands with the executors they are not bound to.
+func (a *Agent) executorBindingsFor(cmd CommandMessage) []string {
+	var out []string
+	for _, name := range a.cfg.ExecutorBindings {
+		if slices.Contains(cmd.ExecutorBindings, name) {
+			out = append(out, name)
+		}
+	}
+	return out
+}
+
+func (a *Agent) do(ctx context.Context, cli *http.Client, method, path string, body interface{}) (*http.Response, error) {
+	var reqBody io.Reader
+	if body != nil {
+		raw, err := json.Marshal(body)
+		if err != nil {
+			return nil, fmt.Errorf("while marshaling request body: %w", err)
+		}
+		reqBody = bytes.NewReader(raw)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, strings.TrimSuffix(a.cfg.URL, "/")+path, reqBody)
+	if err != nil {
+		return nil, fmt.Errorf("while creating request: %w", err)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if a.cfg.BearerToken != "" {
+		req.Header.Set("Authorization", "Bearer "+a.cfg.BearerToken)
+	}
+
+	return cli.Do(req)
+}
+
+func checkResponse(res *http.Response) error {
+	if res.StatusCode >= 200 && res.StatusCode < 300 {
+		return nil
+	}
+
+	body, _ := io.ReadAll(io.LimitReader(res.Body, 1024))
+	return fmt.Errorf("unexpected status code %d: %s", res.StatusCode, strings.TrimSpace(string(body)))
+}
+
+// agentNotifierHandler handles the notifier commands sent by the hub. Notifications are managed in the hub channels,
+// so they cannot be changed on the agent.
+type agentNotifierHandler struct {
+	botName string
+}
+
+// NotificationsEnabled returns true, as the agent forwards all events to the hub.
+func (h *agentNotifierHandler) NotificationsEnabled(string) bool {
+	return true
+}
+
+// SetNotificationsEnabled returns error, as the notifications are managed in the hub.
+func (h *agentNotifierHandler) SetNotificationsEnabled(string, bool) error {
+	return execute.ErrNotificationsNotConfigured
+}
+
+// BotName returns the hub bot name.
+func (h *agentNotifierHandler) BotName() string {
+	return h.botName
+}
@@ -0,0 +1,268 @@
+package hub
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	logtest "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kubeshop/botkube/pkg/bot/interactive"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/events"
+	"github.com/kubeshop/botkube/pkg/execute"
+	"github.com/kubeshop/botkube/pkg/notifier"
+)
+
+const testToken = "s3cr3t"
+
+func TestAgentSendEvent(t *testing.T) {
+	// given
+	sender := &fakeSender{sent: make(chan sentEvent, 1)}
+	_, agent := fixHubWithAgent(t, sender, &fakeExecutorFactory{})
+
+	event := events.Event{
+		TypeMeta: metaV1.TypeMeta{Kind: "Pod"},
+		Name:     "payments-api-7d9f-x2b4c",
+		Title:    "v1/pods error",
+		Level:    config.Error,
+		Buttons:  []events.Button{{Name: "Describe", Command: "kubectl describe pod payments-api-7d9f-x2b4c"}},
+		Actions:  []events.Action{{Command: "@Botkube kubectl logs payments-api-7d9f-x2b4c"}},
+	}
+
+	// when
+	err := agent.SendEvent(context.Background(), event, []string{"k8s-err-events"})
+
+	// then
+	require.NoError(t, err)
+	select {
+	case sent := <-sender.sent:
+		assert.Equal(t, []string{"k8s-err-events"}, sent.sources)
+		assert.Equal(t, "[prod] v1/pods error", sent.event.Title)
+		assert.Equal(t, "prod", sent.event.Cluster)
+		assert.Equal(t, "kubectl describe pod payments-api-7d9f-x2b4c --cluster=prod", sent.event.Buttons[0].Command)
+		assert.Empty(t, sent.event.Actions)
+	case <-time.After(5 * time.Second):
+		t.Fatal("event wasn't sent")
+	}
+}
+
+func TestServerExecute(t *testing.T) {
+	// given
+	executorFactory := &fakeExecutorFactory{}
+	srv, agent := fixHubWithAgent(t, &fakeSender{}, executorFactory)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		_ = agent.Start(ctx)
+	}()
+	require.Eventually(t, func() bool {
+		return srv.IsConnected("prod")
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// when
+	msg, err := srv.Execute(context.Background(), "prod", execute.RemoteCommand{
+		Command:          "kubectl get pods --cluster=prod",
+		User:             "Jane",
+		BotName:          "@Hub",
+		ExecutorBindings: []string{"kubectl-read-only", "kubectl-admin"},
+		UserID:           "U123",
+		UserGroups:       []string{"devs"},
+	})
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, "`kubectl get pods --cluster=prod` on `prod`", msg.Description)
+	assert.Equal(t, []string{"prod"}, srv.Clusters())
+
+	input := executorFactory.lastInput()
+	assert.Equal(t, "Jane", input.User)
+	assert.Equal(t, "U123", input.UserID)
+	assert.Equal(t, []string{"devs"}, input.UserGroups)
+	assert.Equal(t, []string{"kubectl-read-only"}, input.Conversation.ExecutorBindings)
+	assert.True(t, input.Conversation.IsAuthenticated)
+	assert.Equal(t, "@Hub", input.NotifierHandler.BotName())
+}
+
+func TestServerExecuteWithoutCommonExecutorBindings(t *testing.T) {
+	// given
+	executorFactory := &fakeExecutorFactory{}
+	srv, agent := fixHubWithAgent(t, &fakeSender{}, executorFactory)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		_ = agent.Start(ctx)
+	}()
+	require.Eventually(t, func() bool {
+		return srv.IsConnected("prod")
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// when
+	_, err := srv.Execute(context.Background(), "prod", execute.RemoteCommand{
+		Command:          "kubectl delete pod nginx --cluster=prod",
+		ExecutorBindings: []string{"kubectl-admin"},
+	})
+
+	// then
+	require.NoError(t, err)
+	assert.Empty(t, executorFactory.lastInput().Conversation.ExecutorBindings)
+}
+
+func TestServerExecuteNotConnected(t *testing.T) {
+	// given
+	srv, _ := fixHubWithAgent(t, &fakeSender{}, &fakeExecutorFactory{})
+
+	// when
+	_, err := srv.Execute(context.Background(), "staging", execute.RemoteCommand{Command: "kubectl get pods --cluster=staging"})
+
+	// then
+	assert.EqualError(t, err, `cluster "staging" is not connected to the hub`)
+	assert.False(t, srv.IsConnected("staging"))
+}
+
+func TestAgentUnauthorized(t *testing.T) {
+	testCases := []struct {
+		Name        string
+		HubAgents   map[string]config.HubAgent
+		AgentToken  string
+		ExpectedErr string
+	}{
+		{
+			Name:        "Invalid token",
+			HubAgents:   map[string]config.HubAgent{"prod": {BearerToken: testToken}},
+			AgentToken:  "invalid",
+			ExpectedErr: "while sending event to the hub: unexpected status code 401: Unauthorized",
+		},
+		{
+			Name:        "No agents configured",
+			AgentToken:  "",
+			ExpectedErr: "while sending event to the hub: unexpected status code 401: Unauthorized",
+		},
+		{
+			Name:        "Token of other cluster",
+			HubAgents:   map[string]config.HubAgent{"prod": {BearerToken: testToken}, "staging": {BearerToken: "staging-token"}},
+			AgentToken:  "staging-token",
+			ExpectedErr: `while sending event to the hub: unexpected status code 403: agent is not allowed to send events for cluster "prod"`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			// given
+			logger, _ := logtest.NewNullLogger()
+			srv := NewServer(context.Background(), logger, config.Hub{Agents: tc.HubAgents})
+			httpSrv := httptest.NewServer(srv.Handler(&fakeSender{}))
+			defer httpSrv.Close()
+
+			agent := NewAgent(logger, config.Hub{URL: httpSrv.URL, BearerToken: tc.AgentToken}, "prod", &fakeExecutorFactory{})
+
+			// when
+			err := agent.SendEvent(context.Background(), events.Event{Title: "v1/pods error"}, []string{"k8s-err-events"})
+
+			// then
+			assert.EqualError(t, err, tc.ExpectedErr)
+		})
+	}
+}
+
+func TestServerRejectsPollForOtherCluster(t *testing.T) {
+	// given
+	logger, _ := logtest.NewNullLogger()
+	srv := NewServer(context.Background(), logger, config.Hub{Agents: map[string]config.HubAgent{
+		"prod":    {BearerToken: testToken},
+		"staging": {BearerToken: "staging-token"},
+	}})
+	httpSrv := httptest.NewServer(srv.Handler(&fakeSender{}))
+	defer httpSrv.Close()
+
+	req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%s%s?%s=prod", httpSrv.URL, CommandsPath, clusterQueryParam), nil)
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer staging-token")
+
+	// when
+	res, err := http.DefaultClient.Do(req)
+
+	// then
+	require.NoError(t, err)
+	defer res.Body.Close()
+	assert.Equal(t, http.StatusForbidden, res.StatusCode)
+	assert.False(t, srv.IsConnected("prod"))
+}
+
+func fixHubWithAgent(t *testing.T, sender EventSender, executorFactory ExecutorFactory) (*Server, *Agent) {
+	t.Helper()
+
+	logger, _ := logtest.NewNullLogger()
+	srv := NewServer(context.Background(), logger, config.Hub{
+		Mode:           config.HubServerMode,
+		CommandTimeout: 5 * time.Second,
+		Agents:         map[string]config.HubAgent{"prod": {BearerToken: testToken}},
+	})
+	srv.pollTimeout = 100 * time.Millisecond
+
+	httpSrv := httptest.NewServer(srv.Handler(sender))
+	t.Cleanup(httpSrv.Close)
+
+	agent := NewAgent(logger, config.Hub{
+		Mode:             config.HubAgentMode,
+		URL:              httpSrv.URL,
+		BearerToken:      testToken,
+		ExecutorBindings: []string{"kubectl-read-only"},
+	}, "prod", executorFactory)
+	return srv, agent
+}
+
+type sentEvent struct {
+	event   events.Event
+	sources []string
+}
+
+type fakeSender struct {
+	sent chan sentEvent
+}
+
+func (f *fakeSender) SendEvent(_ context.Context, event events.Event, sources []string, _ notifier.ResultFn) error {
+	if f.sent != nil {
+		f.sent <- sentEvent{event: event, sources: sources}
+	}
+	return nil
+}
+
+type fakeExecutorFactory struct {
+	mu    sync.Mutex
+	input execute.NewDefaultInput
+}
+
+func (f *fakeExecutorFactory) NewDefault(cfg execute.NewDefaultInput) execute.Executor {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.input = cfg
+	return &fakeExecutor{cmd: cfg.Message}
+}
+
+func (f *fakeExecutorFactory) lastInput() execute.NewDefaultInput {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.input
+}
+
+type fakeExecutor struct {
+	cmd string
+}
+
+func (f *fakeExecutor) Execute(context.Context) interactive.Message {
+	return interactive.Message{
+		Base: interactive.Base{
+			Description: "`" + f.cmd + "` on `prod`",
+		},
+	}
+}
@@ -0,0 +1,48 @@
+// Package hub implements the multi-cluster setup, where the agents forward their events to a single Botkube instance,
+// called the hub, which sends them to the communication platforms and forwards the commands targeting the agent clusters.
+//
+// The agents connect to the hub over HTTP, so the hub doesn't need to reach the agent clusters. Commands are delivered
+// to the agents with long polling.
+package hub
+
+import (
+	"github.com/kubeshop/botkube/pkg/bot/interactive"
+	"github.com/kubeshop/botkube/pkg/events"
+)
+
+const (
+	// EventsPath is the path of the hub endpoint, which receives events from the agents.
+	EventsPath = "/hub/events"
+	// CommandsPath is the path of the hub endpoint, from which the agents poll the commands to execute.
+	CommandsPath = "/hub/commands"
+	// ResultsPath is the path of the hub endpoint, which receives the command responses from the agents.
+	ResultsPath = "/hub/results"
+
+	clusterQueryParam = "cluster"
+)
+
+// EventMessage is an event forwarded by the agent to the hub.
+type EventMessage struct {
+	Cluster string       `json:"cluster"`
+	Sources []string     `json:"sources"`
+	Event   events.Event `json:"event"`
+}
+
+// CommandMessage is a command forwarded by the hub to the agent.
+type CommandMessage struct {
+	ID      string `json:"id"`
+	Command string `json:"command"`
+	User    string `json:"user,omitempty"`
+	BotName string `json:"botName,omitempty"`
+	Stdin   []byte `json:"stdin,omitempty"`
+	// ExecutorBindings holds the executor bindings of the hub channel which sent the command.
+	ExecutorBindings []string `json:"executorBindings,omitempty"`
+	UserID           string   `json:"userID,omitempty"`
+	UserGroups       []string `json:"userGroups,omitempty"`
+}
+
+// ResultMessage is a command response sent by the agent to the hub.
+type ResultMessage struct {
+	ID      string              `json:"id"`
+	Message interactive.Message `json:"message"`
+}
@@ -0,0 +1,359 @@
+package hub
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+
+	"github.com/kubeshop/botkube/pkg/bot/interactive"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/events"
+	"github.com/kubeshop/botkube/pkg/execute"
+	"github.com/kubeshop/botkube/pkg/httpsrv"
+	"github.com/kubeshop/botkube/pkg/notifier"
+)
+
+const (
+	// pollTimeout is the time after which a poll request without commands is finished, so the agent can send a new one.
+	pollTimeout = 25 * time.Second
+	// agentTTL is the time after the last poll request, after which the agent is considered disconnected.
+	agentTTL = 2 * pollTimeout
+
+	defaultCommandTimeout = time.Minute
+	commandQueueSize      = 10
+
+	// maxPayloadSize limits the size of the agent requests. Events can contain container logs.
+	maxPayloadSize = 10 << 20
+)
+
+// EventSender sends events to the communication platforms.
+type EventSender interface {
+	SendEvent(ctx context.Context, event events.Event, sources []string, onResult notifier.ResultFn) error
+}
+
+// Server receives events from the agents and forwards them the commands targeting their clusters.
+type Server struct {
+	ctx            context.Context
+	log            logrus.FieldLogger
+	commandTimeout time.Duration
+	pollTimeout    time.Duration
+	// tokens holds the agent bearer tokens indexed by the agent cluster name.
+	tokens map[string]string
+
+	mu      sync.Mutex
+	agents  map[string]*agentConn
+	results map[string]pendingResult
+}
+
+// pendingResult awaits the response of the command sent to the agent of a given cluster.
+type pendingResult struct {
+	cluster string
+	result  chan ResultMessage
+}
+
+// agentHandlerFunc handles a request of the authenticated agent of a given cluster.
+type agentHandlerFunc func(w http.ResponseWriter, req *http.Request, cluster string)
+
+type agentConn struct {
+	commands chan CommandMessage
+	polling  int
+	lastSeen time.Time
+}
+
+// NewServer returns a new Server instance.
+// Given context is used for sending the events, as they are sent after the agent request is handled.
+func NewServer(ctx context.Context, log logrus.FieldLogger, cfg config.Hub) *Server {
+	commandTimeout := cfg.CommandTimeout
+	if commandTimeout <= 0 {
+		commandTimeout = defaultCommandTimeout
+	}
+
+	tokens := make(map[string]string, len(cfg.Agents))
+	for cluster, agent := range cfg.Agents {
+		tokens[cluster] = agent.BearerToken
+	}
+
+	return &Server{
+		ctx:            ctx,
+		log:            log,
+		commandTimeout: commandTimeout,
+		pollTimeout:    pollTimeout,
+		tokens:         tokens,
+		agents:         map[string]*agentConn{},
+		results:        map[string]pendingResult{},
+	}
+}
+
+// Handler returns the HTTP handler of the agent endpoints. The events received from the agents are sent with a given sender.
+// Each agent is authenticated with the bearer token of its cluster, so it can't send events or poll commands for other clusters.
+func (s *Server) Handler(sender EventSender) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc(EventsPath, s.authenticated(func(w http.ResponseWriter, req *http.Request, cluster string) {
+		s.handleEvent(w, req, cluster, sender)
+	}))
+	mux.HandleFunc(CommandsPath, s.authenticated(s.handlePoll))
+	mux.HandleFunc(ResultsPath, s.authenticated(s.handleResult))
+	return mux
+}
+
+// authenticated returns a handler which passes the requests to a given handler together with the cluster name of the agent
+// identified by the bearer token. Requests without a known token are rejected.
+func (s *Server) authenticated(next agentHandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		cluster, found := s.clusterForRequest(req)
+		if !found {
+			http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+			return
+		}
+		next(w, req, cluster)
+	}
+}
+
+// clusterForRequest returns the cluster whose agent token is sent in a given request.
+func (s *Server) clusterForRequest(req *http.Request) (string, bool) {
+	var (
+		out   string
+		found bool
+	)
+	// all tokens are compared, so the time doesn't reveal which agent matched
+	for cluster, token := range s.tokens {
+		if httpsrv.IsBearerTokenValid(req, token) {
+			out, found = cluster, true
+		}
+	}
+	return out, found
+}
+
+// Clusters returns the sorted names of the connected clusters.
+func (s *Server) Clusters() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var out []string
+	for name, conn := range s.agents {
+		if s.isConnected(conn) {
+			out = append(out, name)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+// IsConnected returns true if the agent of a given cluster polls for commands.
+func (s *Server) IsConnected(cluster string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	conn, found := s.agents[cluster]
+	return found && s.isConnected(conn)
+}
+
+// Execute forwards a given command to the agent of a given cluster and waits for its response.
+func (s *Server) Execute(ctx context.Context, cluster string, cmd execute.RemoteCommand) (interactive.Message, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.commandTimeout)
+	defer cancel()
+
+	msg := CommandMessage{
+		ID:      uuid.New().String(),
+		Command: cmd.Command,
+		User:    cmd.User,
+		BotName: cmd.BotName,
+		Stdin:   cmd.Stdin,
+		// the agent enforces the channel bindings and RBAC rules
+		ExecutorBindings: cmd.ExecutorBindings,
+		UserID:           cmd.UserID,
+		UserGroups:       cmd.UserGroups,
+	}
+
+	s.mu.Lock()
+	conn, found := s.agents[cluster]
+	if !found || !s.isConnected(conn) {
+		s.mu.Unlock()
+		return interactive.Message{}, fmt.Errorf("cluster %q is not connected to the hub", cluster)
+	}
+	result := make(chan ResultMessage, 1)
+	s.results[msg.ID] = pendingResult{cluster: cluster, result: result}
+	s.mu.Unlock()
+
+	defer func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		delete(s.results, msg.ID)
+	}()
+
+	select {
+	case conn.commands <- msg:
+	case <-ctx.Done():
+		return interactive.Message{}, fmt.Errorf("while queueing command for cluster %q: %w", cluster, ctx.Err())
+	}
+
+	select {
+	case res := <-result:
+		return res.Message, nil
+	case <-ctx.Done():
+		return interactive.Message{}, fmt.Errorf("while waiting for response from cluster %q: %w", cluster, ctx.Err())
+	}
+}
+
+func (s *Server) handleEvent(w http.ResponseWriter, req *http.Request, cluster string, sender EventSender) {
+	if req.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var msg EventMessage
+	if err := json.NewDecoder(http.MaxBytesReader(w, req.Body, maxPayloadSize)).Decode(&msg); err != nil {
+		s.log.Errorf("while decoding agent event: %s", err.Error())
+		http.Error(w, "invalid payload", http.StatusBadRequest)
+		return
+	}
+	if msg.Cluster == "" {
+		http.Error(w, "cluster name is required", http.StatusBadRequest)
+		return
+	}
+	if msg.Cluster != cluster {
+		http.Error(w, fmt.Sprintf("agent is not allowed to send events for cluster %q", msg.Cluster), http.StatusForbidden)
+		return
+	}
+
+	event := eventForCluster(msg.Event, msg.Cluster)
+	go func() {
+		err := sender.SendEvent(s.ctx, event, msg.Sources, nil)
+		if err != nil {
+			s.log.Errorf("while sending event from cluster %q: %s", msg.Cluster, err.Error())
+		}
+	}()
+
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func (s *Server) handlePoll(w http.ResponseWriter, req *http.Request, agentCluster string) {
+	if req.Method != http.MethodGet {
+		w.Header().Set("Allow", http.MethodGet)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	cluster := req.URL.Query().Get(clusterQueryParam)
+	if cluster == "" {
+		http.Error(w, "cluster name is required", http.StatusBadRequest)
+		return
+	}
+	if cluster != agentCluster {
+		http.Error(w, fmt.Sprintf("agent is not allowed to poll commands for cluster %q", cluster), http.StatusForbidden)
+		return
+	}
+
+	conn := s.startPolling(cluster)
+	defer s.stopPolling(conn)
+
+	select {
+	case cmd := <-conn.commands:
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(cmd); err != nil {
+			s.log.Errorf("while sending command to cluster %q: %s", cluster, err.Error())
+		}
+	case <-time.After(s.pollTimeout):
+		w.WriteHeader(http.StatusNoContent)
+	case <-req.Context().Done():
+	}
+}
+
+func (s *Server) handleResult(w http.ResponseWriter, req *http.Request, cluster string) {
+	if req.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var msg ResultMessage
+	if err := json.NewDecoder(http.MaxBytesReader(w, req.Body, maxPayloadSize)).Decode(&msg); err != nil {
+		s.log.Errorf("while decoding command result: %s", err.Error())
+		http.Error(w, "invalid payload", http.StatusBadRequest)
+		return
+	}
+
+	s.mu.Lock()
+	pending, found := s.results[msg.ID]
+	s.mu.Unlock()
+	if !found || pending.cluster != cluster {
+		// the command has already timed out, or it was sent to another cluster
+		http.Error(w, "unknown command", http.StatusNotFound)
+		return
+	}
+
+	// the channel is buffered and the result is sent only once
+	select {
+	case pending.result <- msg:
+	default:
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *Server) startPolling(cluster string) *agentConn {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	conn, found := s.agents[cluster]
+	if !found {
+		s.log.Infof("Cluster %q connected to the hub", cluster)
+		conn = &agentConn{commands: make(chan CommandMessage, commandQueueSize)}
+		s.agents[cluster] = conn
+	}
+	conn.polling++
+	conn.lastSeen = time.Now()
+	return conn
+}
+
+func (s *Server) stopPolling(conn *agentConn) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	conn.polling--
+	conn.lastSeen = time.Now()
+}
+
+// isConnected returns true if the agent polls for commands, or did it recently. The server mutex must be held.
+func (s *Server) isConnected(conn *agentConn) bool {
+	return conn.polling > 0 || time.Since(conn.lastSeen) < agentTTL
+}
+
+// eventForCluster prefixes the event title with the cluster name, and makes the event buttons target the cluster.
+func eventForCluster(event events.Event, cluster string) events.Event {
+	if event.Cluster == "" {
+		event.Cluster = cluster
+	}
+	event.Title = fmt.Sprintf("[%s] %s", event.Cluster, event.Title)
+
+	if len(event.Buttons) > 0 {
+		buttons := make([]events.Button, 0, len(event.Buttons))
+		for _, btn := range event.Buttons {
+			btn.Command = withClusterFlag(btn.Command, event.Cluster)
+			buttons = append(buttons, btn)
+		}
+		event.Buttons = buttons
+	}
+	if len(event.SuggestedCommands) > 0 {
+		cmds := make([]string, 0, len(event.SuggestedCommands))
+		for _, cmd := range event.SuggestedCommands {
+			cmds = append(cmds, withClusterFlag(cmd, event.Cluster))
+		}
+		event.SuggestedCommands = cmds
+	}
+
+	// actions are already executed by the agent
+	event.Actions = nil
+	return event
+}
+
+func withClusterFlag(cmd, cluster string) string {
+	return fmt.Sprintf("%s %s=%s", cmd, execute.AbbrClusterFlag, cluster)
+}
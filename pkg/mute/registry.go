@@ -0,0 +1,154 @@
+package mute
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kubeshop/botkube/pkg/events"
+)
+
+// Rule suppresses notifications about a given resource or namespace.
+type Rule struct {
+	// Kind and Name identify the muted resource. They are empty for the namespace rules.
+	// Resource rules match also the events for objects controlled by the resource, e.g. Pods of a Deployment.
+	Kind string
+	Name string
+	// Namespace is the muted namespace. It is empty for the resource rules.
+	Namespace string
+	// Until is the rule expiration time. Zero time means that the rule doesn't expire.
+	Until time.Time
+}
+
+// String returns the muted target, e.g. `deployment/payments-api` or `namespace staging`.
+func (r Rule) String() string {
+	if r.Kind == "" {
+		return fmt.Sprintf("namespace %s", r.Namespace)
+	}
+	return fmt.Sprintf("%s/%s", strings.ToLower(r.Kind), r.Name)
+}
+
+// Matches returns true if a given event is muted by the rule.
+func (r Rule) Matches(event events.Event) bool {
+	if r.Kind == "" {
+		return event.Namespace == r.Namespace
+	}
+
+	if strings.EqualFold(event.Kind, r.Kind) && event.Name == r.Name {
+		return true
+	}
+	return event.Owner != nil && strings.EqualFold(event.Owner.Kind, r.Kind) && event.Owner.Name == r.Name
+}
+
+func (r Rule) isActive(now time.Time) bool {
+	return r.Until.IsZero() || now.Before(r.Until)
+}
+
+func (r Rule) sameTarget(other Rule) bool {
+	return strings.EqualFold(r.Kind, other.Kind) && r.Name == other.Name && r.Namespace == other.Namespace
+}
+
+// Registry holds the mute rules per channel. The rules are kept in memory only.
+type Registry struct {
+	now func() time.Time
+
+	mu    sync.RWMutex
+	rules map[string][]Rule
+}
+
+// NewRegistry returns a new Registry instance.
+func NewRegistry() *Registry {
+	return &Registry{
+		now:   time.Now,
+		rules: map[string][]Rule{},
+	}
+}
+
+// Add adds a given rule for a given channel. It replaces the existing rule for the same target.
+func (r *Registry) Add(channel string, rule Rule) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	rules := r.activeRules(channel)
+	for i := range rules {
+		if rules[i].sameTarget(rule) {
+			rules[i] = rule
+			r.rules[channel] = rules
+			return
+		}
+	}
+	r.rules[channel] = append(rules, rule)
+}
+
+// Remove removes the rule for the same target as a given rule. It returns false if there was no such active rule.
+func (r *Registry) Remove(channel string, rule Rule) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	rules := r.activeRules(channel)
+	for i := range rules {
+		if rules[i].sameTarget(rule) {
+			r.rules[channel] = append(rules[:i], rules[i+1:]...)
+			return true
+		}
+	}
+	r.rules[channel] = rules
+	return false
+}
+
+// List returns active rules for a given channel, sorted by their targets.
+func (r *Registry) List(channel string) []Rule {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	rules := r.activeRules(channel)
+	r.rules[channel] = rules
+
+	out := make([]Rule, len(rules))
+	copy(out, rules)
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].String() < out[j].String()
+	})
+	return out
+}
+
+// Filter returns channels for which a given event is not muted.
+func (r *Registry) Filter(event events.Event, channels []string) []string {
+	now := r.now()
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var out []string
+	for _, channel := range channels {
+		if r.isMuted(channel, event, now) {
+			continue
+		}
+		out = append(out, channel)
+	}
+	return out
+}
+
+func (r *Registry) isMuted(channel string, event events.Event, now time.Time) bool {
+	for _, rule := range r.rules[channel] {
+		if rule.isActive(now) && rule.Matches(event) {
+			return true
+		}
+	}
+	return false
+}
+
+// activeRules returns the rules which haven't expired yet. It must be called with the lock held.
+func (r *Registry) activeRules(channel string) []Rule {
+	now := r.now()
+
+	var out []Rule
+	for _, rule := range r.rules[channel] {
+		if rule.isActive(now) {
+			out = append(out, rule)
+		}
+	}
+	return out
+}
@@ -0,0 +1,113 @@
+package mute
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kubeshop/botkube/pkg/events"
+)
+
+func TestRuleMatches(t *testing.T) {
+	tests := []struct {
+		name  string
+		rule  Rule
+		event events.Event
+
+		expected bool
+	}{
+		{
+			name:     "Namespace",
+			rule:     Rule{Namespace: "staging"},
+			event:    fixEvent("Pod", "staging", "api-7d9f-x2b4c", nil),
+			expected: true,
+		},
+		{
+			name:  "Other namespace",
+			rule:  Rule{Namespace: "staging"},
+			event: fixEvent("Pod", "prod", "api-7d9f-x2b4c", nil),
+		},
+		{
+			name:     "Resource",
+			rule:     Rule{Kind: "deployment", Name: "payments-api"},
+			event:    fixEvent("Deployment", "prod", "payments-api", nil),
+			expected: true,
+		},
+		{
+			name:     "Resource owner",
+			rule:     Rule{Kind: "deployment", Name: "payments-api"},
+			event:    fixEvent("Pod", "prod", "payments-api-7d9f-x2b4c", &events.Owner{Kind: "Deployment", Name: "payments-api"}),
+			expected: true,
+		},
+		{
+			name:  "Other resource",
+			rule:  Rule{Kind: "deployment", Name: "payments-api"},
+			event: fixEvent("Deployment", "prod", "orders-api", nil),
+		},
+		{
+			name:  "Other kind",
+			rule:  Rule{Kind: "deployment", Name: "payments-api"},
+			event: fixEvent("Service", "prod", "payments-api", nil),
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.rule.Matches(tc.event))
+		})
+	}
+}
+
+func TestRegistry(t *testing.T) {
+	// given
+	now := time.Date(2022, 10, 10, 12, 0, 0, 0, time.UTC)
+	registry := NewRegistry()
+	registry.now = func() time.Time { return now }
+
+	event := fixEvent("Pod", "staging", "payments-api-7d9f-x2b4c", &events.Owner{Kind: "Deployment", Name: "payments-api"})
+	channels := []string{"alerts", "dev"}
+
+	// when
+	registry.Add("alerts", Rule{Kind: "deployment", Name: "payments-api", Until: now.Add(time.Hour)})
+	registry.Add("dev", Rule{Namespace: "staging"})
+	registry.Add("dev", Rule{Kind: "Deployment", Name: "payments-api", Until: now.Add(time.Minute)})
+
+	// then
+	assert.Empty(t, registry.Filter(event, channels))
+	assert.Equal(t, []Rule{
+		{Kind: "Deployment", Name: "payments-api", Until: now.Add(time.Minute)},
+		{Namespace: "staging"},
+	}, registry.List("dev"))
+
+	// when
+	removed := registry.Remove("dev", Rule{Namespace: "staging"})
+	notFound := registry.Remove("dev", Rule{Namespace: "prod"})
+
+	// then
+	assert.True(t, removed)
+	assert.False(t, notFound)
+	assert.Empty(t, registry.Filter(event, channels))
+
+	// when
+	now = now.Add(30 * time.Minute)
+
+	// then
+	assert.Equal(t, []string{"dev"}, registry.Filter(event, channels))
+	assert.Empty(t, registry.List("dev"))
+	assert.Len(t, registry.List("alerts"), 1)
+}
+
+func TestRuleString(t *testing.T) {
+	assert.Equal(t, "deployment/payments-api", Rule{Kind: "Deployment", Name: "payments-api"}.String())
+	assert.Equal(t, "namespace staging", Rule{Namespace: "staging"}.String())
+}
+
+func fixEvent(kind, namespace, name string, owner *events.Owner) events.Event {
+	return events.Event{
+		TypeMeta:  metaV1.TypeMeta{Kind: kind},
+		Name:      name,
+		Namespace: namespace,
+		Owner:     owner,
+	}
+}
@@ -0,0 +1,133 @@
+package notifier
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/kubeshop/botkube/internal/analytics"
+	"github.com/kubeshop/botkube/pkg/bot/interactive"
+	"github.com/kubeshop/botkube/pkg/events"
+	"github.com/kubeshop/botkube/pkg/multierror"
+)
+
+// maxInFlightCallsPerNotifier limits the number of concurrent calls to a single notifier.
+// Calls abandoned after the timeout keep their slot until the notifier returns, so a stuck notifier
+// can't pile up goroutines, and it doesn't take the slots of other notifiers.
+const maxInFlightCallsPerNotifier = 4
+
+// ResultFn is called once a given notifier finishes handling the dispatched call.
+type ResultFn func(n Notifier, err error)
+
+// Dispatcher fans out calls to all notifiers concurrently, so a slow notifier doesn't delay the others.
+type Dispatcher struct {
+	log       analytics.ReportPanicLogger
+	reporter  analytics.FatalErrorAnalyticsReporter
+	notifiers []Notifier
+	slots     []chan struct{}
+	timeout   time.Duration
+}
+
+// NewDispatcher returns a new Dispatcher instance. Each notifier call is bounded by a given timeout. Zero timeout means no limit.
+func NewDispatcher(log analytics.ReportPanicLogger, reporter analytics.FatalErrorAnalyticsReporter, notifiers []Notifier, timeout time.Duration) *Dispatcher {
+	slots := make([]chan struct{}, len(notifiers))
+	for i := range slots {
+		slots[i] = make(chan struct{}, maxInFlightCallsPerNotifier)
+	}
+	return &Dispatcher{
+		log:       log,
+		reporter:  reporter,
+		notifiers: notifiers,
+		slots:     slots,
+		timeout:   timeout,
+	}
+}
+
+// SendEvent sends a given event to all notifiers and waits until they finish.
+// The onResult function, if provided, is called for each notifier. Returned error aggregates all notifier errors.
+func (d *Dispatcher) SendEvent(ctx context.Context, event events.Event, sources []string, onResult ResultFn) error {
+	return d.dispatch(ctx, func(ctx context.Context, n Notifier) error {
+		return n.SendEvent(ctx, event, sources)
+	}, onResult)
+}
+
+// SendGenericMessage sends a given message to all notifiers and waits until they finish.
+// Returned error aggregates all notifier errors.
+func (d *Dispatcher) SendGenericMessage(ctx context.Context, msg interactive.GenericMessage, sources []string) error {
+	return d.dispatch(ctx, func(ctx context.Context, n Notifier) error {
+		return n.SendGenericMessage(ctx, msg, sources)
+	}, nil)
+}
+
+func (d *Dispatcher) dispatch(ctx context.Context, call func(context.Context, Notifier) error, onResult ResultFn) error {
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs = multierror.New()
+	)
+
+	for i, n := range d.notifiers {
+		wg.Add(1)
+		go func(n Notifier, slots chan struct{}) {
+			defer wg.Done()
+			defer analytics.ReportPanicIfOccurs(d.log, d.reporter)
+
+			err := d.callWithTimeout(ctx, n, slots, call)
+			if onResult != nil {
+				onResult(n, err)
+			}
+			if err == nil {
+				return
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			errs = multierror.Append(errs, fmt.Errorf("%s %s: %w", n.IntegrationName(), n.Type(), err))
+		}(n, d.slots[i])
+	}
+	wg.Wait()
+
+	return errs.ErrorOrNil()
+}
+
+// callWithTimeout calls a given notifier once there is a free slot for it. It stops waiting when the timeout fires,
+// even if the notifier ignores the context. In such case, the call keeps its slot until it returns.
+func (d *Dispatcher) callWithTimeout(ctx context.Context, n Notifier, slots chan struct{}, call func(context.Context, Notifier) error) error {
+	if d.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, d.timeout)
+		defer cancel()
+	}
+
+	select {
+	case slots <- struct{}{}:
+	case <-ctx.Done():
+		return d.contextErr(ctx, ctx.Err())
+	}
+
+	result := make(chan error, 1)
+	go func() {
+		defer func() { <-slots }()
+		defer analytics.ReportPanicIfOccurs(d.log, d.reporter)
+
+		result <- call(ctx, n)
+	}()
+
+	select {
+	case err := <-result:
+		if err != nil && ctx.Err() == context.DeadlineExceeded {
+			return d.contextErr(ctx, err)
+		}
+		return err
+	case <-ctx.Done():
+		return d.contextErr(ctx, ctx.Err())
+	}
+}
+
+func (d *Dispatcher) contextErr(ctx context.Context, err error) error {
+	if ctx.Err() == context.DeadlineExceeded && d.timeout > 0 {
+		return fmt.Errorf("timed out after %s: %w", d.timeout, err)
+	}
+	return err
+}
@@ -0,0 +1,182 @@
+package notifier
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	logtest "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/internal/analytics"
+	"github.com/kubeshop/botkube/pkg/bot/interactive"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/events"
+)
+
+func TestDispatcherSendEvent(t *testing.T) {
+	// given
+	release := make(chan struct{})
+	slow := &fakeNotifier{name: config.SlackCommPlatformIntegration, block: release}
+	fast := &fakeNotifier{name: config.ElasticsearchCommPlatformIntegration}
+	failing := &fakeNotifier{name: config.WebhookCommPlatformIntegration, err: errors.New("service unavailable")}
+
+	logger, _ := logtest.NewNullLogger()
+	dispatcher := NewDispatcher(logger, analytics.NewNoopReporter(), []Notifier{slow, fast, failing}, time.Minute)
+
+	var (
+		mu      sync.Mutex
+		results = map[config.CommPlatformIntegration]error{}
+	)
+	onResult := func(n Notifier, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		results[n.IntegrationName()] = err
+	}
+
+	// when
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- dispatcher.SendEvent(context.Background(), events.Event{Name: "nginx"}, []string{"k8s-events"}, onResult)
+	}()
+
+	// then
+	assert.Eventually(t, func() bool {
+		return fast.Calls() == 1 && failing.Calls() == 1
+	}, time.Second, time.Millisecond, "fast notifiers shouldn't wait for the slow one")
+
+	close(release)
+	err := <-errCh
+	require.Error(t, err)
+	assert.EqualError(t, err, "1 error occurred:\n\t* webhook sink: service unavailable")
+
+	assert.Equal(t, map[config.CommPlatformIntegration]error{
+		config.SlackCommPlatformIntegration:         nil,
+		config.ElasticsearchCommPlatformIntegration: nil,
+		config.WebhookCommPlatformIntegration:       failing.err,
+	}, results)
+}
+
+func TestDispatcherSendEventTimeout(t *testing.T) {
+	// given
+	stuck := &fakeNotifier{name: config.SlackCommPlatformIntegration, block: make(chan struct{})}
+
+	logger, _ := logtest.NewNullLogger()
+	dispatcher := NewDispatcher(logger, analytics.NewNoopReporter(), []Notifier{stuck}, 10*time.Millisecond)
+
+	// when
+	err := dispatcher.SendEvent(context.Background(), events.Event{Name: "nginx"}, nil, nil)
+
+	// then
+	require.Error(t, err)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Contains(t, err.Error(), "slack bot: timed out after 10ms")
+}
+
+func TestDispatcherSendEventIgnoredContext(t *testing.T) {
+	// given
+	release := make(chan struct{})
+	defer close(release)
+	stuck := &fakeNotifier{name: config.SlackCommPlatformIntegration, block: release, ignoreCtx: true}
+	fast := &fakeNotifier{name: config.ElasticsearchCommPlatformIntegration}
+
+	logger, _ := logtest.NewNullLogger()
+	dispatcher := NewDispatcher(logger, analytics.NewNoopReporter(), []Notifier{stuck, fast}, 10*time.Millisecond)
+
+	const sentEvents = 3 * maxInFlightCallsPerNotifier
+
+	// when
+	var errs []error
+	for i := 0; i < sentEvents; i++ {
+		errs = append(errs, dispatcher.SendEvent(context.Background(), events.Event{Name: "nginx"}, nil, nil))
+	}
+
+	// then
+	for _, err := range errs {
+		require.Error(t, err)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Contains(t, err.Error(), "slack bot: timed out after 10ms")
+	}
+	assert.Equal(t, maxInFlightCallsPerNotifier, stuck.Calls(), "stuck notifier should be called only when it has a free slot")
+	assert.Equal(t, sentEvents, fast.Calls())
+}
+
+func TestDispatcherSendEventReleasesSlots(t *testing.T) {
+	// given
+	release := make(chan struct{})
+	stuck := &fakeNotifier{name: config.SlackCommPlatformIntegration, block: release, ignoreCtx: true}
+
+	logger, _ := logtest.NewNullLogger()
+	dispatcher := NewDispatcher(logger, analytics.NewNoopReporter(), []Notifier{stuck}, 10*time.Millisecond)
+
+	for i := 0; i < maxInFlightCallsPerNotifier; i++ {
+		err := dispatcher.SendEvent(context.Background(), events.Event{Name: "nginx"}, nil, nil)
+		require.Error(t, err)
+	}
+
+	// when
+	close(release)
+
+	// then
+	assert.Eventually(t, func() bool {
+		return dispatcher.SendEvent(context.Background(), events.Event{Name: "nginx"}, nil, nil) == nil
+	}, time.Second, time.Millisecond, "slots should be released once the stuck calls return")
+}
+
+type fakeNotifier struct {
+	name      config.CommPlatformIntegration
+	err       error
+	block     chan struct{}
+	ignoreCtx bool
+
+	mu    sync.Mutex
+	calls int
+}
+
+func (f *fakeNotifier) SendEvent(ctx context.Context, _ events.Event, _ []string) error {
+	f.mu.Lock()
+	f.calls++
+	f.mu.Unlock()
+
+	if f.block == nil {
+		return f.err
+	}
+	if f.ignoreCtx {
+		<-f.block
+		return f.err
+	}
+	select {
+	case <-f.block:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	return f.err
+}
+
+func (f *fakeNotifier) Calls() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.calls
+}
+
+func (f *fakeNotifier) SendMessageToAll(context.Context, interactive.Message) error {
+	return nil
+}
+
+func (f *fakeNotifier) SendGenericMessage(context.Context, interactive.GenericMessage, []string) error {
+	return nil
+}
+
+func (f *fakeNotifier) IntegrationName() config.CommPlatformIntegration {
+	return f.name
+}
+
+func (f *fakeNotifier) Type() config.IntegrationType {
+	if f.name == config.SlackCommPlatformIntegration {
+		return config.BotIntegrationType
+	}
+	return config.SinkIntegrationType
+}
@@ -0,0 +1,106 @@
+package owner
+
+import (
+	"context"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+
+	"github.com/kubeshop/botkube/pkg/events"
+)
+
+// maxChainLength limits the number of owners followed, in case of circular owner references.
+const maxChainLength = 10
+
+// Resolver resolves the top-level controller of Kubernetes objects by following their controller owner references,
+// for example Pod → ReplicaSet → Deployment, or Pod → Job → CronJob.
+type Resolver struct {
+	dynamicCli dynamic.Interface
+	mapper     meta.RESTMapper
+}
+
+// NewResolver returns a new Resolver instance.
+func NewResolver(dynamicCli dynamic.Interface, mapper meta.RESTMapper) *Resolver {
+	return &Resolver{
+		dynamicCli: dynamicCli,
+		mapper:     mapper,
+	}
+}
+
+// TopLevelOwnerForEvent returns the top-level controller of the event object.
+// For Kubernetes Events, the involved object is resolved. It returns nil if the object is not controlled by any other object.
+func (r *Resolver) TopLevelOwnerForEvent(ctx context.Context, event events.Event) (*events.Owner, error) {
+	if obj, ok := event.Object.(*unstructured.Unstructured); ok && obj.GetKind() != "Event" {
+		return r.TopLevelOwner(ctx, obj.GetNamespace(), obj.GetOwnerReferences())
+	}
+
+	if event.Kind == "" || event.Name == "" {
+		return nil, nil
+	}
+	obj, err := r.get(ctx, schema.FromAPIVersionAndKind(event.APIVersion, event.Kind), event.Namespace, event.Name)
+	if err != nil || obj == nil {
+		return nil, err
+	}
+	return r.TopLevelOwner(ctx, event.Namespace, obj.GetOwnerReferences())
+}
+
+// TopLevelOwner follows the controller owner references and returns the last resolved owner.
+// If an owner doesn't exist anymore, the last existing one is returned. It returns nil if there is no controller owner reference.
+func (r *Resolver) TopLevelOwner(ctx context.Context, namespace string, refs []metaV1.OwnerReference) (*events.Owner, error) {
+	var owner *events.Owner
+	for i := 0; i < maxChainLength; i++ {
+		ref := controllerRef(refs)
+		if ref == nil {
+			return owner, nil
+		}
+
+		owner = &events.Owner{
+			APIVersion: ref.APIVersion,
+			Kind:       ref.Kind,
+			Name:       ref.Name,
+		}
+		obj, err := r.get(ctx, schema.FromAPIVersionAndKind(ref.APIVersion, ref.Kind), namespace, ref.Name)
+		if err != nil {
+			return nil, err
+		}
+		if obj == nil {
+			return owner, nil
+		}
+		refs = obj.GetOwnerReferences()
+	}
+	return owner, nil
+}
+
+// get returns a given object. It returns nil if the object is not found.
+func (r *Resolver) get(ctx context.Context, gvk schema.GroupVersionKind, namespace, name string) (*unstructured.Unstructured, error) {
+	mapping, err := r.mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		return nil, fmt.Errorf("while getting REST mapping for %s: %w", gvk.String(), err)
+	}
+	if mapping.Scope.Name() == meta.RESTScopeNameRoot {
+		namespace = ""
+	}
+
+	obj, err := r.dynamicCli.Resource(mapping.Resource).Namespace(namespace).Get(ctx, name, metaV1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("while getting %s %q: %w", gvk.Kind, name, err)
+	}
+	return obj, nil
+}
+
+func controllerRef(refs []metaV1.OwnerReference) *metaV1.OwnerReference {
+	for i := range refs {
+		if refs[i].Controller != nil && *refs[i].Controller {
+			return &refs[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,115 @@
+package owner
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic/fake"
+
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/events"
+)
+
+func TestResolverTopLevelOwnerForEvent(t *testing.T) {
+	tests := []struct {
+		name  string
+		event events.Event
+
+		expected *events.Owner
+	}{
+		{
+			name:     "Pod managed by Deployment",
+			event:    fixEvent(fixObject("v1", "Pod", "payments-api-7d9f-x2b4c", fixRef("apps/v1", "ReplicaSet", "payments-api-7d9f"))),
+			expected: &events.Owner{APIVersion: "apps/v1", Kind: "Deployment", Name: "payments-api"},
+		},
+		{
+			name:     "Pod managed by CronJob",
+			event:    fixEvent(fixObject("v1", "Pod", "backup-27812-abcde", fixRef("batch/v1", "Job", "backup-27812"))),
+			expected: &events.Owner{APIVersion: "batch/v1", Kind: "CronJob", Name: "backup"},
+		},
+		{
+			name:     "Owner removed",
+			event:    fixEvent(fixObject("v1", "Pod", "worker-abcde", fixRef("apps/v1", "ReplicaSet", "worker-6c8d"))),
+			expected: &events.Owner{APIVersion: "apps/v1", Kind: "ReplicaSet", Name: "worker-6c8d"},
+		},
+		{
+			name:  "Object without owner",
+			event: fixEvent(fixObject("v1", "Pod", "standalone")),
+		},
+		{
+			name: "Kubernetes Event for Pod",
+			event: events.Event{
+				TypeMeta:  metaV1.TypeMeta{APIVersion: "v1", Kind: "Pod"},
+				Name:      "payments-api-7d9f-x2b4c",
+				Namespace: "default",
+				Object:    fixObject("v1", "Event", "payments-api-7d9f-x2b4c.1234"),
+			},
+			expected: &events.Owner{APIVersion: "apps/v1", Kind: "Deployment", Name: "payments-api"},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// given
+			resolver := NewResolver(fake.NewSimpleDynamicClient(runtime.NewScheme(),
+				fixObject("v1", "Pod", "payments-api-7d9f-x2b4c", fixRef("apps/v1", "ReplicaSet", "payments-api-7d9f")),
+				fixObject("apps/v1", "ReplicaSet", "payments-api-7d9f", fixRef("apps/v1", "Deployment", "payments-api")),
+				fixObject("apps/v1", "Deployment", "payments-api"),
+				fixObject("batch/v1", "Job", "backup-27812", fixRef("batch/v1", "CronJob", "backup")),
+				fixObject("batch/v1", "CronJob", "backup"),
+			), fixMapper())
+
+			// when
+			owner, err := resolver.TopLevelOwnerForEvent(context.Background(), tc.event)
+
+			// then
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, owner)
+		})
+	}
+}
+
+func fixEvent(obj *unstructured.Unstructured) events.Event {
+	return events.Event{
+		TypeMeta:  metaV1.TypeMeta{APIVersion: obj.GetAPIVersion(), Kind: obj.GetKind()},
+		Name:      obj.GetName(),
+		Namespace: obj.GetNamespace(),
+		Type:      config.CreateEvent,
+		Object:    obj,
+	}
+}
+
+func fixObject(apiVersion, kind, name string, refs ...metaV1.OwnerReference) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion(apiVersion)
+	obj.SetKind(kind)
+	obj.SetName(name)
+	obj.SetNamespace("default")
+	obj.SetOwnerReferences(refs)
+	return obj
+}
+
+func fixRef(apiVersion, kind, name string) metaV1.OwnerReference {
+	controller := true
+	return metaV1.OwnerReference{APIVersion: apiVersion, Kind: kind, Name: name, Controller: &controller}
+}
+
+func fixMapper() meta.RESTMapper {
+	mapper := meta.NewDefaultRESTMapper(nil)
+	for _, gvk := range []schema.GroupVersionKind{
+		{Version: "v1", Kind: "Pod"},
+		{Group: "apps", Version: "v1", Kind: "ReplicaSet"},
+		{Group: "apps", Version: "v1", Kind: "Deployment"},
+		{Group: "batch", Version: "v1", Kind: "Job"},
+		{Group: "batch", Version: "v1", Kind: "CronJob"},
+	} {
+		mapper.Add(gvk, meta.RESTScopeNamespace)
+	}
+	return mapper
+}
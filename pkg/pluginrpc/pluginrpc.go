@@ -0,0 +1,158 @@
+// Package pluginrpc holds the gRPC transport shared by the Botkube plugins. Plugins are separate binaries
+// started and supervised with hashicorp/go-plugin. The contract between Botkube and the plugins is defined in pb/plugin.proto.
+package pluginrpc
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os/exec"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/go-plugin"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+
+	"github.com/kubeshop/botkube/pkg/pluginrpc/pb"
+)
+
+const (
+	// SourcePluginName is the name under which source plugins serve the Source service.
+	SourcePluginName = "source"
+	// ExecutorPluginName is the name under which executor plugins serve the Executor service.
+	ExecutorPluginName = "executor"
+)
+
+// Handshake is used by Botkube and the plugins to verify that they speak the same protocol.
+// It's not a security measure, it only prevents from running a plugin binary directly.
+var Handshake = plugin.HandshakeConfig{
+	ProtocolVersion:  1,
+	MagicCookieKey:   "BOTKUBE_PLUGIN",
+	MagicCookieValue: "botkube",
+}
+
+// Conn is a connection to a running plugin binary.
+type Conn struct {
+	client *plugin.Client
+	conn   *grpc.ClientConn
+	done   <-chan struct{}
+	stderr io.Closer
+}
+
+// Start runs a given plugin binary and connects to the plugin served under a given name, such as SourcePluginName.
+// Lines written to stderr are logged. The binary must be stopped with Kill.
+func Start(log logrus.FieldLogger, path, name string) (*Conn, error) {
+	stderr := log.WithField("stream", "stderr").WriterLevel(logrus.InfoLevel)
+	client := plugin.NewClient(&plugin.ClientConfig{
+		HandshakeConfig: Handshake,
+		Plugins:         pluginSet(nil, nil),
+		// #nosec G204 -- the binary comes from the configured plugins directory
+		Cmd:              exec.Command(path),
+		AllowedProtocols: []plugin.Protocol{plugin.ProtocolGRPC},
+		Stderr:           stderr,
+		Logger:           hclog.NewNullLogger(),
+	})
+	fail := func(err error) (*Conn, error) {
+		client.Kill()
+		_ = stderr.Close()
+		return nil, err
+	}
+
+	rpc, err := client.Client()
+	if err != nil {
+		return fail(fmt.Errorf("while starting %q: %w", path, err))
+	}
+	raw, err := rpc.Dispense(name)
+	if err != nil {
+		return fail(fmt.Errorf("while connecting to %q plugin: %w", name, err))
+	}
+	cli, ok := raw.(*grpcClient)
+	if !ok {
+		return fail(fmt.Errorf("unexpected %q plugin client type %T", name, raw))
+	}
+
+	return &Conn{client: client, conn: cli.conn, done: cli.done, stderr: stderr}, nil
+}
+
+// Source returns a client of the Source service served by the plugin.
+func (c *Conn) Source() pb.SourceClient {
+	return pb.NewSourceClient(c.conn)
+}
+
+// Executor returns a client of the Executor service served by the plugin.
+func (c *Conn) Executor() pb.ExecutorClient {
+	return pb.NewExecutorClient(c.conn)
+}
+
+// Done returns a channel which is closed when the plugin binary exits.
+func (c *Conn) Done() <-chan struct{} {
+	return c.done
+}
+
+// Kill stops the plugin binary.
+func (c *Conn) Kill() {
+	c.client.Kill()
+	_ = c.stderr.Close()
+}
+
+// ServeSource serves a given Source implementation. It's meant to be called from the main function of a source plugin.
+func ServeSource(impl pb.SourceServer) {
+	plugin.Serve(&plugin.ServeConfig{
+		HandshakeConfig: Handshake,
+		Plugins:         pluginSet(impl, nil),
+		GRPCServer:      plugin.DefaultGRPCServer,
+	})
+}
+
+// ServeExecutor serves a given Executor implementation. It's meant to be called from the main function of an executor plugin.
+func ServeExecutor(impl pb.ExecutorServer) {
+	plugin.Serve(&plugin.ServeConfig{
+		HandshakeConfig: Handshake,
+		Plugins:         pluginSet(nil, impl),
+		GRPCServer:      plugin.DefaultGRPCServer,
+	})
+}
+
+func pluginSet(source pb.SourceServer, executor pb.ExecutorServer) plugin.PluginSet {
+	return plugin.PluginSet{
+		SourcePluginName:   &sourcePlugin{impl: source},
+		ExecutorPluginName: &executorPlugin{impl: executor},
+	}
+}
+
+// grpcClient is returned on the Botkube side for both plugin types, as the services share the connection.
+type grpcClient struct {
+	conn *grpc.ClientConn
+	// done is closed when the plugin binary exits.
+	done <-chan struct{}
+}
+
+// sourcePlugin implements plugin.GRPCPlugin for the Source service.
+type sourcePlugin struct {
+	plugin.NetRPCUnsupportedPlugin
+	impl pb.SourceServer
+}
+
+func (p *sourcePlugin) GRPCServer(_ *plugin.GRPCBroker, s *grpc.Server) error {
+	pb.RegisterSourceServer(s, p.impl)
+	return nil
+}
+
+func (p *sourcePlugin) GRPCClient(ctx context.Context, _ *plugin.GRPCBroker, conn *grpc.ClientConn) (interface{}, error) {
+	return &grpcClient{conn: conn, done: ctx.Done()}, nil
+}
+
+// executorPlugin implements plugin.GRPCPlugin for the Executor service.
+type executorPlugin struct {
+	plugin.NetRPCUnsupportedPlugin
+	impl pb.ExecutorServer
+}
+
+func (p *executorPlugin) GRPCServer(_ *plugin.GRPCBroker, s *grpc.Server) error {
+	pb.RegisterExecutorServer(s, p.impl)
+	return nil
+}
+
+func (p *executorPlugin) GRPCClient(ctx context.Context, _ *plugin.GRPCBroker, conn *grpc.ClientConn) (interface{}, error) {
+	return &grpcClient{conn: conn, done: ctx.Done()}, nil
+}
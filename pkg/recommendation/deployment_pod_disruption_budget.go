@@ -0,0 +1,114 @@
+package recommendation
+
+import (
+	"context"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/events"
+	"github.com/kubeshop/botkube/pkg/utils"
+)
+
+const deploymentPodDisruptionBudgetName = "DeploymentPodDisruptionBudget"
+
+// DeploymentPodDisruptionBudgetProvider provides the DeploymentPodDisruptionBudget recommendation.
+type DeploymentPodDisruptionBudgetProvider struct{}
+
+// Name returns the provider name.
+func (DeploymentPodDisruptionBudgetProvider) Name() string {
+	return deploymentPodDisruptionBudgetName
+}
+
+// NewRecommendation creates a new DeploymentPodDisruptionBudget instance.
+func (DeploymentPodDisruptionBudgetProvider) NewRecommendation(deps Dependencies) Recommendation {
+	return NewDeploymentPodDisruptionBudget(deps.DynamicCli)
+}
+
+// DeploymentPodDisruptionBudget adds recommendations if replicated Deployment Pods are not covered by any PodDisruptionBudget.
+type DeploymentPodDisruptionBudget struct {
+	dynamicCli dynamic.Interface
+}
+
+// NewDeploymentPodDisruptionBudget creates a new DeploymentPodDisruptionBudget instance.
+func NewDeploymentPodDisruptionBudget(dynamicCli dynamic.Interface) *DeploymentPodDisruptionBudget {
+	return &DeploymentPodDisruptionBudget{dynamicCli: dynamicCli}
+}
+
+// Do executes the recommendation checks.
+func (f *DeploymentPodDisruptionBudget) Do(ctx context.Context, event events.Event) (Result, error) {
+	if event.Kind != "Deployment" || event.Type != config.CreateEvent || utils.GetObjectTypeMetaData(event.Object).Kind == "Event" {
+		return Result{}, nil
+	}
+
+	unstrObj, ok := event.Object.(*unstructured.Unstructured)
+	if !ok {
+		return Result{}, fmt.Errorf("cannot convert %T into type %T", event.Object, unstrObj)
+	}
+
+	var deployment appsv1.Deployment
+	err := utils.TransformIntoTypedObject(unstrObj, &deployment)
+	if err != nil {
+		return Result{}, fmt.Errorf("while transforming object type %T into type: %T: %w", event.Object, deployment, err)
+	}
+
+	// a single replica can't stay available during a voluntary disruption anyway
+	if deployment.Spec.Replicas == nil || *deployment.Spec.Replicas < 2 {
+		return Result{}, nil
+	}
+
+	covered, err := f.isCoveredByPodDisruptionBudget(ctx, deployment)
+	if err != nil {
+		return Result{}, fmt.Errorf("while checking PodDisruptionBudgets: %w", err)
+	}
+	if covered {
+		return Result{}, nil
+	}
+
+	return Result{
+		Info: []string{
+			fmt.Sprintf("Pods of Deployment '%s/%s' are not covered by any PodDisruptionBudget, so all replicas can be evicted at once during node maintenance.", deployment.Namespace, deployment.Name),
+		},
+	}, nil
+}
+
+func (f *DeploymentPodDisruptionBudget) isCoveredByPodDisruptionBudget(ctx context.Context, deployment appsv1.Deployment) (bool, error) {
+	pdbGVR := schema.GroupVersionResource{
+		Group:    "policy",
+		Version:  "v1",
+		Resource: "poddisruptionbudgets",
+	}
+	list, err := f.dynamicCli.Resource(pdbGVR).Namespace(deployment.Namespace).List(ctx, metaV1.ListOptions{})
+	if err != nil {
+		return false, err
+	}
+
+	podLabels := labels.Set(deployment.Spec.Template.Labels)
+	for idx := range list.Items {
+		var pdb policyv1.PodDisruptionBudget
+		if err := utils.TransformIntoTypedObject(&list.Items[idx], &pdb); err != nil {
+			return false, fmt.Errorf("while transforming object type %T into type: %T: %w", list.Items[idx], pdb, err)
+		}
+
+		selector, err := metaV1.LabelSelectorAsSelector(pdb.Spec.Selector)
+		if err != nil {
+			return false, fmt.Errorf("while parsing selector of PodDisruptionBudget %q: %w", pdb.Name, err)
+		}
+		if selector.Matches(podLabels) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// Name returns the recommendation name.
+func (f *DeploymentPodDisruptionBudget) Name() string {
+	return deploymentPodDisruptionBudgetName
+}
@@ -0,0 +1,112 @@
+package recommendation_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	coreV1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/scheme"
+
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/events"
+	"github.com/kubeshop/botkube/pkg/recommendation"
+)
+
+func TestDeploymentPodDisruptionBudget_Do(t *testing.T) {
+	tests := []struct {
+		name     string
+		replicas int32
+		pdbs     []runtime.Object
+
+		expected recommendation.Result
+	}{
+		{
+			name:     "Not covered",
+			replicas: 3,
+			pdbs:     []runtime.Object{fixPodDisruptionBudget(map[string]string{"app": "orders-api"})},
+			expected: recommendation.Result{
+				Info: []string{
+					"Pods of Deployment 'prod/payments-api' are not covered by any PodDisruptionBudget, so all replicas can be evicted at once during node maintenance.",
+				},
+			},
+		},
+		{
+			name:     "Covered",
+			replicas: 3,
+			pdbs:     []runtime.Object{fixPodDisruptionBudget(map[string]string{"app": "payments-api"})},
+		},
+		{
+			name:     "Single replica",
+			replicas: 1,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// given
+			dynamicCli := fake.NewSimpleDynamicClientWithCustomListKinds(scheme.Scheme, map[schema.GroupVersionResource]string{
+				{Group: "policy", Version: "v1", Resource: "poddisruptionbudgets"}: "PodDisruptionBudgetList",
+			}, tc.pdbs...)
+			recomm := recommendation.NewDeploymentPodDisruptionBudget(dynamicCli)
+
+			deployment := fixDeployment(tc.replicas)
+			unstrObj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(deployment)
+			require.NoError(t, err)
+
+			event, err := events.New(deployment.ObjectMeta, &unstructured.Unstructured{Object: unstrObj}, config.CreateEvent, "apps/v1/deployments", "sample")
+			require.NoError(t, err)
+
+			// when
+			actual, err := recomm.Do(context.Background(), event)
+
+			// then
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
+func fixDeployment(replicas int32) *appsv1.Deployment {
+	return &appsv1.Deployment{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Deployment",
+			APIVersion: "apps/v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "payments-api",
+			Namespace: "prod",
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Template: coreV1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{"app": "payments-api", "tier": "backend"},
+				},
+			},
+		},
+	}
+}
+
+func fixPodDisruptionBudget(matchLabels map[string]string) *policyv1.PodDisruptionBudget {
+	return &policyv1.PodDisruptionBudget{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "PodDisruptionBudget",
+			APIVersion: "policy/v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "pdb",
+			Namespace: "prod",
+		},
+		Spec: policyv1.PodDisruptionBudgetSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: matchLabels},
+		},
+	}
+}
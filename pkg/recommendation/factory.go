@@ -2,6 +2,8 @@ package recommendation
 
 import (
 	"context"
+	"fmt"
+	"sort"
 
 	"github.com/sirupsen/logrus"
 	"k8s.io/client-go/dynamic"
@@ -23,15 +25,53 @@ type Result struct {
 	Warnings []string
 }
 
+// Provider contributes custom recommendations, computed from the event's object.
+// Registered providers are enabled per source with the `recommendations.providers` property.
+type Provider interface {
+	// Name returns the unique provider name, used in the source configuration.
+	Name() string
+	// NewRecommendation creates the recommendation run for events of the sources with the provider enabled.
+	NewRecommendation(deps Dependencies) Recommendation
+}
+
+// Dependencies holds the dependencies available to the recommendation providers.
+type Dependencies struct {
+	Log        logrus.FieldLogger
+	DynamicCli dynamic.Interface
+}
+
 // Factory is a factory for creating recommendation sets.
 type Factory struct {
 	logger     logrus.FieldLogger
 	dynamicCli dynamic.Interface
+
+	// providers holds recommendations of the registered providers, indexed by the provider name.
+	providers map[string]Recommendation
 }
 
 // NewFactory creates a new Factory instance.
 func NewFactory(logger logrus.FieldLogger, dynamicCli dynamic.Interface) *Factory {
-	return &Factory{logger: logger, dynamicCli: dynamicCli}
+	return &Factory{logger: logger, dynamicCli: dynamicCli, providers: map[string]Recommendation{}}
+}
+
+// Register registers given recommendation providers. It returns error if a provider with the same name is already registered.
+func (f *Factory) Register(providers ...Provider) error {
+	for _, provider := range providers {
+		name := provider.Name()
+		if name == "" {
+			return fmt.Errorf("provider name cannot be empty")
+		}
+		if _, exists := f.providers[name]; exists {
+			return fmt.Errorf("provider %q is already registered", name)
+		}
+
+		f.logger.Infof("Registering recommendation provider %q...", name)
+		f.providers[name] = provider.NewRecommendation(Dependencies{
+			Log:        f.logger.WithField("provider", name),
+			DynamicCli: f.dynamicCli,
+		})
+	}
+	return nil
 }
 
 // NewForSources merges recommendation options from multiple sources, and creates a new AggregatedRunner.
@@ -62,6 +102,12 @@ func (f *Factory) mergeConfig(sources map[string]config.Sources, mapKeyOrder []s
 		if sourceCfg.Ingress.TLSSecretValid != nil {
 			mergedCfg.Ingress.TLSSecretValid = sourceCfg.Ingress.TLSSecretValid
 		}
+		for name, enabled := range sourceCfg.Providers {
+			if mergedCfg.Providers == nil {
+				mergedCfg.Providers = map[string]bool{}
+			}
+			mergedCfg.Providers[name] = enabled
+		}
 	}
 
 	return mergedCfg
@@ -85,5 +131,17 @@ func (f *Factory) recommendationsForConfig(cfg config.Recommendations) []Recomme
 		recommendations = append(recommendations, NewIngressTLSSecretValid(f.dynamicCli))
 	}
 
+	// run the registered providers in a stable order
+	var names []string
+	for name, enabled := range cfg.Providers {
+		if _, registered := f.providers[name]; enabled && registered {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		recommendations = append(recommendations, f.providers[name])
+	}
+
 	return recommendations
 }
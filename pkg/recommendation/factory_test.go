@@ -1,6 +1,7 @@
 package recommendation_test
 
 import (
+	"context"
 	"testing"
 
 	logtest "github.com/sirupsen/logrus/hooks/test"
@@ -8,6 +9,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/events"
 	"github.com/kubeshop/botkube/pkg/ptr"
 	"github.com/kubeshop/botkube/pkg/recommendation"
 )
@@ -93,3 +95,94 @@ func TestFactory_NewForSources(t *testing.T) {
 
 	assert.Equal(t, expectedNames, actualNames)
 }
+
+func TestFactory_NewForSourcesWithProviders(t *testing.T) {
+	// given
+	sources := map[string]config.Sources{
+		"first": {
+			Kubernetes: config.KubernetesSource{
+				Recommendations: config.Recommendations{
+					Providers: map[string]bool{
+						"ServiceHasEndpoints": true,
+						"NotRegistered":       true,
+					},
+				},
+			},
+		},
+		"second": {
+			Kubernetes: config.KubernetesSource{
+				Recommendations: config.Recommendations{
+					Pod: config.PodRecommendations{
+						LabelsSet: ptr.Bool(true),
+					},
+					Providers: map[string]bool{
+						"DeploymentPodDisruptionBudget": true,
+						"ServiceHasEndpoints":           false, // override `true` from `first`
+					},
+				},
+			},
+		},
+	}
+
+	logger, _ := logtest.NewNullLogger()
+	factory := recommendation.NewFactory(logger, nil)
+	err := factory.Register(
+		recommendation.DeploymentPodDisruptionBudgetProvider{},
+		fakeProvider{name: "ServiceHasEndpoints"},
+	)
+	require.NoError(t, err)
+
+	// when
+	recRunner, recCfg := factory.NewForSources(sources, []string{"first", "second"})
+	actualRecomms := recRunner.Recommendations()
+
+	// then
+	assert.Equal(t, map[string]bool{
+		"DeploymentPodDisruptionBudget": true,
+		"ServiceHasEndpoints":           false,
+		"NotRegistered":                 true,
+	}, recCfg.Providers)
+
+	var actualNames []string
+	for _, r := range actualRecomms {
+		actualNames = append(actualNames, r.Name())
+	}
+	assert.Equal(t, []string{"PodLabelsSet", "DeploymentPodDisruptionBudget"}, actualNames)
+}
+
+func TestFactory_RegisterDuplicate(t *testing.T) {
+	// given
+	logger, _ := logtest.NewNullLogger()
+	factory := recommendation.NewFactory(logger, nil)
+	require.NoError(t, factory.Register(fakeProvider{name: "ServiceHasEndpoints"}))
+
+	// when
+	err := factory.Register(fakeProvider{name: "ServiceHasEndpoints"})
+
+	// then
+	assert.EqualError(t, err, `provider "ServiceHasEndpoints" is already registered`)
+}
+
+type fakeProvider struct {
+	name string
+}
+
+func (p fakeProvider) Name() string {
+	return p.name
+}
+
+func (p fakeProvider) NewRecommendation(_ recommendation.Dependencies) recommendation.Recommendation {
+	return fakeRecommendation(p)
+}
+
+type fakeRecommendation struct {
+	name string
+}
+
+func (r fakeRecommendation) Do(_ context.Context, _ events.Event) (recommendation.Result, error) {
+	return recommendation.Result{Warnings: []string{"Service has no endpoints."}}, nil
+}
+
+func (r fakeRecommendation) Name() string {
+	return r.name
+}
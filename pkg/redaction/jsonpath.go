@@ -0,0 +1,182 @@
+package redaction
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+type pathSegmentKind int
+
+const (
+	fieldSegment pathSegmentKind = iota
+	wildcardSegment
+	indexSegment
+)
+
+// pathSegment is a single step of the JSONPath expression.
+type pathSegment struct {
+	kind  pathSegmentKind
+	field string
+	index int
+	// recursive is set for the segments prefixed with `..`, which match at any depth.
+	recursive bool
+}
+
+// jsonPath is a parsed subset of the JSONPath syntax, which supports the dot and bracket notation,
+// wildcards, array indexes and the recursive descent, e.g. `$..containers[*].env[*].value`.
+// Unlike the JSONPath implementation from client-go, it allows replacing the selected values.
+type jsonPath []pathSegment
+
+func parseJSONPath(in string) (jsonPath, error) {
+	path := strings.TrimSpace(in)
+	path = strings.TrimPrefix(path, "$")
+	if path == "" {
+		return nil, fmt.Errorf("path cannot be empty")
+	}
+	if path[0] != '.' && path[0] != '[' {
+		path = "." + path
+	}
+
+	var out jsonPath
+	for i := 0; i < len(path); {
+		recursive, dotted := false, false
+		switch {
+		case strings.HasPrefix(path[i:], ".."):
+			recursive = true
+			i += 2
+		case path[i] == '.':
+			dotted = true
+			i++
+		case path[i] == '[':
+		default:
+			return nil, fmt.Errorf("unexpected %q at position %d", path[i], i)
+		}
+
+		if i >= len(path) {
+			return nil, fmt.Errorf("unexpected end of path")
+		}
+
+		var (
+			seg pathSegment
+			err error
+		)
+		if path[i] == '[' && !dotted {
+			seg, i, err = parseBracketSegment(path, i)
+		} else {
+			seg, i, err = parseDotSegment(path, i)
+		}
+		if err != nil {
+			return nil, err
+		}
+		seg.recursive = recursive
+		out = append(out, seg)
+	}
+
+	return out, nil
+}
+
+func parseDotSegment(path string, start int) (pathSegment, int, error) {
+	end := start
+	for end < len(path) && path[end] != '.' && path[end] != '[' {
+		end++
+	}
+	name := path[start:end]
+	switch name {
+	case "":
+		return pathSegment{}, 0, fmt.Errorf("empty field name at position %d", start)
+	case "*":
+		return pathSegment{kind: wildcardSegment}, end, nil
+	default:
+		return pathSegment{kind: fieldSegment, field: name}, end, nil
+	}
+}
+
+func parseBracketSegment(path string, start int) (pathSegment, int, error) {
+	end := strings.IndexByte(path[start:], ']')
+	if end == -1 {
+		return pathSegment{}, 0, fmt.Errorf("missing closing bracket for position %d", start)
+	}
+	end += start
+
+	content := strings.TrimSpace(path[start+1 : end])
+	next := end + 1
+	switch {
+	case content == "*":
+		return pathSegment{kind: wildcardSegment}, next, nil
+	case len(content) >= 2 && (content[0] == '\'' || content[0] == '"') && content[len(content)-1] == content[0]:
+		return pathSegment{kind: fieldSegment, field: content[1 : len(content)-1]}, next, nil
+	}
+
+	idx, err := strconv.Atoi(content)
+	if err != nil {
+		return pathSegment{}, 0, fmt.Errorf("invalid index %q at position %d", content, start)
+	}
+	return pathSegment{kind: indexSegment, index: idx}, next, nil
+}
+
+// Replace replaces all values selected by the path with the result of a given function.
+// The node is modified in place. Returned value must be used in case the root node itself is replaced.
+func (p jsonPath) Replace(node interface{}, replaceFn func(interface{}) interface{}) interface{} {
+	return replacePath(node, p, replaceFn)
+}
+
+func replacePath(node interface{}, path jsonPath, replaceFn func(interface{}) interface{}) interface{} {
+	if len(path) == 0 {
+		return replaceFn(node)
+	}
+
+	seg, rest := path[0], path[1:]
+	if seg.recursive {
+		// match the segment at the current level...
+		current := seg
+		current.recursive = false
+		node = replacePath(node, append(jsonPath{current}, rest...), replaceFn)
+		// ...and at any level below
+		return replaceChildren(node, func(child interface{}) interface{} {
+			return replacePath(child, path, replaceFn)
+		})
+	}
+
+	switch seg.kind {
+	case fieldSegment:
+		obj, ok := node.(map[string]interface{})
+		if !ok {
+			return node
+		}
+		if val, found := obj[seg.field]; found {
+			obj[seg.field] = replacePath(val, rest, replaceFn)
+		}
+	case wildcardSegment:
+		return replaceChildren(node, func(child interface{}) interface{} {
+			return replacePath(child, rest, replaceFn)
+		})
+	case indexSegment:
+		items, ok := node.([]interface{})
+		if !ok {
+			return node
+		}
+		idx := seg.index
+		if idx < 0 {
+			idx += len(items)
+		}
+		if idx >= 0 && idx < len(items) {
+			items[idx] = replacePath(items[idx], rest, replaceFn)
+		}
+	}
+	return node
+}
+
+func replaceChildren(node interface{}, replaceFn func(interface{}) interface{}) interface{} {
+	switch val := node.(type) {
+	case map[string]interface{}:
+		for key, child := range val {
+			val[key] = replaceFn(child)
+		}
+	case []interface{}:
+		for idx, child := range val {
+			val[idx] = replaceFn(child)
+		}
+	}
+	return node
+}
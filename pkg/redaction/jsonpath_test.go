@@ -0,0 +1,118 @@
+package redaction
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONPathReplace(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+		exp  map[string]interface{}
+	}{
+		{
+			name: "Dot notation",
+			path: "$.spec.replicas",
+			exp:  fixDoc(func(doc map[string]interface{}) { doc["spec"].(map[string]interface{})["replicas"] = "x" }),
+		},
+		{
+			name: "Without root",
+			path: "spec.replicas",
+			exp:  fixDoc(func(doc map[string]interface{}) { doc["spec"].(map[string]interface{})["replicas"] = "x" }),
+		},
+		{
+			name: "Bracket notation with index",
+			path: "$['spec'].items[1].value",
+			exp:  fixDoc(func(doc map[string]interface{}) { fixItem(doc, 1)["value"] = "x" }),
+		},
+		{
+			name: "Negative index",
+			path: "$.spec.items[-1].value",
+			exp:  fixDoc(func(doc map[string]interface{}) { fixItem(doc, 1)["value"] = "x" }),
+		},
+		{
+			name: "Wildcard",
+			path: "$.spec.items[*].value",
+			exp: fixDoc(func(doc map[string]interface{}) {
+				fixItem(doc, 0)["value"] = "x"
+				fixItem(doc, 1)["value"] = "x"
+			}),
+		},
+		{
+			name: "Recursive descent",
+			path: "$..value",
+			exp: fixDoc(func(doc map[string]interface{}) {
+				doc["value"] = "x"
+				fixItem(doc, 0)["value"] = "x"
+				fixItem(doc, 1)["value"] = "x"
+			}),
+		},
+		{
+			name: "Missing field",
+			path: "$.status.phase",
+			exp:  fixDoc(nil),
+		},
+		{
+			name: "Index out of range",
+			path: "$.spec.items[5].value",
+			exp:  fixDoc(nil),
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// given
+			path, err := parseJSONPath(tc.path)
+			require.NoError(t, err)
+
+			// when
+			got := path.Replace(fixDoc(nil), func(interface{}) interface{} { return "x" })
+
+			// then
+			assert.Equal(t, tc.exp, got)
+		})
+	}
+}
+
+func TestParseJSONPathErrors(t *testing.T) {
+	tests := map[string]string{
+		"$":           "path cannot be empty",
+		"$.spec.":     "unexpected end of path",
+		"$.spec..":    "unexpected end of path",
+		"$.spec[abc]": `invalid index "abc" at position 5`,
+		"$.spec[0":    "missing closing bracket for position 5",
+		"$.a.[0]":     "empty field name at position 3",
+	}
+	for path, expErr := range tests {
+		t.Run(path, func(t *testing.T) {
+			// when
+			_, err := parseJSONPath(path)
+
+			// then
+			assert.EqualError(t, err, expErr)
+		})
+	}
+}
+
+func fixDoc(mutateFn func(doc map[string]interface{})) map[string]interface{} {
+	doc := map[string]interface{}{
+		"value": "root",
+		"spec": map[string]interface{}{
+			"replicas": int64(3),
+			"items": []interface{}{
+				map[string]interface{}{"name": "a", "value": "1"},
+				map[string]interface{}{"name": "b", "value": "2"},
+			},
+		},
+	}
+	if mutateFn != nil {
+		mutateFn(doc)
+	}
+	return doc
+}
+
+func fixItem(doc map[string]interface{}, idx int) map[string]interface{} {
+	return doc["spec"].(map[string]interface{})["items"].([]interface{})[idx].(map[string]interface{})
+}
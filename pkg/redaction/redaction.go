@@ -0,0 +1,187 @@
+package redaction
+
+import (
+	"fmt"
+	"regexp"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/events"
+)
+
+// DefaultMask replaces the redacted values if no custom mask is configured.
+const DefaultMask = "***"
+
+// Redactor masks sensitive data in events, before they are sent to bots and sinks.
+type Redactor struct {
+	mask     string
+	patterns []*regexp.Regexp
+	paths    []jsonPath
+}
+
+// New returns a new Redactor instance. If redaction is disabled, the returned Redactor leaves events untouched.
+func New(cfg config.Redaction) (*Redactor, error) {
+	r := &Redactor{mask: cfg.Mask}
+	if r.mask == "" {
+		r.mask = DefaultMask
+	}
+	if !cfg.Enabled {
+		return r, nil
+	}
+
+	for idx, rule := range cfg.Rules {
+		if rule.Pattern != "" {
+			pattern, err := regexp.Compile(rule.Pattern)
+			if err != nil {
+				return nil, fmt.Errorf("while compiling pattern of rule %d: %w", idx, err)
+			}
+			r.patterns = append(r.patterns, pattern)
+		}
+
+		if rule.JSONPath != "" {
+			path, err := parseJSONPath(rule.JSONPath)
+			if err != nil {
+				return nil, fmt.Errorf("while parsing JSONPath of rule %d: %w", idx, err)
+			}
+			r.paths = append(r.paths, path)
+		}
+	}
+
+	return r, nil
+}
+
+// RedactEvent returns a copy of a given event with sensitive data masked.
+// Patterns are applied to the event texts and all string values of the object, while JSONPath rules are applied to the object only.
+func (r *Redactor) RedactEvent(event events.Event) events.Event {
+	if len(r.patterns) == 0 && len(r.paths) == 0 {
+		return event
+	}
+
+	event.Title = r.RedactString(event.Title)
+	event.Reason = r.RedactString(event.Reason)
+	event.Error = r.RedactString(event.Error)
+	event.Messages = r.redactStrings(event.Messages)
+	event.Recommendations = r.redactStrings(event.Recommendations)
+	event.Warnings = r.redactStrings(event.Warnings)
+	event.Object = r.redactObject(event.Object)
+	if event.Logs != nil {
+		logs := *event.Logs
+		logs.Content = r.RedactString(logs.Content)
+		event.Logs = &logs
+	}
+
+	return event
+}
+
+// RedactString masks all pattern matches in a given string. If a pattern has capture groups, only the groups are masked.
+func (r *Redactor) RedactString(in string) string {
+	for _, pattern := range r.patterns {
+		in = r.redactPattern(pattern, in)
+	}
+	return in
+}
+
+func (r *Redactor) redactPattern(pattern *regexp.Regexp, in string) string {
+	matches := pattern.FindAllStringSubmatchIndex(in, -1)
+	if len(matches) == 0 {
+		return in
+	}
+
+	var (
+		out  []byte
+		last int
+	)
+	for _, match := range matches {
+		// mask the whole match if there are no capture groups, or the groups only otherwise
+		spans := [][2]int{{match[0], match[1]}}
+		if len(match) > 2 {
+			spans = spans[:0]
+			for i := 2; i+1 < len(match); i += 2 {
+				if match[i] < 0 || match[i] < last || match[i] == match[i+1] {
+					continue
+				}
+				spans = append(spans, [2]int{match[i], match[i+1]})
+			}
+		}
+
+		for _, span := range spans {
+			if span[0] == span[1] {
+				continue
+			}
+			out = append(out, in[last:span[0]]...)
+			out = append(out, r.mask...)
+			last = span[1]
+		}
+	}
+	out = append(out, in[last:]...)
+	return string(out)
+}
+
+func (r *Redactor) redactStrings(in []string) []string {
+	if in == nil {
+		return nil
+	}
+
+	out := make([]string, 0, len(in))
+	for _, item := range in {
+		out = append(out, r.RedactString(item))
+	}
+	return out
+}
+
+// redactObject returns a redacted copy of the Kubernetes object. The original object is shared with other components, so it's never modified.
+func (r *Redactor) redactObject(obj interface{}) interface{} {
+	var content map[string]interface{}
+	switch val := obj.(type) {
+	case nil:
+		return nil
+	case *unstructured.Unstructured:
+		content = runtime.DeepCopyJSON(val.Object)
+	case map[string]interface{}:
+		content = runtime.DeepCopyJSON(val)
+	default:
+		var err error
+		content, err = runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+		if err != nil {
+			// it's safer to drop the object than to pass it unredacted
+			return nil
+		}
+	}
+
+	var node interface{} = content
+	for _, path := range r.paths {
+		node = path.Replace(node, func(interface{}) interface{} {
+			return r.mask
+		})
+	}
+	if len(r.patterns) > 0 {
+		node = r.redactStringValues(node)
+	}
+
+	content, ok := node.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	if _, isMap := obj.(map[string]interface{}); isMap {
+		return content
+	}
+	return &unstructured.Unstructured{Object: content}
+}
+
+func (r *Redactor) redactStringValues(node interface{}) interface{} {
+	switch val := node.(type) {
+	case string:
+		return r.RedactString(val)
+	case map[string]interface{}:
+		for key, child := range val {
+			val[key] = r.redactStringValues(child)
+		}
+	case []interface{}:
+		for idx, child := range val {
+			val[idx] = r.redactStringValues(child)
+		}
+	}
+	return node
+}
@@ -0,0 +1,144 @@
+package redaction
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/events"
+)
+
+func TestRedactorRedactEvent(t *testing.T) {
+	// given
+	redactor, err := New(config.Redaction{
+		Enabled: true,
+		Rules: []config.RedactionRule{
+			{Pattern: `(?i)token[=:]\s*(\S+)`},
+			{Pattern: `ghp_[A-Za-z0-9]{8,}`},
+			{JSONPath: `$..env[*].value`},
+			{JSONPath: `metadata.annotations['example.com/password']`},
+		},
+	})
+	require.NoError(t, err)
+
+	obj := fixPodObject()
+	event := events.Event{
+		Name:     "nginx",
+		Messages: []string{"Started with token=s3cr3t and key ghp_abcdefgh1234", "Nothing to hide"},
+		Error:    "Auth failed for TOKEN: abc123",
+		Object:   obj,
+		Logs:     &events.ContainerLogs{Container: "nginx", Content: "connecting with token: s3cr3t"},
+	}
+
+	// when
+	got := redactor.RedactEvent(event)
+
+	// then
+	assert.Equal(t, []string{"Started with token=*** and key ***", "Nothing to hide"}, got.Messages)
+	assert.Equal(t, "Auth failed for TOKEN: ***", got.Error)
+	assert.Equal(t, "nginx", got.Name)
+	assert.Equal(t, "connecting with token: ***", got.Logs.Content)
+
+	gotObj, ok := got.Object.(*unstructured.Unstructured)
+	require.True(t, ok)
+	containers, _, err := unstructured.NestedSlice(gotObj.Object, "spec", "containers")
+	require.NoError(t, err)
+	env := containers[0].(map[string]interface{})["env"].([]interface{})
+	assert.Equal(t, map[string]interface{}{"name": "API_KEY", "value": "***"}, env[0])
+	assert.Equal(t, map[string]interface{}{"name": "LOG_LEVEL", "value": "***"}, env[1])
+	assert.Equal(t, "--token=***", containers[0].(map[string]interface{})["args"].([]interface{})[0])
+	assert.Equal(t, map[string]string{"example.com/password": "***", "team": "a"}, gotObj.GetAnnotations())
+
+	// the original object is shared with other components, so it must stay untouched
+	assert.Equal(t, fixPodObject(), obj)
+	assert.Equal(t, "connecting with token: s3cr3t", event.Logs.Content)
+}
+
+func TestRedactorDisabled(t *testing.T) {
+	// given
+	redactor, err := New(config.Redaction{
+		Enabled: false,
+		Rules:   []config.RedactionRule{{Pattern: `.*`}},
+	})
+	require.NoError(t, err)
+
+	event := events.Event{Messages: []string{"token=s3cr3t"}, Object: fixPodObject()}
+
+	// when
+	got := redactor.RedactEvent(event)
+
+	// then
+	assert.Equal(t, event, got)
+}
+
+func TestRedactorRedactStringCustomMask(t *testing.T) {
+	// given
+	redactor, err := New(config.Redaction{
+		Enabled: true,
+		Mask:    "[REDACTED]",
+		Rules: []config.RedactionRule{
+			{Pattern: `password=(\w+)&user=(\w+)`},
+		},
+	})
+	require.NoError(t, err)
+
+	// when
+	got := redactor.RedactString("https://example.com?password=foo&user=bar&other=baz")
+
+	// then
+	assert.Equal(t, "https://example.com?password=[REDACTED]&user=[REDACTED]&other=baz", got)
+}
+
+func TestNewInvalidRules(t *testing.T) {
+	tests := map[string]struct {
+		rule   config.RedactionRule
+		expErr string
+	}{
+		"Invalid pattern": {
+			rule:   config.RedactionRule{Pattern: `token=(\S+`},
+			expErr: "while compiling pattern of rule 0: error parsing regexp: missing closing ): `token=(\\S+`",
+		},
+		"Invalid JSONPath": {
+			rule:   config.RedactionRule{JSONPath: `$.spec.containers[*`},
+			expErr: "while parsing JSONPath of rule 0: missing closing bracket for position 16",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// when
+			_, err := New(config.Redaction{Enabled: true, Rules: []config.RedactionRule{tc.rule}})
+
+			// then
+			assert.EqualError(t, err, tc.expErr)
+		})
+	}
+}
+
+func fixPodObject() *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "Pod",
+		"metadata": map[string]interface{}{
+			"name": "nginx",
+			"annotations": map[string]interface{}{
+				"example.com/password": "hunter2",
+				"team":                 "a",
+			},
+		},
+		"spec": map[string]interface{}{
+			"containers": []interface{}{
+				map[string]interface{}{
+					"name": "nginx",
+					"args": []interface{}{"--token=s3cr3t"},
+					"env": []interface{}{
+						map[string]interface{}{"name": "API_KEY", "value": "s3cr3t"},
+						map[string]interface{}{"name": "LOG_LEVEL", "value": "debug"},
+					},
+				},
+			},
+		},
+	}}
+}
@@ -0,0 +1,197 @@
+package sink
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/sns"
+	"github.com/aws/aws-sdk-go/service/sns/snsiface"
+	"github.com/aws/aws-sdk-go/service/sqs"
+	"github.com/aws/aws-sdk-go/service/sqs/sqsiface"
+	"github.com/sirupsen/logrus"
+
+	"github.com/kubeshop/botkube/pkg/bot/interactive"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/events"
+	"github.com/kubeshop/botkube/pkg/multierror"
+	"github.com/kubeshop/botkube/pkg/sliceutil"
+)
+
+var _ Sink = &AWS{}
+
+const (
+	// awsFIFOSuffix is the name suffix of the FIFO SNS topics and SQS queues.
+	awsFIFOSuffix         = ".fifo"
+	awsStringAttrDataType = "String"
+)
+
+// AWS provides functionality to publish events to AWS SNS topic and SQS queue.
+// Events are published as JSON, together with the message attributes which can be used in the subscription filter policies.
+type AWS struct {
+	log      logrus.FieldLogger
+	reporter AnalyticsReporter
+	snsCli   snsiface.SNSAPI
+	sqsCli   sqsiface.SQSAPI
+
+	topicARN string
+	queueURL string
+	bindings config.SinkBindings
+}
+
+// NewAWS creates a new AWS instance.
+func NewAWS(log logrus.FieldLogger, c config.AWS, reporter AnalyticsReporter) (*AWS, error) {
+	sess, err := session.NewSession(&aws.Config{Region: aws.String(c.Region)})
+	if err != nil {
+		return nil, fmt.Errorf("while creating AWS session: %w", err)
+	}
+
+	awsCfg := &aws.Config{Credentials: awsCredentials(sess, c.RoleArn, sess.Config.Credentials)}
+	return newAWS(log, c, sns.New(sess, awsCfg), sqs.New(sess, awsCfg), reporter)
+}
+
+func newAWS(log logrus.FieldLogger, c config.AWS, snsCli snsiface.SNSAPI, sqsCli sqsiface.SQSAPI, reporter AnalyticsReporter) (*AWS, error) {
+	a := &AWS{
+		log:      log,
+		reporter: reporter,
+		snsCli:   snsCli,
+		sqsCli:   sqsCli,
+		topicARN: c.SNS.TopicARN,
+		queueURL: c.SQS.QueueURL,
+		bindings: c.Bindings,
+	}
+
+	err := reporter.ReportSinkEnabled(a.IntegrationName())
+	if err != nil {
+		return nil, fmt.Errorf("while reporting analytics: %w", err)
+	}
+
+	return a, nil
+}
+
+// SendEvent publishes a given event to the configured SNS topic and SQS queue.
+func (a *AWS) SendEvent(ctx context.Context, event events.Event, eventSources []string) error {
+	if !sliceutil.Intersect(eventSources, a.bindings.Sources) {
+		a.log.Debugf("Event sources do not match AWS sources, event: %+v, eventSources: %+v", event, eventSources)
+		return nil
+	}
+
+	raw, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("while marshaling event: %w", err)
+	}
+	body := string(raw)
+
+	errs := multierror.New()
+	if a.topicARN != "" {
+		if err := a.publishToSNS(ctx, event, body); err != nil {
+			errs = multierror.Append(errs, fmt.Errorf("while publishing event to SNS topic: %w", err))
+		}
+	}
+	if a.queueURL != "" {
+		if err := a.sendToSQS(ctx, event, body); err != nil {
+			errs = multierror.Append(errs, fmt.Errorf("while sending event to SQS queue: %w", err))
+		}
+	}
+	if err := errs.ErrorOrNil(); err != nil {
+		return err
+	}
+
+	a.log.Debugf("Event successfully sent to AWS: %+v", event)
+	return nil
+}
+
+// SendMessageToAll is no-op.
+func (a *AWS) SendMessageToAll(_ context.Context, _ interactive.Message) error {
+	return nil
+}
+
+// SendGenericMessage is no-op.
+func (a *AWS) SendGenericMessage(_ context.Context, _ interactive.GenericMessage, _ []string) error {
+	return nil
+}
+
+// IntegrationName describes the sink integration name.
+func (a *AWS) IntegrationName() config.CommPlatformIntegration {
+	return config.AWSCommPlatformIntegration
+}
+
+// Type describes the sink type.
+func (a *AWS) Type() config.IntegrationType {
+	return config.SinkIntegrationType
+}
+
+func (a *AWS) publishToSNS(ctx context.Context, event events.Event, body string) error {
+	in := &sns.PublishInput{
+		TopicArn:          aws.String(a.topicARN),
+		Message:           aws.String(body),
+		MessageAttributes: map[string]*sns.MessageAttributeValue{},
+	}
+	for key, val := range awsMessageAttributes(event) {
+		in.MessageAttributes[key] = &sns.MessageAttributeValue{
+			DataType:    aws.String(awsStringAttrDataType),
+			StringValue: aws.String(val),
+		}
+	}
+	if strings.HasSuffix(a.topicARN, awsFIFOSuffix) {
+		in.MessageGroupId = aws.String(awsMessageGroupID(event))
+		in.MessageDeduplicationId = aws.String(awsDeduplicationID(body))
+	}
+
+	_, err := a.snsCli.PublishWithContext(ctx, in)
+	return err
+}
+
+func (a *AWS) sendToSQS(ctx context.Context, event events.Event, body string) error {
+	in := &sqs.SendMessageInput{
+		QueueUrl:          aws.String(a.queueURL),
+		MessageBody:       aws.String(body),
+		MessageAttributes: map[string]*sqs.MessageAttributeValue{},
+	}
+	for key, val := range awsMessageAttributes(event) {
+		in.MessageAttributes[key] = &sqs.MessageAttributeValue{
+			DataType:    aws.String(awsStringAttrDataType),
+			StringValue: aws.String(val),
+		}
+	}
+	if strings.HasSuffix(a.queueURL, awsFIFOSuffix) {
+		in.MessageGroupId = aws.String(awsMessageGroupID(event))
+		in.MessageDeduplicationId = aws.String(awsDeduplicationID(body))
+	}
+
+	_, err := a.sqsCli.SendMessageWithContext(ctx, in)
+	return err
+}
+
+// awsMessageAttributes returns the non-empty event details. Attribute values cannot be empty.
+func awsMessageAttributes(event events.Event) map[string]string {
+	attrs := map[string]string{
+		"cluster":   event.Cluster,
+		"namespace": event.Namespace,
+		"kind":      event.Kind,
+		"name":      event.Name,
+		"type":      string(event.Type),
+		"level":     string(event.Level),
+	}
+	for key, val := range attrs {
+		if val == "" {
+			delete(attrs, key)
+		}
+	}
+	return attrs
+}
+
+// awsMessageGroupID returns the FIFO message group ID. Events from the same namespace are delivered in order.
+func awsMessageGroupID(event events.Event) string {
+	return event.Cluster + "/" + event.Namespace
+}
+
+func awsDeduplicationID(body string) string {
+	sum := sha256.Sum256([]byte(body))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,37 @@
+package sink
+
+import (
+	"os"
+
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/credentials/stscreds"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/sts"
+)
+
+const (
+	// AWS Role ARN from POD env variable while using IAM Role for service account
+	awsRoleARNEnvName = "AWS_ROLE_ARN"
+	// The token file mount path in POD env variable while using IAM Role for service account
+	// #nosec G101
+	awsWebIDTokenFileEnvName = "AWS_WEB_IDENTITY_TOKEN_FILE"
+)
+
+// awsCredentials returns credentials for IAM Role for Service Account if configured.
+// Otherwise, a given role is assumed. If it's not specified, the fallback credentials are used.
+// It's shared by all sinks which authenticate with AWS.
+func awsCredentials(sess *session.Session, roleARN string, fallback *credentials.Credentials) *credentials.Credentials {
+	// Use OIDC token to generate credentials if using IAM to Service Account
+	awsRoleARN := os.Getenv(awsRoleARNEnvName)
+	awsWebIdentityTokenFile := os.Getenv(awsWebIDTokenFileEnvName)
+	if awsRoleARN != "" && awsWebIdentityTokenFile != "" {
+		p := stscreds.NewWebIdentityRoleProviderWithOptions(sts.New(sess), awsRoleARN, "", stscreds.FetchTokenPath(awsWebIdentityTokenFile))
+		return credentials.NewCredentials(p)
+	}
+
+	if roleARN != "" {
+		return stscreds.NewCredentials(sess, roleARN)
+	}
+
+	return fallback
+}
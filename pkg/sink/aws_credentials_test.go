@@ -0,0 +1,68 @@
+package sink
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAWSCredentials(t *testing.T) {
+	// given
+	sess, err := session.NewSession(&aws.Config{Region: aws.String("eu-central-1")})
+	require.NoError(t, err)
+	fallback := credentials.NewStaticCredentials("id", "secret", "")
+
+	tests := []struct {
+		name        string
+		env         map[string]string
+		roleARN     string
+		expFallback bool
+	}{
+		{
+			name:        "no role",
+			expFallback: true,
+		},
+		{
+			name:    "assumed role",
+			roleARN: "arn:aws:iam::123456789012:role/botkube",
+		},
+		{
+			name: "IAM Role for Service Account",
+			env: map[string]string{
+				awsRoleARNEnvName:        "arn:aws:iam::123456789012:role/botkube-irsa",
+				awsWebIDTokenFileEnvName: "/var/run/secrets/eks.amazonaws.com/serviceaccount/token",
+			},
+		},
+		{
+			name: "incomplete IAM Role for Service Account",
+			env: map[string]string{
+				awsRoleARNEnvName: "arn:aws:iam::123456789012:role/botkube-irsa",
+			},
+			expFallback: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Setenv(awsRoleARNEnvName, "")
+			t.Setenv(awsWebIDTokenFileEnvName, "")
+			for key, val := range tc.env {
+				t.Setenv(key, val)
+			}
+
+			// when
+			got := awsCredentials(sess, tc.roleARN, fallback)
+
+			// then
+			require.NotNil(t, got)
+			if tc.expFallback {
+				assert.Same(t, fallback, got)
+				return
+			}
+			assert.NotSame(t, fallback, got)
+		})
+	}
+}
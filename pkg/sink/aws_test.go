@@ -0,0 +1,112 @@
+package sink
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/sns"
+	"github.com/aws/aws-sdk-go/service/sns/snsiface"
+	"github.com/aws/aws-sdk-go/service/sqs"
+	"github.com/aws/aws-sdk-go/service/sqs/sqsiface"
+	logtest "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/internal/analytics"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/events"
+)
+
+func TestAWS_SendEvent(t *testing.T) {
+	// given
+	snsCli := &fakeSNS{}
+	sqsCli := &fakeSQS{}
+
+	logger, _ := logtest.NewNullLogger()
+	sink, err := newAWS(logger, config.AWS{
+		Enabled:  true,
+		SNS:      config.AWSSNS{TopicARN: "arn:aws:sns:eu-central-1:123456789012:botkube"},
+		SQS:      config.AWSSQS{QueueURL: "https://sqs.eu-central-1.amazonaws.com/123456789012/botkube.fifo"},
+		Bindings: config.SinkBindings{Sources: []string{"k8s-err-events"}},
+	}, snsCli, sqsCli, analytics.NewNoopReporter())
+	require.NoError(t, err)
+
+	// when
+	err = sink.SendEvent(context.Background(), fixSinkEvent(config.Error), []string{"other"})
+	require.NoError(t, err)
+	err = sink.SendEvent(context.Background(), fixSinkEvent(config.Error), []string{"k8s-err-events"})
+	require.NoError(t, err)
+
+	// then
+	require.Len(t, snsCli.published, 1)
+	published := snsCli.published[0]
+	assert.Equal(t, "arn:aws:sns:eu-central-1:123456789012:botkube", aws.StringValue(published.TopicArn))
+	assert.Nil(t, published.MessageGroupId)
+	assert.Equal(t, "default", aws.StringValue(published.MessageAttributes["namespace"].StringValue))
+	assert.Equal(t, "error", aws.StringValue(published.MessageAttributes["level"].StringValue))
+
+	var gotEvent events.Event
+	require.NoError(t, json.Unmarshal([]byte(aws.StringValue(published.Message)), &gotEvent))
+	assert.Equal(t, "nginx", gotEvent.Name)
+	assert.Equal(t, "Pod", gotEvent.Kind)
+
+	require.Len(t, sqsCli.sent, 1)
+	sent := sqsCli.sent[0]
+	assert.Equal(t, aws.StringValue(published.Message), aws.StringValue(sent.MessageBody))
+	assert.Equal(t, "dev/default", aws.StringValue(sent.MessageGroupId))
+	assert.Len(t, aws.StringValue(sent.MessageDeduplicationId), 64)
+	assert.Equal(t, "Pod", aws.StringValue(sent.MessageAttributes["kind"].StringValue))
+}
+
+func TestAWS_SendEventFailure(t *testing.T) {
+	// given
+	snsCli := &fakeSNS{err: errors.New("AuthorizationError")}
+	sqsCli := &fakeSQS{}
+
+	logger, _ := logtest.NewNullLogger()
+	sink, err := newAWS(logger, config.AWS{
+		SNS:      config.AWSSNS{TopicARN: "arn:aws:sns:eu-central-1:123456789012:botkube"},
+		SQS:      config.AWSSQS{QueueURL: "https://sqs.eu-central-1.amazonaws.com/123456789012/botkube"},
+		Bindings: config.SinkBindings{Sources: []string{"k8s-err-events"}},
+	}, snsCli, sqsCli, analytics.NewNoopReporter())
+	require.NoError(t, err)
+
+	// when
+	err = sink.SendEvent(context.Background(), fixSinkEvent(config.Error), []string{"k8s-err-events"})
+
+	// then
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "while publishing event to SNS topic: AuthorizationError")
+	// SQS is not affected by SNS failure
+	assert.Len(t, sqsCli.sent, 1)
+}
+
+type fakeSNS struct {
+	snsiface.SNSAPI
+
+	err       error
+	published []*sns.PublishInput
+}
+
+func (f *fakeSNS) PublishWithContext(_ aws.Context, in *sns.PublishInput, _ ...request.Option) (*sns.PublishOutput, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	f.published = append(f.published, in)
+	return &sns.PublishOutput{}, nil
+}
+
+type fakeSQS struct {
+	sqsiface.SQSAPI
+
+	sent []*sqs.SendMessageInput
+}
+
+func (f *fakeSQS) SendMessageWithContext(_ aws.Context, in *sqs.SendMessageInput, _ ...request.Option) (*sqs.SendMessageOutput, error) {
+	f.sent = append(f.sent, in)
+	return &sqs.SendMessageOutput{}, nil
+}
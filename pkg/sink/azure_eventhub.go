@@ -0,0 +1,411 @@
+package sink
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/kubeshop/botkube/pkg/bot/interactive"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/events"
+	"github.com/kubeshop/botkube/pkg/multierror"
+	"github.com/kubeshop/botkube/pkg/sliceutil"
+)
+
+var _ Sink = &AzureEventHub{}
+
+const (
+	defaultAzureEventHubMaxEvents     = 100
+	defaultAzureEventHubFlushInterval = 10 * time.Second
+	// azureEventHubMaxBatchBytes is kept below the 1 MB batch limit of the Standard tier, as the request contains also the envelope.
+	azureEventHubMaxBatchBytes = 900 * 1024
+
+	azureEventHubBatchContentType = "application/vnd.microsoft.servicebus.json"
+	azureEventHubAPIVersion       = "2014-01"
+	azureEventHubResource         = "https://eventhubs.azure.net"
+
+	// azureSASTokenTTL is the lifetime of the generated Shared Access Signature tokens.
+	azureSASTokenTTL = time.Hour
+	// azureTokenRefreshMargin is the time before the token expiry when the token is refreshed.
+	azureTokenRefreshMargin = 5 * time.Minute
+
+	defaultAzureIMDSTokenURL = "http://169.254.169.254/metadata/identity/oauth2/token"
+	azureIMDSAPIVersion      = "2018-02-01"
+)
+
+// AzureEventHub provides functionality to send events to Azure Event Hub, e.g. to ingest them into Microsoft Sentinel.
+// Events are buffered and sent in batches, once the batch is full or the flush interval elapses.
+type AzureEventHub struct {
+	log      logrus.FieldLogger
+	reporter AnalyticsReporter
+	httpCli  *http.Client
+
+	messagesURL   string
+	tokenProvider azureTokenProvider
+	bindings      config.SinkBindings
+	maxEvents     int
+	flushInterval time.Duration
+
+	mu          sync.Mutex
+	buffer      []AzureEventHubMessage
+	bufferBytes int
+}
+
+// AzureEventHubMessage is a single event sent in a batch.
+type AzureEventHubMessage struct {
+	Body           string            `json:"Body"`
+	UserProperties map[string]string `json:"UserProperties,omitempty"`
+}
+
+type azureTokenProvider interface {
+	// Authorization returns the value of the Authorization header.
+	Authorization(ctx context.Context) (string, error)
+}
+
+// NewAzureEventHub creates a new AzureEventHub instance.
+func NewAzureEventHub(log logrus.FieldLogger, c config.AzureEventHub, reporter AnalyticsReporter) (*AzureEventHub, error) {
+	httpCli := &http.Client{Timeout: defaultHTTPCliTimeout}
+
+	namespace, eventHub := c.Namespace, c.EventHub
+	var tokenProvider azureTokenProvider
+	if c.ConnectionString != "" {
+		connStr, err := parseAzureConnectionString(c.ConnectionString)
+		if err != nil {
+			return nil, fmt.Errorf("while parsing connection string: %w", err)
+		}
+		namespace = connStr.Namespace
+		if connStr.EntityPath != "" {
+			eventHub = connStr.EntityPath
+		}
+		tokenProvider = &azureSASTokenProvider{
+			resourceURI: fmt.Sprintf("https://%s/%s", namespace, eventHub),
+			keyName:     connStr.KeyName,
+			key:         connStr.Key,
+		}
+	} else {
+		tokenProvider = &azureManagedIdentityTokenProvider{
+			httpCli:  httpCli,
+			tokenURL: defaultAzureIMDSTokenURL,
+			clientID: c.ManagedIdentity.ClientID,
+		}
+	}
+	if eventHub == "" {
+		return nil, errors.New("event hub name is required if the connection string doesn't contain the EntityPath")
+	}
+
+	maxEvents := c.Batch.MaxEvents
+	if maxEvents <= 0 {
+		maxEvents = defaultAzureEventHubMaxEvents
+	}
+	flushInterval := c.Batch.FlushInterval
+	if flushInterval <= 0 {
+		flushInterval = defaultAzureEventHubFlushInterval
+	}
+
+	hub := &AzureEventHub{
+		log:           log,
+		reporter:      reporter,
+		httpCli:       httpCli,
+		messagesURL:   fmt.Sprintf("https://%s/%s/messages?api-version=%s", namespace, url.PathEscape(eventHub), azureEventHubAPIVersion),
+		tokenProvider: tokenProvider,
+		bindings:      c.Bindings,
+		maxEvents:     maxEvents,
+		flushInterval: flushInterval,
+	}
+
+	err := reporter.ReportSinkEnabled(hub.IntegrationName())
+	if err != nil {
+		return nil, fmt.Errorf("while reporting analytics: %w", err)
+	}
+
+	return hub, nil
+}
+
+// Run flushes the buffered events periodically. Once the context is cancelled, the remaining events are flushed.
+func (a *AzureEventHub) Run(ctx context.Context) error {
+	a.log.Info("Starting Azure Event Hub flusher...")
+	ticker := time.NewTicker(a.flushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			a.log.Info("Shutdown requested. Flushing remaining events...")
+			// the parent context is already cancelled
+			flushCtx, cancel := context.WithTimeout(context.Background(), defaultHTTPCliTimeout)
+			defer cancel()
+			if err := a.flush(flushCtx, a.takeBuffer()); err != nil {
+				a.log.Errorf("while flushing events on shutdown: %s", err.Error())
+			}
+			return nil
+		case <-ticker.C:
+			if err := a.flush(ctx, a.takeBuffer()); err != nil {
+				a.log.Errorf("while flushing events: %s", err.Error())
+			}
+		}
+	}
+}
+
+// SendEvent buffers a given event. If the batch is full, the buffered events are sent immediately.
+func (a *AzureEventHub) SendEvent(ctx context.Context, event events.Event, eventSources []string) error {
+	if !sliceutil.Intersect(eventSources, a.bindings.Sources) {
+		a.log.Debugf("Event sources do not match Azure Event Hub sources, event: %+v, eventSources: %+v", event, eventSources)
+		return nil
+	}
+
+	raw, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("while marshaling event: %w", err)
+	}
+	msg := AzureEventHubMessage{
+		Body: string(raw),
+		UserProperties: map[string]string{
+			"cluster":   event.Cluster,
+			"namespace": event.Namespace,
+			"kind":      event.Kind,
+			"type":      string(event.Type),
+			"level":     string(event.Level),
+		},
+	}
+
+	a.mu.Lock()
+	var batch []AzureEventHubMessage
+	if a.bufferBytes+len(msg.Body) > azureEventHubMaxBatchBytes {
+		batch = a.takeBufferLocked()
+	}
+	a.buffer = append(a.buffer, msg)
+	a.bufferBytes += len(msg.Body)
+	if batch == nil && len(a.buffer) >= a.maxEvents {
+		batch = a.takeBufferLocked()
+	}
+	a.mu.Unlock()
+
+	if err := a.flush(ctx, batch); err != nil {
+		return fmt.Errorf("while sending events to Azure Event Hub: %w", err)
+	}
+	return nil
+}
+
+// SendMessageToAll is no-op.
+func (a *AzureEventHub) SendMessageToAll(_ context.Context, _ interactive.Message) error {
+	return nil
+}
+
+// SendGenericMessage is no-op.
+func (a *AzureEventHub) SendGenericMessage(_ context.Context, _ interactive.GenericMessage, _ []string) error {
+	return nil
+}
+
+// IntegrationName describes the sink integration name.
+func (a *AzureEventHub) IntegrationName() config.CommPlatformIntegration {
+	return config.AzureEventHubCommPlatformIntegration
+}
+
+// Type describes the sink type.
+func (a *AzureEventHub) Type() config.IntegrationType {
+	return config.SinkIntegrationType
+}
+
+func (a *AzureEventHub) takeBuffer() []AzureEventHubMessage {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.takeBufferLocked()
+}
+
+func (a *AzureEventHub) takeBufferLocked() []AzureEventHubMessage {
+	out := a.buffer
+	a.buffer = nil
+	a.bufferBytes = 0
+	return out
+}
+
+func (a *AzureEventHub) flush(ctx context.Context, batch []AzureEventHubMessage) (err error) {
+	if len(batch) == 0 {
+		return nil
+	}
+
+	body, err := json.Marshal(batch)
+	if err != nil {
+		return fmt.Errorf("while marshaling batch: %w", err)
+	}
+
+	authz, err := a.tokenProvider.Authorization(ctx)
+	if err != nil {
+		return fmt.Errorf("while getting authorization token: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, a.messagesURL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("while creating request: %w", err)
+	}
+	req.Header.Set("Content-Type", azureEventHubBatchContentType)
+	req.Header.Set("Authorization", authz)
+
+	resp, err := a.httpCli.Do(req)
+	if err != nil {
+		return fmt.Errorf("while sending request: %w", err)
+	}
+	defer func() {
+		deferredErr := resp.Body.Close()
+		if deferredErr != nil {
+			err = multierror.Append(err, deferredErr)
+		}
+	}()
+
+	if resp.StatusCode != http.StatusCreated {
+		raw, _ := io.ReadAll(resp.Body)
+		return &StatusError{
+			StatusCode: resp.StatusCode,
+			Message:    fmt.Sprintf("got unexpected status code %d while sending %d events: %s", resp.StatusCode, len(batch), strings.TrimSpace(string(raw))),
+		}
+	}
+
+	a.log.Debugf("Successfully sent %d events to Azure Event Hub", len(batch))
+	return nil
+}
+
+type azureConnectionString struct {
+	Namespace  string
+	KeyName    string
+	Key        string
+	EntityPath string
+}
+
+// parseAzureConnectionString parses the connection string in format:
+// Endpoint=sb://<namespace>/;SharedAccessKeyName=<key name>;SharedAccessKey=<key>[;EntityPath=<event hub>]
+func parseAzureConnectionString(in string) (azureConnectionString, error) {
+	var out azureConnectionString
+	for _, part := range strings.Split(in, ";") {
+		key, val, found := strings.Cut(strings.TrimSpace(part), "=")
+		if !found {
+			continue
+		}
+		switch strings.ToLower(key) {
+		case "endpoint":
+			endpoint, err := url.Parse(val)
+			if err != nil {
+				return azureConnectionString{}, fmt.Errorf("while parsing endpoint: %w", err)
+			}
+			out.Namespace = endpoint.Host
+		case "sharedaccesskeyname":
+			out.KeyName = val
+		case "sharedaccesskey":
+			out.Key = val
+		case "entitypath":
+			out.EntityPath = val
+		}
+	}
+
+	if out.Namespace == "" || out.KeyName == "" || out.Key == "" {
+		return azureConnectionString{}, fmt.Errorf("the Endpoint, SharedAccessKeyName and SharedAccessKey are required")
+	}
+	return out, nil
+}
+
+// azureSASTokenProvider generates Shared Access Signature tokens.
+// See https://learn.microsoft.com/en-us/rest/api/eventhub/generate-sas-token
+type azureSASTokenProvider struct {
+	resourceURI string
+	keyName     string
+	key         string
+
+	nowFn func() time.Time
+}
+
+func (p *azureSASTokenProvider) Authorization(_ context.Context) (string, error) {
+	now := time.Now
+	if p.nowFn != nil {
+		now = p.nowFn
+	}
+
+	encodedURI := url.QueryEscape(p.resourceURI)
+	expiry := strconv.FormatInt(now().Add(azureSASTokenTTL).Unix(), 10)
+
+	mac := hmac.New(sha256.New, []byte(p.key))
+	mac.Write([]byte(encodedURI + "\n" + expiry))
+	signature := base64.StdEncoding.EncodeToString(mac.Sum(nil))
+
+	return fmt.Sprintf("SharedAccessSignature sr=%s&sig=%s&se=%s&skn=%s", encodedURI, url.QueryEscape(signature), expiry, p.keyName), nil
+}
+
+// azureManagedIdentityTokenProvider gets Azure AD tokens of the managed identity from the Instance Metadata Service.
+// See https://learn.microsoft.com/en-us/azure/active-directory/managed-identities-azure-resources/how-to-use-vm-token
+type azureManagedIdentityTokenProvider struct {
+	httpCli  *http.Client
+	tokenURL string
+	clientID string
+
+	mu        sync.Mutex
+	token     string
+	expiresAt time.Time
+}
+
+type azureIMDSTokenResponse struct {
+	AccessToken string `json:"access_token"`
+	ExpiresOn   string `json:"expires_on"`
+}
+
+func (p *azureManagedIdentityTokenProvider) Authorization(ctx context.Context) (_ string, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.token != "" && time.Now().Add(azureTokenRefreshMargin).Before(p.expiresAt) {
+		return "Bearer " + p.token, nil
+	}
+
+	query := url.Values{}
+	query.Set("api-version", azureIMDSAPIVersion)
+	query.Set("resource", azureEventHubResource)
+	if p.clientID != "" {
+		query.Set("client_id", p.clientID)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.tokenURL+"?"+query.Encode(), nil)
+	if err != nil {
+		return "", fmt.Errorf("while creating request: %w", err)
+	}
+	req.Header.Set("Metadata", "true")
+
+	resp, err := p.httpCli.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("while sending request: %w", err)
+	}
+	defer func() {
+		deferredErr := resp.Body.Close()
+		if deferredErr != nil {
+			err = multierror.Append(err, deferredErr)
+		}
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		raw, _ := io.ReadAll(resp.Body)
+		return "", newUnexpectedStatusError(resp.StatusCode, raw)
+	}
+
+	var out azureIMDSTokenResponse
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return "", fmt.Errorf("while decoding response: %w", err)
+	}
+	expiresOn, err := strconv.ParseInt(out.ExpiresOn, 10, 64)
+	if err != nil {
+		return "", fmt.Errorf("while parsing token expiry: %w", err)
+	}
+
+	p.token = out.AccessToken
+	p.expiresAt = time.Unix(expiresOn, 0)
+	return "Bearer " + p.token, nil
+}
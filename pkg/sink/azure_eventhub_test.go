@@ -0,0 +1,260 @@
+package sink
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	logtest "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/internal/analytics"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/events"
+)
+
+func TestAzureEventHub_SendEventBatches(t *testing.T) {
+	// given
+	srv := newFakeEventHubServer(t)
+	defer srv.Close()
+
+	hub := newTestAzureEventHub(t, srv, config.AzureEventHubBatch{MaxEvents: 2, FlushInterval: time.Hour})
+
+	// when
+	err := hub.SendEvent(context.Background(), fixSinkEvent(config.Error), []string{"k8s-err-events"})
+	require.NoError(t, err)
+	err = hub.SendEvent(context.Background(), fixSinkEvent(config.Error), []string{"other"})
+	require.NoError(t, err)
+
+	// then
+	assert.Empty(t, srv.Batches())
+
+	// when
+	err = hub.SendEvent(context.Background(), fixSinkEvent(config.Info), []string{"k8s-err-events"})
+	require.NoError(t, err)
+
+	// then
+	batches := srv.Batches()
+	require.Len(t, batches, 1)
+	require.Len(t, batches[0], 2)
+	assert.Equal(t, "error", batches[0][0].UserProperties["level"])
+	assert.Equal(t, "info", batches[0][1].UserProperties["level"])
+
+	var gotEvent events.Event
+	require.NoError(t, json.Unmarshal([]byte(batches[0][0].Body), &gotEvent))
+	assert.Equal(t, "nginx", gotEvent.Name)
+
+	assert.Equal(t, "/botkube-events/messages", srv.Path())
+	assert.True(t, strings.HasPrefix(srv.Authorization(), "SharedAccessSignature sr="))
+}
+
+func TestAzureEventHub_RunFlushesOnInterval(t *testing.T) {
+	// given
+	srv := newFakeEventHubServer(t)
+	defer srv.Close()
+
+	hub := newTestAzureEventHub(t, srv, config.AzureEventHubBatch{FlushInterval: 10 * time.Millisecond})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- hub.Run(ctx)
+	}()
+
+	// when
+	err := hub.SendEvent(context.Background(), fixSinkEvent(config.Error), []string{"k8s-err-events"})
+	require.NoError(t, err)
+
+	// then
+	assert.Eventually(t, func() bool {
+		return len(srv.Batches()) == 1
+	}, time.Second, 5*time.Millisecond)
+
+	cancel()
+	assert.NoError(t, <-errCh)
+}
+
+func TestAzureEventHub_RunFlushesOnShutdown(t *testing.T) {
+	// given
+	srv := newFakeEventHubServer(t)
+	defer srv.Close()
+
+	hub := newTestAzureEventHub(t, srv, config.AzureEventHubBatch{FlushInterval: time.Hour})
+	err := hub.SendEvent(context.Background(), fixSinkEvent(config.Error), []string{"k8s-err-events"})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// when
+	err = hub.Run(ctx)
+
+	// then
+	require.NoError(t, err)
+	assert.Len(t, srv.Batches(), 1)
+}
+
+func TestParseAzureConnectionString(t *testing.T) {
+	tests := map[string]struct {
+		givenConnStr string
+		expConnStr   azureConnectionString
+		expErrMsg    string
+	}{
+		"With entity path": {
+			givenConnStr: "Endpoint=sb://botkube.servicebus.windows.net/;SharedAccessKeyName=send;SharedAccessKey=c2VjcmV0PQ==;EntityPath=events",
+			expConnStr: azureConnectionString{
+				Namespace:  "botkube.servicebus.windows.net",
+				KeyName:    "send",
+				Key:        "c2VjcmV0PQ==",
+				EntityPath: "events",
+			},
+		},
+		"Without entity path": {
+			givenConnStr: "Endpoint=sb://botkube.servicebus.windows.net/;SharedAccessKeyName=send;SharedAccessKey=key",
+			expConnStr: azureConnectionString{
+				Namespace: "botkube.servicebus.windows.net",
+				KeyName:   "send",
+				Key:       "key",
+			},
+		},
+		"Missing key": {
+			givenConnStr: "Endpoint=sb://botkube.servicebus.windows.net/;SharedAccessKeyName=send",
+			expErrMsg:    "the Endpoint, SharedAccessKeyName and SharedAccessKey are required",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// when
+			connStr, err := parseAzureConnectionString(tc.givenConnStr)
+
+			// then
+			if tc.expErrMsg != "" {
+				assert.EqualError(t, err, tc.expErrMsg)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expConnStr, connStr)
+		})
+	}
+}
+
+func TestAzureSASTokenProvider(t *testing.T) {
+	// given
+	provider := &azureSASTokenProvider{
+		resourceURI: "https://botkube.servicebus.windows.net/events",
+		keyName:     "send",
+		key:         "secret",
+		nowFn: func() time.Time {
+			return time.Unix(1700000000, 0)
+		},
+	}
+
+	// when
+	token, err := provider.Authorization(context.Background())
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, "SharedAccessSignature sr=https%3A%2F%2Fbotkube.servicebus.windows.net%2Fevents&sig=lzCnHkMeQ1q0rxQoirkDaaaGjkW0%2FvTq5gypPPBBwnU%3D&se=1700003600&skn=send", token)
+}
+
+func TestAzureManagedIdentityTokenProvider(t *testing.T) {
+	// given
+	var calls int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		assert.Equal(t, "true", r.Header.Get("Metadata"))
+		assert.Equal(t, azureEventHubResource, r.URL.Query().Get("resource"))
+		assert.Equal(t, "client-id", r.URL.Query().Get("client_id"))
+		_, _ = w.Write([]byte(fmt.Sprintf(`{"access_token":"token","expires_on":"%d"}`, time.Now().Add(time.Hour).Unix())))
+	}))
+	defer ts.Close()
+
+	provider := &azureManagedIdentityTokenProvider{
+		httpCli:  ts.Client(),
+		tokenURL: ts.URL,
+		clientID: "client-id",
+	}
+
+	// when
+	first, err := provider.Authorization(context.Background())
+	require.NoError(t, err)
+	second, err := provider.Authorization(context.Background())
+	require.NoError(t, err)
+
+	// then
+	assert.Equal(t, "Bearer token", first)
+	assert.Equal(t, first, second)
+	assert.Equal(t, 1, calls)
+}
+
+func newTestAzureEventHub(t *testing.T, srv *fakeEventHubServer, batch config.AzureEventHubBatch) *AzureEventHub {
+	t.Helper()
+
+	logger, _ := logtest.NewNullLogger()
+	hub, err := NewAzureEventHub(logger, config.AzureEventHub{
+		Enabled:          true,
+		ConnectionString: "Endpoint=sb://" + srv.Listener.Addr().String() + "/;SharedAccessKeyName=send;SharedAccessKey=secret;EntityPath=botkube-events",
+		Batch:            batch,
+		Bindings:         config.SinkBindings{Sources: []string{"k8s-err-events"}},
+	}, analytics.NewNoopReporter())
+	require.NoError(t, err)
+
+	hub.httpCli = srv.Client()
+	return hub
+}
+
+type fakeEventHubServer struct {
+	*httptest.Server
+
+	mu      sync.Mutex
+	batches [][]AzureEventHubMessage
+	path    string
+	authz   string
+}
+
+func newFakeEventHubServer(t *testing.T) *fakeEventHubServer {
+	t.Helper()
+
+	srv := &fakeEventHubServer{}
+	srv.Server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var batch []AzureEventHubMessage
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&batch))
+		assert.Equal(t, azureEventHubBatchContentType, r.Header.Get("Content-Type"))
+
+		srv.mu.Lock()
+		srv.batches = append(srv.batches, batch)
+		srv.path = r.URL.Path
+		srv.authz = r.Header.Get("Authorization")
+		srv.mu.Unlock()
+
+		w.WriteHeader(http.StatusCreated)
+	}))
+	return srv
+}
+
+func (s *fakeEventHubServer) Batches() [][]AzureEventHubMessage {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.batches
+}
+
+func (s *fakeEventHubServer) Path() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.path
+}
+
+func (s *fakeEventHubServer) Authorization() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.authz
+}
@@ -0,0 +1,233 @@
+package sink
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/kubeshop/botkube/pkg/bot/interactive"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/events"
+	"github.com/kubeshop/botkube/pkg/format"
+	"github.com/kubeshop/botkube/pkg/multierror"
+	"github.com/kubeshop/botkube/pkg/sliceutil"
+)
+
+var _ Sink = &Datadog{}
+
+const (
+	defaultDatadogSite      = "datadoghq.com"
+	datadogEventsPath       = "/api/v1/events"
+	datadogSeriesPath       = "/api/v2/series"
+	datadogAPIKeyHeader     = "DD-API-KEY"
+	datadogSourceType       = "kubernetes"
+	datadogEventCountMetric = "botkube.event.count"
+	// datadogCountMetricType is the `count` type of the metrics intake API v2.
+	datadogCountMetricType = 1
+
+	// Field limits defined by the Datadog Events API.
+	datadogMaxTitleLength          = 100
+	datadogMaxTextLength           = 4000
+	datadogMaxAggregationKeyLength = 100
+)
+
+// datadogAlertTypes maps event levels to Datadog event alert types.
+var datadogAlertTypes = map[config.Level]string{
+	config.Critical: "error",
+	config.Error:    "error",
+	config.Warn:     "warning",
+	config.Info:     "info",
+	config.Debug:    "info",
+}
+
+// Datadog provides functionality to post events to Datadog. Optionally, it emits a count metric for each event.
+type Datadog struct {
+	log      logrus.FieldLogger
+	reporter AnalyticsReporter
+	httpCli  *http.Client
+
+	apiURL         string
+	apiKey         string
+	tags           []string
+	metricsEnabled bool
+	bindings       config.SinkBindings
+}
+
+// DatadogEvent is the Datadog Events API payload.
+type DatadogEvent struct {
+	Title          string   `json:"title"`
+	Text           string   `json:"text"`
+	AlertType      string   `json:"alert_type,omitempty"`
+	AggregationKey string   `json:"aggregation_key,omitempty"`
+	SourceTypeName string   `json:"source_type_name,omitempty"`
+	DateHappened   int64    `json:"date_happened,omitempty"`
+	Tags           []string `json:"tags,omitempty"`
+}
+
+// DatadogSeries is the Datadog metrics intake API v2 payload.
+type DatadogSeries struct {
+	Series []DatadogMetric `json:"series"`
+}
+
+// DatadogMetric is a single metric time series.
+type DatadogMetric struct {
+	Metric string               `json:"metric"`
+	Type   int                  `json:"type"`
+	Points []DatadogMetricPoint `json:"points"`
+	Tags   []string             `json:"tags,omitempty"`
+}
+
+// DatadogMetricPoint is a single metric value.
+type DatadogMetricPoint struct {
+	Timestamp int64   `json:"timestamp"`
+	Value     float64 `json:"value"`
+}
+
+// NewDatadog creates a new Datadog instance.
+func NewDatadog(log logrus.FieldLogger, c config.Datadog, reporter AnalyticsReporter) (*Datadog, error) {
+	site := c.Site
+	if site == "" {
+		site = defaultDatadogSite
+	}
+
+	dd := &Datadog{
+		log:            log,
+		reporter:       reporter,
+		httpCli:        &http.Client{Timeout: defaultHTTPCliTimeout},
+		apiURL:         "https://api." + site,
+		apiKey:         c.APIKey,
+		tags:           c.Tags,
+		metricsEnabled: c.Metrics.Enabled,
+		bindings:       c.Bindings,
+	}
+
+	err := reporter.ReportSinkEnabled(dd.IntegrationName())
+	if err != nil {
+		return nil, fmt.Errorf("while reporting analytics: %w", err)
+	}
+
+	return dd, nil
+}
+
+// SendEvent posts a given event to Datadog.
+func (d *Datadog) SendEvent(ctx context.Context, event events.Event, eventSources []string) error {
+	if !sliceutil.Intersect(eventSources, d.bindings.Sources) {
+		d.log.Debugf("Event sources do not match Datadog sources, event: %+v, eventSources: %+v", event, eventSources)
+		return nil
+	}
+
+	tags := d.tagsFor(event)
+
+	errs := multierror.New()
+	err := d.post(ctx, datadogEventsPath, DatadogEvent{
+		Title:          truncate(strings.TrimSpace(format.ShortMessage(event)), datadogMaxTitleLength),
+		Text:           truncate(eventDetailsText(event), datadogMaxTextLength),
+		AlertType:      datadogAlertTypes[event.Level],
+		AggregationKey: truncate(strings.Join([]string{event.Cluster, event.Namespace, event.Kind, event.Name}, "/"), datadogMaxAggregationKeyLength),
+		SourceTypeName: datadogSourceType,
+		DateHappened:   event.TimeStamp.Unix(),
+		Tags:           tags,
+	})
+	if err != nil {
+		errs = multierror.Append(errs, fmt.Errorf("while posting Datadog event: %w", err))
+	}
+
+	if d.metricsEnabled {
+		err := d.post(ctx, datadogSeriesPath, DatadogSeries{
+			Series: []DatadogMetric{
+				{
+					Metric: datadogEventCountMetric,
+					Type:   datadogCountMetricType,
+					Points: []DatadogMetricPoint{{Timestamp: event.TimeStamp.Unix(), Value: 1}},
+					Tags:   tags,
+				},
+			},
+		})
+		if err != nil {
+			errs = multierror.Append(errs, fmt.Errorf("while submitting Datadog metric: %w", err))
+		}
+	}
+
+	if err := errs.ErrorOrNil(); err != nil {
+		return err
+	}
+
+	d.log.Debugf("Event successfully sent to Datadog: %+v", event)
+	return nil
+}
+
+// SendMessageToAll is no-op.
+func (d *Datadog) SendMessageToAll(_ context.Context, _ interactive.Message) error {
+	return nil
+}
+
+// SendGenericMessage is no-op.
+func (d *Datadog) SendGenericMessage(_ context.Context, _ interactive.GenericMessage, _ []string) error {
+	return nil
+}
+
+// IntegrationName describes the sink integration name.
+func (d *Datadog) IntegrationName() config.CommPlatformIntegration {
+	return config.DatadogCommPlatformIntegration
+}
+
+// Type describes the sink type.
+func (d *Datadog) Type() config.IntegrationType {
+	return config.SinkIntegrationType
+}
+
+// tagsFor returns the configured tags together with the ones generated from the event.
+func (d *Datadog) tagsFor(event events.Event) []string {
+	tags := append([]string{}, d.tags...)
+	generated := []struct{ key, val string }{
+		{key: "kube_cluster_name", val: event.Cluster},
+		{key: "kube_namespace", val: event.Namespace},
+		{key: "kube_kind", val: event.Kind},
+		{key: "event_type", val: string(event.Type)},
+		{key: "level", val: string(event.Level)},
+	}
+	for _, tag := range generated {
+		if tag.val == "" {
+			continue
+		}
+		tags = append(tags, fmt.Sprintf("%s:%s", tag.key, tag.val))
+	}
+	return tags
+}
+
+func (d *Datadog) post(ctx context.Context, path string, in interface{}) (err error) {
+	body, err := json.Marshal(in)
+	if err != nil {
+		return fmt.Errorf("while marshaling request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, d.apiURL+path, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("while creating request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(datadogAPIKeyHeader, d.apiKey)
+
+	resp, err := d.httpCli.Do(req)
+	if err != nil {
+		return fmt.Errorf("while sending request: %w", err)
+	}
+	defer func() {
+		deferredErr := resp.Body.Close()
+		if deferredErr != nil {
+			err = multierror.Append(err, deferredErr)
+		}
+	}()
+
+	if resp.StatusCode != http.StatusAccepted {
+		raw, _ := io.ReadAll(resp.Body)
+		return newUnexpectedStatusError(resp.StatusCode, raw)
+	}
+	return nil
+}
@@ -0,0 +1,122 @@
+package sink
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	logtest "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/internal/analytics"
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+func TestDatadog_SendEvent(t *testing.T) {
+	tests := map[string]struct {
+		metricsEnabled bool
+		expPaths       []string
+	}{
+		"Event only": {
+			metricsEnabled: false,
+			expPaths:       []string{datadogEventsPath},
+		},
+		"Event with metric": {
+			metricsEnabled: true,
+			expPaths:       []string{datadogEventsPath, datadogSeriesPath},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given
+			var (
+				gotPaths  []string
+				gotEvent  DatadogEvent
+				gotSeries DatadogSeries
+			)
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "my-key", r.Header.Get(datadogAPIKeyHeader))
+				gotPaths = append(gotPaths, r.URL.Path)
+				switch r.URL.Path {
+				case datadogEventsPath:
+					require.NoError(t, json.NewDecoder(r.Body).Decode(&gotEvent))
+				case datadogSeriesPath:
+					require.NoError(t, json.NewDecoder(r.Body).Decode(&gotSeries))
+				}
+				w.WriteHeader(http.StatusAccepted)
+			}))
+			defer ts.Close()
+
+			dd := newTestDatadog(t, ts, tc.metricsEnabled)
+			event := fixSinkEvent(config.Warn)
+
+			// when
+			err := dd.SendEvent(context.Background(), event, []string{"k8s-all-events"})
+
+			// then
+			require.NoError(t, err)
+			assert.Equal(t, tc.expPaths, gotPaths)
+
+			expTags := []string{"team:platform", "kube_cluster_name:dev", "kube_namespace:default", "kube_kind:Pod", "event_type:error", "level:warn"}
+			assert.Equal(t, "warning", gotEvent.AlertType)
+			assert.Equal(t, "dev/default/Pod/nginx", gotEvent.AggregationKey)
+			assert.Equal(t, "Back-off restarting failed container", gotEvent.Text)
+			assert.Equal(t, event.TimeStamp.Unix(), gotEvent.DateHappened)
+			assert.Equal(t, expTags, gotEvent.Tags)
+
+			if !tc.metricsEnabled {
+				return
+			}
+			require.Len(t, gotSeries.Series, 1)
+			assert.Equal(t, DatadogMetric{
+				Metric: datadogEventCountMetric,
+				Type:   datadogCountMetricType,
+				Points: []DatadogMetricPoint{{Timestamp: event.TimeStamp.Unix(), Value: 1}},
+				Tags:   expTags,
+			}, gotSeries.Series[0])
+		})
+	}
+}
+
+func TestDatadog_SendEventFailure(t *testing.T) {
+	// given
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == datadogSeriesPath {
+			w.WriteHeader(http.StatusAccepted)
+			return
+		}
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"errors":["Forbidden"]}`))
+	}))
+	defer ts.Close()
+
+	dd := newTestDatadog(t, ts, true)
+
+	// when
+	err := dd.SendEvent(context.Background(), fixSinkEvent(config.Error), []string{"k8s-all-events"})
+
+	// then
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `while posting Datadog event: got unexpected status code 403: {"errors":["Forbidden"]}`)
+	assert.NotContains(t, err.Error(), "metric")
+}
+
+func newTestDatadog(t *testing.T, ts *httptest.Server, metricsEnabled bool) *Datadog {
+	t.Helper()
+
+	logger, _ := logtest.NewNullLogger()
+	dd, err := NewDatadog(logger, config.Datadog{
+		Enabled:  true,
+		APIKey:   "my-key",
+		Tags:     []string{"team:platform"},
+		Metrics:  config.DatadogMetrics{Enabled: metricsEnabled},
+		Bindings: config.SinkBindings{Sources: []string{"k8s-all-events"}},
+	}, analytics.NewNoopReporter())
+	require.NoError(t, err)
+
+	dd.apiURL = ts.URL
+	return dd
+}
@@ -5,15 +5,12 @@ import (
 	"crypto/tls"
 	"fmt"
 	"net/http"
-	"os"
+	"net/url"
 	"time"
 
-	"github.com/aws/aws-sdk-go/aws/credentials"
 	"github.com/aws/aws-sdk-go/aws/credentials/ec2rolecreds"
-	"github.com/aws/aws-sdk-go/aws/credentials/stscreds"
 	"github.com/aws/aws-sdk-go/aws/session"
 	v4 "github.com/aws/aws-sdk-go/aws/signer/v4"
-	"github.com/aws/aws-sdk-go/service/sts"
 	"github.com/olivere/elastic"
 	"github.com/sha1sum/aws_signing_client"
 	"github.com/sirupsen/logrus"
@@ -32,11 +29,8 @@ const (
 	indexSuffixFormat = "2006-01-02" // YYYY-MM-DD
 	// awsService for the AWS client to authenticate against
 	awsService = "es"
-	// AWS Role ARN from POD env variable while using IAM Role for service account
-	awsRoleARNEnvName = "AWS_ROLE_ARN"
-	// The token file mount path in POD env variable while using IAM Role for service account
-	// #nosec G101
-	awsWebIDTokenFileEnvName = "AWS_WEB_IDENTITY_TOKEN_FILE"
+	// dataStreamDocType is the only document type supported by data streams
+	dataStreamDocType = "_doc"
 )
 
 // Elasticsearch provides integration with the Elasticsearch solution.
@@ -48,26 +42,40 @@ type Elasticsearch struct {
 }
 
 // NewElasticsearch creates a new Elasticsearch instance.
-func NewElasticsearch(log logrus.FieldLogger, c config.Elasticsearch, reporter AnalyticsReporter) (*Elasticsearch, error) {
+func NewElasticsearch(ctx context.Context, log logrus.FieldLogger, c config.Elasticsearch, reporter AnalyticsReporter) (*Elasticsearch, error) {
+	elsClient, err := NewElasticsearchClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	esNotifier := &Elasticsearch{
+		log:      log,
+		reporter: reporter,
+		client:   elsClient,
+		indices:  c.Indices,
+	}
+
+	err = esNotifier.putIndexTemplates(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	err = reporter.ReportSinkEnabled(esNotifier.IntegrationName())
+	if err != nil {
+		return nil, fmt.Errorf("while reporting analytics: %w", err)
+	}
+
+	return esNotifier, nil
+}
+
+// NewElasticsearchClient creates a new Elasticsearch client, which signs the requests with AWS credentials, if enabled.
+func NewElasticsearchClient(c config.Elasticsearch) (*elastic.Client, error) {
 	var elsClient *elastic.Client
 	var err error
-	var creds *credentials.Credentials
 	if c.AWSSigning.Enabled {
 		// Get credentials from environment variables and create the AWS Signature Version 4 signer
 		sess := session.Must(session.NewSession())
-
-		// Use OIDC token to generate credentials if using IAM to Service Account
-		awsRoleARN := os.Getenv(awsRoleARNEnvName)
-		awsWebIdentityTokenFile := os.Getenv(awsWebIDTokenFileEnvName)
-		if awsRoleARN != "" && awsWebIdentityTokenFile != "" {
-			svc := sts.New(sess)
-			p := stscreds.NewWebIdentityRoleProviderWithOptions(svc, awsRoleARN, "", stscreds.FetchTokenPath(awsWebIdentityTokenFile))
-			creds = credentials.NewCredentials(p)
-		} else if c.AWSSigning.RoleArn != "" {
-			creds = stscreds.NewCredentials(sess, c.AWSSigning.RoleArn)
-		} else {
-			creds = ec2rolecreds.NewCredentials(sess)
-		}
+		creds := awsCredentials(sess, c.AWSSigning.RoleArn, ec2rolecreds.NewCredentials(sess))
 
 		signer := v4.NewSigner(creds)
 		awsClient, err := aws_signing_client.New(signer, nil, awsService, c.AWSSigning.AWSRegion)
@@ -109,19 +117,7 @@ func NewElasticsearch(log logrus.FieldLogger, c config.Elasticsearch, reporter A
 		}
 	}
 
-	esNotifier := &Elasticsearch{
-		log:      log,
-		reporter: reporter,
-		client:   elsClient,
-		indices:  c.Indices,
-	}
-
-	err = reporter.ReportSinkEnabled(esNotifier.IntegrationName())
-	if err != nil {
-		return nil, fmt.Errorf("while reporting analytics: %w", err)
-	}
-
-	return esNotifier, nil
+	return elsClient, nil
 }
 
 type mapping struct {
@@ -132,11 +128,80 @@ type settings struct {
 	Index index `json:"index"`
 }
 type index struct {
-	Shards   int `json:"number_of_shards"`
-	Replicas int `json:"number_of_replicas"`
+	Shards    int        `json:"number_of_shards"`
+	Replicas  int        `json:"number_of_replicas"`
+	Lifecycle *lifecycle `json:"lifecycle,omitempty"`
 }
+type lifecycle struct {
+	Name string `json:"name"`
+}
+
+// indexTemplate is the composable index template.
+// See https://www.elastic.co/guide/en/elasticsearch/reference/current/index-templates.html
+type indexTemplate struct {
+	IndexPatterns []string          `json:"index_patterns"`
+	Priority      int               `json:"priority,omitempty"`
+	DataStream    *struct{}         `json:"data_stream,omitempty"`
+	Template      mapping           `json:"template"`
+	Meta          map[string]string `json:"_meta,omitempty"`
+}
+
+func indexSettings(indexCfg config.ELSIndex) settings {
+	out := settings{
+		Index: index{
+			Shards:   indexCfg.Shards,
+			Replicas: indexCfg.Replicas,
+		},
+	}
+	if indexCfg.ILMPolicy != "" {
+		out.Index.Lifecycle = &lifecycle{Name: indexCfg.ILMPolicy}
+	}
+	return out
+}
+
+// putIndexTemplates creates or updates the index templates for the indices which have it enabled.
+func (e *Elasticsearch) putIndexTemplates(ctx context.Context) error {
+	for _, indexCfg := range e.indices {
+		if !indexCfg.Template.Create {
+			continue
+		}
+
+		name := indexCfg.Template.Name
+		if name == "" {
+			name = indexCfg.Name
+		}
+
+		tpl := indexTemplate{
+			// daily indices are suffixed with the date
+			IndexPatterns: []string{indexCfg.Name + "-*"},
+			Priority:      indexCfg.Template.Priority,
+			Template:      mapping{Settings: indexSettings(indexCfg)},
+			Meta:          map[string]string{"managed_by": "botkube"},
+		}
+		if indexCfg.DataStream {
+			tpl.IndexPatterns = []string{indexCfg.Name}
+			tpl.DataStream = &struct{}{}
+		}
+
+		_, err := e.client.PerformRequest(ctx, elastic.PerformRequestOptions{
+			Method: http.MethodPut,
+			Path:   "/_index_template/" + url.PathEscape(name),
+			Body:   tpl,
+		})
+		if err != nil {
+			return fmt.Errorf("while putting Elasticsearch index template %q: %w", name, err)
+		}
+		e.log.Infof("Elasticsearch index template %q successfully put", name)
+	}
+
+	return nil
+}
+
+func (e *Elasticsearch) flushIndex(ctx context.Context, indexCfg config.ELSIndex, event events.Event) error {
+	if indexCfg.DataStream {
+		return e.flushDataStream(ctx, indexCfg, event)
+	}
 
-func (e *Elasticsearch) flushIndex(ctx context.Context, indexCfg config.ELSIndex, event interface{}) error {
 	// Construct the ELS Index Name with timestamp suffix
 	indexName := indexCfg.Name + "-" + time.Now().Format(indexSuffixFormat)
 	// Create index if not exists
@@ -147,12 +212,7 @@ func (e *Elasticsearch) flushIndex(ctx context.Context, indexCfg config.ELSIndex
 	if !exists {
 		// Create a new index.
 		mapping := mapping{
-			Settings: settings{
-				index{
-					Shards:   indexCfg.Shards,
-					Replicas: indexCfg.Replicas,
-				},
-			},
+			Settings: indexSettings(indexCfg),
 		}
 		_, err := e.client.CreateIndex(indexName).BodyJson(mapping).Do(ctx)
 		if err != nil {
@@ -173,6 +233,30 @@ func (e *Elasticsearch) flushIndex(ctx context.Context, indexCfg config.ELSIndex
 	return nil
 }
 
+// dataStreamDocument is the event with the `@timestamp` field, which is required by data streams.
+type dataStreamDocument struct {
+	events.Event
+	Timestamp time.Time `json:"@timestamp"`
+}
+
+func (e *Elasticsearch) flushDataStream(ctx context.Context, indexCfg config.ELSIndex, event events.Event) error {
+	doc := dataStreamDocument{
+		Event:     event,
+		Timestamp: event.TimeStamp,
+	}
+	if doc.Timestamp.IsZero() {
+		doc.Timestamp = time.Now()
+	}
+
+	// Data streams accept only the `create` operation and don't support mapping types.
+	_, err := e.client.Index().Index(indexCfg.Name).Type(dataStreamDocType).OpType("create").BodyJson(doc).Do(ctx)
+	if err != nil {
+		return fmt.Errorf("while posting data to ELS data stream: %w", err)
+	}
+	e.log.Debugf("Event successfully sent to Elasticsearch data stream %s", indexCfg.Name)
+	return nil
+}
+
 // SendEvent sends event notification to Elasticsearch
 func (e *Elasticsearch) SendEvent(ctx context.Context, event events.Event, eventSources []string) (err error) {
 	e.log.Debugf(">> Sending to Elasticsearch: %+v", event)
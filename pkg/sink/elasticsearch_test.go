@@ -0,0 +1,176 @@
+package sink
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	logtest "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/internal/analytics"
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+func TestElasticsearch_PutIndexTemplates(t *testing.T) {
+	tests := map[string]struct {
+		givenIndex config.ELSIndex
+
+		expPath     string
+		expTemplate string
+	}{
+		"Data stream with ILM policy": {
+			givenIndex: config.ELSIndex{
+				Name:       "botkube-events",
+				Shards:     1,
+				Replicas:   1,
+				DataStream: true,
+				ILMPolicy:  "botkube-30d",
+				Template: config.ELSIndexTemplate{
+					Create:   true,
+					Priority: 200,
+				},
+			},
+			expPath: "/_index_template/botkube-events",
+			expTemplate: `{
+				"index_patterns": ["botkube-events"],
+				"priority": 200,
+				"data_stream": {},
+				"template": {"settings": {"index": {"number_of_shards": 1, "number_of_replicas": 1, "lifecycle": {"name": "botkube-30d"}}}},
+				"_meta": {"managed_by": "botkube"}
+			}`,
+		},
+		"Daily indices with custom template name": {
+			givenIndex: config.ELSIndex{
+				Name:     "botkube",
+				Shards:   2,
+				Template: config.ELSIndexTemplate{Create: true, Name: "botkube-daily"},
+			},
+			expPath: "/_index_template/botkube-daily",
+			expTemplate: `{
+				"index_patterns": ["botkube-*"],
+				"template": {"settings": {"index": {"number_of_shards": 2, "number_of_replicas": 0}}},
+				"_meta": {"managed_by": "botkube"}
+			}`,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given
+			srv := newFakeElasticsearchServer(t)
+			defer srv.Close()
+
+			logger, _ := logtest.NewNullLogger()
+
+			// when
+			_, err := NewElasticsearch(context.Background(), logger, config.Elasticsearch{
+				Enabled: true,
+				Server:  srv.URL,
+				Indices: map[string]config.ELSIndex{"default": tc.givenIndex},
+			}, analytics.NewNoopReporter())
+
+			// then
+			require.NoError(t, err)
+			reqs := srv.Requests()
+			require.Len(t, reqs, 1)
+			assert.Equal(t, http.MethodPut, reqs[0].Method)
+			assert.Equal(t, tc.expPath, reqs[0].Path)
+			assert.JSONEq(t, tc.expTemplate, reqs[0].Body)
+		})
+	}
+}
+
+func TestElasticsearch_SendEventToDataStream(t *testing.T) {
+	// given
+	srv := newFakeElasticsearchServer(t)
+	defer srv.Close()
+
+	logger, _ := logtest.NewNullLogger()
+	es, err := NewElasticsearch(context.Background(), logger, config.Elasticsearch{
+		Enabled: true,
+		Server:  srv.URL,
+		Indices: map[string]config.ELSIndex{
+			"default": {
+				Name:       "botkube-events",
+				Type:       "botkube-event",
+				DataStream: true,
+				Bindings:   config.SinkBindings{Sources: []string{"k8s-err-events"}},
+			},
+		},
+	}, analytics.NewNoopReporter())
+	require.NoError(t, err)
+
+	event := fixSinkEvent(config.Error)
+
+	// when
+	err = es.SendEvent(context.Background(), event, []string{"k8s-err-events"})
+
+	// then
+	require.NoError(t, err)
+	reqs := srv.Requests()
+	require.Len(t, reqs, 1)
+	assert.Equal(t, http.MethodPost, reqs[0].Method)
+	assert.Equal(t, "/botkube-events/_doc/", reqs[0].Path)
+	assert.Equal(t, "create", reqs[0].OpType)
+
+	var doc map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(reqs[0].Body), &doc))
+	assert.Equal(t, "nginx", doc["Name"])
+	assert.Equal(t, "Pod", doc["kind"])
+	assert.Equal(t, event.TimeStamp.Format("2006-01-02T15:04:05.999999999Z07:00"), doc["@timestamp"])
+}
+
+type fakeElasticsearchRequest struct {
+	Method string
+	Path   string
+	OpType string
+	Body   string
+}
+
+type fakeElasticsearchServer struct {
+	*httptest.Server
+
+	mu   sync.Mutex
+	reqs []fakeElasticsearchRequest
+}
+
+func newFakeElasticsearchServer(t *testing.T) *fakeElasticsearchServer {
+	t.Helper()
+
+	srv := &fakeElasticsearchServer{}
+	srv.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var reader io.Reader = r.Body
+		if r.Header.Get("Content-Encoding") == "gzip" {
+			gz, err := gzip.NewReader(r.Body)
+			require.NoError(t, err)
+			reader = gz
+		}
+		body, err := io.ReadAll(reader)
+		require.NoError(t, err)
+
+		srv.mu.Lock()
+		srv.reqs = append(srv.reqs, fakeElasticsearchRequest{
+			Method: r.Method,
+			Path:   r.URL.Path,
+			OpType: r.URL.Query().Get("op_type"),
+			Body:   string(body),
+		})
+		srv.mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"acknowledged":true,"_index":"botkube-events","_id":"1","result":"created"}`))
+	}))
+	return srv
+}
+
+func (s *fakeElasticsearchServer) Requests() []fakeElasticsearchRequest {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.reqs
+}
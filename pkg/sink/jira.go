@@ -0,0 +1,352 @@
+package sink
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/kubeshop/botkube/pkg/bot/interactive"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/events"
+	"github.com/kubeshop/botkube/pkg/multierror"
+	"github.com/kubeshop/botkube/pkg/sliceutil"
+)
+
+var _ Sink = &Jira{}
+
+const (
+	defaultJiraIssueType = "Bug"
+	jiraIssuePath        = "/rest/api/2/issue"
+	jiraSearchPath       = "/rest/api/2/search"
+	// jiraFingerprintLabelPrefix marks the issues with the event fingerprint, so repeated events can find them.
+	jiraFingerprintLabelPrefix = "botkube-"
+	jiraFingerprintLength      = 16
+
+	// jiraMaxSummaryLength is the limit of the issue summary length defined by Jira.
+	jiraMaxSummaryLength = 255
+	// jiraMaxTextLength keeps the description and comments well below the Jira field limit.
+	jiraMaxTextLength = 30000
+)
+
+// Jira provides functionality to open Jira issues for error and critical events.
+// Repeated events for the same object and reason are added as comments to the issue which is still open.
+type Jira struct {
+	log      logrus.FieldLogger
+	reporter AnalyticsReporter
+	httpCli  *http.Client
+
+	url       string
+	username  string
+	apiToken  string
+	project   string
+	issueType string
+	labels    []string
+	routes    []jiraRoute
+	bindings  config.SinkBindings
+
+	// mu serializes the lookups and issue creation, so concurrent events don't open duplicated issues.
+	mu sync.Mutex
+}
+
+type jiraRoute struct {
+	config.JiraRoute
+	matcher jiraMatcher
+}
+
+// JiraIssue is the Jira issue creation payload.
+type JiraIssue struct {
+	Fields JiraIssueFields `json:"fields"`
+}
+
+// JiraIssueFields holds fields of the created issue.
+type JiraIssueFields struct {
+	Project     JiraKey   `json:"project"`
+	IssueType   JiraName  `json:"issuetype"`
+	Summary     string    `json:"summary"`
+	Description string    `json:"description"`
+	Labels      []string  `json:"labels,omitempty"`
+	Priority    *JiraName `json:"priority,omitempty"`
+}
+
+// JiraKey references a Jira object by its key.
+type JiraKey struct {
+	Key string `json:"key"`
+}
+
+// JiraName references a Jira object by its name.
+type JiraName struct {
+	Name string `json:"name"`
+}
+
+// JiraComment is the Jira comment payload.
+type JiraComment struct {
+	Body string `json:"body"`
+}
+
+type jiraSearchResult struct {
+	Issues []JiraKey `json:"issues"`
+}
+
+// NewJira creates a new Jira instance.
+func NewJira(log logrus.FieldLogger, c config.Jira, reporter AnalyticsReporter) (*Jira, error) {
+	issueType := c.IssueType
+	if issueType == "" {
+		issueType = defaultJiraIssueType
+	}
+
+	routes := make([]jiraRoute, 0, len(c.Routes))
+	for idx, route := range c.Routes {
+		matcher, err := parseJiraQuery(route.Query)
+		if err != nil {
+			return nil, fmt.Errorf("while parsing query of route %d: %w", idx, err)
+		}
+		routes = append(routes, jiraRoute{JiraRoute: route, matcher: matcher})
+	}
+
+	jira := &Jira{
+		log:       log,
+		reporter:  reporter,
+		httpCli:   &http.Client{Timeout: defaultHTTPCliTimeout},
+		url:       strings.TrimSuffix(c.URL, "/"),
+		username:  c.Username,
+		apiToken:  c.APIToken,
+		project:   c.Project,
+		issueType: issueType,
+		labels:    c.Labels,
+		routes:    routes,
+		bindings:  c.Bindings,
+	}
+
+	err := reporter.ReportSinkEnabled(jira.IntegrationName())
+	if err != nil {
+		return nil, fmt.Errorf("while reporting analytics: %w", err)
+	}
+
+	return jira, nil
+}
+
+// SendEvent opens a Jira issue for a given error event, or comments the issue already opened for the same fingerprint.
+func (j *Jira) SendEvent(ctx context.Context, event events.Event, eventSources []string) error {
+	if !sliceutil.Intersect(eventSources, j.bindings.Sources) {
+		j.log.Debugf("Event sources do not match Jira sources, event: %+v, eventSources: %+v", event, eventSources)
+		return nil
+	}
+
+	if event.Level != config.Error && event.Level != config.Critical {
+		j.log.Debugf("Skipping event with %q level as it doesn't open issues", event.Level)
+		return nil
+	}
+
+	fields, ok := j.issueFieldsFor(event)
+	if !ok {
+		j.log.Debugf("Skipping event as it doesn't match any Jira route, event: %+v", event)
+		return nil
+	}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	fingerprintLabel := jiraFingerprintLabelPrefix + jiraFingerprint(event)
+	issueKey, err := j.findOpenIssue(ctx, fields.Project.Key, fingerprintLabel)
+	if err != nil {
+		return fmt.Errorf("while searching for open Jira issue: %w", err)
+	}
+
+	if issueKey != "" {
+		err := j.do(ctx, http.MethodPost, fmt.Sprintf("%s/%s/comment", jiraIssuePath, url.PathEscape(issueKey)), JiraComment{
+			Body: truncate(jiraCommentText(event), jiraMaxTextLength),
+		}, nil)
+		if err != nil {
+			return fmt.Errorf("while commenting Jira issue %q: %w", issueKey, err)
+		}
+		j.log.Debugf("Event added as a comment to Jira issue %q: %+v", issueKey, event)
+		return nil
+	}
+
+	fields.Labels = append(fields.Labels, fingerprintLabel)
+	var created JiraKey
+	if err := j.do(ctx, http.MethodPost, jiraIssuePath, JiraIssue{Fields: fields}, &created); err != nil {
+		return fmt.Errorf("while creating Jira issue: %w", err)
+	}
+
+	j.log.Debugf("Jira issue %q created for event: %+v", created.Key, event)
+	return nil
+}
+
+// SendMessageToAll is no-op.
+func (j *Jira) SendMessageToAll(_ context.Context, _ interactive.Message) error {
+	return nil
+}
+
+// SendGenericMessage is no-op.
+func (j *Jira) SendGenericMessage(_ context.Context, _ interactive.GenericMessage, _ []string) error {
+	return nil
+}
+
+// IntegrationName describes the sink integration name.
+func (j *Jira) IntegrationName() config.CommPlatformIntegration {
+	return config.JiraCommPlatformIntegration
+}
+
+// Type describes the sink type.
+func (j *Jira) Type() config.IntegrationType {
+	return config.SinkIntegrationType
+}
+
+// issueFieldsFor returns the fields of the issue for a given event, based on the first matching route.
+// If no route matches, the default project is used. It returns false if there is no project for the event.
+func (j *Jira) issueFieldsFor(event events.Event) (JiraIssueFields, bool) {
+	fields := JiraIssueFields{
+		Project:     JiraKey{Key: j.project},
+		IssueType:   JiraName{Name: j.issueType},
+		Summary:     truncate(jiraSummary(event), jiraMaxSummaryLength),
+		Description: truncate(jiraDescription(event), jiraMaxTextLength),
+		Labels:      append([]string{}, j.labels...),
+	}
+
+	for _, route := range j.routes {
+		if !route.matcher.Match(event) {
+			continue
+		}
+
+		if route.Project != "" {
+			fields.Project.Key = route.Project
+		}
+		if route.IssueType != "" {
+			fields.IssueType.Name = route.IssueType
+		}
+		if route.Priority != "" {
+			fields.Priority = &JiraName{Name: route.Priority}
+		}
+		fields.Labels = append(fields.Labels, route.Labels...)
+		break
+	}
+
+	return fields, fields.Project.Key != ""
+}
+
+func (j *Jira) findOpenIssue(ctx context.Context, project, fingerprintLabel string) (string, error) {
+	query := url.Values{}
+	query.Set("jql", fmt.Sprintf(`project = %q AND labels = %q AND statusCategory != Done ORDER BY created DESC`, project, fingerprintLabel))
+	query.Set("maxResults", "1")
+	query.Set("fields", "key")
+
+	var out jiraSearchResult
+	if err := j.do(ctx, http.MethodGet, jiraSearchPath+"?"+query.Encode(), nil, &out); err != nil {
+		return "", err
+	}
+	if len(out.Issues) == 0 {
+		return "", nil
+	}
+	return out.Issues[0].Key, nil
+}
+
+func (j *Jira) do(ctx context.Context, method, path string, in, out interface{}) (err error) {
+	var body io.Reader
+	if in != nil {
+		raw, err := json.Marshal(in)
+		if err != nil {
+			return fmt.Errorf("while marshaling request: %w", err)
+		}
+		body = bytes.NewReader(raw)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, j.url+path, body)
+	if err != nil {
+		return fmt.Errorf("while creating request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if j.username != "" {
+		req.SetBasicAuth(j.username, j.apiToken)
+	} else {
+		req.Header.Set("Authorization", "Bearer "+j.apiToken)
+	}
+
+	resp, err := j.httpCli.Do(req)
+	if err != nil {
+		return fmt.Errorf("while sending request: %w", err)
+	}
+	defer func() {
+		deferredErr := resp.Body.Close()
+		if deferredErr != nil {
+			err = multierror.Append(err, deferredErr)
+		}
+	}()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		raw, _ := io.ReadAll(resp.Body)
+		return newUnexpectedStatusError(resp.StatusCode, raw)
+	}
+
+	if out == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("while decoding response: %w", err)
+	}
+	return nil
+}
+
+// jiraFingerprint identifies repeated events for the same object and reason.
+func jiraFingerprint(event events.Event) string {
+	sum := sha256.Sum256([]byte(strings.Join([]string{event.Cluster, event.Namespace, event.Kind, event.Name, event.Reason}, "/")))
+	return hex.EncodeToString(sum[:])[:jiraFingerprintLength]
+}
+
+func jiraSummary(event events.Event) string {
+	object := event.Name
+	if event.Namespace != "" {
+		object = event.Namespace + "/" + event.Name
+	}
+
+	summary := fmt.Sprintf("%s %s", event.Kind, object)
+	if event.Reason != "" {
+		summary = fmt.Sprintf("%s: %s", event.Reason, summary)
+	}
+	if event.Cluster != "" {
+		summary = fmt.Sprintf("[%s] %s", event.Cluster, summary)
+	}
+	return summary
+}
+
+func jiraDescription(event events.Event) string {
+	lines := []string{
+		fmt.Sprintf("*Cluster:* %s", event.Cluster),
+		fmt.Sprintf("*Namespace:* %s", event.Namespace),
+		fmt.Sprintf("*Kind:* %s", event.Kind),
+		fmt.Sprintf("*Name:* %s", event.Name),
+		fmt.Sprintf("*Reason:* %s", event.Reason),
+		fmt.Sprintf("*Level:* %s", event.Level),
+	}
+	if details := eventDetailsText(event); details != "" {
+		lines = append(lines, "", "{noformat}", details, "{noformat}")
+	}
+	return strings.Join(lines, "\n")
+}
+
+func jiraCommentText(event events.Event) string {
+	header := "The event occurred again"
+	if !event.TimeStamp.IsZero() {
+		header = fmt.Sprintf("The event occurred again at %s", event.TimeStamp.UTC().Format(time.RFC3339))
+	}
+
+	details := eventDetailsText(event)
+	if details == "" {
+		return header + "."
+	}
+	return fmt.Sprintf("%s:\n{noformat}\n%s\n{noformat}", header, details)
+}
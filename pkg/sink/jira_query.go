@@ -0,0 +1,324 @@
+package sink
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/kubeshop/botkube/pkg/events"
+)
+
+// jiraQueryFields returns the event values matched by the JQL-style queries.
+var jiraQueryFields = map[string]func(event events.Event) []string{
+	"cluster":   func(e events.Event) []string { return []string{e.Cluster} },
+	"namespace": func(e events.Event) []string { return []string{e.Namespace} },
+	"kind":      func(e events.Event) []string { return []string{e.Kind} },
+	"name":      func(e events.Event) []string { return []string{e.Name} },
+	"reason":    func(e events.Event) []string { return []string{e.Reason} },
+	"type":      func(e events.Event) []string { return []string{string(e.Type)} },
+	"level":     func(e events.Event) []string { return []string{string(e.Level)} },
+	"message":   func(e events.Event) []string { return e.Messages },
+}
+
+// jiraMatcher matches events against a parsed query.
+type jiraMatcher interface {
+	Match(event events.Event) bool
+}
+
+type jiraOrMatcher []jiraMatcher
+
+func (m jiraOrMatcher) Match(event events.Event) bool {
+	for _, item := range m {
+		if item.Match(event) {
+			return true
+		}
+	}
+	return false
+}
+
+type jiraAndMatcher []jiraMatcher
+
+func (m jiraAndMatcher) Match(event events.Event) bool {
+	for _, item := range m {
+		if !item.Match(event) {
+			return false
+		}
+	}
+	return true
+}
+
+type jiraNotMatcher struct {
+	matcher jiraMatcher
+}
+
+func (m jiraNotMatcher) Match(event events.Event) bool {
+	return !m.matcher.Match(event)
+}
+
+// jiraCondition compares a given event field with values.
+// The `=` and `in` operators compare values exactly, while `~` checks if the field contains the value, ignoring case.
+type jiraCondition struct {
+	field   string
+	op      string
+	values  []string
+	negated bool
+}
+
+func (c jiraCondition) Match(event events.Event) bool {
+	matched := false
+	for _, got := range jiraQueryFields[c.field](event) {
+		for _, want := range c.values {
+			if c.op == "~" {
+				matched = strings.Contains(strings.ToLower(got), strings.ToLower(want))
+			} else {
+				matched = got == want
+			}
+			if matched {
+				break
+			}
+		}
+		if matched {
+			break
+		}
+	}
+	return matched != c.negated
+}
+
+// parseJiraQuery parses a JQL-style query, such as `namespace = prod AND (kind = Pod OR reason ~ "oom")`.
+// Supported operators are `=`, `!=`, `~`, `!~`, `in` and `not in`, combined with `AND`, `OR`, `NOT` and parentheses.
+func parseJiraQuery(query string) (jiraMatcher, error) {
+	tokens, err := tokenizeJiraQuery(query)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("query cannot be empty")
+	}
+
+	p := &jiraQueryParser{tokens: tokens}
+	matcher, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if tok, ok := p.peek(); ok {
+		return nil, fmt.Errorf("unexpected %q", tok.val)
+	}
+	return matcher, nil
+}
+
+type jiraToken struct {
+	val    string
+	quoted bool
+}
+
+func tokenizeJiraQuery(query string) ([]jiraToken, error) {
+	var (
+		tokens []jiraToken
+		runes  = []rune(query)
+	)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case strings.ContainsRune("(),=~", r):
+			tokens = append(tokens, jiraToken{val: string(r)})
+			i++
+		case r == '!':
+			if i+1 >= len(runes) || (runes[i+1] != '=' && runes[i+1] != '~') {
+				return nil, fmt.Errorf("unexpected %q at position %d", r, i)
+			}
+			tokens = append(tokens, jiraToken{val: string(runes[i : i+2])})
+			i += 2
+		case r == '"' || r == '\'':
+			end := i + 1
+			for end < len(runes) && runes[end] != r {
+				end++
+			}
+			if end >= len(runes) {
+				return nil, fmt.Errorf("unterminated string at position %d", i)
+			}
+			tokens = append(tokens, jiraToken{val: string(runes[i+1 : end]), quoted: true})
+			i = end + 1
+		default:
+			end := i
+			for end < len(runes) && !unicode.IsSpace(runes[end]) && !strings.ContainsRune("(),=~!\"'", runes[end]) {
+				end++
+			}
+			tokens = append(tokens, jiraToken{val: string(runes[i:end])})
+			i = end
+		}
+	}
+	return tokens, nil
+}
+
+type jiraQueryParser struct {
+	tokens []jiraToken
+	pos    int
+}
+
+func (p *jiraQueryParser) parseOr() (jiraMatcher, error) {
+	var out jiraOrMatcher
+	for {
+		matcher, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, matcher)
+		if !p.consumeKeyword("or") {
+			break
+		}
+	}
+	if len(out) == 1 {
+		return out[0], nil
+	}
+	return out, nil
+}
+
+func (p *jiraQueryParser) parseAnd() (jiraMatcher, error) {
+	var out jiraAndMatcher
+	for {
+		matcher, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, matcher)
+		if !p.consumeKeyword("and") {
+			break
+		}
+	}
+	if len(out) == 1 {
+		return out[0], nil
+	}
+	return out, nil
+}
+
+func (p *jiraQueryParser) parseUnary() (jiraMatcher, error) {
+	if p.consumeKeyword("not") {
+		matcher, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return jiraNotMatcher{matcher: matcher}, nil
+	}
+
+	if p.consumeSymbol("(") {
+		matcher, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if !p.consumeSymbol(")") {
+			return nil, fmt.Errorf("missing closing parenthesis")
+		}
+		return matcher, nil
+	}
+
+	return p.parseCondition()
+}
+
+func (p *jiraQueryParser) parseCondition() (jiraMatcher, error) {
+	tok, ok := p.next()
+	if !ok {
+		return nil, fmt.Errorf("unexpected end of query")
+	}
+	field := strings.ToLower(tok.val)
+	if _, found := jiraQueryFields[field]; tok.quoted || !found {
+		return nil, fmt.Errorf("unknown field %q", tok.val)
+	}
+
+	cond := jiraCondition{field: field}
+	switch {
+	case p.consumeSymbol("="):
+		cond.op = "="
+	case p.consumeSymbol("!="):
+		cond.op, cond.negated = "=", true
+	case p.consumeSymbol("~"):
+		cond.op = "~"
+	case p.consumeSymbol("!~"):
+		cond.op, cond.negated = "~", true
+	case p.consumeKeyword("in"):
+		cond.op = "="
+	case p.consumeKeyword("not"):
+		if !p.consumeKeyword("in") {
+			return nil, fmt.Errorf("expected \"in\" after \"not\" for field %q", field)
+		}
+		cond.op, cond.negated = "=", true
+	default:
+		return nil, fmt.Errorf("missing operator for field %q", field)
+	}
+
+	if cond.op == "=" && p.consumeSymbol("(") {
+		values, err := p.parseList()
+		if err != nil {
+			return nil, fmt.Errorf("while parsing values for field %q: %w", field, err)
+		}
+		cond.values = values
+		return cond, nil
+	}
+
+	val, ok := p.next()
+	if !ok || (!val.quoted && isJiraSymbol(val.val)) {
+		return nil, fmt.Errorf("missing value for field %q", field)
+	}
+	cond.values = []string{val.val}
+	return cond, nil
+}
+
+func (p *jiraQueryParser) parseList() ([]string, error) {
+	var out []string
+	for {
+		val, ok := p.next()
+		if !ok || (!val.quoted && isJiraSymbol(val.val)) {
+			return nil, fmt.Errorf("missing value")
+		}
+		out = append(out, val.val)
+
+		if p.consumeSymbol(")") {
+			return out, nil
+		}
+		if !p.consumeSymbol(",") {
+			return nil, fmt.Errorf("expected \",\" or \")\"")
+		}
+	}
+}
+
+func (p *jiraQueryParser) peek() (jiraToken, bool) {
+	if p.pos >= len(p.tokens) {
+		return jiraToken{}, false
+	}
+	return p.tokens[p.pos], true
+}
+
+func (p *jiraQueryParser) next() (jiraToken, bool) {
+	tok, ok := p.peek()
+	if ok {
+		p.pos++
+	}
+	return tok, ok
+}
+
+func (p *jiraQueryParser) consumeSymbol(symbol string) bool {
+	tok, ok := p.peek()
+	if !ok || tok.quoted || tok.val != symbol {
+		return false
+	}
+	p.pos++
+	return true
+}
+
+func (p *jiraQueryParser) consumeKeyword(keyword string) bool {
+	tok, ok := p.peek()
+	if !ok || tok.quoted || !strings.EqualFold(tok.val, keyword) {
+		return false
+	}
+	p.pos++
+	return true
+}
+
+func isJiraSymbol(in string) bool {
+	switch in {
+	case "(", ")", ",", "=", "~", "!=", "!~":
+		return true
+	}
+	return false
+}
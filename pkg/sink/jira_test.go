@@ -0,0 +1,228 @@
+package sink
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	logtest "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/internal/analytics"
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+func TestJira_SendEventDeduplicatesIssues(t *testing.T) {
+	// given
+	jiraSrv := newFakeJiraServer(t)
+	ts := httptest.NewServer(jiraSrv)
+	defer ts.Close()
+
+	jira := newTestJira(t, config.Jira{
+		URL:      ts.URL,
+		Username: "bot@example.com",
+		APIToken: "token",
+		Project:  "OPS",
+		Labels:   []string{"kubernetes"},
+		Routes: []config.JiraRoute{
+			{
+				Query:    `namespace = prod AND reason in (BackOff, OOMKilled)`,
+				Project:  "PROD",
+				Priority: "High",
+				Labels:   []string{"prod"},
+			},
+		},
+		Bindings: config.SinkBindings{Sources: []string{"k8s-err-events"}},
+	})
+
+	event := fixSinkEvent(config.Error)
+
+	// when
+	for i := 0; i < 3; i++ {
+		err := jira.SendEvent(context.Background(), event, []string{"k8s-err-events"})
+		require.NoError(t, err)
+	}
+
+	// then
+	require.Len(t, jiraSrv.issues, 1)
+	issue := jiraSrv.issues[0]
+	assert.Equal(t, "OPS", issue.Project.Key)
+	assert.Equal(t, "Bug", issue.IssueType.Name)
+	assert.Nil(t, issue.Priority)
+	assert.Equal(t, "[dev] BackOff: Pod default/nginx", issue.Summary)
+	assert.Contains(t, issue.Description, "Back-off restarting failed container")
+	assert.Equal(t, []string{"kubernetes", jiraFingerprintLabelPrefix + jiraFingerprint(event)}, issue.Labels)
+
+	require.Len(t, jiraSrv.comments["OPS-1"], 2)
+	assert.Contains(t, jiraSrv.comments["OPS-1"][0], "The event occurred again at")
+}
+
+func TestJira_SendEventUsesMatchingRoute(t *testing.T) {
+	// given
+	jiraSrv := newFakeJiraServer(t)
+	ts := httptest.NewServer(jiraSrv)
+	defer ts.Close()
+
+	jira := newTestJira(t, config.Jira{
+		URL:      ts.URL,
+		APIToken: "token",
+		Routes: []config.JiraRoute{
+			{Query: `namespace = prod`, Project: "PROD"},
+			{Query: `kind = Pod AND message ~ "back-off"`, Project: "APPS", IssueType: "Incident", Priority: "High", Labels: []string{"pods"}},
+		},
+		Bindings: config.SinkBindings{Sources: []string{"k8s-err-events"}},
+	})
+
+	// when
+	err := jira.SendEvent(context.Background(), fixSinkEvent(config.Critical), []string{"k8s-err-events"})
+	require.NoError(t, err)
+
+	otherEvent := fixSinkEvent(config.Error)
+	otherEvent.Kind = "Deployment"
+	err = jira.SendEvent(context.Background(), otherEvent, []string{"k8s-err-events"})
+	require.NoError(t, err)
+
+	// then
+	require.Len(t, jiraSrv.issues, 1)
+	issue := jiraSrv.issues[0]
+	assert.Equal(t, "APPS", issue.Project.Key)
+	assert.Equal(t, "Incident", issue.IssueType.Name)
+	assert.Equal(t, &JiraName{Name: "High"}, issue.Priority)
+	assert.Equal(t, "pods", issue.Labels[0])
+}
+
+func TestJira_SendEventSkipsNonErrorEvents(t *testing.T) {
+	// given
+	jiraSrv := newFakeJiraServer(t)
+	ts := httptest.NewServer(jiraSrv)
+	defer ts.Close()
+
+	jira := newTestJira(t, config.Jira{
+		URL:      ts.URL,
+		APIToken: "token",
+		Project:  "OPS",
+		Bindings: config.SinkBindings{Sources: []string{"k8s-err-events"}},
+	})
+
+	// when
+	err := jira.SendEvent(context.Background(), fixSinkEvent(config.Warn), []string{"k8s-err-events"})
+	require.NoError(t, err)
+	err = jira.SendEvent(context.Background(), fixSinkEvent(config.Error), []string{"k8s-create-events"})
+	require.NoError(t, err)
+
+	// then
+	assert.Empty(t, jiraSrv.issues)
+}
+
+func TestParseJiraQuery(t *testing.T) {
+	event := fixSinkEvent(config.Error)
+
+	tests := []struct {
+		name     string
+		query    string
+		expMatch bool
+		expErr   string
+	}{
+		{name: "Equal", query: `namespace = default`, expMatch: true},
+		{name: "Not equal", query: `namespace != default`, expMatch: false},
+		{name: "Quoted value", query: `message = "Back-off restarting failed container"`, expMatch: true},
+		{name: "Contains ignoring case", query: `message ~ 'BACK-OFF'`, expMatch: true},
+		{name: "Not contains", query: `message !~ oom`, expMatch: true},
+		{name: "In", query: `reason in (OOMKilled, BackOff)`, expMatch: true},
+		{name: "Not in", query: `reason NOT IN (OOMKilled, BackOff)`, expMatch: false},
+		{name: "And", query: `cluster = dev and kind = Pod and name = nginx`, expMatch: true},
+		{name: "Or", query: `namespace = prod OR level = error`, expMatch: true},
+		{name: "And before or", query: `namespace = prod AND kind = Pod OR type = error`, expMatch: true},
+		{name: "Parentheses", query: `namespace = prod AND (kind = Pod OR type = error)`, expMatch: false},
+		{name: "Not", query: `NOT namespace = prod`, expMatch: true},
+		{name: "Empty", query: `  `, expErr: "query cannot be empty"},
+		{name: "Unknown field", query: `owner = team-a`, expErr: `unknown field "owner"`},
+		{name: "Missing operator", query: `namespace default`, expErr: `missing operator for field "namespace"`},
+		{name: "Missing value", query: `namespace =`, expErr: `missing value for field "namespace"`},
+		{name: "Unclosed list", query: `reason in (BackOff`, expErr: `while parsing values for field "reason": expected "," or ")"`},
+		{name: "Unclosed parenthesis", query: `(namespace = prod`, expErr: "missing closing parenthesis"},
+		{name: "Unterminated string", query: `name = "nginx`, expErr: "unterminated string at position 7"},
+		{name: "Trailing tokens", query: `namespace = prod kind = Pod`, expErr: `unexpected "kind"`},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// when
+			matcher, err := parseJiraQuery(tc.query)
+
+			// then
+			if tc.expErr != "" {
+				assert.EqualError(t, err, tc.expErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expMatch, matcher.Match(event))
+		})
+	}
+}
+
+func newTestJira(t *testing.T, cfg config.Jira) *Jira {
+	t.Helper()
+
+	logger, _ := logtest.NewNullLogger()
+	jira, err := NewJira(logger, cfg, analytics.NewNoopReporter())
+	require.NoError(t, err)
+	return jira
+}
+
+// fakeJiraServer stores created issues and comments, and finds the open issues by their labels.
+type fakeJiraServer struct {
+	t        *testing.T
+	mu       sync.Mutex
+	issues   []JiraIssueFields
+	comments map[string][]string
+}
+
+func newFakeJiraServer(t *testing.T) *fakeJiraServer {
+	return &fakeJiraServer{t: t, comments: map[string][]string{}}
+}
+
+func (s *fakeJiraServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if user, _, ok := r.BasicAuth(); !ok || user == "" {
+		assert.Equal(s.t, "Bearer token", r.Header.Get("Authorization"))
+	}
+
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == jiraSearchPath:
+		jql := r.URL.Query().Get("jql")
+		var out jiraSearchResult
+		for idx, issue := range s.issues {
+			label := issue.Labels[len(issue.Labels)-1]
+			if strings.Contains(jql, `labels = "`+label+`"`) && strings.Contains(jql, `project = "`+issue.Project.Key+`"`) {
+				out.Issues = append(out.Issues, JiraKey{Key: s.issueKey(idx)})
+			}
+		}
+		require.NoError(s.t, json.NewEncoder(w).Encode(out))
+	case r.Method == http.MethodPost && r.URL.Path == jiraIssuePath:
+		var in JiraIssue
+		require.NoError(s.t, json.NewDecoder(r.Body).Decode(&in))
+		s.issues = append(s.issues, in.Fields)
+		w.WriteHeader(http.StatusCreated)
+		require.NoError(s.t, json.NewEncoder(w).Encode(JiraKey{Key: s.issueKey(len(s.issues) - 1)}))
+	case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/comment"):
+		var in JiraComment
+		require.NoError(s.t, json.NewDecoder(r.Body).Decode(&in))
+		key := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, jiraIssuePath+"/"), "/comment")
+		s.comments[key] = append(s.comments[key], in.Body)
+		w.WriteHeader(http.StatusCreated)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func (s *fakeJiraServer) issueKey(idx int) string {
+	return s.issues[idx].Project.Key + "-" + strconv.Itoa(idx+1)
+}
@@ -0,0 +1,269 @@
+package sink
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/kubeshop/botkube/pkg/bot/interactive"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/events"
+	"github.com/kubeshop/botkube/pkg/multierror"
+	"github.com/kubeshop/botkube/pkg/sliceutil"
+)
+
+const (
+	kafkaJSONContentType = "application/vnd.kafka.json.v2+json"
+	kafkaAvroContentType = "application/vnd.kafka.avro.v2+json"
+	kafkaAcceptType      = "application/vnd.kafka.v2+json"
+
+	kafkaAvroKeySchema = `{"type":"string"}`
+	// kafkaAvroValueSchema describes the KafkaEvent record.
+	kafkaAvroValueSchema = `{
+  "type": "record",
+  "name": "Event",
+  "namespace": "io.botkube",
+  "fields": [
+    {"name": "apiVersion", "type": "string"},
+    {"name": "kind", "type": "string"},
+    {"name": "name", "type": "string"},
+    {"name": "namespace", "type": "string"},
+    {"name": "cluster", "type": "string"},
+    {"name": "type", "type": "string"},
+    {"name": "level", "type": "string"},
+    {"name": "reason", "type": "string"},
+    {"name": "error", "type": "string"},
+    {"name": "messages", "type": {"type": "array", "items": "string"}},
+    {"name": "recommendations", "type": {"type": "array", "items": "string"}},
+    {"name": "warnings", "type": {"type": "array", "items": "string"}},
+    {"name": "timestamp", "type": {"type": "long", "logicalType": "timestamp-millis"}}
+  ]
+}`
+)
+
+// Kafka provides functionality to publish events to a Kafka topic via the Confluent REST Proxy.
+type Kafka struct {
+	log      logrus.FieldLogger
+	reporter AnalyticsReporter
+	httpCli  *http.Client
+
+	url           string
+	format        config.KafkaFormat
+	valueSchemaID int
+	username      string
+	password      string
+	bindings      config.SinkBindings
+}
+
+// KafkaEvent is the record value published to Kafka.
+type KafkaEvent struct {
+	APIVersion      string   `json:"apiVersion"`
+	Kind            string   `json:"kind"`
+	Name            string   `json:"name"`
+	Namespace       string   `json:"namespace"`
+	Owner           string   `json:"owner,omitempty"`
+	Cluster         string   `json:"cluster"`
+	Type            string   `json:"type"`
+	Level           string   `json:"level"`
+	Reason          string   `json:"reason"`
+	Error           string   `json:"error"`
+	Messages        []string `json:"messages"`
+	Recommendations []string `json:"recommendations"`
+	Warnings        []string `json:"warnings"`
+	// Timestamp is the event time in Unix milliseconds.
+	Timestamp int64 `json:"timestamp"`
+}
+
+// KafkaRecords is the REST Proxy produce request payload.
+type KafkaRecords struct {
+	KeySchema     string        `json:"key_schema,omitempty"`
+	ValueSchema   string        `json:"value_schema,omitempty"`
+	ValueSchemaID int           `json:"value_schema_id,omitempty"`
+	Records       []KafkaRecord `json:"records"`
+}
+
+// KafkaRecord is a single record published to Kafka.
+type KafkaRecord struct {
+	Key   string     `json:"key"`
+	Value KafkaEvent `json:"value"`
+}
+
+type kafkaProduceResponse struct {
+	Offsets []struct {
+		Partition int    `json:"partition"`
+		Offset    int64  `json:"offset"`
+		ErrorCode *int   `json:"error_code"`
+		Error     string `json:"error"`
+	} `json:"offsets"`
+}
+
+// NewKafka creates a new Kafka instance.
+func NewKafka(log logrus.FieldLogger, c config.Kafka, reporter AnalyticsReporter) (*Kafka, error) {
+	format := c.Format
+	if format == "" {
+		format = config.KafkaJSONFormat
+	}
+
+	k := &Kafka{
+		log:           log,
+		reporter:      reporter,
+		httpCli:       &http.Client{Timeout: defaultHTTPCliTimeout},
+		url:           fmt.Sprintf("%s/topics/%s", strings.TrimSuffix(c.URL, "/"), url.PathEscape(c.Topic)),
+		format:        format,
+		valueSchemaID: c.ValueSchemaID,
+		username:      c.Username,
+		password:      c.Password,
+		bindings:      c.Bindings,
+	}
+
+	err := reporter.ReportSinkEnabled(k.IntegrationName())
+	if err != nil {
+		return nil, fmt.Errorf("while reporting analytics: %w", err)
+	}
+
+	return k, nil
+}
+
+// SendEvent publishes a given event to the Kafka topic.
+func (k *Kafka) SendEvent(ctx context.Context, event events.Event, eventSources []string) error {
+	if !sliceutil.Intersect(eventSources, k.bindings.Sources) {
+		k.log.Debugf("Event sources do not match Kafka sources, event: %+v, eventSources: %+v", event, eventSources)
+		return nil
+	}
+
+	records := KafkaRecords{
+		Records: []KafkaRecord{
+			{
+				Key:   kafkaPartitionKey(event),
+				Value: kafkaEventFrom(event),
+			},
+		},
+	}
+	if k.format == config.KafkaAvroFormat {
+		records.KeySchema = kafkaAvroKeySchema
+		if k.valueSchemaID > 0 {
+			records.ValueSchemaID = k.valueSchemaID
+		} else {
+			records.ValueSchema = kafkaAvroValueSchema
+		}
+	}
+
+	err := k.produce(ctx, records)
+	if err != nil {
+		return fmt.Errorf("while publishing event to Kafka: %w", err)
+	}
+
+	k.log.Debugf("Event successfully sent to Kafka: %+v", event)
+	return nil
+}
+
+// SendMessageToAll is no-op.
+func (k *Kafka) SendMessageToAll(_ context.Context, _ interactive.Message) error {
+	return nil
+}
+
+// SendGenericMessage is no-op.
+func (k *Kafka) SendGenericMessage(_ context.Context, _ interactive.GenericMessage, _ []string) error {
+	return nil
+}
+
+// IntegrationName describes the sink integration name.
+func (k *Kafka) IntegrationName() config.CommPlatformIntegration {
+	return config.KafkaCommPlatformIntegration
+}
+
+// Type describes the sink type.
+func (k *Kafka) Type() config.IntegrationType {
+	return config.SinkIntegrationType
+}
+
+func (k *Kafka) produce(ctx context.Context, records KafkaRecords) (err error) {
+	body, err := json.Marshal(records)
+	if err != nil {
+		return fmt.Errorf("while marshaling records: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, k.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("while creating request: %w", err)
+	}
+	req.Header.Set("Content-Type", k.contentType())
+	req.Header.Set("Accept", kafkaAcceptType)
+	if k.username != "" {
+		req.SetBasicAuth(k.username, k.password)
+	}
+
+	resp, err := k.httpCli.Do(req)
+	if err != nil {
+		return fmt.Errorf("while sending request: %w", err)
+	}
+	defer func() {
+		deferredErr := resp.Body.Close()
+		if deferredErr != nil {
+			err = multierror.Append(err, deferredErr)
+		}
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		raw, _ := io.ReadAll(resp.Body)
+		return newUnexpectedStatusError(resp.StatusCode, raw)
+	}
+
+	// REST Proxy reports failures of the individual records in the response body
+	var out kafkaProduceResponse
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return fmt.Errorf("while decoding response: %w", err)
+	}
+	for _, offset := range out.Offsets {
+		if offset.ErrorCode != nil {
+			return fmt.Errorf("got error code %d: %s", *offset.ErrorCode, offset.Error)
+		}
+	}
+	return nil
+}
+
+func (k *Kafka) contentType() string {
+	if k.format == config.KafkaAvroFormat {
+		return kafkaAvroContentType
+	}
+	return kafkaJSONContentType
+}
+
+// kafkaPartitionKey returns the record key. Events from the same namespace land in the same partition, so their order is preserved.
+func kafkaPartitionKey(event events.Event) string {
+	return event.Cluster + "/" + event.Namespace
+}
+
+func kafkaEventFrom(event events.Event) KafkaEvent {
+	// Avro arrays are not nullable
+	nonNil := func(in []string) []string {
+		if in == nil {
+			return []string{}
+		}
+		return in
+	}
+
+	return KafkaEvent{
+		APIVersion:      event.APIVersion,
+		Kind:            event.Kind,
+		Name:            event.Name,
+		Namespace:       event.Namespace,
+		Owner:           event.OwnerString(),
+		Cluster:         event.Cluster,
+		Type:            string(event.Type),
+		Level:           string(event.Level),
+		Reason:          event.Reason,
+		Error:           event.Error,
+		Messages:        nonNil(event.Messages),
+		Recommendations: nonNil(event.Recommendations),
+		Warnings:        nonNil(event.Warnings),
+		Timestamp:       event.TimeStamp.UnixMilli(),
+	}
+}
@@ -0,0 +1,118 @@
+package sink
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	logtest "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/internal/analytics"
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+func TestKafka_SendEvent(t *testing.T) {
+	tests := map[string]struct {
+		cfg config.Kafka
+
+		expContentType   string
+		expKeySchema     string
+		expValueSchema   string
+		expValueSchemaID int
+	}{
+		"JSON format by default": {
+			cfg:            config.Kafka{},
+			expContentType: kafkaJSONContentType,
+		},
+		"Avro format with built-in schema": {
+			cfg:            config.Kafka{Format: config.KafkaAvroFormat},
+			expContentType: kafkaAvroContentType,
+			expKeySchema:   kafkaAvroKeySchema,
+			expValueSchema: kafkaAvroValueSchema,
+		},
+		"Avro format with registered schema": {
+			cfg:              config.Kafka{Format: config.KafkaAvroFormat, ValueSchemaID: 42},
+			expContentType:   kafkaAvroContentType,
+			expKeySchema:     kafkaAvroKeySchema,
+			expValueSchemaID: 42,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given
+			var (
+				gotRecords     KafkaRecords
+				gotContentType string
+			)
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/topics/botkube-events", r.URL.Path)
+				gotContentType = r.Header.Get("Content-Type")
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&gotRecords))
+				_, _ = w.Write([]byte(`{"offsets":[{"partition":1,"offset":10}]}`))
+			}))
+			defer ts.Close()
+
+			tc.cfg.URL = ts.URL
+			tc.cfg.Topic = "botkube-events"
+			tc.cfg.Bindings = config.SinkBindings{Sources: []string{"k8s-all-events"}}
+
+			logger, _ := logtest.NewNullLogger()
+			kafka, err := NewKafka(logger, tc.cfg, analytics.NewNoopReporter())
+			require.NoError(t, err)
+
+			event := fixSinkEvent(config.Error)
+
+			// when
+			err = kafka.SendEvent(context.Background(), event, []string{"k8s-all-events"})
+
+			// then
+			require.NoError(t, err)
+			assert.Equal(t, tc.expContentType, gotContentType)
+			assert.Equal(t, tc.expKeySchema, gotRecords.KeySchema)
+			assert.Equal(t, tc.expValueSchema, gotRecords.ValueSchema)
+			assert.Equal(t, tc.expValueSchemaID, gotRecords.ValueSchemaID)
+			require.Len(t, gotRecords.Records, 1)
+			assert.Equal(t, "dev/default", gotRecords.Records[0].Key)
+			assert.Equal(t, KafkaEvent{
+				APIVersion:      "v1",
+				Kind:            "Pod",
+				Name:            "nginx",
+				Namespace:       "default",
+				Cluster:         "dev",
+				Type:            "error",
+				Level:           "error",
+				Reason:          "BackOff",
+				Messages:        []string{"Back-off restarting failed container"},
+				Recommendations: []string{},
+				Warnings:        []string{},
+				Timestamp:       event.TimeStamp.UnixMilli(),
+			}, gotRecords.Records[0].Value)
+		})
+	}
+}
+
+func TestKafka_SendEventFailure(t *testing.T) {
+	// given
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"offsets":[{"partition":null,"offset":null,"error_code":40403,"error":"Schema not found"}]}`))
+	}))
+	defer ts.Close()
+
+	logger, _ := logtest.NewNullLogger()
+	kafka, err := NewKafka(logger, config.Kafka{
+		URL:      ts.URL,
+		Topic:    "botkube-events",
+		Bindings: config.SinkBindings{Sources: []string{"k8s-all-events"}},
+	}, analytics.NewNoopReporter())
+	require.NoError(t, err)
+
+	// when
+	err = kafka.SendEvent(context.Background(), fixSinkEvent(config.Info), []string{"k8s-all-events"})
+
+	// then
+	assert.EqualError(t, err, "while publishing event to Kafka: got error code 40403: Schema not found")
+}
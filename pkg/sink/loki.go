@@ -0,0 +1,188 @@
+package sink
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/kubeshop/botkube/pkg/bot/interactive"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/events"
+	"github.com/kubeshop/botkube/pkg/multierror"
+	"github.com/kubeshop/botkube/pkg/sliceutil"
+)
+
+var _ Sink = &Loki{}
+
+const (
+	lokiPushPath       = "/loki/api/v1/push"
+	lokiTenantIDHeader = "X-Scope-OrgID"
+)
+
+// Loki provides functionality to push events as structured log lines to Grafana Loki.
+type Loki struct {
+	log      logrus.FieldLogger
+	reporter AnalyticsReporter
+	httpCli  *http.Client
+
+	url      string
+	tenantID string
+	username string
+	password string
+	labels   map[string]string
+	bindings config.SinkBindings
+}
+
+// LokiPushRequest is the Loki push API payload.
+type LokiPushRequest struct {
+	Streams []LokiStream `json:"streams"`
+}
+
+// LokiStream holds log lines with a given set of labels.
+type LokiStream struct {
+	Stream map[string]string `json:"stream"`
+	// Values are pairs of the Unix epoch in nanoseconds and the log line.
+	Values [][2]string `json:"values"`
+}
+
+// NewLoki creates a new Loki instance.
+func NewLoki(log logrus.FieldLogger, c config.Loki, reporter AnalyticsReporter) (*Loki, error) {
+	l := &Loki{
+		log:      log,
+		reporter: reporter,
+		httpCli:  &http.Client{Timeout: defaultHTTPCliTimeout},
+		url:      strings.TrimSuffix(c.URL, "/") + lokiPushPath,
+		tenantID: c.TenantID,
+		username: c.Username,
+		password: c.Password,
+		labels:   c.Labels,
+		bindings: c.Bindings,
+	}
+
+	err := reporter.ReportSinkEnabled(l.IntegrationName())
+	if err != nil {
+		return nil, fmt.Errorf("while reporting analytics: %w", err)
+	}
+
+	return l, nil
+}
+
+// SendEvent pushes a given event to Loki.
+func (l *Loki) SendEvent(ctx context.Context, event events.Event, eventSources []string) error {
+	if !sliceutil.Intersect(eventSources, l.bindings.Sources) {
+		l.log.Debugf("Event sources do not match Loki sources, event: %+v, eventSources: %+v", event, eventSources)
+		return nil
+	}
+
+	line, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("while marshaling event: %w", err)
+	}
+
+	ts := event.TimeStamp
+	if ts.IsZero() {
+		ts = time.Now()
+	}
+
+	err = l.push(ctx, LokiPushRequest{
+		Streams: []LokiStream{
+			{
+				Stream: l.streamLabels(event),
+				Values: [][2]string{{strconv.FormatInt(ts.UnixNano(), 10), string(line)}},
+			},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("while pushing event to Loki: %w", err)
+	}
+
+	l.log.Debugf("Event successfully sent to Loki: %+v", event)
+	return nil
+}
+
+// SendMessageToAll is no-op.
+func (l *Loki) SendMessageToAll(_ context.Context, _ interactive.Message) error {
+	return nil
+}
+
+// SendGenericMessage is no-op.
+func (l *Loki) SendGenericMessage(_ context.Context, _ interactive.GenericMessage, _ []string) error {
+	return nil
+}
+
+// IntegrationName describes the sink integration name.
+func (l *Loki) IntegrationName() config.CommPlatformIntegration {
+	return config.LokiCommPlatformIntegration
+}
+
+// Type describes the sink type.
+func (l *Loki) Type() config.IntegrationType {
+	return config.SinkIntegrationType
+}
+
+// streamLabels returns the configured labels together with the ones generated from the event.
+// Labels with high cardinality, such as the resource name, are kept in the log line only.
+func (l *Loki) streamLabels(event events.Event) map[string]string {
+	labels := map[string]string{}
+	for key, val := range l.labels {
+		labels[key] = val
+	}
+
+	generated := map[string]string{
+		"cluster":   event.Cluster,
+		"namespace": event.Namespace,
+		"level":     string(event.Level),
+	}
+	for key, val := range generated {
+		// Loki drops labels with empty values
+		if val == "" {
+			continue
+		}
+		labels[key] = val
+	}
+	return labels
+}
+
+func (l *Loki) push(ctx context.Context, in LokiPushRequest) (err error) {
+	body, err := json.Marshal(in)
+	if err != nil {
+		return fmt.Errorf("while marshaling request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, l.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("while creating request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if l.tenantID != "" {
+		req.Header.Set(lokiTenantIDHeader, l.tenantID)
+	}
+	if l.username != "" {
+		req.SetBasicAuth(l.username, l.password)
+	}
+
+	resp, err := l.httpCli.Do(req)
+	if err != nil {
+		return fmt.Errorf("while sending request: %w", err)
+	}
+	defer func() {
+		deferredErr := resp.Body.Close()
+		if deferredErr != nil {
+			err = multierror.Append(err, deferredErr)
+		}
+	}()
+
+	if resp.StatusCode != http.StatusNoContent {
+		raw, _ := io.ReadAll(resp.Body)
+		return newUnexpectedStatusError(resp.StatusCode, raw)
+	}
+	return nil
+}
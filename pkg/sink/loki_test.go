@@ -0,0 +1,112 @@
+package sink
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	logtest "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/internal/analytics"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/events"
+)
+
+func TestLoki_SendEvent(t *testing.T) {
+	// given
+	var (
+		gotReq    LokiPushRequest
+		gotTenant string
+		gotUser   string
+	)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, lokiPushPath, r.URL.Path)
+		gotTenant = r.Header.Get(lokiTenantIDHeader)
+		gotUser, _, _ = r.BasicAuth()
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&gotReq))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer ts.Close()
+
+	logger, _ := logtest.NewNullLogger()
+	loki, err := NewLoki(logger, config.Loki{
+		Enabled:  true,
+		URL:      ts.URL + "/",
+		TenantID: "platform",
+		Username: "botkube",
+		Password: "secret",
+		Labels:   map[string]string{"job": "botkube"},
+		Bindings: config.SinkBindings{Sources: []string{"k8s-all-events"}},
+	}, analytics.NewNoopReporter())
+	require.NoError(t, err)
+
+	event := fixSinkEvent(config.Error)
+
+	// when
+	err = loki.SendEvent(context.Background(), event, []string{"k8s-all-events"})
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, "platform", gotTenant)
+	assert.Equal(t, "botkube", gotUser)
+
+	require.Len(t, gotReq.Streams, 1)
+	stream := gotReq.Streams[0]
+	assert.Equal(t, map[string]string{
+		"job":       "botkube",
+		"cluster":   "dev",
+		"namespace": "default",
+		"level":     "error",
+	}, stream.Stream)
+
+	require.Len(t, stream.Values, 1)
+	assert.Equal(t, strconv.FormatInt(event.TimeStamp.UnixNano(), 10), stream.Values[0][0])
+
+	var gotEvent events.Event
+	require.NoError(t, json.Unmarshal([]byte(stream.Values[0][1]), &gotEvent))
+	assert.Equal(t, "nginx", gotEvent.Name)
+	assert.Equal(t, "BackOff", gotEvent.Reason)
+}
+
+func TestLoki_StreamLabelsSkipsEmptyValues(t *testing.T) {
+	// given
+	logger, _ := logtest.NewNullLogger()
+	loki, err := NewLoki(logger, config.Loki{URL: "http://loki"}, analytics.NewNoopReporter())
+	require.NoError(t, err)
+
+	event := fixSinkEvent(config.Info)
+	event.Namespace = ""
+
+	// when
+	labels := loki.streamLabels(event)
+
+	// then
+	assert.Equal(t, map[string]string{"cluster": "dev", "level": "info"}, labels)
+}
+
+func TestLoki_SendEventFailure(t *testing.T) {
+	// given
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("entry too far behind\n"))
+	}))
+	defer ts.Close()
+
+	logger, _ := logtest.NewNullLogger()
+	loki, err := NewLoki(logger, config.Loki{
+		URL:      ts.URL,
+		Bindings: config.SinkBindings{Sources: []string{"k8s-all-events"}},
+	}, analytics.NewNoopReporter())
+	require.NoError(t, err)
+
+	// when
+	err = loki.SendEvent(context.Background(), fixSinkEvent(config.Error), []string{"k8s-all-events"})
+
+	// then
+	assert.EqualError(t, err, "while pushing event to Loki: got unexpected status code 400: entry too far behind")
+}
@@ -0,0 +1,218 @@
+package sink
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/kubeshop/botkube/pkg/bot/interactive"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/events"
+	"github.com/kubeshop/botkube/pkg/format"
+	"github.com/kubeshop/botkube/pkg/multierror"
+	"github.com/kubeshop/botkube/pkg/sliceutil"
+)
+
+const (
+	defaultOpsgenieAPIURL = "https://api.opsgenie.com"
+	opsgenieAlertsPath    = "/v2/alerts"
+	opsgenieSource        = "Botkube"
+
+	// Field limits defined by the Opsgenie Alert API.
+	opsgenieMaxMessageLength     = 130
+	opsgenieMaxAliasLength       = 512
+	opsgenieMaxDescriptionLength = 15000
+	opsgenieMaxTagLength         = 50
+	opsgenieMaxTags              = 20
+)
+
+// opsgeniePriorities maps event levels to Opsgenie alert priorities.
+var opsgeniePriorities = map[config.Level]string{
+	config.Critical: "P1",
+	config.Error:    "P2",
+	config.Warn:     "P3",
+	config.Info:     "P4",
+	config.Debug:    "P5",
+}
+
+// Opsgenie provides functionality to create Opsgenie alerts for events.
+// Alerts of the same object share the alias, so Opsgenie deduplicates them while they are open.
+type Opsgenie struct {
+	log      logrus.FieldLogger
+	reporter AnalyticsReporter
+	httpCli  *http.Client
+
+	url      string
+	apiKey   string
+	tags     []string
+	bindings config.SinkBindings
+}
+
+// OpsgenieAlert is the Opsgenie create alert request payload.
+type OpsgenieAlert struct {
+	Message     string            `json:"message"`
+	Alias       string            `json:"alias,omitempty"`
+	Description string            `json:"description,omitempty"`
+	Tags        []string          `json:"tags,omitempty"`
+	Details     map[string]string `json:"details,omitempty"`
+	Entity      string            `json:"entity,omitempty"`
+	Source      string            `json:"source,omitempty"`
+	Priority    string            `json:"priority,omitempty"`
+}
+
+// NewOpsgenie creates a new Opsgenie instance.
+func NewOpsgenie(log logrus.FieldLogger, c config.Opsgenie, reporter AnalyticsReporter) (*Opsgenie, error) {
+	url := c.URL
+	if url == "" {
+		url = defaultOpsgenieAPIURL
+	}
+
+	og := &Opsgenie{
+		log:      log,
+		reporter: reporter,
+		httpCli:  &http.Client{Timeout: defaultHTTPCliTimeout},
+		url:      strings.TrimSuffix(url, "/") + opsgenieAlertsPath,
+		apiKey:   c.APIKey,
+		tags:     c.Tags,
+		bindings: c.Bindings,
+	}
+
+	err := reporter.ReportSinkEnabled(og.IntegrationName())
+	if err != nil {
+		return nil, fmt.Errorf("while reporting analytics: %w", err)
+	}
+
+	return og, nil
+}
+
+// SendEvent creates an Opsgenie alert for a given event.
+func (o *Opsgenie) SendEvent(ctx context.Context, event events.Event, eventSources []string) error {
+	if !sliceutil.Intersect(eventSources, o.bindings.Sources) {
+		o.log.Debugf("Event sources do not match Opsgenie sources, event: %+v, eventSources: %+v", event, eventSources)
+		return nil
+	}
+
+	err := o.createAlert(ctx, o.alertFor(event))
+	if err != nil {
+		return fmt.Errorf("while creating Opsgenie alert: %w", err)
+	}
+
+	o.log.Debugf("Event successfully sent to Opsgenie: %+v", event)
+	return nil
+}
+
+// SendMessageToAll is no-op.
+func (o *Opsgenie) SendMessageToAll(_ context.Context, _ interactive.Message) error {
+	return nil
+}
+
+// SendGenericMessage is no-op.
+func (o *Opsgenie) SendGenericMessage(_ context.Context, _ interactive.GenericMessage, _ []string) error {
+	return nil
+}
+
+// IntegrationName describes the sink integration name.
+func (o *Opsgenie) IntegrationName() config.CommPlatformIntegration {
+	return config.OpsgenieCommPlatformIntegration
+}
+
+// Type describes the sink type.
+func (o *Opsgenie) Type() config.IntegrationType {
+	return config.SinkIntegrationType
+}
+
+func (o *Opsgenie) alertFor(event events.Event) OpsgenieAlert {
+	details := map[string]string{
+		"cluster":   event.Cluster,
+		"namespace": event.Namespace,
+		"kind":      event.Kind,
+		"name":      event.Name,
+		"type":      string(event.Type),
+		"level":     string(event.Level),
+		"reason":    event.Reason,
+	}
+	for key, val := range details {
+		if val == "" {
+			delete(details, key)
+		}
+	}
+
+	return OpsgenieAlert{
+		Message:     truncate(strings.TrimSpace(format.ShortMessage(event)), opsgenieMaxMessageLength),
+		Alias:       truncate(opsgenieAlias(event), opsgenieMaxAliasLength),
+		Description: truncate(eventDetailsText(event), opsgenieMaxDescriptionLength),
+		Tags:        o.tagsFor(event),
+		Details:     details,
+		Entity:      strings.Trim(strings.Join([]string{event.Kind, event.Name}, "/"), "/"),
+		Source:      opsgenieSource,
+		Priority:    opsgeniePriorities[event.Level],
+	}
+}
+
+// tagsFor returns the configured tags together with the ones generated from the event resource.
+func (o *Opsgenie) tagsFor(event events.Event) []string {
+	tags := append([]string{}, o.tags...)
+	generated := []struct{ key, val string }{
+		{key: "cluster", val: event.Cluster},
+		{key: "namespace", val: event.Namespace},
+		{key: "kind", val: event.Kind},
+		{key: "resource", val: event.Name},
+	}
+	for _, tag := range generated {
+		if tag.val == "" {
+			continue
+		}
+		tags = append(tags, fmt.Sprintf("%s:%s", tag.key, tag.val))
+	}
+
+	var out []string
+	for _, tag := range tags {
+		out = append(out, truncate(tag, opsgenieMaxTagLength))
+		if len(out) == opsgenieMaxTags {
+			break
+		}
+	}
+	return out
+}
+
+func (o *Opsgenie) createAlert(ctx context.Context, alert OpsgenieAlert) (err error) {
+	body, err := json.Marshal(alert)
+	if err != nil {
+		return fmt.Errorf("while marshaling alert: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, o.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("while creating request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "GenieKey "+o.apiKey)
+
+	resp, err := o.httpCli.Do(req)
+	if err != nil {
+		return fmt.Errorf("while sending request: %w", err)
+	}
+	defer func() {
+		deferredErr := resp.Body.Close()
+		if deferredErr != nil {
+			err = multierror.Append(err, deferredErr)
+		}
+	}()
+
+	if resp.StatusCode != http.StatusAccepted {
+		raw, _ := io.ReadAll(resp.Body)
+		return newUnexpectedStatusError(resp.StatusCode, raw)
+	}
+	return nil
+}
+
+// opsgenieAlias returns the alias which identifies the alerts of a given object.
+func opsgenieAlias(event events.Event) string {
+	return strings.Join([]string{event.Cluster, event.Namespace, event.Kind, event.Name, string(event.Type), event.Reason}, "/")
+}
@@ -0,0 +1,84 @@
+package sink
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	logtest "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/internal/analytics"
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+func TestOpsgenie_SendEvent(t *testing.T) {
+	// given
+	var (
+		gotAlert    OpsgenieAlert
+		gotAuthz    string
+		gotRequests int
+	)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotRequests++
+		gotAuthz = r.Header.Get("Authorization")
+		assert.Equal(t, opsgenieAlertsPath, r.URL.Path)
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&gotAlert))
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer ts.Close()
+
+	logger, _ := logtest.NewNullLogger()
+	og, err := NewOpsgenie(logger, config.Opsgenie{
+		Enabled:  true,
+		URL:      ts.URL + "/",
+		APIKey:   "my-key",
+		Tags:     []string{"team:platform"},
+		Bindings: config.SinkBindings{Sources: []string{"k8s-err-events"}},
+	}, analytics.NewNoopReporter())
+	require.NoError(t, err)
+
+	// when
+	err = og.SendEvent(context.Background(), fixSinkEvent(config.Critical), []string{"other"})
+	require.NoError(t, err)
+	err = og.SendEvent(context.Background(), fixSinkEvent(config.Critical), []string{"k8s-err-events"})
+	require.NoError(t, err)
+
+	// then
+	assert.Equal(t, 1, gotRequests)
+	assert.Equal(t, "GenieKey my-key", gotAuthz)
+	assert.Equal(t, "P1", gotAlert.Priority)
+	assert.Equal(t, "dev/default/Pod/nginx/error/BackOff", gotAlert.Alias)
+	assert.Equal(t, "Pod/nginx", gotAlert.Entity)
+	assert.Equal(t, opsgenieSource, gotAlert.Source)
+	assert.Equal(t, "Back-off restarting failed container", gotAlert.Description)
+	assert.Equal(t, []string{"team:platform", "cluster:dev", "namespace:default", "kind:Pod", "resource:nginx"}, gotAlert.Tags)
+	assert.Equal(t, "BackOff", gotAlert.Details["reason"])
+	assert.LessOrEqual(t, len(gotAlert.Message), opsgenieMaxMessageLength)
+}
+
+func TestOpsgenie_SendEventFailure(t *testing.T) {
+	// given
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		_, _ = w.Write([]byte(`{"message":"Request body is not processable."}`))
+	}))
+	defer ts.Close()
+
+	logger, _ := logtest.NewNullLogger()
+	og, err := NewOpsgenie(logger, config.Opsgenie{
+		URL:      ts.URL,
+		APIKey:   "my-key",
+		Bindings: config.SinkBindings{Sources: []string{"k8s-err-events"}},
+	}, analytics.NewNoopReporter())
+	require.NoError(t, err)
+
+	// when
+	err = og.SendEvent(context.Background(), fixSinkEvent(config.Warn), []string{"k8s-err-events"})
+
+	// then
+	assert.EqualError(t, err, `while creating Opsgenie alert: got unexpected status code 422: {"message":"Request body is not processable."}`)
+}
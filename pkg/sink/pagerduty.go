@@ -0,0 +1,302 @@
+package sink
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/kubeshop/botkube/pkg/bot/interactive"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/events"
+	"github.com/kubeshop/botkube/pkg/format"
+	"github.com/kubeshop/botkube/pkg/multierror"
+)
+
+const (
+	defaultPagerDutyEventsURL = "https://events.pagerduty.com/v2/enqueue"
+	pagerDutyClientName       = "Botkube"
+	// pagerDutyMaxSummaryLength is the limit of the summary length defined by the PagerDuty Events API v2.
+	pagerDutyMaxSummaryLength = 1024
+
+	pagerDutyTriggerAction = "trigger"
+	pagerDutyResolveAction = "resolve"
+)
+
+// pagerDutySeverities maps event levels to PagerDuty severities.
+var pagerDutySeverities = map[config.Level]string{
+	config.Critical: "critical",
+	config.Error:    "error",
+	config.Warn:     "warning",
+	config.Info:     "info",
+	config.Debug:    "info",
+}
+
+// PagerDuty provides functionality to trigger and resolve PagerDuty incidents for events.
+// Error and critical events trigger incidents. Once an info event is received for the same object, the incident is resolved.
+type PagerDuty struct {
+	log      logrus.FieldLogger
+	reporter AnalyticsReporter
+	httpCli  *http.Client
+
+	url         string
+	routingKeys map[string]string
+
+	// openIncidents holds routing keys of the triggered incidents by their deduplication keys.
+	openIncidentsMu sync.Mutex
+	openIncidents   map[string]map[string]struct{}
+}
+
+// PagerDutyEvent is the PagerDuty Events API v2 request payload.
+type PagerDutyEvent struct {
+	RoutingKey  string            `json:"routing_key"`
+	EventAction string            `json:"event_action"`
+	DedupKey    string            `json:"dedup_key"`
+	Client      string            `json:"client,omitempty"`
+	Payload     *PagerDutyPayload `json:"payload,omitempty"`
+}
+
+// PagerDutyPayload contains details of the triggered incident.
+type PagerDutyPayload struct {
+	Summary       string             `json:"summary"`
+	Source        string             `json:"source"`
+	Severity      string             `json:"severity"`
+	Timestamp     string             `json:"timestamp,omitempty"`
+	Component     string             `json:"component,omitempty"`
+	Group         string             `json:"group,omitempty"`
+	Class         string             `json:"class,omitempty"`
+	CustomDetails PagerDutyEventInfo `json:"custom_details"`
+}
+
+// PagerDutyEventInfo contains the event details attached to the incident.
+type PagerDutyEventInfo struct {
+	Type            config.EventType `json:"type"`
+	Reason          string           `json:"reason,omitempty"`
+	Error           string           `json:"error,omitempty"`
+	Messages        []string         `json:"messages,omitempty"`
+	Recommendations []string         `json:"recommendations,omitempty"`
+	Warnings        []string         `json:"warnings,omitempty"`
+}
+
+// NewPagerDuty creates a new PagerDuty instance.
+func NewPagerDuty(log logrus.FieldLogger, c config.PagerDuty, reporter AnalyticsReporter) (*PagerDuty, error) {
+	url := c.URL
+	if url == "" {
+		url = defaultPagerDutyEventsURL
+	}
+
+	pd := &PagerDuty{
+		log:           log,
+		reporter:      reporter,
+		httpCli:       &http.Client{Timeout: defaultHTTPCliTimeout},
+		url:           url,
+		routingKeys:   c.RoutingKeys,
+		openIncidents: map[string]map[string]struct{}{},
+	}
+
+	err := reporter.ReportSinkEnabled(pd.IntegrationName())
+	if err != nil {
+		return nil, fmt.Errorf("while reporting analytics: %w", err)
+	}
+
+	return pd, nil
+}
+
+// SendEvent triggers or resolves PagerDuty incidents for a given event.
+func (p *PagerDuty) SendEvent(ctx context.Context, event events.Event, eventSources []string) error {
+	routingKeys := p.routingKeysFor(eventSources)
+	if len(routingKeys) == 0 {
+		p.log.Debugf("Event sources do not match PagerDuty sources, event: %+v, eventSources: %+v", event, eventSources)
+		return nil
+	}
+
+	dedupKey := pagerDutyDedupKey(event)
+	switch event.Level {
+	case config.Error, config.Critical:
+		return p.trigger(ctx, event, dedupKey, routingKeys)
+	case config.Info:
+		return p.resolve(ctx, dedupKey)
+	default:
+		p.log.Debugf("Skipping event with %q level as it doesn't trigger nor resolve incidents", event.Level)
+		return nil
+	}
+}
+
+func (p *PagerDuty) trigger(ctx context.Context, event events.Event, dedupKey string, routingKeys []string) error {
+	payload := &PagerDutyPayload{
+		Summary:   pagerDutySummary(event),
+		Source:    pagerDutySource(event),
+		Severity:  pagerDutySeverities[event.Level],
+		Component: event.Name,
+		Group:     event.Namespace,
+		Class:     event.Kind,
+		CustomDetails: PagerDutyEventInfo{
+			Type:            event.Type,
+			Reason:          event.Reason,
+			Error:           event.Error,
+			Messages:        event.Messages,
+			Recommendations: event.Recommendations,
+			Warnings:        event.Warnings,
+		},
+	}
+	if !event.TimeStamp.IsZero() {
+		payload.Timestamp = event.TimeStamp.UTC().Format(time.RFC3339)
+	}
+
+	errs := multierror.New()
+	for _, routingKey := range routingKeys {
+		err := p.postEvent(ctx, PagerDutyEvent{
+			RoutingKey:  routingKey,
+			EventAction: pagerDutyTriggerAction,
+			DedupKey:    dedupKey,
+			Client:      pagerDutyClientName,
+			Payload:     payload,
+		})
+		if err != nil {
+			errs = multierror.Append(errs, fmt.Errorf("while triggering PagerDuty incident: %w", err))
+			continue
+		}
+		p.markOpen(dedupKey, routingKey)
+	}
+
+	p.log.Debugf("PagerDuty incident %q triggered", dedupKey)
+	return errs.ErrorOrNil()
+}
+
+func (p *PagerDuty) resolve(ctx context.Context, dedupKey string) error {
+	routingKeys := p.popOpen(dedupKey)
+	if len(routingKeys) == 0 {
+		return nil
+	}
+
+	errs := multierror.New()
+	for _, routingKey := range routingKeys {
+		err := p.postEvent(ctx, PagerDutyEvent{
+			RoutingKey:  routingKey,
+			EventAction: pagerDutyResolveAction,
+			DedupKey:    dedupKey,
+		})
+		if err != nil {
+			// keep it open, so the next info event retries the resolution
+			p.markOpen(dedupKey, routingKey)
+			errs = multierror.Append(errs, fmt.Errorf("while resolving PagerDuty incident: %w", err))
+		}
+	}
+
+	p.log.Debugf("PagerDuty incident %q resolved", dedupKey)
+	return errs.ErrorOrNil()
+}
+
+// SendMessageToAll is no-op.
+func (p *PagerDuty) SendMessageToAll(_ context.Context, _ interactive.Message) error {
+	return nil
+}
+
+// SendGenericMessage is no-op.
+func (p *PagerDuty) SendGenericMessage(_ context.Context, _ interactive.GenericMessage, _ []string) error {
+	return nil
+}
+
+// IntegrationName describes the sink integration name.
+func (p *PagerDuty) IntegrationName() config.CommPlatformIntegration {
+	return config.PagerDutyCommPlatformIntegration
+}
+
+// Type describes the sink type.
+func (p *PagerDuty) Type() config.IntegrationType {
+	return config.SinkIntegrationType
+}
+
+// routingKeysFor returns unique routing keys for a given event sources.
+func (p *PagerDuty) routingKeysFor(eventSources []string) []string {
+	unique := map[string]struct{}{}
+	for _, source := range eventSources {
+		if key, found := p.routingKeys[source]; found {
+			unique[key] = struct{}{}
+		}
+	}
+
+	out := make([]string, 0, len(unique))
+	for key := range unique {
+		out = append(out, key)
+	}
+	sort.Strings(out)
+	return out
+}
+
+func (p *PagerDuty) markOpen(dedupKey, routingKey string) {
+	p.openIncidentsMu.Lock()
+	defer p.openIncidentsMu.Unlock()
+
+	if p.openIncidents[dedupKey] == nil {
+		p.openIncidents[dedupKey] = map[string]struct{}{}
+	}
+	p.openIncidents[dedupKey][routingKey] = struct{}{}
+}
+
+func (p *PagerDuty) popOpen(dedupKey string) []string {
+	p.openIncidentsMu.Lock()
+	defer p.openIncidentsMu.Unlock()
+
+	var out []string
+	for routingKey := range p.openIncidents[dedupKey] {
+		out = append(out, routingKey)
+	}
+	delete(p.openIncidents, dedupKey)
+	sort.Strings(out)
+	return out
+}
+
+func (p *PagerDuty) postEvent(ctx context.Context, in PagerDutyEvent) (err error) {
+	body, err := json.Marshal(in)
+	if err != nil {
+		return fmt.Errorf("while marshaling event: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("while creating request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := p.httpCli.Do(req)
+	if err != nil {
+		return fmt.Errorf("while sending request: %w", err)
+	}
+	defer func() {
+		deferredErr := resp.Body.Close()
+		if deferredErr != nil {
+			err = multierror.Append(err, deferredErr)
+		}
+	}()
+
+	if resp.StatusCode != http.StatusAccepted {
+		raw, _ := io.ReadAll(resp.Body)
+		return newUnexpectedStatusError(resp.StatusCode, raw)
+	}
+	return nil
+}
+
+// pagerDutyDedupKey returns the key which identifies the incidents of a given object.
+func pagerDutyDedupKey(event events.Event) string {
+	return strings.Join([]string{event.Cluster, event.Namespace, event.Kind, event.Name}, "/")
+}
+
+func pagerDutySource(event events.Event) string {
+	if event.Cluster != "" {
+		return event.Cluster
+	}
+	return pagerDutyClientName
+}
+
+func pagerDutySummary(event events.Event) string {
+	return truncate(strings.TrimSpace(format.ShortMessage(event)), pagerDutyMaxSummaryLength)
+}
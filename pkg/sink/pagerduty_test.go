@@ -0,0 +1,163 @@
+package sink
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	logtest "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kubeshop/botkube/internal/analytics"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/events"
+)
+
+func TestPagerDuty_SendEvent(t *testing.T) {
+	// given
+	var (
+		mu       sync.Mutex
+		received []PagerDutyEvent
+	)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var in PagerDutyEvent
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&in))
+
+		mu.Lock()
+		received = append(received, in)
+		mu.Unlock()
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer ts.Close()
+
+	logger, _ := logtest.NewNullLogger()
+	pd, err := NewPagerDuty(logger, config.PagerDuty{
+		Enabled: true,
+		URL:     ts.URL,
+		RoutingKeys: map[string]string{
+			"k8s-err-events": "key-a",
+			"k8s-all-events": "key-a",
+			"k8s-pod-events": "key-b",
+		},
+	}, analytics.NewNoopReporter())
+	require.NoError(t, err)
+
+	failedEvent := fixSinkEvent(config.Error)
+	createdEvent := fixSinkEvent(config.Info)
+
+	// when
+	err = pd.SendEvent(context.Background(), failedEvent, []string{"k8s-err-events", "k8s-all-events", "k8s-pod-events", "other"})
+	require.NoError(t, err)
+	err = pd.SendEvent(context.Background(), createdEvent, []string{"k8s-all-events"})
+	require.NoError(t, err)
+	// already resolved
+	err = pd.SendEvent(context.Background(), createdEvent, []string{"k8s-all-events"})
+	require.NoError(t, err)
+
+	// then
+	require.Len(t, received, 4)
+
+	for i, key := range []string{"key-a", "key-b"} {
+		assert.Equal(t, key, received[i].RoutingKey)
+		assert.Equal(t, pagerDutyTriggerAction, received[i].EventAction)
+		assert.Equal(t, "dev/default/Pod/nginx", received[i].DedupKey)
+		require.NotNil(t, received[i].Payload)
+		assert.Equal(t, "error", received[i].Payload.Severity)
+		assert.Equal(t, "dev", received[i].Payload.Source)
+		assert.Equal(t, "nginx", received[i].Payload.Component)
+		assert.Equal(t, "default", received[i].Payload.Group)
+		assert.Equal(t, "BackOff", received[i].Payload.CustomDetails.Reason)
+	}
+
+	for i, key := range []string{"key-a", "key-b"} {
+		assert.Equal(t, key, received[i+2].RoutingKey)
+		assert.Equal(t, pagerDutyResolveAction, received[i+2].EventAction)
+		assert.Equal(t, "dev/default/Pod/nginx", received[i+2].DedupKey)
+		assert.Nil(t, received[i+2].Payload)
+	}
+}
+
+func TestPagerDuty_SendEventSkipsNotPagingLevels(t *testing.T) {
+	tests := map[string]struct {
+		level   config.Level
+		sources []string
+	}{
+		"Warning event": {
+			level:   config.Warn,
+			sources: []string{"k8s-err-events"},
+		},
+		"Info event without open incident": {
+			level:   config.Info,
+			sources: []string{"k8s-err-events"},
+		},
+		"Error event from not bound source": {
+			level:   config.Error,
+			sources: []string{"other"},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				t.Errorf("unexpected request to PagerDuty")
+			}))
+			defer ts.Close()
+
+			logger, _ := logtest.NewNullLogger()
+			pd, err := NewPagerDuty(logger, config.PagerDuty{
+				URL:         ts.URL,
+				RoutingKeys: map[string]string{"k8s-err-events": "key-a"},
+			}, analytics.NewNoopReporter())
+			require.NoError(t, err)
+
+			// when
+			err = pd.SendEvent(context.Background(), fixSinkEvent(tc.level), tc.sources)
+
+			// then
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestPagerDuty_SendEventFailure(t *testing.T) {
+	// given
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"status":"invalid event"}`))
+	}))
+	defer ts.Close()
+
+	logger, _ := logtest.NewNullLogger()
+	pd, err := NewPagerDuty(logger, config.PagerDuty{
+		URL:         ts.URL,
+		RoutingKeys: map[string]string{"k8s-err-events": "key-a"},
+	}, analytics.NewNoopReporter())
+	require.NoError(t, err)
+
+	// when
+	err = pd.SendEvent(context.Background(), fixSinkEvent(config.Critical), []string{"k8s-err-events"})
+
+	// then
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `got unexpected status code 400: {"status":"invalid event"}`)
+	assert.Empty(t, pd.openIncidents)
+}
+
+func fixSinkEvent(level config.Level) events.Event {
+	return events.Event{
+		TypeMeta:  v1.TypeMeta{APIVersion: "v1", Kind: "Pod"},
+		Name:      "nginx",
+		Namespace: "default",
+		Cluster:   "dev",
+		Reason:    "BackOff",
+		Level:     level,
+		Type:      config.ErrorEvent,
+		Messages:  []string{"Back-off restarting failed container"},
+		TimeStamp: v1.Now().Time,
+	}
+}
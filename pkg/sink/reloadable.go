@@ -0,0 +1,68 @@
+package sink
+
+import (
+	"context"
+	"sync"
+
+	"github.com/kubeshop/botkube/pkg/bot/interactive"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/events"
+)
+
+var _ Sink = &Reloadable{}
+
+// Reloadable wraps a sink which is recreated once its configuration is reloaded.
+// The components which send notifications keep the wrapper, so they don't need to be updated.
+type Reloadable struct {
+	mu   sync.RWMutex
+	sink Sink
+}
+
+// NewReloadable returns a new Reloadable instance for a given sink.
+func NewReloadable(sink Sink) *Reloadable {
+	return &Reloadable{
+		sink: sink,
+	}
+}
+
+// Swap replaces the wrapped sink and returns the previous one. The messages which are being sent with the previous
+// sink are not interrupted.
+func (r *Reloadable) Swap(sink Sink) Sink {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	previous := r.sink
+	r.sink = sink
+	return previous
+}
+
+// SendEvent sends a given event with the current sink.
+func (r *Reloadable) SendEvent(ctx context.Context, event events.Event, eventSources []string) error {
+	return r.current().SendEvent(ctx, event, eventSources)
+}
+
+// SendMessageToAll sends a given message with the current sink.
+func (r *Reloadable) SendMessageToAll(ctx context.Context, msg interactive.Message) error {
+	return r.current().SendMessageToAll(ctx, msg)
+}
+
+// SendGenericMessage sends a given message with the current sink.
+func (r *Reloadable) SendGenericMessage(ctx context.Context, msg interactive.GenericMessage, sourceBindings []string) error {
+	return r.current().SendGenericMessage(ctx, msg, sourceBindings)
+}
+
+// IntegrationName describes the wrapped sink integration name.
+func (r *Reloadable) IntegrationName() config.CommPlatformIntegration {
+	return r.current().IntegrationName()
+}
+
+// Type describes the wrapped sink type.
+func (r *Reloadable) Type() config.IntegrationType {
+	return r.current().Type()
+}
+
+func (r *Reloadable) current() Sink {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.sink
+}
@@ -0,0 +1,33 @@
+package sink
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+func TestReloadable_SendEventUsesSwappedSink(t *testing.T) {
+	// given
+	first := &fakeFlakySink{}
+	second := &fakeFlakySink{}
+	reloadable := NewReloadable(first)
+
+	event := fixSinkEvent(config.Error)
+	event.Name = "first"
+	require.NoError(t, reloadable.SendEvent(context.Background(), event, []string{"k8s-err-events"}))
+
+	// when
+	previous := reloadable.Swap(second)
+
+	event.Name = "second"
+	require.NoError(t, reloadable.SendEvent(context.Background(), event, []string{"k8s-err-events"}))
+
+	// then
+	assert.Same(t, first, previous)
+	assert.Equal(t, []string{"first"}, first.Sent())
+	assert.Equal(t, []string{"second"}, second.Sent())
+}
@@ -0,0 +1,413 @@
+package sink
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/olivere/elastic"
+	"github.com/sirupsen/logrus"
+
+	"github.com/kubeshop/botkube/pkg/bot/interactive"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/events"
+)
+
+var _ Sink = &Retrying{}
+
+const (
+	defaultRetryInitialBackoff = time.Second
+	defaultRetryMaxBackoff     = 5 * time.Minute
+	defaultRetryQueueSize      = 1000
+	defaultRetryMaxSizeBytes   = 100 * 1024 * 1024
+	retryItemFileExt           = ".json"
+	retryItemTmpFileExt        = ".tmp"
+	retryItemCorruptedFileExt  = ".corrupted"
+	retryQueueDirPerm          = 0o700
+	retryItemFilePerm          = 0o600
+)
+
+// Retrying wraps a sink and retries sending the events which failed, with exponential backoff.
+// All events are queued and sent one by one, so they are delivered in order once the downstream is available again.
+// Events which failed permanently, e.g. were rejected with a 4xx status code, are dropped without retrying.
+// Optionally, the queue is buffered on disk, so it survives restarts.
+type Retrying struct {
+	log  logrus.FieldLogger
+	sink Sink
+
+	maxAttempts    int
+	initialBackoff time.Duration
+	maxBackoff     time.Duration
+	queueSize      int
+	maxSizeBytes   int64
+	store          *retryDiskStore
+
+	mu         sync.Mutex
+	queue      []*retryItem
+	queueBytes int64
+	seq        uint64
+	wakeCh     chan struct{}
+}
+
+type retryItem struct {
+	ID       string       `json:"id"`
+	Event    events.Event `json:"event"`
+	Sources  []string     `json:"sources"`
+	Attempts int          `json:"attempts"`
+
+	// size is the size of the item in the disk buffer. It's counted even if the item failed to be persisted
+	// and is kept only in memory, so such items are limited by the maximum size as well.
+	size int64
+}
+
+// NewRetrying returns a new Retrying instance for a given sink. The name must be unique, as it's used for the disk buffer directory.
+func NewRetrying(log logrus.FieldLogger, name string, sink Sink, cfg config.SinkRetry) (*Retrying, error) {
+	r := &Retrying{
+		log:            log,
+		sink:           sink,
+		maxAttempts:    cfg.MaxAttempts,
+		initialBackoff: cfg.InitialBackoff,
+		maxBackoff:     cfg.MaxBackoff,
+		queueSize:      cfg.QueueSize,
+		maxSizeBytes:   cfg.Persistence.MaxSizeBytes,
+		wakeCh:         make(chan struct{}, 1),
+	}
+	if r.initialBackoff <= 0 {
+		r.initialBackoff = defaultRetryInitialBackoff
+	}
+	if r.maxBackoff <= 0 {
+		r.maxBackoff = defaultRetryMaxBackoff
+	}
+	if r.queueSize <= 0 {
+		r.queueSize = defaultRetryQueueSize
+	}
+	if r.maxSizeBytes <= 0 {
+		r.maxSizeBytes = defaultRetryMaxSizeBytes
+	}
+
+	if !cfg.Persistence.Enabled {
+		return r, nil
+	}
+
+	store, err := newRetryDiskStore(log, filepath.Join(cfg.Persistence.Dir, name))
+	if err != nil {
+		return nil, fmt.Errorf("while creating disk buffer: %w", err)
+	}
+	r.store = store
+
+	items, err := store.Load()
+	if err != nil {
+		return nil, fmt.Errorf("while loading buffered events: %w", err)
+	}
+	for _, item := range items {
+		r.queue = append(r.queue, item)
+		r.queueBytes += item.size
+	}
+	r.dropOverflowLocked()
+	if len(r.queue) > 0 {
+		log.Infof("Loaded %d buffered events to retry", len(r.queue))
+	}
+
+	return r, nil
+}
+
+// Run sends the queued events until the context is cancelled.
+func (r *Retrying) Run(ctx context.Context) error {
+	r.log.Info("Starting sink retry queue...")
+	for {
+		item, ok := r.head()
+		if !ok {
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-r.wakeCh:
+				continue
+			}
+		}
+
+		if backoff := r.backoff(item.Attempts); backoff > 0 {
+			timer := time.NewTimer(backoff)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return nil
+			case <-timer.C:
+			}
+		}
+
+		err := r.sink.SendEvent(ctx, item.Event, item.Sources)
+		if ctx.Err() != nil {
+			return nil
+		}
+		r.completeAttempt(item, err)
+	}
+}
+
+// SendEvent queues a given event to be sent by Run and returns immediately.
+// All events go through the queue, so they are sent in order, also when some of them need to be retried.
+func (r *Retrying) SendEvent(_ context.Context, event events.Event, eventSources []string) error {
+	r.enqueue(&retryItem{
+		Event:   event,
+		Sources: eventSources,
+	})
+	return nil
+}
+
+// SendMessageToAll sends a given message without retries.
+func (r *Retrying) SendMessageToAll(ctx context.Context, msg interactive.Message) error {
+	return r.sink.SendMessageToAll(ctx, msg)
+}
+
+// SendGenericMessage sends a given message without retries.
+func (r *Retrying) SendGenericMessage(ctx context.Context, msg interactive.GenericMessage, sourceBindings []string) error {
+	return r.sink.SendGenericMessage(ctx, msg, sourceBindings)
+}
+
+// IntegrationName describes the wrapped sink integration name.
+func (r *Retrying) IntegrationName() config.CommPlatformIntegration {
+	return r.sink.IntegrationName()
+}
+
+// Type describes the wrapped sink type.
+func (r *Retrying) Type() config.IntegrationType {
+	return r.sink.Type()
+}
+
+func (r *Retrying) head() (*retryItem, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if len(r.queue) == 0 {
+		return nil, false
+	}
+	return r.queue[0], true
+}
+
+func (r *Retrying) enqueue(item *retryItem) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.seq++
+	item.ID = fmt.Sprintf("%020d-%010d", time.Now().UnixNano(), r.seq)
+	if r.store != nil {
+		size, err := r.store.Save(item)
+		if err != nil {
+			// still retry it from memory, as long as Botkube is running
+			r.log.Errorf("while buffering event on disk, keeping it only in memory: %s", err.Error())
+		}
+		item.size = size
+		r.queueBytes += size
+	}
+	r.queue = append(r.queue, item)
+	r.dropOverflowLocked()
+
+	select {
+	case r.wakeCh <- struct{}{}:
+	default:
+	}
+}
+
+// completeAttempt removes the event from the queue if it was sent or if it exceeded the maximum attempts.
+func (r *Retrying) completeAttempt(item *retryItem, sendErr error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// the item could be dropped in the meantime, as the queue overflowed
+	if len(r.queue) == 0 || r.queue[0] != item {
+		return
+	}
+
+	if sendErr == nil {
+		r.removeHeadLocked()
+		return
+	}
+
+	item.Attempts++
+	if isPermanent(sendErr) {
+		r.log.Errorf("Dropping event which cannot be sent: %s", sendErr.Error())
+		r.removeHeadLocked()
+		return
+	}
+	if r.maxAttempts > 0 && item.Attempts >= r.maxAttempts {
+		r.log.Errorf("Dropping event after %d failed attempts: %s", item.Attempts, sendErr.Error())
+		r.removeHeadLocked()
+		return
+	}
+
+	r.log.Warnf("Sending event failed (attempt %d), retrying in %s: %s", item.Attempts, r.backoff(item.Attempts), sendErr.Error())
+	if r.store == nil {
+		return
+	}
+	size, err := r.store.Save(item)
+	if err != nil {
+		r.log.Errorf("while buffering event on disk, keeping it only in memory: %s", err.Error())
+	}
+	r.queueBytes += size - item.size
+	item.size = size
+}
+
+func (r *Retrying) dropOverflowLocked() {
+	for len(r.queue) > r.queueSize || (r.store != nil && r.queueBytes > r.maxSizeBytes && len(r.queue) > 0) {
+		r.log.Errorf("Sink retry queue is full. Dropping the oldest event %s/%s", r.queue[0].Event.Kind, r.queue[0].Event.Name)
+		r.removeHeadLocked()
+	}
+}
+
+func (r *Retrying) removeHeadLocked() {
+	item := r.queue[0]
+	r.queue[0] = nil
+	r.queue = r.queue[1:]
+	r.queueBytes -= item.size
+
+	if r.store == nil {
+		return
+	}
+	if err := r.store.Delete(item); err != nil {
+		r.log.Errorf("while deleting buffered event: %s", err.Error())
+	}
+}
+
+// isPermanent returns true if sending an event failed in a way which retrying won't fix,
+// such as a request rejected by the downstream service or an event which cannot be encoded.
+func isPermanent(err error) bool {
+	var (
+		statusErr        *StatusError
+		elasticErr       *elastic.Error
+		unsupportedType  *json.UnsupportedTypeError
+		unsupportedValue *json.UnsupportedValueError
+		marshalerErr     *json.MarshalerError
+	)
+	switch {
+	case errors.As(err, &statusErr):
+		return isPermanentStatusCode(statusErr.StatusCode)
+	case errors.As(err, &elasticErr):
+		return isPermanentStatusCode(elasticErr.Status)
+	default:
+		return errors.As(err, &unsupportedType) || errors.As(err, &unsupportedValue) || errors.As(err, &marshalerErr)
+	}
+}
+
+// isPermanentStatusCode returns true for client errors, apart from the ones which may succeed later.
+func isPermanentStatusCode(code int) bool {
+	if code == http.StatusRequestTimeout || code == http.StatusTooManyRequests {
+		return false
+	}
+	return code >= http.StatusBadRequest && code < http.StatusInternalServerError
+}
+
+// backoff returns the time to wait before a next attempt, based on the number of already failed attempts.
+func (r *Retrying) backoff(attempts int) time.Duration {
+	if attempts <= 0 {
+		return 0
+	}
+
+	backoff := r.initialBackoff
+	for i := 1; i < attempts; i++ {
+		backoff *= 2
+		if backoff >= r.maxBackoff {
+			return r.maxBackoff
+		}
+	}
+	return backoff
+}
+
+// retryDiskStore stores queued events as files. File names are ordered by the time when the events were queued.
+type retryDiskStore struct {
+	log logrus.FieldLogger
+	dir string
+}
+
+func newRetryDiskStore(log logrus.FieldLogger, dir string) (*retryDiskStore, error) {
+	if err := os.MkdirAll(dir, retryQueueDirPerm); err != nil {
+		return nil, err
+	}
+	return &retryDiskStore{log: log, dir: dir}, nil
+}
+
+// Save writes a given item atomically and returns its size.
+// If the item cannot be written, the size is still returned, so the item can be counted while it's kept in memory.
+func (s *retryDiskStore) Save(item *retryItem) (int64, error) {
+	raw, err := json.Marshal(item)
+	if err != nil {
+		return 0, fmt.Errorf("while marshaling event: %w", err)
+	}
+	size := int64(len(raw))
+
+	path := s.path(item)
+	tmpPath := path + retryItemTmpFileExt
+	if err := os.WriteFile(tmpPath, raw, retryItemFilePerm); err != nil {
+		_ = os.Remove(tmpPath)
+		return size, fmt.Errorf("while writing file: %w", err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		_ = os.Remove(tmpPath)
+		return size, fmt.Errorf("while renaming file: %w", err)
+	}
+	return size, nil
+}
+
+// Delete removes a given item. It's a no-op if the item wasn't stored.
+func (s *retryDiskStore) Delete(item *retryItem) error {
+	err := os.Remove(s.path(item))
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// Load returns the stored items, starting from the oldest one.
+func (s *retryDiskStore) Load() ([]*retryItem, error) {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return nil, fmt.Errorf("while reading directory: %w", err)
+	}
+
+	var names []string
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), retryItemFileExt) {
+			continue
+		}
+		names = append(names, entry.Name())
+	}
+	sort.Strings(names)
+
+	var out []*retryItem
+	for _, name := range names {
+		raw, err := os.ReadFile(filepath.Join(s.dir, name))
+		if err != nil {
+			return nil, fmt.Errorf("while reading %q: %w", name, err)
+		}
+
+		var item retryItem
+		if err := json.Unmarshal(raw, &item); err != nil {
+			// a corrupted file shouldn't block the whole queue, so it's moved aside to be inspected
+			s.moveAsideCorrupted(name, err)
+			continue
+		}
+		item.size = int64(len(raw))
+		out = append(out, &item)
+	}
+	return out, nil
+}
+
+func (s *retryDiskStore) moveAsideCorrupted(name string, unmarshalErr error) {
+	path := filepath.Join(s.dir, name)
+	corruptedPath := path + retryItemCorruptedFileExt
+	s.log.Warnf("Skipping corrupted buffered event %q, moving it to %q: %s", name, corruptedPath, unmarshalErr.Error())
+	if err := os.Rename(path, corruptedPath); err != nil {
+		s.log.Errorf("while moving corrupted buffered event %q: %s", name, err.Error())
+	}
+}
+
+func (s *retryDiskStore) path(item *retryItem) string {
+	return filepath.Join(s.dir, item.ID+retryItemFileExt)
+}
@@ -0,0 +1,393 @@
+package sink
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/olivere/elastic"
+	"github.com/sirupsen/logrus"
+	logtest "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/pkg/bot/interactive"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/events"
+)
+
+func TestRetrying_SendEventRetriesInOrder(t *testing.T) {
+	// given
+	fake := &fakeFlakySink{failures: 2}
+	retrying := newTestRetrying(t, fake, config.SinkRetry{InitialBackoff: time.Millisecond})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- retrying.Run(ctx)
+	}()
+
+	// when
+	for _, name := range []string{"first", "second", "third"} {
+		event := fixSinkEvent(config.Error)
+		event.Name = name
+		err := retrying.SendEvent(context.Background(), event, []string{"k8s-err-events"})
+		require.NoError(t, err)
+	}
+
+	// then
+	assert.Eventually(t, func() bool {
+		return len(fake.Sent()) == 3
+	}, time.Second, time.Millisecond)
+	assert.Equal(t, []string{"first", "second", "third"}, fake.Sent())
+	assert.Equal(t, 5, fake.Attempts())
+
+	cancel()
+	assert.NoError(t, <-errCh)
+}
+
+func TestRetrying_SendEventKeepsOrderWhileFirstAttemptIsInFlight(t *testing.T) {
+	// given
+	release := make(chan struct{})
+	fake := &fakeFlakySink{failures: 1, release: release}
+	retrying := newTestRetrying(t, fake, config.SinkRetry{InitialBackoff: time.Millisecond})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		_ = retrying.Run(ctx)
+	}()
+
+	first := fixSinkEvent(config.Error)
+	first.Name = "first"
+	require.NoError(t, retrying.SendEvent(context.Background(), first, []string{"k8s-err-events"}))
+	require.Eventually(t, func() bool {
+		return fake.Attempts() == 1
+	}, time.Second, time.Millisecond)
+
+	// when
+	second := fixSinkEvent(config.Error)
+	second.Name = "second"
+	require.NoError(t, retrying.SendEvent(context.Background(), second, []string{"k8s-err-events"}))
+	close(release)
+
+	// then
+	assert.Eventually(t, func() bool {
+		return len(fake.Sent()) == 2
+	}, time.Second, time.Millisecond)
+	assert.Equal(t, []string{"first", "second"}, fake.Sent())
+	assert.Equal(t, 3, fake.Attempts())
+}
+
+func TestRetrying_PermanentErrors(t *testing.T) {
+	tests := []struct {
+		name        string
+		err         error
+		expAttempts int
+	}{
+		{
+			name:        "bad request is dropped",
+			err:         &StatusError{StatusCode: http.StatusBadRequest, Message: "bad request"},
+			expAttempts: 1,
+		},
+		{
+			name:        "wrapped not found is dropped",
+			err:         fmt.Errorf("while sending event: %w", newUnexpectedStatusError(http.StatusNotFound, nil)),
+			expAttempts: 1,
+		},
+		{
+			name:        "Elasticsearch rejection is dropped",
+			err:         fmt.Errorf("while posting data to ELS: %w", &elastic.Error{Status: http.StatusBadRequest}),
+			expAttempts: 1,
+		},
+		{
+			name:        "marshaling failure is dropped",
+			err:         fmt.Errorf("while marshaling event: %w", &json.UnsupportedValueError{Str: "NaN"}),
+			expAttempts: 1,
+		},
+		{
+			name:        "too many requests is retried",
+			err:         &StatusError{StatusCode: http.StatusTooManyRequests, Message: "slow down"},
+			expAttempts: 3,
+		},
+		{
+			name:        "server error is retried",
+			err:         newUnexpectedStatusError(http.StatusServiceUnavailable, nil),
+			expAttempts: 3,
+		},
+		{
+			name:        "network error is retried",
+			err:         errors.New("connection refused"),
+			expAttempts: 3,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// given
+			fake := &fakeFlakySink{failures: 10, err: tc.err}
+			retrying := newTestRetrying(t, fake, config.SinkRetry{MaxAttempts: 3, InitialBackoff: time.Millisecond})
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			go func() {
+				_ = retrying.Run(ctx)
+			}()
+
+			// when
+			err := retrying.SendEvent(context.Background(), fixSinkEvent(config.Error), []string{"k8s-err-events"})
+			require.NoError(t, err)
+
+			// then
+			assert.Eventually(t, func() bool {
+				_, queued := retrying.head()
+				return !queued
+			}, time.Second, time.Millisecond)
+			assert.Equal(t, tc.expAttempts, fake.Attempts())
+			assert.Empty(t, fake.Sent())
+		})
+	}
+}
+
+func TestRetrying_DropsEventAfterMaxAttempts(t *testing.T) {
+	// given
+	fake := &fakeFlakySink{failures: 10}
+	retrying := newTestRetrying(t, fake, config.SinkRetry{MaxAttempts: 3, InitialBackoff: time.Millisecond})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		_ = retrying.Run(ctx)
+	}()
+
+	// when
+	err := retrying.SendEvent(context.Background(), fixSinkEvent(config.Error), []string{"k8s-err-events"})
+	require.NoError(t, err)
+
+	// then
+	assert.Eventually(t, func() bool {
+		_, queued := retrying.head()
+		return !queued
+	}, time.Second, time.Millisecond)
+	assert.Equal(t, 3, fake.Attempts())
+	assert.Empty(t, fake.Sent())
+}
+
+func TestRetrying_DropsOldestEventsOnOverflow(t *testing.T) {
+	// given
+	fake := &fakeFlakySink{failures: 10}
+	retrying := newTestRetrying(t, fake, config.SinkRetry{QueueSize: 2})
+
+	// when
+	for _, name := range []string{"first", "second", "third"} {
+		event := fixSinkEvent(config.Error)
+		event.Name = name
+		err := retrying.SendEvent(context.Background(), event, []string{"k8s-err-events"})
+		require.NoError(t, err)
+	}
+
+	// then
+	require.Len(t, retrying.queue, 2)
+	assert.Equal(t, "second", retrying.queue[0].Event.Name)
+	assert.Equal(t, "third", retrying.queue[1].Event.Name)
+}
+
+func TestRetrying_PersistsQueueOnDisk(t *testing.T) {
+	// given
+	dir := t.TempDir()
+	cfg := config.SinkRetry{
+		InitialBackoff: time.Millisecond,
+		Persistence: config.SinkRetryPersistence{
+			Enabled: true,
+			Dir:     dir,
+		},
+	}
+
+	failing := &fakeFlakySink{failures: 10}
+	retrying := newTestRetrying(t, failing, cfg)
+	for _, name := range []string{"first", "second"} {
+		event := fixSinkEvent(config.Error)
+		event.Name = name
+		err := retrying.SendEvent(context.Background(), event, []string{"k8s-err-events"})
+		require.NoError(t, err)
+	}
+
+	files, err := os.ReadDir(filepath.Join(dir, "test-sink"))
+	require.NoError(t, err)
+	require.Len(t, files, 2)
+
+	// when
+	working := &fakeFlakySink{}
+	restored := newTestRetrying(t, working, cfg)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		_ = restored.Run(ctx)
+	}()
+
+	// then
+	assert.Eventually(t, func() bool {
+		return len(working.Sent()) == 2
+	}, time.Second, time.Millisecond)
+	assert.Equal(t, []string{"first", "second"}, working.Sent())
+
+	assert.Eventually(t, func() bool {
+		files, err := os.ReadDir(filepath.Join(dir, "test-sink"))
+		return err == nil && len(files) == 0
+	}, time.Second, time.Millisecond)
+}
+
+func TestRetrying_CountsEventsWhichFailedToBePersisted(t *testing.T) {
+	// given
+	dir := t.TempDir()
+	retrying := newTestRetrying(t, &fakeFlakySink{failures: 10}, config.SinkRetry{
+		InitialBackoff: time.Millisecond,
+		Persistence: config.SinkRetryPersistence{
+			Enabled: true,
+			Dir:     dir,
+		},
+	})
+	// the disk buffer is not writable anymore
+	require.NoError(t, os.RemoveAll(filepath.Join(dir, "test-sink")))
+
+	// when
+	err := retrying.SendEvent(context.Background(), fixSinkEvent(config.Error), []string{"k8s-err-events"})
+
+	// then
+	require.NoError(t, err)
+	retrying.mu.Lock()
+	defer retrying.mu.Unlock()
+	require.Len(t, retrying.queue, 1)
+	assert.Positive(t, retrying.queue[0].size)
+	assert.Equal(t, retrying.queue[0].size, retrying.queueBytes)
+}
+
+func TestRetrying_MovesAsideCorruptedBufferedEvents(t *testing.T) {
+	// given
+	dir := t.TempDir()
+	sinkDir := filepath.Join(dir, "test-sink")
+	require.NoError(t, os.MkdirAll(sinkDir, 0o700))
+	require.NoError(t, os.WriteFile(filepath.Join(sinkDir, "corrupted.json"), []byte("{"), 0o600))
+
+	logger, hook := logtest.NewNullLogger()
+
+	// when
+	retrying, err := NewRetrying(logger, "test-sink", &fakeFlakySink{}, config.SinkRetry{
+		Persistence: config.SinkRetryPersistence{
+			Enabled: true,
+			Dir:     dir,
+		},
+	})
+
+	// then
+	require.NoError(t, err)
+	assert.Empty(t, retrying.queue)
+	assert.NoFileExists(t, filepath.Join(sinkDir, "corrupted.json"))
+	assert.FileExists(t, filepath.Join(sinkDir, "corrupted.json.corrupted"))
+
+	require.NotNil(t, hook.LastEntry())
+	assert.Equal(t, logrus.WarnLevel, hook.LastEntry().Level)
+}
+
+func TestRetrying_Backoff(t *testing.T) {
+	// given
+	retrying := newTestRetrying(t, &fakeFlakySink{}, config.SinkRetry{
+		InitialBackoff: time.Second,
+		MaxBackoff:     5 * time.Second,
+	})
+
+	tests := map[int]time.Duration{
+		0: 0,
+		1: time.Second,
+		2: 2 * time.Second,
+		3: 4 * time.Second,
+		4: 5 * time.Second,
+		9: 5 * time.Second,
+	}
+	for attempts, exp := range tests {
+		// when
+		got := retrying.backoff(attempts)
+
+		// then
+		assert.Equal(t, exp, got, "attempts: %d", attempts)
+	}
+}
+
+func newTestRetrying(t *testing.T, sink Sink, cfg config.SinkRetry) *Retrying {
+	t.Helper()
+
+	logger, _ := logtest.NewNullLogger()
+	retrying, err := NewRetrying(logger, "test-sink", sink, cfg)
+	require.NoError(t, err)
+	return retrying
+}
+
+// fakeFlakySink fails a given number of first attempts with a given error.
+// If the release channel is set, the first attempt waits until it's closed.
+type fakeFlakySink struct {
+	mu       sync.Mutex
+	failures int
+	err      error
+	release  chan struct{}
+	attempts int
+	sent     []string
+}
+
+func (f *fakeFlakySink) SendEvent(_ context.Context, event events.Event, _ []string) error {
+	f.mu.Lock()
+	f.attempts++
+	release := f.release
+	f.release = nil
+	f.mu.Unlock()
+	if release != nil {
+		<-release
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.failures > 0 {
+		f.failures--
+		if f.err != nil {
+			return f.err
+		}
+		return errors.New("service unavailable")
+	}
+	f.sent = append(f.sent, event.Name)
+	return nil
+}
+
+func (f *fakeFlakySink) Sent() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]string{}, f.sent...)
+}
+
+func (f *fakeFlakySink) Attempts() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.attempts
+}
+
+func (f *fakeFlakySink) SendMessageToAll(context.Context, interactive.Message) error {
+	return nil
+}
+
+func (f *fakeFlakySink) SendGenericMessage(context.Context, interactive.GenericMessage, []string) error {
+	return nil
+}
+
+func (f *fakeFlakySink) IntegrationName() config.CommPlatformIntegration {
+	return config.WebhookCommPlatformIntegration
+}
+
+func (f *fakeFlakySink) Type() config.IntegrationType {
+	return config.SinkIntegrationType
+}
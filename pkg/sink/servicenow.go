@@ -0,0 +1,222 @@
+package sink
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"text/template"
+
+	sprig "github.com/go-task/slim-sprig"
+	"github.com/sirupsen/logrus"
+
+	"github.com/kubeshop/botkube/pkg/bot/interactive"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/events"
+	"github.com/kubeshop/botkube/pkg/multierror"
+	"github.com/kubeshop/botkube/pkg/sliceutil"
+)
+
+var _ Sink = &ServiceNow{}
+
+const (
+	defaultServiceNowTable = "incident"
+	serviceNowTableAPIPath = "/api/now/table/"
+)
+
+// defaultServiceNowFields are the incident fields rendered for each event. They can be overridden in the configuration.
+var defaultServiceNowFields = map[string]string{
+	"short_description": `[{{ .Event.Cluster }}] {{ with .Event.Reason }}{{ . }}: {{ end }}{{ .Event.Kind }} {{ with .Event.Namespace }}{{ . }}/{{ end }}{{ .Event.Name }}`,
+	"description": `Cluster: {{ .Event.Cluster }}
+Namespace: {{ .Event.Namespace }}
+Kind: {{ .Event.Kind }}
+Name: {{ .Event.Name }}
+Reason: {{ .Event.Reason }}
+Level: {{ .Event.Level }}
+{{ range .Event.Messages }}
+{{ . }}{{ end }}{{ with .Event.Error }}
+Error: {{ . }}{{ end }}{{ range .Event.Recommendations }}
+Recommendation: {{ . }}{{ end }}{{ range .Event.Warnings }}
+Warning: {{ . }}{{ end }}`,
+	"urgency":             `{{ if eq (toString .Event.Level) "critical" }}1{{ else }}2{{ end }}`,
+	"impact":              `{{ if eq (toString .Event.Level) "critical" }}1{{ else }}2{{ end }}`,
+	"correlation_id":      `{{ .Event.Cluster }}/{{ .Event.Namespace }}/{{ .Event.Kind }}/{{ .Event.Name }}`,
+	"correlation_display": `Botkube`,
+}
+
+// ServiceNow provides functionality to create ServiceNow incidents for error and critical events using the Table API.
+type ServiceNow struct {
+	log      logrus.FieldLogger
+	reporter AnalyticsReporter
+	httpCli  *http.Client
+
+	url      string
+	username string
+	password string
+	fields   map[string]*template.Template
+	bindings config.SinkBindings
+}
+
+// serviceNowTemplateData is the data available in the field templates.
+type serviceNowTemplateData struct {
+	Event events.Event
+}
+
+type serviceNowRecordResponse struct {
+	Result struct {
+		SysID  string `json:"sys_id"`
+		Number string `json:"number"`
+	} `json:"result"`
+}
+
+// NewServiceNow creates a new ServiceNow instance.
+func NewServiceNow(log logrus.FieldLogger, c config.ServiceNow, reporter AnalyticsReporter) (*ServiceNow, error) {
+	table := c.Table
+	if table == "" {
+		table = defaultServiceNowTable
+	}
+
+	fieldTpls := map[string]string{}
+	for name, tpl := range defaultServiceNowFields {
+		fieldTpls[name] = tpl
+	}
+	for name, tpl := range c.Fields {
+		if tpl == "" {
+			// allows disabling the default fields
+			delete(fieldTpls, name)
+			continue
+		}
+		fieldTpls[name] = tpl
+	}
+
+	fields := make(map[string]*template.Template, len(fieldTpls))
+	for name, raw := range fieldTpls {
+		tpl, err := template.New(name).Funcs(sprig.FuncMap()).Parse(raw)
+		if err != nil {
+			return nil, fmt.Errorf("while parsing template for field %q: %w", name, err)
+		}
+		fields[name] = tpl
+	}
+
+	sn := &ServiceNow{
+		log:      log,
+		reporter: reporter,
+		httpCli:  &http.Client{Timeout: defaultHTTPCliTimeout},
+		url:      strings.TrimSuffix(c.URL, "/") + serviceNowTableAPIPath + url.PathEscape(table),
+		username: c.Username,
+		password: c.Password,
+		fields:   fields,
+		bindings: c.Bindings,
+	}
+
+	err := reporter.ReportSinkEnabled(sn.IntegrationName())
+	if err != nil {
+		return nil, fmt.Errorf("while reporting analytics: %w", err)
+	}
+
+	return sn, nil
+}
+
+// SendEvent creates a ServiceNow incident for a given error or critical event.
+func (s *ServiceNow) SendEvent(ctx context.Context, event events.Event, eventSources []string) (err error) {
+	if !sliceutil.Intersect(eventSources, s.bindings.Sources) {
+		s.log.Debugf("Event sources do not match ServiceNow sources, event: %+v, eventSources: %+v", event, eventSources)
+		return nil
+	}
+
+	if event.Level != config.Error && event.Level != config.Critical {
+		s.log.Debugf("Skipping event with %q level as it doesn't create incidents", event.Level)
+		return nil
+	}
+
+	record, err := s.recordFor(event)
+	if err != nil {
+		return fmt.Errorf("while rendering incident fields: %w", err)
+	}
+
+	body, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("while marshaling incident: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("while creating request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	req.SetBasicAuth(s.username, s.password)
+
+	resp, err := s.httpCli.Do(req)
+	if err != nil {
+		return fmt.Errorf("while sending request: %w", err)
+	}
+	defer func() {
+		deferredErr := resp.Body.Close()
+		if deferredErr != nil {
+			err = multierror.Append(err, deferredErr)
+		}
+	}()
+
+	if resp.StatusCode != http.StatusCreated {
+		raw, _ := io.ReadAll(resp.Body)
+		return newUnexpectedStatusError(resp.StatusCode, raw)
+	}
+
+	var out serviceNowRecordResponse
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return fmt.Errorf("while decoding response: %w", err)
+	}
+
+	s.log.Debugf("ServiceNow incident %q created for event: %+v", out.Result.Number, event)
+	return nil
+}
+
+// SendMessageToAll is no-op.
+func (s *ServiceNow) SendMessageToAll(_ context.Context, _ interactive.Message) error {
+	return nil
+}
+
+// SendGenericMessage is no-op.
+func (s *ServiceNow) SendGenericMessage(_ context.Context, _ interactive.GenericMessage, _ []string) error {
+	return nil
+}
+
+// IntegrationName describes the sink integration name.
+func (s *ServiceNow) IntegrationName() config.CommPlatformIntegration {
+	return config.ServiceNowCommPlatformIntegration
+}
+
+// Type describes the sink type.
+func (s *ServiceNow) Type() config.IntegrationType {
+	return config.SinkIntegrationType
+}
+
+// recordFor renders the incident fields for a given event. Fields rendered to empty values are skipped.
+func (s *ServiceNow) recordFor(event events.Event) (map[string]string, error) {
+	names := make([]string, 0, len(s.fields))
+	for name := range s.fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	record := make(map[string]string, len(names))
+	for _, name := range names {
+		var out bytes.Buffer
+		if err := s.fields[name].Execute(&out, serviceNowTemplateData{Event: event}); err != nil {
+			return nil, fmt.Errorf("while rendering field %q: %w", name, err)
+		}
+
+		val := strings.TrimSpace(out.String())
+		if val == "" {
+			continue
+		}
+		record[name] = val
+	}
+	return record, nil
+}
@@ -0,0 +1,127 @@
+package sink
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	logtest "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/internal/analytics"
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+func TestServiceNow_SendEvent(t *testing.T) {
+	tests := map[string]struct {
+		level     config.Level
+		fields    map[string]string
+		expRecord map[string]string
+	}{
+		"Critical event with default fields": {
+			level: config.Critical,
+			expRecord: map[string]string{
+				"short_description":   "[dev] BackOff: Pod default/nginx",
+				"description":         "Cluster: dev\nNamespace: default\nKind: Pod\nName: nginx\nReason: BackOff\nLevel: critical\n\nBack-off restarting failed container",
+				"urgency":             "1",
+				"impact":              "1",
+				"correlation_id":      "dev/default/Pod/nginx",
+				"correlation_display": "Botkube",
+			},
+		},
+		"Error event with custom fields": {
+			level: config.Error,
+			fields: map[string]string{
+				"short_description":   "{{ .Event.Reason }} in {{ .Event.Namespace }}",
+				"assignment_group":    "Kubernetes",
+				"description":         "",
+				"correlation_display": "",
+			},
+			expRecord: map[string]string{
+				"short_description": "BackOff in default",
+				"assignment_group":  "Kubernetes",
+				"urgency":           "2",
+				"impact":            "2",
+				"correlation_id":    "dev/default/Pod/nginx",
+			},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given
+			var gotRecord map[string]string
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				user, pass, ok := r.BasicAuth()
+				assert.True(t, ok)
+				assert.Equal(t, "botkube", user)
+				assert.Equal(t, "secret", pass)
+				assert.Equal(t, "/api/now/table/incident", r.URL.Path)
+
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&gotRecord))
+				w.WriteHeader(http.StatusCreated)
+				_, _ = w.Write([]byte(`{"result":{"sys_id":"abc","number":"INC0010001"}}`))
+			}))
+			defer ts.Close()
+
+			sn := newTestServiceNow(t, ts.URL, tc.fields)
+
+			// when
+			err := sn.SendEvent(context.Background(), fixSinkEvent(tc.level), []string{"k8s-err-events"})
+
+			// then
+			require.NoError(t, err)
+			assert.Equal(t, tc.expRecord, gotRecord)
+		})
+	}
+}
+
+func TestServiceNow_SendEventSkipsNonErrorEvents(t *testing.T) {
+	// given
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+	}))
+	defer ts.Close()
+
+	sn := newTestServiceNow(t, ts.URL, nil)
+
+	// when
+	err := sn.SendEvent(context.Background(), fixSinkEvent(config.Warn), []string{"k8s-err-events"})
+
+	// then
+	require.NoError(t, err)
+}
+
+func TestServiceNow_SendEventUnexpectedStatus(t *testing.T) {
+	// given
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"error":{"message":"Operation Failed"}}`))
+	}))
+	defer ts.Close()
+
+	sn := newTestServiceNow(t, ts.URL, nil)
+
+	// when
+	err := sn.SendEvent(context.Background(), fixSinkEvent(config.Error), []string{"k8s-err-events"})
+
+	// then
+	assert.EqualError(t, err, `got unexpected status code 403: {"error":{"message":"Operation Failed"}}`)
+}
+
+func newTestServiceNow(t *testing.T, url string, fields map[string]string) *ServiceNow {
+	t.Helper()
+
+	logger, _ := logtest.NewNullLogger()
+	sn, err := NewServiceNow(logger, config.ServiceNow{
+		URL:      url,
+		Username: "botkube",
+		Password: "secret",
+		Fields:   fields,
+		Bindings: config.SinkBindings{Sources: []string{"k8s-err-events"}},
+	}, analytics.NewNoopReporter())
+	require.NoError(t, err)
+	return sn
+}
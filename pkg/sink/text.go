@@ -0,0 +1,31 @@
+package sink
+
+import (
+	"strings"
+
+	"github.com/kubeshop/botkube/pkg/events"
+)
+
+// eventDetailsText returns the event messages, error, recommendations and warnings as plaintext lines.
+func eventDetailsText(event events.Event) string {
+	var lines []string
+	lines = append(lines, event.Messages...)
+	if event.Error != "" {
+		lines = append(lines, "Error: "+event.Error)
+	}
+	for _, rec := range event.Recommendations {
+		lines = append(lines, "Recommendation: "+rec)
+	}
+	for _, warn := range event.Warnings {
+		lines = append(lines, "Warning: "+warn)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// truncate shortens a given string to the maximum length, marking the cut with an ellipsis.
+func truncate(in string, maxLen int) string {
+	if len(in) <= maxLen {
+		return in
+	}
+	return in[:maxLen-3] + "..."
+}
@@ -1,6 +1,9 @@
 package sink
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/kubeshop/botkube/pkg/config"
 	"github.com/kubeshop/botkube/pkg/notifier"
 )
@@ -15,3 +18,22 @@ type AnalyticsReporter interface {
 	// ReportSinkEnabled reports an enabled sink.
 	ReportSinkEnabled(platform config.CommPlatformIntegration) error
 }
+
+// StatusError is returned when the downstream service responds with an unexpected HTTP status code.
+type StatusError struct {
+	StatusCode int
+	Message    string
+}
+
+// Error returns the error message.
+func (e *StatusError) Error() string {
+	return e.Message
+}
+
+// newUnexpectedStatusError returns a StatusError for a given response status code and body.
+func newUnexpectedStatusError(statusCode int, body []byte) *StatusError {
+	return &StatusError{
+		StatusCode: statusCode,
+		Message:    fmt.Sprintf("got unexpected status code %d: %s", statusCode, strings.TrimSpace(string(body))),
+	}
+}
@@ -3,11 +3,17 @@ package sink
 import (
 	"bytes"
 	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strings"
+	"text/template"
 	"time"
 
+	sprig "github.com/go-task/slim-sprig"
 	"github.com/sirupsen/logrus"
 
 	"github.com/kubeshop/botkube/pkg/bot/interactive"
@@ -18,7 +24,13 @@ import (
 	"github.com/kubeshop/botkube/pkg/sliceutil"
 )
 
-const defaultHTTPCliTimeout = 30 * time.Second
+const (
+	defaultHTTPCliTimeout = 30 * time.Second
+
+	defaultWebhookContentType     = "application/json"
+	defaultWebhookSignatureHeader = "X-Botkube-Signature-256"
+	webhookSignaturePrefix        = "sha256="
+)
 
 // Webhook provides functionality to notify external service about new events.
 type Webhook struct {
@@ -27,6 +39,16 @@ type Webhook struct {
 
 	URL      string
 	Bindings config.SinkBindings
+
+	template        *template.Template
+	headers         map[string]string
+	signingSecret   string
+	signatureHeader string
+}
+
+// webhookTemplateData is the data available in the webhook payload template.
+type webhookTemplateData struct {
+	Event events.Event
 }
 
 // WebhookPayload contains json payload to be sent to webhook url
@@ -44,6 +66,7 @@ type EventMeta struct {
 	Kind      string `json:"kind"`
 	Name      string `json:"name"`
 	Namespace string `json:"namespace"`
+	Owner     string `json:"owner,omitempty"`
 	Cluster   string `json:"cluster,omitempty"`
 }
 
@@ -59,10 +82,24 @@ type EventStatus struct {
 // NewWebhook creates a new Webhook instance.
 func NewWebhook(log logrus.FieldLogger, c config.Webhook, reporter AnalyticsReporter) (*Webhook, error) {
 	whNotifier := &Webhook{
-		log:      log,
-		reporter: reporter,
-		URL:      c.URL,
-		Bindings: c.Bindings,
+		log:             log,
+		reporter:        reporter,
+		URL:             c.URL,
+		Bindings:        c.Bindings,
+		headers:         c.Headers,
+		signingSecret:   c.Signing.Secret,
+		signatureHeader: c.Signing.Header,
+	}
+	if whNotifier.signatureHeader == "" {
+		whNotifier.signatureHeader = defaultWebhookSignatureHeader
+	}
+
+	if c.Template != "" {
+		tpl, err := template.New("webhook-payload").Funcs(sprig.FuncMap()).Parse(c.Template)
+		if err != nil {
+			return nil, fmt.Errorf("while parsing payload template: %w", err)
+		}
+		whNotifier.template = tpl
 	}
 
 	err := reporter.ReportSinkEnabled(whNotifier.IntegrationName())
@@ -80,11 +117,32 @@ func (w *Webhook) SendEvent(ctx context.Context, event events.Event, eventSource
 		return nil
 	}
 
+	body, err := w.payloadFor(event)
+	if err != nil {
+		return fmt.Errorf("while preparing webhook payload: %w", err)
+	}
+
+	err = w.post(ctx, body)
+	if err != nil {
+		return fmt.Errorf("while sending event to webhook: %w", err)
+	}
+
+	w.log.Debugf("Event successfully sent to Webhook: %+v", event)
+	return nil
+}
+
+// payloadFor returns the request body rendered from the user-provided template, or the default JSON payload.
+func (w *Webhook) payloadFor(event events.Event) ([]byte, error) {
+	if w.template != nil {
+		return w.renderPayload(event)
+	}
+
 	jsonPayload := &WebhookPayload{
 		EventMeta: EventMeta{
 			Kind:      event.Kind,
 			Name:      event.Name,
 			Namespace: event.Namespace,
+			Owner:     event.OwnerString(),
 			Cluster:   event.Cluster,
 		},
 		EventStatus: EventStatus{
@@ -99,14 +157,7 @@ func (w *Webhook) SendEvent(ctx context.Context, event events.Event, eventSource
 		Recommendations: event.Recommendations,
 		Warnings:        event.Warnings,
 	}
-
-	err = w.PostWebhook(ctx, jsonPayload)
-	if err != nil {
-		return fmt.Errorf("while sending event to webhook: %w", err)
-	}
-
-	w.log.Debugf("Event successfully sent to Webhook: %+v", event)
-	return nil
+	return json.Marshal(jsonPayload)
 }
 
 // SendMessageToAll is no-op.
@@ -126,11 +177,52 @@ func (w *Webhook) PostWebhook(ctx context.Context, jsonPayload *WebhookPayload)
 		return err
 	}
 
+	return w.post(ctx, message)
+}
+
+// renderPayload renders the request body from the user-provided template.
+func (w *Webhook) renderPayload(event events.Event) ([]byte, error) {
+	var result bytes.Buffer
+	err := w.template.Execute(&result, webhookTemplateData{Event: event})
+	if err != nil {
+		return nil, err
+	}
+
+	// catch template mistakes early, instead of sending malformed JSON to the third-party API
+	if w.contentType() == defaultWebhookContentType && !json.Valid(result.Bytes()) {
+		return nil, fmt.Errorf("rendered payload is not a valid JSON: %s", result.String())
+	}
+	return result.Bytes(), nil
+}
+
+func (w *Webhook) contentType() string {
+	for key, val := range w.headers {
+		if strings.EqualFold(key, "Content-Type") {
+			return val
+		}
+	}
+	return defaultWebhookContentType
+}
+
+// sign returns the hex-encoded HMAC-SHA256 signature of a given body.
+func (w *Webhook) sign(body []byte) string {
+	mac := hmac.New(sha256.New, []byte(w.signingSecret))
+	mac.Write(body)
+	return webhookSignaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+func (w *Webhook) post(ctx context.Context, message []byte) (err error) {
 	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.URL, bytes.NewBuffer(message))
 	if err != nil {
 		return err
 	}
-	req.Header.Add("Content-Type", "application/json")
+	req.Header.Set("Content-Type", defaultWebhookContentType)
+	for key, val := range w.headers {
+		req.Header.Set(key, val)
+	}
+	if w.signingSecret != "" {
+		req.Header.Set(w.signatureHeader, w.sign(message))
+	}
 
 	client := &http.Client{Timeout: defaultHTTPCliTimeout}
 	resp, err := client.Do(req)
@@ -144,8 +236,12 @@ func (w *Webhook) PostWebhook(ctx context.Context, jsonPayload *WebhookPayload)
 		}
 	}()
 
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("Error Posting Webhook: %s", fmt.Sprint(resp.StatusCode))
+	// third-party APIs often respond with other success codes, such as 201 or 204
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return &StatusError{
+			StatusCode: resp.StatusCode,
+			Message:    fmt.Sprintf("Error Posting Webhook: %d", resp.StatusCode),
+		}
 	}
 
 	return nil
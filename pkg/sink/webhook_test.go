@@ -2,12 +2,20 @@ package sink
 
 import (
 	"context"
-	"fmt"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 
+	logtest "github.com/sirupsen/logrus/hooks/test"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/internal/analytics"
+	"github.com/kubeshop/botkube/pkg/config"
 )
 
 // Unit test PostWebhook
@@ -20,7 +28,7 @@ func TestPostWebhook(t *testing.T) {
 			httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 				w.WriteHeader(http.StatusServiceUnavailable)
 			})),
-			fmt.Errorf("Error Posting Webhook: %s", fmt.Sprint(http.StatusServiceUnavailable)),
+			&StatusError{StatusCode: http.StatusServiceUnavailable, Message: "Error Posting Webhook: 503"},
 		},
 		`Status Ok`: {
 			httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -44,3 +52,97 @@ func TestPostWebhook(t *testing.T) {
 		})
 	}
 }
+
+func TestWebhook_SendEventWithTemplate(t *testing.T) {
+	// given
+	var (
+		gotBody    string
+		gotHeaders http.Header
+	)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		raw, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		gotBody = string(raw)
+		gotHeaders = r.Header
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer ts.Close()
+
+	logger, _ := logtest.NewNullLogger()
+	wh, err := NewWebhook(logger, config.Webhook{
+		URL:      ts.URL,
+		Template: `{"title": {{ printf "%s %s" .Event.Kind .Event.Name | toJson }}, "severity": "{{ .Event.Level | toString | upper }}"}`,
+		Headers: map[string]string{
+			"Authorization": "Bearer token",
+		},
+		Signing: config.WebhookSigning{
+			Secret: "secret",
+		},
+		Bindings: config.SinkBindings{Sources: []string{"k8s-err-events"}},
+	}, analytics.NewNoopReporter())
+	require.NoError(t, err)
+
+	// when
+	err = wh.SendEvent(context.Background(), fixSinkEvent(config.Error), []string{"k8s-err-events"})
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, `{"title": "Pod nginx", "severity": "ERROR"}`, gotBody)
+	assert.Equal(t, "application/json", gotHeaders.Get("Content-Type"))
+	assert.Equal(t, "Bearer token", gotHeaders.Get("Authorization"))
+
+	mac := hmac.New(sha256.New, []byte("secret"))
+	mac.Write([]byte(gotBody))
+	assert.Equal(t, "sha256="+hex.EncodeToString(mac.Sum(nil)), gotHeaders.Get(defaultWebhookSignatureHeader))
+}
+
+func TestWebhook_RenderPayloadErrors(t *testing.T) {
+	tests := map[string]struct {
+		template  string
+		headers   map[string]string
+		expErrMsg string
+	}{
+		"Invalid JSON": {
+			template:  `{"name": {{ .Event.Name }}}`,
+			expErrMsg: `rendered payload is not a valid JSON: {"name": nginx}`,
+		},
+		"Unknown field": {
+			template:  `{{ .Event.Unknown }}`,
+			expErrMsg: `template: webhook-payload:1:9: executing "webhook-payload" at <.Event.Unknown>: can't evaluate field Unknown in type events.Event`,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given
+			logger, _ := logtest.NewNullLogger()
+			wh, err := NewWebhook(logger, config.Webhook{
+				Template: tc.template,
+				Headers:  tc.headers,
+			}, analytics.NewNoopReporter())
+			require.NoError(t, err)
+
+			// when
+			_, err = wh.renderPayload(fixSinkEvent(config.Error))
+
+			// then
+			assert.EqualError(t, err, tc.expErrMsg)
+		})
+	}
+}
+
+func TestWebhook_RenderPayloadSkipsJSONValidationForOtherContentTypes(t *testing.T) {
+	// given
+	logger, _ := logtest.NewNullLogger()
+	wh, err := NewWebhook(logger, config.Webhook{
+		Template: `name={{ .Event.Name | urlquery }}`,
+		Headers:  map[string]string{"content-type": "application/x-www-form-urlencoded"},
+	}, analytics.NewNoopReporter())
+	require.NoError(t, err)
+
+	// when
+	body, err := wh.renderPayload(fixSinkEvent(config.Error))
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, "name=nginx", string(body))
+}
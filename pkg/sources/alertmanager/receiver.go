@@ -0,0 +1,224 @@
+package alertmanager
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/events"
+	"github.com/kubeshop/botkube/pkg/sliceutil"
+)
+
+const (
+	// Path is the path of the source server endpoint, which receives the Alertmanager webhook payloads.
+	Path = "/sources/alertmanager"
+
+	alertKind     = "Alert"
+	alertResource = "alertmanager/alerts"
+
+	resolvedStatus = "resolved"
+
+	// maxPayloadSize limits the size of the webhook payload. Alertmanager sends the alerts in groups, so the payload can be large.
+	maxPayloadSize = 10 << 20
+)
+
+// severityLevels maps the alert severity label to event levels.
+var severityLevels = map[string]config.Level{
+	"critical": config.Critical,
+	"error":    config.Error,
+	"warning":  config.Warn,
+	"info":     config.Info,
+	"none":     config.Info,
+}
+
+// EventHandler handles events received from external sources.
+type EventHandler interface {
+	HandleExternalEvent(ctx context.Context, event events.Event, sources []string)
+}
+
+// Receiver receives Alertmanager webhook payloads and converts the alerts into events.
+// See https://prometheus.io/docs/alerting/latest/configuration/#webhook_config.
+type Receiver struct {
+	ctx         context.Context
+	log         logrus.FieldLogger
+	clusterName string
+	sources     map[string]config.AlertmanagerSource
+	handler     EventHandler
+}
+
+// Payload is the Alertmanager webhook payload.
+type Payload struct {
+	Version           string            `json:"version"`
+	GroupKey          string            `json:"groupKey"`
+	Status            string            `json:"status"`
+	Receiver          string            `json:"receiver"`
+	GroupLabels       map[string]string `json:"groupLabels"`
+	CommonLabels      map[string]string `json:"commonLabels"`
+	CommonAnnotations map[string]string `json:"commonAnnotations"`
+	ExternalURL       string            `json:"externalURL"`
+	Alerts            []Alert           `json:"alerts"`
+}
+
+// Alert is a single alert sent in the webhook payload.
+type Alert struct {
+	Status       string            `json:"status"`
+	Labels       map[string]string `json:"labels"`
+	Annotations  map[string]string `json:"annotations"`
+	StartsAt     time.Time         `json:"startsAt"`
+	EndsAt       time.Time         `json:"endsAt"`
+	GeneratorURL string            `json:"generatorURL"`
+	Fingerprint  string            `json:"fingerprint"`
+}
+
+// NewReceiver returns a new Receiver instance for the source bindings with enabled Alertmanager source.
+// Given context is used for sending the events, as they are sent after the webhook request is handled.
+func NewReceiver(ctx context.Context, log logrus.FieldLogger, clusterName string, sources map[string]config.Sources, handler EventHandler) *Receiver {
+	enabled := map[string]config.AlertmanagerSource{}
+	for name, src := range sources {
+		if src.Alertmanager.Enabled {
+			enabled[name] = src.Alertmanager
+		}
+	}
+
+	return &Receiver{
+		ctx:         ctx,
+		log:         log,
+		clusterName: clusterName,
+		sources:     enabled,
+		handler:     handler,
+	}
+}
+
+// Enabled returns true if any source binding has Alertmanager source enabled.
+func (r *Receiver) Enabled() bool {
+	return len(r.sources) > 0
+}
+
+// ServeHTTP handles the Alertmanager webhook request.
+func (r *Receiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var payload Payload
+	if err := json.NewDecoder(http.MaxBytesReader(w, req.Body, maxPayloadSize)).Decode(&payload); err != nil {
+		r.log.Errorf("while decoding Alertmanager payload: %s", err.Error())
+		http.Error(w, "invalid payload", http.StatusBadRequest)
+		return
+	}
+
+	sources := r.sourcesForReceiver(payload.Receiver)
+	if len(sources) == 0 {
+		r.log.Debugf("Skipping alerts from receiver %q as no source binding accepts them", payload.Receiver)
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	for _, alert := range payload.Alerts {
+		r.handler.HandleExternalEvent(r.ctx, r.eventFor(alert, payload), sources)
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// sourcesForReceiver returns sorted names of the source bindings, which accept alerts from a given receiver.
+func (r *Receiver) sourcesForReceiver(receiver string) []string {
+	var out []string
+	for name, src := range r.sources {
+		if len(src.Receivers) > 0 && !sliceutil.Intersect(src.Receivers, []string{receiver}) {
+			continue
+		}
+		out = append(out, name)
+	}
+	sort.Strings(out)
+	return out
+}
+
+func (r *Receiver) eventFor(alert Alert, payload Payload) events.Event {
+	labels := mergeMaps(payload.CommonLabels, alert.Labels)
+	annotations := mergeMaps(payload.CommonAnnotations, alert.Annotations)
+
+	status := alert.Status
+	if status == "" {
+		status = payload.Status
+	}
+
+	name := labels["alertname"]
+	event := events.Event{
+		TypeMeta:  metaV1.TypeMeta{Kind: alertKind},
+		Title:     fmt.Sprintf("Alert %s: %s", status, name),
+		Name:      name,
+		Namespace: labels["namespace"],
+		Reason:    labels["severity"],
+		Cluster:   r.clusterName,
+		Resource:  alertResource,
+		TimeStamp: alert.StartsAt,
+		Messages:  alertMessages(annotations, alert.GeneratorURL),
+	}
+
+	if status == resolvedStatus {
+		event.Type = config.InfoEvent
+		event.Level = config.Info
+		if !alert.EndsAt.IsZero() {
+			event.TimeStamp = alert.EndsAt
+		}
+		return event
+	}
+
+	event.Level = levelForSeverity(labels["severity"])
+	switch event.Level {
+	case config.Critical, config.Error:
+		event.Type = config.ErrorEvent
+	case config.Warn:
+		event.Type = config.WarningEvent
+	default:
+		event.Type = config.InfoEvent
+	}
+	return event
+}
+
+func levelForSeverity(severity string) config.Level {
+	level, found := severityLevels[strings.ToLower(severity)]
+	if !found {
+		// alerts without known severity still indicate a problem
+		return config.Error
+	}
+	return level
+}
+
+func alertMessages(annotations map[string]string, generatorURL string) []string {
+	var out []string
+	for _, key := range []string{"summary", "description", "message"} {
+		if val := strings.TrimSpace(annotations[key]); val != "" {
+			out = append(out, val)
+		}
+	}
+	if runbook := annotations["runbook_url"]; runbook != "" {
+		out = append(out, fmt.Sprintf("Runbook: %s", runbook))
+	}
+	if generatorURL != "" {
+		out = append(out, fmt.Sprintf("Source: %s", generatorURL))
+	}
+	return out
+}
+
+// mergeMaps returns a new map with the entries of all given maps. The later maps take precedence.
+func mergeMaps(in ...map[string]string) map[string]string {
+	out := map[string]string{}
+	for _, m := range in {
+		for key, val := range m {
+			out[key] = val
+		}
+	}
+	return out
+}
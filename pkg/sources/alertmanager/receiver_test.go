@@ -0,0 +1,162 @@
+package alertmanager
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	logtest "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/events"
+)
+
+const fixPayload = `{
+  "version": "4",
+  "status": "firing",
+  "receiver": "botkube",
+  "commonLabels": {"alertname": "KubePodCrashLooping", "namespace": "default"},
+  "commonAnnotations": {"summary": "Pod is crash looping."},
+  "alerts": [
+    {
+      "status": "firing",
+      "labels": {"severity": "critical"},
+      "annotations": {"description": "Pod default/nginx is restarting.", "runbook_url": "https://runbooks.example.com/crashloop"},
+      "startsAt": "2022-10-10T10:00:00Z",
+      "generatorURL": "http://prometheus/graph"
+    },
+    {
+      "status": "resolved",
+      "labels": {"severity": "warning"},
+      "startsAt": "2022-10-10T09:00:00Z",
+      "endsAt": "2022-10-10T09:30:00Z"
+    }
+  ]
+}`
+
+func TestReceiverServeHTTP(t *testing.T) {
+	// given
+	logger, _ := logtest.NewNullLogger()
+	handler := &fakeEventHandler{}
+	receiver := NewReceiver(context.Background(), logger, "dev", map[string]config.Sources{
+		"all-alerts":    {Alertmanager: config.AlertmanagerSource{Enabled: true}},
+		"botkube-only":  {Alertmanager: config.AlertmanagerSource{Enabled: true, Receivers: []string{"botkube"}}},
+		"other-only":    {Alertmanager: config.AlertmanagerSource{Enabled: true, Receivers: []string{"other"}}},
+		"k8s-no-alerts": {},
+	}, handler)
+
+	req := httptest.NewRequest(http.MethodPost, Path, strings.NewReader(fixPayload))
+	rec := httptest.NewRecorder()
+
+	// when
+	receiver.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusOK, rec.Code)
+	require.Len(t, handler.calls, 2)
+
+	firing := handler.calls[0]
+	assert.Equal(t, []string{"all-alerts", "botkube-only"}, firing.sources)
+	assert.Equal(t, events.Event{
+		TypeMeta:  metaV1.TypeMeta{Kind: "Alert"},
+		Title:     "Alert firing: KubePodCrashLooping",
+		Name:      "KubePodCrashLooping",
+		Namespace: "default",
+		Reason:    "critical",
+		Cluster:   "dev",
+		Resource:  "alertmanager/alerts",
+		Type:      config.ErrorEvent,
+		Level:     config.Critical,
+		TimeStamp: time.Date(2022, 10, 10, 10, 0, 0, 0, time.UTC),
+		Messages: []string{
+			"Pod is crash looping.",
+			"Pod default/nginx is restarting.",
+			"Runbook: https://runbooks.example.com/crashloop",
+			"Source: http://prometheus/graph",
+		},
+	}, firing.event)
+
+	resolved := handler.calls[1]
+	assert.Equal(t, "Alert resolved: KubePodCrashLooping", resolved.event.Title)
+	assert.Equal(t, config.InfoEvent, resolved.event.Type)
+	assert.Equal(t, config.Info, resolved.event.Level)
+	assert.Equal(t, time.Date(2022, 10, 10, 9, 30, 0, 0, time.UTC), resolved.event.TimeStamp)
+}
+
+func TestReceiverServeHTTPErrors(t *testing.T) {
+	tests := []struct {
+		name         string
+		method       string
+		body         string
+		expectedCode int
+	}{
+		{
+			name:         "Method not allowed",
+			method:       http.MethodGet,
+			expectedCode: http.StatusMethodNotAllowed,
+		},
+		{
+			name:         "Invalid payload",
+			method:       http.MethodPost,
+			body:         "{not-json",
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// given
+			logger, _ := logtest.NewNullLogger()
+			handler := &fakeEventHandler{}
+			receiver := NewReceiver(context.Background(), logger, "dev", map[string]config.Sources{
+				"alerts": {Alertmanager: config.AlertmanagerSource{Enabled: true}},
+			}, handler)
+
+			req := httptest.NewRequest(tc.method, Path, strings.NewReader(tc.body))
+			rec := httptest.NewRecorder()
+
+			// when
+			receiver.ServeHTTP(rec, req)
+
+			// then
+			assert.Equal(t, tc.expectedCode, rec.Code)
+			assert.Empty(t, handler.calls)
+		})
+	}
+}
+
+func TestLevelForSeverity(t *testing.T) {
+	tests := []struct {
+		severity string
+		expected config.Level
+	}{
+		{severity: "critical", expected: config.Critical},
+		{severity: "Warning", expected: config.Warn},
+		{severity: "none", expected: config.Info},
+		{severity: "", expected: config.Error},
+		{severity: "page", expected: config.Error},
+	}
+	for _, tc := range tests {
+		t.Run(tc.severity, func(t *testing.T) {
+			assert.Equal(t, tc.expected, levelForSeverity(tc.severity))
+		})
+	}
+}
+
+type handledEvent struct {
+	event   events.Event
+	sources []string
+}
+
+type fakeEventHandler struct {
+	calls []handledEvent
+}
+
+func (f *fakeEventHandler) HandleExternalEvent(_ context.Context, event events.Event, sources []string) {
+	f.calls = append(f.calls, handledEvent{event: event, sources: sources})
+}
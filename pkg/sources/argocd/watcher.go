@@ -0,0 +1,243 @@
+package argocd
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/events"
+)
+
+const (
+	applicationResource = "argoproj.io/v1alpha1/applications"
+
+	syncStatusSynced    = "Synced"
+	syncStatusOutOfSync = "OutOfSync"
+	syncStatusUnknown   = "Unknown"
+
+	healthStatusHealthy  = "Healthy"
+	healthStatusDegraded = "Degraded"
+	healthStatusMissing  = "Missing"
+	healthStatusUnknown  = "Unknown"
+
+	recoveredReason = "Recovered"
+)
+
+var applicationGVR = schema.GroupVersionResource{
+	Group:    "argoproj.io",
+	Version:  "v1alpha1",
+	Resource: "applications",
+}
+
+// problemSyncStatuses and problemHealthStatuses trigger a notification when an Application enters them.
+// Leaving them for the Synced or Healthy status is notified as a recovery.
+var (
+	problemSyncStatuses = map[string]struct{}{
+		syncStatusOutOfSync: {},
+		syncStatusUnknown:   {},
+	}
+	problemHealthStatuses = map[string]struct{}{
+		healthStatusDegraded: {},
+		healthStatusMissing:  {},
+		healthStatusUnknown:  {},
+	}
+)
+
+// EventHandler handles events received from external sources.
+type EventHandler interface {
+	HandleExternalEvent(ctx context.Context, event events.Event, sources []string)
+}
+
+// Watcher watches Argo CD Applications and sends events on sync and health status transitions.
+type Watcher struct {
+	log          logrus.FieldLogger
+	clusterName  string
+	dynamicCli   dynamic.Interface
+	mapper       meta.RESTMapper
+	resyncPeriod time.Duration
+	sources      []string
+	handler      EventHandler
+}
+
+// applicationStatus holds the Application status fields relevant for the notifications.
+type applicationStatus struct {
+	Sync             string
+	Health           string
+	HealthMessage    string
+	Revision         string
+	OperationMessage string
+}
+
+// NewWatcher returns a new Watcher instance for the source bindings with enabled Argo CD source.
+func NewWatcher(log logrus.FieldLogger, clusterName string, dynamicCli dynamic.Interface, mapper meta.RESTMapper, resyncPeriod time.Duration, sources map[string]config.Sources, handler EventHandler) *Watcher {
+	var enabled []string
+	for name, src := range sources {
+		if src.ArgoCD.Enabled {
+			enabled = append(enabled, name)
+		}
+	}
+	sort.Strings(enabled)
+
+	return &Watcher{
+		log:          log,
+		clusterName:  clusterName,
+		dynamicCli:   dynamicCli,
+		mapper:       mapper,
+		resyncPeriod: resyncPeriod,
+		sources:      enabled,
+		handler:      handler,
+	}
+}
+
+// Enabled returns true if any source binding has Argo CD source enabled.
+func (w *Watcher) Enabled() bool {
+	return len(w.sources) > 0
+}
+
+// Start starts watching Argo CD Applications. It blocks until the context is cancelled.
+func (w *Watcher) Start(ctx context.Context) error {
+	if _, err := w.mapper.ResourcesFor(applicationGVR); err != nil {
+		w.log.Warnf("Argo CD Application resource is not available in the cluster, skipping watching Applications: %s", err.Error())
+		return nil
+	}
+
+	w.log.Info("Starting Argo CD Applications watcher...")
+	factory := dynamicinformer.NewDynamicSharedInformerFactory(w.dynamicCli, w.resyncPeriod)
+	factory.ForResource(applicationGVR).Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			w.handleUpdate(ctx, oldObj, newObj)
+		},
+	})
+
+	factory.Start(ctx.Done())
+	<-ctx.Done()
+	return nil
+}
+
+func (w *Watcher) handleUpdate(ctx context.Context, oldObj, newObj interface{}) {
+	oldApp, ok := oldObj.(*unstructured.Unstructured)
+	if !ok {
+		return
+	}
+	newApp, ok := newObj.(*unstructured.Unstructured)
+	if !ok {
+		return
+	}
+
+	event, ok := w.eventFor(newApp, statusFrom(oldApp), statusFrom(newApp))
+	if !ok {
+		return
+	}
+
+	w.handler.HandleExternalEvent(ctx, event, w.sources)
+}
+
+// eventFor returns an event for the Application status transition. It returns false if the transition is not worth notifying.
+func (w *Watcher) eventFor(app *unstructured.Unstructured, old, current applicationStatus) (events.Event, bool) {
+	var messages []string
+
+	healthChanged, degraded := transition(problemHealthStatuses, healthStatusHealthy, old.Health, current.Health)
+	if healthChanged {
+		messages = append(messages, fmt.Sprintf("Health status changed from %s to %s.", old.Health, current.Health))
+	}
+	syncChanged, outOfSync := transition(problemSyncStatuses, syncStatusSynced, old.Sync, current.Sync)
+	if syncChanged {
+		messages = append(messages, fmt.Sprintf("Sync status changed from %s to %s.", old.Sync, current.Sync))
+	}
+
+	if len(messages) == 0 {
+		return events.Event{}, false
+	}
+
+	if current.HealthMessage != "" {
+		messages = append(messages, current.HealthMessage)
+	}
+	if current.OperationMessage != "" {
+		messages = append(messages, fmt.Sprintf("Last operation: %s", current.OperationMessage))
+	}
+	if current.Revision != "" {
+		messages = append(messages, fmt.Sprintf("Revision: %s", current.Revision))
+	}
+
+	event := events.Event{
+		TypeMeta:  metaV1.TypeMeta{Kind: app.GetKind(), APIVersion: app.GetAPIVersion()},
+		Name:      app.GetName(),
+		Namespace: app.GetNamespace(),
+		Cluster:   w.clusterName,
+		Resource:  applicationResource,
+		TimeStamp: time.Now(),
+		Object:    app,
+		Messages:  messages,
+	}
+
+	switch {
+	case degraded:
+		event.Type, event.Level, event.Reason = config.ErrorEvent, config.Error, current.Health
+	case outOfSync:
+		event.Type, event.Level, event.Reason = config.WarningEvent, config.Warn, current.Sync
+	default:
+		event.Type, event.Level, event.Reason = config.InfoEvent, config.Info, recoveredReason
+	}
+	event.Title = fmt.Sprintf("Argo CD Application %s", event.Reason)
+	event.SuggestedCommands = suggestedCommands(app.GetName(), degraded, outOfSync)
+
+	return event, true
+}
+
+func suggestedCommands(name string, degraded, outOfSync bool) []string {
+	out := []string{fmt.Sprintf("argocd app get %s", name)}
+	if outOfSync {
+		out = append(out,
+			fmt.Sprintf("argocd app diff %s", name),
+			fmt.Sprintf("argocd app sync %s", name),
+		)
+	}
+	if degraded {
+		out = append(out,
+			fmt.Sprintf("argocd app history %s", name),
+			fmt.Sprintf("argocd app rollback %s", name),
+		)
+	}
+	return out
+}
+
+func statusFrom(app *unstructured.Unstructured) applicationStatus {
+	str := func(fields ...string) string {
+		val, _, _ := unstructured.NestedString(app.Object, fields...)
+		return val
+	}
+
+	return applicationStatus{
+		Sync:             str("status", "sync", "status"),
+		Health:           str("status", "health", "status"),
+		HealthMessage:    str("status", "health", "message"),
+		Revision:         str("status", "sync", "revision"),
+		OperationMessage: str("status", "operationState", "message"),
+	}
+}
+
+// transition returns true if the status change should be notified, that is, when the status becomes a problem,
+// or when it recovers from a problem to the expected status. The second value indicates if the new status is a problem.
+func transition(problemStatuses map[string]struct{}, expected, from, to string) (bool, bool) {
+	if from == to || from == "" || to == "" {
+		return false, false
+	}
+
+	if _, isProblem := problemStatuses[to]; isProblem {
+		return true, true
+	}
+
+	_, wasProblem := problemStatuses[from]
+	return to == expected && wasProblem, false
+}
@@ -0,0 +1,150 @@
+package argocd
+
+import (
+	"testing"
+
+	logtest "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+func TestWatcherEventFor(t *testing.T) {
+	tests := []struct {
+		name     string
+		old      applicationStatus
+		current  applicationStatus
+		expected bool
+
+		expectedType     config.EventType
+		expectedLevel    config.Level
+		expectedReason   string
+		expectedMessages []string
+		expectedCommands []string
+	}{
+		{
+			name:           "Synced to OutOfSync",
+			old:            applicationStatus{Sync: "Synced", Health: "Healthy"},
+			current:        applicationStatus{Sync: "OutOfSync", Health: "Healthy", Revision: "abc123"},
+			expected:       true,
+			expectedType:   config.WarningEvent,
+			expectedLevel:  config.Warn,
+			expectedReason: "OutOfSync",
+			expectedMessages: []string{
+				"Sync status changed from Synced to OutOfSync.",
+				"Revision: abc123",
+			},
+			expectedCommands: []string{
+				"argocd app get guestbook",
+				"argocd app diff guestbook",
+				"argocd app sync guestbook",
+			},
+		},
+		{
+			name:           "Healthy to Degraded and OutOfSync",
+			old:            applicationStatus{Sync: "Synced", Health: "Healthy"},
+			current:        applicationStatus{Sync: "OutOfSync", Health: "Degraded", HealthMessage: "Deployment exceeded its progress deadline"},
+			expected:       true,
+			expectedType:   config.ErrorEvent,
+			expectedLevel:  config.Error,
+			expectedReason: "Degraded",
+			expectedMessages: []string{
+				"Health status changed from Healthy to Degraded.",
+				"Sync status changed from Synced to OutOfSync.",
+				"Deployment exceeded its progress deadline",
+			},
+			expectedCommands: []string{
+				"argocd app get guestbook",
+				"argocd app diff guestbook",
+				"argocd app sync guestbook",
+				"argocd app history guestbook",
+				"argocd app rollback guestbook",
+			},
+		},
+		{
+			name:           "Recovered from Degraded",
+			old:            applicationStatus{Sync: "Synced", Health: "Degraded"},
+			current:        applicationStatus{Sync: "Synced", Health: "Healthy"},
+			expected:       true,
+			expectedType:   config.InfoEvent,
+			expectedLevel:  config.Info,
+			expectedReason: "Recovered",
+			expectedMessages: []string{
+				"Health status changed from Degraded to Healthy.",
+			},
+			expectedCommands: []string{
+				"argocd app get guestbook",
+			},
+		},
+		{
+			name:     "Progressing to Healthy",
+			old:      applicationStatus{Sync: "Synced", Health: "Progressing"},
+			current:  applicationStatus{Sync: "Synced", Health: "Healthy"},
+			expected: false,
+		},
+		{
+			name:     "No change",
+			old:      applicationStatus{Sync: "OutOfSync", Health: "Degraded"},
+			current:  applicationStatus{Sync: "OutOfSync", Health: "Degraded"},
+			expected: false,
+		},
+		{
+			name:     "Status not reported yet",
+			old:      applicationStatus{},
+			current:  applicationStatus{Sync: "OutOfSync", Health: "Missing"},
+			expected: false,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// given
+			logger, _ := logtest.NewNullLogger()
+			watcher := NewWatcher(logger, "dev", nil, nil, 0, map[string]config.Sources{
+				"argocd": {ArgoCD: config.ArgoCDSource{Enabled: true}},
+			}, nil)
+			app := &unstructured.Unstructured{}
+			app.SetAPIVersion("argoproj.io/v1alpha1")
+			app.SetKind("Application")
+			app.SetName("guestbook")
+			app.SetNamespace("argocd")
+
+			// when
+			event, ok := watcher.eventFor(app, tc.old, tc.current)
+
+			// then
+			require.Equal(t, tc.expected, ok)
+			if !tc.expected {
+				return
+			}
+			assert.Equal(t, "Application", event.Kind)
+			assert.Equal(t, "guestbook", event.Name)
+			assert.Equal(t, "argocd", event.Namespace)
+			assert.Equal(t, "dev", event.Cluster)
+			assert.Equal(t, tc.expectedType, event.Type)
+			assert.Equal(t, tc.expectedLevel, event.Level)
+			assert.Equal(t, tc.expectedReason, event.Reason)
+			assert.Equal(t, "Argo CD Application "+tc.expectedReason, event.Title)
+			assert.Equal(t, tc.expectedMessages, event.Messages)
+			assert.Equal(t, tc.expectedCommands, event.SuggestedCommands)
+		})
+	}
+}
+
+func TestWatcherEnabled(t *testing.T) {
+	// given
+	logger, _ := logtest.NewNullLogger()
+	sources := map[string]config.Sources{
+		"b-argocd": {ArgoCD: config.ArgoCDSource{Enabled: true}},
+		"a-argocd": {ArgoCD: config.ArgoCDSource{Enabled: true}},
+		"k8s":      {},
+	}
+
+	// when
+	watcher := NewWatcher(logger, "dev", nil, nil, 0, sources, nil)
+
+	// then
+	assert.True(t, watcher.Enabled())
+	assert.Equal(t, []string{"a-argocd", "b-argocd"}, watcher.sources)
+}
@@ -0,0 +1,266 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/events"
+)
+
+const (
+	// Path is the path of the source server endpoint, which receives the Kubernetes audit webhook payloads.
+	Path = "/sources/audit"
+
+	auditKind     = "AuditEvent"
+	auditResource = "audit/events"
+
+	// responseCompleteStage is the only stage reported, so a single request doesn't generate multiple events.
+	responseCompleteStage = "ResponseComplete"
+
+	// maxPayloadSize limits the size of the webhook payload. API server sends the audit events in batches, so the payload can be large.
+	maxPayloadSize = 10 << 20
+)
+
+// EventHandler handles events received from external sources.
+type EventHandler interface {
+	HandleExternalEvent(ctx context.Context, event events.Event, sources []string)
+}
+
+// Receiver receives Kubernetes audit webhook payloads and converts the audit events matching the configured rules into events.
+// See https://kubernetes.io/docs/tasks/debug/debug-cluster/audit/#webhook-backend.
+type Receiver struct {
+	ctx         context.Context
+	log         logrus.FieldLogger
+	clusterName string
+	sources     map[string]config.AuditSource
+	handler     EventHandler
+}
+
+// EventList is the audit webhook payload.
+type EventList struct {
+	Items []Event `json:"items"`
+}
+
+// Event is a single audit event sent in the webhook payload.
+type Event struct {
+	AuditID                  string            `json:"auditID"`
+	Stage                    string            `json:"stage"`
+	RequestURI               string            `json:"requestURI"`
+	Verb                     string            `json:"verb"`
+	User                     UserInfo          `json:"user"`
+	ImpersonatedUser         *UserInfo         `json:"impersonatedUser,omitempty"`
+	SourceIPs                []string          `json:"sourceIPs"`
+	UserAgent                string            `json:"userAgent"`
+	ObjectRef                *ObjectReference  `json:"objectRef,omitempty"`
+	ResponseStatus           *ResponseStatus   `json:"responseStatus,omitempty"`
+	RequestReceivedTimestamp metaV1.MicroTime  `json:"requestReceivedTimestamp"`
+	Annotations              map[string]string `json:"annotations"`
+}
+
+// UserInfo holds the user details of the audit event.
+type UserInfo struct {
+	Username string   `json:"username"`
+	Groups   []string `json:"groups"`
+}
+
+// ObjectReference holds the details of the object the request is about.
+type ObjectReference struct {
+	Resource    string `json:"resource"`
+	Namespace   string `json:"namespace"`
+	Name        string `json:"name"`
+	APIGroup    string `json:"apiGroup"`
+	APIVersion  string `json:"apiVersion"`
+	Subresource string `json:"subresource"`
+}
+
+// ResponseStatus holds the response status of the audited request.
+type ResponseStatus struct {
+	Code int `json:"code"`
+}
+
+// NewReceiver returns a new Receiver instance for the source bindings with enabled audit source.
+// Given context is used for sending the events, as they are sent after the webhook request is handled.
+func NewReceiver(ctx context.Context, log logrus.FieldLogger, clusterName string, sources map[string]config.Sources, handler EventHandler) *Receiver {
+	enabled := map[string]config.AuditSource{}
+	for name, src := range sources {
+		if src.Audit.Enabled {
+			enabled[name] = src.Audit
+		}
+	}
+
+	return &Receiver{
+		ctx:         ctx,
+		log:         log,
+		clusterName: clusterName,
+		sources:     enabled,
+		handler:     handler,
+	}
+}
+
+// Enabled returns true if any source binding has audit source enabled.
+func (r *Receiver) Enabled() bool {
+	return len(r.sources) > 0
+}
+
+// ServeHTTP handles the audit webhook request.
+func (r *Receiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var payload EventList
+	if err := json.NewDecoder(http.MaxBytesReader(w, req.Body, maxPayloadSize)).Decode(&payload); err != nil {
+		r.log.Errorf("while decoding audit payload: %s", err.Error())
+		http.Error(w, "invalid payload", http.StatusBadRequest)
+		return
+	}
+
+	for _, item := range payload.Items {
+		if item.Stage != responseCompleteStage {
+			continue
+		}
+		r.handleAuditEvent(item)
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// handleAuditEvent sends one event per matched rule. Source bindings with the same rule get the same event.
+func (r *Receiver) handleAuditEvent(item Event) {
+	sourcesPerRule := map[string][]string{}
+	rules := map[string]config.AuditRule{}
+	for name, src := range r.sources {
+		rule, found := matchingRule(src.Rules, item)
+		if !found {
+			continue
+		}
+		sourcesPerRule[rule.Name] = append(sourcesPerRule[rule.Name], name)
+		rules[rule.Name] = rule
+	}
+
+	ruleNames := make([]string, 0, len(rules))
+	for name := range rules {
+		ruleNames = append(ruleNames, name)
+	}
+	sort.Strings(ruleNames)
+
+	for _, name := range ruleNames {
+		sources := sourcesPerRule[name]
+		sort.Strings(sources)
+		r.handler.HandleExternalEvent(r.ctx, r.eventFor(item, rules[name]), sources)
+	}
+}
+
+func (r *Receiver) eventFor(item Event, rule config.AuditRule) events.Event {
+	event := events.Event{
+		TypeMeta:  metaV1.TypeMeta{Kind: auditKind},
+		Title:     fmt.Sprintf("Audit: %s", rule.Name),
+		Reason:    rule.Name,
+		Level:     rule.Level,
+		Cluster:   r.clusterName,
+		Resource:  auditResource,
+		TimeStamp: item.RequestReceivedTimestamp.Time,
+	}
+	if event.Level == "" {
+		event.Level = config.Warn
+	}
+	switch event.Level {
+	case config.Critical, config.Error:
+		event.Type = config.ErrorEvent
+	case config.Warn:
+		event.Type = config.WarningEvent
+	default:
+		event.Type = config.InfoEvent
+	}
+	if event.TimeStamp.IsZero() {
+		event.TimeStamp = time.Now()
+	}
+	if ref := item.ObjectRef; ref != nil {
+		event.Name = ref.Name
+		event.Namespace = ref.Namespace
+	}
+
+	if rule.Description != "" {
+		event.Messages = append(event.Messages, rule.Description)
+	}
+
+	user := item.User.Username
+	if len(item.User.Groups) > 0 {
+		user = fmt.Sprintf("%s (groups: %s)", user, strings.Join(item.User.Groups, ", "))
+	}
+	event.Messages = append(event.Messages, fmt.Sprintf("User: %s", user))
+	if item.ImpersonatedUser != nil {
+		event.Messages = append(event.Messages, fmt.Sprintf("Impersonated user: %s", item.ImpersonatedUser.Username))
+	}
+	event.Messages = append(event.Messages, fmt.Sprintf("Request: %s %s", strings.ToUpper(item.Verb), item.RequestURI))
+	if item.ResponseStatus != nil {
+		event.Messages = append(event.Messages, fmt.Sprintf("Response code: %d", item.ResponseStatus.Code))
+	}
+	if len(item.SourceIPs) > 0 {
+		event.Messages = append(event.Messages, fmt.Sprintf("Source IPs: %s", strings.Join(item.SourceIPs, ", ")))
+	}
+	if item.UserAgent != "" {
+		event.Messages = append(event.Messages, fmt.Sprintf("User agent: %s", item.UserAgent))
+	}
+	event.Messages = append(event.Messages, fmt.Sprintf("Audit ID: %s", item.AuditID))
+
+	return event
+}
+
+// matchingRule returns the first rule matching a given audit event.
+func matchingRule(rules []config.AuditRule, item Event) (config.AuditRule, bool) {
+	for _, rule := range rules {
+		if ruleMatches(rule, item) {
+			return rule, true
+		}
+	}
+	return config.AuditRule{}, false
+}
+
+// ruleMatches returns true if an audit event matches all criteria of a given rule. Empty criteria match all events.
+func ruleMatches(rule config.AuditRule, item Event) bool {
+	if len(rule.Verbs) > 0 && !containsFold(rule.Verbs, item.Verb) {
+		return false
+	}
+
+	var ref ObjectReference
+	if item.ObjectRef != nil {
+		ref = *item.ObjectRef
+	}
+	if len(rule.Resources) > 0 {
+		resource := ref.Resource
+		if ref.Subresource != "" {
+			resource = fmt.Sprintf("%s/%s", resource, ref.Subresource)
+		}
+		if !containsFold(rule.Resources, resource) {
+			return false
+		}
+	}
+	if rule.Namespaces.IsConfigured() && !rule.Namespaces.IsAllowed(ref.Namespace) {
+		return false
+	}
+	if rule.Users.IsConfigured() && !rule.Users.IsAllowed(item.User.Username) {
+		return false
+	}
+	return true
+}
+
+func containsFold(items []string, value string) bool {
+	for _, item := range items {
+		if strings.EqualFold(item, value) {
+			return true
+		}
+	}
+	return false
+}
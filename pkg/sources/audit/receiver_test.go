@@ -0,0 +1,176 @@
+package audit
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	logtest "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/events"
+)
+
+const fixPayload = `{
+  "kind": "EventList",
+  "apiVersion": "audit.k8s.io/v1",
+  "items": [
+    {
+      "auditID": "a1",
+      "stage": "RequestReceived",
+      "requestURI": "/api/v1/namespaces/prod/pods/api/exec?command=sh",
+      "verb": "create",
+      "user": {"username": "alice"},
+      "objectRef": {"resource": "pods", "namespace": "prod", "name": "api", "subresource": "exec"}
+    },
+    {
+      "auditID": "a1",
+      "stage": "ResponseComplete",
+      "requestURI": "/api/v1/namespaces/prod/pods/api/exec?command=sh",
+      "verb": "create",
+      "user": {"username": "alice", "groups": ["developers", "system:authenticated"]},
+      "sourceIPs": ["10.0.0.1"],
+      "userAgent": "kubectl/v1.25.0",
+      "objectRef": {"resource": "pods", "namespace": "prod", "name": "api", "subresource": "exec"},
+      "responseStatus": {"code": 101},
+      "requestReceivedTimestamp": "2022-10-10T10:00:00.000000Z"
+    },
+    {
+      "auditID": "a2",
+      "stage": "ResponseComplete",
+      "requestURI": "/api/v1/namespaces/prod/secrets/db",
+      "verb": "get",
+      "user": {"username": "system:serviceaccount:default:app"},
+      "objectRef": {"resource": "secrets", "namespace": "prod", "name": "db"},
+      "responseStatus": {"code": 200}
+    },
+    {
+      "auditID": "a3",
+      "stage": "ResponseComplete",
+      "requestURI": "/api/v1/namespaces/kube-system/secrets/token",
+      "verb": "get",
+      "user": {"username": "system:serviceaccount:kube-system:coredns"},
+      "objectRef": {"resource": "secrets", "namespace": "kube-system", "name": "token"},
+      "responseStatus": {"code": 200}
+    }
+  ]
+}`
+
+func TestReceiverServeHTTP(t *testing.T) {
+	// given
+	logger, _ := logtest.NewNullLogger()
+	handler := &fakeEventHandler{}
+	execRule := config.AuditRule{
+		Name:        "PodExecInProd",
+		Description: "Someone executed a command in a production Pod.",
+		Verbs:       []string{"create"},
+		Resources:   []string{"pods/exec"},
+		Namespaces:  config.Namespaces{Include: []string{"^prod$"}},
+	}
+	secretsRule := config.AuditRule{
+		Name:      "SecretReadByUnknownServiceAccount",
+		Level:     config.Error,
+		Verbs:     []string{"get", "list"},
+		Resources: []string{"secrets"},
+		Users: config.AuditUsers{
+			Include: []string{"^system:serviceaccount:"},
+			Exclude: []string{"^system:serviceaccount:kube-system:"},
+		},
+	}
+	receiver := NewReceiver(context.Background(), logger, "dev", map[string]config.Sources{
+		"security":  {Audit: config.AuditSource{Enabled: true, Rules: []config.AuditRule{execRule, secretsRule}}},
+		"exec-only": {Audit: config.AuditSource{Enabled: true, Rules: []config.AuditRule{execRule}}},
+		"no-audit":  {},
+	}, handler)
+
+	req := httptest.NewRequest(http.MethodPost, Path, strings.NewReader(fixPayload))
+	rec := httptest.NewRecorder()
+
+	// when
+	receiver.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusOK, rec.Code)
+	require.Len(t, handler.events, 2)
+	assert.Equal(t, [][]string{{"exec-only", "security"}, {"security"}}, handler.sources)
+
+	exec := handler.events[0]
+	assert.Equal(t, "Audit: PodExecInProd", exec.Title)
+	assert.Equal(t, "AuditEvent", exec.Kind)
+	assert.Equal(t, "api", exec.Name)
+	assert.Equal(t, "prod", exec.Namespace)
+	assert.Equal(t, config.Warn, exec.Level)
+	assert.Equal(t, config.WarningEvent, exec.Type)
+	assert.Equal(t, time.Date(2022, 10, 10, 10, 0, 0, 0, time.UTC), exec.TimeStamp.UTC())
+	assert.Equal(t, []string{
+		"Someone executed a command in a production Pod.",
+		"User: alice (groups: developers, system:authenticated)",
+		"Request: CREATE /api/v1/namespaces/prod/pods/api/exec?command=sh",
+		"Response code: 101",
+		"Source IPs: 10.0.0.1",
+		"User agent: kubectl/v1.25.0",
+		"Audit ID: a1",
+	}, exec.Messages)
+
+	secret := handler.events[1]
+	assert.Equal(t, "Audit: SecretReadByUnknownServiceAccount", secret.Title)
+	assert.Equal(t, "SecretReadByUnknownServiceAccount", secret.Reason)
+	assert.Equal(t, config.ErrorEvent, secret.Type)
+	assert.Equal(t, "db", secret.Name)
+}
+
+func TestReceiverServeHTTPInvalidRequest(t *testing.T) {
+	tests := []struct {
+		name         string
+		method       string
+		body         string
+		expectedCode int
+	}{
+		{
+			name:         "Invalid method",
+			method:       http.MethodGet,
+			expectedCode: http.StatusMethodNotAllowed,
+		},
+		{
+			name:         "Invalid payload",
+			method:       http.MethodPost,
+			body:         "{",
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// given
+			logger, _ := logtest.NewNullLogger()
+			handler := &fakeEventHandler{}
+			receiver := NewReceiver(context.Background(), logger, "dev", map[string]config.Sources{
+				"all": {Audit: config.AuditSource{Enabled: true, Rules: []config.AuditRule{{Name: "All"}}}},
+			}, handler)
+
+			req := httptest.NewRequest(tc.method, Path, strings.NewReader(tc.body))
+			rec := httptest.NewRecorder()
+
+			// when
+			receiver.ServeHTTP(rec, req)
+
+			// then
+			assert.Equal(t, tc.expectedCode, rec.Code)
+			assert.Empty(t, handler.events)
+		})
+	}
+}
+
+type fakeEventHandler struct {
+	events  []events.Event
+	sources [][]string
+}
+
+func (f *fakeEventHandler) HandleExternalEvent(_ context.Context, event events.Event, sources []string) {
+	f.events = append(f.events, event)
+	f.sources = append(f.sources, sources)
+}
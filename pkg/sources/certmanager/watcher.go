@@ -0,0 +1,339 @@
+package certmanager
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/events"
+)
+
+const (
+	certificateResource = "cert-manager.io/v1/certificates"
+
+	// DefaultExpiryWarningDays is used when the source doesn't specify the number of days.
+	DefaultExpiryWarningDays = 14
+
+	// expiryCheckInterval defines how often all certificates are checked for the upcoming expiry.
+	expiryCheckInterval = time.Hour
+
+	reasonExpiresSoon   = "ExpiresSoon"
+	reasonExpired       = "Expired"
+	reasonRenewalFailed = "RenewalFailed"
+
+	day = 24 * time.Hour
+)
+
+var certificateGVR = schema.GroupVersionResource{
+	Group:    "cert-manager.io",
+	Version:  "v1",
+	Resource: "certificates",
+}
+
+// EventHandler handles events received from external sources.
+type EventHandler interface {
+	HandleExternalEvent(ctx context.Context, event events.Event, sources []string)
+}
+
+// Watcher watches cert-manager Certificates. It sends warning events when certificates are about to expire,
+// and error events when certificates expired or their renewal failed.
+type Watcher struct {
+	log          logrus.FieldLogger
+	clusterName  string
+	dynamicCli   dynamic.Interface
+	mapper       meta.RESTMapper
+	resyncPeriod time.Duration
+	sources      map[string]config.CertManagerSource
+	handler      EventHandler
+	now          func() time.Time
+
+	// notified holds the last expiry notification reason per source and certificate, so it's sent only once.
+	notified   map[string]string
+	notifiedMu sync.Mutex
+}
+
+// certificate holds the Certificate fields relevant for the notifications.
+type certificate struct {
+	obj             *unstructured.Unstructured
+	NotAfter        time.Time
+	LastFailureTime string
+	SecretName      string
+	DNSNames        []string
+	FailureMessage  string
+}
+
+// NewWatcher returns a new Watcher instance for the source bindings with enabled cert-manager source.
+func NewWatcher(log logrus.FieldLogger, clusterName string, dynamicCli dynamic.Interface, mapper meta.RESTMapper, resyncPeriod time.Duration, sources map[string]config.Sources, handler EventHandler) *Watcher {
+	enabled := map[string]config.CertManagerSource{}
+	for name, src := range sources {
+		if !src.CertManager.Enabled {
+			continue
+		}
+		if src.CertManager.ExpiryWarningDays <= 0 {
+			src.CertManager.ExpiryWarningDays = DefaultExpiryWarningDays
+		}
+		enabled[name] = src.CertManager
+	}
+
+	return &Watcher{
+		log:          log,
+		clusterName:  clusterName,
+		dynamicCli:   dynamicCli,
+		mapper:       mapper,
+		resyncPeriod: resyncPeriod,
+		sources:      enabled,
+		handler:      handler,
+		now:          time.Now,
+		notified:     map[string]string{},
+	}
+}
+
+// Enabled returns true if any source binding has cert-manager source enabled.
+func (w *Watcher) Enabled() bool {
+	return len(w.sources) > 0
+}
+
+// Start starts watching Certificates. It blocks until the context is cancelled.
+func (w *Watcher) Start(ctx context.Context) error {
+	if _, err := w.mapper.ResourcesFor(certificateGVR); err != nil {
+		w.log.Warnf("cert-manager Certificate resource is not available in the cluster, skipping watching Certificates: %s", err.Error())
+		return nil
+	}
+
+	w.log.Info("Starting cert-manager Certificates watcher...")
+	factory := dynamicinformer.NewDynamicSharedInformerFactory(w.dynamicCli, w.resyncPeriod)
+	informer := factory.ForResource(certificateGVR).Informer()
+	informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			w.handleUpdate(ctx, oldObj, newObj)
+		},
+		DeleteFunc: func(obj interface{}) {
+			if cert, ok := obj.(*unstructured.Unstructured); ok {
+				w.forget(cert)
+			}
+		},
+	})
+
+	factory.Start(ctx.Done())
+	if !cache.WaitForCacheSync(ctx.Done(), informer.HasSynced) {
+		return nil
+	}
+
+	ticker := time.NewTicker(expiryCheckInterval)
+	defer ticker.Stop()
+	for {
+		for _, obj := range informer.GetStore().List() {
+			if cert, ok := obj.(*unstructured.Unstructured); ok {
+				w.checkExpiry(ctx, certificateFrom(cert))
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func (w *Watcher) handleUpdate(ctx context.Context, oldObj, newObj interface{}) {
+	oldCert, ok := oldObj.(*unstructured.Unstructured)
+	if !ok {
+		return
+	}
+	newCert, ok := newObj.(*unstructured.Unstructured)
+	if !ok {
+		return
+	}
+
+	old, current := certificateFrom(oldCert), certificateFrom(newCert)
+	if current.LastFailureTime != "" && current.LastFailureTime != old.LastFailureTime {
+		w.notify(ctx, current, reasonRenewalFailed, w.sourcesFor(current, nil))
+	}
+
+	w.checkExpiry(ctx, current)
+}
+
+// checkExpiry sends an event to the source bindings for which the certificate is within the expiry warning period.
+// Each source binding is notified once per certificate expiry date and reason.
+func (w *Watcher) checkExpiry(ctx context.Context, cert certificate) {
+	if cert.NotAfter.IsZero() {
+		return
+	}
+
+	remaining := cert.NotAfter.Sub(w.now())
+	reason := reasonExpiresSoon
+	if remaining <= 0 {
+		reason = reasonExpired
+	}
+
+	sources := w.sourcesFor(cert, func(src config.CertManagerSource) bool {
+		return remaining <= time.Duration(src.ExpiryWarningDays)*day
+	})
+
+	var toNotify []string
+	w.notifiedMu.Lock()
+	for _, name := range sources {
+		key := notifiedKey(name, cert.obj)
+		state := fmt.Sprintf("%s/%s", cert.NotAfter.UTC().Format(time.RFC3339), reason)
+		if w.notified[key] == state {
+			continue
+		}
+		w.notified[key] = state
+		toNotify = append(toNotify, name)
+	}
+	w.notifiedMu.Unlock()
+
+	if len(toNotify) == 0 {
+		return
+	}
+	w.notify(ctx, cert, reason, toNotify)
+}
+
+func (w *Watcher) notify(ctx context.Context, cert certificate, reason string, sources []string) {
+	if len(sources) == 0 {
+		return
+	}
+	w.handler.HandleExternalEvent(ctx, w.eventFor(cert, reason), sources)
+}
+
+func (w *Watcher) eventFor(cert certificate, reason string) events.Event {
+	name, namespace := cert.obj.GetName(), cert.obj.GetNamespace()
+	event := events.Event{
+		TypeMeta:  metaV1.TypeMeta{Kind: cert.obj.GetKind(), APIVersion: cert.obj.GetAPIVersion()},
+		Name:      name,
+		Namespace: namespace,
+		Reason:    reason,
+		Cluster:   w.clusterName,
+		Resource:  certificateResource,
+		TimeStamp: w.now(),
+		Object:    cert.obj,
+		SuggestedCommands: []string{
+			fmt.Sprintf("kubectl describe certificate %s --namespace %s", name, namespace),
+			fmt.Sprintf("cmctl status certificate %s --namespace %s", name, namespace),
+		},
+	}
+
+	switch reason {
+	case reasonExpiresSoon:
+		days := int(cert.NotAfter.Sub(w.now()) / day)
+		event.Type, event.Level = config.WarningEvent, config.Warn
+		event.Title = fmt.Sprintf("Certificate %s expires in %d day(s)", name, days)
+		event.Messages = append(event.Messages, fmt.Sprintf("Certificate expires at %s.", cert.NotAfter.UTC().Format(time.RFC3339)))
+		event.SuggestedCommands = append(event.SuggestedCommands, fmt.Sprintf("cmctl renew %s --namespace %s", name, namespace))
+	case reasonExpired:
+		event.Type, event.Level = config.ErrorEvent, config.Error
+		event.Title = fmt.Sprintf("Certificate %s expired", name)
+		event.Messages = append(event.Messages, fmt.Sprintf("Certificate expired at %s.", cert.NotAfter.UTC().Format(time.RFC3339)))
+		event.SuggestedCommands = append(event.SuggestedCommands, fmt.Sprintf("cmctl renew %s --namespace %s", name, namespace))
+	case reasonRenewalFailed:
+		event.Type, event.Level = config.ErrorEvent, config.Error
+		event.Title = fmt.Sprintf("Certificate %s renewal failed", name)
+		if cert.FailureMessage != "" {
+			event.Messages = append(event.Messages, cert.FailureMessage)
+		}
+		if !cert.NotAfter.IsZero() {
+			event.Messages = append(event.Messages, fmt.Sprintf("Current certificate expires at %s.", cert.NotAfter.UTC().Format(time.RFC3339)))
+		}
+	}
+
+	if len(cert.DNSNames) > 0 {
+		event.Messages = append(event.Messages, fmt.Sprintf("DNS names: %s", strings.Join(cert.DNSNames, ", ")))
+	}
+	if cert.SecretName != "" {
+		event.Messages = append(event.Messages, fmt.Sprintf("Secret: %s", cert.SecretName))
+	}
+
+	return event
+}
+
+// sourcesFor returns sorted names of the source bindings, which watch the certificate namespace and match a given predicate.
+func (w *Watcher) sourcesFor(cert certificate, predicate func(src config.CertManagerSource) bool) []string {
+	var out []string
+	for name, src := range w.sources {
+		if src.Namespaces.IsConfigured() && !src.Namespaces.IsAllowed(cert.obj.GetNamespace()) {
+			continue
+		}
+		if predicate != nil && !predicate(src) {
+			continue
+		}
+		out = append(out, name)
+	}
+	sort.Strings(out)
+	return out
+}
+
+func (w *Watcher) forget(obj *unstructured.Unstructured) {
+	w.notifiedMu.Lock()
+	defer w.notifiedMu.Unlock()
+	for name := range w.sources {
+		delete(w.notified, notifiedKey(name, obj))
+	}
+}
+
+func notifiedKey(source string, obj *unstructured.Unstructured) string {
+	return fmt.Sprintf("%s/%s/%s", source, obj.GetNamespace(), obj.GetName())
+}
+
+func certificateFrom(obj *unstructured.Unstructured) certificate {
+	str := func(fields ...string) string {
+		val, _, _ := unstructured.NestedString(obj.Object, fields...)
+		return val
+	}
+
+	out := certificate{
+		obj:             obj,
+		LastFailureTime: str("status", "lastFailureTime"),
+		SecretName:      str("spec", "secretName"),
+	}
+	out.DNSNames, _, _ = unstructured.NestedStringSlice(obj.Object, "spec", "dnsNames")
+
+	if notAfter := str("status", "notAfter"); notAfter != "" {
+		if parsed, err := time.Parse(time.RFC3339, notAfter); err == nil {
+			out.NotAfter = parsed
+		}
+	}
+
+	out.FailureMessage = failureMessage(obj)
+	return out
+}
+
+// failureMessage returns the message of the Issuing or Ready condition, which describes why the renewal failed.
+func failureMessage(obj *unstructured.Unstructured) string {
+	conditions, _, _ := unstructured.NestedSlice(obj.Object, "status", "conditions")
+
+	messages := map[string]string{}
+	for _, item := range conditions {
+		cond, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		condType, _ := cond["type"].(string)
+		status, _ := cond["status"].(string)
+		message, _ := cond["message"].(string)
+		if status == string(metaV1.ConditionTrue) && condType == "Ready" {
+			continue
+		}
+		messages[condType] = message
+	}
+
+	for _, condType := range []string{"Issuing", "Ready"} {
+		if msg := messages[condType]; msg != "" {
+			return msg
+		}
+	}
+	return ""
+}
@@ -0,0 +1,186 @@
+package certmanager
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	logtest "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/events"
+)
+
+var fixNow = time.Date(2022, 10, 10, 10, 0, 0, 0, time.UTC)
+
+func TestWatcherCheckExpiry(t *testing.T) {
+	tests := []struct {
+		name            string
+		notAfter        time.Time
+		expectedSources [][]string
+		expectedReason  string
+		expectedLevel   config.Level
+		expectedTitle   string
+	}{
+		{
+			name:     "Within the default warning period only",
+			notAfter: fixNow.Add(10 * day),
+			expectedSources: [][]string{
+				{"default"},
+			},
+			expectedReason: "ExpiresSoon",
+			expectedLevel:  config.Warn,
+			expectedTitle:  "Certificate web-tls expires in 10 day(s)",
+		},
+		{
+			name:     "Within both warning periods",
+			notAfter: fixNow.Add(2*day + time.Hour),
+			expectedSources: [][]string{
+				{"default", "short"},
+			},
+			expectedReason: "ExpiresSoon",
+			expectedLevel:  config.Warn,
+			expectedTitle:  "Certificate web-tls expires in 2 day(s)",
+		},
+		{
+			name:     "Expired",
+			notAfter: fixNow.Add(-time.Hour),
+			expectedSources: [][]string{
+				{"default", "short"},
+			},
+			expectedReason: "Expired",
+			expectedLevel:  config.Error,
+			expectedTitle:  "Certificate web-tls expired",
+		},
+		{
+			name:     "Not within any warning period",
+			notAfter: fixNow.Add(60 * day),
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// given
+			handler := &fakeEventHandler{}
+			watcher := fixWatcher(handler)
+			cert := certificateFrom(fixCertificate(tc.notAfter, ""))
+
+			// when
+			watcher.checkExpiry(context.Background(), cert)
+			// repeated checks are not notified again
+			watcher.checkExpiry(context.Background(), cert)
+
+			// then
+			assert.Equal(t, tc.expectedSources, handler.sources)
+			if len(tc.expectedSources) == 0 {
+				return
+			}
+			event := handler.events[0]
+			assert.Equal(t, tc.expectedReason, event.Reason)
+			assert.Equal(t, tc.expectedLevel, event.Level)
+			assert.Equal(t, tc.expectedTitle, event.Title)
+			assert.Equal(t, "Certificate", event.Kind)
+			assert.Contains(t, event.Messages, "DNS names: example.com, www.example.com")
+			assert.Contains(t, event.SuggestedCommands, "cmctl renew web-tls --namespace web")
+		})
+	}
+}
+
+func TestWatcherHandleUpdateRenewalFailed(t *testing.T) {
+	// given
+	handler := &fakeEventHandler{}
+	watcher := fixWatcher(handler)
+
+	notAfter := fixNow.Add(30 * day)
+	oldCert := fixCertificate(notAfter, "")
+	newCert := fixCertificate(notAfter, "2022-10-10T09:59:00Z")
+	err := unstructured.SetNestedSlice(newCert.Object, []interface{}{
+		map[string]interface{}{"type": "Ready", "status": "True", "message": "Certificate is up to date and has not expired"},
+		map[string]interface{}{"type": "Issuing", "status": "False", "reason": "Failed", "message": "The certificate request has failed to complete and will be retried"},
+	}, "status", "conditions")
+	require.NoError(t, err)
+
+	// when
+	watcher.handleUpdate(context.Background(), oldCert, newCert)
+	// resync doesn't repeat the notification
+	watcher.handleUpdate(context.Background(), newCert, newCert)
+
+	// then
+	require.Len(t, handler.events, 1)
+	event := handler.events[0]
+	assert.Equal(t, [][]string{{"default", "short"}}, handler.sources)
+	assert.Equal(t, "RenewalFailed", event.Reason)
+	assert.Equal(t, config.ErrorEvent, event.Type)
+	assert.Equal(t, config.Error, event.Level)
+	assert.Equal(t, "Certificate web-tls renewal failed", event.Title)
+	assert.Equal(t, []string{
+		"The certificate request has failed to complete and will be retried",
+		"Current certificate expires at 2022-11-09T10:00:00Z.",
+		"DNS names: example.com, www.example.com",
+		"Secret: web-tls-secret",
+	}, event.Messages)
+}
+
+func TestWatcherSourcesForNamespaces(t *testing.T) {
+	// given
+	logger, _ := logtest.NewNullLogger()
+	watcher := NewWatcher(logger, "dev", nil, nil, 0, map[string]config.Sources{
+		"web-only":   {CertManager: config.CertManagerSource{Enabled: true, Namespaces: config.Namespaces{Include: []string{"web"}}}},
+		"other-only": {CertManager: config.CertManagerSource{Enabled: true, Namespaces: config.Namespaces{Include: []string{"other"}}}},
+	}, nil)
+	cert := certificateFrom(fixCertificate(fixNow, ""))
+
+	// when
+	sources := watcher.sourcesFor(cert, nil)
+
+	// then
+	assert.Equal(t, []string{"web-only"}, sources)
+	assert.Equal(t, DefaultExpiryWarningDays, watcher.sources["web-only"].ExpiryWarningDays)
+}
+
+type fakeEventHandler struct {
+	events  []events.Event
+	sources [][]string
+}
+
+func (f *fakeEventHandler) HandleExternalEvent(_ context.Context, event events.Event, sources []string) {
+	f.events = append(f.events, event)
+	f.sources = append(f.sources, sources)
+}
+
+func fixWatcher(handler EventHandler) *Watcher {
+	logger, _ := logtest.NewNullLogger()
+	watcher := NewWatcher(logger, "dev", nil, nil, 0, map[string]config.Sources{
+		"default":  {CertManager: config.CertManagerSource{Enabled: true}},
+		"short":    {CertManager: config.CertManagerSource{Enabled: true, ExpiryWarningDays: 3}},
+		"disabled": {},
+	}, handler)
+	watcher.now = func() time.Time {
+		return fixNow
+	}
+	return watcher
+}
+
+func fixCertificate(notAfter time.Time, lastFailureTime string) *unstructured.Unstructured {
+	status := map[string]interface{}{
+		"notAfter": notAfter.Format(time.RFC3339),
+	}
+	if lastFailureTime != "" {
+		status["lastFailureTime"] = lastFailureTime
+	}
+
+	cert := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "cert-manager.io/v1",
+		"kind":       "Certificate",
+		"spec": map[string]interface{}{
+			"secretName": "web-tls-secret",
+			"dnsNames":   []interface{}{"example.com", "www.example.com"},
+		},
+		"status": status,
+	}}
+	cert.SetName("web-tls")
+	cert.SetNamespace("web")
+	return cert
+}
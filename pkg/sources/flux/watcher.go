@@ -0,0 +1,257 @@
+package flux
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/events"
+)
+
+const (
+	readyCondition = "Ready"
+
+	conditionTrue  = "True"
+	conditionFalse = "False"
+
+	driftDetectedReason = "DriftDetected"
+	recoveredReason     = "Recovered"
+)
+
+// kind describes a watched Flux resource.
+type kind struct {
+	GroupKind schema.GroupKind
+	// Command is the flux CLI name of the resource, e.g. `kustomization`.
+	Command string
+}
+
+var (
+	kustomizationKind = kind{
+		GroupKind: schema.GroupKind{Group: "kustomize.toolkit.fluxcd.io", Kind: "Kustomization"},
+		Command:   "kustomization",
+	}
+	helmReleaseKind = kind{
+		GroupKind: schema.GroupKind{Group: "helm.toolkit.fluxcd.io", Kind: "HelmRelease"},
+		Command:   "helmrelease",
+	}
+)
+
+// EventHandler handles events received from external sources.
+type EventHandler interface {
+	HandleExternalEvent(ctx context.Context, event events.Event, sources []string)
+}
+
+// Watcher watches Flux Kustomizations and HelmReleases, and sends events on reconciliation failures, drift, and recoveries.
+type Watcher struct {
+	log          logrus.FieldLogger
+	clusterName  string
+	dynamicCli   dynamic.Interface
+	mapper       meta.RESTMapper
+	resyncPeriod time.Duration
+	sources      map[string]config.FluxSource
+	handler      EventHandler
+}
+
+// reconcileStatus holds the Flux resource status fields relevant for the notifications.
+type reconcileStatus struct {
+	Ready             string
+	Reason            string
+	Message           string
+	AttemptedRevision string
+	AppliedRevision   string
+	Drifted           bool
+}
+
+// NewWatcher returns a new Watcher instance for the source bindings with enabled Flux source.
+func NewWatcher(log logrus.FieldLogger, clusterName string, dynamicCli dynamic.Interface, mapper meta.RESTMapper, resyncPeriod time.Duration, sources map[string]config.Sources, handler EventHandler) *Watcher {
+	enabled := map[string]config.FluxSource{}
+	for name, src := range sources {
+		if src.Flux.Enabled {
+			enabled[name] = src.Flux
+		}
+	}
+
+	return &Watcher{
+		log:          log,
+		clusterName:  clusterName,
+		dynamicCli:   dynamicCli,
+		mapper:       mapper,
+		resyncPeriod: resyncPeriod,
+		sources:      enabled,
+		handler:      handler,
+	}
+}
+
+// Enabled returns true if any source binding has Flux source enabled.
+func (w *Watcher) Enabled() bool {
+	return len(w.sources) > 0
+}
+
+// Start starts watching the Flux resources installed in the cluster. It blocks until the context is cancelled.
+func (w *Watcher) Start(ctx context.Context) error {
+	factory := dynamicinformer.NewDynamicSharedInformerFactory(w.dynamicCli, w.resyncPeriod)
+
+	watched := 0
+	for _, k := range []kind{kustomizationKind, helmReleaseKind} {
+		// Flux API versions differ between releases, so the version served by the cluster is used
+		mapping, err := w.mapper.RESTMapping(k.GroupKind)
+		if err != nil {
+			w.log.Warnf("Flux %s resource is not available in the cluster, skipping watching it: %s", k.GroupKind.Kind, err.Error())
+			continue
+		}
+
+		k := k
+		factory.ForResource(mapping.Resource).Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+			UpdateFunc: func(oldObj, newObj interface{}) {
+				w.handleUpdate(ctx, k, oldObj, newObj)
+			},
+		})
+		watched++
+	}
+	if watched == 0 {
+		return nil
+	}
+
+	w.log.Info("Starting Flux watcher...")
+	factory.Start(ctx.Done())
+	<-ctx.Done()
+	return nil
+}
+
+func (w *Watcher) handleUpdate(ctx context.Context, k kind, oldObj, newObj interface{}) {
+	oldRes, ok := oldObj.(*unstructured.Unstructured)
+	if !ok {
+		return
+	}
+	newRes, ok := newObj.(*unstructured.Unstructured)
+	if !ok {
+		return
+	}
+
+	sources := w.sourcesFor(newRes.GetNamespace())
+	if len(sources) == 0 {
+		return
+	}
+
+	event, ok := w.eventFor(k, newRes, statusFrom(oldRes), statusFrom(newRes))
+	if !ok {
+		return
+	}
+	w.handler.HandleExternalEvent(ctx, event, sources)
+}
+
+// eventFor returns an event for the reconciliation status change. It returns false if the change is not worth notifying.
+func (w *Watcher) eventFor(k kind, obj *unstructured.Unstructured, old, current reconcileStatus) (events.Event, bool) {
+	name, namespace := obj.GetName(), obj.GetNamespace()
+	event := events.Event{
+		TypeMeta:  metaV1.TypeMeta{Kind: obj.GetKind(), APIVersion: obj.GetAPIVersion()},
+		Name:      name,
+		Namespace: namespace,
+		Cluster:   w.clusterName,
+		Resource:  resourceName(obj),
+		TimeStamp: time.Now(),
+		Object:    obj,
+	}
+
+	switch {
+	case current.Drifted && !old.Drifted:
+		event.Type, event.Level, event.Reason = config.WarningEvent, config.Warn, driftDetectedReason
+		event.Title = fmt.Sprintf("Flux %s %s drift detected", k.GroupKind.Kind, name)
+		event.Messages = messagesFor(current, current.AppliedRevision)
+	case current.Ready == conditionFalse && (old.Ready != conditionFalse || old.Reason != current.Reason || old.AttemptedRevision != current.AttemptedRevision):
+		event.Type, event.Level, event.Reason = config.ErrorEvent, config.Error, current.Reason
+		event.Title = fmt.Sprintf("Flux %s %s reconciliation failed", k.GroupKind.Kind, name)
+		event.Messages = messagesFor(current, current.AttemptedRevision)
+	case old.Ready == conditionFalse && current.Ready == conditionTrue:
+		event.Type, event.Level, event.Reason = config.InfoEvent, config.Info, recoveredReason
+		event.Title = fmt.Sprintf("Flux %s %s recovered", k.GroupKind.Kind, name)
+		event.Messages = messagesFor(current, current.AppliedRevision)
+	default:
+		return events.Event{}, false
+	}
+
+	event.SuggestedCommands = []string{
+		fmt.Sprintf("flux get %ss %s --namespace %s", k.Command, name, namespace),
+		fmt.Sprintf("flux events --for %s/%s --namespace %s", k.GroupKind.Kind, name, namespace),
+	}
+	if event.Level != config.Info {
+		event.SuggestedCommands = append(event.SuggestedCommands,
+			fmt.Sprintf("flux reconcile %s %s --with-source --namespace %s", k.Command, name, namespace),
+		)
+	}
+
+	return event, true
+}
+
+// sourcesFor returns sorted names of the source bindings, which watch a given namespace.
+func (w *Watcher) sourcesFor(namespace string) []string {
+	var out []string
+	for name, src := range w.sources {
+		if src.Namespaces.IsConfigured() && !src.Namespaces.IsAllowed(namespace) {
+			continue
+		}
+		out = append(out, name)
+	}
+	sort.Strings(out)
+	return out
+}
+
+func messagesFor(status reconcileStatus, revision string) []string {
+	var out []string
+	if status.Message != "" {
+		out = append(out, strings.TrimSpace(status.Message))
+	}
+	if revision != "" {
+		out = append(out, fmt.Sprintf("Revision: %s", revision))
+	}
+	return out
+}
+
+func statusFrom(obj *unstructured.Unstructured) reconcileStatus {
+	str := func(fields ...string) string {
+		val, _, _ := unstructured.NestedString(obj.Object, fields...)
+		return val
+	}
+
+	out := reconcileStatus{
+		AttemptedRevision: str("status", "lastAttemptedRevision"),
+		AppliedRevision:   str("status", "lastAppliedRevision"),
+	}
+
+	conditions, _, _ := unstructured.NestedSlice(obj.Object, "status", "conditions")
+	for _, item := range conditions {
+		cond, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		reason, _ := cond["reason"].(string)
+		if reason == driftDetectedReason {
+			out.Drifted = true
+		}
+		if condType, _ := cond["type"].(string); condType != readyCondition {
+			continue
+		}
+		out.Ready, _ = cond["status"].(string)
+		out.Reason = reason
+		out.Message, _ = cond["message"].(string)
+	}
+	return out
+}
+
+// resourceName returns the resource in the `group/version/resource` format, the same as for Kubernetes sources.
+func resourceName(obj *unstructured.Unstructured) string {
+	return fmt.Sprintf("%s/%ss", obj.GetAPIVersion(), strings.ToLower(obj.GetKind()))
+}
@@ -0,0 +1,194 @@
+package flux
+
+import (
+	"context"
+	"testing"
+
+	logtest "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/events"
+)
+
+func TestWatcherHandleUpdate(t *testing.T) {
+	tests := []struct {
+		name      string
+		kind      kind
+		namespace string
+		old       fixStatus
+		current   fixStatus
+
+		expectedSources  [][]string
+		expectedTitle    string
+		expectedReason   string
+		expectedLevel    config.Level
+		expectedMessages []string
+		expectedCommands []string
+	}{
+		{
+			name:            "Kustomization reconciliation failed",
+			kind:            kustomizationKind,
+			namespace:       "flux-system",
+			old:             fixStatus{ready: "True", reason: "ReconciliationSucceeded", applied: "main/abc123"},
+			current:         fixStatus{ready: "False", reason: "BuildFailed", message: "kustomize build failed: missing resource", applied: "main/abc123", attempted: "main/def456"},
+			expectedSources: [][]string{{"all", "flux-system"}},
+			expectedTitle:   "Flux Kustomization apps reconciliation failed",
+			expectedReason:  "BuildFailed",
+			expectedLevel:   config.Error,
+			expectedMessages: []string{
+				"kustomize build failed: missing resource",
+				"Revision: main/def456",
+			},
+			expectedCommands: []string{
+				"flux get kustomizations apps --namespace flux-system",
+				"flux events --for Kustomization/apps --namespace flux-system",
+				"flux reconcile kustomization apps --with-source --namespace flux-system",
+			},
+		},
+		{
+			name:            "Failing HelmRelease with a new revision",
+			kind:            helmReleaseKind,
+			namespace:       "apps",
+			old:             fixStatus{ready: "False", reason: "UpgradeFailed", attempted: "1.0.0"},
+			current:         fixStatus{ready: "False", reason: "UpgradeFailed", message: "timed out waiting for the condition", attempted: "1.0.1"},
+			expectedSources: [][]string{{"all"}},
+			expectedTitle:   "Flux HelmRelease apps reconciliation failed",
+			expectedReason:  "UpgradeFailed",
+			expectedLevel:   config.Error,
+			expectedMessages: []string{
+				"timed out waiting for the condition",
+				"Revision: 1.0.1",
+			},
+			expectedCommands: []string{
+				"flux get helmreleases apps --namespace apps",
+				"flux events --for HelmRelease/apps --namespace apps",
+				"flux reconcile helmrelease apps --with-source --namespace apps",
+			},
+		},
+		{
+			name:            "HelmRelease drift detected",
+			kind:            helmReleaseKind,
+			namespace:       "apps",
+			old:             fixStatus{ready: "True", reason: "UpgradeSucceeded", applied: "1.0.1"},
+			current:         fixStatus{ready: "True", reason: "UpgradeSucceeded", applied: "1.0.1", drifted: true},
+			expectedSources: [][]string{{"all"}},
+			expectedTitle:   "Flux HelmRelease apps drift detected",
+			expectedReason:  "DriftDetected",
+			expectedLevel:   config.Warn,
+			expectedMessages: []string{
+				"Revision: 1.0.1",
+			},
+			expectedCommands: []string{
+				"flux get helmreleases apps --namespace apps",
+				"flux events --for HelmRelease/apps --namespace apps",
+				"flux reconcile helmrelease apps --with-source --namespace apps",
+			},
+		},
+		{
+			name:            "Kustomization recovered",
+			kind:            kustomizationKind,
+			namespace:       "flux-system",
+			old:             fixStatus{ready: "False", reason: "BuildFailed", attempted: "main/def456"},
+			current:         fixStatus{ready: "True", reason: "ReconciliationSucceeded", message: "Applied revision: main/fed789", applied: "main/fed789"},
+			expectedSources: [][]string{{"all", "flux-system"}},
+			expectedTitle:   "Flux Kustomization apps recovered",
+			expectedReason:  "Recovered",
+			expectedLevel:   config.Info,
+			expectedMessages: []string{
+				"Applied revision: main/fed789",
+				"Revision: main/fed789",
+			},
+			expectedCommands: []string{
+				"flux get kustomizations apps --namespace flux-system",
+				"flux events --for Kustomization/apps --namespace flux-system",
+			},
+		},
+		{
+			name:      "Repeated failure",
+			kind:      kustomizationKind,
+			namespace: "flux-system",
+			old:       fixStatus{ready: "False", reason: "BuildFailed", attempted: "main/def456"},
+			current:   fixStatus{ready: "False", reason: "BuildFailed", attempted: "main/def456"},
+		},
+		{
+			name:      "Successful reconciliation",
+			kind:      kustomizationKind,
+			namespace: "flux-system",
+			old:       fixStatus{ready: "True", reason: "ReconciliationSucceeded", applied: "main/abc123"},
+			current:   fixStatus{ready: "True", reason: "ReconciliationSucceeded", applied: "main/def456"},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// given
+			handler := &fakeEventHandler{}
+			logger, _ := logtest.NewNullLogger()
+			watcher := NewWatcher(logger, "dev", nil, nil, 0, map[string]config.Sources{
+				"all":         {Flux: config.FluxSource{Enabled: true}},
+				"flux-system": {Flux: config.FluxSource{Enabled: true, Namespaces: config.Namespaces{Include: []string{"flux-system"}}}},
+				"disabled":    {},
+			}, handler)
+
+			// when
+			watcher.handleUpdate(context.Background(), tc.kind, fixResource(tc.kind, tc.namespace, tc.old), fixResource(tc.kind, tc.namespace, tc.current))
+
+			// then
+			assert.Equal(t, tc.expectedSources, handler.sources)
+			if len(tc.expectedSources) == 0 {
+				return
+			}
+			require.Len(t, handler.events, 1)
+			event := handler.events[0]
+			assert.Equal(t, tc.kind.GroupKind.Kind, event.Kind)
+			assert.Equal(t, tc.expectedTitle, event.Title)
+			assert.Equal(t, tc.expectedReason, event.Reason)
+			assert.Equal(t, tc.expectedLevel, event.Level)
+			assert.Equal(t, tc.expectedMessages, event.Messages)
+			assert.Equal(t, tc.expectedCommands, event.SuggestedCommands)
+		})
+	}
+}
+
+type fixStatus struct {
+	ready     string
+	reason    string
+	message   string
+	applied   string
+	attempted string
+	drifted   bool
+}
+
+type fakeEventHandler struct {
+	events  []events.Event
+	sources [][]string
+}
+
+func (f *fakeEventHandler) HandleExternalEvent(_ context.Context, event events.Event, sources []string) {
+	f.events = append(f.events, event)
+	f.sources = append(f.sources, sources)
+}
+
+func fixResource(k kind, namespace string, status fixStatus) *unstructured.Unstructured {
+	conditions := []interface{}{
+		map[string]interface{}{"type": "Ready", "status": status.ready, "reason": status.reason, "message": status.message},
+	}
+	if status.drifted {
+		conditions = append(conditions, map[string]interface{}{"type": "Released", "status": "True", "reason": "DriftDetected"})
+	}
+
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{
+		"status": map[string]interface{}{
+			"conditions":            conditions,
+			"lastAppliedRevision":   status.applied,
+			"lastAttemptedRevision": status.attempted,
+		},
+	}}
+	obj.SetAPIVersion(k.GroupKind.Group + "/v1beta2")
+	obj.SetKind(k.GroupKind.Kind)
+	obj.SetName("apps")
+	obj.SetNamespace(namespace)
+	return obj
+}
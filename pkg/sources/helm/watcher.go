@@ -0,0 +1,308 @@
+package helm
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/events"
+)
+
+const (
+	releaseKind     = "HelmRelease"
+	releaseResource = "helm/releases"
+
+	// releaseSecretSelector matches the Secrets used by Helm 3 as the release storage.
+	releaseSecretSelector = "owner=helm"
+	releaseSecretType     = "helm.sh/release.v1"
+
+	statusDeployed = "deployed"
+	statusFailed   = "failed"
+
+	reasonInstalled  = "Installed"
+	reasonUpgraded   = "Upgraded"
+	reasonRolledBack = "RolledBack"
+	reasonFailed     = "Failed"
+)
+
+var (
+	secretGVR = schema.GroupVersionResource{Version: "v1", Resource: "secrets"}
+
+	gzipMagic = []byte{0x1f, 0x8b}
+
+	reasonVerbs = map[string]string{
+		reasonInstalled:  "installed",
+		reasonUpgraded:   "upgraded",
+		reasonRolledBack: "rolled back",
+		reasonFailed:     "failed",
+	}
+)
+
+// EventHandler handles events received from external sources.
+type EventHandler interface {
+	HandleExternalEvent(ctx context.Context, event events.Event, sources []string)
+}
+
+// Watcher watches Helm release Secrets and sends events when releases are installed, upgraded, rolled back, or failed.
+type Watcher struct {
+	log          logrus.FieldLogger
+	clusterName  string
+	dynamicCli   dynamic.Interface
+	resyncPeriod time.Duration
+	sources      map[string]config.HelmSource
+	handler      EventHandler
+	startTime    time.Time
+}
+
+// release holds the Helm release fields relevant for the notifications.
+// See https://github.com/helm/helm/blob/main/pkg/release/release.go.
+type release struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+	Version   int    `json:"version"`
+	Info      struct {
+		Status       string    `json:"status"`
+		Description  string    `json:"description"`
+		LastDeployed time.Time `json:"last_deployed"`
+	} `json:"info"`
+	Chart struct {
+		Metadata struct {
+			Name       string `json:"name"`
+			Version    string `json:"version"`
+			AppVersion string `json:"appVersion"`
+		} `json:"metadata"`
+	} `json:"chart"`
+}
+
+// NewWatcher returns a new Watcher instance for the source bindings with enabled Helm source.
+func NewWatcher(log logrus.FieldLogger, clusterName string, dynamicCli dynamic.Interface, resyncPeriod time.Duration, sources map[string]config.Sources, handler EventHandler) *Watcher {
+	enabled := map[string]config.HelmSource{}
+	for name, src := range sources {
+		if src.Helm.Enabled {
+			enabled[name] = src.Helm
+		}
+	}
+
+	return &Watcher{
+		log:          log,
+		clusterName:  clusterName,
+		dynamicCli:   dynamicCli,
+		resyncPeriod: resyncPeriod,
+		sources:      enabled,
+		handler:      handler,
+	}
+}
+
+// Enabled returns true if any source binding has Helm source enabled.
+func (w *Watcher) Enabled() bool {
+	return len(w.sources) > 0
+}
+
+// Start starts watching Helm releases. It blocks until the context is cancelled.
+func (w *Watcher) Start(ctx context.Context) error {
+	w.log.Info("Starting Helm releases watcher...")
+	w.startTime = time.Now()
+
+	factory := dynamicinformer.NewFilteredDynamicSharedInformerFactory(w.dynamicCli, w.resyncPeriod, metaV1.NamespaceAll, func(opts *metaV1.ListOptions) {
+		opts.LabelSelector = releaseSecretSelector
+	})
+	factory.ForResource(secretGVR).Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			w.handle(ctx, nil, obj)
+		},
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			w.handle(ctx, oldObj, newObj)
+		},
+	})
+
+	factory.Start(ctx.Done())
+	<-ctx.Done()
+	return nil
+}
+
+func (w *Watcher) handle(ctx context.Context, oldObj, newObj interface{}) {
+	secret, ok := newObj.(*unstructured.Unstructured)
+	if !ok || !w.shouldNotify(oldObj, secret) {
+		return
+	}
+
+	sources := w.sourcesFor(secret.GetNamespace())
+	if len(sources) == 0 {
+		return
+	}
+
+	rel, err := decodeRelease(secret)
+	if err != nil {
+		w.log.Errorf("while decoding Helm release from Secret %s/%s: %s", secret.GetNamespace(), secret.GetName(), err.Error())
+		return
+	}
+
+	event, ok := w.eventFor(rel)
+	if !ok {
+		return
+	}
+	w.handler.HandleExternalEvent(ctx, event, sources)
+}
+
+// shouldNotify returns true if the release Secret reached the deployed or failed status.
+// Helm creates the Secrets in a pending status first, and updates them once the operation is finished.
+func (w *Watcher) shouldNotify(oldObj interface{}, secret *unstructured.Unstructured) bool {
+	secretType, _, _ := unstructured.NestedString(secret.Object, "type")
+	if secretType != releaseSecretType {
+		return false
+	}
+
+	status := secret.GetLabels()["status"]
+	if status != statusDeployed && status != statusFailed {
+		return false
+	}
+
+	if oldObj == nil {
+		// Secrets listed on startup describe past operations
+		return secret.GetCreationTimestamp().Time.After(w.startTime)
+	}
+
+	old, ok := oldObj.(*unstructured.Unstructured)
+	if !ok {
+		return false
+	}
+	return old.GetLabels()["status"] != status
+}
+
+// sourcesFor returns sorted names of the source bindings, which watch a given namespace.
+func (w *Watcher) sourcesFor(namespace string) []string {
+	var out []string
+	for name, src := range w.sources {
+		if src.Namespaces.IsConfigured() && !src.Namespaces.IsAllowed(namespace) {
+			continue
+		}
+		out = append(out, name)
+	}
+	sort.Strings(out)
+	return out
+}
+
+func (w *Watcher) eventFor(rel release) (events.Event, bool) {
+	var reason string
+	switch {
+	case rel.Info.Status == statusFailed:
+		reason = reasonFailed
+	case rel.Info.Status != statusDeployed:
+		return events.Event{}, false
+	case strings.HasPrefix(rel.Info.Description, "Rollback"):
+		reason = reasonRolledBack
+	case rel.Version <= 1:
+		reason = reasonInstalled
+	default:
+		reason = reasonUpgraded
+	}
+
+	chart := rel.Chart.Metadata
+	messages := []string{
+		fmt.Sprintf("Chart: %s-%s", chart.Name, chart.Version),
+	}
+	if chart.AppVersion != "" {
+		messages = append(messages, fmt.Sprintf("App version: %s", chart.AppVersion))
+	}
+	messages = append(messages,
+		fmt.Sprintf("Revision: %d", rel.Version),
+		fmt.Sprintf("Status: %s", rel.Info.Status),
+	)
+	if rel.Info.Description != "" {
+		messages = append(messages, fmt.Sprintf("Description: %s", rel.Info.Description))
+	}
+
+	event := events.Event{
+		TypeMeta:  metaV1.TypeMeta{Kind: releaseKind},
+		Title:     fmt.Sprintf("Helm release %s %s", rel.Name, reasonVerbs[reason]),
+		Name:      rel.Name,
+		Namespace: rel.Namespace,
+		Reason:    reason,
+		Cluster:   w.clusterName,
+		Resource:  releaseResource,
+		TimeStamp: rel.Info.LastDeployed,
+		Messages:  messages,
+		SuggestedCommands: []string{
+			fmt.Sprintf("helm status %s --namespace %s", rel.Name, rel.Namespace),
+			fmt.Sprintf("helm history %s --namespace %s", rel.Name, rel.Namespace),
+		},
+	}
+	if event.TimeStamp.IsZero() {
+		event.TimeStamp = time.Now()
+	}
+
+	if reason == reasonFailed {
+		event.Type, event.Level = config.ErrorEvent, config.Error
+		if rel.Version > 1 {
+			event.SuggestedCommands = append(event.SuggestedCommands, fmt.Sprintf("helm rollback %s --namespace %s", rel.Name, rel.Namespace))
+		}
+		return event, true
+	}
+
+	event.Type, event.Level = config.InfoEvent, config.Info
+	return event, true
+}
+
+// decodeRelease decodes the release stored in the Secret. Helm stores the release as base64 encoded, gzipped JSON.
+func decodeRelease(secret *unstructured.Unstructured) (release, error) {
+	// the dynamic client returns Secret data encoded as base64, the same as the API server
+	raw, found, err := unstructured.NestedString(secret.Object, "data", "release")
+	if err != nil || !found {
+		return release{}, fmt.Errorf("missing release data")
+	}
+
+	data, err := base64.StdEncoding.DecodeString(raw)
+	if err != nil {
+		return release{}, fmt.Errorf("while decoding Secret data: %w", err)
+	}
+	data, err = base64.StdEncoding.DecodeString(string(data))
+	if err != nil {
+		return release{}, fmt.Errorf("while decoding release: %w", err)
+	}
+
+	if bytes.HasPrefix(data, gzipMagic) {
+		reader, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return release{}, fmt.Errorf("while creating gzip reader: %w", err)
+		}
+		defer reader.Close()
+
+		data, err = io.ReadAll(reader)
+		if err != nil {
+			return release{}, fmt.Errorf("while decompressing release: %w", err)
+		}
+	}
+
+	var out release
+	if err := json.Unmarshal(data, &out); err != nil {
+		return release{}, fmt.Errorf("while unmarshaling release: %w", err)
+	}
+
+	if out.Name == "" {
+		out.Name = secret.GetLabels()["name"]
+	}
+	if out.Namespace == "" {
+		out.Namespace = secret.GetNamespace()
+	}
+	if out.Version == 0 {
+		out.Version, _ = strconv.Atoi(secret.GetLabels()["version"])
+	}
+	return out, nil
+}
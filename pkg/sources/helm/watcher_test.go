@@ -0,0 +1,268 @@
+package helm
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+	"time"
+
+	logtest "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/events"
+)
+
+func TestWatcherHandle(t *testing.T) {
+	deployedAt := time.Date(2022, 10, 10, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		version     int
+		description string
+		oldStatus   string
+		newStatus   string
+
+		expectedEvent *events.Event
+	}{
+		{
+			name:        "Install",
+			version:     1,
+			description: "Install complete",
+			oldStatus:   "pending-install",
+			newStatus:   "deployed",
+			expectedEvent: &events.Event{
+				TypeMeta:  metaV1.TypeMeta{Kind: "HelmRelease"},
+				Title:     "Helm release nginx installed",
+				Name:      "nginx",
+				Namespace: "web",
+				Reason:    "Installed",
+				Type:      config.InfoEvent,
+				Level:     config.Info,
+				Cluster:   "dev",
+				Resource:  "helm/releases",
+				TimeStamp: deployedAt,
+				Messages: []string{
+					"Chart: nginx-13.2.1",
+					"App version: 1.23.1",
+					"Revision: 1",
+					"Status: deployed",
+					"Description: Install complete",
+				},
+				SuggestedCommands: []string{
+					"helm status nginx --namespace web",
+					"helm history nginx --namespace web",
+				},
+			},
+		},
+		{
+			name:        "Failed upgrade",
+			version:     3,
+			description: "Upgrade \"nginx\" failed: timed out waiting for the condition",
+			oldStatus:   "pending-upgrade",
+			newStatus:   "failed",
+			expectedEvent: &events.Event{
+				TypeMeta:  metaV1.TypeMeta{Kind: "HelmRelease"},
+				Title:     "Helm release nginx failed",
+				Name:      "nginx",
+				Namespace: "web",
+				Reason:    "Failed",
+				Type:      config.ErrorEvent,
+				Level:     config.Error,
+				Cluster:   "dev",
+				Resource:  "helm/releases",
+				TimeStamp: deployedAt,
+				Messages: []string{
+					"Chart: nginx-13.2.1",
+					"App version: 1.23.1",
+					"Revision: 3",
+					"Status: failed",
+					"Description: Upgrade \"nginx\" failed: timed out waiting for the condition",
+				},
+				SuggestedCommands: []string{
+					"helm status nginx --namespace web",
+					"helm history nginx --namespace web",
+					"helm rollback nginx --namespace web",
+				},
+			},
+		},
+		{
+			name:        "Rollback",
+			version:     4,
+			description: "Rollback to 2",
+			oldStatus:   "pending-rollback",
+			newStatus:   "deployed",
+			expectedEvent: &events.Event{
+				TypeMeta:  metaV1.TypeMeta{Kind: "HelmRelease"},
+				Title:     "Helm release nginx rolled back",
+				Name:      "nginx",
+				Namespace: "web",
+				Reason:    "RolledBack",
+				Type:      config.InfoEvent,
+				Level:     config.Info,
+				Cluster:   "dev",
+				Resource:  "helm/releases",
+				TimeStamp: deployedAt,
+				Messages: []string{
+					"Chart: nginx-13.2.1",
+					"App version: 1.23.1",
+					"Revision: 4",
+					"Status: deployed",
+					"Description: Rollback to 2",
+				},
+				SuggestedCommands: []string{
+					"helm status nginx --namespace web",
+					"helm history nginx --namespace web",
+				},
+			},
+		},
+		{
+			name:        "Superseded",
+			version:     2,
+			description: "Upgrade complete",
+			oldStatus:   "deployed",
+			newStatus:   "superseded",
+		},
+		{
+			name:        "Resync",
+			version:     2,
+			description: "Upgrade complete",
+			oldStatus:   "deployed",
+			newStatus:   "deployed",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// given
+			logger, _ := logtest.NewNullLogger()
+			handler := &fakeEventHandler{}
+			watcher := NewWatcher(logger, "dev", nil, 0, map[string]config.Sources{
+				"helm": {Helm: config.HelmSource{Enabled: true}},
+			}, handler)
+
+			oldSecret := fixReleaseSecret(t, tc.version, tc.oldStatus, tc.description, deployedAt)
+			newSecret := fixReleaseSecret(t, tc.version, tc.newStatus, tc.description, deployedAt)
+
+			// when
+			watcher.handle(context.Background(), oldSecret, newSecret)
+
+			// then
+			if tc.expectedEvent == nil {
+				assert.Empty(t, handler.events)
+				return
+			}
+			require.Len(t, handler.events, 1)
+			assert.Equal(t, *tc.expectedEvent, handler.events[0])
+			assert.Equal(t, []string{"helm"}, handler.sources)
+		})
+	}
+}
+
+func TestWatcherSourcesFor(t *testing.T) {
+	// given
+	logger, _ := logtest.NewNullLogger()
+	watcher := NewWatcher(logger, "dev", nil, 0, map[string]config.Sources{
+		"all":      {Helm: config.HelmSource{Enabled: true}},
+		"prod":     {Helm: config.HelmSource{Enabled: true, Namespaces: config.Namespaces{Include: []string{"prod-.*"}}}},
+		"not-test": {Helm: config.HelmSource{Enabled: true, Namespaces: config.Namespaces{Include: []string{".*"}, Exclude: []string{"test"}}}},
+		"disabled": {},
+	}, nil)
+
+	// when
+	prodSources := watcher.sourcesFor("prod-eu")
+	testSources := watcher.sourcesFor("test")
+
+	// then
+	assert.Equal(t, []string{"all", "not-test", "prod"}, prodSources)
+	assert.Equal(t, []string{"all"}, testSources)
+}
+
+func TestDecodeReleaseUncompressed(t *testing.T) {
+	// given
+	raw, err := json.Marshal(map[string]interface{}{
+		"name":    "redis",
+		"version": 2,
+		"info":    map[string]interface{}{"status": "deployed"},
+	})
+	require.NoError(t, err)
+
+	secret := &unstructured.Unstructured{Object: map[string]interface{}{
+		"data": map[string]interface{}{
+			"release": base64.StdEncoding.EncodeToString([]byte(base64.StdEncoding.EncodeToString(raw))),
+		},
+	}}
+	secret.SetNamespace("cache")
+
+	// when
+	rel, err := decodeRelease(secret)
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, "redis", rel.Name)
+	assert.Equal(t, "cache", rel.Namespace)
+	assert.Equal(t, 2, rel.Version)
+	assert.Equal(t, "deployed", rel.Info.Status)
+}
+
+type fakeEventHandler struct {
+	events  []events.Event
+	sources []string
+}
+
+func (f *fakeEventHandler) HandleExternalEvent(_ context.Context, event events.Event, sources []string) {
+	f.events = append(f.events, event)
+	f.sources = sources
+}
+
+func fixReleaseSecret(t *testing.T, version int, status, description string, deployedAt time.Time) *unstructured.Unstructured {
+	t.Helper()
+
+	raw, err := json.Marshal(map[string]interface{}{
+		"name":      "nginx",
+		"namespace": "web",
+		"version":   version,
+		"info": map[string]interface{}{
+			"status":        status,
+			"description":   description,
+			"last_deployed": deployedAt,
+		},
+		"chart": map[string]interface{}{
+			"metadata": map[string]interface{}{
+				"name":       "nginx",
+				"version":    "13.2.1",
+				"appVersion": "1.23.1",
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	_, err = gz.Write(raw)
+	require.NoError(t, err)
+	require.NoError(t, gz.Close())
+
+	helmEncoded := base64.StdEncoding.EncodeToString(buf.Bytes())
+	secret := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "Secret",
+		"type":       "helm.sh/release.v1",
+		"data": map[string]interface{}{
+			"release": base64.StdEncoding.EncodeToString([]byte(helmEncoded)),
+		},
+	}}
+	secret.SetName("sh.helm.release.v1.nginx.v1")
+	secret.SetNamespace("web")
+	secret.SetLabels(map[string]string{
+		"owner":  "helm",
+		"name":   "nginx",
+		"status": status,
+	})
+	return secret
+}
@@ -0,0 +1,358 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	batchV1 "k8s.io/api/batch/v1"
+	coreV1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/events"
+)
+
+const (
+	jobResource     = "batch/v1/jobs"
+	cronJobResource = "batch/v1/cronjobs"
+
+	// jobNameLabel is set by the Job controller on all Pods created for a given Job.
+	jobNameLabel = "job-name"
+	// cronJobEventsSelector selects the Kubernetes events reported by the CronJob controller.
+	cronJobEventsSelector = "involvedObject.kind=CronJob"
+
+	reasonCompleted = "Completed"
+	reasonFailed    = "Failed"
+	// maxFailedContainers limits the number of container exit reasons attached to the event.
+	maxFailedContainers = 5
+)
+
+// missedScheduleReasons are the reasons of the CronJob controller events, reported when a CronJob missed its schedule.
+var missedScheduleReasons = map[string]struct{}{
+	"MissSchedule":       {},
+	"TooManyMissedTimes": {},
+}
+
+// EventHandler handles events received from external sources.
+type EventHandler interface {
+	HandleExternalEvent(ctx context.Context, event events.Event, sources []string)
+}
+
+// Watcher watches Jobs and CronJobs. It sends events when Jobs complete or fail, and when CronJobs miss their schedule.
+type Watcher struct {
+	log          logrus.FieldLogger
+	clusterName  string
+	k8sCli       kubernetes.Interface
+	resyncPeriod time.Duration
+	sources      map[string]config.JobsSource
+	handler      EventHandler
+	startTime    time.Time
+}
+
+// failedContainer describes a container which terminated with an error in one of the Job Pods.
+type failedContainer struct {
+	Pod       string
+	Container string
+	Reason    string
+	ExitCode  int32
+	Message   string
+}
+
+// NewWatcher returns a new Watcher instance for the source bindings with enabled Jobs source.
+func NewWatcher(log logrus.FieldLogger, clusterName string, k8sCli kubernetes.Interface, resyncPeriod time.Duration, sources map[string]config.Sources, handler EventHandler) *Watcher {
+	enabled := map[string]config.JobsSource{}
+	for name, src := range sources {
+		if src.Jobs.Enabled {
+			enabled[name] = src.Jobs
+		}
+	}
+
+	return &Watcher{
+		log:          log,
+		clusterName:  clusterName,
+		k8sCli:       k8sCli,
+		resyncPeriod: resyncPeriod,
+		sources:      enabled,
+		handler:      handler,
+	}
+}
+
+// Enabled returns true if any source binding has Jobs source enabled.
+func (w *Watcher) Enabled() bool {
+	return len(w.sources) > 0
+}
+
+// Start starts watching Jobs and CronJob events. It blocks until the context is cancelled.
+func (w *Watcher) Start(ctx context.Context) error {
+	w.log.Info("Starting Jobs watcher...")
+	w.startTime = time.Now()
+
+	jobsFactory := informers.NewSharedInformerFactory(w.k8sCli, w.resyncPeriod)
+	jobsFactory.Batch().V1().Jobs().Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			oldJob, ok := oldObj.(*batchV1.Job)
+			if !ok {
+				return
+			}
+			newJob, ok := newObj.(*batchV1.Job)
+			if !ok {
+				return
+			}
+			w.handleJobUpdate(ctx, oldJob, newJob)
+		},
+	})
+
+	eventsFactory := informers.NewSharedInformerFactoryWithOptions(w.k8sCli, w.resyncPeriod, informers.WithTweakListOptions(func(opts *metaV1.ListOptions) {
+		opts.FieldSelector = cronJobEventsSelector
+	}))
+	eventsFactory.Core().V1().Events().Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			if event, ok := obj.(*coreV1.Event); ok {
+				w.handleCronJobEvent(ctx, event)
+			}
+		},
+	})
+
+	jobsFactory.Start(ctx.Done())
+	eventsFactory.Start(ctx.Done())
+	<-ctx.Done()
+	return nil
+}
+
+func (w *Watcher) handleJobUpdate(ctx context.Context, oldJob, newJob *batchV1.Job) {
+	oldFinished, _ := finishedCondition(oldJob)
+	newFinished, cond := finishedCondition(newJob)
+	if oldFinished || !newFinished {
+		return
+	}
+
+	failed := cond.Type == batchV1.JobFailed
+	sources := w.sourcesFor(newJob.Namespace, func(src config.JobsSource) bool {
+		return failed || src.NotifyOnCompletion
+	})
+	if len(sources) == 0 {
+		return
+	}
+
+	var containers []failedContainer
+	if failed {
+		var err error
+		containers, err = w.failedContainers(ctx, newJob)
+		if err != nil {
+			w.log.Errorf("while getting failed containers for Job %s/%s: %s", newJob.Namespace, newJob.Name, err.Error())
+			// continue, as the event is still useful without the details
+		}
+	}
+
+	w.handler.HandleExternalEvent(ctx, w.jobEvent(newJob, cond, containers), sources)
+}
+
+func (w *Watcher) handleCronJobEvent(ctx context.Context, k8sEvent *coreV1.Event) {
+	if _, missed := missedScheduleReasons[k8sEvent.Reason]; !missed {
+		return
+	}
+
+	timestamp := k8sEvent.LastTimestamp.Time
+	if timestamp.IsZero() {
+		timestamp = k8sEvent.EventTime.Time
+	}
+	if timestamp.Before(w.startTime) {
+		// events listed on startup were already reported
+		return
+	}
+
+	obj := k8sEvent.InvolvedObject
+	sources := w.sourcesFor(obj.Namespace, nil)
+	if len(sources) == 0 {
+		return
+	}
+
+	w.handler.HandleExternalEvent(ctx, events.Event{
+		TypeMeta:  metaV1.TypeMeta{Kind: obj.Kind, APIVersion: obj.APIVersion},
+		Title:     fmt.Sprintf("CronJob %s missed its schedule", obj.Name),
+		Name:      obj.Name,
+		Namespace: obj.Namespace,
+		Messages:  []string{k8sEvent.Message},
+		Type:      config.WarningEvent,
+		Reason:    k8sEvent.Reason,
+		Level:     config.Warn,
+		Cluster:   w.clusterName,
+		TimeStamp: timestamp,
+		Resource:  cronJobResource,
+		SuggestedCommands: []string{
+			fmt.Sprintf("kubectl describe cronjob %s --namespace %s", obj.Name, obj.Namespace),
+		},
+	}, sources)
+}
+
+func (w *Watcher) jobEvent(job *batchV1.Job, cond batchV1.JobCondition, containers []failedContainer) events.Event {
+	event := events.Event{
+		TypeMeta:  metaV1.TypeMeta{Kind: "Job", APIVersion: batchV1.SchemeGroupVersion.String()},
+		Name:      job.Name,
+		Namespace: job.Namespace,
+		Cluster:   w.clusterName,
+		Resource:  jobResource,
+		TimeStamp: cond.LastTransitionTime.Time,
+		Object:    job,
+	}
+	if event.TimeStamp.IsZero() {
+		event.TimeStamp = time.Now()
+	}
+
+	if cronJob := ownerCronJob(job); cronJob != "" {
+		event.Messages = append(event.Messages, fmt.Sprintf("CronJob: %s", cronJob))
+	}
+	if duration, ok := jobDuration(job, cond); ok {
+		event.Messages = append(event.Messages, fmt.Sprintf("Duration: %s", duration))
+	}
+
+	if cond.Type == batchV1.JobComplete {
+		event.Type, event.Level, event.Reason = config.InfoEvent, config.Info, reasonCompleted
+		event.Title = fmt.Sprintf("Job %s completed", job.Name)
+		event.Messages = append(event.Messages, fmt.Sprintf("Succeeded pods: %d", job.Status.Succeeded))
+		return event
+	}
+
+	event.Type, event.Level, event.Reason = config.ErrorEvent, config.Error, reasonFailed
+	if cond.Reason != "" {
+		event.Reason = cond.Reason
+	}
+	event.Title = fmt.Sprintf("Job %s failed", job.Name)
+	if cond.Message != "" {
+		event.Messages = append(event.Messages, cond.Message)
+	}
+	event.Messages = append(event.Messages, fmt.Sprintf("Failed pods: %d", job.Status.Failed))
+
+	for _, c := range containers {
+		msg := fmt.Sprintf("Pod %s, container %s: %s (exit code %d)", c.Pod, c.Container, c.Reason, c.ExitCode)
+		if c.Message != "" {
+			msg = fmt.Sprintf("%s: %s", msg, strings.TrimSpace(c.Message))
+		}
+		event.Messages = append(event.Messages, msg)
+	}
+
+	if len(containers) > 0 {
+		last := containers[0]
+		event.Buttons = append(event.Buttons, events.Button{
+			Name:    "Show logs",
+			Command: fmt.Sprintf("kubectl logs pod/%s --container %s --namespace %s", last.Pod, last.Container, job.Namespace),
+		})
+	}
+	event.SuggestedCommands = []string{
+		fmt.Sprintf("kubectl describe job %s --namespace %s", job.Name, job.Namespace),
+	}
+
+	return event
+}
+
+// failedContainers returns the containers which terminated with an error, starting from the most recent ones.
+func (w *Watcher) failedContainers(ctx context.Context, job *batchV1.Job) ([]failedContainer, error) {
+	pods, err := w.k8sCli.CoreV1().Pods(job.Namespace).List(ctx, metaV1.ListOptions{
+		LabelSelector: fmt.Sprintf("%s=%s", jobNameLabel, job.Name),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("while listing Pods: %w", err)
+	}
+
+	type terminated struct {
+		failedContainer
+		finishedAt time.Time
+	}
+	var all []terminated
+	for _, pod := range pods.Items {
+		for _, status := range pod.Status.ContainerStatuses {
+			state := status.State.Terminated
+			if state == nil {
+				state = status.LastTerminationState.Terminated
+			}
+			if state == nil || state.ExitCode == 0 {
+				continue
+			}
+
+			all = append(all, terminated{
+				failedContainer: failedContainer{
+					Pod:       pod.Name,
+					Container: status.Name,
+					Reason:    state.Reason,
+					ExitCode:  state.ExitCode,
+					Message:   state.Message,
+				},
+				finishedAt: state.FinishedAt.Time,
+			})
+		}
+	}
+
+	sort.SliceStable(all, func(i, j int) bool {
+		return all[i].finishedAt.After(all[j].finishedAt)
+	})
+
+	var out []failedContainer
+	for idx, item := range all {
+		if idx == maxFailedContainers {
+			break
+		}
+		out = append(out, item.failedContainer)
+	}
+	return out, nil
+}
+
+// sourcesFor returns sorted names of the source bindings, which watch a given namespace and match a given predicate.
+func (w *Watcher) sourcesFor(namespace string, predicate func(src config.JobsSource) bool) []string {
+	var out []string
+	for name, src := range w.sources {
+		if src.Namespaces.IsConfigured() && !src.Namespaces.IsAllowed(namespace) {
+			continue
+		}
+		if predicate != nil && !predicate(src) {
+			continue
+		}
+		out = append(out, name)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// finishedCondition returns the Complete or Failed condition, if the Job has finished.
+func finishedCondition(job *batchV1.Job) (bool, batchV1.JobCondition) {
+	for _, cond := range job.Status.Conditions {
+		if cond.Status != coreV1.ConditionTrue {
+			continue
+		}
+		if cond.Type == batchV1.JobComplete || cond.Type == batchV1.JobFailed {
+			return true, cond
+		}
+	}
+	return false, batchV1.JobCondition{}
+}
+
+func jobDuration(job *batchV1.Job, cond batchV1.JobCondition) (time.Duration, bool) {
+	if job.Status.StartTime == nil {
+		return 0, false
+	}
+
+	end := cond.LastTransitionTime.Time
+	if job.Status.CompletionTime != nil {
+		end = job.Status.CompletionTime.Time
+	}
+	if end.IsZero() {
+		return 0, false
+	}
+
+	return end.Sub(job.Status.StartTime.Time).Round(time.Second), true
+}
+
+func ownerCronJob(job *batchV1.Job) string {
+	for _, ref := range job.OwnerReferences {
+		if ref.Kind == "CronJob" {
+			return ref.Name
+		}
+	}
+	return ""
+}
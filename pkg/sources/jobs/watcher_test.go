@@ -0,0 +1,219 @@
+package jobs
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	logtest "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	batchV1 "k8s.io/api/batch/v1"
+	coreV1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/events"
+)
+
+var fixStartTime = time.Date(2022, 10, 10, 10, 0, 0, 0, time.UTC)
+
+func TestWatcherHandleJobUpdateFailed(t *testing.T) {
+	// given
+	handler := &fakeEventHandler{}
+	k8sCli := fake.NewSimpleClientset(
+		fixPod("backup-1", "backup-27755520", 1, "Error", fixStartTime.Add(time.Minute)),
+		fixPod("backup-2", "backup-27755520", 137, "OOMKilled", fixStartTime.Add(2*time.Minute)),
+		fixPod("other-1", "other-27755520", 1, "Error", fixStartTime),
+	)
+	watcher := fixWatcher(k8sCli, handler)
+
+	oldJob := fixJob()
+	newJob := fixJob()
+	newJob.Status.Failed = 2
+	newJob.Status.Conditions = []batchV1.JobCondition{
+		{
+			Type:               batchV1.JobFailed,
+			Status:             coreV1.ConditionTrue,
+			Reason:             "BackoffLimitExceeded",
+			Message:            "Job has reached the specified backoff limit",
+			LastTransitionTime: metaV1.NewTime(fixStartTime.Add(150 * time.Second)),
+		},
+	}
+
+	// when
+	watcher.handleJobUpdate(context.Background(), oldJob, newJob)
+	// resync doesn't repeat the notification
+	watcher.handleJobUpdate(context.Background(), newJob, newJob)
+
+	// then
+	require.Len(t, handler.events, 1)
+	event := handler.events[0]
+	assert.Equal(t, [][]string{{"all", "failures-only"}}, handler.sources)
+	assert.Equal(t, "Job", event.Kind)
+	assert.Equal(t, "Job backup-27755520 failed", event.Title)
+	assert.Equal(t, "BackoffLimitExceeded", event.Reason)
+	assert.Equal(t, config.ErrorEvent, event.Type)
+	assert.Equal(t, config.Error, event.Level)
+	assert.Equal(t, []string{
+		"CronJob: backup",
+		"Duration: 2m30s",
+		"Job has reached the specified backoff limit",
+		"Failed pods: 2",
+		"Pod backup-2, container main: OOMKilled (exit code 137)",
+		"Pod backup-1, container main: Error (exit code 1)",
+	}, event.Messages)
+	assert.Equal(t, []events.Button{
+		{Name: "Show logs", Command: "kubectl logs pod/backup-2 --container main --namespace default"},
+	}, event.Buttons)
+}
+
+func TestWatcherHandleJobUpdateCompleted(t *testing.T) {
+	// given
+	handler := &fakeEventHandler{}
+	watcher := fixWatcher(fake.NewSimpleClientset(), handler)
+
+	oldJob := fixJob()
+	newJob := fixJob()
+	completionTime := metaV1.NewTime(fixStartTime.Add(42 * time.Second))
+	newJob.Status.Succeeded = 1
+	newJob.Status.CompletionTime = &completionTime
+	newJob.Status.Conditions = []batchV1.JobCondition{
+		{Type: batchV1.JobComplete, Status: coreV1.ConditionTrue, LastTransitionTime: completionTime},
+	}
+
+	// when
+	watcher.handleJobUpdate(context.Background(), oldJob, newJob)
+
+	// then
+	require.Len(t, handler.events, 1)
+	event := handler.events[0]
+	assert.Equal(t, [][]string{{"all"}}, handler.sources)
+	assert.Equal(t, "Job backup-27755520 completed", event.Title)
+	assert.Equal(t, "Completed", event.Reason)
+	assert.Equal(t, config.Info, event.Level)
+	assert.Equal(t, []string{
+		"CronJob: backup",
+		"Duration: 42s",
+		"Succeeded pods: 1",
+	}, event.Messages)
+	assert.Empty(t, event.Buttons)
+}
+
+func TestWatcherHandleCronJobEvent(t *testing.T) {
+	tests := []struct {
+		name           string
+		reason         string
+		timestamp      time.Time
+		expectedEvents int
+	}{
+		{
+			name:           "Missed schedule",
+			reason:         "MissSchedule",
+			timestamp:      fixStartTime.Add(time.Minute),
+			expectedEvents: 1,
+		},
+		{
+			name:           "Event before start",
+			reason:         "MissSchedule",
+			timestamp:      fixStartTime.Add(-time.Minute),
+			expectedEvents: 0,
+		},
+		{
+			name:           "Other reason",
+			reason:         "SuccessfulCreate",
+			timestamp:      fixStartTime.Add(time.Minute),
+			expectedEvents: 0,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// given
+			handler := &fakeEventHandler{}
+			watcher := fixWatcher(fake.NewSimpleClientset(), handler)
+			k8sEvent := &coreV1.Event{
+				InvolvedObject: coreV1.ObjectReference{Kind: "CronJob", APIVersion: "batch/v1", Name: "backup", Namespace: "default"},
+				Reason:         tc.reason,
+				Message:        "Missed scheduled time to start a job: Mon, 10 Oct 2022 10:00:00 +0000",
+				LastTimestamp:  metaV1.NewTime(tc.timestamp),
+			}
+
+			// when
+			watcher.handleCronJobEvent(context.Background(), k8sEvent)
+
+			// then
+			require.Len(t, handler.events, tc.expectedEvents)
+			if tc.expectedEvents == 0 {
+				return
+			}
+			event := handler.events[0]
+			assert.Equal(t, "CronJob backup missed its schedule", event.Title)
+			assert.Equal(t, "CronJob", event.Kind)
+			assert.Equal(t, config.Warn, event.Level)
+			assert.Equal(t, []string{k8sEvent.Message}, event.Messages)
+		})
+	}
+}
+
+type fakeEventHandler struct {
+	events  []events.Event
+	sources [][]string
+}
+
+func (f *fakeEventHandler) HandleExternalEvent(_ context.Context, event events.Event, sources []string) {
+	f.events = append(f.events, event)
+	f.sources = append(f.sources, sources)
+}
+
+func fixWatcher(k8sCli *fake.Clientset, handler EventHandler) *Watcher {
+	logger, _ := logtest.NewNullLogger()
+	watcher := NewWatcher(logger, "dev", k8sCli, 0, map[string]config.Sources{
+		"all":           {Jobs: config.JobsSource{Enabled: true, NotifyOnCompletion: true}},
+		"failures-only": {Jobs: config.JobsSource{Enabled: true}},
+		"other-ns":      {Jobs: config.JobsSource{Enabled: true, NotifyOnCompletion: true, Namespaces: config.Namespaces{Include: []string{"prod"}}}},
+		"disabled":      {},
+	}, handler)
+	watcher.startTime = fixStartTime
+	return watcher
+}
+
+func fixJob() *batchV1.Job {
+	startTime := metaV1.NewTime(fixStartTime)
+	return &batchV1.Job{
+		ObjectMeta: metaV1.ObjectMeta{
+			Name:      "backup-27755520",
+			Namespace: "default",
+			OwnerReferences: []metaV1.OwnerReference{
+				{Kind: "CronJob", Name: "backup"},
+			},
+		},
+		Status: batchV1.JobStatus{
+			StartTime: &startTime,
+		},
+	}
+}
+
+func fixPod(name, jobName string, exitCode int32, reason string, finishedAt time.Time) *coreV1.Pod {
+	return &coreV1.Pod{
+		ObjectMeta: metaV1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+			Labels:    map[string]string{"job-name": jobName},
+		},
+		Status: coreV1.PodStatus{
+			ContainerStatuses: []coreV1.ContainerStatus{
+				{
+					Name: "main",
+					State: coreV1.ContainerState{
+						Terminated: &coreV1.ContainerStateTerminated{
+							ExitCode:   exitCode,
+							Reason:     reason,
+							FinishedAt: metaV1.NewTime(finishedAt),
+						},
+					},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,361 @@
+package nodes
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	coreV1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/events"
+)
+
+const (
+	nodeResource = "v1/nodes"
+
+	// DefaultInterval is used when the source doesn't specify how often the nodes are inspected.
+	DefaultInterval = time.Minute
+
+	// activePodsSelector skips the finished Pods, which don't reserve the node resources anymore.
+	activePodsSelector = "status.phase!=Succeeded,status.phase!=Failed"
+)
+
+// DefaultConditions are the node conditions reported when the source doesn't specify them.
+var DefaultConditions = []string{
+	string(coreV1.NodeMemoryPressure),
+	string(coreV1.NodeDiskPressure),
+	string(coreV1.NodePIDPressure),
+}
+
+// EventHandler handles events received from external sources.
+type EventHandler interface {
+	HandleExternalEvent(ctx context.Context, event events.Event, sources []string)
+}
+
+// Monitor periodically inspects the node conditions and the requested resources,
+// and sends warning events when the node is under pressure or the configured thresholds are crossed.
+type Monitor struct {
+	log         logrus.FieldLogger
+	clusterName string
+	k8sCli      kubernetes.Interface
+	sources     map[string]config.NodesSource
+	handler     EventHandler
+	interval    time.Duration
+
+	// exceeded holds the problems already reported per source and node. A problem is reported again only after it was resolved.
+	exceeded   map[string]map[string]struct{}
+	exceededMu sync.Mutex
+}
+
+// nodeUsage holds the resources requested by the Pods scheduled on a given node.
+type nodeUsage struct {
+	CPU    resource.Quantity
+	Memory resource.Quantity
+	Pods   int64
+}
+
+// problem describes a single node condition or crossed threshold.
+type problem struct {
+	ID      string
+	Message string
+}
+
+// NewMonitor returns a new Monitor instance for the source bindings with enabled nodes source.
+func NewMonitor(log logrus.FieldLogger, clusterName string, k8sCli kubernetes.Interface, sources map[string]config.Sources, handler EventHandler) *Monitor {
+	enabled := map[string]config.NodesSource{}
+	var interval time.Duration
+	for name, src := range sources {
+		cfg := src.Nodes
+		if !cfg.Enabled {
+			continue
+		}
+		if cfg.Interval <= 0 {
+			cfg.Interval = DefaultInterval
+		}
+		if len(cfg.Conditions) == 0 {
+			cfg.Conditions = DefaultConditions
+		}
+		if interval == 0 || cfg.Interval < interval {
+			interval = cfg.Interval
+		}
+		enabled[name] = cfg
+	}
+
+	return &Monitor{
+		log:         log,
+		clusterName: clusterName,
+		k8sCli:      k8sCli,
+		sources:     enabled,
+		handler:     handler,
+		interval:    interval,
+		exceeded:    map[string]map[string]struct{}{},
+	}
+}
+
+// Enabled returns true if any source binding has nodes source enabled.
+func (m *Monitor) Enabled() bool {
+	return len(m.sources) > 0
+}
+
+// Start starts inspecting nodes with the shortest interval configured for the source bindings. It blocks until the context is cancelled.
+func (m *Monitor) Start(ctx context.Context) error {
+	m.log.Infof("Starting nodes monitor with %s interval...", m.interval)
+
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+	for {
+		if err := m.check(ctx); err != nil {
+			m.log.Errorf("while checking nodes: %s", err.Error())
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func (m *Monitor) check(ctx context.Context) error {
+	nodes, err := m.k8sCli.CoreV1().Nodes().List(ctx, metaV1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("while listing nodes: %w", err)
+	}
+
+	usage, err := m.usagePerNode(ctx)
+	if err != nil {
+		return err
+	}
+
+	existing := map[string]struct{}{}
+	for _, node := range nodes.Items {
+		existing[node.Name] = struct{}{}
+		m.checkNode(ctx, node, usage[node.Name])
+	}
+	m.forgetRemovedNodes(existing)
+	return nil
+}
+
+func (m *Monitor) forgetRemovedNodes(existing map[string]struct{}) {
+	m.exceededMu.Lock()
+	defer m.exceededMu.Unlock()
+	for key := range m.exceeded {
+		// node names can't contain slashes, so the last part of the key is always the node name
+		node := key[strings.LastIndex(key, "/")+1:]
+		if _, found := existing[node]; !found {
+			delete(m.exceeded, key)
+		}
+	}
+}
+
+// checkNode sends one event per node with all problems, which are new for the given source binding.
+func (m *Monitor) checkNode(ctx context.Context, node coreV1.Node, usage nodeUsage) {
+	newProblemsPerSource := map[string][]problem{}
+
+	m.exceededMu.Lock()
+	for name, src := range m.sources {
+		key := fmt.Sprintf("%s/%s", name, node.Name)
+		reported := m.exceeded[key]
+
+		current := map[string]struct{}{}
+		for _, p := range problemsFor(node, usage, src) {
+			current[p.ID] = struct{}{}
+			if _, already := reported[p.ID]; already {
+				continue
+			}
+			newProblemsPerSource[name] = append(newProblemsPerSource[name], p)
+		}
+		m.exceeded[key] = current
+	}
+	m.exceededMu.Unlock()
+
+	// source bindings with the same new problems get the same event
+	sourcesPerEvent := map[string][]string{}
+	problemsPerEvent := map[string][]problem{}
+	for name, problems := range newProblemsPerSource {
+		var ids []string
+		for _, p := range problems {
+			ids = append(ids, p.ID)
+		}
+		eventKey := strings.Join(ids, ",")
+		sourcesPerEvent[eventKey] = append(sourcesPerEvent[eventKey], name)
+		problemsPerEvent[eventKey] = problems
+	}
+
+	eventKeys := make([]string, 0, len(sourcesPerEvent))
+	for key := range sourcesPerEvent {
+		eventKeys = append(eventKeys, key)
+	}
+	sort.Strings(eventKeys)
+
+	for _, key := range eventKeys {
+		sources := sourcesPerEvent[key]
+		sort.Strings(sources)
+		m.handler.HandleExternalEvent(ctx, m.eventFor(node, problemsPerEvent[key]), sources)
+	}
+}
+
+func (m *Monitor) eventFor(node coreV1.Node, problems []problem) events.Event {
+	var (
+		ids      []string
+		messages []string
+	)
+	for _, p := range problems {
+		ids = append(ids, p.ID)
+		messages = append(messages, p.Message)
+	}
+
+	return events.Event{
+		TypeMeta:  metaV1.TypeMeta{Kind: "Node", APIVersion: "v1"},
+		Title:     fmt.Sprintf("Node %s: %s", node.Name, strings.Join(ids, ", ")),
+		Name:      node.Name,
+		Messages:  messages,
+		Type:      config.WarningEvent,
+		Reason:    ids[0],
+		Level:     config.Warn,
+		Cluster:   m.clusterName,
+		TimeStamp: time.Now(),
+		Resource:  nodeResource,
+		SuggestedCommands: []string{
+			fmt.Sprintf("kubectl describe node %s", node.Name),
+			fmt.Sprintf("kubectl top node %s", node.Name),
+		},
+	}
+}
+
+// usagePerNode returns the resources requested by the active Pods, grouped by the node name.
+func (m *Monitor) usagePerNode(ctx context.Context) (map[string]nodeUsage, error) {
+	pods, err := m.k8sCli.CoreV1().Pods(metaV1.NamespaceAll).List(ctx, metaV1.ListOptions{
+		FieldSelector: activePodsSelector,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("while listing Pods: %w", err)
+	}
+
+	out := map[string]nodeUsage{}
+	for _, pod := range pods.Items {
+		if pod.Spec.NodeName == "" || pod.Status.Phase == coreV1.PodSucceeded || pod.Status.Phase == coreV1.PodFailed {
+			continue
+		}
+
+		usage := out[pod.Spec.NodeName]
+		requests := PodRequests(pod)
+		usage.CPU.Add(requests[coreV1.ResourceCPU])
+		usage.Memory.Add(requests[coreV1.ResourceMemory])
+		usage.Pods++
+		out[pod.Spec.NodeName] = usage
+	}
+	return out, nil
+}
+
+// PodRequests returns the effective Pod requests, the same way as the scheduler calculates them:
+// the sum of the app containers, or the highest init container request if it's bigger, plus the Pod overhead.
+func PodRequests(pod coreV1.Pod) coreV1.ResourceList {
+	out := coreV1.ResourceList{}
+	for _, container := range pod.Spec.Containers {
+		for name, quantity := range container.Resources.Requests {
+			sum := out[name]
+			sum.Add(quantity)
+			out[name] = sum
+		}
+	}
+
+	for _, container := range pod.Spec.InitContainers {
+		for name, quantity := range container.Resources.Requests {
+			if current, found := out[name]; !found || quantity.Cmp(current) > 0 {
+				out[name] = quantity.DeepCopy()
+			}
+		}
+	}
+
+	for name, quantity := range pod.Spec.Overhead {
+		sum := out[name]
+		sum.Add(quantity)
+		out[name] = sum
+	}
+	return out
+}
+
+func problemsFor(node coreV1.Node, usage nodeUsage, cfg config.NodesSource) []problem {
+	var out []problem
+
+	for _, cond := range node.Status.Conditions {
+		if cond.Status != coreV1.ConditionTrue || !containsCondition(cfg.Conditions, string(cond.Type)) {
+			continue
+		}
+		msg := fmt.Sprintf("Node condition %s is true", cond.Type)
+		if cond.Message != "" {
+			msg = fmt.Sprintf("%s: %s", msg, cond.Message)
+		}
+		out = append(out, problem{ID: string(cond.Type), Message: msg})
+	}
+
+	allocatable := node.Status.Allocatable
+	thresholds := []struct {
+		id          string
+		name        string
+		threshold   int
+		requested   float64
+		allocatable float64
+		format      func(float64) string
+	}{
+		{
+			id:          "CPURequestsThresholdExceeded",
+			name:        "CPU requests",
+			threshold:   cfg.Thresholds.CPURequests,
+			requested:   float64(usage.CPU.MilliValue()),
+			allocatable: float64(allocatable.Cpu().MilliValue()),
+			format:      func(v float64) string { return fmt.Sprintf("%gm", v) },
+		},
+		{
+			id:          "MemoryRequestsThresholdExceeded",
+			name:        "Memory requests",
+			threshold:   cfg.Thresholds.MemoryRequests,
+			requested:   float64(usage.Memory.Value()),
+			allocatable: float64(allocatable.Memory().Value()),
+			format:      func(v float64) string { return fmt.Sprintf("%.0fMi", v/(1<<20)) },
+		},
+		{
+			id:          "PodsThresholdExceeded",
+			name:        "Pods",
+			threshold:   cfg.Thresholds.Pods,
+			requested:   float64(usage.Pods),
+			allocatable: float64(allocatable.Pods().Value()),
+			format:      func(v float64) string { return fmt.Sprintf("%g", v) },
+		},
+	}
+	for _, t := range thresholds {
+		if t.threshold <= 0 || t.allocatable <= 0 {
+			continue
+		}
+
+		percent := t.requested / t.allocatable * 100
+		if percent < float64(t.threshold) {
+			continue
+		}
+		out = append(out, problem{
+			ID: t.id,
+			Message: fmt.Sprintf("%s: %s of %s allocatable (%.0f%%) crossed the %d%% threshold",
+				t.name, t.format(t.requested), t.format(t.allocatable), percent, t.threshold),
+		})
+	}
+
+	return out
+}
+
+func containsCondition(conditions []string, condType string) bool {
+	for _, c := range conditions {
+		if strings.EqualFold(c, condType) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,160 @@
+package nodes
+
+import (
+	"context"
+	"testing"
+
+	logtest "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	coreV1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/events"
+)
+
+func TestMonitorCheck(t *testing.T) {
+	// given
+	node := fixNode("node-1", coreV1.ConditionTrue)
+	k8sCli := fake.NewSimpleClientset(
+		node,
+		fixPod("app-1", "node-1", coreV1.PodRunning, "1500m", "1Gi"),
+		fixPod("app-2", "node-1", coreV1.PodRunning, "2", "1Gi"),
+		fixPod("finished", "node-1", coreV1.PodSucceeded, "4", "4Gi"),
+		fixPod("other", "node-2", coreV1.PodRunning, "4", "4Gi"),
+	)
+	handler := &fakeEventHandler{}
+	logger, _ := logtest.NewNullLogger()
+	monitor := NewMonitor(logger, "dev", k8sCli, map[string]config.Sources{
+		"cpu":             {Nodes: config.NodesSource{Enabled: true, Thresholds: config.NodeThresholds{CPURequests: 80}}},
+		"conditions-only": {Nodes: config.NodesSource{Enabled: true}},
+		"disk-only":       {Nodes: config.NodesSource{Enabled: true, Conditions: []string{"DiskPressure"}}},
+		"disabled":        {},
+	}, handler)
+
+	// when
+	err := monitor.check(context.Background())
+
+	// then
+	require.NoError(t, err)
+	require.Len(t, handler.events, 2)
+
+	assert.Equal(t, []string{"conditions-only"}, handler.sources[0])
+	assert.Equal(t, "Node node-1: MemoryPressure", handler.events[0].Title)
+	assert.Equal(t, []string{"Node condition MemoryPressure is true: kubelet has insufficient memory available"}, handler.events[0].Messages)
+
+	assert.Equal(t, []string{"cpu"}, handler.sources[1])
+	event := handler.events[1]
+	assert.Equal(t, "Node node-1: MemoryPressure, CPURequestsThresholdExceeded", event.Title)
+	assert.Equal(t, "MemoryPressure", event.Reason)
+	assert.Equal(t, "Node", event.Kind)
+	assert.Equal(t, config.WarningEvent, event.Type)
+	assert.Equal(t, config.Warn, event.Level)
+	assert.Equal(t, []string{
+		"Node condition MemoryPressure is true: kubelet has insufficient memory available",
+		"CPU requests: 3500m of 4000m allocatable (88%) crossed the 80% threshold",
+	}, event.Messages)
+
+	// when
+	handler.events, handler.sources = nil, nil
+	err = monitor.check(context.Background())
+
+	// then
+	require.NoError(t, err)
+	assert.Empty(t, handler.events, "problems already reported must not be sent again")
+
+	// when
+	node.Status.Conditions[0].Status = coreV1.ConditionFalse
+	_, err = k8sCli.CoreV1().Nodes().Update(context.Background(), node, metaV1.UpdateOptions{})
+	require.NoError(t, err)
+	require.NoError(t, monitor.check(context.Background()))
+
+	node.Status.Conditions[0].Status = coreV1.ConditionTrue
+	_, err = k8sCli.CoreV1().Nodes().Update(context.Background(), node, metaV1.UpdateOptions{})
+	require.NoError(t, err)
+	require.NoError(t, monitor.check(context.Background()))
+
+	// then
+	require.Len(t, handler.events, 1, "resolved problem must be reported again when it reappears")
+	assert.Equal(t, []string{"conditions-only", "cpu"}, handler.sources[0])
+	assert.Equal(t, "Node node-1: MemoryPressure", handler.events[0].Title)
+}
+
+func TestPodRequests(t *testing.T) {
+	// given
+	pod := coreV1.Pod{
+		Spec: coreV1.PodSpec{
+			InitContainers: []coreV1.Container{
+				fixContainer("3", "100Mi"),
+			},
+			Containers: []coreV1.Container{
+				fixContainer("1", "1Gi"),
+				fixContainer("500m", "1Gi"),
+			},
+			Overhead: coreV1.ResourceList{
+				coreV1.ResourceCPU: resource.MustParse("100m"),
+			},
+		},
+	}
+
+	// when
+	requests := PodRequests(pod)
+
+	// then
+	cpu, memory := requests[coreV1.ResourceCPU], requests[coreV1.ResourceMemory]
+	assert.Equal(t, int64(3100), cpu.MilliValue())
+	assert.Equal(t, int64(2<<30), memory.Value())
+}
+
+type fakeEventHandler struct {
+	events  []events.Event
+	sources [][]string
+}
+
+func (f *fakeEventHandler) HandleExternalEvent(_ context.Context, event events.Event, sources []string) {
+	f.events = append(f.events, event)
+	f.sources = append(f.sources, sources)
+}
+
+func fixNode(name string, memoryPressure coreV1.ConditionStatus) *coreV1.Node {
+	return &coreV1.Node{
+		ObjectMeta: metaV1.ObjectMeta{Name: name},
+		Status: coreV1.NodeStatus{
+			Conditions: []coreV1.NodeCondition{
+				{Type: coreV1.NodeMemoryPressure, Status: memoryPressure, Message: "kubelet has insufficient memory available"},
+				{Type: coreV1.NodeDiskPressure, Status: coreV1.ConditionFalse},
+				{Type: coreV1.NodeReady, Status: coreV1.ConditionTrue},
+			},
+			Allocatable: coreV1.ResourceList{
+				coreV1.ResourceCPU:    resource.MustParse("4"),
+				coreV1.ResourceMemory: resource.MustParse("8Gi"),
+				coreV1.ResourcePods:   resource.MustParse("110"),
+			},
+		},
+	}
+}
+
+func fixPod(name, nodeName string, phase coreV1.PodPhase, cpu, memory string) *coreV1.Pod {
+	return &coreV1.Pod{
+		ObjectMeta: metaV1.ObjectMeta{Name: name, Namespace: "default"},
+		Spec: coreV1.PodSpec{
+			NodeName:   nodeName,
+			Containers: []coreV1.Container{fixContainer(cpu, memory)},
+		},
+		Status: coreV1.PodStatus{Phase: phase},
+	}
+}
+
+func fixContainer(cpu, memory string) coreV1.Container {
+	return coreV1.Container{
+		Resources: coreV1.ResourceRequirements{
+			Requests: coreV1.ResourceList{
+				coreV1.ResourceCPU:    resource.MustParse(cpu),
+				coreV1.ResourceMemory: resource.MustParse(memory),
+			},
+		},
+	}
+}
@@ -0,0 +1,224 @@
+package plugin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/events"
+)
+
+const (
+	// DefaultDirectory is used when the plugins directory is not configured.
+	DefaultDirectory = "/botkube/plugins"
+
+	pluginKind = "Plugin"
+
+	defaultInitialBackoff = time.Second
+	defaultMaxBackoff     = time.Minute
+)
+
+// EventHandler handles events received from external sources.
+type EventHandler interface {
+	HandleExternalEvent(ctx context.Context, event events.Event, sources []string)
+}
+
+// Manager discovers the source plugin binaries and supervises the plugins enabled in source bindings.
+// Plugins which stop are restarted with exponential backoff.
+type Manager struct {
+	log         logrus.FieldLogger
+	clusterName string
+	dir         string
+	bindings    []binding
+	handler     EventHandler
+
+	newPlugin      func(log logrus.FieldLogger, path string) Plugin
+	initialBackoff time.Duration
+	maxBackoff     time.Duration
+}
+
+// binding is a single plugin enabled in a given source binding.
+type binding struct {
+	Source string
+	Plugin string
+	Config []byte
+}
+
+// NewManager returns a new Manager instance for the plugins enabled in source bindings.
+func NewManager(log logrus.FieldLogger, clusterName string, cfg config.PluginsSettings, sources map[string]config.Sources, handler EventHandler) (*Manager, error) {
+	var bindings []binding
+	for srcName, src := range sources {
+		for pluginName, plugin := range src.Plugins {
+			if !plugin.Enabled {
+				continue
+			}
+
+			raw, err := json.Marshal(plugin.Config)
+			if err != nil {
+				return nil, fmt.Errorf("while marshaling configuration of %q plugin in %q source: %w", pluginName, srcName, err)
+			}
+			bindings = append(bindings, binding{Source: srcName, Plugin: pluginName, Config: raw})
+		}
+	}
+	sort.Slice(bindings, func(i, j int) bool {
+		if bindings[i].Source != bindings[j].Source {
+			return bindings[i].Source < bindings[j].Source
+		}
+		return bindings[i].Plugin < bindings[j].Plugin
+	})
+
+	dir := cfg.Directory
+	if dir == "" {
+		dir = DefaultDirectory
+	}
+
+	return &Manager{
+		log:         log,
+		clusterName: clusterName,
+		dir:         dir,
+		bindings:    bindings,
+		handler:     handler,
+		newPlugin: func(log logrus.FieldLogger, path string) Plugin {
+			return NewProcess(log, path)
+		},
+		initialBackoff: defaultInitialBackoff,
+		maxBackoff:     defaultMaxBackoff,
+	}, nil
+}
+
+// Enabled returns true if any source binding has a plugin enabled.
+func (m *Manager) Enabled() bool {
+	return len(m.bindings) > 0
+}
+
+// Start discovers the plugin binaries and runs the enabled plugins. It blocks until the context is cancelled.
+func (m *Manager) Start(ctx context.Context) error {
+	m.log.Infof("Starting source plugins from %q...", m.dir)
+
+	paths, err := Discover(m.dir)
+	if err != nil {
+		m.log.Errorf("Source plugins are disabled: %s", err.Error())
+		return nil
+	}
+
+	var wg sync.WaitGroup
+	for _, b := range m.bindings {
+		path, found := paths[b.Plugin]
+		if !found {
+			m.log.Errorf("Plugin %q enabled in %q source not found in %q. Skipping...", b.Plugin, b.Source, m.dir)
+			continue
+		}
+
+		log := m.log.WithFields(logrus.Fields{"plugin": b.Plugin, "source": b.Source})
+		wg.Add(1)
+		go func(b binding, plugin Plugin, log logrus.FieldLogger) {
+			defer wg.Done()
+			m.supervise(ctx, b, plugin, log)
+		}(b, m.newPlugin(log, path), log)
+	}
+
+	wg.Wait()
+	return nil
+}
+
+// supervise runs a given plugin until the context is cancelled, and forwards its events to the source binding.
+func (m *Manager) supervise(ctx context.Context, b binding, plugin Plugin, log logrus.FieldLogger) {
+	out := make(chan Event)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for event := range out {
+			m.handler.HandleExternalEvent(ctx, m.eventFor(b, event), []string{b.Source})
+		}
+	}()
+	defer func() {
+		close(out)
+		<-done
+	}()
+
+	attempts := 0
+	for {
+		startedAt := time.Now()
+		err := plugin.Stream(ctx, b.Config, out)
+		if ctx.Err() != nil {
+			return
+		}
+
+		if time.Since(startedAt) >= m.maxBackoff {
+			// the plugin was running long enough to start the backoff again
+			attempts = 0
+		}
+		attempts++
+
+		backoff := m.backoff(attempts)
+		if err != nil {
+			log.Errorf("Plugin stopped with error: %s. Restarting in %s...", err.Error(), backoff)
+		} else {
+			log.Warnf("Plugin stopped. Restarting in %s...", backoff)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+	}
+}
+
+// backoff returns the time to wait before a next restart, based on the number of restarts in a row.
+func (m *Manager) backoff(attempts int) time.Duration {
+	backoff := m.initialBackoff
+	for i := 1; i < attempts; i++ {
+		backoff *= 2
+		if backoff >= m.maxBackoff {
+			return m.maxBackoff
+		}
+	}
+	return backoff
+}
+
+func (m *Manager) eventFor(b binding, in Event) events.Event {
+	event := events.Event{
+		TypeMeta:          metaV1.TypeMeta{Kind: in.Kind, APIVersion: in.APIVersion},
+		Title:             in.Title,
+		Name:              in.Name,
+		Namespace:         in.Namespace,
+		Messages:          in.Messages,
+		Reason:            in.Reason,
+		Level:             in.Level,
+		Cluster:           m.clusterName,
+		TimeStamp:         in.TimeStamp,
+		Resource:          fmt.Sprintf("plugins/%s", b.Plugin),
+		SuggestedCommands: in.SuggestedCommands,
+	}
+	if event.Kind == "" {
+		event.Kind = pluginKind
+	}
+	if event.Name == "" {
+		event.Name = b.Plugin
+	}
+	if event.Title == "" {
+		event.Title = fmt.Sprintf("Event from %s plugin", b.Plugin)
+	}
+	if event.TimeStamp.IsZero() {
+		event.TimeStamp = time.Now()
+	}
+
+	switch event.Level {
+	case config.Critical, config.Error:
+		event.Type = config.ErrorEvent
+	case config.Warn:
+		event.Type = config.WarningEvent
+	default:
+		event.Level = config.Info
+		event.Type = config.InfoEvent
+	}
+	return event
+}
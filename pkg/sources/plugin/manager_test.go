@@ -0,0 +1,136 @@
+package plugin
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	logtest "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/events"
+)
+
+func TestManagerStart(t *testing.T) {
+	// given
+	dir := t.TempDir()
+	writeBinary(t, dir, "botkube-source-velero", "#!/bin/sh\n")
+
+	handler := &fakeEventHandler{}
+	logger, _ := logtest.NewNullLogger()
+	manager, err := NewManager(logger, "dev", config.PluginsSettings{Directory: dir}, map[string]config.Sources{
+		"backups": {Plugins: map[string]config.SourcePlugin{
+			"velero":  {Enabled: true, Config: map[string]interface{}{"namespace": "velero"}},
+			"missing": {Enabled: true},
+		}},
+		"disabled": {Plugins: map[string]config.SourcePlugin{
+			"velero": {Enabled: false},
+		}},
+	}, handler)
+	require.NoError(t, err)
+	manager.initialBackoff = time.Millisecond
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	plugin := &fakePlugin{
+		cancel: cancel,
+		runs: []fakeRun{
+			{events: []Event{{Title: "Backup failed", Level: config.Error, Kind: "Backup", Name: "daily"}}, err: errors.New("connection lost")},
+			{events: []Event{{Messages: []string{"Backup finished"}}}},
+		},
+	}
+	manager.newPlugin = func(_ logrus.FieldLogger, path string) Plugin {
+		assert.Equal(t, filepath.Join(dir, "botkube-source-velero"), path)
+		return plugin
+	}
+
+	// when
+	err = manager.Start(ctx)
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, []string{`{"namespace":"velero"}`, `{"namespace":"velero"}`}, plugin.configs, "plugin must be restarted after failure")
+
+	require.Len(t, handler.events, 2)
+	assert.Equal(t, [][]string{{"backups"}, {"backups"}}, handler.sources)
+
+	failed := handler.events[0]
+	assert.Equal(t, "Backup failed", failed.Title)
+	assert.Equal(t, "Backup", failed.Kind)
+	assert.Equal(t, "daily", failed.Name)
+	assert.Equal(t, config.ErrorEvent, failed.Type)
+	assert.Equal(t, "dev", failed.Cluster)
+	assert.Equal(t, "plugins/velero", failed.Resource)
+
+	finished := handler.events[1]
+	assert.Equal(t, "Event from velero plugin", finished.Title)
+	assert.Equal(t, "Plugin", finished.Kind)
+	assert.Equal(t, "velero", finished.Name)
+	assert.Equal(t, config.Info, finished.Level)
+	assert.Equal(t, config.InfoEvent, finished.Type)
+	assert.Equal(t, []string{"Backup finished"}, finished.Messages)
+	assert.False(t, finished.TimeStamp.IsZero())
+}
+
+func TestManagerBackoff(t *testing.T) {
+	// given
+	manager := &Manager{initialBackoff: time.Second, maxBackoff: 5 * time.Second}
+
+	// when
+	var got []time.Duration
+	for attempts := 1; attempts <= 5; attempts++ {
+		got = append(got, manager.backoff(attempts))
+	}
+
+	// then
+	assert.Equal(t, []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second}, got)
+}
+
+type fakeRun struct {
+	events []Event
+	err    error
+}
+
+// fakePlugin sends events defined for subsequent runs, and cancels the context after the last run.
+type fakePlugin struct {
+	mu      sync.Mutex
+	cancel  context.CancelFunc
+	runs    []fakeRun
+	configs []string
+}
+
+func (f *fakePlugin) Stream(_ context.Context, cfg []byte, out chan<- Event) error {
+	f.mu.Lock()
+	f.configs = append(f.configs, string(cfg))
+	run := f.runs[0]
+	f.runs = f.runs[1:]
+	last := len(f.runs) == 0
+	f.mu.Unlock()
+
+	for _, event := range run.events {
+		out <- event
+	}
+	if last {
+		f.cancel()
+	}
+	return run.err
+}
+
+type fakeEventHandler struct {
+	mu      sync.Mutex
+	events  []events.Event
+	sources [][]string
+}
+
+func (f *fakeEventHandler) HandleExternalEvent(_ context.Context, event events.Event, sources []string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.events = append(f.events, event)
+	f.sources = append(f.sources, sources)
+}
@@ -0,0 +1,28 @@
+package plugin
+
+import (
+	"context"
+	"time"
+
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+// Plugin is the contract implemented by source plugins.
+// Stream sends plugin events to the out channel until the context is cancelled or the plugin stops.
+type Plugin interface {
+	Stream(ctx context.Context, cfg []byte, out chan<- Event) error
+}
+
+// Event is an event sent by a source plugin. It mirrors the Event message from pkg/pluginrpc/pb/plugin.proto.
+type Event struct {
+	Title             string       `json:"title"`
+	Messages          []string     `json:"messages,omitempty"`
+	Level             config.Level `json:"level,omitempty"`
+	Reason            string       `json:"reason,omitempty"`
+	Kind              string       `json:"kind,omitempty"`
+	APIVersion        string       `json:"apiVersion,omitempty"`
+	Name              string       `json:"name,omitempty"`
+	Namespace         string       `json:"namespace,omitempty"`
+	TimeStamp         time.Time    `json:"timestamp,omitempty"`
+	SuggestedCommands []string     `json:"suggestedCommands,omitempty"`
+}
@@ -0,0 +1,110 @@
+package plugin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/pluginrpc"
+	"github.com/kubeshop/botkube/pkg/pluginrpc/pb"
+)
+
+// BinaryPrefix is the file name prefix of the source plugin binaries. The rest of the file name is the plugin name.
+const BinaryPrefix = "botkube-source-"
+
+// Process is a Plugin which runs an external binary. The binary serves the Source gRPC service defined in
+// pkg/pluginrpc/pb/plugin.proto, see pluginrpc.ServeSource. Lines written to stderr are logged.
+type Process struct {
+	log  logrus.FieldLogger
+	path string
+}
+
+// NewProcess returns a new Process instance for a given plugin binary.
+func NewProcess(log logrus.FieldLogger, path string) *Process {
+	return &Process{log: log, path: path}
+}
+
+// Stream runs the plugin binary and forwards its events until the stream ends or the context is cancelled.
+// The binary is stopped afterwards.
+func (p *Process) Stream(ctx context.Context, cfg []byte, out chan<- Event) error {
+	conn, err := pluginrpc.Start(p.log, p.path, pluginrpc.SourcePluginName)
+	if err != nil {
+		return err
+	}
+	defer conn.Kill()
+
+	stream, err := conn.Source().Stream(ctx, &pb.StreamRequest{Config: cfg})
+	if err != nil {
+		return fmt.Errorf("while starting stream: %w", err)
+	}
+
+	for {
+		event, err := stream.Recv()
+		switch {
+		case err == nil:
+		case errors.Is(err, io.EOF), ctx.Err() != nil:
+			return nil
+		default:
+			return fmt.Errorf("while receiving events: %w", err)
+		}
+
+		select {
+		case out <- eventFromProto(event):
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+func eventFromProto(in *pb.Event) Event {
+	event := Event{
+		Title:             in.Title,
+		Messages:          in.Messages,
+		Level:             config.Level(in.Level),
+		Reason:            in.Reason,
+		Kind:              in.Kind,
+		APIVersion:        in.ApiVersion,
+		Name:              in.Name,
+		Namespace:         in.Namespace,
+		SuggestedCommands: in.SuggestedCommands,
+	}
+	if in.Timestamp != nil {
+		event.TimeStamp = in.Timestamp.AsTime()
+	}
+	return event
+}
+
+// Discover returns paths of the source plugin binaries found in a given directory, indexed by the plugin name.
+func Discover(dir string) (map[string]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("while reading plugins directory: %w", err)
+	}
+
+	out := map[string]string{}
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasPrefix(name, BinaryPrefix) {
+			continue
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			return nil, fmt.Errorf("while getting details of %q: %w", name, err)
+		}
+		if info.Mode()&0o111 == 0 {
+			// not executable
+			continue
+		}
+
+		out[strings.TrimPrefix(name, BinaryPrefix)] = filepath.Join(dir, name)
+	}
+	return out, nil
+}
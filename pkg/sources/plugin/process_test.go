@@ -0,0 +1,169 @@
+package plugin
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	logtest "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/pluginrpc"
+	"github.com/kubeshop/botkube/pkg/pluginrpc/pb"
+)
+
+// testPluginEnv selects the fake plugin served by the test binary when it's started as a plugin.
+const testPluginEnv = "BOTKUBE_TEST_SOURCE_PLUGIN"
+
+func TestMain(m *testing.M) {
+	switch os.Getenv(testPluginEnv) {
+	case "":
+		os.Exit(m.Run())
+	case "echo":
+		pluginrpc.ServeSource(&echoSource{})
+	case "failing":
+		pluginrpc.ServeSource(&failingSource{})
+	}
+	os.Exit(0)
+}
+
+func TestProcessStream(t *testing.T) {
+	// given
+	path := writePluginBinary(t, t.TempDir(), "botkube-source-echo", "echo")
+	logger, _ := logtest.NewNullLogger()
+	out := make(chan Event, 10)
+
+	// when
+	err := NewProcess(logger, path).Stream(context.Background(), []byte(`"from config"`), out)
+
+	// then
+	require.NoError(t, err)
+	close(out)
+	var got []Event
+	for event := range out {
+		got = append(got, event)
+	}
+	assert.Equal(t, []Event{
+		{Title: "Backup finished", Level: config.Warn, Messages: []string{"took 5m"}, TimeStamp: time.Date(2022, 10, 1, 12, 0, 0, 0, time.UTC)},
+		{Title: "Config", Messages: []string{`"from config"`}},
+	}, got)
+}
+
+func TestProcessStreamFailure(t *testing.T) {
+	// given
+	path := writePluginBinary(t, t.TempDir(), "botkube-source-failing", "failing")
+	logger, _ := logtest.NewNullLogger()
+
+	// when
+	err := NewProcess(logger, path).Stream(context.Background(), nil, make(chan Event))
+
+	// then
+	assert.EqualError(t, err, "while receiving events: rpc error: code = Unavailable desc = backend is down")
+}
+
+func TestProcessStreamNotAPlugin(t *testing.T) {
+	// given
+	path := writeBinary(t, t.TempDir(), "botkube-source-invalid", "#!/bin/sh\nexit 3\n")
+	logger, _ := logtest.NewNullLogger()
+
+	// when
+	err := NewProcess(logger, path).Stream(context.Background(), nil, make(chan Event))
+
+	// then
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `while starting "`+path+`"`)
+}
+
+func TestProcessStreamCancelled(t *testing.T) {
+	// given
+	path := writePluginBinary(t, t.TempDir(), "botkube-source-echo", "echo")
+	logger, _ := logtest.NewNullLogger()
+	ctx, cancel := context.WithCancel(context.Background())
+	out := make(chan Event)
+
+	// when
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- NewProcess(logger, path).Stream(ctx, nil, out)
+	}()
+	<-out
+	cancel()
+
+	// then
+	select {
+	case err := <-errCh:
+		assert.NoError(t, err)
+	case <-time.After(10 * time.Second):
+		t.Fatal("plugin was not stopped after the context was cancelled")
+	}
+}
+
+func TestDiscover(t *testing.T) {
+	// given
+	dir := t.TempDir()
+	writeBinary(t, dir, "botkube-source-velero", "#!/bin/sh\n")
+	writeBinary(t, dir, "other-binary", "#!/bin/sh\n")
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "botkube-source-readme"), []byte("not executable"), 0o600))
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "botkube-source-dir"), 0o700))
+
+	// when
+	paths, err := Discover(dir)
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"velero": filepath.Join(dir, "botkube-source-velero"),
+	}, paths)
+}
+
+func writeBinary(t *testing.T, dir, name, content string) string {
+	t.Helper()
+
+	path := filepath.Join(dir, name)
+	// #nosec G306 -- test binaries need to be executable
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o700))
+	return path
+}
+
+// writePluginBinary writes a script which runs the test binary as a given fake plugin.
+func writePluginBinary(t *testing.T, dir, name, fake string) string {
+	t.Helper()
+
+	return writeBinary(t, dir, name, fmt.Sprintf("#!/bin/sh\n%s=%s exec %q\n", testPluginEnv, fake, os.Args[0]))
+}
+
+// echoSource sends a static event, followed by an event with the received configuration.
+type echoSource struct {
+	pb.UnimplementedSourceServer
+}
+
+func (*echoSource) Stream(req *pb.StreamRequest, stream pb.Source_StreamServer) error {
+	fmt.Fprintln(os.Stderr, "started")
+	err := stream.Send(&pb.Event{
+		Title:     "Backup finished",
+		Level:     string(config.Warn),
+		Messages:  []string{"took 5m"},
+		Timestamp: timestamppb.New(time.Date(2022, 10, 1, 12, 0, 0, 0, time.UTC)),
+	})
+	if err != nil {
+		return err
+	}
+	return stream.Send(&pb.Event{Title: "Config", Messages: []string{string(req.Config)}})
+}
+
+// failingSource stops the stream with an error.
+type failingSource struct {
+	pb.UnimplementedSourceServer
+}
+
+func (*failingSource) Stream(*pb.StreamRequest, pb.Source_StreamServer) error {
+	return status.Error(codes.Unavailable, "backend is down")
+}
@@ -0,0 +1,308 @@
+package podcrashes
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	coreV1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/events"
+)
+
+const (
+	podResource = "v1/pods"
+
+	// DefaultMinRestarts is used when the source doesn't specify the number of restarts.
+	DefaultMinRestarts = 3
+	// DefaultLogLines is used when the source doesn't specify the number of log lines.
+	DefaultLogLines = 20
+
+	reasonOOMKilled        = "OOMKilled"
+	reasonCrashLoopBackOff = "CrashLoopBackOff"
+)
+
+// EventHandler handles events received from external sources.
+type EventHandler interface {
+	HandleExternalEvent(ctx context.Context, event events.Event, sources []string)
+}
+
+// Watcher watches Pods and sends a single aggregated event when a container is OOMKilled or crash looping,
+// once it's restarted a configured number of times.
+type Watcher struct {
+	log          logrus.FieldLogger
+	clusterName  string
+	k8sCli       kubernetes.Interface
+	resyncPeriod time.Duration
+	sources      map[string]config.PodCrashesSource
+	handler      EventHandler
+
+	// notified holds the containers already reported per source binding, grouped by the Pod UID.
+	notified   map[types.UID]map[string]struct{}
+	notifiedMu sync.Mutex
+}
+
+// crash describes a crashing container.
+type crash struct {
+	Pod       *coreV1.Pod
+	Container string
+	Reason    string
+	Restarts  int32
+	LastState *coreV1.ContainerStateTerminated
+}
+
+// NewWatcher returns a new Watcher instance for the source bindings with enabled Pod crashes source.
+func NewWatcher(log logrus.FieldLogger, clusterName string, k8sCli kubernetes.Interface, resyncPeriod time.Duration, sources map[string]config.Sources, handler EventHandler) *Watcher {
+	enabled := map[string]config.PodCrashesSource{}
+	for name, src := range sources {
+		cfg := src.PodCrashes
+		if !cfg.Enabled {
+			continue
+		}
+		if cfg.MinRestarts <= 0 {
+			cfg.MinRestarts = DefaultMinRestarts
+		}
+		if cfg.LogLines <= 0 {
+			cfg.LogLines = DefaultLogLines
+		}
+		enabled[name] = cfg
+	}
+
+	return &Watcher{
+		log:          log,
+		clusterName:  clusterName,
+		k8sCli:       k8sCli,
+		resyncPeriod: resyncPeriod,
+		sources:      enabled,
+		handler:      handler,
+		notified:     map[types.UID]map[string]struct{}{},
+	}
+}
+
+// Enabled returns true if any source binding has Pod crashes source enabled.
+func (w *Watcher) Enabled() bool {
+	return len(w.sources) > 0
+}
+
+// Start starts watching Pods. It blocks until the context is cancelled.
+func (w *Watcher) Start(ctx context.Context) error {
+	w.log.Info("Starting Pod crashes watcher...")
+
+	factory := informers.NewSharedInformerFactory(w.k8sCli, w.resyncPeriod)
+	factory.Core().V1().Pods().Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		UpdateFunc: func(_, newObj interface{}) {
+			if pod, ok := newObj.(*coreV1.Pod); ok {
+				w.handlePod(ctx, pod)
+			}
+		},
+		DeleteFunc: func(obj interface{}) {
+			if pod, ok := obj.(*coreV1.Pod); ok {
+				w.forget(pod)
+			}
+		},
+	})
+
+	factory.Start(ctx.Done())
+	<-ctx.Done()
+	return nil
+}
+
+func (w *Watcher) handlePod(ctx context.Context, pod *coreV1.Pod) {
+	for _, c := range crashesFor(pod) {
+		sources := w.sourcesToNotify(c)
+		if len(sources) == 0 {
+			continue
+		}
+
+		// log lines are the same for all source bindings, so the biggest number is fetched
+		logLines := 0
+		for _, name := range sources {
+			if lines := w.sources[name].LogLines; lines > logLines {
+				logLines = lines
+			}
+		}
+
+		logs, err := w.previousLogs(ctx, c, logLines)
+		if err != nil {
+			w.log.Errorf("while getting logs of container %q in Pod %s/%s: %s", c.Container, pod.Namespace, pod.Name, err.Error())
+			// continue, as the event is still useful without logs
+		}
+
+		w.handler.HandleExternalEvent(ctx, w.eventFor(c, logs), sources)
+	}
+}
+
+// sourcesToNotify returns sorted names of the source bindings, which weren't notified about the crash yet
+// and for which the container reached the minimum number of restarts.
+func (w *Watcher) sourcesToNotify(c crash) []string {
+	w.notifiedMu.Lock()
+	defer w.notifiedMu.Unlock()
+
+	var out []string
+	for name, src := range w.sources {
+		if src.Namespaces.IsConfigured() && !src.Namespaces.IsAllowed(c.Pod.Namespace) {
+			continue
+		}
+		if int(c.Restarts) < src.MinRestarts {
+			continue
+		}
+
+		reported, found := w.notified[c.Pod.UID]
+		if !found {
+			reported = map[string]struct{}{}
+			w.notified[c.Pod.UID] = reported
+		}
+		key := fmt.Sprintf("%s/%s", name, c.Container)
+		if _, already := reported[key]; already {
+			continue
+		}
+		reported[key] = struct{}{}
+		out = append(out, name)
+	}
+	sort.Strings(out)
+	return out
+}
+
+func (w *Watcher) forget(pod *coreV1.Pod) {
+	w.notifiedMu.Lock()
+	defer w.notifiedMu.Unlock()
+
+	delete(w.notified, pod.UID)
+}
+
+func (w *Watcher) previousLogs(ctx context.Context, c crash, lines int) (string, error) {
+	tailLines := int64(lines)
+	raw, err := w.k8sCli.CoreV1().Pods(c.Pod.Namespace).GetLogs(c.Pod.Name, &coreV1.PodLogOptions{
+		Container: c.Container,
+		Previous:  true,
+		TailLines: &tailLines,
+	}).DoRaw(ctx)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(raw)), nil
+}
+
+func (w *Watcher) eventFor(c crash, logs string) events.Event {
+	pod := c.Pod
+	event := events.Event{
+		TypeMeta:  metaV1.TypeMeta{Kind: "Pod", APIVersion: "v1"},
+		Name:      pod.Name,
+		Namespace: pod.Namespace,
+		Type:      config.ErrorEvent,
+		Reason:    c.Reason,
+		Level:     config.Error,
+		Cluster:   w.clusterName,
+		TimeStamp: time.Now(),
+		Resource:  podResource,
+		Object:    pod,
+		Messages: []string{
+			fmt.Sprintf("Restarts: %d", c.Restarts),
+		},
+		Buttons: []events.Button{
+			{
+				Name:    "Show previous logs",
+				Command: fmt.Sprintf("kubectl logs pod/%s --container %s --previous --namespace %s", pod.Name, c.Container, pod.Namespace),
+			},
+			{
+				Name:    "Describe Pod",
+				Command: fmt.Sprintf("kubectl describe pod/%s --namespace %s", pod.Name, pod.Namespace),
+			},
+		},
+	}
+
+	if c.Reason == reasonOOMKilled {
+		event.Title = fmt.Sprintf("Container %s in Pod %s was OOMKilled", c.Container, pod.Name)
+	} else {
+		event.Title = fmt.Sprintf("Container %s in Pod %s is crash looping", c.Container, pod.Name)
+	}
+
+	if state := c.LastState; state != nil {
+		msg := fmt.Sprintf("Last termination: %s (exit code %d)", state.Reason, state.ExitCode)
+		if !state.FinishedAt.IsZero() {
+			msg = fmt.Sprintf("%s at %s", msg, state.FinishedAt.UTC().Format(time.RFC3339))
+		}
+		event.Messages = append(event.Messages, msg)
+	}
+
+	if resources, found := containerResources(pod, c.Container); found {
+		event.Messages = append(event.Messages,
+			fmt.Sprintf("Limits: %s", formatResources(resources.Limits)),
+			fmt.Sprintf("Requests: %s", formatResources(resources.Requests)),
+		)
+	}
+
+	if logs != "" {
+		event.Messages = append(event.Messages, fmt.Sprintf("Last logs of the previous container:\n%s", logs))
+	}
+
+	return event
+}
+
+// crashesFor returns the containers which are OOMKilled or crash looping.
+func crashesFor(pod *coreV1.Pod) []crash {
+	var out []crash
+	statuses := append(append([]coreV1.ContainerStatus{}, pod.Status.InitContainerStatuses...), pod.Status.ContainerStatuses...)
+	for _, status := range statuses {
+		lastState := status.LastTerminationState.Terminated
+		if status.State.Terminated != nil {
+			lastState = status.State.Terminated
+		}
+
+		var reason string
+		switch {
+		case lastState != nil && lastState.Reason == reasonOOMKilled:
+			reason = reasonOOMKilled
+		case status.State.Waiting != nil && status.State.Waiting.Reason == reasonCrashLoopBackOff:
+			reason = reasonCrashLoopBackOff
+		default:
+			continue
+		}
+
+		out = append(out, crash{
+			Pod:       pod,
+			Container: status.Name,
+			Reason:    reason,
+			Restarts:  status.RestartCount,
+			LastState: lastState,
+		})
+	}
+	return out
+}
+
+func containerResources(pod *coreV1.Pod, name string) (coreV1.ResourceRequirements, bool) {
+	containers := append(append([]coreV1.Container{}, pod.Spec.InitContainers...), pod.Spec.Containers...)
+	for _, c := range containers {
+		if c.Name == name {
+			return c.Resources, true
+		}
+	}
+	return coreV1.ResourceRequirements{}, false
+}
+
+func formatResources(in coreV1.ResourceList) string {
+	if len(in) == 0 {
+		return "not set"
+	}
+
+	var out []string
+	for _, name := range []coreV1.ResourceName{coreV1.ResourceCPU, coreV1.ResourceMemory} {
+		if quantity, found := in[name]; found {
+			out = append(out, fmt.Sprintf("%s=%s", name, quantity.String()))
+		}
+	}
+	if len(out) == 0 {
+		return "not set"
+	}
+	return strings.Join(out, ", ")
+}
@@ -0,0 +1,193 @@
+package podcrashes
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	logtest "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	coreV1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/events"
+)
+
+var fixFinishedAt = time.Date(2022, 10, 10, 10, 0, 0, 0, time.UTC)
+
+func TestWatcherHandlePodOOMKilled(t *testing.T) {
+	// given
+	handler := &fakeEventHandler{}
+	watcher := fixWatcher(handler)
+	pod := fixPod(coreV1.ContainerStatus{
+		Name:         "app",
+		RestartCount: 3,
+		State: coreV1.ContainerState{
+			Waiting: &coreV1.ContainerStateWaiting{Reason: reasonCrashLoopBackOff},
+		},
+		LastTerminationState: coreV1.ContainerState{
+			Terminated: &coreV1.ContainerStateTerminated{Reason: reasonOOMKilled, ExitCode: 137, FinishedAt: metaV1.NewTime(fixFinishedAt)},
+		},
+	})
+
+	// when
+	watcher.handlePod(context.Background(), pod)
+	// next restarts don't repeat the notification
+	pod.Status.ContainerStatuses[0].RestartCount = 4
+	watcher.handlePod(context.Background(), pod)
+
+	// then
+	require.Len(t, handler.events, 1)
+	assert.Equal(t, [][]string{{"all"}}, handler.sources)
+
+	event := handler.events[0]
+	assert.Equal(t, "Container app in Pod api-7d9f was OOMKilled", event.Title)
+	assert.Equal(t, "Pod", event.Kind)
+	assert.Equal(t, reasonOOMKilled, event.Reason)
+	assert.Equal(t, config.ErrorEvent, event.Type)
+	assert.Equal(t, config.Error, event.Level)
+	assert.Equal(t, []string{
+		"Restarts: 3",
+		"Last termination: OOMKilled (exit code 137) at 2022-10-10T10:00:00Z",
+		"Limits: cpu=500m, memory=128Mi",
+		"Requests: memory=64Mi",
+		"Last logs of the previous container:\nfake logs",
+	}, event.Messages)
+	assert.Equal(t, []events.Button{
+		{Name: "Show previous logs", Command: "kubectl logs pod/api-7d9f --container app --previous --namespace default"},
+		{Name: "Describe Pod", Command: "kubectl describe pod/api-7d9f --namespace default"},
+	}, event.Buttons)
+}
+
+func TestWatcherHandlePod(t *testing.T) {
+	tests := []struct {
+		name            string
+		status          coreV1.ContainerStatus
+		expectedSources [][]string
+		expectedReason  string
+	}{
+		{
+			name: "Crash loop with enough restarts",
+			status: coreV1.ContainerStatus{
+				Name:                 "app",
+				RestartCount:         5,
+				State:                coreV1.ContainerState{Waiting: &coreV1.ContainerStateWaiting{Reason: reasonCrashLoopBackOff}},
+				LastTerminationState: coreV1.ContainerState{Terminated: &coreV1.ContainerStateTerminated{Reason: "Error", ExitCode: 1}},
+			},
+			expectedSources: [][]string{{"all", "strict"}},
+			expectedReason:  reasonCrashLoopBackOff,
+		},
+		{
+			name: "Crash loop below the minimum restarts of a strict source",
+			status: coreV1.ContainerStatus{
+				Name:         "app",
+				RestartCount: 3,
+				State:        coreV1.ContainerState{Waiting: &coreV1.ContainerStateWaiting{Reason: reasonCrashLoopBackOff}},
+			},
+			expectedSources: [][]string{{"all"}},
+			expectedReason:  reasonCrashLoopBackOff,
+		},
+		{
+			name: "Not enough restarts",
+			status: coreV1.ContainerStatus{
+				Name:         "app",
+				RestartCount: 1,
+				State:        coreV1.ContainerState{Waiting: &coreV1.ContainerStateWaiting{Reason: reasonCrashLoopBackOff}},
+			},
+		},
+		{
+			name: "Running container restarted after an error",
+			status: coreV1.ContainerStatus{
+				Name:                 "app",
+				RestartCount:         10,
+				State:                coreV1.ContainerState{Running: &coreV1.ContainerStateRunning{}},
+				LastTerminationState: coreV1.ContainerState{Terminated: &coreV1.ContainerStateTerminated{Reason: "Error", ExitCode: 1}},
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// given
+			handler := &fakeEventHandler{}
+			watcher := fixWatcher(handler)
+
+			// when
+			watcher.handlePod(context.Background(), fixPod(tc.status))
+
+			// then
+			assert.Equal(t, tc.expectedSources, handler.sources)
+			if len(tc.expectedSources) == 0 {
+				return
+			}
+			assert.Equal(t, tc.expectedReason, handler.events[0].Reason)
+		})
+	}
+}
+
+func TestWatcherForget(t *testing.T) {
+	// given
+	handler := &fakeEventHandler{}
+	watcher := fixWatcher(handler)
+	pod := fixPod(coreV1.ContainerStatus{
+		Name:         "app",
+		RestartCount: 3,
+		State:        coreV1.ContainerState{Waiting: &coreV1.ContainerStateWaiting{Reason: reasonCrashLoopBackOff}},
+	})
+	watcher.handlePod(context.Background(), pod)
+
+	// when
+	watcher.forget(pod)
+	watcher.handlePod(context.Background(), pod)
+
+	// then
+	assert.Len(t, handler.events, 2, "recreated Pod must be reported again")
+}
+
+type fakeEventHandler struct {
+	events  []events.Event
+	sources [][]string
+}
+
+func (f *fakeEventHandler) HandleExternalEvent(_ context.Context, event events.Event, sources []string) {
+	f.events = append(f.events, event)
+	f.sources = append(f.sources, sources)
+}
+
+func fixWatcher(handler EventHandler) *Watcher {
+	logger, _ := logtest.NewNullLogger()
+	return NewWatcher(logger, "dev", fake.NewSimpleClientset(), 0, map[string]config.Sources{
+		"all":      {PodCrashes: config.PodCrashesSource{Enabled: true}},
+		"strict":   {PodCrashes: config.PodCrashesSource{Enabled: true, MinRestarts: 5}},
+		"other-ns": {PodCrashes: config.PodCrashesSource{Enabled: true, Namespaces: config.Namespaces{Include: []string{"prod"}}}},
+		"disabled": {},
+	}, handler)
+}
+
+func fixPod(status coreV1.ContainerStatus) *coreV1.Pod {
+	return &coreV1.Pod{
+		ObjectMeta: metaV1.ObjectMeta{Name: "api-7d9f", Namespace: "default", UID: "123"},
+		Spec: coreV1.PodSpec{
+			Containers: []coreV1.Container{
+				{
+					Name: "app",
+					Resources: coreV1.ResourceRequirements{
+						Limits: coreV1.ResourceList{
+							coreV1.ResourceCPU:    resource.MustParse("500m"),
+							coreV1.ResourceMemory: resource.MustParse("128Mi"),
+						},
+						Requests: coreV1.ResourceList{
+							coreV1.ResourceMemory: resource.MustParse("64Mi"),
+						},
+					},
+				},
+			},
+		},
+		Status: coreV1.PodStatus{
+			ContainerStatuses: []coreV1.ContainerStatus{status},
+		},
+	}
+}
@@ -0,0 +1,330 @@
+package pvcusage
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/events"
+)
+
+const (
+	pvcResource = "v1/persistentvolumeclaims"
+
+	// DefaultInterval is used when the source doesn't specify how often the volume stats are polled.
+	DefaultInterval = 5 * time.Minute
+
+	thresholdExceededReason = "VolumeUsageThresholdExceeded"
+)
+
+// DefaultThresholds are the usage percentages reported when the source doesn't specify them.
+var DefaultThresholds = []int{80, 90}
+
+// EventHandler handles events received from external sources.
+type EventHandler interface {
+	HandleExternalEvent(ctx context.Context, event events.Event, sources []string)
+}
+
+// Monitor periodically polls the kubelet volume stats and sends warning events
+// when the PersistentVolumeClaim usage crosses the configured thresholds.
+type Monitor struct {
+	log         logrus.FieldLogger
+	clusterName string
+	k8sCli      kubernetes.Interface
+	sources     map[string]config.PVCUsageSource
+	handler     EventHandler
+	interval    time.Duration
+
+	// nodeStats returns the volume stats of a given node. It's a field, so it can be replaced in tests.
+	nodeStats func(ctx context.Context, node string) ([]volumeStats, error)
+
+	// reported holds the highest threshold already reported per source and PVC.
+	// A lower threshold is reported again only after the usage drops below it.
+	reported   map[string]int
+	reportedMu sync.Mutex
+}
+
+// volumeStats holds the usage of a volume backed by a PersistentVolumeClaim.
+type volumeStats struct {
+	Namespace      string
+	Name           string
+	Pod            string
+	CapacityBytes  uint64
+	UsedBytes      uint64
+	AvailableBytes uint64
+}
+
+// summary is the subset of the kubelet stats summary with the Pod volumes.
+// See https://github.com/kubernetes/kubelet/blob/master/pkg/apis/stats/v1alpha1/types.go.
+type summary struct {
+	Pods []struct {
+		PodRef struct {
+			Name string `json:"name"`
+		} `json:"podRef"`
+		Volumes []struct {
+			CapacityBytes  *uint64 `json:"capacityBytes"`
+			UsedBytes      *uint64 `json:"usedBytes"`
+			AvailableBytes *uint64 `json:"availableBytes"`
+			PVCRef         *struct {
+				Name      string `json:"name"`
+				Namespace string `json:"namespace"`
+			} `json:"pvcRef"`
+		} `json:"volume"`
+	} `json:"pods"`
+}
+
+// NewMonitor returns a new Monitor instance for the source bindings with enabled PVC usage source.
+func NewMonitor(log logrus.FieldLogger, clusterName string, k8sCli kubernetes.Interface, sources map[string]config.Sources, handler EventHandler) *Monitor {
+	enabled := map[string]config.PVCUsageSource{}
+	var interval time.Duration
+	for name, src := range sources {
+		cfg := src.PVCUsage
+		if !cfg.Enabled {
+			continue
+		}
+		if cfg.Interval <= 0 {
+			cfg.Interval = DefaultInterval
+		}
+		if len(cfg.Thresholds) == 0 {
+			cfg.Thresholds = DefaultThresholds
+		}
+		if interval == 0 || cfg.Interval < interval {
+			interval = cfg.Interval
+		}
+		enabled[name] = cfg
+	}
+
+	m := &Monitor{
+		log:         log,
+		clusterName: clusterName,
+		k8sCli:      k8sCli,
+		sources:     enabled,
+		handler:     handler,
+		interval:    interval,
+		reported:    map[string]int{},
+	}
+	m.nodeStats = m.kubeletStats
+	return m
+}
+
+// Enabled returns true if any source binding has PVC usage source enabled.
+func (m *Monitor) Enabled() bool {
+	return len(m.sources) > 0
+}
+
+// Start starts polling the volume stats with the shortest interval configured for the source bindings. It blocks until the context is cancelled.
+func (m *Monitor) Start(ctx context.Context) error {
+	m.log.Infof("Starting PVC usage monitor with %s interval...", m.interval)
+
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+	for {
+		if err := m.check(ctx); err != nil {
+			m.log.Errorf("while checking PVC usage: %s", err.Error())
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func (m *Monitor) check(ctx context.Context) error {
+	nodes, err := m.k8sCli.CoreV1().Nodes().List(ctx, metaV1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("while listing nodes: %w", err)
+	}
+
+	// the same PVC can be mounted by multiple Pods, so the stats are deduplicated
+	volumes := map[string]volumeStats{}
+	complete := true
+	for _, node := range nodes.Items {
+		stats, err := m.nodeStats(ctx, node.Name)
+		if err != nil {
+			m.log.Errorf("while getting volume stats from node %q: %s", node.Name, err.Error())
+			complete = false
+			continue
+		}
+		for _, vol := range stats {
+			volumes[fmt.Sprintf("%s/%s", vol.Namespace, vol.Name)] = vol
+		}
+	}
+
+	keys := make([]string, 0, len(volumes))
+	for key := range volumes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		m.checkVolume(ctx, volumes[key])
+	}
+	if complete {
+		// stats of a node which failed to respond are missing, so the PVCs can't be treated as removed
+		m.forgetRemovedVolumes(volumes)
+	}
+	return nil
+}
+
+func (m *Monitor) forgetRemovedVolumes(existing map[string]volumeStats) {
+	m.reportedMu.Lock()
+	defer m.reportedMu.Unlock()
+	for key := range m.reported {
+		// namespaces and PVC names can't contain slashes, so the last two parts of the key are always the namespace and PVC name
+		parts := strings.Split(key, "/")
+		if len(parts) < 3 {
+			continue
+		}
+		if _, found := existing[strings.Join(parts[len(parts)-2:], "/")]; !found {
+			delete(m.reported, key)
+		}
+	}
+}
+
+// checkVolume sends one event per crossed threshold, which is new for the given source bindings.
+func (m *Monitor) checkVolume(ctx context.Context, vol volumeStats) {
+	if vol.CapacityBytes == 0 {
+		return
+	}
+	percent := float64(vol.UsedBytes) / float64(vol.CapacityBytes) * 100
+
+	sourcesPerThreshold := map[int][]string{}
+	m.reportedMu.Lock()
+	for name, src := range m.sources {
+		if src.Namespaces.IsConfigured() && !src.Namespaces.IsAllowed(vol.Namespace) {
+			continue
+		}
+
+		key := fmt.Sprintf("%s/%s/%s", name, vol.Namespace, vol.Name)
+		crossed := crossedThreshold(src.Thresholds, percent)
+		if crossed > m.reported[key] {
+			sourcesPerThreshold[crossed] = append(sourcesPerThreshold[crossed], name)
+		}
+		if crossed == 0 {
+			delete(m.reported, key)
+			continue
+		}
+		m.reported[key] = crossed
+	}
+	m.reportedMu.Unlock()
+
+	thresholds := make([]int, 0, len(sourcesPerThreshold))
+	for threshold := range sourcesPerThreshold {
+		thresholds = append(thresholds, threshold)
+	}
+	sort.Ints(thresholds)
+
+	for _, threshold := range thresholds {
+		sources := sourcesPerThreshold[threshold]
+		sort.Strings(sources)
+		m.handler.HandleExternalEvent(ctx, m.eventFor(vol, percent, threshold), sources)
+	}
+}
+
+func (m *Monitor) eventFor(vol volumeStats, percent float64, threshold int) events.Event {
+	messages := []string{
+		fmt.Sprintf("Used %s of %s (%.0f%%) crossed the %d%% threshold", formatBytes(vol.UsedBytes), formatBytes(vol.CapacityBytes), percent, threshold),
+		fmt.Sprintf("Available: %s", formatBytes(vol.AvailableBytes)),
+	}
+	if vol.Pod != "" {
+		messages = append(messages, fmt.Sprintf("Mounted by Pod: %s", vol.Pod))
+	}
+
+	return events.Event{
+		TypeMeta:  metaV1.TypeMeta{Kind: "PersistentVolumeClaim", APIVersion: "v1"},
+		Title:     fmt.Sprintf("PersistentVolumeClaim %s is %.0f%% full", vol.Name, percent),
+		Name:      vol.Name,
+		Namespace: vol.Namespace,
+		Messages:  messages,
+		Type:      config.WarningEvent,
+		Reason:    thresholdExceededReason,
+		Level:     config.Warn,
+		Cluster:   m.clusterName,
+		TimeStamp: time.Now(),
+		Resource:  pvcResource,
+		SuggestedCommands: []string{
+			fmt.Sprintf("kubectl describe pvc %s --namespace %s", vol.Name, vol.Namespace),
+		},
+	}
+}
+
+// kubeletStats returns the stats of the PVC-backed volumes, reported by the kubelet on a given node.
+func (m *Monitor) kubeletStats(ctx context.Context, node string) ([]volumeStats, error) {
+	raw, err := m.k8sCli.CoreV1().RESTClient().Get().
+		Resource("nodes").
+		Name(node).
+		SubResource("proxy", "stats", "summary").
+		DoRaw(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("while getting stats summary: %w", err)
+	}
+
+	return volumesFromSummary(raw)
+}
+
+func volumesFromSummary(raw []byte) ([]volumeStats, error) {
+	var stats summary
+	if err := json.Unmarshal(raw, &stats); err != nil {
+		return nil, fmt.Errorf("while decoding stats summary: %w", err)
+	}
+
+	var out []volumeStats
+	for _, pod := range stats.Pods {
+		for _, vol := range pod.Volumes {
+			if vol.PVCRef == nil || vol.CapacityBytes == nil || vol.UsedBytes == nil {
+				continue
+			}
+
+			item := volumeStats{
+				Namespace:     vol.PVCRef.Namespace,
+				Name:          vol.PVCRef.Name,
+				Pod:           pod.PodRef.Name,
+				CapacityBytes: *vol.CapacityBytes,
+				UsedBytes:     *vol.UsedBytes,
+			}
+			if vol.AvailableBytes != nil {
+				item.AvailableBytes = *vol.AvailableBytes
+			}
+			out = append(out, item)
+		}
+	}
+	return out, nil
+}
+
+// crossedThreshold returns the highest threshold crossed by a given usage, or zero if none is crossed.
+func crossedThreshold(thresholds []int, percent float64) int {
+	out := 0
+	for _, threshold := range thresholds {
+		if percent >= float64(threshold) && threshold > out {
+			out = threshold
+		}
+	}
+	return out
+}
+
+func formatBytes(bytes uint64) string {
+	const unit = 1024
+	if bytes < unit {
+		return fmt.Sprintf("%dB", bytes)
+	}
+
+	value := float64(bytes)
+	suffixes := []string{"Ki", "Mi", "Gi", "Ti", "Pi"}
+	idx := -1
+	for value >= unit && idx < len(suffixes)-1 {
+		value /= unit
+		idx++
+	}
+	return strings.TrimSuffix(fmt.Sprintf("%.1f", value), ".0") + suffixes[idx]
+}
@@ -0,0 +1,129 @@
+package pvcusage
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	logtest "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	coreV1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/events"
+)
+
+const gi = 1 << 30
+
+func TestMonitorCheck(t *testing.T) {
+	// given
+	handler := &fakeEventHandler{}
+	logger, _ := logtest.NewNullLogger()
+	k8sCli := fake.NewSimpleClientset(
+		&coreV1.Node{ObjectMeta: metaV1.ObjectMeta{Name: "node-1"}},
+		&coreV1.Node{ObjectMeta: metaV1.ObjectMeta{Name: "node-2"}},
+	)
+	monitor := NewMonitor(logger, "dev", k8sCli, map[string]config.Sources{
+		"defaults": {PVCUsage: config.PVCUsageSource{Enabled: true}},
+		"critical": {PVCUsage: config.PVCUsageSource{Enabled: true, Thresholds: []int{95}}},
+		"other-ns": {PVCUsage: config.PVCUsageSource{Enabled: true, Namespaces: config.Namespaces{Include: []string{"prod"}}}},
+		"disabled": {},
+	}, handler)
+
+	stats := map[string][]volumeStats{
+		"node-1": {
+			{Namespace: "default", Name: "data-db-0", Pod: "db-0", CapacityBytes: 10 * gi, UsedBytes: 8.5 * gi, AvailableBytes: 1.5 * gi},
+			{Namespace: "default", Name: "cache", Pod: "cache-0", CapacityBytes: 10 * gi, UsedBytes: gi, AvailableBytes: 9 * gi},
+		},
+	}
+	monitor.nodeStats = func(_ context.Context, node string) ([]volumeStats, error) {
+		if node == "node-2" {
+			return nil, errors.New("connection refused")
+		}
+		return stats[node], nil
+	}
+
+	// when
+	require.NoError(t, monitor.check(context.Background()))
+
+	// then
+	require.Len(t, handler.events, 1)
+	assert.Equal(t, [][]string{{"defaults"}}, handler.sources)
+	event := handler.events[0]
+	assert.Equal(t, "PersistentVolumeClaim data-db-0 is 85% full", event.Title)
+	assert.Equal(t, "PersistentVolumeClaim", event.Kind)
+	assert.Equal(t, "default", event.Namespace)
+	assert.Equal(t, config.WarningEvent, event.Type)
+	assert.Equal(t, config.Warn, event.Level)
+	assert.Equal(t, []string{
+		"Used 8.5Gi of 10Gi (85%) crossed the 80% threshold",
+		"Available: 1.5Gi",
+		"Mounted by Pod: db-0",
+	}, event.Messages)
+
+	// when
+	handler.events, handler.sources = nil, nil
+	require.NoError(t, monitor.check(context.Background()))
+
+	// then
+	assert.Empty(t, handler.events, "thresholds already reported must not be sent again")
+
+	// when
+	stats["node-1"][0].UsedBytes = 96 * gi / 10
+	require.NoError(t, monitor.check(context.Background()))
+
+	// then
+	require.Len(t, handler.events, 2)
+	assert.Equal(t, [][]string{{"defaults"}, {"critical"}}, handler.sources)
+	assert.Equal(t, "Used 9.6Gi of 10Gi (96%) crossed the 90% threshold", handler.events[0].Messages[0])
+	assert.Equal(t, "Used 9.6Gi of 10Gi (96%) crossed the 95% threshold", handler.events[1].Messages[0])
+
+	// when
+	handler.events, handler.sources = nil, nil
+	stats["node-1"][0].UsedBytes = 5 * gi
+	require.NoError(t, monitor.check(context.Background()))
+	stats["node-1"][0].UsedBytes = 8 * gi
+	require.NoError(t, monitor.check(context.Background()))
+
+	// then
+	require.Len(t, handler.events, 1, "threshold must be reported again after the usage drops below it")
+	assert.Equal(t, [][]string{{"defaults"}}, handler.sources)
+}
+
+func TestVolumesFromSummary(t *testing.T) {
+	// given
+	raw := []byte(`{
+	  "node": {"nodeName": "node-1"},
+	  "pods": [
+	    {
+	      "podRef": {"name": "db-0", "namespace": "default"},
+	      "volume": [
+	        {"name": "data", "capacityBytes": 1000, "usedBytes": 800, "availableBytes": 200, "pvcRef": {"name": "data-db-0", "namespace": "default"}},
+	        {"name": "tmp", "capacityBytes": 1000, "usedBytes": 10}
+	      ]
+	    }
+	  ]
+	}`)
+
+	// when
+	volumes, err := volumesFromSummary(raw)
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, []volumeStats{
+		{Namespace: "default", Name: "data-db-0", Pod: "db-0", CapacityBytes: 1000, UsedBytes: 800, AvailableBytes: 200},
+	}, volumes)
+}
+
+type fakeEventHandler struct {
+	events  []events.Event
+	sources [][]string
+}
+
+func (f *fakeEventHandler) HandleExternalEvent(_ context.Context, event events.Event, sources []string) {
+	f.events = append(f.events, event)
+	f.sources = append(f.sources, sources)
+}
@@ -11,6 +11,7 @@ import (
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/tools/cache"
+	"k8s.io/utils/strings/slices"
 
 	"github.com/kubeshop/botkube/pkg/config"
 	"github.com/kubeshop/botkube/pkg/utils"
@@ -24,6 +25,29 @@ type registration struct {
 	events          []config.EventType
 	mappedResources []string
 	mappedEvent     config.EventType
+	nsLabels        *namespaceLabels
+}
+
+// namespaceLabels resolves Namespace labels from the Namespace informer cache.
+type namespaceLabels struct {
+	store cache.Store
+}
+
+// get returns labels of a given Namespace. It returns nil if the Namespace informer is not registered or the Namespace is not found.
+func (n *namespaceLabels) get(name string) map[string]string {
+	if n == nil || n.store == nil {
+		return nil
+	}
+
+	obj, exists, err := n.store.GetByKey(name)
+	if err != nil || !exists {
+		return nil
+	}
+	ns, ok := obj.(*unstructured.Unstructured)
+	if !ok {
+		return nil
+	}
+	return ns.GetLabels()
 }
 
 func (r registration) handleEvent(ctx context.Context, resource string, target config.EventType, sourceRoutes []route, fn eventHandler) {
@@ -31,7 +55,8 @@ func (r registration) handleEvent(ctx context.Context, resource string, target c
 	case config.CreateEvent:
 		r.informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
 			AddFunc: func(obj interface{}) {
-				sources, err := sourcesForObjNamespace(ctx, sourceRoutes, obj, r.log, r.mapper, r.dynamicCli)
+				routes := routesMatchingSelectors(sourceRoutes, obj, r.log)
+				sources, err := sourcesForObjNamespace(ctx, routes, obj, r.log, r.mapper, r.dynamicCli, r.nsLabels)
 				if err != nil {
 					r.log.WithFields(logrus.Fields{
 						"eventHandler": config.CreateEvent,
@@ -49,7 +74,8 @@ func (r registration) handleEvent(ctx context.Context, resource string, target c
 	case config.DeleteEvent:
 		r.informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
 			DeleteFunc: func(obj interface{}) {
-				sources, err := sourcesForObjNamespace(ctx, sourceRoutes, obj, r.log, r.mapper, r.dynamicCli)
+				routes := routesMatchingSelectors(sourceRoutes, obj, r.log)
+				sources, err := sourcesForObjNamespace(ctx, routes, obj, r.log, r.mapper, r.dynamicCli, r.nsLabels)
 				if err != nil {
 					r.log.WithFields(logrus.Fields{
 						"eventHandler": config.DeleteEvent,
@@ -67,7 +93,8 @@ func (r registration) handleEvent(ctx context.Context, resource string, target c
 	case config.UpdateEvent:
 		r.informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
 			UpdateFunc: func(oldObj, newObj interface{}) {
-				sources, diffs, err := qualifySourcesForUpdate(ctx, newObj, oldObj, sourceRoutes, r.log, r.mapper, r.dynamicCli)
+				routes := routesMatchingSelectors(sourceRoutes, newObj, r.log)
+				sources, diffs, err := qualifySourcesForUpdate(ctx, newObj, oldObj, routes, r.log, r.mapper, r.dynamicCli, r.nsLabels)
 				if err != nil {
 					r.log.WithFields(logrus.Fields{
 						"eventHandler": config.UpdateEvent,
@@ -117,7 +144,7 @@ func (r registration) handleMapped(ctx context.Context, targetEvent config.Event
 			}
 
 			sourceRoutes := sourceRoutes(routeTable, gvrToString, targetEvent)
-			sources, err := sourcesForObjNamespace(ctx, sourceRoutes, obj, r.log, r.mapper, r.dynamicCli)
+			sources, err := sourcesForObjNamespace(ctx, sourceRoutes, obj, r.log, r.mapper, r.dynamicCli, r.nsLabels)
 			if err != nil {
 				r.log.Errorf("cannot calculate sources for observed mapped resource event: %q in Add event handler: %s", targetEvent, err.Error())
 				return
@@ -148,7 +175,7 @@ func (r registration) includesSrcResource(resource string) bool {
 	return false
 }
 
-func sourcesForObjNamespace(ctx context.Context, routes []route, obj interface{}, log logrus.FieldLogger, mapper meta.RESTMapper, cli dynamic.Interface) ([]string, error) {
+func sourcesForObjNamespace(ctx context.Context, routes []route, obj interface{}, log logrus.FieldLogger, mapper meta.RESTMapper, cli dynamic.Interface, nsLabels *namespaceLabels) ([]string, error) {
 	var out []string
 
 	objectMeta, err := utils.GetObjectMetaData(ctx, cli, mapper, obj)
@@ -168,8 +195,9 @@ func sourcesForObjNamespace(ctx context.Context, routes []route, obj interface{}
 	}
 
 	log.Debugf("handling events for target Namespace: %s in routes: %+v", targetNs, routes)
+	targetNsLabels := nsLabels.get(targetNs)
 	for _, route := range routes {
-		if route.namespaces.IsAllowed(targetNs) {
+		if route.namespaces.IsAllowedWithLabels(targetNs, targetNsLabels) {
 			out = append(out, route.source)
 		}
 	}
@@ -184,10 +212,11 @@ func qualifySourcesForUpdate(
 	log logrus.FieldLogger,
 	mapper meta.RESTMapper,
 	cli dynamic.Interface,
+	nsLabels *namespaceLabels,
 ) ([]string, []string, error) {
 	var sources, diffs []string
 
-	candidates, err := sourcesForObjNamespace(ctx, routes, newObj, log, mapper, cli)
+	candidates, err := sourcesForObjNamespace(ctx, routes, newObj, log, mapper, cli, nsLabels)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -217,19 +246,41 @@ func qualifySourcesForUpdate(
 				continue
 			}
 
-			diff, err := utils.Diff(oldUnstruct.Object, newUnstruct.Object, r.updateSetting)
-			if err != nil {
-				log.Errorf("while getting diff: %w", err)
-			}
+			diff := updateDiff(oldUnstruct, newUnstruct, r.updateSetting, log)
 			log.Debugf("About to qualify source: %s for update, diff: %s, updateSetting: %+v", source, diff, r.updateSetting)
 
-			if len(diff) > 0 && r.updateSetting.IncludeDiff {
-				sources = append(sources, source)
+			if len(diff) == 0 {
+				// none of the watched fields changed
+				continue
+			}
+
+			sources = append(sources, source)
+			// sources watching the same fields get the same diff, so it's included only once
+			if r.updateSetting.IncludeDiff && !slices.Contains(diffs, diff) {
 				diffs = append(diffs, diff)
-				log.Debugf("Qualified for update: source: %s for update, diff: %s, updateSetting: %+v", source, diff, r.updateSetting)
 			}
+			log.Debugf("Qualified for update: source: %s for update, diff: %s, updateSetting: %+v", source, diff, r.updateSetting)
 		}
 	}
 
 	return sources, diffs, nil
 }
+
+// updateDiff returns the differences in the fields watched by a given update setting.
+// A field which is missing in any of the objects is treated as not changed, and the other fields are still checked.
+func updateDiff(oldObj, newObj *unstructured.Unstructured, updateSetting config.UpdateSetting, log logrus.FieldLogger) string {
+	if oldObj == nil || newObj == nil {
+		return ""
+	}
+
+	var out strings.Builder
+	for _, field := range updateSetting.Fields {
+		diff, err := utils.Diff(oldObj.Object, newObj.Object, config.UpdateSetting{Fields: []string{field}})
+		if err != nil {
+			log.Debugf("Skipping field %q while getting diff: %s", field, err.Error())
+			continue
+		}
+		out.WriteString(diff)
+	}
+	return out.String()
+}
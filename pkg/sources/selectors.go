@@ -0,0 +1,77 @@
+package sources
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+)
+
+// routesMatchingSelectors returns routes with label and field selectors matching a given object.
+// It is needed when the resource informer is shared by routes with different selectors.
+func routesMatchingSelectors(routes []route, obj interface{}, log logrus.FieldLogger) []route {
+	unstrObj, ok := obj.(*unstructured.Unstructured)
+	if !ok {
+		return routes
+	}
+
+	var out []route
+	for _, r := range routes {
+		matches, err := r.matchesSelectors(unstrObj)
+		if err != nil {
+			log.Errorf("while matching selectors of source %q: %s", r.source, err.Error())
+			continue
+		}
+		if matches {
+			out = append(out, r)
+		}
+	}
+	return out
+}
+
+func (r route) matchesSelectors(obj *unstructured.Unstructured) (bool, error) {
+	if r.labelSelector != "" {
+		selector, err := labels.Parse(r.labelSelector)
+		if err != nil {
+			return false, fmt.Errorf("while parsing label selector %q: %w", r.labelSelector, err)
+		}
+		if !selector.Matches(labels.Set(obj.GetLabels())) {
+			return false, nil
+		}
+	}
+
+	if r.fieldSelector != "" {
+		selector, err := fields.ParseSelector(r.fieldSelector)
+		if err != nil {
+			return false, fmt.Errorf("while parsing field selector %q: %w", r.fieldSelector, err)
+		}
+		for _, req := range selector.Requirements() {
+			if !fieldRequirementMatches(obj, req) {
+				return false, nil
+			}
+		}
+	}
+
+	return true, nil
+}
+
+// fieldRequirementMatches evaluates a field selector requirement against the object fields.
+// Missing fields are treated as empty strings, which is consistent with the API server behavior for the supported fields.
+func fieldRequirementMatches(obj *unstructured.Unstructured, req fields.Requirement) bool {
+	value := ""
+	field, found, err := unstructured.NestedFieldNoCopy(obj.Object, strings.Split(req.Field, ".")...)
+	if err == nil && found && field != nil {
+		value = fmt.Sprintf("%v", field)
+	}
+
+	switch req.Operator {
+	case selection.NotEquals:
+		return value != req.Value
+	default:
+		return value == req.Value
+	}
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/sirupsen/logrus"
 	"k8s.io/apimachinery/pkg/api/meta"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/tools/cache"
 
@@ -12,16 +13,21 @@ import (
 	"github.com/kubeshop/botkube/pkg/recommendation"
 )
 
-const eventsResource = "v1/events"
+const (
+	eventsResource     = "v1/events"
+	namespacesResource = "v1/namespaces"
+)
 
 type mergedEvents map[string]map[config.EventType]struct{}
-type registrationHandler func(resource string) (cache.SharedIndexInformer, error)
+type registrationHandler func(resource string, opts metaV1.ListOptions) (cache.SharedIndexInformer, error)
 type eventHandler func(ctx context.Context, resource string, sources []string, updateDiffs []string) func(obj interface{})
 
 type route struct {
 	source        string
 	namespaces    config.Namespaces
 	updateSetting config.UpdateSetting
+	labelSelector string
+	fieldSelector string
 }
 
 func (r route) hasActionableUpdateSetting() bool {
@@ -42,6 +48,7 @@ type Router struct {
 	table         map[string][]entry
 	bindings      map[string]struct{}
 	registrations map[string]registration
+	nsLabels      *namespaceLabels
 }
 
 // NewRouter creates a new router to use for routing event types to registered informers.
@@ -53,19 +60,37 @@ func NewRouter(mapper meta.RESTMapper, dynamicCli dynamic.Interface, log logrus.
 		table:         make(map[string][]entry),
 		bindings:      make(map[string]struct{}),
 		registrations: make(map[string]registration),
+		nsLabels:      &namespaceLabels{},
 	}
 }
 
 // AddCommunicationsBindings adds source binding from a given communications
 func (r *Router) AddCommunicationsBindings(c config.Communications) {
 	r.AddBindingsByNameIfConditionTrue(c.Slack.Enabled, c.Slack.Channels)
-	r.AddBindingsByNameIfConditionTrue(c.SocketSlack.Enabled, c.SocketSlack.Channels)
+	r.AddBindingsByNameIfConditionTrue(c.SocketSlack.Enabled, c.SocketSlack.AllChannels())
+	for _, profile := range c.SocketSlack.ChannelDiscovery.Profiles {
+		r.AddBindingsIfConditionTrue(c.SocketSlack.Enabled && c.SocketSlack.ChannelDiscovery.Enabled, profile.Bindings)
+	}
 	r.AddBindingsByNameIfConditionTrue(c.Mattermost.Enabled, c.Mattermost.Channels)
 	r.AddBindingsIfConditionTrue(c.Teams.Enabled, c.Teams.Bindings)
 	r.AddBindingsByIDIfConditionTrue(c.Discord.Enabled, c.Discord.Channels)
+	r.AddBindingsByNameIfConditionTrue(c.RocketChat.Enabled, c.RocketChat.Channels)
+	r.AddBindingsByNameIfConditionTrue(c.GoogleChat.Enabled, c.GoogleChat.Channels)
+	r.AddBindingsByIDIfConditionTrue(c.Webex.Enabled, c.Webex.Channels)
+	r.AddBindingsByNameIfConditionTrue(c.Matrix.Enabled, c.Matrix.Channels)
+	r.AddBindingsByNameIfConditionTrue(c.Loopback.Enabled, c.Loopback.Channels)
 	r.AddElsIndexSinkBindingsIfConditionTrue(c.Elasticsearch.Enabled, c.Elasticsearch.Indices)
 
 	r.AddSinkBindingsIfConditionTrue(c.Webhook.Enabled, c.Webhook.Bindings)
+	r.AddSinkBindingsIfConditionTrue(c.PagerDuty.Enabled, c.PagerDuty.Bindings())
+	r.AddSinkBindingsIfConditionTrue(c.Opsgenie.Enabled, c.Opsgenie.Bindings)
+	r.AddSinkBindingsIfConditionTrue(c.Kafka.Enabled, c.Kafka.Bindings)
+	r.AddSinkBindingsIfConditionTrue(c.AWS.Enabled, c.AWS.Bindings)
+	r.AddSinkBindingsIfConditionTrue(c.AzureEventHub.Enabled, c.AzureEventHub.Bindings)
+	r.AddSinkBindingsIfConditionTrue(c.Loki.Enabled, c.Loki.Bindings)
+	r.AddSinkBindingsIfConditionTrue(c.Datadog.Enabled, c.Datadog.Bindings)
+	r.AddSinkBindingsIfConditionTrue(c.Jira.Enabled, c.Jira.Bindings)
+	r.AddSinkBindingsIfConditionTrue(c.ServiceNow.Enabled, c.ServiceNow.Bindings)
 }
 
 // AddEnabledActionBindings adds source bindings for enabled Actions.
@@ -186,7 +211,7 @@ func (r *Router) BuildTable(cfg *config.Config) *Router {
 func (r *Router) RegisterInformers(targetEvents []config.EventType, handler registrationHandler) error {
 	resources := r.resourcesForEvents(targetEvents)
 	for _, resource := range resources {
-		informer, err := handler(resource)
+		informer, err := handler(resource, r.informerListOptions(resource))
 		if err != nil {
 			return err
 		}
@@ -196,6 +221,7 @@ func (r *Router) RegisterInformers(targetEvents []config.EventType, handler regi
 			log:        r.log,
 			mapper:     r.mapper,
 			dynamicCli: r.dynamicCli,
+			nsLabels:   r.nsLabels,
 		}
 	}
 	return nil
@@ -214,7 +240,7 @@ func (r *Router) MapWithEventsInformer(srcEvent config.EventType, dstEvent confi
 		return nil
 	}
 
-	informer, err := handler(eventsResource)
+	informer, err := handler(eventsResource, metaV1.ListOptions{})
 	if err != nil {
 		return err
 	}
@@ -226,10 +252,27 @@ func (r *Router) MapWithEventsInformer(srcEvent config.EventType, dstEvent confi
 		log:             r.log,
 		mapper:          r.mapper,
 		dynamicCli:      r.dynamicCli,
+		nsLabels:        r.nsLabels,
 	}
 	return nil
 }
 
+// RegisterNamespaceInformer registers the Namespace informer used to resolve Namespace labels,
+// if any route allows Namespaces by a label selector. The labels are read from the informer cache,
+// so Namespaces added or relabeled later are matched as well.
+func (r *Router) RegisterNamespaceInformer(handler registrationHandler) error {
+	if !r.usesNamespaceLabelSelector() {
+		return nil
+	}
+
+	informer, err := handler(namespacesResource, metaV1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	r.nsLabels.store = informer.GetStore()
+	return nil
+}
+
 // HandleEvent allows router clients to create handlers that are
 // triggered for a target event.
 func (r *Router) HandleEvent(ctx context.Context, target config.EventType, handlerFn eventHandler) {
@@ -250,6 +293,17 @@ func (r *Router) HandleMappedEvent(ctx context.Context, targetEvent config.Event
 	}
 }
 
+// SourcesForObject returns the sources which are routed for a given resource event and match the object namespace.
+// It's used for the objects which are not received by the registered informers. The error and warning events are
+// Kubernetes Event objects, so the resource selectors are not applied to them, in the same way as for the mapped events.
+func (r *Router) SourcesForObject(ctx context.Context, resource string, target config.EventType, obj interface{}) ([]string, error) {
+	routes := r.getSourceRoutes(resource, target)
+	if target != config.ErrorEvent && target != config.WarningEvent {
+		routes = routesMatchingSelectors(routes, obj, r.log)
+	}
+	return sourcesForObjNamespace(ctx, routes, obj, r.log, r.mapper, r.dynamicCli, r.nsLabels)
+}
+
 // GetSourceRoutes returns all routes for a resource and target event
 func (r *Router) getSourceRoutes(resource string, targetEvent config.EventType) []route {
 	return sourceRoutes(r.table, resource, targetEvent)
@@ -288,7 +342,12 @@ func (r *Router) mergeEventRoutes(resource string, sources map[string]config.Sou
 				}
 
 				namespaces := sourceOrResourceNamespaces(srcGroupCfg.Kubernetes.Namespaces, r.Namespaces)
-				route := route{source: srcGroupName, namespaces: namespaces}
+				route := route{
+					source:        srcGroupName,
+					namespaces:    namespaces,
+					labelSelector: r.LabelSelector,
+					fieldSelector: r.FieldSelector,
+				}
 				if e == config.UpdateEvent {
 					route.updateSetting = config.UpdateSetting{
 						Fields:      r.UpdateSetting.Fields,
@@ -374,6 +433,42 @@ func (r *Router) resourcesForEvents(targets []config.EventType) []string {
 	return out
 }
 
+// informerListOptions returns the selectors passed to the informer of a given resource.
+// The informer is shared by all routes, so the selectors are used only if all routes of the resource have the same ones.
+// Otherwise, the informer watches all objects and the routes are filtered by their selectors when handling events.
+func (r *Router) informerListOptions(resource string) metaV1.ListOptions {
+	var opts *metaV1.ListOptions
+	for _, routedEvent := range r.table[resource] {
+		for _, route := range routedEvent.routes {
+			if opts == nil {
+				opts = &metaV1.ListOptions{LabelSelector: route.labelSelector, FieldSelector: route.fieldSelector}
+				continue
+			}
+			if opts.LabelSelector != route.labelSelector || opts.FieldSelector != route.fieldSelector {
+				return metaV1.ListOptions{}
+			}
+		}
+	}
+
+	if opts == nil {
+		return metaV1.ListOptions{}
+	}
+	return *opts
+}
+
+func (r *Router) usesNamespaceLabelSelector() bool {
+	for _, routedEvents := range r.table {
+		for _, routedEvent := range routedEvents {
+			for _, route := range routedEvent.routes {
+				if route.namespaces.LabelSelector != "" {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
 func (r *Router) mappedInformer(event config.EventType) (registration, bool) {
 	for _, informer := range r.registrations {
 		if informer.mappedEvent == event {
@@ -1,11 +1,15 @@
 package sources
 
 import (
+	"context"
 	"testing"
 
 	logtest "github.com/sirupsen/logrus/hooks/test"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/tools/cache"
 
 	"github.com/kubeshop/botkube/pkg/config"
 )
@@ -423,3 +427,317 @@ func TestSetEventRouteForRecommendationsIfShould(t *testing.T) {
 		})
 	}
 }
+
+func TestSourcesForObjNamespace_UsesNamespaceLabels(t *testing.T) {
+	// given
+	logger, _ := logtest.NewNullLogger()
+	cfg := &config.Config{
+		Sources: map[string]config.Sources{
+			"payments": {
+				Kubernetes: config.KubernetesSource{
+					Namespaces: config.Namespaces{LabelSelector: "team=payments"},
+					Resources: []config.Resource{
+						{Type: "v1/pods", Event: config.KubernetesEvent{Types: []config.EventType{config.CreateEvent}}},
+					},
+				},
+			},
+			"botkube": {
+				Kubernetes: config.KubernetesSource{
+					Namespaces: config.Namespaces{Include: []string{"botkube"}},
+					Resources: []config.Resource{
+						{Type: "v1/pods", Event: config.KubernetesEvent{Types: []config.EventType{config.CreateEvent}}},
+					},
+				},
+			},
+		},
+	}
+	router := NewRouter(nil, nil, logger).
+		AddBindings(config.BotBindings{Sources: []string{"payments", "botkube"}}).
+		BuildTable(cfg)
+
+	store := cache.NewStore(cache.MetaNamespaceKeyFunc)
+	require.NoError(t, store.Add(fixNamespace("checkout", map[string]string{"team": "payments"})))
+	require.NoError(t, store.Add(fixNamespace("catalog", map[string]string{"team": "shop"})))
+
+	var registered string
+	err := router.RegisterNamespaceInformer(func(resource string, _ metaV1.ListOptions) (cache.SharedIndexInformer, error) {
+		registered = resource
+		return &fakeInformer{store: store}, nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "v1/namespaces", registered)
+
+	routes := router.getSourceRoutes("v1/pods", config.CreateEvent)
+	tests := map[string][]string{
+		"checkout": {"payments"},
+		"catalog":  nil,
+		"botkube":  {"botkube"},
+		"unknown":  nil,
+	}
+	for ns, expected := range tests {
+		// when
+		pod := &unstructured.Unstructured{}
+		pod.SetKind("Pod")
+		pod.SetName("app")
+		pod.SetNamespace(ns)
+		sources, err := sourcesForObjNamespace(context.Background(), routes, pod, logger, nil, nil, router.nsLabels)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, expected, sources, ns)
+	}
+}
+
+func TestRouter_SourcesForObject(t *testing.T) {
+	// given
+	logger, _ := logtest.NewNullLogger()
+	cfg := &config.Config{
+		Sources: map[string]config.Sources{
+			"prod-pods": {
+				Kubernetes: config.KubernetesSource{
+					Namespaces: config.Namespaces{Include: []string{"prod"}},
+					Resources: []config.Resource{
+						{Type: "v1/pods", Event: config.KubernetesEvent{Types: []config.EventType{config.CreateEvent}}},
+					},
+				},
+			},
+			"labeled-pods": {
+				Kubernetes: config.KubernetesSource{
+					Namespaces: config.Namespaces{Include: []string{".*"}},
+					Resources: []config.Resource{
+						{
+							Type:          "v1/pods",
+							LabelSelector: "app=payments",
+							Event:         config.KubernetesEvent{Types: []config.EventType{config.CreateEvent, config.DeleteEvent}},
+						},
+					},
+				},
+			},
+		},
+	}
+	router := NewRouter(nil, nil, logger).
+		AddBindings(config.BotBindings{Sources: []string{"prod-pods", "labeled-pods"}}).
+		BuildTable(cfg)
+
+	tests := []struct {
+		name      string
+		namespace string
+		labels    map[string]string
+		eventType config.EventType
+		expected  []string
+	}{
+		{
+			name:      "Namespace and labels match",
+			namespace: "prod",
+			labels:    map[string]string{"app": "payments"},
+			eventType: config.CreateEvent,
+			expected:  []string{"labeled-pods", "prod-pods"},
+		},
+		{
+			name:      "Only namespace matches",
+			namespace: "prod",
+			eventType: config.CreateEvent,
+			expected:  []string{"prod-pods"},
+		},
+		{
+			name:      "Event type not routed",
+			namespace: "prod",
+			eventType: config.UpdateEvent,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			pod := &unstructured.Unstructured{}
+			pod.SetKind("Pod")
+			pod.SetName("app")
+			pod.SetNamespace(tc.namespace)
+			pod.SetLabels(tc.labels)
+
+			// when
+			sources, err := router.SourcesForObject(context.Background(), "v1/pods", tc.eventType, pod)
+
+			// then
+			require.NoError(t, err)
+			assert.ElementsMatch(t, tc.expected, sources)
+		})
+	}
+}
+
+func TestRouter_RegisterNamespaceInformer_SkipsWithoutLabelSelectors(t *testing.T) {
+	// given
+	logger, _ := logtest.NewNullLogger()
+	cfg := &config.Config{
+		Sources: map[string]config.Sources{
+			"k8s-events": {
+				Kubernetes: config.KubernetesSource{
+					Namespaces: config.Namespaces{Include: []string{".*"}},
+					Resources: []config.Resource{
+						{Type: "v1/pods", Event: config.KubernetesEvent{Types: []config.EventType{config.CreateEvent}}},
+					},
+				},
+			},
+		},
+	}
+	router := NewRouter(nil, nil, logger).
+		AddBindings(config.BotBindings{Sources: []string{"k8s-events"}}).
+		BuildTable(cfg)
+
+	// when
+	err := router.RegisterNamespaceInformer(func(resource string, _ metaV1.ListOptions) (cache.SharedIndexInformer, error) {
+		t.Fatalf("informer for %q must not be registered", resource)
+		return nil, nil
+	})
+
+	// then
+	require.NoError(t, err)
+}
+
+func TestRouter_InformerListOptions(t *testing.T) {
+	tests := []struct {
+		name      string
+		resources map[string]config.Resource
+
+		expected metaV1.ListOptions
+	}{
+		{
+			name: "Same selectors in all sources",
+			resources: map[string]config.Resource{
+				"ingress":      {Type: "v1/pods", LabelSelector: "app=ingress", FieldSelector: "status.phase!=Running"},
+				"ingress-copy": {Type: "v1/pods", LabelSelector: "app=ingress", FieldSelector: "status.phase!=Running"},
+			},
+			expected: metaV1.ListOptions{LabelSelector: "app=ingress", FieldSelector: "status.phase!=Running"},
+		},
+		{
+			name: "Different selectors",
+			resources: map[string]config.Resource{
+				"ingress": {Type: "v1/pods", LabelSelector: "app=ingress"},
+				"all":     {Type: "v1/pods"},
+			},
+			expected: metaV1.ListOptions{},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// given
+			logger, _ := logtest.NewNullLogger()
+			cfg := &config.Config{Sources: map[string]config.Sources{}}
+			var bindings []string
+			for name, res := range tc.resources {
+				res.Event = config.KubernetesEvent{Types: []config.EventType{config.CreateEvent, config.DeleteEvent}}
+				cfg.Sources[name] = config.Sources{Kubernetes: config.KubernetesSource{Resources: []config.Resource{res}}}
+				bindings = append(bindings, name)
+			}
+			router := NewRouter(nil, nil, logger).
+				AddBindings(config.BotBindings{Sources: bindings}).
+				BuildTable(cfg)
+
+			// when
+			opts := router.informerListOptions("v1/pods")
+
+			// then
+			assert.Equal(t, tc.expected, opts)
+		})
+	}
+}
+
+func TestRoutesMatchingSelectors(t *testing.T) {
+	// given
+	logger, _ := logtest.NewNullLogger()
+	routes := []route{
+		{source: "all"},
+		{source: "ingress", labelSelector: "app=ingress"},
+		{source: "not-running", fieldSelector: "status.phase!=Running"},
+		{source: "ingress-on-node", labelSelector: "app in (ingress, gateway)", fieldSelector: "spec.nodeName=node-1,metadata.namespace=ingress"},
+		{source: "invalid", labelSelector: "app=="},
+	}
+
+	pod := &unstructured.Unstructured{Object: map[string]interface{}{
+		"spec":   map[string]interface{}{"nodeName": "node-1"},
+		"status": map[string]interface{}{"phase": "Running"},
+	}}
+	pod.SetName("ingress-1")
+	pod.SetNamespace("ingress")
+	pod.SetLabels(map[string]string{"app": "ingress"})
+
+	// when
+	out := routesMatchingSelectors(routes, pod, logger)
+
+	// then
+	var sources []string
+	for _, r := range out {
+		sources = append(sources, r.source)
+	}
+	assert.Equal(t, []string{"all", "ingress", "ingress-on-node"}, sources)
+}
+
+type fakeInformer struct {
+	cache.SharedIndexInformer
+	store cache.Store
+}
+
+func (f *fakeInformer) GetStore() cache.Store {
+	return f.store
+}
+
+func fixNamespace(name string, labels map[string]string) *unstructured.Unstructured {
+	ns := &unstructured.Unstructured{}
+	ns.SetAPIVersion("v1")
+	ns.SetKind("Namespace")
+	ns.SetName(name)
+	ns.SetLabels(labels)
+	return ns
+}
+
+func TestQualifySourcesForUpdate(t *testing.T) {
+	// given
+	logger, _ := logtest.NewNullLogger()
+	routes := []route{
+		{source: "all", namespaces: config.Namespaces{Include: []string{".*"}}},
+		{source: "image-diff", namespaces: config.Namespaces{Include: []string{".*"}}, updateSetting: config.UpdateSetting{Fields: []string{"spec.image"}, IncludeDiff: true}},
+		{source: "image-diff-copy", namespaces: config.Namespaces{Include: []string{".*"}}, updateSetting: config.UpdateSetting{Fields: []string{"spec.image"}, IncludeDiff: true}},
+		{source: "image-no-diff", namespaces: config.Namespaces{Include: []string{".*"}}, updateSetting: config.UpdateSetting{Fields: []string{"spec.image"}}},
+		{source: "replicas", namespaces: config.Namespaces{Include: []string{".*"}}, updateSetting: config.UpdateSetting{Fields: []string{"spec.replicas"}, IncludeDiff: true}},
+	}
+	oldObj := fixDeployment("nginx:1.21", 2)
+	newObj := fixDeployment("nginx:1.23", 2)
+
+	// when
+	sources, diffs, err := qualifySourcesForUpdate(context.Background(), newObj, oldObj, routes, logger, nil, nil, nil)
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, []string{"all", "image-diff", "image-diff-copy", "image-no-diff"}, sources)
+	assert.Equal(t, []string{"spec.image:\n\t-: nginx:1.21\n\t+: nginx:1.23\n"}, diffs)
+}
+
+func TestQualifySourcesForUpdateMissingFields(t *testing.T) {
+	// given
+	logger, _ := logtest.NewNullLogger()
+	routes := []route{
+		{source: "missing-then-image", namespaces: config.Namespaces{Include: []string{".*"}}, updateSetting: config.UpdateSetting{Fields: []string{"spec.strategy.type", "spec.image"}, IncludeDiff: true}},
+		{source: "image-then-missing", namespaces: config.Namespaces{Include: []string{".*"}}, updateSetting: config.UpdateSetting{Fields: []string{"spec.image", "metadata.labels.app"}, IncludeDiff: true}},
+		{source: "missing-only", namespaces: config.Namespaces{Include: []string{".*"}}, updateSetting: config.UpdateSetting{Fields: []string{"spec.strategy.type", "metadata.labels.app"}, IncludeDiff: true}},
+		{source: "missing-and-replicas", namespaces: config.Namespaces{Include: []string{".*"}}, updateSetting: config.UpdateSetting{Fields: []string{"spec.strategy.type", "spec.replicas"}, IncludeDiff: true}},
+	}
+	oldObj := fixDeployment("nginx:1.21", 2)
+	newObj := fixDeployment("nginx:1.23", 2)
+
+	// when
+	sources, diffs, err := qualifySourcesForUpdate(context.Background(), newObj, oldObj, routes, logger, nil, nil, nil)
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, []string{"missing-then-image", "image-then-missing"}, sources)
+	assert.Equal(t, []string{"spec.image:\n\t-: nginx:1.21\n\t+: nginx:1.23\n"}, diffs)
+}
+
+func fixDeployment(image string, replicas int64) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{
+		"spec": map[string]interface{}{"image": image, "replicas": replicas},
+	}}
+	obj.SetAPIVersion("apps/v1")
+	obj.SetKind("Deployment")
+	obj.SetName("nginx")
+	obj.SetNamespace("default")
+	return obj
+}
@@ -0,0 +1,325 @@
+package trivy
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/events"
+)
+
+const (
+	reportResource = "aquasecurity.github.io/v1alpha1/vulnerabilityreports"
+
+	// DefaultMinSeverity is used when the source doesn't specify the minimal severity of reported vulnerabilities.
+	DefaultMinSeverity = "HIGH"
+	// DefaultTopVulnerabilities is used when the source doesn't specify the number of vulnerabilities listed in the event.
+	DefaultTopVulnerabilities = 5
+
+	// Labels set by Trivy Operator on the reports, which point to the scanned workload.
+	resourceKindLabel      = "trivy-operator.resource.kind"
+	resourceNameLabel      = "trivy-operator.resource.name"
+	resourceNamespaceLabel = "trivy-operator.resource.namespace"
+	containerNameLabel     = "trivy-operator.container.name"
+
+	vulnerabilitiesFoundReason = "VulnerabilitiesFound"
+)
+
+var reportGVR = schema.GroupVersionResource{
+	Group:    "aquasecurity.github.io",
+	Version:  "v1alpha1",
+	Resource: "vulnerabilityreports",
+}
+
+// severities are ordered from the most severe one.
+var severities = []string{"CRITICAL", "HIGH", "MEDIUM", "LOW", "UNKNOWN"}
+
+// EventHandler handles events received from external sources.
+type EventHandler interface {
+	HandleExternalEvent(ctx context.Context, event events.Event, sources []string)
+}
+
+// Watcher watches Trivy Operator VulnerabilityReports and sends summarized events when new reports appear.
+type Watcher struct {
+	log          logrus.FieldLogger
+	clusterName  string
+	dynamicCli   dynamic.Interface
+	mapper       meta.RESTMapper
+	resyncPeriod time.Duration
+	sources      map[string]config.TrivySource
+	handler      EventHandler
+	startTime    time.Time
+}
+
+// VulnerabilityReport is the subset of the Trivy Operator VulnerabilityReport used for the events.
+type VulnerabilityReport struct {
+	Report struct {
+		Registry struct {
+			Server string `json:"server"`
+		} `json:"registry"`
+		Artifact struct {
+			Repository string `json:"repository"`
+			Tag        string `json:"tag"`
+		} `json:"artifact"`
+		Summary struct {
+			CriticalCount int `json:"criticalCount"`
+			HighCount     int `json:"highCount"`
+			MediumCount   int `json:"mediumCount"`
+			LowCount      int `json:"lowCount"`
+			UnknownCount  int `json:"unknownCount"`
+		} `json:"summary"`
+		Vulnerabilities []Vulnerability `json:"vulnerabilities"`
+	} `json:"report"`
+}
+
+// Vulnerability is a single vulnerability found in the scanned image.
+type Vulnerability struct {
+	VulnerabilityID  string   `json:"vulnerabilityID"`
+	Resource         string   `json:"resource"`
+	InstalledVersion string   `json:"installedVersion"`
+	FixedVersion     string   `json:"fixedVersion"`
+	Severity         string   `json:"severity"`
+	Title            string   `json:"title"`
+	Score            *float64 `json:"score,omitempty"`
+}
+
+// NewWatcher returns a new Watcher instance for the source bindings with enabled Trivy source.
+func NewWatcher(log logrus.FieldLogger, clusterName string, dynamicCli dynamic.Interface, mapper meta.RESTMapper, resyncPeriod time.Duration, sources map[string]config.Sources, handler EventHandler) *Watcher {
+	enabled := map[string]config.TrivySource{}
+	for name, src := range sources {
+		cfg := src.Trivy
+		if !cfg.Enabled {
+			continue
+		}
+		if cfg.MinSeverity == "" {
+			cfg.MinSeverity = DefaultMinSeverity
+		}
+		if cfg.TopVulnerabilities <= 0 {
+			cfg.TopVulnerabilities = DefaultTopVulnerabilities
+		}
+		enabled[name] = cfg
+	}
+
+	return &Watcher{
+		log:          log,
+		clusterName:  clusterName,
+		dynamicCli:   dynamicCli,
+		mapper:       mapper,
+		resyncPeriod: resyncPeriod,
+		sources:      enabled,
+		handler:      handler,
+	}
+}
+
+// Enabled returns true if any source binding has Trivy source enabled.
+func (w *Watcher) Enabled() bool {
+	return len(w.sources) > 0
+}
+
+// Start starts watching VulnerabilityReports. It blocks until the context is cancelled.
+func (w *Watcher) Start(ctx context.Context) error {
+	if _, err := w.mapper.ResourcesFor(reportGVR); err != nil {
+		w.log.Warnf("Trivy Operator VulnerabilityReport resource is not available in the cluster, skipping watching reports: %s", err.Error())
+		return nil
+	}
+
+	w.log.Info("Starting Trivy VulnerabilityReports watcher...")
+	w.startTime = time.Now()
+
+	factory := dynamicinformer.NewDynamicSharedInformerFactory(w.dynamicCli, w.resyncPeriod)
+	factory.ForResource(reportGVR).Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			if report, ok := obj.(*unstructured.Unstructured); ok {
+				w.handleReport(ctx, report)
+			}
+		},
+	})
+
+	factory.Start(ctx.Done())
+	<-ctx.Done()
+	return nil
+}
+
+func (w *Watcher) handleReport(ctx context.Context, obj *unstructured.Unstructured) {
+	if !obj.GetCreationTimestamp().Time.After(w.startTime) {
+		// reports listed on startup were already reported
+		return
+	}
+
+	var report VulnerabilityReport
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, &report); err != nil {
+		w.log.Errorf("while converting VulnerabilityReport %s/%s: %s", obj.GetNamespace(), obj.GetName(), err.Error())
+		return
+	}
+
+	// source bindings with the same settings get the same event
+	sourcesPerEvent := map[string][]string{}
+	cfgPerEvent := map[string]config.TrivySource{}
+	for name, src := range w.sources {
+		if src.Namespaces.IsConfigured() && !src.Namespaces.IsAllowed(obj.GetNamespace()) {
+			continue
+		}
+		if countAtLeast(report, src.MinSeverity) == 0 {
+			continue
+		}
+
+		key := fmt.Sprintf("%s/%d", strings.ToUpper(src.MinSeverity), src.TopVulnerabilities)
+		sourcesPerEvent[key] = append(sourcesPerEvent[key], name)
+		cfgPerEvent[key] = src
+	}
+
+	keys := make([]string, 0, len(sourcesPerEvent))
+	for key := range sourcesPerEvent {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		sources := sourcesPerEvent[key]
+		sort.Strings(sources)
+		w.handler.HandleExternalEvent(ctx, w.eventFor(obj, report, cfgPerEvent[key]), sources)
+	}
+}
+
+func (w *Watcher) eventFor(obj *unstructured.Unstructured, report VulnerabilityReport, cfg config.TrivySource) events.Event {
+	labels := obj.GetLabels()
+	kind, name := labels[resourceKindLabel], labels[resourceNameLabel]
+	namespace := labels[resourceNamespaceLabel]
+	if namespace == "" {
+		namespace = obj.GetNamespace()
+	}
+
+	workload := obj.GetName()
+	if kind != "" && name != "" {
+		workload = fmt.Sprintf("%s/%s", kind, name)
+	}
+
+	summary := report.Report.Summary
+	event := events.Event{
+		TypeMeta:  metaV1.TypeMeta{Kind: obj.GetKind(), APIVersion: obj.GetAPIVersion()},
+		Title:     fmt.Sprintf("Vulnerabilities found in %s", workload),
+		Name:      obj.GetName(),
+		Namespace: namespace,
+		Reason:    vulnerabilitiesFoundReason,
+		Cluster:   w.clusterName,
+		Resource:  reportResource,
+		TimeStamp: obj.GetCreationTimestamp().Time,
+		Object:    obj,
+	}
+	switch {
+	case summary.CriticalCount > 0:
+		event.Type, event.Level = config.ErrorEvent, config.Error
+	case summary.HighCount > 0:
+		event.Type, event.Level = config.WarningEvent, config.Warn
+	default:
+		event.Type, event.Level = config.InfoEvent, config.Info
+	}
+
+	if container := labels[containerNameLabel]; container != "" {
+		event.Messages = append(event.Messages, fmt.Sprintf("Container: %s", container))
+	}
+	if image := imageName(report); image != "" {
+		event.Messages = append(event.Messages, fmt.Sprintf("Image: %s", image))
+	}
+	event.Messages = append(event.Messages, fmt.Sprintf("Critical: %d, High: %d, Medium: %d, Low: %d, Unknown: %d",
+		summary.CriticalCount, summary.HighCount, summary.MediumCount, summary.LowCount, summary.UnknownCount))
+
+	for _, vuln := range topVulnerabilities(report.Report.Vulnerabilities, cfg.MinSeverity, cfg.TopVulnerabilities) {
+		msg := fmt.Sprintf("%s (%s) in %s %s", vuln.VulnerabilityID, vuln.Severity, vuln.Resource, vuln.InstalledVersion)
+		if vuln.FixedVersion != "" {
+			msg = fmt.Sprintf("%s, fixed in %s", msg, vuln.FixedVersion)
+		}
+		if vuln.Title != "" {
+			msg = fmt.Sprintf("%s: %s", msg, vuln.Title)
+		}
+		event.Messages = append(event.Messages, msg)
+	}
+
+	event.SuggestedCommands = []string{
+		fmt.Sprintf("kubectl describe vulnerabilityreport %s --namespace %s", obj.GetName(), obj.GetNamespace()),
+	}
+	return event
+}
+
+// topVulnerabilities returns the most severe vulnerabilities, starting from the ones with the highest score.
+func topVulnerabilities(in []Vulnerability, minSeverity string, limit int) []Vulnerability {
+	minRank := severityRank(minSeverity)
+
+	var out []Vulnerability
+	for _, vuln := range in {
+		if severityRank(vuln.Severity) <= minRank {
+			out = append(out, vuln)
+		}
+	}
+
+	sort.SliceStable(out, func(i, j int) bool {
+		iRank, jRank := severityRank(out[i].Severity), severityRank(out[j].Severity)
+		if iRank != jRank {
+			return iRank < jRank
+		}
+		return score(out[i]) > score(out[j])
+	})
+
+	if len(out) > limit {
+		out = out[:limit]
+	}
+	return out
+}
+
+// countAtLeast returns the number of vulnerabilities with a given or higher severity.
+func countAtLeast(report VulnerabilityReport, minSeverity string) int {
+	summary := report.Report.Summary
+	counts := []int{summary.CriticalCount, summary.HighCount, summary.MediumCount, summary.LowCount, summary.UnknownCount}
+
+	out := 0
+	for idx := 0; idx <= severityRank(minSeverity) && idx < len(counts); idx++ {
+		out += counts[idx]
+	}
+	return out
+}
+
+// severityRank returns the severity index, where zero is the most severe one. Unknown severities have the lowest rank.
+func severityRank(severity string) int {
+	for idx, item := range severities {
+		if strings.EqualFold(item, severity) {
+			return idx
+		}
+	}
+	return len(severities) - 1
+}
+
+func score(vuln Vulnerability) float64 {
+	if vuln.Score == nil {
+		return 0
+	}
+	return *vuln.Score
+}
+
+func imageName(report VulnerabilityReport) string {
+	artifact := report.Report.Artifact
+	if artifact.Repository == "" {
+		return ""
+	}
+
+	image := artifact.Repository
+	if server := report.Report.Registry.Server; server != "" {
+		image = fmt.Sprintf("%s/%s", server, image)
+	}
+	if artifact.Tag != "" {
+		image = fmt.Sprintf("%s:%s", image, artifact.Tag)
+	}
+	return image
+}
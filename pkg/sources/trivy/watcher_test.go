@@ -0,0 +1,158 @@
+package trivy
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	logtest "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/events"
+)
+
+var fixStartTime = time.Date(2022, 10, 10, 10, 0, 0, 0, time.UTC)
+
+func TestWatcherHandleReport(t *testing.T) {
+	// given
+	handler := &fakeEventHandler{}
+	watcher := fixWatcher(handler)
+	report := fixReport(fixStartTime.Add(time.Minute), map[string]interface{}{
+		"criticalCount": int64(1),
+		"highCount":     int64(2),
+		"mediumCount":   int64(1),
+	}, []interface{}{
+		fixVulnerability("CVE-2022-0003", "MEDIUM", 5.5),
+		fixVulnerability("CVE-2022-0002", "HIGH", 7.5),
+		fixVulnerability("CVE-2022-0004", "HIGH", int64(8)),
+		fixVulnerability("CVE-2022-0001", "CRITICAL", 9.8),
+	})
+
+	// when
+	watcher.handleReport(context.Background(), report)
+
+	// then
+	require.Len(t, handler.events, 2)
+	assert.Equal(t, [][]string{{"critical-only"}, {"all", "default-ns"}}, handler.sources)
+
+	critical := handler.events[0]
+	assert.Equal(t, []string{
+		"Container: nginx",
+		"Image: index.docker.io/library/nginx:1.21",
+		"Critical: 1, High: 2, Medium: 1, Low: 0, Unknown: 0",
+		"CVE-2022-0001 (CRITICAL) in openssl 1.1.1n, fixed in 1.1.1q: Buffer overflow",
+	}, critical.Messages)
+
+	event := handler.events[1]
+	assert.Equal(t, "Vulnerabilities found in ReplicaSet/nginx-6d4cf56db6", event.Title)
+	assert.Equal(t, "VulnerabilityReport", event.Kind)
+	assert.Equal(t, "default", event.Namespace)
+	assert.Equal(t, config.ErrorEvent, event.Type)
+	assert.Equal(t, config.Error, event.Level)
+	assert.Equal(t, []string{
+		"Container: nginx",
+		"Image: index.docker.io/library/nginx:1.21",
+		"Critical: 1, High: 2, Medium: 1, Low: 0, Unknown: 0",
+		"CVE-2022-0001 (CRITICAL) in openssl 1.1.1n, fixed in 1.1.1q: Buffer overflow",
+		"CVE-2022-0004 (HIGH) in openssl 1.1.1n, fixed in 1.1.1q: Buffer overflow",
+	}, event.Messages)
+	assert.Equal(t, []string{
+		"kubectl describe vulnerabilityreport replicaset-nginx-6d4cf56db6-nginx --namespace default",
+	}, event.SuggestedCommands)
+}
+
+func TestWatcherHandleReportSkipped(t *testing.T) {
+	tests := []struct {
+		name      string
+		createdAt time.Time
+		summary   map[string]interface{}
+	}{
+		{
+			name:      "Report created before start",
+			createdAt: fixStartTime.Add(-time.Minute),
+			summary:   map[string]interface{}{"criticalCount": int64(1)},
+		},
+		{
+			name:      "Only low severity vulnerabilities",
+			createdAt: fixStartTime.Add(time.Minute),
+			summary:   map[string]interface{}{"lowCount": int64(10)},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// given
+			handler := &fakeEventHandler{}
+			watcher := fixWatcher(handler)
+
+			// when
+			watcher.handleReport(context.Background(), fixReport(tc.createdAt, tc.summary, nil))
+
+			// then
+			assert.Empty(t, handler.events)
+		})
+	}
+}
+
+type fakeEventHandler struct {
+	events  []events.Event
+	sources [][]string
+}
+
+func (f *fakeEventHandler) HandleExternalEvent(_ context.Context, event events.Event, sources []string) {
+	f.events = append(f.events, event)
+	f.sources = append(f.sources, sources)
+}
+
+func fixWatcher(handler EventHandler) *Watcher {
+	logger, _ := logtest.NewNullLogger()
+	watcher := NewWatcher(logger, "dev", nil, nil, 0, map[string]config.Sources{
+		"all":           {Trivy: config.TrivySource{Enabled: true, TopVulnerabilities: 2}},
+		"default-ns":    {Trivy: config.TrivySource{Enabled: true, TopVulnerabilities: 2, Namespaces: config.Namespaces{Include: []string{"default"}}}},
+		"critical-only": {Trivy: config.TrivySource{Enabled: true, MinSeverity: "CRITICAL"}},
+		"other-ns":      {Trivy: config.TrivySource{Enabled: true, Namespaces: config.Namespaces{Include: []string{"prod"}}}},
+		"disabled":      {},
+	}, handler)
+	watcher.startTime = fixStartTime
+	return watcher
+}
+
+func fixReport(createdAt time.Time, summary map[string]interface{}, vulnerabilities []interface{}) *unstructured.Unstructured {
+	report := map[string]interface{}{
+		"registry": map[string]interface{}{"server": "index.docker.io"},
+		"artifact": map[string]interface{}{"repository": "library/nginx", "tag": "1.21"},
+		"summary":  summary,
+	}
+	if vulnerabilities != nil {
+		report["vulnerabilities"] = vulnerabilities
+	}
+
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{"report": report}}
+	obj.SetAPIVersion("aquasecurity.github.io/v1alpha1")
+	obj.SetKind("VulnerabilityReport")
+	obj.SetName("replicaset-nginx-6d4cf56db6-nginx")
+	obj.SetNamespace("default")
+	obj.SetCreationTimestamp(metaV1.NewTime(createdAt))
+	obj.SetLabels(map[string]string{
+		resourceKindLabel:      "ReplicaSet",
+		resourceNameLabel:      "nginx-6d4cf56db6",
+		resourceNamespaceLabel: "default",
+		containerNameLabel:     "nginx",
+	})
+	return obj
+}
+
+func fixVulnerability(id, severity string, score interface{}) interface{} {
+	return map[string]interface{}{
+		"vulnerabilityID":  id,
+		"resource":         "openssl",
+		"installedVersion": "1.1.1n",
+		"fixedVersion":     "1.1.1q",
+		"severity":         severity,
+		"title":            "Buffer overflow",
+		"score":            score,
+	}
+}
@@ -0,0 +1,241 @@
+package velero
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/events"
+)
+
+const (
+	phaseCompleted        = "Completed"
+	phasePartiallyFailed  = "PartiallyFailed"
+	phaseFailed           = "Failed"
+	phaseFailedValidation = "FailedValidation"
+
+	// scheduleLabel is set by Velero on Backups created by a Schedule.
+	scheduleLabel = "velero.io/schedule-name"
+)
+
+// kind describes a watched Velero resource.
+type kind struct {
+	Name     string
+	GVR      schema.GroupVersionResource
+	Resource string
+	// Command is the velero CLI command for the resource, e.g. `backup`.
+	Command string
+}
+
+var (
+	backupKind = kind{
+		Name:     "Backup",
+		GVR:      schema.GroupVersionResource{Group: "velero.io", Version: "v1", Resource: "backups"},
+		Resource: "velero.io/v1/backups",
+		Command:  "backup",
+	}
+	restoreKind = kind{
+		Name:     "Restore",
+		GVR:      schema.GroupVersionResource{Group: "velero.io", Version: "v1", Resource: "restores"},
+		Resource: "velero.io/v1/restores",
+		Command:  "restore",
+	}
+)
+
+// phaseLevels holds the final phases, which are notified, with the event type and level.
+var phaseLevels = map[string]struct {
+	Type  config.EventType
+	Level config.Level
+	Verb  string
+}{
+	phaseCompleted:        {Type: config.InfoEvent, Level: config.Info, Verb: "completed"},
+	phasePartiallyFailed:  {Type: config.WarningEvent, Level: config.Warn, Verb: "partially failed"},
+	phaseFailed:           {Type: config.ErrorEvent, Level: config.Error, Verb: "failed"},
+	phaseFailedValidation: {Type: config.ErrorEvent, Level: config.Error, Verb: "failed validation"},
+}
+
+// EventHandler handles events received from external sources.
+type EventHandler interface {
+	HandleExternalEvent(ctx context.Context, event events.Event, sources []string)
+}
+
+// Watcher watches Velero Backups and Restores, and sends events when they complete, partially fail, or fail.
+type Watcher struct {
+	log          logrus.FieldLogger
+	clusterName  string
+	dynamicCli   dynamic.Interface
+	mapper       meta.RESTMapper
+	resyncPeriod time.Duration
+	sources      []string
+	handler      EventHandler
+}
+
+// NewWatcher returns a new Watcher instance for the source bindings with enabled Velero source.
+func NewWatcher(log logrus.FieldLogger, clusterName string, dynamicCli dynamic.Interface, mapper meta.RESTMapper, resyncPeriod time.Duration, sources map[string]config.Sources, handler EventHandler) *Watcher {
+	var enabled []string
+	for name, src := range sources {
+		if src.Velero.Enabled {
+			enabled = append(enabled, name)
+		}
+	}
+	sort.Strings(enabled)
+
+	return &Watcher{
+		log:          log,
+		clusterName:  clusterName,
+		dynamicCli:   dynamicCli,
+		mapper:       mapper,
+		resyncPeriod: resyncPeriod,
+		sources:      enabled,
+		handler:      handler,
+	}
+}
+
+// Enabled returns true if any source binding has Velero source enabled.
+func (w *Watcher) Enabled() bool {
+	return len(w.sources) > 0
+}
+
+// Start starts watching Velero Backups and Restores. It blocks until the context is cancelled.
+func (w *Watcher) Start(ctx context.Context) error {
+	if _, err := w.mapper.ResourcesFor(backupKind.GVR); err != nil {
+		w.log.Warnf("Velero Backup resource is not available in the cluster, skipping watching Backups and Restores: %s", err.Error())
+		return nil
+	}
+
+	w.log.Info("Starting Velero watcher...")
+	factory := dynamicinformer.NewDynamicSharedInformerFactory(w.dynamicCli, w.resyncPeriod)
+	for _, k := range []kind{backupKind, restoreKind} {
+		k := k
+		factory.ForResource(k.GVR).Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+			UpdateFunc: func(oldObj, newObj interface{}) {
+				w.handleUpdate(ctx, k, oldObj, newObj)
+			},
+		})
+	}
+
+	factory.Start(ctx.Done())
+	<-ctx.Done()
+	return nil
+}
+
+func (w *Watcher) handleUpdate(ctx context.Context, k kind, oldObj, newObj interface{}) {
+	oldRes, ok := oldObj.(*unstructured.Unstructured)
+	if !ok {
+		return
+	}
+	newRes, ok := newObj.(*unstructured.Unstructured)
+	if !ok {
+		return
+	}
+
+	oldPhase, newPhase := phase(oldRes), phase(newRes)
+	if oldPhase == newPhase {
+		return
+	}
+	if _, final := phaseLevels[newPhase]; !final {
+		return
+	}
+
+	w.handler.HandleExternalEvent(ctx, w.eventFor(k, newRes), w.sources)
+}
+
+func (w *Watcher) eventFor(k kind, obj *unstructured.Unstructured) events.Event {
+	str := func(fields ...string) string {
+		val, _, _ := unstructured.NestedString(obj.Object, fields...)
+		return val
+	}
+	num := func(fields ...string) int64 {
+		val, _, _ := unstructured.NestedInt64(obj.Object, fields...)
+		return val
+	}
+
+	current := phase(obj)
+	level := phaseLevels[current]
+	name, namespace := obj.GetName(), obj.GetNamespace()
+
+	event := events.Event{
+		TypeMeta:  metaV1.TypeMeta{Kind: k.Name, APIVersion: k.GVR.GroupVersion().String()},
+		Title:     fmt.Sprintf("Velero %s %s %s", k.Name, name, level.Verb),
+		Name:      name,
+		Namespace: namespace,
+		Type:      level.Type,
+		Reason:    current,
+		Level:     level.Level,
+		Cluster:   w.clusterName,
+		Resource:  k.Resource,
+		TimeStamp: time.Now(),
+		Object:    obj,
+	}
+
+	if schedule := obj.GetLabels()[scheduleLabel]; schedule != "" {
+		event.Messages = append(event.Messages, fmt.Sprintf("Schedule: %s", schedule))
+	}
+	if backup := str("spec", "backupName"); backup != "" {
+		event.Messages = append(event.Messages, fmt.Sprintf("Backup: %s", backup))
+	}
+	if duration, ok := duration(str("status", "startTimestamp"), str("status", "completionTimestamp")); ok {
+		event.Messages = append(event.Messages, fmt.Sprintf("Duration: %s", duration))
+	}
+	if total := num("status", "progress", "totalItems"); total > 0 {
+		event.Messages = append(event.Messages, fmt.Sprintf("Items backed up: %d of %d", num("status", "progress", "itemsBackedUp"), total))
+	}
+	if errs, warnings := num("status", "errors"), num("status", "warnings"); errs > 0 || warnings > 0 {
+		event.Messages = append(event.Messages, fmt.Sprintf("Errors: %d, warnings: %d", errs, warnings))
+	}
+	if reason := str("status", "failureReason"); reason != "" {
+		event.Messages = append(event.Messages, fmt.Sprintf("Failure reason: %s", reason))
+	}
+	if validationErrs, _, _ := unstructured.NestedStringSlice(obj.Object, "status", "validationErrors"); len(validationErrs) > 0 {
+		event.Messages = append(event.Messages, fmt.Sprintf("Validation errors: %s", strings.Join(validationErrs, "; ")))
+	}
+
+	event.Buttons = []events.Button{
+		{
+			Name:    fmt.Sprintf("Describe %s", strings.ToLower(k.Name)),
+			Command: fmt.Sprintf("velero describe %s %s --details --namespace %s", k.Command, name, namespace),
+		},
+	}
+	if current != phaseCompleted {
+		event.Buttons = append(event.Buttons, events.Button{
+			Name:    "Show logs",
+			Command: fmt.Sprintf("velero %s logs %s --namespace %s", k.Command, name, namespace),
+		})
+	}
+
+	return event
+}
+
+func phase(obj *unstructured.Unstructured) string {
+	val, _, _ := unstructured.NestedString(obj.Object, "status", "phase")
+	return val
+}
+
+func duration(start, end string) (time.Duration, bool) {
+	if start == "" || end == "" {
+		return 0, false
+	}
+
+	startTime, err := time.Parse(time.RFC3339, start)
+	if err != nil {
+		return 0, false
+	}
+	endTime, err := time.Parse(time.RFC3339, end)
+	if err != nil {
+		return 0, false
+	}
+
+	return endTime.Sub(startTime).Round(time.Second), true
+}
@@ -0,0 +1,193 @@
+package velero
+
+import (
+	"context"
+	"testing"
+
+	logtest "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/events"
+)
+
+func TestWatcherHandleUpdate(t *testing.T) {
+	tests := []struct {
+		name     string
+		kind     kind
+		oldPhase string
+		newPhase string
+		status   map[string]interface{}
+		spec     map[string]interface{}
+
+		expectedTitle    string
+		expectedType     config.EventType
+		expectedLevel    config.Level
+		expectedMessages []string
+		expectedButtons  []events.Button
+	}{
+		{
+			name:     "Backup completed",
+			kind:     backupKind,
+			oldPhase: "InProgress",
+			newPhase: "Completed",
+			status: map[string]interface{}{
+				"startTimestamp":      "2022-10-10T10:00:00Z",
+				"completionTimestamp": "2022-10-10T10:02:30Z",
+				"progress":            map[string]interface{}{"itemsBackedUp": int64(42), "totalItems": int64(42)},
+			},
+			expectedTitle: "Velero Backup daily-20221010 completed",
+			expectedType:  config.InfoEvent,
+			expectedLevel: config.Info,
+			expectedMessages: []string{
+				"Schedule: daily",
+				"Duration: 2m30s",
+				"Items backed up: 42 of 42",
+			},
+			expectedButtons: []events.Button{
+				{Name: "Describe backup", Command: "velero describe backup daily-20221010 --details --namespace velero"},
+			},
+		},
+		{
+			name:     "Backup partially failed",
+			kind:     backupKind,
+			oldPhase: "InProgress",
+			newPhase: "PartiallyFailed",
+			status: map[string]interface{}{
+				"errors":   int64(2),
+				"warnings": int64(1),
+			},
+			expectedTitle: "Velero Backup daily-20221010 partially failed",
+			expectedType:  config.WarningEvent,
+			expectedLevel: config.Warn,
+			expectedMessages: []string{
+				"Schedule: daily",
+				"Errors: 2, warnings: 1",
+			},
+			expectedButtons: []events.Button{
+				{Name: "Describe backup", Command: "velero describe backup daily-20221010 --details --namespace velero"},
+				{Name: "Show logs", Command: "velero backup logs daily-20221010 --namespace velero"},
+			},
+		},
+		{
+			name:     "Restore failed validation",
+			kind:     restoreKind,
+			oldPhase: "New",
+			newPhase: "FailedValidation",
+			spec:     map[string]interface{}{"backupName": "daily-20221009"},
+			status: map[string]interface{}{
+				"validationErrors": []interface{}{"backup not found"},
+			},
+			expectedTitle: "Velero Restore daily-20221010 failed validation",
+			expectedType:  config.ErrorEvent,
+			expectedLevel: config.Error,
+			expectedMessages: []string{
+				"Schedule: daily",
+				"Backup: daily-20221009",
+				"Validation errors: backup not found",
+			},
+			expectedButtons: []events.Button{
+				{Name: "Describe restore", Command: "velero describe restore daily-20221010 --details --namespace velero"},
+				{Name: "Show logs", Command: "velero restore logs daily-20221010 --namespace velero"},
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// given
+			handler := &fakeEventHandler{}
+			watcher := fixWatcher(handler)
+			oldObj := fixResource(tc.kind, map[string]interface{}{"phase": tc.oldPhase}, tc.spec)
+			status := map[string]interface{}{"phase": tc.newPhase}
+			for key, val := range tc.status {
+				status[key] = val
+			}
+			newObj := fixResource(tc.kind, status, tc.spec)
+
+			// when
+			watcher.handleUpdate(context.Background(), tc.kind, oldObj, newObj)
+
+			// then
+			require.Len(t, handler.events, 1)
+			assert.Equal(t, [][]string{{"backups"}}, handler.sources)
+
+			event := handler.events[0]
+			assert.Equal(t, tc.kind.Name, event.Kind)
+			assert.Equal(t, tc.expectedTitle, event.Title)
+			assert.Equal(t, tc.newPhase, event.Reason)
+			assert.Equal(t, tc.expectedType, event.Type)
+			assert.Equal(t, tc.expectedLevel, event.Level)
+			assert.Equal(t, tc.expectedMessages, event.Messages)
+			assert.Equal(t, tc.expectedButtons, event.Buttons)
+		})
+	}
+}
+
+func TestWatcherHandleUpdateSkipped(t *testing.T) {
+	tests := []struct {
+		name     string
+		oldPhase string
+		newPhase string
+	}{
+		{
+			name:     "Backup started",
+			oldPhase: "New",
+			newPhase: "InProgress",
+		},
+		{
+			name:     "Resync of completed Backup",
+			oldPhase: "Completed",
+			newPhase: "Completed",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// given
+			handler := &fakeEventHandler{}
+			watcher := fixWatcher(handler)
+			oldObj := fixResource(backupKind, map[string]interface{}{"phase": tc.oldPhase}, nil)
+			newObj := fixResource(backupKind, map[string]interface{}{"phase": tc.newPhase}, nil)
+
+			// when
+			watcher.handleUpdate(context.Background(), backupKind, oldObj, newObj)
+
+			// then
+			assert.Empty(t, handler.events)
+		})
+	}
+}
+
+type fakeEventHandler struct {
+	events  []events.Event
+	sources [][]string
+}
+
+func (f *fakeEventHandler) HandleExternalEvent(_ context.Context, event events.Event, sources []string) {
+	f.events = append(f.events, event)
+	f.sources = append(f.sources, sources)
+}
+
+func fixWatcher(handler EventHandler) *Watcher {
+	logger, _ := logtest.NewNullLogger()
+	return NewWatcher(logger, "dev", nil, nil, 0, map[string]config.Sources{
+		"backups":  {Velero: config.VeleroSource{Enabled: true}},
+		"disabled": {},
+	}, handler)
+}
+
+func fixResource(k kind, status, spec map[string]interface{}) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{
+		"status": status,
+	}}
+	if spec != nil {
+		obj.Object["spec"] = spec
+	}
+	obj.SetAPIVersion(k.GVR.GroupVersion().String())
+	obj.SetKind(k.Name)
+	obj.SetName("daily-20221010")
+	obj.SetNamespace("velero")
+	obj.SetLabels(map[string]string{scheduleLabel: "daily"})
+	return obj
+}
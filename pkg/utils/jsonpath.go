@@ -9,13 +9,23 @@ import (
 )
 
 func parseJsonpath(obj interface{}, jsonpathStr string) (string, error) {
+	return findJsonpath(obj, jsonpathStr, false)
+}
+
+// ParseJsonpathAllowMissingKeys returns the comma-separated values found in a given object
+// with a relaxed JSONPath expression, such as `status.phase`. It returns "<none>" if the values are missing.
+func ParseJsonpathAllowMissingKeys(obj interface{}, jsonpathStr string) (string, error) {
+	return findJsonpath(obj, jsonpathStr, true)
+}
+
+func findJsonpath(obj interface{}, jsonpathStr string, allowMissingKeys bool) (string, error) {
 	// Parse and print jsonpath
 	fields, err := get.RelaxedJSONPathExpression(jsonpathStr)
 	if err != nil {
 		return "", err
 	}
 
-	j := jsonpath.New("jsonpath")
+	j := jsonpath.New("jsonpath").AllowMissingKeys(allowMissingKeys)
 	if err := j.Parse(fields); err != nil {
 		return "", err
 	}
@@ -114,9 +114,10 @@ func ExtractAnnotationsFromEvent(ctx context.Context, dynamicCli dynamic.Interfa
 	return annotations.GetAnnotations(), nil
 }
 
-// GetClusterNameFromKubectlCmd this will return cluster name from kubectl command
+// GetClusterNameFromKubectlCmd this will return cluster name from kubectl command.
+// Both `--cluster-name` and its `--cluster` alias are supported.
 func GetClusterNameFromKubectlCmd(cmd string) string {
-	r, _ := regexp.Compile(`--cluster-name[=|' ']([^\s]*)`)
+	r, _ := regexp.Compile(`--cluster(?:-name)?[=|' ']([^\s]*)`)
 	//this gives 2 match with cluster name and without
 	matchedArray := r.FindStringSubmatch(cmd)
 	var s string
@@ -24,6 +24,9 @@ func TestGetClusterNameFromKubectlCmd(t *testing.T) {
 		{input: "--cluster-name=", expected: ""},
 		{input: "", expected: ""},
 		{input: "--cluster-nameminikube1", expected: ""},
+		{input: "get pods --cluster=prod", expected: "prod"},
+		{input: "--cluster prod -n default", expected: "prod"},
+		{input: "create rolebinding view --clusterrole=view", expected: ""},
 	}
 
 	for _, ts := range tests {